@@ -30,6 +30,8 @@ func NewCreateCommand() *cobra.Command {
 	}
 
 	cmd.Flags().IntVarP(&opts.Machines, "machines", "m", 1, "Number of machines to create.")
+	cmd.Flags().BoolVar(&opts.FaultInjection, "unsafe-fault-injection", false,
+		"Run the machine daemons with fault injection enabled to test failures with 'uc machine fault'.")
 
 	return cmd
 }
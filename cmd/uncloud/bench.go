@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/psviderski/uncloud/internal/bench"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/spf13/cobra"
+)
+
+// NewBenchCommand creates a new hidden command to benchmark the cluster components on a simulated cluster.
+func NewBenchCommand() *cobra.Command {
+	opts := bench.Options{}
+	var verbose bool
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark scheduling, state propagation, and DNS on a simulated cluster.",
+		Long: `Benchmark scheduling, state propagation, and DNS on a simulated cluster.
+
+The command simulates a cluster of virtual machines running many services in-process. It uses the same
+scheduler, cluster store, and DNS resolver code as the machine daemon but doesn't need real machines or
+a running cluster. Use it to catch performance regressions before they affect large clusters.`,
+		Example: `  # Benchmark a cluster with 50 machines running 500 services with 3 replicas each.
+  uc bench --machines 50 --services 500 --replicas 3`,
+		Hidden:            true,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !verbose {
+				// The simulated components log every change which would flood the output.
+				slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+			}
+
+			fmt.Printf("Simulating %d machines running %d services with %d replicas each...\n\n",
+				opts.Machines, opts.Services, opts.Replicas)
+			results, err := bench.Run(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+
+			t := tui.NewTable()
+			t.Headers("BENCHMARK", "OPS", "TOTAL", "P50", "P95", "P99", "MAX")
+			for _, r := range results {
+				t.Row(
+					r.Name,
+					fmt.Sprintf("%d", len(r.Samples)),
+					r.Total.String(),
+					r.Percentile(50).String(),
+					r.Percentile(95).String(),
+					r.Percentile(99).String(),
+					r.Max().String(),
+				)
+			}
+			fmt.Println(t)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Machines, "machines", 50, "Number of virtual machines in the simulated cluster.")
+	cmd.Flags().IntVar(&opts.Services, "services", 200, "Number of services deployed to the simulated cluster.")
+	cmd.Flags().IntVar(&opts.Replicas, "replicas", 3, "Number of containers of each service.")
+	cmd.Flags().IntVar(&opts.DNSQueries, "dns-queries", bench.DefaultDNSQueries,
+		"Number of DNS lookups in the DNS benchmark.")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show the logs of the simulated components.")
+
+	return cmd
+}
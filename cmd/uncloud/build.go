@@ -26,7 +26,12 @@ func NewBuildCommand() *cobra.Command {
 		Long: `Build images for services from a Compose file using local Docker.
 
 By default, built images remain on the local Docker host. Use --push to upload them
-to cluster machines or --push-registry to upload them to external registries.`,
+to cluster machines or --push-registry to upload them to external registries.
+
+Use --builder to build the images with Docker on a cluster machine instead, for example, if Docker isn't
+available locally or the machines have a different platform. The CLI connects to Docker on the machine over SSH
+using the machine connection from the cluster context. With --push, the images are uploaded from the builder
+to other machines over the cluster network.`,
 		Example: `  # Build all services that have a build section in compose.yaml.
   uc build
 
@@ -39,9 +44,15 @@ to cluster machines or --push-registry to upload them to external registries.`,
   # Build services and push images to specific machines.
   uc build --push -m machine1,machine2
 
+  # Build services on machine1 and push images from it to all cluster machines.
+  uc build --builder machine1 --push
+
   # Build services and push images to external registries (e.g., Docker Hub).
   uc build --push-registry
 
+  # Build services reusing layers from a shared registry cache and update the cache after building.
+  uc build --cache-repo registry.example.com/myapp/cache
+
   # Build services with build arguments, pull newer base images before building, and don't use cache.
   uc build --build-arg NODE_VERSION=24 --build-arg ENV=production --no-cache --pull`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -59,6 +70,13 @@ to cluster machines or --push-registry to upload them to external registries.`,
 	cmd.Flags().StringArrayVar(&opts.BuildArgs, "build-arg", nil,
 		"Set a build-time variable for services. Used in Dockerfiles that declare the variable with ARG.\n"+
 			"Can be specified multiple times. Format: --build-arg VAR=VALUE")
+	cmd.Flags().StringVar(&opts.Builder, "builder", "",
+		"Name or ID of a cluster machine to build the images on using its Docker over SSH.\n"+
+			"The machine must be reachable over an SSH connection in the cluster context. (default is local Docker)")
+	cmd.Flags().StringVar(&opts.CacheRepo, "cache-repo", "",
+		"Registry repository to use as a shared build cache, e.g. registry.example.com/myapp/cache.\n"+
+			"Layers are imported from and exported to it so builds on other machines can reuse them. "+
+			"Services with build.cache_from or build.cache_to keep their own cache configuration.")
 	cmd.Flags().BoolVar(&opts.Check, "check", false,
 		"Check the build configuration for services without building them.")
 	cmd.Flags().BoolVar(&opts.Deps, "deps", false,
@@ -80,6 +98,7 @@ to cluster machines or --push-registry to upload them to external registries.`,
 	cmd.Flags().BoolVar(&opts.PushRegistry, "push-registry", false,
 		"Upload the built images to external registries (e.g., Docker Hub) after building.")
 
+	completion.BuilderFlag(cmd)
 	completion.MachinesFlag(cmd)
 
 	return cmd
@@ -101,7 +120,7 @@ func runBuild(ctx context.Context, uncli *cli.CLI, opts buildOptions) error {
 
 	project, err := compose.LoadProject(ctx, opts.files, composecli.WithDefaultProfiles(opts.profiles...))
 	if err != nil {
-		return fmt.Errorf("load compose file(s): %w", err)
+		return cli.WithExitCode(cli.ExitCodeValidation, fmt.Errorf("load compose file(s): %w", err))
 	}
 
 	uncli.SetClusterContextIfUnset(compose.ClusterContext(project))
@@ -0,0 +1,100 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/filters"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+type pruneOptions struct {
+	all         bool
+	keepStorage string
+	until       time.Duration
+}
+
+func NewPruneCommand() *cobra.Command {
+	opts := pruneOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove the build cache of the local Docker builder.",
+		Long: `Remove the build cache of the local Docker builder used by 'uc build' and 'uc deploy'.
+By default, only dangling cache records are removed. Use --all to remove all unused build cache.
+A shared registry cache configured with --cache-repo is not affected.`,
+		Example: `  # Remove dangling build cache.
+  uc builder prune
+
+  # Remove all unused build cache without confirmation.
+  uc builder prune --all --yes
+
+  # Remove build cache older than a week but keep at most 10GB.
+  uc builder prune --until 168h --keep-storage 10GB`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return prune(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.all, "all", "a", false,
+		"Remove all unused build cache, not just dangling records.")
+	cmd.Flags().StringVar(&opts.keepStorage, "keep-storage", "",
+		"Amount of disk space to keep for the build cache, e.g. 10GB. (default is to remove all matching cache)")
+	cmd.Flags().DurationVar(&opts.until, "until", 0,
+		"Only remove build cache not used for longer than this duration, e.g. 24h.")
+
+	return cmd
+}
+
+func prune(ctx context.Context, uncli *cli.CLI, opts pruneOptions) error {
+	pruneOpts := build.CachePruneOptions{
+		All:     opts.all,
+		Filters: filters.NewArgs(),
+	}
+	if opts.keepStorage != "" {
+		size, err := units.RAMInBytes(opts.keepStorage)
+		if err != nil {
+			return fmt.Errorf("invalid --keep-storage value '%s': %w", opts.keepStorage, err)
+		}
+		pruneOpts.ReservedSpace = size
+		// Older Docker API versions only support the deprecated KeepStorage option.
+		pruneOpts.KeepStorage = size
+	}
+	if opts.until > 0 {
+		pruneOpts.Filters.Add("until", opts.until.String())
+	}
+
+	title := "Remove dangling build cache?"
+	if opts.all {
+		title = "Remove all unused build cache?"
+	}
+	confirmed, err := uncli.Confirm(title)
+	if err != nil {
+		return fmt.Errorf("confirm: %w", err)
+	}
+	if !confirmed {
+		return cli.Cancelled("Build cache prune cancelled. No build cache was removed.")
+	}
+
+	dockerCli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("create Docker client: %w", err)
+	}
+	defer dockerCli.Close()
+
+	report, err := dockerCli.BuildCachePrune(ctx, pruneOpts)
+	if err != nil {
+		return fmt.Errorf("prune build cache: %w", err)
+	}
+
+	fmt.Printf("Removed %d build cache records. Total reclaimed space: %s\n",
+		len(report.CachesDeleted), units.HumanSize(float64(report.SpaceReclaimed)))
+	return nil
+}
@@ -0,0 +1,20 @@
+package builder
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "builder",
+		Short: "Manage the build cache used by 'uc build'.",
+		Long: "Manage the build cache used by 'uc build'.\n" +
+			"Images are built with the local Docker BuildKit builder. Its cache keeps layers from previous builds " +
+			"to speed up rebuilds. Use 'uc build --cache-repo' to also share the cache between machines through " +
+			"a registry.",
+	}
+	cmd.AddCommand(
+		NewPruneCommand(),
+	)
+	return cmd
+}
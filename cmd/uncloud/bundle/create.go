@@ -0,0 +1,213 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/psviderski/uncloud/internal/bundle"
+	"github.com/psviderski/uncloud/internal/selfupdate"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/scripts"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	arch       string
+	caddyImage string
+	images     []string
+	output     string
+	version    string
+}
+
+func NewCreateCommand() *cobra.Command {
+	opts := createOptions{}
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a bundle for installing Uncloud on machines without internet access.",
+		Long: `Create a bundle for installing Uncloud on machines without internet access.
+
+Run this command on a computer with internet access. It downloads the uncloudd and corrosion
+binaries, pulls the Caddy image and any extra images for the target architecture, and packs
+them into a single tarball together with the install script.
+
+Copy the bundle to a computer that can reach the machines over SSH and pass it to
+'uc machine init' or 'uc machine add' with --from-bundle. Docker must already be installed on
+the machines as it can't be installed without internet access.
+
+The bundle can also be installed manually on a machine:
+  mkdir uncloud-bundle && tar -xzf <BUNDLE> -C uncloud-bundle
+  sudo UNCLOUD_BUNDLE_DIR=$PWD/uncloud-bundle bash uncloud-bundle/install.sh`,
+		Example: `  # Create a bundle with the latest release for amd64 machines.
+  uc bundle create
+
+  # Create a bundle with a specific release for arm64 machines.
+  uc bundle create --arch arm64 --version 0.9.0
+
+  # Include the images of your services so they can run without access to a registry.
+  uc bundle create --image postgres:17 --image ghcr.io/acme/app:1.2.3 -o uncloud-bundle.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return create(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.arch, "arch", "amd64",
+		fmt.Sprintf("Architecture of the target machines. Supported values: %s.", strings.Join(bundle.Archs, ", ")))
+	cmd.Flags().StringVar(&opts.caddyImage, "caddy-image", "",
+		"Caddy image to include in the bundle and deploy with 'uc machine init'. "+
+			"(default is the latest version of the official Docker image 'caddy')")
+	cmd.Flags().StringSliceVar(&opts.images, "image", nil,
+		"Extra image to include in the bundle. Can be specified multiple times.")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"Path to write the bundle to. (default uncloud-bundle-<VERSION>-linux-<ARCH>.tar.gz)")
+	cmd.Flags().StringVar(&opts.version, "version", "latest",
+		"Version of the Uncloud daemon to include in the bundle.")
+
+	return cmd
+}
+
+func create(ctx context.Context, opts createOptions) error {
+	if !slices.Contains(bundle.Archs, opts.arch) {
+		return fmt.Errorf("unsupported architecture '%s', supported values: %s",
+			opts.arch, strings.Join(bundle.Archs, ", "))
+	}
+
+	releases := selfupdate.NewClient()
+	var (
+		release *selfupdate.Release
+		err     error
+	)
+	if opts.version == "latest" {
+		release, err = releases.LatestRelease(ctx, selfupdate.ChannelStable)
+	} else {
+		release, err = releases.Release(ctx, opts.version)
+	}
+	if err != nil {
+		return fmt.Errorf("get Uncloud release: %w", err)
+	}
+
+	caddyImage := opts.caddyImage
+	if caddyImage == "" {
+		latest, err := client.LatestCaddyImage()
+		if err != nil {
+			return fmt.Errorf("look up latest Caddy image: %w", err)
+		}
+		caddyImage = reference.FamiliarString(latest)
+	}
+	images := []string{caddyImage}
+	for _, image := range opts.images {
+		if !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+
+	corrosionVersion, err := bundle.CorrosionVersion(scripts.InstallScript)
+	if err != nil {
+		return err
+	}
+
+	archiveName := bundle.UncloudArchiveName(opts.arch)
+	fmt.Printf("Downloading %s from release %s...\n", archiveName, release.Tag)
+	uncloudArchive, err := releases.Download(ctx, release, archiveName)
+	if err != nil {
+		return fmt.Errorf("download uncloudd: %w", err)
+	}
+	checksums, err := releases.Download(ctx, release, selfupdate.ChecksumsFile)
+	if err != nil {
+		return fmt.Errorf("download checksums: %w", err)
+	}
+	if err = selfupdate.VerifyChecksum(checksums, archiveName, uncloudArchive); err != nil {
+		return fmt.Errorf("verify uncloudd archive: %w", err)
+	}
+
+	corrosionURL, err := bundle.CorrosionArchiveURL(corrosionVersion, opts.arch)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Downloading corrosion %s...\n", corrosionVersion)
+	corrosionArchive, err := bundle.Download(ctx, http.DefaultClient, corrosionURL)
+	if err != nil {
+		return fmt.Errorf("download corrosion: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "uncloud-bundle-")
+	if err != nil {
+		return fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fmt.Printf("Pulling images for linux/%s: %s...\n", opts.arch, strings.Join(images, ", "))
+	imagesPath := filepath.Join(tmpDir, bundle.ImagesFile)
+	if err = bundle.SaveImages(ctx, imagesPath, images, opts.arch); err != nil {
+		return err
+	}
+
+	output := opts.output
+	if output == "" {
+		output = fmt.Sprintf("uncloud-bundle-%s-linux-%s.tar.gz", release.Version, opts.arch)
+	}
+	manifest := &bundle.Manifest{
+		Version:          release.Version,
+		CorrosionVersion: corrosionVersion,
+		Arch:             opts.arch,
+		CaddyImage:       caddyImage,
+		Images:           images,
+		Created:          time.Now().UTC(),
+	}
+	if err = writeBundle(output, manifest, uncloudArchive, corrosionArchive, imagesPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Bundle created: %s\n", output)
+	fmt.Printf("Install it on a machine with 'uc machine init --from-bundle %s ...'\n", output)
+	return nil
+}
+
+func writeBundle(
+	path string, manifest *bundle.Manifest, uncloudArchive, corrosionArchive []byte, imagesPath string,
+) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create bundle file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	w := bundle.NewWriter(f)
+	// Write the manifest first so it can be read without decompressing the whole bundle.
+	if err = w.WriteManifest(manifest); err != nil {
+		return err
+	}
+	if err = w.WriteFile(bundle.InstallScriptFile, 0o755, []byte(scripts.InstallScript)); err != nil {
+		return err
+	}
+	if err = w.WriteFile(bundle.UninstallScriptFile, 0o755, []byte(scripts.UninstallScript)); err != nil {
+		return err
+	}
+	if err = w.WriteFile(bundle.UncloudArchiveFile, 0o644, uncloudArchive); err != nil {
+		return err
+	}
+	if err = w.WriteFile(bundle.CorrosionArchiveFile, 0o644, corrosionArchive); err != nil {
+		return err
+	}
+	if err = w.CopyFile(bundle.ImagesFile, 0o644, imagesPath); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Manage air-gapped installation bundles.",
+	}
+	cmd.AddCommand(
+		NewCreateCommand(),
+	)
+	return cmd
+}
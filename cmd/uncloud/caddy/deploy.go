@@ -47,7 +47,7 @@ func NewDeployCommand() *cobra.Command {
 		"Caddy Docker image to deploy. (default caddy:LATEST_VERSION)")
 	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
 		"Machine names or IDs to deploy to. Can be specified multiple times or as a comma-separated "+
-			"list. (default is all machines)")
+			"list. (default is machines with the ingress role or all machines if there are none)")
 
 	completion.MachinesFlag(cmd)
 
@@ -110,6 +110,11 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 	placement := api.Placement{
 		Machines: cli.ExpandCommaSeparatedValues(opts.machines),
 	}
+	if len(placement.Machines) == 0 {
+		if placement, err = clusterClient.IngressPlacement(ctx); err != nil {
+			return err
+		}
+	}
 	d, err := clusterClient.NewCaddyDeployment(opts.image, caddyfile, placement)
 	if err != nil {
 		return fmt.Errorf("create caddy deployment: %w", err)
@@ -170,7 +175,7 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 			title = "Proceed with deployment to " + tui.NameStyle.Render(deployTarget) + confirmStyle.Render("?")
 		}
 
-		confirmed, err := tui.Confirm(title)
+		confirmed, err := uncli.Confirm(title)
 		if err != nil {
 			return fmt.Errorf("confirm deployment: %w", err)
 		}
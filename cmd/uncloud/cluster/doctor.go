@@ -0,0 +1,271 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/huh/v2"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type doctorOptions struct {
+	authoritative string
+	repair        bool
+}
+
+func NewDoctorCommand() *cobra.Command {
+	opts := doctorOptions{}
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Detect and repair a split-brain cluster.",
+		Long: `Detect and repair a split-brain cluster.
+
+After a prolonged network split, machines may end up in separate partitions that don't
+see each other and have divergent cluster state. The doctor command asks every reachable
+machine which machines it sees and compares the cluster state in their stores.
+
+With --repair, you choose the authoritative partition. The machines in other partitions
+discard their cluster store and re-sync it from the authoritative machines. Changes made
+on the other partitions while the cluster was split are lost, for example, services
+deployed or machines added there. Containers keep running and are registered again
+after the re-sync.`,
+		Example: `  # Check the cluster for split-brain.
+  uc cluster doctor
+
+  # Repair the cluster by choosing the authoritative partition interactively.
+  uc cluster doctor --repair
+
+  # Repair the cluster using the partition with machine 'server-1' as authoritative.
+  uc cluster doctor --repair --authoritative server-1 --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return doctor(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.authoritative, "authoritative", "",
+		"Name or ID of a machine in the authoritative partition to use for --repair. "+
+			"If not set, you will be prompted to choose one.")
+	cmd.Flags().BoolVar(&opts.repair, "repair", false,
+		"Re-sync the machines in non-authoritative partitions from the authoritative one.")
+
+	return cmd
+}
+
+func doctor(ctx context.Context, uncli *cli.CLI, opts doctorOptions) error {
+	c, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	resp, err := c.MachineClient.InspectMachine(c.ProxyMachinesContext(ctx, nil), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect machines: %w", err)
+	}
+
+	var (
+		machines    []*pb.MachineDetails
+		unreachable []string
+	)
+	for _, m := range resp.Machines {
+		if m.Metadata != nil && m.Metadata.Error != "" {
+			unreachable = append(unreachable, m.Metadata.MachineName)
+			continue
+		}
+		if m.Machine == nil {
+			continue
+		}
+		machines = append(machines, m)
+	}
+
+	partitions := findPartitions(machines)
+	printPartitions(partitions)
+	if len(unreachable) > 0 {
+		fmt.Println()
+		tui.PrintWarning(fmt.Sprintf("unreachable machines aren't included: %s", strings.Join(unreachable, ", ")))
+	}
+
+	fmt.Println()
+	if !diverged(partitions) {
+		fmt.Println("No split-brain detected. All reachable machines see each other and have the same cluster state.")
+		return nil
+	}
+	if len(partitions) == 1 {
+		fmt.Println("All reachable machines see each other but their cluster state differs. This is usually " +
+			"temporary while changes replicate. Run the command again in a minute to check if the state converged.")
+		if !opts.repair {
+			return nil
+		}
+	} else {
+		fmt.Printf("Split-brain detected: the cluster is split into %d partitions.\n", len(partitions))
+		if !opts.repair {
+			fmt.Println("Run 'uc cluster doctor --repair' to choose the authoritative partition and re-sync the others.")
+			return nil
+		}
+	}
+
+	return repair(ctx, uncli, c, partitions, opts)
+}
+
+func printPartitions(partitions []partition) {
+	t := tui.NewTable()
+	t.Headers("PARTITION", "MACHINE", "STORE VERSION", "STORE DIGEST", "SEES")
+	for i, p := range partitions {
+		for _, m := range p.machines {
+			t.Row(
+				fmt.Sprintf("%d", i+1),
+				m.Machine.Name,
+				fmt.Sprintf("%d", m.StoreDbVersion),
+				shortDigest(m.StoreDigest),
+				fmt.Sprintf("%d machines", len(m.AliveMachineIds)),
+			)
+		}
+	}
+	fmt.Println(t)
+}
+
+// shortDigest returns the first 12 characters of the store digest for display.
+func shortDigest(digest string) string {
+	if len(digest) > 12 {
+		return digest[:12]
+	}
+	return digest
+}
+
+// repair re-syncs the store on the machines outside the authoritative partition.
+func repair(
+	ctx context.Context, uncli *cli.CLI, c *client.Client, partitions []partition, opts doctorOptions,
+) error {
+	auth, err := selectAuthoritative(partitions, opts.authoritative)
+	if err != nil {
+		return err
+	}
+
+	var targets []*pb.MachineDetails
+	if len(partitions) == 1 {
+		// A single partition with divergent digests. Re-sync the machines whose digest differs from the machine
+		// chosen as authoritative.
+		var authDigest string
+		for _, m := range auth.machines {
+			if m.Machine.Name == opts.authoritative || m.Machine.Id == opts.authoritative {
+				authDigest = m.StoreDigest
+			}
+		}
+		if authDigest == "" {
+			return errors.New("--authoritative machine must be set to repair machines in a single partition")
+		}
+		for _, m := range auth.machines {
+			if m.StoreDigest != authDigest {
+				targets = append(targets, m)
+			}
+		}
+	} else {
+		for _, p := range partitions {
+			if p.contains(auth.machines[0].Machine.Id) {
+				continue
+			}
+			targets = append(targets, p.machines...)
+		}
+	}
+	if len(targets) == 0 {
+		fmt.Println("Nothing to repair.")
+		return nil
+	}
+
+	targetNames := make([]string, len(targets))
+	for i, m := range targets {
+		targetNames[i] = m.Machine.Name
+	}
+	fmt.Printf("Machines to re-sync from %s: %s\n", strings.Join(auth.names(), ", "),
+		strings.Join(targetNames, ", "))
+
+	confirmed, err := uncli.Confirm("Discard the cluster store on these machines and re-sync it? " +
+		"Changes made only on them will be lost.")
+	if err != nil {
+		return fmt.Errorf("confirm repair: %w", err)
+	}
+	if !confirmed {
+		return cli.Cancelled("Repair cancelled. No changes were made.")
+	}
+
+	// Re-sync the machine the CLI is connected to last as it restarts and drops the connection.
+	local, err := c.MachineClient.Inspect(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect connected machine: %w", err)
+	}
+	slices.SortStableFunc(targets, func(a, b *pb.MachineDetails) int {
+		switch {
+		case a.Machine.Id == local.Id:
+			return 1
+		case b.Machine.Id == local.Id:
+			return -1
+		}
+		return 0
+	})
+
+	req := &pb.ResyncStoreRequest{MinStoreDbVersion: auth.maxStoreDBVersion()}
+	var errs []error
+	for _, m := range targets {
+		mctx := c.ProxyMachinesContext(ctx, []string{m.Machine.Id})
+		if _, err = c.MachineClient.ResyncStore(mctx, req); err != nil {
+			errs = append(errs, fmt.Errorf("re-sync machine '%s': %w", m.Machine.Name, err))
+			continue
+		}
+		fmt.Printf("Machine '%s' is restarting to re-sync its cluster store.\n", m.Machine.Name)
+	}
+	if err = errors.Join(errs...); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Run 'uc cluster doctor' again in a few minutes to check that the cluster state converged.")
+	return nil
+}
+
+// selectAuthoritative returns the partition containing the machine with the given name or ID, or prompts the user
+// to choose one if the machine is not specified.
+func selectAuthoritative(partitions []partition, nameOrID string) (partition, error) {
+	if nameOrID != "" {
+		for _, p := range partitions {
+			if p.contains(nameOrID) {
+				return p, nil
+			}
+		}
+		return partition{}, fmt.Errorf("machine '%s' not found among reachable machines", nameOrID)
+	}
+
+	if !tui.IsStdinTerminal() {
+		return partition{}, errors.New("cannot prompt to choose the authoritative partition in non-interactive " +
+			"mode, use --authoritative flag to specify a machine in it")
+	}
+	if len(partitions) == 1 {
+		return partition{}, errors.New("--authoritative machine must be set to repair machines in a single partition")
+	}
+
+	options := make([]huh.Option[int], len(partitions))
+	for i, p := range partitions {
+		options[i] = huh.NewOption(fmt.Sprintf("Partition %d: %s", i+1, strings.Join(p.names(), ", ")), i)
+	}
+	var selected int
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title("Select the authoritative partition").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return partition{}, fmt.Errorf("select authoritative partition: %w", err)
+	}
+	return partitions[selected], nil
+}
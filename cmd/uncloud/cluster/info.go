@@ -0,0 +1,211 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func NewInfoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Display a summary of the cluster.",
+		Long: `Display a summary of the cluster.
+
+Prints the cluster name, machine health, network, ingress machines, the versions of the
+machine daemons, and the number of services, containers, and volumes. Include the output
+when reporting an issue to help diagnose the problem.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return info(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func info(ctx context.Context, uncli *cli.CLI) error {
+	c, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	name := uncli.ContextOverrideOrCurrent()
+	if conn := uncli.DirectConnection(); conn != "" {
+		name = conn + " (direct connection)"
+	}
+
+	machines, err := c.ListMachines(client.WithFreshMachines(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	states := make(map[pb.MachineMember_MembershipState]int)
+	for _, m := range machines {
+		states[m.State]++
+	}
+	machinesSummary := fmt.Sprintf("%d (%d up", len(machines), states[pb.MachineMember_UP])
+	if n := states[pb.MachineMember_SUSPECT]; n > 0 {
+		machinesSummary += fmt.Sprintf(", %d suspect", n)
+	}
+	if n := states[pb.MachineMember_DOWN]; n > 0 {
+		machinesSummary += fmt.Sprintf(", %d down", n)
+	}
+	machinesSummary += ")"
+
+	netResp, err := c.ClusterClient.GetNetwork(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("get cluster network: %w", err)
+	}
+	network := "-"
+	if prefix, err := netResp.Network.ToPrefix(); err == nil {
+		network = prefix.String()
+	}
+
+	domain, err := c.GetDomain(ctx)
+	if err != nil {
+		if !errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("get cluster domain: %w", err)
+		}
+		domain = "- (not reserved)"
+	}
+
+	ingressIDs, err := c.IngressMachineIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("get ingress machines: %w", err)
+	}
+	var ingress []string
+	for _, id := range ingressIDs {
+		m := machines.FindByNameOrID(id)
+		if m == nil {
+			continue
+		}
+		endpoint := m.Machine.Name
+		if m.Machine.PublicIp != nil {
+			if ip, err := m.Machine.PublicIp.ToAddr(); err == nil {
+				endpoint += " (" + ip.String() + ")"
+			}
+		}
+		ingress = append(ingress, endpoint)
+	}
+	slices.Sort(ingress)
+	if len(ingress) == 0 {
+		ingress = []string{"-"}
+	}
+
+	resp, err := c.MachineClient.InspectMachine(c.ProxyMachinesContext(ctx, nil), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect machines: %w", err)
+	}
+	var (
+		details     []*pb.MachineDetails
+		unreachable []string
+	)
+	for _, m := range resp.Machines {
+		if m.Metadata != nil && m.Metadata.Error != "" {
+			unreachable = append(unreachable, m.Metadata.MachineName)
+			continue
+		}
+		details = append(details, m)
+	}
+	versions := versionCounts(details)
+
+	services, err := c.ListServices(ctx)
+	if err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+	containers, running := 0, 0
+	for _, s := range services {
+		for _, ctr := range s.Containers {
+			containers++
+			if ctr.Container.State.Running {
+				running++
+			}
+		}
+	}
+
+	volumes, err := c.ListVolumes(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", err)
+	}
+
+	rows := [][2]string{
+		{"Cluster", name},
+		{"Machines", machinesSummary},
+		// Uncloud is leaderless. Every machine stores the full cluster state and can accept changes.
+		{"Leader", "- (leaderless, all machines are equal)"},
+		{"Network", network},
+		{"Domain", domain},
+		{"Ingress", strings.Join(ingress, ", ")},
+		{"Versions", formatVersionCounts(versions)},
+		{"Services", fmt.Sprintf("%d", len(services))},
+		{"Containers", fmt.Sprintf("%d (%d running)", containers, running)},
+		{"Volumes", fmt.Sprintf("%d", len(volumes))},
+	}
+	for _, r := range rows {
+		fmt.Printf("%-11s %s\n", r[0]+":", r[1])
+	}
+
+	if len(versions) > 1 {
+		fmt.Println()
+		tui.PrintWarning("machines run different versions of uncloudd. " +
+			"Upgrade all machines to the same version to avoid compatibility issues.")
+	}
+	if len(unreachable) > 0 {
+		fmt.Println()
+		tui.PrintWarning(fmt.Sprintf("unreachable machines aren't included in versions: %s",
+			strings.Join(unreachable, ", ")))
+	}
+
+	return nil
+}
+
+type versionCount struct {
+	version string
+	count   int
+}
+
+// versionCounts groups the machines by their daemon version. The versions are sorted by the number of machines
+// in descending order so the most common version comes first.
+func versionCounts(machines []*pb.MachineDetails) []versionCount {
+	counts := make(map[string]int)
+	for _, m := range machines {
+		v := m.DaemonVersion
+		if v == "" {
+			v = "unknown"
+		}
+		counts[v]++
+	}
+
+	result := make([]versionCount, 0, len(counts))
+	for v, n := range counts {
+		result = append(result, versionCount{version: v, count: n})
+	}
+	slices.SortFunc(result, func(a, b versionCount) int {
+		if a.count != b.count {
+			return b.count - a.count
+		}
+		return strings.Compare(a.version, b.version)
+	})
+	return result
+}
+
+func formatVersionCounts(versions []versionCount) string {
+	if len(versions) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = fmt.Sprintf("%s (%d)", v.version, v.count)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,27 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionCounts(t *testing.T) {
+	t.Parallel()
+
+	machine := func(version string) *pb.MachineDetails {
+		return &pb.MachineDetails{DaemonVersion: version}
+	}
+
+	versions := versionCounts([]*pb.MachineDetails{
+		machine("0.10.0"), machine(""), machine("0.9.1"), machine("0.10.0"),
+	})
+	assert.Equal(t, []versionCount{
+		{version: "0.10.0", count: 2},
+		{version: "0.9.1", count: 1},
+		{version: "unknown", count: 1},
+	}, versions)
+	assert.Equal(t, "0.10.0 (2), 0.9.1 (1), unknown (1)", formatVersionCounts(versions))
+	assert.Equal(t, "-", formatVersionCounts(versionCounts(nil)))
+}
@@ -0,0 +1,142 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewMTLSCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mtls",
+		Short: "Manage the certificates issued for mTLS-enabled services.",
+		Long: `Manage the clock tolerance and renewal windows of the certificates issued for services with x-mtls.
+
+Each machine issues short-lived certificates valid for 24 hours using its own clock. Machines with clocks that
+drift apart, such as Raspberry Pis without a real-time clock that boot with a stale time, may reject each other's
+certificates and break the mTLS connections between services:
+
+  - The clock tolerance is how far back the validity of a certificate starts before it's issued. Machines with
+    clocks behind the issuing machine by up to the tolerance accept the certificate.
+  - The renew-before window is how long before expiration a certificate is reissued. Machines with clocks ahead
+    of the issuing machine by up to the window accept the certificate.
+
+Machines log a warning when the clock skew with another machine approaches the windows or a certificate is
+rejected because of it. Keep the machine clocks synchronized with NTP and increase the windows only if the clocks
+still drift. Machines apply configuration changes within a minute.`,
+	}
+	cmd.AddCommand(
+		newMTLSResetCommand(),
+		newMTLSSetCommand(),
+		newMTLSShowCommand(),
+	)
+	return cmd
+}
+
+func newMTLSSetCommand() *cobra.Command {
+	var config api.MeshConfig
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the clock tolerance or renewal window of mTLS certificates.",
+		Example: `  # Tolerate machine clocks drifting apart by up to 2 hours.
+  uc cluster mtls set --clock-tolerance 2h --renew-before 2h`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			if !cmd.Flags().Changed("clock-tolerance") && !cmd.Flags().Changed("renew-before") {
+				return fmt.Errorf("at least one of --clock-tolerance or --renew-before must be specified")
+			}
+
+			return updateMeshConfig(cmd.Context(), uncli, func(c *api.MeshConfig) {
+				if cmd.Flags().Changed("clock-tolerance") {
+					c.ClockTolerance = config.ClockTolerance
+				}
+				if cmd.Flags().Changed("renew-before") {
+					c.RenewBefore = config.RenewBefore
+				}
+			})
+		},
+	}
+	cmd.Flags().DurationVar(&config.ClockTolerance, "clock-tolerance", 0, fmt.Sprintf(
+		"How far back the validity of certificates starts before they're issued, up to %s. 0 resets it to "+
+			"the default %s.", api.MeshCertValidity/2, api.DefaultMeshClockTolerance))
+	cmd.Flags().DurationVar(&config.RenewBefore, "renew-before", 0, fmt.Sprintf(
+		"How long before expiration certificates are reissued, up to %s. 0 resets it to the default %s.",
+		api.MeshCertValidity/2, api.DefaultMeshRenewBefore))
+
+	return cmd
+}
+
+func newMTLSResetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset",
+		Short: "Reset the clock tolerance and renewal window of mTLS certificates to the defaults.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return updateMeshConfig(cmd.Context(), uncli, func(c *api.MeshConfig) {
+				*c = api.MeshConfig{}
+			})
+		},
+	}
+}
+
+func newMTLSShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show the clock tolerance and renewal window of mTLS certificates.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			ctx := cmd.Context()
+
+			clusterClient, err := uncli.ConnectCluster(ctx)
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer clusterClient.Close()
+
+			config, err := clusterClient.MeshConfig(ctx)
+			if err != nil {
+				return fmt.Errorf("get mesh config: %w", err)
+			}
+			fmt.Printf("Certificate validity: %s\n", api.MeshCertValidity)
+			fmt.Printf("Clock tolerance:      %s\n", formatWindow(config.ClockTolerance, api.DefaultMeshClockTolerance))
+			fmt.Printf("Renew before:         %s\n", formatWindow(config.RenewBefore, api.DefaultMeshRenewBefore))
+			return nil
+		},
+	}
+}
+
+// formatWindow formats the configured window or the default one if it's not set.
+func formatWindow(d, def time.Duration) string {
+	if d == 0 {
+		return def.String() + " " + tui.Faint.Render("(default)")
+	}
+	return d.String()
+}
+
+func updateMeshConfig(ctx context.Context, uncli *cli.CLI, update func(c *api.MeshConfig)) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	config, err := clusterClient.MeshConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get mesh config: %w", err)
+	}
+	update(&config)
+
+	if err = clusterClient.SetMeshConfig(ctx, config); err != nil {
+		return fmt.Errorf("set mesh config: %w", err)
+	}
+	fmt.Println("mTLS certificate configuration updated.")
+	return nil
+}
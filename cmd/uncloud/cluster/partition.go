@@ -0,0 +1,103 @@
+package cluster
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// partition is a group of machines that see each other in the cluster membership but don't see any machine
+// from other partitions.
+type partition struct {
+	machines []*pb.MachineDetails
+	// digests are the distinct store digests of the machines in the partition.
+	digests []string
+}
+
+// names returns the sorted names of the machines in the partition.
+func (p partition) names() []string {
+	names := make([]string, len(p.machines))
+	for i, m := range p.machines {
+		names[i] = m.Machine.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// maxStoreDBVersion returns the highest store database version among the machines in the partition.
+func (p partition) maxStoreDBVersion() int64 {
+	var version int64
+	for _, m := range p.machines {
+		version = max(version, m.StoreDbVersion)
+	}
+	return version
+}
+
+// contains returns true if the partition contains the machine with the given name or ID.
+func (p partition) contains(nameOrID string) bool {
+	return slices.ContainsFunc(p.machines, func(m *pb.MachineDetails) bool {
+		return m.Machine.Name == nameOrID || m.Machine.Id == nameOrID
+	})
+}
+
+// findPartitions groups the machines into partitions by their views of the cluster membership. Two machines are
+// in the same partition if at least one of them sees the other as alive, directly or through other machines.
+func findPartitions(machines []*pb.MachineDetails) []partition {
+	index := make(map[string]int, len(machines))
+	for i, m := range machines {
+		index[m.Machine.Id] = i
+	}
+
+	// Union-find over the machine indices.
+	parent := make([]int, len(machines))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i, m := range machines {
+		for _, id := range m.AliveMachineIds {
+			if j, ok := index[id]; ok {
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+
+	groups := make(map[int]*partition)
+	var roots []int
+	for i, m := range machines {
+		root := find(i)
+		p, ok := groups[root]
+		if !ok {
+			p = &partition{}
+			groups[root] = p
+			roots = append(roots, root)
+		}
+		p.machines = append(p.machines, m)
+		if !slices.Contains(p.digests, m.StoreDigest) {
+			p.digests = append(p.digests, m.StoreDigest)
+		}
+	}
+
+	partitions := make([]partition, len(roots))
+	for i, root := range roots {
+		partitions[i] = *groups[root]
+	}
+	// Sort the largest partitions first as they're most likely to be authoritative.
+	sort.SliceStable(partitions, func(i, j int) bool {
+		return len(partitions[i].machines) > len(partitions[j].machines)
+	})
+	return partitions
+}
+
+// diverged returns true if the partitions have different cluster states: there is more than one partition or
+// the machines in a partition have different store digests.
+func diverged(partitions []partition) bool {
+	return len(partitions) > 1 || (len(partitions) == 1 && len(partitions[0].digests) > 1)
+}
@@ -0,0 +1,86 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPartitions(t *testing.T) {
+	t.Parallel()
+
+	machine := func(name, digest string, alive ...string) *pb.MachineDetails {
+		return &pb.MachineDetails{
+			Machine:         &pb.MachineInfo{Id: name + "-id", Name: name},
+			AliveMachineIds: alive,
+			StoreDigest:     digest,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		machines     []*pb.MachineDetails
+		wantNames    [][]string
+		wantDiverged bool
+	}{
+		{
+			name: "healthy",
+			machines: []*pb.MachineDetails{
+				machine("m1", "a", "m2-id", "m3-id"),
+				machine("m2", "a", "m1-id", "m3-id"),
+				machine("m3", "a", "m1-id", "m2-id"),
+			},
+			wantNames: [][]string{{"m1", "m2", "m3"}},
+		},
+		{
+			name: "asymmetric view is one partition",
+			machines: []*pb.MachineDetails{
+				machine("m1", "a", "m2-id"),
+				machine("m2", "a"),
+			},
+			wantNames: [][]string{{"m1", "m2"}},
+		},
+		{
+			name: "split",
+			machines: []*pb.MachineDetails{
+				machine("m1", "a", "m2-id"),
+				machine("m2", "a", "m1-id"),
+				machine("m3", "b", "m4-id", "m5-id"),
+				machine("m4", "b", "m3-id"),
+				machine("m5", "b", "m3-id"),
+			},
+			wantNames:    [][]string{{"m3", "m4", "m5"}, {"m1", "m2"}},
+			wantDiverged: true,
+		},
+		{
+			name: "divergent digests in one partition",
+			machines: []*pb.MachineDetails{
+				machine("m1", "a", "m2-id"),
+				machine("m2", "b", "m1-id"),
+			},
+			wantNames:    [][]string{{"m1", "m2"}},
+			wantDiverged: true,
+		},
+		{
+			name: "unknown alive machine is ignored",
+			machines: []*pb.MachineDetails{
+				machine("m1", "a", "unknown-id"),
+			},
+			wantNames: [][]string{{"m1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partitions := findPartitions(tt.machines)
+
+			names := make([][]string, len(partitions))
+			for i, p := range partitions {
+				names[i] = p.names()
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantDiverged, diverged(partitions))
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Manage the cluster.",
+	}
+	cmd.AddCommand(
+		NewDoctorCommand(),
+		NewInfoCommand(),
+		NewMTLSCommand(),
+		NewSecretModeCommand(),
+		NewSysctlsCommand(),
+		NewUlimitsCommand(),
+		NewUsernsCommand(),
+	)
+	return cmd
+}
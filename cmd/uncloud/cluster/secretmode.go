@@ -0,0 +1,130 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewSecretModeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret-mode",
+		Short: "Manage the most permissive file mode allowed for secrets.",
+		Long: `Manage the most permissive file mode allowed for secrets mounted into service containers.
+
+Secrets are mounted with mode 0400 and owned by root by default. Services can set a different
+mode with the 'mode' option of a secret in the Compose file. When the cluster maximum is set,
+deployments that mount secrets with more permissions than the maximum are rejected. For example,
+with the maximum 0440, secrets can be readable by their owner and group but not by others.
+
+World-writable secrets are always rejected.`,
+	}
+	cmd.AddCommand(
+		newSecretModeSetCommand(),
+		newSecretModeUnsetCommand(),
+		newSecretModeStatusCommand(),
+	)
+	return cmd
+}
+
+func newSecretModeSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set MODE",
+		Short: "Set the most permissive file mode allowed for secrets.",
+		Example: `  # Allow secrets to be readable by their owner and group only.
+  uc cluster secret-mode set 0440`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			mode, err := parseFileMode(args[0])
+			if err != nil {
+				return err
+			}
+			return setSecretMaxMode(cmd.Context(), uncli, mode)
+		},
+	}
+	return cmd
+}
+
+func newSecretModeUnsetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Remove the limit on the file mode of secrets.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setSecretMaxMode(cmd.Context(), uncli, 0)
+		},
+	}
+	return cmd
+}
+
+func newSecretModeStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the most permissive file mode allowed for secrets.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return secretModeStatus(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+// parseFileMode parses an octal file mode like '0440' that contains only permission bits.
+func parseFileMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || os.FileMode(mode)&^os.ModePerm != 0 {
+		return 0, fmt.Errorf("invalid mode '%s': must be octal permission bits, for example, 0440", s)
+	}
+	if mode == 0 {
+		return 0, fmt.Errorf("invalid mode '%s': secrets must be readable by their owner, "+
+			"use 'unset' to remove the limit", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+func setSecretMaxMode(ctx context.Context, uncli *cli.CLI, mode os.FileMode) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if err = clusterClient.SetSecretMaxMode(ctx, mode); err != nil {
+		return fmt.Errorf("set secret max mode: %w", err)
+	}
+	if mode == 0 {
+		fmt.Println("Removed the limit on the file mode of secrets.")
+	} else {
+		fmt.Printf("Secrets can be mounted with at most mode %04o. It applies to new deployments.\n", uint32(mode))
+	}
+
+	return nil
+}
+
+func secretModeStatus(ctx context.Context, uncli *cli.CLI) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	mode, err := clusterClient.SecretMaxMode(ctx)
+	if err != nil {
+		return fmt.Errorf("get secret max mode: %w", err)
+	}
+	if mode == 0 {
+		fmt.Println("No limit on the file mode of secrets.")
+	} else {
+		fmt.Printf("Secrets can be mounted with at most mode %04o.\n", uint32(mode))
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package cluster
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFileMode(t *testing.T) {
+	t.Parallel()
+
+	mode, err := parseFileMode("0440")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o440), mode)
+
+	mode, err = parseFileMode("600")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), mode)
+
+	for _, s := range []string{"0", "0999", "rw", "04755"} {
+		_, err = parseFileMode(s)
+		assert.Error(t, err, s)
+	}
+}
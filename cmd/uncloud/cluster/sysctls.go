@@ -0,0 +1,142 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewSysctlsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sysctls",
+		Short: "Manage the cluster policy for sysctls that services may set.",
+		Long: `Manage the cluster policy for sysctls that services may set.
+
+By default, services can set any namespaced sysctls with 'sysctls' in the Compose file or
+'uc run --sysctl'. Once you allow at least one sysctl, the policy is enabled and services can
+only set sysctls that match the allow-list. Deployments that request other sysctls fail
+validation before any containers are changed.
+
+A pattern is either an exact sysctl name like 'net.ipv4.ip_forward' or a prefix followed by
+'.*' like 'net.ipv4.*' that matches all sysctls under it.
+
+Removing the last pattern from the allow-list disables the policy and allows all sysctls again.
+The policy applies to containers created from now on. It doesn't affect running containers.`,
+	}
+	cmd.AddCommand(
+		newSysctlsAllowCommand(),
+		newSysctlsDisallowCommand(),
+		newSysctlsListCommand(),
+	)
+	return cmd
+}
+
+func newSysctlsAllowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allow PATTERN [PATTERN...]",
+		Short: "Allow services to set sysctls matching the patterns.",
+		Example: `  # Allow services to enable IP forwarding only.
+  uc cluster sysctls allow net.ipv4.ip_forward
+
+  # Allow all IPv4 and IPv6 network sysctls.
+  uc cluster sysctls allow 'net.ipv4.*' 'net.ipv6.*'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return allowSysctls(cmd.Context(), uncli, args)
+		},
+	}
+	return cmd
+}
+
+func allowSysctls(ctx context.Context, uncli *cli.CLI, patterns []string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	allowed, err := clusterClient.AllowSysctls(ctx, patterns...)
+	if err != nil {
+		return fmt.Errorf("allow sysctls: %w", err)
+	}
+	fmt.Printf("Allowed sysctls: %s\n", strings.Join(allowed, ", "))
+
+	return nil
+}
+
+func newSysctlsDisallowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "disallow PATTERN [PATTERN...]",
+		Aliases: []string{"rm"},
+		Short:   "Remove patterns from the sysctl allow-list.",
+		Example: `  # Remove a pattern from the allow-list.
+  uc cluster sysctls disallow 'net.ipv6.*'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return disallowSysctls(cmd.Context(), uncli, args)
+		},
+	}
+	return cmd
+}
+
+func disallowSysctls(ctx context.Context, uncli *cli.CLI, patterns []string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	allowed, err := clusterClient.DisallowSysctls(ctx, patterns...)
+	if err != nil {
+		return fmt.Errorf("disallow sysctls: %w", err)
+	}
+	if len(allowed) == 0 {
+		fmt.Println("The sysctl allow-list is empty. Services can set any sysctls.")
+	} else {
+		fmt.Printf("Allowed sysctls: %s\n", strings.Join(allowed, ", "))
+	}
+
+	return nil
+}
+
+func newSysctlsListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List sysctl patterns that services may set.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listSysctls(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func listSysctls(ctx context.Context, uncli *cli.CLI) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	allowed, err := clusterClient.ListAllowedSysctls(ctx)
+	if err != nil {
+		return fmt.Errorf("list allowed sysctls: %w", err)
+	}
+
+	if len(allowed) == 0 {
+		fmt.Println("No sysctl policy. Services can set any sysctls.")
+		return nil
+	}
+	for _, p := range allowed {
+		fmt.Println(p)
+	}
+
+	return nil
+}
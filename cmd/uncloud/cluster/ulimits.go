@@ -0,0 +1,172 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewUlimitsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ulimits",
+		Short: "Manage cluster-wide default ulimits for service containers.",
+		Long: `Manage cluster-wide default ulimits for service containers.
+
+Default ulimits apply to every service container created in the cluster, so you don't have to
+repeat them in every Compose file. Ulimits set for a service with 'ulimits' in the Compose file
+or 'uc run --ulimit' override the defaults with the same name.
+
+The defaults apply when a container is created. Redeploy services with 'uc deploy --recreate'
+to apply changed defaults to existing containers.`,
+	}
+	cmd.AddCommand(
+		newUlimitsListCommand(),
+		newUlimitsRmCommand(),
+		newUlimitsSetCommand(),
+	)
+	return cmd
+}
+
+func newUlimitsSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set TYPE=SOFT[:HARD] [TYPE=SOFT[:HARD]...]",
+		Short: "Set cluster-wide default ulimits.",
+		Example: `  # Raise the open files limit for all service containers.
+  uc cluster ulimits set nofile=65536
+
+  # Set the soft and hard limits for open files and processes.
+  uc cluster ulimits set nofile=32768:65536 nproc=4096`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setUlimits(cmd.Context(), uncli, args)
+		},
+	}
+	return cmd
+}
+
+func setUlimits(ctx context.Context, uncli *cli.CLI, args []string) error {
+	ulimits := make(map[string]api.Ulimit, len(args))
+	for _, arg := range args {
+		u, err := units.ParseUlimit(arg)
+		if err != nil {
+			return fmt.Errorf("invalid ulimit '%s': %w", arg, err)
+		}
+		ulimits[u.Name] = api.Ulimit{Soft: u.Soft, Hard: u.Hard}
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if _, err = clusterClient.SetDefaultUlimits(ctx, ulimits); err != nil {
+		return fmt.Errorf("set default ulimits: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(ulimits)) {
+		fmt.Printf("Default ulimit '%s' set to %s.\n", name, formatUlimit(ulimits[name]))
+	}
+	fmt.Println("The defaults apply to containers created from now on. " +
+		"Redeploy services with 'uc deploy --recreate' to apply them to existing containers.")
+
+	return nil
+}
+
+func newUlimitsRmCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm TYPE [TYPE...]",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove cluster-wide default ulimits.",
+		Example: `  # Remove the default open files limit.
+  uc cluster ulimits rm nofile`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return removeUlimits(cmd.Context(), uncli, args)
+		},
+	}
+	return cmd
+}
+
+func removeUlimits(ctx context.Context, uncli *cli.CLI, names []string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if _, err = clusterClient.RemoveDefaultUlimits(ctx, names...); err != nil {
+		return fmt.Errorf("remove default ulimits: %w", err)
+	}
+	for _, name := range names {
+		fmt.Printf("Default ulimit '%s' removed.\n", name)
+	}
+
+	return nil
+}
+
+func newUlimitsListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List cluster-wide default ulimits.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listUlimits(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func listUlimits(ctx context.Context, uncli *cli.CLI) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	ulimits, err := clusterClient.ListDefaultUlimits(ctx)
+	if err != nil {
+		return fmt.Errorf("list default ulimits: %w", err)
+	}
+
+	if len(ulimits) == 0 {
+		fmt.Println("No default ulimits.")
+		return nil
+	}
+
+	t := tui.NewTable()
+	t.Headers("TYPE", "SOFT", "HARD")
+	for _, name := range slices.Sorted(maps.Keys(ulimits)) {
+		u := ulimits[name]
+		t.Row(name, formatUlimitValue(u.Soft), formatUlimitValue(u.Hard))
+	}
+	fmt.Println(t)
+
+	return nil
+}
+
+func formatUlimit(u api.Ulimit) string {
+	if u.Soft == u.Hard {
+		return formatUlimitValue(u.Soft)
+	}
+	return formatUlimitValue(u.Soft) + ":" + formatUlimitValue(u.Hard)
+}
+
+// formatUlimitValue formats the limit value where -1 means unlimited.
+func formatUlimitValue(v int64) string {
+	if v == -1 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(v, 10)
+}
@@ -0,0 +1,16 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUlimit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "65536", formatUlimit(api.Ulimit{Soft: 65536, Hard: 65536}))
+	assert.Equal(t, "1024:2048", formatUlimit(api.Ulimit{Soft: 1024, Hard: 2048}))
+	assert.Equal(t, "unlimited", formatUlimit(api.Ulimit{Soft: -1, Hard: -1}))
+}
@@ -0,0 +1,107 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewUsernsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "userns",
+		Short: "Manage user namespace remapping for service containers.",
+		Long: `Manage user namespace remapping for service containers.
+
+With user namespace remapping, root in a container is mapped to an unprivileged user on the
+machine. A process that escapes the container has no root privileges on the machine, which
+improves isolation when the cluster runs workloads you don't fully trust.
+
+Remapping is configured in Docker with the 'userns-remap' daemon option. When it's enabled for
+the cluster, machines added with 'uc machine add' get Docker installed with this option. Docker
+that was already installed on a machine isn't reconfigured. The install script prints the steps
+to configure it manually, and the machine logs a warning for every container it runs without
+remapping.
+
+Privileged services and services with 'pid: host' are opted out automatically as they can't run
+with remapping. Opt out other services with 'userns_mode: host' in the Compose file.`,
+	}
+	cmd.AddCommand(
+		newUsernsSetCommand(true),
+		newUsernsSetCommand(false),
+		newUsernsStatusCommand(),
+	)
+	return cmd
+}
+
+func newUsernsSetCommand(enable bool) *cobra.Command {
+	use, short := "disable", "Disable user namespace remapping for new machines."
+	if enable {
+		use, short = "enable", "Enable user namespace remapping for new machines."
+	}
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setUsernsRemap(cmd.Context(), uncli, enable)
+		},
+	}
+	return cmd
+}
+
+func setUsernsRemap(ctx context.Context, uncli *cli.CLI, enable bool) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if err = clusterClient.SetUsernsRemap(ctx, enable); err != nil {
+		return fmt.Errorf("set user namespace remapping: %w", err)
+	}
+	if enable {
+		fmt.Println("User namespace remapping enabled for the cluster. Docker on new machines will be configured " +
+			"with userns-remap. Existing machines must be configured manually.")
+	} else {
+		fmt.Println("User namespace remapping disabled for the cluster. Docker on existing machines " +
+			"isn't reconfigured.")
+	}
+
+	return nil
+}
+
+func newUsernsStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show whether user namespace remapping is enabled for the cluster.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return usernsStatus(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func usernsStatus(ctx context.Context, uncli *cli.CLI) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	enabled, err := clusterClient.UsernsRemap(ctx)
+	if err != nil {
+		return fmt.Errorf("get user namespace remapping: %w", err)
+	}
+	if enabled {
+		fmt.Println("User namespace remapping is enabled.")
+	} else {
+		fmt.Println("User namespace remapping is disabled.")
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	composecli "github.com/compose-spec/compose-go/v2/cli"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/k8s"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+const (
+	convertFormatCompose = "compose"
+	convertFormatK8s     = "k8s"
+	convertFormatSpec    = "spec"
+)
+
+type convertOptions struct {
+	files    []string
+	profiles []string
+	services []string
+	format   string
+	output   string
+}
+
+// NewComposeCommand creates a new command group to work with Compose files.
+func NewComposeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Work with Compose files without deploying them.",
+	}
+	cmd.AddCommand(
+		NewComposeConvertCommand(),
+	)
+	return cmd
+}
+
+// NewComposeConvertCommand creates a new command to convert a Compose file to other formats.
+func NewComposeConvertCommand() *cobra.Command {
+	opts := convertOptions{}
+	cmd := &cobra.Command{
+		Use:   "convert [FLAGS] [SERVICE...]",
+		Short: "Convert a Compose file to the resolved Compose, Uncloud service spec, or Kubernetes format.",
+		Long: `Convert a Compose file to the resolved Compose, Uncloud service spec, or Kubernetes format.
+
+The 'compose' format prints the Compose file with all variables interpolated and extensions resolved.
+
+The 'spec' format prints the Uncloud service specs that 'uc deploy' would deploy for the services as YAML documents.
+They use the same canonical encoding as the specs stored in the cluster, which is useful for reviewing or diffing
+the exact changes of a Compose file.
+
+The 'k8s' format converts the services to Kubernetes Deployments (DaemonSets for global services), Services,
+Ingresses, ConfigMaps, and PersistentVolumeClaims. This is a best-effort conversion to help you move to
+Kubernetes later without rewriting configs. Features that can't be converted are reported as warnings.
+Review the generated manifests before applying them.`,
+		Example: `  # Print the resolved Compose file.
+  uc compose convert
+
+  # Print the service specs that would be deployed.
+  uc compose convert --format spec
+
+  # Convert all services to Kubernetes manifests and write them to a file.
+  uc compose convert --format k8s -o k8s.yaml
+
+  # Convert only the web service and its dependencies.
+  uc compose convert --format k8s web`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.services = args
+			return runConvert(cmd.Context(), opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return completion.ComposeServices(cmd.Context(), args, toComplete, opts.files, opts.profiles)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
+		"One or more Compose files to convert. (default compose.yaml)")
+	cmd.Flags().StringVar(&opts.format, "format", convertFormatCompose,
+		fmt.Sprintf("Output format: '%s', '%s', or '%s'.", convertFormatCompose, convertFormatSpec, convertFormatK8s))
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"Write the output to the specified path instead of stdout.")
+	cmd.Flags().StringSliceVarP(&opts.profiles, "profile", "p", nil,
+		"One or more Compose profiles to enable.")
+
+	return cmd
+}
+
+func runConvert(ctx context.Context, opts convertOptions) error {
+	if opts.format != convertFormatCompose && opts.format != convertFormatSpec && opts.format != convertFormatK8s {
+		return fmt.Errorf("invalid format '%s', must be '%s', '%s', or '%s'",
+			opts.format, convertFormatCompose, convertFormatSpec, convertFormatK8s)
+	}
+
+	project, err := compose.LoadProject(ctx, opts.files, composecli.WithDefaultProfiles(opts.profiles...))
+	if err != nil {
+		return cli.WithExitCode(cli.ExitCodeValidation, fmt.Errorf("load compose file(s): %w", err))
+	}
+	if len(opts.services) > 0 {
+		if project, err = project.WithSelectedServices(opts.services); err != nil {
+			return fmt.Errorf("select services: %w", err)
+		}
+	}
+
+	var data []byte
+	switch opts.format {
+	case convertFormatCompose:
+		if data, err = project.MarshalYAML(); err != nil {
+			return fmt.Errorf("marshal Compose file: %w", err)
+		}
+	case convertFormatSpec:
+		if data, err = convertToSpecs(project); err != nil {
+			return err
+		}
+	case convertFormatK8s:
+		if data, err = convertToK8s(project); err != nil {
+			return err
+		}
+	}
+
+	if opts.output == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+	if err = os.WriteFile(opts.output, data, 0o644); err != nil {
+		return fmt.Errorf("write output file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Converted services written to %s\n", opts.output)
+	return nil
+}
+
+// convertToSpecs converts the services to service specs encoded as YAML documents sorted by service name.
+func convertToSpecs(project *types.Project) ([]byte, error) {
+	names := project.ServiceNames()
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	for i, name := range names {
+		spec, err := compose.ServiceSpecFromCompose(project, name)
+		if err != nil {
+			return nil, fmt.Errorf("convert compose service '%s': %w", name, err)
+		}
+		data, err := api.ServiceSpecYAML(spec)
+		if err != nil {
+			return nil, fmt.Errorf("marshal service spec '%s': %w", name, err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+func convertToK8s(project *types.Project) ([]byte, error) {
+	names := project.ServiceNames()
+	slices.Sort(names)
+
+	specs := make([]api.ServiceSpec, 0, len(names))
+	for _, name := range names {
+		spec, err := compose.ServiceSpecFromCompose(project, name)
+		if err != nil {
+			return nil, fmt.Errorf("convert compose service '%s': %w", name, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	result, err := k8s.Export(project.Name, specs)
+	if err != nil {
+		return nil, fmt.Errorf("convert services to Kubernetes manifests: %w", err)
+	}
+	for _, w := range result.Warnings {
+		tui.PrintWarning(w)
+	}
+
+	data, err := k8s.MarshalYAML(result.Objects)
+	if err != nil {
+		return nil, fmt.Errorf("marshal Kubernetes manifests: %w", err)
+	}
+	return data, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"charm.land/lipgloss/v2"
 	composecli "github.com/compose-spec/compose-go/v2/cli"
@@ -13,25 +14,32 @@ import (
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/completion"
 	"github.com/psviderski/uncloud/internal/cli/logs"
+	cliprogress "github.com/psviderski/uncloud/internal/cli/progress"
 	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/psviderski/uncloud/pkg/client/compose"
 	"github.com/psviderski/uncloud/pkg/client/deploy"
 	"github.com/psviderski/uncloud/pkg/client/deploy/operation"
+	"github.com/psviderski/uncloud/pkg/client/timing"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type deployOptions struct {
 	cli.BuildServicesOptions
 
-	files      []string
-	profiles   []string
-	services   []string
-	noBuild    bool
-	recreate   bool
-	skipHealth bool
-	yes        bool
+	files              []string
+	profiles           []string
+	services           []string
+	noBuild            bool
+	recreate           bool
+	skipHealth         bool
+	snapshotVolumes    bool
+	timings            bool
+	maxConcurrentPulls int
+	ingressLast        bool
 }
 
 // NewDeployCommand creates a new command to deploy services from a Compose file.
@@ -41,8 +49,6 @@ func NewDeployCommand() *cobra.Command {
 		Use:   "deploy [FLAGS] [SERVICE...]",
 		Short: "Deploy services from a Compose file.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
-
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 			opts.services = args
 
@@ -57,8 +63,20 @@ func NewDeployCommand() *cobra.Command {
 	cmd.Flags().StringArrayVar(&opts.BuildServicesOptions.BuildArgs, "build-arg", nil,
 		"Set a build-time variable for services. Used in Dockerfiles that declare the variable with ARG.\n"+
 			"Can be specified multiple times. Format: --build-arg VAR=VALUE")
+	cmd.Flags().StringVar(&opts.BuildServicesOptions.Builder, "builder", "",
+		"Name or ID of a cluster machine to build service images on using its Docker over SSH.\n"+
+			"The built images are pushed from it to other machines. (default is local Docker)")
+	cmd.Flags().StringVar(&opts.BuildServicesOptions.CacheRepo, "build-cache-repo", "",
+		"Registry repository to use as a shared build cache when building service images.")
 	cmd.Flags().BoolVar(&opts.BuildServicesOptions.Pull, "build-pull", false,
 		"Always attempt to pull newer versions of base images before building service images.")
+	cmd.Flags().BoolVar(&opts.ingressLast, "ingress-last", false,
+		"Update containers on machines that run Caddy and serve ingress traffic after all other machines.")
+	cmd.Flags().IntVar(&opts.maxConcurrentPulls, "max-concurrent-pulls", 0,
+		"Pull images on all target machines before updating containers, running at most this many pulls at a time "+
+			"across the cluster.\n"+
+			"Use it to avoid saturating a shared network uplink. By default, images are pulled one by one when "+
+			"their containers are updated.")
 	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
 		"One or more Compose files to deploy services from. (default compose.yaml)")
 	cmd.Flags().BoolVar(&opts.noBuild, "no-build", false,
@@ -73,9 +91,13 @@ func NewDeployCommand() *cobra.Command {
 		"Skip the monitoring period and health checks after starting new containers. Useful for faster emergency "+
 			"deployments.\n"+
 			"Warning: This may cause downtime if new containers fail to start properly.")
-	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
-		"Auto-confirm deployment plan. Should be explicitly set when running non-interactively,\n"+
-			"e.g., in CI/CD pipelines. [$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().BoolVar(&opts.snapshotVolumes, "snapshot-volumes", false,
+		"Snapshot the volumes used by the updated services before deploying them to be able to restore the data "+
+			"with 'uc volume snapshot restore'.\n"+
+			"Only volumes stored on btrfs or ZFS filesystems can be snapshotted, other volumes are skipped.")
+	cli.AddTimingsFlag(cmd, &opts.timings)
+
+	completion.BuilderFlag(cmd)
 
 	// TODO: Consider adding a filter flag to specify which machines to deploy to but keep the rest running.
 	//  Could be useful to test a new version on a subset of machines before rolling out to all.
@@ -85,9 +107,15 @@ func NewDeployCommand() *cobra.Command {
 
 // runDeploy parses the Compose file(s) and deploys the services.
 func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
+	if opts.maxConcurrentPulls < 0 {
+		return errors.New("--max-concurrent-pulls must not be negative")
+	}
+	ctx, printTimings := cli.WithTimings(ctx, opts.timings)
+	defer printTimings()
+
 	project, err := compose.LoadProject(ctx, opts.files, composecli.WithDefaultProfiles(opts.profiles...))
 	if err != nil {
-		return fmt.Errorf("load compose file(s): %w", err)
+		return cli.WithExitCode(cli.ExitCodeValidation, fmt.Errorf("load compose file(s): %w", err))
 	}
 
 	uncli.SetClusterContextIfUnset(compose.ClusterContext(project))
@@ -127,6 +155,13 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 	defer clusterClient.Close()
 
 	if len(servicesToBuild) > 0 && !opts.noBuild {
+		var builder *cli.Builder
+		if opts.Builder != "" {
+			if builder, err = uncli.ResolveBuilder(ctx, clusterClient, opts.Builder); err != nil {
+				return err
+			}
+		}
+
 		// Push built service images to cluster machines one at a time.
 		var errs []error
 		for _, s := range servicesToBuild {
@@ -138,11 +173,15 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 			// Push to the specified x-machines or to *all* cluster machines if not specified.
 			var pushOpts client.PushImageOptions
 			if machines, ok := s.Extensions[compose.MachinesExtensionKey].(compose.MachinesSource); ok {
-				pushOpts.Machines = machines
+				pushOpts.Machines = machines.PushMachines()
 			}
 			if len(pushOpts.Machines) == 0 {
 				pushOpts.AllMachines = true
 			}
+			if builder != nil {
+				pushOpts.Builder = builder.Machine
+				pushOpts.BuilderDockerHost = builder.DockerHost
+			}
 
 			boldStyle := lipgloss.NewStyle().Bold(true)
 			err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
@@ -167,12 +206,19 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 		ForceRecreate:     opts.recreate,
 		SkipHealthMonitor: opts.skipHealth,
 	}
+	if opts.ingressLast {
+		if strategy.LastMachineIDs, err = clusterClient.IngressMachineIDs(ctx); err != nil {
+			return fmt.Errorf("get ingress machines: %w", err)
+		}
+	}
 	composeDeploy, err := compose.NewDeploymentWithStrategy(ctx, clusterClient, project, strategy)
 	if err != nil {
 		return fmt.Errorf("create compose deployment: %w", err)
 	}
 
+	stopTiming := timing.Start(ctx, "", timing.PhasePlan)
 	plan, err := composeDeploy.Plan(ctx)
+	stopTiming()
 	if err != nil {
 		return fmt.Errorf("plan deployment: %w", err)
 	}
@@ -202,28 +248,21 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 	fmt.Println(plan.Format())
 
 	// Ask for plan confirmation before proceeding with the deployment unless auto-confirmed with --yes.
-	if !opts.yes {
-		if !tui.IsStdinTerminal() {
-			return errors.New("cannot ask to confirm deployment plan in non-interactive mode, " +
-				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
-		}
-
-		title := "Proceed with deployment?"
-		// Include the direct connection or context name in the confirmation prompt to avoid accidentally
-		// deploying to the wrong cluster.
-		if deployTarget != "" {
-			isDark := lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
-			confirmStyle := tui.ThemeConfirm().Theme(isDark).Focused.Title
-			title = "Proceed with deployment to " + tui.NameStyle.Render(deployTarget) + confirmStyle.Render("?")
-		}
+	confirmTitle := "Proceed with deployment?"
+	// Include the direct connection or context name in the confirmation prompt to avoid accidentally
+	// deploying to the wrong cluster.
+	if deployTarget != "" {
+		isDark := lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
+		confirmStyle := tui.ThemeConfirm().Theme(isDark).Focused.Title
+		confirmTitle = "Proceed with deployment to " + tui.NameStyle.Render(deployTarget) + confirmStyle.Render("?")
+	}
 
-		confirmed, err := tui.Confirm(title)
-		if err != nil {
-			return fmt.Errorf("confirm deployment: %w", err)
-		}
-		if !confirmed {
-			return cli.Cancelled("Deploy cancelled. No changes were made.")
-		}
+	confirmed, err := uncli.Confirm(confirmTitle)
+	if err != nil {
+		return fmt.Errorf("confirm deployment: %w", err)
+	}
+	if !confirmed {
+		return cli.Cancelled("Deploy cancelled. No changes were made.")
 	}
 
 	title := "Deploying"
@@ -231,6 +270,31 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 		title += " to " + tui.NameStyle.Render(deployTarget)
 	}
 	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		defer timing.Start(ctx, "", timing.PhaseDeploy)()
+		if opts.snapshotVolumes {
+			if err := snapshotVolumes(ctx, clusterClient, plan.UpdatedServiceVolumes()); err != nil {
+				return err
+			}
+		}
+		if opts.maxConcurrentPulls > 0 {
+			pulls := plan.ImagePulls()
+			// Pull on the machines serving live traffic last as well.
+			slices.SortStableFunc(pulls, func(a, b deploy.ImagePull) int {
+				aLast := slices.Contains(strategy.LastMachineIDs, a.MachineID)
+				bLast := slices.Contains(strategy.LastMachineIDs, b.MachineID)
+				switch {
+				case aLast == bLast:
+					return 0
+				case aLast:
+					return 1
+				default:
+					return -1
+				}
+			})
+			if err := clusterClient.PrePullImages(ctx, pulls, opts.maxConcurrentPulls); err != nil {
+				return fmt.Errorf("pull images: %w", err)
+			}
+		}
 		if err := plan.Execute(ctx, clusterClient); err != nil {
 			return fmt.Errorf("deploy services: %w", err)
 		}
@@ -257,6 +321,33 @@ func runDeploy(ctx context.Context, uncli *cli.CLI, opts deployOptions) error {
 	return nil
 }
 
+// snapshotVolumes creates snapshots of the volumes with the given names on all machines they exist on. Volumes that
+// can't be snapshotted because of their filesystem or driver, or on machines that don't support snapshots, are
+// skipped.
+func snapshotVolumes(ctx context.Context, cli *client.Client, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	volumes, err := cli.ListVolumes(ctx, &api.VolumeFilter{Names: names})
+	if err != nil {
+		return fmt.Errorf("list volumes to snapshot: %w", err)
+	}
+
+	pw := progress.ContextWriter(ctx)
+	for _, v := range volumes {
+		if _, err = cli.CreateVolumeSnapshot(ctx, v.MachineID, v.Volume.Name); err != nil {
+			if code := status.Code(err); code == codes.FailedPrecondition || code == codes.Unimplemented {
+				eventID := cliprogress.VolumeEventID(v.Volume.Name, v.MachineName)
+				pw.Event(progress.NewEvent(eventID, progress.Warning, "Snapshot skipped: "+status.Convert(err).Message()))
+				continue
+			}
+			return fmt.Errorf("snapshot volume '%s' on machine '%s': %w", v.Volume.Name, v.MachineName, err)
+		}
+	}
+	return nil
+}
+
 // printFailedContainerLogs fetches the last tail log lines from a container that failed during deployment and prints
 // them using the standard log formatter under the provided header.
 func printFailedContainerLogs(
@@ -0,0 +1,380 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the embedded DNS servers and service discovery on machines.",
+		Long: `Manage the embedded DNS servers and service discovery on machines.
+
+The embedded DNS server on each machine resolves service names in the 'internal' domain and forwards other
+queries to the nameservers from the machine's /etc/resolv.conf by default. You can configure custom upstream
+DNS servers, forward queries for specific zones to other DNS servers (conditional forwarding), cache negative
+answers, log a sample of queries to the machine daemon logs, resolve service names on the machines themselves,
+and advertise services on the LAN using multicast DNS (mDNS). Settings apply to all machines in the cluster
+and can be overridden per machine with --machine.
+
+Machines apply configuration changes within 10 seconds.`,
+	}
+	cmd.AddCommand(
+		newConfigForwardCommand(),
+		newConfigResetCommand(),
+		newConfigSetCommand(),
+		newConfigShowCommand(),
+		newConfigUnforwardCommand(),
+	)
+	return cmd
+}
+
+type configSetOptions struct {
+	hostDNS      bool
+	machine      string
+	mdns         bool
+	negativeTTL  time.Duration
+	queryLogRate float64
+	upstreams    []string
+}
+
+func newConfigSetCommand() *cobra.Command {
+	opts := configSetOptions{}
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the DNS forwarding, query logging, host DNS, or mDNS settings.",
+		Long: `Set the upstream DNS servers to forward external queries to, the maximum duration to cache
+negative answers (the name doesn't exist or has no records of the requested type), the fraction of queries
+to log, whether the machines resolve service names using the embedded DNS server (host DNS), or whether they
+advertise services on the LAN using multicast DNS (mDNS).
+
+The upstreams replace the nameservers from /etc/resolv.conf. Negative caching is disabled by default.
+
+Logged queries include the client address, name, type, response code, answers, and the time to answer. They're
+written to the machine daemon logs that you can view with 'uc machine logs -m MACHINE'. Query logging is disabled
+by default.
+
+With host DNS enabled, the embedded DNS server is registered in systemd-resolved on the machine as the DNS
+server for the 'internal' domain. Processes running on the machine outside containers, such as cron jobs,
+can then resolve service names like 'web.internal'. Other names are still resolved by the DNS servers configured
+on the machine. Host DNS requires systemd-resolved and is disabled by default.
+
+With mDNS enabled, machines running Caddy answer multicast DNS queries on their LAN interfaces for the ingress
+hostnames in the .local domain, e.g. 'jellyfin.local', with their LAN IP address. They also advertise them as
+HTTP or HTTPS services (DNS-SD) so that devices outside the cluster such as TVs or home automation systems can
+find them. mDNS is disabled by default. Enable it only on machines connected to a trusted LAN.`,
+		Example: `  # Forward external queries from all machines to Quad9 and cache negative answers for 30 seconds.
+  uc dns config set --upstream 9.9.9.9,149.112.112.112 --negative-ttl 30s
+
+  # Use a local resolver on machine-1.
+  uc dns config set --upstream 10.0.0.53:5353 -m machine-1
+
+  # Log every tenth query on machine-1 to debug a service name that doesn't resolve, then disable logging.
+  uc dns config set --query-log-rate 0.1 -m machine-1
+  uc dns config set --query-log-rate 0 -m machine-1
+
+  # Resolve service names on all machines.
+  uc dns config set --host-dns
+
+  # Advertise the .local ingress hostnames on the LAN of machine-1.
+  uc dns config set --mdns -m machine-1`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			if !cmd.Flags().Changed("upstream") && !cmd.Flags().Changed("negative-ttl") &&
+				!cmd.Flags().Changed("query-log-rate") && !cmd.Flags().Changed("host-dns") &&
+				!cmd.Flags().Changed("mdns") {
+				return fmt.Errorf("at least one of --upstream, --negative-ttl, --query-log-rate, --host-dns, " +
+					"or --mdns must be specified")
+			}
+
+			return updateDNSConfig(cmd.Context(), uncli, opts.machine, func(f *api.DNSSettings) error {
+				if cmd.Flags().Changed("upstream") {
+					f.Upstreams = cli.ExpandCommaSeparatedValues(opts.upstreams)
+				}
+				if cmd.Flags().Changed("negative-ttl") {
+					f.NegativeTTL = &opts.negativeTTL
+				}
+				if cmd.Flags().Changed("query-log-rate") {
+					f.QueryLogSampleRate = &opts.queryLogRate
+				}
+				if cmd.Flags().Changed("host-dns") {
+					f.HostDNS = &opts.hostDNS
+				}
+				if cmd.Flags().Changed("mdns") {
+					f.MDNS = &opts.mdns
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().BoolVar(&opts.hostDNS, "host-dns", false,
+		"Register the embedded DNS server in systemd-resolved on the machines to resolve service names "+
+			"outside containers. Use --host-dns=false to disable.")
+	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
+		"Name or ID of the machine to set the settings for. (default is all machines)")
+	cmd.Flags().BoolVar(&opts.mdns, "mdns", false,
+		"Advertise the ingress hostnames in the .local domain on the LAN of the machines using multicast DNS. "+
+			"Use --mdns=false to disable.")
+	cmd.Flags().DurationVar(&opts.negativeTTL, "negative-ttl", 0,
+		"Maximum duration to cache negative answers, e.g. 30s or 5m. 0 disables negative caching.")
+	cmd.Flags().Float64Var(&opts.queryLogRate, "query-log-rate", 0,
+		"Fraction of DNS queries from 0 to 1 to log, e.g. 0.1 logs every tenth query on average. "+
+			"0 disables query logging.")
+	cmd.Flags().StringSliceVar(&opts.upstreams, "upstream", nil,
+		"Upstream DNS server in the IP[:PORT] format. Can be specified multiple times or as a comma-separated list.")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func newConfigForwardCommand() *cobra.Command {
+	var machine string
+	cmd := &cobra.Command{
+		Use:   "forward ZONE SERVER [SERVER...]",
+		Short: "Forward queries for a zone to specific DNS servers.",
+		Long: `Forward queries for a zone and its subdomains to specific DNS servers instead of the upstreams,
+for example, to resolve internal corporate domains. The most specific zone matching a query is used.
+
+SERVER is a DNS server in the IP[:PORT] format. The port defaults to 53.`,
+		Example: `  # Resolve corp.example.com and its subdomains using the corporate DNS servers.
+  uc dns config forward corp.example.com 10.0.0.53 10.0.1.53`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			zone := api.NormaliseDNSZone(args[0])
+
+			return updateDNSConfig(cmd.Context(), uncli, machine, func(f *api.DNSSettings) error {
+				if f.Zones == nil {
+					f.Zones = make(map[string][]string)
+				}
+				f.Zones[zone] = args[1:]
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&machine, "machine", "m", "",
+		"Name or ID of the machine to forward the zone on. (default is all machines)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func newConfigUnforwardCommand() *cobra.Command {
+	var machine string
+	cmd := &cobra.Command{
+		Use:   "unforward ZONE [ZONE...]",
+		Short: "Stop forwarding queries for zones to specific DNS servers.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			return updateDNSConfig(cmd.Context(), uncli, machine, func(f *api.DNSSettings) error {
+				for _, z := range args {
+					zone := api.NormaliseDNSZone(z)
+					if _, ok := f.Zones[zone]; !ok {
+						return fmt.Errorf("zone '%s' is not forwarded", zone)
+					}
+					delete(f.Zones, zone)
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&machine, "machine", "m", "",
+		"Name or ID of the machine to stop forwarding the zones on. (default is all machines)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func newConfigResetCommand() *cobra.Command {
+	var machine string
+	cmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Remove the DNS settings.",
+		Long: `Remove the cluster-wide DNS settings or the settings of a machine with --machine.
+The settings of individual machines are kept when the cluster-wide settings are removed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			return updateDNSConfig(cmd.Context(), uncli, machine, func(f *api.DNSSettings) error {
+				*f = api.DNSSettings{}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&machine, "machine", "m", "",
+		"Name or ID of the machine to remove the settings of. (default is the cluster-wide settings)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+// updateDNSConfig updates the cluster-wide DNS settings or the settings of the machine if specified.
+func updateDNSConfig(
+	ctx context.Context, uncli *cli.CLI, machine string, update func(f *api.DNSSettings) error,
+) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	config, err := clusterClient.DNSConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get DNS config: %w", err)
+	}
+
+	if machine == "" {
+		if err = update(&config.DNSSettings); err != nil {
+			return err
+		}
+	} else {
+		m, err := clusterClient.InspectMachine(ctx, machine)
+		if err != nil {
+			return fmt.Errorf("inspect machine '%s': %w", machine, err)
+		}
+		f := config.Machines[m.Machine.Id]
+		if err = update(&f); err != nil {
+			return err
+		}
+		if config.Machines == nil {
+			config.Machines = make(map[string]api.DNSSettings)
+		}
+		config.Machines[m.Machine.Id] = f
+	}
+
+	if err = clusterClient.SetDNSConfig(ctx, config); err != nil {
+		return fmt.Errorf("set DNS config: %w", err)
+	}
+	fmt.Println("DNS configuration updated.")
+	return nil
+}
+
+func newConfigShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show the DNS configuration.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			ctx := cmd.Context()
+
+			clusterClient, err := uncli.ConnectCluster(ctx)
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer clusterClient.Close()
+
+			config, err := clusterClient.DNSConfig(ctx)
+			if err != nil {
+				return fmt.Errorf("get DNS config: %w", err)
+			}
+
+			fmt.Println("Cluster:")
+			printDNSSettings(config.DNSSettings, true)
+			if len(config.Machines) == 0 {
+				return nil
+			}
+
+			machines, err := clusterClient.ListMachines(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("list machines: %w", err)
+			}
+			for _, id := range slices.Sorted(maps.Keys(config.Machines)) {
+				name := id
+				if m := machines.FindByNameOrID(id); m != nil {
+					name = m.Machine.Name
+				}
+				fmt.Printf("\nMachine %s:\n", name)
+				printDNSSettings(config.Machines[id], false)
+			}
+			return nil
+		},
+	}
+}
+
+// printDNSSettings prints the settings. The unset settings are printed as defaults for the cluster
+// and as inherited for machines.
+func printDNSSettings(f api.DNSSettings, cluster bool) {
+	unset := tui.Faint.Render("(inherited from cluster)")
+	if cluster {
+		unset = tui.Faint.Render("(nameservers from /etc/resolv.conf)")
+	}
+	upstreams := unset
+	if len(f.Upstreams) > 0 {
+		upstreams = strings.Join(f.Upstreams, ", ")
+	}
+	fmt.Printf("  Upstreams:    %s\n", upstreams)
+
+	ttl := tui.Faint.Render("(inherited from cluster)")
+	if cluster {
+		ttl = tui.Faint.Render("(disabled)")
+	}
+	if f.NegativeTTL != nil {
+		ttl = f.NegativeTTL.String()
+		if *f.NegativeTTL == 0 {
+			ttl = "disabled"
+		}
+	}
+	fmt.Printf("  Negative TTL: %s\n", ttl)
+
+	queryLog := tui.Faint.Render("(inherited from cluster)")
+	if cluster {
+		queryLog = tui.Faint.Render("(disabled)")
+	}
+	if f.QueryLogSampleRate != nil {
+		queryLog = fmt.Sprintf("%g%% of queries", *f.QueryLogSampleRate*100)
+		if *f.QueryLogSampleRate == 0 {
+			queryLog = "disabled"
+		}
+	}
+	fmt.Printf("  Query log:    %s\n", queryLog)
+
+	hostDNS := tui.Faint.Render("(inherited from cluster)")
+	if cluster {
+		hostDNS = tui.Faint.Render("(disabled)")
+	}
+	if f.HostDNS != nil {
+		hostDNS = "disabled"
+		if *f.HostDNS {
+			hostDNS = "enabled"
+		}
+	}
+	fmt.Printf("  Host DNS:     %s\n", hostDNS)
+
+	mdns := tui.Faint.Render("(inherited from cluster)")
+	if cluster {
+		mdns = tui.Faint.Render("(disabled)")
+	}
+	if f.MDNS != nil {
+		mdns = "disabled"
+		if *f.MDNS {
+			mdns = "enabled"
+		}
+	}
+	fmt.Printf("  mDNS:         %s\n", mdns)
+
+	if len(f.Zones) == 0 {
+		return
+	}
+	fmt.Println("  Forwarded zones:")
+	t := tui.NewTable()
+	for _, zone := range slices.Sorted(maps.Keys(f.Zones)) {
+		t.Row("    "+zone, strings.Join(f.Zones[zone], ", "))
+	}
+	fmt.Println(t)
+}
@@ -11,9 +11,11 @@ func NewRootCommand() *cobra.Command {
 		Long: "Manage cluster domain in Uncloud DNS.\n" +
 			"DNS commands allow you to reserve or release a unique 'xxxxxx.uncld.dev' domain for your " +
 			"cluster. When reserved, Caddy service deployments will automatically update DNS records to route " +
-			"traffic to the services in the cluster.",
+			"traffic to the services in the cluster.\n" +
+			"DNS config commands configure the embedded DNS servers on machines and service discovery on the LAN.",
 	}
 	cmd.AddCommand(
+		NewConfigCommand(),
 		NewReleaseCommand(),
 		NewReserveCommand(),
 		NewShowCommand(),
@@ -0,0 +1,62 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get PROJECT/SERVICE [KEY]",
+		Short: "Show environment variable overrides for a service.",
+		Long: `Show environment variable overrides for a service as KEY=VALUE lines.
+If KEY is specified, only its value is printed.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			project, service, err := parseServiceRef(args[0])
+			if err != nil {
+				return err
+			}
+			key := ""
+			if len(args) > 1 {
+				key = args[1]
+			}
+			return get(cmd.Context(), uncli, project, service, key)
+		},
+	}
+	return cmd
+}
+
+func get(ctx context.Context, uncli *cli.CLI, project, service, key string) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	env, err := client.ServiceEnv(ctx, project, service)
+	if err != nil {
+		return fmt.Errorf("get environment variables: %w", err)
+	}
+
+	if key != "" {
+		value, ok := env[key]
+		if !ok {
+			return fmt.Errorf("environment variable '%s' is not set for service '%s/%s'", key, project, service)
+		}
+		fmt.Println(value)
+		return nil
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(env)) {
+		fmt.Printf("%s=%s\n", k, env[k])
+	}
+	return nil
+}
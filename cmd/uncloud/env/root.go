@@ -0,0 +1,37 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Manage environment variable overrides for Compose services.",
+		Long: `Manage environment variable overrides for Compose services stored in the cluster.
+
+The overrides are merged into the service environment from the Compose file when you run 'uc deploy'.
+They take precedence over the variables in the Compose file. Use them to quickly change non-secret settings
+like feature flags or log levels without editing the Compose file. Don't store secrets in them.
+
+Services are referenced as PROJECT/SERVICE where PROJECT is the Compose project name.`,
+	}
+	cmd.AddCommand(
+		NewGetCommand(),
+		NewSetCommand(),
+		NewUnsetCommand(),
+	)
+	return cmd
+}
+
+// parseServiceRef parses a service reference in the format PROJECT/SERVICE.
+func parseServiceRef(ref string) (string, string, error) {
+	project, service, ok := strings.Cut(ref, "/")
+	if !ok || project == "" || service == "" || strings.Contains(service, "/") {
+		return "", "", fmt.Errorf("invalid service '%s': expected format PROJECT/SERVICE", ref)
+	}
+	return project, service, nil
+}
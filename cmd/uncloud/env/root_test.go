@@ -0,0 +1,89 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServiceRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		ref         string
+		wantProject string
+		wantService string
+		wantErr     bool
+	}{
+		{name: "valid", ref: "myapp/web", wantProject: "myapp", wantService: "web"},
+		{name: "no slash", ref: "web", wantErr: true},
+		{name: "empty project", ref: "/web", wantErr: true},
+		{name: "empty service", ref: "myapp/", wantErr: true},
+		{name: "extra slash", ref: "myapp/web/extra", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, service, err := parseServiceRef(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProject, project)
+			assert.Equal(t, tt.wantService, service)
+		})
+	}
+}
+
+func TestParseEnvAssignments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "multiple",
+			args: []string{"LOG_LEVEL=debug", "WORKERS=4"},
+			want: map[string]string{"LOG_LEVEL": "debug", "WORKERS": "4"},
+		},
+		{
+			name: "empty value",
+			args: []string{"EMPTY="},
+			want: map[string]string{"EMPTY": ""},
+		},
+		{
+			name: "value with equals",
+			args: []string{"DSN=host=db port=5432"},
+			want: map[string]string{"DSN": "host=db port=5432"},
+		},
+		{
+			name: "last wins",
+			args: []string{"A=1", "A=2"},
+			want: map[string]string{"A": "2"},
+		},
+		{name: "missing equals", args: []string{"LOG_LEVEL"}, wantErr: true},
+		{name: "empty key", args: []string{"=value"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env, err := parseEnvAssignments(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, env)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set PROJECT/SERVICE KEY=VALUE...",
+		Short: "Set environment variable overrides for a service.",
+		Example: `  # Enable a feature flag for the web service in the myapp project.
+  uc env set myapp/web FEATURE_NEW_CHECKOUT=true
+
+  # Set multiple variables and apply them.
+  uc env set myapp/web LOG_LEVEL=debug WORKERS=4
+  uc deploy`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			project, service, err := parseServiceRef(args[0])
+			if err != nil {
+				return err
+			}
+			env, err := parseEnvAssignments(args[1:])
+			if err != nil {
+				return err
+			}
+			return set(cmd.Context(), uncli, project, service, env)
+		},
+	}
+	return cmd
+}
+
+func parseEnvAssignments(args []string) (map[string]string, error) {
+	env := make(map[string]string, len(args))
+	for _, a := range args {
+		key, value, ok := strings.Cut(a, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid environment variable '%s': expected format KEY=VALUE", a)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+func set(ctx context.Context, uncli *cli.CLI, project, service string, env map[string]string) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if _, err = client.SetServiceEnv(ctx, project, service, env); err != nil {
+		return fmt.Errorf("set environment variables: %w", err)
+	}
+	fmt.Printf("Environment variables set for service '%s/%s'. Run 'uc deploy' to apply them.\n", project, service)
+
+	return nil
+}
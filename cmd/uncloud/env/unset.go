@@ -0,0 +1,43 @@
+package env
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewUnsetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset PROJECT/SERVICE KEY...",
+		Short: "Remove environment variable overrides for a service.",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			project, service, err := parseServiceRef(args[0])
+			if err != nil {
+				return err
+			}
+			return unset(cmd.Context(), uncli, project, service, args[1:])
+		},
+	}
+	return cmd
+}
+
+func unset(ctx context.Context, uncli *cli.CLI, project, service string, keys []string) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	if _, err = client.UnsetServiceEnv(ctx, project, service, keys...); err != nil {
+		return fmt.Errorf("unset environment variables: %w", err)
+	}
+	fmt.Printf("Environment variables removed for service '%s/%s'. Run 'uc deploy' to apply the change.\n",
+		project, service)
+
+	return nil
+}
@@ -0,0 +1,388 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+)
+
+type inspectOptions struct {
+	images   []string
+	machines []string
+	format   string
+	remote   bool
+}
+
+func NewInspectCommand() *cobra.Command {
+	opts := inspectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "inspect IMAGE [IMAGE...]",
+		Short: "Display detailed information on images across machines.",
+		Long: "Display detailed information on images across machines in the cluster. By default, on all machines.\n" +
+			"For each image, a table compares the image ID, creation time, size, and digest on every machine and " +
+			"highlights the machines where the image differs from the rest.",
+		Example: `  # Compare an image on all machines.
+  uc image inspect myapp:latest
+
+  # Compare an image on specific machines.
+  uc image inspect myapp:latest -m machine1,machine2
+
+  # Also check the image digest in the registry to find outdated machines.
+  uc image inspect postgres:16 --remote
+
+  # Print the image ID on each machine using a Go template.
+  uc image inspect myapp:latest --format '{{.Machine}} {{.ID}}'
+
+  # Print the full image details on each machine as JSON.
+  uc image inspect myapp:latest --format '{{json .}}'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.images = args
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return inspect(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "",
+		"Format the output using the given Go template. The template is executed for the image on each machine.\n"+
+			"Available fields: .Machine, .RemoteDigest (with --remote), and all fields of the Docker image inspect "+
+			"response, e.g. .ID, .Created, .Size, .RepoDigests. Use '{{json .}}' to print all fields as JSON.")
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Filter machines to inspect the image on. Can be specified multiple times or as a comma-separated list. "+
+			"(default is include all machines)")
+	cmd.Flags().BoolVar(&opts.remote, "remote", false,
+		"Look up the image digest in the registry from each machine and compare it with the local image.")
+
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+// inspectFormatData is the data available to the --format template for the image on a machine.
+type inspectFormatData struct {
+	Machine string
+	image.InspectResponse
+	// RemoteDigest is the digest of the image in the registry resolved by the machine. Only set with --remote.
+	RemoteDigest string `json:",omitempty"`
+}
+
+// imageInspectRow represents an image on a single machine for display.
+type imageInspectRow struct {
+	machine string
+	// id is the short image ID.
+	id      string
+	created string
+	size    string
+	// digest is the repository digest of the image or empty if the image hasn't been pulled from or pushed to
+	// a registry.
+	digest string
+	// remoteDigest is the digest of the image in the registry. Only set with --remote.
+	remoteDigest string
+	// remoteErr is the error that occurred when looking up the image in the registry from the machine.
+	remoteErr string
+	// err is the error that occurred when inspecting the image on the machine, e.g. "not found".
+	err  string
+	data inspectFormatData
+}
+
+func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
+	var tmpl *template.Template
+	if opts.format != "" {
+		var err error
+		tmpl, err = template.New("format").Funcs(template.FuncMap{
+			"json": func(v any) (string, error) {
+				b, err := json.Marshal(v)
+				return string(b), err
+			},
+		}).Parse(opts.format)
+		if err != nil {
+			return fmt.Errorf("parse format template: %w", err)
+		}
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	machines := cli.ExpandCommaSeparatedValues(opts.machines)
+	proxyCtx := clusterClient.ProxyMachinesContext(ctx, machines)
+
+	allImages, err := clusterClient.InspectImages(proxyCtx, opts.images)
+	if err != nil {
+		return fmt.Errorf("inspect images: %w", err)
+	}
+
+	for i, name := range opts.images {
+		images := allImages[i]
+		if images == nil && !opts.remote {
+			return fmt.Errorf("image '%s' not found on any machine", name)
+		}
+
+		var remoteImages []api.MachineRemoteImage
+		if opts.remote {
+			if remoteImages, err = clusterClient.InspectRemoteImage(proxyCtx, name); err != nil {
+				return fmt.Errorf("inspect image '%s' in registry: %w", name, err)
+			}
+		}
+
+		rows := imageInspectRows(name, images, remoteImages)
+
+		if tmpl != nil {
+			for _, row := range rows {
+				if row.err != "" {
+					continue
+				}
+				if err = tmpl.Execute(os.Stdout, row.data); err != nil {
+					return fmt.Errorf("execute format template: %w", err)
+				}
+				fmt.Println()
+			}
+			continue
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", tui.Bold.Render("Image:"), tui.FormatImage(name, tui.NoStyle))
+		fmt.Println(formatImageInspectTable(rows, opts.remote))
+		for _, warning := range imageInspectWarnings(rows, opts.remote) {
+			fmt.Println(tui.Yellow.Render(warning))
+		}
+	}
+
+	return nil
+}
+
+// imageInspectRows converts the image inspect responses from machines to rows sorted by machine name.
+func imageInspectRows(name string, images []api.MachineImage, remoteImages []api.MachineRemoteImage) []imageInspectRow {
+	repo := ""
+	if named, err := reference.ParseNormalizedNamed(name); err == nil {
+		repo = named.Name()
+	}
+
+	rowsByMachine := make(map[string]*imageInspectRow)
+	for _, mi := range images {
+		row := &imageInspectRow{machine: mi.Metadata.GetMachineName()}
+		rowsByMachine[row.machine] = row
+
+		if mi.Metadata != nil && mi.Metadata.Error != "" {
+			row.err = mi.Metadata.Error
+			if mi.Metadata.Status != nil && codes.Code(mi.Metadata.Status.Code) == codes.NotFound {
+				row.err = "not found"
+			}
+			continue
+		}
+
+		img := mi.Image
+		row.id = strings.TrimPrefix(img.ID, "sha256:")
+		if len(row.id) > 12 {
+			row.id = row.id[:12]
+		}
+		if created, err := time.Parse(time.RFC3339Nano, img.Created); err == nil {
+			row.created = units.HumanDuration(time.Now().UTC().Sub(created)) + " ago"
+		}
+		row.size = units.HumanSizeWithPrecision(float64(img.Size), 3)
+		row.digest = repoDigest(img.RepoDigests, repo)
+		row.data = inspectFormatData{
+			Machine:         row.machine,
+			InspectResponse: img,
+		}
+	}
+
+	for _, mri := range remoteImages {
+		m := mri.Metadata.GetMachineName()
+		row, ok := rowsByMachine[m]
+		if !ok {
+			row = &imageInspectRow{machine: m, err: "not found"}
+			rowsByMachine[m] = row
+		}
+		if mri.Metadata != nil && mri.Metadata.Error != "" {
+			row.remoteErr = mri.Metadata.Error
+			continue
+		}
+		if mri.Image.Reference != nil {
+			row.remoteDigest = mri.Image.Reference.Digest().String()
+			row.data.RemoteDigest = row.remoteDigest
+		}
+	}
+
+	rows := make([]imageInspectRow, 0, len(rowsByMachine))
+	for _, row := range rowsByMachine {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].machine < rows[j].machine
+	})
+
+	return rows
+}
+
+// repoDigest returns the digest from the repo digests that belongs to the given repository. If there is no such
+// digest, it returns the first one.
+func repoDigest(repoDigests []string, repo string) string {
+	for _, rd := range repoDigests {
+		name, dgst, ok := strings.Cut(rd, "@")
+		if ok && name == repo {
+			return dgst
+		}
+	}
+	if len(repoDigests) > 0 {
+		if _, dgst, ok := strings.Cut(repoDigests[0], "@"); ok {
+			return dgst
+		}
+	}
+	return ""
+}
+
+// mostCommonValue returns the value that appears most often among the rows that have the image. It's used as
+// the reference value to highlight the machines where the image differs.
+func mostCommonValue(rows []imageInspectRow, value func(imageInspectRow) string) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, row := range rows {
+		if row.err != "" {
+			continue
+		}
+		v := value(row)
+		counts[v]++
+		if counts[v] > counts[best] || (counts[v] == counts[best] && v < best) {
+			best = v
+		}
+	}
+	return best
+}
+
+// imageInspectWarnings returns the warnings about the differences of the image across machines.
+func imageInspectWarnings(rows []imageInspectRow, remote bool) []string {
+	var warnings []string
+
+	commonID := mostCommonValue(rows, func(r imageInspectRow) string { return r.id })
+	var differentID, missing, outdated, remoteFailed []string
+	for _, row := range rows {
+		if remote && row.remoteErr != "" {
+			remoteFailed = append(remoteFailed, fmt.Sprintf("%s (%s)", row.machine, row.remoteErr))
+		}
+		if row.err != "" {
+			missing = append(missing, row.machine)
+			continue
+		}
+		if row.id != commonID {
+			differentID = append(differentID, row.machine)
+		}
+		if remote && row.remoteDigest != "" && row.digest != row.remoteDigest {
+			outdated = append(outdated, row.machine)
+		}
+	}
+
+	if len(differentID) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Image ID differs on machines: %s",
+			strings.Join(differentID, ", ")))
+	}
+	if len(missing) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Image is missing or failed to inspect on machines: %s",
+			strings.Join(missing, ", ")))
+	}
+	if len(outdated) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Image differs from the registry on machines: %s",
+			strings.Join(outdated, ", ")))
+	}
+	if len(remoteFailed) > 0 {
+		warnings = append(warnings, fmt.Sprintf("Failed to look up the image in the registry on machines: %s",
+			strings.Join(remoteFailed, ", ")))
+	}
+
+	return warnings
+}
+
+func formatImageInspectTable(rows []imageInspectRow, remote bool) string {
+	commonID := mostCommonValue(rows, func(r imageInspectRow) string { return r.id })
+	commonDigest := mostCommonValue(rows, func(r imageInspectRow) string { return r.digest })
+
+	// highlight renders the value in yellow if it differs from the common value.
+	highlight := func(v, common string) string {
+		if v != common {
+			return tui.Yellow.Render(orDash(v))
+		}
+		return orDash(v)
+	}
+
+	t := tui.NewTable()
+	headers := []string{"MACHINE", "IMAGE ID", "CREATED", "SIZE", "DIGEST"}
+	if remote {
+		headers = append(headers, "REGISTRY DIGEST")
+	}
+	t.Headers(headers...)
+
+	for _, row := range rows {
+		if row.err != "" {
+			values := []string{row.machine, tui.Red.Render(row.err), "", "", ""}
+			if remote {
+				values = append(values, formatRemoteDigest(row))
+			}
+			t.Row(values...)
+			continue
+		}
+
+		values := []string{
+			row.machine,
+			highlight(row.id, commonID),
+			row.created,
+			row.size,
+			highlight(shortDigest(row.digest), shortDigest(commonDigest)),
+		}
+		if remote {
+			values = append(values, formatRemoteDigest(row))
+		}
+		t.Row(values...)
+	}
+
+	return t.String()
+}
+
+// formatRemoteDigest renders the registry digest in green if it matches the local image digest and in yellow if not.
+func formatRemoteDigest(row imageInspectRow) string {
+	switch {
+	case row.remoteErr != "":
+		return tui.Red.Render("error")
+	case row.remoteDigest == "":
+		return "-"
+	case row.digest == row.remoteDigest:
+		return tui.Green.Render(shortDigest(row.remoteDigest))
+	default:
+		return tui.Yellow.Render(shortDigest(row.remoteDigest))
+	}
+}
+
+// shortDigest truncates the digest to the algorithm and the first 12 characters of the hex for display.
+func shortDigest(dgst string) string {
+	algo, hex, ok := strings.Cut(dgst, ":")
+	if !ok || len(hex) <= 12 {
+		return dgst
+	}
+	return algo + ":" + hex[:12]
+}
+
+func orDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
@@ -0,0 +1,115 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	digestA = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	digestB = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+func TestImageInspectRows(t *testing.T) {
+	images := []api.MachineImage{
+		{
+			Metadata: &pb.Metadata{MachineName: "machine-2"},
+			Image: image.InspectResponse{
+				ID:          "sha256:1111111111111111111111",
+				RepoDigests: []string{"docker.io/library/postgres@" + digestA},
+			},
+		},
+		{
+			Metadata: &pb.Metadata{MachineName: "machine-1"},
+			Image: image.InspectResponse{
+				ID:          "sha256:1111111111111111111111",
+				RepoDigests: []string{"docker.io/library/postgres@" + digestA},
+			},
+		},
+		{
+			Metadata: &pb.Metadata{MachineName: "machine-3"},
+			Image: image.InspectResponse{
+				ID:          "sha256:2222222222222222222222",
+				RepoDigests: []string{"docker.io/library/postgres@" + digestB},
+			},
+		},
+		{
+			Metadata: &pb.Metadata{
+				MachineName: "machine-4",
+				Error:       "No such image: postgres:16",
+				Status:      &status.Status{Code: int32(codes.NotFound)},
+			},
+		},
+	}
+
+	ref, err := reference.ParseNormalizedNamed("postgres@" + digestA)
+	require.NoError(t, err)
+	remoteImages := []api.MachineRemoteImage{
+		{
+			Metadata: &pb.Metadata{MachineName: "machine-1"},
+			Image:    api.RemoteImage{Reference: ref.(reference.Canonical)},
+		},
+		{
+			Metadata: &pb.Metadata{MachineName: "machine-3"},
+			Image:    api.RemoteImage{Reference: ref.(reference.Canonical)},
+		},
+		{
+			Metadata: &pb.Metadata{MachineName: "machine-2", Error: "unauthorized"},
+		},
+	}
+
+	rows := imageInspectRows("postgres:16", images, remoteImages)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, "machine-1", rows[0].machine)
+	assert.Equal(t, "111111111111", rows[0].id)
+	assert.Equal(t, digestA, rows[0].digest)
+	assert.Equal(t, digestA, rows[0].remoteDigest)
+	assert.Equal(t, "machine-1", rows[0].data.Machine)
+	assert.Equal(t, digestA, rows[0].data.RemoteDigest)
+
+	assert.Equal(t, "machine-2", rows[1].machine)
+	assert.Equal(t, "unauthorized", rows[1].remoteErr)
+
+	assert.Equal(t, "machine-3", rows[2].machine)
+	assert.Equal(t, "222222222222", rows[2].id)
+	assert.Equal(t, digestB, rows[2].digest)
+
+	assert.Equal(t, "machine-4", rows[3].machine)
+	assert.Equal(t, "not found", rows[3].err)
+
+	assert.Equal(t, []string{
+		"Image ID differs on machines: machine-3",
+		"Image is missing or failed to inspect on machines: machine-4",
+		"Image differs from the registry on machines: machine-3",
+		"Failed to look up the image in the registry on machines: machine-2 (unauthorized)",
+	}, imageInspectWarnings(rows, true))
+	assert.Equal(t, []string{
+		"Image ID differs on machines: machine-3",
+		"Image is missing or failed to inspect on machines: machine-4",
+	}, imageInspectWarnings(rows, false))
+}
+
+func TestRepoDigest(t *testing.T) {
+	repoDigests := []string{
+		"registry.example.com/postgres@" + digestB,
+		"docker.io/library/postgres@" + digestA,
+	}
+
+	assert.Equal(t, digestA, repoDigest(repoDigests, "docker.io/library/postgres"))
+	assert.Equal(t, digestB, repoDigest(repoDigests, "docker.io/library/other"))
+	assert.Equal(t, "", repoDigest(nil, "docker.io/library/postgres"))
+}
+
+func TestShortDigest(t *testing.T) {
+	assert.Equal(t, "sha256:aaaaaaaaaaaa", shortDigest(digestA))
+	assert.Equal(t, "", shortDigest(""))
+}
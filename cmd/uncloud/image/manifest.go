@@ -0,0 +1,189 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/containerd/platforms"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewManifestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Create multi-platform images from images on cluster machines.",
+		Long: `Create multi-platform images from platform-specific images on cluster machines.
+
+In a cluster with machines of different architectures, you can build an image on each machine for its native
+platform using the same tag and then publish them to a registry as a single multi-platform image.
+Docker on the machines must use the containerd image store.`,
+	}
+	cmd.AddCommand(
+		newManifestInspectCommand(),
+		newManifestPushCommand(),
+	)
+	return cmd
+}
+
+type manifestOptions struct {
+	image     string
+	target    string
+	machines  []string
+	platforms []string
+}
+
+func newManifestInspectCommand() *cobra.Command {
+	opts := manifestOptions{}
+	cmd := &cobra.Command{
+		Use:   "inspect IMAGE",
+		Short: "Show the platforms of an image available on cluster machines.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.image = args[0]
+			return manifestInspect(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Machine names or IDs to look for the image on. Can be specified multiple times or as a comma-separated "+
+			"list. (default is all machines)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func newManifestPushCommand() *cobra.Command {
+	opts := manifestOptions{}
+	cmd := &cobra.Command{
+		Use:   "push IMAGE [TARGET]",
+		Short: "Push a multi-platform image created from images on cluster machines to a registry.",
+		Long: `Push the platform-specific variants of IMAGE found on cluster machines to a registry and create
+a multi-platform manifest list tagged as TARGET that references them. TARGET defaults to IMAGE.
+
+If the same platform is available on several machines, the image from the machine with the first name
+in alphabetical order is used. The registry credentials are taken from the local Docker config.`,
+		Example: `  # Build the image on an amd64 and an arm64 machine and publish it as a multi-platform image.
+  uc build
+  uc image manifest push ghcr.io/myorg/myapp:1.0
+
+  # Publish local images under a different name.
+  uc image manifest push myapp:1.0 ghcr.io/myorg/myapp:1.0
+
+  # Publish only specific platforms.
+  uc image manifest push ghcr.io/myorg/myapp:1.0 --platform linux/amd64,linux/arm64`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.image = args[0]
+			opts.target = opts.image
+			if len(args) > 1 {
+				opts.target = args[1]
+			}
+			return manifestPush(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Machine names or IDs to take the platform images from. Can be specified multiple times or as "+
+			"a comma-separated list. (default is all machines)")
+	cmd.Flags().StringSliceVar(&opts.platforms, "platform", nil,
+		"Platforms to include in the manifest list (e.g., linux/amd64,linux/arm64). (default is all found platforms)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func manifestInspect(ctx context.Context, uncli *cli.CLI, opts manifestOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	images, err := clusterClient.ImagePlatforms(ctx, opts.image, cli.ExpandCommaSeparatedValues(opts.machines))
+	if err != nil {
+		return err
+	}
+	printPlatformImages(images)
+	return nil
+}
+
+func manifestPush(ctx context.Context, uncli *cli.CLI, opts manifestOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	images, err := clusterClient.ImagePlatforms(ctx, opts.image, cli.ExpandCommaSeparatedValues(opts.machines))
+	if err != nil {
+		return err
+	}
+	if images, err = filterPlatformImages(images, cli.ExpandCommaSeparatedValues(opts.platforms)); err != nil {
+		return err
+	}
+
+	printPlatformImages(images)
+	fmt.Println()
+
+	var digest string
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		digest, err = clusterClient.PushImageManifest(ctx, opts.target, images)
+		return err
+	}, uncli.ProgressOut(), fmt.Sprintf("Pushing multi-platform image %s", opts.target))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Pushed manifest list %s with digest %s.\n", tui.NameStyle.Render(opts.target), digest)
+	return nil
+}
+
+// filterPlatformImages returns the images matching the platforms. It fails if any of the platforms is missing.
+func filterPlatformImages(images []client.PlatformImage, platformSpecs []string) ([]client.PlatformImage, error) {
+	if len(platformSpecs) == 0 {
+		return images, nil
+	}
+
+	var filtered []client.PlatformImage
+	for _, spec := range platformSpecs {
+		p, err := platforms.Parse(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform '%s': %w", spec, err)
+		}
+		matcher := platforms.OnlyStrict(p)
+
+		i := slices.IndexFunc(images, func(img client.PlatformImage) bool {
+			return matcher.Match(platforms.Platform{
+				OS:           img.Platform.OS,
+				Architecture: img.Platform.Architecture,
+				Variant:      img.Platform.Variant,
+			})
+		})
+		if i == -1 {
+			return nil, fmt.Errorf("platform '%s' not found on any machine", spec)
+		}
+		if !slices.ContainsFunc(filtered, func(img client.PlatformImage) bool {
+			return img.Digest == images[i].Digest
+		}) {
+			filtered = append(filtered, images[i])
+		}
+	}
+	return filtered, nil
+}
+
+func printPlatformImages(images []client.PlatformImage) {
+	t := tui.NewTable()
+	t.Headers("PLATFORM", "MACHINE", "DIGEST")
+	for _, img := range images {
+		t.Row(img.Platform.String(), img.MachineName, img.Digest)
+	}
+	fmt.Println(t)
+}
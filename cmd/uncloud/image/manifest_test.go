@@ -0,0 +1,53 @@
+package image
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterPlatformImages(t *testing.T) {
+	t.Parallel()
+
+	images := []client.PlatformImage{
+		{Platform: v1.Platform{OS: "linux", Architecture: "amd64"}, Digest: "sha256:amd64"},
+		{Platform: v1.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, Digest: "sha256:armv7"},
+		{Platform: v1.Platform{OS: "linux", Architecture: "arm64"}, Digest: "sha256:arm64"},
+	}
+
+	tests := []struct {
+		name      string
+		platforms []string
+		want      []string
+		wantErr   string
+	}{
+		{name: "all", want: []string{"sha256:amd64", "sha256:armv7", "sha256:arm64"}},
+		{name: "subset", platforms: []string{"linux/arm64", "linux/amd64"}, want: []string{"sha256:arm64", "sha256:amd64"}},
+		{name: "normalised", platforms: []string{"linux/arm64/v8", "linux/arm/v7"}, want: []string{"sha256:arm64", "sha256:armv7"}},
+		{name: "duplicate", platforms: []string{"linux/amd64", "linux/amd64"}, want: []string{"sha256:amd64"}},
+		{name: "missing", platforms: []string{"linux/s390x"}, wantErr: "platform 'linux/s390x' not found"},
+		{name: "invalid", platforms: []string{"linux/"}, wantErr: "invalid platform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filtered, err := filterPlatformImages(images, tt.platforms)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var digests []string
+			for _, img := range filtered {
+				digests = append(digests, img.Digest)
+			}
+			assert.Equal(t, tt.want, digests)
+		})
+	}
+}
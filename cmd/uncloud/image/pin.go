@@ -0,0 +1,119 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewPinCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pin IMAGE [IMAGE...]",
+		Short: "Protect images from being removed by image prune.",
+		Long: `Pin one or more images to protect them from being removed by 'uc image prune --all' on all machines.
+The list of pinned images is stored in the cluster. An image without a tag or digest pins all tags of the repository.`,
+		Example: `  # Pin a specific image tag.
+  uc image pin postgres:17
+
+  # Pin all tags of an image repository.
+  uc image pin ghcr.io/myorg/base`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return pin(cmd.Context(), uncli, args)
+		},
+	}
+
+	return cmd
+}
+
+func pin(ctx context.Context, uncli *cli.CLI, images []string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if _, err = clusterClient.PinImage(ctx, images...); err != nil {
+		return fmt.Errorf("pin images: %w", err)
+	}
+	for _, img := range images {
+		fmt.Printf("Image '%s' pinned.\n", img)
+	}
+
+	return nil
+}
+
+func NewUnpinCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unpin IMAGE [IMAGE...]",
+		Short: "Allow pinned images to be removed by image prune.",
+		Long: `Unpin one or more images so they can be removed by 'uc image prune --all' again.
+The image references must match the pinned ones as shown by 'uc image ls-pinned'.`,
+		Example: `  # Unpin an image.
+  uc image unpin postgres:17`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return unpin(cmd.Context(), uncli, args)
+		},
+	}
+
+	return cmd
+}
+
+func unpin(ctx context.Context, uncli *cli.CLI, images []string) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if _, err = clusterClient.UnpinImage(ctx, images...); err != nil {
+		return fmt.Errorf("unpin images: %w", err)
+	}
+	for _, img := range images {
+		fmt.Printf("Image '%s' unpinned.\n", img)
+	}
+
+	return nil
+}
+
+func NewListPinnedCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ls-pinned",
+		Short: "List pinned images protected from image prune.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listPinned(cmd.Context(), uncli)
+		},
+	}
+
+	return cmd
+}
+
+func listPinned(ctx context.Context, uncli *cli.CLI) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	pinned, err := clusterClient.ListPinnedImages(ctx)
+	if err != nil {
+		return fmt.Errorf("list pinned images: %w", err)
+	}
+
+	if len(pinned) == 0 {
+		fmt.Println("No pinned images.")
+		return nil
+	}
+	for _, img := range pinned {
+		fmt.Println(img)
+	}
+
+	return nil
+}
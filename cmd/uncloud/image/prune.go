@@ -0,0 +1,70 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type pruneOptions struct {
+	all      bool
+	machines []string
+}
+
+func NewPruneCommand() *cobra.Command {
+	opts := pruneOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove unused images on machines in the cluster.",
+		Long: `Remove dangling images on machines in the cluster. By default, on all machines.
+Use --all to remove all images not used by any container except the pinned ones (see 'uc image pin').
+The command exits with a non-zero code if pruning fails on any machine.`,
+		Example: `  # Remove dangling images on all machines.
+  uc image prune
+
+  # Remove all images not used by any container on all machines.
+  uc image prune --all
+
+  # Remove dangling images on specific machines.
+  uc image prune -m machine1,machine2`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return prune(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.all, "all", "a", false,
+		"Remove all images not used by any container, not just dangling ones. Pinned images are kept.")
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Machine names or IDs to prune images on. Can be specified multiple times or as a comma-separated list. "+
+			"(default is all machines)")
+
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func prune(ctx context.Context, uncli *cli.CLI, opts pruneOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	results, err := clusterClient.PruneImages(ctx, client.PruneImagesOptions{
+		Machines: cli.ExpandCommaSeparatedValues(opts.machines),
+		All:      opts.all,
+	})
+	if err != nil {
+		return fmt.Errorf("prune images: %w", err)
+	}
+
+	fmt.Println(formatImageResults(results, formatDeletedImages))
+	return imageResultsError("prune images", results, false)
+}
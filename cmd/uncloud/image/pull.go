@@ -0,0 +1,81 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type pullOptions struct {
+	image    string
+	machines []string
+	timings  bool
+}
+
+func NewPullCommand() *cobra.Command {
+	opts := pullOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "pull IMAGE",
+		Short: "Pull an image from a registry on machines in the cluster.",
+		Long: `Pull an image from a registry on machines in the cluster. By default, on all machines.
+Machines use the registry credentials from the local Docker config or their own Docker config if available.
+Pulls that fail because of network or temporary registry errors are retried up to 5 times. A retried pull reuses
+the layers that have already been downloaded.
+The command exits with a non-zero code if pulling fails on any machine.`,
+		Example: `  # Pull an image on all machines.
+  uc image pull nginx:1.29
+
+  # Pull an image on specific machines.
+  uc image pull nginx:1.29 -m machine1,machine2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			opts.image = args[0]
+			return pull(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Machine names or IDs to pull the image on. Can be specified multiple times or as a comma-separated list. "+
+			"(default is all machines)")
+
+	cli.AddTimingsFlag(cmd, &opts.timings)
+
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func pull(ctx context.Context, uncli *cli.CLI, opts pullOptions) error {
+	ctx, printTimings := cli.WithTimings(ctx, opts.timings)
+	defer printTimings()
+
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	var results []api.MachineImageResult
+	err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		results, err = clusterClient.PullImage(ctx, opts.image, client.PullImageOptions{
+			Machines: cli.ExpandCommaSeparatedValues(opts.machines),
+		})
+		return err
+	}, uncli.ProgressOut(), fmt.Sprintf("Pulling image %s", opts.image))
+	if err != nil {
+		return fmt.Errorf("pull image: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println(formatImageResults(results, func(api.MachineImageResult) string { return "" }))
+	return imageResultsError(fmt.Sprintf("pull image '%s'", opts.image), results, false)
+}
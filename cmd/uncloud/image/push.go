@@ -16,6 +16,8 @@ type pushOptions struct {
 	image    string
 	machines []string
 	platform string
+	stream   bool
+	timings  bool
 }
 
 func NewPushCommand() *cobra.Command {
@@ -25,7 +27,11 @@ func NewPushCommand() *cobra.Command {
 		Use:   "push IMAGE",
 		Short: "Upload a local Docker image to the cluster.",
 		Long: `Upload a local Docker image to the cluster transferring only the missing layers.
-The image is uploaded to all cluster machines (default) or the specified machine(s).`,
+The image is uploaded to all cluster machines (default) or the specified machine(s).
+
+Use --stream to upload the whole image as a tarball over the cluster connection and load it into Docker
+on the machines instead. It's slower for images that share layers with the images already on the machines
+but doesn't require the containerd image store on the machines or a proxy container in the local Docker.`,
 		Example: `  # Push image to all machines in the cluster.
   uc image push myapp:latest
 
@@ -36,7 +42,10 @@ The image is uploaded to all cluster machines (default) or the specified machine
   uc image push myapp:latest -m machine1,machine2,machine3
 
   # Push a specific platform of a multi-platform image.
-  uc image push myapp:latest --platform linux/amd64`,
+  uc image push myapp:latest --platform linux/amd64
+
+  # Stream the whole image to machines that don't use the containerd image store.
+  uc image push myapp:latest --stream`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
@@ -54,6 +63,11 @@ The image is uploaded to all cluster machines (default) or the specified machine
 		"Push a specific platform of a multi-platform image (e.g., linux/amd64, linux/arm64).\n"+
 			"Local Docker must be configured to use containerd image store to support multi-platform images.",
 	)
+	cmd.Flags().BoolVar(&opts.stream, "stream", false,
+		"Upload the whole image as a tarball over the cluster connection and load it into Docker on the machines\n"+
+			"instead of pushing only the missing layers to their registry.")
+
+	cli.AddTimingsFlag(cmd, &opts.timings)
 
 	completion.MachinesFlag(cmd)
 
@@ -61,6 +75,9 @@ The image is uploaded to all cluster machines (default) or the specified machine
 }
 
 func push(ctx context.Context, uncli *cli.CLI, opts pushOptions) error {
+	ctx, printTimings := cli.WithTimings(ctx, opts.timings)
+	defer printTimings()
+
 	clusterClient, err := uncli.ConnectCluster(ctx)
 	if err != nil {
 		return fmt.Errorf("connect to cluster: %w", err)
@@ -68,7 +85,7 @@ func push(ctx context.Context, uncli *cli.CLI, opts pushOptions) error {
 	defer clusterClient.Close()
 
 	machines := cli.ExpandCommaSeparatedValues(opts.machines)
-	pushOpts := client.PushImageOptions{}
+	pushOpts := client.PushImageOptions{Stream: opts.stream}
 
 	// Special handling for an explicit "all" keyword to push to all machines.
 	if len(machines) == 1 && machines[0] == "all" {
@@ -0,0 +1,120 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// formatImageResults formats the per-machine results of an image operation as a table. The details function
+// returns the text for the DETAILS column of a successful result.
+func formatImageResults(results []api.MachineImageResult, details func(api.MachineImageResult) string) string {
+	results = slices.Clone(results)
+	slices.SortFunc(results, func(a, b api.MachineImageResult) int {
+		return strings.Compare(a.MachineName, b.MachineName)
+	})
+
+	t := tui.NewTable()
+	t.Headers("MACHINE", "STATUS", "DETAILS")
+	for _, r := range results {
+		if r.Succeeded() {
+			t.Row(r.MachineName, tui.Green.Render(string(r.Status)), details(r))
+			continue
+		}
+
+		errMsg := ""
+		if r.Err != nil {
+			errMsg = r.Err.Error()
+		}
+		t.Row(r.MachineName, tui.Red.Render(string(r.Status)), errMsg)
+	}
+
+	return t.String()
+}
+
+// imageResultsError returns an error if the image operation failed on any machine so that the command exits
+// with a non-zero code. The exit code is cli.ExitCodePartialFailure if the operation succeeded on some machines.
+// If allowNotFound is true, machines that don't have the image aren't considered failed unless none of the machines
+// has it.
+func imageResultsError(action string, results []api.MachineImageResult, allowNotFound bool) error {
+	succeeded, err := imageResultsFailure(action, results, allowNotFound)
+	if err != nil && succeeded {
+		return cli.WithExitCode(cli.ExitCodePartialFailure, err)
+	}
+	return err
+}
+
+// imagesResultsError is like imageResultsError but for an operation on several images. The exit code is computed
+// once over the results of all images and machines so it doesn't depend on the order of the images: it's
+// cli.ExitCodePartialFailure if the operation succeeded on any machine for any image. errs are the errors of
+// the images that failed without per-machine results, e.g. because the request to the cluster failed.
+func imagesResultsError(
+	action string, images []string, results [][]api.MachineImageResult, allowNotFound bool, errs []error,
+) error {
+	anySucceeded := false
+	for i, img := range images {
+		succeeded, err := imageResultsFailure(fmt.Sprintf("%s '%s'", action, img), results[i], allowNotFound)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		anySucceeded = anySucceeded || succeeded
+	}
+
+	err := errors.Join(errs...)
+	if err != nil && anySucceeded {
+		return cli.WithExitCode(cli.ExitCodePartialFailure, err)
+	}
+	return err
+}
+
+// imageResultsFailure returns an error if the image operation failed on any machine and whether it succeeded
+// on at least one machine.
+func imageResultsFailure(action string, results []api.MachineImageResult, allowNotFound bool) (bool, error) {
+	var failed, notFound []string
+	for _, r := range results {
+		switch r.Status {
+		case api.ImageOperationSucceeded:
+		case api.ImageOperationNotFound:
+			notFound = append(notFound, r.MachineName)
+		default:
+			failed = append(failed, r.MachineName)
+		}
+	}
+
+	if !allowNotFound || len(notFound) == len(results) {
+		failed = append(failed, notFound...)
+	}
+	succeeded := len(failed) < len(results)
+	if len(failed) == 0 {
+		return succeeded, nil
+	}
+
+	slices.Sort(failed)
+	return succeeded, fmt.Errorf("failed to %s on %d of %d machine(s): %s",
+		action, len(failed), len(results), strings.Join(failed, ", "))
+}
+
+// formatDeletedImages summarises the images untagged and deleted by a remove or prune operation.
+func formatDeletedImages(r api.MachineImageResult) string {
+	untagged, deleted := 0, 0
+	for _, d := range r.Deleted {
+		if d.Untagged != "" {
+			untagged++
+		}
+		if d.Deleted != "" {
+			deleted++
+		}
+	}
+
+	details := fmt.Sprintf("%d untagged, %d deleted", untagged, deleted)
+	if r.SpaceReclaimed > 0 {
+		details += fmt.Sprintf(", %s reclaimed", units.HumanSize(float64(r.SpaceReclaimed)))
+	}
+	return details
+}
@@ -0,0 +1,166 @@
+package image
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageResultsError(t *testing.T) {
+	t.Parallel()
+
+	succeeded := func(name string) api.MachineImageResult {
+		return api.MachineImageResult{MachineName: name, Status: api.ImageOperationSucceeded}
+	}
+	withStatus := func(name string, status api.ImageOperationStatus) api.MachineImageResult {
+		return api.MachineImageResult{MachineName: name, Status: status, Err: errors.New(string(status))}
+	}
+
+	tests := []struct {
+		name          string
+		results       []api.MachineImageResult
+		allowNotFound bool
+		wantErr       string
+		wantExitCode  int
+	}{
+		{
+			name:    "all succeeded",
+			results: []api.MachineImageResult{succeeded("m1"), succeeded("m2")},
+		},
+		{
+			name: "not found allowed on some machines",
+			results: []api.MachineImageResult{
+				succeeded("m1"), withStatus("m2", api.ImageOperationNotFound),
+			},
+			allowNotFound: true,
+		},
+		{
+			name: "not found on all machines",
+			results: []api.MachineImageResult{
+				withStatus("m1", api.ImageOperationNotFound), withStatus("m2", api.ImageOperationNotFound),
+			},
+			allowNotFound: true,
+			wantErr:       "failed to remove on 2 of 2 machine(s): m1, m2",
+			wantExitCode:  cli.ExitCodeError,
+		},
+		{
+			name: "not found not allowed",
+			results: []api.MachineImageResult{
+				succeeded("m1"), withStatus("m2", api.ImageOperationNotFound),
+			},
+			wantErr:      "failed to remove on 1 of 2 machine(s): m2",
+			wantExitCode: cli.ExitCodePartialFailure,
+		},
+		{
+			name: "in use and permission denied",
+			results: []api.MachineImageResult{
+				withStatus("m3", api.ImageOperationInUse),
+				succeeded("m2"),
+				withStatus("m1", api.ImageOperationPermissionDenied),
+			},
+			allowNotFound: true,
+			wantErr:       "failed to remove on 2 of 3 machine(s): m1, m3",
+			wantExitCode:  cli.ExitCodePartialFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := imageResultsError("remove", tt.results, tt.allowNotFound)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantExitCode, cli.ExitCode(err))
+			}
+		})
+	}
+}
+
+func TestImagesResultsError(t *testing.T) {
+	t.Parallel()
+
+	succeeded := api.MachineImageResult{MachineName: "m1", Status: api.ImageOperationSucceeded}
+	partial := []api.MachineImageResult{
+		{MachineName: "m1", Status: api.ImageOperationSucceeded},
+		{MachineName: "m2", Status: api.ImageOperationInUse, Err: errors.New("in use")},
+	}
+	failed := []api.MachineImageResult{
+		{MachineName: "m1", Status: api.ImageOperationInUse, Err: errors.New("in use")},
+		{MachineName: "m2", Status: api.ImageOperationPermissionDenied, Err: errors.New("permission denied")},
+	}
+	notFound := []api.MachineImageResult{
+		{MachineName: "m1", Status: api.ImageOperationNotFound, Err: errors.New("not found")},
+	}
+
+	tests := []struct {
+		name         string
+		images       []string
+		results      [][]api.MachineImageResult
+		errs         []error
+		wantErr      string
+		wantExitCode int
+	}{
+		{
+			name:    "all succeeded",
+			images:  []string{"a", "b"},
+			results: [][]api.MachineImageResult{{succeeded}, {succeeded}},
+		},
+		{
+			name:    "failed image before partially failed",
+			images:  []string{"a", "b"},
+			results: [][]api.MachineImageResult{failed, partial},
+			wantErr: "failed to remove image 'a' on 2 of 2 machine(s): m1, m2\n" +
+				"failed to remove image 'b' on 1 of 2 machine(s): m2",
+			wantExitCode: cli.ExitCodePartialFailure,
+		},
+		{
+			name:    "partially failed image before failed",
+			images:  []string{"b", "a"},
+			results: [][]api.MachineImageResult{partial, failed},
+			wantErr: "failed to remove image 'b' on 1 of 2 machine(s): m2\n" +
+				"failed to remove image 'a' on 2 of 2 machine(s): m1, m2",
+			wantExitCode: cli.ExitCodePartialFailure,
+		},
+		{
+			name:         "failed image after succeeded",
+			images:       []string{"a", "b"},
+			results:      [][]api.MachineImageResult{{succeeded}, notFound},
+			wantErr:      "failed to remove image 'b' on 1 of 1 machine(s): m1",
+			wantExitCode: cli.ExitCodePartialFailure,
+		},
+		{
+			name:    "all failed",
+			images:  []string{"a", "b"},
+			results: [][]api.MachineImageResult{failed, notFound},
+			wantErr: "failed to remove image 'a' on 2 of 2 machine(s): m1, m2\n" +
+				"failed to remove image 'b' on 1 of 1 machine(s): m1",
+			wantExitCode: cli.ExitCodeError,
+		},
+		{
+			name:         "request failed",
+			errs:         []error{errors.New("remove images 'a', 'b': connection refused")},
+			wantErr:      "remove images 'a', 'b': connection refused",
+			wantExitCode: cli.ExitCodeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := imagesResultsError("remove image", tt.images, tt.results, true, tt.errs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantExitCode, cli.ExitCode(err))
+			}
+		})
+	}
+}
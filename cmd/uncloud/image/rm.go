@@ -0,0 +1,126 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	images   []string
+	machines []string
+	force    bool
+	failFast bool
+}
+
+func NewRemoveCommand() *cobra.Command {
+	opts := removeOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "rm IMAGE [IMAGE...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove images from machines in the cluster.",
+		Long: `Remove one or more images from machines in the cluster. By default, from all machines.
+Machines that don't have the image are reported as not-found but are only considered a failure if none of the machines
+has the image. The command exits with a non-zero code if the removal fails on any machine. Use --fail-fast to stop
+at the first image that fails to be removed instead of continuing with the remaining images. Without --fail-fast,
+all images are removed in a single request to each machine.`,
+		Example: `  # Remove an image from all machines.
+  uc image rm myapp:1.0
+
+  # Remove an image from specific machines.
+  uc image rm myapp:1.0 -m machine1,machine2
+
+  # Remove an image even if it's used by stopped containers.
+  uc image rm -f myapp:1.0`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			opts.images = args
+			return remove(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cli.AddFailFastFlags(cmd, &opts.failFast)
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false,
+		"Force removal of the image even if it's used by stopped containers or has other tags.")
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Machine names or IDs to remove the image from. Can be specified multiple times or as a comma-separated list. "+
+			"(default is all machines)")
+
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func remove(ctx context.Context, uncli *cli.CLI, opts removeOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	rmOpts := client.RemoveImageOptions{
+		Machines: cli.ExpandCommaSeparatedValues(opts.machines),
+		Force:    opts.force,
+	}
+
+	// All images are removed in one request per machine. With --fail-fast, they're removed one at a time instead
+	// to not remove the images after the first one that fails.
+	batches := [][]string{opts.images}
+	if opts.failFast {
+		batches = make([][]string, len(opts.images))
+		for i, img := range opts.images {
+			batches[i] = []string{img}
+		}
+	}
+
+	var (
+		errs          []error
+		removedImages []string
+		removed       [][]api.MachineImageResult
+	)
+	for _, batch := range batches {
+		results, err := clusterClient.RemoveImages(ctx, batch, rmOpts)
+		if err != nil {
+			noun := "image"
+			if len(batch) > 1 {
+				noun = "images"
+			}
+			errs = append(errs, fmt.Errorf("remove %s '%s': %w", noun, strings.Join(batch, "', '"), err))
+			if opts.failFast {
+				break
+			}
+			continue
+		}
+
+		failed := false
+		for i, img := range batch {
+			if len(removedImages) > 0 {
+				fmt.Println()
+			}
+			removedImages = append(removedImages, img)
+			removed = append(removed, results[i])
+
+			fmt.Printf("%s %s\n", tui.Bold.Render("Image:"), img)
+			fmt.Println(formatImageResults(results[i], formatDeletedImages))
+			if _, err = imageResultsFailure("remove image", results[i], true); err != nil {
+				failed = true
+			}
+		}
+		if failed && opts.failFast {
+			break
+		}
+	}
+
+	// Images skipped after a failure with --fail-fast are not counted as succeeded.
+	return imagesResultsError("remove image", removedImages, removed, true, errs)
+}
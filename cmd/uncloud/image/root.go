@@ -11,8 +11,16 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		NewInspectCommand(),
 		NewListCommand(),
+		NewListPinnedCommand(),
+		NewManifestCommand(),
+		NewPinCommand(),
+		NewPruneCommand(),
+		NewPullCommand(),
 		NewPushCommand(),
+		NewRemoveCommand(),
+		NewUnpinCommand(),
 	)
 
 	return cmd
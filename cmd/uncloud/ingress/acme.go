@@ -0,0 +1,203 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func NewACMEDNSCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "acme-dns",
+		Short: "Manage the ACME DNS-01 challenge for issuing certificates.",
+		Long: `Manage the ACME DNS-01 challenge for issuing certificates.
+
+By default, Caddy proves the ownership of a domain to Let's Encrypt by serving a challenge on port 80 or 443.
+With the DNS-01 challenge, Caddy creates a TXT record using the API of your DNS provider instead. This lets
+you issue certificates for machines that aren't reachable from the internet and issue wildcard certificates.
+
+The Caddy image must include the module for your DNS provider. The official Caddy image doesn't include any.`,
+	}
+	cmd.AddCommand(
+		newACMEDNSSetCommand(),
+		newACMEDNSShowCommand(),
+		newACMEDNSUnsetCommand(),
+	)
+	return cmd
+}
+
+type acmeDNSSetOptions struct {
+	credentials []string
+	domains     []string
+	resolvers   []string
+}
+
+func newACMEDNSSetCommand() *cobra.Command {
+	opts := acmeDNSSetOptions{}
+	cmd := &cobra.Command{
+		Use:   "set PROVIDER",
+		Short: "Use the ACME DNS-01 challenge with a DNS provider.",
+		Long: `Use the ACME DNS-01 challenge with a DNS provider to issue certificates for HTTPS sites.
+
+PROVIDER is the name of a Caddy DNS provider module from https://github.com/caddy-dns, for example cloudflare.
+Credentials are sealed with the cluster public key before they're stored in the cluster. Only the machines can
+decrypt them. If you omit the value of a credential, you're prompted to enter it.`,
+		Example: `  # Use Cloudflare for all HTTPS sites. Prompts for the API token.
+  uc ingress acme-dns set cloudflare --credential api_token
+
+  # Issue a wildcard certificate for *.apps.example.com with Route 53.
+  uc ingress acme-dns set route53 --domain '*.apps.example.com' \
+    --credential access_key_id=AKIA... --credential secret_access_key --credential region=us-east-1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			config := api.ACMEDNS{
+				Provider:  args[0],
+				Domains:   opts.domains,
+				Resolvers: opts.resolvers,
+			}
+			creds, err := parseCredentials(config.Provider, opts.credentials)
+			if err != nil {
+				return err
+			}
+			if len(creds) > 0 {
+				config.Credentials = creds
+			}
+			return setACMEDNS(cmd.Context(), uncli, config)
+		},
+	}
+	cmd.Flags().StringArrayVar(&opts.credentials, "credential", nil,
+		"Credential option of the DNS provider in the KEY=VALUE format. The value can be a sealed secret.\n"+
+			"Omit the value to enter it interactively. Can be specified multiple times.")
+	cmd.Flags().StringSliceVarP(&opts.domains, "domain", "d", nil,
+		"Use the DNS-01 challenge only for this domain and its subdomains. A wildcard domain such as\n"+
+			"'*.example.com' issues a single wildcard certificate for its subdomains. Can be specified multiple "+
+			"times.\n(default is all HTTPS sites)")
+	cmd.Flags().StringSliceVar(&opts.resolvers, "resolver", nil,
+		"DNS resolver to check the propagation of challenge records. Can be specified multiple times.")
+	return cmd
+}
+
+// parseCredentials parses the KEY=VALUE credential options and prompts for the missing values. If no credentials
+// are specified for a well-known provider, it prompts for all of its options.
+func parseCredentials(provider string, specs []string) (map[string]string, error) {
+	if len(specs) == 0 && tui.IsStdinTerminal() {
+		specs = api.ACMEDNSProviders[provider]
+	}
+
+	creds := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		key, value, ok := strings.Cut(spec, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid credential '%s': expected KEY=VALUE", spec)
+		}
+		if !ok {
+			if !tui.IsStdinTerminal() {
+				return nil, fmt.Errorf("credential '%s' has no value: specify it as %s=VALUE", key, key)
+			}
+			fmt.Fprintf(os.Stderr, "%s: ", key)
+			v, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Fprintln(os.Stderr)
+			if err != nil {
+				return nil, fmt.Errorf("read credential '%s': %w", key, err)
+			}
+			value = string(v)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("credential '%s' is empty", key)
+		}
+		creds[key] = value
+	}
+	return creds, nil
+}
+
+func setACMEDNS(ctx context.Context, uncli *cli.CLI, config api.ACMEDNS) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if err = clusterClient.SetACMEDNS(ctx, config); err != nil {
+		return fmt.Errorf("set ACME DNS-01 challenge: %w", err)
+	}
+	fmt.Printf("Caddy will use the ACME DNS-01 challenge with provider '%s' for new certificates.\n",
+		config.Provider)
+	fmt.Println(tui.Faint.Render("If the Caddy image doesn't include the provider module, the machines keep " +
+		"using the default challenges.\nCheck 'uc caddy config' for validation errors."))
+	return nil
+}
+
+func newACMEDNSShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show the ACME DNS-01 challenge configuration.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			clusterClient, err := uncli.ConnectCluster(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer clusterClient.Close()
+
+			config, err := clusterClient.ACMEDNS(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("get ACME DNS-01 challenge: %w", err)
+			}
+			if config == nil {
+				fmt.Println("ACME DNS-01 challenge is not configured. Caddy uses the default challenges.")
+				return nil
+			}
+
+			fmt.Printf("Provider:    %s\n", config.Provider)
+			domains := "all HTTPS sites"
+			if len(config.Domains) > 0 {
+				domains = strings.Join(config.Domains, ", ")
+			}
+			fmt.Printf("Domains:     %s\n", domains)
+			if len(config.Credentials) > 0 {
+				fmt.Printf("Credentials: %s %s\n", strings.Join(slices.Sorted(maps.Keys(config.Credentials)), ", "),
+					tui.Faint.Render("(sealed)"))
+			}
+			if len(config.Resolvers) > 0 {
+				fmt.Printf("Resolvers:   %s\n", strings.Join(config.Resolvers, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+func newACMEDNSUnsetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset",
+		Short: "Stop using the ACME DNS-01 challenge and remove the DNS provider credentials.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			clusterClient, err := uncli.ConnectCluster(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer clusterClient.Close()
+
+			if err = clusterClient.RemoveACMEDNS(cmd.Context()); err != nil {
+				return fmt.Errorf("remove ACME DNS-01 challenge: %w", err)
+			}
+			fmt.Println("ACME DNS-01 challenge removed. Caddy will use the default challenges for new certificates.")
+			return nil
+		},
+	}
+}
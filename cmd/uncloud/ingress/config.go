@@ -0,0 +1,198 @@
+package ingress
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/quick"
+	composecli "github.com/compose-spec/compose-go/v2/cli"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+const (
+	configFormatCaddyfile = "caddyfile"
+	configFormatJSON      = "json"
+)
+
+type configOptions struct {
+	format   string
+	machine  string
+	noColor  bool
+	validate bool
+	files    []string
+	profiles []string
+	services []string
+}
+
+func NewConfigCommand() *cobra.Command {
+	opts := configOptions{}
+	cmd := &cobra.Command{
+		Use:   "config [FLAGS] [SERVICE...]",
+		Short: "Show the ingress proxy configuration or check a Compose file for route conflicts.",
+		Long: `Show the ingress proxy configuration or check a Compose file for route conflicts.
+
+By default, print the Caddyfile generated on the connected machine or a specified one. With --format json, print
+the JSON config currently loaded into Caddy on the machine instead.
+
+With --validate, check the ingress routes of the services in a Compose file for conflicts with each other and with
+the services running in the cluster without deploying anything. A route conflicts if multiple services define the
+same hostname or site address but only one definition can be used. Routes shared by services with the same settings
+are reported as warnings because Caddy load balances requests across all of them.`,
+		Example: `  # Show the Caddyfile generated on the connected machine.
+  uc ingress config
+
+  # Show the JSON config loaded into Caddy on a specific machine.
+  uc ingress config --format json -m machine-1
+
+  # Check the routes in compose.yaml for conflicts before deploying it.
+  uc ingress config --validate
+
+  # Check the routes of specific services in a Compose file.
+  uc ingress config --validate -f compose.prod.yaml web api`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.services = args
+
+			if opts.validate {
+				if cmd.Flags().Changed("format") || cmd.Flags().Changed("machine") {
+					return errors.New("--format and --machine can't be used with --validate")
+				}
+				return validateRoutes(cmd.Context(), uncli, opts)
+			}
+			if len(args) > 0 || len(opts.files) > 0 || len(opts.profiles) > 0 {
+				return errors.New("--file, --profile, and services can only be used with --validate")
+			}
+			return showConfig(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
+		"One or more Compose files to check with --validate. (default compose.yaml)")
+	cmd.Flags().StringVar(&opts.format, "format", configFormatCaddyfile,
+		fmt.Sprintf("Output format: '%s' or '%s'.", configFormatCaddyfile, configFormatJSON))
+	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
+		"Name or ID of the machine to get the configuration from. (default is connected machine)")
+	cmd.Flags().BoolVar(&opts.noColor, "no-color", false,
+		"Disable syntax highlighting for the output.")
+	cmd.Flags().StringSliceVarP(&opts.profiles, "profile", "p", nil,
+		"One or more Compose profiles to enable with --validate.")
+	cmd.Flags().BoolVar(&opts.validate, "validate", false,
+		"Check the ingress routes of services in a Compose file for conflicts instead of showing the configuration.")
+
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func showConfig(ctx context.Context, uncli *cli.CLI, opts configOptions) error {
+	if opts.format != configFormatCaddyfile && opts.format != configFormatJSON {
+		return fmt.Errorf("invalid format '%s', must be '%s' or '%s'",
+			opts.format, configFormatCaddyfile, configFormatJSON)
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if opts.machine != "" {
+		ctx = clusterClient.ProxySingleMachineContext(ctx, opts.machine)
+	}
+
+	var config, lexer string
+	if opts.format == configFormatJSON {
+		resp, err := clusterClient.Caddy.GetJSONConfig(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("get Caddy JSON config: %w", err)
+		}
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, resp.Config, "", "  "); err != nil {
+			return fmt.Errorf("format Caddy JSON config: %w", err)
+		}
+		config, lexer = buf.String()+"\n", "json"
+	} else {
+		resp, err := clusterClient.Caddy.GetConfig(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("get Caddy config: %w", err)
+		}
+		config, lexer = resp.Caddyfile, "caddy"
+	}
+
+	if opts.noColor {
+		fmt.Print(config)
+	} else if err = quick.Highlight(os.Stdout, config, lexer, "terminal256", "monokai"); err != nil {
+		// If highlighting fails, fall back to plain output.
+		fmt.Print(config)
+	}
+
+	return nil
+}
+
+func validateRoutes(ctx context.Context, uncli *cli.CLI, opts configOptions) error {
+	project, err := compose.LoadProject(ctx, opts.files, composecli.WithDefaultProfiles(opts.profiles...))
+	if err != nil {
+		return cli.WithExitCode(cli.ExitCodeValidation, fmt.Errorf("load compose file(s): %w", err))
+	}
+	uncli.SetClusterContextIfUnset(compose.ClusterContext(project))
+
+	if len(opts.services) > 0 {
+		if project, err = project.WithSelectedServices(opts.services); err != nil {
+			return fmt.Errorf("select services: %w", err)
+		}
+	}
+
+	names := project.ServiceNames()
+	slices.Sort(names)
+	var specs []api.ServiceSpec
+	for _, name := range names {
+		spec, err := compose.ServiceSpecFromCompose(project, name)
+		if err != nil {
+			return fmt.Errorf("convert compose service '%s' to service spec: %w", name, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	issues, err := clusterClient.IngressRouteIssues(ctx, specs)
+	if err != nil {
+		return fmt.Errorf("check ingress routes: %w", err)
+	}
+	if len(issues) == 0 {
+		fmt.Println(tui.Green.Render("✔") + " No ingress route conflicts found.")
+		return nil
+	}
+
+	conflicts := 0
+	for _, issue := range issues {
+		label := tui.Yellow.Render("warning")
+		if issue.Conflict {
+			label = tui.Red.Render("conflict")
+			conflicts++
+		}
+		fmt.Printf("%s %s %s\n", label, issue.Route,
+			tui.Faint.Render("(services: "+strings.Join(issue.Services, ", ")+")"))
+		fmt.Printf("  %s\n", issue.Message)
+	}
+
+	if conflicts > 0 {
+		return fmt.Errorf("found %d ingress route conflict(s)", conflicts)
+	}
+	return nil
+}
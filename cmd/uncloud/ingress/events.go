@@ -0,0 +1,103 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type eventsOptions struct {
+	machines []string
+}
+
+func NewEventsCommand() *cobra.Command {
+	opts := eventsOptions{}
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Show the recent changes of the ingress configuration on machines.",
+		Long: `Show the recent changes of the ingress configuration on machines.
+
+An event is recorded every time a machine loads a new ingress configuration into Caddy (loaded), fails to load it
+and keeps serving the previous one (failed), or reverts to the previous configuration because Caddy stopped serving
+requests after loading the new one (reverted). The most recent events are kept for each machine.`,
+		Example: `  # Show the ingress events on all machines.
+  uc ingress events
+
+  # Show the ingress events on specific machines.
+  uc ingress events -m machine-1,machine-2`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return events(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Names or IDs of the machines to show the events for. Can be specified multiple times or as a "+
+			"comma-separated list. (default is all machines)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+type machineIngressEvent struct {
+	machine string
+	api.IngressEvent
+}
+
+func events(ctx context.Context, uncli *cli.CLI, opts eventsOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	machines, err := clusterClient.ListMachines(ctx, &api.MachineFilter{NamesOrIDs: opts.machines})
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+
+	var all []machineIngressEvent
+	for _, m := range machines {
+		events, err := clusterClient.IngressEvents(ctx, m.Machine.Id)
+		if err != nil {
+			return fmt.Errorf("get ingress events for machine '%s': %w", m.Machine.Name, err)
+		}
+		if events == nil {
+			continue
+		}
+		for _, e := range events.Events {
+			all = append(all, machineIngressEvent{machine: m.Machine.Name, IngressEvent: e})
+		}
+	}
+	if len(all) == 0 {
+		fmt.Println("No ingress events found.")
+		return nil
+	}
+	slices.SortStableFunc(all, func(a, b machineIngressEvent) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	t := tui.NewTable()
+	t.Headers("TIME", "MACHINE", "TYPE", "MESSAGE")
+	for _, e := range all {
+		eventType := e.Type
+		switch e.Type {
+		case api.IngressEventFailed:
+			eventType = tui.Red.Render(e.Type)
+		case api.IngressEventReverted:
+			eventType = tui.Yellow.Render(e.Type)
+		}
+		t.Row(e.Time.Local().Format(time.DateTime), e.machine, eventType, e.Message)
+	}
+	fmt.Println(t)
+
+	return nil
+}
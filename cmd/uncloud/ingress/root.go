@@ -0,0 +1,23 @@
+package ingress
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ingress",
+		Short: "Inspect and configure ingress traffic handling in the cluster.",
+		Long: "Inspect and configure ingress traffic handling in the cluster.\n" +
+			"Ingress traffic is served by the Caddy reverse proxy on machines with the ingress role " +
+			"or on all machines if no machine has the role.",
+	}
+	cmd.AddCommand(
+		NewConfigCommand(),
+		NewEventsCommand(),
+		NewStatusCommand(),
+		NewVIPCommand(),
+		NewACMEDNSCommand(),
+	)
+	return cmd
+}
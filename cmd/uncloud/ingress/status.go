@@ -0,0 +1,119 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+type statusOptions struct {
+	check bool
+}
+
+func NewStatusCommand() *cobra.Command {
+	opts := statusOptions{}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show which machines serve ingress traffic.",
+		Long: `Show which machines serve ingress traffic.
+
+A machine is serving if it's up and runs a healthy Caddy container. With --check, Caddy must also respond on
+the public IP of the machine. Point the DNS records of your domains only to the public IPs of serving machines.`,
+		Example: `  # Show the ingress status of all machines.
+  uc ingress status
+
+  # Also check that Caddy is reachable on the public IP of each machine.
+  uc ingress status --check`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return status(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.check, "check", false,
+		"Send HTTP requests to the public IPs of machines running Caddy to check they're reachable.")
+
+	return cmd
+}
+
+func status(ctx context.Context, uncli *cli.CLI, opts statusOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	machines, err := clusterClient.IngressStatus(ctx, client.IngressStatusOptions{CheckReachable: opts.check})
+	if err != nil {
+		return fmt.Errorf("get ingress status: %w", err)
+	}
+
+	t := tui.NewTable()
+	t.Headers("MACHINE", "STATE", "INGRESS", "PUBLIC IP", "CADDY", "REACHABLE", "SERVING")
+
+	serving := 0
+	for _, m := range machines {
+		ingress := "no"
+		if m.Designated {
+			ingress = "yes"
+		}
+
+		publicIP := "-"
+		if m.Machine.PublicIp != nil {
+			ip, _ := m.Machine.PublicIp.ToAddr()
+			publicIP = ip.String()
+		}
+
+		caddy := "not running"
+		if m.CaddyRunning {
+			caddy = "running"
+			if !m.CaddyHealthy {
+				caddy = "unhealthy"
+			}
+		}
+
+		reachable := "-"
+		if m.Reachable != nil {
+			reachable = "yes"
+			if !*m.Reachable {
+				reachable = "no: " + m.ReachableError
+			}
+		}
+
+		servingStr := tui.Faint.Render("no")
+		if m.Serving() {
+			servingStr = tui.Green.Render("yes")
+			serving++
+		} else if m.Designated {
+			servingStr = tui.Red.Render("no")
+		}
+
+		state := m.State.String()
+		state = state[:1] + strings.ToLower(state[1:])
+
+		t.Row(m.Machine.Name, state, ingress, publicIP, caddy, reachable, servingStr)
+	}
+
+	fmt.Println(t)
+	fmt.Printf("%d of %d machines serve ingress traffic.\n", serving, len(machines))
+
+	vip, vipStatus, err := clusterClient.IngressVIP(ctx)
+	if err != nil {
+		if grpcstatus.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return fmt.Errorf("get ingress virtual IP: %w", err)
+	}
+	if vip != nil {
+		fmt.Println(formatVIP(vip, vipStatus))
+	}
+	return nil
+}
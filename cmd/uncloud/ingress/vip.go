@@ -0,0 +1,138 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewVIPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vip",
+		Short: "Manage the virtual IP address that floats among ingress machines.",
+		Long: `Manage the virtual IP address that floats among ingress machines.
+
+One healthy ingress machine holds the virtual IP at a time. If it goes down or its Caddy container becomes
+unhealthy, another ingress machine takes the address over within a few seconds and announces it with
+a gratuitous ARP. All ingress machines must be in the same layer 2 network, for example the same LAN or VLAN.`,
+	}
+	cmd.AddCommand(
+		newVIPSetCommand(),
+		newVIPShowCommand(),
+		newVIPUnsetCommand(),
+	)
+	return cmd
+}
+
+func newVIPSetCommand() *cobra.Command {
+	var iface string
+	cmd := &cobra.Command{
+		Use:   "set ADDRESS/PREFIX",
+		Short: "Set the virtual IP address for ingress machines.",
+		Example: `  # Float 192.168.1.100 among ingress machines in the 192.168.1.0/24 network.
+  uc ingress vip set 192.168.1.100/24
+
+  # Assign the address to a specific network interface on the machines.
+  uc ingress vip set 192.168.1.100/24 --interface eth1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			addr, err := netip.ParsePrefix(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid address '%s': must be an IP address with a prefix length, "+
+					"e.g. 192.168.1.100/24", args[0])
+			}
+			return setVIP(cmd.Context(), uncli, api.IngressVIP{Address: addr, Interface: iface})
+		},
+	}
+	cmd.Flags().StringVar(&iface, "interface", "",
+		"Network interface on the machines to assign the address to. "+
+			"(default is the interface with an address in the same network)")
+	return cmd
+}
+
+func setVIP(ctx context.Context, uncli *cli.CLI, vip api.IngressVIP) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	if err = clusterClient.SetIngressVIP(ctx, vip); err != nil {
+		return fmt.Errorf("set ingress virtual IP: %w", err)
+	}
+	fmt.Printf("Ingress virtual IP set to %s. A healthy ingress machine will take it over in a few seconds.\n",
+		vip.Address.Addr())
+	return nil
+}
+
+func newVIPShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show the virtual IP address and the machine that holds it.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			clusterClient, err := uncli.ConnectCluster(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer clusterClient.Close()
+
+			vip, status, err := clusterClient.IngressVIP(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("get ingress virtual IP: %w", err)
+			}
+			if vip == nil {
+				fmt.Println("Ingress virtual IP is not configured.")
+				return nil
+			}
+			fmt.Println(formatVIP(vip, status))
+			return nil
+		},
+	}
+}
+
+func newVIPUnsetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset",
+		Short: "Remove the virtual IP address from ingress machines.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			clusterClient, err := uncli.ConnectCluster(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("connect to cluster: %w", err)
+			}
+			defer clusterClient.Close()
+
+			if err = clusterClient.RemoveIngressVIP(cmd.Context()); err != nil {
+				return fmt.Errorf("remove ingress virtual IP: %w", err)
+			}
+			fmt.Println("Ingress virtual IP removed. The holder machine will release the address in a few seconds.")
+			return nil
+		},
+	}
+}
+
+// formatVIP returns a one-line description of the virtual IP and its holder.
+func formatVIP(vip *api.IngressVIP, status *api.IngressVIPStatus) string {
+	s := fmt.Sprintf("Virtual IP: %s", vip.Address)
+	if vip.Interface != "" {
+		s += fmt.Sprintf(" on interface %s", vip.Interface)
+	}
+	if status == nil {
+		return s + ", not held by any machine"
+	}
+	return s + fmt.Sprintf(", held by %s for %s", status.MachineName,
+		units.HumanDuration(time.Since(status.Since)))
+}
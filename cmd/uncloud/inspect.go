@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/psviderski/uncloud/cmd/uncloud/service"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	inspectFormatJSON = "json"
+	inspectFormatYAML = "yaml"
+
+	inspectTypeImage   = "image"
+	inspectTypeMachine = "machine"
+	inspectTypeService = "service"
+	inspectTypeVolume  = "volume"
+)
+
+type inspectOptions struct {
+	refs     []string
+	format   string
+	machines []string
+}
+
+// inspectRef is a resource to inspect specified as [TYPE/]NAME.
+type inspectRef struct {
+	typ  string
+	name string
+}
+
+func (r inspectRef) String() string {
+	return r.typ + "/" + r.name
+}
+
+// inspectImage is the image on a machine as printed by the inspect command.
+type inspectImage struct {
+	Machine string
+	image.InspectResponse
+}
+
+func NewInspectCommand() *cobra.Command {
+	opts := inspectOptions{}
+	cmd := &cobra.Command{
+		Use:   "inspect [TYPE/]NAME [[TYPE/]NAME...]",
+		Short: "Display detailed information on services, machines, volumes, or images.",
+		Long: `Display detailed information on services, machines, volumes, or images in a consistent format.
+
+Each resource is specified as TYPE/NAME where TYPE is one of 'service', 'machine', 'volume', or 'image'.
+TYPE defaults to 'service' if omitted. A service or machine is a single object. A volume or image is an object
+for each machine it exists on. All objects are printed as a JSON array by default. Use --format yaml to print
+them as YAML or pass a Go template to print each object on a separate line.
+
+A single service name without a type and --format prints a summary of the service containers as a table.`,
+		Example: `  # Print a summary of the service containers.
+  uc inspect web
+
+  # Print the full details of a service as JSON.
+  uc inspect service/web
+
+  # Print a machine and a volume as YAML.
+  uc inspect machine/machine1 volume/data --format yaml
+
+  # Print the IDs of an image on specific machines using a Go template.
+  uc inspect image/nginx:latest -m machine1,machine2 --format '{{.Machine}} {{.Id}}'
+
+  # Print the number of containers of each service.
+  uc inspect service/web service/db --format '{{.Name}}: {{len .Containers}}'`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && !strings.Contains(args[0], "/") && !cmd.Flags().Changed("format") {
+				// Keep the table output of 'uc inspect SERVICE' that predates the TYPE/NAME syntax.
+				return service.NewInspectCommand("").RunE(cmd, args)
+			}
+
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.refs = args
+			return inspect(cmd.Context(), uncli, opts)
+		},
+		GroupID: "service",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completeInspectRef(cmd.Context(), uncli, toComplete)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.format, "format", "f", inspectFormatJSON,
+		"Output format: 'json', 'yaml', or a Go template executed for each object.\n"+
+			"Template fields match the JSON output, e.g. '{{.Name}}' for a service. "+
+			"Use '{{json .}}' to print a value as JSON.")
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Filter machines to inspect volumes and images on. Can be specified multiple times or as a comma-separated "+
+			"list. (default is all machines)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
+	refs := make([]inspectRef, len(opts.refs))
+	for i, arg := range opts.refs {
+		ref, err := parseInspectRef(arg)
+		if err != nil {
+			return err
+		}
+		refs[i] = ref
+	}
+
+	c, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	machines := cli.ExpandCommaSeparatedValues(opts.machines)
+	var objects []json.RawMessage
+	for _, ref := range refs {
+		objs, err := inspectResource(ctx, c, ref, machines)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, objs...)
+	}
+
+	return printInspectObjects(os.Stdout, objects, opts.format)
+}
+
+// completeInspectRef completes the resource types and the names of services, machines, and volumes. Service names
+// are also completed without a type.
+func completeInspectRef(
+	ctx context.Context, uncli *cli.CLI, toComplete string,
+) ([]cobra.Completion, cobra.ShellCompDirective) {
+	typ, name, ok := strings.Cut(toComplete, "/")
+	if !ok {
+		types := []cobra.Completion{}
+		for _, t := range []string{inspectTypeService, inspectTypeMachine, inspectTypeVolume, inspectTypeImage} {
+			if strings.HasPrefix(t, toComplete) {
+				types = append(types, t+"/")
+			}
+		}
+		services, _ := completion.Services(ctx, uncli, nil, toComplete)
+		return append(types, services...), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []cobra.Completion
+	var directive cobra.ShellCompDirective
+	switch typ {
+	case inspectTypeService:
+		names, directive = completion.Services(ctx, uncli, nil, name)
+	case inspectTypeMachine:
+		names, directive = completion.Machines(ctx, uncli, nil, name)
+	case inspectTypeVolume:
+		names, directive = completion.Volumes(ctx, uncli, nil, name)
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	for i := range names {
+		names[i] = typ + "/" + names[i]
+	}
+	return names, directive
+}
+
+// parseInspectRef parses a resource reference in the [TYPE/]NAME format. TYPE defaults to service.
+func parseInspectRef(s string) (inspectRef, error) {
+	typ, name, ok := strings.Cut(s, "/")
+	if !ok {
+		typ, name = inspectTypeService, s
+	}
+	if name == "" {
+		return inspectRef{}, fmt.Errorf("invalid resource '%s': expected [TYPE/]NAME, e.g. 'service/web'", s)
+	}
+
+	switch typ {
+	case inspectTypeImage, inspectTypeMachine, inspectTypeService, inspectTypeVolume:
+		return inspectRef{typ: typ, name: name}, nil
+	default:
+		return inspectRef{}, fmt.Errorf("invalid resource type '%s' in '%s': must be one of: %s, %s, %s, %s",
+			typ, s, inspectTypeService, inspectTypeMachine, inspectTypeVolume, inspectTypeImage)
+	}
+}
+
+// inspectResource returns the JSON-encoded objects describing the resource. Volumes and images are looked up
+// only on the given machines or on all machines if none are specified.
+func inspectResource(
+	ctx context.Context, c *client.Client, ref inspectRef, machines []string,
+) ([]json.RawMessage, error) {
+	var values []any
+
+	switch ref.typ {
+	case inspectTypeService:
+		svc, err := c.InspectService(ctx, ref.name)
+		if err != nil {
+			return nil, fmt.Errorf("inspect service '%s': %w", ref.name, err)
+		}
+		values = append(values, svc)
+	case inspectTypeMachine:
+		member, err := c.InspectMachine(ctx, ref.name)
+		if err != nil {
+			return nil, fmt.Errorf("inspect machine '%s': %w", ref.name, err)
+		}
+		values = append(values, member)
+	case inspectTypeVolume:
+		volumes, err := c.ListVolumes(ctx, &api.VolumeFilter{Names: []string{ref.name}, Machines: machines})
+		if err != nil {
+			return nil, fmt.Errorf("list volumes: %w", err)
+		}
+		if len(volumes) == 0 {
+			return nil, fmt.Errorf("volume '%s' not found", ref.name)
+		}
+		for _, v := range volumes {
+			values = append(values, v)
+		}
+	case inspectTypeImage:
+		images, err := c.InspectImage(c.ProxyMachinesContext(ctx, machines), ref.name)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return nil, fmt.Errorf("image '%s' not found", ref.name)
+			}
+			return nil, fmt.Errorf("inspect image '%s': %w", ref.name, err)
+		}
+		for _, mi := range images {
+			// Skip the machines where the image doesn't exist.
+			if mi.Metadata != nil && mi.Metadata.Error != "" {
+				continue
+			}
+			values = append(values, inspectImage{
+				Machine:         mi.Metadata.GetMachineName(),
+				InspectResponse: mi.Image,
+			})
+		}
+	}
+
+	objects := make([]json.RawMessage, len(values))
+	for i, v := range values {
+		var err error
+		if m, ok := v.(proto.Message); ok {
+			objects[i], err = protojson.Marshal(m)
+		} else {
+			objects[i], err = json.Marshal(v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("encode %s: %w", ref, err)
+		}
+	}
+	return objects, nil
+}
+
+// printInspectObjects prints the JSON-encoded objects as a JSON array, YAML list, or executes the Go template
+// for each object decoded from JSON so that the template fields match the JSON output.
+func printInspectObjects(w io.Writer, objects []json.RawMessage, format string) error {
+	if objects == nil {
+		objects = []json.RawMessage{}
+	}
+
+	switch format {
+	case inspectFormatJSON:
+		data, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case inspectFormatYAML:
+		data, err := json.Marshal(objects)
+		if err != nil {
+			return fmt.Errorf("encode JSON: %w", err)
+		}
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return fmt.Errorf("encode YAML: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	tmpl, err := template.New("format").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(format)
+	if err != nil {
+		return fmt.Errorf("parse format template: %w", err)
+	}
+
+	for _, obj := range objects {
+		// Decode numbers as json.Number to print them as is rather than in the float notation.
+		var v any
+		dec := json.NewDecoder(bytes.NewReader(obj))
+		dec.UseNumber()
+		if err = dec.Decode(&v); err != nil {
+			return fmt.Errorf("decode object: %w", err)
+		}
+
+		var buf bytes.Buffer
+		if err = tmpl.Execute(&buf, v); err != nil {
+			return fmt.Errorf("execute format template: %w", err)
+		}
+		if _, err = fmt.Fprintln(w, buf.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
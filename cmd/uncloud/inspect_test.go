@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInspectRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		arg     string
+		want    inspectRef
+		wantErr string
+	}{
+		{arg: "service/web", want: inspectRef{typ: "service", name: "web"}},
+		{arg: "machine/machine-1", want: inspectRef{typ: "machine", name: "machine-1"}},
+		{arg: "volume/data", want: inspectRef{typ: "volume", name: "data"}},
+		{arg: "image/ghcr.io/org/app:1.0", want: inspectRef{typ: "image", name: "ghcr.io/org/app:1.0"}},
+		{arg: "web", want: inspectRef{typ: "service", name: "web"}},
+		{arg: "service/", wantErr: "expected [TYPE/]NAME"},
+		{arg: "container/web", wantErr: "invalid resource type 'container'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			t.Parallel()
+
+			ref, err := parseInspectRef(tt.arg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ref)
+		})
+	}
+}
+
+func TestPrintInspectObjects(t *testing.T) {
+	t.Parallel()
+
+	objects := []json.RawMessage{
+		json.RawMessage(`{"Name":"web","Replicas":3,"Labels":{"env":"prod"}}`),
+		json.RawMessage(`{"Name":"db","Replicas":1}`),
+	}
+
+	tests := []struct {
+		name    string
+		objects []json.RawMessage
+		format  string
+		want    string
+	}{
+		{
+			name:    "json",
+			objects: objects,
+			format:  "json",
+			want: `[
+  {
+    "Name": "web",
+    "Replicas": 3,
+    "Labels": {
+      "env": "prod"
+    }
+  },
+  {
+    "Name": "db",
+    "Replicas": 1
+  }
+]
+`,
+		},
+		{
+			name:    "yaml",
+			objects: objects,
+			format:  "yaml",
+			want: `- Labels:
+    env: prod
+  Name: web
+  Replicas: 3
+- Name: db
+  Replicas: 1
+`,
+		},
+		{
+			name:    "template",
+			objects: objects,
+			format:  "{{.Name}} {{.Replicas}} {{json .Labels}}",
+			want:    "web 3 {\"env\":\"prod\"}\ndb 1 null\n",
+		},
+		{
+			name:   "no objects",
+			format: "json",
+			want:   "[]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, printInspectObjects(&buf, tt.objects, tt.format))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
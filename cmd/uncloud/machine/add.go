@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,25 +13,31 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
+	"github.com/psviderski/uncloud/internal/bundle"
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/config"
 	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/profile"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/spf13/cobra"
 )
 
 type addOptions struct {
+	fromBundle  string
 	name        string
 	noCaddy     bool
 	noInstall   bool
+	profile     string
 	publicIP    string
+	roles       []string
+	skipChecks  bool
 	sshKey      string
 	version     string
 	wgEndpoints []string
 	wgPort      int
-	yes         bool
+	zone        string
 }
 
 func NewAddCommand() *cobra.Command {
@@ -45,7 +52,9 @@ Connection methods:
   ssh+go://user@host  - Use Go's built-in SSH library`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			if err := validateFromBundle(cmd, opts.fromBundle, opts.noInstall); err != nil {
+				return err
+			}
 
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 
@@ -71,6 +80,11 @@ Connection methods:
 			return add(cmd.Context(), uncli, remoteMachine, opts)
 		},
 	}
+	cmd.Flags().StringVar(
+		&opts.fromBundle, "from-bundle", "",
+		"Path to an air-gapped installation bundle created with 'uc bundle create' to install the machine from\n"+
+			"instead of downloading the binaries and images from the internet.",
+	)
 	cmd.Flags().StringVarP(&opts.name, "name", "n", "", "Assign a name to the machine.")
 	cmd.Flags().BoolVar(
 		&opts.noCaddy, "no-caddy", false,
@@ -81,11 +95,26 @@ Connection methods:
 		"Skip installation of Docker, Uncloud daemon, and dependencies on the machine. "+
 			"Assumes they're already installed and running.",
 	)
+	cmd.Flags().StringVar(
+		&opts.profile, "profile", profile.Default,
+		fmt.Sprintf("Resource profile of the machine. Supported values: %s.\n", strings.Join(profile.Names, ", "))+
+			"Use 'small' for resource-constrained machines like Raspberry Pi to reduce CPU, memory, and disk usage\n"+
+			"at the cost of slower reaction to container changes.",
+	)
 	cmd.Flags().StringVar(
 		&opts.publicIP, "public-ip", "auto",
 		"Public IP address of the machine for ingress configuration. Use 'auto' for automatic detection, "+
 			fmt.Sprintf("blank '' or '%s' to disable ingress on this machine, or specify an IP address.", PublicIPNone),
 	)
+	cmd.Flags().StringSliceVar(
+		&opts.roles, "role", nil,
+		fmt.Sprintf("Role to assign to the machine. Supported values: %s.\n", strings.Join(api.MachineRoles, ", "))+
+			"Can be specified multiple times or as a comma-separated list.",
+	)
+	cmd.Flags().BoolVar(
+		&opts.skipChecks, "skip-checks", false,
+		"Skip the host prerequisite checks, such as WireGuard support, Docker version, and port availability.",
+	)
 	cmd.Flags().StringVarP(
 		&opts.sshKey, "ssh-key", "i", "",
 		fmt.Sprintf("Path to SSH private key for remote login (if not already added to SSH agent). (default %q)",
@@ -108,9 +137,11 @@ Connection methods:
 		&opts.wgPort, "wg-port", network.DefaultWireGuardPort,
 		"UDP port WireGuard listens on for incoming connections from other machines.",
 	)
-	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
-		"Auto-confirm prompts (e.g., resetting an already initialised machine).\n"+
-			"Should be explicitly set when running non-interactively, e.g., in CI/CD pipelines. [$UNCLOUD_AUTO_CONFIRM]")
+	cmd.Flags().StringVar(
+		&opts.zone, "zone", "",
+		"Zone (failure domain) of the machine, e.g. a data center or a cloud provider location.\n"+
+			"Replicas of services that spread across zones are balanced between machines in different zones.",
+	)
 
 	return cmd
 }
@@ -133,14 +164,38 @@ func add(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine,
 	if opts.wgPort < 1 || opts.wgPort > 65535 {
 		return fmt.Errorf("invalid WireGuard port %d: must be between 1 and 65535", opts.wgPort)
 	}
+	if err := profile.Validate(opts.profile); err != nil {
+		return fmt.Errorf("invalid --profile: %w", err)
+	}
+	roles := cli.ExpandCommaSeparatedValues(opts.roles)
+	if err := api.ValidateMachineRoles(roles); err != nil {
+		return fmt.Errorf("invalid --role: %w", err)
+	}
+	if err := api.ValidateMachineZone(opts.zone); err != nil {
+		return fmt.Errorf("invalid --zone: %w", err)
+	}
+
+	var manifest *bundle.Manifest
+	if opts.fromBundle != "" {
+		var err error
+		if manifest, err = bundle.ReadManifest(opts.fromBundle); err != nil {
+			return fmt.Errorf("read bundle '%s': %w", opts.fromBundle, err)
+		}
+	}
+
 	addOpts := cli.AddMachineOptions{
 		MachineName:   opts.name,
 		PublicIP:      publicIP,
 		RemoteMachine: remoteMachine,
+		Roles:         roles,
+		Zone:          opts.zone,
 		SkipInstall:   opts.noInstall,
+		SkipChecks:    opts.skipChecks,
 		Version:       opts.version,
+		BundlePath:    opts.fromBundle,
 		WireguardPort: opts.wgPort,
-		AutoConfirm:   opts.yes,
+		Profile:       opts.profile,
+		AutoConfirm:   uncli.AssumeYes,
 	}
 	if len(opts.wgEndpoints) > 0 {
 		expanded := cli.ExpandCommaSeparatedValues(opts.wgEndpoints)
@@ -211,9 +266,19 @@ func add(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine,
 		}
 	}
 
+	if manifest != nil && !slices.Contains(manifest.Images, caddyImage) {
+		tui.PrintWarning(fmt.Sprintf("The Caddy image '%s' used in the cluster is not included in the bundle. "+
+			"The machine may fail to pull it without internet access. Create a bundle with "+
+			"'uc bundle create --caddy-image %s' to include it.", caddyImage, caddyImage))
+	}
+
 	fmt.Println()
 	fmt.Println("Preparing Caddy deployment...")
-	d, err := clusterClient.NewCaddyDeployment(caddyImage, "", api.Placement{})
+	placement, err := clusterClient.IngressPlacement(ctx)
+	if err != nil {
+		return err
+	}
+	d, err := clusterClient.NewCaddyDeployment(caddyImage, "", placement)
 	if err != nil {
 		return fmt.Errorf("create caddy deployment: %w", err)
 	}
@@ -235,14 +300,12 @@ func add(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteMachine,
 		fmt.Println(summary)
 		fmt.Println()
 
-		if !opts.yes {
-			confirmed, err := tui.Confirm("Proceed with deployment?")
-			if err != nil {
-				return fmt.Errorf("confirm deployment: %w", err)
-			}
-			if !confirmed {
-				return cli.Cancelled("Caddy deploy cancelled. The machine has been added to the cluster.")
-			}
+		confirmed, err := uncli.Confirm("Proceed with deployment?")
+		if err != nil {
+			return fmt.Errorf("confirm deployment: %w", err)
+		}
+		if !confirmed {
+			return cli.Cancelled("Caddy deploy cancelled. The machine has been added to the cluster.")
 		}
 
 		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
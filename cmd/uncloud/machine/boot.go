@@ -0,0 +1,120 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func NewBootStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "boot-status",
+		Short: "Show the service containers started by machines after Docker failed to start them on boot.",
+		Long: `Show the service containers started by machines after Docker failed to start them on boot.
+
+Docker starts all containers at once when a machine boots and gives up on the ones
+that fail to start, for example, because the machine network isn't ready yet. Once
+the machine daemon has configured the network, it starts such containers in order:
+the dependencies of services (depends_on) before the services that depend on them
+and, at the same dependency level, containers of stateful services with named
+volumes first. Each group of containers is started once the previous one is running
+and healthy. Containers stopped by the user stay stopped.
+
+Only reachable machines are shown.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return bootStatus(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func bootStatus(ctx context.Context, uncli *cli.CLI) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	// Setup context to proxy request to all machines.
+	ctx = client.ProxyMachinesContext(ctx, nil)
+
+	resp, err := client.MachineClient.InspectMachine(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect machines: %w", err)
+	}
+
+	var machines []*pb.MachineDetails
+	for _, m := range resp.Machines {
+		if m.Metadata != nil && m.Metadata.Error != "" {
+			tui.PrintWarning(fmt.Sprintf("failed to inspect machine '%s': %s", m.Metadata.MachineName, m.Metadata.Error))
+			continue
+		}
+		if m.Machine == nil {
+			continue
+		}
+		machines = append(machines, m)
+	}
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].Machine.Name < machines[j].Machine.Name
+	})
+
+	now := time.Now().UTC()
+	t := tui.NewTable()
+	t.Headers("MACHINE", "RECOVERY", "STARTED", "FAILED")
+	for _, m := range machines {
+		r := m.BootRecovery
+		if r == nil {
+			t.Row(m.Machine.Name, "not started", "-", "-")
+			continue
+		}
+
+		status := "in progress"
+		if r.FinishedAt != nil {
+			status = "finished " + units.HumanDuration(now.Sub(r.FinishedAt.AsTime())) + " ago"
+		}
+		failed := 0
+		for _, c := range r.Containers {
+			if c.Error != "" {
+				failed++
+			}
+		}
+		t.Row(m.Machine.Name, status, fmt.Sprintf("%d", len(r.Containers)-failed), fmt.Sprintf("%d", failed))
+	}
+	fmt.Println(t)
+
+	containers := tui.NewTable()
+	containers.Headers("MACHINE", "SERVICE", "CONTAINER", "STATEFUL", "STATUS")
+	found := false
+	for _, m := range machines {
+		if m.BootRecovery == nil {
+			continue
+		}
+		for _, c := range m.BootRecovery.Containers {
+			found = true
+			stateful := "no"
+			if c.Stateful {
+				stateful = "yes"
+			}
+			status := "started"
+			if c.Error != "" {
+				status = "failed: " + c.Error
+			}
+			containers.Row(m.Machine.Name, c.ServiceName, c.ContainerName, stateful, status)
+		}
+	}
+	if found {
+		fmt.Println()
+		fmt.Println(containers)
+	}
+
+	return nil
+}
@@ -3,4 +3,8 @@ package machine
 const (
 	// PublicIPNone is the value used to indicate removal of public IP
 	PublicIPNone = "none"
+	// RolesNone is the value used to indicate removal of all machine roles.
+	RolesNone = "none"
+	// ZoneNone is the value used to indicate removal of the machine zone.
+	ZoneNone = "none"
 )
@@ -0,0 +1,250 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/fault"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type faultOptions struct {
+	machines []string
+}
+
+type faultInjectOptions struct {
+	faultOptions
+	delay    time.Duration
+	duration time.Duration
+	match    string
+}
+
+func NewFaultCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fault",
+		Short: "Inject faults into machine daemons for failure testing.",
+		Long: `Inject faults into machine daemons for failure testing.
+
+Faults simulate failures on a machine to test how the cluster and your tooling handle them. They can only be
+injected if the daemon runs with the --unsafe-fault-injection flag. Never enable it on production machines.
+
+Supported faults:
+  drop-heartbeats   Stop pinging the systemd watchdog and sending heartbeats in log streams. Systemd restarts
+                    the daemon when the watchdog timeout expires as if the daemon hung.
+  delay-rpc         Delay the machine API requests served by the daemon.
+  fail-image-pull   Fail the image pulls on the machine as if the registry were unavailable.
+
+Faults are kept in memory and removed when the daemon restarts.`,
+	}
+	cmd.AddCommand(
+		newFaultInjectCommand(),
+		newFaultClearCommand(),
+		newFaultListCommand(),
+	)
+	return cmd
+}
+
+func newFaultInjectCommand() *cobra.Command {
+	opts := faultInjectOptions{}
+	cmd := &cobra.Command{
+		Use:   "inject FAULT",
+		Short: "Inject a fault into machine daemons.",
+		Long: `Inject a fault into machine daemons. It replaces the active fault of the same kind.
+
+FAULT is one of drop-heartbeats, delay-rpc, or fail-image-pull.`,
+		Example: `  # Delay all Docker API requests on a machine by 5 seconds for 10 minutes.
+  uc machine fault inject delay-rpc --delay 5s --match /api.Docker/ --for 10m -m machine-1
+
+  # Fail pulls of nginx images on all machines.
+  uc machine fault inject fail-image-pull --match nginx
+
+  # Make the daemon on a machine hang from the systemd point of view.
+  uc machine fault inject drop-heartbeats -m machine-1`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: fault.Kinds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			f := fault.Fault{Kind: args[0], Match: opts.match, Delay: opts.delay}
+			if err := f.Validate(); err != nil {
+				return err
+			}
+			if opts.duration < 0 {
+				return fmt.Errorf("--for must not be negative")
+			}
+			return injectFault(cmd.Context(), uncli, f, opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.delay, "delay", 0,
+		"How long to delay the matching API requests. Required for the delay-rpc fault.")
+	cmd.Flags().DurationVar(&opts.duration, "for", 0,
+		"Remove the fault automatically after this duration. (default is until cleared)")
+	cmd.Flags().StringVar(&opts.match, "match", "",
+		"Limit the fault to the API methods (e.g. /api.Docker/) or images (e.g. nginx) that contain the substring.")
+	addFaultMachinesFlag(cmd, &opts.faultOptions)
+	return cmd
+}
+
+func newFaultClearCommand() *cobra.Command {
+	opts := faultOptions{}
+	cmd := &cobra.Command{
+		Use:   "clear [FAULT]",
+		Short: "Remove injected faults from machine daemons.",
+		Long:  "Remove the injected fault of the given kind or all faults if FAULT isn't specified.",
+		Example: `  # Remove all faults on all machines.
+  uc machine fault clear
+
+  # Remove the delay-rpc fault on a machine.
+  uc machine fault clear delay-rpc -m machine-1`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: fault.Kinds,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			kind := ""
+			if len(args) > 0 {
+				kind = args[0]
+				if !slices.Contains(fault.Kinds, kind) {
+					return fmt.Errorf("invalid fault kind '%s', must be one of: %s",
+						kind, strings.Join(fault.Kinds, ", "))
+				}
+			}
+			return clearFaults(cmd.Context(), uncli, kind, opts)
+		},
+	}
+	addFaultMachinesFlag(cmd, &opts)
+	return cmd
+}
+
+func newFaultListCommand() *cobra.Command {
+	opts := faultOptions{}
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List faults injected into machine daemons.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listFaults(cmd.Context(), uncli, opts)
+		},
+	}
+	addFaultMachinesFlag(cmd, &opts)
+	return cmd
+}
+
+func addFaultMachinesFlag(cmd *cobra.Command, opts *faultOptions) {
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Names or IDs of the machines. Can be specified multiple times or as a comma-separated list. "+
+			"(default is all machines)")
+	completion.MachinesFlag(cmd)
+}
+
+func injectFault(ctx context.Context, uncli *cli.CLI, f fault.Fault, opts faultInjectOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	req := &pb.InjectFaultRequest{Fault: &pb.Fault{Kind: f.Kind, Match: f.Match}}
+	if f.Delay != 0 {
+		req.Fault.Delay = durationpb.New(f.Delay)
+	}
+	if opts.duration != 0 {
+		req.Duration = durationpb.New(opts.duration)
+	}
+
+	ctx = client.ProxyMachinesContext(ctx, cli.ExpandCommaSeparatedValues(opts.machines))
+	resp, err := client.MachineClient.InjectFault(ctx, req)
+	if err != nil {
+		return fmt.Errorf("inject fault: %w", unimplementedHint(err))
+	}
+	return printFaults(resp)
+}
+
+func clearFaults(ctx context.Context, uncli *cli.CLI, kind string, opts faultOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	ctx = client.ProxyMachinesContext(ctx, cli.ExpandCommaSeparatedValues(opts.machines))
+	resp, err := client.MachineClient.ClearFaults(ctx, &pb.ClearFaultsRequest{Kind: kind})
+	if err != nil {
+		return fmt.Errorf("clear faults: %w", unimplementedHint(err))
+	}
+	return printFaults(resp)
+}
+
+func listFaults(ctx context.Context, uncli *cli.CLI, opts faultOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	ctx = client.ProxyMachinesContext(ctx, cli.ExpandCommaSeparatedValues(opts.machines))
+	resp, err := client.MachineClient.ListFaults(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("list faults: %w", unimplementedHint(err))
+	}
+	return printFaults(resp)
+}
+
+// printFaults prints the active faults on each machine. Machines with fault injection disabled are shown as such.
+func printFaults(resp *pb.FaultsResponse) error {
+	var machines []*pb.MachineFaults
+	failed := 0
+	for _, m := range resp.Machines {
+		if m.Metadata != nil && m.Metadata.Error != "" {
+			tui.PrintWarning(fmt.Sprintf("machine '%s': %s", m.Metadata.MachineName, m.Metadata.Error))
+			failed++
+			continue
+		}
+		machines = append(machines, m)
+	}
+	slices.SortFunc(machines, func(a, b *pb.MachineFaults) int {
+		return strings.Compare(a.Metadata.GetMachineName(), b.Metadata.GetMachineName())
+	})
+
+	t := tui.NewTable()
+	t.Headers("MACHINE", "FAULT", "MATCH", "DELAY", "EXPIRES")
+	for _, m := range machines {
+		name := m.Metadata.GetMachineName()
+		if !m.Enabled {
+			t.Row(name, tui.Faint.Render("disabled"), "", "", "")
+			continue
+		}
+		if len(m.Faults) == 0 {
+			t.Row(name, tui.Faint.Render("none"), "", "", "")
+			continue
+		}
+		for _, f := range m.Faults {
+			delay, expires := "", ""
+			if f.Delay != nil {
+				delay = f.Delay.AsDuration().String()
+			}
+			if f.Expires != nil {
+				expires = f.Expires.AsTime().Local().Format(time.DateTime)
+			}
+			t.Row(name, tui.Red.Render(f.Kind), f.Match, delay, expires)
+		}
+	}
+	fmt.Println(t)
+
+	if failed > 0 {
+		return fmt.Errorf("failed on %d machine(s)", failed)
+	}
+	return nil
+}
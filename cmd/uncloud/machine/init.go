@@ -2,8 +2,10 @@ package machine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/netip"
+	"os"
 	"strings"
 	"time"
 
@@ -12,11 +14,14 @@ import (
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
 	"github.com/psviderski/uncloud/cmd/uncloud/dns"
+	"github.com/psviderski/uncloud/internal/bundle"
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/config"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/internal/machine/cluster"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/profile"
+	"github.com/psviderski/uncloud/internal/machine/store"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
@@ -24,17 +29,21 @@ import (
 type initOptions struct {
 	context     string
 	dnsEndpoint string
+	fromBundle  string
 	name        string
 	network     string
 	noCaddy     bool
 	noDNS       bool
 	noInstall   bool
+	profile     string
 	publicIP    string
+	skipChecks  bool
 	sshKey      string
+	store       string
+	usernsRemap bool
 	version     string
 	wgEndpoints []string
 	wgPort      int
-	yes         bool
 }
 
 func NewInitCommand() *cobra.Command {
@@ -59,11 +68,16 @@ Connection methods:
 
   # Initialise without Caddy (no reverse proxy) and without an automatically managed domain name (xxxxxx.uncld.dev).
   # You can deploy Caddy with 'uc caddy deploy' and reserve a domain with 'uc dns reserve' later.
-  uc machine init root@<your-server-ip> --no-caddy --no-dns`,
+  uc machine init root@<your-server-ip> --no-caddy --no-dns
+
+  # Initialise a machine without internet access from a bundle created with 'uc bundle create'.
+  uc machine init root@<your-server-ip> --from-bundle uncloud-bundle-0.9.0-linux-amd64.tar.gz`,
 		// TODO: support initialising a cluster on the local machine.
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			if err := validateFromBundle(cmd, opts.fromBundle, opts.noInstall); err != nil {
+				return err
+			}
 
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 
@@ -99,6 +113,11 @@ Connection methods:
 	)
 	cmd.Flags().StringVar(&opts.dnsEndpoint, "dns-endpoint", dns.DefaultUncloudDNSAPIEndpoint,
 		"API endpoint for the Uncloud DNS service.")
+	cmd.Flags().StringVar(
+		&opts.fromBundle, "from-bundle", "",
+		"Path to an air-gapped installation bundle created with 'uc bundle create' to install the machine from\n"+
+			"instead of downloading the binaries and images from the internet. Implies --no-dns.",
+	)
 	cmd.Flags().StringVarP(
 		&opts.name, "name", "n", "",
 		"Assign a name to the machine.",
@@ -120,16 +139,39 @@ Connection methods:
 		"Skip installation of Docker, Uncloud daemon, and dependencies on the machine. "+
 			"Assumes they're already installed and running.",
 	)
+	cmd.Flags().StringVar(
+		&opts.profile, "profile", profile.Default,
+		fmt.Sprintf("Resource profile of the machine. Supported values: %s.\n", strings.Join(profile.Names, ", "))+
+			"Use 'small' for resource-constrained machines like Raspberry Pi to reduce CPU, memory, and disk usage\n"+
+			"at the cost of slower reaction to container changes.",
+	)
+	cmd.Flags().StringVar(
+		&opts.store, "store", store.BackendCorrosion,
+		fmt.Sprintf("Backend of the cluster state store. Supported values: %s.\n", strings.Join(store.Backends, ", "))+
+			"'corrosion' replicates the state to all machines. 'sqlite' keeps it in a local SQLite database\n"+
+			"with every write synced to disk but doesn't replicate it, so the cluster is limited to a single machine\n"+
+			"and 'uc machine add' fails. The backend can't be changed later.",
+	)
 	cmd.Flags().StringVar(
 		&opts.publicIP, "public-ip", "auto",
 		"Public IP address of the machine for ingress configuration. Use 'auto' for automatic detection, "+
 			fmt.Sprintf("blank '' or '%s' to disable ingress on this machine, or specify an IP address.", PublicIPNone),
 	)
+	cmd.Flags().BoolVar(
+		&opts.skipChecks, "skip-checks", false,
+		"Skip the host prerequisite checks, such as WireGuard support, Docker version, and port availability.",
+	)
 	cmd.Flags().StringVarP(
 		&opts.sshKey, "ssh-key", "i", "",
 		fmt.Sprintf("Path to SSH private key for remote login (if not already added to SSH agent). (default %q)",
 			cli.DefaultSSHKeyPath),
 	)
+	cmd.Flags().BoolVar(
+		&opts.usernsRemap, "userns-remap", false,
+		"Enable user namespace remapping for the cluster. Docker installed on this and future machines is\n"+
+			"configured with userns-remap so root in containers is an unprivileged user on the machine.\n"+
+			"Opt out a service with 'userns_mode: host'. Manage it later with 'uc cluster userns'.",
+	)
 	cmd.Flags().StringVar(
 		&opts.version, "version", "latest",
 		"Version of the Uncloud daemon to install on the machine.",
@@ -147,9 +189,6 @@ Connection methods:
 		&opts.wgPort, "wg-port", network.DefaultWireGuardPort,
 		"UDP port WireGuard listens on for incoming connections from other machines.",
 	)
-	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
-		"Auto-confirm prompts (e.g., resetting an already initialised machine).\n"+
-			"Should be explicitly set when running non-interactively, e.g., in CI/CD pipelines. [$UNCLOUD_AUTO_CONFIRM]")
 
 	return cmd
 }
@@ -186,6 +225,27 @@ func initCluster(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteM
 	if opts.wgPort < 1 || opts.wgPort > 65535 {
 		return fmt.Errorf("invalid WireGuard port %d: must be between 1 and 65535", opts.wgPort)
 	}
+	if err := profile.Validate(opts.profile); err != nil {
+		return fmt.Errorf("invalid --profile: %w", err)
+	}
+	if err := store.ValidateBackend(opts.store); err != nil {
+		return fmt.Errorf("invalid --store: %w", err)
+	}
+
+	caddyImage := ""
+	if opts.fromBundle != "" {
+		manifest, err := bundle.ReadManifest(opts.fromBundle)
+		if err != nil {
+			return fmt.Errorf("read bundle '%s': %w", opts.fromBundle, err)
+		}
+		caddyImage = manifest.CaddyImage
+		if !opts.noDNS {
+			// Reserving a domain requires the machine to reach the Uncloud DNS service over the internet.
+			opts.noDNS = true
+			fmt.Println("Skipping cluster domain reservation in Uncloud DNS as the machine is installed from a bundle.")
+		}
+	}
+
 	initOpts := cli.InitClusterOptions{
 		Context:       opts.context,
 		MachineName:   opts.name,
@@ -193,9 +253,14 @@ func initCluster(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteM
 		PublicIP:      publicIP,
 		RemoteMachine: remoteMachine,
 		SkipInstall:   opts.noInstall,
+		SkipChecks:    opts.skipChecks,
 		Version:       opts.version,
+		BundlePath:    opts.fromBundle,
 		WireguardPort: opts.wgPort,
-		AutoConfirm:   opts.yes,
+		Profile:       opts.profile,
+		StoreBackend:  opts.store,
+		UsernsRemap:   opts.usernsRemap,
+		AutoConfirm:   uncli.AssumeYes,
 	}
 	if len(opts.wgEndpoints) > 0 {
 		expanded := cli.ExpandCommaSeparatedValues(opts.wgEndpoints)
@@ -233,6 +298,13 @@ func initCluster(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteM
 	}
 	fmt.Println("Cluster is ready.")
 
+	if opts.usernsRemap {
+		if err = client.SetUsernsRemap(ctx, true); err != nil {
+			return fmt.Errorf("enable user namespace remapping for the cluster: %w", err)
+		}
+		fmt.Println("User namespace remapping enabled for the cluster.")
+	}
+
 	if opts.noCaddy && opts.noDNS {
 		return nil
 	}
@@ -248,7 +320,7 @@ func initCluster(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteM
 	}
 
 	if !opts.noCaddy {
-		d, err := client.NewCaddyDeployment("", "", api.Placement{})
+		d, err := client.NewCaddyDeployment(caddyImage, "", api.Placement{})
 		if err != nil {
 			return fmt.Errorf("create caddy deployment: %w", err)
 		}
@@ -269,3 +341,20 @@ func initCluster(ctx context.Context, uncli *cli.CLI, remoteMachine *cli.RemoteM
 
 	return nil
 }
+
+// validateFromBundle checks the --from-bundle flag is not combined with flags that contradict it.
+func validateFromBundle(cmd *cobra.Command, path string, noInstall bool) error {
+	if path == "" {
+		return nil
+	}
+	if noInstall {
+		return errors.New("--from-bundle can't be used with --no-install")
+	}
+	if cmd.Flags().Changed("version") {
+		return errors.New("--from-bundle can't be used with --version, the bundle defines the version to install")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("bundle: %w", err)
+	}
+	return nil
+}
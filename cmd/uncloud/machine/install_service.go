@@ -0,0 +1,118 @@
+package machine
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/log"
+	"github.com/psviderski/uncloud/internal/systemd"
+	"github.com/spf13/cobra"
+)
+
+type installServiceOptions struct {
+	bin          string
+	dataDir      string
+	logFormat    string
+	print        bool
+	restartDelay time.Duration
+	unitDir      string
+	watchdog     time.Duration
+}
+
+func NewInstallServiceCommand() *cobra.Command {
+	opts := installServiceOptions{}
+	cmd := &cobra.Command{
+		Use:   "install-service",
+		Short: "Install a hardened systemd service for the machine daemon on this machine.",
+		Long: `Install a hardened systemd service for the machine daemon on this machine.
+
+Generates the systemd unit for uncloudd with sandboxing options, an automatic restart policy,
+and a watchdog that restarts the daemon if it stops responding. The command writes the unit
+file, reloads systemd, and enables the service. It must be run as root on the machine.
+Restart the service to apply the new unit to a running daemon.
+
+The daemon notifies systemd once its API is ready, so 'systemctl start uncloud' returns only
+when the machine can accept requests, for example, to initialise or join a cluster.
+
+The watchdog requires uncloudd of the same version or later. Older daemons don't send
+watchdog pings and are restarted by systemd every watchdog timeout. Use --watchdog 0 to
+disable the watchdog for them.`,
+		Example: `  # Install the service on this machine.
+  sudo uc machine install-service
+
+  # Print the unit file without installing it, for example, to review it or copy it to another machine.
+  uc machine install-service --print
+
+  # Install the service with a custom data directory and without the watchdog.
+  sudo uc machine install-service --data-dir /data/uncloud --watchdog 0`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return installService(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.bin, "bin", "/usr/local/bin/uncloudd",
+		"Path to the uncloudd binary.")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", "",
+		"Directory for storing persistent machine state. (default is the daemon's default /var/lib/uncloud)")
+	cmd.Flags().StringVar(&opts.logFormat, "log-format", log.FormatText,
+		fmt.Sprintf("Format of the daemon logs: '%s' or '%s'. Use '%s' to collect the logs with a log shipper.",
+			log.FormatText, log.FormatJSON, log.FormatJSON))
+	cmd.Flags().BoolVar(&opts.print, "print", false,
+		"Print the unit file to stdout instead of installing it.")
+	cmd.Flags().DurationVar(&opts.restartDelay, "restart-delay", systemd.DefaultRestartDelay,
+		"Time to wait before restarting the daemon after it exits.")
+	cmd.Flags().StringVar(&opts.unitDir, "unit-dir", systemd.DefaultUnitDir,
+		"Directory to write the unit file to.")
+	cmd.Flags().DurationVar(&opts.watchdog, "watchdog", systemd.DefaultWatchdog,
+		"Time after which systemd restarts the daemon if it stops responding. 0 disables the watchdog.")
+
+	return cmd
+}
+
+func installService(opts installServiceOptions) error {
+	unit, err := systemd.DaemonUnit(systemd.UnitOptions{
+		BinPath:      opts.bin,
+		DataDir:      opts.dataDir,
+		Watchdog:     opts.watchdog,
+		RestartDelay: opts.restartDelay,
+		LogFormat:    opts.logFormat,
+	})
+	if err != nil {
+		return fmt.Errorf("generate unit: %w", err)
+	}
+
+	if opts.print {
+		fmt.Print(unit)
+		return nil
+	}
+
+	if _, err = os.Stat(opts.bin); err != nil {
+		tui.PrintWarning(fmt.Sprintf("uncloudd binary not found at %s. Install it before starting the service.",
+			opts.bin))
+	}
+
+	path := filepath.Join(opts.unitDir, systemd.DaemonUnitName)
+	if err = os.WriteFile(path, []byte(unit), 0o644); err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("write unit file: %w. Run the command as root or with sudo", err)
+		}
+		return fmt.Errorf("write unit file: %w", err)
+	}
+	fmt.Printf("Systemd unit file created: %s\n", path)
+
+	for _, args := range [][]string{{"daemon-reload"}, {"enable", systemd.DaemonUnitName}} {
+		out, err := exec.Command("systemctl", args...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("systemctl %s: %w: %s", args[0], err, out)
+		}
+	}
+	fmt.Printf("Service %s enabled. Run 'systemctl restart uncloud' to apply the changes to a running daemon.\n",
+		systemd.DaemonUnitName)
+
+	return nil
+}
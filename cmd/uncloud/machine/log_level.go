@@ -0,0 +1,166 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/log"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// logLevelDefault resets the log level to the default of the machine resource profile.
+const logLevelDefault = "default"
+
+type logLevelOptions struct {
+	machines []string
+}
+
+func NewLogLevelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log-level",
+		Short: "Show or change the log level of machine daemons.",
+		Long: `Show or change the log level of machine daemons.
+
+The log level is the minimum level of the messages that the Uncloud daemon writes to its logs. View the logs
+with 'uc machine logs'. The default level depends on the machine resource profile.`,
+	}
+	cmd.AddCommand(
+		newLogLevelSetCommand(),
+		newLogLevelShowCommand(),
+	)
+	return cmd
+}
+
+func newLogLevelSetCommand() *cobra.Command {
+	opts := logLevelOptions{}
+	cmd := &cobra.Command{
+		Use:   "set LEVEL",
+		Short: "Change the log level of machine daemons at runtime.",
+		Long: `Change the log level of machine daemons at runtime without restarting them.
+
+LEVEL is one of debug, info, warn, or error. Use 'default' to reset the level to the default of the machine
+resource profile. The level is reset to the default when the daemon restarts.`,
+		Example: `  # Enable debug logs on a machine to troubleshoot it.
+  uc machine log-level set debug -m machine-1
+
+  # Reset the log level on all machines.
+  uc machine log-level set default`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"debug", "info", "warn", "error", logLevelDefault},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			level := args[0]
+			if level == logLevelDefault {
+				level = ""
+			} else if _, err := log.ParseLevel(level); err != nil {
+				return err
+			}
+			return setLogLevel(cmd.Context(), uncli, level, opts)
+		},
+	}
+	addLogLevelMachinesFlag(cmd, &opts)
+	return cmd
+}
+
+func newLogLevelShowCommand() *cobra.Command {
+	opts := logLevelOptions{}
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the log level of machine daemons.",
+		Example: `  # Show the log level of all machines.
+  uc machine log-level show
+
+  # Show the log level of specific machines.
+  uc machine log-level show -m machine-1,machine-2`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return showLogLevel(cmd.Context(), uncli, opts)
+		},
+	}
+	addLogLevelMachinesFlag(cmd, &opts)
+	return cmd
+}
+
+func addLogLevelMachinesFlag(cmd *cobra.Command, opts *logLevelOptions) {
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Names or IDs of the machines. Can be specified multiple times or as a comma-separated list. "+
+			"(default is all machines)")
+	completion.MachinesFlag(cmd)
+}
+
+func setLogLevel(ctx context.Context, uncli *cli.CLI, level string, opts logLevelOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	ctx = client.ProxyMachinesContext(ctx, cli.ExpandCommaSeparatedValues(opts.machines))
+	resp, err := client.MachineClient.SetLogLevel(ctx, &pb.SetLogLevelRequest{Level: level})
+	if err != nil {
+		return fmt.Errorf("set log level: %w", unimplementedHint(err))
+	}
+	return printLogLevels(resp)
+}
+
+func showLogLevel(ctx context.Context, uncli *cli.CLI, opts logLevelOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	ctx = client.ProxyMachinesContext(ctx, cli.ExpandCommaSeparatedValues(opts.machines))
+	resp, err := client.MachineClient.GetLogLevel(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("get log level: %w", unimplementedHint(err))
+	}
+	return printLogLevels(resp)
+}
+
+func printLogLevels(resp *pb.LogLevelResponse) error {
+	var machines []*pb.MachineLogLevel
+	failed := 0
+	for _, m := range resp.Machines {
+		if m.Metadata != nil && m.Metadata.Error != "" {
+			tui.PrintWarning(fmt.Sprintf("machine '%s': %s", m.Metadata.MachineName, m.Metadata.Error))
+			failed++
+			continue
+		}
+		machines = append(machines, m)
+	}
+	slices.SortFunc(machines, func(a, b *pb.MachineLogLevel) int {
+		return strings.Compare(a.Metadata.GetMachineName(), b.Metadata.GetMachineName())
+	})
+
+	t := tui.NewTable()
+	t.Headers("MACHINE", "LOG LEVEL")
+	for _, m := range machines {
+		t.Row(m.Metadata.GetMachineName(), m.Level)
+	}
+	fmt.Println(t)
+
+	if failed > 0 {
+		return fmt.Errorf("failed on %d machine(s)", failed)
+	}
+	return nil
+}
+
+// unimplementedHint adds a hint to update the daemon if the machines don't support the request.
+func unimplementedHint(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("make sure the target machines are running the latest uncloudd daemon version: %w", err)
+	}
+	return err
+}
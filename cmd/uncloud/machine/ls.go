@@ -3,49 +3,79 @@ package machine
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/netip"
+	"os"
 	"strings"
 
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/spf13/cobra"
 )
 
+type listOptions struct {
+	watch bool
+}
+
 func NewListCommand() *cobra.Command {
+	opts := listOptions{}
 	cmd := &cobra.Command{
 		Use:     "ls",
 		Aliases: []string{"list"},
 		Short:   "List machines in a cluster.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
-			return list(cmd.Context(), uncli)
+			return list(cmd.Context(), uncli, opts)
 		},
 	}
+	cmd.Flags().BoolVarP(&opts.watch, "watch", "w", false,
+		"Keep the list updated as machines or containers in the cluster change.")
 	return cmd
 }
 
-func list(ctx context.Context, uncli *cli.CLI) error {
-	client, err := uncli.ConnectCluster(ctx)
+func list(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
+	c, err := uncli.ConnectCluster(ctx)
 	if err != nil {
 		return fmt.Errorf("connect to cluster: %w", err)
 	}
-	defer client.Close()
+	defer c.Close()
+
+	render := func(ctx context.Context, w io.Writer) error {
+		return printMachines(ctx, c, w)
+	}
+	if opts.watch {
+		return cli.Watch(ctx, c, render)
+	}
+	return render(ctx, os.Stdout)
+}
 
-	machines, err := client.ListMachines(ctx, nil)
+func printMachines(ctx context.Context, c *client.Client, w io.Writer) error {
+	machines, err := c.ListMachines(client.WithFreshMachines(ctx), nil)
 	if err != nil {
 		return fmt.Errorf("list machines: %w", err)
 	}
 
 	// Print the list of machines in a table format.
 	t := tui.NewTable()
-	t.Headers("NAME", "STATE", "ADDRESS", "PUBLIC IP", "WIREGUARD ENDPOINTS", "MACHINE ID")
+	t.Headers("NAME", "STATE", "ROLES", "ZONE", "ADDRESS", "PUBLIC IP", "WIREGUARD ENDPOINTS", "MACHINE ID")
 
 	for _, member := range machines {
 		m := member.Machine
 		subnet, _ := m.Network.Subnet.ToPrefix()
 		subnet = netip.PrefixFrom(network.MachineIP(subnet), subnet.Bits())
 
+		roles := "-"
+		if len(m.Roles) > 0 {
+			roles = strings.Join(m.Roles, ",")
+		}
+
+		zone := "-"
+		if m.Zone != "" {
+			zone = m.Zone
+		}
+
 		publicIP := "-"
 		if m.PublicIp != nil {
 			ip, _ := m.PublicIp.ToAddr()
@@ -61,6 +91,8 @@ func list(ctx context.Context, uncli *cli.CLI) error {
 		t.Row(
 			m.Name,
 			capitalise(member.State.String()),
+			roles,
+			zone,
 			subnet.String(),
 			publicIP,
 			strings.Join(endpoints, tui.Faint.Render(", ")),
@@ -68,8 +100,8 @@ func list(ctx context.Context, uncli *cli.CLI) error {
 		)
 	}
 
-	fmt.Println(t)
-	return nil
+	_, err = fmt.Fprintln(w, t)
+	return err
 }
 
 // capitalise returns a string where the first character is upper case, and the rest is lower case.
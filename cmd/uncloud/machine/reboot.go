@@ -0,0 +1,379 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rebootPollInterval is the interval for checking if a rebooted machine is back and its workloads are healthy.
+const rebootPollInterval = 2 * time.Second
+
+type rebootOptions struct {
+	drain   bool
+	rolling bool
+	timeout time.Duration
+}
+
+func NewRebootCommand() *cobra.Command {
+	opts := rebootOptions{}
+	cmd := &cobra.Command{
+		Use:   "reboot [MACHINE...]",
+		Short: "Reboot machines, optionally one at a time across the cluster.",
+		Long: `Reboot machines, optionally one at a time across the cluster, for example, to apply kernel updates.
+
+After rebooting a machine, the command waits for it to come back, rejoin the cluster network,
+and for the service containers that were running before the reboot to become healthy again.
+
+With --rolling, machines are rebooted one at a time. The next machine is rebooted only after
+the previous one has fully recovered and all other machines in the cluster are up. The rolling
+reboot stops at the first machine that doesn't recover within --timeout. The machine you're
+connected to is rebooted last.
+
+With --drain, the service containers on a machine are stopped before rebooting it so that
+the ingress stops routing traffic to them. They're started again after the reboot.
+
+Machines must run the daemon as a systemd service to be rebooted.`,
+		Example: `  # Reboot a single machine.
+  uc machine reboot machine1
+
+  # Reboot all machines one at a time, draining each before the reboot.
+  uc machine reboot --rolling --drain
+
+  # Reboot the specified machines one at a time.
+  uc machine reboot --rolling machine1 machine2`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return reboot(cmd.Context(), uncli, args, opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Machines(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.drain, "drain", false,
+		"Stop the service containers on each machine before rebooting it and start them again after the reboot.")
+	cmd.Flags().BoolVar(&opts.rolling, "rolling", false,
+		"Reboot machines one at a time waiting for each to recover. Required to reboot more than one machine.\n"+
+			"Reboots all machines in the cluster if no machines are specified.")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Minute,
+		"Maximum time to wait for each machine to come back and its service containers to become healthy.")
+
+	return cmd
+}
+
+func reboot(ctx context.Context, uncli *cli.CLI, namesOrIDs []string, opts rebootOptions) error {
+	return cycleMachines(ctx, uncli, namesOrIDs, opts, "reboot", nil)
+}
+
+// prepareFunc runs on a drained machine before rebooting it and returns whether the machine should be rebooted.
+type prepareFunc func(ctx context.Context, c *client.Client, m *pb.MachineInfo) (bool, error)
+
+// cycleMachines drains, prepares, and reboots the machines one at a time if opts.rolling is set. action describes
+// what's done to the machines in the messages, e.g. "reboot". A nil prepare always reboots the machines.
+func cycleMachines(
+	ctx context.Context, uncli *cli.CLI, namesOrIDs []string, opts rebootOptions, action string, prepare prepareFunc,
+) error {
+	if len(namesOrIDs) == 0 && !opts.rolling {
+		return fmt.Errorf("specify the machines to %s or use --rolling to %s all machines one at a time",
+			action, action)
+	}
+	if len(namesOrIDs) > 1 && !opts.rolling {
+		return fmt.Errorf("use --rolling to %s several machines one at a time to avoid downtime", action)
+	}
+
+	c, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	// The client is replaced after rebooting the connected machine.
+	defer func() {
+		if c != nil {
+			c.Close()
+		}
+	}()
+
+	var filter *api.MachineFilter
+	if len(namesOrIDs) > 0 {
+		filter = &api.MachineFilter{NamesOrIDs: namesOrIDs}
+	}
+	machines, err := c.ListMachines(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if len(machines) == 0 {
+		return errors.New("no machines found in the cluster")
+	}
+	slices.SortFunc(machines, func(a, b *pb.MachineMember) int {
+		return strings.Compare(a.Machine.Name, b.Machine.Name)
+	})
+
+	// Reboot the machine the CLI is connected to last as it drops the connection.
+	local, err := c.MachineClient.Inspect(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect connected machine: %w", err)
+	}
+	slices.SortStableFunc(machines, func(a, b *pb.MachineMember) int {
+		switch {
+		case a.Machine.Id == local.Id:
+			return 1
+		case b.Machine.Id == local.Id:
+			return -1
+		}
+		return 0
+	})
+
+	names := make([]string, len(machines))
+	for i, m := range machines {
+		names[i] = m.Machine.Name
+	}
+	if opts.rolling {
+		fmt.Printf("This will %s the machines one at a time in order: %s.\n", action, strings.Join(names, ", "))
+	} else {
+		fmt.Printf("This will %s machine '%s'.\n", action, names[0])
+	}
+	if opts.drain {
+		fmt.Printf("Service containers on each machine will be stopped before and started again after the %s.\n",
+			action)
+	} else {
+		fmt.Println("Service containers on each machine will be unavailable while it reboots.")
+	}
+	confirmed, err := uncli.Confirm("")
+	if err != nil {
+		return fmt.Errorf("confirm %s: %w", action, err)
+	}
+	if !confirmed {
+		return cli.Cancelled("Cancelled. No changes were made.")
+	}
+
+	for i, m := range machines {
+		fmt.Println()
+		if opts.rolling {
+			if err = checkOtherMachinesUp(ctx, c, m.Machine.Id); err != nil {
+				return fmt.Errorf("rolling %s stopped at machine '%s': %w", action, m.Machine.Name, err)
+			}
+		}
+
+		if c, err = rebootMachine(ctx, uncli, c, m.Machine, m.Machine.Id == local.Id, opts, prepare); err != nil {
+			if rest := names[i+1:]; len(rest) > 0 {
+				fmt.Printf("Machines skipped: %s.\n", strings.Join(rest, ", "))
+			}
+			return fmt.Errorf("%s machine '%s': %w", action, m.Machine.Name, err)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("All machines are done.")
+	return nil
+}
+
+// checkOtherMachinesUp returns an error if any machine other than the one with the given ID isn't up. It prevents
+// a rolling reboot from taking down more than one machine at a time.
+func checkOtherMachinesUp(ctx context.Context, c *client.Client, machineID string) error {
+	machines, err := c.ListMachines(client.WithFreshMachines(ctx), nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	for _, m := range machines {
+		if m.Machine.Id != machineID && m.State != pb.MachineMember_UP {
+			return fmt.Errorf("machine '%s' is %s, wait for it to be up", m.Machine.Name,
+				strings.ToLower(m.State.String()))
+		}
+	}
+	return nil
+}
+
+// rebootMachine drains the machine if requested, runs prepare, reboots the machine if needed, and waits for it to
+// recover. It returns the client to use for the following requests which is a new one if the machine the CLI is
+// connected to has been rebooted.
+func rebootMachine(
+	ctx context.Context,
+	uncli *cli.CLI,
+	c *client.Client,
+	m *pb.MachineInfo,
+	connected bool,
+	opts rebootOptions,
+	prepare prepareFunc,
+) (*client.Client, error) {
+	mctx := c.ProxySingleMachineContext(ctx, m.Id)
+
+	before, err := inspectBootRecovery(mctx, c)
+	if err != nil {
+		return c, fmt.Errorf("inspect machine: %w", err)
+	}
+	resp, err := c.Docker.ListServiceContainers(mctx, "", container.ListOptions{})
+	if err != nil {
+		return c, fmt.Errorf("list service containers: %w", err)
+	}
+	var running []api.ServiceContainer
+	if len(resp) > 0 {
+		running = resp[0].Containers
+	}
+
+	if opts.drain {
+		for _, ctr := range running {
+			fmt.Printf("Stopping container '%s' of service '%s' on machine '%s'.\n",
+				ctr.Name, ctr.ServiceName(), m.Name)
+			if err = c.Docker.StopContainer(mctx, ctr.ID, container.StopOptions{}); err != nil {
+				return c, fmt.Errorf("stop container '%s': %w", ctr.Name, err)
+			}
+		}
+	}
+
+	rebootNeeded := true
+	if prepare != nil {
+		if rebootNeeded, err = prepare(ctx, c, m); err != nil {
+			if opts.drain {
+				fmt.Printf("Service containers on machine '%s' were left stopped.\n", m.Name)
+			}
+			return c, err
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	if rebootNeeded {
+		if _, err = c.MachineClient.Reboot(mctx, &emptypb.Empty{}); err != nil {
+			return c, fmt.Errorf("request reboot: %w", err)
+		}
+		fmt.Printf("Machine '%s' is rebooting.\n", m.Name)
+
+		if connected {
+			// The connection to the rebooting machine is dropped so reconnect once it's back.
+			c.Close()
+			if c, err = reconnect(waitCtx, uncli); err != nil {
+				return nil, fmt.Errorf("reconnect to cluster after reboot: %w", err)
+			}
+			mctx = c.ProxySingleMachineContext(ctx, m.Id)
+		}
+
+		if err = waitRebooted(waitCtx, c, m, before); err != nil {
+			return c, err
+		}
+		fmt.Printf("Machine '%s' is back and up in the cluster.\n", m.Name)
+	}
+
+	if opts.drain {
+		for _, ctr := range running {
+			fmt.Printf("Starting container '%s' of service '%s' on machine '%s'.\n",
+				ctr.Name, ctr.ServiceName(), m.Name)
+			if err = c.Docker.StartContainer(mctx, ctr.ID, container.StartOptions{}); err != nil {
+				return c, fmt.Errorf("start container '%s': %w", ctr.Name, err)
+			}
+		}
+	}
+
+	if err = waitContainersHealthy(waitCtx, c, m, running); err != nil {
+		return c, err
+	}
+	fmt.Printf("Machine '%s' is ready, %d service containers are healthy.\n", m.Name, len(running))
+	return c, nil
+}
+
+// inspectBootRecovery returns the time the boot recovery started on the machine the context is proxied to.
+// It changes every time the machine daemon starts, so it's used to detect that the machine has rebooted.
+func inspectBootRecovery(ctx context.Context, c *client.Client) (*timestamppb.Timestamp, error) {
+	resp, err := c.MachineClient.InspectMachine(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Machines) == 0 || resp.Machines[0].BootRecovery == nil {
+		return nil, nil
+	}
+	return resp.Machines[0].BootRecovery.StartedAt, nil
+}
+
+// reconnect connects to the cluster retrying until the context is done.
+func reconnect(ctx context.Context, uncli *cli.CLI) (*client.Client, error) {
+	for {
+		c, err := uncli.ConnectClusterWithOptions(ctx, cli.ConnectOptions{})
+		if err == nil {
+			return c, nil
+		}
+		select {
+		case <-time.After(rebootPollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+	}
+}
+
+// waitRebooted waits for the machine daemon to start again after the reboot, finish starting the service containers
+// that Docker failed to start, and for the machine to be up in the cluster.
+func waitRebooted(ctx context.Context, c *client.Client, m *pb.MachineInfo, before *timestamppb.Timestamp) error {
+	mctx := c.ProxySingleMachineContext(ctx, m.Id)
+	for {
+		resp, err := c.MachineClient.InspectMachine(mctx, &emptypb.Empty{})
+		if err == nil && len(resp.Machines) > 0 {
+			recovery := resp.Machines[0].BootRecovery
+			if recovery != nil && recovery.FinishedAt != nil &&
+				(before == nil || !recovery.StartedAt.AsTime().Equal(before.AsTime())) {
+				member, err := c.InspectMachine(client.WithFreshMachines(ctx), m.Id)
+				if err == nil && member.State == pb.MachineMember_UP {
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-time.After(rebootPollInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("machine '%s' didn't come back after the reboot: %w", m.Name, ctx.Err())
+		}
+	}
+}
+
+// waitContainersHealthy waits for the containers that were running before the reboot to be running and healthy.
+func waitContainersHealthy(
+	ctx context.Context, c *client.Client, m *pb.MachineInfo, containers []api.ServiceContainer,
+) error {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	mctx := c.ProxySingleMachineContext(ctx, m.Id)
+	var unhealthy []string
+	for {
+		resp, err := c.Docker.ListServiceContainers(mctx, "", container.ListOptions{All: true})
+		if err == nil && len(resp) > 0 {
+			healthy := make(map[string]bool)
+			for _, ctr := range resp[0].Containers {
+				healthy[ctr.ID] = ctr.Healthy()
+			}
+			unhealthy = nil
+			for _, ctr := range containers {
+				if !healthy[ctr.ID] {
+					unhealthy = append(unhealthy, ctr.Name)
+				}
+			}
+			if len(unhealthy) == 0 {
+				return nil
+			}
+		}
+
+		select {
+		case <-time.After(rebootPollInterval):
+		case <-ctx.Done():
+			if len(unhealthy) > 0 {
+				return fmt.Errorf("service containers on machine '%s' aren't healthy after the reboot: %s",
+					m.Name, strings.Join(unhealthy, ", "))
+			}
+			return fmt.Errorf("list service containers on machine '%s': %w", m.Name, ctx.Err())
+		}
+	}
+}
@@ -23,7 +23,6 @@ import (
 
 type removeOptions struct {
 	noReset bool
-	yes     bool
 }
 
 func NewRmCommand() *cobra.Command {
@@ -47,8 +46,6 @@ func NewRmCommand() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
-		"Do not prompt for confirmation before removing the machine.")
 	cmd.Flags().BoolVar(&opts.noReset, "no-reset", false,
 		"Do not reset the machine after removing it from the cluster. This will leave all containers and data intact.")
 
@@ -130,15 +127,13 @@ func remove(ctx context.Context, uncli *cli.CLI, nameOrID string, opts removeOpt
 		fmt.Printf("This will remove machine '%s' from the cluster without resetting it.\n", m.Name)
 	}
 
-	if !opts.yes {
-		confirmed, err := tui.Confirm("")
-		if err != nil {
-			return fmt.Errorf("confirm removal: %w", err)
-		}
-		if !confirmed {
-			fmt.Println("Cancelled. Machine was not removed.")
-			return nil
-		}
+	confirmed, err := uncli.Confirm("")
+	if err != nil {
+		return fmt.Errorf("confirm removal: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Cancelled. Machine was not removed.")
+		return nil
 	}
 
 	if reset && len(containers) > 0 {
@@ -12,12 +12,21 @@ func NewRootCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		NewAddCommand(),
+		NewBootStatusCommand(),
+		NewFaultCommand(),
+		NewHostUpdatesCommand(),
 		NewInitCommand(),
+		NewInstallServiceCommand(),
 		NewListCommand(),
+		NewLogLevelCommand(),
 		NewLogsCommand(),
+		NewRebootCommand(),
 		NewRenameCommand(),
 		NewRmCommand(),
 		NewRTTCommand(),
+		NewRunUpdatesCommand(),
+		NewSetRoleCommand(),
+		NewSyncStatusCommand(),
 		NewUpdateCommand(),
 	)
 	return cmd
@@ -0,0 +1,189 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	RebootAuto   = "auto"
+	RebootAlways = "always"
+	RebootNever  = "never"
+
+	// hostUpdateTimeout is the maximum time to wait for the update command to complete on a machine.
+	hostUpdateTimeout = time.Hour
+)
+
+type runUpdatesOptions struct {
+	rebootOptions
+	command string
+	reboot  string
+}
+
+func NewRunUpdatesCommand() *cobra.Command {
+	opts := runUpdatesOptions{}
+	cmd := &cobra.Command{
+		Use:   "run-updates [MACHINE...]",
+		Short: "Update the OS packages on machines, optionally one at a time across the cluster.",
+		Long: `Update the OS packages on machines, optionally one at a time across the cluster.
+
+The update command runs as root on the machine host. By default, it's 'apt-get update && apt-get -y
+upgrade' or 'dnf -y upgrade --refresh' depending on the package manager on each machine.
+
+After updating a machine, the command reboots it if the host reports that a reboot is required,
+for example, to load a new kernel. Then it waits for the service containers that were running
+before the update to become healthy again. Rolling and drain modes work the same way as in
+'uc machine reboot'.
+
+Each update is recorded in the cluster state. Use 'uc machine host-updates' to see the history.`,
+		Example: `  # Update all machines one at a time, draining each before the update.
+  uc machine run-updates --rolling --drain
+
+  # Update a single machine with a custom command without rebooting it.
+  uc machine run-updates machine1 --command "apt-get update && apt-get -y install --only-upgrade openssl" \
+    --reboot never`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return runUpdates(cmd.Context(), uncli, args, opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Machines(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.command, "command", "",
+		"Shell command that updates the host packages. Detected for apt-get or dnf on each machine if not set.")
+	cmd.Flags().BoolVar(&opts.drain, "drain", false,
+		"Stop the service containers on each machine before the update and start them again after it.")
+	cmd.Flags().StringVar(&opts.reboot, "reboot", RebootAuto, fmt.Sprintf(
+		"When to reboot machines after the update: '%s' if the host requires it, '%s', or '%s'.",
+		RebootAuto, RebootAlways, RebootNever))
+	cmd.Flags().BoolVar(&opts.rolling, "rolling", false,
+		"Update machines one at a time waiting for each to recover. Required to update more than one machine.\n"+
+			"Updates all machines in the cluster if no machines are specified.")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Minute,
+		"Maximum time to wait for each machine to come back and its service containers to become healthy.")
+
+	return cmd
+}
+
+func runUpdates(ctx context.Context, uncli *cli.CLI, namesOrIDs []string, opts runUpdatesOptions) error {
+	switch opts.reboot {
+	case RebootAuto, RebootAlways, RebootNever:
+	default:
+		return fmt.Errorf("invalid --reboot value '%s', must be one of: %s, %s, %s",
+			opts.reboot, RebootAuto, RebootAlways, RebootNever)
+	}
+
+	prepare := func(ctx context.Context, c *client.Client, m *pb.MachineInfo) (bool, error) {
+		fmt.Printf("Updating host packages on machine '%s'.\n", m.Name)
+		updateCtx, cancel := context.WithTimeout(ctx, hostUpdateTimeout)
+		defer cancel()
+
+		update, err := c.RunHostUpdate(updateCtx, m.Id, opts.command)
+		if err != nil {
+			return false, fmt.Errorf("run host update: %w", err)
+		}
+		if update.Error != "" {
+			if update.Output != "" {
+				fmt.Println(tui.Faint.Render(update.Output))
+			}
+			return false, fmt.Errorf("update host packages: %s", update.Error)
+		}
+		fmt.Printf("Host packages updated on machine '%s' in %s.\n", m.Name,
+			units.HumanDuration(update.FinishedAt.Sub(update.StartedAt)))
+
+		switch opts.reboot {
+		case RebootAlways:
+			return true, nil
+		case RebootNever:
+			if update.RebootRequired {
+				fmt.Printf("Machine '%s' requires a reboot to apply the updates, run 'uc machine reboot %s'.\n",
+					m.Name, m.Name)
+			}
+			return false, nil
+		}
+		if !update.RebootRequired {
+			fmt.Printf("Machine '%s' doesn't require a reboot.\n", m.Name)
+		}
+		return update.RebootRequired, nil
+	}
+
+	return cycleMachines(ctx, uncli, namesOrIDs, opts.rebootOptions, "update", prepare)
+}
+
+func NewHostUpdatesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "host-updates MACHINE",
+		Short: "Show the history of OS package updates on a machine.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return hostUpdates(cmd.Context(), uncli, args[0])
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Machines(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+}
+
+func hostUpdates(ctx context.Context, uncli *cli.CLI, nameOrID string) error {
+	c, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	member, err := c.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return fmt.Errorf("inspect machine '%s': %w", nameOrID, err)
+	}
+	history, err := c.HostUpdates(ctx, member.Machine.Id)
+	if err != nil {
+		return fmt.Errorf("get host updates: %w", err)
+	}
+	if len(history.Updates) == 0 {
+		fmt.Printf("Host packages on machine '%s' have never been updated with 'uc machine run-updates'.\n",
+			member.Machine.Name)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	t := tui.NewTable()
+	t.Headers("STARTED", "DURATION", "RESULT", "REBOOT REQUIRED", "COMMAND")
+	// Show the most recent updates first.
+	for i := len(history.Updates) - 1; i >= 0; i-- {
+		u := history.Updates[i]
+		t.Row(
+			units.HumanDuration(now.Sub(u.StartedAt))+" ago",
+			units.HumanDuration(u.FinishedAt.Sub(u.StartedAt)),
+			hostUpdateResult(u),
+			fmt.Sprintf("%t", u.RebootRequired),
+			u.Command,
+		)
+	}
+	fmt.Println(t)
+	return nil
+}
+
+func hostUpdateResult(u api.HostUpdate) string {
+	if u.Error != "" {
+		return "failed: " + u.Error
+	}
+	return "updated"
+}
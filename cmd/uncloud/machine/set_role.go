@@ -0,0 +1,81 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewSetRoleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-role MACHINE ROLE...",
+		Short: "Set the roles of a machine in the cluster.",
+		Long: fmt.Sprintf(`Set the roles of a machine in the cluster. The given roles replace the current ones.
+Use '%s' to remove all roles from the machine.
+
+Supported roles:
+  builder        Runs image builds and serves built images to other machines.
+  control-plane  Coordinates cluster-wide tasks such as image auto-updates.
+  ingress        Runs the Caddy reverse proxy and serves ingress traffic.
+  worker         Runs containers of replicated services without explicit placement.
+
+A role only takes effect when at least one machine in the cluster has it. Otherwise, any machine can do the job.`,
+			RolesNone),
+		Example: `  # Serve ingress traffic only on machine1.
+  uc machine set-role machine1 ingress
+
+  # Make machine2 a control-plane machine that doesn't run replicated services when there are workers.
+  uc machine set-role machine2 control-plane
+
+  # Run service workloads on machine3.
+  uc machine set-role machine3 worker
+
+  # Remove all roles from machine1.
+  uc machine set-role machine1 none`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setRole(cmd.Context(), uncli, args[0], args[1:])
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return append(slices.Clone(api.MachineRoles), RolesNone), cobra.ShellCompDirectiveNoFileComp
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Machines(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+	return cmd
+}
+
+func setRole(ctx context.Context, uncli *cli.CLI, machineNameOrID string, roles []string) error {
+	roles = cli.ExpandCommaSeparatedValues(roles)
+	if len(roles) == 1 && roles[0] == RolesNone {
+		roles = nil
+	}
+	if err := api.ValidateMachineRoles(roles); err != nil {
+		return err
+	}
+
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	machine, err := client.SetMachineRoles(ctx, machineNameOrID, roles)
+	if err != nil {
+		return fmt.Errorf("set machine roles: %w", err)
+	}
+
+	fmt.Printf("Machine '%s' roles set to: %s\n", machine.Name, formatRoles(machine.Roles))
+	fmt.Println(tui.Faint.Render("Service placement changes take effect on the next deployment, " +
+		"e.g. 'uc deploy' or 'uc caddy deploy'."))
+	return nil
+}
@@ -0,0 +1,103 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func NewSyncStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync-status",
+		Short: "Show the connectivity status of machines and conflicts detected after being offline.",
+		Long: `Show the connectivity status of machines and conflicts detected after being offline.
+
+A machine goes offline when it loses connection to all other machines in the cluster.
+An offline machine keeps running and restarting its local containers. Changes to its
+local state are synced automatically when the connection is restored.
+
+After reconnecting, the machine compares its local containers with the changes made
+in the rest of the cluster while it was offline and reports the containers that
+diverged as conflicts. For example, a local container of a service that was redeployed
+or removed on other machines. Redeploy or remove the service to resolve a conflict.
+
+Only reachable machines are shown. Connect to an offline machine directly with
+--connect to see its status.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return syncStatus(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func syncStatus(ctx context.Context, uncli *cli.CLI) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	// Setup context to proxy request to all machines.
+	ctx = client.ProxyMachinesContext(ctx, nil)
+
+	resp, err := client.MachineClient.InspectMachine(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect machines: %w", err)
+	}
+
+	var machines []*pb.MachineDetails
+	for _, m := range resp.Machines {
+		if m.Metadata != nil && m.Metadata.Error != "" {
+			tui.PrintWarning(fmt.Sprintf("failed to inspect machine '%s': %s", m.Metadata.MachineName, m.Metadata.Error))
+			continue
+		}
+		if m.Machine == nil {
+			continue
+		}
+		machines = append(machines, m)
+	}
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].Machine.Name < machines[j].Machine.Name
+	})
+
+	now := time.Now().UTC()
+	t := tui.NewTable()
+	t.Headers("MACHINE", "STATUS", "LAST RECONNECTED", "CONFLICTS")
+	for _, m := range machines {
+		status := "online"
+		if m.OfflineSince != nil {
+			status = "offline for " + units.HumanDuration(now.Sub(m.OfflineSince.AsTime()))
+		}
+		reconnected := "-"
+		if m.LastReconnected != nil {
+			reconnected = units.HumanDuration(now.Sub(m.LastReconnected.AsTime())) + " ago"
+		}
+		t.Row(m.Machine.Name, status, reconnected, fmt.Sprintf("%d", len(m.SyncConflicts)))
+	}
+	fmt.Println(t)
+
+	conflicts := tui.NewTable()
+	conflicts.Headers("MACHINE", "SERVICE", "CONTAINER", "REASON")
+	found := false
+	for _, m := range machines {
+		for _, c := range m.SyncConflicts {
+			found = true
+			conflicts.Row(m.Machine.Name, c.ServiceName, c.ContainerName, c.Reason)
+		}
+	}
+	if found {
+		fmt.Println()
+		fmt.Println(conflicts)
+	}
+
+	return nil
+}
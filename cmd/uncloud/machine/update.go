@@ -10,13 +10,16 @@ import (
 	"github.com/psviderski/uncloud/internal/cli/completion"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
 
 type updateOptions struct {
 	name        string
 	publicIP    string
+	roles       []string
 	wgEndpoints []string
+	zone        string
 }
 
 func NewUpdateCommand() *cobra.Command {
@@ -26,7 +29,7 @@ func NewUpdateCommand() *cobra.Command {
 		Short: "Update machine configuration in the cluster.",
 		Long: `Update machine configuration in the cluster.
 
-Change the name, public IP address, or WireGuard endpoints of an existing machine.
+Change the name, public IP address, roles, zone, or WireGuard endpoints of an existing machine.
 At least one flag must be specified to perform an update.`,
 		Example: `  # Rename a machine.
   uc machine update machine1 --name web-server
@@ -37,6 +40,15 @@ At least one flag must be specified to perform an update.`,
   # Remove the public IP address from a machine.
   uc machine update machine1 --public-ip none
 
+  # Designate a machine for image builds.
+  uc machine update machine1 --role builder
+
+  # Remove all roles from a machine.
+  uc machine update machine1 --role none
+
+  # Place a machine in the fsn1 zone to spread service replicas across zones.
+  uc machine update machine1 --zone fsn1
+
   # Update WireGuard endpoints for a machine.
   uc machine update machine1 --wg-endpoint 203.0.113.10 --wg-endpoint 192.168.1.5
 
@@ -65,6 +77,13 @@ At least one flag must be specified to perform an update.`,
 		fmt.Sprintf("Public IP address of the machine for ingress configuration. Use '%s' or '' to remove the public IP.",
 			PublicIPNone),
 	)
+	cmd.Flags().StringSliceVar(
+		&opts.roles, "role", nil,
+		fmt.Sprintf("Roles of the machine that replace the current ones. Supported values: %s.\n",
+			strings.Join(api.MachineRoles, ", "))+
+			fmt.Sprintf("Can be specified multiple times or as a comma-separated list. Use '%s' to remove all roles.",
+				RolesNone),
+	)
 	cmd.Flags().StringSliceVar(
 		&opts.wgEndpoints, "wg-endpoint", nil,
 		fmt.Sprintf("WireGuard endpoint address that other machines in the cluster should use to establish "+
@@ -73,14 +92,21 @@ At least one flag must be specified to perform an update.`,
 			"Format: IP, IP:PORT, IPv6, or [IPv6]:PORT. Default port is %d if omitted.\n", network.DefaultWireGuardPort)+
 			"Multiple endpoints can be specified by repeating the flag or using a comma-separated list.",
 	)
+	cmd.Flags().StringVar(
+		&opts.zone, "zone", "",
+		fmt.Sprintf("Zone (failure domain) of the machine, e.g. a data center or a cloud provider location.\n"+
+			"Use '%s' or '' to remove the zone.", ZoneNone),
+	)
 
 	return cmd
 }
 
 func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts updateOptions, machineNameOrID string) error {
 	// Check if at least one flag was explicitly set.
-	if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("public-ip") && !cmd.Flags().Changed("wg-endpoint") {
-		return fmt.Errorf("at least one update flag must be specified (--name, --public-ip, --wg-endpoint)")
+	if !cmd.Flags().Changed("name") && !cmd.Flags().Changed("public-ip") && !cmd.Flags().Changed("role") &&
+		!cmd.Flags().Changed("wg-endpoint") && !cmd.Flags().Changed("zone") {
+		return fmt.Errorf("at least one update flag must be specified " +
+			"(--name, --public-ip, --role, --wg-endpoint, --zone)")
 	}
 
 	client, err := uncli.ConnectCluster(ctx)
@@ -118,6 +144,28 @@ func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts update
 		}
 	}
 
+	if cmd.Flags().Changed("role") {
+		roles := cli.ExpandCommaSeparatedValues(opts.roles)
+		if len(roles) == 1 && roles[0] == RolesNone {
+			roles = nil
+		}
+		if err = api.ValidateMachineRoles(roles); err != nil {
+			return fmt.Errorf("invalid --role: %w", err)
+		}
+		req.Roles = &pb.MachineRoles{Roles: roles}
+	}
+
+	if cmd.Flags().Changed("zone") {
+		zone := opts.zone
+		if zone == ZoneNone {
+			zone = ""
+		}
+		if err = api.ValidateMachineZone(zone); err != nil {
+			return fmt.Errorf("invalid --zone: %w", err)
+		}
+		req.Zone = &zone
+	}
+
 	// Parse and set endpoints if the flag was explicitly provided.
 	if cmd.Flags().Changed("wg-endpoint") {
 		expanded := cli.ExpandCommaSeparatedValues(opts.wgEndpoints)
@@ -157,6 +205,14 @@ func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts update
 		}
 		changes = append(changes, fmt.Sprintf("public IP: %s -> %s", oldIP, newIP))
 	}
+	if cmd.Flags().Changed("role") {
+		changes = append(changes, fmt.Sprintf("roles: %s -> %s",
+			formatRoles(machine.Machine.Roles), formatRoles(updatedMachine.Roles)))
+	}
+	if cmd.Flags().Changed("zone") {
+		changes = append(changes, fmt.Sprintf("zone: %s -> %s",
+			formatZone(machine.Machine.Zone), formatZone(updatedMachine.Zone)))
+	}
 	if cmd.Flags().Changed("wg-endpoint") {
 		formatEndpoints := func(eps []*pb.IPPort) string {
 			if len(eps) == 0 {
@@ -181,3 +237,19 @@ func update(ctx context.Context, uncli *cli.CLI, cmd *cobra.Command, opts update
 
 	return nil
 }
+
+// formatRoles returns a comma-separated list of machine roles or RolesNone if there are none.
+func formatRoles(roles []string) string {
+	if len(roles) == 0 {
+		return RolesNone
+	}
+	return strings.Join(roles, ", ")
+}
+
+// formatZone returns the machine zone or ZoneNone if it's not set.
+func formatZone(zone string) string {
+	if zone == "" {
+		return ZoneNone
+	}
+	return zone
+}
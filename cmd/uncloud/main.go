@@ -9,17 +9,30 @@ import (
 	"strings"
 
 	"charm.land/lipgloss/v2"
+	"github.com/psviderski/uncloud/cmd/uncloud/builder"
+	"github.com/psviderski/uncloud/cmd/uncloud/bundle"
 	"github.com/psviderski/uncloud/cmd/uncloud/caddy"
+	"github.com/psviderski/uncloud/cmd/uncloud/cluster"
 	cmdcontext "github.com/psviderski/uncloud/cmd/uncloud/context"
 	"github.com/psviderski/uncloud/cmd/uncloud/dns"
+	"github.com/psviderski/uncloud/cmd/uncloud/env"
 	"github.com/psviderski/uncloud/cmd/uncloud/image"
+	"github.com/psviderski/uncloud/cmd/uncloud/ingress"
 	cmdmachine "github.com/psviderski/uncloud/cmd/uncloud/machine"
+	"github.com/psviderski/uncloud/cmd/uncloud/migrate"
+	"github.com/psviderski/uncloud/cmd/uncloud/network"
+	"github.com/psviderski/uncloud/cmd/uncloud/policy"
+	"github.com/psviderski/uncloud/cmd/uncloud/project"
+	"github.com/psviderski/uncloud/cmd/uncloud/reservation"
+	"github.com/psviderski/uncloud/cmd/uncloud/secret"
 	"github.com/psviderski/uncloud/cmd/uncloud/service"
+	"github.com/psviderski/uncloud/cmd/uncloud/support"
 	"github.com/psviderski/uncloud/cmd/uncloud/volume"
 	"github.com/psviderski/uncloud/cmd/uncloud/wg"
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/config"
 	"github.com/psviderski/uncloud/internal/fs"
+	"github.com/psviderski/uncloud/internal/grpcversion"
 	"github.com/psviderski/uncloud/internal/log"
 	"github.com/psviderski/uncloud/internal/machine"
 	"github.com/psviderski/uncloud/internal/version"
@@ -27,9 +40,12 @@ import (
 )
 
 type globalOptions struct {
-	configPath string
-	connect    string
-	context    string
+	configPath       string
+	connect          string
+	context          string
+	forceVersionSkew bool
+	progress         string
+	yes              bool
 }
 
 func main() {
@@ -37,8 +53,17 @@ func main() {
 
 	opts := globalOptions{}
 	cmd := &cobra.Command{
-		Use:           "uc",
-		Short:         "A CLI tool for managing Uncloud resources such as machines, services, and volumes.",
+		Use:   "uc",
+		Short: "A CLI tool for managing Uncloud resources such as machines, services, and volumes.",
+		Long: `A CLI tool for managing Uncloud resources such as machines, services, and volumes.
+
+Exit codes:
+  0  Success.
+  1  Generic failure.
+  2  Invalid arguments, flags, or configuration such as a Compose file.
+  3  Partial failure: an operation on multiple targets failed for some of them.
+  4  Failed to connect to the cluster or lost the connection.
+  5  Conflict with the current cluster state, e.g. a resource already exists or is locked.`,
 		Version:       version.String(),
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -46,6 +71,12 @@ func main() {
 			cli.BindEnvToFlag(cmd, "connect", "UNCLOUD_CONNECT")
 			cli.BindEnvToFlag(cmd, "context", "UNCLOUD_CONTEXT")
 			cli.BindEnvToFlag(cmd, "uncloud-config", "UNCLOUD_CONFIG")
+			cli.BindEnvToFlag(cmd, "force-version-skew", "UNCLOUD_FORCE_VERSION_SKEW")
+			grpcversion.AllowVersionSkew.Store(opts.forceVersionSkew)
+			cli.BindEnvToFlag(cmd, "yes", cli.AssumeYesEnv)
+			// UNCLOUD_AUTO_CONFIRM is the previous name of UNCLOUD_ASSUME_YES kept for backward compatibility.
+			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
+			cli.BindEnvToFlag(cmd, "progress", "UNCLOUD_PROGRESS")
 
 			var conn *config.MachineConnection
 			if opts.connect != "" {
@@ -93,6 +124,10 @@ func main() {
 			if err != nil {
 				return fmt.Errorf("initialise CLI: %w", err)
 			}
+			uncli.AssumeYes = opts.yes
+			if err = uncli.SetProgressMode(opts.progress); err != nil {
+				return err
+			}
 			cmd.SetContext(context.WithValue(cmd.Context(), "cli", uncli))
 			return nil
 		},
@@ -106,6 +141,16 @@ func main() {
 	_ = cmd.MarkPersistentFlagFilename("uncloud-config", "yaml", "yml")
 	cmd.PersistentFlags().StringVarP(&opts.context, "context", "c", "",
 		"Name of the cluster context to use (default is the current context). [$UNCLOUD_CONTEXT]")
+	cmd.PersistentFlags().BoolVar(&opts.forceVersionSkew, "force-version-skew", false,
+		"Proceed even if the CLI and machine daemon versions are more than one minor version apart.\n"+
+			"Unsupported version skew may cause unexpected behavior. [$UNCLOUD_FORCE_VERSION_SKEW]")
+	cmd.PersistentFlags().StringVar(&opts.progress, "progress", cli.ProgressAuto, fmt.Sprintf(
+		"Type of progress output for long-running operations such as deploy or image pull: %s.\n"+
+			"Use 'plain' to print each update on a new line, e.g. in CI logs. [$UNCLOUD_PROGRESS]",
+		strings.Join(cli.ProgressModes, ", ")))
+	cmd.PersistentFlags().BoolVarP(&opts.yes, "yes", "y", false,
+		"Auto-confirm all prompts. Should be explicitly set when running non-interactively,\n"+
+			"e.g., in scripts or CI/CD pipelines. [$UNCLOUD_ASSUME_YES]")
 
 	// Set custom help function to show links to docs and Discord only for the root 'uc' command.
 	defaultHelpFunc := cmd.HelpFunc()
@@ -131,19 +176,35 @@ func main() {
 	})
 
 	cmd.AddCommand(
+		NewBenchCommand(),
 		NewBuildCommand(),
+		NewComposeCommand(),
 		NewDeployCommand(),
 		NewDocsCommand(),
 		NewImagesCommand(),
+		NewInspectCommand(),
 		NewPsCommand(),
+		NewSelfUpdateCommand(),
+		NewVersionCommand(),
+		builder.NewRootCommand(),
+		bundle.NewRootCommand(),
 		caddy.NewRootCommand(),
+		cluster.NewRootCommand(),
 		cmdcontext.NewRootCommand(),
 		dns.NewRootCommand(),
+		env.NewRootCommand(),
 		image.NewRootCommand(),
+		ingress.NewRootCommand(),
 		cmdmachine.NewRootCommand(),
+		migrate.NewRootCommand(),
+		network.NewRootCommand(),
+		policy.NewRootCommand(),
+		project.NewRootCommand(),
+		reservation.NewRootCommand(),
+		secret.NewRootCommand(),
 		service.NewRootCommand(),
+		service.NewBackupCommand("service"),
 		service.NewExecCommand("service"),
-		service.NewInspectCommand("service"),
 		service.NewListCommand("service"),
 		service.NewLogsCommand("service"),
 		service.NewRmCommand("service"),
@@ -151,14 +212,18 @@ func main() {
 		service.NewScaleCommand("service"),
 		service.NewStartCommand("service"),
 		service.NewStopCommand("service"),
+		support.NewSupportBundleCommand(),
 		volume.NewRootCommand(),
 		wg.NewRootCommand(),
 	)
+	cli.WrapUsageErrors(cmd)
+
 	if err := cmd.Execute(); err != nil {
 		if cancelled, ok := errors.AsType[*cli.CancelledError](err); ok {
 			fmt.Fprintln(os.Stderr, cancelled.Error())
-			os.Exit(1)
+			os.Exit(cli.ExitCodeError)
 		}
-		cobra.CheckErr(err)
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(cli.ExitCode(err))
 	}
 }
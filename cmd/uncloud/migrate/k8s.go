@@ -0,0 +1,93 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/k8s"
+	"github.com/spf13/cobra"
+)
+
+type k8sOptions struct {
+	files   []string
+	output  string
+	project string
+}
+
+func NewK8sCommand() *cobra.Command {
+	opts := k8sOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "k8s -f PATH [-f PATH...]",
+		Short: "Convert Kubernetes manifests to a Compose file.",
+		Long: `Convert Kubernetes manifests to a Compose file that can be deployed with 'uc deploy'.
+
+This is a best-effort conversion of Deployments, StatefulSets, DaemonSets, Pods, Services, Ingresses, ConfigMaps,
+and Secrets. Services and Ingresses are converted to x-ports, ConfigMaps and Secrets are inlined as environment
+variables and configs. Constructs that can't be converted are reported as warnings. Review the generated file before
+deploying it.`,
+		Example: `  # Convert all manifests in a directory and print the Compose file.
+  uc migrate k8s -f manifests/
+
+  # Convert manifests and write the Compose file.
+  uc migrate k8s -f deployment.yaml -f service.yaml -o compose.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return convertK8s(opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
+		"Kubernetes manifest files or directories to convert. Can be specified multiple times.")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"Write the Compose file to the specified path instead of stdout.")
+	cmd.Flags().StringVarP(&opts.project, "project-name", "p", "",
+		"Project name for the Compose file. (default is the name of the current directory)")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func convertK8s(opts k8sOptions) error {
+	manifests, err := k8s.ReadManifests(opts.files)
+	if err != nil {
+		return fmt.Errorf("read manifests: %w", err)
+	}
+	if len(manifests) == 0 {
+		return fmt.Errorf("no Kubernetes manifests found")
+	}
+
+	projectName := opts.project
+	if projectName == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("get current directory: %w", err)
+		}
+		projectName = filepath.Base(wd)
+	}
+
+	result, err := k8s.Import(projectName, manifests)
+	if err != nil {
+		return fmt.Errorf("convert manifests: %w", err)
+	}
+	for _, w := range result.Warnings {
+		tui.PrintWarning(w)
+	}
+
+	data, err := result.Project.MarshalYAML()
+	if err != nil {
+		return fmt.Errorf("marshal Compose file: %w", err)
+	}
+
+	if opts.output == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+	if err = os.WriteFile(opts.output, data, 0o644); err != nil {
+		return fmt.Errorf("write Compose file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Compose file written to %s\n", opts.output)
+	return nil
+}
@@ -0,0 +1,16 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Convert configurations from other platforms to Uncloud.",
+	}
+	cmd.AddCommand(
+		NewK8sCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,298 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/spf13/cobra"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// staleHandshakeAge is the age of the last WireGuard handshake after which the peer connection is likely broken.
+// Peers are configured with a persistent keepalive so handshakes normally renew every 2 minutes.
+const staleHandshakeAge = 5 * time.Minute
+
+type inspectOptions struct {
+	machines []string
+}
+
+func NewInspectCommand() *cobra.Command {
+	opts := inspectOptions{}
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Inspect the Docker network and WireGuard interface on machines.",
+		Long: `Inspect the Uncloud-managed Docker network and WireGuard interface on machines. By default, on all machines.
+
+Shows the subnet allocated to each machine, containers attached to the Docker network with their IPs,
+WireGuard peers with their handshake ages and allowed IPs, and routes via the WireGuard interface.
+Detected problems such as overlapping subnets, missing peers, stale handshakes, or missing routes are
+reported at the end.`,
+		Example: `  # Inspect the network on all machines.
+  uc network inspect
+
+  # Inspect the network on specific machines.
+  uc network inspect -m machine1,machine2`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return inspect(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Machine names or IDs to inspect the network on. Can be specified multiple times or as a comma-separated "+
+			"list. (default is all machines)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	machines, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	cluster := make([]*pb.MachineInfo, len(machines))
+	for i, m := range machines {
+		cluster[i] = m.Machine
+	}
+
+	proxyCtx := client.ProxyMachinesContext(ctx, cli.ExpandCommaSeparatedValues(opts.machines))
+	resp, err := client.MachineClient.InspectNetwork(proxyCtx, &emptypb.Empty{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return fmt.Errorf("inspect network: make sure the target machines are running the latest "+
+				"uncloudd daemon version: %w", err)
+		}
+		return fmt.Errorf("inspect network: %w", err)
+	}
+
+	var inspected []*pb.MachineNetwork
+	for _, m := range resp.Machines {
+		// NOTE: Metadata should never be nil in practice. This is legacy fallback that will be removed.
+		if m.Metadata == nil {
+			tui.PrintWarning("metadata is missing in response from unknown server")
+			continue
+		}
+		if m.Metadata.Error != "" {
+			tui.PrintWarning(fmt.Sprintf("failed to inspect network on machine '%s': %s",
+				m.Metadata.MachineName, m.Metadata.Error))
+			continue
+		}
+		inspected = append(inspected, m)
+	}
+	slices.SortFunc(inspected, func(a, b *pb.MachineNetwork) int {
+		return strings.Compare(a.Metadata.MachineName, b.Metadata.MachineName)
+	})
+
+	for i, m := range inspected {
+		if i > 0 {
+			fmt.Println()
+		}
+		printMachineNetwork(m, cluster)
+	}
+
+	if problems := diagnose(inspected, cluster, time.Now()); len(problems) > 0 {
+		fmt.Println()
+		fmt.Println(tui.BoldYellow.Render("Problems:"))
+		for _, p := range problems {
+			fmt.Printf("  - %s\n", p)
+		}
+	}
+	return nil
+}
+
+func printMachineNetwork(m *pb.MachineNetwork, cluster []*pb.MachineInfo) {
+	fmt.Println(tui.Bold.Render("Machine: " + m.Metadata.MachineName))
+	if m.Config != nil {
+		subnet, _ := m.Config.Subnet.ToPrefix()
+		managementIP, _ := m.Config.ManagementIp.ToAddr()
+		fmt.Printf("Subnet:               %s\n", subnet)
+		fmt.Printf("Management IP:        %s\n", managementIP)
+	}
+
+	if dn := m.DockerNetwork; dn != nil {
+		subnet, _ := dn.Subnet.ToPrefix()
+		gateway, _ := dn.Gateway.ToAddr()
+		fmt.Printf("Docker network:       %s (%s), subnet %s, gateway %s\n", dn.Name, dn.Driver, subnet, gateway)
+	} else {
+		fmt.Println("Docker network:       not found")
+	}
+
+	if wg := m.Wireguard; wg != nil {
+		fmt.Printf("WireGuard interface:  %s, port %d, public key %s\n",
+			wg.InterfaceName, wg.ListenPort, wgtypes.Key(wg.PublicKey).String())
+	}
+
+	routes := make([]string, len(m.WireguardRoutes))
+	for i, r := range m.WireguardRoutes {
+		prefix, _ := r.ToPrefix()
+		routes[i] = prefix.String()
+	}
+	fmt.Printf("WireGuard routes:     %s\n", strings.Join(routes, ", "))
+
+	if m.DockerNetwork != nil && len(m.DockerNetwork.Containers) > 0 {
+		fmt.Println()
+		t := tui.NewTable()
+		t.Headers("CONTAINER", "ID", "IP")
+		for _, c := range m.DockerNetwork.Containers {
+			ip, _ := c.Ip.ToAddr()
+			t.Row(c.Name, stringid.TruncateID(c.Id), ip.String())
+		}
+		fmt.Println(t)
+	}
+
+	if m.Wireguard != nil && len(m.Wireguard.Peers) > 0 {
+		namesByKey := make(map[string]string, len(cluster))
+		for _, mi := range cluster {
+			if mi.Network != nil {
+				namesByKey[wgtypes.Key(mi.Network.PublicKey).String()] = mi.Name
+			}
+		}
+
+		fmt.Println()
+		t := tui.NewTable()
+		t.Headers("PEER", "ENDPOINT", "HANDSHAKE", "RECEIVED", "SENT", "ALLOWED IPS")
+		for _, p := range m.Wireguard.Peers {
+			name, ok := namesByKey[wgtypes.Key(p.PublicKey).String()]
+			if !ok {
+				name = "(unknown)"
+			}
+			handshake := "never"
+			if p.LastHandshakeTime != nil {
+				handshake = time.Since(p.LastHandshakeTime.AsTime()).Round(time.Second).String() + " ago"
+			}
+			t.Row(
+				name,
+				p.Endpoint,
+				handshake,
+				units.HumanSize(float64(p.ReceiveBytes)),
+				units.HumanSize(float64(p.TransmitBytes)),
+				strings.Join(p.AllowedIps, tui.Faint.Render(", ")),
+			)
+		}
+		fmt.Println(t)
+	}
+}
+
+// diagnose returns the problems detected in the network configuration of the inspected machines, such as
+// overlapping subnets, a Docker network that doesn't match the machine subnet, missing or stale WireGuard peers,
+// and missing routes to other machines.
+func diagnose(inspected []*pb.MachineNetwork, cluster []*pb.MachineInfo, now time.Time) []string {
+	var problems []string
+
+	subnets := make(map[string]netip.Prefix, len(cluster))
+	for _, mi := range cluster {
+		if mi.Network == nil {
+			continue
+		}
+		if subnet, err := mi.Network.Subnet.ToPrefix(); err == nil {
+			subnets[mi.Id] = subnet
+		}
+	}
+	for i, a := range cluster {
+		for _, b := range cluster[i+1:] {
+			sa, okA := subnets[a.Id]
+			sb, okB := subnets[b.Id]
+			if okA && okB && sa.Overlaps(sb) {
+				problems = append(problems, fmt.Sprintf("machines '%s' and '%s' have overlapping subnets %s and %s.",
+					a.Name, b.Name, sa, sb))
+			}
+		}
+	}
+
+	for _, m := range inspected {
+		name := m.Metadata.MachineName
+		if m.DockerNetwork == nil {
+			problems = append(problems, fmt.Sprintf("machine '%s': Docker network not found.", name))
+		} else if subnet, ok := subnets[m.Metadata.MachineId]; ok {
+			if dockerSubnet, err := m.DockerNetwork.Subnet.ToPrefix(); err != nil || dockerSubnet != subnet {
+				problems = append(problems, fmt.Sprintf(
+					"machine '%s': Docker network subnet %s doesn't match the machine subnet %s.",
+					name, dockerSubnet, subnet))
+			}
+		}
+
+		if m.Wireguard == nil {
+			continue
+		}
+		peers := make(map[string]*pb.WireGuardPeer, len(m.Wireguard.Peers))
+		for _, p := range m.Wireguard.Peers {
+			peers[wgtypes.Key(p.PublicKey).String()] = p
+		}
+		var routes []netip.Prefix
+		for _, r := range m.WireguardRoutes {
+			if prefix, err := r.ToPrefix(); err == nil {
+				routes = append(routes, prefix)
+			}
+		}
+
+		for _, peerMachine := range cluster {
+			if peerMachine.Id == m.Metadata.MachineId || peerMachine.Network == nil {
+				continue
+			}
+			peerName := peerMachine.Name
+
+			p, ok := peers[wgtypes.Key(peerMachine.Network.PublicKey).String()]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("machine '%s': no WireGuard peer for machine '%s'.",
+					name, peerName))
+				continue
+			}
+			if p.LastHandshakeTime == nil {
+				problems = append(problems, fmt.Sprintf("machine '%s': no handshake with machine '%s'.",
+					name, peerName))
+			} else if age := now.Sub(p.LastHandshakeTime.AsTime()); age > staleHandshakeAge {
+				problems = append(problems, fmt.Sprintf("machine '%s': last handshake with machine '%s' was %s ago.",
+					name, peerName, age.Round(time.Second)))
+			}
+
+			subnet, ok := subnets[peerMachine.Id]
+			if !ok {
+				continue
+			}
+			var allowed []netip.Prefix
+			for _, ip := range p.AllowedIps {
+				if prefix, err := netip.ParsePrefix(ip); err == nil {
+					allowed = append(allowed, prefix)
+				}
+			}
+			if !covered(allowed, subnet) {
+				problems = append(problems, fmt.Sprintf(
+					"machine '%s': allowed IPs of the peer '%s' don't include its subnet %s.", name, peerName, subnet))
+			}
+			if !covered(routes, subnet) {
+				problems = append(problems, fmt.Sprintf(
+					"machine '%s': no route to the subnet %s of machine '%s' via WireGuard.", name, subnet, peerName))
+			}
+		}
+	}
+
+	return problems
+}
+
+// covered returns true if the subnet is fully contained in any of the prefixes.
+func covered(prefixes []netip.Prefix, subnet netip.Prefix) bool {
+	return slices.ContainsFunc(prefixes, func(p netip.Prefix) bool {
+		return p.Bits() <= subnet.Bits() && p.Contains(subnet.Addr())
+	})
+}
@@ -0,0 +1,131 @@
+package network
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDiagnose(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	machine := func(id, subnet string, key byte) *pb.MachineInfo {
+		return &pb.MachineInfo{
+			Id:   id,
+			Name: "machine-" + id,
+			Network: &pb.NetworkConfig{
+				Subnet:    pb.NewIPPrefix(netip.MustParsePrefix(subnet)),
+				PublicKey: make32(key),
+			},
+		}
+	}
+	peer := func(key byte, handshake time.Time, allowedIPs ...string) *pb.WireGuardPeer {
+		p := &pb.WireGuardPeer{PublicKey: make32(key), AllowedIps: allowedIPs}
+		if !handshake.IsZero() {
+			p.LastHandshakeTime = timestamppb.New(handshake)
+		}
+		return p
+	}
+	routes := func(prefixes ...string) []*pb.IPPrefix {
+		var r []*pb.IPPrefix
+		for _, p := range prefixes {
+			r = append(r, pb.NewIPPrefix(netip.MustParsePrefix(p)))
+		}
+		return r
+	}
+	dockerNetwork := func(subnet string) *pb.DockerNetwork {
+		return &pb.DockerNetwork{Subnet: pb.NewIPPrefix(netip.MustParsePrefix(subnet))}
+	}
+
+	cluster := []*pb.MachineInfo{
+		machine("1", "10.210.0.0/24", 1),
+		machine("2", "10.210.1.0/24", 2),
+	}
+
+	tests := []struct {
+		name      string
+		cluster   []*pb.MachineInfo
+		inspected []*pb.MachineNetwork
+		want      []string
+	}{
+		{
+			name:    "healthy",
+			cluster: cluster,
+			inspected: []*pb.MachineNetwork{
+				{
+					Metadata:      &pb.Metadata{MachineId: "1", MachineName: "machine-1"},
+					DockerNetwork: dockerNetwork("10.210.0.0/24"),
+					Wireguard: &pb.InspectWireGuardNetworkResponse{Peers: []*pb.WireGuardPeer{
+						peer(2, now.Add(-time.Minute), "10.210.1.0/24", "fdcc::2/128"),
+					}},
+					WireguardRoutes: routes("10.210.0.0/16"),
+				},
+			},
+		},
+		{
+			name:    "broken peer",
+			cluster: cluster,
+			inspected: []*pb.MachineNetwork{
+				{
+					Metadata:      &pb.Metadata{MachineId: "1", MachineName: "machine-1"},
+					DockerNetwork: dockerNetwork("10.210.5.0/24"),
+					Wireguard: &pb.InspectWireGuardNetworkResponse{Peers: []*pb.WireGuardPeer{
+						peer(2, now.Add(-time.Hour)),
+					}},
+					WireguardRoutes: routes("10.210.2.0/24"),
+				},
+			},
+			want: []string{
+				"machine 'machine-1': Docker network subnet 10.210.5.0/24 doesn't match the machine subnet 10.210.0.0/24.",
+				"machine 'machine-1': last handshake with machine 'machine-2' was 1h0m0s ago.",
+				"machine 'machine-1': allowed IPs of the peer 'machine-2' don't include its subnet 10.210.1.0/24.",
+				"machine 'machine-1': no route to the subnet 10.210.1.0/24 of machine 'machine-2' via WireGuard.",
+			},
+		},
+		{
+			name:    "missing peer and docker network",
+			cluster: cluster,
+			inspected: []*pb.MachineNetwork{
+				{
+					Metadata:  &pb.Metadata{MachineId: "2", MachineName: "machine-2"},
+					Wireguard: &pb.InspectWireGuardNetworkResponse{},
+				},
+			},
+			want: []string{
+				"machine 'machine-2': Docker network not found.",
+				"machine 'machine-2': no WireGuard peer for machine 'machine-1'.",
+			},
+		},
+		{
+			name: "overlapping subnets",
+			cluster: []*pb.MachineInfo{
+				machine("1", "10.210.0.0/24", 1),
+				machine("2", "10.210.0.0/16", 2),
+			},
+			want: []string{
+				"machines 'machine-1' and 'machine-2' have overlapping subnets 10.210.0.0/24 and 10.210.0.0/16.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, diagnose(tt.inspected, tt.cluster, now))
+		})
+	}
+}
+
+// make32 returns a 32-byte WireGuard public key filled with the given byte.
+func make32(b byte) []byte {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = b
+	}
+	return key
+}
@@ -0,0 +1,391 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/cluster"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	allocationStatusOK         = "ok"
+	allocationStatusOutside    = "outside cluster network"
+	allocationStatusNotApplied = "not applied"
+	allocationStatusUnknown    = "unreachable"
+
+	// subnetApplyTimeout is the maximum time to wait for a machine to restart and apply its new subnet.
+	subnetApplyTimeout = 3 * time.Minute
+)
+
+func NewIPAMCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ipam",
+		Short: "Manage IP address allocation in the cluster network.",
+	}
+	cmd.AddCommand(
+		NewIPAMListCommand(),
+		NewIPAMSetCIDRCommand(),
+	)
+	return cmd
+}
+
+func NewIPAMListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List subnets allocated to machines and IPs used by containers.",
+		Long: `List the cluster network, the subnet allocated to each machine, and the IPs used by containers
+in the subnet.
+
+The STATUS column shows 'outside cluster network' for machines whose subnet hasn't been reallocated yet
+after changing the cluster network, and 'not applied' for machines that haven't applied their new subnet.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return listIPAM(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+// allocation describes the subnet allocated to a machine and its usage by containers.
+type allocation struct {
+	machine string
+	subnet  netip.Prefix
+	// ips are the IPs of containers in the Docker network on the machine. Nil if the machine is unreachable.
+	ips    []netip.Addr
+	status string
+}
+
+func listIPAM(ctx context.Context, uncli *cli.CLI) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	network, err := client.GetClusterNetwork(ctx)
+	if err != nil {
+		return fmt.Errorf("get cluster network: %w", err)
+	}
+	members, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	machines := make([]*pb.MachineInfo, len(members))
+	for i, m := range members {
+		machines[i] = m.Machine
+	}
+
+	resp, err := client.MachineClient.InspectNetwork(client.ProxyMachinesContext(ctx, nil), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect network: %w", err)
+	}
+	var inspected []*pb.MachineNetwork
+	for _, m := range resp.Machines {
+		// NOTE: Metadata should never be nil in practice. This is legacy fallback that will be removed.
+		if m.Metadata == nil {
+			tui.PrintWarning("metadata is missing in response from unknown server")
+			continue
+		}
+		if m.Metadata.Error != "" {
+			tui.PrintWarning(fmt.Sprintf("failed to inspect network on machine '%s': %s",
+				m.Metadata.MachineName, m.Metadata.Error))
+			continue
+		}
+		inspected = append(inspected, m)
+	}
+
+	fmt.Printf("Cluster network: %s\n\n", network)
+
+	t := tui.NewTable()
+	t.Headers("MACHINE", "SUBNET", "USED", "STATUS", "CONTAINER IPS")
+	for _, a := range allocations(network, machines, inspected) {
+		used := "-"
+		ips := make([]string, len(a.ips))
+		if a.ips != nil {
+			used = fmt.Sprintf("%d/%d", len(a.ips), subnetCapacity(a.subnet))
+			for i, ip := range a.ips {
+				ips[i] = ip.String()
+			}
+		}
+		status := a.status
+		if status != allocationStatusOK {
+			status = tui.Yellow.Render(status)
+		}
+		t.Row(a.machine, a.subnet.String(), used, status, strings.Join(ips, tui.Faint.Render(", ")))
+	}
+	fmt.Println(t)
+
+	return nil
+}
+
+// allocations returns the subnet allocations of the machines sorted by machine name. The container IPs
+// and the applied subnets are taken from the inspected machines.
+func allocations(network netip.Prefix, machines []*pb.MachineInfo, inspected []*pb.MachineNetwork) []allocation {
+	byID := make(map[string]*pb.MachineNetwork, len(inspected))
+	for _, m := range inspected {
+		byID[m.Metadata.MachineId] = m
+	}
+
+	allocs := make([]allocation, 0, len(machines))
+	for _, mi := range machines {
+		subnet, _ := mi.Network.Subnet.ToPrefix()
+		a := allocation{machine: mi.Name, subnet: subnet, status: allocationStatusOK}
+
+		if m, ok := byID[mi.Id]; ok {
+			a.ips = []netip.Addr{}
+			if m.DockerNetwork != nil {
+				for _, c := range m.DockerNetwork.Containers {
+					if ip, err := c.Ip.ToAddr(); err == nil {
+						a.ips = append(a.ips, ip)
+					}
+				}
+				slices.SortFunc(a.ips, func(x, y netip.Addr) int { return x.Compare(y) })
+			}
+			if m.Config != nil {
+				if applied, err := m.Config.Subnet.ToPrefix(); err == nil && applied != subnet {
+					a.status = allocationStatusNotApplied
+				}
+			}
+		} else {
+			a.status = allocationStatusUnknown
+		}
+		if !network.Contains(subnet.Addr()) || subnet.Bits() < network.Bits() {
+			a.status = allocationStatusOutside
+		}
+
+		allocs = append(allocs, a)
+	}
+
+	slices.SortFunc(allocs, func(a, b allocation) int {
+		return strings.Compare(a.machine, b.machine)
+	})
+	return allocs
+}
+
+// subnetCapacity returns the number of IPs available for containers in the subnet excluding the network address,
+// the broadcast address, and the machine IP used as the gateway.
+func subnetCapacity(subnet netip.Prefix) int {
+	hostBits := subnet.Addr().BitLen() - subnet.Bits()
+	if hostBits >= 31 {
+		return math.MaxInt32
+	}
+	return max(1<<hostBits-3, 0)
+}
+
+type setCIDROptions struct {
+}
+
+func NewIPAMSetCIDRCommand() *cobra.Command {
+	opts := setCIDROptions{}
+	cmd := &cobra.Command{
+		Use:   "set-cidr CIDR",
+		Short: "Change the cluster network CIDR and reallocate machine subnets.",
+		Long: `Change the cluster network CIDR and reallocate the subnets of machines that are outside the new network.
+
+Machines are reallocated one by one. Each machine gets a new subnet from the new network and restarts its daemon
+to apply it. Containers on the machine are moved to the new subnet and get new IPs. They lose connectivity to
+other machines for a short time while the machine is restarting. The machine you are connected to is reallocated
+last.
+
+If the process is interrupted, run the command again with the same CIDR to resume it.`,
+		Example: `  # Move the cluster to the 10.100.0.0/16 network.
+  uc network ipam set-cidr 10.100.0.0/16`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return setCIDR(cmd.Context(), uncli, args[0], opts)
+		},
+	}
+
+	return cmd
+}
+
+// rolloutStep is a step of the subnet reallocation plan for a machine.
+type rolloutStep struct {
+	machine *pb.MachineInfo
+	// subnet is the new subnet for the machine. Invalid if the current subnet is kept.
+	subnet netip.Prefix
+}
+
+func setCIDR(ctx context.Context, uncli *cli.CLI, cidr string, opts setCIDROptions) error {
+	network, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid CIDR '%s': %w", cidr, err)
+	}
+	network = network.Masked()
+
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	currentNetwork, err := client.GetClusterNetwork(ctx)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return fmt.Errorf("get cluster network: make sure the machines are running the latest "+
+				"uncloudd daemon version: %w", err)
+		}
+		return fmt.Errorf("get cluster network: %w", err)
+	}
+	members, err := client.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	machines := make([]*pb.MachineInfo, len(members))
+	for i, m := range members {
+		machines[i] = m.Machine
+	}
+	proxyMachine, err := client.MachineClient.Inspect(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("inspect proxy machine: %w", err)
+	}
+
+	steps, err := planRollout(network, machines, proxyMachine.Id)
+	if err != nil {
+		return fmt.Errorf("plan machine subnets in network %s: %w", network, err)
+	}
+
+	fmt.Printf("Cluster network: %s → %s\n\n", currentNetwork, network)
+	t := tui.NewTable()
+	t.Headers("MACHINE", "SUBNET", "NEW SUBNET")
+	for _, s := range steps {
+		subnet, _ := s.machine.Network.Subnet.ToPrefix()
+		newSubnet := tui.Faint.Render("unchanged")
+		if s.subnet.IsValid() {
+			newSubnet = s.subnet.String()
+		}
+		t.Row(s.machine.Name, subnet.String(), newSubnet)
+	}
+	fmt.Println(t)
+	fmt.Println()
+	fmt.Println("Machines with a new subnet will restart one by one to apply it. Their containers will get new IPs " +
+		"and lose connectivity to other machines while the machine is restarting.")
+
+	confirmed, err := uncli.Confirm("")
+	if err != nil {
+		return fmt.Errorf("confirm network change: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Cancelled. Cluster network was not changed.")
+		return nil
+	}
+
+	if network != currentNetwork {
+		if _, err = client.SetClusterNetwork(ctx, network); err != nil {
+			return fmt.Errorf("set cluster network: %w", err)
+		}
+		fmt.Printf("Cluster network changed to %s.\n", network)
+	}
+
+	for _, s := range steps {
+		if err = reallocateMachine(ctx, client, s, s.machine.Id == proxyMachine.Id); err != nil {
+			return fmt.Errorf("reallocate subnet for machine '%s': %w", s.machine.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// planRollout returns the subnet reallocation steps for all machines sorted by name with the proxy machine last.
+// Machines that already have a subnet in the network are included to apply their subnet if it hasn't been applied
+// yet, for example, after an interrupted rollout.
+func planRollout(network netip.Prefix, machines []*pb.MachineInfo, proxyMachineID string) ([]rolloutStep, error) {
+	machines = slices.Clone(machines)
+	slices.SortFunc(machines, func(a, b *pb.MachineInfo) int {
+		if (a.Id == proxyMachineID) != (b.Id == proxyMachineID) {
+			if a.Id == proxyMachineID {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	plan, err := cluster.PlanSubnets(network, machines)
+	if err != nil {
+		return nil, err
+	}
+	steps := make([]rolloutStep, len(machines))
+	for i, m := range machines {
+		steps[i] = rolloutStep{machine: m, subnet: plan[m.Id]}
+	}
+	return steps, nil
+}
+
+// reallocateMachine reallocates the machine subnet in the cluster store and applies it on the machine.
+func reallocateMachine(ctx context.Context, client *client.Client, step rolloutStep, proxy bool) error {
+	m := step.machine
+	if step.subnet.IsValid() {
+		updated, err := client.ReallocateMachineSubnet(ctx, m.Id)
+		if err != nil {
+			return err
+		}
+		subnet, _ := updated.Network.Subnet.ToPrefix()
+		fmt.Printf("Machine '%s' subnet reallocated to %s.\n", m.Name, subnet)
+	}
+
+	resp, err := client.MachineClient.ApplySubnet(client.ProxySingleMachineContext(ctx, m.Id), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("apply subnet: %w", err)
+	}
+	subnet, _ := resp.Subnet.ToPrefix()
+	if !resp.Restarting {
+		return nil
+	}
+
+	if proxy {
+		fmt.Printf("Machine '%s' you are connected to is restarting to apply subnet %s. "+
+			"Run 'uc network ipam ls' in a minute to verify the result.\n", m.Name, subnet)
+		return nil
+	}
+
+	fmt.Printf("Machine '%s' is restarting to apply subnet %s...\n", m.Name, subnet)
+	if err = waitSubnetApplied(ctx, client, m.Id, subnet); err != nil {
+		return err
+	}
+	fmt.Printf("Machine '%s' applied subnet %s.\n", m.Name, subnet)
+	return nil
+}
+
+// waitSubnetApplied waits for the machine to come back after the restart with the subnet applied.
+func waitSubnetApplied(ctx context.Context, client *client.Client, machineID string, subnet netip.Prefix) error {
+	ctx, cancel := context.WithTimeout(ctx, subnetApplyTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the machine to apply subnet %s", subnet)
+		case <-ticker.C:
+			resp, err := client.MachineClient.InspectNetwork(
+				client.ProxySingleMachineContext(ctx, machineID), &emptypb.Empty{})
+			// The machine is unreachable while it's restarting.
+			if err != nil || len(resp.Machines) == 0 || resp.Machines[0].DockerNetwork == nil {
+				continue
+			}
+			dockerSubnet, _ := resp.Machines[0].DockerNetwork.Subnet.ToPrefix()
+			if dockerSubnet == subnet {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package network
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocations(t *testing.T) {
+	t.Parallel()
+
+	network := netip.MustParsePrefix("10.210.0.0/16")
+	machines := []*pb.MachineInfo{
+		{Id: "1", Name: "b", Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.210.0.0/24"))}},
+		{Id: "2", Name: "a", Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.210.1.0/24"))}},
+		{Id: "3", Name: "c", Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.100.0.0/24"))}},
+		{Id: "4", Name: "d", Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.210.2.0/24"))}},
+	}
+	inspected := []*pb.MachineNetwork{
+		{
+			Metadata: &pb.Metadata{MachineId: "1"},
+			Config:   &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.210.0.0/24"))},
+			DockerNetwork: &pb.DockerNetwork{Containers: []*pb.DockerNetworkContainer{
+				{Name: "web", Ip: pb.NewIP(netip.MustParseAddr("10.210.0.3"))},
+				{Name: "db", Ip: pb.NewIP(netip.MustParseAddr("10.210.0.2"))},
+			}},
+		},
+		{
+			Metadata:      &pb.Metadata{MachineId: "2"},
+			Config:        &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.100.1.0/24"))},
+			DockerNetwork: &pb.DockerNetwork{},
+		},
+		{
+			Metadata: &pb.Metadata{MachineId: "3"},
+			Config:   &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.100.0.0/24"))},
+		},
+	}
+
+	allocs := allocations(network, machines, inspected)
+	assert.Equal(t, []allocation{
+		{machine: "a", subnet: netip.MustParsePrefix("10.210.1.0/24"), ips: []netip.Addr{},
+			status: allocationStatusNotApplied},
+		{machine: "b", subnet: netip.MustParsePrefix("10.210.0.0/24"), status: allocationStatusOK,
+			ips: []netip.Addr{netip.MustParseAddr("10.210.0.2"), netip.MustParseAddr("10.210.0.3")}},
+		{machine: "c", subnet: netip.MustParsePrefix("10.100.0.0/24"), ips: []netip.Addr{},
+			status: allocationStatusOutside},
+		{machine: "d", subnet: netip.MustParsePrefix("10.210.2.0/24"), status: allocationStatusUnknown},
+	}, allocs)
+	assert.Equal(t, 253, subnetCapacity(allocs[0].subnet))
+}
+
+func TestPlanRollout(t *testing.T) {
+	t.Parallel()
+
+	machine := func(id, name, subnet string) *pb.MachineInfo {
+		return &pb.MachineInfo{
+			Id:      id,
+			Name:    name,
+			Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix(subnet))},
+		}
+	}
+	machines := []*pb.MachineInfo{
+		machine("1", "c", "10.210.0.0/24"),
+		machine("2", "a", "10.210.1.0/24"),
+		machine("3", "b", "10.100.0.0/24"),
+	}
+
+	tests := []struct {
+		name    string
+		network string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:    "new network",
+			network: "10.100.0.0/16",
+			// 10.100.0.0/24 is kept by machine b so new subnets skip it.
+			want: map[string]string{"a": "10.100.2.0/24", "b": "", "c": "10.100.1.0/24"},
+		},
+		{
+			name:    "same network",
+			network: "10.210.0.0/16",
+			want:    map[string]string{"a": "", "b": "10.210.2.0/24", "c": ""},
+		},
+		{
+			name:    "not enough space",
+			network: "10.100.0.0/23",
+			wantErr: "no available subnet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			steps, err := planRollout(netip.MustParsePrefix(tt.network), machines, "2")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// The proxy machine 'a' is the last one.
+			names := make([]string, len(steps))
+			got := make(map[string]string, len(steps))
+			for i, s := range steps {
+				names[i] = s.machine.Name
+				got[s.machine.Name] = ""
+				if s.subnet.IsValid() {
+					got[s.machine.Name] = s.subnet.String()
+				}
+			}
+			assert.Equal(t, []string{"b", "c", "a"}, names)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
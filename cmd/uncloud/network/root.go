@@ -0,0 +1,17 @@
+package network
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Inspect the cluster network and manage IP address allocation.",
+	}
+	cmd.AddCommand(
+		NewInspectCommand(),
+		NewIPAMCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,16 @@
+package policy
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Author admission policies for service deployments.",
+	}
+	cmd.AddCommand(
+		NewTestCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,137 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	composecli "github.com/compose-spec/compose-go/v2/cli"
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/policy"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/spf13/cobra"
+)
+
+type testOptions struct {
+	policyFile string
+	files      []string
+	profiles   []string
+	services   []string
+}
+
+func NewTestCommand() *cobra.Command {
+	opts := testOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "test POLICY_FILE [SERVICE...]",
+		Short: "Check services from a Compose file against an admission policy.",
+		Long: `Check services from a Compose file against an admission policy without deploying them.
+
+Machines evaluate the admission policy from /var/lib/uncloud/policy.yaml (or the file set with
+uncloudd --policy-file) before creating service containers. The policy can set default resource limits
+and reject services that don't pass its rules:
+
+  default_limits:
+    cpu: 0.5
+    memory: 512M
+  require_limits: [cpu, memory]
+  forbid_latest_tag: true
+  allowed_registries: [ghcr.io/acme]
+  forbid_privileged: true
+
+The command fails if any of the services is rejected.`,
+		Example: `  # Check all services in compose.yaml.
+  uc policy test policy.yaml
+
+  # Check the web service from a specific Compose file.
+  uc policy test policy.yaml web -f compose.prod.yaml`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.policyFile = args[0]
+			opts.services = args[1:]
+			return test(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.files, "file", "f", nil,
+		"One or more Compose files with the services to check. (default compose.yaml)")
+	cmd.Flags().StringSliceVarP(&opts.profiles, "profile", "p", nil,
+		"One or more Compose profiles to enable.")
+
+	return cmd
+}
+
+func test(cmd *cobra.Command, opts testOptions) error {
+	data, err := os.ReadFile(opts.policyFile)
+	if err != nil {
+		return fmt.Errorf("read policy file: %w", err)
+	}
+	rules, err := policy.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	project, err := compose.LoadProject(cmd.Context(), opts.files,
+		composecli.WithDefaultProfiles(opts.profiles...))
+	if err != nil {
+		return cli.WithExitCode(cli.ExitCodeValidation, fmt.Errorf("load compose file(s): %w", err))
+	}
+	if len(opts.services) > 0 {
+		if project, err = project.WithSelectedServices(opts.services); err != nil {
+			return fmt.Errorf("select services: %w", err)
+		}
+	}
+
+	rejected := 0
+	for _, name := range project.ServiceNames() {
+		spec, err := compose.ServiceSpecFromCompose(project, name)
+		if err != nil {
+			return fmt.Errorf("convert compose service '%s' to service spec: %w", name, err)
+		}
+		// Evaluate the spec as the machine daemon sees it.
+		spec = spec.SetDefaults()
+
+		admitted, err := rules.Admit(spec)
+		if err != nil {
+			rejectedErr, ok := errors.AsType[*policy.RejectedError](err)
+			if !ok {
+				return fmt.Errorf("service '%s': %w", name, err)
+			}
+			rejected++
+			fmt.Printf("✗ %s: rejected\n", name)
+			for _, v := range rejectedErr.Violations {
+				fmt.Printf("    %s\n", v)
+			}
+			continue
+		}
+
+		changes := limitChanges(spec.Container.Resources, admitted.Container.Resources)
+		if len(changes) == 0 {
+			fmt.Printf("✓ %s: admitted\n", name)
+			continue
+		}
+		fmt.Printf("✓ %s: admitted with changes\n", name)
+		for _, c := range changes {
+			fmt.Printf("    %s\n", c)
+		}
+	}
+
+	if rejected > 0 {
+		return fmt.Errorf("%d of %d services rejected by the policy", rejected, len(project.Services))
+	}
+	return nil
+}
+
+// limitChanges describes the resource limits set by the policy defaults.
+func limitChanges(before, after api.ContainerResources) []string {
+	var changes []string
+	if before.CPU != after.CPU {
+		changes = append(changes, fmt.Sprintf("CPU limit set to %g", float64(after.CPU)/api.Core))
+	}
+	if before.Memory != after.Memory {
+		changes = append(changes, "memory limit set to "+units.BytesSize(float64(after.Memory)))
+	}
+	return changes
+}
@@ -0,0 +1,50 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List Compose projects deployed to the cluster.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("list projects: %w", err)
+	}
+
+	t := tui.NewTable()
+	t.Headers("NAME", "OWNER", "PROTECTED", "SERVICES")
+	for _, p := range projects {
+		protected := ""
+		if p.Protected {
+			protected = tui.Yellow.Render("yes")
+		}
+		t.Row(p.Name, p.Owner, protected, strings.Join(p.Services, ", "))
+	}
+	fmt.Println(t)
+	return nil
+}
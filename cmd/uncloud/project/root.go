@@ -0,0 +1,22 @@
+package project
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Manage the metadata of Compose projects deployed to the cluster.",
+		Long: `Manage the metadata of Compose projects deployed to the cluster.
+
+'uc deploy' records the services deployed from each Compose project. Set an owner to show who is responsible
+for a project and protect production projects from accidental changes: removing or stopping the services
+of a protected project with 'uc rm' or 'uc stop' requires --force.`,
+	}
+	cmd.AddCommand(
+		NewListCommand(),
+		NewSetCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type setOptions struct {
+	owner     string
+	protected bool
+}
+
+func NewSetCommand() *cobra.Command {
+	opts := setOptions{}
+	cmd := &cobra.Command{
+		Use:   "set PROJECT",
+		Short: "Set the owner or protection of a Compose project.",
+		Example: `  # Protect the production project from accidental removal of its services.
+  uc project set shop --protected --owner platform-team
+
+  # Remove the protection.
+  uc project set shop --protected=false`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			var update api.ProjectUpdate
+			if cmd.Flags().Changed("owner") {
+				update.Owner = &opts.owner
+			}
+			if cmd.Flags().Changed("protected") {
+				update.Protected = &opts.protected
+			}
+			if update.Owner == nil && update.Protected == nil {
+				return errors.New("nothing to set, specify --owner or --protected")
+			}
+			return set(cmd.Context(), uncli, args[0], update)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.owner, "owner", "",
+		"Team or person responsible for the project. Use an empty value to unset it.")
+	cmd.Flags().BoolVar(&opts.protected, "protected", false,
+		"Require --force to remove or stop the services of the project.")
+
+	return cmd
+}
+
+func set(ctx context.Context, uncli *cli.CLI, name string, update api.ProjectUpdate) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	p, err := client.UpdateProject(ctx, name, update)
+	if err != nil {
+		return fmt.Errorf("update project: %w", err)
+	}
+
+	state := "not protected"
+	if p.Protected {
+		state = "protected"
+	}
+	if p.Owner != "" {
+		fmt.Printf("Project '%s' owned by %s is %s.\n", p.Name, p.Owner, state)
+	} else {
+		fmt.Printf("Project '%s' is %s.\n", p.Name, state)
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"time"
 
@@ -34,6 +36,7 @@ const (
 
 type psOptions struct {
 	sortBy string
+	watch  bool
 }
 
 func NewPsCommand() *cobra.Command {
@@ -44,7 +47,9 @@ func NewPsCommand() *cobra.Command {
 		Long: `List all service containers across all machines in the cluster.
 
 This command provides a comprehensive overview of all running containers that are part of a service,
-making it easy to see the distribution and status of containers across the cluster.`,
+making it easy to see the distribution and status of containers across the cluster.
+
+With --watch, the list is updated each time containers or machines in the cluster change.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 
@@ -59,6 +64,8 @@ making it easy to see the distribution and status of containers across the clust
 	}
 	cmd.Flags().StringVarP(&opts.sortBy, "sort", "s", sortByService,
 		"Sort containers by 'service', 'machine', or 'health'.")
+	cmd.Flags().BoolVarP(&opts.watch, "watch", "w", false,
+		"Keep the list updated as containers or machines in the cluster change.")
 	return cmd
 }
 
@@ -82,6 +89,16 @@ func runPs(ctx context.Context, uncli *cli.CLI, opts psOptions) error {
 	}
 	defer clusterClient.Close()
 
+	if opts.watch {
+		return cli.Watch(ctx, clusterClient, func(ctx context.Context, w io.Writer) error {
+			containers, err := collectContainers(ctx, clusterClient)
+			if err != nil {
+				return fmt.Errorf("collect containers: %w", err)
+			}
+			return printContainers(w, sortContainers(containers, opts.sortBy))
+		})
+	}
+
 	var containers []containerInfo
 	err = spinner.New().
 		Title(" Collecting container info...").
@@ -101,10 +118,14 @@ func runPs(ctx context.Context, uncli *cli.CLI, opts psOptions) error {
 		return fmt.Errorf("collect containers: %w", err)
 	}
 
-	// Sort the containers based on the sorting option.
+	return printContainers(os.Stdout, sortContainers(containers, opts.sortBy))
+}
+
+// sortContainers sorts the containers in place by the given option and returns them.
+func sortContainers(containers []containerInfo, sortBy string) []containerInfo {
 	sort.SliceStable(containers, func(i, j int) bool {
 		a, b := containers[i], containers[j]
-		switch opts.sortBy {
+		switch sortBy {
 		case sortByHealth:
 			if a.highlight != b.highlight {
 				return a.highlight < b.highlight
@@ -127,11 +148,10 @@ func runPs(ctx context.Context, uncli *cli.CLI, opts psOptions) error {
 		// Fallback to creation time (newest first).
 		return a.created.After(b.created)
 	})
-
-	return printContainers(containers)
+	return containers
 }
 
-func printContainers(containers []containerInfo) error {
+func printContainers(w io.Writer, containers []containerInfo) error {
 	t := tui.NewTable()
 
 	// Show HOOK column only when hook containers are present.
@@ -193,8 +213,8 @@ func printContainers(containers []containerInfo) error {
 		}
 	}
 
-	fmt.Println(t)
-	return nil
+	_, err := fmt.Fprintln(w, t)
+	return err
 }
 
 func collectContainers(ctx context.Context, cli *client.Client) ([]containerInfo, error) {
@@ -0,0 +1,45 @@
+package reservation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List published ports and hostnames reserved by Compose projects.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	reservations, err := client.ListPublishedReservations(ctx)
+	if err != nil {
+		return fmt.Errorf("list reservations: %w", err)
+	}
+
+	t := tui.NewTable()
+	t.Headers("PROJECT", "PORT", "HOSTNAME")
+	for _, r := range reservations {
+		t.Row(r.Project, r.Port, r.Hostname)
+	}
+	fmt.Println(t)
+	return nil
+}
@@ -0,0 +1,45 @@
+package reservation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewReleaseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release PROJECT",
+		Short: "Release all published ports and hostnames reserved by a Compose project.",
+		Long: `Release all published ports and hostnames reserved by a Compose project so that other projects
+can claim them. The running services of the project are not affected.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return release(cmd.Context(), uncli, args[0])
+		},
+	}
+	return cmd
+}
+
+func release(ctx context.Context, uncli *cli.CLI, project string) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	released, err := client.ReleasePublished(ctx, project)
+	if err != nil {
+		return fmt.Errorf("release reservations: %w", err)
+	}
+	for _, r := range released {
+		if r.Port != "" {
+			fmt.Printf("Released port %s.\n", r.Port)
+		} else {
+			fmt.Printf("Released hostname %s.\n", r.Hostname)
+		}
+	}
+	return nil
+}
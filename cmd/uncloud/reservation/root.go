@@ -0,0 +1,25 @@
+package reservation
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reservation",
+		Short: "Manage published port and hostname reservations of Compose projects.",
+		Long: `Manage published port and hostname reservations of Compose projects.
+
+'uc deploy' reserves the published ports and ingress hostnames of the deployed services for their Compose project.
+Deploying a service from another project that publishes the same port or hostname fails with an error naming
+the project that owns it. HTTP(S) ingress ports are shared by all services so only their hostnames are reserved.
+
+Deploying all services of a project releases the reservations the project no longer uses. Release the reservations
+of a project manually after removing all its services.`,
+	}
+	cmd.AddCommand(
+		NewListCommand(),
+		NewReleaseCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,90 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type createOptions struct {
+	file   string
+	update bool
+}
+
+func NewCreateCommand() *cobra.Command {
+	opts := createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create SECRET [VALUE]",
+		Short: "Store a secret in the cluster so that services can reference it by name.",
+		Long: `Store a secret in the cluster encrypted with the cluster public key. Only the cluster machines can
+decrypt it when they mount the secret into service containers.
+
+The value is read from the argument, a file (--file), or the standard input. If the standard input is a terminal,
+you're prompted to enter the value without echoing it. Reference the secret by name as an external secret
+in a Compose file:
+
+  secrets:
+    db_password:
+      external: true
+
+Redeploy the services that use the secret after updating it with --update to apply the new value.`,
+		Example: `  # Create a secret with a value entered interactively.
+  uc secret create db_password
+
+  # Create a secret from the contents of a file.
+  uc secret create tls_key --file server.key
+
+  # Update the value of an existing secret from the standard input.
+  openssl rand -hex 32 | uc secret create api_token --update`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			value, err := readSecretValue(args[1:], opts.file)
+			if err != nil {
+				return err
+			}
+			return create(cmd.Context(), uncli, args[0], value, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "",
+		"Read the secret value from a file. Use '-' to read from the standard input.")
+	cmd.Flags().BoolVar(&opts.update, "update", false,
+		"Update the value of the secret if it already exists.")
+
+	return cmd
+}
+
+func create(ctx context.Context, uncli *cli.CLI, name string, value []byte, opts createOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	secret, err := client.CreateSecret(ctx, name, value, opts.update)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.AlreadyExists:
+			return fmt.Errorf("secret '%s' already exists, use --update to update its value", name)
+		case codes.Unimplemented:
+			return errors.New("the connected machine doesn't support cluster secrets, upgrade it to the latest version")
+		}
+		return fmt.Errorf("create secret: %w", err)
+	}
+
+	if secret.Version > 1 {
+		fmt.Printf("Secret '%s' updated to version %d. Redeploy the services that use it to apply the new value.\n",
+			name, secret.Version)
+		return nil
+	}
+	fmt.Printf("Secret '%s' created.\n", name)
+	return nil
+}
@@ -0,0 +1,61 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewInspectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect SECRET",
+		Short: "Display detailed information on a secret stored in the cluster.",
+		Long: `Display the version and timestamps of a secret stored in the cluster and the services that reference it.
+The secret value is never shown.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return inspect(cmd.Context(), uncli, args[0])
+		},
+	}
+	return cmd
+}
+
+// secretInfo is the output of the inspect command.
+type secretInfo struct {
+	api.ClusterSecret
+	// Services are the names of the services that reference the secret.
+	Services []string
+}
+
+func inspect(ctx context.Context, uncli *cli.CLI, name string) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	secret, err := client.InspectSecret(ctx, name)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("secret '%s' not found", name)
+		}
+		return fmt.Errorf("inspect secret: %w", err)
+	}
+	services, err := client.ClusterSecretUsage(ctx, name)
+	if err != nil {
+		return fmt.Errorf("list services using secret: %w", err)
+	}
+
+	data, err := json.MarshalIndent(secretInfo{ClusterSecret: secret, Services: services}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal secret: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
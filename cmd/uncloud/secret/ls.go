@@ -0,0 +1,55 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List secrets stored in the cluster.",
+		Long:    "List secrets stored in the cluster with 'uc secret create'. Secret values are never shown.",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return list(cmd.Context(), uncli)
+		},
+	}
+	return cmd
+}
+
+func list(ctx context.Context, uncli *cli.CLI) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	secrets, err := client.ListSecrets(ctx)
+	if err != nil {
+		return fmt.Errorf("list secrets: %w", err)
+	}
+
+	now := time.Now()
+	t := tui.NewTable()
+	t.Headers("NAME", "VERSION", "CREATED", "UPDATED")
+	for _, s := range secrets {
+		t.Row(
+			s.Name,
+			strconv.FormatInt(s.Version, 10),
+			units.HumanDuration(now.Sub(s.CreatedAt))+" ago",
+			units.HumanDuration(now.Sub(s.UpdatedAt))+" ago",
+		)
+	}
+	fmt.Println(t)
+	return nil
+}
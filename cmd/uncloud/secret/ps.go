@@ -0,0 +1,82 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/spf13/cobra"
+)
+
+func NewPsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ps SECRET",
+		Short: "List service containers that mount a secret.",
+		Long: `List service containers across all machines in the cluster that mount the secret with the given name,
+and the path, mode, and owner of the mounted file in each of them.
+
+Use it to check which services are affected before rotating or removing a secret.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return ps(cmd.Context(), uncli, args[0])
+		},
+	}
+	return cmd
+}
+
+func ps(ctx context.Context, uncli *cli.CLI, name string) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	usage, err := client.SecretUsage(ctx, name)
+	if err != nil {
+		return fmt.Errorf("list secret usage: %w", err)
+	}
+
+	if len(usage) == 0 {
+		fmt.Printf("No service containers mount secret '%s'.\n", name)
+		return nil
+	}
+
+	t := tui.NewTable()
+	t.Headers("SERVICE", "CONTAINER ID", "MACHINE", "PATH", "MODE", "OWNER", "SEALED")
+
+	for _, u := range usage {
+		id := u.ContainerID
+		if len(id) > 12 {
+			id = id[:12]
+		}
+		sealed := "no"
+		if u.Sealed {
+			sealed = "yes"
+		}
+		t.Row(
+			u.ServiceName,
+			id,
+			u.MachineName,
+			u.Mount.Target(),
+			fmt.Sprintf("%04o", u.Mount.FileMode().Perm()),
+			owner(u.Mount.Uid, u.Mount.Gid),
+			sealed,
+		)
+	}
+
+	fmt.Println(t)
+	return nil
+}
+
+// owner formats the owner of a mounted secret file as UID:GID. An empty ID means root.
+func owner(uid, gid string) string {
+	if uid == "" {
+		uid = "0"
+	}
+	if gid == "" {
+		gid = "0"
+	}
+	return uid + ":" + gid
+}
@@ -0,0 +1,70 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	force bool
+}
+
+func NewRemoveCommand() *cobra.Command {
+	opts := removeOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "rm SECRET [SECRET...]",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove secrets stored in the cluster.",
+		Long: `Remove secrets stored in the cluster. A secret that is referenced by a service can't be removed
+without --force because the service containers can't be recreated without it. Running containers keep
+their copy of the secret.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return remove(cmd.Context(), uncli, args, opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false,
+		"Remove the secrets even if they're referenced by services.")
+
+	return cmd
+}
+
+func remove(ctx context.Context, uncli *cli.CLI, names []string, opts removeOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	for _, name := range names {
+		if !opts.force {
+			services, err := client.ClusterSecretUsage(ctx, name)
+			if err != nil {
+				return fmt.Errorf("list services using secret '%s': %w", name, err)
+			}
+			if len(services) > 0 {
+				return cli.WithExitCode(cli.ExitCodeValidation, fmt.Errorf(
+					"secret '%s' is referenced by services: %s. Use --force to remove it anyway",
+					name, strings.Join(services, ", ")))
+			}
+		}
+
+		if err = client.RemoveSecret(ctx, name); err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return fmt.Errorf("secret '%s' not found", name)
+			}
+			return fmt.Errorf("remove secret '%s': %w", name, err)
+		}
+		fmt.Printf("Secret '%s' removed.\n", name)
+	}
+	return nil
+}
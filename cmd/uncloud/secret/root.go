@@ -0,0 +1,21 @@
+package secret
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage secrets for services in the cluster.",
+	}
+	cmd.AddCommand(
+		NewCreateCommand(),
+		NewInspectCommand(),
+		NewListCommand(),
+		NewPsCommand(),
+		NewRemoveCommand(),
+		NewSealCommand(),
+	)
+	return cmd
+}
@@ -0,0 +1,121 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+type sealOptions struct {
+	file string
+	name string
+}
+
+func NewSealCommand() *cobra.Command {
+	opts := sealOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "seal [VALUE]",
+		Short: "Encrypt a secret value so that it can be safely stored in a Compose file.",
+		Long: `Encrypt a secret value with the cluster public key so that it can be safely committed to git
+inside a Compose file. Only the cluster machines can decrypt the sealed value when they mount the secret
+into service containers.
+
+The value is read from the argument, a file (--file), or the standard input. If the standard input is a terminal,
+you're prompted to enter the value without echoing it. Use the sealed value as the name of an external secret:
+
+  secrets:
+    db_password:
+      external: true
+      name: sealed://...`,
+		Example: `  # Seal a value entered interactively.
+  uc secret seal
+
+  # Seal the contents of a file and print a Compose secret definition.
+  uc secret seal --file db_password.txt --name db_password
+
+  # Seal a value from the standard input.
+  vault kv get -field=password secret/db | uc secret seal`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			value, err := readSecretValue(args, opts.file)
+			if err != nil {
+				return err
+			}
+			return seal(cmd.Context(), uncli, value, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "",
+		"Read the secret value from a file. Use '-' to read from the standard input.")
+	cmd.Flags().StringVar(&opts.name, "name", "",
+		"Print a Compose secret definition with this name instead of the sealed value.")
+
+	return cmd
+}
+
+func readSecretValue(args []string, file string) ([]byte, error) {
+	if len(args) > 0 && file != "" {
+		return nil, fmt.Errorf("specify the secret value either as an argument or with --file, not both")
+	}
+	if len(args) > 0 && args[0] != "-" {
+		return []byte(args[0]), nil
+	}
+
+	if file != "" && file != "-" {
+		value, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read secret file: %w", err)
+		}
+		return value, nil
+	}
+
+	if tui.IsStdinTerminal() {
+		fmt.Fprint(os.Stderr, "Secret value: ")
+		value, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("read secret value: %w", err)
+		}
+		if len(value) == 0 {
+			return nil, fmt.Errorf("secret value is empty")
+		}
+		return value, nil
+	}
+
+	value, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("read secret value from stdin: %w", err)
+	}
+	// Piped values usually end with a newline that isn't part of the secret.
+	return []byte(strings.TrimSuffix(string(value), "\n")), nil
+}
+
+func seal(ctx context.Context, uncli *cli.CLI, value []byte, opts sealOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	sealed, err := client.SealSecret(ctx, value)
+	if err != nil {
+		return err
+	}
+
+	if opts.name == "" {
+		fmt.Println(sealed)
+		return nil
+	}
+	fmt.Printf("secrets:\n  %s:\n    external: true\n    name: %s\n", opts.name, sealed)
+	return nil
+}
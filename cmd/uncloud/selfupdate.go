@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/selfupdate"
+	"github.com/psviderski/uncloud/internal/version"
+	"github.com/spf13/cobra"
+)
+
+type selfUpdateOptions struct {
+	channel   string
+	check     bool
+	checksums string
+	force     bool
+	fromFile  string
+}
+
+func NewSelfUpdateCommand() *cobra.Command {
+	opts := selfUpdateOptions{}
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update the CLI to the latest version.",
+		Long: `Update the CLI to the latest version.
+
+Downloads the latest release for your platform from GitHub, verifies its SHA-256 checksum
+against the checksums published with the release, and replaces the running binary.
+
+Use --from-file to update from a release archive or binary downloaded in advance, for example,
+on a machine without internet access. Pass the checksums.txt file from the same release with
+--checksums to verify the archive.
+
+The CLI installed with Homebrew can't update itself. Use 'brew upgrade uncloud' instead.`,
+		Example: `  # Update to the latest stable release.
+  uc self-update
+
+  # Check if a newer release is available without installing it.
+  uc self-update --check
+
+  # Update to the latest pre-release or stable release, whichever is newer.
+  uc self-update --channel beta
+
+  # Update from a release archive downloaded in advance.
+  uc self-update --from-file uncloud_linux_amd64.tar.gz --checksums checksums.txt`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.check && opts.fromFile != "" {
+				return errors.New("--check and --from-file can't be used together")
+			}
+			if opts.checksums != "" && opts.fromFile == "" {
+				return errors.New("--checksums can only be used with --from-file")
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return selfUpdate(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.channel, "channel", selfupdate.ChannelStable,
+		fmt.Sprintf("Release channel to update from. Supported values: %s.",
+			strings.Join(selfupdate.Channels, ", ")))
+	cmd.Flags().BoolVar(&opts.check, "check", false,
+		"Only check if a newer release is available without installing it.")
+	cmd.Flags().StringVar(&opts.checksums, "checksums", "",
+		"Path to the checksums.txt file from the release to verify the --from-file archive.")
+	cmd.Flags().BoolVar(&opts.force, "force", false,
+		"Install the latest release even if it's not newer than the current version, "+
+			"for example, to switch from the beta to the stable channel.")
+	cmd.Flags().StringVar(&opts.fromFile, "from-file", "",
+		"Path to a release archive (uncloud_<OS>_<ARCH>.tar.gz) or binary to update from instead of downloading it.")
+
+	return cmd
+}
+
+func selfUpdate(ctx context.Context, uncli *cli.CLI, opts selfUpdateOptions) error {
+	exePath, err := selfupdate.ExecutablePath()
+	if err != nil {
+		return fmt.Errorf("get path to the running binary: %w", err)
+	}
+	if strings.Contains(exePath, "/Cellar/") {
+		return errors.New("the CLI is installed with Homebrew and can't update itself, " +
+			"use 'brew upgrade uncloud' instead")
+	}
+
+	var (
+		binary     []byte
+		newVersion string
+	)
+	if opts.fromFile != "" {
+		if binary, err = binaryFromFile(opts.fromFile, opts.checksums); err != nil {
+			return err
+		}
+	} else {
+		client := selfupdate.NewClient()
+		release, err := client.LatestRelease(ctx, opts.channel)
+		if err != nil {
+			return fmt.Errorf("check for updates: %w", err)
+		}
+		newVersion = release.Version
+
+		if !isNewer(release.Version, version.String()) && !opts.force {
+			fmt.Printf("The CLI is up to date (version %s, latest %s release %s).\n",
+				version.String(), opts.channel, release.Version)
+			return nil
+		}
+		if opts.check {
+			fmt.Printf("A new %s release is available: %s (current version %s).\n",
+				opts.channel, release.Version, version.String())
+			fmt.Println("Run 'uc self-update' to install it.")
+			return nil
+		}
+
+		if !uncli.AssumeYes {
+			fmt.Printf("The CLI at %s will be updated from %s to %s.\n", exePath, version.String(), release.Version)
+			if err = confirmSelfUpdate(uncli); err != nil {
+				return err
+			}
+		}
+
+		if binary, err = downloadBinary(ctx, client, release); err != nil {
+			return err
+		}
+	}
+
+	if opts.fromFile != "" && !uncli.AssumeYes {
+		fmt.Printf("The CLI at %s will be replaced with %s.\n", exePath, opts.fromFile)
+		if err = confirmSelfUpdate(uncli); err != nil {
+			return err
+		}
+	}
+
+	if err = selfupdate.Replace(exePath, binary); err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return fmt.Errorf("%w\nRun the command with sudo to update the binary in %s",
+				err, filepath.Dir(exePath))
+		}
+		return err
+	}
+
+	if newVersion != "" {
+		fmt.Printf("Updated the CLI to version %s.\n", newVersion)
+	} else {
+		fmt.Println("Updated the CLI. Run 'uc version' to check the new version.")
+	}
+	return nil
+}
+
+func confirmSelfUpdate(uncli *cli.CLI) error {
+	fmt.Println()
+	confirmed, err := uncli.Confirm("")
+	if err != nil {
+		return fmt.Errorf("confirm update: %w", err)
+	}
+	if !confirmed {
+		return cli.Cancelled("Cancelled. The CLI was not updated.")
+	}
+	return nil
+}
+
+func downloadBinary(ctx context.Context, client *selfupdate.Client, release *selfupdate.Release) ([]byte, error) {
+	archiveName := selfupdate.CurrentArchiveName()
+	fmt.Printf("Downloading %s from release %s...\n", archiveName, release.Tag)
+
+	archive, err := client.Download(ctx, release, archiveName)
+	if err != nil {
+		return nil, fmt.Errorf("download release archive: %w", err)
+	}
+	checksums, err := client.Download(ctx, release, selfupdate.ChecksumsFile)
+	if err != nil {
+		return nil, fmt.Errorf("download checksums: %w", err)
+	}
+	if err = selfupdate.VerifyChecksum(checksums, archiveName, archive); err != nil {
+		return nil, fmt.Errorf("verify release archive: %w", err)
+	}
+
+	binary, err := selfupdate.ExtractBinary(archive)
+	if err != nil {
+		return nil, fmt.Errorf("extract binary: %w", err)
+	}
+	return binary, nil
+}
+
+func binaryFromFile(path, checksumsPath string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	if checksumsPath != "" {
+		checksums, err := os.ReadFile(checksumsPath)
+		if err != nil {
+			return nil, fmt.Errorf("read checksums file: %w", err)
+		}
+		if err = selfupdate.VerifyChecksum(checksums, filepath.Base(path), data); err != nil {
+			return nil, fmt.Errorf("verify file: %w", err)
+		}
+	} else {
+		tui.PrintWarning("the file is not verified. Use --checksums to verify it against the release checksums.")
+	}
+
+	if !selfupdate.IsArchive(data) {
+		return data, nil
+	}
+	binary, err := selfupdate.ExtractBinary(data)
+	if err != nil {
+		return nil, fmt.Errorf("extract binary: %w", err)
+	}
+	return binary, nil
+}
+
+// isNewer returns true if version a is newer than b. Versions that can't be parsed, such as development builds,
+// are always considered older so they can be updated.
+func isNewer(a, b string) bool {
+	va, err := semver.NewVersion(a)
+	if err != nil {
+		return false
+	}
+	vb, err := semver.NewVersion(b)
+	if err != nil || strings.HasSuffix(vb.Prerelease(), "dev") {
+		return true
+	}
+	return va.GreaterThan(vb)
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+func NewAutoUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auto-update",
+		Short: "Manage automatic image updates of services.",
+		Long: `Manage automatic image updates of services with the x-auto-update extension in the Compose file.
+
+The cluster periodically checks the registry for the image tag of these services. When the tag points
+to a new image digest, it performs a rolling update of the service to the new image.`,
+	}
+	cmd.AddCommand(
+		newAutoUpdatePauseCommand(true),
+		newAutoUpdatePauseCommand(false),
+		newAutoUpdateStatusCommand(),
+	)
+	return cmd
+}
+
+func newAutoUpdatePauseCommand(pause bool) *cobra.Command {
+	use, short := "resume [SERVICE]", "Resume automatic image updates for a service or all services."
+	if pause {
+		use, short = "pause [SERVICE]", "Pause automatic image updates for a service or all services."
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: short + `
+Without SERVICE, it applies to all services. Resuming all services also resumes the individually paused ones.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			service := ""
+			if len(args) > 0 {
+				service = args[0]
+			}
+			return setAutoUpdatePaused(cmd.Context(), uncli, service, pause)
+		},
+	}
+}
+
+func setAutoUpdatePaused(ctx context.Context, uncli *cli.CLI, service string, pause bool) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	paused, err := client.SetAutoUpdatePaused(ctx, service, pause)
+	if err != nil {
+		return fmt.Errorf("update paused auto-updates: %w", err)
+	}
+
+	action := "resumed"
+	if pause {
+		action = "paused"
+	}
+	if service == "" {
+		fmt.Printf("Automatic image updates %s for all services.\n", action)
+	} else {
+		fmt.Printf("Automatic image updates %s for service '%s'.\n", action, service)
+		if !pause && paused.All {
+			fmt.Println("Note: automatic image updates are still paused for all services. " +
+				"Run 'uc service auto-update resume' to resume them.")
+		}
+	}
+
+	return nil
+}
+
+func newAutoUpdateStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [SERVICE]",
+		Short: "Show the status of automatic image updates.",
+		Long: `Show the status of automatic image updates for services with auto-update enabled.
+If SERVICE is specified, also show its recent auto-update events.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+
+			service := ""
+			if len(args) > 0 {
+				service = args[0]
+			}
+			return autoUpdateStatus(cmd.Context(), uncli, service)
+		},
+	}
+}
+
+func autoUpdateStatus(ctx context.Context, uncli *cli.CLI, serviceName string) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	var services []api.Service
+	if serviceName != "" {
+		svc, err := client.InspectService(ctx, serviceName)
+		if err != nil {
+			return fmt.Errorf("inspect service '%s': %w", serviceName, err)
+		}
+		services = []api.Service{svc}
+	} else if services, err = client.ListServices(ctx); err != nil {
+		return fmt.Errorf("list services: %w", err)
+	}
+	services = slices.DeleteFunc(services, func(s api.Service) bool {
+		return len(s.Containers) == 0 || s.Containers[0].Container.ServiceSpec.AutoUpdate == nil
+	})
+
+	if len(services) == 0 {
+		if serviceName != "" {
+			fmt.Printf("Automatic image updates are not enabled for service '%s'.\n", serviceName)
+		} else {
+			fmt.Println("No services have automatic image updates enabled.")
+		}
+		return nil
+	}
+	slices.SortFunc(services, func(a, b api.Service) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	paused, err := client.AutoUpdatePaused(ctx)
+	if err != nil {
+		return fmt.Errorf("get paused auto-updates: %w", err)
+	}
+
+	t := tui.NewTable()
+	t.Headers("SERVICE", "IMAGE", "INTERVAL", "DIGEST", "LAST CHECK", "LAST UPDATE", "STATE")
+
+	var status *api.AutoUpdateStatus
+	now := time.Now()
+	for _, svc := range services {
+		if status, err = client.AutoUpdateStatus(ctx, svc.ID); err != nil {
+			return fmt.Errorf("get auto-update status for service '%s': %w", svc.Name, err)
+		}
+		spec := svc.Containers[0].Container.ServiceSpec
+		if status == nil {
+			status = &api.AutoUpdateStatus{}
+		}
+
+		image := status.Image
+		if image == "" {
+			image = spec.Container.Image
+		}
+		t.Row(
+			svc.Name,
+			image,
+			spec.AutoUpdate.Interval.String(),
+			shortDigest(status.Digest),
+			timeAgo(now, status.LastCheck),
+			timeAgo(now, status.LastUpdate),
+			autoUpdateState(status, paused, svc.Name),
+		)
+	}
+	fmt.Println(t)
+
+	// Show the events only for a single service.
+	if serviceName != "" {
+		fmt.Println()
+		if len(status.Events) == 0 {
+			fmt.Println("No auto-update events.")
+			return nil
+		}
+
+		fmt.Println(tui.Bold.Render("Events:"))
+		for _, e := range slices.Backward(status.Events) {
+			fmt.Printf("  %s  %-7s  %s\n", e.Time.Local().Format(time.DateTime), e.Type, e.Message)
+		}
+	}
+
+	return nil
+}
+
+func autoUpdateState(status *api.AutoUpdateStatus, paused *pb.AutoUpdatePaused, service string) string {
+	switch {
+	case paused.All || slices.Contains(paused.Services, service):
+		return "paused"
+	case status.Error != "":
+		return "error: " + status.Error
+	case status.LastCheck.IsZero():
+		return "pending"
+	default:
+		return "ok"
+	}
+}
+
+// shortDigest truncates the hex part of the image digest to 12 characters.
+func shortDigest(digest string) string {
+	algo, hex, ok := strings.Cut(digest, ":")
+	if !ok || len(hex) <= 12 {
+		return digest
+	}
+	return algo + ":" + hex[:12]
+}
+
+func timeAgo(now, t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return units.HumanDuration(now.Sub(t)) + " ago"
+}
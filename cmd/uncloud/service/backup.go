@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// backupTimestampLayout is the layout of the timestamp in the default backup file names.
+const backupTimestampLayout = "20060102T150405Z"
+
+type backupOptions struct {
+	container string
+	output    string
+}
+
+func NewBackupCommand(groupID string) *cobra.Command {
+	opts := backupOptions{}
+	cmd := &cobra.Command{
+		Use:   "backup SERVICE",
+		Short: "Create a logical backup of a service using its backup hook.",
+		Long: `Create a logical backup of a service using its backup hook.
+
+The backup hook is the command defined in the 'x-backup' extension of the service, for example, pg_dump.
+It runs in a running service container and writes the backup to its standard output which is saved to
+the output file. Unlike a raw copy of the volume files, the backup is consistent even when the service
+is writing to its data.
+
+Use '-o -' to write the backup to the standard output, for example, to compress and upload it to
+a backup target. The standard error of the hook is printed to stderr. A partial backup is never saved
+to the output file if the hook fails.
+
+Uncloud doesn't schedule backups. Run the command periodically, e.g. with cron or a CI pipeline.`,
+		Example: `  # Save a backup of the db service to db-<timestamp>.backup in the current directory.
+  uc backup db
+
+  # Save a backup to a specific file.
+  uc backup db -o /backups/db.dump
+
+  # Compress and upload a backup to S3.
+  uc backup db -o - | gzip | aws s3 cp - s3://backups/db-$(date +%F).sql.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return backup(cmd.Context(), uncli, args[0], opts)
+		},
+		GroupID: groupID,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Services(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.container, "container", "",
+		"ID or name of the container to run the backup hook in. Accepts full ID or a unique prefix "+
+			"(default is the first running container of the service)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"File to save the backup to or '-' to write it to the standard output. "+
+			"(default is SERVICE-TIMESTAMP.backup in the current directory)")
+
+	cmd.AddCommand(newBackupVerifyCommand())
+
+	return cmd
+}
+
+func backup(ctx context.Context, uncli *cli.CLI, serviceName string, opts backupOptions) error {
+	// Don't show the connection progress as the backup may be written to the standard output.
+	client, err := uncli.ConnectClusterWithOptions(ctx, cli.ConnectOptions{
+		ShowProgress: false,
+	})
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := client.InspectService(ctx, serviceName)
+	if err != nil {
+		return fmt.Errorf("inspect service: %w", err)
+	}
+	ctr, err := backupContainer(svc, opts.container)
+	if err != nil {
+		return err
+	}
+
+	hook := ctr.Container.ServiceSpec.Backup
+	if hook == nil {
+		return fmt.Errorf("service '%s' has no backup hook: define the backup command with "+
+			"the 'x-backup' extension in the Compose file and redeploy the service", svc.Name)
+	}
+	timeout := api.DefaultBackupTimeout
+	if hook.Timeout != nil {
+		timeout = *hook.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := opts.output
+	if output == "" {
+		output = fmt.Sprintf("%s-%s.backup", svc.Name, time.Now().UTC().Format(backupTimestampLayout))
+	}
+
+	var out io.Writer = os.Stdout
+	var tmp *os.File
+	if output != "-" {
+		// Write to a temporary file next to the output file and rename it when the hook succeeds so that a partial
+		// backup never replaces the output file.
+		tmp, err = os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".*.tmp")
+		if err != nil {
+			return fmt.Errorf("create backup file: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		out = tmp
+	}
+
+	exitCode, err := client.ExecContainer(ctx, svc.ID, ctr.Container.ID, api.ExecOptions{
+		Command:      hook.Command,
+		User:         hook.User,
+		AttachStdout: true,
+		AttachStderr: true,
+		Stdout:       out,
+		Stderr:       os.Stderr,
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("backup hook timed out after %s", timeout)
+		}
+		return fmt.Errorf("run backup hook in container '%s': %w", ctr.Container.Name, err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("backup hook failed in container '%s' with exit code %d", ctr.Container.Name, exitCode)
+	}
+
+	if tmp == nil {
+		return nil
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("write backup file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), output); err != nil {
+		return fmt.Errorf("save backup file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Backup of service '%s' saved to %s\n", svc.Name, output)
+
+	return nil
+}
+
+// backupContainer returns the service container with the given name or ID or the first running container
+// if not specified.
+func backupContainer(svc api.Service, nameOrID string) (api.MachineServiceContainer, error) {
+	if nameOrID != "" {
+		ctr, err := svc.FindContainer(nameOrID)
+		if err != nil {
+			return ctr, fmt.Errorf("find container '%s' in service '%s': %w", nameOrID, svc.Name, err)
+		}
+		if !ctr.Container.State.Running {
+			return ctr, fmt.Errorf("container '%s' is not running", ctr.Container.Name)
+		}
+		return ctr, nil
+	}
+
+	for _, ctr := range svc.Containers {
+		if ctr.Container.Healthy() {
+			return ctr, nil
+		}
+	}
+	return api.MachineServiceContainer{}, fmt.Errorf("service '%s' has no running containers", svc.Name)
+}
+
+type backupVerifyOptions struct {
+	check   string
+	keep    bool
+	machine string
+}
+
+func newBackupVerifyCommand() *cobra.Command {
+	opts := backupVerifyOptions{}
+	cmd := &cobra.Command{
+		Use:   "verify SERVICE [BACKUP]",
+		Short: "Verify that a backup of a service can be restored.",
+		Long: `Verify that a backup of a service can be restored.
+
+Runs a throwaway container of the service on a machine, restores the backup into it using the 'restore'
+command of the 'x-backup' extension, and runs the check command in the container. The throwaway container
+uses the image, environment, configs, and secrets of the service but doesn't mount its volumes, publish its
+ports, or receive traffic, so the running service and its data aren't affected. The container is removed
+when the verification completes.
+
+BACKUP is a backup file created with 'uc backup' or a directory with backup files, in which case the latest
+SERVICE-TIMESTAMP.backup file is used. Defaults to the current directory.
+
+The command exits with a non-zero code if the backup can't be restored or the check fails.`,
+		Example: `  # Verify the latest backup of the db service in /backups.
+  uc backup verify db /backups --check "psql -U postgres -d app -c 'SELECT count(*) FROM users'"
+
+  # Verify a specific backup file on machine-2 and keep the container for inspection.
+  uc backup verify db db.dump --check "pg_isready -U postgres" -m machine-2 --keep`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			path := "."
+			if len(args) > 1 {
+				path = args[1]
+			}
+			return backupVerify(cmd.Context(), uncli, args[0], path, opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Services(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.check, "check", "",
+		"Command to run with 'sh -c' in the container after the backup is restored. "+
+			"The verification fails if it exits with a non-zero code. (required)")
+	cmd.Flags().BoolVar(&opts.keep, "keep", false,
+		"Keep the throwaway container after the verification for inspection.")
+	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
+		"Name or ID of the machine to run the throwaway container on. "+
+			"(default is the machine of the first service container)")
+	_ = cmd.MarkFlagRequired("check")
+
+	return cmd
+}
+
+func backupVerify(ctx context.Context, uncli *cli.CLI, serviceName, path string, opts backupVerifyOptions) error {
+	backupFile, err := latestBackup(path, serviceName)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(backupFile)
+	if err != nil {
+		return fmt.Errorf("open backup file: %w", err)
+	}
+	defer f.Close()
+
+	client, err := uncli.ConnectClusterWithOptions(ctx, cli.ConnectOptions{
+		ShowProgress: false,
+	})
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	svc, err := client.InspectService(ctx, serviceName)
+	if err != nil {
+		return fmt.Errorf("inspect service: %w", err)
+	}
+	if len(svc.Containers) == 0 {
+		return fmt.Errorf("service '%s' has no containers", svc.Name)
+	}
+	spec := svc.Containers[0].Container.ServiceSpec
+	if spec.Backup == nil || len(spec.Backup.RestoreCommand) == 0 {
+		return fmt.Errorf("service '%s' has no backup restore command: define it with the 'restore' attribute of "+
+			"the 'x-backup' extension in the Compose file and redeploy the service", svc.Name)
+	}
+
+	machineID := svc.Containers[0].MachineID
+	if opts.machine != "" {
+		machineID = opts.machine
+	}
+	machine, err := client.InspectMachine(ctx, machineID)
+	if err != nil {
+		return fmt.Errorf("inspect machine '%s': %w", machineID, err)
+	}
+
+	timeout := api.DefaultBackupTimeout
+	if spec.Backup.Timeout != nil {
+		timeout = *spec.Backup.Timeout
+	}
+	verifyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Printf("Creating a throwaway container of service '%s' on machine '%s'...\n", svc.Name, machine.Machine.Name)
+	resp, err := client.CreateBackupVerifyContainer(verifyCtx, svc.ID, spec, machine.Machine.Id)
+	if err != nil {
+		return fmt.Errorf("create throwaway container: %w", err)
+	}
+	defer func() {
+		if opts.keep {
+			fmt.Printf("Throwaway container '%s' is kept for inspection. "+
+				"Remove it with 'docker rm -fv %s' on machine '%s'.\n", resp.Name, resp.Name, machine.Machine.Name)
+			return
+		}
+		// Use the parent context to remove the container even if the verification timed out.
+		err := client.RemoveContainer(ctx, svc.ID, resp.ID, container.RemoveOptions{
+			Force:         true,
+			RemoveVolumes: true,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to remove throwaway container '%s': %v\n", resp.Name, err)
+		}
+	}()
+
+	if err = client.StartContainer(verifyCtx, svc.ID, resp.ID); err != nil {
+		return fmt.Errorf("start throwaway container: %w", err)
+	}
+	if err = waitBackupVerifyContainer(verifyCtx, client, svc.ID, resp.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restoring backup %s...\n", backupFile)
+	exitCode, err := client.ExecContainer(verifyCtx, svc.ID, resp.ID, api.ExecOptions{
+		Command:      spec.Backup.RestoreCommand,
+		User:         spec.Backup.User,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Stdin:        f,
+		Stdout:       io.Discard,
+		Stderr:       os.Stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("run backup restore command: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("backup %s can't be restored: restore command failed with exit code %d",
+			backupFile, exitCode)
+	}
+
+	fmt.Println("Running check command...")
+	exitCode, err = client.ExecContainer(verifyCtx, svc.ID, resp.ID, api.ExecOptions{
+		Command:      []string{"sh", "-c", opts.check},
+		User:         spec.Backup.User,
+		AttachStdout: true,
+		AttachStderr: true,
+		Stdout:       os.Stdout,
+		Stderr:       os.Stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("run check command: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("backup %s is restored but the check command failed with exit code %d",
+			backupFile, exitCode)
+	}
+
+	fmt.Printf("Backup %s of service '%s' is restorable.\n", backupFile, svc.Name)
+	return nil
+}
+
+// latestBackup returns the path if it's a file or the latest backup file of the service created by 'uc backup'
+// with the default name if it's a directory.
+func latestBackup(path, serviceName string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("backup: %w", err)
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", fmt.Errorf("read backup directory: %w", err)
+	}
+	// The backup names contain the UTC timestamp so the latest backup is the last one in lexical order.
+	var names []string
+	for _, e := range entries {
+		ts, ok := strings.CutPrefix(e.Name(), serviceName+"-")
+		if !ok || e.IsDir() {
+			continue
+		}
+		if ts, ok = strings.CutSuffix(ts, ".backup"); !ok {
+			continue
+		}
+		// Skip backups of other services with the same name prefix, e.g. db-replica for db.
+		if _, err = time.Parse(backupTimestampLayout, ts); err == nil {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no backups of service '%s' found in '%s'", serviceName, path)
+	}
+
+	return filepath.Join(path, slices.Max(names)), nil
+}
+
+// waitBackupVerifyContainer waits until the throwaway container is running and healthy if it has a health check.
+func waitBackupVerifyContainer(ctx context.Context, client api.ContainerClient, serviceID, containerID string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		ctr, err := client.InspectContainer(ctx, serviceID, containerID)
+		if err != nil {
+			return fmt.Errorf("inspect throwaway container: %w", err)
+		}
+		if ctr.Container.Healthy() {
+			return nil
+		}
+		if !ctr.Container.State.Running && ctr.Container.State.Status != container.StateCreated {
+			return fmt.Errorf("throwaway container exited with code %d", ctr.Container.State.ExitCode)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for throwaway container to become healthy: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
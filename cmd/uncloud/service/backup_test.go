@@ -0,0 +1,57 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestBackup(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		"db-20261014T030000Z.backup",
+		"db-20261015T030000Z.backup",
+		"db-replica-20261016T030000Z.backup",
+		"web-20261016T030000Z.backup",
+		"db.dump",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "db-20261017T030000Z.backup"), 0o700))
+
+	t.Run("latest in directory", func(t *testing.T) {
+		t.Parallel()
+
+		path, err := latestBackup(dir, "db")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "db-20261015T030000Z.backup"), path)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		t.Parallel()
+
+		file := filepath.Join(dir, "db.dump")
+		path, err := latestBackup(file, "db")
+		require.NoError(t, err)
+		assert.Equal(t, file, path)
+	})
+
+	t.Run("no backups", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := latestBackup(dir, "cache")
+		assert.ErrorContains(t, err, "no backups of service 'cache' found")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := latestBackup(filepath.Join(dir, "missing"), "db")
+		assert.Error(t, err)
+	})
+}
@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"slices"
+	"strconv"
 	"time"
 
 	"github.com/docker/docker/pkg/stringid"
@@ -12,11 +15,13 @@ import (
 	"github.com/psviderski/uncloud/internal/cli/completion"
 	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/spf13/cobra"
 )
 
 type inspectOptions struct {
 	service string
+	watch   bool
 }
 
 func NewInspectCommand(groupID string) *cobra.Command {
@@ -39,25 +44,40 @@ func NewInspectCommand(groupID string) *cobra.Command {
 			return completion.Services(cmd.Context(), uncli, args, toComplete)
 		},
 	}
+	cmd.Flags().BoolVarP(&opts.watch, "watch", "w", false,
+		"Keep the information updated as the service containers or machines in the cluster change.")
 	return cmd
 }
 
 func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
-	client, err := uncli.ConnectCluster(ctx)
+	c, err := uncli.ConnectCluster(ctx)
 	if err != nil {
 		return fmt.Errorf("connect to cluster: %w", err)
 	}
-	defer client.Close()
+	defer c.Close()
 
-	svc, err := client.InspectService(ctx, opts.service)
+	render := func(ctx context.Context, w io.Writer) error {
+		return printService(ctx, c, opts.service, w)
+	}
+	if opts.watch {
+		return cli.Watch(ctx, c, render)
+	}
+	return render(ctx, os.Stdout)
+}
+
+func printService(ctx context.Context, c *client.Client, nameOrID string, w io.Writer) error {
+	svc, err := c.InspectService(ctx, nameOrID)
 	if err != nil {
 		return fmt.Errorf("inspect service: %w", err)
 	}
 
-	fmt.Printf("Service ID: %s\n", svc.ID)
-	fmt.Printf("Name:       %s\n", svc.Name)
-	fmt.Printf("Mode:       %s\n", svc.Mode)
-	fmt.Println()
+	fmt.Fprintf(w, "Service ID: %s\n", svc.ID)
+	fmt.Fprintf(w, "Name:       %s\n", svc.Name)
+	fmt.Fprintf(w, "Mode:       %s\n", svc.Mode)
+	if restart := restartPolicy(svc); restart != "" {
+		fmt.Fprintf(w, "Restart:    %s\n", restart)
+	}
+	fmt.Fprintln(w)
 
 	// Combine regular and hook containers.
 	allContainers := append(svc.Containers, svc.HookContainers...)
@@ -79,9 +99,9 @@ func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
 
 	t := tui.NewTable()
 	if hasHooks {
-		t.Headers("CONTAINER ID", "IMAGE", "CREATED", "STATUS", "HOOK", "IP ADDRESS", "MACHINE")
+		t.Headers("CONTAINER ID", "IMAGE", "CREATED", "STATUS", "RESTARTS", "HOOK", "IP ADDRESS", "MACHINE")
 	} else {
-		t.Headers("CONTAINER ID", "IMAGE", "CREATED", "STATUS", "IP ADDRESS", "MACHINE")
+		t.Headers("CONTAINER ID", "IMAGE", "CREATED", "STATUS", "RESTARTS", "IP ADDRESS", "MACHINE")
 	}
 
 	now := time.Now().UTC()
@@ -110,6 +130,7 @@ func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
 				tui.FormatImage(ctr.Container.Config.Image, tui.NoStyle),
 				created,
 				state,
+				strconv.Itoa(ctr.Container.Restarts()),
 				ctr.Container.Config.Labels[api.LabelHook],
 				ipStr,
 				machine,
@@ -120,12 +141,31 @@ func inspect(ctx context.Context, uncli *cli.CLI, opts inspectOptions) error {
 				tui.FormatImage(ctr.Container.Config.Image, tui.NoStyle),
 				created,
 				state,
+				strconv.Itoa(ctr.Container.Restarts()),
 				ipStr,
 				machine,
 			)
 		}
 	}
 
-	fmt.Println(t)
-	return nil
+	_, err = fmt.Fprintln(w, t)
+	return err
+}
+
+// restartPolicy returns the restart policy of the service from the spec of its most recently created container
+// or an empty string if the service has no containers.
+func restartPolicy(svc api.Service) string {
+	var latest *api.MachineServiceContainer
+	for i := range svc.Containers {
+		if latest == nil || svc.Containers[i].Container.CreatedTime().After(latest.Container.CreatedTime()) {
+			latest = &svc.Containers[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	if latest.Container.ServiceSpec.RestartPolicy == "" {
+		return api.RestartPolicyUnlessStopped
+	}
+	return latest.Container.ServiceSpec.RestartPolicy
 }
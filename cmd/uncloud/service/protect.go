@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkProtected returns an error if any of the services (names or IDs) was deployed from a protected Compose project
+// unless force is set. The action describes what is done to the services, e.g. "remove".
+func checkProtected(ctx context.Context, cli *client.Client, services []string, action string, force bool) error {
+	if force {
+		return nil
+	}
+
+	projects, err := cli.ListProjects(ctx)
+	if err != nil {
+		// Older machines don't support project metadata so no project can be protected.
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return fmt.Errorf("list projects: %w", err)
+	}
+	if !slices.ContainsFunc(projects, func(p api.Project) bool { return p.Protected }) {
+		return nil
+	}
+
+	for _, s := range services {
+		name := s
+		// The service may be specified by ID.
+		if _, ok := api.ServiceProject(projects, name); !ok {
+			if svc, err := cli.InspectService(ctx, s); err == nil {
+				name = svc.Name
+			}
+		}
+
+		if p, ok := api.ServiceProject(projects, name); ok && p.Protected {
+			owner := ""
+			if p.Owner != "" {
+				owner = fmt.Sprintf(" owned by %s", p.Owner)
+			}
+			return fmt.Errorf("service '%s' belongs to protected project '%s'%s. Use --force to %s it anyway",
+				name, p.Name, owner, action)
+		}
+	}
+	return nil
+}
@@ -1,17 +1,30 @@
 package service
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
 
 type rmOptions struct {
 	services []string
+	force    bool
+	volumes  bool
+}
+
+// serviceVolume is a named Docker volume used by a service on a machine.
+type serviceVolume struct {
+	machineID   string
+	machineName string
+	name        string
 }
 
 func NewRmCommand(groupID string) *cobra.Command {
@@ -22,9 +35,16 @@ func NewRmCommand(groupID string) *cobra.Command {
 		Short:   "Remove one or more services.",
 		Long: `Remove one or more services.
 
-The volumes used by the services are preserved and should be removed separately
-with 'uc volume rm'. Anonymous Docker volumes (automatically created from VOLUME
-directives in image Dockerfiles) are automatically removed with their containers.`,
+The named volumes used by the services are preserved unless --volumes is specified.
+The preserved volumes are listed after the removal and can be removed separately with
+'uc volume rm'. Use 'uc volume ls --orphaned' to find all volumes not used by any container.
+Anonymous Docker volumes (automatically created from VOLUME directives in image Dockerfiles)
+are automatically removed with their containers.`,
+		Example: `  # Remove a service and keep its volumes.
+  uc rm web
+
+  # Remove a service and its named volumes after confirmation.
+  uc rm db --volumes`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
@@ -37,6 +57,10 @@ directives in image Dockerfiles) are automatically removed with their containers
 			return completion.Services(cmd.Context(), uncli, args, toComplete)
 		},
 	}
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false,
+		"Remove the services even if they belong to a protected Compose project.")
+	cmd.Flags().BoolVar(&opts.volumes, "volumes", false,
+		"Remove the named volumes used by the services. Volumes still used by other containers are kept.")
 	return cmd
 }
 
@@ -47,6 +71,44 @@ func rm(ctx context.Context, uncli *cli.CLI, opts rmOptions) error {
 	}
 	defer client.Close()
 
+	if err = checkProtected(ctx, client, opts.services, "remove", opts.force); err != nil {
+		return err
+	}
+
+	// Collect the volumes before the service containers are removed as they are the only record of the volumes
+	// used by the services.
+	volumes := make(map[string][]serviceVolume)
+	for _, s := range opts.services {
+		// Removing a service that doesn't exist fails below with a proper error.
+		if svc, err := client.InspectService(ctx, s); err == nil {
+			volumes[s] = serviceVolumes(svc)
+		}
+	}
+
+	if opts.volumes && !uncli.AssumeYes {
+		var all []serviceVolume
+		for _, vols := range volumes {
+			all = append(all, vols...)
+		}
+		if len(all) > 0 {
+			fmt.Println("The following volumes will be removed with the services:")
+			printVolumes(all)
+			fmt.Println()
+
+			confirmed, err := uncli.Confirm("")
+			if err != nil {
+				return fmt.Errorf("confirm removal: %w", err)
+			}
+			if !confirmed {
+				fmt.Println("Cancelled. No services or volumes were removed.")
+				return nil
+			}
+		}
+	}
+
+	// The volumes of the removed services. A volume may be shared by several services so the volumes are removed
+	// after all the services.
+	var removed []serviceVolume
 	for _, s := range opts.services {
 		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
 			if err = client.RemoveService(ctx, s); err != nil {
@@ -54,7 +116,66 @@ func rm(ctx context.Context, uncli *cli.CLI, opts rmOptions) error {
 			}
 			return nil
 		}, uncli.ProgressOut(), "Removing service "+s)
+		if err == nil {
+			for _, v := range volumes[s] {
+				if !slices.Contains(removed, v) {
+					removed = append(removed, v)
+				}
+			}
+		}
 	}
+	if len(removed) == 0 {
+		return err
+	}
+
+	if !opts.volumes {
+		fmt.Println()
+		fmt.Println("The following volumes used by the removed services were preserved:")
+		printVolumes(removed)
+		fmt.Println("Remove them with 'uc volume rm' if they are no longer needed.")
+		return err
+	}
+
+	volumesErr := progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		var removeErr error
+		for _, v := range removed {
+			// Don't force the removal so that the volumes still used by other containers are kept.
+			if err := client.RemoveVolume(ctx, v.machineID, v.name, false); err != nil &&
+				!errors.Is(err, api.ErrNotFound) {
+				removeErr = errors.Join(removeErr, fmt.Errorf("remove volume '%s' on machine '%s': %w",
+					v.name, v.machineName, err))
+			}
+		}
+		return removeErr
+	}, uncli.ProgressOut(), "Removing volumes")
+
+	return errors.Join(err, volumesErr)
+}
 
-	return err
+// serviceVolumes returns the named Docker volumes mounted into the service containers sorted by name and machine.
+func serviceVolumes(svc api.Service) []serviceVolume {
+	var volumes []serviceVolume
+	for _, ctr := range append(svc.Containers, svc.HookContainers...) {
+		for _, v := range ctr.Container.ServiceSpec.MountedDockerVolumes() {
+			sv := serviceVolume{
+				machineID:   ctr.MachineID,
+				machineName: ctr.MachineName,
+				name:        v.DockerVolumeName(),
+			}
+			if !slices.Contains(volumes, sv) {
+				volumes = append(volumes, sv)
+			}
+		}
+	}
+
+	slices.SortFunc(volumes, func(a, b serviceVolume) int {
+		return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.machineName, b.machineName))
+	})
+	return volumes
+}
+
+func printVolumes(volumes []serviceVolume) {
+	for _, v := range volumes {
+		fmt.Printf(" • '%s' on machine '%s'\n", v.name, v.machineName)
+	}
 }
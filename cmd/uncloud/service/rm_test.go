@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceVolumes(t *testing.T) {
+	t.Parallel()
+
+	spec := api.ServiceSpec{
+		Name: "db",
+		Container: api.ContainerSpec{
+			VolumeMounts: []api.VolumeMount{
+				{VolumeName: "data", ContainerPath: "/data"},
+				{VolumeName: "config", ContainerPath: "/config"},
+				{VolumeName: "tmp", ContainerPath: "/tmp"},
+			},
+		},
+		Volumes: []api.VolumeSpec{
+			{Name: "data", Type: api.VolumeTypeVolume, VolumeOptions: &api.VolumeOptions{Name: "db-data"}},
+			{Name: "config", Type: api.VolumeTypeBind, BindOptions: &api.BindOptions{HostPath: "/etc/db"}},
+			{Name: "tmp", Type: api.VolumeTypeTmpfs},
+			// Not mounted into the container.
+			{Name: "unused", Type: api.VolumeTypeVolume},
+		},
+	}
+	ctr := func(machineID, machineName string) api.MachineServiceContainer {
+		return api.MachineServiceContainer{
+			MachineID:   machineID,
+			MachineName: machineName,
+			Container:   api.ServiceContainer{ServiceSpec: spec},
+		}
+	}
+
+	svc := api.Service{
+		Name:       "db",
+		Containers: []api.MachineServiceContainer{ctr("id2", "machine2"), ctr("id1", "machine1"), ctr("id1", "machine1")},
+	}
+
+	assert.Equal(t, []serviceVolume{
+		{machineID: "id1", machineName: "machine1", name: "db-data"},
+		{machineID: "id2", machineName: "machine2", name: "db-data"},
+	}, serviceVolumes(svc))
+	assert.Empty(t, serviceVolumes(api.Service{Name: "empty"}))
+}
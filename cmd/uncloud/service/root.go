@@ -11,6 +11,8 @@ func NewRootCommand() *cobra.Command {
 		Short:   "Manage services in the cluster.",
 	}
 	cmd.AddCommand(
+		NewAutoUpdateCommand(),
+		NewBackupCommand(""),
 		NewExecCommand(""),
 		NewInspectCommand(""),
 		NewListCommand(""),
@@ -20,6 +22,7 @@ func NewRootCommand() *cobra.Command {
 		NewScaleCommand(""),
 		NewStartCommand(""),
 		NewStopCommand(""),
+		NewUpdateCommand(""),
 	)
 	return cmd
 }
@@ -35,6 +35,7 @@ type runOptions struct {
 	publish           []string
 	pull              string
 	replicas          uint
+	restart           string
 	ulimits           []string
 	user              string
 	volumes           []string
@@ -93,7 +94,8 @@ func NewRunCommand(groupID string) *cobra.Command {
 		"Give extended privileges to service containers. This is a security risk and should be used with caution.")
 	cmd.Flags().StringSliceVarP(&opts.publish, "publish", "p", nil,
 		"Publish a service port to make it accessible outside the cluster. Can be specified multiple times.\n"+
-			"Format: [hostname:]container_port[/protocol] or [host_ip:]host_port:container_port[/protocol]@host\n"+
+			"Format: [name=][hostname:]container_port[/protocol] or "+
+			"[name=][host_ip|%interface:]host_port:container_port[/protocol]@host\n"+
 			"Supported protocols: tcp, udp, http, https (default is tcp). If a hostname for http(s) port is not specified\n"+
 			"and a cluster domain is reserved, service-name.cluster-domain will be used as the hostname.\n"+
 			"Examples:\n"+
@@ -101,12 +103,16 @@ func NewRunCommand(groupID string) *cobra.Command {
 			"  -p app.example.com:8080/https  Publish port 8080 as HTTPS via reverse proxy with custom hostname\n"+
 			// TODO: add support for publishing L4 tcp/udp ports.
 			//"  -p 9000:8080                   Publish port 8080 as TCP port 9000 via reverse proxy\n"+
-			"  -p 53:5353/udp@host            Bind UDP port 5353 to host port 53")
+			"  -p 53:5353/udp@host            Bind UDP port 5353 to host port 53\n"+
+			"  -p %eth1:5432:5432@host        Bind TCP port 5432 to host port 5432 on the eth1 interface only")
 	cmd.Flags().StringVar(&opts.pull, "pull", api.PullPolicyMissing,
 		fmt.Sprintf("Pull image from the registry before running service containers ('%s', '%s', '%s').",
 			api.PullPolicyAlways, api.PullPolicyMissing, api.PullPolicyNever))
 	cmd.Flags().UintVar(&opts.replicas, "replicas", 1,
 		"Number of containers to run for the service. Only valid for a replicated service.")
+	cmd.Flags().StringVar(&opts.restart, "restart", api.RestartPolicyUnlessStopped,
+		fmt.Sprintf("Restart policy to apply when service containers exit ('%s', '%s', '%s[:max-retries]', '%s').",
+			api.RestartPolicyNo, api.RestartPolicyAlways, api.RestartPolicyOnFailure, api.RestartPolicyUnlessStopped))
 	cmd.Flags().StringVarP(&opts.user, "user", "u", "",
 		"User name or UID and optionally group name or GID used for running the command inside service containers.\n"+
 			"Format: USER[:GROUP] or UID[:GID]. If not specified, the user is set to the default user of the image.")
@@ -247,6 +253,10 @@ func prepareServiceSpec(opts runOptions) (api.ServiceSpec, error) {
 		Replicas:  opts.replicas,
 		Volumes:   volumes,
 	}
+	// Keep the default policy implicit to not change the spec of services that don't set it.
+	if opts.restart != api.RestartPolicyUnlessStopped {
+		spec.RestartPolicy = opts.restart
+	}
 
 	if caddyfile != "" {
 		spec.Caddy = &api.CaddySpec{
@@ -262,7 +272,7 @@ func prepareServiceSpec(opts runOptions) (api.ServiceSpec, error) {
 	}
 
 	if err = spec.Validate(); err != nil {
-		return spec, fmt.Errorf("invalid service configuration: %w", err)
+		return spec, cli.WithExitCode(cli.ExitCodeValidation, fmt.Errorf("invalid service configuration: %w", err))
 	}
 
 	// Generate a service name if not specified to be able to include it in the progress title.
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -20,7 +19,6 @@ import (
 type scaleOptions struct {
 	service  string
 	replicas uint
-	yes      bool
 }
 
 func NewScaleCommand(groupID string) *cobra.Command {
@@ -31,8 +29,6 @@ func NewScaleCommand(groupID string) *cobra.Command {
 		Long:  "Scale a replicated service by changing the number of replicas.",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.BindEnvToFlag(cmd, "yes", "UNCLOUD_AUTO_CONFIRM")
-
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 
 			opts.service = args[0]
@@ -54,10 +50,6 @@ func NewScaleCommand(groupID string) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
-		"Auto-confirm scaling plan. Should be explicitly set when running non-interactively,\n"+
-			"e.g., in CI/CD pipelines. [$UNCLOUD_AUTO_CONFIRM]")
-
 	return cmd
 }
 
@@ -135,28 +127,21 @@ func scale(ctx context.Context, uncli *cli.CLI, opts scaleOptions) error {
 	fmt.Println()
 
 	// Ask for confirmation unless auto-confirmed with --yes.
-	if !opts.yes {
-		if !tui.IsStdinTerminal() {
-			return errors.New("cannot ask to confirm scaling plan in non-interactive mode, " +
-				"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
-		}
-
-		title := "Proceed with scaling?"
-		// Include the direct connection or context name in the confirmation prompt to avoid accidentally
-		// scaling on the wrong cluster.
-		if deployTarget != "" {
-			isDark := lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
-			confirmStyle := tui.ThemeConfirm().Theme(isDark).Focused.Title
-			title = "Proceed with scaling on " + tui.NameStyle.Render(deployTarget) + confirmStyle.Render("?")
-		}
+	confirmTitle := "Proceed with scaling?"
+	// Include the direct connection or context name in the confirmation prompt to avoid accidentally
+	// scaling on the wrong cluster.
+	if deployTarget != "" {
+		isDark := lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
+		confirmStyle := tui.ThemeConfirm().Theme(isDark).Focused.Title
+		confirmTitle = "Proceed with scaling on " + tui.NameStyle.Render(deployTarget) + confirmStyle.Render("?")
+	}
 
-		confirmed, err := tui.Confirm(title)
-		if err != nil {
-			return fmt.Errorf("confirm scaling: %w", err)
-		}
-		if !confirmed {
-			return cli.Cancelled("Scaling cancelled. No changes were made.")
-		}
+	confirmed, err := uncli.Confirm(confirmTitle)
+	if err != nil {
+		return fmt.Errorf("confirm scaling: %w", err)
+	}
+	if !confirmed {
+		return cli.Cancelled("Scaling cancelled. No changes were made.")
 	}
 
 	title := fmt.Sprintf("Scaling service %s (%d → %d replicas)",
@@ -14,8 +14,11 @@ import (
 
 type stopOptions struct {
 	services []string
+	force    bool
 	signal   string
-	timeout  int
+	// timeout is only used if timeoutSet is true, otherwise the service stop grace period is used.
+	timeout    int
+	timeoutSet bool
 }
 
 func NewStopCommand(groupID string) *cobra.Command {
@@ -31,6 +34,7 @@ Services can be specified by name or ID. Stopped services can be restarted with
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 			opts.services = args
+			opts.timeoutSet = cmd.Flags().Changed("timeout")
 			return stop(cmd.Context(), uncli, opts)
 		},
 		GroupID: groupID,
@@ -39,12 +43,15 @@ Services can be specified by name or ID. Stopped services can be restarted with
 			return completion.Services(cmd.Context(), uncli, args, toComplete)
 		},
 	}
+	cmd.Flags().BoolVar(&opts.force, "force", false,
+		"Stop the services even if they belong to a protected Compose project.")
 	cmd.Flags().StringVarP(&opts.signal, "signal", "s", "",
 		"Signal to send to each container's main process.\n"+
-			"Can be a signal name (SIGTERM, SIGINT, SIGHUP, etc.) or a number. (default SIGTERM)")
-	cmd.Flags().IntVarP(&opts.timeout, "timeout", "t", 10,
+			"Can be a signal name (SIGTERM, SIGINT, SIGHUP, etc.) or a number.\n"+
+			"(default is the service stop_signal or SIGTERM)")
+	cmd.Flags().IntVarP(&opts.timeout, "timeout", "t", 0,
 		"Seconds to wait for each container to stop gracefully before forcibly killing it with SIGKILL.\n"+
-			"Use -1 to wait indefinitely.")
+			"Use -1 to wait indefinitely. (default is the service stop_grace_period or 10)")
 	return cmd
 }
 
@@ -55,9 +62,14 @@ func stop(ctx context.Context, uncli *cli.CLI, opts stopOptions) error {
 	}
 	defer client.Close()
 
-	stopOpts := container.StopOptions{
-		Signal:  opts.signal,
-		Timeout: &opts.timeout,
+	if err = checkProtected(ctx, client, opts.services, "stop", opts.force); err != nil {
+		return err
+	}
+
+	// Docker uses the stop signal and timeout configured for each container from its service spec if not set.
+	stopOpts := container.StopOptions{Signal: opts.signal}
+	if opts.timeoutSet {
+		stopOpts.Timeout = &opts.timeout
 	}
 
 	for _, s := range opts.services {
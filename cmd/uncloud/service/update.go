@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+type updateOptions struct {
+	service string
+	image   string
+	env     []string
+	envRm   []string
+}
+
+func NewUpdateCommand(groupID string) *cobra.Command {
+	opts := updateOptions{}
+	cmd := &cobra.Command{
+		Use:   "update SERVICE",
+		Short: "Update the image or environment variables of a running service.",
+		Long: `Update the image or environment variables of a running service with a rolling update.
+
+The rest of the service configuration is taken from its running containers. This is handy for hotfix deploys
+when re-running the full 'uc deploy' is overkill. Note that the next 'uc deploy' of the Compose file
+replaces the changes made by this command unless you also update the Compose file.`,
+		Example: `  # Roll out a new image version.
+  uc service update web --image myapp:1.2.1
+
+  # Change an environment variable and remove another one.
+  uc service update web -e LOG_LEVEL=debug --env-rm FEATURE_X`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			opts.service = args[0]
+
+			if opts.image == "" && len(opts.env) == 0 && len(opts.envRm) == 0 {
+				return errors.New("nothing to update, specify at least one of --image, --env, or --env-rm")
+			}
+
+			return update(cmd.Context(), uncli, opts)
+		},
+		GroupID: groupID,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Services(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.image, "image", "i", "",
+		"New container image for the service.")
+	cmd.Flags().StringSliceVarP(&opts.env, "env", "e", nil,
+		"Set or change an environment variable for service containers. Can be specified multiple times.\n"+
+			"Format: VAR=value or just VAR to use the value from the local environment.")
+	cmd.Flags().StringSliceVar(&opts.envRm, "env-rm", nil,
+		"Remove an environment variable from service containers. Can be specified multiple times.")
+	return cmd
+}
+
+// updateSpec returns a copy of the service spec with the image and environment variables changed
+// according to the update options.
+func updateSpec(spec api.ServiceSpec, opts updateOptions) (api.ServiceSpec, error) {
+	spec = spec.Clone()
+
+	if opts.image != "" {
+		spec.Container.Image = opts.image
+	}
+
+	env, err := parseEnv(opts.env)
+	if err != nil {
+		return spec, err
+	}
+	if len(env) > 0 && spec.Container.Env == nil {
+		spec.Container.Env = make(api.EnvVars, len(env))
+	}
+	for k, v := range env {
+		spec.Container.Env[k] = v
+	}
+	for _, k := range opts.envRm {
+		if _, ok := spec.Container.Env[k]; !ok {
+			return spec, fmt.Errorf("environment variable '%s' is not set for the service", k)
+		}
+		delete(spec.Container.Env, k)
+	}
+
+	return spec, nil
+}
+
+func update(ctx context.Context, uncli *cli.CLI, opts updateOptions) error {
+	clusterClient, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer clusterClient.Close()
+
+	svc, err := clusterClient.InspectService(ctx, opts.service)
+	if err != nil {
+		return fmt.Errorf("inspect service '%s': %w", opts.service, err)
+	}
+	if len(svc.Containers) == 0 {
+		return fmt.Errorf("service '%s' has no containers to take the configuration from", svc.Name)
+	}
+
+	// TODO: Check if all containers have the same spec. If not, prompt user to choose which one to update.
+	//  This can happen if a service deployment failed midway and some containers were not updated.
+	spec, err := updateSpec(svc.Containers[0].Container.ServiceSpec, opts)
+	if err != nil {
+		return err
+	}
+
+	deployment := clusterClient.NewDeployment(spec, nil)
+	plan, err := deployment.Plan(ctx)
+	if err != nil {
+		return fmt.Errorf("plan deployment: %w", err)
+	}
+
+	if len(plan.Operations) == 0 {
+		fmt.Printf("Service %s is up to date. No changes required.\n", tui.NameStyle.Render(svc.Name))
+		return nil
+	}
+
+	fmt.Println(tui.Bold.Underline(true).Render("Update plan"))
+	fmt.Println()
+
+	directConn := uncli.DirectConnection()
+	contextName := uncli.ContextOverrideOrCurrent()
+	deployTarget := ""
+	if directConn != "" {
+		deployTarget = directConn
+		fmt.Println(tui.Faint.Render("connection: ") + tui.NameStyle.Render(directConn))
+		fmt.Println()
+	} else if contextName != "" && len(uncli.Config.Contexts) > 1 {
+		// Only show context if there's more than one to avoid unnecessary clutter.
+		deployTarget = contextName
+		fmt.Println(tui.Faint.Render("context: ") + tui.NameStyle.Render(contextName))
+		fmt.Println()
+	}
+
+	fmt.Println(plan.Format())
+
+	summary := plan.FormatSummary()
+	fmt.Println(tui.Faint.Render(strings.Repeat("─", lipgloss.Width(summary))))
+	fmt.Println(summary)
+	fmt.Println()
+
+	// Ask for confirmation unless auto-confirmed with --yes.
+	confirmTitle := "Proceed with update?"
+	// Include the direct connection or context name in the confirmation prompt to avoid accidentally
+	// updating a service on the wrong cluster.
+	if deployTarget != "" {
+		isDark := lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
+		confirmStyle := tui.ThemeConfirm().Theme(isDark).Focused.Title
+		confirmTitle = "Proceed with update on " + tui.NameStyle.Render(deployTarget) + confirmStyle.Render("?")
+	}
+
+	confirmed, err := uncli.Confirm(confirmTitle)
+	if err != nil {
+		return fmt.Errorf("confirm update: %w", err)
+	}
+	if !confirmed {
+		return cli.Cancelled("Update cancelled. No changes were made.")
+	}
+
+	title := fmt.Sprintf("Updating service %s", tui.NameStyle.Render(svc.Name))
+	return progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		if _, err = deployment.Run(ctx); err != nil {
+			return fmt.Errorf("deploy service: %w", err)
+		}
+		return nil
+	}, uncli.ProgressOut(), title)
+}
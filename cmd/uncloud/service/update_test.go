@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSpec(t *testing.T) {
+	spec := api.ServiceSpec{
+		Name: "web",
+		Container: api.ContainerSpec{
+			Image: "myapp:1.2.0",
+			Env:   api.EnvVars{"LOG_LEVEL": "info", "FEATURE_X": "true"},
+		},
+		Replicas: 3,
+	}
+
+	tests := []struct {
+		name    string
+		spec    api.ServiceSpec
+		opts    updateOptions
+		want    api.ServiceSpec
+		wantErr string
+	}{
+		{
+			name: "image",
+			spec: spec,
+			opts: updateOptions{image: "myapp:1.2.1"},
+			want: api.ServiceSpec{
+				Name: "web",
+				Container: api.ContainerSpec{
+					Image: "myapp:1.2.1",
+					Env:   api.EnvVars{"LOG_LEVEL": "info", "FEATURE_X": "true"},
+				},
+				Replicas: 3,
+			},
+		},
+		{
+			name: "set and remove env",
+			spec: spec,
+			opts: updateOptions{env: []string{"LOG_LEVEL=debug", "WORKERS=4"}, envRm: []string{"FEATURE_X"}},
+			want: api.ServiceSpec{
+				Name: "web",
+				Container: api.ContainerSpec{
+					Image: "myapp:1.2.0",
+					Env:   api.EnvVars{"LOG_LEVEL": "debug", "WORKERS": "4"},
+				},
+				Replicas: 3,
+			},
+		},
+		{
+			name: "set env without existing env",
+			spec: api.ServiceSpec{Name: "web", Container: api.ContainerSpec{Image: "myapp"}},
+			opts: updateOptions{env: []string{"LOG_LEVEL=debug"}},
+			want: api.ServiceSpec{
+				Name: "web",
+				Container: api.ContainerSpec{
+					Image: "myapp",
+					Env:   api.EnvVars{"LOG_LEVEL": "debug"},
+				},
+			},
+		},
+		{
+			name:    "remove unset env",
+			spec:    spec,
+			opts:    updateOptions{envRm: []string{"MISSING"}},
+			wantErr: "environment variable 'MISSING' is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := updateSpec(tt.spec, tt.opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Container.Image, got.Container.Image)
+			assert.Equal(t, tt.want.Container.Env, got.Container.Env)
+			assert.Equal(t, tt.want.Replicas, got.Replicas)
+		})
+	}
+
+	// The original spec must not be modified.
+	assert.Equal(t, "myapp:1.2.0", spec.Container.Image)
+	assert.Equal(t, api.EnvVars{"LOG_LEVEL": "info", "FEATURE_X": "true"}, spec.Container.Env)
+}
@@ -0,0 +1,334 @@
+package support
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/journal"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/version"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// logUnits are the systemd services whose logs are collected from each machine.
+var logUnits = []string{journal.UnitUncloud, journal.UnitDocker, journal.UnitCorrosion}
+
+type bundleOptions struct {
+	machines []string
+	output   string
+	since    string
+	tail     int
+}
+
+func NewSupportBundleCommand() *cobra.Command {
+	opts := bundleOptions{}
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect cluster diagnostics into an archive to attach to a bug report.",
+		Long: `Collect cluster diagnostics into an archive to attach to a bug report.
+
+The archive contains:
+  - logs of the uncloud, docker, and uncloud-corrosion systemd services on each machine,
+  - machine details such as the daemon version, store sync state, and round-trip times to other machines,
+  - the network configuration and the generated Caddyfile of each machine,
+  - the recent ingress configuration events of each machine,
+  - the list of machines and services in the cluster with their specs.
+
+Environment variable values and values that look like passwords, tokens, or keys are replaced with <redacted>.
+Review the archive before sharing it as the redaction can't detect every secret, for example, in application logs
+printed by the daemon. Daemon crashes are included as the stack traces are written to the daemon logs.
+
+A failure to collect some data doesn't stop the collection. The errors are listed in errors.txt in the archive.`,
+		Example: `  # Collect diagnostics from all machines.
+  uc support-bundle
+
+  # Collect diagnostics from specific machines with logs for the last 2 hours.
+  uc support-bundle -m machine-1,machine-2 --since 2h
+
+  # Write the archive to a specific file.
+  uc support-bundle -o /tmp/bundle.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			if opts.tail <= 0 {
+				return fmt.Errorf("--tail must be a positive number")
+			}
+			if opts.output == "" {
+				opts.output = fmt.Sprintf("uncloud-support-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+			}
+			return createBundle(cmd.Context(), uncli, opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Names or IDs of the machines to collect diagnostics from. Can be specified multiple times or as "+
+			"a comma-separated list. (default is all machines)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "",
+		"Path to the archive to create. (default uncloud-support-<timestamp>.tar.gz)")
+	cmd.Flags().StringVar(&opts.since, "since", "24h",
+		"Collect logs since a timestamp (e.g. 2025-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes).")
+	cmd.Flags().IntVarP(&opts.tail, "tail", "n", 2000,
+		"Maximum number of log lines to collect from each systemd service on each machine.")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+// bundle is a gzipped tar archive with diagnostics. Collection errors are recorded instead of failing.
+type bundle struct {
+	tw      *tar.Writer
+	created time.Time
+	errors  []string
+}
+
+func (b *bundle) add(name string, data []byte) error {
+	if err := b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: b.created,
+	}); err != nil {
+		return fmt.Errorf("write '%s' to archive: %w", name, err)
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		return fmt.Errorf("write '%s' to archive: %w", name, err)
+	}
+	return nil
+}
+
+// addJSON redacts the JSON-encoded value and adds it to the bundle.
+func (b *bundle) addJSON(name string, v any) error {
+	var data []byte
+	var err error
+	if m, ok := v.(proto.Message); ok {
+		data, err = protojson.Marshal(m)
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err == nil {
+		data, err = redactJSON(data)
+	}
+	if err != nil {
+		b.errorf("encode %s: %v", name, err)
+		return nil
+	}
+	return b.add(name, append(data, '\n'))
+}
+
+func (b *bundle) errorf(format string, args ...any) {
+	b.errors = append(b.errors, fmt.Sprintf(format, args...))
+}
+
+func createBundle(ctx context.Context, uncli *cli.CLI, opts bundleOptions) error {
+	c, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	machines, err := c.ListMachines(ctx, &api.MachineFilter{NamesOrIDs: cli.ExpandCommaSeparatedValues(opts.machines)})
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	if len(machines) == 0 {
+		return fmt.Errorf("no machines found")
+	}
+
+	f, err := os.OpenFile(opts.output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("create archive: %w", err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	b := &bundle{tw: tar.NewWriter(gz), created: time.Now().UTC()}
+
+	if err = collect(ctx, c, b, machines, opts); err != nil {
+		os.Remove(opts.output)
+		return err
+	}
+
+	summary := fmt.Sprintf("Created: %s\nCLI version: %s\nCluster context: %s\nMachines: %d\n",
+		b.created.Format(time.RFC3339), version.String(), uncli.ContextOverrideOrCurrent(), len(machines))
+	if err = b.add("summary.txt", []byte(summary)); err != nil {
+		return err
+	}
+	if len(b.errors) > 0 {
+		if err = b.add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+
+	if err = b.tw.Close(); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	if err = gz.Close(); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("write archive: %w", err)
+	}
+
+	fmt.Printf("Support bundle written to %s\n", opts.output)
+	if len(b.errors) > 0 {
+		tui.PrintWarning(fmt.Sprintf("failed to collect some diagnostics, see errors.txt in the archive (%d errors)",
+			len(b.errors)))
+	}
+	fmt.Println(tui.Faint.Render("Review the archive for sensitive data before sharing it."))
+	return nil
+}
+
+// collect adds the cluster and machine diagnostics to the bundle. It only returns an error if writing
+// the archive fails.
+func collect(
+	ctx context.Context, c *client.Client, b *bundle, machines api.MachineMembersList, opts bundleOptions,
+) error {
+	machineIDs := make([]string, len(machines))
+	members := make([]json.RawMessage, len(machines))
+	for i, m := range machines {
+		machineIDs[i] = m.Machine.Id
+		members[i], _ = protojson.Marshal(m)
+	}
+	if err := b.addJSON("cluster/machines.json", members); err != nil {
+		return err
+	}
+
+	services, err := c.ListServices(ctx)
+	if err != nil {
+		b.errorf("list services: %v", err)
+	} else if err = b.addJSON("cluster/services.json", services); err != nil {
+		return err
+	}
+
+	// Machine details and network configuration support broadcasting to all selected machines at once.
+	proxyCtx := c.ProxyMachinesContext(ctx, machineIDs)
+	if resp, err := c.MachineClient.InspectMachine(proxyCtx, &emptypb.Empty{}); err != nil {
+		b.errorf("inspect machines: %v", err)
+	} else {
+		for _, m := range resp.Machines {
+			if err = addMachineResponse(b, m.Metadata, m, "machine.json"); err != nil {
+				return err
+			}
+		}
+	}
+	if resp, err := c.MachineClient.InspectNetwork(proxyCtx, &emptypb.Empty{}); err != nil {
+		b.errorf("inspect network: %v", err)
+	} else {
+		for _, m := range resp.Machines {
+			if err = addMachineResponse(b, m.Metadata, m, "network.json"); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, m := range machines {
+		dir := machineDir(m.Machine.Name)
+
+		caddyCtx := c.ProxySingleMachineContext(ctx, m.Machine.Id)
+		if resp, err := c.Caddy.GetConfig(caddyCtx, &emptypb.Empty{}); err != nil {
+			b.errorf("get Caddy config on machine '%s': %v", m.Machine.Name, err)
+		} else if err = b.add(path.Join(dir, "Caddyfile"), []byte(redactText(resp.Caddyfile))); err != nil {
+			return err
+		}
+
+		if events, err := c.IngressEvents(ctx, m.Machine.Id); err != nil {
+			b.errorf("get ingress events of machine '%s': %v", m.Machine.Name, err)
+		} else if events != nil {
+			if err = b.addJSON(path.Join(dir, "ingress-events.json"), events); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, unit := range logUnits {
+		if err = collectLogs(ctx, c, b, machines, unit, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMachineResponse adds a response from a broadcast request to the directory of the machine that sent it.
+func addMachineResponse(b *bundle, md *pb.Metadata, m proto.Message, name string) error {
+	if md == nil {
+		return nil
+	}
+	if md.Error != "" {
+		b.errorf("%s on machine '%s': %s", strings.TrimSuffix(name, ".json"), md.MachineName, md.Error)
+		return nil
+	}
+	return b.addJSON(path.Join(machineDir(md.MachineName), name), m)
+}
+
+// collectLogs adds the recent logs of the systemd service from each machine to the bundle.
+func collectLogs(
+	ctx context.Context, c *client.Client, b *bundle, machines api.MachineMembersList, unit string,
+	opts bundleOptions,
+) error {
+	machineIDs := make([]string, len(machines))
+	for i, m := range machines {
+		machineIDs[i] = m.Machine.Id
+	}
+
+	stream, err := c.MachineLogs(ctx, unit, api.ServiceLogsOptions{
+		Tail:     opts.tail,
+		Since:    opts.since,
+		Machines: machineIDs,
+	})
+	if err != nil {
+		b.errorf("get logs of systemd service '%s': %v", unit, err)
+		return nil
+	}
+
+	logs := make(map[string]*bytes.Buffer)
+	for entry := range stream {
+		if entry.Err != nil {
+			b.errorf("get logs of systemd service '%s' on machine '%s': %v",
+				unit, entry.Metadata.MachineName, entry.Err)
+			continue
+		}
+		buf, ok := logs[entry.Metadata.MachineName]
+		if !ok {
+			buf = &bytes.Buffer{}
+			logs[entry.Metadata.MachineName] = buf
+		}
+		buf.WriteString(entry.Timestamp.UTC().Format(time.RFC3339Nano))
+		buf.WriteByte(' ')
+		buf.WriteString(redactText(string(entry.Message)))
+		if !bytes.HasSuffix(entry.Message, []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+	}
+
+	names := make([]string, 0, len(logs))
+	for name := range logs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if err = b.add(path.Join(machineDir(name), "logs", unit+".log"), logs[name].Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func machineDir(name string) string {
+	return path.Join("machines", strings.ReplaceAll(name, "/", "_"))
+}
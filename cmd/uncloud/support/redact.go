@@ -0,0 +1,82 @@
+package support
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const redacted = "<redacted>"
+
+// sensitiveKeyRegexp matches JSON object keys and config options whose values are likely secrets.
+var sensitiveKeyRegexp = regexp.MustCompile(
+	`(?i)(password|passwd|secret|token|private_?key|api_?key|credential|auth)`)
+
+// sensitiveTextRegexp matches 'key=value', 'key: value', and 'key value' pairs in text with a sensitive key.
+// The value is the third group.
+var sensitiveTextRegexp = regexp.MustCompile(
+	`(?i)(\b[\w.-]*(?:password|passwd|secret|token|private_?key|api_?key|credential)[\w.-]*)(\s*[=:]\s*|\s+)("[^"]*"|\S+)`)
+
+// redactText replaces the values of sensitive key-value pairs in text such as logs or a Caddyfile.
+func redactText(text string) string {
+	return sensitiveTextRegexp.ReplaceAllString(text, "${1}${2}"+redacted)
+}
+
+// redactJSON replaces the values of sensitive keys and all environment variable values in a JSON document and
+// returns it indented.
+func redactJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(redactValue(v), "", "  ")
+}
+
+func redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			switch {
+			case strings.EqualFold(k, "env"):
+				val[k] = redactEnv(item)
+			case sensitiveKeyRegexp.MatchString(k):
+				// Keep empty values and structure markers to show that the setting is unset.
+				if item != nil && item != "" {
+					val[k] = redacted
+				}
+			default:
+				val[k] = redactValue(item)
+			}
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// redactEnv replaces the values of environment variables in the KEY=VALUE list or KEY: VALUE map form.
+func redactEnv(v any) any {
+	switch env := v.(type) {
+	case []any:
+		for i, item := range env {
+			if s, ok := item.(string); ok {
+				if name, _, found := strings.Cut(s, "="); found {
+					env[i] = name + "=" + redacted
+				}
+			}
+		}
+		return env
+	case map[string]any:
+		for k := range env {
+			env[k] = redacted
+		}
+		return env
+	default:
+		return v
+	}
+}
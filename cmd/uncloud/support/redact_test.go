@@ -0,0 +1,94 @@
+package support
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "no secrets",
+			text: "level=INFO msg=\"Machine started.\" machine=machine-1",
+			want: "level=INFO msg=\"Machine started.\" machine=machine-1",
+		},
+		{
+			name: "key=value",
+			text: "connecting with password=hunter2 to db",
+			want: "connecting with password=<redacted> to db",
+		},
+		{
+			name: "quoted value",
+			text: `api_key="abc def" host=example.com`,
+			want: "api_key=<redacted> host=example.com",
+		},
+		{
+			name: "key: value",
+			text: "CF_API_TOKEN: 0123456789",
+			want: "CF_API_TOKEN: <redacted>",
+		},
+		{
+			name: "Caddyfile option",
+			text: "\tdns cloudflare {\n\t\tapi_token abcdef\n\t}",
+			want: "\tdns cloudflare {\n\t\tapi_token <redacted>\n\t}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, redactText(tt.text))
+		})
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "env list",
+			data: `{"env":["FOO=bar","EMPTY"]}`,
+			want: `{"env":["FOO=<redacted>","EMPTY"]}`,
+		},
+		{
+			name: "env map",
+			data: `{"Env":{"FOO":"bar"}}`,
+			want: `{"Env":{"FOO":"<redacted>"}}`,
+		},
+		{
+			name: "nested sensitive keys",
+			data: `{"spec":{"name":"web","auth":{"user":"admin"},"password":"","db_password":"x"}}`,
+			want: `{"spec":{"name":"web","auth":"<redacted>","password":"","db_password":"<redacted>"}}`,
+		},
+		{
+			name: "sensitive keys in list",
+			data: `[{"token":"t"},{"name":"n"}]`,
+			want: `[{"token":"<redacted>"},{"name":"n"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := redactJSON([]byte(tt.data))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+
+	_, err := redactJSON([]byte("not json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/internal/grpcversion"
+	"github.com/psviderski/uncloud/internal/version"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type versionOptions struct {
+	cluster bool
+}
+
+func NewVersionCommand() *cobra.Command {
+	opts := versionOptions{}
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show the CLI version and optionally the daemon versions of all machines.",
+		Long: `Show the CLI version and optionally the daemon versions of all machines.
+
+The CLI and machine daemons support a version skew of one minor version, for example,
+CLI 0.21.x works with daemons 0.20.x and 0.22.x. The CLI warns when it talks to a daemon
+of a different version. Daemons reject requests from a CLI that is more than one minor
+version apart unless the global --force-version-skew flag is set.`,
+		Example: `  # Show the CLI version.
+  uc version
+
+  # Show the daemon version of every machine in the cluster.
+  uc version --cluster`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.cluster {
+				fmt.Printf("Client: %s\n", version.String())
+				return nil
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return clusterVersions(cmd.Context(), uncli)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.cluster, "cluster", false,
+		"Show the daemon version of every machine in the cluster.")
+
+	return cmd
+}
+
+func clusterVersions(ctx context.Context, uncli *cli.CLI) error {
+	// Inspecting versions must work even if they are too far apart to troubleshoot the skew.
+	grpcversion.AllowVersionSkew.Store(true)
+
+	c, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer c.Close()
+
+	resp, err := c.MachineClient.InspectMachine(c.ProxyMachinesContext(ctx, nil), &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("inspect machines: %w", err)
+	}
+
+	fmt.Printf("Client: %s\n\n", version.String())
+
+	t := tui.NewTable()
+	t.Headers("MACHINE", "DAEMON VERSION", "SKEW")
+	unsupported := false
+	for _, m := range resp.Machines {
+		if m.Metadata != nil && m.Metadata.Error != "" {
+			t.Row(m.Metadata.MachineName, tui.Faint.Render("unreachable"), "")
+			continue
+		}
+		if m.Machine == nil {
+			continue
+		}
+
+		daemonVersion := m.DaemonVersion
+		if daemonVersion == "" {
+			daemonVersion = "unknown"
+		}
+		skew := ""
+		switch grpcversion.CheckSkewString(version.String(), m.DaemonVersion) {
+		case grpcversion.SkewSupported:
+			skew = tui.Yellow.Render("supported")
+		case grpcversion.SkewUnsupported:
+			skew = tui.Red.Render("unsupported")
+			unsupported = true
+		}
+		t.Row(m.Machine.Name, daemonVersion, skew)
+	}
+	fmt.Println(t)
+
+	if unsupported {
+		fmt.Println()
+		tui.PrintWarning(fmt.Sprintf("some machines are more than %d minor version apart from the CLI. "+
+			"Upgrade the CLI or machines so their versions match.", grpcversion.MaxMinorVersionSkew))
+	}
+	return nil
+}
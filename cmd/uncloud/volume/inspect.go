@@ -3,12 +3,10 @@ package volume
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/completion"
-	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
 
@@ -52,33 +50,12 @@ func inspect(ctx context.Context, uncli *cli.CLI, name string, opts inspectOptio
 	}
 	defer client.Close()
 
-	filter := &api.VolumeFilter{
-		Names: []string{name},
-	}
-	if opts.machine != "" {
-		filter.Machines = []string{opts.machine}
-	}
-
-	volumes, err := client.ListVolumes(ctx, filter)
+	vol, err := findVolume(ctx, client, name, opts.machine)
 	if err != nil {
-		return fmt.Errorf("list volumes: %w", err)
-	}
-
-	if len(volumes) == 0 {
-		if opts.machine != "" {
-			return fmt.Errorf("volume '%s' not found on machine '%s'", name, opts.machine)
-		}
-		return fmt.Errorf("volume '%s' not found on any machine", name)
-	}
-	if len(volumes) > 1 {
-		fmt.Printf("Volume '%s' found on multiple machines:\n", name)
-		for _, v := range volumes {
-			fmt.Printf(" • %s\n", v.MachineName)
-		}
-		return errors.New("specify --machine flag to choose which machine to use")
+		return err
 	}
 
-	data, err := json.MarshalIndent(volumes[0], "", "  ")
+	data, err := json.MarshalIndent(vol, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal volume: %w", err)
 	}
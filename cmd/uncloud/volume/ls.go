@@ -15,6 +15,7 @@ import (
 
 type listOptions struct {
 	machines []string
+	orphaned bool
 	quiet    bool
 }
 
@@ -25,6 +26,10 @@ func NewListCommand() *cobra.Command {
 		Use:     "ls",
 		Aliases: []string{"list"},
 		Short:   "List volumes across all machines in the cluster.",
+		Long: `List volumes across all machines in the cluster.
+
+Use --orphaned to report the volumes that aren't used by any container, for example, the volumes left behind
+by removed services. They keep consuming disk space until removed with 'uc volume rm'.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uncli := cmd.Context().Value("cli").(*cli.CLI)
 			return list(cmd.Context(), uncli, opts)
@@ -34,6 +39,8 @@ func NewListCommand() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
 		"Filter volumes by machine name or ID. Can be specified multiple times or as a comma-separated list. "+
 			"(default is include all machines)")
+	cmd.Flags().BoolVar(&opts.orphaned, "orphaned", false,
+		"Only display volumes not used by any container.")
 	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false,
 		"Only display volume names.")
 
@@ -49,13 +56,10 @@ func list(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
 	}
 	defer client.Close()
 
+	filter := &api.VolumeFilter{Dangling: opts.orphaned}
 	// Apply machine filter if specified.
-	var filter *api.VolumeFilter
 	if len(opts.machines) > 0 {
-		machines := cli.ExpandCommaSeparatedValues(opts.machines)
-		filter = &api.VolumeFilter{
-			Machines: machines,
-		}
+		filter.Machines = cli.ExpandCommaSeparatedValues(opts.machines)
 	}
 
 	volumes, err := client.ListVolumes(ctx, filter)
@@ -65,7 +69,11 @@ func list(ctx context.Context, uncli *cli.CLI, opts listOptions) error {
 
 	if len(volumes) == 0 {
 		if !opts.quiet {
-			fmt.Println("No volumes found.")
+			if opts.orphaned {
+				fmt.Println("No orphaned volumes found.")
+			} else {
+				fmt.Println("No volumes found.")
+			}
 		}
 		return nil
 	}
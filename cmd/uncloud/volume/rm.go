@@ -7,15 +7,14 @@ import (
 
 	"github.com/psviderski/uncloud/internal/cli"
 	"github.com/psviderski/uncloud/internal/cli/completion"
-	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/spf13/cobra"
 )
 
 type removeOptions struct {
+	failFast bool
 	force    bool
 	machines []string
-	yes      bool
 }
 
 func NewRemoveCommand() *cobra.Command {
@@ -37,15 +36,13 @@ func NewRemoveCommand() *cobra.Command {
 		},
 	}
 
+	cli.AddFailFastFlags(cmd, &opts.failFast)
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false,
 		"Force the removal of one or more volumes.")
 	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
 		"Name or ID of the machine to remove one or more volumes from. "+
 			"Can be specified multiple times or as a comma-separated list.\n"+
 			"If not specified, the found volume(s) will be removed from all machines.")
-	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false,
-		"Do not prompt for confirmation before removing the volume(s).")
-
 	completion.MachinesFlag(cmd)
 
 	return cmd
@@ -79,15 +76,15 @@ func remove(ctx context.Context, uncli *cli.CLI, names []string, opts removeOpti
 		return fmt.Errorf("no volumes found matching the specified names")
 	}
 
-	// Confirm removal if not using --yes flag.
-	if !opts.yes {
+	// Confirm removal unless auto-confirmed with the --yes flag or UNCLOUD_ASSUME_YES.
+	if !uncli.AssumeYes {
 		fmt.Println("The following volumes will be removed:")
 		for _, v := range volumes {
 			fmt.Printf(" • '%s' on machine '%s'\n", v.Volume.Name, v.MachineName)
 		}
 
 		fmt.Println()
-		confirmed, err := tui.Confirm("")
+		confirmed, err := uncli.Confirm("")
 		if err != nil {
 			return fmt.Errorf("confirm removal: %w", err)
 		}
@@ -98,12 +95,17 @@ func remove(ctx context.Context, uncli *cli.CLI, names []string, opts removeOpti
 	}
 
 	// Remove the volumes one by one collecting errors.
-	var removeErr error
+	var errs []error
+	attempted := 0
 	for _, v := range volumes {
+		attempted++
 		if err = client.RemoveVolume(ctx, v.MachineID, v.Volume.Name, opts.force); err != nil {
 			if !errors.Is(err, api.ErrNotFound) {
-				removeErr = errors.Join(removeErr, fmt.Errorf("failed to remove volume '%s' on machine '%s': %w",
+				errs = append(errs, fmt.Errorf("failed to remove volume '%s' on machine '%s': %w",
 					v.Volume.Name, v.MachineName, err))
+				if opts.failFast {
+					break
+				}
 			}
 			continue
 		}
@@ -111,5 +113,6 @@ func remove(ctx context.Context, uncli *cli.CLI, names []string, opts removeOpti
 		fmt.Printf("Volume '%s' removed from machine '%s'.\n", v.Volume.Name, v.MachineName)
 	}
 
-	return removeErr
+	// Volumes skipped after a failure with --fail-fast are not counted as removed.
+	return cli.JoinTargetErrors(attempted, errs)
 }
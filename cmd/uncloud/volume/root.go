@@ -14,6 +14,7 @@ func NewRootCommand() *cobra.Command {
 		NewInspectCommand(),
 		NewListCommand(),
 		NewRemoveCommand(),
+		NewSnapshotCommand(),
 	)
 	return cmd
 }
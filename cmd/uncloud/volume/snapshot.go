@@ -0,0 +1,328 @@
+package volume
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/cli"
+	"github.com/psviderski/uncloud/internal/cli/completion"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewSnapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage instant snapshots of volumes stored on btrfs or ZFS filesystems.",
+		Long: `Manage instant snapshots of volumes stored on btrfs or ZFS filesystems.
+
+Snapshots use the copy-on-write capabilities of the filesystem the Docker volumes are stored on so they are created
+instantly and only consume space for the data changed after the snapshot. Only volumes with the 'local' driver
+are supported.
+
+On btrfs, the snapshots are stored next to the volume data and are removed together with the volume.
+On ZFS, a snapshot of the dataset the volume is stored on is created but only the volume data is restored from it.
+The ZFS snapshots aren't removed together with the volume and keep the data of all volumes on the dataset.
+
+Use 'uc deploy --snapshot-volumes' to snapshot the volumes used by services before deploying them.`,
+	}
+	cmd.AddCommand(
+		newSnapshotCreateCommand(),
+		newSnapshotListCommand(),
+		newSnapshotRestoreCommand(),
+		newSnapshotRemoveCommand(),
+	)
+	return cmd
+}
+
+type snapshotCreateOptions struct {
+	machines []string
+}
+
+func newSnapshotCreateCommand() *cobra.Command {
+	opts := snapshotCreateOptions{}
+	cmd := &cobra.Command{
+		Use:   "create VOLUME_NAME",
+		Short: "Create a snapshot of a volume.",
+		Long:  "Create a snapshot of a volume on every machine it exists on unless machines are specified.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return createSnapshot(cmd.Context(), uncli, args[0], opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Volumes(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Name or ID of the machine to snapshot the volume on. "+
+			"Can be specified multiple times or as a comma-separated list. (default is all machines with the volume)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func createSnapshot(ctx context.Context, uncli *cli.CLI, name string, opts snapshotCreateOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	filter := &api.VolumeFilter{
+		Names:    []string{name},
+		Machines: cli.ExpandCommaSeparatedValues(opts.machines),
+	}
+	volumes, err := client.ListVolumes(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", err)
+	}
+	if len(volumes) == 0 {
+		return fmt.Errorf("volume '%s' not found", name)
+	}
+
+	return progress.RunWithTitle(ctx, func(ctx context.Context) error {
+		var snapshotErr error
+		for _, v := range volumes {
+			if _, err := client.CreateVolumeSnapshot(ctx, v.MachineID, v.Volume.Name); err != nil {
+				snapshotErr = errors.Join(snapshotErr, fmt.Errorf("snapshot volume '%s' on machine '%s': %w",
+					v.Volume.Name, v.MachineName, err))
+			}
+		}
+		return snapshotErr
+	}, uncli.ProgressOut(), "Creating volume snapshots")
+}
+
+type snapshotListOptions struct {
+	machines []string
+}
+
+func newSnapshotListCommand() *cobra.Command {
+	opts := snapshotListOptions{}
+	cmd := &cobra.Command{
+		Use:     "ls [VOLUME_NAME]",
+		Aliases: []string{"list"},
+		Short:   "List snapshots of a volume or all volumes.",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return listSnapshots(cmd.Context(), uncli, name, opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return completion.Volumes(cmd.Context(), uncli, args, toComplete)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.machines, "machine", "m", nil,
+		"Filter snapshots by machine name or ID. Can be specified multiple times or as a comma-separated list. "+
+			"(default is include all machines)")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func listSnapshots(ctx context.Context, uncli *cli.CLI, name string, opts snapshotListOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	snapshots, err := client.ListVolumeSnapshots(ctx, name, cli.ExpandCommaSeparatedValues(opts.machines))
+	if err != nil {
+		return fmt.Errorf("list volume snapshots: %w", err)
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("No volume snapshots found.")
+		return nil
+	}
+
+	slices.SortFunc(snapshots, func(a, b api.MachineVolumeSnapshot) int {
+		return cmp.Or(
+			cmp.Compare(a.Snapshot.Volume, b.Snapshot.Volume),
+			cmp.Compare(a.MachineName, b.MachineName),
+			cmp.Compare(a.Snapshot.ID, b.Snapshot.ID),
+		)
+	})
+
+	t := tui.NewTable()
+	t.Headers("VOLUME", "SNAPSHOT", "CREATED", "BACKEND", "MACHINE")
+	now := time.Now().UTC()
+	for _, s := range snapshots {
+		created := units.HumanDuration(now.Sub(s.Snapshot.Created)) + " ago"
+		t.Row(s.Snapshot.Volume, s.Snapshot.ID, created, s.Snapshot.Backend, s.MachineName)
+	}
+	fmt.Println(t)
+
+	return nil
+}
+
+type snapshotRestoreOptions struct {
+	machine string
+}
+
+func newSnapshotRestoreCommand() *cobra.Command {
+	opts := snapshotRestoreOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore VOLUME_NAME SNAPSHOT",
+		Short: "Restore the data of a volume from its snapshot.",
+		Long: `Restore the data of a volume from its snapshot. All the current data in the volume is replaced with
+the data from the snapshot.
+
+The volume must not be used by running containers. Stop the services using the volume with 'uc stop'
+before restoring it and start them again with 'uc start' after.`,
+		Example: `  # Restore the snapshot of the db-data volume created before the last deploy.
+  uc stop db
+  uc volume snapshot restore db-data 20261015T123045.123Z
+  uc start db`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return restoreSnapshot(cmd.Context(), uncli, args[0], args[1], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
+		"Name or ID of the machine where the volume is located. "+
+			"Required if the volume exists on multiple machines.")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func restoreSnapshot(ctx context.Context, uncli *cli.CLI, name, id string, opts snapshotRestoreOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	vol, err := findVolume(ctx, client, name, opts.machine)
+	if err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("Replace all data in volume '%s' on machine '%s' with snapshot '%s'?",
+		vol.Volume.Name, vol.MachineName, id)
+	confirmed, err := uncli.Confirm(title)
+	if err != nil {
+		return fmt.Errorf("confirm restore: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Cancelled. The volume was not restored.")
+		return nil
+	}
+
+	if err = client.RestoreVolumeSnapshot(ctx, vol.MachineID, vol.Volume.Name, id); err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("snapshot '%s' of volume '%s' not found on machine '%s'",
+				id, vol.Volume.Name, vol.MachineName)
+		}
+		return fmt.Errorf("restore volume snapshot: %w", err)
+	}
+	fmt.Printf("Volume '%s' on machine '%s' restored from snapshot '%s'.\n", vol.Volume.Name, vol.MachineName, id)
+
+	return nil
+}
+
+type snapshotRemoveOptions struct {
+	machine string
+}
+
+func newSnapshotRemoveCommand() *cobra.Command {
+	opts := snapshotRemoveOptions{}
+	cmd := &cobra.Command{
+		Use:     "rm VOLUME_NAME SNAPSHOT [SNAPSHOT...]",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove one or more snapshots of a volume.",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return removeSnapshots(cmd.Context(), uncli, args[0], args[1:], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.machine, "machine", "m", "",
+		"Name or ID of the machine where the volume is located. "+
+			"Required if the volume exists on multiple machines.")
+	completion.MachinesFlag(cmd)
+
+	return cmd
+}
+
+func removeSnapshots(ctx context.Context, uncli *cli.CLI, name string, ids []string, opts snapshotRemoveOptions) error {
+	client, err := uncli.ConnectCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to cluster: %w", err)
+	}
+	defer client.Close()
+
+	vol, err := findVolume(ctx, client, name, opts.machine)
+	if err != nil {
+		return err
+	}
+
+	var removeErr error
+	for _, id := range ids {
+		if err = client.RemoveVolumeSnapshot(ctx, vol.MachineID, vol.Volume.Name, id); err != nil {
+			removeErr = errors.Join(removeErr, fmt.Errorf("failed to remove snapshot '%s': %w", id, err))
+			continue
+		}
+		fmt.Printf("Snapshot '%s' of volume '%s' removed from machine '%s'.\n", id, vol.Volume.Name, vol.MachineName)
+	}
+
+	return removeErr
+}
+
+// findVolume returns the volume with the given name on the machine or the only machine the volume exists on
+// if the machine is empty.
+func findVolume(ctx context.Context, client *client.Client, name, machine string) (api.MachineVolume, error) {
+	filter := &api.VolumeFilter{
+		Names: []string{name},
+	}
+	if machine != "" {
+		filter.Machines = []string{machine}
+	}
+
+	volumes, err := client.ListVolumes(ctx, filter)
+	if err != nil {
+		return api.MachineVolume{}, fmt.Errorf("list volumes: %w", err)
+	}
+
+	if len(volumes) == 0 {
+		if machine != "" {
+			return api.MachineVolume{}, fmt.Errorf("volume '%s' not found on machine '%s'", name, machine)
+		}
+		return api.MachineVolume{}, fmt.Errorf("volume '%s' not found on any machine", name)
+	}
+	if len(volumes) > 1 {
+		fmt.Printf("Volume '%s' found on multiple machines:\n", name)
+		for _, v := range volumes {
+			fmt.Printf(" • %s\n", v.MachineName)
+		}
+		return api.MachineVolume{}, errors.New("specify --machine flag to choose which machine to use")
+	}
+
+	return volumes[0], nil
+}
@@ -2,33 +2,49 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/psviderski/uncloud/internal/daemon"
+	"github.com/psviderski/uncloud/internal/grpccompress"
 	"github.com/psviderski/uncloud/internal/log"
 	"github.com/psviderski/uncloud/internal/machine"
+	"github.com/psviderski/uncloud/internal/machine/throttle"
 	"github.com/psviderski/uncloud/internal/version"
 	"github.com/spf13/cobra"
 )
 
 func main() {
+	log.DaemonLevel.Set(slog.LevelDebug)
 	logger := slog.New(log.NewSlogTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
+		Level: log.DaemonLevel,
 	}))
 	slog.SetDefault(logger)
 
-	var dataDir string
+	var dataDir, logFormat string
+	var opts daemon.Options
 	cmd := &cobra.Command{
 		Use:           "uncloudd",
 		Short:         "Uncloud machine daemon.",
 		Version:       version.String(),
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			handler, err := log.NewHandler(os.Stderr, logFormat, &slog.HandlerOptions{Level: log.DaemonLevel})
+			if err != nil {
+				return err
+			}
+			slog.SetDefault(slog.New(handler))
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			d, err := daemon.New(dataDir)
+			if opts.UnsafeFaultInjection {
+				slog.Warn("Fault injection is enabled. Faults can be injected through the machine API.")
+			}
+			d, err := daemon.New(dataDir, opts)
 			if err != nil {
 				return err
 			}
@@ -41,6 +57,30 @@ func main() {
 	cmd.PersistentFlags().StringVarP(&dataDir, "data-dir", "d", machine.DefaultDataDir,
 		"Directory for storing persistent machine state")
 	_ = cmd.MarkFlagDirname("data-dir")
+	cmd.PersistentFlags().StringVar(&logFormat, "log-format", log.FormatText,
+		fmt.Sprintf("Log output format: '%s' or '%s'", log.FormatText, log.FormatJSON))
+	cmd.Flags().StringVar(&opts.GRPCCompression, "grpc-compression", grpccompress.None,
+		fmt.Sprintf("Compression for API requests proxied to other machines over the mesh %v. "+
+			"All machines must run a version that supports it.", grpccompress.Names))
+	cmd.Flags().BoolVar(&opts.UnsafeFaultInjection, "unsafe-fault-injection", false,
+		"Allow injecting faults such as dropped heartbeats, delayed API requests, or failed image pulls through "+
+			"the machine API for failure testing. Never enable it on production machines.")
+	cmd.Flags().StringVar(&opts.PolicyFile, "policy-file", "",
+		"Admission policy file with the rules that service specs must pass before their containers are created "+
+			"on this machine. Test it with 'uc policy test'. (default <data-dir>/policy.yaml)")
+	cmd.Flags().StringVar(&opts.MetricsAddr, "metrics-addr", "",
+		"Address to serve Prometheus metrics on at /metrics, e.g. 127.0.0.1:9100. Metrics include the counts and "+
+			"latencies of queries to the embedded DNS server. Disabled if empty.")
+	cmd.Flags().Float64Var(&opts.Throttle.RequestRate, "api-rate-limit", throttle.DefaultConfig.RequestRate,
+		"Sustained number of API requests per second allowed from each client. Requests over the limit are "+
+			"rejected with a 'server busy' error. 0 disables the limit.")
+	cmd.Flags().IntVar(&opts.Throttle.RequestBurst, "api-rate-burst", throttle.DefaultConfig.RequestBurst,
+		"Number of API requests a client can send at once above the sustained rate.")
+	cmd.Flags().IntVar(&opts.Throttle.MaxHeavyOps, "max-heavy-ops", throttle.DefaultConfig.MaxHeavyOps,
+		"Maximum number of heavy operations such as image pulls, service container creations, and volume "+
+			"snapshots running concurrently on the machine. Operations over the limit are queued. 0 disables the limit.")
+	cmd.Flags().DurationVar(&opts.Throttle.QueueTimeout, "heavy-ops-queue-timeout", throttle.DefaultConfig.QueueTimeout,
+		"Maximum time a heavy operation waits in the queue before it's rejected with a 'server busy' error.")
 
 	// Add dial-stdio subcommand.
 	cmd.AddCommand(newDialStdioCommand())
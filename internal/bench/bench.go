@@ -0,0 +1,333 @@
+// Package bench simulates a cluster of virtual machines running many services to profile scheduling, cluster state
+// propagation, and DNS performance at a scale that is hard to reproduce with real machines. The virtual machines
+// run the same scheduler, store, and DNS resolver code as the machine daemon in-process, so regressions in these
+// components show up before they affect large clusters.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/dns"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+)
+
+const (
+	// DefaultDNSQueries is the default number of DNS lookups in the DNS benchmark.
+	DefaultDNSQueries = 100_000
+	// propagationTimeout is the maximum time to wait for a change to propagate to the reconcilers.
+	propagationTimeout = time.Minute
+)
+
+// Options configures the size of the simulated cluster.
+type Options struct {
+	// Machines is the number of virtual machines in the cluster.
+	Machines int
+	// Services is the number of services deployed to the cluster.
+	Services int
+	// Replicas is the number of containers of each service.
+	Replicas int
+	// DNSQueries is the number of DNS lookups in the DNS benchmark. DefaultDNSQueries is used if 0.
+	DNSQueries int
+}
+
+func (o Options) Validate() error {
+	if o.Machines < 1 {
+		return errors.New("number of machines must be at least 1")
+	}
+	if o.Services < 1 {
+		return errors.New("number of services must be at least 1")
+	}
+	if o.Replicas < 1 {
+		return errors.New("number of replicas must be at least 1")
+	}
+	if o.DNSQueries < 0 {
+		return errors.New("number of DNS queries must not be negative")
+	}
+	return nil
+}
+
+// Result is the measured durations of repeated operations of a benchmark.
+type Result struct {
+	Name    string
+	Samples []time.Duration
+	// Total is the wall time of the benchmark.
+	Total time.Duration
+}
+
+// Percentile returns the p-th percentile (0-100) of the samples using the nearest-rank method.
+func (r Result) Percentile(p float64) time.Duration {
+	if len(r.Samples) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(r.Samples)
+	slices.Sort(sorted)
+
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+// Max returns the longest sample.
+func (r Result) Max() time.Duration {
+	if len(r.Samples) == 0 {
+		return 0
+	}
+	return slices.Max(r.Samples)
+}
+
+// Run runs all benchmarks against a simulated cluster with the given options.
+func Run(ctx context.Context, opts Options) ([]Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.DNSQueries == 0 {
+		opts.DNSQueries = DefaultDNSQueries
+	}
+
+	results := []Result{Scheduling(opts)}
+
+	dir, err := os.MkdirTemp("", "uncloud-bench-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	backend, err := store.NewSQLiteBackend(filepath.Join(dir, store.SQLiteFileName))
+	if err != nil {
+		return nil, fmt.Errorf("create store: %w", err)
+	}
+	defer backend.Close()
+	s := store.New(backend)
+
+	propagation, err := StatePropagation(ctx, s, opts)
+	if err != nil {
+		return nil, fmt.Errorf("state propagation benchmark: %w", err)
+	}
+	results = append(results, propagation)
+
+	dnsResults, err := DNS(ctx, s, opts)
+	if err != nil {
+		return nil, fmt.Errorf("DNS benchmark: %w", err)
+	}
+	return append(results, dnsResults...), nil
+}
+
+// Scheduling measures the time to plan the deployment of each service to the virtual machines.
+func Scheduling(opts Options) Result {
+	state := VirtualClusterState(opts.Machines)
+	res := Result{Name: "schedule service"}
+
+	start := time.Now()
+	for i := range opts.Services {
+		spec := api.ServiceSpec{
+			Name:      serviceName(i),
+			Mode:      api.ServiceModeReplicated,
+			Replicas:  uint(opts.Replicas),
+			Container: api.ContainerSpec{Image: "nginx"},
+		}
+		strategy := &deploy.RollingStrategy{}
+
+		t := time.Now()
+		// Errors can't happen for the valid specs of the virtual services.
+		_, _ = strategy.Plan(state, nil, spec)
+		res.Samples = append(res.Samples, time.Since(t))
+	}
+	res.Total = time.Since(start)
+
+	return res
+}
+
+// StatePropagation measures the time from a virtual machine writing its containers to the store to the reconcilers
+// watching the store receiving the updated list of containers. The virtual machines write their containers one
+// after another, each in a single batch as the machine daemon does after a container change.
+func StatePropagation(ctx context.Context, s *store.Store, opts Options) (Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates, err := s.WatchContainers(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("watch containers: %w", err)
+	}
+	// Skip the initial list.
+	if _, err = receive(ctx, updates); err != nil {
+		return Result{}, err
+	}
+
+	perMachine := VirtualContainers(opts)
+	res := Result{Name: "propagate machine state"}
+	want := 0
+
+	start := time.Now()
+	for i := range opts.Machines {
+		machineID := machineID(i)
+		want += len(perMachine[machineID])
+
+		t := time.Now()
+		if err = s.SyncContainers(ctx, machineID, perMachine[machineID], nil); err != nil {
+			return Result{}, fmt.Errorf("sync containers of machine '%s': %w", machineID, err)
+		}
+		if len(perMachine[machineID]) == 0 {
+			continue
+		}
+		// Wait for the update that includes all containers written so far.
+		for {
+			records, err := receive(ctx, updates)
+			if err != nil {
+				return Result{}, err
+			}
+			if len(records) >= want {
+				break
+			}
+		}
+		res.Samples = append(res.Samples, time.Since(t))
+	}
+	res.Total = time.Since(start)
+
+	return res, nil
+}
+
+// DNS measures the time for the DNS resolver to pick up all containers from the store and the time to resolve
+// random service names.
+func DNS(ctx context.Context, s *store.Store, opts Options) ([]Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resolver := dns.NewClusterResolver(s)
+	load := Result{Name: "load DNS records"}
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- resolver.Run(ctx)
+	}()
+
+	// The containers have been written to the store by the state propagation benchmark.
+	last := serviceName(opts.Services - 1)
+	deadline := time.Now().Add(propagationTimeout)
+	for len(resolver.Resolve(last)) < opts.Replicas {
+		select {
+		case err := <-errCh:
+			return nil, fmt.Errorf("run DNS resolver: %w", err)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.New("timed out waiting for the DNS resolver to load records")
+		}
+	}
+	load.Total = time.Since(start)
+	load.Samples = []time.Duration{load.Total}
+
+	resolve := Result{Name: "resolve service"}
+	start = time.Now()
+	for range opts.DNSQueries {
+		name := serviceName(rand.IntN(opts.Services))
+		t := time.Now()
+		resolver.Resolve(name)
+		resolve.Samples = append(resolve.Samples, time.Since(t))
+	}
+	resolve.Total = time.Since(start)
+
+	return []Result{load, resolve}, nil
+}
+
+func receive(ctx context.Context, updates <-chan []store.ContainerRecord) ([]store.ContainerRecord, error) {
+	select {
+	case records, ok := <-updates:
+		if !ok {
+			return nil, errors.New("containers watch closed")
+		}
+		return records, nil
+	case <-time.After(propagationTimeout):
+		return nil, errors.New("timed out waiting for containers update")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// VirtualClusterState returns the cluster state with the given number of virtual machines for scheduling.
+func VirtualClusterState(machines int) *scheduler.ClusterState {
+	state := &scheduler.ClusterState{}
+	for i := range machines {
+		state.Machines = append(state.Machines, &scheduler.Machine{Info: &pb.MachineInfo{
+			Id:   machineID(i),
+			Name: fmt.Sprintf("machine-%d", i),
+			Network: &pb.NetworkConfig{
+				Subnet: pb.NewIPPrefix(machineSubnet(i)),
+			},
+		}})
+	}
+	return state
+}
+
+// VirtualContainers returns the running containers of the virtual services spread evenly across the virtual machines
+// keyed by machine ID.
+func VirtualContainers(opts Options) map[string][]api.ServiceContainer {
+	containers := make(map[string][]api.ServiceContainer, opts.Machines)
+	n := 0
+	for svc := range opts.Services {
+		for replica := range opts.Replicas {
+			m := n % opts.Machines
+			// Assign sequential IPs in the machine subnet skipping the network and gateway addresses.
+			ip := machineSubnet(m).Addr()
+			for range len(containers[machineID(m)]) + 2 {
+				ip = ip.Next()
+			}
+
+			id := fmt.Sprintf("%s-%d", serviceName(svc), replica)
+			ctr := api.ServiceContainer{
+				Container: api.Container{InspectResponse: container.InspectResponse{
+					ContainerJSONBase: &container.ContainerJSONBase{
+						ID:    id,
+						Name:  id,
+						State: &container.State{Running: true},
+					},
+					Config: &container.Config{Labels: map[string]string{
+						api.LabelServiceID:   serviceName(svc) + "-id",
+						api.LabelServiceName: serviceName(svc),
+						api.LabelManaged:     "",
+					}},
+					NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+						api.DockerNetworkName: {IPAddress: ip.String()},
+					}},
+				}},
+				ServiceSpec: api.ServiceSpec{
+					Name:      serviceName(svc),
+					Mode:      api.ServiceModeReplicated,
+					Container: api.ContainerSpec{Image: "nginx"},
+				},
+			}
+			containers[machineID(m)] = append(containers[machineID(m)], ctr)
+			n++
+		}
+	}
+	return containers
+}
+
+func machineID(i int) string {
+	return fmt.Sprintf("virtual-machine-%d", i)
+}
+
+// machineSubnet returns a unique /24 subnet in 10.210.0.0/16 for the first 256 machines and in the following /16
+// networks for the rest.
+func machineSubnet(i int) netip.Prefix {
+	return netip.PrefixFrom(netip.AddrFrom4([4]byte{10, byte(210 + i/256), byte(i % 256), 0}), 24)
+}
+
+func serviceName(i int) string {
+	return fmt.Sprintf("service-%d", i)
+}
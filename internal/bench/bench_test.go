@@ -0,0 +1,65 @@
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{Machines: 5, Services: 10, Replicas: 3, DNSQueries: 100}
+	results, err := Run(context.Background(), opts)
+	require.NoError(t, err)
+
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Name
+	}
+	assert.Equal(t, []string{"schedule service", "propagate machine state", "load DNS records", "resolve service"},
+		names)
+	assert.Len(t, results[0].Samples, opts.Services)
+	assert.Len(t, results[1].Samples, opts.Machines)
+	assert.Len(t, results[3].Samples, opts.DNSQueries)
+}
+
+func TestVirtualContainers(t *testing.T) {
+	t.Parallel()
+
+	containers := VirtualContainers(Options{Machines: 4, Services: 3, Replicas: 3})
+	require.Len(t, containers, 4)
+
+	total := 0
+	ips := make(map[string]struct{})
+	for _, ctrs := range containers {
+		// 9 containers spread evenly across 4 machines.
+		assert.GreaterOrEqual(t, len(ctrs), 2)
+		assert.LessOrEqual(t, len(ctrs), 3)
+		for _, c := range ctrs {
+			ips[c.Container.UncloudNetworkIP().String()] = struct{}{}
+		}
+		total += len(ctrs)
+	}
+	assert.Equal(t, 9, total)
+	assert.Len(t, ips, 9, "container IPs must be unique")
+}
+
+func TestResult_Percentile(t *testing.T) {
+	t.Parallel()
+
+	r := Result{}
+	assert.Zero(t, r.Percentile(50))
+	assert.Zero(t, r.Max())
+
+	for i := 10; i >= 1; i-- {
+		r.Samples = append(r.Samples, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 5*time.Millisecond, r.Percentile(50))
+	assert.Equal(t, 10*time.Millisecond, r.Percentile(95))
+	assert.Equal(t, time.Millisecond, r.Percentile(0))
+	assert.Equal(t, 10*time.Millisecond, r.Max())
+}
@@ -0,0 +1,254 @@
+// Package bundle creates and reads air-gapped installation bundles. A bundle is a gzipped tarball with everything
+// needed to install Uncloud on a machine without internet access: the uncloudd and corrosion release archives,
+// the install and uninstall scripts, and the container images to load into Docker.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// Names of the files in the bundle. The install script looks for them in the directory set by UNCLOUD_BUNDLE_DIR.
+const (
+	ManifestFile         = "bundle.json"
+	InstallScriptFile    = "install.sh"
+	UninstallScriptFile  = "uninstall.sh"
+	UncloudArchiveFile   = "uncloudd.tar.gz"
+	CorrosionArchiveFile = "corrosion.tar.gz"
+	ImagesFile           = "images.tar"
+)
+
+const corrosionGitHubURL = "https://github.com/psviderski/corrosion"
+
+// Archs is the list of supported machine architectures.
+var Archs = []string{"amd64", "arm64"}
+
+// Manifest describes the content of the bundle.
+type Manifest struct {
+	// Version is the uncloudd version without the 'v' prefix.
+	Version          string `json:"version"`
+	CorrosionVersion string `json:"corrosion_version"`
+	// Arch is the machine architecture the binaries and images are built for.
+	Arch string `json:"arch"`
+	// CaddyImage is the Caddy image included in the bundle that 'uc machine init' deploys.
+	CaddyImage string `json:"caddy_image,omitempty"`
+	// Images is the list of all images in the images file including the Caddy image.
+	Images  []string  `json:"images"`
+	Created time.Time `json:"created"`
+}
+
+// Validate checks if the manifest describes a bundle that can be installed.
+func (m *Manifest) Validate() error {
+	if m.Version == "" {
+		return errors.New("uncloudd version is not set")
+	}
+	if !slices.Contains(Archs, m.Arch) {
+		return fmt.Errorf("unsupported architecture '%s', supported values: %v", m.Arch, Archs)
+	}
+	return nil
+}
+
+// UncloudArchiveName returns the name of the uncloudd release archive for the architecture.
+func UncloudArchiveName(arch string) string {
+	return fmt.Sprintf("uncloudd_linux_%s.tar.gz", arch)
+}
+
+// corrosionVersionRegex matches the default corrosion version pinned in the install script.
+var corrosionVersionRegex = regexp.MustCompile(`(?m)^CORROSION_VERSION=\$\{CORROSION_VERSION:-(v[^}]+)}$`)
+
+// CorrosionVersion returns the default corrosion version pinned in the install script so the bundle includes
+// the same version the script would download.
+func CorrosionVersion(installScript string) (string, error) {
+	m := corrosionVersionRegex.FindStringSubmatch(installScript)
+	if m == nil {
+		return "", errors.New("corrosion version not found in install script")
+	}
+	return m[1], nil
+}
+
+// CorrosionArchiveURL returns the download URL of the corrosion release archive for the architecture.
+func CorrosionArchiveURL(version, arch string) (string, error) {
+	var rustArch string
+	switch arch {
+	case "amd64":
+		rustArch = "x86_64"
+	case "arm64":
+		rustArch = "aarch64"
+	default:
+		return "", fmt.Errorf("unsupported architecture '%s', supported values: %v", arch, Archs)
+	}
+	return fmt.Sprintf("%s/releases/download/%s/corrosion-%s-unknown-linux-gnu.tar.gz",
+		corrosionGitHubURL, version, rustArch), nil
+}
+
+// Download downloads the file from the URL.
+func Download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from '%s': %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// SaveImages pulls the images for linux/arch from their registries and writes them to a file in the format
+// produced by 'docker save' that can be loaded with 'docker load'.
+func SaveImages(ctx context.Context, path string, images []string, arch string) error {
+	platform := v1.Platform{OS: "linux", Architecture: arch}
+	refToImage := make(map[name.Reference]v1.Image, len(images))
+	for _, image := range images {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			return fmt.Errorf("parse image '%s': %w", image, err)
+		}
+		img, err := remote.Image(ref,
+			remote.WithContext(ctx),
+			remote.WithPlatform(platform),
+			remote.WithAuthFromKeychain(authn.DefaultKeychain),
+		)
+		if err != nil {
+			return fmt.Errorf("pull image '%s' for platform %s: %w", image, platform, err)
+		}
+		refToImage[ref] = img
+	}
+
+	if err := tarball.MultiRefWriteToFile(path, refToImage); err != nil {
+		return fmt.Errorf("write images: %w", err)
+	}
+	return nil
+}
+
+// Writer writes files to a gzipped tarball bundle.
+type Writer struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+// NewWriter returns a writer that writes a gzipped tarball to w.
+func NewWriter(w io.Writer) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+// WriteManifest writes the manifest file to the bundle.
+func (w *Writer) WriteManifest(m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	return w.WriteFile(ManifestFile, 0o644, append(data, '\n'))
+}
+
+// WriteFile writes a file with the given name, mode, and content to the bundle.
+func (w *Writer) WriteFile(name string, mode int64, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    mode,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write header for '%s': %w", name, err)
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return fmt.Errorf("write '%s': %w", name, err)
+	}
+	return nil
+}
+
+// CopyFile copies the local file at path to the bundle under the given name.
+func (w *Writer) CopyFile(name string, mode int64, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    mode,
+		Size:    info.Size(),
+		ModTime: time.Now(),
+	}
+	if err = w.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write header for '%s': %w", name, err)
+	}
+	if _, err = io.Copy(w.tw, f); err != nil {
+		return fmt.Errorf("write '%s': %w", name, err)
+	}
+	return nil
+}
+
+// Close flushes the tarball and gzip streams. It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.tw.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+// ReadManifest reads and validates the manifest from the bundle file at path.
+func ReadManifest(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("read gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("'%s' not found in bundle, is it an Uncloud bundle?", ManifestFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read tar archive: %w", err)
+		}
+		if hdr.Name != ManifestFile {
+			continue
+		}
+
+		var m Manifest
+		if err = json.NewDecoder(tr).Decode(&m); err != nil {
+			return nil, fmt.Errorf("decode '%s': %w", ManifestFile, err)
+		}
+		if err = m.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid bundle manifest: %w", err)
+		}
+		return &m, nil
+	}
+}
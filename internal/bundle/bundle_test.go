@@ -0,0 +1,113 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/scripts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrosionVersion(t *testing.T) {
+	v, err := CorrosionVersion(scripts.InstallScript)
+	require.NoError(t, err)
+	assert.Regexp(t, `^v\d+\.\d+\.\d+`, v)
+
+	_, err = CorrosionVersion("#!/usr/bin/env bash\n")
+	assert.Error(t, err)
+}
+
+func TestCorrosionArchiveURL(t *testing.T) {
+	url, err := CorrosionArchiveURL("v0.2.2", "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/psviderski/corrosion/releases/download/v0.2.2/"+
+		"corrosion-x86_64-unknown-linux-gnu.tar.gz", url)
+
+	url, err = CorrosionArchiveURL("v0.2.2", "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/psviderski/corrosion/releases/download/v0.2.2/"+
+		"corrosion-aarch64-unknown-linux-gnu.tar.gz", url)
+
+	_, err = CorrosionArchiveURL("v0.2.2", "riscv64")
+	assert.Error(t, err)
+}
+
+func TestManifestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			manifest: Manifest{Version: "0.9.0", Arch: "arm64"},
+		},
+		{
+			name:     "no version",
+			manifest: Manifest{Arch: "amd64"},
+			wantErr:  "version is not set",
+		},
+		{
+			name:     "unsupported arch",
+			manifest: Manifest{Version: "0.9.0", Arch: "386"},
+			wantErr:  "unsupported architecture",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriterReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	localFile := filepath.Join(dir, "images.tar")
+	require.NoError(t, os.WriteFile(localFile, []byte("images"), 0o644))
+
+	want := &Manifest{
+		Version:          "0.9.0",
+		CorrosionVersion: "v0.2.2",
+		Arch:             "amd64",
+		CaddyImage:       "caddy:2.10.2",
+		Images:           []string{"caddy:2.10.2", "postgres:17"},
+		Created:          time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	path := filepath.Join(dir, "bundle.tar.gz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := NewWriter(f)
+	require.NoError(t, w.WriteFile(InstallScriptFile, 0o755, []byte("#!/bin/sh\n")))
+	require.NoError(t, w.CopyFile(ImagesFile, 0o644, localFile))
+	require.NoError(t, w.WriteManifest(want))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	got, err := ReadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestReadManifestNotBundle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bundle.tar.gz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := NewWriter(f)
+	require.NoError(t, w.WriteFile("README", 0o644, []byte("hello")))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	_, err = ReadManifest(path)
+	assert.ErrorContains(t, err, "not found in bundle")
+}
@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"charm.land/lipgloss/v2"
 	composetypes "github.com/compose-spec/compose-go/v2/types"
 	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/distribution/reference"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/flags"
 	composeapi "github.com/docker/compose/v2/pkg/api"
 	composev2 "github.com/docker/compose/v2/pkg/compose"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/psviderski/uncloud/pkg/client/compose"
 )
@@ -22,6 +28,10 @@ import (
 type BuildServicesOptions struct {
 	// BuildArgs sets build-time variables for services. Used in Dockerfiles that declare variables with ARG.
 	BuildArgs []string
+	// CacheRepo is a registry repository used as a shared BuildKit layer cache for services that don't
+	// configure build.cache_from or build.cache_to. Each service stores its cache under a tag with its name
+	// so builds on different machines can reuse layers built elsewhere.
+	CacheRepo string
 	// Check the build configuration for services without building them.
 	Check bool
 	// Deps enables to also build services declared as dependencies of the selected Services.
@@ -42,6 +52,85 @@ type BuildServicesOptions struct {
 	// Cluster-specific options (only used if PushCluster is true).
 	// Machines is a list of machine names or IDs to push the image to. If empty, images are pushed to all machines.
 	Machines []string
+
+	// Builder is the name or ID of a cluster machine to build the images on using its Docker instead of the local
+	// Docker. The Docker daemon on the machine is accessed over SSH using the machine connection from the cluster
+	// context. With PushCluster, the images are pushed from the builder to other machines over the cluster network.
+	Builder string
+}
+
+// Builder is a cluster machine that builds images with its Docker daemon accessed over SSH.
+type Builder struct {
+	Machine *pb.MachineInfo
+	// DockerHost is the Docker host URL of the Docker daemon on the machine, e.g. ssh://user@host:port.
+	DockerHost string
+}
+
+// ResolveBuilder finds the builder machine by name or ID in the cluster and the SSH connection to it
+// in the Uncloud config.
+func (cli *CLI) ResolveBuilder(ctx context.Context, c *client.Client, nameOrID string) (*Builder, error) {
+	mm, err := c.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("inspect builder machine '%s': %w", nameOrID, err)
+	}
+
+	var conns []config.MachineConnection
+	if cli.conn != nil {
+		conns = []config.MachineConnection{*cli.conn}
+	} else if cfg, ok := cli.Config.Contexts[cli.ContextOverrideOrCurrent()]; ok {
+		conns = cfg.Connections
+	}
+
+	host, err := sshDockerHost(conns, mm.Machine.Id)
+	if err != nil {
+		return nil, err
+	}
+	if host == "" {
+		return nil, fmt.Errorf("no SSH connection to builder machine '%s' found in the Uncloud config: "+
+			"builds can only run on machines that were added to the cluster over SSH", mm.Machine.Name)
+	}
+
+	return &Builder{Machine: mm.Machine, DockerHost: host}, nil
+}
+
+// sshDockerHost returns the Docker host URL to connect to the Docker daemon on the machine with the given ID using
+// the first SSH connection to the machine. It returns an empty string if there is no such connection.
+func sshDockerHost(conns []config.MachineConnection, machineID string) (string, error) {
+	for _, conn := range conns {
+		if conn.MachineID != machineID {
+			continue
+		}
+
+		dest := conn.SSH
+		if dest == "" {
+			dest = conn.SSHCLI
+		}
+		if dest == "" {
+			// Docker always uses the ssh CLI but it's compatible with the Go SSH destination.
+			dest = conn.SSHGo
+		}
+		if dest == "" {
+			continue
+		}
+
+		user, host, port, err := dest.Parse()
+		if err != nil {
+			return "", fmt.Errorf("parse SSH destination '%s': %w", dest, err)
+		}
+		u := url.URL{Scheme: "ssh", Host: host}
+		if strings.Contains(host, ":") {
+			u.Host = "[" + host + "]"
+		}
+		if port != 0 {
+			u.Host = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+		if user != "" {
+			u.User = url.User(user)
+		}
+		return u.String(), nil
+	}
+
+	return "", nil
 }
 
 // BuildServices builds images for services in the Compose project.
@@ -51,22 +140,51 @@ func (cli *CLI) BuildServices(ctx context.Context, project *composetypes.Project
 		return fmt.Errorf("cannot specify both PushCluster and PushRegistry: choose one push target")
 	}
 
+	var (
+		clusterClient *client.Client
+		builder       *Builder
+		err           error
+	)
+	if opts.Builder != "" {
+		if clusterClient, err = cli.ConnectCluster(ctx); err != nil {
+			return fmt.Errorf("connect to cluster: %w", err)
+		}
+		defer clusterClient.Close()
+
+		if builder, err = cli.ResolveBuilder(ctx, clusterClient, opts.Builder); err != nil {
+			return err
+		}
+		fmt.Fprintf(cli.ProgressOut(), "Building on machine %s (%s)\n",
+			lipgloss.NewStyle().Bold(true).Render(builder.Machine.Name), builder.DockerHost)
+	}
+
 	// Build service images using Compose implementation.
 	dockerCli, err := command.NewDockerCli()
 	if err != nil {
 		return fmt.Errorf("create docker client: %w", err)
 	}
-	// Initialise the Docker CLI with default options.
-	if err = dockerCli.Initialize(flags.NewClientOptions()); err != nil {
+	// Initialise the Docker CLI with default options connecting to the Docker on the builder if specified.
+	clientOpts := flags.NewClientOptions()
+	if builder != nil {
+		clientOpts.Hosts = []string{builder.DockerHost}
+	}
+	if err = dockerCli.Initialize(clientOpts); err != nil {
 		return fmt.Errorf("initialise docker client: %w", err)
 	}
 
+	if opts.CacheRepo != "" && !opts.NoCache {
+		if project, err = WithRegistryCache(project, opts.CacheRepo); err != nil {
+			return err
+		}
+	}
+
 	composeService := composev2.NewComposeService(dockerCli)
 	buildOpts := composeapi.BuildOptions{
 		Args:     composetypes.NewMappingWithEquals(opts.BuildArgs),
 		Check:    opts.Check,
 		Deps:     opts.Deps,
 		NoCache:  opts.NoCache,
+		Progress: cli.progressMode,
 		Pull:     opts.Pull,
 		Push:     opts.PushRegistry,
 		Services: opts.Services,
@@ -93,11 +211,12 @@ func (cli *CLI) BuildServices(ctx context.Context, project *composetypes.Project
 	// Add a line break after the build output.
 	fmt.Fprintln(cli.ProgressOut())
 
-	clusterClient, err := cli.ConnectCluster(ctx)
-	if err != nil {
-		return fmt.Errorf("connect to cluster: %w", err)
+	if clusterClient == nil {
+		if clusterClient, err = cli.ConnectCluster(ctx); err != nil {
+			return fmt.Errorf("connect to cluster: %w", err)
+		}
+		defer clusterClient.Close()
 	}
-	defer clusterClient.Close()
 
 	// Push one service image at a time.
 	var errs []error
@@ -114,12 +233,16 @@ func (cli *CLI) BuildServices(ctx context.Context, project *composetypes.Project
 		if len(opts.Machines) > 0 {
 			pushOpts.Machines = opts.Machines
 		} else if machines, ok := s.Extensions[compose.MachinesExtensionKey].(compose.MachinesSource); ok {
-			pushOpts.Machines = machines
+			pushOpts.Machines = machines.PushMachines()
 		}
 
 		if len(pushOpts.Machines) == 0 {
 			pushOpts.AllMachines = true
 		}
+		if builder != nil {
+			pushOpts.Builder = builder.Machine
+			pushOpts.BuilderDockerHost = builder.DockerHost
+		}
 
 		boldStyle := lipgloss.NewStyle().Bold(true)
 		err = progress.RunWithTitle(ctx, func(ctx context.Context) error {
@@ -137,6 +260,38 @@ func (cli *CLI) BuildServices(ctx context.Context, project *composetypes.Project
 	return errors.Join(errs...)
 }
 
+// WithRegistryCache returns a copy of the project where services with a build config import and export
+// the BuildKit cache from/to the given registry repository. The cache for each service is stored under
+// a tag with the service name. Services that already set build.cache_from or build.cache_to keep their
+// own cache configuration.
+func WithRegistryCache(project *composetypes.Project, repo string) (*composetypes.Project, error) {
+	named, err := reference.ParseNormalizedNamed(repo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache repository '%s': %w", repo, err)
+	}
+	if !reference.IsNameOnly(named) {
+		return nil, fmt.Errorf("invalid cache repository '%s': must not contain a tag or digest", repo)
+	}
+
+	transform := func(name string, s composetypes.ServiceConfig) (composetypes.ServiceConfig, error) {
+		if s.Build == nil {
+			return s, nil
+		}
+		// Copy the build config to not modify the original project.
+		build := *s.Build
+		ref := fmt.Sprintf("%s:%s", named.String(), name)
+		if len(build.CacheFrom) == 0 {
+			build.CacheFrom = composetypes.StringList{"type=registry,ref=" + ref}
+		}
+		if len(build.CacheTo) == 0 {
+			build.CacheTo = composetypes.StringList{"type=registry,ref=" + ref + ",mode=max"}
+		}
+		s.Build = &build
+		return s, nil
+	}
+	return project.WithServicesTransform(transform)
+}
+
 // ServicesThatNeedBuild returns a list of services that require building.
 // deps indicates whether to include services that are dependencies of the selected services.
 // Implementation is based on the logic from docker/compose/v2/pkg/compose/build.go.
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"net/netip"
+	"testing"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/internal/cli/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRegistryCache(t *testing.T) {
+	project := &composetypes.Project{
+		Services: composetypes.Services{
+			"web": {
+				Name:  "web",
+				Build: &composetypes.BuildConfig{Context: "."},
+			},
+			"api": {
+				Name: "api",
+				Build: &composetypes.BuildConfig{
+					Context:   "./api",
+					CacheFrom: composetypes.StringList{"type=local,src=/tmp/cache"},
+				},
+			},
+			"db": {
+				Name:  "db",
+				Image: "postgres",
+			},
+		},
+	}
+
+	got, err := WithRegistryCache(project, "registry.example.com/myapp/cache")
+	require.NoError(t, err)
+
+	web := got.Services["web"].Build
+	assert.Equal(t, composetypes.StringList{"type=registry,ref=registry.example.com/myapp/cache:web"}, web.CacheFrom)
+	assert.Equal(t, composetypes.StringList{"type=registry,ref=registry.example.com/myapp/cache:web,mode=max"},
+		web.CacheTo)
+
+	api := got.Services["api"].Build
+	assert.Equal(t, composetypes.StringList{"type=local,src=/tmp/cache"}, api.CacheFrom,
+		"explicit cache_from must be kept")
+	assert.Equal(t, composetypes.StringList{"type=registry,ref=registry.example.com/myapp/cache:api,mode=max"},
+		api.CacheTo)
+
+	assert.Nil(t, got.Services["db"].Build)
+	assert.Empty(t, project.Services["web"].Build.CacheFrom, "original project must not be modified")
+}
+
+func TestWithRegistryCache_Invalid(t *testing.T) {
+	project := &composetypes.Project{}
+
+	tests := []struct {
+		name    string
+		repo    string
+		wantErr string
+	}{
+		{name: "tag", repo: "registry.example.com/cache:latest", wantErr: "must not contain a tag or digest"},
+		{name: "invalid", repo: "Invalid Repo", wantErr: "invalid cache repository"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := WithRegistryCache(project, tt.repo)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWithRegistryCache_DockerHub(t *testing.T) {
+	project := &composetypes.Project{
+		Services: composetypes.Services{
+			"web": {Name: "web", Build: &composetypes.BuildConfig{Context: "."}},
+		},
+	}
+
+	got, err := WithRegistryCache(project, "myuser/cache")
+	require.NoError(t, err)
+	assert.Equal(t, composetypes.StringList{"type=registry,ref=docker.io/myuser/cache:web"},
+		got.Services["web"].Build.CacheFrom)
+}
+
+func TestSSHDockerHost(t *testing.T) {
+	t.Parallel()
+
+	conns := []config.MachineConnection{
+		{SSH: "root@1.2.3.4", MachineID: "m1"},
+		{TCP: &netip.AddrPort{}, MachineID: "m2"},
+		{SSHGo: "admin@example.com:2222", MachineID: "m2"},
+		{SSHCLI: "user@::1", MachineID: "m3"},
+		{Unix: "/run/uncloud/uncloud.sock", MachineID: "m4"},
+	}
+
+	tests := []struct {
+		name      string
+		machineID string
+		want      string
+	}{
+		{name: "ssh", machineID: "m1", want: "ssh://root@1.2.3.4"},
+		{name: "ssh go with port after non-ssh connection", machineID: "m2", want: "ssh://admin@example.com:2222"},
+		{name: "ipv6", machineID: "m3", want: "ssh://user@[::1]"},
+		{name: "no ssh connection", machineID: "m4", want: ""},
+		{name: "unknown machine", machineID: "m5", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := sshDockerHost(conns, tt.machineID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,12 +2,15 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/netip"
 	"os"
+	"path/filepath"
 	"slices"
+	"time"
 
-	"github.com/docker/cli/cli/streams"
 	"github.com/psviderski/uncloud/internal/cli/config"
 	"github.com/psviderski/uncloud/internal/machine"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
@@ -16,6 +19,7 @@ import (
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client"
 	"github.com/psviderski/uncloud/pkg/client/connector"
+	"github.com/psviderski/uncloud/pkg/client/timing"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -26,12 +30,19 @@ const (
 	// Used when no key is explicitly provided and SSH agent authentication fails.
 	DefaultSSHKeyPath  = "~/.ssh/id_ed25519"
 	DefaultContextName = "default"
+	// DefaultMachinesCacheTTL is how long the list of cluster machines is cached between commands unless
+	// configured otherwise. It's short enough for machine changes made by others to show up quickly.
+	DefaultMachinesCacheTTL = 10 * time.Second
 )
 
 type CLI struct {
-	Config          *config.Config
+	Config *config.Config
+	// AssumeYes auto-confirms all prompts shown with Confirm. It's set with the global --yes flag.
+	AssumeYes       bool
 	conn            *config.MachineConnection
 	contextOverride string
+	// progressMode is one of ProgressModes set with SetProgressMode.
+	progressMode string
 }
 
 // New creates a new CLI instance with the given config path or remote machine connection.
@@ -104,8 +115,18 @@ func (cli *CLI) ConnectCluster(ctx context.Context) (*client.Client, error) {
 // If the CLI has an override context, it is used instead of the current default.
 // Options are useful when using the CLI as a library where you may want to disable visual feedback.
 func (cli *CLI) ConnectClusterWithOptions(ctx context.Context, opts ConnectOptions) (*client.Client, error) {
+	defer timing.Start(ctx, "", timing.PhaseConnect)()
+	switch cli.ProgressMode() {
+	case ProgressQuiet:
+		opts.ShowProgress = false
+	case ProgressPlain:
+		opts.PlainProgress = true
+	}
+
 	if cli.conn != nil {
-		return ConnectCluster(ctx, *cli.conn, opts)
+		opts.Client = append(cli.clientOptions(""), opts.Client...)
+		c, err := ConnectCluster(ctx, *cli.conn, opts)
+		return c, WithExitCode(ExitCodeConnection, err)
 	}
 
 	if len(cli.Config.Contexts) == 0 {
@@ -149,6 +170,9 @@ func (cli *CLI) ConnectClusterWithOptions(ctx context.Context, opts ConnectOptio
 		)
 	}
 
+	// Options passed explicitly take precedence over the config.
+	opts.Client = append(cli.clientOptions(contextName), opts.Client...)
+
 	// Try each connection in order until one succeeds.
 	var lastErr error
 	for _, conn := range cfg.Connections {
@@ -160,22 +184,83 @@ func (cli *CLI) ConnectClusterWithOptions(ctx context.Context, opts ConnectOptio
 		lastErr = err
 	}
 
-	return nil, fmt.Errorf("failed to connect to cluster context '%s': "+
+	return nil, WithExitCode(ExitCodeConnection, fmt.Errorf("failed to connect to cluster context '%s': "+
 		"all connections (%d) in the Uncloud config (%s) failed; last error: %w",
-		contextName, len(cfg.Connections), cli.Config.Path(), lastErr)
+		contextName, len(cfg.Connections), cli.Config.Path(), lastErr))
+}
+
+// clientOptions returns the cluster client options from the client section of the config for the given context.
+// The machines are cached per context only when connecting with a context.
+func (cli *CLI) clientOptions(contextName string) []client.Option {
+	// Config is not loaded when the CLI is initialised with a machine connection.
+	if cli.Config == nil {
+		return nil
+	}
+	var opts []client.Option
+	cfg := cli.Config.Client
+	if contextName != "" {
+		ttl := DefaultMachinesCacheTTL
+		if cfg != nil && cfg.MachinesCacheTTL != nil {
+			ttl = *cfg.MachinesCacheTTL
+		}
+		if path, err := machinesCachePath(cli.Config.Path(), contextName); err == nil {
+			opts = append(opts, client.WithMachinesCache(path, ttl))
+		}
+	}
+	if cfg == nil {
+		return opts
+	}
+
+	opts = append(opts,
+		client.WithConnectTimeout(cfg.ConnectTimeout),
+		client.WithRPCTimeout(cfg.RPCTimeout),
+	)
+	if cfg.Retry != nil {
+		opts = append(opts, client.WithRetryPolicy(client.RetryPolicy{
+			MaxAttempts:    cfg.Retry.MaxAttempts,
+			InitialBackoff: cfg.Retry.InitialBackoff,
+			MaxBackoff:     cfg.Retry.MaxBackoff,
+		}))
+	}
+	if cfg.Compression != "" {
+		opts = append(opts, client.WithCompression(cfg.Compression))
+	}
+	return opts
+}
+
+// machinesCachePath returns the path to the file caching the machines of the cluster context. The file name is
+// derived from the config path as well as the context name as different configs may have contexts with the same name.
+func machinesCachePath(configPath, contextName string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	if abs, err := filepath.Abs(configPath); err == nil {
+		configPath = abs
+	}
+	sum := sha256.Sum256([]byte(configPath + "\x00" + contextName))
+	return filepath.Join(cacheDir, "uncloud", "machines", hex.EncodeToString(sum[:8])+".json"), nil
 }
 
 type InitClusterOptions struct {
-	Context            string
-	MachineName        string
-	Network            netip.Prefix
-	PublicIP           *netip.Addr
-	RemoteMachine      *RemoteMachine
-	SkipInstall        bool
-	Version            string
+	Context       string
+	MachineName   string
+	Network       netip.Prefix
+	PublicIP      *netip.Addr
+	RemoteMachine *RemoteMachine
+	SkipInstall   bool
+	// SkipChecks skips the host prerequisite checks before initialising or joining the cluster.
+	SkipChecks bool
+	Version    string
+	// BundlePath is the path to an air-gapped installation bundle to install the machine from.
+	BundlePath         string
 	AutoConfirm        bool
 	WireguardEndpoints []*pb.IPPort
 	WireguardPort      int
+	Profile            string
+	StoreBackend       string
+	// UsernsRemap configures Docker on the machine with user namespace remapping if it's installed by Uncloud.
+	UsernsRemap bool
 }
 
 // InitCluster initialises a new cluster on a remote machine and returns a client to interact with the cluster.
@@ -194,7 +279,12 @@ func (cli *CLI) initRemoteMachine(ctx context.Context, opts InitClusterOptions)
 		return nil, err
 	}
 
-	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, opts.SkipInstall, opts.Version)
+	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, opts.SkipInstall, installOptions{
+		version:     opts.Version,
+		profile:     opts.Profile,
+		bundlePath:  opts.BundlePath,
+		usernsRemap: opts.UsernsRemap,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -222,14 +312,10 @@ func (cli *CLI) initRemoteMachine(ctx context.Context, opts InitClusterOptions)
 	}
 
 	// Check machine meets all necessary system requirements before proceeding.
-	checkResp, err := machineClient.CheckPrerequisites(ctx, &emptypb.Empty{})
-	// TODO(lhf): remove Unimplemented check when v0.9.0 is released.
-	if err != nil {
-		if status.Convert(err).Code() != codes.Unimplemented {
-			return nil, fmt.Errorf("check machine prerequisites: %w", err)
+	if !opts.SkipChecks {
+		if err = checkPrerequisites(ctx, machineClient.MachineClient, opts.WireguardPort); err != nil {
+			return nil, err
 		}
-	} else if !checkResp.Satisfied {
-		return nil, fmt.Errorf("machine prerequisites not satisfied: %s", checkResp.Error)
 	}
 
 	req := &pb.InitClusterRequest{
@@ -237,6 +323,8 @@ func (cli *CLI) initRemoteMachine(ctx context.Context, opts InitClusterOptions)
 		Network:            pb.NewIPPrefix(opts.Network),
 		WireguardEndpoints: opts.WireguardEndpoints,
 		WireguardPort:      int32(opts.WireguardPort),
+		Profile:            opts.Profile,
+		StoreBackend:       opts.StoreBackend,
 	}
 	if opts.PublicIP != nil {
 		if opts.PublicIP.IsValid() {
@@ -313,14 +401,23 @@ func (cli *CLI) newContextName(name string) (string, error) {
 }
 
 type AddMachineOptions struct {
-	MachineName        string
-	PublicIP           *netip.Addr
-	RemoteMachine      *RemoteMachine
-	SkipInstall        bool
-	Version            string
+	MachineName   string
+	PublicIP      *netip.Addr
+	RemoteMachine *RemoteMachine
+	// Roles to assign to the machine, e.g. api.MachineRoleBuilder.
+	Roles []string
+	// Zone is the failure domain of the machine, e.g. a data center or a cloud provider location.
+	Zone        string
+	SkipInstall bool
+	// SkipChecks skips the host prerequisite checks before initialising or joining the cluster.
+	SkipChecks bool
+	Version    string
+	// BundlePath is the path to an air-gapped installation bundle to install the machine from.
+	BundlePath         string
 	AutoConfirm        bool
 	WireguardEndpoints []*pb.IPPort
 	WireguardPort      int
+	Profile            string
 }
 
 // AddMachine provisions a remote machine and adds it to the cluster. It returns a cluster client and a machine client.
@@ -339,7 +436,20 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 		}
 	}()
 
-	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, opts.SkipInstall, opts.Version)
+	installOpts := installOptions{
+		version:    opts.Version,
+		profile:    opts.Profile,
+		bundlePath: opts.BundlePath,
+	}
+	if !opts.SkipInstall {
+		// Install Docker on the new machine with user namespace remapping if it's enabled for the cluster.
+		installOpts.usernsRemap, err = c.UsernsRemap(ctx)
+		if err != nil && status.Code(err) != codes.Unimplemented {
+			return nil, nil, fmt.Errorf("get cluster userns remap option: %w", err)
+		}
+	}
+
+	machineClient, err := provisionOrConnectRemoteMachine(ctx, opts.RemoteMachine, opts.SkipInstall, installOpts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -357,7 +467,7 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 	}
 	if minfo.Id != "" {
 		// Check if the machine is already a member of this cluster.
-		machines, err := c.ListMachines(ctx, nil)
+		machines, err := c.ListMachines(client.WithFreshMachines(ctx), nil)
 		if err != nil {
 			return nil, nil, fmt.Errorf("list cluster machines: %w", err)
 		}
@@ -378,14 +488,10 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 	}
 
 	// Check machine meets all necessary system requirements before proceeding.
-	checkResp, err := machineClient.CheckPrerequisites(ctx, &emptypb.Empty{})
-	// TODO(lhf): remove Unimplemented check when v0.9.0 is released.
-	if err != nil {
-		if status.Convert(err).Code() != codes.Unimplemented {
-			return nil, nil, fmt.Errorf("check machine prerequisites: %w", err)
+	if !opts.SkipChecks {
+		if err = checkPrerequisites(ctx, machineClient.MachineClient, opts.WireguardPort); err != nil {
+			return nil, nil, err
 		}
-	} else if !checkResp.Satisfied {
-		return nil, nil, fmt.Errorf("machine prerequisites not satisfied: %s", checkResp.Error)
 	}
 
 	tokenResp, err := machineClient.Token(ctx, &emptypb.Empty{})
@@ -419,6 +525,8 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 			Endpoints: endpoints,
 			PublicKey: token.PublicKey,
 		},
+		Roles: opts.Roles,
+		Zone:  opts.Zone,
 	}
 	if opts.PublicIP != nil {
 		if opts.PublicIP.IsValid() {
@@ -447,7 +555,7 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 	}
 
 	// Get the most up-to-date list of other machines in the cluster to include them in the join request.
-	machines, err := c.ListMachines(ctx, nil)
+	machines, err := c.ListMachines(client.WithFreshMachines(ctx), nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("list cluster machines: %w", err)
 	}
@@ -464,6 +572,7 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 		OtherMachines:     otherMachines,
 		MinStoreDbVersion: storeDBVersion,
 		WireguardPort:     int32(opts.WireguardPort),
+		Profile:           opts.Profile,
 	}
 	if _, err = machineClient.JoinCluster(ctx, joinReq); err != nil {
 		return nil, nil, fmt.Errorf("join cluster: %w", err)
@@ -500,13 +609,12 @@ func (cli *CLI) AddMachine(ctx context.Context, opts AddMachineOptions) (*client
 
 // provisionOrConnectRemoteMachine installs the Uncloud daemon and dependencies on the remote machine over SSH and
 // returns a machine API client to interact with the machine. The client should be closed after use by the caller.
-// The version parameter specifies the version of the Uncloud daemon to install. If empty, the latest version is used.
 // If skipInstall is true, the installation step is skipped, and it is assumed that the Uncloud daemon and dependencies
-// are already installed and running.
+// are already installed and running. Otherwise, the machine is provisioned with the install options.
 // The remoteMachine.SSHKeyPath could be updated to the default SSH key path if it is not set and the SSH agent
 // authentication fails.
 func provisionOrConnectRemoteMachine(
-	ctx context.Context, remoteMachine *RemoteMachine, skipInstall bool, version string,
+	ctx context.Context, remoteMachine *RemoteMachine, skipInstall bool, installOpts installOptions,
 ) (*client.Client, error) {
 	// Use Go's built-in SSH library.
 	if remoteMachine.UseSSHGo {
@@ -530,7 +638,7 @@ func provisionOrConnectRemoteMachine(
 		if !skipInstall {
 			// Provision the remote machine by installing the Uncloud daemon and dependencies over SSH.
 			exec := sshexec.NewRemote(sshClient)
-			if err = provisionMachine(ctx, exec, version); err != nil {
+			if err = provisionMachine(ctx, exec, installOpts); err != nil {
 				return nil, fmt.Errorf("provision machine: %w", err)
 			}
 		}
@@ -572,7 +680,7 @@ func provisionOrConnectRemoteMachine(
 			remoteMachine.Port,
 			remoteMachine.KeyPath,
 		)
-		if err := provisionMachine(ctx, exec, version); err != nil {
+		if err := provisionMachine(ctx, exec, installOpts); err != nil {
 			return nil, fmt.Errorf("provision machine: %w", err)
 		}
 
@@ -600,8 +708,3 @@ func (cli *CLI) SetClusterContextIfUnset(name string) {
 	}
 	cli.contextOverride = name
 }
-
-// ProgressOut returns an output stream for progress writer.
-func (cli *CLI) ProgressOut() *streams.Out {
-	return streams.NewOut(os.Stdout)
-}
@@ -39,3 +39,12 @@ func MachinesFlag(cmd *cobra.Command) {
 			return Machines(cmd.Context(), uncli, args, toComplete)
 		})
 }
+
+// BuilderFlag registers completion of machine names for the --builder flag.
+func BuilderFlag(cmd *cobra.Command) {
+	cmd.RegisterFlagCompletionFunc("builder",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			uncli := cmd.Context().Value("cli").(*cli.CLI)
+			return Machines(cmd.Context(), uncli, nil, toComplete)
+		})
+}
@@ -1,21 +1,73 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/psviderski/uncloud/internal/grpccompress"
 )
 
 type Config struct {
 	CurrentContext string              `yaml:"current_context"`
 	Contexts       map[string]*Context `yaml:"contexts"`
+	// Client configures timeouts and retries for connections to the cluster. Defaults are used if not set.
+	Client *ClientConfig `yaml:"client,omitempty"`
 
 	// path is the file path config is read from.
 	path string
 }
 
+// ClientConfig configures timeouts and retries for RPCs to the cluster machines.
+type ClientConfig struct {
+	// ConnectTimeout is the maximum time to establish a connection to a machine. 0 means no timeout.
+	ConnectTimeout time.Duration `yaml:"connect_timeout,omitempty"`
+	// RPCTimeout is the timeout for each attempt of a request that doesn't stream data. 0 means no timeout.
+	RPCTimeout time.Duration `yaml:"rpc_timeout,omitempty"`
+	// Retry configures retries of read-only requests. The default policy is used if not set.
+	Retry *RetryConfig `yaml:"retry,omitempty"`
+	// Compression is the compression of requests and responses: none, gzip, or zstd. Default is none.
+	Compression string `yaml:"compression,omitempty"`
+	// MachinesCacheTTL is how long the list of cluster machines is cached between commands. 0 disables the cache.
+	// The default TTL is used if not set.
+	MachinesCacheTTL *time.Duration `yaml:"machines_cache_ttl,omitempty"`
+}
+
+// RetryConfig configures retries with exponential backoff.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of attempts including the first one. 1 disables retries.
+	MaxAttempts    int           `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+}
+
+func (c *ClientConfig) Validate() error {
+	if c.ConnectTimeout < 0 {
+		return errors.New("connect_timeout must not be negative")
+	}
+	if c.RPCTimeout < 0 {
+		return errors.New("rpc_timeout must not be negative")
+	}
+	if err := grpccompress.Validate(c.Compression); err != nil {
+		return err
+	}
+	if c.MachinesCacheTTL != nil && *c.MachinesCacheTTL < 0 {
+		return errors.New("machines_cache_ttl must not be negative")
+	}
+	if c.Retry != nil {
+		if c.Retry.MaxAttempts < 1 {
+			return errors.New("retry.max_attempts must be at least 1")
+		}
+		if c.Retry.InitialBackoff < 0 || c.Retry.MaxBackoff < 0 {
+			return errors.New("retry backoff must not be negative")
+		}
+	}
+	return nil
+}
+
 func NewFromFile(path string) (*Config, error) {
 	_, err := os.Stat(path)
 	if err != nil && !os.IsNotExist(err) {
@@ -47,6 +99,11 @@ func (c *Config) Read() error {
 	if err = yaml.Unmarshal(data, c); err != nil {
 		return fmt.Errorf("parse config file '%s': %s", c.path, yaml.FormatError(err, true, true))
 	}
+	if c.Client != nil {
+		if err = c.Client.Validate(); err != nil {
+			return fmt.Errorf("invalid client config in '%s': %w", c.path, err)
+		}
+	}
 
 	return nil
 }
@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestConfig_Save(t *testing.T) {
@@ -78,3 +81,95 @@ func TestConfig_Save(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ReadClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    *ClientConfig
+		wantErr string
+	}{
+		{
+			name:    "no client section",
+			content: "current_context: default\n",
+		},
+		{
+			name: "timeouts and retry",
+			content: `client:
+  connect_timeout: 20s
+  rpc_timeout: 1m
+  compression: zstd
+  retry:
+    max_attempts: 5
+    initial_backoff: 500ms
+    max_backoff: 10s
+`,
+			want: &ClientConfig{
+				ConnectTimeout: 20 * time.Second,
+				RPCTimeout:     time.Minute,
+				Compression:    "zstd",
+				Retry: &RetryConfig{
+					MaxAttempts:    5,
+					InitialBackoff: 500 * time.Millisecond,
+					MaxBackoff:     10 * time.Second,
+				},
+			},
+		},
+		{
+			name: "disabled machines cache",
+			content: `client:
+  machines_cache_ttl: 0s
+`,
+			want: &ClientConfig{MachinesCacheTTL: new(time.Duration)},
+		},
+		{
+			name: "negative machines cache ttl",
+			content: `client:
+  machines_cache_ttl: -1s
+`,
+			wantErr: "machines_cache_ttl must not be negative",
+		},
+		{
+			name: "invalid max attempts",
+			content: `client:
+  retry:
+    max_attempts: 0
+`,
+			wantErr: "retry.max_attempts must be at least 1",
+		},
+		{
+			name: "invalid compression",
+			content: `client:
+  compression: lz4
+`,
+			wantErr: "unsupported compression 'lz4'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := NewFromFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, cfg.Client) {
+				t.Errorf("Expected client config %+v, got: %+v", tt.want, cfg.Client)
+			}
+		})
+	}
+}
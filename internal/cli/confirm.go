@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/cli/tui"
+)
+
+// AssumeYesEnv is the environment variable that auto-confirms all prompts like the global --yes flag.
+const AssumeYesEnv = "UNCLOUD_ASSUME_YES"
+
+// ErrNonInteractive is returned by Confirm when it can't prompt the user because stdin is not a terminal.
+var ErrNonInteractive = errors.New("cannot ask for confirmation in non-interactive mode, " +
+	"use --yes flag or set " + AssumeYesEnv + "=true to auto-confirm")
+
+// isStdinTerminal is a variable to allow overriding it in tests.
+var isStdinTerminal = tui.IsStdinTerminal
+
+// Confirm shows a confirmation prompt with the given title and returns whether the user confirmed it. It returns true
+// without prompting if AssumeYes is set. If stdin is not a terminal, e.g. when running in a script, it fails with
+// ErrNonInteractive instead of waiting for input that never comes.
+func (cli *CLI) Confirm(title string) (bool, error) {
+	if cli.AssumeYes {
+		return true, nil
+	}
+	if !isStdinTerminal() {
+		return false, WithExitCode(ExitCodeValidation, ErrNonInteractive)
+	}
+	return tui.Confirm(title)
+}
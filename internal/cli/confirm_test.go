@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Not parallel as it overrides the package-level isStdinTerminal.
+func TestConfirm_NonInteractive(t *testing.T) {
+	orig := isStdinTerminal
+	isStdinTerminal = func() bool { return false }
+	t.Cleanup(func() { isStdinTerminal = orig })
+
+	t.Run("assume yes", func(t *testing.T) {
+		confirmed, err := (&CLI{AssumeYes: true}).Confirm("")
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+	})
+
+	t.Run("fails without assume yes", func(t *testing.T) {
+		confirmed, err := (&CLI{}).Confirm("")
+		require.ErrorIs(t, err, ErrNonInteractive)
+		assert.False(t, confirmed)
+		assert.Equal(t, ExitCodeValidation, ExitCode(err))
+	})
+}
@@ -21,22 +21,27 @@ import (
 type ConnectOptions struct {
 	// Whether to show connection progress spinner if stdout is a terminal or progress logs if not.
 	ShowProgress bool
+	// Whether to show progress logs instead of the spinner even if stdout is a terminal.
+	PlainProgress bool
+	// Client configures the cluster client, for example, its timeouts and retry policy.
+	Client []client.Option
 }
 
 func ConnectCluster(ctx context.Context, conn config.MachineConnection, opts ConnectOptions) (*client.Client, error) {
 	if opts.ShowProgress {
-		return connectClusterWithProgress(ctx, conn)
+		return connectClusterWithProgress(ctx, conn, opts.PlainProgress, opts.Client)
 	}
-	return connectCluster(ctx, conn)
+	return connectCluster(ctx, conn, opts.Client)
 }
 
 // connectClusterWithProgress connects to the cluster while displaying a progress spinner.
-// If the stdout is not a terminal, it falls back to simple progress logs to stderr.
-func connectClusterWithProgress(ctx context.Context, conn config.MachineConnection) (*client.Client, error) {
-	// If stdout is not a terminal, fall back to simple progress logs.
-	if !tui.IsStdoutTerminal() {
+// If plain is set or the stdout is not a terminal, it falls back to simple progress logs to stderr.
+func connectClusterWithProgress(
+	ctx context.Context, conn config.MachineConnection, plain bool, clientOpts []client.Option,
+) (*client.Client, error) {
+	if plain || !tui.IsStdoutTerminal() {
 		fmt.Fprintln(os.Stderr, "Connecting to", conn.String())
-		cli, err := connectCluster(ctx, conn)
+		cli, err := connectCluster(ctx, conn, clientOpts)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, "Connection failed:", err)
 		} else {
@@ -46,7 +51,7 @@ func connectClusterWithProgress(ctx context.Context, conn config.MachineConnecti
 	}
 
 	// Run the connection TUI model.
-	p := tea.NewProgram(newConnectModel(ctx, conn))
+	p := tea.NewProgram(newConnectModel(ctx, conn, clientOpts))
 	model, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("run connection TUI: %w", err)
@@ -56,7 +61,9 @@ func connectClusterWithProgress(ctx context.Context, conn config.MachineConnecti
 	return m.result.client, m.result.err
 }
 
-func connectCluster(ctx context.Context, conn config.MachineConnection) (*client.Client, error) {
+func connectCluster(
+	ctx context.Context, conn config.MachineConnection, clientOpts []client.Option,
+) (*client.Client, error) {
 	// Determine which SSH type is configured.
 	var sshDest config.SSHDestination
 	var useGoSSH bool
@@ -77,9 +84,9 @@ func connectCluster(ctx context.Context, conn config.MachineConnection) (*client
 		sshDest = conn.SSHGo
 		useGoSSH = true
 	} else if conn.TCP != nil && conn.TCP.IsValid() {
-		return client.New(ctx, connector.NewTCPConnector(*conn.TCP))
+		return client.New(ctx, connector.NewTCPConnector(*conn.TCP), clientOpts...)
 	} else if conn.Unix != "" {
-		return client.New(ctx, connector.NewUnixConnector(conn.Unix))
+		return client.New(ctx, connector.NewUnixConnector(conn.Unix), clientOpts...)
 	} else {
 		return nil, errors.New("connection configuration is invalid")
 	}
@@ -101,16 +108,18 @@ func connectCluster(ctx context.Context, conn config.MachineConnection) (*client
 
 	// Create appropriate connector based on type.
 	if useGoSSH {
-		return client.New(ctx, connector.NewSSHConnector(sshConfig))
+		return client.New(ctx, connector.NewSSHConnector(sshConfig), clientOpts...)
 	}
-	return client.New(ctx, connector.NewSSHCLIConnector(sshConfig))
+	return client.New(ctx, connector.NewSSHCLIConnector(sshConfig), clientOpts...)
 }
 
 // connectModel is a TUI model for connecting to a cluster with a progress spinner.
 type connectModel struct {
-	ctx     context.Context
-	conn    config.MachineConnection
-	spinner spinner.Model
+	ctx  context.Context
+	conn config.MachineConnection
+	// clientOpts configure the cluster client.
+	clientOpts []client.Option
+	spinner    spinner.Model
 	// showSpinner controls whether the spinner is visible (delayed to avoid flashing).
 	showSpinner bool
 	// done indicates whether the connection attempt has completed (successfully or with error).
@@ -127,15 +136,16 @@ type connectResultMsg struct {
 // showSpinnerMsg is sent after a delay to show the spinner.
 type showSpinnerMsg struct{}
 
-func newConnectModel(ctx context.Context, conn config.MachineConnection) connectModel {
+func newConnectModel(ctx context.Context, conn config.MachineConnection, clientOpts []client.Option) connectModel {
 	s := spinner.New()
 	s.Spinner = spinner.MiniDot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Yellow) // the same yellow as in compose progress
 
 	return connectModel{
-		ctx:     ctx,
-		conn:    conn,
-		spinner: s,
+		ctx:        ctx,
+		conn:       conn,
+		clientOpts: clientOpts,
+		spinner:    s,
 	}
 }
 
@@ -149,7 +159,7 @@ func (m connectModel) Init() tea.Cmd {
 
 func (m connectModel) connect() tea.Cmd {
 	return func() tea.Msg {
-		cli, err := connectCluster(m.ctx, m.conn)
+		cli, err := connectCluster(m.ctx, m.conn, m.clientOpts)
 		return connectResultMsg{
 			client: cli,
 			err:    err,
@@ -1,5 +1,29 @@
 package cli
 
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes returned by the CLI to let scripts and CI pipelines tell failures apart.
+const (
+	// ExitCodeError is returned for any failure that doesn't have a more specific exit code.
+	ExitCodeError = 1
+	// ExitCodeValidation is returned when the command arguments, flags, or configuration such as a Compose file
+	// are invalid. Retrying the command without changing the input won't help.
+	ExitCodeValidation = 2
+	// ExitCodePartialFailure is returned when an operation on multiple targets, for example, machines or images,
+	// succeeded for some of them and failed for others.
+	ExitCodePartialFailure = 3
+	// ExitCodeConnection is returned when the CLI fails to connect to the cluster or loses the connection.
+	ExitCodeConnection = 4
+	// ExitCodeConflict is returned when an operation conflicts with the current state of the cluster, for example,
+	// a resource already exists or is locked by another operation.
+	ExitCodeConflict = 5
+)
+
 // CancelledError signals an interactive command was aborted by the user at a confirmation prompt.
 type CancelledError struct {
 	Message string
@@ -13,3 +37,61 @@ func (e *CancelledError) Error() string {
 func Cancelled(msg string) error {
 	return &CancelledError{Message: msg}
 }
+
+// ExitError is an error that sets the exit code of the CLI.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// WithExitCode wraps the error so that the CLI exits with the given code if the command fails with it.
+// It returns nil if err is nil.
+func WithExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitError{Code: code, Err: err}
+}
+
+// JoinTargetErrors joins the errors of an operation on the given number of targets. The joined error has
+// the ExitCodePartialFailure exit code if the operation succeeded for at least one target.
+func JoinTargetErrors(targets int, errs []error) error {
+	err := errors.Join(errs...)
+	if err == nil || len(errs) >= targets {
+		return err
+	}
+	return WithExitCode(ExitCodePartialFailure, err)
+}
+
+// ExitCode returns the exit code of the CLI for the error a command failed with. The code set explicitly with
+// WithExitCode takes precedence. Otherwise, the code is derived from the gRPC status of an error returned
+// by the cluster.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := errors.AsType[*ExitError](err); ok {
+		return exitErr.Code
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.InvalidArgument:
+			return ExitCodeValidation
+		case codes.Unavailable:
+			return ExitCodeConnection
+		case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+			return ExitCodeConflict
+		}
+	}
+
+	return ExitCodeError
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "generic", err: errors.New("boom"), want: ExitCodeError},
+		{
+			name: "explicit code",
+			err:  fmt.Errorf("deploy: %w", WithExitCode(ExitCodeValidation, errors.New("invalid"))),
+			want: ExitCodeValidation,
+		},
+		{
+			name: "explicit code takes precedence over gRPC status",
+			err:  WithExitCode(ExitCodePartialFailure, status.Error(codes.Unavailable, "down")),
+			want: ExitCodePartialFailure,
+		},
+		{
+			name: "invalid argument",
+			err:  fmt.Errorf("run: %w", status.Error(codes.InvalidArgument, "bad spec")),
+			want: ExitCodeValidation,
+		},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), want: ExitCodeConnection},
+		{name: "already exists", err: status.Error(codes.AlreadyExists, "exists"), want: ExitCodeConflict},
+		{name: "failed precondition", err: status.Error(codes.FailedPrecondition, "locked"), want: ExitCodeConflict},
+		{name: "not found", err: status.Error(codes.NotFound, "missing"), want: ExitCodeError},
+		{name: "cancelled", err: Cancelled("Cancelled."), want: ExitCodeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestJoinTargetErrors(t *testing.T) {
+	t.Parallel()
+
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	assert.NoError(t, JoinTargetErrors(2, nil))
+
+	err := JoinTargetErrors(3, []error{errA, errB})
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+	assert.Equal(t, ExitCodePartialFailure, ExitCode(err))
+
+	err = JoinTargetErrors(2, []error{errA, errB})
+	assert.EqualError(t, err, "a\nb")
+	assert.Equal(t, ExitCodeError, ExitCode(err))
+}
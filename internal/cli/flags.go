@@ -56,3 +56,30 @@ func ParseWireGuardEndpoints(values []string, defaultPort uint16) ([]*pb.IPPort,
 	}
 	return endpoints, nil
 }
+
+// AddFailFastFlags adds the mutually exclusive --fail-fast and --keep-going flags to a command that operates
+// on multiple targets. The command keeps going after a failure by default.
+func AddFailFastFlags(cmd *cobra.Command, failFast *bool) {
+	cmd.Flags().BoolVar(failFast, "fail-fast", false,
+		"Stop at the first target that fails and skip the remaining ones.")
+	cmd.Flags().Bool("keep-going", false,
+		"Continue with the remaining targets after a failure and report all failures at the end. (default)")
+	cmd.MarkFlagsMutuallyExclusive("fail-fast", "keep-going")
+}
+
+// WrapUsageErrors makes the argument and flag validation errors of the command and all its subcommands exit with
+// ExitCodeValidation.
+func WrapUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return WithExitCode(ExitCodeValidation, err)
+	})
+
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			return WithExitCode(ExitCodeValidation, args(cmd, a))
+		}
+	}
+	for _, c := range cmd.Commands() {
+		WrapUsageErrors(c)
+	}
+}
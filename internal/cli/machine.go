@@ -3,7 +3,6 @@ package cli
 import (
 	"context"
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -13,8 +12,11 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	machineprofile "github.com/psviderski/uncloud/internal/machine/profile"
 	"github.com/psviderski/uncloud/internal/sshexec"
 	"github.com/psviderski/uncloud/scripts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -30,7 +32,19 @@ type RemoteMachine struct {
 
 // installCmd returns a shell command that decodes the base64-encoded install script and pipes it
 // into bash, optionally via sudo and with UNCLOUD_* environment variables set.
-func installCmd(scriptBase64, user, version string) string {
+// installOptions configures the installation of the Uncloud daemon and dependencies on a remote machine.
+type installOptions struct {
+	// version of the Uncloud daemon to install. If empty, the latest version is installed.
+	version string
+	// profile is the resource profile passed to the install script to tune the Docker daemon configuration.
+	profile string
+	// bundlePath is the local path to an air-gapped installation bundle to install the machine from.
+	bundlePath string
+	// usernsRemap configures Docker with user namespace remapping when the install script installs Docker.
+	usernsRemap bool
+}
+
+func installCmd(scriptBase64, user, bundleDir string, opts installOptions) string {
 	sudoPrefix := ""
 	var env []string
 
@@ -39,8 +53,17 @@ func installCmd(scriptBase64, user, version string) string {
 		sudoPrefix = "sudo "
 		env = append(env, "UNCLOUD_GROUP_ADD_USER="+sshexec.Quote(user))
 	}
-	if version != "" {
-		env = append(env, "UNCLOUD_VERSION="+sshexec.Quote(version))
+	// The version of the binaries installed from a bundle is defined by the bundle.
+	if bundleDir != "" {
+		env = append(env, "UNCLOUD_BUNDLE_DIR="+sshexec.Quote(bundleDir))
+	} else if opts.version != "" {
+		env = append(env, "UNCLOUD_VERSION="+sshexec.Quote(opts.version))
+	}
+	if opts.profile != "" && opts.profile != machineprofile.Default {
+		env = append(env, "UNCLOUD_PROFILE="+sshexec.Quote(opts.profile))
+	}
+	if opts.usernsRemap {
+		env = append(env, "UNCLOUD_USERNS_REMAP=true")
 	}
 
 	envPrefix := ""
@@ -52,8 +75,10 @@ func installCmd(scriptBase64, user, version string) string {
 }
 
 // provisionMachine provisions the remote machine by running the Uncloud install script embedded in the uc CLI.
-// If version is specified, it will be passed to the install script as UNCLOUD_VERSION environment variable.
-func provisionMachine(ctx context.Context, exec sshexec.Executor, version string) error {
+// The options are passed to the install script as environment variables. If opts.bundlePath is specified,
+// the air-gapped installation bundle is uploaded to the machine and the binaries and images are installed from it
+// instead of being downloaded.
+func provisionMachine(ctx context.Context, exec sshexec.Executor, opts installOptions) error {
 	user, err := exec.Run(ctx, "whoami")
 	if err != nil {
 		return fmt.Errorf("run whoami: %w", err)
@@ -90,19 +115,95 @@ func provisionMachine(ctx context.Context, exec sshexec.Executor, version string
 		}
 	}
 
+	bundleDir := ""
+	if opts.bundlePath != "" {
+		if bundleDir, err = uploadBundle(ctx, exec, opts.bundlePath); err != nil {
+			return err
+		}
+		defer func() {
+			// Use a new context to clean up even if the provisioning was cancelled.
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_, _ = exec.Run(cleanupCtx, sshexec.QuoteCommand("rm", "-rf", bundleDir))
+		}()
+	}
+
 	scriptBase64 := base64.StdEncoding.EncodeToString([]byte(scripts.InstallScript))
-	cmd := sshexec.QuoteCommand("bash", "-c", "set -o pipefail; "+installCmd(scriptBase64, user, version))
+	cmd := sshexec.QuoteCommand("bash", "-c",
+		"set -o pipefail; "+installCmd(scriptBase64, user, bundleDir, opts))
 	if err = exec.Stream(ctx, cmd, os.Stdout, os.Stderr); err != nil {
 		return fmt.Errorf("run install script: %w", err)
 	}
 	return nil
 }
 
+// uploadBundle uploads the bundle file to a new temporary directory on the machine and extracts it there.
+// It returns the path to the directory.
+func uploadBundle(ctx context.Context, exec sshexec.Executor, bundlePath string) (string, error) {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return "", fmt.Errorf("open bundle: %w", err)
+	}
+	defer f.Close()
+
+	dir, err := exec.Run(ctx, "mktemp -d /tmp/uncloud-bundle.XXXXXX")
+	if err != nil {
+		return "", fmt.Errorf("create temporary directory for bundle: %w", err)
+	}
+
+	fmt.Printf("Uploading bundle %s to the machine...\n", bundlePath)
+	if _, err = exec.RunWithInput(ctx, sshexec.QuoteCommand("tar", "-xzf", "-", "-C", dir), f); err != nil {
+		_, _ = exec.Run(ctx, sshexec.QuoteCommand("rm", "-rf", dir))
+		return "", fmt.Errorf("upload bundle: %w", err)
+	}
+	return dir, nil
+}
+
+// checkPrerequisites runs the host prerequisite checks on the machine and prints their results. It returns an error
+// if any check failed.
+func checkPrerequisites(ctx context.Context, machineClient pb.MachineClient, wgPort int) error {
+	resp, err := machineClient.CheckPrerequisites(ctx, &pb.CheckPrerequisitesRequest{WireguardPort: int32(wgPort)})
+	if err != nil {
+		// TODO(lhf): remove Unimplemented check when v0.9.0 is released.
+		if status.Convert(err).Code() == codes.Unimplemented {
+			return nil
+		}
+		return fmt.Errorf("check machine prerequisites: %w", err)
+	}
+
+	if len(resp.Checks) > 0 {
+		fmt.Println("Machine prerequisites:")
+		for _, c := range resp.Checks {
+			fmt.Println(formatPrerequisiteCheck(c))
+		}
+		fmt.Println()
+	}
+	if !resp.Satisfied {
+		return fmt.Errorf("machine prerequisites not satisfied: %s\n"+
+			"Fix the problems and try again or use --skip-checks to proceed anyway", resp.Error)
+	}
+	return nil
+}
+
+func formatPrerequisiteCheck(c *pb.PrerequisiteCheck) string {
+	var symbol string
+	switch c.Status {
+	case pb.PrerequisiteCheck_OK:
+		symbol = tui.Green.Render("✓")
+	case pb.PrerequisiteCheck_WARNING:
+		symbol = tui.Yellow.Render("!")
+	case pb.PrerequisiteCheck_FAILED:
+		symbol = tui.Red.Render("✗")
+	default:
+		symbol = tui.Faint.Render("-")
+	}
+	return fmt.Sprintf(" %s %s: %s", symbol, c.Name, c.Message)
+}
+
 func promptResetMachine() error {
 	if !tui.IsStdinTerminal() {
-		return errors.New("the remote machine is already initialised as a cluster member; " +
-			"cannot ask to confirm reset in non-interactive mode, " +
-			"use --yes flag or set UNCLOUD_AUTO_CONFIRM=true to auto-confirm")
+		return WithExitCode(ExitCodeValidation, fmt.Errorf(
+			"the remote machine is already initialised as a cluster member; %w", ErrNonInteractive))
 	}
 
 	fmt.Println(tui.Red.Render("The remote machine is already initialised as a cluster member. Resetting it will:\n" +
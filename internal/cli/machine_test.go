@@ -10,10 +10,13 @@ func TestInstallCmd(t *testing.T) {
 	const scriptB64 = "SCRIPT_BASE64_PLACEHOLDER"
 
 	tests := []struct {
-		name    string
-		user    string
-		version string
-		want    string
+		name        string
+		user        string
+		version     string
+		profile     string
+		bundleDir   string
+		usernsRemap bool
+		want        string
 	}{
 		{
 			name: "root",
@@ -38,11 +41,40 @@ func TestInstallCmd(t *testing.T) {
 			want: "printf '%s' SCRIPT_BASE64_PLACEHOLDER | base64 -d | " +
 				"sudo UNCLOUD_GROUP_ADD_USER=nonroot UNCLOUD_VERSION=v1.2.3 bash",
 		},
+		{
+			name:    "root with default profile",
+			user:    "root",
+			profile: "default",
+			want:    "printf '%s' SCRIPT_BASE64_PLACEHOLDER | base64 -d | bash",
+		},
+		{
+			name:    "nonroot with version and small profile",
+			user:    "nonroot",
+			version: "v1.2.3",
+			profile: "small",
+			want: "printf '%s' SCRIPT_BASE64_PLACEHOLDER | base64 -d | " +
+				"sudo UNCLOUD_GROUP_ADD_USER=nonroot UNCLOUD_VERSION=v1.2.3 UNCLOUD_PROFILE=small bash",
+		},
+		{
+			name:      "nonroot with bundle ignores version",
+			user:      "nonroot",
+			version:   "latest",
+			bundleDir: "/tmp/uncloud-bundle.abc123",
+			want: "printf '%s' SCRIPT_BASE64_PLACEHOLDER | base64 -d | " +
+				"sudo UNCLOUD_GROUP_ADD_USER=nonroot UNCLOUD_BUNDLE_DIR=/tmp/uncloud-bundle.abc123 bash",
+		},
+		{
+			name:        "root with userns remap",
+			user:        "root",
+			usernsRemap: true,
+			want:        "printf '%s' SCRIPT_BASE64_PLACEHOLDER | base64 -d | UNCLOUD_USERNS_REMAP=true bash",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, installCmd(scriptB64, tt.user, tt.version))
+			opts := installOptions{version: tt.version, profile: tt.profile, usernsRemap: tt.usernsRemap}
+			assert.Equal(t, tt.want, installCmd(scriptB64, tt.user, tt.bundleDir, opts))
 		})
 	}
 }
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/docker/cli/cli/streams"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+)
+
+// Progress output modes for long-running operations such as deploying services or pulling images.
+const (
+	// ProgressAuto renders the progress as ProgressTTY if stdout is a terminal and as ProgressPlain otherwise.
+	ProgressAuto = "auto"
+	// ProgressTTY renders the progress with spinners that are updated in place.
+	ProgressTTY = "tty"
+	// ProgressPlain prints each progress event on a new line without colors which is readable in CI logs.
+	ProgressPlain = "plain"
+	// ProgressQuiet doesn't print progress events.
+	ProgressQuiet = "quiet"
+)
+
+// ProgressModes are the supported values of the global --progress flag.
+var ProgressModes = []string{ProgressAuto, ProgressTTY, ProgressPlain, ProgressQuiet}
+
+// SetProgressMode sets how the progress of long-running operations is displayed. It's set with the global
+// --progress flag.
+func (cli *CLI) SetProgressMode(mode string) error {
+	if !slices.Contains(ProgressModes, mode) {
+		return WithExitCode(ExitCodeValidation, fmt.Errorf("invalid --progress value '%s', must be one of: %s",
+			mode, strings.Join(ProgressModes, ", ")))
+	}
+	cli.progressMode = mode
+	// The progress writer from Docker Compose used by the commands reads the mode from its global variable.
+	progress.Mode = mode
+	return nil
+}
+
+// ProgressMode returns the progress mode resolving ProgressAuto to ProgressTTY or ProgressPlain depending on whether
+// stdout is a terminal.
+func (cli *CLI) ProgressMode() string {
+	if cli.progressMode == ProgressAuto || cli.progressMode == "" {
+		if tui.IsStdoutTerminal() {
+			return ProgressTTY
+		}
+		return ProgressPlain
+	}
+	return cli.progressMode
+}
+
+// ProgressOut returns an output stream for progress writer.
+func (cli *CLI) ProgressOut() *streams.Out {
+	if cli.ProgressMode() == ProgressPlain {
+		// Strip the styles from the event IDs and statuses so that each line is readable as plain text.
+		return streams.NewOut(&colorprofile.Writer{Forward: os.Stdout, Profile: colorprofile.NoTTY})
+	}
+	return streams.NewOut(os.Stdout)
+}
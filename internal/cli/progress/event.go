@@ -48,6 +48,11 @@ func OldPreDeployHookEventID(serviceName, containerID, machineName string) strin
 		tui.Faint.Render(" on ") + machineName
 }
 
+// DependencyEventID returns a progress event ID for waiting for a service dependency.
+func DependencyEventID(serviceName string) string {
+	return tui.Faint.Render("Service ") + serviceName
+}
+
 // ImageEventID returns a progress event ID for image pull operations.
 func ImageEventID(image, machineName string) string {
 	return tui.Faint.Render("Image ") + image +
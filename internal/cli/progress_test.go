@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Not parallel as SetProgressMode sets the global progress mode of Docker Compose.
+func TestSetProgressMode(t *testing.T) {
+	orig := progress.Mode
+	t.Cleanup(func() { progress.Mode = orig })
+
+	for _, mode := range []string{ProgressTTY, ProgressPlain, ProgressQuiet} {
+		cli := &CLI{}
+		require.NoError(t, cli.SetProgressMode(mode))
+		assert.Equal(t, mode, cli.ProgressMode())
+		assert.Equal(t, mode, progress.Mode)
+	}
+
+	err := (&CLI{}).SetProgressMode("json")
+	require.ErrorContains(t, err, "invalid --progress value 'json'")
+	assert.Equal(t, ExitCodeValidation, ExitCode(err))
+}
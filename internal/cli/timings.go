@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/client/timing"
+	"github.com/spf13/cobra"
+)
+
+// AddTimingsFlag adds the --timings flag to the command.
+func AddTimingsFlag(cmd *cobra.Command, timings *bool) {
+	cmd.Flags().BoolVar(timings, "timings", false,
+		"Print a breakdown of where time was spent per machine, e.g. connecting, pulling images, creating "+
+			"containers,\nand waiting for them to become healthy. Timings are only printed and never sent anywhere.")
+}
+
+// WithTimings returns a context that records the timings of client operations if enabled and a function that prints
+// the timings report. The report is printed even if the command fails to help diagnose slow or stuck operations.
+func WithTimings(ctx context.Context, enabled bool) (context.Context, func()) {
+	if !enabled {
+		return ctx, func() {}
+	}
+
+	r := timing.NewRecorder()
+	start := time.Now()
+	return timing.WithRecorder(ctx, r), func() {
+		PrintTimings(r, time.Since(start))
+	}
+}
+
+// PrintTimings prints the timings recorded by the recorder as a table.
+func PrintTimings(r *timing.Recorder, total time.Duration) {
+	fmt.Println()
+	fmt.Println(tui.Bold.Underline(true).Render("Timings"))
+	fmt.Println()
+
+	t := tui.NewTable()
+	t.Headers("MACHINE", "PHASE", "COUNT", "TOTAL", "MAX")
+	for _, s := range r.Summaries() {
+		machine := s.Machine
+		if machine == "" {
+			machine = tui.Faint.Render("(cluster)")
+		}
+		t.Row(machine, s.Phase, fmt.Sprintf("%d", s.Count), formatTiming(s.Total), formatTiming(s.Max))
+	}
+	fmt.Println(t)
+	fmt.Println()
+	fmt.Printf("Total time: %s\n", formatTiming(total))
+}
+
+func formatTiming(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(10 * time.Millisecond).String()
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// watchRenderDelay is how long to wait for more cluster events after an event before re-rendering the output.
+	// It coalesces bursts of events, for example, during a deployment into a single render.
+	watchRenderDelay = 500 * time.Millisecond
+	// clearScreen moves the cursor to the top left corner and clears the terminal screen.
+	clearScreen = "\033[H\033[2J"
+)
+
+// RenderFunc writes the output of a command to w.
+type RenderFunc func(ctx context.Context, w io.Writer) error
+
+// Watch renders the output of a command and re-renders it each time the machines or containers in the cluster
+// change until the context is cancelled. The changes are streamed from the connected machine rather than polled.
+func Watch(ctx context.Context, c *client.Client, render RenderFunc) error {
+	events, err := c.WatchEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("watch cluster events: %w", err)
+	}
+
+	draw := func() error {
+		var buf bytes.Buffer
+		if err := render(ctx, &buf); err != nil {
+			return err
+		}
+		fmt.Fprint(os.Stdout, clearScreen)
+		fmt.Fprintln(os.Stdout, tui.Faint.Render(fmt.Sprintf(
+			"Watching cluster changes, updated at %s. Press Ctrl+C to exit.", time.Now().Format(time.TimeOnly))))
+		fmt.Fprintln(os.Stdout)
+		_, err := buf.WriteTo(os.Stdout)
+		return err
+	}
+	if err = draw(); err != nil {
+		return err
+	}
+
+	var rerender <-chan time.Time
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if e.Err != nil {
+				if status.Code(e.Err) == codes.Unimplemented {
+					return errors.New("the connected machine doesn't support watching cluster events, " +
+						"upgrade it to the latest version")
+				}
+				return fmt.Errorf("watch cluster events: %w", e.Err)
+			}
+			if rerender == nil {
+				rerender = time.After(watchRenderDelay)
+			}
+		case <-rerender:
+			rerender = nil
+			if err = draw(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -4,18 +4,46 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	systemd "github.com/coreos/go-systemd/daemon"
+	"github.com/psviderski/uncloud/internal/grpccompress"
 	"github.com/psviderski/uncloud/internal/machine"
+	"github.com/psviderski/uncloud/internal/machine/throttle"
 )
 
 type Daemon struct {
 	machine *machine.Machine
 }
 
-func New(dataDir string) (*Daemon, error) {
+// Options configures the daemon.
+type Options struct {
+	// GRPCCompression is the compression for API requests proxied to other machines.
+	GRPCCompression string
+	// UnsafeFaultInjection allows injecting faults into the daemon through the machine API for failure testing.
+	UnsafeFaultInjection bool
+	// PolicyFile is the path to the admission policy file. Default is <data-dir>/policy.yaml.
+	PolicyFile string
+	// MetricsAddr is the address to serve Prometheus metrics on. Metrics aren't served if empty.
+	MetricsAddr string
+	// Throttle limits the API request rate of each client and the number of concurrent heavy operations.
+	Throttle throttle.Config
+}
+
+func New(dataDir string, opts Options) (*Daemon, error) {
+	if err := grpccompress.Validate(opts.GRPCCompression); err != nil {
+		return nil, err
+	}
+	if err := opts.Throttle.Validate(); err != nil {
+		return nil, err
+	}
 	config := &machine.Config{
-		DataDir: dataDir,
+		DataDir:         dataDir,
+		GRPCCompression: opts.GRPCCompression,
+		FaultInjection:  opts.UnsafeFaultInjection,
+		PolicyFile:      opts.PolicyFile,
+		MetricsAddr:     opts.MetricsAddr,
+		Throttle:        opts.Throttle,
 	}
 	mach, err := machine.NewMachine(config)
 	if err != nil {
@@ -30,17 +58,71 @@ func New(dataDir string) (*Daemon, error) {
 func (d *Daemon) Run(ctx context.Context) error {
 	slog.Info("Starting machine.")
 
-	// Notify systemd that the daemon is ready when the machine is started.
-	go func() {
+	go d.notifySystemd(ctx)
+	go d.runWatchdog(ctx)
+
+	err := d.machine.Run(ctx)
+	notify(systemd.SdNotifyStopping)
+	return err
+}
+
+// notifySystemd reports the daemon readiness and status to systemd if the daemon runs as a Type=notify service.
+// The daemon is ready once the machine API is serving requests, so a join or init script can wait for it with
+// 'systemctl start' before calling the API. The status shows if the machine has joined a cluster, for example,
+// in 'systemctl status uncloud'.
+func (d *Daemon) notifySystemd(ctx context.Context) {
+	select {
+	case <-d.machine.Started():
+	case <-ctx.Done():
+		return
+	}
+
+	status := "Machine API is ready. Waiting for the machine to initialise or join a cluster."
+	if d.machine.Initialised() {
+		status = "Machine API is ready. Starting cluster components."
+	}
+	notify(systemd.SdNotifyReady + "\nSTATUS=" + status)
+
+	select {
+	case <-d.machine.ClusterReady():
+		notify("STATUS=Machine is running as a cluster member.")
+	case <-ctx.Done():
+	}
+}
+
+// runWatchdog pings the systemd watchdog if it's enabled with WatchdogSec in the unit. Before each ping, it checks
+// that the machine state is accessible. If the daemon deadlocks, the pings stop and systemd restarts it.
+func (d *Daemon) runWatchdog(ctx context.Context) {
+	interval, err := systemd.SdWatchdogEnabled(false)
+	if err != nil {
+		slog.Error("Failed to check if systemd watchdog is enabled.", "err", err)
+		return
+	}
+	if interval == 0 {
+		return
+	}
+	slog.Info("Systemd watchdog is enabled.", "timeout", interval)
+
+	// Ping at half the timeout as recommended by systemd to tolerate scheduling delays.
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
 		select {
-		case <-d.machine.Started():
-			_, err := systemd.SdNotify(false, systemd.SdNotifyReady)
-			if err != nil {
-				slog.Error("Failed to notify systemd that the daemon is ready.", "err", err)
+		case <-ticker.C:
+			// Blocks if the machine state lock is held forever.
+			d.machine.Initialised()
+			if d.machine.Faults().DropHeartbeat() {
+				continue
 			}
+			notify(systemd.SdNotifyWatchdog)
 		case <-ctx.Done():
+			return
 		}
-	}()
+	}
+}
 
-	return d.machine.Run(ctx)
+func notify(state string) {
+	if _, err := systemd.SdNotify(false, state); err != nil {
+		slog.Error("Failed to notify systemd.", "state", state, "err", err)
+	}
 }
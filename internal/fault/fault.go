@@ -0,0 +1,178 @@
+// Package fault injects failures into a machine daemon at runtime, such as a hung daemon, a slow API, or a broken
+// image registry. It's meant for testing how reconcilers and the CLI handle failures on a real cluster without
+// breaking the machines by hand. Faults can only be injected if the daemon runs with --unsafe-fault-injection.
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DropHeartbeats stops the daemon from pinging the systemd watchdog and from sending heartbeats in log streams.
+	// Systemd restarts the daemon when the watchdog timeout expires as if the daemon hung.
+	DropHeartbeats = "drop-heartbeats"
+	// DelayRPC delays the machine API requests served by the daemon.
+	DelayRPC = "delay-rpc"
+	// FailImagePull fails the image pulls on the machine as if the registry were unavailable.
+	FailImagePull = "fail-image-pull"
+)
+
+// Kinds are all supported fault kinds.
+var Kinds = []string{DropHeartbeats, DelayRPC, FailImagePull}
+
+// ErrDisabled is returned when injecting a fault into a daemon that doesn't allow fault injection.
+var ErrDisabled = errors.New("fault injection is disabled, run the daemon with --unsafe-fault-injection to enable it")
+
+// Fault is a failure injected into the daemon.
+type Fault struct {
+	Kind string
+	// Match limits the fault to the API methods (DelayRPC) or images (FailImagePull) that contain the substring.
+	// Empty matches all.
+	Match string
+	// Delay is how long to delay the matching API requests for the DelayRPC fault.
+	Delay time.Duration
+	// Expires is when the fault is removed automatically. Zero means the fault stays until cleared.
+	Expires time.Time
+}
+
+func (f Fault) Validate() error {
+	if !slices.Contains(Kinds, f.Kind) {
+		return fmt.Errorf("invalid fault kind '%s', must be one of: %s", f.Kind, strings.Join(Kinds, ", "))
+	}
+	switch f.Kind {
+	case DropHeartbeats:
+		if f.Match != "" {
+			return fmt.Errorf("match is not supported for fault '%s'", f.Kind)
+		}
+	case DelayRPC:
+		if f.Delay <= 0 {
+			return fmt.Errorf("delay must be positive for fault '%s'", f.Kind)
+		}
+	}
+	if f.Kind != DelayRPC && f.Delay != 0 {
+		return fmt.Errorf("delay is not supported for fault '%s'", f.Kind)
+	}
+	return nil
+}
+
+// Injector holds the faults injected into the daemon. Its methods are safe for concurrent use and a nil Injector
+// has no faults.
+type Injector struct {
+	enabled bool
+	mu      sync.RWMutex
+	// faults are the injected faults by kind. A new fault replaces the one of the same kind.
+	faults map[string]Fault
+	now    func() time.Time
+}
+
+// NewInjector creates an injector. If enabled is false, faults can't be injected.
+func NewInjector(enabled bool) *Injector {
+	return &Injector{
+		enabled: enabled,
+		faults:  make(map[string]Fault),
+		now:     time.Now,
+	}
+}
+
+// Enabled returns true if faults can be injected.
+func (i *Injector) Enabled() bool {
+	return i != nil && i.enabled
+}
+
+// Inject adds the fault replacing the active fault of the same kind.
+func (i *Injector) Inject(f Fault) error {
+	if !i.Enabled() {
+		return ErrDisabled
+	}
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults[f.Kind] = f
+	return nil
+}
+
+// Clear removes the fault of the given kind or all faults if kind is empty.
+func (i *Injector) Clear(kind string) {
+	if i == nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if kind == "" {
+		clear(i.faults)
+	} else {
+		delete(i.faults, kind)
+	}
+}
+
+// List returns the active faults sorted by kind.
+func (i *Injector) List() []Fault {
+	if i == nil {
+		return nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	var faults []Fault
+	for _, f := range i.faults {
+		if i.isActive(f) {
+			faults = append(faults, f)
+		}
+	}
+	slices.SortFunc(faults, func(a, b Fault) int {
+		return strings.Compare(a.Kind, b.Kind)
+	})
+	return faults
+}
+
+func (i *Injector) isActive(f Fault) bool {
+	return f.Expires.IsZero() || i.now().Before(f.Expires)
+}
+
+// active returns the fault of the given kind if it's injected and not expired.
+func (i *Injector) active(kind string) (Fault, bool) {
+	if i == nil {
+		return Fault{}, false
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	f, ok := i.faults[kind]
+	if !ok || !i.isActive(f) {
+		return Fault{}, false
+	}
+	return f, true
+}
+
+// DropHeartbeat returns true if the daemon should skip sending a heartbeat.
+func (i *Injector) DropHeartbeat() bool {
+	_, ok := i.active(DropHeartbeats)
+	return ok
+}
+
+// RPCDelay returns how long to delay the API request with the given full method name.
+func (i *Injector) RPCDelay(method string) time.Duration {
+	f, ok := i.active(DelayRPC)
+	if !ok || !strings.Contains(method, f.Match) {
+		return 0
+	}
+	return f.Delay
+}
+
+// ImagePullError returns an error if pulling the image should fail.
+func (i *Injector) ImagePullError(image string) error {
+	f, ok := i.active(FailImagePull)
+	if !ok || !strings.Contains(image, f.Match) {
+		return nil
+	}
+	return fmt.Errorf("pull image '%s': injected fault '%s'", image, FailImagePull)
+}
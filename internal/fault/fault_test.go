@@ -0,0 +1,133 @@
+package fault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestFaultValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fault   Fault
+		wantErr string
+	}{
+		{name: "drop heartbeats", fault: Fault{Kind: DropHeartbeats}},
+		{name: "delay rpc", fault: Fault{Kind: DelayRPC, Delay: time.Second, Match: "Docker/"}},
+		{name: "fail image pull", fault: Fault{Kind: FailImagePull, Match: "nginx"}},
+		{name: "unknown kind", fault: Fault{Kind: "crash"}, wantErr: "invalid fault kind 'crash'"},
+		{name: "delay rpc without delay", fault: Fault{Kind: DelayRPC}, wantErr: "delay must be positive"},
+		{
+			name:    "delay for image pull",
+			fault:   Fault{Kind: FailImagePull, Delay: time.Second},
+			wantErr: "delay is not supported",
+		},
+		{
+			name:    "match for heartbeats",
+			fault:   Fault{Kind: DropHeartbeats, Match: "x"},
+			wantErr: "match is not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.fault.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInjector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		i := NewInjector(false)
+		assert.ErrorIs(t, i.Inject(Fault{Kind: DropHeartbeats}), ErrDisabled)
+		assert.False(t, i.DropHeartbeat())
+	})
+
+	t.Run("nil has no faults", func(t *testing.T) {
+		t.Parallel()
+		var i *Injector
+		assert.False(t, i.Enabled())
+		assert.False(t, i.DropHeartbeat())
+		assert.Zero(t, i.RPCDelay("/api.Docker/PullImage"))
+		assert.NoError(t, i.ImagePullError("nginx"))
+		assert.Empty(t, i.List())
+	})
+
+	t.Run("inject and clear", func(t *testing.T) {
+		t.Parallel()
+		i := NewInjector(true)
+		require.NoError(t, i.Inject(Fault{Kind: DropHeartbeats}))
+		require.NoError(t, i.Inject(Fault{Kind: DelayRPC, Delay: time.Second, Match: "/api.Docker/"}))
+		require.NoError(t, i.Inject(Fault{Kind: FailImagePull, Match: "nginx"}))
+
+		assert.True(t, i.DropHeartbeat())
+		assert.Equal(t, time.Second, i.RPCDelay("/api.Docker/CreateContainer"))
+		assert.Zero(t, i.RPCDelay("/api.Machine/InspectMachine"))
+		assert.ErrorContains(t, i.ImagePullError("nginx:1.27"), "injected fault")
+		assert.NoError(t, i.ImagePullError("redis"))
+		assert.Equal(t, []string{DelayRPC, DropHeartbeats, FailImagePull}, kinds(i.List()))
+
+		i.Clear(DelayRPC)
+		assert.Zero(t, i.RPCDelay("/api.Docker/CreateContainer"))
+		assert.Equal(t, []string{DropHeartbeats, FailImagePull}, kinds(i.List()))
+
+		i.Clear("")
+		assert.Empty(t, i.List())
+		assert.False(t, i.DropHeartbeat())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		i := NewInjector(true)
+		i.now = func() time.Time { return now }
+		require.NoError(t, i.Inject(Fault{Kind: DropHeartbeats, Expires: now.Add(time.Minute)}))
+		assert.True(t, i.DropHeartbeat())
+
+		now = now.Add(time.Minute)
+		assert.False(t, i.DropHeartbeat())
+		assert.Empty(t, i.List())
+	})
+}
+
+func TestInjectorUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	i := NewInjector(true)
+	require.NoError(t, i.Inject(Fault{Kind: DelayRPC, Delay: time.Hour}))
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	// Fault requests are never delayed.
+	resp, err := i.UnaryServerInterceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/api.Machine/ClearFaults"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = i.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/api.Machine/Token"}, handler)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func kinds(faults []Fault) []string {
+	var k []string
+	for _, f := range faults {
+		k = append(k, f.Kind)
+	}
+	return k
+}
@@ -0,0 +1,49 @@
+package fault
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor delays the API requests matching the DelayRPC fault.
+func (i *Injector) UnaryServerInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	if err := i.delay(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor delays the streaming API requests matching the DelayRPC fault.
+func (i *Injector) StreamServerInterceptor(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	if err := i.delay(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (i *Injector) delay(ctx context.Context, method string) error {
+	// Never delay the fault injection requests so that a fault can always be cleared.
+	if strings.Contains(method, "Fault") {
+		return nil
+	}
+	d := i.RPCDelay(method)
+	if d == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,42 @@
+// Package grpccompress registers the gzip and zstd compressors for gRPC and provides helpers to enable compression
+// on client connections. Compression trades CPU for bandwidth on metered or slow links between machines.
+// Importing the package registers the compressors, so both the client and the server must import it to be able to
+// decompress the messages compressed by the other side.
+package grpccompress
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+const (
+	// None disables compression.
+	None = "none"
+	// Gzip is widely supported but slower than zstd.
+	Gzip = gzip.Name
+	// Zstd compresses faster than gzip with a similar ratio.
+	Zstd = "zstd"
+)
+
+// Names is the list of supported compression names.
+var Names = []string{None, Gzip, Zstd}
+
+// Validate checks if the compression name is supported. An empty name is equivalent to None.
+func Validate(name string) error {
+	if name != "" && !slices.Contains(Names, name) {
+		return fmt.Errorf("unsupported compression '%s', supported values: %v", name, Names)
+	}
+	return nil
+}
+
+// CallOptions returns the gRPC call options to compress requests with the named compressor. The server compresses
+// its responses with the same compressor. It returns nil if the compression is disabled.
+func CallOptions(name string) []grpc.CallOption {
+	if name == "" || name == None {
+		return nil
+	}
+	return []grpc.CallOption{grpc.UseCompressor(name)}
+}
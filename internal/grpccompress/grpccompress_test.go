@@ -0,0 +1,61 @@
+package grpccompress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressors(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(strings.Repeat("2025-06-01T12:00:00Z INFO Request served. status=200\n", 1000))
+
+	for _, name := range []string{Gzip, Zstd} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := encoding.GetCompressor(name)
+			require.NotNil(t, c, "compressor must be registered")
+
+			// Run several times to reuse the pooled encoders and decoders.
+			for range 3 {
+				var buf bytes.Buffer
+				w, err := c.Compress(&buf)
+				require.NoError(t, err)
+				_, err = w.Write(msg)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+				assert.Less(t, buf.Len(), len(msg)/10)
+
+				r, err := c.Decompress(&buf)
+				require.NoError(t, err)
+				got, err := io.ReadAll(r)
+				require.NoError(t, err)
+				assert.Equal(t, msg, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"", None, Gzip, Zstd} {
+		assert.NoError(t, Validate(name), name)
+	}
+	assert.Error(t, Validate("lz4"))
+}
+
+func TestCallOptions(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, CallOptions(""))
+	assert.Nil(t, CallOptions(None))
+	assert.Len(t, CallOptions(Zstd), 1)
+}
@@ -97,7 +97,7 @@ func checkClientVersionHeaders(ctx context.Context) error {
 			currentVersion, requiredMinServer, ReleaseURL)
 	}
 
-	return nil
+	return checkClientVersionSkew(md)
 }
 
 func ServerUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
@@ -121,10 +121,7 @@ func ServerStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamSer
 }
 
 func ClientUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-	ctx = metadata.AppendToOutgoingContext(ctx,
-		MetadataKeyClientVersion, currentVersion.String(),
-		MetadataKeyMinServerVersion, MinServerVersion,
-	)
+	ctx = metadata.AppendToOutgoingContext(ctx, outgoingVersionPairs()...)
 
 	// TODO: Remove when checkServerVersionInResponse is no longer needed,
 	// as we'll no longer need to extract headers from the response here.
@@ -138,6 +135,7 @@ func ClientUnaryInterceptor(ctx context.Context, method string, req, reply any,
 
 	// TODO: Remove eventually (see note on method below).
 	checkServerVersionInResponse(respMD)
+	checkVersionSkewInResponse(respMD)
 
 	return nil
 }
@@ -160,10 +158,7 @@ func checkServerVersionInResponse(md metadata.MD) {
 }
 
 func ClientStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-	ctx = metadata.AppendToOutgoingContext(ctx,
-		MetadataKeyClientVersion, currentVersion.String(),
-		MetadataKeyMinServerVersion, MinServerVersion,
-	)
+	ctx = metadata.AppendToOutgoingContext(ctx, outgoingVersionPairs()...)
 
 	stream, err := streamer(ctx, desc, cc, method, opts...)
 	if err != nil {
@@ -189,6 +184,7 @@ func (s *versionedClientStream) Header() (metadata.MD, error) {
 	}
 
 	checkServerVersionInResponse(md)
+	checkVersionSkewInResponse(md)
 
 	return md, nil
 }
@@ -0,0 +1,119 @@
+package grpcversion
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Masterminds/semver"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MetadataKeyAllowVersionSkew is sent by the client to ask the daemon to accept requests even if their versions
+	// are too far apart.
+	MetadataKeyAllowVersionSkew = "uncloud-allow-version-skew"
+
+	// MaxMinorVersionSkew is the maximum difference between the minor versions of the client and daemon that
+	// is supported. The client warns about a supported skew and the daemon rejects requests with a larger skew
+	// unless the client explicitly allows it.
+	MaxMinorVersionSkew = 1
+)
+
+var (
+	// AllowVersionSkew makes the client ask the daemon to accept its requests regardless of the version skew.
+	// The CLI sets it with the --force-version-skew flag.
+	AllowVersionSkew atomic.Bool
+
+	// skewWarned tracks if we've already printed the version skew warning.
+	skewWarned atomic.Bool
+)
+
+// Skew describes how far apart two versions are.
+type Skew int
+
+const (
+	// SkewNone means the versions have the same major and minor versions or one of them is unknown.
+	SkewNone Skew = iota
+	// SkewSupported means the minor versions differ by no more than MaxMinorVersionSkew.
+	SkewSupported
+	// SkewUnsupported means the major versions differ or the minor versions differ by more than MaxMinorVersionSkew.
+	SkewUnsupported
+)
+
+// CheckSkew compares the two versions against the version skew policy. Unknown (zero) and development versions
+// are not checked.
+func CheckSkew(a, b *semver.Version) Skew {
+	if !checkable(a) || !checkable(b) {
+		return SkewNone
+	}
+	if a.Major() != b.Major() {
+		return SkewUnsupported
+	}
+
+	diff := a.Minor() - b.Minor()
+	if diff < 0 {
+		diff = -diff
+	}
+	switch {
+	case diff == 0:
+		return SkewNone
+	case diff <= MaxMinorVersionSkew:
+		return SkewSupported
+	default:
+		return SkewUnsupported
+	}
+}
+
+// CheckSkewString is like CheckSkew but parses the versions first. Invalid versions are treated as unknown.
+func CheckSkewString(a, b string) Skew {
+	return CheckSkew(parseVersionOrZero(a), parseVersionOrZero(b))
+}
+
+func checkable(v *semver.Version) bool {
+	return !v.Equal(zeroVersion) && !strings.HasSuffix(v.Prerelease(), "dev")
+}
+
+func checkClientVersionSkew(md metadata.MD) error {
+	if values := md.Get(MetadataKeyAllowVersionSkew); len(values) > 0 && values[0] == "true" {
+		return nil
+	}
+
+	clientVersion := extractVersion(md, MetadataKeyClientVersion)
+	if CheckSkew(clientVersion, currentVersion) == SkewUnsupported {
+		return status.Errorf(codes.FailedPrecondition,
+			"version check failed: client version %s and daemon version %s are more than %d minor version apart. Upgrade the CLI or daemon so their versions match, "+
+				"or use --force-version-skew to proceed anyway: %s",
+			clientVersion, currentVersion, MaxMinorVersionSkew, ReleaseURL)
+	}
+	return nil
+}
+
+// checkVersionSkewInResponse warns the user once if the daemon version differs from the client version.
+func checkVersionSkewInResponse(md metadata.MD) {
+	serverVersion := extractVersion(md, MetadataKeyServerVersion)
+	if CheckSkew(currentVersion, serverVersion) == SkewNone {
+		return
+	}
+	if skewWarned.Swap(true) {
+		return
+	}
+
+	fmt.Fprintf(WarnWriter, "WARNING: CLI version %s differs from daemon version %s. "+
+		"Keep the CLI and all machines on the same version to avoid compatibility issues: %s\n",
+		currentVersion, serverVersion, ReleaseURL)
+}
+
+// outgoingVersionPairs returns the version metadata key-value pairs the client sends with each request.
+func outgoingVersionPairs() []string {
+	kv := []string{
+		MetadataKeyClientVersion, currentVersion.String(),
+		MetadataKeyMinServerVersion, MinServerVersion,
+	}
+	if AllowVersionSkew.Load() {
+		kv = append(kv, MetadataKeyAllowVersionSkew, "true")
+	}
+	return kv
+}
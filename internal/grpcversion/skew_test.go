@@ -0,0 +1,91 @@
+package grpcversion
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckSkew(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want Skew
+	}{
+		{name: "same version", a: "0.21.0", b: "0.21.0", want: SkewNone},
+		{name: "different patch", a: "0.21.0", b: "0.21.3", want: SkewNone},
+		{name: "one minor older", a: "0.21.0", b: "0.20.5", want: SkewSupported},
+		{name: "one minor newer", a: "0.21.0", b: "0.22.0", want: SkewSupported},
+		{name: "two minors apart", a: "0.21.0", b: "0.23.0", want: SkewUnsupported},
+		{name: "different major", a: "1.0.0", b: "0.23.0", want: SkewUnsupported},
+		{name: "unknown version", a: "0.21.0", b: "", want: SkewNone},
+		{name: "invalid version", a: "nightly-abc", b: "0.10.0", want: SkewNone},
+		{name: "dev version", a: "999.0.0-dev", b: "0.10.0", want: SkewNone},
+		{name: "prerelease version", a: "0.23.0-rc.1", b: "0.21.0", want: SkewUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CheckSkewString(tt.a, tt.b))
+			assert.Equal(t, tt.want, CheckSkewString(tt.b, tt.a), "must be symmetric")
+		})
+	}
+}
+
+func setCurrentVersion(t *testing.T, v string) {
+	t.Helper()
+	old := currentVersion
+	currentVersion = semver.MustParse(v)
+	t.Cleanup(func() { currentVersion = old })
+}
+
+func TestCheckClientVersionSkew(t *testing.T) {
+	setCurrentVersion(t, "0.23.0")
+
+	assert.NoError(t, checkClientVersionSkew(metadata.Pairs(MetadataKeyClientVersion, "0.22.1")))
+	assert.NoError(t, checkClientVersionSkew(metadata.Pairs(
+		MetadataKeyClientVersion, "0.21.0",
+		MetadataKeyAllowVersionSkew, "true",
+	)))
+
+	err := checkClientVersionSkew(metadata.Pairs(MetadataKeyClientVersion, "0.21.0"))
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, err.Error(), "--force-version-skew")
+}
+
+func TestCheckVersionSkewInResponse_WarnOnce(t *testing.T) {
+	setCurrentVersion(t, "0.23.0")
+	skewWarned.Store(false)
+
+	output := captureWarnings(t, func() {
+		checkVersionSkewInResponse(metadata.Pairs(MetadataKeyServerVersion, "0.23.4"))
+	})
+	assert.Empty(t, output, "patch versions may differ")
+
+	md := metadata.Pairs(MetadataKeyServerVersion, "0.22.0")
+	output = captureWarnings(t, func() {
+		checkVersionSkewInResponse(md)
+	})
+	assert.Contains(t, output, "WARNING")
+
+	output = captureWarnings(t, func() {
+		checkVersionSkewInResponse(md)
+	})
+	assert.Empty(t, output, "second call should not warn")
+}
+
+func TestOutgoingVersionPairs(t *testing.T) {
+	md := metadata.Pairs(outgoingVersionPairs()...)
+	assert.Empty(t, md.Get(MetadataKeyAllowVersionSkew))
+
+	AllowVersionSkew.Store(true)
+	t.Cleanup(func() { AllowVersionSkew.Store(false) })
+	md = metadata.Pairs(outgoingVersionPairs()...)
+	assert.Equal(t, []string{"true"}, md.Get(MetadataKeyAllowVersionSkew))
+}
@@ -0,0 +1,644 @@
+package k8s
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// NameLabel is the label that selects the pods of an exported service.
+	NameLabel = "app.kubernetes.io/name"
+	// PartOfLabel is the label that identifies the project the exported objects belong to.
+	PartOfLabel = "app.kubernetes.io/part-of"
+
+	// defaultClaimSize is the storage size requested by the PersistentVolumeClaims created for named volumes.
+	// Docker volumes don't have a size so it's just a placeholder that should be adjusted.
+	defaultClaimSize = "1Gi"
+	// defaultConfigMode is the file mode Uncloud uses for config files when not specified.
+	defaultConfigMode = 0o444
+)
+
+// ExportResult is the result of converting Uncloud service specs to Kubernetes objects.
+type ExportResult struct {
+	Objects []runtime.Object
+	// Warnings describe the service features that were skipped or converted only partially.
+	Warnings []string
+}
+
+type exporter struct {
+	projectName string
+	configMaps  []runtime.Object
+	claims      []runtime.Object
+	objects     []runtime.Object
+	warnings    []string
+	// exported tracks the names of the ConfigMaps and PersistentVolumeClaims shared by services to export them once.
+	exported map[string]struct{}
+}
+
+// Export converts the Uncloud service specs to Deployments, DaemonSets, Services, Ingresses, ConfigMaps, and
+// PersistentVolumeClaims on a best-effort basis. Features that can't be converted are reported as warnings.
+// The objects don't specify a namespace so they can be applied to any namespace.
+func Export(projectName string, specs []api.ServiceSpec) (*ExportResult, error) {
+	ex := &exporter{
+		projectName: projectName,
+		exported:    make(map[string]struct{}),
+	}
+
+	for _, s := range specs {
+		spec := s.SetDefaults()
+		if err := spec.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid service '%s': %w", spec.Name, err)
+		}
+		ex.exportService(spec)
+	}
+
+	objects := slices.Concat(ex.configMaps, ex.claims, ex.objects)
+	return &ExportResult{Objects: objects, Warnings: ex.warnings}, nil
+}
+
+func (ex *exporter) warnf(format string, args ...any) {
+	ex.warnings = append(ex.warnings, fmt.Sprintf(format, args...))
+}
+
+// exportOnce returns true if an object with the given kind and name hasn't been exported yet and marks it exported.
+func (ex *exporter) exportOnce(kind, name string) bool {
+	key := kind + "/" + name
+	if _, ok := ex.exported[key]; ok {
+		return false
+	}
+	ex.exported[key] = struct{}{}
+	return true
+}
+
+func (ex *exporter) objectMeta(name string) metav1.ObjectMeta {
+	labels := map[string]string{NameLabel: name}
+	if ex.projectName != "" {
+		labels[PartOfLabel] = dnsName(ex.projectName)
+	}
+	return metav1.ObjectMeta{Name: name, Labels: labels}
+}
+
+func (ex *exporter) exportService(spec api.ServiceSpec) {
+	// Uncloud service names are already valid Kubernetes object names.
+	name := spec.Name
+	ref := fmt.Sprintf("Service '%s'", name)
+
+	if spec.Caddy != nil {
+		ex.warnf("%s: custom Caddy config (x-caddy) is not supported. Configure the Ingress manually.", ref)
+	}
+	if spec.Placement.HasMachines() || len(spec.Placement.ExcludeMachines) > 0 || spec.Placement.MachineCount > 0 {
+		ex.warnf("%s: placement constraints (x-machines) are not supported. "+
+			"Use a node selector or affinity to restrict the nodes the pods can run on.", ref)
+	}
+	if len(spec.InternalIPs) > 0 {
+		ex.warnf("%s: fixed internal IPs (x-internal-ip) are not supported. "+
+			"Clients should use the Service DNS name or a Service with a fixed clusterIP.", ref)
+	}
+	if spec.MTLS {
+		ex.warnf("%s: transparent mTLS (x-mtls) is not supported. Use a service mesh such as Istio or Linkerd.", ref)
+	}
+	if spec.PreDeploy != nil {
+		ex.warnf("%s: pre-deploy hook (x-pre_deploy) is not supported. "+
+			"Consider running the command in a Job or an init container.", ref)
+	}
+	switch spec.RestartPolicy {
+	case "", api.RestartPolicyAlways, api.RestartPolicyUnlessStopped:
+	default:
+		ex.warnf("%s: restart policy '%s' is not supported. Pods of a Deployment or DaemonSet are always "+
+			"restarted.", ref, spec.RestartPolicy)
+	}
+	if len(spec.Secrets) > 0 {
+		ex.warnf("%s: secrets are not exported to avoid writing their values to the manifests. "+
+			"Create Kubernetes Secrets and mount them into the pods manually.", ref)
+	}
+
+	ctr := ex.container(ref, name, spec)
+	podSpec := corev1.PodSpec{Containers: []corev1.Container{ctr}}
+	ex.podSpec(ref, spec, &podSpec)
+	ex.podVolumes(ref, spec, &podSpec)
+
+	meta := ex.objectMeta(name)
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: meta.Labels, Annotations: spec.Container.Annotations},
+		Spec:       podSpec,
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{NameLabel: name}}
+
+	if spec.Mode == api.ServiceModeGlobal {
+		ex.objects = append(ex.objects, &appsv1.DaemonSet{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+			ObjectMeta: meta,
+			Spec: appsv1.DaemonSetSpec{
+				Selector: selector,
+				Template: template,
+			},
+		})
+	} else {
+		replicas := int32(spec.Replicas)
+		ex.objects = append(ex.objects, &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: meta,
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: selector,
+				Template: template,
+				Strategy: deploymentStrategy(spec),
+			},
+		})
+	}
+
+	ex.exportPorts(ref, name, spec)
+}
+
+// deploymentStrategy returns the rolling update strategy that replaces containers in the same order as Uncloud.
+func deploymentStrategy(spec api.ServiceSpec) appsv1.DeploymentStrategy {
+	order := spec.UpdateConfig.Order
+	if order == "" {
+		// Uncloud stops the old container first if the service has volumes that can't be safely shared.
+		order = api.UpdateOrderStartFirst
+		if len(spec.MountedDockerVolumes()) > 0 {
+			order = api.UpdateOrderStopFirst
+		}
+	}
+
+	zero, one := intstr.FromInt32(0), intstr.FromInt32(1)
+	rolling := &appsv1.RollingUpdateDeployment{MaxSurge: &one, MaxUnavailable: &zero}
+	if order == api.UpdateOrderStopFirst {
+		rolling = &appsv1.RollingUpdateDeployment{MaxSurge: &zero, MaxUnavailable: &one}
+	}
+	return appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType, RollingUpdate: rolling}
+}
+
+func (ex *exporter) container(ref, name string, spec api.ServiceSpec) corev1.Container {
+	c := spec.Container
+	ctr := corev1.Container{
+		Name:       name,
+		Image:      c.Image,
+		Command:    escapeAll(c.Entrypoint),
+		Args:       escapeAll(c.Command),
+		WorkingDir: c.WorkingDir,
+	}
+
+	switch c.PullPolicy {
+	case api.PullPolicyAlways:
+		ctr.ImagePullPolicy = corev1.PullAlways
+	case api.PullPolicyNever:
+		ctr.ImagePullPolicy = corev1.PullNever
+	default:
+		ctr.ImagePullPolicy = corev1.PullIfNotPresent
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(c.Env)) {
+		if k == "" {
+			continue
+		}
+		// Kubernetes expands $(VAR) references in env values, command, and args so the dollar signs must be escaped.
+		ctr.Env = append(ctr.Env, corev1.EnvVar{Name: k, Value: escape(c.Env[k])})
+	}
+
+	ctr.Resources = ex.resources(ref, c.Resources)
+	ctr.ReadinessProbe = ex.probe(ref, c.Healthcheck)
+	ctr.SecurityContext = ex.securityContext(ref, c)
+
+	for _, p := range spec.Ports {
+		cp := corev1.ContainerPort{Name: p.Name, ContainerPort: int32(p.ContainerPort), Protocol: corev1.ProtocolTCP}
+		if p.Protocol == api.ProtocolUDP {
+			cp.Protocol = corev1.ProtocolUDP
+		}
+		if p.Mode == api.PortModeHost {
+			cp.HostPort = int32(p.PublishedPort)
+			if p.HostIP.IsValid() {
+				cp.HostIP = p.HostIP.String()
+			}
+			if p.HostInterface != "" {
+				ex.warnf("%s: binding host port %d to interface '%s' is not supported. "+
+					"The port is bound to all interfaces.", ref, p.PublishedPort, p.HostInterface)
+			}
+		}
+		if !slices.Contains(ctr.Ports, cp) {
+			ctr.Ports = append(ctr.Ports, cp)
+		}
+	}
+
+	return ctr
+}
+
+func (ex *exporter) resources(ref string, r api.ContainerResources) corev1.ResourceRequirements {
+	var res corev1.ResourceRequirements
+	if r.CPU > 0 {
+		res.Limits = corev1.ResourceList{
+			corev1.ResourceCPU: *resource.NewMilliQuantity(r.CPU/api.MilliCore, resource.DecimalSI),
+		}
+	}
+	if r.Memory > 0 {
+		if res.Limits == nil {
+			res.Limits = corev1.ResourceList{}
+		}
+		res.Limits[corev1.ResourceMemory] = *resource.NewQuantity(r.Memory, resource.BinarySI)
+	}
+	if r.MemoryReservation > 0 {
+		res.Requests = corev1.ResourceList{
+			corev1.ResourceMemory: *resource.NewQuantity(r.MemoryReservation, resource.BinarySI),
+		}
+	}
+
+	if len(r.Devices) > 0 || len(r.DeviceReservations) > 0 {
+		ex.warnf("%s: devices are not supported. Use a device plugin to expose devices to pods.", ref)
+	}
+	if len(r.Ulimits) > 0 || r.PidsLimit != 0 {
+		ex.warnf("%s: ulimits and pids limit are not supported. Configure them on the nodes instead.", ref)
+	}
+	if r.EgressBandwidth != 0 {
+		ex.warnf("%s: egress bandwidth limit (x-bandwidth) is not supported. Use the "+
+			"kubernetes.io/egress-bandwidth pod annotation if your CNI plugin supports it.", ref)
+	}
+	return res
+}
+
+// probe converts the health check to a readiness probe. Docker doesn't restart unhealthy containers and Uncloud uses
+// the health check only to decide when a new container is ready during a deployment, which matches the semantics
+// of a readiness probe rather than a liveness probe.
+func (ex *exporter) probe(ref string, hc *api.HealthcheckSpec) *corev1.Probe {
+	if hc == nil || hc.Disable || (len(hc.Test) > 0 && hc.Test[0] == "NONE") {
+		return nil
+	}
+
+	var command []string
+	switch {
+	case len(hc.Test) == 0:
+		ex.warnf("%s: health check that overrides only the options of the image health check is not supported. "+
+			"Define a probe manually.", ref)
+		return nil
+	case hc.Test[0] == "CMD":
+		command = hc.Test[1:]
+	case hc.Test[0] == "CMD-SHELL":
+		command = []string{"/bin/sh", "-c", strings.Join(hc.Test[1:], " ")}
+	default:
+		ex.warnf("%s: unsupported health check test '%s', skipped.", ref, strings.Join(hc.Test, " "))
+		return nil
+	}
+
+	// Docker defaults are used for unset options.
+	probe := &corev1.Probe{
+		ProbeHandler:     corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: command}},
+		PeriodSeconds:    seconds(hc.Interval, 30*time.Second),
+		TimeoutSeconds:   seconds(hc.Timeout, 30*time.Second),
+		FailureThreshold: 3,
+	}
+	if hc.Retries > 0 {
+		probe.FailureThreshold = int32(hc.Retries)
+	}
+	if hc.StartPeriod > 0 {
+		probe.InitialDelaySeconds = seconds(hc.StartPeriod, 0)
+	}
+	return probe
+}
+
+// seconds returns the duration in whole seconds, at least 1, or the default if the duration is zero.
+func seconds(d, def time.Duration) int32 {
+	if d == 0 {
+		d = def
+	}
+	return int32(max(d.Round(time.Second)/time.Second, 1))
+}
+
+func (ex *exporter) securityContext(ref string, c api.ContainerSpec) *corev1.SecurityContext {
+	var sc corev1.SecurityContext
+	set := false
+
+	if c.User != "" {
+		user, group, hasGroup := strings.Cut(c.User, ":")
+		uid, uidErr := strconv.ParseInt(user, 10, 64)
+		gid, gidErr := strconv.ParseInt(group, 10, 64)
+		if uidErr != nil || (hasGroup && gidErr != nil) {
+			ex.warnf("%s: user '%s' must be numeric to run as it in Kubernetes, skipped.", ref, c.User)
+		} else {
+			sc.RunAsUser = &uid
+			if hasGroup {
+				sc.RunAsGroup = &gid
+			}
+			set = true
+		}
+	}
+	if c.Privileged {
+		sc.Privileged = &c.Privileged
+		set = true
+	}
+	if len(c.CapAdd) > 0 || len(c.CapDrop) > 0 {
+		sc.Capabilities = &corev1.Capabilities{}
+		// Kubernetes expects capability names without the CAP_ prefix.
+		for _, capability := range c.CapAdd {
+			sc.Capabilities.Add = append(sc.Capabilities.Add,
+				corev1.Capability(strings.TrimPrefix(capability, "CAP_")))
+		}
+		for _, capability := range c.CapDrop {
+			sc.Capabilities.Drop = append(sc.Capabilities.Drop,
+				corev1.Capability(strings.TrimPrefix(capability, "CAP_")))
+		}
+		set = true
+	}
+
+	if !set {
+		return nil
+	}
+	return &sc
+}
+
+// podSpec sets the pod-level options of the service container.
+func (ex *exporter) podSpec(ref string, spec api.ServiceSpec, pod *corev1.PodSpec) {
+	c := spec.Container
+
+	if c.Hostname != "" {
+		if dnsLabelRegexp.MatchString(c.Hostname) {
+			pod.Hostname = c.Hostname
+		} else {
+			ex.warnf("%s: hostname '%s' is not a valid DNS label, skipped.", ref, c.Hostname)
+		}
+	}
+	if c.StopGracePeriod != nil {
+		grace := int64(c.StopGracePeriod.Round(time.Second) / time.Second)
+		pod.TerminationGracePeriodSeconds = &grace
+	}
+	if len(c.Sysctls) > 0 {
+		pod.SecurityContext = &corev1.PodSecurityContext{}
+		for _, k := range slices.Sorted(maps.Keys(c.Sysctls)) {
+			pod.SecurityContext.Sysctls = append(pod.SecurityContext.Sysctls,
+				corev1.Sysctl{Name: k, Value: c.Sysctls[k]})
+		}
+	}
+
+	switch {
+	case c.PidMode == "host":
+		pod.HostPID = true
+	case c.PidMode != "":
+		ex.warnf("%s: PID mode '%s' is not supported, skipped.", ref, c.PidMode)
+	}
+	switch c.IpcMode {
+	case "", api.IpcModePrivate, api.IpcModeShareable:
+	case api.IpcModeHost:
+		pod.HostIPC = true
+	default:
+		ex.warnf("%s: IPC mode '%s' is not supported, skipped.", ref, c.IpcMode)
+	}
+	// The local logging driver is the default set for all containers.
+	if c.LogDriver != nil && c.LogDriver.Name != "local" {
+		ex.warnf("%s: logging driver '%s' is not supported. Kubernetes collects container logs on the nodes.",
+			ref, c.LogDriver.Name)
+	}
+
+	aliases := make(map[string][]string)
+	for _, h := range c.ExtraHosts {
+		host, ip, _ := strings.Cut(h, ":")
+		if _, err := netip.ParseAddr(ip); err != nil {
+			ex.warnf("%s: extra host '%s' must have an IP address in Kubernetes, skipped.", ref, h)
+			continue
+		}
+		aliases[ip] = append(aliases[ip], host)
+	}
+	for _, ip := range slices.Sorted(maps.Keys(aliases)) {
+		pod.HostAliases = append(pod.HostAliases, corev1.HostAlias{IP: ip, Hostnames: aliases[ip]})
+	}
+}
+
+// podVolumes adds the volumes and configs mounted into the service container to the pod.
+func (ex *exporter) podVolumes(ref string, spec api.ServiceSpec, pod *corev1.PodSpec) {
+	ctr := &pod.Containers[0]
+
+	for _, m := range spec.Container.VolumeMounts {
+		vol, ok := spec.Volume(m.VolumeName)
+		if !ok {
+			continue
+		}
+		volName := dnsName(vol.Name)
+		mount := corev1.VolumeMount{Name: volName, MountPath: m.ContainerPath, ReadOnly: m.ReadOnly}
+		if vol.VolumeOptions != nil {
+			mount.SubPath = vol.VolumeOptions.SubPath
+		}
+		ctr.VolumeMounts = append(ctr.VolumeMounts, mount)
+
+		if slices.ContainsFunc(pod.Volumes, func(v corev1.Volume) bool { return v.Name == volName }) {
+			continue
+		}
+		pod.Volumes = append(pod.Volumes, ex.podVolume(ref, spec, vol, volName))
+	}
+
+	if shm := spec.Container.Resources.SharedMemory; shm > 0 {
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: "dshm",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium:    corev1.StorageMediumMemory,
+				SizeLimit: resource.NewQuantity(shm, resource.BinarySI),
+			}},
+		})
+		ctr.VolumeMounts = append(ctr.VolumeMounts, corev1.VolumeMount{Name: "dshm", MountPath: "/dev/shm"})
+	}
+
+	for _, m := range spec.Container.ConfigMounts {
+		cfg, ok := spec.Config(m.ConfigName)
+		if !ok {
+			continue
+		}
+		cmName := dnsName(cfg.Name)
+		key := invalidConfigNameChars.ReplaceAllString(cfg.Name, "-")
+		ex.exportConfigMap(cmName, key, cfg.Content)
+
+		if m.Uid != "" || m.Gid != "" {
+			ex.warnf("%s: owner of config '%s' is not supported. Use the pod fsGroup to set the group instead.",
+				ref, cfg.Name)
+		}
+		target := m.ContainerPath
+		if target == "" {
+			target = "/" + cfg.Name
+		}
+
+		volName := "config-" + cmName
+		ctr.VolumeMounts = append(ctr.VolumeMounts, corev1.VolumeMount{
+			Name: volName, MountPath: target, SubPath: key, ReadOnly: true,
+		})
+		if slices.ContainsFunc(pod.Volumes, func(v corev1.Volume) bool { return v.Name == volName }) {
+			continue
+		}
+		mode := int32(defaultConfigMode)
+		if m.Mode != nil {
+			mode = int32(m.Mode.Perm())
+		}
+		pod.Volumes = append(pod.Volumes, corev1.Volume{
+			Name: volName,
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
+				DefaultMode:          &mode,
+			}},
+		})
+	}
+}
+
+func (ex *exporter) podVolume(ref string, spec api.ServiceSpec, vol api.VolumeSpec, name string) corev1.Volume {
+	v := corev1.Volume{Name: name}
+	switch vol.Type {
+	case api.VolumeTypeBind:
+		v.HostPath = &corev1.HostPathVolumeSource{Path: vol.BindOptions.HostPath}
+		if vol.BindOptions.CreateHostPath {
+			hostPathType := corev1.HostPathDirectoryOrCreate
+			v.HostPath.Type = &hostPathType
+		}
+	case api.VolumeTypeTmpfs:
+		v.EmptyDir = &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}
+		if vol.TmpfsOptions != nil && vol.TmpfsOptions.SizeBytes > 0 {
+			v.EmptyDir.SizeLimit = resource.NewQuantity(vol.TmpfsOptions.SizeBytes, resource.BinarySI)
+		}
+	case api.VolumeTypeVolume:
+		claimName := dnsName(vol.DockerVolumeName())
+		v.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName}
+		ex.exportClaim(claimName)
+		if spec.Mode == api.ServiceModeGlobal || spec.Replicas > 1 {
+			ex.warnf("%s: each replica uses its own Docker volume '%s' on its machine in Uncloud but all pods "+
+				"share the same PersistentVolumeClaim in Kubernetes. Consider converting the service to "+
+				"a StatefulSet with volume claim templates.", ref, vol.DockerVolumeName())
+		}
+	}
+	return v
+}
+
+func (ex *exporter) exportClaim(name string) {
+	if !ex.exportOnce("PersistentVolumeClaim", name) {
+		return
+	}
+	ex.claims = append(ex.claims, &corev1.PersistentVolumeClaim{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+		ObjectMeta: ex.objectMeta(name),
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(defaultClaimSize)},
+			},
+		},
+	})
+	ex.warnf("PersistentVolumeClaim '%s': requests %s of storage from the default storage class. "+
+		"Adjust the size and storage class as needed.", name, defaultClaimSize)
+}
+
+func (ex *exporter) exportConfigMap(name, key string, content []byte) {
+	if !ex.exportOnce("ConfigMap", name) {
+		return
+	}
+	cm := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: ex.objectMeta(name),
+	}
+	if utf8.Valid(content) {
+		cm.Data = map[string]string{key: string(content)}
+	} else {
+		cm.BinaryData = map[string][]byte{key: content}
+	}
+	ex.configMaps = append(ex.configMaps, cm)
+}
+
+// exportPorts creates a Service that exposes the published container ports to other pods, and an Ingress for
+// the ports published via the Uncloud ingress (Caddy).
+func (ex *exporter) exportPorts(ref, name string, spec api.ServiceSpec) {
+	if len(spec.Ports) == 0 {
+		ex.warnf("%s: no ports are published so no Kubernetes Service is created. Other services can reach "+
+			"any container port in Uncloud but need a Service in Kubernetes. Add one if needed.", ref)
+		return
+	}
+
+	svc := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: ex.objectMeta(name),
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{NameLabel: name}},
+	}
+	for _, p := range spec.Ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol == api.ProtocolUDP {
+			protocol = corev1.ProtocolUDP
+		}
+		port := int32(p.ContainerPort)
+		if slices.ContainsFunc(svc.Spec.Ports, func(sp corev1.ServicePort) bool {
+			return sp.Port == port && sp.Protocol == protocol
+		}) {
+			continue
+		}
+		// The service port is the same as the container port because Uncloud services are reached directly
+		// on the container ports.
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       fmt.Sprintf("%s-%d", p.Protocol, port),
+			Port:       port,
+			TargetPort: intstr.FromInt32(port),
+			Protocol:   protocol,
+		})
+	}
+	ex.objects = append(ex.objects, svc)
+
+	ing := &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: ex.objectMeta(name),
+	}
+	var tlsHosts []string
+	for _, p := range spec.Ports {
+		if p.Mode != api.PortModeIngress || (p.Protocol != api.ProtocolHTTP && p.Protocol != api.ProtocolHTTPS) {
+			continue
+		}
+		if p.Hostname == "" {
+			ex.warnf("%s: port %d has no hostname. Uncloud assigns a hostname in the cluster domain on deploy "+
+				"but the Ingress rule matches any host.", ref, p.ContainerPort)
+		} else if p.Protocol == api.ProtocolHTTPS && !slices.Contains(tlsHosts, p.Hostname) {
+			tlsHosts = append(tlsHosts, p.Hostname)
+		}
+
+		pathType := networkingv1.PathTypePrefix
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
+			Host: p.Hostname,
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{{
+					Path:     "/",
+					PathType: &pathType,
+					Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+						Name: name,
+						Port: networkingv1.ServiceBackendPort{Number: int32(p.ContainerPort)},
+					}},
+				}},
+			}},
+		})
+	}
+	if len(ing.Spec.Rules) == 0 {
+		return
+	}
+
+	if len(tlsHosts) > 0 {
+		secretName := name + "-tls"
+		ing.Spec.TLS = []networkingv1.IngressTLS{{Hosts: tlsHosts, SecretName: secretName}}
+		ex.warnf("%s: Uncloud obtains TLS certificates automatically but the Ingress expects them in the Secret "+
+			"'%s'. Use cert-manager or create the Secret manually.", ref, secretName)
+	}
+	ex.objects = append(ex.objects, ing)
+}
+
+var (
+	invalidDNSNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+	dnsLabelRegexp      = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+)
+
+// dnsName converts the name to a valid Kubernetes object name (RFC 1123 label).
+func dnsName(name string) string {
+	n := invalidDNSNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(n) > 63 {
+		n = n[:63]
+	}
+	return strings.Trim(n, "-")
+}
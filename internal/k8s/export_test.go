@@ -0,0 +1,199 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	configMode := os.FileMode(0o400)
+	specs := []api.ServiceSpec{
+		{
+			Name:     "web-app",
+			Replicas: 2,
+			Container: api.ContainerSpec{
+				Annotations: map[string]string{"com.example.team": "platform"},
+				Image:       "nginx:1.29",
+				Command:     []string{"nginx", "-g", "daemon off;"},
+				Env:         api.EnvVars{"PASSWORD": "pa$(word)", "LOG_LEVEL": "debug"},
+				PullPolicy:  api.PullPolicyAlways,
+				User:        "1000:1000",
+				Healthcheck: &api.HealthcheckSpec{
+					Test:     []string{"CMD-SHELL", "curl -f localhost:8080"},
+					Interval: 10 * time.Second,
+				},
+				Resources: api.ContainerResources{
+					CPU:               api.Core / 2,
+					Memory:            256 * 1024 * 1024,
+					MemoryReservation: 128 * 1024 * 1024,
+				},
+				VolumeMounts: []api.VolumeMount{
+					{VolumeName: "data", ContainerPath: "/data"},
+					{VolumeName: "cache", ContainerPath: "/cache"},
+				},
+				ConfigMounts: []api.ConfigMount{
+					{ConfigName: "nginx.conf", ContainerPath: "/etc/nginx/conf.d/default.conf", Mode: &configMode},
+				},
+			},
+			Configs: []api.ConfigSpec{
+				{Name: "nginx.conf", Content: []byte("server { listen 8080; }\n")},
+			},
+			Ports: []api.PortSpec{
+				{Hostname: "example.com", ContainerPort: 8080, Protocol: api.ProtocolHTTPS, Mode: api.PortModeIngress},
+				{PublishedPort: 9000, ContainerPort: 9000, Protocol: api.ProtocolTCP, Mode: api.PortModeHost},
+			},
+			Volumes: []api.VolumeSpec{
+				{Name: "data", Type: api.VolumeTypeVolume},
+				{Name: "cache", Type: api.VolumeTypeTmpfs},
+			},
+		},
+		{
+			Name: "agent",
+			Mode: api.ServiceModeGlobal,
+			Container: api.ContainerSpec{
+				Image: "agent:1.0",
+			},
+			Placement: api.Placement{Machines: []string{"machine1"}},
+		},
+	}
+
+	result, err := Export("demo", specs)
+	require.NoError(t, err)
+
+	kinds := make([]string, len(result.Objects))
+	for i, obj := range result.Objects {
+		kinds[i] = obj.GetObjectKind().GroupVersionKind().Kind
+	}
+	assert.Equal(t, []string{
+		"ConfigMap", "PersistentVolumeClaim", "Deployment", "Service", "Ingress", "DaemonSet",
+	}, kinds)
+
+	cm := result.Objects[0].(*corev1.ConfigMap)
+	assert.Equal(t, "nginx-conf", cm.Name)
+	assert.Equal(t, map[string]string{"nginx.conf": "server { listen 8080; }\n"}, cm.Data)
+
+	deployment := result.Objects[2].(*appsv1.Deployment)
+	assert.Equal(t, "web-app", deployment.Name)
+	assert.Equal(t, map[string]string{NameLabel: "web-app", PartOfLabel: "demo"}, deployment.Labels)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.Equal(t, map[string]string{"com.example.team": "platform"}, deployment.Spec.Template.Annotations)
+	assert.Equal(t, 0, deployment.Spec.Strategy.RollingUpdate.MaxSurge.IntValue(),
+		"services with volumes are updated stop-first")
+
+	ctr := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{"nginx", "-g", "daemon off;"}, ctr.Args)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "PASSWORD", Value: "pa$$(word)"},
+	}, ctr.Env)
+	assert.Equal(t, corev1.PullAlways, ctr.ImagePullPolicy)
+	assert.Equal(t, int64(1000), *ctr.SecurityContext.RunAsGroup)
+	assert.Equal(t, "500m", ctr.Resources.Limits.Cpu().String())
+	assert.Equal(t, "256Mi", ctr.Resources.Limits.Memory().String())
+	assert.Equal(t, "128Mi", ctr.Resources.Requests.Memory().String())
+	require.NotNil(t, ctr.ReadinessProbe)
+	assert.Equal(t, []string{"/bin/sh", "-c", "curl -f localhost:8080"}, ctr.ReadinessProbe.Exec.Command)
+	assert.Equal(t, int32(10), ctr.ReadinessProbe.PeriodSeconds)
+	assert.Equal(t, []corev1.ContainerPort{
+		{ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+		{ContainerPort: 9000, HostPort: 9000, Protocol: corev1.ProtocolTCP},
+	}, ctr.Ports)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "data", MountPath: "/data"},
+		{Name: "cache", MountPath: "/cache"},
+		{
+			Name:      "config-nginx-conf",
+			MountPath: "/etc/nginx/conf.d/default.conf",
+			SubPath:   "nginx.conf",
+			ReadOnly:  true,
+		},
+	}, ctr.VolumeMounts)
+
+	volumes := deployment.Spec.Template.Spec.Volumes
+	require.Len(t, volumes, 3)
+	assert.Equal(t, "data", volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, corev1.StorageMediumMemory, volumes[1].EmptyDir.Medium)
+	assert.Equal(t, int32(0o400), *volumes[2].ConfigMap.DefaultMode)
+
+	ingress := result.Objects[4].(*networkingv1.Ingress)
+	require.Len(t, ingress.Spec.Rules, 1)
+	assert.Equal(t, "example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "web-app-tls"}},
+		ingress.Spec.TLS)
+
+	assert.Contains(t, result.Warnings, "Service 'web-app': Uncloud obtains TLS certificates automatically but "+
+		"the Ingress expects them in the Secret 'web-app-tls'. Use cert-manager or create the Secret manually.")
+	assert.Contains(t, result.Warnings, "Service 'agent': placement constraints (x-machines) are not supported. "+
+		"Use a node selector or affinity to restrict the nodes the pods can run on.")
+}
+
+// TestExport_RoundTrip checks that the services exported from a Compose file can be imported back.
+func TestExport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	project, err := compose.LoadProjectFromContent(context.Background(), `
+services:
+  web:
+    image: nginx:1.29
+    environment:
+      GREETING: "Hello $$USER"
+    x-ports:
+      - app.example.com:80/https
+    volumes:
+      - data:/data
+volumes:
+  data:
+`)
+	require.NoError(t, err)
+	spec, err := compose.ServiceSpecFromCompose(project, "web")
+	require.NoError(t, err)
+
+	exported, err := Export(project.Name, []api.ServiceSpec{spec})
+	require.NoError(t, err)
+
+	data, err := MarshalYAML(exported.Objects)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "creationTimestamp")
+	assert.NotContains(t, string(data), "status")
+
+	manifests, err := ParseManifests(data, "exported.yaml")
+	require.NoError(t, err)
+	imported, err := Import("demo", manifests)
+	require.NoError(t, err)
+
+	web, ok := imported.Project.Services["web"]
+	require.True(t, ok)
+	assert.Equal(t, "nginx:1.29", web.Image)
+	assert.Equal(t, "Hello $$USER", *web.Environment["GREETING"])
+	assert.Equal(t, []string{"app.example.com:80/https"}, web.Extensions[compose.PortsExtensionKey])
+	assert.Equal(t, []types.ServiceVolumeConfig{
+		{Type: types.VolumeTypeVolume, Source: "data", Target: "/data"},
+	}, web.Volumes)
+}
+
+func TestMarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	data, err := MarshalYAML([]runtime.Object{
+		&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, Data: map[string]string{"a": "b"}},
+		&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1\ndata:\n  a: b\nkind: ConfigMap\nmetadata: {}\n---\n"+
+		"apiVersion: v1\nkind: ConfigMap\nmetadata: {}\n", string(data))
+}
@@ -0,0 +1,795 @@
+package k8s
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ImportResult is the result of converting Kubernetes manifests to a Compose project.
+type ImportResult struct {
+	Project *types.Project
+	// Warnings describe the Kubernetes constructs that were skipped or converted only partially.
+	Warnings []string
+}
+
+// workload is a Kubernetes object that runs pods, such as a Deployment.
+type workload struct {
+	manifest Manifest
+	replicas *int32
+	global   bool
+	template corev1.PodTemplateSpec
+	// claimTemplates are the names of the StatefulSet volume claim templates.
+	claimTemplates []string
+	// serviceName is the name of the Compose service for the first container of the pod.
+	serviceName string
+}
+
+type importer struct {
+	project    *types.Project
+	workloads  []*workload
+	services   []corev1.Service
+	ingresses  []networkingv1.Ingress
+	configMaps map[string]corev1.ConfigMap
+	secrets    map[string]corev1.Secret
+	warnings   []string
+	// inlinedSecrets tracks the secrets which values have been inlined to the Compose file to warn only once.
+	inlinedSecrets map[string]struct{}
+}
+
+// ignoredKinds are the kinds that don't have an equivalent in Uncloud and are skipped with a single warning.
+var ignoredKinds = []string{
+	"ClusterRole", "ClusterRoleBinding", "HorizontalPodAutoscaler", "Namespace", "NetworkPolicy",
+	"PersistentVolume", "PersistentVolumeClaim", "PodDisruptionBudget", "Role", "RoleBinding", "ServiceAccount",
+}
+
+// Import converts Deployments, StatefulSets, DaemonSets, Pods, Services, Ingresses, ConfigMaps, and Secrets from
+// the Kubernetes manifests into a Compose project on a best-effort basis. Constructs that can't be converted
+// are reported as warnings. Namespaces are ignored so object names must be unique across all manifests.
+func Import(projectName string, manifests []Manifest) (*ImportResult, error) {
+	im := &importer{
+		project: &types.Project{
+			Name:     projectName,
+			Services: types.Services{},
+		},
+		configMaps:     make(map[string]corev1.ConfigMap),
+		secrets:        make(map[string]corev1.Secret),
+		inlinedSecrets: make(map[string]struct{}),
+	}
+
+	if err := im.decode(manifests); err != nil {
+		return nil, err
+	}
+
+	im.nameServices()
+	for _, w := range im.workloads {
+		im.convertWorkload(w)
+	}
+	for _, svc := range im.services {
+		im.convertService(svc)
+	}
+	for _, ing := range im.ingresses {
+		im.convertIngress(ing)
+	}
+
+	return &ImportResult{Project: im.project, Warnings: im.warnings}, nil
+}
+
+func (im *importer) warnf(format string, args ...any) {
+	im.warnings = append(im.warnings, fmt.Sprintf(format, args...))
+}
+
+func (im *importer) decode(manifests []Manifest) error {
+	var ignored []string
+	for _, m := range manifests {
+		switch m.Kind {
+		case "Deployment":
+			var d appsv1.Deployment
+			if err := m.Decode(&d); err != nil {
+				return err
+			}
+			im.workloads = append(im.workloads, &workload{
+				manifest: m, replicas: d.Spec.Replicas, template: d.Spec.Template,
+			})
+		case "StatefulSet":
+			var s appsv1.StatefulSet
+			if err := m.Decode(&s); err != nil {
+				return err
+			}
+			w := &workload{manifest: m, replicas: s.Spec.Replicas, template: s.Spec.Template}
+			for _, c := range s.Spec.VolumeClaimTemplates {
+				w.claimTemplates = append(w.claimTemplates, c.Name)
+			}
+			im.workloads = append(im.workloads, w)
+			im.warnf("%s: converted to a regular service. Stable network identities and ordered rollouts "+
+				"are not supported, volume claim templates are converted to named volumes.", m)
+		case "DaemonSet":
+			var d appsv1.DaemonSet
+			if err := m.Decode(&d); err != nil {
+				return err
+			}
+			im.workloads = append(im.workloads, &workload{manifest: m, global: true, template: d.Spec.Template})
+		case "Pod":
+			var p corev1.Pod
+			if err := m.Decode(&p); err != nil {
+				return err
+			}
+			im.workloads = append(im.workloads, &workload{
+				manifest: m,
+				template: corev1.PodTemplateSpec{ObjectMeta: p.ObjectMeta, Spec: p.Spec},
+			})
+		case "Service":
+			var s corev1.Service
+			if err := m.Decode(&s); err != nil {
+				return err
+			}
+			im.services = append(im.services, s)
+		case "Ingress":
+			var ing networkingv1.Ingress
+			if err := m.Decode(&ing); err != nil {
+				return err
+			}
+			im.ingresses = append(im.ingresses, ing)
+		case "ConfigMap":
+			var cm corev1.ConfigMap
+			if err := m.Decode(&cm); err != nil {
+				return err
+			}
+			im.configMaps[cm.Name] = cm
+		case "Secret":
+			var s corev1.Secret
+			if err := m.Decode(&s); err != nil {
+				return err
+			}
+			im.secrets[s.Name] = s
+		default:
+			if slices.Contains(ignoredKinds, m.Kind) {
+				ignored = append(ignored, m.String())
+			} else {
+				im.warnf("%s: unsupported kind, skipped.", m)
+			}
+		}
+	}
+
+	if len(ignored) > 0 {
+		im.warnf("Skipped objects that have no equivalent in Uncloud: %s.", strings.Join(ignored, ", "))
+	}
+	return nil
+}
+
+// selects returns true if the Service selects the pods of the workload.
+func selects(svc corev1.Service, w *workload) bool {
+	if len(svc.Spec.Selector) == 0 {
+		return false
+	}
+	for k, v := range svc.Spec.Selector {
+		if w.template.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// selectedWorkloads returns the workloads selected by the Service.
+func (im *importer) selectedWorkloads(svc corev1.Service) []*workload {
+	var selected []*workload
+	for _, w := range im.workloads {
+		if selects(svc, w) {
+			selected = append(selected, w)
+		}
+	}
+	return selected
+}
+
+// nameServices picks the Compose service names for workloads. A workload selected by exactly one Service is named
+// after the Service so that other services can keep reaching it by the same name using the cluster DNS.
+func (im *importer) nameServices() {
+	used := make(map[string]struct{})
+	for _, w := range im.workloads {
+		var selectedBy []corev1.Service
+		for _, svc := range im.services {
+			if selects(svc, w) {
+				selectedBy = append(selectedBy, svc)
+			}
+		}
+
+		w.serviceName = w.manifest.Metadata.Name
+		if len(selectedBy) == 1 {
+			if _, ok := used[selectedBy[0].Name]; !ok {
+				w.serviceName = selectedBy[0].Name
+			}
+		}
+		if _, ok := used[w.serviceName]; ok {
+			w.serviceName = strings.ToLower(w.manifest.Kind) + "-" + w.serviceName
+		}
+		used[w.serviceName] = struct{}{}
+	}
+}
+
+func (im *importer) convertWorkload(w *workload) {
+	spec := w.template.Spec
+	if len(spec.InitContainers) > 0 {
+		im.warnf("%s: init containers are not supported and were skipped. "+
+			"Consider using an x-pre_deploy hook for one-off tasks.", w.manifest)
+	}
+	if len(spec.NodeSelector) > 0 || spec.Affinity != nil {
+		im.warnf("%s: node selectors and affinity are not supported. "+
+			"Use x-machines to restrict the machines the service can run on.", w.manifest)
+	}
+	if spec.HostNetwork {
+		im.warnf("%s: host network is not supported, the service uses the cluster network.", w.manifest)
+	}
+	if len(spec.ImagePullSecrets) > 0 {
+		im.warnf("%s: image pull secrets are not supported. "+
+			"Log in to the registry on the machines or push images with 'uc image push'.", w.manifest)
+	}
+	if len(spec.Containers) > 1 {
+		im.warnf("%s: each of the %d containers is converted to a separate service. "+
+			"They don't share the network namespace or emptyDir volumes.", w.manifest, len(spec.Containers))
+	}
+
+	for i, ctr := range spec.Containers {
+		name := w.serviceName
+		if i > 0 {
+			name = w.serviceName + "-" + ctr.Name
+		}
+		im.project.Services[name] = im.convertContainer(w, ctr, name)
+	}
+}
+
+func (im *importer) convertContainer(w *workload, ctr corev1.Container, name string) types.ServiceConfig {
+	svc := types.ServiceConfig{
+		Name:       name,
+		Image:      ctr.Image,
+		Entrypoint: escapeAll(unescapeExpandedAll(ctr.Command)),
+		Command:    escapeAll(unescapeExpandedAll(ctr.Args)),
+		WorkingDir: ctr.WorkingDir,
+	}
+
+	switch ctr.ImagePullPolicy {
+	case corev1.PullAlways:
+		svc.PullPolicy = types.PullPolicyAlways
+	case corev1.PullIfNotPresent:
+		svc.PullPolicy = types.PullPolicyMissing
+	case corev1.PullNever:
+		svc.PullPolicy = types.PullPolicyNever
+	}
+
+	svc.Environment = im.convertEnv(w, ctr)
+	svc.Deploy = im.convertDeploy(w, ctr)
+	svc.HealthCheck = im.convertProbe(w, ctr)
+	im.convertSecurityContext(w, ctr, &svc)
+	im.convertVolumeMounts(w, ctr, &svc)
+
+	return svc
+}
+
+func (im *importer) convertEnv(w *workload, ctr corev1.Container) types.MappingWithEquals {
+	env := types.MappingWithEquals{}
+	set := func(k, v string) {
+		v = escape(v)
+		env[k] = &v
+	}
+	// setFrom sets the variables from a ConfigMap or Secret skipping the keys that aren't valid variable names
+	// like Kubernetes does.
+	setFrom := func(data map[string]string, prefix string) {
+		var skipped []string
+		for _, k := range slices.Sorted(maps.Keys(data)) {
+			if !envVarNameRegexp.MatchString(prefix + k) {
+				skipped = append(skipped, k)
+				continue
+			}
+			set(prefix+k, data[k])
+		}
+		if len(skipped) > 0 {
+			im.warnf("%s: keys that are not valid environment variable names skipped in envFrom: %s.",
+				w.manifest, strings.Join(skipped, ", "))
+		}
+	}
+
+	for _, from := range ctr.EnvFrom {
+		switch {
+		case from.ConfigMapRef != nil:
+			data, ok := im.configMapData(from.ConfigMapRef.Name)
+			if !ok {
+				im.warnf("%s: ConfigMap '%s' referenced in envFrom not found.", w.manifest, from.ConfigMapRef.Name)
+				continue
+			}
+			setFrom(data, from.Prefix)
+		case from.SecretRef != nil:
+			data, ok := im.secretData(w, from.SecretRef.Name)
+			if !ok {
+				im.warnf("%s: Secret '%s' referenced in envFrom not found.", w.manifest, from.SecretRef.Name)
+				continue
+			}
+			setFrom(data, from.Prefix)
+		}
+	}
+
+	for _, e := range ctr.Env {
+		if e.ValueFrom == nil {
+			set(e.Name, unescapeExpanded(e.Value))
+			continue
+		}
+
+		switch {
+		case e.ValueFrom.ConfigMapKeyRef != nil:
+			ref := e.ValueFrom.ConfigMapKeyRef
+			data, _ := im.configMapData(ref.Name)
+			if v, ok := data[ref.Key]; ok {
+				set(e.Name, v)
+			} else {
+				im.warnf("%s: key '%s' of ConfigMap '%s' for env %s not found.",
+					w.manifest, ref.Key, ref.Name, e.Name)
+			}
+		case e.ValueFrom.SecretKeyRef != nil:
+			ref := e.ValueFrom.SecretKeyRef
+			data, _ := im.secretData(w, ref.Name)
+			if v, ok := data[ref.Key]; ok {
+				set(e.Name, v)
+			} else {
+				im.warnf("%s: key '%s' of Secret '%s' for env %s not found.", w.manifest, ref.Key, ref.Name, e.Name)
+			}
+		default:
+			im.warnf("%s: env %s uses an unsupported value source, skipped.", w.manifest, e.Name)
+		}
+	}
+
+	if len(env) == 0 {
+		return nil
+	}
+	return env
+}
+
+func (im *importer) configMapData(name string) (map[string]string, bool) {
+	cm, ok := im.configMaps[name]
+	if !ok {
+		return nil, false
+	}
+	data := maps.Clone(cm.Data)
+	if data == nil {
+		data = make(map[string]string)
+	}
+	for k, v := range cm.BinaryData {
+		data[k] = string(v)
+	}
+	return data, true
+}
+
+// secretData returns the decoded values of the Secret. It warns once per Secret that its values are inlined
+// to the Compose file in plain text.
+func (im *importer) secretData(w *workload, name string) (map[string]string, bool) {
+	s, ok := im.secrets[name]
+	if !ok {
+		return nil, false
+	}
+	if _, ok = im.inlinedSecrets[name]; !ok {
+		im.inlinedSecrets[name] = struct{}{}
+		im.warnf("%s: values of Secret '%s' are inlined in plain text. Review the Compose file before committing it.",
+			w.manifest, name)
+	}
+
+	data := make(map[string]string, len(s.Data)+len(s.StringData))
+	for k, v := range s.Data {
+		data[k] = string(v)
+	}
+	maps.Copy(data, s.StringData)
+	return data, true
+}
+
+func (im *importer) convertDeploy(w *workload, ctr corev1.Container) *types.DeployConfig {
+	deploy := &types.DeployConfig{}
+	if w.global {
+		deploy.Mode = "global"
+	} else if w.replicas != nil && *w.replicas != 1 {
+		replicas := int(*w.replicas)
+		deploy.Replicas = &replicas
+	}
+
+	limits := ctr.Resources.Limits
+	if cpu, ok := limits[corev1.ResourceCPU]; ok {
+		if deploy.Resources.Limits == nil {
+			deploy.Resources.Limits = &types.Resource{}
+		}
+		deploy.Resources.Limits.NanoCPUs = types.NanoCPUs(cpu.AsApproximateFloat64())
+	}
+	if mem, ok := limits[corev1.ResourceMemory]; ok {
+		if deploy.Resources.Limits == nil {
+			deploy.Resources.Limits = &types.Resource{}
+		}
+		deploy.Resources.Limits.MemoryBytes = types.UnitBytes(mem.Value())
+	}
+	if mem, ok := ctr.Resources.Requests[corev1.ResourceMemory]; ok {
+		deploy.Resources.Reservations = &types.Resource{MemoryBytes: types.UnitBytes(mem.Value())}
+	}
+	if _, ok := ctr.Resources.Requests[corev1.ResourceCPU]; ok {
+		im.warnf("%s: CPU requests are not supported, only CPU limits are converted.", w.manifest)
+	}
+
+	if deploy.Mode == "" && deploy.Replicas == nil &&
+		deploy.Resources.Limits == nil && deploy.Resources.Reservations == nil {
+		return nil
+	}
+	return deploy
+}
+
+// convertProbe converts the liveness probe or the readiness probe if the former is not set to a health check.
+// Only exec probes can be converted as the health check command runs inside the container.
+func (im *importer) convertProbe(w *workload, ctr corev1.Container) *types.HealthCheckConfig {
+	probe := ctr.LivenessProbe
+	if probe == nil {
+		probe = ctr.ReadinessProbe
+	}
+	if probe == nil {
+		return nil
+	}
+	if probe.Exec == nil {
+		im.warnf("%s: only exec probes can be converted to a health check, probe for container '%s' skipped.",
+			w.manifest, ctr.Name)
+		return nil
+	}
+
+	hc := &types.HealthCheckConfig{
+		Test: append(types.HealthCheckTest{"CMD"}, escapeAll(probe.Exec.Command)...),
+	}
+	seconds := func(s int32) *types.Duration {
+		d := types.Duration(time.Duration(s) * time.Second)
+		return &d
+	}
+	if probe.PeriodSeconds > 0 {
+		hc.Interval = seconds(probe.PeriodSeconds)
+	}
+	if probe.TimeoutSeconds > 0 {
+		hc.Timeout = seconds(probe.TimeoutSeconds)
+	}
+	if probe.InitialDelaySeconds > 0 {
+		hc.StartPeriod = seconds(probe.InitialDelaySeconds)
+	}
+	if probe.FailureThreshold > 0 {
+		retries := uint64(probe.FailureThreshold)
+		hc.Retries = &retries
+	}
+	return hc
+}
+
+func (im *importer) convertSecurityContext(w *workload, ctr corev1.Container, svc *types.ServiceConfig) {
+	var uid, gid *int64
+	if psc := w.template.Spec.SecurityContext; psc != nil {
+		uid, gid = psc.RunAsUser, psc.RunAsGroup
+	}
+
+	if sc := ctr.SecurityContext; sc != nil {
+		if sc.RunAsUser != nil {
+			uid = sc.RunAsUser
+		}
+		if sc.RunAsGroup != nil {
+			gid = sc.RunAsGroup
+		}
+		if sc.Privileged != nil {
+			svc.Privileged = *sc.Privileged
+		}
+		if sc.Capabilities != nil {
+			for _, c := range sc.Capabilities.Add {
+				svc.CapAdd = append(svc.CapAdd, string(c))
+			}
+			for _, c := range sc.Capabilities.Drop {
+				svc.CapDrop = append(svc.CapDrop, string(c))
+			}
+		}
+	}
+
+	if uid != nil {
+		svc.User = strconv.FormatInt(*uid, 10)
+		if gid != nil {
+			svc.User += ":" + strconv.FormatInt(*gid, 10)
+		}
+	}
+}
+
+func (im *importer) convertVolumeMounts(w *workload, ctr corev1.Container, svc *types.ServiceConfig) {
+	volumes := make(map[string]corev1.Volume, len(w.template.Spec.Volumes))
+	for _, v := range w.template.Spec.Volumes {
+		volumes[v.Name] = v
+	}
+
+	for _, mount := range ctr.VolumeMounts {
+		vol, ok := volumes[mount.Name]
+		if !ok {
+			if slices.Contains(w.claimTemplates, mount.Name) {
+				im.addNamedVolume(svc, mount.Name, mount)
+				continue
+			}
+			im.warnf("%s: volume '%s' not found.", w.manifest, mount.Name)
+			continue
+		}
+
+		switch {
+		case vol.ConfigMap != nil:
+			data, ok := im.configMapData(vol.ConfigMap.Name)
+			if !ok {
+				im.warnf("%s: ConfigMap '%s' for volume '%s' not found.", w.manifest, vol.ConfigMap.Name, vol.Name)
+				continue
+			}
+			im.addConfigFiles(svc, vol.ConfigMap.Name, data, vol.ConfigMap.Items, mount)
+		case vol.Secret != nil:
+			data, ok := im.secretData(w, vol.Secret.SecretName)
+			if !ok {
+				im.warnf("%s: Secret '%s' for volume '%s' not found.", w.manifest, vol.Secret.SecretName, vol.Name)
+				continue
+			}
+			im.addConfigFiles(svc, vol.Secret.SecretName, data, vol.Secret.Items, mount)
+		case vol.EmptyDir != nil:
+			if vol.EmptyDir.Medium == corev1.StorageMediumMemory {
+				svc.Volumes = append(svc.Volumes, types.ServiceVolumeConfig{
+					Type:   types.VolumeTypeTmpfs,
+					Target: mount.MountPath,
+				})
+				continue
+			}
+			im.warnf("%s: emptyDir volume '%s' is converted to a named volume that persists across deployments.",
+				w.manifest, vol.Name)
+			im.addNamedVolume(svc, w.serviceName+"-"+vol.Name, mount)
+		case vol.PersistentVolumeClaim != nil:
+			im.addNamedVolume(svc, vol.PersistentVolumeClaim.ClaimName, mount)
+		case vol.HostPath != nil:
+			svc.Volumes = append(svc.Volumes, types.ServiceVolumeConfig{
+				Type:     types.VolumeTypeBind,
+				Source:   vol.HostPath.Path,
+				Target:   mount.MountPath,
+				ReadOnly: mount.ReadOnly,
+			})
+		default:
+			im.warnf("%s: unsupported type of volume '%s', skipped.", w.manifest, vol.Name)
+		}
+	}
+}
+
+func (im *importer) addNamedVolume(svc *types.ServiceConfig, name string, mount corev1.VolumeMount) {
+	if im.project.Volumes == nil {
+		im.project.Volumes = types.Volumes{}
+	}
+	im.project.Volumes[name] = types.VolumeConfig{}
+	svc.Volumes = append(svc.Volumes, types.ServiceVolumeConfig{
+		Type:     types.VolumeTypeVolume,
+		Source:   name,
+		Target:   mount.MountPath,
+		ReadOnly: mount.ReadOnly,
+	})
+}
+
+var (
+	invalidConfigNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+	envVarNameRegexp       = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// addConfigFiles adds the ConfigMap or Secret keys as configs mounted to the service the same way Kubernetes
+// projects them as files into the volume mount path.
+func (im *importer) addConfigFiles(
+	svc *types.ServiceConfig, source string, data map[string]string, items []corev1.KeyToPath, mount corev1.VolumeMount,
+) {
+	paths := make(map[string]string)
+	if len(items) > 0 {
+		for _, item := range items {
+			paths[item.Key] = item.Path
+		}
+	} else {
+		for k := range data {
+			paths[k] = k
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(paths)) {
+		content, ok := data[key]
+		if !ok {
+			continue
+		}
+		target := path.Join(mount.MountPath, paths[key])
+		if mount.SubPath != "" {
+			if mount.SubPath != paths[key] {
+				continue
+			}
+			target = mount.MountPath
+		}
+
+		name := invalidConfigNameChars.ReplaceAllString(source+"-"+key, "-")
+		if im.project.Configs == nil {
+			im.project.Configs = types.Configs{}
+		}
+		im.project.Configs[name] = types.ConfigObjConfig{Content: escape(content)}
+		svc.Configs = append(svc.Configs, types.ServiceConfigObjConfig{Source: name, Target: target})
+	}
+}
+
+// resolveTargetPort returns the container port the Service port forwards traffic to.
+func resolveTargetPort(port corev1.ServicePort, w *workload) (int32, bool) {
+	switch {
+	case port.TargetPort.Type == intstr.String:
+		for _, ctr := range w.template.Spec.Containers {
+			for _, p := range ctr.Ports {
+				if p.Name == port.TargetPort.StrVal {
+					return p.ContainerPort, true
+				}
+			}
+		}
+		return 0, false
+	case port.TargetPort.IntVal != 0:
+		return port.TargetPort.IntVal, true
+	default:
+		return port.Port, true
+	}
+}
+
+func addPort(svc *types.ServiceConfig, port string) {
+	if svc.Extensions == nil {
+		svc.Extensions = types.Extensions{}
+	}
+	ports, _ := svc.Extensions[compose.PortsExtensionKey].([]string)
+	if !slices.Contains(ports, port) {
+		svc.Extensions[compose.PortsExtensionKey] = append(ports, port)
+	}
+}
+
+func (im *importer) convertService(svc corev1.Service) {
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		im.warnf("Service '%s': ExternalName services are not supported, skipped.", svc.Name)
+		return
+	}
+
+	selected := im.selectedWorkloads(svc)
+	if len(selected) == 0 {
+		im.warnf("Service '%s': doesn't select any converted workload, skipped.", svc.Name)
+		return
+	}
+
+	for _, w := range selected {
+		composeSvc := im.project.Services[w.serviceName]
+		if w.serviceName != svc.Name {
+			im.warnf("Service '%s': the selected pods are converted to service '%s'. "+
+				"Update the clients to use the new name.", svc.Name, w.serviceName)
+		}
+
+		for _, port := range svc.Spec.Ports {
+			target, ok := resolveTargetPort(port, w)
+			if !ok {
+				im.warnf("Service '%s': target port '%s' not found in %s.", svc.Name, port.TargetPort.String(),
+					w.manifest)
+				continue
+			}
+			protocol := strings.ToLower(string(port.Protocol))
+			if protocol == "" {
+				protocol = "tcp"
+			}
+
+			switch svc.Spec.Type {
+			case corev1.ServiceTypeNodePort:
+				if port.NodePort == 0 {
+					im.warnf("Service '%s': port %d has no explicit nodePort, not published.", svc.Name, port.Port)
+					continue
+				}
+				addPort(&composeSvc, fmt.Sprintf("%d:%d/%s@host", port.NodePort, target, protocol))
+			case corev1.ServiceTypeLoadBalancer:
+				im.warnf("Service '%s': LoadBalancer port %d is published on the host of every machine running "+
+					"the service. Consider using x-ports with the https protocol for HTTP services.",
+					svc.Name, port.Port)
+				addPort(&composeSvc, fmt.Sprintf("%d:%d/%s@host", port.Port, target, protocol))
+			default:
+				if port.Port != target {
+					im.warnf("Service '%s': port %d forwards to container port %d. "+
+						"Clients must connect to port %d directly in Uncloud.", svc.Name, port.Port, target, target)
+				}
+			}
+		}
+
+		im.project.Services[w.serviceName] = composeSvc
+	}
+}
+
+func (im *importer) convertIngress(ing networkingv1.Ingress) {
+	if len(ing.Spec.TLS) > 0 {
+		im.warnf("Ingress '%s': TLS secrets are ignored. "+
+			"Caddy obtains and renews certificates for the hostnames automatically.", ing.Name)
+	}
+	if ing.Spec.DefaultBackend != nil {
+		im.warnf("Ingress '%s': default backend is not supported, skipped.", ing.Name)
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			if p.Backend.Service == nil {
+				im.warnf("Ingress '%s': only Service backends are supported, path '%s' skipped.", ing.Name, p.Path)
+				continue
+			}
+			if p.Path != "" && p.Path != "/" {
+				im.warnf("Ingress '%s': path-based routing for '%s%s' is not supported by x-ports, "+
+					"the whole host is routed to the service. Use x-caddy for custom routing.",
+					ing.Name, rule.Host, p.Path)
+			}
+			im.addIngressPort(ing, rule.Host, p.Backend.Service)
+		}
+	}
+}
+
+func (im *importer) addIngressPort(ing networkingv1.Ingress, host string, backend *networkingv1.IngressServiceBackend) {
+	idx := slices.IndexFunc(im.services, func(s corev1.Service) bool {
+		return s.Name == backend.Name
+	})
+	if idx < 0 {
+		im.warnf("Ingress '%s': backend Service '%s' not found.", ing.Name, backend.Name)
+		return
+	}
+	svc := im.services[idx]
+
+	var svcPort *corev1.ServicePort
+	for i, p := range svc.Spec.Ports {
+		if (backend.Port.Name != "" && p.Name == backend.Port.Name) ||
+			(backend.Port.Name == "" && p.Port == backend.Port.Number) {
+			svcPort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if svcPort == nil {
+		im.warnf("Ingress '%s': port of backend Service '%s' not found.", ing.Name, backend.Name)
+		return
+	}
+
+	for _, w := range im.selectedWorkloads(svc) {
+		target, ok := resolveTargetPort(*svcPort, w)
+		if !ok {
+			continue
+		}
+
+		port := fmt.Sprintf("%d/https", target)
+		if host != "" {
+			port = host + ":" + port
+		}
+		composeSvc := im.project.Services[w.serviceName]
+		addPort(&composeSvc, port)
+		im.project.Services[w.serviceName] = composeSvc
+	}
+}
+
+// escape escapes the dollar signs so that neither Compose nor Kubernetes interpolate them as variables.
+// Both reduce "$$" to a single "$".
+func escape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+// unescapeExpanded reduces "$$" to "$" in the values that Kubernetes expands, such as env values, command,
+// and args, to get the literal value.
+func unescapeExpanded(s string) string {
+	return strings.ReplaceAll(s, "$$", "$")
+}
+
+func unescapeExpandedAll(ss []string) []string {
+	unescaped := make([]string, len(ss))
+	for i, s := range ss {
+		unescaped[i] = unescapeExpanded(s)
+	}
+	return unescaped
+}
+
+func escapeAll(ss []string) []string {
+	if len(ss) == 0 {
+		return nil
+	}
+	escaped := make([]string, len(ss))
+	for i, s := range ss {
+		escaped[i] = escape(s)
+	}
+	return escaped
+}
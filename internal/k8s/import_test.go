@@ -0,0 +1,327 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/client/compose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webManifests = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+data:
+  LOG_LEVEL: debug
+  nginx.conf: |
+    server { listen 8080; }
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: web-secret
+type: Opaque
+data:
+  password: cGEkJHdvcmQ=
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web-deployment
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: nginx
+          image: nginx:1.29
+          imagePullPolicy: Always
+          args: ["nginx", "-g", "daemon off;"]
+          ports:
+            - name: http
+              containerPort: 8080
+          envFrom:
+            - configMapRef:
+                name: web-config
+          env:
+            - name: DB_PASSWORD
+              valueFrom:
+                secretKeyRef:
+                  name: web-secret
+                  key: password
+            - name: POD_NAME
+              valueFrom:
+                fieldRef:
+                  fieldPath: metadata.name
+          resources:
+            limits:
+              cpu: 500m
+              memory: 256Mi
+            requests:
+              memory: 128Mi
+          livenessProbe:
+            exec:
+              command: ["test", "-f", "/tmp/healthy"]
+            periodSeconds: 10
+            failureThreshold: 3
+          volumeMounts:
+            - name: config
+              mountPath: /etc/nginx/conf.d/default.conf
+              subPath: nginx.conf
+            - name: data
+              mountPath: /data
+            - name: cache
+              mountPath: /cache
+      volumes:
+        - name: config
+          configMap:
+            name: web-config
+        - name: data
+          persistentVolumeClaim:
+            claimName: web-data
+        - name: cache
+          emptyDir:
+            medium: Memory
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+  ports:
+    - port: 80
+      targetPort: http
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  rules:
+    - host: example.com
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: web
+                port:
+                  number: 80
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+`
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	manifests, err := ParseManifests([]byte(webManifests), "web.yaml")
+	require.NoError(t, err)
+	require.Len(t, manifests, 6)
+
+	result, err := Import("demo", manifests)
+	require.NoError(t, err)
+
+	project := result.Project
+	require.Len(t, project.Services, 1)
+	// The Deployment is named after the Service that selects it to preserve the DNS name.
+	web, ok := project.Services["web"]
+	require.True(t, ok)
+
+	assert.Equal(t, "nginx:1.29", web.Image)
+	assert.Equal(t, types.PullPolicyAlways, web.PullPolicy)
+	assert.Equal(t, types.ShellCommand{"nginx", "-g", "daemon off;"}, web.Command)
+	require.NotNil(t, web.Environment["LOG_LEVEL"])
+	assert.Equal(t, "debug", *web.Environment["LOG_LEVEL"])
+	require.NotNil(t, web.Environment["DB_PASSWORD"])
+	assert.Equal(t, "pa$$$$word", *web.Environment["DB_PASSWORD"], "dollar signs must be escaped")
+	assert.NotContains(t, web.Environment, "POD_NAME")
+	assert.NotContains(t, web.Environment, "nginx.conf")
+
+	require.NotNil(t, web.Deploy)
+	assert.Equal(t, 3, *web.Deploy.Replicas)
+	assert.InDelta(t, 0.5, float64(web.Deploy.Resources.Limits.NanoCPUs), 0.001)
+	assert.Equal(t, types.UnitBytes(256*1024*1024), web.Deploy.Resources.Limits.MemoryBytes)
+	assert.Equal(t, types.UnitBytes(128*1024*1024), web.Deploy.Resources.Reservations.MemoryBytes)
+
+	require.NotNil(t, web.HealthCheck)
+	assert.Equal(t, types.HealthCheckTest{"CMD", "test", "-f", "/tmp/healthy"}, web.HealthCheck.Test)
+	assert.Equal(t, uint64(3), *web.HealthCheck.Retries)
+
+	assert.Equal(t, []types.ServiceConfigObjConfig{
+		{Source: "web-config-nginx.conf", Target: "/etc/nginx/conf.d/default.conf"},
+	}, web.Configs)
+	assert.Equal(t, "server { listen 8080; }\n", project.Configs["web-config-nginx.conf"].Content)
+	assert.Equal(t, []types.ServiceVolumeConfig{
+		{Type: types.VolumeTypeVolume, Source: "web-data", Target: "/data"},
+		{Type: types.VolumeTypeTmpfs, Target: "/cache"},
+	}, web.Volumes)
+	assert.Contains(t, project.Volumes, "web-data")
+
+	assert.Equal(t, []string{"example.com:8080/https"}, web.Extensions[compose.PortsExtensionKey])
+
+	assert.Contains(t, result.Warnings, "Deployment 'web-deployment': env POD_NAME uses an unsupported value "+
+		"source, skipped.")
+	assert.Contains(t, result.Warnings, "Service 'web': port 80 forwards to container port 8080. "+
+		"Clients must connect to port 8080 directly in Uncloud.")
+	assert.Contains(t, result.Warnings, "Skipped objects that have no equivalent in Uncloud: ServiceAccount 'web'.")
+
+	// The generated Compose file must be loadable and deployable.
+	data, err := project.MarshalYAML()
+	require.NoError(t, err)
+	loaded, err := compose.LoadProjectFromContent(context.Background(), string(data))
+	require.NoError(t, err)
+	loadedWeb, err := loaded.GetService("web")
+	require.NoError(t, err)
+	assert.Equal(t, "pa$$word", *loadedWeb.Environment["DB_PASSWORD"])
+
+	_, err = compose.ServiceSpecFromCompose(loaded, "web")
+	require.NoError(t, err)
+}
+
+func TestImport_ServiceTypes(t *testing.T) {
+	t.Parallel()
+
+	const manifestsYAML = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:
+  selector:
+    matchLabels:
+      app: agent
+  template:
+    metadata:
+      labels:
+        app: agent
+    spec:
+      containers:
+        - name: agent
+          image: agent:1.0
+        - name: sidecar
+          image: sidecar:1.0
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: agent-nodeport
+spec:
+  type: NodePort
+  selector:
+    app: agent
+  ports:
+    - port: 9100
+      nodePort: 30100
+      protocol: UDP
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: agent-lb
+spec:
+  type: LoadBalancer
+  selector:
+    app: agent
+  ports:
+    - port: 443
+      targetPort: 8443
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: external
+spec:
+  type: ExternalName
+  externalName: example.com
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+`
+
+	manifests, err := ParseManifests([]byte(manifestsYAML), "agent.yaml")
+	require.NoError(t, err)
+
+	result, err := Import("demo", manifests)
+	require.NoError(t, err)
+
+	// The DaemonSet is selected by two Services so it keeps its own name.
+	require.Len(t, result.Project.Services, 2)
+	agent := result.Project.Services["agent"]
+	require.NotNil(t, agent.Deploy)
+	assert.Equal(t, "global", agent.Deploy.Mode)
+	assert.Equal(t, []string{"30100:9100/udp@host", "443:8443/tcp@host"},
+		agent.Extensions[compose.PortsExtensionKey])
+	assert.Equal(t, "sidecar:1.0", result.Project.Services["agent-sidecar"].Image)
+
+	assert.Contains(t, result.Warnings, "CronJob 'backup': unsupported kind, skipped.")
+	assert.Contains(t, result.Warnings, "Service 'external': ExternalName services are not supported, skipped.")
+}
+
+func TestParseManifests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      string
+		wantKinds []string
+		wantErr   string
+	}{
+		{
+			name: "multiple documents with empty ones",
+			data: "---\n# comment only\n---\nkind: ConfigMap\napiVersion: v1\nmetadata:\n  name: a\n---\n",
+			wantKinds: []string{
+				"ConfigMap",
+			},
+		},
+		{
+			name: "list",
+			data: `{"apiVersion": "v1", "kind": "List", "items": [
+				{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "a"}},
+				{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "a"}}
+			]}`,
+			wantKinds: []string{"Service", "Deployment"},
+		},
+		{
+			name:    "missing kind",
+			data:    "apiVersion: v1\nmetadata:\n  name: a\n",
+			wantErr: "kind is not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifests, err := ParseManifests([]byte(tt.data), "test.yaml")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var kinds []string
+			for _, m := range manifests {
+				kinds = append(kinds, m.Kind)
+			}
+			assert.Equal(t, tt.wantKinds, kinds)
+		})
+	}
+}
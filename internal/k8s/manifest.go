@@ -0,0 +1,196 @@
+// Package k8s converts between Kubernetes manifests and Uncloud Compose projects on a best-effort basis.
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Manifest is a single Kubernetes object document from a manifest file.
+type Manifest struct {
+	metav1.TypeMeta
+	Metadata metav1.ObjectMeta `json:"metadata"`
+	// Source is the path of the file the manifest was read from.
+	Source string `json:"-"`
+	// Raw is the YAML or JSON document of the object.
+	Raw []byte `json:"-"`
+}
+
+// String returns a human-readable identifier of the manifest, for example, "Deployment 'web'".
+func (m Manifest) String() string {
+	return fmt.Sprintf("%s '%s'", m.Kind, m.Metadata.Name)
+}
+
+// Decode unmarshals the raw manifest document into the given typed Kubernetes object.
+func (m Manifest) Decode(obj any) error {
+	if err := yaml.Unmarshal(m.Raw, obj); err != nil {
+		return fmt.Errorf("decode %s from %s: %w", m, m.Source, err)
+	}
+	return nil
+}
+
+// ReadManifests reads Kubernetes manifests from the given files or directories. Directories are walked recursively
+// for .yaml, .yml, and .json files. Multi-document YAML files and List objects are split into separate manifests.
+func ReadManifests(paths []string) ([]Manifest, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+
+		err = filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && slices.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(path)) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read directory '%s': %w", p, err)
+		}
+	}
+
+	var manifests []Manifest
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		ms, err := ParseManifests(data, f)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, ms...)
+	}
+
+	return manifests, nil
+}
+
+// ParseManifests parses Kubernetes manifests from a (multi-document) YAML or JSON data. Empty documents are skipped.
+func ParseManifests(data []byte, source string) ([]Manifest, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var manifests []Manifest
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read manifest from %s: %w", source, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		ms, err := parseManifest(doc, source)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, ms...)
+	}
+
+	return manifests, nil
+}
+
+func parseManifest(doc []byte, source string) ([]Manifest, error) {
+	m := Manifest{Source: source, Raw: doc}
+	if err := yaml.Unmarshal(doc, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest from %s: %w", source, err)
+	}
+	// Skip documents that contain only comments.
+	if m.Kind == "" && m.APIVersion == "" {
+		return nil, nil
+	}
+	if m.Kind == "" {
+		return nil, fmt.Errorf("parse manifest from %s: kind is not set", source)
+	}
+
+	if !strings.HasSuffix(m.Kind, "List") {
+		return []Manifest{m}, nil
+	}
+
+	var list struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := yaml.Unmarshal(doc, &list); err != nil {
+		return nil, fmt.Errorf("parse %s from %s: %w", m.Kind, source, err)
+	}
+
+	var manifests []Manifest
+	for _, item := range list.Items {
+		ms, err := parseManifest(item, source)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, ms...)
+	}
+	return manifests, nil
+}
+
+// MarshalYAML marshals the Kubernetes objects to a multi-document YAML. Empty status and creation timestamp fields
+// that the typed objects always contain are omitted to keep the manifests clean.
+func MarshalYAML(objects []runtime.Object) ([]byte, error) {
+	var buf bytes.Buffer
+	for i, obj := range objects {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("marshal object: %w", err)
+		}
+		var doc map[string]any
+		if err = json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("unmarshal object: %w", err)
+		}
+		delete(doc, "status")
+		removeNulls(doc)
+
+		data, err = yaml.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("marshal object to YAML: %w", err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// removeNulls recursively removes the fields with null values from the object.
+func removeNulls(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if val == nil {
+				delete(v, k)
+				continue
+			}
+			removeNulls(val)
+		}
+	case []any:
+		for _, val := range v {
+			removeNulls(val)
+		}
+	}
+}
@@ -7,6 +7,10 @@ import (
 	"strings"
 )
 
+// DaemonLevel is the minimum level of the daemon logs. It can be changed at runtime, for example, when the machine
+// is configured with a resource profile that reduces the log volume.
+var DaemonLevel = new(slog.LevelVar)
+
 func InitLoggerFromEnv() {
 	debugValues := []string{"1", "true", "yes"}
 	if slices.Contains(debugValues, strings.ToLower(os.Getenv("DEBUG"))) {
@@ -8,6 +8,25 @@ import (
 	"sync"
 )
 
+const (
+	// FormatText is the human-readable log format: LEVEL MESSAGE key1=value1 key2=value2.
+	FormatText = "text"
+	// FormatJSON is the log format with one JSON object per line for log collectors.
+	FormatJSON = "json"
+)
+
+// NewHandler returns a slog handler that writes logs in the given format (FormatText or FormatJSON).
+func NewHandler(w io.Writer, format string, opts *slog.HandlerOptions) (slog.Handler, error) {
+	switch format {
+	case "", FormatText:
+		return NewSlogTextHandler(w, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("invalid log format '%s': must be '%s' or '%s'", format, FormatText, FormatJSON)
+	}
+}
+
 // SlogTextHandler extends the standard [slog.TextHandler] to provide custom formatting that is very similar
 // to the default slog handler. It prefixes each log entry with a right-padded level indicator and message,
 // while excluding the default time, level, and message attributes from the structured output.
@@ -0,0 +1,28 @@
+package log
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ParseLevel parses a log level name: debug, info, warn, or error. The name is case-insensitive.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level '%s': must be one of: debug, info, warn, error", name)
+	}
+}
+
+// LevelName returns the lowercase name of the log level, e.g. debug.
+func LevelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
@@ -0,0 +1,33 @@
+package log
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"INFO":    slog.LevelInfo,
+		" warn ":  slog.LevelWarn,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+	}
+	for name, want := range tests {
+		level, err := ParseLevel(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, level, name)
+
+		roundTrip, err := ParseLevel(LevelName(level))
+		require.NoError(t, err, name)
+		assert.Equal(t, want, roundTrip, "level name must round-trip")
+	}
+
+	_, err := ParseLevel("trace")
+	assert.ErrorContains(t, err, "invalid log level 'trace'")
+}
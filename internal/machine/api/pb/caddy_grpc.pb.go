@@ -20,7 +20,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Caddy_GetConfig_FullMethodName = "/api.Caddy/GetConfig"
+	Caddy_GetConfig_FullMethodName     = "/api.Caddy/GetConfig"
+	Caddy_GetJSONConfig_FullMethodName = "/api.Caddy/GetJSONConfig"
 )
 
 // CaddyClient is the client API for Caddy service.
@@ -29,6 +30,8 @@ const (
 type CaddyClient interface {
 	// GetConfig retrieves the current Caddy configuration from the machine.
 	GetConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCaddyConfigResponse, error)
+	// GetJSONConfig retrieves the JSON configuration currently loaded into Caddy running on the machine.
+	GetJSONConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCaddyJSONConfigResponse, error)
 }
 
 type caddyClient struct {
@@ -49,12 +52,24 @@ func (c *caddyClient) GetConfig(ctx context.Context, in *emptypb.Empty, opts ...
 	return out, nil
 }
 
+func (c *caddyClient) GetJSONConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetCaddyJSONConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCaddyJSONConfigResponse)
+	err := c.cc.Invoke(ctx, Caddy_GetJSONConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CaddyServer is the server API for Caddy service.
 // All implementations must embed UnimplementedCaddyServer
 // for forward compatibility.
 type CaddyServer interface {
 	// GetConfig retrieves the current Caddy configuration from the machine.
 	GetConfig(context.Context, *emptypb.Empty) (*GetCaddyConfigResponse, error)
+	// GetJSONConfig retrieves the JSON configuration currently loaded into Caddy running on the machine.
+	GetJSONConfig(context.Context, *emptypb.Empty) (*GetCaddyJSONConfigResponse, error)
 	mustEmbedUnimplementedCaddyServer()
 }
 
@@ -68,6 +83,9 @@ type UnimplementedCaddyServer struct{}
 func (UnimplementedCaddyServer) GetConfig(context.Context, *emptypb.Empty) (*GetCaddyConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
 }
+func (UnimplementedCaddyServer) GetJSONConfig(context.Context, *emptypb.Empty) (*GetCaddyJSONConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJSONConfig not implemented")
+}
 func (UnimplementedCaddyServer) mustEmbedUnimplementedCaddyServer() {}
 func (UnimplementedCaddyServer) testEmbeddedByValue()               {}
 
@@ -107,6 +125,24 @@ func _Caddy_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Caddy_GetJSONConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaddyServer).GetJSONConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Caddy_GetJSONConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaddyServer).GetJSONConfig(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Caddy_ServiceDesc is the grpc.ServiceDesc for Caddy service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -118,6 +154,10 @@ var Caddy_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfig",
 			Handler:    _Caddy_GetConfig_Handler,
 		},
+		{
+			MethodName: "GetJSONConfig",
+			Handler:    _Caddy_GetJSONConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/machine/api/pb/caddy.proto",
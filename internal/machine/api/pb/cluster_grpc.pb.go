@@ -20,14 +20,57 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Cluster_AddMachine_FullMethodName          = "/api.Cluster/AddMachine"
-	Cluster_ListMachines_FullMethodName        = "/api.Cluster/ListMachines"
-	Cluster_UpdateMachine_FullMethodName       = "/api.Cluster/UpdateMachine"
-	Cluster_RemoveMachine_FullMethodName       = "/api.Cluster/RemoveMachine"
-	Cluster_ReserveDomain_FullMethodName       = "/api.Cluster/ReserveDomain"
-	Cluster_GetDomain_FullMethodName           = "/api.Cluster/GetDomain"
-	Cluster_ReleaseDomain_FullMethodName       = "/api.Cluster/ReleaseDomain"
-	Cluster_CreateDomainRecords_FullMethodName = "/api.Cluster/CreateDomainRecords"
+	Cluster_AddMachine_FullMethodName                = "/api.Cluster/AddMachine"
+	Cluster_ListMachines_FullMethodName              = "/api.Cluster/ListMachines"
+	Cluster_UpdateMachine_FullMethodName             = "/api.Cluster/UpdateMachine"
+	Cluster_RemoveMachine_FullMethodName             = "/api.Cluster/RemoveMachine"
+	Cluster_ReserveDomain_FullMethodName             = "/api.Cluster/ReserveDomain"
+	Cluster_GetDomain_FullMethodName                 = "/api.Cluster/GetDomain"
+	Cluster_ReleaseDomain_FullMethodName             = "/api.Cluster/ReleaseDomain"
+	Cluster_CreateDomainRecords_FullMethodName       = "/api.Cluster/CreateDomainRecords"
+	Cluster_PinImage_FullMethodName                  = "/api.Cluster/PinImage"
+	Cluster_UnpinImage_FullMethodName                = "/api.Cluster/UnpinImage"
+	Cluster_ListPinnedImages_FullMethodName          = "/api.Cluster/ListPinnedImages"
+	Cluster_SetDefaultUlimits_FullMethodName         = "/api.Cluster/SetDefaultUlimits"
+	Cluster_RemoveDefaultUlimits_FullMethodName      = "/api.Cluster/RemoveDefaultUlimits"
+	Cluster_ListDefaultUlimits_FullMethodName        = "/api.Cluster/ListDefaultUlimits"
+	Cluster_AllowSysctls_FullMethodName              = "/api.Cluster/AllowSysctls"
+	Cluster_DisallowSysctls_FullMethodName           = "/api.Cluster/DisallowSysctls"
+	Cluster_ListAllowedSysctls_FullMethodName        = "/api.Cluster/ListAllowedSysctls"
+	Cluster_SetUsernsRemap_FullMethodName            = "/api.Cluster/SetUsernsRemap"
+	Cluster_GetUsernsRemap_FullMethodName            = "/api.Cluster/GetUsernsRemap"
+	Cluster_GetSealingKey_FullMethodName             = "/api.Cluster/GetSealingKey"
+	Cluster_SetSecretMaxMode_FullMethodName          = "/api.Cluster/SetSecretMaxMode"
+	Cluster_GetSecretMaxMode_FullMethodName          = "/api.Cluster/GetSecretMaxMode"
+	Cluster_CreateSecret_FullMethodName              = "/api.Cluster/CreateSecret"
+	Cluster_ListSecrets_FullMethodName               = "/api.Cluster/ListSecrets"
+	Cluster_InspectSecret_FullMethodName             = "/api.Cluster/InspectSecret"
+	Cluster_RemoveSecret_FullMethodName              = "/api.Cluster/RemoveSecret"
+	Cluster_SetServiceEnv_FullMethodName             = "/api.Cluster/SetServiceEnv"
+	Cluster_UnsetServiceEnv_FullMethodName           = "/api.Cluster/UnsetServiceEnv"
+	Cluster_GetServiceEnv_FullMethodName             = "/api.Cluster/GetServiceEnv"
+	Cluster_ReservePublished_FullMethodName          = "/api.Cluster/ReservePublished"
+	Cluster_ReleasePublished_FullMethodName          = "/api.Cluster/ReleasePublished"
+	Cluster_ListPublishedReservations_FullMethodName = "/api.Cluster/ListPublishedReservations"
+	Cluster_UpdateProject_FullMethodName             = "/api.Cluster/UpdateProject"
+	Cluster_ListProjects_FullMethodName              = "/api.Cluster/ListProjects"
+	Cluster_SetAutoUpdatePaused_FullMethodName       = "/api.Cluster/SetAutoUpdatePaused"
+	Cluster_GetAutoUpdatePaused_FullMethodName       = "/api.Cluster/GetAutoUpdatePaused"
+	Cluster_GetAutoUpdateStatus_FullMethodName       = "/api.Cluster/GetAutoUpdateStatus"
+	Cluster_GetHostUpdates_FullMethodName            = "/api.Cluster/GetHostUpdates"
+	Cluster_SetIngressVIP_FullMethodName             = "/api.Cluster/SetIngressVIP"
+	Cluster_GetIngressVIP_FullMethodName             = "/api.Cluster/GetIngressVIP"
+	Cluster_SetACMEDNS_FullMethodName                = "/api.Cluster/SetACMEDNS"
+	Cluster_GetACMEDNS_FullMethodName                = "/api.Cluster/GetACMEDNS"
+	Cluster_GetIngressEvents_FullMethodName          = "/api.Cluster/GetIngressEvents"
+	Cluster_SetDNSConfig_FullMethodName              = "/api.Cluster/SetDNSConfig"
+	Cluster_GetDNSConfig_FullMethodName              = "/api.Cluster/GetDNSConfig"
+	Cluster_SetMeshConfig_FullMethodName             = "/api.Cluster/SetMeshConfig"
+	Cluster_GetMeshConfig_FullMethodName             = "/api.Cluster/GetMeshConfig"
+	Cluster_GetNetwork_FullMethodName                = "/api.Cluster/GetNetwork"
+	Cluster_SetNetwork_FullMethodName                = "/api.Cluster/SetNetwork"
+	Cluster_ReallocateMachineSubnet_FullMethodName   = "/api.Cluster/ReallocateMachineSubnet"
+	Cluster_WatchEvents_FullMethodName               = "/api.Cluster/WatchEvents"
 )
 
 // ClusterClient is the client API for Cluster service.
@@ -42,6 +85,96 @@ type ClusterClient interface {
 	GetDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error)
 	ReleaseDomain(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Domain, error)
 	CreateDomainRecords(ctx context.Context, in *CreateDomainRecordsRequest, opts ...grpc.CallOption) (*CreateDomainRecordsResponse, error)
+	// PinImage adds image references to the cluster-wide list of pinned images that are protected from pruning.
+	PinImage(ctx context.Context, in *PinImageRequest, opts ...grpc.CallOption) (*PinnedImages, error)
+	// UnpinImage removes image references from the cluster-wide list of pinned images.
+	UnpinImage(ctx context.Context, in *PinImageRequest, opts ...grpc.CallOption) (*PinnedImages, error)
+	ListPinnedImages(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PinnedImages, error)
+	// SetDefaultUlimits adds or replaces cluster-wide default ulimits applied to every service container created
+	// afterwards. Ulimits set for a service override the defaults with the same name.
+	SetDefaultUlimits(ctx context.Context, in *DefaultUlimits, opts ...grpc.CallOption) (*DefaultUlimits, error)
+	// RemoveDefaultUlimits removes cluster-wide default ulimits by name.
+	RemoveDefaultUlimits(ctx context.Context, in *RemoveDefaultUlimitsRequest, opts ...grpc.CallOption) (*DefaultUlimits, error)
+	ListDefaultUlimits(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DefaultUlimits, error)
+	// AllowSysctls adds patterns to the cluster sysctl allow-list. When the list isn't empty, service containers
+	// can only set sysctls that match one of the patterns.
+	AllowSysctls(ctx context.Context, in *SysctlsRequest, opts ...grpc.CallOption) (*AllowedSysctls, error)
+	// DisallowSysctls removes patterns from the cluster sysctl allow-list.
+	DisallowSysctls(ctx context.Context, in *SysctlsRequest, opts ...grpc.CallOption) (*AllowedSysctls, error)
+	ListAllowedSysctls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AllowedSysctls, error)
+	// SetUsernsRemap enables or disables user namespace remapping for the cluster. New machines are installed with
+	// userns-remap configured in Docker and existing machines report if their Docker doesn't support it.
+	SetUsernsRemap(ctx context.Context, in *UsernsRemap, opts ...grpc.CallOption) (*UsernsRemap, error)
+	GetUsernsRemap(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UsernsRemap, error)
+	// GetSealingKey returns the public key for sealing secrets that only the cluster machines can decrypt.
+	// The cluster sealing key pair is generated on first use.
+	GetSealingKey(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SealingKey, error)
+	// SetSecretMaxMode sets the most permissive file mode allowed for secrets mounted into service containers.
+	// Zero mode removes the limit.
+	SetSecretMaxMode(ctx context.Context, in *SecretMaxMode, opts ...grpc.CallOption) (*SecretMaxMode, error)
+	GetSecretMaxMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SecretMaxMode, error)
+	// CreateSecret stores a secret encrypted with the cluster sealing key so that services can reference it by name.
+	// It fails with AlreadyExists if the secret exists unless update is set.
+	CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*ClusterSecret, error)
+	ListSecrets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterSecrets, error)
+	InspectSecret(ctx context.Context, in *InspectSecretRequest, opts ...grpc.CallOption) (*ClusterSecret, error)
+	RemoveSecret(ctx context.Context, in *RemoveSecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SetServiceEnv sets environment variable overrides for a Compose service. They're merged into the service
+	// environment from the Compose file on deploy.
+	SetServiceEnv(ctx context.Context, in *SetServiceEnvRequest, opts ...grpc.CallOption) (*ServiceEnv, error)
+	// UnsetServiceEnv removes environment variable overrides for a Compose service.
+	UnsetServiceEnv(ctx context.Context, in *UnsetServiceEnvRequest, opts ...grpc.CallOption) (*ServiceEnv, error)
+	GetServiceEnv(ctx context.Context, in *GetServiceEnvRequest, opts ...grpc.CallOption) (*ServiceEnv, error)
+	// ReservePublished reserves the published ports and ingress hostnames for a Compose project so that other projects
+	// can't claim them. It fails with AlreadyExists if another project has already reserved any of them.
+	ReservePublished(ctx context.Context, in *ReservePublishedRequest, opts ...grpc.CallOption) (*PublishedReservations, error)
+	// ReleasePublished releases all published ports and ingress hostnames reserved by a Compose project.
+	ReleasePublished(ctx context.Context, in *ReleasePublishedRequest, opts ...grpc.CallOption) (*PublishedReservations, error)
+	ListPublishedReservations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PublishedReservations, error)
+	// UpdateProject updates the metadata of a Compose project and records the services deployed from it. Services
+	// recorded for another project are moved to this one.
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	ListProjects(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Projects, error)
+	// SetAutoUpdatePaused pauses or resumes automatic image updates for a service or for all services if the service
+	// name is empty.
+	SetAutoUpdatePaused(ctx context.Context, in *SetAutoUpdatePausedRequest, opts ...grpc.CallOption) (*AutoUpdatePaused, error)
+	GetAutoUpdatePaused(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AutoUpdatePaused, error)
+	// GetAutoUpdateStatus returns the state of automatic image updates for a service.
+	GetAutoUpdateStatus(ctx context.Context, in *GetAutoUpdateStatusRequest, opts ...grpc.CallOption) (*AutoUpdateStatus, error)
+	// GetHostUpdates returns the history of OS package updates on a machine host.
+	GetHostUpdates(ctx context.Context, in *GetHostUpdatesRequest, opts ...grpc.CallOption) (*HostUpdates, error)
+	// SetIngressVIP sets the virtual IP address that floats among ingress machines. An empty config removes it.
+	SetIngressVIP(ctx context.Context, in *IngressVIP, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetIngressVIP returns the virtual IP configuration and the machine that currently holds the address.
+	GetIngressVIP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IngressVIP, error)
+	// SetACMEDNS sets the ACME DNS-01 challenge configuration for issuing certificates on ingress machines.
+	// An empty config removes it.
+	SetACMEDNS(ctx context.Context, in *ACMEDNS, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetACMEDNS returns the ACME DNS-01 challenge configuration.
+	GetACMEDNS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ACMEDNS, error)
+	// GetIngressEvents returns the most recent changes of the ingress configuration on a machine.
+	GetIngressEvents(ctx context.Context, in *GetIngressEventsRequest, opts ...grpc.CallOption) (*IngressEvents, error)
+	// SetDNSConfig sets the forwarding configuration of the embedded DNS servers on machines. An empty config
+	// removes it so that machines forward queries to the nameservers from their /etc/resolv.conf.
+	SetDNSConfig(ctx context.Context, in *DNSConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetDNSConfig returns the forwarding configuration of the embedded DNS servers.
+	GetDNSConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DNSConfig, error)
+	// SetMeshConfig sets the configuration of the certificates issued for mTLS-enabled services. An empty config
+	// removes it so that machines use the default clock tolerance and renewal windows.
+	SetMeshConfig(ctx context.Context, in *MeshConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetMeshConfig returns the configuration of the certificates issued for mTLS-enabled services.
+	GetMeshConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MeshConfig, error)
+	// GetNetwork returns the cluster network from which machine subnets are allocated.
+	GetNetwork(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterNetwork, error)
+	// SetNetwork changes the cluster network. Existing machine subnets are not changed until they are reallocated
+	// with ReallocateMachineSubnet.
+	SetNetwork(ctx context.Context, in *ClusterNetwork, opts ...grpc.CallOption) (*ClusterNetwork, error)
+	// ReallocateMachineSubnet allocates a new subnet from the cluster network for the machine if its current subnet
+	// is outside the network.
+	ReallocateMachineSubnet(ctx context.Context, in *ReallocateMachineSubnetRequest, opts ...grpc.CallOption) (*UpdateMachineResponse, error)
+	// WatchEvents streams an event each time the machines or containers in the cluster store change until
+	// the client cancels the request.
+	WatchEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ClusterEvent], error)
 }
 
 type clusterClient struct {
@@ -132,6 +265,445 @@ func (c *clusterClient) CreateDomainRecords(ctx context.Context, in *CreateDomai
 	return out, nil
 }
 
+func (c *clusterClient) PinImage(ctx context.Context, in *PinImageRequest, opts ...grpc.CallOption) (*PinnedImages, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinnedImages)
+	err := c.cc.Invoke(ctx, Cluster_PinImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) UnpinImage(ctx context.Context, in *PinImageRequest, opts ...grpc.CallOption) (*PinnedImages, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinnedImages)
+	err := c.cc.Invoke(ctx, Cluster_UnpinImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListPinnedImages(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PinnedImages, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinnedImages)
+	err := c.cc.Invoke(ctx, Cluster_ListPinnedImages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetDefaultUlimits(ctx context.Context, in *DefaultUlimits, opts ...grpc.CallOption) (*DefaultUlimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DefaultUlimits)
+	err := c.cc.Invoke(ctx, Cluster_SetDefaultUlimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemoveDefaultUlimits(ctx context.Context, in *RemoveDefaultUlimitsRequest, opts ...grpc.CallOption) (*DefaultUlimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DefaultUlimits)
+	err := c.cc.Invoke(ctx, Cluster_RemoveDefaultUlimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListDefaultUlimits(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DefaultUlimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DefaultUlimits)
+	err := c.cc.Invoke(ctx, Cluster_ListDefaultUlimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) AllowSysctls(ctx context.Context, in *SysctlsRequest, opts ...grpc.CallOption) (*AllowedSysctls, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllowedSysctls)
+	err := c.cc.Invoke(ctx, Cluster_AllowSysctls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) DisallowSysctls(ctx context.Context, in *SysctlsRequest, opts ...grpc.CallOption) (*AllowedSysctls, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllowedSysctls)
+	err := c.cc.Invoke(ctx, Cluster_DisallowSysctls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListAllowedSysctls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AllowedSysctls, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllowedSysctls)
+	err := c.cc.Invoke(ctx, Cluster_ListAllowedSysctls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetUsernsRemap(ctx context.Context, in *UsernsRemap, opts ...grpc.CallOption) (*UsernsRemap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsernsRemap)
+	err := c.cc.Invoke(ctx, Cluster_SetUsernsRemap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetUsernsRemap(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UsernsRemap, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UsernsRemap)
+	err := c.cc.Invoke(ctx, Cluster_GetUsernsRemap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetSealingKey(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SealingKey, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SealingKey)
+	err := c.cc.Invoke(ctx, Cluster_GetSealingKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetSecretMaxMode(ctx context.Context, in *SecretMaxMode, opts ...grpc.CallOption) (*SecretMaxMode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretMaxMode)
+	err := c.cc.Invoke(ctx, Cluster_SetSecretMaxMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetSecretMaxMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SecretMaxMode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretMaxMode)
+	err := c.cc.Invoke(ctx, Cluster_GetSecretMaxMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*ClusterSecret, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterSecret)
+	err := c.cc.Invoke(ctx, Cluster_CreateSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListSecrets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterSecrets, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterSecrets)
+	err := c.cc.Invoke(ctx, Cluster_ListSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) InspectSecret(ctx context.Context, in *InspectSecretRequest, opts ...grpc.CallOption) (*ClusterSecret, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterSecret)
+	err := c.cc.Invoke(ctx, Cluster_InspectSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) RemoveSecret(ctx context.Context, in *RemoveSecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_RemoveSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetServiceEnv(ctx context.Context, in *SetServiceEnvRequest, opts ...grpc.CallOption) (*ServiceEnv, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceEnv)
+	err := c.cc.Invoke(ctx, Cluster_SetServiceEnv_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) UnsetServiceEnv(ctx context.Context, in *UnsetServiceEnvRequest, opts ...grpc.CallOption) (*ServiceEnv, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceEnv)
+	err := c.cc.Invoke(ctx, Cluster_UnsetServiceEnv_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetServiceEnv(ctx context.Context, in *GetServiceEnvRequest, opts ...grpc.CallOption) (*ServiceEnv, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceEnv)
+	err := c.cc.Invoke(ctx, Cluster_GetServiceEnv_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ReservePublished(ctx context.Context, in *ReservePublishedRequest, opts ...grpc.CallOption) (*PublishedReservations, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishedReservations)
+	err := c.cc.Invoke(ctx, Cluster_ReservePublished_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ReleasePublished(ctx context.Context, in *ReleasePublishedRequest, opts ...grpc.CallOption) (*PublishedReservations, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishedReservations)
+	err := c.cc.Invoke(ctx, Cluster_ReleasePublished_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListPublishedReservations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PublishedReservations, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishedReservations)
+	err := c.cc.Invoke(ctx, Cluster_ListPublishedReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, Cluster_UpdateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ListProjects(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Projects, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Projects)
+	err := c.cc.Invoke(ctx, Cluster_ListProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetAutoUpdatePaused(ctx context.Context, in *SetAutoUpdatePausedRequest, opts ...grpc.CallOption) (*AutoUpdatePaused, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutoUpdatePaused)
+	err := c.cc.Invoke(ctx, Cluster_SetAutoUpdatePaused_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetAutoUpdatePaused(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AutoUpdatePaused, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutoUpdatePaused)
+	err := c.cc.Invoke(ctx, Cluster_GetAutoUpdatePaused_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetAutoUpdateStatus(ctx context.Context, in *GetAutoUpdateStatusRequest, opts ...grpc.CallOption) (*AutoUpdateStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AutoUpdateStatus)
+	err := c.cc.Invoke(ctx, Cluster_GetAutoUpdateStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetHostUpdates(ctx context.Context, in *GetHostUpdatesRequest, opts ...grpc.CallOption) (*HostUpdates, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostUpdates)
+	err := c.cc.Invoke(ctx, Cluster_GetHostUpdates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetIngressVIP(ctx context.Context, in *IngressVIP, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetIngressVIP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetIngressVIP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IngressVIP, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngressVIP)
+	err := c.cc.Invoke(ctx, Cluster_GetIngressVIP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetACMEDNS(ctx context.Context, in *ACMEDNS, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetACMEDNS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetACMEDNS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ACMEDNS, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ACMEDNS)
+	err := c.cc.Invoke(ctx, Cluster_GetACMEDNS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetIngressEvents(ctx context.Context, in *GetIngressEventsRequest, opts ...grpc.CallOption) (*IngressEvents, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngressEvents)
+	err := c.cc.Invoke(ctx, Cluster_GetIngressEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetDNSConfig(ctx context.Context, in *DNSConfig, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetDNSConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetDNSConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DNSConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DNSConfig)
+	err := c.cc.Invoke(ctx, Cluster_GetDNSConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetMeshConfig(ctx context.Context, in *MeshConfig, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Cluster_SetMeshConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetMeshConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MeshConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeshConfig)
+	err := c.cc.Invoke(ctx, Cluster_GetMeshConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) GetNetwork(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClusterNetwork, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterNetwork)
+	err := c.cc.Invoke(ctx, Cluster_GetNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) SetNetwork(ctx context.Context, in *ClusterNetwork, opts ...grpc.CallOption) (*ClusterNetwork, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClusterNetwork)
+	err := c.cc.Invoke(ctx, Cluster_SetNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) ReallocateMachineSubnet(ctx context.Context, in *ReallocateMachineSubnetRequest, opts ...grpc.CallOption) (*UpdateMachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMachineResponse)
+	err := c.cc.Invoke(ctx, Cluster_ReallocateMachineSubnet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterClient) WatchEvents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ClusterEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Cluster_ServiceDesc.Streams[0], Cluster_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[emptypb.Empty, ClusterEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Cluster_WatchEventsClient = grpc.ServerStreamingClient[ClusterEvent]
+
 // ClusterServer is the server API for Cluster service.
 // All implementations must embed UnimplementedClusterServer
 // for forward compatibility.
@@ -144,205 +716,1191 @@ type ClusterServer interface {
 	GetDomain(context.Context, *emptypb.Empty) (*Domain, error)
 	ReleaseDomain(context.Context, *emptypb.Empty) (*Domain, error)
 	CreateDomainRecords(context.Context, *CreateDomainRecordsRequest) (*CreateDomainRecordsResponse, error)
+	// PinImage adds image references to the cluster-wide list of pinned images that are protected from pruning.
+	PinImage(context.Context, *PinImageRequest) (*PinnedImages, error)
+	// UnpinImage removes image references from the cluster-wide list of pinned images.
+	UnpinImage(context.Context, *PinImageRequest) (*PinnedImages, error)
+	ListPinnedImages(context.Context, *emptypb.Empty) (*PinnedImages, error)
+	// SetDefaultUlimits adds or replaces cluster-wide default ulimits applied to every service container created
+	// afterwards. Ulimits set for a service override the defaults with the same name.
+	SetDefaultUlimits(context.Context, *DefaultUlimits) (*DefaultUlimits, error)
+	// RemoveDefaultUlimits removes cluster-wide default ulimits by name.
+	RemoveDefaultUlimits(context.Context, *RemoveDefaultUlimitsRequest) (*DefaultUlimits, error)
+	ListDefaultUlimits(context.Context, *emptypb.Empty) (*DefaultUlimits, error)
+	// AllowSysctls adds patterns to the cluster sysctl allow-list. When the list isn't empty, service containers
+	// can only set sysctls that match one of the patterns.
+	AllowSysctls(context.Context, *SysctlsRequest) (*AllowedSysctls, error)
+	// DisallowSysctls removes patterns from the cluster sysctl allow-list.
+	DisallowSysctls(context.Context, *SysctlsRequest) (*AllowedSysctls, error)
+	ListAllowedSysctls(context.Context, *emptypb.Empty) (*AllowedSysctls, error)
+	// SetUsernsRemap enables or disables user namespace remapping for the cluster. New machines are installed with
+	// userns-remap configured in Docker and existing machines report if their Docker doesn't support it.
+	SetUsernsRemap(context.Context, *UsernsRemap) (*UsernsRemap, error)
+	GetUsernsRemap(context.Context, *emptypb.Empty) (*UsernsRemap, error)
+	// GetSealingKey returns the public key for sealing secrets that only the cluster machines can decrypt.
+	// The cluster sealing key pair is generated on first use.
+	GetSealingKey(context.Context, *emptypb.Empty) (*SealingKey, error)
+	// SetSecretMaxMode sets the most permissive file mode allowed for secrets mounted into service containers.
+	// Zero mode removes the limit.
+	SetSecretMaxMode(context.Context, *SecretMaxMode) (*SecretMaxMode, error)
+	GetSecretMaxMode(context.Context, *emptypb.Empty) (*SecretMaxMode, error)
+	// CreateSecret stores a secret encrypted with the cluster sealing key so that services can reference it by name.
+	// It fails with AlreadyExists if the secret exists unless update is set.
+	CreateSecret(context.Context, *CreateSecretRequest) (*ClusterSecret, error)
+	ListSecrets(context.Context, *emptypb.Empty) (*ClusterSecrets, error)
+	InspectSecret(context.Context, *InspectSecretRequest) (*ClusterSecret, error)
+	RemoveSecret(context.Context, *RemoveSecretRequest) (*emptypb.Empty, error)
+	// SetServiceEnv sets environment variable overrides for a Compose service. They're merged into the service
+	// environment from the Compose file on deploy.
+	SetServiceEnv(context.Context, *SetServiceEnvRequest) (*ServiceEnv, error)
+	// UnsetServiceEnv removes environment variable overrides for a Compose service.
+	UnsetServiceEnv(context.Context, *UnsetServiceEnvRequest) (*ServiceEnv, error)
+	GetServiceEnv(context.Context, *GetServiceEnvRequest) (*ServiceEnv, error)
+	// ReservePublished reserves the published ports and ingress hostnames for a Compose project so that other projects
+	// can't claim them. It fails with AlreadyExists if another project has already reserved any of them.
+	ReservePublished(context.Context, *ReservePublishedRequest) (*PublishedReservations, error)
+	// ReleasePublished releases all published ports and ingress hostnames reserved by a Compose project.
+	ReleasePublished(context.Context, *ReleasePublishedRequest) (*PublishedReservations, error)
+	ListPublishedReservations(context.Context, *emptypb.Empty) (*PublishedReservations, error)
+	// UpdateProject updates the metadata of a Compose project and records the services deployed from it. Services
+	// recorded for another project are moved to this one.
+	UpdateProject(context.Context, *UpdateProjectRequest) (*Project, error)
+	ListProjects(context.Context, *emptypb.Empty) (*Projects, error)
+	// SetAutoUpdatePaused pauses or resumes automatic image updates for a service or for all services if the service
+	// name is empty.
+	SetAutoUpdatePaused(context.Context, *SetAutoUpdatePausedRequest) (*AutoUpdatePaused, error)
+	GetAutoUpdatePaused(context.Context, *emptypb.Empty) (*AutoUpdatePaused, error)
+	// GetAutoUpdateStatus returns the state of automatic image updates for a service.
+	GetAutoUpdateStatus(context.Context, *GetAutoUpdateStatusRequest) (*AutoUpdateStatus, error)
+	// GetHostUpdates returns the history of OS package updates on a machine host.
+	GetHostUpdates(context.Context, *GetHostUpdatesRequest) (*HostUpdates, error)
+	// SetIngressVIP sets the virtual IP address that floats among ingress machines. An empty config removes it.
+	SetIngressVIP(context.Context, *IngressVIP) (*emptypb.Empty, error)
+	// GetIngressVIP returns the virtual IP configuration and the machine that currently holds the address.
+	GetIngressVIP(context.Context, *emptypb.Empty) (*IngressVIP, error)
+	// SetACMEDNS sets the ACME DNS-01 challenge configuration for issuing certificates on ingress machines.
+	// An empty config removes it.
+	SetACMEDNS(context.Context, *ACMEDNS) (*emptypb.Empty, error)
+	// GetACMEDNS returns the ACME DNS-01 challenge configuration.
+	GetACMEDNS(context.Context, *emptypb.Empty) (*ACMEDNS, error)
+	// GetIngressEvents returns the most recent changes of the ingress configuration on a machine.
+	GetIngressEvents(context.Context, *GetIngressEventsRequest) (*IngressEvents, error)
+	// SetDNSConfig sets the forwarding configuration of the embedded DNS servers on machines. An empty config
+	// removes it so that machines forward queries to the nameservers from their /etc/resolv.conf.
+	SetDNSConfig(context.Context, *DNSConfig) (*emptypb.Empty, error)
+	// GetDNSConfig returns the forwarding configuration of the embedded DNS servers.
+	GetDNSConfig(context.Context, *emptypb.Empty) (*DNSConfig, error)
+	// SetMeshConfig sets the configuration of the certificates issued for mTLS-enabled services. An empty config
+	// removes it so that machines use the default clock tolerance and renewal windows.
+	SetMeshConfig(context.Context, *MeshConfig) (*emptypb.Empty, error)
+	// GetMeshConfig returns the configuration of the certificates issued for mTLS-enabled services.
+	GetMeshConfig(context.Context, *emptypb.Empty) (*MeshConfig, error)
+	// GetNetwork returns the cluster network from which machine subnets are allocated.
+	GetNetwork(context.Context, *emptypb.Empty) (*ClusterNetwork, error)
+	// SetNetwork changes the cluster network. Existing machine subnets are not changed until they are reallocated
+	// with ReallocateMachineSubnet.
+	SetNetwork(context.Context, *ClusterNetwork) (*ClusterNetwork, error)
+	// ReallocateMachineSubnet allocates a new subnet from the cluster network for the machine if its current subnet
+	// is outside the network.
+	ReallocateMachineSubnet(context.Context, *ReallocateMachineSubnetRequest) (*UpdateMachineResponse, error)
+	// WatchEvents streams an event each time the machines or containers in the cluster store change until
+	// the client cancels the request.
+	WatchEvents(*emptypb.Empty, grpc.ServerStreamingServer[ClusterEvent]) error
+	mustEmbedUnimplementedClusterServer()
+}
+
+// UnimplementedClusterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClusterServer struct{}
+
+func (UnimplementedClusterServer) AddMachine(context.Context, *AddMachineRequest) (*AddMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMachine not implemented")
+}
+func (UnimplementedClusterServer) ListMachines(context.Context, *emptypb.Empty) (*ListMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMachines not implemented")
+}
+func (UnimplementedClusterServer) UpdateMachine(context.Context, *UpdateMachineRequest) (*UpdateMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMachine not implemented")
+}
+func (UnimplementedClusterServer) RemoveMachine(context.Context, *RemoveMachineRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMachine not implemented")
+}
+func (UnimplementedClusterServer) ReserveDomain(context.Context, *ReserveDomainRequest) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveDomain not implemented")
+}
+func (UnimplementedClusterServer) GetDomain(context.Context, *emptypb.Empty) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDomain not implemented")
+}
+func (UnimplementedClusterServer) ReleaseDomain(context.Context, *emptypb.Empty) (*Domain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDomain not implemented")
+}
+func (UnimplementedClusterServer) CreateDomainRecords(context.Context, *CreateDomainRecordsRequest) (*CreateDomainRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDomainRecords not implemented")
+}
+func (UnimplementedClusterServer) PinImage(context.Context, *PinImageRequest) (*PinnedImages, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinImage not implemented")
+}
+func (UnimplementedClusterServer) UnpinImage(context.Context, *PinImageRequest) (*PinnedImages, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpinImage not implemented")
+}
+func (UnimplementedClusterServer) ListPinnedImages(context.Context, *emptypb.Empty) (*PinnedImages, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPinnedImages not implemented")
+}
+func (UnimplementedClusterServer) SetDefaultUlimits(context.Context, *DefaultUlimits) (*DefaultUlimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDefaultUlimits not implemented")
+}
+func (UnimplementedClusterServer) RemoveDefaultUlimits(context.Context, *RemoveDefaultUlimitsRequest) (*DefaultUlimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDefaultUlimits not implemented")
+}
+func (UnimplementedClusterServer) ListDefaultUlimits(context.Context, *emptypb.Empty) (*DefaultUlimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDefaultUlimits not implemented")
+}
+func (UnimplementedClusterServer) AllowSysctls(context.Context, *SysctlsRequest) (*AllowedSysctls, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllowSysctls not implemented")
+}
+func (UnimplementedClusterServer) DisallowSysctls(context.Context, *SysctlsRequest) (*AllowedSysctls, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisallowSysctls not implemented")
+}
+func (UnimplementedClusterServer) ListAllowedSysctls(context.Context, *emptypb.Empty) (*AllowedSysctls, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllowedSysctls not implemented")
+}
+func (UnimplementedClusterServer) SetUsernsRemap(context.Context, *UsernsRemap) (*UsernsRemap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUsernsRemap not implemented")
+}
+func (UnimplementedClusterServer) GetUsernsRemap(context.Context, *emptypb.Empty) (*UsernsRemap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsernsRemap not implemented")
+}
+func (UnimplementedClusterServer) GetSealingKey(context.Context, *emptypb.Empty) (*SealingKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSealingKey not implemented")
+}
+func (UnimplementedClusterServer) SetSecretMaxMode(context.Context, *SecretMaxMode) (*SecretMaxMode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSecretMaxMode not implemented")
+}
+func (UnimplementedClusterServer) GetSecretMaxMode(context.Context, *emptypb.Empty) (*SecretMaxMode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretMaxMode not implemented")
+}
+func (UnimplementedClusterServer) CreateSecret(context.Context, *CreateSecretRequest) (*ClusterSecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSecret not implemented")
+}
+func (UnimplementedClusterServer) ListSecrets(context.Context, *emptypb.Empty) (*ClusterSecrets, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
+}
+func (UnimplementedClusterServer) InspectSecret(context.Context, *InspectSecretRequest) (*ClusterSecret, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectSecret not implemented")
+}
+func (UnimplementedClusterServer) RemoveSecret(context.Context, *RemoveSecretRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSecret not implemented")
+}
+func (UnimplementedClusterServer) SetServiceEnv(context.Context, *SetServiceEnvRequest) (*ServiceEnv, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServiceEnv not implemented")
+}
+func (UnimplementedClusterServer) UnsetServiceEnv(context.Context, *UnsetServiceEnvRequest) (*ServiceEnv, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsetServiceEnv not implemented")
+}
+func (UnimplementedClusterServer) GetServiceEnv(context.Context, *GetServiceEnvRequest) (*ServiceEnv, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceEnv not implemented")
+}
+func (UnimplementedClusterServer) ReservePublished(context.Context, *ReservePublishedRequest) (*PublishedReservations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReservePublished not implemented")
+}
+func (UnimplementedClusterServer) ReleasePublished(context.Context, *ReleasePublishedRequest) (*PublishedReservations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleasePublished not implemented")
+}
+func (UnimplementedClusterServer) ListPublishedReservations(context.Context, *emptypb.Empty) (*PublishedReservations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPublishedReservations not implemented")
+}
+func (UnimplementedClusterServer) UpdateProject(context.Context, *UpdateProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedClusterServer) ListProjects(context.Context, *emptypb.Empty) (*Projects, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedClusterServer) SetAutoUpdatePaused(context.Context, *SetAutoUpdatePausedRequest) (*AutoUpdatePaused, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutoUpdatePaused not implemented")
+}
+func (UnimplementedClusterServer) GetAutoUpdatePaused(context.Context, *emptypb.Empty) (*AutoUpdatePaused, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAutoUpdatePaused not implemented")
+}
+func (UnimplementedClusterServer) GetAutoUpdateStatus(context.Context, *GetAutoUpdateStatusRequest) (*AutoUpdateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAutoUpdateStatus not implemented")
+}
+func (UnimplementedClusterServer) GetHostUpdates(context.Context, *GetHostUpdatesRequest) (*HostUpdates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostUpdates not implemented")
+}
+func (UnimplementedClusterServer) SetIngressVIP(context.Context, *IngressVIP) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIngressVIP not implemented")
+}
+func (UnimplementedClusterServer) GetIngressVIP(context.Context, *emptypb.Empty) (*IngressVIP, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIngressVIP not implemented")
+}
+func (UnimplementedClusterServer) SetACMEDNS(context.Context, *ACMEDNS) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetACMEDNS not implemented")
+}
+func (UnimplementedClusterServer) GetACMEDNS(context.Context, *emptypb.Empty) (*ACMEDNS, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetACMEDNS not implemented")
+}
+func (UnimplementedClusterServer) GetIngressEvents(context.Context, *GetIngressEventsRequest) (*IngressEvents, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIngressEvents not implemented")
+}
+func (UnimplementedClusterServer) SetDNSConfig(context.Context, *DNSConfig) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDNSConfig not implemented")
+}
+func (UnimplementedClusterServer) GetDNSConfig(context.Context, *emptypb.Empty) (*DNSConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDNSConfig not implemented")
+}
+func (UnimplementedClusterServer) SetMeshConfig(context.Context, *MeshConfig) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMeshConfig not implemented")
+}
+func (UnimplementedClusterServer) GetMeshConfig(context.Context, *emptypb.Empty) (*MeshConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeshConfig not implemented")
+}
+func (UnimplementedClusterServer) GetNetwork(context.Context, *emptypb.Empty) (*ClusterNetwork, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetwork not implemented")
+}
+func (UnimplementedClusterServer) SetNetwork(context.Context, *ClusterNetwork) (*ClusterNetwork, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetNetwork not implemented")
+}
+func (UnimplementedClusterServer) ReallocateMachineSubnet(context.Context, *ReallocateMachineSubnetRequest) (*UpdateMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReallocateMachineSubnet not implemented")
+}
+func (UnimplementedClusterServer) WatchEvents(*emptypb.Empty, grpc.ServerStreamingServer[ClusterEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedClusterServer) mustEmbedUnimplementedClusterServer() {}
+func (UnimplementedClusterServer) testEmbeddedByValue()                 {}
+
+// UnsafeClusterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClusterServer will
+// result in compilation errors.
+type UnsafeClusterServer interface {
 	mustEmbedUnimplementedClusterServer()
 }
 
-// UnimplementedClusterServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedClusterServer struct{}
+func RegisterClusterServer(s grpc.ServiceRegistrar, srv ClusterServer) {
+	// If the following call pancis, it indicates UnimplementedClusterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Cluster_ServiceDesc, srv)
+}
+
+func _Cluster_AddMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).AddMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_AddMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).AddMachine(ctx, req.(*AddMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListMachines(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_UpdateMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).UpdateMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_UpdateMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).UpdateMachine(ctx, req.(*UpdateMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemoveMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveMachine(ctx, req.(*RemoveMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReserveDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReserveDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReserveDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReserveDomain(ctx, req.(*ReserveDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetDomain(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReleaseDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReleaseDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReleaseDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReleaseDomain(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_CreateDomainRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDomainRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).CreateDomainRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_CreateDomainRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).CreateDomainRecords(ctx, req.(*CreateDomainRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_PinImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).PinImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_PinImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).PinImage(ctx, req.(*PinImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_UnpinImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).UnpinImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_UnpinImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).UnpinImage(ctx, req.(*PinImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListPinnedImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListPinnedImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListPinnedImages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListPinnedImages(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetDefaultUlimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DefaultUlimits)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetDefaultUlimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetDefaultUlimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetDefaultUlimits(ctx, req.(*DefaultUlimits))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemoveDefaultUlimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDefaultUlimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveDefaultUlimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveDefaultUlimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveDefaultUlimits(ctx, req.(*RemoveDefaultUlimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListDefaultUlimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListDefaultUlimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListDefaultUlimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListDefaultUlimits(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_AllowSysctls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SysctlsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).AllowSysctls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_AllowSysctls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).AllowSysctls(ctx, req.(*SysctlsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_DisallowSysctls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SysctlsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).DisallowSysctls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_DisallowSysctls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).DisallowSysctls(ctx, req.(*SysctlsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedClusterServer) AddMachine(context.Context, *AddMachineRequest) (*AddMachineResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddMachine not implemented")
+func _Cluster_ListAllowedSysctls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListAllowedSysctls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListAllowedSysctls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListAllowedSysctls(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) ListMachines(context.Context, *emptypb.Empty) (*ListMachinesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListMachines not implemented")
+
+func _Cluster_SetUsernsRemap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsernsRemap)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetUsernsRemap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetUsernsRemap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetUsernsRemap(ctx, req.(*UsernsRemap))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) UpdateMachine(context.Context, *UpdateMachineRequest) (*UpdateMachineResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateMachine not implemented")
+
+func _Cluster_GetUsernsRemap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetUsernsRemap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetUsernsRemap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetUsernsRemap(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) RemoveMachine(context.Context, *RemoveMachineRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveMachine not implemented")
+
+func _Cluster_GetSealingKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetSealingKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetSealingKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetSealingKey(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetSecretMaxMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretMaxMode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetSecretMaxMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetSecretMaxMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetSecretMaxMode(ctx, req.(*SecretMaxMode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetSecretMaxMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetSecretMaxMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetSecretMaxMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetSecretMaxMode(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_CreateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).CreateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_CreateSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).CreateSecret(ctx, req.(*CreateSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListSecrets(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_InspectSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).InspectSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_InspectSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).InspectSecret(ctx, req.(*InspectSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_RemoveSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).RemoveSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_RemoveSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).RemoveSecret(ctx, req.(*RemoveSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_SetServiceEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetServiceEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetServiceEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetServiceEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetServiceEnv(ctx, req.(*SetServiceEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_UnsetServiceEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetServiceEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).UnsetServiceEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_UnsetServiceEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).UnsetServiceEnv(ctx, req.(*UnsetServiceEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetServiceEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetServiceEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetServiceEnv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetServiceEnv(ctx, req.(*GetServiceEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReservePublished_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservePublishedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReservePublished(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReservePublished_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReservePublished(ctx, req.(*ReservePublishedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReleasePublished_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleasePublishedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReleasePublished(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReleasePublished_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReleasePublished(ctx, req.(*ReleasePublishedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListPublishedReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListPublishedReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListPublishedReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListPublishedReservations(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_UpdateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ListProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ListProjects(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) ReserveDomain(context.Context, *ReserveDomainRequest) (*Domain, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReserveDomain not implemented")
+
+func _Cluster_SetAutoUpdatePaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutoUpdatePausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetAutoUpdatePaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetAutoUpdatePaused_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetAutoUpdatePaused(ctx, req.(*SetAutoUpdatePausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) GetDomain(context.Context, *emptypb.Empty) (*Domain, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDomain not implemented")
+
+func _Cluster_GetAutoUpdatePaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetAutoUpdatePaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetAutoUpdatePaused_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetAutoUpdatePaused(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) ReleaseDomain(context.Context, *emptypb.Empty) (*Domain, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDomain not implemented")
+
+func _Cluster_GetAutoUpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAutoUpdateStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetAutoUpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetAutoUpdateStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetAutoUpdateStatus(ctx, req.(*GetAutoUpdateStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) CreateDomainRecords(context.Context, *CreateDomainRecordsRequest) (*CreateDomainRecordsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDomainRecords not implemented")
+
+func _Cluster_GetHostUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHostUpdatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).GetHostUpdates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetHostUpdates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetHostUpdates(ctx, req.(*GetHostUpdatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedClusterServer) mustEmbedUnimplementedClusterServer() {}
-func (UnimplementedClusterServer) testEmbeddedByValue()                 {}
 
-// UnsafeClusterServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ClusterServer will
-// result in compilation errors.
-type UnsafeClusterServer interface {
-	mustEmbedUnimplementedClusterServer()
+func _Cluster_SetIngressVIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngressVIP)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetIngressVIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetIngressVIP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetIngressVIP(ctx, req.(*IngressVIP))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterClusterServer(s grpc.ServiceRegistrar, srv ClusterServer) {
-	// If the following call pancis, it indicates UnimplementedClusterServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _Cluster_GetIngressVIP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&Cluster_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ClusterServer).GetIngressVIP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_GetIngressVIP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).GetIngressVIP(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_AddMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddMachineRequest)
+func _Cluster_SetACMEDNS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACMEDNS)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).AddMachine(ctx, in)
+		return srv.(ClusterServer).SetACMEDNS(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_AddMachine_FullMethodName,
+		FullMethod: Cluster_SetACMEDNS_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).AddMachine(ctx, req.(*AddMachineRequest))
+		return srv.(ClusterServer).SetACMEDNS(ctx, req.(*ACMEDNS))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_ListMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Cluster_GetACMEDNS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).ListMachines(ctx, in)
+		return srv.(ClusterServer).GetACMEDNS(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_ListMachines_FullMethodName,
+		FullMethod: Cluster_GetACMEDNS_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).ListMachines(ctx, req.(*emptypb.Empty))
+		return srv.(ClusterServer).GetACMEDNS(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_UpdateMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateMachineRequest)
+func _Cluster_GetIngressEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIngressEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).UpdateMachine(ctx, in)
+		return srv.(ClusterServer).GetIngressEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_UpdateMachine_FullMethodName,
+		FullMethod: Cluster_GetIngressEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).UpdateMachine(ctx, req.(*UpdateMachineRequest))
+		return srv.(ClusterServer).GetIngressEvents(ctx, req.(*GetIngressEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_RemoveMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveMachineRequest)
+func _Cluster_SetDNSConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DNSConfig)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).RemoveMachine(ctx, in)
+		return srv.(ClusterServer).SetDNSConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_RemoveMachine_FullMethodName,
+		FullMethod: Cluster_SetDNSConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).RemoveMachine(ctx, req.(*RemoveMachineRequest))
+		return srv.(ClusterServer).SetDNSConfig(ctx, req.(*DNSConfig))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_ReserveDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReserveDomainRequest)
+func _Cluster_GetDNSConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).ReserveDomain(ctx, in)
+		return srv.(ClusterServer).GetDNSConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_ReserveDomain_FullMethodName,
+		FullMethod: Cluster_GetDNSConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).ReserveDomain(ctx, req.(*ReserveDomainRequest))
+		return srv.(ClusterServer).GetDNSConfig(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_GetDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Cluster_SetMeshConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeshConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).SetMeshConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_SetMeshConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).SetMeshConfig(ctx, req.(*MeshConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_GetMeshConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).GetDomain(ctx, in)
+		return srv.(ClusterServer).GetMeshConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_GetDomain_FullMethodName,
+		FullMethod: Cluster_GetMeshConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).GetDomain(ctx, req.(*emptypb.Empty))
+		return srv.(ClusterServer).GetMeshConfig(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_ReleaseDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Cluster_GetNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).ReleaseDomain(ctx, in)
+		return srv.(ClusterServer).GetNetwork(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_ReleaseDomain_FullMethodName,
+		FullMethod: Cluster_GetNetwork_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).ReleaseDomain(ctx, req.(*emptypb.Empty))
+		return srv.(ClusterServer).GetNetwork(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Cluster_CreateDomainRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDomainRecordsRequest)
+func _Cluster_SetNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterNetwork)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ClusterServer).CreateDomainRecords(ctx, in)
+		return srv.(ClusterServer).SetNetwork(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Cluster_CreateDomainRecords_FullMethodName,
+		FullMethod: Cluster_SetNetwork_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ClusterServer).CreateDomainRecords(ctx, req.(*CreateDomainRecordsRequest))
+		return srv.(ClusterServer).SetNetwork(ctx, req.(*ClusterNetwork))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cluster_ReallocateMachineSubnet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReallocateMachineSubnetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServer).ReallocateMachineSubnet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Cluster_ReallocateMachineSubnet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServer).ReallocateMachineSubnet(ctx, req.(*ReallocateMachineSubnetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cluster_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusterServer).WatchEvents(m, &grpc.GenericServerStream[emptypb.Empty, ClusterEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Cluster_WatchEventsServer = grpc.ServerStreamingServer[ClusterEvent]
+
 // Cluster_ServiceDesc is the grpc.ServiceDesc for Cluster service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -382,7 +1940,181 @@ var Cluster_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateDomainRecords",
 			Handler:    _Cluster_CreateDomainRecords_Handler,
 		},
+		{
+			MethodName: "PinImage",
+			Handler:    _Cluster_PinImage_Handler,
+		},
+		{
+			MethodName: "UnpinImage",
+			Handler:    _Cluster_UnpinImage_Handler,
+		},
+		{
+			MethodName: "ListPinnedImages",
+			Handler:    _Cluster_ListPinnedImages_Handler,
+		},
+		{
+			MethodName: "SetDefaultUlimits",
+			Handler:    _Cluster_SetDefaultUlimits_Handler,
+		},
+		{
+			MethodName: "RemoveDefaultUlimits",
+			Handler:    _Cluster_RemoveDefaultUlimits_Handler,
+		},
+		{
+			MethodName: "ListDefaultUlimits",
+			Handler:    _Cluster_ListDefaultUlimits_Handler,
+		},
+		{
+			MethodName: "AllowSysctls",
+			Handler:    _Cluster_AllowSysctls_Handler,
+		},
+		{
+			MethodName: "DisallowSysctls",
+			Handler:    _Cluster_DisallowSysctls_Handler,
+		},
+		{
+			MethodName: "ListAllowedSysctls",
+			Handler:    _Cluster_ListAllowedSysctls_Handler,
+		},
+		{
+			MethodName: "SetUsernsRemap",
+			Handler:    _Cluster_SetUsernsRemap_Handler,
+		},
+		{
+			MethodName: "GetUsernsRemap",
+			Handler:    _Cluster_GetUsernsRemap_Handler,
+		},
+		{
+			MethodName: "GetSealingKey",
+			Handler:    _Cluster_GetSealingKey_Handler,
+		},
+		{
+			MethodName: "SetSecretMaxMode",
+			Handler:    _Cluster_SetSecretMaxMode_Handler,
+		},
+		{
+			MethodName: "GetSecretMaxMode",
+			Handler:    _Cluster_GetSecretMaxMode_Handler,
+		},
+		{
+			MethodName: "CreateSecret",
+			Handler:    _Cluster_CreateSecret_Handler,
+		},
+		{
+			MethodName: "ListSecrets",
+			Handler:    _Cluster_ListSecrets_Handler,
+		},
+		{
+			MethodName: "InspectSecret",
+			Handler:    _Cluster_InspectSecret_Handler,
+		},
+		{
+			MethodName: "RemoveSecret",
+			Handler:    _Cluster_RemoveSecret_Handler,
+		},
+		{
+			MethodName: "SetServiceEnv",
+			Handler:    _Cluster_SetServiceEnv_Handler,
+		},
+		{
+			MethodName: "UnsetServiceEnv",
+			Handler:    _Cluster_UnsetServiceEnv_Handler,
+		},
+		{
+			MethodName: "GetServiceEnv",
+			Handler:    _Cluster_GetServiceEnv_Handler,
+		},
+		{
+			MethodName: "ReservePublished",
+			Handler:    _Cluster_ReservePublished_Handler,
+		},
+		{
+			MethodName: "ReleasePublished",
+			Handler:    _Cluster_ReleasePublished_Handler,
+		},
+		{
+			MethodName: "ListPublishedReservations",
+			Handler:    _Cluster_ListPublishedReservations_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _Cluster_UpdateProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _Cluster_ListProjects_Handler,
+		},
+		{
+			MethodName: "SetAutoUpdatePaused",
+			Handler:    _Cluster_SetAutoUpdatePaused_Handler,
+		},
+		{
+			MethodName: "GetAutoUpdatePaused",
+			Handler:    _Cluster_GetAutoUpdatePaused_Handler,
+		},
+		{
+			MethodName: "GetAutoUpdateStatus",
+			Handler:    _Cluster_GetAutoUpdateStatus_Handler,
+		},
+		{
+			MethodName: "GetHostUpdates",
+			Handler:    _Cluster_GetHostUpdates_Handler,
+		},
+		{
+			MethodName: "SetIngressVIP",
+			Handler:    _Cluster_SetIngressVIP_Handler,
+		},
+		{
+			MethodName: "GetIngressVIP",
+			Handler:    _Cluster_GetIngressVIP_Handler,
+		},
+		{
+			MethodName: "SetACMEDNS",
+			Handler:    _Cluster_SetACMEDNS_Handler,
+		},
+		{
+			MethodName: "GetACMEDNS",
+			Handler:    _Cluster_GetACMEDNS_Handler,
+		},
+		{
+			MethodName: "GetIngressEvents",
+			Handler:    _Cluster_GetIngressEvents_Handler,
+		},
+		{
+			MethodName: "SetDNSConfig",
+			Handler:    _Cluster_SetDNSConfig_Handler,
+		},
+		{
+			MethodName: "GetDNSConfig",
+			Handler:    _Cluster_GetDNSConfig_Handler,
+		},
+		{
+			MethodName: "SetMeshConfig",
+			Handler:    _Cluster_SetMeshConfig_Handler,
+		},
+		{
+			MethodName: "GetMeshConfig",
+			Handler:    _Cluster_GetMeshConfig_Handler,
+		},
+		{
+			MethodName: "GetNetwork",
+			Handler:    _Cluster_GetNetwork_Handler,
+		},
+		{
+			MethodName: "SetNetwork",
+			Handler:    _Cluster_SetNetwork_Handler,
+		},
+		{
+			MethodName: "ReallocateMachineSubnet",
+			Handler:    _Cluster_ReallocateMachineSubnet_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Cluster_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/machine/api/pb/cluster.proto",
 }
@@ -27,9 +27,19 @@ const (
 	Machine_Inspect_FullMethodName                 = "/api.Machine/Inspect"
 	Machine_InspectMachine_FullMethodName          = "/api.Machine/InspectMachine"
 	Machine_InspectWireGuardNetwork_FullMethodName = "/api.Machine/InspectWireGuardNetwork"
+	Machine_InspectNetwork_FullMethodName          = "/api.Machine/InspectNetwork"
 	Machine_Reset_FullMethodName                   = "/api.Machine/Reset"
+	Machine_ApplySubnet_FullMethodName             = "/api.Machine/ApplySubnet"
+	Machine_ResyncStore_FullMethodName             = "/api.Machine/ResyncStore"
+	Machine_Reboot_FullMethodName                  = "/api.Machine/Reboot"
+	Machine_RunHostUpdate_FullMethodName           = "/api.Machine/RunHostUpdate"
 	Machine_InspectService_FullMethodName          = "/api.Machine/InspectService"
 	Machine_MachineLogs_FullMethodName             = "/api.Machine/MachineLogs"
+	Machine_SetLogLevel_FullMethodName             = "/api.Machine/SetLogLevel"
+	Machine_GetLogLevel_FullMethodName             = "/api.Machine/GetLogLevel"
+	Machine_InjectFault_FullMethodName             = "/api.Machine/InjectFault"
+	Machine_ClearFaults_FullMethodName             = "/api.Machine/ClearFaults"
+	Machine_ListFaults_FullMethodName              = "/api.Machine/ListFaults"
 )
 
 // MachineClient is the client API for Machine service.
@@ -37,7 +47,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MachineClient interface {
 	// CheckPrerequisites verifies if the machine meets all necessary system requirements to participate in the cluster.
-	CheckPrerequisites(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CheckPrerequisitesResponse, error)
+	CheckPrerequisites(ctx context.Context, in *CheckPrerequisitesRequest, opts ...grpc.CallOption) (*CheckPrerequisitesResponse, error)
 	InitCluster(ctx context.Context, in *InitClusterRequest, opts ...grpc.CallOption) (*InitClusterResponse, error)
 	JoinCluster(ctx context.Context, in *JoinClusterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Token(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TokenResponse, error)
@@ -47,10 +57,36 @@ type MachineClient interface {
 	InspectMachine(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InspectMachineResponse, error)
 	// InspectWireGuardNetwork retrieves the current WireGuard network configuration and peer status.
 	InspectWireGuardNetwork(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InspectWireGuardNetworkResponse, error)
+	// InspectNetwork retrieves the machine network configuration including the Docker network, its containers,
+	// WireGuard peers, and routes. Supports broadcasting to multiple machines.
+	InspectNetwork(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InspectNetworkResponse, error)
 	// Reset restores the machine to a clean state, removing all cluster-related configuration and data.
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ApplySubnet applies the machine subnet from the cluster store if it differs from the local one. The machine
+	// daemon restarts to reconfigure the network and move its containers to the new subnet.
+	ApplySubnet(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApplySubnetResponse, error)
+	// ResyncStore discards the local cluster store database and restarts the machine daemon to re-sync the store
+	// from other machines. Used to repair a machine whose store diverged from the rest of the cluster.
+	ResyncStore(ctx context.Context, in *ResyncStoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Reboot reboots the machine host with systemd shortly after the call returns. Used to roll out kernel updates.
+	Reboot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RunHostUpdate runs the OS package update command on the machine host and records the result in the history
+	// of host updates in the cluster store.
+	RunHostUpdate(ctx context.Context, in *RunHostUpdateRequest, opts ...grpc.CallOption) (*RunHostUpdateResponse, error)
 	InspectService(ctx context.Context, in *InspectServiceRequest, opts ...grpc.CallOption) (*InspectServiceResponse, error)
 	MachineLogs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error)
+	// SetLogLevel changes the minimum level of the machine daemon logs until the daemon restarts. Supports
+	// broadcasting to multiple machines.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
+	// GetLogLevel returns the minimum level of the machine daemon logs. Supports broadcasting to multiple machines.
+	GetLogLevel(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LogLevelResponse, error)
+	// InjectFault injects a fault into the machine daemon for failure testing. The daemon must run with
+	// --unsafe-fault-injection. Supports broadcasting to multiple machines.
+	InjectFault(ctx context.Context, in *InjectFaultRequest, opts ...grpc.CallOption) (*FaultsResponse, error)
+	// ClearFaults removes the injected faults. Supports broadcasting to multiple machines.
+	ClearFaults(ctx context.Context, in *ClearFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error)
+	// ListFaults returns the active injected faults. Supports broadcasting to multiple machines.
+	ListFaults(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FaultsResponse, error)
 }
 
 type machineClient struct {
@@ -61,7 +97,7 @@ func NewMachineClient(cc grpc.ClientConnInterface) MachineClient {
 	return &machineClient{cc}
 }
 
-func (c *machineClient) CheckPrerequisites(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CheckPrerequisitesResponse, error) {
+func (c *machineClient) CheckPrerequisites(ctx context.Context, in *CheckPrerequisitesRequest, opts ...grpc.CallOption) (*CheckPrerequisitesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckPrerequisitesResponse)
 	err := c.cc.Invoke(ctx, Machine_CheckPrerequisites_FullMethodName, in, out, cOpts...)
@@ -131,6 +167,16 @@ func (c *machineClient) InspectWireGuardNetwork(ctx context.Context, in *emptypb
 	return out, nil
 }
 
+func (c *machineClient) InspectNetwork(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InspectNetworkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InspectNetworkResponse)
+	err := c.cc.Invoke(ctx, Machine_InspectNetwork_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineClient) Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -141,6 +187,46 @@ func (c *machineClient) Reset(ctx context.Context, in *ResetRequest, opts ...grp
 	return out, nil
 }
 
+func (c *machineClient) ApplySubnet(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ApplySubnetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplySubnetResponse)
+	err := c.cc.Invoke(ctx, Machine_ApplySubnet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) ResyncStore(ctx context.Context, in *ResyncStoreRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Machine_ResyncStore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) Reboot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Machine_Reboot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) RunHostUpdate(ctx context.Context, in *RunHostUpdateRequest, opts ...grpc.CallOption) (*RunHostUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunHostUpdateResponse)
+	err := c.cc.Invoke(ctx, Machine_RunHostUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *machineClient) InspectService(ctx context.Context, in *InspectServiceRequest, opts ...grpc.CallOption) (*InspectServiceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(InspectServiceResponse)
@@ -170,12 +256,62 @@ func (c *machineClient) MachineLogs(ctx context.Context, in *LogsRequest, opts .
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Machine_MachineLogsClient = grpc.ServerStreamingClient[LogEntry]
 
+func (c *machineClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevelResponse)
+	err := c.cc.Invoke(ctx, Machine_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) GetLogLevel(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LogLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogLevelResponse)
+	err := c.cc.Invoke(ctx, Machine_GetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) InjectFault(ctx context.Context, in *InjectFaultRequest, opts ...grpc.CallOption) (*FaultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FaultsResponse)
+	err := c.cc.Invoke(ctx, Machine_InjectFault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) ClearFaults(ctx context.Context, in *ClearFaultsRequest, opts ...grpc.CallOption) (*FaultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FaultsResponse)
+	err := c.cc.Invoke(ctx, Machine_ClearFaults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *machineClient) ListFaults(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FaultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FaultsResponse)
+	err := c.cc.Invoke(ctx, Machine_ListFaults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MachineServer is the server API for Machine service.
 // All implementations must embed UnimplementedMachineServer
 // for forward compatibility.
 type MachineServer interface {
 	// CheckPrerequisites verifies if the machine meets all necessary system requirements to participate in the cluster.
-	CheckPrerequisites(context.Context, *emptypb.Empty) (*CheckPrerequisitesResponse, error)
+	CheckPrerequisites(context.Context, *CheckPrerequisitesRequest) (*CheckPrerequisitesResponse, error)
 	InitCluster(context.Context, *InitClusterRequest) (*InitClusterResponse, error)
 	JoinCluster(context.Context, *JoinClusterRequest) (*emptypb.Empty, error)
 	Token(context.Context, *emptypb.Empty) (*TokenResponse, error)
@@ -185,10 +321,36 @@ type MachineServer interface {
 	InspectMachine(context.Context, *emptypb.Empty) (*InspectMachineResponse, error)
 	// InspectWireGuardNetwork retrieves the current WireGuard network configuration and peer status.
 	InspectWireGuardNetwork(context.Context, *emptypb.Empty) (*InspectWireGuardNetworkResponse, error)
+	// InspectNetwork retrieves the machine network configuration including the Docker network, its containers,
+	// WireGuard peers, and routes. Supports broadcasting to multiple machines.
+	InspectNetwork(context.Context, *emptypb.Empty) (*InspectNetworkResponse, error)
 	// Reset restores the machine to a clean state, removing all cluster-related configuration and data.
 	Reset(context.Context, *ResetRequest) (*emptypb.Empty, error)
+	// ApplySubnet applies the machine subnet from the cluster store if it differs from the local one. The machine
+	// daemon restarts to reconfigure the network and move its containers to the new subnet.
+	ApplySubnet(context.Context, *emptypb.Empty) (*ApplySubnetResponse, error)
+	// ResyncStore discards the local cluster store database and restarts the machine daemon to re-sync the store
+	// from other machines. Used to repair a machine whose store diverged from the rest of the cluster.
+	ResyncStore(context.Context, *ResyncStoreRequest) (*emptypb.Empty, error)
+	// Reboot reboots the machine host with systemd shortly after the call returns. Used to roll out kernel updates.
+	Reboot(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// RunHostUpdate runs the OS package update command on the machine host and records the result in the history
+	// of host updates in the cluster store.
+	RunHostUpdate(context.Context, *RunHostUpdateRequest) (*RunHostUpdateResponse, error)
 	InspectService(context.Context, *InspectServiceRequest) (*InspectServiceResponse, error)
 	MachineLogs(*LogsRequest, grpc.ServerStreamingServer[LogEntry]) error
+	// SetLogLevel changes the minimum level of the machine daemon logs until the daemon restarts. Supports
+	// broadcasting to multiple machines.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*LogLevelResponse, error)
+	// GetLogLevel returns the minimum level of the machine daemon logs. Supports broadcasting to multiple machines.
+	GetLogLevel(context.Context, *emptypb.Empty) (*LogLevelResponse, error)
+	// InjectFault injects a fault into the machine daemon for failure testing. The daemon must run with
+	// --unsafe-fault-injection. Supports broadcasting to multiple machines.
+	InjectFault(context.Context, *InjectFaultRequest) (*FaultsResponse, error)
+	// ClearFaults removes the injected faults. Supports broadcasting to multiple machines.
+	ClearFaults(context.Context, *ClearFaultsRequest) (*FaultsResponse, error)
+	// ListFaults returns the active injected faults. Supports broadcasting to multiple machines.
+	ListFaults(context.Context, *emptypb.Empty) (*FaultsResponse, error)
 	mustEmbedUnimplementedMachineServer()
 }
 
@@ -199,7 +361,7 @@ type MachineServer interface {
 // pointer dereference when methods are called.
 type UnimplementedMachineServer struct{}
 
-func (UnimplementedMachineServer) CheckPrerequisites(context.Context, *emptypb.Empty) (*CheckPrerequisitesResponse, error) {
+func (UnimplementedMachineServer) CheckPrerequisites(context.Context, *CheckPrerequisitesRequest) (*CheckPrerequisitesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckPrerequisites not implemented")
 }
 func (UnimplementedMachineServer) InitCluster(context.Context, *InitClusterRequest) (*InitClusterResponse, error) {
@@ -220,15 +382,45 @@ func (UnimplementedMachineServer) InspectMachine(context.Context, *emptypb.Empty
 func (UnimplementedMachineServer) InspectWireGuardNetwork(context.Context, *emptypb.Empty) (*InspectWireGuardNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InspectWireGuardNetwork not implemented")
 }
+func (UnimplementedMachineServer) InspectNetwork(context.Context, *emptypb.Empty) (*InspectNetworkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectNetwork not implemented")
+}
 func (UnimplementedMachineServer) Reset(context.Context, *ResetRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
 }
+func (UnimplementedMachineServer) ApplySubnet(context.Context, *emptypb.Empty) (*ApplySubnetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplySubnet not implemented")
+}
+func (UnimplementedMachineServer) ResyncStore(context.Context, *ResyncStoreRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResyncStore not implemented")
+}
+func (UnimplementedMachineServer) Reboot(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reboot not implemented")
+}
+func (UnimplementedMachineServer) RunHostUpdate(context.Context, *RunHostUpdateRequest) (*RunHostUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunHostUpdate not implemented")
+}
 func (UnimplementedMachineServer) InspectService(context.Context, *InspectServiceRequest) (*InspectServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InspectService not implemented")
 }
 func (UnimplementedMachineServer) MachineLogs(*LogsRequest, grpc.ServerStreamingServer[LogEntry]) error {
 	return status.Errorf(codes.Unimplemented, "method MachineLogs not implemented")
 }
+func (UnimplementedMachineServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*LogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedMachineServer) GetLogLevel(context.Context, *emptypb.Empty) (*LogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevel not implemented")
+}
+func (UnimplementedMachineServer) InjectFault(context.Context, *InjectFaultRequest) (*FaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectFault not implemented")
+}
+func (UnimplementedMachineServer) ClearFaults(context.Context, *ClearFaultsRequest) (*FaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearFaults not implemented")
+}
+func (UnimplementedMachineServer) ListFaults(context.Context, *emptypb.Empty) (*FaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFaults not implemented")
+}
 func (UnimplementedMachineServer) mustEmbedUnimplementedMachineServer() {}
 func (UnimplementedMachineServer) testEmbeddedByValue()                 {}
 
@@ -251,7 +443,7 @@ func RegisterMachineServer(s grpc.ServiceRegistrar, srv MachineServer) {
 }
 
 func _Machine_CheckPrerequisites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(CheckPrerequisitesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -263,7 +455,7 @@ func _Machine_CheckPrerequisites_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: Machine_CheckPrerequisites_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MachineServer).CheckPrerequisites(ctx, req.(*emptypb.Empty))
+		return srv.(MachineServer).CheckPrerequisites(ctx, req.(*CheckPrerequisitesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -376,6 +568,24 @@ func _Machine_InspectWireGuardNetwork_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Machine_InspectNetwork_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).InspectNetwork(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_InspectNetwork_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).InspectNetwork(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Machine_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResetRequest)
 	if err := dec(in); err != nil {
@@ -394,6 +604,78 @@ func _Machine_Reset_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Machine_ApplySubnet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).ApplySubnet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_ApplySubnet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).ApplySubnet(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_ResyncStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResyncStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).ResyncStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_ResyncStore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).ResyncStore(ctx, req.(*ResyncStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_Reboot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).Reboot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_Reboot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).Reboot(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_RunHostUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunHostUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).RunHostUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_RunHostUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).RunHostUpdate(ctx, req.(*RunHostUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Machine_InspectService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InspectServiceRequest)
 	if err := dec(in); err != nil {
@@ -423,6 +705,96 @@ func _Machine_MachineLogs_Handler(srv interface{}, stream grpc.ServerStream) err
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Machine_MachineLogsServer = grpc.ServerStreamingServer[LogEntry]
 
+func _Machine_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_GetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).GetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_GetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).GetLogLevel(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_InjectFault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectFaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).InjectFault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_InjectFault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).InjectFault(ctx, req.(*InjectFaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_ClearFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).ClearFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_ClearFaults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).ClearFaults(ctx, req.(*ClearFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Machine_ListFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MachineServer).ListFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Machine_ListFaults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MachineServer).ListFaults(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Machine_ServiceDesc is the grpc.ServiceDesc for Machine service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -458,14 +830,54 @@ var Machine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InspectWireGuardNetwork",
 			Handler:    _Machine_InspectWireGuardNetwork_Handler,
 		},
+		{
+			MethodName: "InspectNetwork",
+			Handler:    _Machine_InspectNetwork_Handler,
+		},
 		{
 			MethodName: "Reset",
 			Handler:    _Machine_Reset_Handler,
 		},
+		{
+			MethodName: "ApplySubnet",
+			Handler:    _Machine_ApplySubnet_Handler,
+		},
+		{
+			MethodName: "ResyncStore",
+			Handler:    _Machine_ResyncStore_Handler,
+		},
+		{
+			MethodName: "Reboot",
+			Handler:    _Machine_Reboot_Handler,
+		},
+		{
+			MethodName: "RunHostUpdate",
+			Handler:    _Machine_RunHostUpdate_Handler,
+		},
 		{
 			MethodName: "InspectService",
 			Handler:    _Machine_InspectService_Handler,
 		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _Machine_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "GetLogLevel",
+			Handler:    _Machine_GetLogLevel_Handler,
+		},
+		{
+			MethodName: "InjectFault",
+			Handler:    _Machine_InjectFault_Handler,
+		},
+		{
+			MethodName: "ClearFaults",
+			Handler:    _Machine_ClearFaults_Handler,
+		},
+		{
+			MethodName: "ListFaults",
+			Handler:    _Machine_ListFaults_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,32 @@
+package proxy
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// MetadataKeyClientAddr is the metadata key for the IP address of the client that sent the request to the first
+	// machine API proxy. It's "local" for clients connected through the Unix socket, e.g. the CLI over SSH.
+	MetadataKeyClientAddr = "client-addr"
+	localClientAddr       = "local"
+)
+
+// setClientAddr records the address of the client in the metadata of the proxied request so that the machine
+// serving it can apply per-client limits. The address recorded by the proxy on another machine is kept.
+func setClientAddr(ctx context.Context, md metadata.MD) {
+	if _, proxied := md["proxy-authority"]; proxied && len(md.Get(MetadataKeyClientAddr)) > 0 {
+		return
+	}
+
+	addr := localClientAddr
+	if p, ok := peer.FromContext(ctx); ok {
+		if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
+			addr = tcpAddr.IP.String()
+		}
+	}
+	md.Set(MetadataKeyClientAddr, addr)
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/psviderski/uncloud/internal/grpccompress"
 	"github.com/siderolabs/grpc-proxy/proxy"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -20,6 +21,8 @@ type Director struct {
 	remoteBackends sync.Map
 	localAddress   atomic.Value
 	mapper         MachineMapper
+	// compression is the compression for RPCs proxied to remote machines.
+	compression string
 }
 
 func NewDirector(localSockPath string, remotePort uint16, mapper MachineMapper) *Director {
@@ -30,6 +33,12 @@ func NewDirector(localSockPath string, remotePort uint16, mapper MachineMapper)
 	}
 }
 
+// SetCompression sets the compression for RPCs proxied to remote machines, one of grpccompress.Names.
+// It must be called before the proxy server accepts requests.
+func (d *Director) SetCompression(name string) {
+	d.compression = name
+}
+
 // UpdateLocalAddress updates the local machine address used to identify which requests should be proxied
 // to the local gRPC server. It is called once during machine startup before the proxy server accepts requests.
 func (d *Director) UpdateLocalAddress(addr string) {
@@ -140,7 +149,7 @@ func (d *Director) remoteBackend(addr string) (*RemoteBackend, error) {
 		return b.(*RemoteBackend), nil
 	}
 
-	backend, err := NewRemoteBackend(addr, d.remotePort)
+	backend, err := NewRemoteBackend(addr, d.remotePort, grpccompress.CallOptions(d.compression)...)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,11 @@ func (b *LocalBackend) String() string {
 
 // GetConnection returns a gRPC connection to the local server listening on the Unix socket.
 func (b *LocalBackend) GetConnection(ctx context.Context, _ string) (context.Context, *grpc.ClientConn, error) {
-	md, _ := metadata.FromIncomingContext(ctx)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	setClientAddr(ctx, md)
 	outCtx := metadata.NewOutgoingContext(ctx, md)
 
 	b.mu.RLock()
@@ -20,6 +20,8 @@ import (
 // https://github.com/siderolabs/talos/blob/59a78da42cdea8fbccc35d0851f9b0eef928261b/internal/app/apid/pkg/backend/apid.go
 type RemoteBackend struct {
 	target string
+	// callOptions are added to all proxied RPCs, for example, to enable compression.
+	callOptions []grpc.CallOption
 
 	mu   sync.RWMutex
 	conn *grpc.ClientConn
@@ -28,14 +30,16 @@ type RemoteBackend struct {
 var _ proxy.Backend = (*RemoteBackend)(nil)
 
 // NewRemoteBackend creates a new instance of RemoteBackend for the given IPv6 address and port.
-func NewRemoteBackend(addr string, port uint16) (*RemoteBackend, error) {
+// The call options are added to all proxied RPCs.
+func NewRemoteBackend(addr string, port uint16, callOpts ...grpc.CallOption) (*RemoteBackend, error) {
 	ip, err := netip.ParseAddr(addr)
 	if err != nil || !ip.Is6() {
 		return nil, fmt.Errorf("address must be a valid IPv6 address: %s", addr)
 	}
 
 	return &RemoteBackend{
-		target: netip.AddrPortFrom(ip, port).String(),
+		target:      netip.AddrPortFrom(ip, port).String(),
+		callOptions: callOpts,
 	}, nil
 }
 
@@ -45,7 +49,11 @@ func (b *RemoteBackend) String() string {
 
 // GetConnection returns a gRPC connection to the remote server.
 func (b *RemoteBackend) GetConnection(ctx context.Context, _ string) (context.Context, *grpc.ClientConn, error) {
-	md, _ := metadata.FromIncomingContext(ctx)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	setClientAddr(ctx, md)
 	if authority := md[":authority"]; len(authority) > 0 {
 		md.Set("proxy-authority", authority...)
 	} else {
@@ -89,7 +97,7 @@ func (b *RemoteBackend) GetConnection(ctx context.Context, _ string) (context.Co
 			MinConnectTimeout: 10 * time.Second,
 		}),
 		grpc.WithDefaultCallOptions(
-			grpc.ForceCodecV2(proxy.Codec()),
+			append([]grpc.CallOption{grpc.ForceCodecV2(proxy.Codec())}, b.callOptions...)...,
 		),
 	)
 
@@ -0,0 +1,301 @@
+package autoupdate
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// CheckInterval is the default interval for checking if any auto-updated services are due for an image update check.
+// Each service is checked with its own interval from the spec.
+const CheckInterval = time.Minute
+
+// State reads and writes the auto-update state in the cluster store.
+type State interface {
+	AutoUpdatePaused(ctx context.Context, serviceName string) (bool, error)
+	AutoUpdateStatus(ctx context.Context, serviceID string) (*api.AutoUpdateStatus, error)
+	PutAutoUpdateStatus(ctx context.Context, status *api.AutoUpdateStatus) error
+}
+
+// Images resolves image tags in registries and inspects images on the machine.
+type Images interface {
+	// ResolveDigest returns the digest the image tag points to in the registry.
+	ResolveDigest(ctx context.Context, image string) (string, error)
+	// RepoDigests returns the repo digests of the local image with the given ID.
+	RepoDigests(ctx context.Context, imageID string) ([]string, error)
+}
+
+// Specs retrieves the full service specs of the containers on the machine. The specs in the cluster store can't be
+// deployed as they don't include sensitive data such as environment variables.
+type Specs interface {
+	ContainerServiceSpec(ctx context.Context, containerID string) (api.ServiceSpec, error)
+}
+
+// DeployFunc performs a rolling update of a service to the given spec.
+type DeployFunc func(ctx context.Context, spec api.ServiceSpec) error
+
+// Controller periodically checks the registries for new image digests of services with auto-update enabled
+// and performs rolling updates of the services when their image tags point to new digests. Each service is checked
+// by only one machine among the machines running the service containers: a control-plane machine if there is one,
+// then the one with the lowest ID.
+type Controller struct {
+	machineID string
+	store     *store.Store
+	state     State
+	images    Images
+	specs     Specs
+	deploy    DeployFunc
+	log       *slog.Logger
+	// checkInterval is the interval for checking if any services are due for an image update check.
+	checkInterval time.Duration
+}
+
+func NewController(
+	machineID string,
+	store *store.Store,
+	state State,
+	images Images,
+	specs Specs,
+	deploy DeployFunc,
+	checkInterval time.Duration,
+) *Controller {
+	return &Controller{
+		machineID:     machineID,
+		store:         store,
+		state:         state,
+		images:        images,
+		specs:         specs,
+		deploy:        deploy,
+		log:           slog.With("component", "auto-update-controller"),
+		checkInterval: checkInterval,
+	}
+}
+
+func (c *Controller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.reconcile(ctx); err != nil {
+				c.log.Error("Failed to check services for image updates.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Controller) reconcile(ctx context.Context) error {
+	records, err := c.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	machines, err := c.store.ListMachines(ctx)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	controlPlane := make(map[string]bool)
+	for _, m := range machines {
+		if api.HasMachineRole(m, api.MachineRoleControlPlane) {
+			controlPlane[m.Id] = true
+		}
+	}
+
+	for _, svc := range coordinatedServices(records, c.machineID, controlPlane) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err = c.check(ctx, svc, time.Now()); err != nil {
+			c.log.Error("Failed to check service for image update.", "service", svc.name, "err", err)
+		}
+	}
+	return nil
+}
+
+// service is a service with auto-update enabled coordinated by this machine.
+type service struct {
+	id   string
+	name string
+	// spec is the service spec from the cluster store without sensitive data.
+	spec api.ServiceSpec
+	// containerID is the ID of the most recently created service container on this machine.
+	containerID string
+	// imageID is the ID of the image of the most recently created service container on this machine.
+	imageID string
+}
+
+// coordinatedServices returns the services with auto-update enabled that should be checked by the machine with
+// the given ID. A service is coordinated by one of the machines running its containers. Machines in the controlPlane
+// set are preferred, then the machine with the lowest ID.
+func coordinatedServices(
+	records []store.ContainerRecord, machineID string, controlPlane map[string]bool,
+) []service {
+	byService := make(map[string][]store.ContainerRecord)
+	for _, r := range records {
+		if r.Container.ServiceSpec.AutoUpdate == nil {
+			continue
+		}
+		id := r.Container.ServiceID()
+		byService[id] = append(byService[id], r)
+	}
+
+	var services []service
+	for id, rs := range byService {
+		coordinator := slices.MinFunc(rs, func(a, b store.ContainerRecord) int {
+			// false sorts before true so negate to put control-plane machines first.
+			if c := cmpBool(!controlPlane[a.MachineID], !controlPlane[b.MachineID]); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.MachineID, b.MachineID)
+		}).MachineID
+		if coordinator != machineID {
+			continue
+		}
+
+		// Take the spec from the most recently created local container as a deployment may have failed midway.
+		var latest *store.ContainerRecord
+		for i := range rs {
+			if rs[i].MachineID != machineID {
+				continue
+			}
+			if latest == nil || rs[i].Container.CreatedTime().After(latest.Container.CreatedTime()) {
+				latest = &rs[i]
+			}
+		}
+		services = append(services, service{
+			id:          id,
+			name:        latest.Container.ServiceName(),
+			spec:        latest.Container.ServiceSpec,
+			containerID: latest.Container.ID,
+			imageID:     latest.Container.Image,
+		})
+	}
+
+	slices.SortFunc(services, func(a, b service) int {
+		return cmp.Compare(a.name, b.name)
+	})
+	return services
+}
+
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+// check checks the registry for a new image digest of the service if it's due and performs a rolling update
+// of the service if the digest has changed.
+func (c *Controller) check(ctx context.Context, svc service, now time.Time) error {
+	paused, err := c.state.AutoUpdatePaused(ctx, svc.name)
+	if err != nil {
+		return err
+	}
+	if paused {
+		return nil
+	}
+
+	status, err := c.state.AutoUpdateStatus(ctx, svc.id)
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		status = &api.AutoUpdateStatus{ServiceID: svc.id}
+	}
+	if !status.LastCheck.IsZero() && now.Sub(status.LastCheck) < svc.spec.AutoUpdate.Interval {
+		return nil
+	}
+	status.ServiceName = svc.name
+	status.LastCheck = now
+
+	image, err := api.AutoUpdateImageTag(svc.spec.Container.Image)
+	if err != nil {
+		return c.fail(ctx, status, err)
+	}
+	status.Image = image
+
+	digest, err := c.images.ResolveDigest(ctx, image)
+	if err != nil {
+		return c.fail(ctx, status, fmt.Errorf("resolve image digest: %w", err))
+	}
+	repoDigests, err := c.images.RepoDigests(ctx, svc.imageID)
+	if err != nil {
+		return c.fail(ctx, status, fmt.Errorf("inspect image of service container: %w", err))
+	}
+
+	if !api.HasRepoDigest(repoDigests, digest) {
+		spec, err := c.specs.ContainerServiceSpec(ctx, svc.containerID)
+		if err != nil {
+			return c.fail(ctx, status, fmt.Errorf("get service spec: %w", err))
+		}
+		spec.Container.Image = image + "@" + digest
+
+		c.log.Info("Updating service to new image digest.", "service", svc.name, "image", spec.Container.Image)
+		if err = c.deploy(ctx, spec); err != nil {
+			return c.fail(ctx, status, fmt.Errorf("deploy service with image '%s': %w", spec.Container.Image, err))
+		}
+		c.log.Info("Service updated to new image digest.", "service", svc.name, "image", spec.Container.Image)
+
+		status.LastUpdate = now
+		status.AddEvent(api.AutoUpdateEvent{
+			Time:    now,
+			Type:    api.AutoUpdateEventUpdated,
+			Message: fmt.Sprintf("Updated image '%s' to digest %s.", image, digest),
+		})
+	}
+
+	status.Digest = digest
+	status.Error = ""
+	return c.state.PutAutoUpdateStatus(ctx, status)
+}
+
+// fail records the failed check or update in the service status. The failure event is added only if the error
+// differs from the previous one to avoid flooding the events with the same repeated failure.
+func (c *Controller) fail(ctx context.Context, status *api.AutoUpdateStatus, err error) error {
+	c.log.Warn("Service image auto-update failed.", "service", status.ServiceName, "err", err)
+
+	if status.Error != err.Error() {
+		status.AddEvent(api.AutoUpdateEvent{
+			Time:    status.LastCheck,
+			Type:    api.AutoUpdateEventFailed,
+			Message: err.Error(),
+		})
+	}
+	status.Error = err.Error()
+	return c.state.PutAutoUpdateStatus(ctx, status)
+}
+
+// DockerImages implements Images using the Docker daemon on the machine.
+type DockerImages struct {
+	Client *client.Client
+}
+
+func (d DockerImages) ResolveDigest(ctx context.Context, image string) (string, error) {
+	// TODO: pass the registry credentials to support private registries.
+	inspect, err := d.Client.DistributionInspect(ctx, image, "")
+	if err != nil {
+		return "", err
+	}
+	return inspect.Descriptor.Digest.String(), nil
+}
+
+func (d DockerImages) RepoDigests(ctx context.Context, imageID string) ([]string, error) {
+	img, err := d.Client.ImageInspect(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	return img.RepoDigests, nil
+}
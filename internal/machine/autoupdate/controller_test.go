@@ -0,0 +1,247 @@
+package autoupdate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	oldDigest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	newDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func testRecord(
+	machineID, serviceID, serviceName, imageID string, created time.Time, autoUpdate *api.AutoUpdateSpec,
+) store.ContainerRecord {
+	return store.ContainerRecord{
+		MachineID: machineID,
+		Container: api.ServiceContainer{
+			Container: api.Container{InspectResponse: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:      machineID + "-" + serviceName,
+					Created: created.Format(time.RFC3339Nano),
+					Image:   imageID,
+				},
+				Config: &container.Config{Labels: map[string]string{
+					api.LabelServiceID:   serviceID,
+					api.LabelServiceName: serviceName,
+				}},
+			}},
+			ServiceSpec: api.ServiceSpec{
+				Name:       serviceName,
+				AutoUpdate: autoUpdate,
+				Container:  api.ContainerSpec{Image: "myapp:1.2"},
+			},
+		},
+	}
+}
+
+func TestCoordinatedServices(t *testing.T) {
+	t.Parallel()
+
+	autoUpdate := &api.AutoUpdateSpec{Interval: time.Hour}
+	now := time.Now()
+	records := []store.ContainerRecord{
+		// web runs on machines a and b, a coordinates it.
+		testRecord("b", "web-id", "web", "img-b", now, autoUpdate),
+		testRecord("a", "web-id", "web", "img-old", now.Add(-time.Hour), autoUpdate),
+		testRecord("a", "web-id", "web", "img-new", now, autoUpdate),
+		// api runs only on machine b.
+		testRecord("b", "api-id", "api", "img-api", now, autoUpdate),
+		// db doesn't have auto-update enabled.
+		testRecord("a", "db-id", "db", "img-db", now, nil),
+	}
+
+	services := coordinatedServices(records, "a", nil)
+	require.Len(t, services, 1)
+	assert.Equal(t, "web-id", services[0].id)
+	assert.Equal(t, "web", services[0].name)
+	assert.Equal(t, "img-new", services[0].imageID, "should use the most recently created local container")
+	assert.Equal(t, "a-web", services[0].containerID)
+
+	services = coordinatedServices(records, "b", nil)
+	require.Len(t, services, 1)
+	assert.Equal(t, "api", services[0].name)
+
+	assert.Empty(t, coordinatedServices(records, "c", nil))
+
+	// Control-plane machine b coordinates all services it runs.
+	controlPlane := map[string]bool{"b": true}
+	assert.Empty(t, coordinatedServices(records, "a", controlPlane))
+	services = coordinatedServices(records, "b", controlPlane)
+	require.Len(t, services, 2)
+	assert.Equal(t, "api", services[0].name)
+	assert.Equal(t, "web", services[1].name)
+	assert.Equal(t, "img-b", services[1].imageID)
+}
+
+type fakeState struct {
+	paused   bool
+	statuses map[string]*api.AutoUpdateStatus
+}
+
+func (s *fakeState) AutoUpdatePaused(context.Context, string) (bool, error) {
+	return s.paused, nil
+}
+
+func (s *fakeState) AutoUpdateStatus(_ context.Context, serviceID string) (*api.AutoUpdateStatus, error) {
+	return s.statuses[serviceID], nil
+}
+
+func (s *fakeState) PutAutoUpdateStatus(_ context.Context, status *api.AutoUpdateStatus) error {
+	s.statuses[status.ServiceID] = status
+	return nil
+}
+
+type fakeImages struct {
+	digest      string
+	err         error
+	repoDigests []string
+}
+
+func (i *fakeImages) ResolveDigest(context.Context, string) (string, error) {
+	return i.digest, i.err
+}
+
+func (i *fakeImages) RepoDigests(context.Context, string) ([]string, error) {
+	return i.repoDigests, nil
+}
+
+// fakeSpecs returns the full service specs of containers as stored in the machine database.
+type fakeSpecs map[string]api.ServiceSpec
+
+func (s fakeSpecs) ContainerServiceSpec(_ context.Context, containerID string) (api.ServiceSpec, error) {
+	spec, ok := s[containerID]
+	if !ok {
+		return api.ServiceSpec{}, errors.New("not found")
+	}
+	return spec, nil
+}
+
+func TestControllerCheck(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	// The spec from the cluster store doesn't include the environment variables.
+	storeSpec := api.ServiceSpec{
+		Name:       "web",
+		AutoUpdate: &api.AutoUpdateSpec{Interval: time.Hour},
+		Container:  api.ContainerSpec{Image: "myapp:1.2@" + oldDigest},
+	}
+	fullSpec := storeSpec.Clone()
+	fullSpec.Container.Env = api.EnvVars{"DB_PASSWORD": "secret"}
+	specs := fakeSpecs{"web-ctr": fullSpec}
+
+	svc := service{
+		id:          "web-id",
+		name:        "web",
+		spec:        storeSpec,
+		containerID: "web-ctr",
+		imageID:     "img",
+	}
+
+	tests := []struct {
+		name       string
+		paused     bool
+		status     *api.AutoUpdateStatus
+		images     *fakeImages
+		deployErr  error
+		wantDeploy string
+		wantStatus *api.AutoUpdateStatus
+	}{
+		{
+			name:   "up to date",
+			images: &fakeImages{digest: oldDigest, repoDigests: []string{"myapp@" + oldDigest}},
+			wantStatus: &api.AutoUpdateStatus{
+				ServiceID: "web-id", ServiceName: "web", Image: "myapp:1.2", Digest: oldDigest, LastCheck: now,
+			},
+		},
+		{
+			name:       "new digest",
+			images:     &fakeImages{digest: newDigest, repoDigests: []string{"myapp@" + oldDigest}},
+			wantDeploy: "myapp:1.2@" + newDigest,
+			wantStatus: &api.AutoUpdateStatus{
+				ServiceID: "web-id", ServiceName: "web", Image: "myapp:1.2", Digest: newDigest,
+				LastCheck: now, LastUpdate: now,
+				Events: []api.AutoUpdateEvent{{
+					Time:    now,
+					Type:    api.AutoUpdateEventUpdated,
+					Message: "Updated image 'myapp:1.2' to digest " + newDigest + ".",
+				}},
+			},
+		},
+		{
+			name:       "deploy failed",
+			images:     &fakeImages{digest: newDigest, repoDigests: []string{"myapp@" + oldDigest}},
+			deployErr:  errors.New("boom"),
+			wantDeploy: "myapp:1.2@" + newDigest,
+			wantStatus: &api.AutoUpdateStatus{
+				ServiceID: "web-id", ServiceName: "web", Image: "myapp:1.2", LastCheck: now,
+				Error: "deploy service with image 'myapp:1.2@" + newDigest + "': boom",
+				Events: []api.AutoUpdateEvent{{
+					Time:    now,
+					Type:    api.AutoUpdateEventFailed,
+					Message: "deploy service with image 'myapp:1.2@" + newDigest + "': boom",
+				}},
+			},
+		},
+		{
+			name: "repeated failure doesn't add event",
+			status: &api.AutoUpdateStatus{
+				ServiceID: "web-id", LastCheck: now.Add(-2 * time.Hour),
+				Error: "resolve image digest: unauthorized",
+			},
+			images: &fakeImages{err: errors.New("unauthorized")},
+			wantStatus: &api.AutoUpdateStatus{
+				ServiceID: "web-id", ServiceName: "web", Image: "myapp:1.2", LastCheck: now,
+				Error: "resolve image digest: unauthorized",
+			},
+		},
+		{
+			name:   "not due",
+			status: &api.AutoUpdateStatus{ServiceID: "web-id", LastCheck: now.Add(-time.Minute)},
+			images: &fakeImages{digest: newDigest},
+			wantStatus: &api.AutoUpdateStatus{
+				ServiceID: "web-id", LastCheck: now.Add(-time.Minute),
+			},
+		},
+		{
+			name:   "paused",
+			paused: true,
+			images: &fakeImages{digest: newDigest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := &fakeState{paused: tt.paused, statuses: map[string]*api.AutoUpdateStatus{}}
+			if tt.status != nil {
+				state.statuses[tt.status.ServiceID] = tt.status
+			}
+			var deployed api.ServiceSpec
+			deploy := func(_ context.Context, spec api.ServiceSpec) error {
+				deployed = spec
+				return tt.deployErr
+			}
+			ctrl := NewController("a", nil, state, tt.images, specs, deploy, CheckInterval)
+
+			require.NoError(t, ctrl.check(context.Background(), svc, now))
+			assert.Equal(t, tt.wantDeploy, deployed.Container.Image)
+			if tt.wantDeploy != "" {
+				assert.Equal(t, fullSpec.Container.Env, deployed.Container.Env,
+					"environment variables should be preserved in the updated service")
+			}
+			assert.Equal(t, tt.wantStatus, state.statuses["web-id"])
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package autoupdate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/connector"
+)
+
+// LocalDeployer returns a DeployFunc that deploys services through the machine API proxy listening on the local
+// Unix socket. The proxy forwards the requests to the machines running the service containers.
+func LocalDeployer(sockPath string) DeployFunc {
+	return func(ctx context.Context, spec api.ServiceSpec) error {
+		cli, err := client.New(ctx, connector.NewUnixConnector(sockPath))
+		if err != nil {
+			return fmt.Errorf("connect to machine API: %w", err)
+		}
+		defer cli.Close()
+
+		_, err = cli.NewDeployment(spec, nil).Run(ctx)
+		return err
+	}
+}
@@ -0,0 +1,360 @@
+// Package boot recovers the service containers that Docker failed to start when the machine booted. Docker starts
+// the containers with a restart policy all at once when its daemon starts and gives up on the ones that fail to
+// start, for example, because the WireGuard network or a volume wasn't ready yet. The recovery starts such containers
+// once the machine network is configured, in a deterministic order: the dependencies of services (depends_on) before
+// the services that depend on them and, among the services at the same dependency level, stateful services first.
+package boot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// startAttempts is the number of times to try starting a container before giving up.
+	startAttempts = 5
+	// retryInterval is the delay before retrying to start a container that failed to start. It grows linearly with
+	// each attempt.
+	retryInterval = 5 * time.Second
+	// readyTimeout is the maximum time to wait for the started containers to become running and healthy before
+	// starting the next batch of containers.
+	readyTimeout = 2 * time.Minute
+	// readyPollInterval is the interval for checking if the started containers are ready.
+	readyPollInterval = time.Second
+)
+
+// anonymousVolumeRegexp matches the names of anonymous Docker volumes that are random 64-character hex strings.
+var anonymousVolumeRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Docker is the subset of the Docker client used by the recovery.
+type Docker interface {
+	ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+	ContainerStart(ctx context.Context, containerID string, options container.StartOptions) error
+}
+
+// Container is a service container started by the recovery.
+type Container struct {
+	ServiceName   string
+	ContainerID   string
+	ContainerName string
+	// DependsOn are the services the service of the container depends on. The failed containers of these services
+	// are started first.
+	DependsOn []string
+	// Stateful is true if the container mounts a named volume so it's started before the stateless containers
+	// at the same dependency level.
+	Stateful bool
+	// Error is the reason the container failed to start. Empty if the container was started.
+	Error string
+}
+
+// Status is the progress of the boot recovery.
+type Status struct {
+	// StartedAt is the time the recovery started. Zero if it hasn't started yet.
+	StartedAt time.Time
+	// FinishedAt is the time the recovery finished. Zero if it's still in progress.
+	FinishedAt time.Time
+	// Containers are the service containers the recovery started or tried to start in the order they were started.
+	Containers []Container
+}
+
+// Recovery starts the service containers that Docker failed to start when its daemon started.
+type Recovery struct {
+	docker Docker
+	log    *slog.Logger
+	// retryInterval and readyTimeout are overridden in tests.
+	retryInterval time.Duration
+	readyTimeout  time.Duration
+
+	mu     sync.RWMutex
+	status Status
+}
+
+func NewRecovery(docker Docker) *Recovery {
+	return &Recovery{
+		docker:        docker,
+		log:           slog.With("component", "boot-recovery"),
+		retryInterval: retryInterval,
+		readyTimeout:  readyTimeout,
+	}
+}
+
+// Status returns the current progress of the boot recovery.
+func (r *Recovery) Status() Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	status := r.status
+	status.Containers = append([]Container(nil), r.status.Containers...)
+	return status
+}
+
+// Run starts the service containers that failed to start once and returns. The containers are started in batches
+// and each batch is started once the containers of the previous one are running and healthy or after a timeout.
+// The recovery is best-effort so the errors are only logged and reported in the status.
+func (r *Recovery) Run(ctx context.Context) {
+	r.mu.Lock()
+	r.status = Status{StartedAt: time.Now()}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.status.FinishedAt = time.Now()
+		r.mu.Unlock()
+	}()
+
+	containers, err := r.failedContainers(ctx)
+	if err != nil {
+		r.log.Error("Failed to list service containers that failed to start.", "err", err)
+		return
+	}
+	if len(containers) == 0 {
+		r.log.Debug("All service containers were started by Docker, nothing to recover.")
+		return
+	}
+	r.log.Info("Starting service containers that Docker failed to start.", "containers", len(containers))
+
+	batches := startBatches(containers)
+	for i, batch := range batches {
+		started := r.startAll(ctx, batch)
+		if len(started) > 0 && i < len(batches)-1 {
+			r.waitReady(ctx, started)
+		}
+	}
+
+	failed := 0
+	for _, c := range r.Status().Containers {
+		if c.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		r.log.Warn("Finished boot recovery, some service containers failed to start.",
+			"started", len(containers)-failed, "failed", failed)
+	} else {
+		r.log.Info("Finished boot recovery.", "started", len(containers))
+	}
+}
+
+// failedContainers returns the service containers that should be running but Docker failed to start them, in the
+// order they should be started.
+func (r *Recovery) failedContainers(ctx context.Context) ([]Container, error) {
+	summaries, err := r.docker.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", api.LabelManaged),
+			filters.Arg("status", "created"),
+			filters.Arg("status", "exited"),
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []Container
+	for _, s := range summaries {
+		// Hook containers are one-off and aren't restarted.
+		if _, ok := s.Labels[api.LabelHook]; ok {
+			continue
+		}
+		ctr, err := r.docker.ContainerInspect(ctx, s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("inspect container '%s': %w", s.ID, err)
+		}
+		if c, ok := failedContainer(ctr); ok {
+			containers = append(containers, c)
+		}
+	}
+
+	levels := dependencyLevels(containers)
+	sort.Slice(containers, func(i, j int) bool {
+		if li, lj := levels[containers[i].ServiceName], levels[containers[j].ServiceName]; li != lj {
+			return li < lj
+		}
+		if containers[i].Stateful != containers[j].Stateful {
+			return containers[i].Stateful
+		}
+		if containers[i].ServiceName != containers[j].ServiceName {
+			return containers[i].ServiceName < containers[j].ServiceName
+		}
+		return containers[i].ContainerName < containers[j].ContainerName
+	})
+	return containers, nil
+}
+
+// dependencyLevels returns the dependency level of each service of the containers. Services that don't depend on
+// other services of the containers have level 0, and the rest have a level higher than all their dependencies.
+// Dependencies that don't have containers to start, e.g. because they're running or on other machines, are ignored.
+// Dependency cycles are broken arbitrarily.
+func dependencyLevels(containers []Container) map[string]int {
+	deps := make(map[string][]string)
+	for _, c := range containers {
+		deps[c.ServiceName] = c.DependsOn
+	}
+
+	levels := make(map[string]int, len(deps))
+	visiting := make(map[string]bool)
+	var level func(service string) int
+	level = func(service string) int {
+		if l, ok := levels[service]; ok {
+			return l
+		}
+		visiting[service] = true
+		l := 0
+		for _, dep := range deps[service] {
+			if _, ok := deps[dep]; !ok || visiting[dep] {
+				continue
+			}
+			l = max(l, level(dep)+1)
+		}
+		visiting[service] = false
+		levels[service] = l
+		return l
+	}
+	for service := range deps {
+		level(service)
+	}
+	return levels
+}
+
+// startBatches splits the ordered containers into batches of the same dependency level and statefulness. Each batch
+// is started once the containers of the previous one are ready.
+func startBatches(containers []Container) [][]Container {
+	levels := dependencyLevels(containers)
+	var batches [][]Container
+	for i, c := range containers {
+		if i == 0 || levels[c.ServiceName] != levels[containers[i-1].ServiceName] ||
+			c.Stateful != containers[i-1].Stateful {
+			batches = append(batches, nil)
+		}
+		batches[len(batches)-1] = append(batches[len(batches)-1], c)
+	}
+	return batches
+}
+
+// failedContainer returns the container if Docker failed to start it despite its restart policy. Containers stopped
+// by the user aren't started as they don't have a start error.
+func failedContainer(ctr container.InspectResponse) (Container, bool) {
+	if ctr.ContainerJSONBase == nil || ctr.State == nil || ctr.HostConfig == nil || ctr.Config == nil {
+		return Container{}, false
+	}
+	if ctr.State.Running || ctr.State.Error == "" {
+		return Container{}, false
+	}
+	policy := ctr.HostConfig.RestartPolicy.Name
+	if policy != container.RestartPolicyUnlessStopped && policy != container.RestartPolicyAlways {
+		return Container{}, false
+	}
+
+	stateful := false
+	for _, m := range ctr.Mounts {
+		if m.Type == mount.TypeVolume && !anonymousVolumeRegexp.MatchString(m.Name) {
+			stateful = true
+			break
+		}
+	}
+
+	var dependsOn []string
+	if deps := ctr.Config.Labels[api.LabelServiceDependsOn]; deps != "" {
+		dependsOn = strings.Split(deps, ",")
+	}
+
+	return Container{
+		ServiceName:   ctr.Config.Labels[api.LabelServiceName],
+		ContainerID:   ctr.ID,
+		ContainerName: strings.TrimPrefix(ctr.Name, "/"),
+		DependsOn:     dependsOn,
+		Stateful:      stateful,
+	}, true
+}
+
+// startAll starts the containers one by one retrying the failed starts and returns the containers that were started.
+func (r *Recovery) startAll(ctx context.Context, containers []Container) []Container {
+	var started []Container
+	for _, c := range containers {
+		if err := r.start(ctx, c); err != nil {
+			c.Error = err.Error()
+			r.log.Error("Failed to start service container.",
+				"service", c.ServiceName, "container", c.ContainerName, "err", err)
+		} else {
+			started = append(started, c)
+			r.log.Info("Started service container.", "service", c.ServiceName, "container", c.ContainerName)
+		}
+
+		r.mu.Lock()
+		r.status.Containers = append(r.status.Containers, c)
+		r.mu.Unlock()
+	}
+	return started
+}
+
+func (r *Recovery) start(ctx context.Context, c Container) error {
+	var err error
+	for attempt := 1; attempt <= startAttempts; attempt++ {
+		if err = r.docker.ContainerStart(ctx, c.ContainerID, container.StartOptions{}); err == nil {
+			return nil
+		}
+		if attempt == startAttempts {
+			break
+		}
+
+		r.log.Debug("Failed to start service container, retrying.",
+			"container", c.ContainerName, "attempt", attempt, "err", err)
+		select {
+		case <-time.After(time.Duration(attempt) * r.retryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// waitReady waits for the containers to be running and healthy if they have a health check. The containers that
+// aren't ready after readyTimeout are logged and don't block the recovery.
+func (r *Recovery) waitReady(ctx context.Context, containers []Container) {
+	ctx, cancel := context.WithTimeout(ctx, r.readyTimeout)
+	defer cancel()
+
+	pending := containers
+	for {
+		var notReady []Container
+		for _, c := range pending {
+			ctr, err := r.docker.ContainerInspect(ctx, c.ContainerID)
+			if err != nil || !ready(ctr) {
+				notReady = append(notReady, c)
+			}
+		}
+		if len(notReady) == 0 {
+			return
+		}
+		pending = notReady
+
+		select {
+		case <-time.After(readyPollInterval):
+		case <-ctx.Done():
+			for _, c := range pending {
+				r.log.Warn("Service container isn't ready, starting the remaining containers anyway.",
+					"service", c.ServiceName, "container", c.ContainerName)
+			}
+			return
+		}
+	}
+}
+
+func ready(ctr container.InspectResponse) bool {
+	if ctr.ContainerJSONBase == nil || ctr.State == nil || !ctr.State.Running {
+		return false
+	}
+	return ctr.State.Health == nil || ctr.State.Health.Status == container.Healthy ||
+		ctr.State.Health.Status == container.NoHealthcheck
+}
@@ -0,0 +1,196 @@
+package boot
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDocker struct {
+	mu         sync.Mutex
+	containers map[string]container.InspectResponse
+	// failures is the number of times starting a container fails before it succeeds, by container ID.
+	failures map[string]int
+	started  []string
+}
+
+func (d *fakeDocker) ContainerList(context.Context, container.ListOptions) ([]container.Summary, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var summaries []container.Summary
+	for id, ctr := range d.containers {
+		summaries = append(summaries, container.Summary{ID: id, Labels: ctr.Config.Labels})
+	}
+	return summaries, nil
+}
+
+func (d *fakeDocker) ContainerInspect(_ context.Context, id string) (container.InspectResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.containers[id], nil
+}
+
+func (d *fakeDocker) ContainerStart(_ context.Context, id string, _ container.StartOptions) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.failures[id] > 0 {
+		d.failures[id]--
+		return errors.New("network not ready")
+	}
+	d.started = append(d.started, id)
+	d.containers[id].State.Running = true
+	d.containers[id].State.Error = ""
+	return nil
+}
+
+func serviceContainer(id, service string, running bool, startErr string, volume string) container.InspectResponse {
+	ctr := container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:         id,
+			Name:       "/" + service + "-" + id,
+			State:      &container.State{Running: running, Error: startErr},
+			HostConfig: &container.HostConfig{RestartPolicy: container.RestartPolicy{Name: "unless-stopped"}},
+		},
+		Config: &container.Config{Labels: map[string]string{
+			api.LabelManaged:     "",
+			api.LabelServiceName: service,
+		}},
+	}
+	if volume != "" {
+		ctr.Mounts = []container.MountPoint{{Type: mount.TypeVolume, Name: volume}}
+	}
+	return ctr
+}
+
+func TestRecovery_Run(t *testing.T) {
+	t.Parallel()
+
+	anonymous := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	docker := &fakeDocker{
+		containers: map[string]container.InspectResponse{
+			"web1":    serviceContainer("web1", "web", false, "address not available", anonymous),
+			"db1":     serviceContainer("db1", "db", false, "address not available", "db-data"),
+			"cache1":  serviceContainer("cache1", "cache", false, "address not available", "cache-data"),
+			"api1":    serviceContainer("api1", "api", false, "address not available", ""),
+			"running": serviceContainer("running", "api", true, "", ""),
+			// Stopped by the user.
+			"stopped": serviceContainer("stopped", "api", false, "", ""),
+		},
+		failures: map[string]int{"api1": 1},
+	}
+	hook := serviceContainer("hook", "db", false, "address not available", "")
+	hook.Config.Labels[api.LabelHook] = api.LabelHookPreDeploy
+	docker.containers["hook"] = hook
+
+	r := NewRecovery(docker)
+	r.retryInterval = time.Millisecond
+	r.Run(context.Background())
+
+	assert.Equal(t, []string{"cache1", "db1", "api1", "web1"}, docker.started,
+		"stateful containers must be started first")
+
+	status := r.Status()
+	assert.False(t, status.StartedAt.IsZero())
+	assert.False(t, status.FinishedAt.IsZero())
+	require.Len(t, status.Containers, 4)
+	assert.Equal(t, Container{
+		ServiceName:   "cache",
+		ContainerID:   "cache1",
+		ContainerName: "cache-cache1",
+		Stateful:      true,
+	}, status.Containers[0])
+	for _, c := range status.Containers {
+		assert.Empty(t, c.Error)
+	}
+}
+
+func TestRecovery_Run_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	withDeps := func(ctr container.InspectResponse, deps string) container.InspectResponse {
+		ctr.Config.Labels[api.LabelServiceDependsOn] = deps
+		return ctr
+	}
+	docker := &fakeDocker{
+		containers: map[string]container.InspectResponse{
+			"web1":    withDeps(serviceContainer("web1", "web", false, "address not available", ""), "api"),
+			"api1":    withDeps(serviceContainer("api1", "api", false, "address not available", ""), "auth,cache,db"),
+			"cache1":  serviceContainer("cache1", "cache", false, "address not available", ""),
+			"db1":     serviceContainer("db1", "db", false, "address not available", "db-data"),
+			"worker1": serviceContainer("worker1", "worker", false, "address not available", ""),
+			// Dependencies that are already running don't affect the order.
+			"auth1": serviceContainer("auth1", "auth", true, "", ""),
+		},
+	}
+
+	r := NewRecovery(docker)
+	r.retryInterval = time.Millisecond
+	r.Run(context.Background())
+
+	assert.Equal(t, []string{"db1", "cache1", "worker1", "api1", "web1"}, docker.started,
+		"dependencies must be started before the services that depend on them")
+	assert.Equal(t, []string{"auth", "cache", "db"}, r.Status().Containers[3].DependsOn)
+}
+
+func TestStartBatches(t *testing.T) {
+	t.Parallel()
+
+	containers := []Container{
+		{ServiceName: "db", Stateful: true},
+		{ServiceName: "cache"},
+		{ServiceName: "worker"},
+		{ServiceName: "api", DependsOn: []string{"cache", "db"}},
+		{ServiceName: "web", DependsOn: []string{"api"}},
+	}
+	assert.Equal(t, [][]Container{
+		containers[0:1],
+		containers[1:3],
+		containers[3:4],
+		containers[4:5],
+	}, startBatches(containers))
+}
+
+func TestDependencyLevels_Cycle(t *testing.T) {
+	t.Parallel()
+
+	levels := dependencyLevels([]Container{
+		{ServiceName: "a", DependsOn: []string{"b"}},
+		{ServiceName: "b", DependsOn: []string{"a"}},
+	})
+	assert.Len(t, levels, 2)
+	assert.NotEqual(t, levels["a"], levels["b"])
+}
+
+func TestRecovery_Run_StartFails(t *testing.T) {
+	t.Parallel()
+
+	docker := &fakeDocker{
+		containers: map[string]container.InspectResponse{
+			"db1":  serviceContainer("db1", "db", false, "address not available", "db-data"),
+			"web1": serviceContainer("web1", "web", false, "address not available", ""),
+		},
+		failures: map[string]int{"db1": startAttempts},
+	}
+
+	r := NewRecovery(docker)
+	r.retryInterval = time.Millisecond
+	r.Run(context.Background())
+
+	// The stateless containers are started even if a stateful one fails to start.
+	assert.Equal(t, []string{"web1"}, docker.started)
+	status := r.Status()
+	require.Len(t, status.Containers, 2)
+	assert.Equal(t, "network not ready", status.Containers[0].Error)
+	assert.Empty(t, status.Containers[1].Error)
+}
@@ -0,0 +1,176 @@
+package caddyconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/fs"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// containerConfigDir is the path where the Caddy config directory is mounted in the caddy service container.
+	containerConfigDir = "/config"
+	// acmeDNSCredentialsDir is the directory in the Caddy config directory for the decrypted DNS provider
+	// credentials. Caddy reads them using {file.*} placeholders so they don't appear in the Caddyfile.
+	acmeDNSCredentialsDir = "acme-dns"
+)
+
+// ACMEDNSSource provides the ACME DNS-01 challenge configuration and decrypts its sealed credentials.
+type ACMEDNSSource interface {
+	ACMEDNS(ctx context.Context) (*api.ACMEDNS, error)
+	OpenSealedSecret(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+// acmeDNSSnippet is the template data for the (acme_dns) Caddyfile snippet.
+type acmeDNSSnippet struct {
+	Provider  string
+	Options   []acmeDNSOption
+	Resolvers []string
+}
+
+type acmeDNSOption struct {
+	Name  string
+	Value string
+}
+
+// wildcardSite is the template data for a site with a wildcard certificate that routes requests to its
+// subdomains.
+type wildcardSite struct {
+	Domain string
+	Hosts  []wildcardHost
+}
+
+type wildcardHost struct {
+	// Matcher is the name of the host matcher, the first label of the hostname.
+	Matcher   string
+	Hostname  string
+	Upstreams []string
+}
+
+func newACMEDNSSnippet(config *api.ACMEDNS) *acmeDNSSnippet {
+	if config == nil {
+		return nil
+	}
+	snippet := &acmeDNSSnippet{
+		Provider:  config.Provider,
+		Resolvers: config.Resolvers,
+	}
+	for _, opt := range slices.Sorted(maps.Keys(config.Credentials)) {
+		snippet.Options = append(snippet.Options, acmeDNSOption{
+			Name:  opt,
+			Value: fmt.Sprintf("{file.%s}", path.Join(containerConfigDir, acmeDNSCredentialsDir, opt)),
+		})
+	}
+	return snippet
+}
+
+// acmeDNSSites returns the HTTPS hostnames that should use the DNS-01 challenge and the sites for the configured
+// wildcard domains. Hostnames covered by a wildcard domain are moved from httpsHostUpstreams to its site.
+func acmeDNSSites(
+	config *api.ACMEDNS, httpsHostUpstreams map[string][]string,
+) (map[string]bool, []wildcardSite) {
+	if config == nil {
+		return nil, nil
+	}
+
+	hosts := make(map[string]bool)
+	wildcardHosts := make(map[string][]wildcardHost)
+	for _, hostname := range slices.Sorted(maps.Keys(httpsHostUpstreams)) {
+		if wildcard, ok := config.Wildcard(hostname); ok {
+			label, _, _ := strings.Cut(hostname, ".")
+			wildcardHosts[wildcard] = append(wildcardHosts[wildcard], wildcardHost{
+				Matcher:   label,
+				Hostname:  hostname,
+				Upstreams: httpsHostUpstreams[hostname],
+			})
+			delete(httpsHostUpstreams, hostname)
+			continue
+		}
+		if config.Matches(hostname) {
+			hosts[hostname] = true
+		}
+	}
+
+	// Wildcard sites are generated even without services to obtain their certificates in advance.
+	var sites []wildcardSite
+	for _, d := range slices.Sorted(slices.Values(config.Wildcards())) {
+		sites = append(sites, wildcardSite{Domain: d, Hosts: wildcardHosts[d]})
+	}
+	return hosts, sites
+}
+
+// openACMEDNSCredentials decrypts the sealed credentials of the ACME DNS-01 challenge configuration.
+func openACMEDNSCredentials(
+	ctx context.Context, source ACMEDNSSource, config *api.ACMEDNS,
+) (map[string][]byte, error) {
+	creds := make(map[string][]byte, len(config.Credentials))
+	for opt, value := range config.Credentials {
+		keyID, ciphertext, err := api.ParseSealedSecret(value)
+		if err != nil {
+			return nil, fmt.Errorf("credential '%s': %w", opt, err)
+		}
+		if creds[opt], err = source.OpenSealedSecret(ctx, keyID, ciphertext); err != nil {
+			return nil, fmt.Errorf("credential '%s': %w", opt, err)
+		}
+	}
+	return creds, nil
+}
+
+// writeACMEDNSCredentials writes the decrypted credentials to files in dir readable by Caddy and removes files
+// of the credentials that are no longer configured. It returns true if any file has changed.
+func writeACMEDNSCredentials(dir string, creds map[string][]byte) (bool, error) {
+	if len(creds) == 0 {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			return false, nil
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return false, fmt.Errorf("remove directory '%s': %w", dir, err)
+		}
+		return true, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return false, fmt.Errorf("create directory '%s': %w", dir, err)
+	}
+	if err := fs.Chown(dir, "", CaddyGroup); err != nil {
+		return false, fmt.Errorf("change owner of directory '%s': %w", dir, err)
+	}
+
+	changed := false
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, fmt.Errorf("read directory '%s': %w", dir, err)
+	}
+	for _, e := range entries {
+		if _, ok := creds[e.Name()]; ok {
+			continue
+		}
+		if err = os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return false, fmt.Errorf("remove stale credential file: %w", err)
+		}
+		changed = true
+	}
+
+	for opt, value := range creds {
+		p := filepath.Join(dir, opt)
+		if current, err := os.ReadFile(p); err == nil && bytes.Equal(current, value) {
+			continue
+		}
+		if err = os.WriteFile(p, value, 0o640); err != nil {
+			return false, fmt.Errorf("write credential file '%s': %w", p, err)
+		}
+		if err = fs.Chown(p, "", CaddyGroup); err != nil {
+			return false, fmt.Errorf("change owner of credential file '%s': %w", p, err)
+		}
+		changed = true
+	}
+	return changed, nil
+}
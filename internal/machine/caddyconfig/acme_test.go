@@ -0,0 +1,192 @@
+package caddyconfig
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaddyfileGeneratorWithACMEDNS(t *testing.T) {
+	sealed := api.SealedSecretPrefix + "0123456789abcdef/Y2lwaGVydGV4dA"
+	tests := []struct {
+		name       string
+		config     *api.ACMEDNS
+		containers []store.ContainerRecord
+		want       string
+	}{
+		{
+			name: "all HTTPS sites",
+			config: &api.ACMEDNS{
+				Provider:    "cloudflare",
+				Credentials: map[string]string{"api_token": sealed},
+			},
+			containers: []store.ContainerRecord{
+				newContainerRecord(newContainer("10.210.0.2", "app.example.com:8080/http"), "mach1"),
+				newContainerRecord(newContainer("10.210.0.3", "secure.example.com:8000/https"), "mach1"),
+			},
+			want: testCaddyfileHeader + `
+# Obtain certificates using the ACME DNS-01 challenge.
+(acme_dns) {
+	tls {
+		dns cloudflare {
+			api_token {file./config/acme-dns/api_token}
+		}
+	}
+}
+
+# Sites generated from service ports.
+
+http://app.example.com {
+	reverse_proxy 10.210.0.2:8080 {
+		import common_proxy
+	}
+	log
+}
+
+https://secure.example.com {
+	reverse_proxy 10.210.0.3:8000 {
+		import common_proxy
+	}
+	import acme_dns
+	log
+}
+`,
+		},
+		{
+			name: "wildcard domain",
+			config: &api.ACMEDNS{
+				Provider: "route53",
+				Credentials: map[string]string{
+					"secret_access_key": sealed,
+					"access_key_id":     sealed,
+				},
+				Domains:   []string{"*.apps.example.com", "*.empty.example.com"},
+				Resolvers: []string{"1.1.1.1", "8.8.8.8"},
+			},
+			containers: []store.ContainerRecord{
+				newContainerRecord(newContainer("10.210.0.2", "web.apps.example.com:8080/https"), "mach1"),
+				newContainerRecord(newContainer("10.210.0.3", "api.apps.example.com:9000/https"), "mach1"),
+				newContainerRecord(newContainer("10.210.0.4", "other.example.com:8000/https"), "mach1"),
+			},
+			want: testCaddyfileHeader + `
+# Obtain certificates using the ACME DNS-01 challenge.
+(acme_dns) {
+	tls {
+		dns route53 {
+			access_key_id {file./config/acme-dns/access_key_id}
+			secret_access_key {file./config/acme-dns/secret_access_key}
+		}
+		resolvers 1.1.1.1 8.8.8.8
+	}
+}
+
+# Sites generated from service ports.
+
+https://other.example.com {
+	reverse_proxy 10.210.0.4:8000 {
+		import common_proxy
+	}
+	log
+}
+
+# Sites with wildcard certificates obtained using the ACME DNS-01 challenge.
+
+https://*.apps.example.com {
+	import acme_dns
+
+	@api host api.apps.example.com
+	handle @api {
+		reverse_proxy 10.210.0.3:9000 {
+			import common_proxy
+		}
+	}
+
+	@web host web.apps.example.com
+	handle @web {
+		reverse_proxy 10.210.0.2:8080 {
+			import common_proxy
+		}
+	}
+
+	# Reject requests to subdomains without a service.
+	handle {
+		abort
+	}
+	log
+}
+
+https://*.empty.example.com {
+	import acme_dns
+
+	# Reject requests to subdomains without a service.
+	handle {
+		abort
+	}
+	log
+}
+`,
+		},
+		{
+			name:   "invalid provider falls back to default challenges",
+			config: &api.ACMEDNS{Provider: "broken"},
+			containers: []store.ContainerRecord{
+				newContainerRecord(newContainer("10.210.0.3", "secure.example.com:8000/https"), "mach1"),
+			},
+			want: testCaddyfileHeader + `
+# Sites generated from service ports.
+
+https://secure.example.com {
+	reverse_proxy 10.210.0.3:8000 {
+		import common_proxy
+	}
+	log
+}
+
+# Skipped invalid user-defined configs:
+# - ACME DNS-01 challenge with provider 'broken': validation failed: module not registered: dns.providers.broken
+`,
+		},
+	}
+
+	ctx := context.Background()
+	validator := NewMockCaddyfileValidator(t)
+	validator.EXPECT().Validate(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, caddyfile string) error {
+			if strings.Contains(caddyfile, "dns broken") {
+				return errors.New("module not registered: dns.providers.broken")
+			}
+			return nil
+		})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewCaddyfileGenerator("test-machine-id", "test-machine", validator, nil)
+			generator.SetACMEDNS(tt.config)
+
+			config, err := generator.Generate(ctx, tt.containers, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, normaliseGeneratedTimestamp(config), "Generated Caddyfile doesn't match")
+		})
+	}
+}
+
+func TestCaddyfileGeneratorWithACMEDNS_CaddyUnavailable(t *testing.T) {
+	// The DNS-01 challenge isn't used when Caddy isn't running as the config can't be validated.
+	generator := NewCaddyfileGenerator("test-machine-id", "test-machine", nil, nil)
+	generator.SetACMEDNS(&api.ACMEDNS{Provider: "cloudflare"})
+
+	config, err := generator.Generate(context.Background(), []store.ContainerRecord{
+		newContainerRecord(newContainer("10.210.0.3", "secure.example.com:8000/https"), "mach1"),
+	}, false)
+	require.NoError(t, err)
+
+	assert.NotContains(t, config, "acme_dns")
+}
@@ -37,22 +37,57 @@ http:// {
 	# Upstreams are marked unhealthy for fail_duration after a failed request (passive health checking).
 	fail_duration 30s
 }
+{{- with .ACMEDNS}}
+
+# Obtain certificates using the ACME DNS-01 challenge.
+(acme_dns) {
+	tls {
+		dns {{.Provider}}{{if .Options}} {
+{{- range .Options}}
+			{{.Name}} {{.Value}}
+{{- end}}
+		}{{end}}
+{{- if .Resolvers}}
+		resolvers {{join .Resolvers " "}}
+{{- end}}
+	}
+}{{end}}
 {{- if or .HTTPHostUpstreams .HTTPSHostUpstreams }}
 
 # Sites generated from service ports.{{end}}
 {{- range $hostname, $upstreams := .HTTPHostUpstreams}}
 
 http://{{$hostname}} {
-	reverse_proxy {{join $upstreams " "}} {
-		import common_proxy
-	}
+{{proxy $hostname $upstreams 1}}
 	log
 }{{end}}
 {{- range $hostname, $upstreams := .HTTPSHostUpstreams}}
 
 https://{{$hostname}} {
-	reverse_proxy {{join $upstreams " "}} {
-		import common_proxy
+{{proxy $hostname $upstreams 1}}
+{{- if index $.ACMEDNSHosts $hostname}}
+	import acme_dns
+{{- end}}
+	log
+}{{end}}
+{{- if .WildcardSites}}
+
+# Sites with wildcard certificates obtained using the ACME DNS-01 challenge.{{end}}
+{{- range .WildcardSites}}
+
+https://{{.Domain}} {
+	import acme_dns
+{{- range .Hosts}}
+
+	@{{.Matcher}} host {{.Hostname}}
+	handle @{{.Matcher}} {
+{{proxy .Hostname .Upstreams 2}}
+	}
+{{- end}}
+
+	# Reject requests to subdomains without a service.
+	handle {
+		abort
 	}
 	log
 }{{end}}
@@ -71,7 +106,10 @@ type CaddyfileGenerator struct {
 	// machineName is the human-friendly name of the machine.
 	machineName string
 	validator   CaddyfileValidator
-	log         *slog.Logger
+	// acmeDNS is the ACME DNS-01 challenge configuration. nil means the default HTTP-01 and TLS-ALPN-01
+	// challenges are used.
+	acmeDNS *api.ACMEDNS
+	log     *slog.Logger
 }
 
 // CaddyfileValidator is an interface for validating Caddyfile configurations.
@@ -97,8 +135,9 @@ func NewCaddyfileGenerator(
 // The Caddyfile is generated from the service ports of the healthy containers.
 // If a 'caddy' service container is running on this machine and defines a custom Caddy config (x-caddy) in its service
 // spec, it will be validated and prepended to the generated Caddyfile. Custom Caddy configs (x-caddy) defined in other
-// service specs are validated and appended to the generated Caddyfile. Invalid configs are logged and skipped to ensure
-// the generated Caddyfile remains valid.
+// service specs are validated and appended to the generated Caddyfile. Custom Caddy directives (x-caddy.directives)
+// are validated and injected into the sites generated from the ports of their services. Invalid configs are logged
+// and skipped to ensure the generated Caddyfile remains valid.
 //
 // The final Caddyfile structure includes:
 //
@@ -108,7 +147,7 @@ func NewCaddyfileGenerator(
 //	...
 //	[service-z x-caddy]
 //
-// If includeCustom is false, custom Caddy configs (x-caddy) are not included in the generated Caddyfile.
+// If includeCustom is false, custom Caddy configs and directives (x-caddy) are not included in the generated Caddyfile.
 func (g *CaddyfileGenerator) Generate(
 	ctx context.Context, records []store.ContainerRecord, includeCustom bool,
 ) (string, error) {
@@ -130,7 +169,7 @@ func (g *CaddyfileGenerator) Generate(
 		containers[i] = cr.Container
 	}
 
-	caddyfile, err := g.generateBaseFromPorts(containers)
+	caddyfile, err := g.generateBaseFromPorts(containers, nil, false, nil)
 	if err != nil {
 		return "", fmt.Errorf("generate base Caddyfile from service ports: %w", err)
 	}
@@ -144,6 +183,103 @@ func (g *CaddyfileGenerator) Generate(
 	// Track validation errors for reporting.
 	var configErrors []string
 
+	// The DNS-01 challenge requires the Caddy image to include the DNS provider module. Validate the config with it
+	// and fall back to the default challenges if it's invalid so that the sites keep being served.
+	var acmeDNS *api.ACMEDNS
+	if g.acmeDNS != nil {
+		caddyfileCandidate, err := g.generateBaseFromPorts(containers, g.acmeDNS, false, nil)
+		if err != nil {
+			return "", fmt.Errorf("generate base Caddyfile with ACME DNS-01 challenge: %w", err)
+		}
+		if err = g.validator.Validate(ctx, caddyfileCandidate); err != nil {
+			g.log.Error("Caddy config with ACME DNS-01 challenge is invalid, using default challenges. "+
+				"Make sure the Caddy image includes the DNS provider module.",
+				"provider", g.acmeDNS.Provider, "err", err)
+			configErrors = append(configErrors,
+				fmt.Sprintf("ACME DNS-01 challenge with provider '%s': validation failed: %v",
+					g.acmeDNS.Provider, err))
+		} else {
+			caddyfile = caddyfileCandidate
+			acmeDNS = g.acmeDNS
+		}
+	}
+
+	// Rate limits also require a non-standard Caddy module. Unlike allow and deny lists, they're skipped if the module
+	// is missing so that the sites keep being served.
+	rateLimits := false
+	if hasRateLimits(containers) {
+		caddyfileCandidate, err := g.generateBaseFromPorts(containers, acmeDNS, true, nil)
+		if err != nil {
+			return "", fmt.Errorf("generate base Caddyfile with rate limits: %w", err)
+		}
+		if err = g.validator.Validate(ctx, caddyfileCandidate); err != nil {
+			g.log.Error("Caddy config with ingress rate limits is invalid, skipping rate limits. "+
+				"Make sure the Caddy image includes the rate_limit module.", "err", err)
+			configErrors = append(configErrors, fmt.Sprintf("ingress rate limits: validation failed: %v", err))
+		} else {
+			caddyfile = caddyfileCandidate
+			rateLimits = true
+		}
+	}
+
+	// There could be multiple service containers for the same service with different custom Caddy configs, for example,
+	// if the service has been partially updated. The most recent container for each service defines the current custom
+	// Caddy config for that service.
+	latestServiceContainers := make(map[string]api.ServiceContainer, len(containers))
+	for _, ctr := range containers {
+		if latest, ok := latestServiceContainers[ctr.ServiceName()]; ok {
+			if ctr.CreatedTime().Compare(latest.CreatedTime()) > 0 {
+				latestServiceContainers[ctr.ServiceName()] = ctr
+			}
+		} else {
+			latestServiceContainers[ctr.ServiceName()] = ctr
+		}
+	}
+	sortedServiceNames := slices.Sorted(maps.Keys(latestServiceContainers))
+	ports := make(map[string]map[string]uint16, len(latestServiceContainers))
+	for name, ctr := range latestServiceContainers {
+		ports[name] = ctr.ServiceSpec.NamedPorts()
+	}
+
+	// Inject the custom Caddy directives of each service into its generated sites. Validate the directives one service
+	// at a time and skip invalid ones so that they don't break the sites of other services.
+	directives := make(map[string]string)
+	for _, serviceName := range sortedServiceNames {
+		ctr := latestServiceContainers[serviceName]
+		if ctr.ServiceSpec.CaddyDirectives() == "" {
+			continue
+		}
+
+		tmplCtx := templateContext{
+			Name:      serviceName,
+			Upstreams: upstreams,
+			Ports:     ports,
+		}
+		rendered, err := renderCaddyfile(tmplCtx, ctr.ServiceSpec.CaddyDirectives())
+		if err != nil {
+			g.log.Error("Failed to render template directives in user-defined Caddy directives for service, "+
+				"skipping them.", "service", serviceName, "err", err)
+			configErrors = append(configErrors,
+				fmt.Sprintf("service '%s': directives: failed to render template: %v", serviceName, err))
+			continue
+		}
+
+		directives[serviceName] = strings.TrimSpace(rendered)
+		caddyfileCandidate, err := g.generateBaseFromPorts(containers, acmeDNS, rateLimits, directives)
+		if err != nil {
+			return "", fmt.Errorf("generate base Caddyfile with custom directives: %w", err)
+		}
+		if err = g.validator.Validate(ctx, caddyfileCandidate); err != nil {
+			g.log.Error("User-defined Caddy directives for service are invalid, skipping them.",
+				"service", serviceName, "err", err)
+			configErrors = append(configErrors,
+				fmt.Sprintf("service '%s': directives: validation failed: %v", serviceName, err))
+			delete(directives, serviceName)
+		} else {
+			caddyfile = caddyfileCandidate
+		}
+	}
+
 	// Find the 'caddy' service container on this machine. Use the most recent one if multiple exist.
 	var caddyCtr *api.ServiceContainer
 	for _, cr := range records {
@@ -160,6 +296,7 @@ func (g *CaddyfileGenerator) Generate(
 		tmplCtx := templateContext{
 			Name:      caddyCtr.ServiceName(),
 			Upstreams: upstreams,
+			Ports:     ports,
 		}
 		renderedConfig, err := renderCaddyfile(tmplCtx, caddyCtr.ServiceSpec.CaddyConfig())
 		if err != nil {
@@ -182,21 +319,6 @@ func (g *CaddyfileGenerator) Generate(
 		}
 	}
 
-	// There could be multiple service containers for the same service with different custom Caddy configs, for example,
-	// if the service has been partially updated. The most recent container for each service defines the current custom
-	// Caddy config for that service.
-	latestServiceContainers := make(map[string]api.ServiceContainer, len(containers))
-	for _, ctr := range containers {
-		if latest, ok := latestServiceContainers[ctr.ServiceName()]; ok {
-			if ctr.CreatedTime().Compare(latest.CreatedTime()) > 0 {
-				latestServiceContainers[ctr.ServiceName()] = ctr
-			}
-		} else {
-			latestServiceContainers[ctr.ServiceName()] = ctr
-		}
-	}
-	sortedServiceNames := slices.Sorted(maps.Keys(latestServiceContainers))
-
 	// Append a custom Caddy config for each service to the Caddyfile and validate it. If the config for a service
 	// is invalid, skip it but continue processing other services to ensure the Caddyfile remains valid.
 	for _, serviceName := range sortedServiceNames {
@@ -214,6 +336,7 @@ func (g *CaddyfileGenerator) Generate(
 		tmplCtx := templateContext{
 			Name:      serviceName,
 			Upstreams: upstreams,
+			Ports:     ports,
 		}
 		renderedConfig, err := renderCaddyfile(tmplCtx, ctr.ServiceSpec.CaddyConfig())
 		if err != nil {
@@ -258,10 +381,33 @@ func (g *CaddyfileGenerator) localMachineRank(machineID string) int {
 	return 1
 }
 
-func (g *CaddyfileGenerator) generateBaseFromPorts(containers []api.ServiceContainer) (string, error) {
+// SetACMEDNS sets the ACME DNS-01 challenge configuration used to obtain certificates for HTTPS sites.
+// nil disables the DNS-01 challenge.
+func (g *CaddyfileGenerator) SetACMEDNS(config *api.ACMEDNS) {
+	g.acmeDNS = config
+}
+
+// generateBaseFromPorts generates the Caddyfile sites from the service ports. If acmeDNS is not nil, HTTPS sites
+// obtain certificates using the DNS-01 challenge. If rateLimits is false, the rate limits of the ingress policies
+// are omitted. directives maps service names to the rendered custom Caddy directives injected into their sites.
+func (g *CaddyfileGenerator) generateBaseFromPorts(
+	containers []api.ServiceContainer, acmeDNS *api.ACMEDNS, rateLimits bool, directives map[string]string,
+) (string, error) {
 	httpHostUpstreams, httpsHostUpstreams := httpUpstreamsFromPorts(containers)
+	acmeDNSHosts, wildcardSites := acmeDNSSites(acmeDNS, httpsHostUpstreams)
+	routes := hostRoutes(containers, g.log)
 
-	funcs := template.FuncMap{"join": strings.Join}
+	funcs := template.FuncMap{
+		"join": strings.Join,
+		"proxy": func(hostname string, upstreams []string, depth int) string {
+			route := routes[hostname]
+			proxy := proxyDirectives(upstreams, route.policy, rateLimits, depth)
+			if d := directives[route.service]; d != "" {
+				return userDirectives(route.service, d, depth) + proxy
+			}
+			return proxy
+		},
+	}
 	tmpl, err := template.New("Caddyfile").Funcs(funcs).Parse(caddyfileTemplate)
 	if err != nil {
 		return "", fmt.Errorf("parse Caddyfile template: %w", err)
@@ -272,11 +418,17 @@ func (g *CaddyfileGenerator) generateBaseFromPorts(containers []api.ServiceConta
 		VerifyResponse     string
 		HTTPHostUpstreams  map[string][]string
 		HTTPSHostUpstreams map[string][]string
+		ACMEDNS            *acmeDNSSnippet
+		ACMEDNSHosts       map[string]bool
+		WildcardSites      []wildcardSite
 	}{
 		VerifyPath:         VerifyPath,
 		VerifyResponse:     g.machineID,
 		HTTPHostUpstreams:  httpHostUpstreams,
 		HTTPSHostUpstreams: httpsHostUpstreams,
+		ACMEDNS:            newACMEDNSSnippet(acmeDNS),
+		ACMEDNSHosts:       acmeDNSHosts,
+		WildcardSites:      wildcardSites,
 	}
 
 	var buf bytes.Buffer
@@ -352,6 +504,7 @@ func serviceUpstreams(containers []api.ServiceContainer) map[string][]string {
 func renderCaddyfile(tmplCtx templateContext, caddyfile string) (string, error) {
 	funcs := template.FuncMap{
 		"upstreams": upstreamsTemplateFn(tmplCtx),
+		"port":      portTemplateFn(tmplCtx),
 	}
 
 	tmpl, err := template.New("Caddyfile").Funcs(funcs).Parse(caddyfile)
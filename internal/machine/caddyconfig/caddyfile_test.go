@@ -1037,3 +1037,67 @@ func newContainerRecordWithPorts(serviceName, ip string, ports []string, machine
 		MachineID: machineID,
 	}
 }
+
+func TestRenderCaddyfile_NamedPorts(t *testing.T) {
+	t.Parallel()
+
+	tmplCtx := templateContext{
+		Name: "web",
+		Upstreams: map[string][]string{
+			"web": {"10.210.0.2"},
+			"api": {"10.210.0.3", "10.210.1.2"},
+		},
+		Ports: map[string]map[string]uint16{
+			"web": {"http": 8080},
+			"api": {"grpc": 9000},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "current service port",
+			template: `reverse_proxy {{upstreams .Name "http"}}`,
+			want:     "reverse_proxy 10.210.0.2:8080",
+		},
+		{
+			name:     "other service port",
+			template: `reverse_proxy h2c://{{upstreams "api" "grpc"}}`,
+			want:     "reverse_proxy h2c://10.210.0.3:9000 10.210.1.2:9000",
+		},
+		{
+			name:     "port number",
+			template: `{{port "http"}} {{port "api" "grpc"}}`,
+			want:     "8080 9000",
+		},
+		{
+			name:     "unknown port",
+			template: `reverse_proxy {{upstreams "api" "http"}}`,
+			wantErr:  "upstreams function: service 'api' has no port named 'http'",
+		},
+		{
+			name:     "unknown port number",
+			template: `{{port "grpc"}}`,
+			wantErr:  "port function: service 'web' has no port named 'grpc'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := renderCaddyfile(tmplCtx, tt.template)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
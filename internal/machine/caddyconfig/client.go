@@ -88,15 +88,36 @@ func (c *CaddyAdminClient) Adapt(ctx context.Context, caddyfile string) (string,
 	return "", errors.New(string(body))
 }
 
-// Load loads a Caddyfile configuration into the Caddy instance running on the machine.
-// Due to a Caddy bug (https://github.com/caddyserver/caddy/issues/7246), we first adapt the Caddyfile to JSON
-// and then load the JSON config to get proper error handling.
-func (c *CaddyAdminClient) Load(ctx context.Context, caddyfile string) error {
-	jsonConfig, err := c.Adapt(ctx, caddyfile)
+// Config returns the JSON configuration currently loaded into the Caddy instance.
+func (c *CaddyAdminClient) Config(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://localhost/config/", nil)
 	if err != nil {
-		return fmt.Errorf("adapt Caddyfile to JSON config: %w", err)
+		return nil, fmt.Errorf("create config request: %w", err)
 	}
 
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send config request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("caddy responded with error: HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// LoadJSON loads a JSON configuration into the Caddy instance running on the machine. Caddy reloads the config
+// gracefully: it starts the new config before stopping the old one so in-flight requests and listeners are not
+// interrupted. If the new config fails to start, Caddy keeps running the old one and an error is returned.
+// Caddyfile configs should be adapted with Adapt first rather than loaded directly due to a Caddy bug
+// (https://github.com/caddyserver/caddy/issues/7246) that breaks error handling.
+func (c *CaddyAdminClient) LoadJSON(ctx context.Context, jsonConfig string) error {
 	req, err := http.NewRequestWithContext(ctx, "POST", "http://localhost/load", strings.NewReader(jsonConfig))
 	if err != nil {
 		return fmt.Errorf("create load request: %w", err)
@@ -3,13 +3,18 @@ package caddyconfig
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"net/netip"
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/psviderski/uncloud/internal/fs"
 	"github.com/psviderski/uncloud/internal/machine/store"
@@ -20,8 +25,22 @@ const (
 	CaddyServiceName = "caddy"
 	CaddyGroup       = "uncloud"
 	VerifyPath       = "/.uncloud-verify"
+
+	// acmeDNSCheckInterval is how often the controller checks the ACME DNS-01 challenge configuration for changes.
+	acmeDNSCheckInterval = 10 * time.Second
+	// verifyAttempts is how many times the controller checks that Caddy serves requests after loading a new config
+	// before reverting to the previous one.
+	verifyAttempts = 3
+	// verifyRetryInterval is the delay between the verification attempts.
+	verifyRetryInterval = time.Second
 )
 
+// IngressEventStore stores the most recent changes of the ingress configuration on machines.
+type IngressEventStore interface {
+	IngressEvents(ctx context.Context, machineID string) (*api.IngressEvents, error)
+	PutIngressEvents(ctx context.Context, e *api.IngressEvents) error
+}
+
 // Controller monitors container changes in the cluster store and generates a configuration file for Caddy reverse
 // proxy. The generated configuration allows Caddy to route external traffic to service containers across the internal
 // network.
@@ -31,21 +50,33 @@ type Controller struct {
 	generator     *CaddyfileGenerator
 	client        *CaddyAdminClient
 	store         *store.Store
-	log           *slog.Logger
+	acmeDNSSource ACMEDNSSource
+	events        IngressEventStore
+	// verifyURL is the URL of the verification endpoint served by the local Caddy that is used to check that Caddy
+	// serves requests after loading a new config.
+	verifyURL  string
+	httpClient *http.Client
+	log        *slog.Logger
+	// acmeDNS caches the last seen ACME DNS-01 challenge configuration.
+	acmeDNS *api.ACMEDNS
 	// lastFingerprint caches the fingerprint of the containers used to generate the latest successfully loaded
 	// Caddyfile. nil means it hasn't been loaded yet or the last load failed.
 	lastFingerprint []containerFingerprint
 	// lastCaddyfile caches the last generated Caddyfile.
 	lastCaddyfile string
+	// lastHostnames are the ingress hostnames in the last successfully loaded config.
+	lastHostnames []string
 }
 
 // containerFingerprint is the subset of container data that the Caddyfile generator depends on.
 // Comparing fingerprints lets the controller skip no-op regenerations.
 type containerFingerprint struct {
-	ID          string
-	IP          netip.Addr
-	Ports       []api.PortSpec
-	CaddyConfig string
+	ID              string
+	IP              netip.Addr
+	Ports           []api.PortSpec
+	CaddyConfig     string
+	CaddyDirectives string
+	Ingress         *api.IngressPolicy
 }
 
 // Equal returns whether two fingerprints describe the same container input to the Caddyfile generator.
@@ -53,10 +84,17 @@ func (f containerFingerprint) Equal(other containerFingerprint) bool {
 	return f.ID == other.ID &&
 		f.IP == other.IP &&
 		api.PortsEqual(f.Ports, other.Ports) &&
-		f.CaddyConfig == other.CaddyConfig
+		f.CaddyConfig == other.CaddyConfig &&
+		f.CaddyDirectives == other.CaddyDirectives &&
+		f.Ingress.Equals(other.Ingress)
 }
 
-func NewController(machineID, configDir, adminSock string, store *store.Store) (*Controller, error) {
+func NewController(
+	machineID, configDir, adminSock string,
+	store *store.Store,
+	acmeDNSSource ACMEDNSSource,
+	events IngressEventStore,
+) (*Controller, error) {
 	if err := os.MkdirAll(configDir, 0o750); err != nil {
 		return nil, fmt.Errorf("create directory for Caddy configuration '%s': %w", configDir, err)
 	}
@@ -73,7 +111,12 @@ func NewController(machineID, configDir, adminSock string, store *store.Store) (
 		caddyfilePath: filepath.Join(configDir, "Caddyfile"),
 		client:        client,
 		store:         store,
-		log:           log,
+		acmeDNSSource: acmeDNSSource,
+		events:        events,
+		// Caddy publishes its HTTP port on the host so the verification endpoint is reachable on the loopback address.
+		verifyURL:  "http://127.0.0.1" + VerifyPath,
+		httpClient: &http.Client{Timeout: 2 * time.Second},
+		log:        log,
 	}, nil
 }
 
@@ -88,47 +131,85 @@ func (c *Controller) Run(ctx context.Context) error {
 		machineName = m.Name
 	}
 	c.generator = NewCaddyfileGenerator(c.machineID, machineName, c.client, c.log)
+	c.refreshACMEDNS(ctx)
 
-	containers, changes, err := c.store.SubscribeContainers(ctx)
+	updates, err := c.store.WatchContainers(ctx)
 	if err != nil {
 		return fmt.Errorf("subscribe to container changes: %w", err)
 	}
 	c.log.Info("Subscribed to container changes in the cluster to generate Caddy configuration.")
 
-	containers = filterHealthyContainers(containers)
-	c.generateAndLoadCaddyfile(ctx, containers)
-
-	// TODO: left for backward compatibility, remove later.
-	if err = c.generateJSONConfig(containers); err != nil {
-		c.log.Error("Failed to generate Caddy JSON configuration to disk.", "err", err)
-	}
+	ticker := time.NewTicker(acmeDNSCheckInterval)
+	defer ticker.Stop()
 
+	// The first update is the current list of containers, the following ones are sent after containers change.
+	var containers []store.ContainerRecord
 	for {
 		select {
-		case _, ok := <-changes:
+		case update, ok := <-updates:
 			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
 				return fmt.Errorf("containers subscription failed")
 			}
 			c.log.Debug("Cluster containers changed, regenerating Caddy configuration.")
-
-			containers, err = c.store.ListContainers(ctx, store.ListOptions{})
-			if err != nil {
-				c.log.Error("Failed to list containers.", "err", err)
-				continue
-			}
-			containers = filterHealthyContainers(containers)
+			containers = filterHealthyContainers(update)
 			c.generateAndLoadCaddyfile(ctx, containers)
 
 			// TODO: left for backward compatibility, remove later.
 			if err = c.generateJSONConfig(containers); err != nil {
 				c.log.Error("Failed to generate Caddy JSON configuration to disk.", "err", err)
 			}
-		case <-ctx.Done():
-			return nil
+		case <-ticker.C:
+			if c.refreshACMEDNS(ctx) && containers != nil {
+				c.log.Debug("ACME DNS-01 challenge configuration changed, regenerating Caddy configuration.")
+				c.generateAndLoadCaddyfile(ctx, containers)
+			}
 		}
 	}
 }
 
+// refreshACMEDNS updates the generator and the credential files if the ACME DNS-01 challenge configuration has
+// changed. It returns true if the Caddy configuration needs to be regenerated.
+func (c *Controller) refreshACMEDNS(ctx context.Context) bool {
+	if c.acmeDNSSource == nil {
+		return false
+	}
+	config, err := c.acmeDNSSource.ACMEDNS(ctx)
+	if err != nil {
+		c.log.Error("Failed to get ACME DNS-01 challenge configuration.", "err", err)
+		return false
+	}
+	if reflect.DeepEqual(config, c.acmeDNS) {
+		return false
+	}
+	c.acmeDNS = config
+
+	var creds map[string][]byte
+	if config != nil {
+		if creds, err = openACMEDNSCredentials(ctx, c.acmeDNSSource, config); err != nil {
+			c.log.Error("Failed to decrypt ACME DNS-01 challenge credentials, using default challenges.", "err", err)
+			config = nil
+		}
+	}
+	dir := filepath.Join(filepath.Dir(c.caddyfilePath), acmeDNSCredentialsDir)
+	if _, err = writeACMEDNSCredentials(dir, creds); err != nil {
+		c.log.Error("Failed to write ACME DNS-01 challenge credentials, using default challenges.", "err", err)
+		config = nil
+	}
+
+	c.generator.SetACMEDNS(config)
+	// Force the next regeneration even if the containers haven't changed.
+	c.lastFingerprint = nil
+	if config != nil {
+		c.log.Info("Using ACME DNS-01 challenge to obtain certificates.", "provider", config.Provider)
+	} else {
+		c.log.Info("Using default ACME challenges to obtain certificates.")
+	}
+	return true
+}
+
 // filterHealthyContainers filters out unhealthy and hook containers.
 // TODO: Filters out containers from this machine that are likely unavailable. The availability can be determined
 // by the cluster membership state of the machine that the container is running on. Implement machine membership
@@ -177,18 +258,60 @@ func (c *Controller) generateAndLoadCaddyfile(ctx context.Context, containers []
 		return
 	}
 
+	// Remember the running config to revert to it if Caddy stops serving requests after the reload. There is nothing
+	// to revert to if Caddy isn't serving requests with the running config either.
+	var prevConfig []byte
+	if err = c.verify(ctx); err == nil {
+		if prevConfig, err = c.client.Config(ctx); err != nil {
+			c.log.Warn("Failed to get current Caddy configuration, the new one can't be reverted if it fails.",
+				"err", err)
+		}
+	} else {
+		c.log.Debug("Caddy isn't serving requests with the current configuration.", "err", err)
+	}
+
 	// Caddy is available, try to load the config which may fail if the config is invalid. Generally, a config can
 	// pass the adaptation/validation step but still fail to load, for example, if it references resources that are
-	// not available.
-	if err = c.client.Load(ctx, caddyfile); err != nil {
+	// not available. The reload is graceful and Caddy keeps running the previous config if the new one fails to load.
+	jsonConfig, err := c.client.Adapt(ctx, caddyfile)
+	if err != nil {
+		err = fmt.Errorf("adapt Caddyfile to JSON config: %w", err)
+	} else {
+		err = c.client.LoadJSON(ctx, jsonConfig)
+	}
+	if err != nil {
 		c.log.Error("Failed to load new Caddy configuration into local Caddy instance.",
 			"err", err, "path", c.caddyfilePath)
+		c.addEvent(ctx, api.IngressEventFailed,
+			fmt.Sprintf("Failed to load new configuration, Caddy keeps the previous one: %v", err))
 		// Mark the cache stale so the next container change retries the load even if the container set is unchanged.
 		c.lastFingerprint = nil
 		// Don't write invalid config to disk.
 		return
 	}
+
+	hostnames := ingressHostnames(fingerprint)
+	if prevConfig != nil {
+		if err = c.verifyWithRetries(ctx); err != nil {
+			c.log.Error("Caddy stopped serving requests after loading new configuration, reverting to previous one.",
+				"err", err)
+			if rerr := c.client.LoadJSON(ctx, string(prevConfig)); rerr != nil {
+				c.log.Error("Failed to revert Caddy configuration.", "err", rerr)
+				c.addEvent(ctx, api.IngressEventFailed, fmt.Sprintf(
+					"Caddy stopped serving requests with new configuration (%v) and reverting to the previous one "+
+						"failed: %v", err, rerr))
+			} else {
+				c.addEvent(ctx, api.IngressEventReverted, fmt.Sprintf(
+					"Caddy stopped serving requests with new configuration, reverted to the previous one: %v", err))
+			}
+			c.lastFingerprint = nil
+			// Don't write the broken config to disk.
+			return
+		}
+	}
+	c.addEvent(ctx, api.IngressEventLoaded, describeHostnamesChange(c.lastHostnames, hostnames))
 	c.lastFingerprint = fingerprint
+	c.lastHostnames = hostnames
 
 	// Config loaded successfully, now write it to disk.
 	if err = c.writeCaddyfileIfChanged(caddyfile); err != nil {
@@ -200,6 +323,114 @@ func (c *Controller) generateAndLoadCaddyfile(ctx context.Context, containers []
 	c.log.Info("New Caddy configuration loaded into local Caddy instance.", "path", c.caddyfilePath)
 }
 
+// verify checks that the local Caddy serves requests by requesting the verification endpoint that responds with
+// the machine ID.
+func (c *Controller) verify(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.verifyURL, nil)
+	if err != nil {
+		return fmt.Errorf("create verification request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send verification request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return fmt.Errorf("read verification response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("verification endpoint responded with HTTP %d", resp.StatusCode)
+	}
+	if strings.TrimSpace(string(body)) != c.machineID {
+		return errors.New("verification endpoint responded with unexpected machine ID")
+	}
+	return nil
+}
+
+// verifyWithRetries calls verify up to verifyAttempts times until it succeeds to tolerate transient errors.
+func (c *Controller) verifyWithRetries(ctx context.Context) error {
+	var err error
+	for i := range verifyAttempts {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(verifyRetryInterval):
+			}
+		}
+		if err = c.verify(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// addEvent records an ingress event for the machine. Errors are logged as events are informational.
+func (c *Controller) addEvent(ctx context.Context, eventType, message string) {
+	if c.events == nil {
+		return
+	}
+	events, err := c.events.IngressEvents(ctx, c.machineID)
+	if err != nil {
+		c.log.Error("Failed to get ingress events.", "err", err)
+		return
+	}
+	if events == nil {
+		events = &api.IngressEvents{MachineID: c.machineID}
+	}
+	events.AddEvent(api.IngressEvent{Time: time.Now().UTC(), Type: eventType, Message: message})
+	if err = c.events.PutIngressEvents(ctx, events); err != nil {
+		c.log.Error("Failed to store ingress event.", "type", eventType, "err", err)
+	}
+}
+
+// ingressHostnames returns the sorted unique hostnames of the HTTP and HTTPS ingress ports in the fingerprints.
+func ingressHostnames(fingerprints []containerFingerprint) []string {
+	var hostnames []string
+	for _, f := range fingerprints {
+		for _, p := range f.Ports {
+			if p.Mode != "" && p.Mode != api.PortModeIngress || p.Hostname == "" {
+				continue
+			}
+			if p.Protocol != api.ProtocolHTTP && p.Protocol != api.ProtocolHTTPS {
+				continue
+			}
+			hostnames = append(hostnames, strings.ToLower(p.Hostname))
+		}
+	}
+	slices.Sort(hostnames)
+	return slices.Compact(hostnames)
+}
+
+// describeHostnamesChange returns an event message for a loaded config describing the added and removed hostnames.
+func describeHostnamesChange(prev, cur []string) string {
+	var added, removed []string
+	for _, h := range cur {
+		if !slices.Contains(prev, h) {
+			added = append(added, h)
+		}
+	}
+	for _, h := range prev {
+		if !slices.Contains(cur, h) {
+			removed = append(removed, h)
+		}
+	}
+
+	var changes []string
+	if len(added) > 0 {
+		changes = append(changes, "added hostnames: "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, "removed hostnames: "+strings.Join(removed, ", "))
+	}
+	if len(changes) == 0 {
+		return "Loaded new configuration, hostnames are unchanged."
+	}
+	return "Loaded new configuration, " + strings.Join(changes, ", ") + "."
+}
+
 // fingerprintContainers returns a fingerprint of containers that the Caddyfile generator depends on.
 func fingerprintContainers(containers []store.ContainerRecord) []containerFingerprint {
 	fingerprints := make([]containerFingerprint, len(containers))
@@ -207,10 +438,12 @@ func fingerprintContainers(containers []store.ContainerRecord) []containerFinger
 		// Ignore ports parsing error as not much we can do about it. The generator just logs them and continues.
 		ports, _ := cr.Container.ServicePorts()
 		fingerprints[i] = containerFingerprint{
-			ID:          cr.Container.ID,
-			IP:          cr.Container.UncloudNetworkIP(),
-			Ports:       ports,
-			CaddyConfig: cr.Container.ServiceSpec.CaddyConfig(),
+			ID:              cr.Container.ID,
+			IP:              cr.Container.UncloudNetworkIP(),
+			Ports:           ports,
+			CaddyConfig:     cr.Container.ServiceSpec.CaddyConfig(),
+			CaddyDirectives: cr.Container.ServiceSpec.CaddyDirectives(),
+			Ingress:         cr.Container.ServiceSpec.Ingress,
 		}
 	}
 	slices.SortFunc(fingerprints, func(a, b containerFingerprint) int {
@@ -1,12 +1,24 @@
 package caddyconfig
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/netip"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/psviderski/uncloud/internal/machine/store"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestContainerFingerprint_EqualCoversAllFields is a guard: when a field is added to containerFingerprint,
@@ -24,7 +36,9 @@ func TestContainerFingerprint_EqualCoversAllFields(t *testing.T) {
 			Protocol:      api.ProtocolHTTP,
 			Mode:          api.PortModeIngress,
 		}},
-		CaddyConfig: "caddy-config",
+		CaddyConfig:     "caddy-config",
+		CaddyDirectives: "encode gzip",
+		Ingress:         &api.IngressPolicy{MaxConnections: 10},
 	}
 
 	assert.True(t, base.Equal(base), "base fingerprint must be equal to itself")
@@ -39,10 +53,12 @@ func TestContainerFingerprint_EqualCoversAllFields(t *testing.T) {
 			v := reflect.ValueOf(&mutated).Elem().FieldByName(field.Name)
 
 			switch field.Name {
-			case "ID", "CaddyConfig":
+			case "ID", "CaddyConfig", "CaddyDirectives":
 				v.SetString(v.String() + "-changed")
 			case "IP":
 				v.Set(reflect.ValueOf(netip.MustParseAddr("10.210.0.99")))
+			case "Ingress":
+				mutated.Ingress = &api.IngressPolicy{MaxConnections: 20}
 			case "Ports":
 				mutated.Ports = []api.PortSpec{{
 					Hostname:      "different.example.com",
@@ -61,3 +77,138 @@ func TestContainerFingerprint_EqualCoversAllFields(t *testing.T) {
 		})
 	}
 }
+
+// fakeCaddy emulates the Caddy admin API and the verification endpoint. It fails to load configs that mention
+// fail.example.com and stops serving requests with configs that mention broken.example.com.
+type fakeCaddy struct {
+	mu     sync.Mutex
+	config string
+}
+
+func (f *fakeCaddy) adminHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/adapt":
+		result, _ := json.Marshal(map[string]string{"caddyfile": string(body)})
+		_ = json.NewEncoder(w).Encode(map[string]json.RawMessage{"result": result})
+	case "/load":
+		if strings.Contains(string(body), "fail.example.com") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"loading new config: certificate not found"}`))
+			return
+		}
+		f.config = string(body)
+	case "/config/":
+		_, _ = w.Write([]byte(f.config))
+	}
+}
+
+func (f *fakeCaddy) verifyHandler(machineID string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if strings.Contains(f.config, "broken.example.com") {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(machineID))
+	}
+}
+
+type memIngressEventStore struct {
+	events map[string]*api.IngressEvents
+}
+
+func (s *memIngressEventStore) IngressEvents(_ context.Context, machineID string) (*api.IngressEvents, error) {
+	return s.events[machineID], nil
+}
+
+func (s *memIngressEventStore) PutIngressEvents(_ context.Context, e *api.IngressEvents) error {
+	s.events[e.MachineID] = e
+	return nil
+}
+
+func TestControllerGenerateAndLoadCaddyfile(t *testing.T) {
+	const machineID = "mach1"
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	fake := &fakeCaddy{config: "{}"}
+	sock := filepath.Join(dir, "admin.sock")
+	ln, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	admin := &http.Server{Handler: http.HandlerFunc(fake.adminHandler)}
+	go admin.Serve(ln)
+	t.Cleanup(func() { admin.Close() })
+
+	verifySrv := httptest.NewServer(fake.verifyHandler(machineID))
+	t.Cleanup(verifySrv.Close)
+
+	events := &memIngressEventStore{events: make(map[string]*api.IngressEvents)}
+	client := NewCaddyAdminClient(sock)
+	ctrl := &Controller{
+		machineID:     machineID,
+		caddyfilePath: filepath.Join(dir, "Caddyfile"),
+		generator:     NewCaddyfileGenerator(machineID, "machine-1", client, nil),
+		client:        client,
+		events:        events,
+		verifyURL:     verifySrv.URL + VerifyPath,
+		httpClient:    verifySrv.Client(),
+		log:           slog.New(slog.DiscardHandler),
+	}
+
+	load := func(hostname string) {
+		ctrl.generateAndLoadCaddyfile(ctx, []store.ContainerRecord{
+			newContainerRecordWithPorts("web", "10.210.0.2", []string{hostname + ":8080/https"}, machineID),
+		})
+	}
+	lastEvent := func() api.IngressEvent {
+		e := events.events[machineID]
+		require.NotNil(t, e)
+		return e.Events[len(e.Events)-1]
+	}
+
+	load("app.example.com")
+	assert.Equal(t, api.IngressEventLoaded, lastEvent().Type)
+	assert.Equal(t, "Loaded new configuration, added hostnames: app.example.com.", lastEvent().Message)
+	assert.Contains(t, fake.config, "app.example.com")
+	goodConfig := fake.config
+
+	// Caddy rejects the new config and keeps running the previous one.
+	load("fail.example.com")
+	assert.Equal(t, api.IngressEventFailed, lastEvent().Type)
+	assert.Contains(t, lastEvent().Message, "certificate not found")
+	assert.Equal(t, goodConfig, fake.config)
+	assert.Nil(t, ctrl.lastFingerprint)
+
+	// Caddy loads the new config but stops serving requests so the previous config is restored.
+	load("broken.example.com")
+	assert.Equal(t, api.IngressEventReverted, lastEvent().Type)
+	assert.Equal(t, goodConfig, fake.config)
+	assert.Nil(t, ctrl.lastFingerprint)
+
+	load("new.example.com")
+	assert.Equal(t, api.IngressEventLoaded, lastEvent().Type)
+	assert.Equal(t, "Loaded new configuration, added hostnames: new.example.com, "+
+		"removed hostnames: app.example.com.", lastEvent().Message)
+	assert.Len(t, events.events[machineID].Events, 4)
+
+	// Unchanged containers don't reload the config or record an event.
+	load("new.example.com")
+	assert.Len(t, events.events[machineID].Events, 4)
+}
+
+func TestDescribeHostnamesChange(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Loaded new configuration, hostnames are unchanged.",
+		describeHostnamesChange([]string{"a.example.com"}, []string{"a.example.com"}))
+	assert.Equal(t, "Loaded new configuration, added hostnames: a.example.com, b.example.com.",
+		describeHostnamesChange(nil, []string{"a.example.com", "b.example.com"}))
+	assert.Equal(t, "Loaded new configuration, removed hostnames: a.example.com.",
+		describeHostnamesChange([]string{"a.example.com"}, nil))
+}
@@ -0,0 +1,202 @@
+package caddyconfig
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// hostRoute is the ingress policy and user-defined Caddy directives for a hostname and the service container
+// they come from.
+type hostRoute struct {
+	service    string
+	created    time.Time
+	policy     *api.IngressPolicy
+	directives string
+}
+
+// sameSettings returns true if the routes have the same ingress policy and Caddy directives.
+func (r hostRoute) sameSettings(other hostRoute) bool {
+	return r.policy.Equals(other.policy) && r.directives == other.directives
+}
+
+// hostRoutes returns the ingress routes of the services for their HTTP and HTTPS hostnames. The most recent container
+// of a service defines its route settings. If services publishing the same hostname have different settings,
+// the settings of the service that comes first alphabetically are used.
+func hostRoutes(containers []api.ServiceContainer, log *slog.Logger) map[string]hostRoute {
+	hosts := make(map[string]hostRoute)
+	for _, ctr := range containers {
+		if !ctr.UncloudNetworkIP().IsValid() {
+			continue
+		}
+		// Ignore ports parsing error as it's already logged by httpUpstreamsFromPorts.
+		ports, _ := ctr.ServicePorts()
+		for _, port := range ports {
+			if port.Mode != api.PortModeIngress ||
+				(port.Protocol != api.ProtocolHTTP && port.Protocol != api.ProtocolHTTPS) {
+				continue
+			}
+
+			hp := hostRoute{
+				service:    ctr.ServiceName(),
+				created:    ctr.CreatedTime(),
+				policy:     ctr.ServiceSpec.Ingress,
+				directives: ctr.ServiceSpec.CaddyDirectives(),
+			}
+			current, ok := hosts[port.Hostname]
+			switch {
+			case !ok:
+				hosts[port.Hostname] = hp
+			case current.service == hp.service:
+				if hp.created.After(current.created) {
+					hosts[port.Hostname] = hp
+				}
+			case !current.sameSettings(hp):
+				first, other := current, hp
+				if hp.service < current.service {
+					first, other = hp, current
+					hosts[port.Hostname] = hp
+				}
+				log.Error("Services publishing the same hostname have different ingress policies or Caddy "+
+					"directives, using the settings of the first service.", "hostname", port.Hostname,
+					"service", first.service, "other_service", other.service)
+			}
+		}
+	}
+	return hosts
+}
+
+// hasRateLimits returns true if any container has an ingress policy with a rate limit.
+func hasRateLimits(containers []api.ServiceContainer) bool {
+	for _, ctr := range containers {
+		if ctr.ServiceSpec.Ingress != nil && ctr.ServiceSpec.Ingress.RateLimit != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyDirectives returns the Caddyfile directives that proxy requests to the upstreams and enforce the ingress
+// policy. depth is the indentation level of the directives.
+func proxyDirectives(upstreams []string, policy *api.IngressPolicy, rateLimits bool, depth int) string {
+	if policy == nil {
+		policy = &api.IngressPolicy{}
+	}
+	var b strings.Builder
+	line := func(depth int, format string, args ...any) {
+		b.WriteString(strings.Repeat("\t", depth))
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\n")
+	}
+
+	// Wrap the directives in a route block to apply them in the written order. Named matchers are scoped
+	// to the block so they don't conflict with other routes in the same site.
+	rateLimit := rateLimits && policy.RateLimit != nil
+	route := len(policy.Deny) > 0 || len(policy.Allow) > 0 || rateLimit || policy.RequestIDHeader != ""
+	if route {
+		line(depth, "route {")
+		depth++
+	}
+	if h := policy.RequestIDHeader; h != "" {
+		// Keep the request ID sent by the client or a proxy in front of Caddy, otherwise generate a new one.
+		line(depth, "@no_request_id not header %s *", h)
+		line(depth, "request_header @no_request_id %s {http.request.uuid}", h)
+		// Defer setting the response header to replace the one from the upstream response if any.
+		line(depth, "header {")
+		line(depth+1, "defer")
+		line(depth+1, "%s {http.request.header.%s}", h, h)
+		line(depth, "}")
+		line(depth, "log_append request_id {http.request.header.%s}", h)
+	}
+	if len(policy.Deny) > 0 {
+		line(depth, "@denied client_ip %s", joinPrefixes(policy.Deny))
+		line(depth, "respond @denied 403")
+	}
+	if len(policy.Allow) > 0 {
+		line(depth, "@not_allowed not client_ip %s", joinPrefixes(policy.Allow))
+		line(depth, "respond @not_allowed 403")
+	}
+	if rateLimit {
+		line(depth, "rate_limit {")
+		line(depth+1, "zone client_ip {")
+		line(depth+2, "key {client_ip}")
+		line(depth+2, "events %d", policy.RateLimit.Requests)
+		line(depth+2, "window %s", formatDuration(policy.RateLimit.Window))
+		line(depth+1, "}")
+		line(depth, "}")
+	}
+
+	if policy.MaxBodySize > 0 {
+		line(depth, "request_body {")
+		line(depth+1, "max_size %d", policy.MaxBodySize)
+		line(depth, "}")
+	}
+
+	line(depth, "reverse_proxy %s {", strings.Join(upstreams, " "))
+	line(depth+1, "import common_proxy")
+	if len(policy.TrustedProxies) > 0 {
+		line(depth+1, "trusted_proxies %s", joinPrefixes(policy.TrustedProxies))
+	}
+	if policy.StreamTimeout > 0 {
+		line(depth+1, "stream_timeout %s", formatDuration(policy.StreamTimeout))
+	}
+	if policy.StreamCloseDelay > 0 {
+		line(depth+1, "stream_close_delay %s", formatDuration(policy.StreamCloseDelay))
+	}
+	if policy.MaxConnections > 0 || policy.H2C() {
+		line(depth+1, "transport http {")
+		if policy.H2C() {
+			// gRPC requires HTTP/2. Service containers don't use TLS in the cluster network so use cleartext HTTP/2.
+			line(depth+2, "versions h2c")
+		}
+		if policy.MaxConnections > 0 {
+			line(depth+2, "max_conns_per_host %d", policy.MaxConnections)
+		}
+		line(depth+1, "}")
+	}
+	line(depth, "}")
+
+	if route {
+		depth--
+		line(depth, "}")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// userDirectives returns the user-defined Caddy directives of the service indented to the given depth.
+func userDirectives(service, directives string, depth int) string {
+	indent := strings.Repeat("\t", depth)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s# User-defined directives from service '%s'.\n", indent, service)
+	for _, line := range strings.Split(directives, "\n") {
+		if strings.TrimSpace(line) != "" {
+			b.WriteString(indent + line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func joinPrefixes(prefixes []netip.Prefix) string {
+	s := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		s[i] = p.String()
+	}
+	return strings.Join(s, " ")
+}
+
+// formatDuration formats the duration as a Caddyfile duration without zero components, e.g. 1m instead of 1m0s.
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,314 @@
+package caddyconfig
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaddyfileGeneratorWithIngressPolicy(t *testing.T) {
+	policy := &api.IngressPolicy{
+		RateLimit:      &api.RateLimit{Requests: 100, Window: time.Minute},
+		MaxConnections: 50,
+		Allow:          []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("203.0.113.7/32")},
+		Deny:           []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")},
+	}
+	withPolicy := func(rec store.ContainerRecord, p *api.IngressPolicy) store.ContainerRecord {
+		rec.Container.ServiceSpec.Ingress = p
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		containers []store.ContainerRecord
+		rateLimit  bool
+		want       string
+	}{
+		{
+			name: "full policy",
+			containers: []store.ContainerRecord{
+				withPolicy(newContainerRecordWithPorts(
+					"web", "10.210.0.2", []string{"app.example.com:8080/https"}, "mach1"), policy),
+			},
+			rateLimit: true,
+			want: testCaddyfileHeader + `
+# Sites generated from service ports.
+
+https://app.example.com {
+	route {
+		@denied client_ip 10.1.0.0/16
+		respond @denied 403
+		@not_allowed not client_ip 10.0.0.0/8 203.0.113.7/32
+		respond @not_allowed 403
+		rate_limit {
+			zone client_ip {
+				key {client_ip}
+				events 100
+				window 1m
+			}
+		}
+		reverse_proxy 10.210.0.2:8080 {
+			import common_proxy
+			transport http {
+				max_conns_per_host 50
+			}
+		}
+	}
+	log
+}
+`,
+		},
+		{
+			name: "rate limit module missing",
+			containers: []store.ContainerRecord{
+				withPolicy(newContainerRecordWithPorts(
+					"web", "10.210.0.2", []string{"app.example.com:8080/http"}, "mach1"), policy),
+			},
+			rateLimit: false,
+			want: testCaddyfileHeader + `
+# Sites generated from service ports.
+
+http://app.example.com {
+	route {
+		@denied client_ip 10.1.0.0/16
+		respond @denied 403
+		@not_allowed not client_ip 10.0.0.0/8 203.0.113.7/32
+		respond @not_allowed 403
+		reverse_proxy 10.210.0.2:8080 {
+			import common_proxy
+			transport http {
+				max_conns_per_host 50
+			}
+		}
+	}
+	log
+}
+
+# Skipped invalid user-defined configs:
+# - ingress rate limits: validation failed: unrecognized directive: rate_limit
+`,
+		},
+		{
+			name: "first service wins for shared hostname",
+			containers: []store.ContainerRecord{
+				withPolicy(newContainerRecordWithPorts(
+					"web", "10.210.0.2", []string{"app.example.com:8080/http"}, "mach1"),
+					&api.IngressPolicy{MaxConnections: 10}),
+				withPolicy(newContainerRecordWithPorts(
+					"api", "10.210.0.3", []string{"app.example.com:8080/http"}, "mach1"),
+					&api.IngressPolicy{MaxConnections: 20}),
+			},
+			want: testCaddyfileHeader + `
+# Sites generated from service ports.
+
+http://app.example.com {
+	reverse_proxy 10.210.0.3:8080 10.210.0.2:8080 {
+		import common_proxy
+		transport http {
+			max_conns_per_host 20
+		}
+	}
+	log
+}
+`,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewMockCaddyfileValidator(t)
+			validator.EXPECT().Validate(mock.Anything, mock.Anything).RunAndReturn(
+				func(ctx context.Context, caddyfile string) error {
+					if !tt.rateLimit && strings.Contains(caddyfile, "rate_limit") {
+						return errors.New("unrecognized directive: rate_limit")
+					}
+					return nil
+				}).Maybe()
+			generator := NewCaddyfileGenerator("test-machine-id", "test-machine", validator, nil)
+
+			config, err := generator.Generate(ctx, tt.containers, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, normaliseGeneratedTimestamp(config), "Generated Caddyfile doesn't match")
+		})
+	}
+}
+
+func TestProxyDirectives(t *testing.T) {
+	t.Parallel()
+
+	upstreams := []string{"10.210.0.2:8080"}
+
+	assert.Equal(t, "\t\treverse_proxy 10.210.0.2:8080 {\n\t\t\timport common_proxy\n\t\t}",
+		proxyDirectives(upstreams, nil, true, 2))
+	assert.Equal(t, proxyDirectives(upstreams, nil, true, 1),
+		proxyDirectives(upstreams, &api.IngressPolicy{
+			RateLimit: &api.RateLimit{Requests: 10, Window: time.Second},
+		}, false, 1), "rate limit must be omitted if rate limits are disabled")
+
+	assert.Equal(t, `	request_body {
+		max_size 1048576
+	}
+	reverse_proxy 10.210.0.2:8080 {
+		import common_proxy
+		stream_timeout 1h
+		stream_close_delay 5m
+		transport http {
+			versions h2c
+			max_conns_per_host 10
+		}
+	}`, proxyDirectives(upstreams, &api.IngressPolicy{
+		MaxConnections:   10,
+		BackendProtocol:  api.IngressBackendH2C,
+		StreamTimeout:    time.Hour,
+		StreamCloseDelay: 5 * time.Minute,
+		MaxBodySize:      1 << 20,
+	}, true, 1))
+
+	assert.Equal(t, `	route {
+		@no_request_id not header X-Request-ID *
+		request_header @no_request_id X-Request-ID {http.request.uuid}
+		header {
+			defer
+			X-Request-ID {http.request.header.X-Request-ID}
+		}
+		log_append request_id {http.request.header.X-Request-ID}
+		reverse_proxy 10.210.0.2:8080 {
+			import common_proxy
+			trusted_proxies 173.245.48.0/20 2400:cb00::/32
+		}
+	}`, proxyDirectives(upstreams, &api.IngressPolicy{
+		RequestIDHeader: api.DefaultRequestIDHeader,
+		TrustedProxies: []netip.Prefix{
+			netip.MustParsePrefix("173.245.48.0/20"),
+			netip.MustParsePrefix("2400:cb00::/32"),
+		},
+	}, true, 1))
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1s", formatDuration(time.Second))
+	assert.Equal(t, "1m", formatDuration(time.Minute))
+	assert.Equal(t, "1h", formatDuration(time.Hour))
+	assert.Equal(t, "1m30s", formatDuration(90*time.Second))
+}
+
+func TestCaddyfileGeneratorWithCaddyDirectives(t *testing.T) {
+	withDirectives := func(rec store.ContainerRecord, directives string) store.ContainerRecord {
+		rec.Container.ServiceSpec.Caddy = &api.CaddySpec{Directives: directives}
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		containers []store.ContainerRecord
+		want       string
+	}{
+		{
+			name: "directives with template",
+			containers: []store.ContainerRecord{
+				withDirectives(newContainerRecordWithPorts(
+					"web", "10.210.0.2", []string{"app.example.com:8080/https"}, "mach1"),
+					"encode gzip\nhandle_path /api/* {\n\treverse_proxy {{upstreams \"api\" 9000}}\n}"),
+				newContainerRecordWithPorts("api", "10.210.0.3", []string{"api.example.com:9000/https"}, "mach1"),
+			},
+			want: testCaddyfileHeader + `
+# Sites generated from service ports.
+
+https://api.example.com {
+	reverse_proxy 10.210.0.3:9000 {
+		import common_proxy
+	}
+	log
+}
+
+https://app.example.com {
+	# User-defined directives from service 'web'.
+	encode gzip
+	handle_path /api/* {
+		reverse_proxy 10.210.0.3:9000
+	}
+	reverse_proxy 10.210.0.2:8080 {
+		import common_proxy
+	}
+	log
+}
+`,
+		},
+		{
+			name: "invalid directives skipped",
+			containers: []store.ContainerRecord{
+				withDirectives(newContainerRecordWithPorts(
+					"web", "10.210.0.2", []string{"app.example.com:8080/http"}, "mach1"), "invalid_directive"),
+				withDirectives(newContainerRecordWithPorts(
+					"api", "10.210.0.3", []string{"api.example.com:9000/http"}, "mach1"), "header -Server"),
+			},
+			want: testCaddyfileHeader + `
+# Sites generated from service ports.
+
+http://api.example.com {
+	# User-defined directives from service 'api'.
+	header -Server
+	reverse_proxy 10.210.0.3:9000 {
+		import common_proxy
+	}
+	log
+}
+
+http://app.example.com {
+	reverse_proxy 10.210.0.2:8080 {
+		import common_proxy
+	}
+	log
+}
+
+# Skipped invalid user-defined configs:
+# - service 'web': directives: validation failed: unrecognized directive: invalid_directive
+`,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewMockCaddyfileValidator(t)
+			validator.EXPECT().Validate(mock.Anything, mock.Anything).RunAndReturn(
+				func(ctx context.Context, caddyfile string) error {
+					if strings.Contains(caddyfile, "invalid_directive") {
+						return errors.New("unrecognized directive: invalid_directive")
+					}
+					return nil
+				})
+			generator := NewCaddyfileGenerator("test-machine-id", "test-machine", validator, nil)
+
+			config, err := generator.Generate(ctx, tt.containers, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, normaliseGeneratedTimestamp(config), "Generated Caddyfile doesn't match")
+		})
+	}
+}
+
+func TestUserDirectives(t *testing.T) {
+	t.Parallel()
+
+	want := "\t\t# User-defined directives from service 'web'.\n" +
+		"\t\tencode gzip\n" +
+		"\n" +
+		"\t\theader {\n" +
+		"\t\t\t-Server\n" +
+		"\t\t}\n"
+	assert.Equal(t, want, userDirectives("web", "encode gzip\n\nheader {\n\t-Server\n}", 2))
+}
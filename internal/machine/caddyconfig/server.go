@@ -2,6 +2,7 @@ package caddyconfig
 
 import (
 	"context"
+	"errors"
 	"os"
 
 	"google.golang.org/grpc/codes"
@@ -37,3 +38,16 @@ func (s *Server) GetConfig(ctx context.Context, _ *emptypb.Empty) (*pb.GetCaddyC
 		ModifiedAt: timestamppb.New(modifiedAt),
 	}, nil
 }
+
+// GetJSONConfig retrieves the JSON configuration currently loaded into Caddy running on the machine.
+func (s *Server) GetJSONConfig(ctx context.Context, _ *emptypb.Empty) (*pb.GetCaddyJSONConfigResponse, error) {
+	config, err := s.service.JSONConfig(ctx)
+	if err != nil {
+		if errors.Is(err, ErrCaddyUnavailable) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetCaddyJSONConfigResponse{Config: config}, nil
+}
@@ -1,20 +1,30 @@
 package caddyconfig
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 )
 
+// ErrCaddyUnavailable is returned when Caddy is not running on the machine.
+var ErrCaddyUnavailable = errors.New("caddy is not running on this machine")
+
 // Service provides methods to interact with the Caddy configuration on the machine.
 type Service struct {
 	configDir string
+	client    *CaddyAdminClient
 }
 
-// NewService creates a new Service instance with the specified Caddy configuration directory.
-func NewService(configDir string) *Service {
-	return &Service{configDir: configDir}
+// NewService creates a new Service instance with the specified Caddy configuration directory and admin socket
+// of the local Caddy instance.
+func NewService(configDir, adminSock string) *Service {
+	return &Service{
+		configDir: configDir,
+		client:    NewCaddyAdminClient(adminSock),
+	}
 }
 
 // Caddyfile retrieves the current Caddy configuration (Caddyfile) from the machine's config directory.
@@ -33,3 +43,11 @@ func (s *Service) Caddyfile() (string, time.Time, error) {
 
 	return string(content), fileInfo.ModTime(), nil
 }
+
+// JSONConfig retrieves the JSON configuration currently loaded into the local Caddy instance.
+func (s *Service) JSONConfig(ctx context.Context) ([]byte, error) {
+	if !s.client.IsAvailable() {
+		return nil, ErrCaddyUnavailable
+	}
+	return s.client.Config(ctx)
+}
@@ -13,10 +13,34 @@ type templateContext struct {
 	Name string
 	// Upstreams maps service names to their container IPs.
 	Upstreams map[string][]string
+	// Ports maps service names to the container port numbers of their named ports by name.
+	Ports map[string]map[string]uint16
+}
+
+// portTemplateFn returns a template function that returns the container port number of a named port of the current
+// or specified service: {{port [service-name] port-name}}.
+func portTemplateFn(tmplCtx templateContext) func(args ...string) (int, error) {
+	return func(args ...string) (int, error) {
+		serviceName := tmplCtx.Name
+		switch len(args) {
+		case 1:
+		case 2:
+			serviceName = args[0]
+		default:
+			return 0, fmt.Errorf("port function: expected 1-2 arguments, got %d", len(args))
+		}
+		name := args[len(args)-1]
+
+		port, ok := tmplCtx.Ports[serviceName][name]
+		if !ok {
+			return 0, fmt.Errorf("port function: service '%s' has no port named '%s'", serviceName, name)
+		}
+		return int(port), nil
+	}
 }
 
 // upstreamsTemplateFn returns a template function that generates a space separated string of upstreams for the service.
-// It optionally accepts a service name and a port number: {{upstreams [service-name] [port]}}.
+// It optionally accepts a service name and a port number or name: {{upstreams [service-name] [port]}}.
 func upstreamsTemplateFn(tmplCtx templateContext) func(args ...any) (string, error) {
 	return func(args ...any) (string, error) {
 		var serviceName string
@@ -47,11 +71,18 @@ func upstreamsTemplateFn(tmplCtx templateContext) func(args ...any) (string, err
 			}
 			serviceName = name
 
-			p, ok := args[1].(int)
-			if !ok {
-				return "", fmt.Errorf("upstreams function: second argument must be port (int)")
+			switch p := args[1].(type) {
+			case int:
+				port = p
+			case string:
+				named, ok := tmplCtx.Ports[name][p]
+				if !ok {
+					return "", fmt.Errorf("upstreams function: service '%s' has no port named '%s'", name, p)
+				}
+				port = int(named)
+			default:
+				return "", fmt.Errorf("upstreams function: second argument must be port number (int) or name (string)")
 			}
-			port = p
 		default:
 			return "", fmt.Errorf("upstreams function: too many arguments; expected 0-2, got %d", len(args))
 		}
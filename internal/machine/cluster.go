@@ -13,14 +13,23 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/autoupdate"
+	"github.com/psviderski/uncloud/internal/machine/boot"
 	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
 	"github.com/psviderski/uncloud/internal/machine/constants"
 	"github.com/psviderski/uncloud/internal/machine/corroservice"
 	"github.com/psviderski/uncloud/internal/machine/dns"
 	"github.com/psviderski/uncloud/internal/machine/docker"
 	"github.com/psviderski/uncloud/internal/machine/firewall"
+	"github.com/psviderski/uncloud/internal/machine/global"
+	"github.com/psviderski/uncloud/internal/machine/mdns"
+	"github.com/psviderski/uncloud/internal/machine/mesh"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/offline"
+	"github.com/psviderski/uncloud/internal/machine/profile"
+	"github.com/psviderski/uncloud/internal/machine/restart"
 	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/internal/machine/vip"
 	"github.com/psviderski/unregistry"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -44,12 +53,30 @@ type clusterController struct {
 	// clusterReady is signalled when the cluster controller has finished initializing all components.
 	clusterReady    chan<- struct{}
 	caddyconfigCtrl *caddyconfig.Controller
+	// meshCtrl configures the mesh proxy that wraps the traffic to mTLS-enabled services in mTLS.
+	meshCtrl *mesh.Controller
 
 	// dnsServer is the embedded internal DNS server for the cluster listening on the machine IP.
 	dnsServer   *dns.Server
 	dnsResolver *dns.ClusterResolver
+	// dnsConfigCtrl applies the DNS configuration of the cluster to the embedded DNS server.
+	dnsConfigCtrl *dns.ConfigController
+	// mdnsResponder advertises the ingress hostnames in the .local domain on the LAN when enabled by dnsConfigCtrl.
+	mdnsResponder *mdns.Responder
 	// unregistry is the embedded container registry that uses the local Docker (containerd) image store as its backend.
 	unregistry *unregistry.Registry
+	// offlineMonitor tracks the connectivity to other machines and detects conflicts on reconnection.
+	offlineMonitor *offline.Monitor
+	// autoUpdateCtrl performs rolling updates of services with auto-update enabled when their images change.
+	autoUpdateCtrl *autoupdate.Controller
+	// globalCtrl deploys global services to machines that joined the cluster.
+	globalCtrl *global.Controller
+	// vipCtrl floats the ingress virtual IP among ingress machines.
+	vipCtrl *vip.Controller
+	// bootRecovery starts the service containers that Docker failed to start when the machine booted.
+	bootRecovery *boot.Recovery
+	// restartSupervisor restarts the service containers that Docker failed to restart.
+	restartSupervisor *restart.Supervisor
 
 	// stopped is a channel that is closed when the controller is stopped.
 	stopped chan struct{}
@@ -66,7 +93,15 @@ func newClusterController(
 	caddyfileCtrl *caddyconfig.Controller,
 	dnsServer *dns.Server,
 	dnsResolver *dns.ClusterResolver,
+	dnsConfigCtrl *dns.ConfigController,
+	mdnsResponder *mdns.Responder,
 	unregistry *unregistry.Registry,
+	offlineMonitor *offline.Monitor,
+	autoUpdateCtrl *autoupdate.Controller,
+	globalCtrl *global.Controller,
+	vipCtrl *vip.Controller,
+	bootRecovery *boot.Recovery,
+	restartSupervisor *restart.Supervisor,
 ) (*clusterController, error) {
 	slog.Info("Starting WireGuard network.")
 	wgnet, err := network.NewWireGuardNetwork()
@@ -74,22 +109,32 @@ func newClusterController(
 		return nil, fmt.Errorf("create WireGuard network: %w", err)
 	}
 	endpointChanges := wgnet.WatchEndpoints()
+	settings := profile.Get(state.Profile)
 
 	return &clusterController{
-		state:           state,
-		store:           store,
-		wgnet:           wgnet,
-		endpointChanges: endpointChanges,
-		server:          server,
-		corroService:    corroService,
-		dockerCtrl:      docker.NewController(state.ID, dockerService, store),
-		dockerReady:     dockerReady,
-		clusterReady:    clusterReady,
-		caddyconfigCtrl: caddyfileCtrl,
-		dnsServer:       dnsServer,
-		dnsResolver:     dnsResolver,
-		unregistry:      unregistry,
-		stopped:         make(chan struct{}),
+		state:             state,
+		store:             store,
+		wgnet:             wgnet,
+		endpointChanges:   endpointChanges,
+		server:            server,
+		corroService:      corroService,
+		dockerCtrl:        docker.NewController(state.ID, dockerService, store, settings.DockerSyncInterval),
+		dockerReady:       dockerReady,
+		clusterReady:      clusterReady,
+		caddyconfigCtrl:   caddyfileCtrl,
+		meshCtrl:          mesh.NewController(state.ID, state.Network.Subnet, store, settings.MeshResyncInterval),
+		dnsServer:         dnsServer,
+		dnsResolver:       dnsResolver,
+		dnsConfigCtrl:     dnsConfigCtrl,
+		mdnsResponder:     mdnsResponder,
+		unregistry:        unregistry,
+		offlineMonitor:    offlineMonitor,
+		autoUpdateCtrl:    autoUpdateCtrl,
+		globalCtrl:        globalCtrl,
+		vipCtrl:           vipCtrl,
+		bootRecovery:      bootRecovery,
+		restartSupervisor: restartSupervisor,
+		stopped:           make(chan struct{}),
 	}, nil
 }
 
@@ -189,6 +234,18 @@ func (cc *clusterController) Run(ctx context.Context) error {
 		return nil
 	})
 
+	errGroup.Go(func() error {
+		slog.Info("Starting DNS configuration controller.")
+		return cc.dnsConfigCtrl.Run(ctx)
+	})
+
+	errGroup.Go(func() error {
+		if err := cc.mdnsResponder.Run(ctx); err != nil {
+			return fmt.Errorf("multicast DNS responder failed: %w", err)
+		}
+		return nil
+	})
+
 	// Synchronise Docker containers to the cluster store.
 	errGroup.Go(func() error {
 		slog.Info("Watching Docker containers and syncing them to cluster store.")
@@ -212,6 +269,44 @@ func (cc *clusterController) Run(ctx context.Context) error {
 		return nil
 	})
 
+	// The mesh proxy listens on the machine IP so it must be started after the Docker network is created.
+	errGroup.Go(func() error {
+		slog.Info("Starting mesh controller.")
+		if err := cc.meshCtrl.Run(ctx); err != nil {
+			return fmt.Errorf("mesh controller failed: %w", err)
+		}
+		return nil
+	})
+
+	// Track the connectivity to other machines to keep running in offline mode and report conflicts on reconnection.
+	errGroup.Go(func() error {
+		slog.Info("Starting offline monitor.")
+		return cc.offlineMonitor.Run(ctx)
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting auto-update controller.")
+		return cc.autoUpdateCtrl.Run(ctx)
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting global service controller.")
+		return cc.globalCtrl.Run(ctx)
+	})
+
+	errGroup.Go(func() error {
+		slog.Info("Starting ingress virtual IP controller.")
+		return cc.vipCtrl.Run(ctx)
+	})
+
+	// Start the service containers that Docker failed to start on boot now that the machine network is configured.
+	// Then keep restarting the containers that Docker fails to restart.
+	errGroup.Go(func() error {
+		cc.bootRecovery.Run(ctx)
+		slog.Info("Starting restart supervisor.")
+		return cc.restartSupervisor.Run(ctx)
+	})
+
 	if cc.unregistry != nil {
 		errGroup.Go(func() error {
 			slog.Info("Starting unregistry server.")
@@ -618,6 +713,9 @@ func (cc *clusterController) Cleanup() error {
 	if err := cc.wgnet.Cleanup(); err != nil {
 		errs = append(errs, fmt.Errorf("cleanup WireGuard network: %w", err))
 	}
+	if err := firewall.CleanupMeshRules(cc.state.Network.Subnet); err != nil {
+		errs = append(errs, fmt.Errorf("cleanup mesh iptables rules: %w", err))
+	}
 	if err := firewall.CleanupIptablesChains(); err != nil {
 		errs = append(errs, fmt.Errorf("cleanup iptables chains: %w", err))
 	}
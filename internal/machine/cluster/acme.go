@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// acmeDNSKey is the key used to store the JSON of the ACME DNS-01 challenge configuration.
+const acmeDNSKey = "acme_dns"
+
+// SetACMEDNS sets or removes the ACME DNS-01 challenge configuration for issuing certificates on ingress machines.
+func (c *Cluster) SetACMEDNS(ctx context.Context, req *pb.ACMEDNS) (*emptypb.Empty, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	if len(req.Config) == 0 {
+		if err := c.store.Delete(ctx, acmeDNSKey); err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.Internal, "delete ACME DNS config from store: %v", err)
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	var config api.ACMEDNS
+	if err := json.Unmarshal(req.Config, &config); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal ACME DNS config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Make sure the machines will be able to decrypt the credentials.
+	for opt, value := range config.Credentials {
+		keyID, ciphertext, err := api.ParseSealedSecret(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "credential '%s': %v", opt, err)
+		}
+		if _, err = c.OpenSealedSecret(ctx, keyID, ciphertext); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "credential '%s': %v", opt, err)
+		}
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal ACME DNS config for store: %v", err)
+	}
+	if err = c.store.Put(ctx, acmeDNSKey, configJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store ACME DNS config: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetACMEDNS returns the ACME DNS-01 challenge configuration. Credentials are returned sealed.
+func (c *Cluster) GetACMEDNS(ctx context.Context, _ *emptypb.Empty) (*pb.ACMEDNS, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ACMEDNS{}
+	if err := c.store.Get(ctx, acmeDNSKey, &resp.Config); err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get ACME DNS config from store: %v", err)
+	}
+	return resp, nil
+}
+
+// ACMEDNS returns the ACME DNS-01 challenge configuration or nil if it's not configured.
+func (c *Cluster) ACMEDNS(ctx context.Context) (*api.ACMEDNS, error) {
+	var configJSON []byte
+	if err := c.store.Get(ctx, acmeDNSKey, &configJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get ACME DNS config from store: %w", err)
+	}
+
+	var config api.ACMEDNS
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, fmt.Errorf("unmarshal ACME DNS config: %w", err)
+	}
+	return &config, nil
+}
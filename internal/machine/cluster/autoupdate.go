@@ -0,0 +1,158 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// autoUpdatePausedKey is the key used to store the JSON of the services with paused automatic image updates.
+	autoUpdatePausedKey = "auto_update_paused"
+	// autoUpdateStatusKeyPrefix is the prefix of the keys used to store the JSON of the automatic image update
+	// status for services. The full key is 'auto_update_status/<service ID>'.
+	autoUpdateStatusKeyPrefix = "auto_update_status/"
+)
+
+// autoUpdatePaused is the representation of the paused automatic image updates in the store.
+type autoUpdatePaused struct {
+	All      bool     `json:"all,omitempty"`
+	Services []string `json:"services,omitempty"`
+}
+
+// SetAutoUpdatePaused pauses or resumes automatic image updates for a service or for all services.
+func (c *Cluster) SetAutoUpdatePaused(
+	ctx context.Context, req *pb.SetAutoUpdatePausedRequest,
+) (*pb.AutoUpdatePaused, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	paused, err := c.autoUpdatePaused(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if req.Service == "" {
+		paused.All = req.Paused
+		if !req.Paused {
+			// Resuming all services also resumes the individually paused ones.
+			paused.Services = nil
+		}
+	} else {
+		idx := slices.Index(paused.Services, req.Service)
+		if req.Paused && idx == -1 {
+			paused.Services = append(paused.Services, req.Service)
+			slices.Sort(paused.Services)
+		} else if !req.Paused && idx != -1 {
+			paused.Services = slices.Delete(paused.Services, idx, idx+1)
+		}
+	}
+
+	pausedJSON, err := json.Marshal(paused)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal paused auto-updates for store: %v", err)
+	}
+	if err = c.store.Put(ctx, autoUpdatePausedKey, pausedJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store paused auto-updates: %v", err)
+	}
+
+	return &pb.AutoUpdatePaused{All: paused.All, Services: paused.Services}, nil
+}
+
+// GetAutoUpdatePaused returns the services with paused automatic image updates.
+func (c *Cluster) GetAutoUpdatePaused(ctx context.Context, _ *emptypb.Empty) (*pb.AutoUpdatePaused, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	paused, err := c.autoUpdatePaused(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &pb.AutoUpdatePaused{All: paused.All, Services: paused.Services}, nil
+}
+
+// AutoUpdatePaused returns true if automatic image updates are paused for the service with the given name.
+func (c *Cluster) AutoUpdatePaused(ctx context.Context, serviceName string) (bool, error) {
+	paused, err := c.autoUpdatePaused(ctx)
+	if err != nil {
+		return false, err
+	}
+	return paused.All || slices.Contains(paused.Services, serviceName), nil
+}
+
+func (c *Cluster) autoUpdatePaused(ctx context.Context) (autoUpdatePaused, error) {
+	var paused autoUpdatePaused
+
+	var pausedJSON []byte
+	if err := c.store.Get(ctx, autoUpdatePausedKey, &pausedJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return paused, nil
+		}
+		return paused, fmt.Errorf("get paused auto-updates from store: %w", err)
+	}
+	if err := json.Unmarshal(pausedJSON, &paused); err != nil {
+		return paused, fmt.Errorf("unmarshal paused auto-updates: %w", err)
+	}
+	return paused, nil
+}
+
+// GetAutoUpdateStatus returns the state of automatic image updates for a service.
+func (c *Cluster) GetAutoUpdateStatus(
+	ctx context.Context, req *pb.GetAutoUpdateStatusRequest,
+) (*pb.AutoUpdateStatus, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if !api.ValidateServiceID(req.ServiceId) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID: '%s'", req.ServiceId)
+	}
+
+	var statusJSON []byte
+	if err := c.store.Get(ctx, autoUpdateStatusKeyPrefix+req.ServiceId, &statusJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return &pb.AutoUpdateStatus{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "get auto-update status from store: %v", err)
+	}
+	return &pb.AutoUpdateStatus{Status: statusJSON}, nil
+}
+
+// AutoUpdateStatus returns the state of automatic image updates for a service or nil if it has never been checked.
+func (c *Cluster) AutoUpdateStatus(ctx context.Context, serviceID string) (*api.AutoUpdateStatus, error) {
+	var statusJSON []byte
+	if err := c.store.Get(ctx, autoUpdateStatusKeyPrefix+serviceID, &statusJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get auto-update status from store: %w", err)
+	}
+
+	var s api.AutoUpdateStatus
+	if err := json.Unmarshal(statusJSON, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal auto-update status: %w", err)
+	}
+	return &s, nil
+}
+
+// PutAutoUpdateStatus stores the state of automatic image updates for a service.
+func (c *Cluster) PutAutoUpdateStatus(ctx context.Context, s *api.AutoUpdateStatus) error {
+	statusJSON, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal auto-update status for store: %w", err)
+	}
+	if err = c.store.Put(ctx, autoUpdateStatusKeyPrefix+s.ServiceID, statusJSON); err != nil {
+		return fmt.Errorf("store auto-update status: %w", err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/netip"
+	"sync"
 	"time"
 
 	"github.com/psviderski/uncloud/internal/corrosion"
@@ -14,6 +15,7 @@ import (
 	"github.com/psviderski/uncloud/internal/machine/network"
 	"github.com/psviderski/uncloud/internal/machine/store"
 	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/pkg/api"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -31,6 +33,14 @@ type Cluster struct {
 	// ready is closed when the cluster controller has finished starting all components
 	// and the machine is ready to serve cluster requests.
 	ready <-chan struct{}
+	// reservationsMu serialises the updates of the published port and hostname reservations.
+	reservationsMu sync.Mutex
+	// projectsMu serialises the updates of the Compose project metadata.
+	projectsMu sync.Mutex
+	// secretsMu serialises the updates of the secrets stored in the cluster.
+	secretsMu sync.Mutex
+	// sealingMu serialises the generation of the cluster sealing key pair.
+	sealingMu sync.Mutex
 }
 
 func NewCluster(store *store.Store, corroAdmin *corrosion.AdminClient, initialised, ready <-chan struct{}) *Cluster {
@@ -60,6 +70,9 @@ func (c *Cluster) Init(ctx context.Context, network netip.Prefix) error {
 	if err := c.store.Put(ctx, "created_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
 		return fmt.Errorf("put created_at to store: %w", err)
 	}
+	if err := c.createSealingKey(ctx); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -78,6 +91,11 @@ func (c *Cluster) AddMachine(ctx context.Context, req *pb.AddMachineRequest) (*p
 	if err := c.checkReady(); err != nil {
 		return nil, err
 	}
+	if backend := c.store.Backend(); !backend.Replicated() {
+		return nil, status.Errorf(codes.FailedPrecondition, "the cluster uses the '%s' store backend that doesn't "+
+			"replicate the state to other machines, so the cluster is limited to a single machine. The store backend "+
+			"is chosen with 'uc machine init --store' and can't be changed later", backend.Name())
+	}
 
 	return c.AddMachineWithoutReadyCheck(ctx, req)
 }
@@ -105,6 +123,12 @@ func (c *Cluster) AddMachineWithoutReadyCheck(
 			return nil, status.Error(codes.InvalidArgument, "invalid public IP")
 		}
 	}
+	if err := api.ValidateMachineRoles(req.Roles); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := api.ValidateMachineZone(req.Zone); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	machines, err := c.store.ListMachines(ctx)
 	if err != nil {
@@ -170,6 +194,8 @@ func (c *Cluster) AddMachineWithoutReadyCheck(
 			PublicKey:    req.Network.PublicKey,
 		},
 		PublicIp: req.PublicIp,
+		Roles:    req.Roles,
+		Zone:     req.Zone,
 	}
 	// TODO: announce the new machine to the cluster members and achieve consensus.
 	//  We should perhaps not proceed if this machine is in a minority partition.
@@ -220,6 +246,8 @@ func (c *Cluster) UpdateMachine(ctx context.Context, req *pb.UpdateMachineReques
 		Name:     currentMachine.Name,
 		Network:  currentMachine.Network,
 		PublicIp: currentMachine.PublicIp,
+		Roles:    currentMachine.Roles,
+		Zone:     currentMachine.Zone,
 	}
 
 	// Apply updates from the request
@@ -262,6 +290,18 @@ func (c *Cluster) UpdateMachine(ctx context.Context, req *pb.UpdateMachineReques
 	if len(req.Endpoints) > 0 {
 		updatedMachine.Network.Endpoints = req.Endpoints
 	}
+	if req.Roles != nil {
+		if err = api.ValidateMachineRoles(req.Roles.Roles); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		updatedMachine.Roles = req.Roles.Roles
+	}
+	if req.Zone != nil {
+		if err = api.ValidateMachineZone(*req.Zone); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		updatedMachine.Zone = *req.Zone
+	}
 
 	// Update the machine in the store
 	if err = c.store.UpdateMachine(ctx, updatedMachine); err != nil {
@@ -345,6 +385,11 @@ func (c *Cluster) RemoveMachine(ctx context.Context, req *pb.RemoveMachineReques
 	return &emptypb.Empty{}, nil
 }
 
+// MembershipStates returns the latest membership states of all cluster members.
+func (c *Cluster) MembershipStates() ([]corrosion.ClusterMembershipState, error) {
+	return c.corroAdmin.ClusterMembershipStates(true)
+}
+
 // MemberRTTs returns the median and standard deviation of round-trip times from this member to each cluster member.
 func (c *Cluster) MemberRTTs() ([]corrosion.MemberRTTStats, error) {
 	return c.corroAdmin.ClusterMemberRTTs()
@@ -0,0 +1,215 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// secretsPrefix is the prefix of the store keys used to store the JSON of the secrets created with CreateSecret.
+// Each secret is stored under its own key so that updating different secrets concurrently from different machines
+// doesn't lose updates.
+const secretsPrefix = "secrets/"
+
+// maxSecretVersions is the number of the latest versions of a secret retained in the store. Containers pinned to
+// an older version can't be created until the service is redeployed with the current version.
+const maxSecretVersions = 10
+
+// clusterSecret is the representation of a secret in the store. The values are sealed with the cluster sealing key
+// pair so that they're never stored in plain text.
+type clusterSecret struct {
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Versions are the latest sealed values of the secret ordered from the oldest to the newest.
+	Versions []clusterSecretVersion `json:"versions"`
+}
+
+// clusterSecretVersion is a sealed value of a secret created or updated with CreateSecret.
+type clusterSecretVersion struct {
+	Version      int64     `json:"version"`
+	SealingKeyID string    `json:"sealing_key_id"`
+	Ciphertext   []byte    `json:"ciphertext"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CreateSecret stores a secret encrypted with the cluster sealing key or updates its value if req.Update is set.
+func (c *Cluster) CreateSecret(ctx context.Context, req *pb.CreateSecretRequest) (*pb.ClusterSecret, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if err := api.ValidateClusterSecretName(req.Name); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(req.Value) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "secret value is empty")
+	}
+
+	key, err := c.ensureSealingKey(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	ciphertext, err := api.SealSecretContent(req.Value, &key.PublicKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	c.secretsMu.Lock()
+	defer c.secretsMu.Unlock()
+
+	s, err := c.secret(ctx, req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	now := time.Now().UTC()
+	if s != nil && !req.Update {
+		return nil, status.Errorf(codes.AlreadyExists, "secret '%s' already exists", req.Name)
+	}
+	if s == nil {
+		s = &clusterSecret{CreatedAt: now}
+	}
+	s.Version++
+	s.UpdatedAt = now
+	s.Versions = append(s.Versions, clusterSecretVersion{
+		Version:      s.Version,
+		SealingKeyID: key.ID,
+		Ciphertext:   ciphertext,
+		CreatedAt:    now,
+	})
+	if len(s.Versions) > maxSecretVersions {
+		s.Versions = slices.Clone(s.Versions[len(s.Versions)-maxSecretVersions:])
+	}
+
+	secretJSON, err := json.Marshal(s)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal secret for store: %v", err)
+	}
+	if err = c.store.Put(ctx, secretsPrefix+req.Name, secretJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store secret: %v", err)
+	}
+	return s.toProto(req.Name), nil
+}
+
+// ListSecrets returns the secrets stored in the cluster sorted by name without their values.
+func (c *Cluster) ListSecrets(ctx context.Context, _ *emptypb.Empty) (*pb.ClusterSecrets, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	values, err := c.store.List(ctx, secretsPrefix)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get secrets from store: %v", err)
+	}
+
+	resp := &pb.ClusterSecrets{}
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		var s clusterSecret
+		if err = json.Unmarshal(values[key], &s); err != nil {
+			return nil, status.Errorf(codes.Internal, "unmarshal secret: %v", err)
+		}
+		resp.Secrets = append(resp.Secrets, s.toProto(strings.TrimPrefix(key, secretsPrefix)))
+	}
+	return resp, nil
+}
+
+// InspectSecret returns the secret stored in the cluster without its value.
+func (c *Cluster) InspectSecret(ctx context.Context, req *pb.InspectSecretRequest) (*pb.ClusterSecret, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	s, err := c.secret(ctx, req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if s == nil {
+		return nil, status.Errorf(codes.NotFound, "secret '%s' not found", req.Name)
+	}
+	return s.toProto(req.Name), nil
+}
+
+// RemoveSecret removes the secret from the cluster. Containers that already mount it keep their copy.
+func (c *Cluster) RemoveSecret(ctx context.Context, req *pb.RemoveSecretRequest) (*emptypb.Empty, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	c.secretsMu.Lock()
+	defer c.secretsMu.Unlock()
+
+	s, err := c.secret(ctx, req.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if s == nil {
+		return nil, status.Errorf(codes.NotFound, "secret '%s' not found", req.Name)
+	}
+
+	if err = c.store.Delete(ctx, secretsPrefix+req.Name); err != nil {
+		return nil, status.Errorf(codes.Internal, "delete secret from store: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SecretValue returns the decrypted value of the given version of the secret stored in the cluster with the given
+// name. Zero version means the current version.
+func (c *Cluster) SecretValue(ctx context.Context, name string, version int64) ([]byte, error) {
+	s, err := c.secret(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("secret '%s' not found in the cluster, create it with 'uc secret create'", name)
+	}
+	if version == 0 {
+		version = s.Version
+	}
+
+	idx := slices.IndexFunc(s.Versions, func(v clusterSecretVersion) bool { return v.Version == version })
+	if idx < 0 {
+		return nil, fmt.Errorf("version %d of secret '%s' is no longer available (current version is %d), "+
+			"redeploy the service to use the current version", version, name, s.Version)
+	}
+	v := s.Versions[idx]
+	return c.OpenSealedSecret(ctx, v.SealingKeyID, v.Ciphertext)
+}
+
+// secret returns the secret with the given name from the store or nil if it doesn't exist.
+func (c *Cluster) secret(ctx context.Context, name string) (*clusterSecret, error) {
+	var secretJSON []byte
+	if err := c.store.Get(ctx, secretsPrefix+name, &secretJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get secret from store: %w", err)
+	}
+
+	var s clusterSecret
+	if err := json.Unmarshal(secretJSON, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal secret: %w", err)
+	}
+	return &s, nil
+}
+
+func (s *clusterSecret) toProto(name string) *pb.ClusterSecret {
+	return &pb.ClusterSecret{
+		Name:      name,
+		Version:   s.Version,
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+}
@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCluster_SecretValue_Versions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	c := newTestCluster(t)
+
+	_, err := c.CreateSecret(ctx, &pb.CreateSecretRequest{Name: "api_key", Value: []byte("v1")})
+	require.NoError(t, err)
+	_, err = c.CreateSecret(ctx, &pb.CreateSecretRequest{Name: "api_key", Value: []byte("v2")})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	s, err := c.CreateSecret(ctx, &pb.CreateSecretRequest{Name: "api_key", Value: []byte("v2"), Update: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, s.Version)
+
+	value, err := c.SecretValue(ctx, "api_key", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v1"), value)
+	value, err = c.SecretValue(ctx, "api_key", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), value)
+
+	for i := 3; i <= maxSecretVersions+1; i++ {
+		_, err = c.CreateSecret(ctx, &pb.CreateSecretRequest{
+			Name: "api_key", Value: fmt.Appendf(nil, "v%d", i), Update: true,
+		})
+		require.NoError(t, err)
+	}
+	_, err = c.SecretValue(ctx, "api_key", 1)
+	assert.EqualError(t, err, "version 1 of secret 'api_key' is no longer available (current version is 11), "+
+		"redeploy the service to use the current version")
+	value, err = c.SecretValue(ctx, "api_key", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v2"), value)
+
+	_, err = c.CreateSecret(ctx, &pb.CreateSecretRequest{Name: "db_password", Value: []byte("pass")})
+	require.NoError(t, err)
+	secrets, err := c.ListSecrets(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, secrets.Secrets, 2)
+	assert.Equal(t, "api_key", secrets.Secrets[0].Name)
+	assert.Equal(t, "db_password", secrets.Secrets[1].Name)
+
+	_, err = c.RemoveSecret(ctx, &pb.RemoveSecretRequest{Name: "api_key"})
+	require.NoError(t, err)
+	_, err = c.SecretValue(ctx, "api_key", 0)
+	assert.ErrorContains(t, err, "secret 'api_key' not found")
+}
@@ -0,0 +1,84 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// dnsConfigKey is the key used to store the JSON of the DNS configuration.
+const dnsConfigKey = "dns_config"
+
+// SetDNSConfig sets or removes the configuration of the embedded DNS servers on machines.
+func (c *Cluster) SetDNSConfig(ctx context.Context, req *pb.DNSConfig) (*emptypb.Empty, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	if len(req.Config) == 0 {
+		if err := c.store.Delete(ctx, dnsConfigKey); err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.Internal, "delete DNS config from store: %v", err)
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	var config api.DNSConfig
+	if err := json.Unmarshal(req.Config, &config); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal DNS config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	for id := range config.Machines {
+		if _, err := c.store.GetMachine(ctx, id); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "machine '%s': %v", id, err)
+		}
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal DNS config for store: %v", err)
+	}
+	if err = c.store.Put(ctx, dnsConfigKey, configJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store DNS config: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetDNSConfig returns the configuration of the embedded DNS servers.
+func (c *Cluster) GetDNSConfig(ctx context.Context, _ *emptypb.Empty) (*pb.DNSConfig, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.DNSConfig{}
+	if err := c.store.Get(ctx, dnsConfigKey, &resp.Config); err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get DNS config from store: %v", err)
+	}
+	return resp, nil
+}
+
+// DNSConfig returns the configuration of the embedded DNS servers or nil if it's not configured.
+func (c *Cluster) DNSConfig(ctx context.Context) (*api.DNSConfig, error) {
+	var configJSON []byte
+	if err := c.store.Get(ctx, dnsConfigKey, &configJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get DNS config from store: %w", err)
+	}
+
+	var config api.DNSConfig
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, fmt.Errorf("unmarshal DNS config: %w", err)
+	}
+	return &config, nil
+}
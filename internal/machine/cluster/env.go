@@ -0,0 +1,150 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceEnvKeyPrefix is the prefix of the keys used to store the JSON of environment variable overrides
+// for Compose services. The full key is 'service_env/<project>/<service>'.
+const serviceEnvKeyPrefix = "service_env/"
+
+// SetServiceEnv sets environment variable overrides for a Compose service.
+func (c *Cluster) SetServiceEnv(ctx context.Context, req *pb.SetServiceEnvRequest) (*pb.ServiceEnv, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if err := validateServiceEnvRef(req.Project, req.Service); err != nil {
+		return nil, err
+	}
+	if len(req.Env) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no environment variables specified")
+	}
+	for k := range req.Env {
+		if k == "" || strings.Contains(k, "=") {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid environment variable name: '%s'", k)
+		}
+	}
+
+	env, err := c.ServiceEnv(ctx, req.Project, req.Service)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if env == nil {
+		env = make(map[string]string, len(req.Env))
+	}
+	for k, v := range req.Env {
+		env[k] = v
+	}
+
+	return c.storeServiceEnv(ctx, req.Project, req.Service, env)
+}
+
+// UnsetServiceEnv removes environment variable overrides for a Compose service.
+func (c *Cluster) UnsetServiceEnv(ctx context.Context, req *pb.UnsetServiceEnvRequest) (*pb.ServiceEnv, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if err := validateServiceEnvRef(req.Project, req.Service); err != nil {
+		return nil, err
+	}
+	if len(req.Keys) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no environment variables specified")
+	}
+
+	env, err := c.ServiceEnv(ctx, req.Project, req.Service)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	var notSet []string
+	for _, k := range req.Keys {
+		if _, ok := env[k]; ok {
+			delete(env, k)
+		} else {
+			notSet = append(notSet, k)
+		}
+	}
+	if len(notSet) > 0 {
+		return nil, status.Errorf(codes.NotFound, "environment variables not set: %s", strings.Join(notSet, ", "))
+	}
+
+	return c.storeServiceEnv(ctx, req.Project, req.Service, env)
+}
+
+// GetServiceEnv returns environment variable overrides for a Compose service.
+func (c *Cluster) GetServiceEnv(ctx context.Context, req *pb.GetServiceEnvRequest) (*pb.ServiceEnv, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if err := validateServiceEnvRef(req.Project, req.Service); err != nil {
+		return nil, err
+	}
+
+	env, err := c.ServiceEnv(ctx, req.Project, req.Service)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &pb.ServiceEnv{Env: env}, nil
+}
+
+// ServiceEnv returns environment variable overrides for a Compose service or nil if there are none.
+func (c *Cluster) ServiceEnv(ctx context.Context, project, service string) (map[string]string, error) {
+	var envJSON []byte
+	if err := c.store.Get(ctx, serviceEnvKey(project, service), &envJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get service environment from store: %w", err)
+	}
+
+	var env map[string]string
+	if err := json.Unmarshal(envJSON, &env); err != nil {
+		return nil, fmt.Errorf("unmarshal service environment: %w", err)
+	}
+	return env, nil
+}
+
+func (c *Cluster) storeServiceEnv(
+	ctx context.Context, project, service string, env map[string]string,
+) (*pb.ServiceEnv, error) {
+	key := serviceEnvKey(project, service)
+	if len(env) == 0 {
+		if err := c.store.Delete(ctx, key); err != nil {
+			return nil, status.Errorf(codes.Internal, "delete service environment from store: %v", err)
+		}
+		return &pb.ServiceEnv{}, nil
+	}
+
+	envJSON, err := json.Marshal(env)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal service environment for store: %v", err)
+	}
+	if err = c.store.Put(ctx, key, envJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store service environment: %v", err)
+	}
+
+	return &pb.ServiceEnv{Env: env}, nil
+}
+
+func serviceEnvKey(project, service string) string {
+	return serviceEnvKeyPrefix + project + "/" + service
+}
+
+func validateServiceEnvRef(project, service string) error {
+	if project == "" || service == "" {
+		return status.Error(codes.InvalidArgument, "project and service names are required")
+	}
+	if strings.Contains(project, "/") || strings.Contains(service, "/") {
+		return status.Error(codes.InvalidArgument, "project and service names must not contain '/'")
+	}
+	return nil
+}
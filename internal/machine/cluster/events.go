@@ -0,0 +1,49 @@
+package cluster
+
+import (
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// WatchEvents streams an event each time the machines or containers in the cluster store change until the client
+// cancels the request. Changes replicated from other machines are reported as well as the local ones.
+func (c *Cluster) WatchEvents(_ *emptypb.Empty, stream grpc.ServerStreamingServer[pb.ClusterEvent]) error {
+	if err := c.checkReady(); err != nil {
+		return err
+	}
+	ctx := stream.Context()
+
+	_, machinesCh, err := c.store.SubscribeMachines(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "subscribe to machine changes: %v", err)
+	}
+	_, containersCh, err := c.store.SubscribeContainers(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "subscribe to container changes: %v", err)
+	}
+
+	for {
+		event := &pb.ClusterEvent{}
+		select {
+		case _, ok := <-machinesCh:
+			if !ok {
+				return status.Error(codes.Unavailable, "machine changes subscription closed")
+			}
+			event.Type = pb.ClusterEvent_MACHINES
+		case _, ok := <-containersCh:
+			if !ok {
+				return status.Error(codes.Unavailable, "container changes subscription closed")
+			}
+			event.Type = pb.ClusterEvent_CONTAINERS
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		}
+
+		if err = stream.Send(event); err != nil {
+			return status.Errorf(codes.Internal, "send event: %v", err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// hostUpdatesKeyPrefix is the prefix of the keys used to store the JSON of the history of host package updates
+// for machines. The full key is 'host_updates/<machine ID>'.
+const hostUpdatesKeyPrefix = "host_updates/"
+
+// GetHostUpdates returns the history of OS package updates on a machine host.
+func (c *Cluster) GetHostUpdates(ctx context.Context, req *pb.GetHostUpdatesRequest) (*pb.HostUpdates, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine ID is required")
+	}
+
+	var historyJSON []byte
+	if err := c.store.Get(ctx, hostUpdatesKeyPrefix+req.MachineId, &historyJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return &pb.HostUpdates{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "get host updates from store: %v", err)
+	}
+	return &pb.HostUpdates{History: historyJSON}, nil
+}
+
+// AddHostUpdate appends the host package update to the history of the machine in the store.
+func (c *Cluster) AddHostUpdate(ctx context.Context, machineID string, update api.HostUpdate) error {
+	history := api.HostUpdateHistory{MachineID: machineID}
+	var historyJSON []byte
+	if err := c.store.Get(ctx, hostUpdatesKeyPrefix+machineID, &historyJSON); err == nil {
+		if err = json.Unmarshal(historyJSON, &history); err != nil {
+			return fmt.Errorf("unmarshal host updates: %w", err)
+		}
+	} else if !errors.Is(err, store.ErrKeyNotFound) {
+		return fmt.Errorf("get host updates from store: %w", err)
+	}
+
+	history.Add(update)
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("marshal host updates for store: %w", err)
+	}
+	if err = c.store.Put(ctx, hostUpdatesKeyPrefix+machineID, historyJSON); err != nil {
+		return fmt.Errorf("store host updates: %w", err)
+	}
+	return nil
+}
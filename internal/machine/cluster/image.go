@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// pinnedImagesPrefix is the prefix of the store keys used to store pinned image references. Each pinned image is
+// stored under its own key so that pinning and unpinning images concurrently from different machines doesn't lose
+// updates.
+const pinnedImagesPrefix = "pinned_images/"
+
+// PinImage adds image references to the list of pinned images that are protected from pruning.
+func (c *Cluster) PinImage(ctx context.Context, req *pb.PinImageRequest) (*pb.PinnedImages, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	images, err := normaliseImageReferences(req.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, img := range images {
+		if err = c.store.Put(ctx, pinnedImagesPrefix+img, img); err != nil {
+			return nil, status.Errorf(codes.Internal, "store pinned image '%s': %v", img, err)
+		}
+	}
+
+	return c.ListPinnedImages(ctx, nil)
+}
+
+// UnpinImage removes image references from the list of pinned images.
+func (c *Cluster) UnpinImage(ctx context.Context, req *pb.PinImageRequest) (*pb.PinnedImages, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	images, err := normaliseImageReferences(req.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned, err := c.storedPinnedImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var notPinned []string
+	for _, img := range images {
+		if !slices.Contains(pinned, img) {
+			notPinned = append(notPinned, img)
+		}
+	}
+	if len(notPinned) > 0 {
+		return nil, status.Errorf(codes.NotFound, "images not pinned: %s", strings.Join(notPinned, ", "))
+	}
+
+	for _, img := range images {
+		if err = c.store.Delete(ctx, pinnedImagesPrefix+img); err != nil {
+			return nil, status.Errorf(codes.Internal, "delete pinned image '%s' from store: %v", img, err)
+		}
+	}
+
+	return c.ListPinnedImages(ctx, nil)
+}
+
+// ListPinnedImages returns the list of pinned image references.
+func (c *Cluster) ListPinnedImages(ctx context.Context, _ *emptypb.Empty) (*pb.PinnedImages, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	pinned, err := c.storedPinnedImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PinnedImages{Images: pinned}, nil
+}
+
+// storedPinnedImages returns the sorted list of pinned image references from the store.
+func (c *Cluster) storedPinnedImages(ctx context.Context) ([]string, error) {
+	values, err := c.store.List(ctx, pinnedImagesPrefix)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get pinned images from store: %v", err)
+	}
+
+	pinned := make([]string, 0, len(values))
+	for key := range values {
+		pinned = append(pinned, strings.TrimPrefix(key, pinnedImagesPrefix))
+	}
+	slices.Sort(pinned)
+
+	return pinned, nil
+}
+
+func normaliseImageReferences(images []string) ([]string, error) {
+	if len(images) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no images specified")
+	}
+
+	normalised := make([]string, len(images))
+	for i, img := range images {
+		ref, err := api.NormalizeImageReference(img)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		normalised[i] = ref
+	}
+
+	return normalised, nil
+}
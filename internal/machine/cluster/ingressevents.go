@@ -0,0 +1,69 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ingressEventsKeyPrefix is the prefix of the keys used to store the JSON of the ingress events for machines.
+// The full key is 'ingress_events/<machine ID>'.
+const ingressEventsKeyPrefix = "ingress_events/"
+
+// GetIngressEvents returns the most recent changes of the ingress configuration on a machine.
+func (c *Cluster) GetIngressEvents(
+	ctx context.Context, req *pb.GetIngressEventsRequest,
+) (*pb.IngressEvents, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if req.MachineId == "" || strings.Contains(req.MachineId, "/") {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid machine ID: '%s'", req.MachineId)
+	}
+
+	var eventsJSON []byte
+	if err := c.store.Get(ctx, ingressEventsKeyPrefix+req.MachineId, &eventsJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return &pb.IngressEvents{}, nil
+		}
+		return nil, status.Errorf(codes.Internal, "get ingress events from store: %v", err)
+	}
+	return &pb.IngressEvents{Events: eventsJSON}, nil
+}
+
+// IngressEvents returns the most recent changes of the ingress configuration on a machine or nil if there are none.
+func (c *Cluster) IngressEvents(ctx context.Context, machineID string) (*api.IngressEvents, error) {
+	var eventsJSON []byte
+	if err := c.store.Get(ctx, ingressEventsKeyPrefix+machineID, &eventsJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get ingress events from store: %w", err)
+	}
+
+	var e api.IngressEvents
+	if err := json.Unmarshal(eventsJSON, &e); err != nil {
+		return nil, fmt.Errorf("unmarshal ingress events: %w", err)
+	}
+	return &e, nil
+}
+
+// PutIngressEvents stores the most recent changes of the ingress configuration on a machine.
+func (c *Cluster) PutIngressEvents(ctx context.Context, e *api.IngressEvents) error {
+	eventsJSON, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal ingress events for store: %w", err)
+	}
+	if err = c.store.Put(ctx, ingressEventsKeyPrefix+e.MachineID, eventsJSON); err != nil {
+		return fmt.Errorf("store ingress events: %w", err)
+	}
+	return nil
+}
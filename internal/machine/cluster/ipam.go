@@ -27,13 +27,19 @@ func NewIPAM(network netip.Prefix) (*IPAM, error) {
 	}, nil
 }
 
-// NewIPAMWithAllocated creates a new IPAM with the given network and already allocated subnets.
+// NewIPAMWithAllocated creates a new IPAM with the given network and already allocated subnets. Allocated subnets
+// that are not fully contained in the network are reserved rather than rejected. They belong to machines that haven't
+// been reallocated yet after the cluster network was changed.
 func NewIPAMWithAllocated(network netip.Prefix, subnets []netip.Prefix) (*IPAM, error) {
 	ipam, err := NewIPAM(network)
 	if err != nil {
 		return nil, err
 	}
 	for _, sn := range subnets {
+		if !ipam.Contains(sn) {
+			ipam.allocated.AddPrefix(sn.Masked())
+			continue
+		}
 		if err = ipam.AllocateSubnet(sn); err != nil {
 			return nil, fmt.Errorf("allocate subnet %s: %w", sn, err)
 		}
@@ -41,6 +47,11 @@ func NewIPAMWithAllocated(network netip.Prefix, subnets []netip.Prefix) (*IPAM,
 	return ipam, nil
 }
 
+// Contains returns true if the subnet is fully contained in the IPAM network.
+func (ipam *IPAM) Contains(subnet netip.Prefix) bool {
+	return ipam.network.Contains(subnet.Addr()) && ipam.network.Contains(netipx.PrefixLastIP(subnet))
+}
+
 func (ipam *IPAM) AllocateSubnetLen(bits int) (netip.Prefix, error) {
 	if bits < ipam.network.Bits() || bits > ipam.network.Addr().BitLen() {
 		return netip.Prefix{}, errors.New("invalid subnet size")
@@ -65,8 +76,7 @@ func (ipam *IPAM) AllocateSubnetLen(bits int) (netip.Prefix, error) {
 }
 
 func (ipam *IPAM) AllocateSubnet(subnet netip.Prefix) error {
-	subnetLastIP := netipx.PrefixLastIP(subnet)
-	if !ipam.network.Contains(subnet.Addr()) || !ipam.network.Contains(subnetLastIP) {
+	if !ipam.Contains(subnet) {
 		return errors.New("subnet not in network")
 	}
 	ipset, err := ipam.allocated.IPSet()
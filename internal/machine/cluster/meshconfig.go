@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/mesh"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetMeshConfig sets or removes the configuration of the certificates issued for mTLS-enabled services.
+func (c *Cluster) SetMeshConfig(ctx context.Context, req *pb.MeshConfig) (*emptypb.Empty, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	if len(req.Config) == 0 {
+		if err := c.store.Delete(ctx, mesh.ConfigStoreKey); err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.Internal, "delete mesh config from store: %v", err)
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	var config api.MeshConfig
+	if err := json.Unmarshal(req.Config, &config); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal mesh config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal mesh config for store: %v", err)
+	}
+	if err = c.store.Put(ctx, mesh.ConfigStoreKey, configJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store mesh config: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetMeshConfig returns the configuration of the certificates issued for mTLS-enabled services.
+func (c *Cluster) GetMeshConfig(ctx context.Context, _ *emptypb.Empty) (*pb.MeshConfig, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.MeshConfig{}
+	if err := c.store.Get(ctx, mesh.ConfigStoreKey, &resp.Config); err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get mesh config from store: %v", err)
+	}
+	return resp, nil
+}
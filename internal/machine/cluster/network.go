@@ -0,0 +1,152 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetNetwork returns the cluster network from which machine subnets are allocated.
+func (c *Cluster) GetNetwork(ctx context.Context, _ *emptypb.Empty) (*pb.ClusterNetwork, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	network, err := c.network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ClusterNetwork{Network: pb.NewIPPrefix(network)}, nil
+}
+
+// SetNetwork changes the cluster network. It verifies that all machines with subnets outside the new network can be
+// reallocated. Machine subnets are not changed until they are reallocated with ReallocateMachineSubnet.
+func (c *Cluster) SetNetwork(ctx context.Context, req *pb.ClusterNetwork) (*pb.ClusterNetwork, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	if req.Network == nil {
+		return nil, status.Error(codes.InvalidArgument, "network not set")
+	}
+	network, err := req.Network.ToPrefix()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid network: %v", err)
+	}
+	if !network.Addr().Is4() {
+		return nil, status.Error(codes.InvalidArgument, "network must be an IPv4 prefix")
+	}
+	if network.Bits() > DefaultSubnetBits {
+		return nil, status.Errorf(codes.InvalidArgument, "network must be /%d or larger", DefaultSubnetBits)
+	}
+	network = network.Masked()
+
+	machines, err := c.store.ListMachines(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list machines: %v", err)
+	}
+	if _, err = PlanSubnets(network, machines); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "plan machine subnets in network %s: %v", network, err)
+	}
+
+	if err = c.store.Put(ctx, "network", network.String()); err != nil {
+		return nil, status.Errorf(codes.Internal, "put network to store: %v", err)
+	}
+	slog.Info("Cluster network changed.", "network", network)
+
+	return &pb.ClusterNetwork{Network: pb.NewIPPrefix(network)}, nil
+}
+
+// ReallocateMachineSubnet allocates a new subnet from the cluster network for the machine if its current subnet
+// is outside the network. The machine has to apply the new subnet with the ApplySubnet machine RPC.
+func (c *Cluster) ReallocateMachineSubnet(
+	ctx context.Context, req *pb.ReallocateMachineSubnetRequest,
+) (*pb.UpdateMachineResponse, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	if req.MachineId == "" {
+		return nil, status.Error(codes.InvalidArgument, "machine_id not set")
+	}
+	m, err := c.store.GetMachine(ctx, req.MachineId)
+	if err != nil {
+		if errors.Is(err, store.ErrMachineNotFound) {
+			return nil, status.Errorf(codes.NotFound, "machine not found: %s", req.MachineId)
+		}
+		return nil, status.Errorf(codes.Internal, "get machine: %v", err)
+	}
+
+	network, err := c.network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	machines, err := c.store.ListMachines(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list machines: %v", err)
+	}
+
+	oldSubnet, _ := m.Network.Subnet.ToPrefix()
+	ipam, err := NewIPAMWithAllocated(network, machineSubnets(machines))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "create IPAM manager: %v", err)
+	}
+	if ipam.Contains(oldSubnet) {
+		// The machine subnet is already in the cluster network.
+		return &pb.UpdateMachineResponse{Machine: m}, nil
+	}
+
+	subnet, err := ipam.AllocateSubnetLen(DefaultSubnetBits)
+	if err != nil {
+		return nil, status.Errorf(codes.ResourceExhausted, "allocate subnet for machine: %v", err)
+	}
+	m.Network.Subnet = pb.NewIPPrefix(subnet)
+	if err = c.store.UpdateMachine(ctx, m); err != nil {
+		return nil, status.Errorf(codes.Internal, "update machine: %v", err)
+	}
+	slog.Info("Machine subnet reallocated.", "id", m.Id, "name", m.Name, "old_subnet", oldSubnet, "subnet", subnet)
+
+	return &pb.UpdateMachineResponse{Machine: m}, nil
+}
+
+// PlanSubnets returns the new subnets for machines whose current subnets are outside the network, keyed by machine
+// ID. New subnets don't overlap with any current machine subnets so that old and new subnets can coexist while
+// machines are being reallocated one by one.
+func PlanSubnets(network netip.Prefix, machines []*pb.MachineInfo) (map[string]netip.Prefix, error) {
+	ipam, err := NewIPAMWithAllocated(network, machineSubnets(machines))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make(map[string]netip.Prefix)
+	for _, m := range machines {
+		subnet, err := m.Network.Subnet.ToPrefix()
+		if err == nil && ipam.Contains(subnet) {
+			continue
+		}
+		if plan[m.Id], err = ipam.AllocateSubnetLen(DefaultSubnetBits); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+func machineSubnets(machines []*pb.MachineInfo) []netip.Prefix {
+	subnets := make([]netip.Prefix, 0, len(machines))
+	for _, m := range machines {
+		if m.Network == nil {
+			continue
+		}
+		if subnet, err := m.Network.Subnet.ToPrefix(); err == nil {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
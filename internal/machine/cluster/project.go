@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// projectsKey is the key used to store the JSON of the Compose project metadata.
+const projectsKey = "projects"
+
+type project struct {
+	Owner     string   `json:"owner,omitempty"`
+	Protected bool     `json:"protected,omitempty"`
+	Services  []string `json:"services,omitempty"`
+}
+
+// UpdateProject updates the metadata of a Compose project and records the services deployed from it.
+func (c *Cluster) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.Project, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "project name is required")
+	}
+
+	c.projectsMu.Lock()
+	defer c.projectsMu.Unlock()
+
+	projects, err := c.projects(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	// Service names are unique in the cluster so a service redeployed from another project moves to it.
+	for name, p := range projects {
+		if name != req.Name {
+			p.Services = slices.DeleteFunc(p.Services, func(s string) bool {
+				return slices.Contains(req.Services, s)
+			})
+		}
+	}
+
+	p := projects[req.Name]
+	if p == nil {
+		p = &project{}
+		projects[req.Name] = p
+	}
+	if req.Owner != nil {
+		p.Owner = *req.Owner
+	}
+	if req.Protected != nil {
+		p.Protected = *req.Protected
+	}
+	for _, s := range req.Services {
+		if !slices.Contains(p.Services, s) {
+			p.Services = append(p.Services, s)
+		}
+	}
+	slices.Sort(p.Services)
+
+	if err = c.storeProjects(ctx, projects); err != nil {
+		return nil, err
+	}
+	return p.toProto(req.Name), nil
+}
+
+// ListProjects returns the metadata of all Compose projects sorted by name.
+func (c *Cluster) ListProjects(ctx context.Context, _ *emptypb.Empty) (*pb.Projects, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	projects, err := c.projects(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	resp := &pb.Projects{}
+	for _, name := range slices.Sorted(maps.Keys(projects)) {
+		resp.Projects = append(resp.Projects, projects[name].toProto(name))
+	}
+	return resp, nil
+}
+
+func (c *Cluster) projects(ctx context.Context) (map[string]*project, error) {
+	projects := make(map[string]*project)
+	var projectsJSON []byte
+	if err := c.store.Get(ctx, projectsKey, &projectsJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return projects, nil
+		}
+		return nil, fmt.Errorf("get projects from store: %w", err)
+	}
+	if err := json.Unmarshal(projectsJSON, &projects); err != nil {
+		return nil, fmt.Errorf("unmarshal projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (c *Cluster) storeProjects(ctx context.Context, projects map[string]*project) error {
+	projectsJSON, err := json.Marshal(projects)
+	if err != nil {
+		return status.Errorf(codes.Internal, "marshal projects for store: %v", err)
+	}
+	if err = c.store.Put(ctx, projectsKey, projectsJSON); err != nil {
+		return status.Errorf(codes.Internal, "store projects: %v", err)
+	}
+	return nil
+}
+
+func (p *project) toProto(name string) *pb.Project {
+	return &pb.Project{
+		Name:      name,
+		Owner:     p.Owner,
+		Protected: p.Protected,
+		Services:  p.Services,
+	}
+}
@@ -0,0 +1,194 @@
+package cluster
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// publishedReservationsKey is the key used to store the JSON of the published ports and ingress hostnames reserved
+// by Compose projects.
+const publishedReservationsKey = "published_reservations"
+
+// publishedReservations map the reserved ports and hostnames to the names of the projects that reserved them.
+type publishedReservations struct {
+	Ports     map[string]string `json:"ports,omitempty"`
+	Hostnames map[string]string `json:"hostnames,omitempty"`
+}
+
+// ReservePublished reserves the published ports and ingress hostnames for a Compose project. The project's
+// reservations that aren't in the request are released if requested.
+func (c *Cluster) ReservePublished(
+	ctx context.Context, req *pb.ReservePublishedRequest,
+) (*pb.PublishedReservations, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if req.Project == "" {
+		return nil, status.Error(codes.InvalidArgument, "project name is required")
+	}
+
+	// The store is eventually consistent across machines so this only serialises the reservations made through
+	// this machine. It's good enough to catch the conflicts between projects deployed at different times.
+	c.reservationsMu.Lock()
+	defer c.reservationsMu.Unlock()
+
+	reservations, err := c.publishedReservations(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	var conflicts []string
+	for _, p := range req.Ports {
+		if owner, ok := reservations.Ports[p]; ok && owner != req.Project {
+			conflicts = append(conflicts, fmt.Sprintf("port %s is reserved by project '%s'", p, owner))
+		}
+	}
+	for _, h := range req.Hostnames {
+		if owner, ok := reservations.Hostnames[h]; ok && owner != req.Project {
+			conflicts = append(conflicts, fmt.Sprintf("hostname %s is reserved by project '%s'", h, owner))
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, status.Error(codes.AlreadyExists, strings.Join(conflicts, "; "))
+	}
+
+	if req.ReleaseOthers {
+		releaseProject(reservations, req.Project)
+	}
+	for _, p := range req.Ports {
+		reservations.Ports[p] = req.Project
+	}
+	for _, h := range req.Hostnames {
+		reservations.Hostnames[h] = req.Project
+	}
+	if !req.DryRun {
+		if err = c.storePublishedReservations(ctx, reservations); err != nil {
+			return nil, err
+		}
+	}
+
+	return reservations.toProto(req.Project), nil
+}
+
+// ReleasePublished releases all published ports and ingress hostnames reserved by a Compose project.
+func (c *Cluster) ReleasePublished(
+	ctx context.Context, req *pb.ReleasePublishedRequest,
+) (*pb.PublishedReservations, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if req.Project == "" {
+		return nil, status.Error(codes.InvalidArgument, "project name is required")
+	}
+
+	c.reservationsMu.Lock()
+	defer c.reservationsMu.Unlock()
+
+	reservations, err := c.publishedReservations(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	released := reservations.toProto(req.Project)
+	if len(released.Reservations) == 0 {
+		return nil, status.Errorf(codes.NotFound, "project '%s' has no reservations", req.Project)
+	}
+
+	releaseProject(reservations, req.Project)
+	if err = c.storePublishedReservations(ctx, reservations); err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// ListPublishedReservations returns the published ports and ingress hostnames reserved by all Compose projects.
+func (c *Cluster) ListPublishedReservations(ctx context.Context, _ *emptypb.Empty) (*pb.PublishedReservations, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	reservations, err := c.publishedReservations(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return reservations.toProto(""), nil
+}
+
+func (c *Cluster) publishedReservations(ctx context.Context) (*publishedReservations, error) {
+	r := &publishedReservations{}
+	var reservationsJSON []byte
+	if err := c.store.Get(ctx, publishedReservationsKey, &reservationsJSON); err != nil {
+		if !errors.Is(err, store.ErrKeyNotFound) {
+			return nil, fmt.Errorf("get published reservations from store: %w", err)
+		}
+	} else if err = json.Unmarshal(reservationsJSON, r); err != nil {
+		return nil, fmt.Errorf("unmarshal published reservations: %w", err)
+	}
+
+	if r.Ports == nil {
+		r.Ports = make(map[string]string)
+	}
+	if r.Hostnames == nil {
+		r.Hostnames = make(map[string]string)
+	}
+	return r, nil
+}
+
+func (c *Cluster) storePublishedReservations(ctx context.Context, r *publishedReservations) error {
+	reservationsJSON, err := json.Marshal(r)
+	if err != nil {
+		return status.Errorf(codes.Internal, "marshal published reservations for store: %v", err)
+	}
+	if err = c.store.Put(ctx, publishedReservationsKey, reservationsJSON); err != nil {
+		return status.Errorf(codes.Internal, "store published reservations: %v", err)
+	}
+	return nil
+}
+
+func releaseProject(r *publishedReservations, project string) {
+	for p, owner := range r.Ports {
+		if owner == project {
+			delete(r.Ports, p)
+		}
+	}
+	for h, owner := range r.Hostnames {
+		if owner == project {
+			delete(r.Hostnames, h)
+		}
+	}
+}
+
+// toProto returns the reservations of the given project or all projects if the project is empty, sorted by project,
+// ports, then hostnames.
+func (r *publishedReservations) toProto(project string) *pb.PublishedReservations {
+	var reservations []*pb.PublishedReservation
+	for p, owner := range r.Ports {
+		if project == "" || owner == project {
+			reservations = append(reservations, &pb.PublishedReservation{Project: owner, Port: p})
+		}
+	}
+	for h, owner := range r.Hostnames {
+		if project == "" || owner == project {
+			reservations = append(reservations, &pb.PublishedReservation{Project: owner, Hostname: h})
+		}
+	}
+	slices.SortFunc(reservations, func(a, b *pb.PublishedReservation) int {
+		return cmp.Or(
+			cmp.Compare(a.Project, b.Project),
+			// Ports before hostnames.
+			cmp.Compare(a.Hostname, b.Hostname),
+			cmp.Compare(a.Port, b.Port),
+		)
+	})
+	return &pb.PublishedReservations{Reservations: reservations}
+}
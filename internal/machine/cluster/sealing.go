@@ -0,0 +1,139 @@
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"golang.org/x/crypto/nacl/box"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// sealingKeysPrefix is the prefix of the store keys used to store the JSON of the cluster key pairs for sealing
+// secrets. Each key pair is stored under its own key so that key pairs generated concurrently on different machines
+// don't overwrite each other and secrets sealed with any of them can still be opened.
+const sealingKeysPrefix = "sealing_keys/"
+
+// sealingKey is the representation of the cluster sealing key pair in the store.
+type sealingKey struct {
+	ID         string    `json:"id"`
+	PublicKey  [32]byte  `json:"public_key"`
+	PrivateKey [32]byte  `json:"private_key"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetSealingKey returns the cluster public key for sealing secrets. It generates the key pair if it doesn't exist.
+func (c *Cluster) GetSealingKey(ctx context.Context, _ *emptypb.Empty) (*pb.SealingKey, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	key, err := c.ensureSealingKey(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return &pb.SealingKey{Id: key.ID, PublicKey: key.PublicKey[:]}, nil
+}
+
+// OpenSealedSecret decrypts the ciphertext of a secret sealed with the cluster sealing key pair with the given ID.
+func (c *Cluster) OpenSealedSecret(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error) {
+	keys, err := c.sealingKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(keys, func(k *sealingKey) bool { return k.ID == keyID })
+	if idx < 0 {
+		return nil, fmt.Errorf("secret is sealed with an unknown cluster key '%s', seal it again with "+
+			"'uc secret seal'", keyID)
+	}
+	return api.OpenSealedSecret(ciphertext, &keys[idx].PublicKey, &keys[idx].PrivateKey)
+}
+
+// ensureSealingKey returns the active cluster sealing key pair and generates it if it doesn't exist yet.
+// The generation is serialised on this machine and the returned key pair is always read back from the store.
+func (c *Cluster) ensureSealingKey(ctx context.Context) (*sealingKey, error) {
+	c.sealingMu.Lock()
+	defer c.sealingMu.Unlock()
+
+	keys, err := c.sealingKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 {
+		return keys[0], nil
+	}
+
+	if err = c.createSealingKey(ctx); err != nil {
+		return nil, err
+	}
+	if keys, err = c.sealingKeys(ctx); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("sealing key not found in store after generating it")
+	}
+	return keys[0], nil
+}
+
+// sealingKeys returns all cluster sealing key pairs from the store. The active key pair used for sealing new secrets
+// is the first one, that is the oldest one. Machines that generated a key pair concurrently converge to the same
+// active key pair once the store is synchronised.
+func (c *Cluster) sealingKeys(ctx context.Context) ([]*sealingKey, error) {
+	values, err := c.store.List(ctx, sealingKeysPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("get sealing keys from store: %w", err)
+	}
+
+	keys := make([]*sealingKey, 0, len(values))
+	for _, keyJSON := range values {
+		var key sealingKey
+		if err = json.Unmarshal(keyJSON, &key); err != nil {
+			return nil, fmt.Errorf("unmarshal sealing key: %w", err)
+		}
+		keys = append(keys, &key)
+	}
+	slices.SortFunc(keys, func(a, b *sealingKey) int {
+		if cmp := a.CreatedAt.Compare(b.CreatedAt); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return keys, nil
+}
+
+// createSealingKey generates a new cluster sealing key pair and stores it under its own key.
+func (c *Cluster) createSealingKey(ctx context.Context) error {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("generate sealing key: %w", err)
+	}
+	key := &sealingKey{
+		ID:         api.SealingKeyID(publicKey),
+		PublicKey:  *publicKey,
+		PrivateKey: *privateKey,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshal sealing key for store: %w", err)
+	}
+	if err = c.store.Put(ctx, sealingKeysPrefix+key.ID, keyJSON); err != nil {
+		return fmt.Errorf("store sealing key: %w", err)
+	}
+	slog.Info("Generated new cluster key pair for sealing secrets.", "id", key.ID)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package cluster
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCluster(t *testing.T) *Cluster {
+	t.Helper()
+
+	backend, err := store.NewSQLiteBackend(filepath.Join(t.TempDir(), store.SQLiteFileName))
+	require.NoError(t, err)
+	t.Cleanup(func() { backend.Close() })
+
+	ready := make(chan struct{})
+	close(ready)
+	return NewCluster(store.New(backend), nil, make(chan struct{}), ready)
+}
+
+func TestCluster_GetSealingKey_Concurrent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	c := newTestCluster(t)
+
+	var wg sync.WaitGroup
+	ids := make([]string, 10)
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key, err := c.GetSealingKey(ctx, nil)
+			assert.NoError(t, err)
+			if key != nil {
+				ids[i] = key.Id
+			}
+		}()
+	}
+	wg.Wait()
+
+	keys, err := c.sealingKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	for _, id := range ids {
+		assert.Equal(t, keys[0].ID, id)
+	}
+}
+
+func TestCluster_OpenSealedSecret_AnyStoredKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	c := newTestCluster(t)
+
+	// Simulate two machines that generated a key pair concurrently before the store was synchronised.
+	require.NoError(t, c.createSealingKey(ctx))
+	require.NoError(t, c.createSealingKey(ctx))
+	keys, err := c.sealingKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	active, err := c.GetSealingKey(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, keys[0].ID, active.Id)
+
+	for _, key := range keys {
+		ciphertext, err := api.SealSecretContent([]byte("secret"), &key.PublicKey)
+		require.NoError(t, err)
+
+		value, err := c.OpenSealedSecret(ctx, key.ID, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("secret"), value)
+	}
+
+	_, err = c.OpenSealedSecret(ctx, "unknown", nil)
+	assert.ErrorContains(t, err, "unknown cluster key 'unknown'")
+}
@@ -0,0 +1,77 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// secretMaxModeKey is the key used to store the JSON of the most permissive file mode allowed for secrets.
+const secretMaxModeKey = "secret_max_mode"
+
+// SetSecretMaxMode sets the most permissive file mode allowed for secrets mounted into service containers.
+func (c *Cluster) SetSecretMaxMode(ctx context.Context, req *pb.SecretMaxMode) (*pb.SecretMaxMode, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if os.FileMode(req.Mode)&^os.ModePerm != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid mode %04o: only permission bits are allowed",
+			req.Mode)
+	}
+
+	if req.Mode == 0 {
+		if err := c.store.Delete(ctx, secretMaxModeKey); err != nil {
+			return nil, status.Errorf(codes.Internal, "delete secret max mode from store: %v", err)
+		}
+		return &pb.SecretMaxMode{}, nil
+	}
+
+	modeJSON, err := json.Marshal(req.Mode)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal secret max mode for store: %v", err)
+	}
+	if err = c.store.Put(ctx, secretMaxModeKey, modeJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store secret max mode: %v", err)
+	}
+
+	return &pb.SecretMaxMode{Mode: req.Mode}, nil
+}
+
+// GetSecretMaxMode returns the most permissive file mode allowed for secrets mounted into service containers.
+func (c *Cluster) GetSecretMaxMode(ctx context.Context, _ *emptypb.Empty) (*pb.SecretMaxMode, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	mode, err := c.SecretMaxMode(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &pb.SecretMaxMode{Mode: uint32(mode)}, nil
+}
+
+// SecretMaxMode returns the most permissive file mode allowed for secrets mounted into service containers.
+// Zero means no limit.
+func (c *Cluster) SecretMaxMode(ctx context.Context) (os.FileMode, error) {
+	var modeJSON []byte
+	if err := c.store.Get(ctx, secretMaxModeKey, &modeJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("get secret max mode from store: %w", err)
+	}
+
+	var mode uint32
+	if err := json.Unmarshal(modeJSON, &mode); err != nil {
+		return 0, fmt.Errorf("unmarshal secret max mode: %w", err)
+	}
+	return os.FileMode(mode), nil
+}
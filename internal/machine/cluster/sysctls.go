@@ -0,0 +1,131 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// allowedSysctlsKey is the key used to store the JSON list of allowed sysctl patterns in the store.
+const allowedSysctlsKey = "allowed_sysctls"
+
+// AllowSysctls adds patterns to the cluster sysctl allow-list.
+func (c *Cluster) AllowSysctls(ctx context.Context, req *pb.SysctlsRequest) (*pb.AllowedSysctls, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if err := validateSysctlPatterns(req.Patterns); err != nil {
+		return nil, err
+	}
+
+	allowed, err := c.AllowedSysctls(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	for _, p := range req.Patterns {
+		if !slices.Contains(allowed, p) {
+			allowed = append(allowed, p)
+		}
+	}
+
+	return c.storeAllowedSysctls(ctx, allowed)
+}
+
+// DisallowSysctls removes patterns from the cluster sysctl allow-list. Removing the last pattern disables
+// the policy so all sysctls are allowed again.
+func (c *Cluster) DisallowSysctls(ctx context.Context, req *pb.SysctlsRequest) (*pb.AllowedSysctls, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if len(req.Patterns) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no sysctls specified")
+	}
+
+	allowed, err := c.AllowedSysctls(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	var notAllowed []string
+	for _, p := range req.Patterns {
+		if i := slices.Index(allowed, p); i >= 0 {
+			allowed = slices.Delete(allowed, i, i+1)
+		} else {
+			notAllowed = append(notAllowed, p)
+		}
+	}
+	if len(notAllowed) > 0 {
+		return nil, status.Errorf(codes.NotFound, "sysctls not in the allow-list: %s", strings.Join(notAllowed, ", "))
+	}
+
+	return c.storeAllowedSysctls(ctx, allowed)
+}
+
+// ListAllowedSysctls returns the cluster sysctl allow-list.
+func (c *Cluster) ListAllowedSysctls(ctx context.Context, _ *emptypb.Empty) (*pb.AllowedSysctls, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	allowed, err := c.AllowedSysctls(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &pb.AllowedSysctls{Patterns: allowed}, nil
+}
+
+// AllowedSysctls returns the sorted cluster sysctl allow-list from the store. It returns nil if there is no policy
+// and all sysctls are allowed.
+func (c *Cluster) AllowedSysctls(ctx context.Context) ([]string, error) {
+	var allowedJSON []byte
+	if err := c.store.Get(ctx, allowedSysctlsKey, &allowedJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get allowed sysctls from store: %w", err)
+	}
+
+	var allowed []string
+	if err := json.Unmarshal(allowedJSON, &allowed); err != nil {
+		return nil, fmt.Errorf("unmarshal allowed sysctls: %w", err)
+	}
+	slices.Sort(allowed)
+	return allowed, nil
+}
+
+// storeAllowedSysctls replaces the sysctl allow-list in the store. Concurrent updates from different machines
+// are resolved by the store as last write wins.
+func (c *Cluster) storeAllowedSysctls(ctx context.Context, allowed []string) (*pb.AllowedSysctls, error) {
+	slices.Sort(allowed)
+	allowedJSON, err := json.Marshal(allowed)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal allowed sysctls for store: %v", err)
+	}
+	if err = c.store.Put(ctx, allowedSysctlsKey, allowedJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store allowed sysctls: %v", err)
+	}
+
+	return &pb.AllowedSysctls{Patterns: allowed}, nil
+}
+
+func validateSysctlPatterns(patterns []string) error {
+	if len(patterns) == 0 {
+		return status.Error(codes.InvalidArgument, "no sysctls specified")
+	}
+	for _, p := range patterns {
+		if err := api.ValidateSysctlPattern(p); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// defaultUlimitsKey is the key used to store the JSON map of default ulimits in the store.
+const defaultUlimitsKey = "default_ulimits"
+
+// SetDefaultUlimits adds or replaces cluster-wide default ulimits.
+func (c *Cluster) SetDefaultUlimits(ctx context.Context, req *pb.DefaultUlimits) (*pb.DefaultUlimits, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if len(req.Ulimits) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no ulimits specified")
+	}
+	for name, u := range req.Ulimits {
+		if err := validateUlimit(name, u); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	ulimits, err := c.DefaultUlimits(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if ulimits == nil {
+		ulimits = make(map[string]api.Ulimit, len(req.Ulimits))
+	}
+	for name, u := range req.Ulimits {
+		ulimits[name] = api.Ulimit{Soft: u.Soft, Hard: u.Hard}
+	}
+
+	return c.storeDefaultUlimits(ctx, ulimits)
+}
+
+// RemoveDefaultUlimits removes cluster-wide default ulimits by name.
+func (c *Cluster) RemoveDefaultUlimits(
+	ctx context.Context, req *pb.RemoveDefaultUlimitsRequest,
+) (*pb.DefaultUlimits, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+	if len(req.Names) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no ulimits specified")
+	}
+
+	ulimits, err := c.DefaultUlimits(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	var notSet []string
+	for _, name := range req.Names {
+		if _, ok := ulimits[name]; ok {
+			delete(ulimits, name)
+		} else {
+			notSet = append(notSet, name)
+		}
+	}
+	if len(notSet) > 0 {
+		slices.Sort(notSet)
+		return nil, status.Errorf(codes.NotFound, "default ulimits not set: %s", strings.Join(notSet, ", "))
+	}
+
+	return c.storeDefaultUlimits(ctx, ulimits)
+}
+
+// ListDefaultUlimits returns the cluster-wide default ulimits.
+func (c *Cluster) ListDefaultUlimits(ctx context.Context, _ *emptypb.Empty) (*pb.DefaultUlimits, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	ulimits, err := c.DefaultUlimits(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return defaultUlimitsToProto(ulimits), nil
+}
+
+// DefaultUlimits returns the cluster-wide default ulimits from the store. It returns nil if none are set.
+func (c *Cluster) DefaultUlimits(ctx context.Context) (map[string]api.Ulimit, error) {
+	var ulimitsJSON []byte
+	if err := c.store.Get(ctx, defaultUlimitsKey, &ulimitsJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get default ulimits from store: %w", err)
+	}
+
+	var ulimits map[string]api.Ulimit
+	if err := json.Unmarshal(ulimitsJSON, &ulimits); err != nil {
+		return nil, fmt.Errorf("unmarshal default ulimits: %w", err)
+	}
+	return ulimits, nil
+}
+
+// storeDefaultUlimits replaces the default ulimits in the store. Concurrent updates from different machines
+// are resolved by the store as last write wins.
+func (c *Cluster) storeDefaultUlimits(ctx context.Context, ulimits map[string]api.Ulimit) (*pb.DefaultUlimits, error) {
+	ulimitsJSON, err := json.Marshal(ulimits)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal default ulimits for store: %v", err)
+	}
+	if err = c.store.Put(ctx, defaultUlimitsKey, ulimitsJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store default ulimits: %v", err)
+	}
+
+	return defaultUlimitsToProto(ulimits), nil
+}
+
+// validateUlimit checks the ulimit name is supported by Docker and the soft limit doesn't exceed the hard limit.
+func validateUlimit(name string, u *pb.Ulimit) error {
+	if u == nil {
+		return fmt.Errorf("ulimit '%s' has no value", name)
+	}
+	if _, err := units.ParseUlimit(fmt.Sprintf("%s=%d:%d", name, u.Soft, u.Hard)); err != nil {
+		return fmt.Errorf("invalid ulimit '%s': %w", name, err)
+	}
+	return nil
+}
+
+func defaultUlimitsToProto(ulimits map[string]api.Ulimit) *pb.DefaultUlimits {
+	resp := &pb.DefaultUlimits{Ulimits: make(map[string]*pb.Ulimit, len(ulimits))}
+	for name, u := range ulimits {
+		resp.Ulimits[name] = &pb.Ulimit{Soft: u.Soft, Hard: u.Hard}
+	}
+	return resp
+}
@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// usernsRemapKey is the key used to store the JSON boolean of the cluster user namespace remapping option.
+const usernsRemapKey = "userns_remap"
+
+// SetUsernsRemap enables or disables user namespace remapping for the cluster.
+func (c *Cluster) SetUsernsRemap(ctx context.Context, req *pb.UsernsRemap) (*pb.UsernsRemap, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	enabledJSON, err := json.Marshal(req.Enabled)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal userns remap option for store: %v", err)
+	}
+	if err = c.store.Put(ctx, usernsRemapKey, enabledJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store userns remap option: %v", err)
+	}
+
+	return &pb.UsernsRemap{Enabled: req.Enabled}, nil
+}
+
+// GetUsernsRemap returns whether user namespace remapping is enabled for the cluster.
+func (c *Cluster) GetUsernsRemap(ctx context.Context, _ *emptypb.Empty) (*pb.UsernsRemap, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	enabled, err := c.UsernsRemap(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &pb.UsernsRemap{Enabled: enabled}, nil
+}
+
+// UsernsRemap returns whether user namespace remapping is enabled for the cluster. It's disabled if the option
+// has never been set.
+func (c *Cluster) UsernsRemap(ctx context.Context) (bool, error) {
+	var enabledJSON []byte
+	if err := c.store.Get(ctx, usernsRemapKey, &enabledJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get userns remap option from store: %w", err)
+	}
+
+	var enabled bool
+	if err := json.Unmarshal(enabledJSON, &enabled); err != nil {
+		return false, fmt.Errorf("unmarshal userns remap option: %w", err)
+	}
+	return enabled, nil
+}
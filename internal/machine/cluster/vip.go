@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// ingressVIPKey is the key used to store the JSON of the ingress virtual IP configuration.
+	ingressVIPKey = "ingress_vip"
+	// ingressVIPStatusKey is the key used to store the JSON of the machine that holds the ingress virtual IP.
+	ingressVIPStatusKey = "ingress_vip_status"
+)
+
+// SetIngressVIP sets or removes the virtual IP address that floats among ingress machines.
+func (c *Cluster) SetIngressVIP(ctx context.Context, req *pb.IngressVIP) (*emptypb.Empty, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	if len(req.Config) == 0 {
+		if err := c.store.Delete(ctx, ingressVIPKey); err != nil && !errors.Is(err, store.ErrKeyNotFound) {
+			return nil, status.Errorf(codes.Internal, "delete ingress VIP from store: %v", err)
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	var vip api.IngressVIP
+	if err := json.Unmarshal(req.Config, &vip); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal ingress VIP: %v", err)
+	}
+	if err := vip.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	vipJSON, err := json.Marshal(vip)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal ingress VIP for store: %v", err)
+	}
+	if err = c.store.Put(ctx, ingressVIPKey, vipJSON); err != nil {
+		return nil, status.Errorf(codes.Internal, "store ingress VIP: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetIngressVIP returns the virtual IP configuration and the machine that currently holds the address.
+func (c *Cluster) GetIngressVIP(ctx context.Context, _ *emptypb.Empty) (*pb.IngressVIP, error) {
+	if err := c.checkReady(); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.IngressVIP{}
+	if err := c.store.Get(ctx, ingressVIPKey, &resp.Config); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return resp, nil
+		}
+		return nil, status.Errorf(codes.Internal, "get ingress VIP from store: %v", err)
+	}
+	if err := c.store.Get(ctx, ingressVIPStatusKey, &resp.Status); err != nil &&
+		!errors.Is(err, store.ErrKeyNotFound) {
+		return nil, status.Errorf(codes.Internal, "get ingress VIP status from store: %v", err)
+	}
+	return resp, nil
+}
+
+// IngressVIP returns the ingress virtual IP configuration or nil if it's not configured.
+func (c *Cluster) IngressVIP(ctx context.Context) (*api.IngressVIP, error) {
+	var vipJSON []byte
+	if err := c.store.Get(ctx, ingressVIPKey, &vipJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get ingress VIP from store: %w", err)
+	}
+
+	var vip api.IngressVIP
+	if err := json.Unmarshal(vipJSON, &vip); err != nil {
+		return nil, fmt.Errorf("unmarshal ingress VIP: %w", err)
+	}
+	return &vip, nil
+}
+
+// IngressVIPStatus returns the machine that holds the ingress virtual IP or nil if no machine holds it.
+func (c *Cluster) IngressVIPStatus(ctx context.Context) (*api.IngressVIPStatus, error) {
+	var statusJSON []byte
+	if err := c.store.Get(ctx, ingressVIPStatusKey, &statusJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get ingress VIP status from store: %w", err)
+	}
+
+	var s api.IngressVIPStatus
+	if err := json.Unmarshal(statusJSON, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal ingress VIP status: %w", err)
+	}
+	return &s, nil
+}
+
+// PutIngressVIPStatus stores the machine that holds the ingress virtual IP.
+func (c *Cluster) PutIngressVIPStatus(ctx context.Context, s *api.IngressVIPStatus) error {
+	statusJSON, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal ingress VIP status for store: %w", err)
+	}
+	if err = c.store.Put(ctx, ingressVIPStatusKey, statusJSON); err != nil {
+		return fmt.Errorf("store ingress VIP status: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,16 @@ package constants
 const (
 	// MachineAPIPort is the port for the Machine API service on the management WireGuard network.
 	MachineAPIPort = 51000
+	// MeshPort is the port for the mesh proxy listening on the machine IP that accepts mTLS connections from
+	// other machines and forwards them to local mTLS-enabled containers.
+	MeshPort = 51010
+	// MeshRedirectPort is the port for the mesh proxy listening on the machine IP that accepts plaintext connections
+	// from local containers redirected by iptables and wraps them in mTLS.
+	MeshRedirectPort = 51011
 	// UnregistryPort is the port for the embedded container registry listening on the machine IP.
 	UnregistryPort = 5000
+
+	// DefaultUncloudSockPath is the default path to the Unix socket of the machine API proxy that forwards requests
+	// to the local or remote machines.
+	DefaultUncloudSockPath = "/run/uncloud/uncloud.sock"
 )
@@ -2,16 +2,22 @@ package machine
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/psviderski/uncloud/internal/corrosion"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
 	_ "modernc.org/sqlite"
 )
 
 const DBFileName = "machine.db"
 
 // NewDB creates a new connection to machine SQLite database and runs schema migrations if necessary.
-func NewDB(path string) (*sqlx.DB, error) {
+// cacheSizeKiB limits the page cache size of each connection. 0 means the SQLite default.
+func NewDB(path string, cacheSizeKiB int) (*sqlx.DB, error) {
 	// Create the database file with 0600 permissions if it doesn't exist, or update permissions if exists.
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
@@ -28,6 +34,10 @@ func NewDB(path string) (*sqlx.DB, error) {
 	// - Write-Ahead Logging (WAL) mode for better read/write performance.
 	// - Busy timeout (5s) to make concurrent writes wait on each other instead of failing immediately.
 	conn := path + "?_pragma=journal_mode=WAL&_pragma=synchronous=NORMAL&_pragma=busy_timeout=5000&_time_format=sqlite"
+	if cacheSizeKiB > 0 {
+		// A negative cache_size is the cache size in KiB rather than the number of pages.
+		conn += fmt.Sprintf("&_pragma=cache_size=-%d", cacheSizeKiB)
+	}
 	db, err := sqlx.Connect("sqlite", conn)
 	if err != nil {
 		return nil, fmt.Errorf("connect to SQLite database '%s': %w", conn, err)
@@ -41,11 +51,84 @@ func NewDB(path string) (*sqlx.DB, error) {
 	    created_at TIMESTAMP NOT NULL DEFAULT (datetime('subsecond')),
 	    updated_at TIMESTAMP NOT NULL DEFAULT (datetime('subsecond'))
 	);
+	-- Number of restarts of the service containers by the machine daemon after Docker failed to restart them.
+	CREATE TABLE IF NOT EXISTS container_restarts (
+	    id TEXT NOT NULL PRIMARY KEY,
+	    count INTEGER NOT NULL DEFAULT 0,
+	    restarted_at TIMESTAMP NOT NULL DEFAULT (datetime('subsecond'))
+	);
+	-- Journal of the in-flight operations that change multiple resources. The operations left after a daemon stop
+	-- or crash are completed or rolled back on the next start.
+	CREATE TABLE IF NOT EXISTS operations (
+	    id INTEGER PRIMARY KEY AUTOINCREMENT,
+	    kind TEXT NOT NULL,
+	    container_name TEXT NOT NULL,
+	    started_at TIMESTAMP NOT NULL DEFAULT (datetime('subsecond'))
+	);
     `
 
 	if _, err = db.Exec(schema); err != nil {
 		return nil, fmt.Errorf("create schema: %w", err)
 	}
+	if err = migrateServiceSpecs(db); err != nil {
+		return nil, fmt.Errorf("migrate service specs: %w", err)
+	}
 
 	return db, nil
 }
+
+// migrateServiceSpecs upgrades the service specs of the containers stored by older daemon versions to the current
+// spec encoding. The specs are also upgraded when read, so this only keeps the stored data current. Specs with fields
+// unknown to this version, for example, written by a newer daemon before a downgrade, are kept as is to not lose them.
+func migrateServiceSpecs(db *sqlx.DB) error {
+	var rows []struct {
+		ID          string `db:"id"`
+		ServiceSpec string `db:"service_spec"`
+	}
+	if err := db.Select(&rows, `SELECT id, service_spec FROM containers`); err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	migrated := 0
+	for _, r := range rows {
+		unknown, err := api.UnknownServiceSpecFields([]byte(r.ServiceSpec))
+		if err == nil && len(unknown) > 0 {
+			slog.Warn("Service spec of container has fields unknown to this daemon version, not migrating it.",
+				"id", r.ID, "fields", unknown)
+			continue
+		}
+		spec, changed, err := api.MigrateServiceSpecJSON([]byte(r.ServiceSpec))
+		if err != nil {
+			slog.Warn("Failed to migrate service spec of container.", "id", r.ID, "err", err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		if _, err = db.Exec(`UPDATE containers SET service_spec = $1, updated_at = datetime('subsecond') WHERE id = $2`,
+			string(spec), r.ID); err != nil {
+			return fmt.Errorf("update service spec of container '%s': %w", r.ID, err)
+		}
+		migrated++
+	}
+	if migrated > 0 {
+		slog.Info("Migrated service specs of containers to the current version.",
+			"containers", migrated, "version", api.ServiceSpecVersion)
+	}
+
+	return nil
+}
+
+// newStoreBackend creates the cluster store backend with the given name. An empty name means the default Corrosion
+// backend that uses the corro client. Other backends keep their data in the machine data directory.
+func newStoreBackend(name, dataDir string, corro *corrosion.APIClient) (store.Backend, error) {
+	switch name {
+	case "", store.BackendCorrosion:
+		return store.NewCorrosionBackend(corro), nil
+	case store.BackendSQLite:
+		return store.NewSQLiteBackend(filepath.Join(dataDir, store.SQLiteFileName))
+	default:
+		return nil, store.ValidateBackend(name)
+	}
+}
@@ -0,0 +1,36 @@
+package machine
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDBMigratesServiceSpecs(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), DBFileName)
+	db, err := NewDB(path, 0)
+	require.NoError(t, err)
+
+	legacy := `{"Configs":null,"Container":{"Image":"nginx"},"Mode":"","Name":"web"}`
+	newer := `{"Container":{"Image":"nginx"},"Name":"db","Schedule":"@daily","SpecVersion":1}`
+	_, err = db.Exec(`INSERT INTO containers (id, service_spec) VALUES ('legacy', $1), ('newer', $2)`, legacy, newer)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = NewDB(path, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	var spec string
+	require.NoError(t, db.Get(&spec, `SELECT service_spec FROM containers WHERE id = 'legacy'`))
+	assert.JSONEq(t, `{"Container":{"Image":"nginx","PidMode":"","Privileged":false,"PullPolicy":"",
+		"Resources":{"CPU":0,"Memory":0,"MemoryReservation":0,"PidsLimit":0,"SharedMemory":0},"User":""},
+		"Mode":"","Name":"web","Placement":{},"SpecVersion":1,"UpdateConfig":{}}`, spec)
+
+	require.NoError(t, db.Get(&spec, `SELECT service_spec FROM containers WHERE id = 'newer'`))
+	assert.Equal(t, newer, spec, "spec with unknown fields must not be migrated")
+}
@@ -0,0 +1,353 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// ConfigCheckInterval is the interval for checking the DNS configuration in the cluster store.
+	ConfigCheckInterval = 10 * time.Second
+	// maxNegativeCacheEntries is the maximum number of cached negative answers.
+	maxNegativeCacheEntries = 10000
+)
+
+// forwardingConfig is the parsed forwarding configuration of the DNS server.
+type forwardingConfig struct {
+	upstreams []netip.AddrPort
+	// zones are sorted from the most specific to the least specific zone.
+	zones       []forwardZone
+	negativeTTL time.Duration
+}
+
+type forwardZone struct {
+	// name is the canonical (fully qualified) zone name.
+	name    string
+	servers []netip.AddrPort
+}
+
+// servers returns the DNS servers to forward the query for the name to.
+func (c *forwardingConfig) servers(name string) []netip.AddrPort {
+	name = dns.CanonicalName(name)
+	for _, z := range c.zones {
+		if dns.IsSubDomain(z.name, name) {
+			return z.servers
+		}
+	}
+	return c.upstreams
+}
+
+// Configure applies the forwarding and query logging settings to the DNS server. The default upstreams are used
+// if the settings don't specify them. The cached negative answers are dropped.
+func (s *Server) Configure(f api.DNSSettings) error {
+	cfg := &forwardingConfig{upstreams: s.defaultUpstreams}
+	if len(f.Upstreams) > 0 {
+		cfg.upstreams = make([]netip.AddrPort, 0, len(f.Upstreams))
+		for _, u := range f.Upstreams {
+			addr, err := api.ParseDNSServer(u)
+			if err != nil {
+				return err
+			}
+			cfg.upstreams = append(cfg.upstreams, addr)
+		}
+	}
+
+	for zone, servers := range f.Zones {
+		z := forwardZone{name: dns.CanonicalName(zone)}
+		for _, srv := range servers {
+			addr, err := api.ParseDNSServer(srv)
+			if err != nil {
+				return fmt.Errorf("zone '%s': %w", zone, err)
+			}
+			z.servers = append(z.servers, addr)
+		}
+		cfg.zones = append(cfg.zones, z)
+	}
+	// Match the most specific zone first.
+	slices.SortFunc(cfg.zones, func(a, b forwardZone) int {
+		if c := dns.CountLabel(b.name) - dns.CountLabel(a.name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	if f.NegativeTTL != nil {
+		cfg.negativeTTL = *f.NegativeTTL
+	}
+
+	var queryLogRate float64
+	if f.QueryLogSampleRate != nil {
+		queryLogRate = *f.QueryLogSampleRate
+	}
+
+	s.forwarding.Store(cfg)
+	s.negativeCache.clear()
+	s.queryLogSampleRate.Store(math.Float64bits(queryLogRate))
+	s.log.Info("Applied DNS configuration.", "upstreams", cfg.upstreams, "zones", len(cfg.zones),
+		"negative_ttl", cfg.negativeTTL, "query_log_sample_rate", queryLogRate)
+
+	return nil
+}
+
+// QueryLogSampleRate returns the fraction of queries from 0 to 1 that the DNS server logs.
+func (s *Server) QueryLogSampleRate() float64 {
+	return math.Float64frombits(s.queryLogSampleRate.Load())
+}
+
+// negativeCache caches negative answers (NXDOMAIN or no records) from the forwarded queries so that repeated
+// queries for names that don't exist, e.g. search domain expansions, don't hit the upstream servers.
+type negativeCache struct {
+	mu      sync.Mutex
+	entries map[string]negativeCacheEntry
+	now     func() time.Time
+}
+
+type negativeCacheEntry struct {
+	resp    *dns.Msg
+	expires time.Time
+}
+
+func newNegativeCache() *negativeCache {
+	return &negativeCache{
+		entries: make(map[string]negativeCacheEntry),
+		now:     time.Now,
+	}
+}
+
+func negativeCacheKey(q dns.Question) string {
+	return fmt.Sprintf("%s|%d|%d", dns.CanonicalName(q.Name), q.Qtype, q.Qclass)
+}
+
+// get returns a copy of the cached negative answer for the query or nil if there is none.
+func (c *negativeCache) get(req *dns.Msg) *dns.Msg {
+	key := negativeCacheKey(req.Question[0])
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil
+	}
+
+	resp := e.resp.Copy()
+	resp.Id = req.Id
+	return resp
+}
+
+// put caches the response if it's a negative answer. The answer is cached for the minimum of the ttl and the negative
+// TTL of the zone from its SOA record if present (RFC 2308).
+func (c *negativeCache) put(req, resp *dns.Msg, ttl time.Duration) {
+	negative := resp.Rcode == dns.RcodeNameError || (resp.Rcode == dns.RcodeSuccess && len(resp.Answer) == 0)
+	if !negative || resp.Truncated {
+		return
+	}
+	for _, rr := range resp.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			soaTTL := time.Duration(min(soa.Hdr.Ttl, soa.Minttl)) * time.Second
+			ttl = min(ttl, soaTTL)
+		}
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= maxNegativeCacheEntries {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxNegativeCacheEntries {
+			return
+		}
+	}
+	c.entries[negativeCacheKey(req.Question[0])] = negativeCacheEntry{
+		resp:    resp.Copy(),
+		expires: now.Add(ttl),
+	}
+}
+
+func (c *negativeCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// ConfigSource provides the DNS configuration of the cluster.
+type ConfigSource interface {
+	// DNSConfig returns the DNS configuration or nil if it's not configured.
+	DNSConfig(ctx context.Context) (*api.DNSConfig, error)
+}
+
+// HostDNS registers the embedded DNS server in the DNS resolver of the machine for the internal domain.
+type HostDNS interface {
+	Registered(ctx context.Context) (bool, error)
+	Register(ctx context.Context) error
+	Unregister(ctx context.Context) error
+}
+
+// MDNS answers multicast DNS queries on the LAN of the machine.
+type MDNS interface {
+	SetEnabled(enabled bool) error
+}
+
+// ConfigController periodically checks the DNS configuration in the cluster and applies the effective
+// settings for the machine to the DNS server, the host DNS resolver, and the multicast DNS responder.
+type ConfigController struct {
+	machineID string
+	source    ConfigSource
+	server    *Server
+	host      HostDNS
+	mdns      MDNS
+	log       *slog.Logger
+	// applied is the last applied settings.
+	applied *api.DNSSettings
+	// hostRegistered is true if the DNS server is known to be registered in the host DNS resolver. It's nil until
+	// the registration is checked.
+	hostRegistered *bool
+	// hostErr is the last error from the host DNS resolver to avoid logging the same error on every check.
+	hostErr string
+	// mdnsEnabled is true if the multicast DNS responder is enabled.
+	mdnsEnabled bool
+}
+
+func NewConfigController(
+	machineID string, source ConfigSource, server *Server, host HostDNS, mdns MDNS,
+) *ConfigController {
+	return &ConfigController{
+		machineID: machineID,
+		source:    source,
+		server:    server,
+		host:      host,
+		mdns:      mdns,
+		log:       slog.With("component", "dns-config-controller"),
+	}
+}
+
+func (c *ConfigController) Run(ctx context.Context) error {
+	ticker := time.NewTicker(ConfigCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		c.sync(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *ConfigController) sync(ctx context.Context) {
+	config, err := c.source.DNSConfig(ctx)
+	if err != nil {
+		c.log.Error("Failed to get DNS configuration.", "err", err)
+		return
+	}
+
+	var f api.DNSSettings
+	if config != nil {
+		f = config.ForMachine(c.machineID)
+	}
+	c.apply(f)
+	c.syncHostDNS(ctx, f.HostDNS != nil && *f.HostDNS)
+	c.syncMDNS(f.MDNS != nil && *f.MDNS)
+}
+
+// apply applies the settings to the DNS server if they changed.
+func (c *ConfigController) apply(f api.DNSSettings) {
+	if c.applied != nil && reflect.DeepEqual(f, *c.applied) {
+		return
+	}
+	// Don't log the default configuration on startup.
+	if c.applied == nil && f.IsZero() {
+		c.applied = &f
+		return
+	}
+
+	if err := c.server.Configure(f); err != nil {
+		c.log.Error("Failed to apply DNS configuration.", "err", err)
+		return
+	}
+	c.applied = &f
+}
+
+// syncHostDNS registers the DNS server in the host DNS resolver if enabled and unregisters it otherwise. The
+// registration is checked on every sync as the resolver may lose it, e.g. when the network interface is recreated.
+func (c *ConfigController) syncHostDNS(ctx context.Context, enabled bool) {
+	if c.host == nil || (!enabled && c.hostRegistered != nil && !*c.hostRegistered) {
+		return
+	}
+
+	registered, err := c.host.Registered(ctx)
+	if err != nil {
+		if enabled {
+			c.logHostError("Failed to check host DNS registration.", err)
+		} else {
+			// The host resolver is likely unavailable so there is nothing to unregister.
+			c.hostRegistered = &registered
+		}
+		return
+	}
+
+	switch {
+	case enabled && !registered:
+		if err = c.host.Register(ctx); err != nil {
+			c.logHostError("Failed to register embedded DNS server in the host DNS resolver.", err)
+			return
+		}
+		c.log.Info("Registered embedded DNS server in the host DNS resolver for the internal domain.")
+		registered = true
+	case !enabled && registered:
+		if err = c.host.Unregister(ctx); err != nil {
+			c.logHostError("Failed to unregister embedded DNS server from the host DNS resolver.", err)
+			return
+		}
+		c.log.Info("Unregistered embedded DNS server from the host DNS resolver.")
+		registered = false
+	}
+	c.hostRegistered = &registered
+	c.hostErr = ""
+}
+
+func (c *ConfigController) logHostError(msg string, err error) {
+	if err.Error() == c.hostErr {
+		return
+	}
+	c.hostErr = err.Error()
+	c.log.Error(msg, "err", err)
+}
+
+// syncMDNS enables or disables the multicast DNS responder. Enabling is retried on the next sync if it fails.
+func (c *ConfigController) syncMDNS(enabled bool) {
+	if c.mdns == nil || enabled == c.mdnsEnabled {
+		return
+	}
+	if err := c.mdns.SetEnabled(enabled); err != nil {
+		c.log.Error("Failed to enable multicast DNS responder.", "err", err)
+		return
+	}
+	c.mdnsEnabled = enabled
+}
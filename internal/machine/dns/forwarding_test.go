@@ -0,0 +1,119 @@
+package dns
+
+import (
+	"net"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Configure(t *testing.T) {
+	t.Parallel()
+
+	defaultUpstream := netip.MustParseAddrPort("1.1.1.1:53")
+	s, err := NewServer(netip.MustParseAddr("10.210.0.1"), netip.MustParsePrefix("10.210.0.0/24"),
+		NewClusterResolver(nil), []netip.AddrPort{defaultUpstream})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Configure(api.DNSSettings{
+		Zones: map[string][]string{
+			"example.com":      {"10.0.0.53"},
+			"corp.example.com": {"10.0.1.53", "10.0.1.54:5353"},
+		},
+	}))
+	cfg := s.forwarding.Load()
+	assert.Equal(t, []netip.AddrPort{defaultUpstream}, cfg.servers("google.com."))
+	assert.Equal(t, []netip.AddrPort{netip.MustParseAddrPort("10.0.0.53:53")}, cfg.servers("www.example.com."))
+	assert.Equal(t, []netip.AddrPort{
+		netip.MustParseAddrPort("10.0.1.53:53"),
+		netip.MustParseAddrPort("10.0.1.54:5353"),
+	}, cfg.servers("Git.Corp.Example.com."))
+	assert.Equal(t, []netip.AddrPort{defaultUpstream}, cfg.servers("notexample.com."))
+
+	require.NoError(t, s.Configure(api.DNSSettings{Upstreams: []string{"9.9.9.9"}}))
+	cfg = s.forwarding.Load()
+	assert.Equal(t, []netip.AddrPort{netip.MustParseAddrPort("9.9.9.9:53")}, cfg.servers("www.example.com."))
+}
+
+func TestServer_ForwardRequest_NegativeCache(t *testing.T) {
+	t.Parallel()
+
+	// Run an upstream DNS server that answers NXDOMAIN for missing.example.com and an A record for other names.
+	var queries atomic.Int32
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	upstream := &dns.Server{
+		PacketConn: pc,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			queries.Add(1)
+			resp := new(dns.Msg).SetReply(req)
+			if req.Question[0].Name == "missing.example.com." {
+				resp.SetRcode(req, dns.RcodeNameError)
+			} else {
+				rr, _ := dns.NewRR(req.Question[0].Name + " 60 IN A 192.0.2.1")
+				resp.Answer = append(resp.Answer, rr)
+			}
+			_ = w.WriteMsg(resp)
+		}),
+	}
+	go upstream.ActivateAndServe()
+	t.Cleanup(func() { _ = upstream.Shutdown() })
+
+	s, err := NewServer(netip.MustParseAddr("10.210.0.1"), netip.MustParsePrefix("10.210.0.0/24"),
+		NewClusterResolver(nil), []netip.AddrPort{})
+	require.NoError(t, err)
+	ttl := time.Minute
+	require.NoError(t, s.Configure(api.DNSSettings{
+		Upstreams:   []string{pc.LocalAddr().String()},
+		NegativeTTL: &ttl,
+	}))
+
+	query := func(name string) *dns.Msg {
+		req := new(dns.Msg).SetQuestion(name, dns.TypeA)
+		resp, err := s.forwardRequest(req, "udp")
+		require.NoError(t, err)
+		assert.Equal(t, req.Id, resp.Id)
+		return resp
+	}
+
+	assert.Equal(t, dns.RcodeNameError, query("missing.example.com.").Rcode)
+	assert.Equal(t, dns.RcodeNameError, query("missing.example.com.").Rcode)
+	assert.EqualValues(t, 1, queries.Load(), "negative answer should be cached")
+
+	assert.Len(t, query("www.example.com.").Answer, 1)
+	assert.Len(t, query("www.example.com.").Answer, 1)
+	assert.EqualValues(t, 3, queries.Load(), "positive answers should not be cached")
+
+	// Changing the configuration drops the cached answers.
+	require.NoError(t, s.Configure(api.DNSSettings{Upstreams: []string{pc.LocalAddr().String()}}))
+	query("missing.example.com.")
+	query("missing.example.com.")
+	assert.EqualValues(t, 5, queries.Load(), "negative caching should be disabled")
+}
+
+func TestNegativeCache_SOATTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := newNegativeCache()
+	c.now = func() time.Time { return now }
+
+	req := new(dns.Msg).SetQuestion("missing.example.com.", dns.TypeA)
+	resp := new(dns.Msg).SetRcode(req, dns.RcodeNameError)
+	soa, err := dns.NewRR("example.com. 300 IN SOA ns.example.com. admin.example.com. 1 7200 3600 1209600 5")
+	require.NoError(t, err)
+	resp.Ns = append(resp.Ns, soa)
+
+	c.put(req, resp, time.Minute)
+	assert.NotNil(t, c.get(req))
+
+	// The SOA minimum TTL (5s) is lower than the configured TTL.
+	now = now.Add(5 * time.Second)
+	assert.Nil(t, c.get(req))
+}
@@ -0,0 +1,118 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"os/exec"
+	"strings"
+)
+
+// HostResolver registers the embedded DNS server in the systemd-resolved of the machine as the DNS server for
+// the internal domain (split DNS). This allows processes running on the machine outside containers to resolve
+// service names while other names are still resolved by the DNS servers configured on the machine.
+type HostResolver struct {
+	// addr is the address the embedded DNS server is listening on.
+	addr netip.Addr
+	// linkName returns the name of the network interface with the address.
+	linkName func(addr netip.Addr) (string, error)
+	run      func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+func NewHostResolver(addr netip.Addr) *HostResolver {
+	return &HostResolver{
+		addr:     addr,
+		linkName: interfaceWithAddr,
+		run:      runCommand,
+	}
+}
+
+// Registered returns true if the DNS server is registered in systemd-resolved for the internal domain.
+func (r *HostResolver) Registered(ctx context.Context) (bool, error) {
+	link, err := r.linkName(r.addr)
+	if err != nil {
+		return false, err
+	}
+
+	out, err := r.run(ctx, "resolvectl", "dns", link)
+	if err != nil {
+		return false, err
+	}
+	if !strings.Contains(string(out), r.addr.String()) {
+		return false, nil
+	}
+	out, err = r.run(ctx, "resolvectl", "domain", link)
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(string(out), "~"+strings.TrimSuffix(InternalDomain, ".")), nil
+}
+
+// Register configures systemd-resolved to send queries for the internal domain to the DNS server. The configuration
+// is set on the network interface with the DNS server address and is lost when the interface is recreated, so it
+// should be re-applied when Registered returns false.
+func (r *HostResolver) Register(ctx context.Context) error {
+	link, err := r.linkName(r.addr)
+	if err != nil {
+		return err
+	}
+
+	// Route only queries for the internal domain to the DNS server.
+	commands := [][]string{
+		{"dns", link, r.addr.String()},
+		{"domain", link, "~" + strings.TrimSuffix(InternalDomain, ".")},
+		{"default-route", link, "false"},
+	}
+	for _, args := range commands {
+		if _, err = r.run(ctx, "resolvectl", args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unregister reverts the systemd-resolved configuration of the network interface with the DNS server address.
+func (r *HostResolver) Unregister(ctx context.Context) error {
+	link, err := r.linkName(r.addr)
+	if err != nil {
+		return err
+	}
+	_, err = r.run(ctx, "resolvectl", "revert", link)
+	return err
+}
+
+// interfaceWithAddr returns the name of the network interface the address is assigned to.
+func interfaceWithAddr(addr netip.Addr) (string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return "", fmt.Errorf("list network interfaces: %w", err)
+	}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, a := range addrs {
+			if ipNet, ok := a.(*net.IPNet); ok {
+				if ip, ok := netip.AddrFromSlice(ipNet.IP); ok && ip.Unmap() == addr {
+					return iface.Name, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("no network interface with address %s", addr)
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,139 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostResolver(t *testing.T) {
+	t.Parallel()
+
+	var commands []string
+	link := map[string]string{}
+	r := &HostResolver{
+		addr:     netip.MustParseAddr("10.210.0.1"),
+		linkName: func(netip.Addr) (string, error) { return "br-uncloud", nil },
+		run: func(_ context.Context, name string, args ...string) ([]byte, error) {
+			commands = append(commands, name+" "+strings.Join(args, " "))
+			switch {
+			case args[0] == "revert":
+				clear(link)
+			case len(args) == 2:
+				return []byte("Link 5 (br-uncloud): " + link[args[0]] + "\n"), nil
+			default:
+				link[args[0]] = args[2]
+			}
+			return nil, nil
+		},
+	}
+	ctx := context.Background()
+
+	registered, err := r.Registered(ctx)
+	require.NoError(t, err)
+	assert.False(t, registered)
+
+	require.NoError(t, r.Register(ctx))
+	registered, err = r.Registered(ctx)
+	require.NoError(t, err)
+	assert.True(t, registered)
+
+	require.NoError(t, r.Unregister(ctx))
+	registered, err = r.Registered(ctx)
+	require.NoError(t, err)
+	assert.False(t, registered)
+
+	assert.Equal(t, []string{
+		"resolvectl dns br-uncloud",
+		"resolvectl dns br-uncloud 10.210.0.1",
+		"resolvectl domain br-uncloud ~internal",
+		"resolvectl default-route br-uncloud false",
+		"resolvectl dns br-uncloud",
+		"resolvectl domain br-uncloud",
+		"resolvectl revert br-uncloud",
+		"resolvectl dns br-uncloud",
+	}, commands)
+}
+
+type fakeHostDNS struct {
+	registered bool
+	err        error
+	calls      int
+}
+
+func (h *fakeHostDNS) Registered(context.Context) (bool, error) {
+	h.calls++
+	return h.registered, h.err
+}
+
+func (h *fakeHostDNS) Register(context.Context) error {
+	h.registered = true
+	return nil
+}
+
+func (h *fakeHostDNS) Unregister(context.Context) error {
+	h.registered = false
+	return nil
+}
+
+type staticConfigSource struct {
+	config *api.DNSConfig
+}
+
+func (s *staticConfigSource) DNSConfig(context.Context) (*api.DNSConfig, error) {
+	return s.config, nil
+}
+
+func TestConfigController_HostDNS(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewServer(netip.MustParseAddr("10.210.0.1"), netip.MustParsePrefix("10.210.0.0/24"),
+		NewClusterResolver(nil), []netip.AddrPort{})
+	require.NoError(t, err)
+	enabled := true
+	source := &staticConfigSource{config: &api.DNSConfig{
+		Machines: map[string]api.DNSSettings{"m-1": {HostDNS: &enabled}},
+	}}
+	host := &fakeHostDNS{}
+	c := NewConfigController("m-1", source, s, host, nil)
+	ctx := context.Background()
+
+	c.sync(ctx)
+	assert.True(t, host.registered)
+
+	// The registration is restored if the host resolver loses it.
+	host.registered = false
+	c.sync(ctx)
+	assert.True(t, host.registered)
+
+	source.config = nil
+	c.sync(ctx)
+	assert.False(t, host.registered)
+
+	// The registration isn't checked again once it's known to be unregistered.
+	calls := host.calls
+	c.sync(ctx)
+	assert.Equal(t, calls, host.calls)
+}
+
+func TestConfigController_HostDNSUnavailable(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewServer(netip.MustParseAddr("10.210.0.1"), netip.MustParsePrefix("10.210.0.0/24"),
+		NewClusterResolver(nil), []netip.AddrPort{})
+	require.NoError(t, err)
+	host := &fakeHostDNS{err: errors.New("resolvectl: executable file not found in $PATH")}
+	c := NewConfigController("m-1", &staticConfigSource{}, s, host, nil)
+	ctx := context.Background()
+
+	// The host resolver is checked once on startup if host DNS is disabled.
+	c.sync(ctx)
+	c.sync(ctx)
+	assert.Equal(t, 1, host.calls)
+}
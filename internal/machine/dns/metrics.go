@@ -0,0 +1,44 @@
+package dns
+
+import (
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// zoneInternal labels queries for names in the cluster internal domain resolved by the DNS server.
+	zoneInternal = "internal"
+	// zoneExternal labels queries for other names forwarded to the upstream DNS servers.
+	zoneExternal = "external"
+)
+
+var (
+	queriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "uncloud",
+		Subsystem: "dns",
+		Name:      "queries_total",
+		Help:      "Number of DNS queries answered by the embedded DNS server by zone (internal or external) and rcode.",
+	}, []string{"zone", "rcode"})
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "uncloud",
+		Subsystem: "dns",
+		Name:      "query_duration_seconds",
+		Help:      "Time to answer DNS queries by zone (internal or external).",
+		Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 3},
+	}, []string{"zone"})
+	negativeCacheHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "uncloud",
+		Subsystem: "dns",
+		Name:      "negative_cache_hits_total",
+		Help:      "Number of external DNS queries answered from the negative cache.",
+	})
+)
+
+// rcodeLabel returns the metric label for the response code, e.g. NOERROR or NXDOMAIN.
+func rcodeLabel(rcode int) string {
+	if s, ok := dns.RcodeToString[rcode]; ok {
+		return s
+	}
+	return "UNKNOWN"
+}
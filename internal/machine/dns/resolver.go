@@ -37,35 +37,22 @@ func NewClusterResolver(store *store.Store) *ClusterResolver {
 
 // Run starts watching for container changes and updates DNS records accordingly.
 func (r *ClusterResolver) Run(ctx context.Context) error {
-	containers, changes, err := r.store.SubscribeContainers(ctx)
+	updates, err := r.store.WatchContainers(ctx)
 	if err != nil {
 		return fmt.Errorf("subscribe to container changes: %w", err)
 	}
 	r.log.Info("Subscribed to container changes in the cluster to keep DNS records updated.")
 
-	// TODO: implement machine membership check using Corrossion Admin client to filter available containers.
-	r.updateServiceIPs(containers)
-
-	for {
-		select {
-		case _, ok := <-changes:
-			if !ok {
-				return fmt.Errorf("containers subscription failed")
-			}
-			r.log.Debug("Cluster containers changed, updating DNS records.")
-
-			containers, err = r.store.ListContainers(ctx, store.ListOptions{})
-			if err != nil {
-				r.log.Error("Failed to list containers.", "err", err)
-				continue
-			}
-
-			// TODO: implement machine membership check using Corrossion Admin client to filter available containers.
-			r.updateServiceIPs(containers)
-		case <-ctx.Done():
-			return nil
-		}
+	// The first update is the current list of containers, the following ones are sent after containers change.
+	for containers := range updates {
+		r.log.Debug("Cluster containers changed, updating DNS records.")
+		// TODO: implement machine membership check using Corrossion Admin client to filter available containers.
+		r.updateServiceIPs(containers)
+	}
+	if ctx.Err() != nil {
+		return nil
 	}
+	return fmt.Errorf("containers subscription failed")
 }
 
 // updateServiceIPs processes container records and updates the serviceIPs map.
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/miekg/dns"
@@ -40,10 +41,15 @@ type Resolver interface {
 // Server is an embedded internal DNS server for service discovery and forwarding external queries
 // to upstream DNS servers.
 type Server struct {
-	listenAddr      netip.Addr
-	localSubnet     netip.Prefix
-	resolver        Resolver
-	upstreamServers []netip.AddrPort
+	listenAddr  netip.Addr
+	localSubnet netip.Prefix
+	resolver    Resolver
+	// defaultUpstreams are the upstream DNS servers used if the forwarding configuration doesn't specify them.
+	defaultUpstreams []netip.AddrPort
+	forwarding       atomic.Pointer[forwardingConfig]
+	negativeCache    *negativeCache
+	// queryLogSampleRate is the float64 bits of the fraction of queries to log.
+	queryLogSampleRate atomic.Uint64
 
 	udpServer        *dns.Server
 	tcpServer        *dns.Server
@@ -87,14 +93,18 @@ func NewServer(listenAddr netip.Addr, localSubnet netip.Prefix, resolver Resolve
 		}
 	}
 
-	return &Server{
+	s := &Server{
 		listenAddr:       listenAddr,
 		localSubnet:      localSubnet,
 		resolver:         resolver,
-		upstreamServers:  upstreams,
+		defaultUpstreams: upstreams,
+		negativeCache:    newNegativeCache(),
 		forwardSemaphore: make(chan struct{}, maxConcurrentForwards),
 		log:              slog.With("component", "dns-server"),
-	}, nil
+	}
+	s.forwarding.Store(&forwardingConfig{upstreams: upstreams})
+
+	return s, nil
 }
 
 // ListenAddr returns the address the DNS server is listening on.
@@ -120,14 +130,14 @@ func (s *Server) Run(ctx context.Context) error {
 	errCh := make(chan error, 1) // Buffer size 1 is for UDP server error only.
 
 	go func() {
-		s.log.Info("Starting DNS server on UDP port.", "addr", addr, "upstreams", s.upstreamServers)
+		s.log.Info("Starting DNS server on UDP port.", "addr", addr, "upstreams", s.forwarding.Load().upstreams)
 		if err := s.udpServer.ListenAndServe(); err != nil {
 			errCh <- fmt.Errorf("listen and serve on %s/udp: %w", addr, err)
 		}
 	}()
 
 	go func() {
-		s.log.Info("Starting DNS server on TCP port.", "addr", addr, "upstreams", s.upstreamServers)
+		s.log.Info("Starting DNS server on TCP port.", "addr", addr, "upstreams", s.forwarding.Load().upstreams)
 		if err := s.tcpServer.ListenAndServe(); err != nil {
 			// TCP server is not critical, so log the error and continue.
 			slog.Warn("Failed to listen and serve DNS server on TCP port. "+
@@ -187,21 +197,35 @@ func (s *Server) handleRequest(w dns.ResponseWriter, req *dns.Msg) {
 	log := s.log.With("name", q.Name, "type", dns.TypeToString[q.Qtype])
 	log.Debug("Received DNS query.")
 
+	start := time.Now()
+	zone := zoneInternal
+	var resp *dns.Msg
 	if !dns.IsSubDomain(InternalDomain, dns.CanonicalName(q.Name)) {
+		zone = zoneExternal
 		log.Debug("Forwarding non-internal DNS query to upstream DNS servers.")
 
 		// Use the same transport for the forwarded request as the original request.
-		resp, err := s.forwardRequest(req, w.LocalAddr().Network())
+		var err error
+		resp, err = s.forwardRequest(req, w.LocalAddr().Network())
 		if err != nil {
 			log.Error("Failed to forward DNS query.", "err", err)
 			resp = new(dns.Msg).SetRcode(req, dns.RcodeServerFailure)
 		}
-
-		s.reply(w, req, resp)
-		return
+	} else {
+		resp = s.resolveInternal(req, w.LocalAddr().Network(), log)
 	}
 
-	// Handle the query for the internal domain.
+	s.reply(w, req, resp)
+
+	duration := time.Since(start)
+	queriesTotal.WithLabelValues(zone, rcodeLabel(resp.Rcode)).Inc()
+	queryDuration.WithLabelValues(zone).Observe(duration.Seconds())
+	s.logQuery(w.RemoteAddr(), q, zone, resp, duration)
+}
+
+// resolveInternal answers the query for the internal domain.
+func (s *Server) resolveInternal(req *dns.Msg, proto string, log *slog.Logger) *dns.Msg {
+	q := req.Question[0]
 	resp := new(dns.Msg).SetReply(req)
 	resp.Authoritative = true
 	resp.RecursionAvailable = true
@@ -221,7 +245,7 @@ func (s *Server) handleRequest(w dns.ResponseWriter, req *dns.Msg) {
 
 	// Truncate the response if it exceeds the maximum size for the transport protocol.
 	maxSize := dns.MinMsgSize
-	if w.LocalAddr().Network() == "tcp" {
+	if proto == "tcp" {
 		maxSize = dns.MaxMsgSize
 	} else {
 		// Retrieve the UDP buffer size from the EDNS0 record if present.
@@ -233,7 +257,29 @@ func (s *Server) handleRequest(w dns.ResponseWriter, req *dns.Msg) {
 	}
 	resp.Truncate(maxSize)
 
-	s.reply(w, req, resp)
+	return resp
+}
+
+// logQuery logs the answered query if it's sampled according to the configured query log sample rate.
+func (s *Server) logQuery(client net.Addr, q dns.Question, zone string, resp *dns.Msg, duration time.Duration) {
+	rate := s.QueryLogSampleRate()
+	if rate <= 0 || (rate < 1 && rand.Float64() >= rate) {
+		return
+	}
+
+	answers := make([]string, 0, len(resp.Answer))
+	for _, rr := range resp.Answer {
+		// Strip the header (name, TTL, class, type) from the record to only log the data, e.g. the IP address.
+		answers = append(answers, strings.TrimPrefix(rr.String(), rr.Header().String()))
+	}
+	s.log.Info("DNS query.",
+		"client", client.String(),
+		"name", q.Name,
+		"type", dns.TypeToString[q.Qtype],
+		"zone", zone,
+		"rcode", rcodeLabel(resp.Rcode),
+		"answers", answers,
+		"duration", duration)
 }
 
 func (s *Server) reply(w dns.ResponseWriter, req *dns.Msg, resp *dns.Msg) error {
@@ -252,12 +298,19 @@ func (s *Server) reply(w dns.ResponseWriter, req *dns.Msg, resp *dns.Msg) error
 	return nil
 }
 
-// forwardRequest forwards a DNS query to system DNS servers
+// forwardRequest forwards a DNS query to the DNS servers configured for its zone or the upstream DNS servers.
 func (s *Server) forwardRequest(req *dns.Msg, proto string) (*dns.Msg, error) {
-	if len(s.upstreamServers) == 0 {
+	cfg := s.forwarding.Load()
+	servers := cfg.servers(req.Question[0].Name)
+	if len(servers) == 0 {
 		return nil, errors.New("no upstream DNS servers configured")
 	}
 
+	if resp := s.negativeCache.get(req); resp != nil {
+		negativeCacheHitsTotal.Inc()
+		return resp, nil
+	}
+
 	// Apply concurrency control for forwarded queries.
 	select {
 	case s.forwardSemaphore <- struct{}{}:
@@ -275,9 +328,12 @@ func (s *Server) forwardRequest(req *dns.Msg, proto string) (*dns.Msg, error) {
 	}
 
 	var lastErr error
-	for _, server := range s.upstreamServers {
+	for _, server := range servers {
 		resp, _, err := client.Exchange(req, server.String())
 		if err == nil {
+			if cfg.negativeTTL > 0 {
+				s.negativeCache.put(req, resp, cfg.negativeTTL)
+			}
 			return resp, nil
 		}
 		lastErr = err
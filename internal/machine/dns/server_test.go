@@ -0,0 +1,79 @@
+package dns
+
+import (
+	"bytes"
+	"log/slog"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingWriter is a dns.ResponseWriter that records the written response.
+type recordingWriter struct {
+	dns.ResponseWriter
+	resp *dns.Msg
+}
+
+func (w *recordingWriter) LocalAddr() net.Addr {
+	return &net.UDPAddr{IP: net.ParseIP("10.210.0.1"), Port: Port}
+}
+
+func (w *recordingWriter) RemoteAddr() net.Addr {
+	return &net.UDPAddr{IP: net.ParseIP("10.210.0.2"), Port: 40000}
+}
+
+func (w *recordingWriter) WriteMsg(m *dns.Msg) error {
+	w.resp = m
+	return nil
+}
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	var m dto.Metric
+	require.NoError(t, c.Write(&m))
+	return m.GetCounter().GetValue()
+}
+
+func TestServer_HandleRequest_QueryLogAndMetrics(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewServer(netip.MustParseAddr("10.210.0.1"), netip.MustParsePrefix("10.210.0.0/24"),
+		NewClusterResolver(nil), []netip.AddrPort{})
+	require.NoError(t, err)
+	var logs bytes.Buffer
+	s.log = slog.New(slog.NewTextHandler(&logs, nil))
+
+	query := func() {
+		w := &recordingWriter{}
+		s.handleRequest(w, new(dns.Msg).SetQuestion("missing.internal.", dns.TypeA))
+		require.NotNil(t, w.resp)
+		assert.Equal(t, dns.RcodeNameError, w.resp.Rcode)
+	}
+	nxdomain := queriesTotal.WithLabelValues(zoneInternal, "NXDOMAIN")
+	before := counterValue(t, nxdomain)
+
+	// Query logging is disabled by default.
+	query()
+	assert.NotContains(t, logs.String(), "DNS query.")
+
+	rate := 1.0
+	require.NoError(t, s.Configure(api.DNSSettings{QueryLogSampleRate: &rate}))
+	logs.Reset()
+	query()
+	assert.Contains(t, logs.String(), `msg="DNS query." client=10.210.0.2:40000 name=missing.internal. type=A `+
+		`zone=internal rcode=NXDOMAIN`)
+
+	rate = 0
+	require.NoError(t, s.Configure(api.DNSSettings{QueryLogSampleRate: &rate}))
+	logs.Reset()
+	query()
+	assert.NotContains(t, logs.String(), "DNS query.")
+
+	assert.Equal(t, before+3, counterValue(t, nxdomain))
+}
@@ -0,0 +1,6 @@
+package docker
+
+import "github.com/psviderski/uncloud/pkg/api"
+
+// applyBandwidthLimits is a no-op on Darwin.
+func (c *Controller) applyBandwidthLimits(_ []api.ServiceContainer) {}
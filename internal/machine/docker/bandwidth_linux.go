@@ -0,0 +1,140 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// minPoliceBurst is the minimum burst size (in bytes) of the bandwidth policer. It must be large enough to fit
+	// several full-size packets, otherwise TCP throughput drops far below the configured rate.
+	minPoliceBurst = 64 * 1024
+	// policeFilterPriority is the priority of the tc filter that polices the container traffic.
+	policeFilterPriority = 1
+)
+
+// applyBandwidthLimits limits the egress bandwidth of the running containers with EgressBandwidth set. The traffic
+// sent by a container is received by the host side of its veth interface so it's policed on the ingress qdisc
+// of that interface. Containers that already have the limit applied are skipped.
+func (c *Controller) applyBandwidthLimits(containers []api.ServiceContainer) {
+	running := make(map[string]struct{}, len(containers))
+	for _, ctr := range containers {
+		rate := ctr.ServiceSpec.Container.Resources.EgressBandwidth
+		if rate == 0 || ctr.State == nil || !ctr.State.Running || ctr.State.Pid == 0 {
+			continue
+		}
+		running[ctr.ID] = struct{}{}
+
+		// The veth interface is recreated when the container restarts so the limit must be reapplied for a new PID.
+		applied := appliedBandwidth{pid: ctr.State.Pid, rate: rate}
+		if c.bandwidthLimits[ctr.ID] == applied {
+			continue
+		}
+
+		ip := ctr.UncloudNetworkIP()
+		if !ip.IsValid() {
+			continue
+		}
+		if err := limitEgressBandwidth(ctr.State.Pid, ip, rate); err != nil {
+			slog.Error("Failed to apply egress bandwidth limit to container.",
+				"id", ctr.ID, "name", ctr.Name, "rate", rate, "err", err)
+			continue
+		}
+		c.bandwidthLimits[ctr.ID] = applied
+		slog.Info("Applied egress bandwidth limit to container.", "id", ctr.ID, "name", ctr.Name,
+			"rate_bytes_per_sec", rate)
+	}
+
+	// Forget the containers that are no longer running.
+	for id := range c.bandwidthLimits {
+		if _, ok := running[id]; !ok {
+			delete(c.bandwidthLimits, id)
+		}
+	}
+}
+
+// limitEgressBandwidth polices the traffic sent by the container with the given PID from the IP address in its network
+// namespace to the rate (in bytes per second).
+func limitEgressBandwidth(pid int, ip netip.Addr, rate int64) error {
+	link, err := hostVeth(pid, ip)
+	if err != nil {
+		return err
+	}
+	index := link.Attrs().Index
+
+	// Replace the ingress qdisc which removes all its filters that might have been added before.
+	ingress := &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+	if err = netlink.QdiscReplace(ingress); err != nil {
+		return fmt.Errorf("replace ingress qdisc on '%s': %w", link.Attrs().Name, err)
+	}
+
+	police := netlink.NewPoliceAction()
+	police.Rate = uint32(rate)
+	police.Burst = uint32(max(rate/10, minPoliceBurst))
+	police.ExceedAction = netlink.TC_POLICE_SHOT
+	filter := &netlink.MatchAll{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: index,
+			Parent:    ingress.Handle,
+			Priority:  policeFilterPriority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{police},
+	}
+	if err = netlink.FilterReplace(filter); err != nil {
+		return fmt.Errorf("replace police filter on '%s': %w", link.Attrs().Name, err)
+	}
+
+	return nil
+}
+
+// hostVeth returns the host side of the veth pair whose container side has the IP address in the network namespace
+// of the process with the given PID.
+func hostVeth(pid int, ip netip.Addr) (netlink.Link, error) {
+	ns, err := netns.GetFromPid(pid)
+	if err != nil {
+		return nil, fmt.Errorf("get network namespace of process %d: %w", pid, err)
+	}
+	defer ns.Close()
+
+	handle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return nil, fmt.Errorf("create netlink handle in network namespace of process %d: %w", pid, err)
+	}
+	defer handle.Close()
+
+	links, err := handle.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("list links in network namespace of process %d: %w", pid, err)
+	}
+	for _, l := range links {
+		if l.Type() != "veth" {
+			continue
+		}
+		addrs, err := handle.AddrList(l, netlink.FAMILY_V4)
+		if err != nil {
+			return nil, fmt.Errorf("list addresses of link '%s': %w", l.Attrs().Name, err)
+		}
+		for _, a := range addrs {
+			if addr, ok := netip.AddrFromSlice(a.IP); ok && addr.Unmap() == ip {
+				// The parent index of the container side of the veth pair is the index of the host side.
+				return netlink.LinkByIndex(l.Attrs().ParentIndex)
+			}
+		}
+	}
+
+	return nil, errors.New("veth interface with the container IP not found")
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/distribution/reference"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
@@ -18,6 +20,7 @@ import (
 	"github.com/psviderski/uncloud/internal/docker"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/pkg/api"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,21 +30,24 @@ import (
 // TODO: it doesn't seem there is much value in having this intermediate Docker client.
 // Consider merging it into the main pkg/client.
 type Client struct {
-	conn       *grpc.ClientConn
+	conn       grpc.ClientConnInterface
 	GRPCClient pb.DockerClient
 }
 
 // NewClient creates a new Docker gRPC client with the provided gRPC connection.
-func NewClient(conn *grpc.ClientConn) *Client {
+func NewClient(conn grpc.ClientConnInterface) *Client {
 	return &Client{
 		conn:       conn,
 		GRPCClient: pb.NewDockerClient(conn),
 	}
 }
 
-// Close closes the gRPC connection.
+// Close closes the gRPC connection if the client owns it.
 func (c *Client) Close() error {
-	return c.conn.Close()
+	if closer, ok := c.conn.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
 }
 
 // CreateContainer creates a new container based on the given configuration.
@@ -245,6 +251,72 @@ func (c *Client) PullImage(ctx context.Context, image string, opts PullOptions)
 	return ch, nil
 }
 
+// loadImageChunkSize is the size of the image tarball chunks streamed to the machine. It must be well below
+// the default 4MB gRPC message size limit.
+const loadImageChunkSize = 1 << 20
+
+// LoadImage streams an image tarball in the 'docker save' format read from r to the machine and loads it into
+// Docker. It returns a channel with the load progress messages that is closed when the load completes or fails.
+func (c *Client) LoadImage(ctx context.Context, r io.Reader) (<-chan docker.PullPushImageMessage, error) {
+	// Reading the tarball fails the stream using the context cancellation to not load a partial tarball.
+	ctx, cancel := context.WithCancelCause(ctx)
+	stream, err := c.GRPCClient.LoadImage(ctx)
+	if err != nil {
+		cancel(nil)
+		return nil, err
+	}
+
+	go func() {
+		buf := make([]byte, loadImageChunkSize)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				// The send error is returned by stream.Recv.
+				if stream.Send(&pb.LoadImageRequest{Chunk: buf[:n]}) != nil {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				stream.CloseSend()
+				return
+			}
+			if err != nil {
+				cancel(fmt.Errorf("read image tarball: %w", err))
+				return
+			}
+		}
+	}()
+
+	ch := make(chan docker.PullPushImageMessage)
+	go func() {
+		defer close(ch)
+		defer cancel(nil)
+
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+					err = cause
+				}
+				ch <- docker.PullPushImageMessage{Err: err}
+				return
+			}
+
+			var jm jsonmessage.JSONMessage
+			if err = json.Unmarshal(msg.Message, &jm); err != nil {
+				ch <- docker.PullPushImageMessage{Err: fmt.Errorf("unmarshal JSON message: %w", err)}
+				return
+			}
+			ch <- docker.PullPushImageMessage{Message: jm}
+		}
+	}()
+
+	return ch, nil
+}
+
 // InspectImage returns the image information for the given image ID. The request may be sent to multiple machines.
 func (c *Client) InspectImage(ctx context.Context, id string) ([]api.MachineImage, error) {
 	resp, err := c.GRPCClient.InspectImage(ctx, &pb.InspectImageRequest{Id: id})
@@ -281,6 +353,49 @@ func (c *Client) InspectImage(ctx context.Context, id string) ([]api.MachineImag
 	return images, nil
 }
 
+// InspectImages returns the image information for each of the given image IDs in one request per machine.
+// The request may be sent to multiple machines. The returned slice is aligned with ids and contains the result
+// from each machine for the image. An image that couldn't be inspected on a machine, for example, because it doesn't
+// exist, has the error and its status in the metadata of the machine result.
+// The IDs of the machines that don't support batch requests are returned separately and their results are omitted.
+func (c *Client) InspectImages(ctx context.Context, ids []string) ([][]api.MachineImage, []string, error) {
+	resp, err := c.GRPCClient.InspectImages(ctx, &pb.InspectImagesRequest{Ids: ids})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	images := make([][]api.MachineImage, len(ids))
+	var unsupported []string
+	for _, msg := range resp.Messages {
+		if isUnimplemented(msg.Metadata) {
+			unsupported = append(unsupported, msg.Metadata.MachineId)
+			continue
+		}
+		if msg.Metadata != nil && msg.Metadata.Error != "" {
+			for i := range ids {
+				images[i] = append(images[i], api.MachineImage{Metadata: msg.Metadata})
+			}
+			continue
+		}
+		if len(msg.Images) != len(ids) {
+			return nil, nil, fmt.Errorf("unexpected number of inspected images from machine '%s': %d, expected %d",
+				msg.Metadata.GetMachineName(), len(msg.Images), len(ids))
+		}
+
+		for i, img := range msg.Images {
+			mi := api.MachineImage{Metadata: batchImageMetadata(msg.Metadata, img.Status)}
+			if img.Status == nil {
+				if err = json.Unmarshal(img.Image, &mi.Image); err != nil {
+					return nil, nil, fmt.Errorf("unmarshal image: %w", err)
+				}
+			}
+			images[i] = append(images[i], mi)
+		}
+	}
+
+	return images, unsupported, nil
+}
+
 // InspectRemoteImage returns the image metadata for an image in a remote registry using the machine's Docker auth
 // credentials if necessary. If the response from a machine doesn't contain an error, the api.RemoteImage will either
 // contain an IndexManifest or an ImageManifest.
@@ -353,6 +468,138 @@ func parseRemoteImageMessage(msg *pb.RemoteImage) (api.MachineRemoteImage, error
 	return mri, nil
 }
 
+// RemoveImage removes the image on the machines the request is sent to and returns the result for each machine.
+func (c *Client) RemoveImage(
+	ctx context.Context, img string, opts image.RemoveOptions,
+) ([]api.MachineImageResult, error) {
+	optsBytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("marshal options: %w", err)
+	}
+
+	resp, err := c.GRPCClient.RemoveImage(ctx, &pb.RemoveImageRequest{Image: img, Options: optsBytes})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]api.MachineImageResult, len(resp.Messages))
+	for i, msg := range resp.Messages {
+		results[i] = api.NewMachineImageResult(msg.Metadata)
+		if !results[i].Succeeded() || len(msg.Deleted) == 0 {
+			continue
+		}
+
+		if err = json.Unmarshal(msg.Deleted, &results[i].Deleted); err != nil {
+			return nil, fmt.Errorf("unmarshal deleted images: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+// RemoveImages removes the images on the machines the request is sent to in one request per machine. The returned
+// slice is aligned with imgs and contains the result from each machine for the image.
+// The IDs of the machines that don't support batch requests are returned separately and their results are omitted.
+func (c *Client) RemoveImages(
+	ctx context.Context, imgs []string, opts image.RemoveOptions,
+) ([][]api.MachineImageResult, []string, error) {
+	optsBytes, err := json.Marshal(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal options: %w", err)
+	}
+
+	resp, err := c.GRPCClient.RemoveImages(ctx, &pb.RemoveImagesRequest{Images: imgs, Options: optsBytes})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make([][]api.MachineImageResult, len(imgs))
+	var unsupported []string
+	for _, msg := range resp.Messages {
+		if isUnimplemented(msg.Metadata) {
+			unsupported = append(unsupported, msg.Metadata.MachineId)
+			continue
+		}
+		if msg.Metadata != nil && msg.Metadata.Error != "" {
+			for i := range imgs {
+				results[i] = append(results[i], api.NewMachineImageResult(msg.Metadata))
+			}
+			continue
+		}
+		if len(msg.Images) != len(imgs) {
+			return nil, nil, fmt.Errorf("unexpected number of removed images from machine '%s': %d, expected %d",
+				msg.Metadata.GetMachineName(), len(msg.Images), len(imgs))
+		}
+
+		for i, img := range msg.Images {
+			r := api.NewMachineImageResult(batchImageMetadata(msg.Metadata, img.Status))
+			if r.Succeeded() && len(img.Deleted) > 0 {
+				if err = json.Unmarshal(img.Deleted, &r.Deleted); err != nil {
+					return nil, nil, fmt.Errorf("unmarshal deleted images: %w", err)
+				}
+			}
+			results[i] = append(results[i], r)
+		}
+	}
+
+	return results, unsupported, nil
+}
+
+// batchImageMetadata returns the metadata of the result for an image in a batch response from a machine.
+// The error status of the image is reported as the error of the machine.
+func batchImageMetadata(md *pb.Metadata, st *rpcstatus.Status) *pb.Metadata {
+	if st == nil {
+		return md
+	}
+	return &pb.Metadata{
+		MachineId:   md.GetMachineId(),
+		MachineName: md.GetMachineName(),
+		Error:       st.Message,
+		Status:      st,
+	}
+}
+
+// isUnimplemented returns true if the machine doesn't support the requested method, e.g. it runs an older version.
+func isUnimplemented(md *pb.Metadata) bool {
+	return md != nil && md.Status != nil && codes.Code(md.Status.Code) == codes.Unimplemented
+}
+
+// PruneImages removes unused images matching the filters on the machines the request is sent to and returns
+// the result for each machine. Images matching any of the references to keep aren't removed.
+func (c *Client) PruneImages(
+	ctx context.Context, pruneFilters filters.Args, keep []string,
+) ([]api.MachineImageResult, error) {
+	filtersJSON, err := filters.ToJSON(pruneFilters)
+	if err != nil {
+		return nil, fmt.Errorf("marshal filters: %w", err)
+	}
+
+	resp, err := c.GRPCClient.PruneImages(ctx, &pb.PruneImagesRequest{
+		Filters: []byte(filtersJSON),
+		Keep:    keep,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]api.MachineImageResult, len(resp.Messages))
+	for i, msg := range resp.Messages {
+		results[i] = api.NewMachineImageResult(msg.Metadata)
+		if !results[i].Succeeded() {
+			continue
+		}
+
+		var report image.PruneReport
+		if err = json.Unmarshal(msg.Report, &report); err != nil {
+			return nil, fmt.Errorf("unmarshal prune report: %w", err)
+		}
+		results[i].Deleted = report.ImagesDeleted
+		results[i].SpaceReclaimed = report.SpaceReclaimed
+	}
+
+	return results, nil
+}
+
 // CreateVolume creates a new volume with the given options.
 func (c *Client) CreateVolume(ctx context.Context, opts volume.CreateOptions) (volume.Volume, error) {
 	var vol volume.Volume
@@ -420,6 +667,66 @@ func (c *Client) RemoveVolume(ctx context.Context, id string, force bool) error
 	return err
 }
 
+// CreateVolumeSnapshot creates an instant snapshot of a local volume stored on a btrfs or ZFS filesystem.
+func (c *Client) CreateVolumeSnapshot(ctx context.Context, volumeName string) (api.VolumeSnapshot, error) {
+	resp, err := c.GRPCClient.CreateVolumeSnapshot(ctx, &pb.CreateVolumeSnapshotRequest{Volume: volumeName})
+	if err != nil {
+		return api.VolumeSnapshot{}, volumeSnapshotError(err)
+	}
+	return volumeSnapshotFromProto(resp), nil
+}
+
+type MachineVolumeSnapshots struct {
+	Metadata  *pb.Metadata
+	Snapshots []api.VolumeSnapshot
+}
+
+// ListVolumeSnapshots returns the snapshots of the volume or all volumes if the name is empty on the machines
+// the request is sent to.
+func (c *Client) ListVolumeSnapshots(ctx context.Context, volumeName string) ([]MachineVolumeSnapshots, error) {
+	resp, err := c.GRPCClient.ListVolumeSnapshots(ctx, &pb.ListVolumeSnapshotsRequest{Volume: volumeName})
+	if err != nil {
+		return nil, volumeSnapshotError(err)
+	}
+
+	machineSnapshots := make([]MachineVolumeSnapshots, len(resp.Messages))
+	for i, msg := range resp.Messages {
+		machineSnapshots[i].Metadata = msg.Metadata
+		for _, snap := range msg.Snapshots {
+			machineSnapshots[i].Snapshots = append(machineSnapshots[i].Snapshots, volumeSnapshotFromProto(snap))
+		}
+	}
+	return machineSnapshots, nil
+}
+
+// RestoreVolumeSnapshot replaces the data of the volume with the data from its snapshot.
+func (c *Client) RestoreVolumeSnapshot(ctx context.Context, volumeName, id string) error {
+	_, err := c.GRPCClient.RestoreVolumeSnapshot(ctx, &pb.VolumeSnapshotRequest{Volume: volumeName, Id: id})
+	return volumeSnapshotError(err)
+}
+
+// RemoveVolumeSnapshot removes the snapshot of the volume.
+func (c *Client) RemoveVolumeSnapshot(ctx context.Context, volumeName, id string) error {
+	_, err := c.GRPCClient.RemoveVolumeSnapshot(ctx, &pb.VolumeSnapshotRequest{Volume: volumeName, Id: id})
+	return volumeSnapshotError(err)
+}
+
+func volumeSnapshotError(err error) error {
+	if err != nil && status.Convert(err).Code() == codes.NotFound {
+		return errdefs.NotFound(err)
+	}
+	return err
+}
+
+func volumeSnapshotFromProto(snap *pb.VolumeSnapshot) api.VolumeSnapshot {
+	return api.VolumeSnapshot{
+		ID:      snap.Id,
+		Volume:  snap.Volume,
+		Backend: snap.Backend,
+		Created: snap.Created.AsTime(),
+	}
+}
+
 // InspectServiceContainer returns the container information and service specification that was used to create the
 // container with the given ID.
 func (c *Client) InspectServiceContainer(ctx context.Context, id string) (api.ServiceContainer, error) {
@@ -0,0 +1,171 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type mockLoadImageClient struct {
+	pb.DockerClient
+	stream *mockLoadImageStream
+}
+
+func (m *mockLoadImageClient) LoadImage(
+	ctx context.Context, _ ...grpc.CallOption,
+) (grpc.BidiStreamingClient[pb.LoadImageRequest, pb.JSONMessage], error) {
+	m.stream.ctx = ctx
+	return m.stream, nil
+}
+
+// mockLoadImageStream collects the sent chunks and replies with the messages after the client closes the stream.
+type mockLoadImageStream struct {
+	grpc.ClientStream
+	ctx      context.Context
+	received bytes.Buffer
+	closed   chan struct{}
+	messages []string
+}
+
+func (s *mockLoadImageStream) Send(req *pb.LoadImageRequest) error {
+	s.received.Write(req.Chunk)
+	return nil
+}
+
+func (s *mockLoadImageStream) CloseSend() error {
+	close(s.closed)
+	return nil
+}
+
+func (s *mockLoadImageStream) Recv() (*pb.JSONMessage, error) {
+	select {
+	case <-s.closed:
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+	if len(s.messages) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return &pb.JSONMessage{Message: []byte(msg)}, nil
+}
+
+func TestClient_LoadImage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams tarball in chunks", func(t *testing.T) {
+		t.Parallel()
+
+		tarball := bytes.Repeat([]byte("x"), 2*loadImageChunkSize+10)
+		stream := &mockLoadImageStream{
+			closed:   make(chan struct{}),
+			messages: []string{`{"stream":"Loaded image: app:1.0\n"}`},
+		}
+		c := &Client{GRPCClient: &mockLoadImageClient{stream: stream}}
+
+		ch, err := c.LoadImage(context.Background(), bytes.NewReader(tarball))
+		require.NoError(t, err)
+
+		var streams []string
+		for msg := range ch {
+			require.NoError(t, msg.Err)
+			streams = append(streams, msg.Message.Stream)
+		}
+		assert.Equal(t, []string{"Loaded image: app:1.0\n"}, streams)
+		assert.Equal(t, tarball, stream.received.Bytes())
+	})
+
+	t.Run("read error aborts stream", func(t *testing.T) {
+		t.Parallel()
+
+		readErr := errors.New("disk failure")
+		stream := &mockLoadImageStream{closed: make(chan struct{})}
+		c := &Client{GRPCClient: &mockLoadImageClient{stream: stream}}
+
+		ch, err := c.LoadImage(context.Background(), iotest.ErrReader(readErr))
+		require.NoError(t, err)
+
+		msg := <-ch
+		assert.ErrorIs(t, msg.Err, readErr)
+		_, ok := <-ch
+		assert.False(t, ok)
+
+		select {
+		case <-stream.closed:
+			t.Fatal("stream must not be closed gracefully after a read error")
+		default:
+		}
+	})
+}
+
+type mockRemoveImagesClient struct {
+	pb.DockerClient
+	resp *pb.RemoveImagesResponse
+}
+
+func (m *mockRemoveImagesClient) RemoveImages(
+	context.Context, *pb.RemoveImagesRequest, ...grpc.CallOption,
+) (*pb.RemoveImagesResponse, error) {
+	return m.resp, nil
+}
+
+func TestClient_RemoveImages(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{GRPCClient: &mockRemoveImagesClient{resp: &pb.RemoveImagesResponse{
+		Messages: []*pb.RemovedImagesBatch{
+			{
+				Metadata: &pb.Metadata{MachineId: "id1", MachineName: "machine-1"},
+				Images: []*pb.ImageRemoval{
+					{Image: "app:1", Deleted: []byte(`[{"Untagged":"app:1"}]`)},
+					{Image: "app:2", Status: &rpcstatus.Status{Code: int32(codes.NotFound), Message: "no such image"}},
+				},
+			},
+			{
+				Metadata: &pb.Metadata{
+					MachineId: "id2", MachineName: "machine-2", Error: "unreachable",
+					Status: &rpcstatus.Status{Code: int32(codes.Unavailable), Message: "unreachable"},
+				},
+			},
+			{
+				Metadata: &pb.Metadata{
+					MachineId: "id3", MachineName: "machine-3", Error: "unknown method",
+					Status: &rpcstatus.Status{Code: int32(codes.Unimplemented), Message: "unknown method"},
+				},
+			},
+		},
+	}}}
+
+	results, unsupported, err := c.RemoveImages(context.Background(), []string{"app:1", "app:2"}, image.RemoveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id3"}, unsupported)
+	require.Len(t, results, 2)
+
+	require.Len(t, results[0], 2)
+	assert.Equal(t, "machine-1", results[0][0].MachineName)
+	assert.Equal(t, api.ImageOperationSucceeded, results[0][0].Status)
+	require.Len(t, results[0][0].Deleted, 1)
+	assert.Equal(t, "app:1", results[0][0].Deleted[0].Untagged)
+	assert.Equal(t, "machine-2", results[0][1].MachineName)
+	assert.EqualError(t, results[0][1].Err, "unreachable")
+
+	require.Len(t, results[1], 2)
+	assert.Equal(t, "machine-1", results[1][0].MachineName)
+	assert.Equal(t, api.ImageOperationNotFound, results[1][0].Status)
+	assert.EqualError(t, results[1][0].Err, "no such image")
+	assert.Equal(t, "machine-2", results[1][1].MachineName)
+	assert.False(t, results[1][1].Succeeded())
+}
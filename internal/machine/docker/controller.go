@@ -19,7 +19,7 @@ const (
 	// EventsDebounceInterval defines how long to wait before processing the next Docker event. Multiple events
 	// occurring within this window will be processed together as a single event to prevent system overload.
 	EventsDebounceInterval = 100 * time.Millisecond
-	// SyncInterval defines a regular interval to sync containers to the cluster store.
+	// SyncInterval defines the default regular interval to sync containers to the cluster store.
 	SyncInterval = 30 * time.Second
 )
 
@@ -30,14 +30,26 @@ type Controller struct {
 	client    *client.Client
 	service   *Service
 	store     *store.Store
+	// syncInterval is the regular interval to sync containers to the cluster store.
+	syncInterval time.Duration
+	// bandwidthLimits tracks the egress bandwidth limits applied to the running containers by container ID.
+	bandwidthLimits map[string]appliedBandwidth
 }
 
-func NewController(machineID string, service *Service, store *store.Store) *Controller {
+// appliedBandwidth is the egress bandwidth limit applied to the container process with the PID.
+type appliedBandwidth struct {
+	pid  int
+	rate int64
+}
+
+func NewController(machineID string, service *Service, store *store.Store, syncInterval time.Duration) *Controller {
 	return &Controller{
-		machineID: machineID,
-		client:    service.Client,
-		service:   service,
-		store:     store,
+		machineID:       machineID,
+		client:          service.Client,
+		service:         service,
+		store:           store,
+		syncInterval:    syncInterval,
+		bandwidthLimits: make(map[string]appliedBandwidth),
 	}
 }
 
@@ -94,7 +106,7 @@ func (c *Controller) WatchAndSyncContainers(ctx context.Context) error {
 		debouncer   *time.Timer
 		debouncerCh = make(chan events.Message)
 		// ticker is used to trigger a regular sync of containers to the cluster store as a fallback.
-		ticker = time.NewTicker(SyncInterval)
+		ticker = time.NewTicker(c.syncInterval)
 	)
 	defer ticker.Stop()
 
@@ -134,7 +146,7 @@ func (c *Controller) WatchAndSyncContainers(ctx context.Context) error {
 			}
 		case <-ticker.C:
 			slog.Debug("Syncing containers to cluster store triggered by a regular interval.",
-				"interval", SyncInterval)
+				"interval", c.syncInterval)
 			if err := c.syncContainersToStore(ctx); err != nil {
 				return fmt.Errorf("sync containers to cluster store: %w", err)
 			}
@@ -162,6 +174,9 @@ func (c *Controller) syncContainersToStore(ctx context.Context) error {
 
 	// Sync both regular and one-off hook containers to the store.
 	containers := append(result.Containers, result.HookContainers...)
+	// Containers are synced on every container event so this is also a good place to apply bandwidth limits
+	// to the started containers.
+	c.applyBandwidthLimits(containers)
 
 	// Delete containers from the store that are no longer present in the Docker daemon.
 	var deleteIDs []string
@@ -178,18 +193,10 @@ func (c *Controller) syncContainersToStore(ctx context.Context) error {
 		}
 	}
 
-	var storeErr error
-	if len(deleteIDs) > 0 {
-		if err = c.store.DeleteContainers(ctx, store.DeleteOptions{IDs: deleteIDs}); err != nil {
-			storeErr = fmt.Errorf("delete containers from store: %w", err)
-		}
+	// Delete the missing containers and create or update the current Docker containers in a single transaction.
+	// The store only writes the records that have changed.
+	if err = c.store.SyncContainers(ctx, c.machineID, containers, deleteIDs); err != nil {
+		return fmt.Errorf("sync containers to store: %w", err)
 	}
-
-	// Create or update the current Docker containers in the store.
-	for _, ctr := range containers {
-		if err = c.store.CreateOrUpdateContainer(ctx, ctr, c.machineID); err != nil {
-			storeErr = errors.Join(storeErr, fmt.Errorf("create or update container '%s': %w", ctr.ID, err))
-		}
-	}
-	return storeErr
+	return nil
 }
@@ -20,23 +20,33 @@ import (
 )
 
 // EnsureUncloudNetwork creates the Docker bridge network NetworkName with the provided machine subnet
-// if it doesn't exist. If the network exists but has a different subnet, it removes and recreates the network.
-// It also configures iptables to allow container access from the WireGuard network.
+// if it doesn't exist. If the network exists but has a different subnet, it removes and recreates the network
+// moving the attached containers to the new network. It also configures iptables to allow container access
+// from the WireGuard network.
 func (c *Controller) EnsureUncloudNetwork(ctx context.Context, subnet netip.Prefix, dnsServer netip.Addr) error {
 	// Ensure the Docker network 'uncloud' is created with the correct subnet.
 	needsCreation := false
+	// Containers that were attached to the network with the old subnet and should be reattached to the new one.
+	var reattach []string
 	nw, err := c.client.NetworkInspect(ctx, NetworkName, dnetwork.InspectOptions{})
 	if err != nil {
 		if !errdefs.IsNotFound(err) {
 			return fmt.Errorf("inspect Docker network '%s': %w", NetworkName, err)
 		}
 		needsCreation = true
-	} else if nw.IPAM.Config[0].Subnet != subnet.String() {
-		// Remove the Docker network if the subnet is different.
-		// It could be a leftover from a previous incomplete cleanup.
-		slog.Info(
-			"Removing Docker network with old subnet.", "name", NetworkName, "subnet", nw.IPAM.Config[0].Subnet,
-		)
+	} else if oldSubnet := nw.IPAM.Config[0].Subnet; oldSubnet != subnet.String() {
+		// Remove the Docker network if the subnet is different. The machine subnet could have been reallocated
+		// after changing the cluster network or it could be a leftover from a previous incomplete cleanup.
+		slog.Info("Removing Docker network with old subnet.", "name", NetworkName, "subnet", oldSubnet)
+		if reattach, err = c.disconnectAllContainers(ctx); err != nil {
+			return err
+		}
+		if prefix, pErr := netip.ParsePrefix(oldSubnet); pErr == nil {
+			if err = cleanupIptables("br-"+nw.ID[:12], prefix); err != nil {
+				slog.Warn("Failed to clean up iptables rules for Docker network with old subnet.",
+					"subnet", oldSubnet, "err", err)
+			}
+		}
 		if err = c.client.NetworkRemove(ctx, NetworkName); err != nil {
 			// It can still fail if the network is in use by a container. Leave it to the user to resolve the issue.
 			return fmt.Errorf("remove Docker network '%s': %w", NetworkName, err)
@@ -53,6 +63,9 @@ func (c *Controller) EnsureUncloudNetwork(ctx context.Context, subnet netip.Pref
 					Config: []dnetwork.IPAMConfig{
 						{
 							Subnet: subnet.String(),
+							// Allocate dynamic container IPs only from the lower half of the subnet. The upper half
+							// is reserved for fixed IPs claimed by services with the x-internal-ip extension.
+							IPRange: network.DynamicIPRange(subnet).String(),
 						},
 					},
 				},
@@ -73,6 +86,16 @@ func (c *Controller) EnsureUncloudNetwork(ctx context.Context, subnet netip.Pref
 		if nw, err = c.client.NetworkInspect(ctx, NetworkName, dnetwork.InspectOptions{}); err != nil {
 			return fmt.Errorf("inspect Docker network '%s': %w", NetworkName, err)
 		}
+
+		for _, id := range reattach {
+			if err = c.client.NetworkConnect(ctx, NetworkName, id, nil); err != nil {
+				slog.Error("Failed to reconnect container to Docker network.",
+					"name", NetworkName, "container", id, "err", err)
+				continue
+			}
+			slog.Info("Container reconnected to Docker network with new subnet.",
+				"name", NetworkName, "container", id)
+		}
 	}
 
 	// Configure iptables to allow WireGuard network to access containers. The Docker daemon should have already
@@ -91,6 +114,36 @@ func (c *Controller) EnsureUncloudNetwork(ctx context.Context, subnet netip.Pref
 	return nil
 }
 
+// disconnectAllContainers forcibly disconnects all containers, including stopped ones, from the Docker network
+// NetworkName. It returns the IDs of the disconnected containers. If any container fails to disconnect, the already
+// disconnected ones are reconnected back.
+func (c *Controller) disconnectAllContainers(ctx context.Context) ([]string, error) {
+	containers, err := c.client.ContainerList(ctx, dockercontainer.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("network", NetworkName)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list containers in Docker network '%s': %w", NetworkName, err)
+	}
+
+	ids := make([]string, 0, len(containers))
+	for _, ctr := range containers {
+		if err = c.client.NetworkDisconnect(ctx, NetworkName, ctr.ID, true); err != nil {
+			// Reconnect the already disconnected containers to leave the network in its original state.
+			for _, id := range ids {
+				if connErr := c.client.NetworkConnect(ctx, NetworkName, id, nil); connErr != nil {
+					slog.Error("Failed to reconnect container to Docker network.",
+						"name", NetworkName, "container", id, "err", connErr)
+				}
+			}
+			return nil, fmt.Errorf("disconnect container '%s' from Docker network '%s': %w",
+				ctr.ID, NetworkName, err)
+		}
+		ids = append(ids, ctr.ID)
+	}
+	return ids, nil
+}
+
 // configureIptables configures iptables rules for the uncloud Docker network.
 func configureIptables(bridgeName string, subnet netip.Prefix, dnsServer netip.Addr) error {
 	ipt := iptables.GetIptable(iptables.IPv4)
@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LoadImage loads an image tarball in the 'docker save' format streamed by the client in chunks into Docker.
+// The chunks are piped to Docker as they arrive so the tarball is never fully buffered in memory or on disk.
+func (s *Server) LoadImage(stream grpc.BidiStreamingServer[pb.LoadImageRequest, pb.JSONMessage]) error {
+	ctx := stream.Context()
+
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					pw.Close()
+				} else {
+					pw.CloseWithError(err)
+				}
+				return
+			}
+			if _, err = pw.Write(req.Chunk); err != nil {
+				// The reader is closed when Docker stops reading the tarball, e.g. because it's invalid.
+				return
+			}
+		}
+	}()
+	defer pr.Close()
+
+	resp, err := s.client.ImageLoad(ctx, pr, client.ImageLoadWithQuiet(false))
+	if err != nil {
+		return status.Errorf(codes.Internal, "load image: %v", err)
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return status.Error(codes.Canceled, ctx.Err().Error())
+			}
+			return status.Errorf(codes.Internal, "decode image load message: %v", err)
+		}
+
+		if err = stream.Send(&pb.JSONMessage{Message: raw}); err != nil {
+			return status.Errorf(codes.Internal, "send image load message to stream: %v", err)
+		}
+	}
+}
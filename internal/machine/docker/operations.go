@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+)
+
+const (
+	// opCreateServiceContainer is the operation that creates a service container, injects its configs and secrets,
+	// and stores its spec in the machine database.
+	opCreateServiceContainer = "create_service_container"
+	// operationFinishTimeout is the maximum time to finish or roll back an operation after it has started changing
+	// resources, even if the client has disconnected.
+	operationFinishTimeout = 2 * time.Minute
+)
+
+// operation is an in-flight operation recorded in the journal of the machine database.
+type operation struct {
+	ID            int64  `db:"id"`
+	Kind          string `db:"kind"`
+	ContainerName string `db:"container_name"`
+}
+
+// beginOperation records the operation in the journal before it starts changing resources.
+func (s *Server) beginOperation(ctx context.Context, kind, containerName string) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `INSERT INTO operations (kind, container_name) VALUES ($1, $2)`,
+		kind, containerName)
+	if err != nil {
+		return 0, fmt.Errorf("record operation in machine database: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// endOperation removes the operation from the journal once it has completed or has been rolled back.
+func (s *Server) endOperation(id int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM operations WHERE id = $1`, id); err != nil {
+		// The operation will be checked again on the next daemon start which is harmless.
+		slog.Error("Failed to remove completed operation from machine database.", "id", id, "err", err)
+	}
+}
+
+// PendingOperations returns the number of in-flight operations recorded in the journal.
+func (s *Server) PendingOperations(ctx context.Context) (int, error) {
+	var n int
+	if err := s.db.GetContext(ctx, &n, `SELECT COUNT(*) FROM operations`); err != nil {
+		return 0, fmt.Errorf("count operations in machine database: %w", err)
+	}
+	return n, nil
+}
+
+// ResumeOperations completes the operations interrupted when the daemon stopped or crashed, for example, during
+// an upgrade or reboot. Service containers that were created but not fully set up are removed so that a retried
+// deployment creates them from scratch. It must be called before the machine API server starts serving requests.
+func (s *Server) ResumeOperations(ctx context.Context) error {
+	var ops []operation
+	if err := s.db.SelectContext(ctx, &ops, `SELECT id, kind, container_name FROM operations ORDER BY id`); err != nil {
+		return fmt.Errorf("list operations in machine database: %w", err)
+	}
+
+	for _, op := range ops {
+		log := slog.With("operation", op.Kind, "container", op.ContainerName)
+		switch op.Kind {
+		case opCreateServiceContainer:
+			if err := s.rollbackServiceContainer(ctx, op.ContainerName); err != nil {
+				// Keep the operation to retry the rollback on the next daemon start.
+				log.Error("Failed to roll back interrupted operation.", "err", err)
+				continue
+			}
+		default:
+			// The operation was likely recorded by a newer daemon version before a downgrade.
+			log.Warn("Skipped interrupted operation of unknown kind.")
+		}
+		s.endOperation(op.ID)
+	}
+	return nil
+}
+
+// rollbackServiceContainer removes the service container with the given name if it has been created but its spec
+// hasn't been stored in the machine database, which is the last step of creating a service container.
+func (s *Server) rollbackServiceContainer(ctx context.Context, name string) error {
+	ctr, err := s.client.ContainerInspect(ctx, name)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("inspect container: %w", err)
+	}
+
+	var stored int
+	if err = s.db.GetContext(ctx, &stored, `SELECT COUNT(*) FROM containers WHERE id = $1`, ctr.ID); err != nil {
+		return fmt.Errorf("get container from machine database: %w", err)
+	}
+	if stored > 0 {
+		return nil
+	}
+
+	opts := container.RemoveOptions{Force: true, RemoveVolumes: true}
+	if err = s.client.ContainerRemove(ctx, ctr.ID, opts); err != nil && !errdefs.IsNotFound(err) {
+		return fmt.Errorf("remove container: %w", err)
+	}
+	slog.Info("Removed partially created service container left by an interrupted deployment.",
+		"container", name, "id", ctr.ID)
+	return nil
+}
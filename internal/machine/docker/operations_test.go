@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestServer_Operations(t *testing.T) {
+	t.Parallel()
+
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	// A single connection keeps the in-memory database shared between queries.
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`CREATE TABLE operations (id INTEGER PRIMARY KEY AUTOINCREMENT, kind TEXT NOT NULL,
+		container_name TEXT NOT NULL, started_at TIMESTAMP NOT NULL DEFAULT (datetime('subsecond')))`)
+	require.NoError(t, err)
+
+	s := &Server{db: db}
+	ctx := context.Background()
+
+	id, err := s.beginOperation(ctx, opCreateServiceContainer, "web-abcd")
+	require.NoError(t, err)
+	_, err = s.beginOperation(ctx, "unknown", "db-efgh")
+	require.NoError(t, err)
+
+	n, err := s.PendingOperations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	s.endOperation(id)
+	n, err = s.PendingOperations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// Operations of unknown kinds are dropped on resume.
+	require.NoError(t, s.ResumeOperations(ctx))
+	n, err = s.PendingOperations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
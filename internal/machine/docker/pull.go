@@ -0,0 +1,162 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Image pulls that fail because of transient registry or network errors are retried with exponential backoff.
+// Docker keeps the layers that have been fully downloaded, and the containerd image store also keeps partially
+// downloaded ones, so a retried pull resumes from where the failed one stopped instead of starting over.
+const (
+	pullMaxAttempts    = 5
+	pullInitialBackoff = 2 * time.Second
+	pullMaxBackoff     = 30 * time.Second
+
+	// pullRetryStatusPrefix is the status prefix of the progress messages sent when a pull is retried.
+	pullRetryStatusPrefix = "Retrying pull"
+)
+
+// transientPullErrors are substrings of Docker pull errors caused by unstable connections or temporary registry
+// failures that are likely to succeed on retry.
+var transientPullErrors = []string{
+	"broken pipe",
+	"connection refused",
+	"connection reset",
+	"i/o timeout",
+	"network is unreachable",
+	"no route to host",
+	"server misbehaving",
+	"temporary failure in name resolution",
+	"tls handshake timeout",
+	"unexpected eof",
+	"http2: server sent goaway",
+	"500 internal server error",
+	"502 bad gateway",
+	"503 service unavailable",
+	"504 gateway timeout",
+}
+
+func isTransientPullError(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, s := range transientPullErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPullRetryMessage returns true if the image pull progress message reports that the pull failed with a transient
+// error and is going to be retried.
+func IsPullRetryMessage(jm jsonmessage.JSONMessage) bool {
+	return jm.ID == "" && strings.HasPrefix(jm.Status, pullRetryStatusPrefix)
+}
+
+func pullRetryMessage(backoff time.Duration, nextAttempt int, err error) jsonmessage.JSONMessage {
+	return jsonmessage.JSONMessage{
+		Status: fmt.Sprintf("%s in %s (attempt %d of %d) after error: %v",
+			pullRetryStatusPrefix, backoff, nextAttempt, pullMaxAttempts, err),
+	}
+}
+
+// pullImageWithRetries pulls the image and forwards the progress messages to the stream. Transient failures are
+// retried and reported to the stream as retry messages.
+func (s *Server) pullImageWithRetries(
+	ctx context.Context, img string, opts image.PullOptions, stream grpc.ServerStreamingServer[pb.JSONMessage],
+) error {
+	backoff := pullInitialBackoff
+	for attempt := 1; ; attempt++ {
+		retryErr, err := s.pullImageAttempt(ctx, img, opts, stream, attempt < pullMaxAttempts)
+		if err != nil || retryErr == nil {
+			return err
+		}
+
+		msg, err := json.Marshal(pullRetryMessage(backoff, attempt+1, retryErr))
+		if err != nil {
+			return status.Errorf(codes.Internal, "marshal image pull retry message: %v", err)
+		}
+		if err = stream.Send(&pb.JSONMessage{Message: msg}); err != nil {
+			return status.Errorf(codes.Internal, "send image pull message to stream: %v", err)
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, ctx.Err().Error())
+		}
+		backoff = min(backoff*2, pullMaxBackoff)
+	}
+}
+
+// pullImageAttempt pulls the image once and forwards the progress messages to the stream. If retry is true and
+// the pull fails with a transient error, the error is returned as retryErr instead of being sent to the stream.
+func (s *Server) pullImageAttempt(
+	ctx context.Context,
+	img string,
+	opts image.PullOptions,
+	stream grpc.ServerStreamingServer[pb.JSONMessage],
+	retry bool,
+) (retryErr error, err error) {
+	respBody, err := s.client.ImagePull(ctx, img, opts)
+	if err != nil {
+		if retry && ctx.Err() == nil && isTransientPullError(err.Error()) {
+			return err, nil
+		}
+		return nil, imageStatusError(err)
+	}
+	defer respBody.Close()
+
+	type result struct {
+		retryErr error
+		err      error
+	}
+	decoder := json.NewDecoder(respBody)
+	resCh := make(chan result, 1)
+
+	go func() {
+		var raw json.RawMessage
+		for {
+			if err := decoder.Decode(&raw); err != nil {
+				if errors.Is(err, io.EOF) {
+					resCh <- result{}
+					return
+				}
+				resCh <- result{err: status.Errorf(codes.Internal, "decode image pull message: %v", err)}
+				return
+			}
+
+			if retry {
+				var jm jsonmessage.JSONMessage
+				if json.Unmarshal(raw, &jm) == nil && jm.Error != nil && isTransientPullError(jm.Error.Message) {
+					resCh <- result{retryErr: errors.New(jm.Error.Message)}
+					return
+				}
+			}
+
+			if err := stream.Send(&pb.JSONMessage{Message: raw}); err != nil {
+				resCh <- result{err: status.Errorf(codes.Internal, "send image pull message to stream: %v", err)}
+				return
+			}
+		}
+	}()
+
+	select {
+	case res := <-resCh:
+		return res.retryErr, res.err
+	case <-ctx.Done():
+		return nil, status.Error(codes.Canceled, ctx.Err().Error())
+	}
+}
@@ -0,0 +1,39 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientPullError(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"read tcp 10.0.0.2:41234->104.18.121.25:443: read: connection reset by peer": true,
+		"unexpected EOF":                                                              true,
+		"net/http: TLS handshake timeout":                                             true,
+		"received unexpected HTTP status: 503 Service Unavailable":                    true,
+		"dial tcp: lookup registry-1.docker.io: Temporary failure in name resolution": true,
+		"manifest for nginx:nope not found: manifest unknown: manifest unknown":       false,
+		"pull access denied for private/app, repository does not exist":               false,
+		"unauthorized: authentication required":                                       false,
+	}
+	for msg, want := range tests {
+		assert.Equal(t, want, isTransientPullError(msg), msg)
+	}
+}
+
+func TestIsPullRetryMessage(t *testing.T) {
+	t.Parallel()
+
+	msg := pullRetryMessage(4*time.Second, 2, errors.New("unexpected EOF"))
+	assert.True(t, IsPullRetryMessage(msg))
+	assert.Equal(t, "Retrying pull in 4s (attempt 2 of 5) after error: unexpected EOF", msg.Status)
+
+	assert.False(t, IsPullRetryMessage(jsonmessage.JSONMessage{Status: "Pulling from library/nginx"}))
+	assert.False(t, IsPullRetryMessage(jsonmessage.JSONMessage{ID: "a1b2c3", Status: "Retrying in 5 seconds"}))
+}
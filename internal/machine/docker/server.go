@@ -40,9 +40,14 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/psviderski/uncloud/internal/fault"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"github.com/psviderski/uncloud/internal/machine/dns"
+	machinenetwork "github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/snapshot"
+	"github.com/psviderski/uncloud/internal/policy"
 	"github.com/psviderski/uncloud/internal/secret"
+	"github.com/psviderski/uncloud/internal/version"
 	"github.com/psviderski/uncloud/pkg/api"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -56,9 +61,10 @@ var fullDockerIDRegex = regexp.MustCompile(`^[a-f0-9]{64}$`)
 // Server implements the gRPC Docker service that proxies requests to the Docker daemon.
 type Server struct {
 	pb.UnimplementedDockerServer
-	client  *client.Client
-	service *Service
-	db      *sqlx.DB
+	client    *client.Client
+	service   *Service
+	db        *sqlx.DB
+	snapshots *snapshot.Manager
 	// internalDNSIP is a function that returns the IP address of the internal DNS server. It may return an empty
 	// address if the address is unknown (e.g. when the machine is not initialised yet).
 	internalDNSIP func() netip.Addr
@@ -69,6 +75,24 @@ type Server struct {
 	networkReady func() bool
 	// waitForNetworkReady is a function that waits for the Docker network to be ready for containers.
 	waitForNetworkReady func(ctx context.Context) error
+	// defaultUlimits is a function that returns the cluster-wide default ulimits for service containers.
+	defaultUlimits func(ctx context.Context) (map[string]api.Ulimit, error)
+	// allowedSysctls is a function that returns the cluster sysctl allow-list for service containers.
+	allowedSysctls func(ctx context.Context) ([]string, error)
+	// usernsRemap is a function that returns whether user namespace remapping is enabled for the cluster.
+	usernsRemap func(ctx context.Context) (bool, error)
+	// openSealedSecret is a function that decrypts a secret sealed with the cluster sealing key pair.
+	openSealedSecret func(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+	// clusterSecretValue is a function that returns the decrypted value of a secret version stored in the cluster.
+	clusterSecretValue func(ctx context.Context, name string, version int64) ([]byte, error)
+	// secretMaxMode is a function that returns the most permissive file mode allowed for secrets.
+	secretMaxMode func(ctx context.Context) (os.FileMode, error)
+	// machineFacts is a function that returns the machine facts for rendering config and secret templates.
+	machineFacts func() api.TemplateMachine
+	// faults are the failures injected into the daemon for testing.
+	faults *fault.Injector
+	// policy admits, rejects, or mutates service specs before their containers are created.
+	policy policy.Policy
 }
 
 type ServerOptions struct {
@@ -77,6 +101,31 @@ type ServerOptions struct {
 	//  API server but in this case we should probably fail until the cluster is initialised.
 	NetworkReady        func() bool
 	WaitForNetworkReady func(ctx context.Context) error
+	// DefaultUlimits returns the cluster-wide default ulimits applied to service containers unless overridden
+	// by the service. It's optional.
+	DefaultUlimits func(ctx context.Context) (map[string]api.Ulimit, error)
+	// AllowedSysctls returns the cluster sysctl allow-list. Service containers that set sysctls not matching
+	// the list are rejected. An empty list allows all sysctls. It's optional.
+	AllowedSysctls func(ctx context.Context) ([]string, error)
+	// UsernsRemap returns whether user namespace remapping is enabled for the cluster. It's only used to warn
+	// when Docker on the machine isn't configured with userns-remap. It's optional.
+	UsernsRemap func(ctx context.Context) (bool, error)
+	// OpenSealedSecret decrypts the ciphertext of a secret sealed with the cluster sealing key pair with the given
+	// ID. Containers with sealed secrets can't be created without it.
+	OpenSealedSecret func(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+	// ClusterSecretValue returns the decrypted value of the given version of the secret stored in the cluster with
+	// the given name. Zero version means the current version. Containers that mount cluster secrets can't be created
+	// without it.
+	ClusterSecretValue func(ctx context.Context, name string, version int64) ([]byte, error)
+	// SecretMaxMode returns the most permissive file mode allowed for secrets mounted into service containers.
+	// Zero means no limit. It's optional.
+	SecretMaxMode func(ctx context.Context) (os.FileMode, error)
+	// MachineFacts returns the machine facts available to config and secret templates. It's optional.
+	MachineFacts func() api.TemplateMachine
+	// Faults are the failures injected into the daemon for testing. It's optional.
+	Faults *fault.Injector
+	// Policy admits, rejects, or mutates service specs before their containers are created. It's optional.
+	Policy policy.Policy
 }
 
 // NewServer creates a new Docker gRPC server with the provided Docker service.
@@ -85,12 +134,22 @@ func NewServer(service *Service, db *sqlx.DB, internalDNSIP func() netip.Addr, m
 		client:        service.Client,
 		service:       service,
 		db:            db,
+		snapshots:     snapshot.NewManager(),
 		internalDNSIP: internalDNSIP,
 		machineID:     machineID,
 	}
 
 	s.networkReady = opts.NetworkReady
 	s.waitForNetworkReady = opts.WaitForNetworkReady
+	s.defaultUlimits = opts.DefaultUlimits
+	s.allowedSysctls = opts.AllowedSysctls
+	s.usernsRemap = opts.UsernsRemap
+	s.openSealedSecret = opts.OpenSealedSecret
+	s.clusterSecretValue = opts.ClusterSecretValue
+	s.secretMaxMode = opts.SecretMaxMode
+	s.machineFacts = opts.MachineFacts
+	s.faults = opts.Faults
+	s.policy = opts.Policy
 
 	return s
 }
@@ -287,42 +346,10 @@ func (s *Server) PullImage(req *pb.PullImageRequest, stream grpc.ServerStreaming
 		}
 	}
 
-	respBody, err := s.client.ImagePull(ctx, req.Image, opts)
-	if err != nil {
-		return status.Error(codes.Internal, err.Error())
-	}
-	defer respBody.Close()
-
-	decoder := json.NewDecoder(respBody)
-	errCh := make(chan error, 1)
-
-	go func() {
-		var raw json.RawMessage
-		for {
-			if err = decoder.Decode(&raw); err != nil {
-				if errors.Is(err, io.EOF) {
-					errCh <- nil
-					return
-				}
-				errCh <- status.Errorf(codes.Internal, "decode image pull message: %v", err)
-				return
-			}
-
-			if err = stream.Send(&pb.JSONMessage{Message: raw}); err != nil {
-				errCh <- status.Errorf(codes.Internal, "send image pull message to stream: %v", err)
-				return
-			}
-		}
-	}()
-
-	for {
-		select {
-		case err = <-errCh:
-			return err
-		case <-ctx.Done():
-			return status.Error(codes.Canceled, ctx.Err().Error())
-		}
+	if err := s.faults.ImagePullError(req.Image); err != nil {
+		return status.Error(codes.Unavailable, err.Error())
 	}
+	return s.pullImageWithRetries(ctx, req.Image, opts, stream)
 }
 
 // InspectImage returns the image information for the given image ID.
@@ -349,6 +376,28 @@ func (s *Server) InspectImage(ctx context.Context, req *pb.InspectImageRequest)
 	}, nil
 }
 
+// InspectImages returns the image information for each of the given image IDs. An image that can't be inspected
+// doesn't fail the request but its result contains the error status instead.
+func (s *Server) InspectImages(ctx context.Context, req *pb.InspectImagesRequest) (*pb.InspectImagesResponse, error) {
+	inspected := &pb.InspectedImages{Images: make([]*pb.InspectedImage, len(req.Ids))}
+	for i, id := range req.Ids {
+		inspected.Images[i] = &pb.InspectedImage{Id: id}
+
+		resp, err := s.client.ImageInspect(ctx, id)
+		if err != nil {
+			inspected.Images[i].Status = status.Convert(imageStatusError(err)).Proto()
+			continue
+		}
+		if inspected.Images[i].Image, err = json.Marshal(resp); err != nil {
+			return nil, status.Errorf(codes.Internal, "marshal image '%s': %v", id, err)
+		}
+	}
+
+	return &pb.InspectImagesResponse{
+		Messages: []*pb.InspectedImages{inspected},
+	}, nil
+}
+
 // InspectRemoteImage returns the image metadata for an image in a remote registry using the machine's Docker auth
 // credentials if necessary.
 func (s *Server) InspectRemoteImage(
@@ -431,6 +480,169 @@ func (s *Server) ListImages(ctx context.Context, req *pb.ListImagesRequest) (*pb
 	}, nil
 }
 
+// RemoveImage removes an image from the machine. The error codes distinguish a missing image (NotFound), an image
+// used by a container (FailedPrecondition), and insufficient permissions (PermissionDenied).
+func (s *Server) RemoveImage(ctx context.Context, req *pb.RemoveImageRequest) (*pb.RemoveImageResponse, error) {
+	var opts image.RemoveOptions
+	if len(req.Options) > 0 {
+		if err := json.Unmarshal(req.Options, &opts); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unmarshal options: %v", err)
+		}
+	}
+
+	deleted, err := s.client.ImageRemove(ctx, req.Image, opts)
+	if err != nil {
+		return nil, imageStatusError(err)
+	}
+
+	removed := pb.RemovedImages{}
+	if len(deleted) > 0 {
+		if removed.Deleted, err = json.Marshal(deleted); err != nil {
+			return nil, status.Errorf(codes.Internal, "marshal deleted images: %v", err)
+		}
+	}
+
+	return &pb.RemoveImageResponse{
+		Messages: []*pb.RemovedImages{&removed},
+	}, nil
+}
+
+// RemoveImages removes each of the given images from the machine. An image that fails to be removed doesn't stop
+// the removal of the others but its result contains the error status with the same codes as RemoveImage.
+func (s *Server) RemoveImages(ctx context.Context, req *pb.RemoveImagesRequest) (*pb.RemoveImagesResponse, error) {
+	var opts image.RemoveOptions
+	if len(req.Options) > 0 {
+		if err := json.Unmarshal(req.Options, &opts); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unmarshal options: %v", err)
+		}
+	}
+
+	batch := &pb.RemovedImagesBatch{Images: make([]*pb.ImageRemoval, len(req.Images))}
+	for i, img := range req.Images {
+		batch.Images[i] = &pb.ImageRemoval{Image: img}
+
+		deleted, err := s.client.ImageRemove(ctx, img, opts)
+		if err != nil {
+			batch.Images[i].Status = status.Convert(imageStatusError(err)).Proto()
+			continue
+		}
+		if len(deleted) > 0 {
+			if batch.Images[i].Deleted, err = json.Marshal(deleted); err != nil {
+				return nil, status.Errorf(codes.Internal, "marshal deleted images: %v", err)
+			}
+		}
+	}
+
+	return &pb.RemoveImagesResponse{
+		Messages: []*pb.RemovedImagesBatch{batch},
+	}, nil
+}
+
+// PruneImages removes unused images matching the filters from the machine. Images matching any of the references
+// to keep aren't removed.
+func (s *Server) PruneImages(ctx context.Context, req *pb.PruneImagesRequest) (*pb.PruneImagesResponse, error) {
+	args := filters.NewArgs()
+	if len(req.Filters) > 0 {
+		var err error
+		if args, err = filters.FromJSON(string(req.Filters)); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unmarshal filters: %v", err)
+		}
+	}
+
+	var (
+		report image.PruneReport
+		err    error
+	)
+	// Dangling images have no tags so they can't match the references to keep. Docker prunes only dangling images
+	// unless explicitly asked to prune all unused images with the dangling=false filter.
+	if len(req.Keep) > 0 && slices.Contains(args.Get("dangling"), "false") {
+		report, err = s.pruneUnusedImagesExcept(ctx, args, req.Keep)
+	} else {
+		report, err = s.client.ImagesPrune(ctx, args)
+	}
+	if err != nil {
+		return nil, imageStatusError(err)
+	}
+
+	reportBytes, err := json.Marshal(report)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal prune report: %v", err)
+	}
+
+	return &pb.PruneImagesResponse{
+		Messages: []*pb.PrunedImages{{Report: reportBytes}},
+	}, nil
+}
+
+// pruneUnusedImagesExcept removes dangling images and all images not used by any container except those matching
+// the references to keep. Docker doesn't support excluding images by reference when pruning, so unused images are
+// removed one by one. Filters other than dangling only apply to dangling images.
+func (s *Server) pruneUnusedImagesExcept(
+	ctx context.Context, args filters.Args, keep []string,
+) (image.PruneReport, error) {
+	danglingArgs := args.Clone()
+	danglingArgs.Del("dangling", "false")
+	danglingArgs.Add("dangling", "true")
+	report, err := s.client.ImagesPrune(ctx, danglingArgs)
+	if err != nil {
+		return report, err
+	}
+
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return report, fmt.Errorf("list containers: %w", err)
+	}
+	usedImages := make(map[string]struct{}, len(containers))
+	for _, ctr := range containers {
+		usedImages[ctr.ImageID] = struct{}{}
+	}
+
+	images, err := s.client.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return report, fmt.Errorf("list images: %w", err)
+	}
+
+	for _, img := range images {
+		if _, ok := usedImages[img.ID]; ok {
+			continue
+		}
+		if api.ImageMatchesPinned(keep, img.RepoTags, img.RepoDigests) {
+			continue
+		}
+
+		// Force is required to remove an image referenced by multiple tags. Images used by stopped containers
+		// have already been excluded above.
+		deleted, err := s.client.ImageRemove(ctx, img.ID, image.RemoveOptions{Force: true, PruneChildren: true})
+		if err != nil {
+			// The image may have been removed concurrently or be a parent of another image. Skip it like
+			// Docker does when pruning.
+			slog.Debug("Failed to remove unused image when pruning.", "id", img.ID, "err", err)
+			continue
+		}
+		report.ImagesDeleted = append(report.ImagesDeleted, deleted...)
+		if img.Size > 0 {
+			report.SpaceReclaimed += uint64(img.Size)
+		}
+	}
+
+	return report, nil
+}
+
+// imageStatusError converts an error from a Docker image operation to a gRPC status error with a code that allows
+// clients to tell apart the common failure reasons.
+func imageStatusError(err error) error {
+	switch {
+	case errdefs.IsNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case errdefs.IsConflict(err):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errdefs.IsPermissionDenied(err), errdefs.IsUnauthorized(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
 // CreateVolume creates a new volume with the given options.
 func (s *Server) CreateVolume(ctx context.Context, req *pb.CreateVolumeRequest) (*pb.CreateVolumeResponse, error) {
 	var opts volume.CreateOptions
@@ -520,14 +732,54 @@ func (s *Server) CreateServiceContainer(
 		return nil, status.Errorf(codes.InvalidArgument, "invalid service ID: '%s'", req.ServiceId)
 	}
 
+	// Reject specs from a newer client instead of silently ignoring the features this daemon doesn't support.
+	unknown, err := api.UnknownServiceSpecFields(req.ServiceSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unmarshal service spec: %v", err)
+	}
+	if len(unknown) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"service spec has fields not supported by the daemon version %s on this machine: %s. "+
+				"Upgrade the daemon on the machine to use them", version.String(), strings.Join(unknown, ", "))
+	}
+
 	var spec api.ServiceSpec
-	if err := json.Unmarshal(req.ServiceSpec, &spec); err != nil {
+	if err = json.Unmarshal(req.ServiceSpec, &spec); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "unmarshal service spec: %v", err)
 	}
 	spec = spec.SetDefaults()
 	if err := spec.Validate(); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid service spec: %v", err)
 	}
+	// The container is created from the spec admitted by the policy but the requested spec is stored so that
+	// the policy mutations don't show up as spec changes on the next deployment.
+	requestedSpec := spec
+	if s.policy != nil {
+		if spec, err = s.policy.Admit(spec); err != nil {
+			if _, ok := errors.AsType[*policy.RejectedError](err); ok {
+				return nil, status.Errorf(codes.PermissionDenied, "service '%s': %v", spec.Name, err)
+			}
+			return nil, status.Errorf(codes.FailedPrecondition, "evaluate admission policy: %v", err)
+		}
+	}
+	if len(spec.Container.Sysctls) > 0 && s.allowedSysctls != nil {
+		allowed, err := s.allowedSysctls(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "get cluster allowed sysctls: %v", err)
+		}
+		if err = api.CheckSysctlsAllowed(spec.Container.Sysctls, allowed); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+	if len(spec.Container.SecretMounts) > 0 && s.secretMaxMode != nil {
+		maxMode, err := s.secretMaxMode(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "get cluster secret max mode: %v", err)
+		}
+		if err = api.CheckSecretModesAllowed(spec.Container.SecretMounts, maxMode); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
 
 	containerName := req.ContainerName
 	if containerName == "" {
@@ -550,11 +802,25 @@ func (s *Server) CreateServiceContainer(
 		}
 	}
 
+	// Inject the container port numbers of the named ports so that health checks and the app can reference them
+	// by name. Explicitly set variables take precedence.
+	for name, port := range spec.NamedPorts() {
+		if _, ok := envVars[api.PortEnvVar(name)]; !ok {
+			envVars[api.PortEnvVar(name)] = strconv.Itoa(int(port))
+		}
+	}
+
+	hostname := containerName
+	if spec.Container.Hostname != "" {
+		hostname = spec.Container.Hostname
+	}
+
 	config := &container.Config{
 		Cmd:        spec.Container.Command,
+		Domainname: spec.Container.Domainname,
 		Env:        envVars.ToSlice(),
 		Entrypoint: spec.Container.Entrypoint,
-		Hostname:   containerName,
+		Hostname:   hostname,
 		Image:      spec.Container.Image,
 		Labels: map[string]string{
 			api.LabelServiceID:   req.ServiceId,
@@ -562,11 +828,22 @@ func (s *Server) CreateServiceContainer(
 			api.LabelServiceMode: spec.Mode,
 			api.LabelManaged:     "",
 		},
-		User: spec.Container.User,
+		StopSignal: spec.Container.StopSignal,
+		User:       spec.Container.User,
+		WorkingDir: spec.Container.WorkingDir,
+	}
+	if spec.Container.StopGracePeriod != nil {
+		// Also apply the grace period when the container is stopped outside a deployment, for example, with
+		// 'uc stop' or when the Docker daemon shuts down.
+		timeout := int(spec.Container.StopGracePeriod.Seconds())
+		config.StopTimeout = &timeout
 	}
 	if spec.Mode == "" {
 		config.Labels[api.LabelServiceMode] = api.ServiceModeReplicated
 	}
+	if len(spec.DependsOn) > 0 {
+		config.Labels[api.LabelServiceDependsOn] = strings.Join(spec.DependsOn, ",")
+	}
 	if hc := spec.Container.Healthcheck; hc != nil {
 		if hc.Disable {
 			config.Healthcheck = &container.HealthConfig{
@@ -585,7 +862,6 @@ func (s *Server) CreateServiceContainer(
 	}
 
 	// TODO: do not set the ports as container labels once migrated to retrieve them from the spec in DB.
-	var err error
 	if len(spec.Ports) > 0 {
 		encodedPorts := make([]string, len(spec.Ports))
 		for i, p := range spec.Ports {
@@ -620,31 +896,73 @@ func (s *Server) CreateServiceContainer(
 		if p.HostIP.IsValid() {
 			portBindings[port][0].HostIP = p.HostIP.String()
 		}
+		if p.HostInterface != "" {
+			// Bind the port to the addresses the interface has on this machine.
+			ips, err := machinenetwork.InterfaceIPs(p.HostInterface)
+			if err != nil {
+				return nil, status.Errorf(codes.FailedPrecondition, "bind host port %d/%s: %v",
+					p.PublishedPort, p.Protocol, err)
+			}
+			portBindings[port] = nil
+			for _, ip := range ips {
+				portBindings[port] = append(portBindings[port], nat.PortBinding{
+					HostIP:   ip.String(),
+					HostPort: strconv.Itoa(int(p.PublishedPort)),
+				})
+			}
+		}
 	}
+	ipcMode, err := s.resolveIpcMode(ctx, spec.Container.IpcMode)
+	if err != nil {
+		return nil, err
+	}
+	usernsMode, err := s.resolveUsernsMode(ctx, spec.Container)
+	if err != nil {
+		return nil, err
+	}
+	ulimits := spec.Container.Resources.Ulimits
+	if s.defaultUlimits != nil {
+		defaults, err := s.defaultUlimits(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "get cluster default ulimits: %v", err)
+		}
+		ulimits = mergeUlimits(defaults, ulimits)
+	}
+	restartPolicy, err := api.ParseRestartPolicy(spec.RestartPolicy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	hostConfig := &container.HostConfig{
+		Annotations:  spec.Container.Annotations,
 		CapAdd:       spec.Container.CapAdd,
 		CapDrop:      spec.Container.CapDrop,
 		Binds:        spec.Container.Volumes,
+		ExtraHosts:   spec.Container.ExtraHosts,
 		Init:         spec.Container.Init,
+		IpcMode:      ipcMode,
 		Mounts:       mounts,
 		PidMode:      container.PidMode(spec.Container.PidMode),
 		PortBindings: portBindings,
 		Privileged:   spec.Container.Privileged,
+		UsernsMode:   usernsMode,
 		Resources: container.Resources{
 			NanoCPUs:          spec.Container.Resources.CPU,
 			Memory:            spec.Container.Resources.Memory,
 			MemoryReservation: spec.Container.Resources.MemoryReservation,
 			Devices:           toDockerDevices(spec.Container.Resources.Devices),
 			DeviceRequests:    spec.Container.Resources.DeviceReservations,
-			Ulimits:           toDockerUlimits(spec.Container.Resources.Ulimits),
-		},
-		// Restart service containers if they exit or a machine restarts unless they are explicitly stopped.
-		// For one-off containers and batch jobs we plan to use a different service type/mode.
-		RestartPolicy: container.RestartPolicy{
-			Name: container.RestartPolicyUnlessStopped,
+			Ulimits:           toDockerUlimits(ulimits),
 		},
-		ShmSize: spec.Container.Resources.SharedMemory,
-		Sysctls: spec.Container.Sysctls,
+		OomScoreAdj: spec.Container.OomScoreAdj,
+		// Restart service containers if they exit or a machine restarts unless they are explicitly stopped or
+		// the service sets a different restart policy. The Docker daemon restarts them with exponential backoff.
+		RestartPolicy: restartPolicy,
+		ShmSize:       spec.Container.Resources.SharedMemory,
+		Sysctls:       spec.Container.Sysctls,
+	}
+	if spec.Container.Resources.PidsLimit != 0 {
+		hostConfig.Resources.PidsLimit = &spec.Container.Resources.PidsLimit
 	}
 
 	// Configure the container to use the internal DNS server if it's available.
@@ -695,45 +1013,124 @@ func (s *Server) CreateServiceContainer(
 		}
 	}
 
+	// Strip the service volumes, published ports, and restart policy from the throwaway container to verify a backup
+	// in. It must not touch the service data so the backup is restored into the fresh anonymous volumes of the image.
+	if req.ContainerType == pb.CreateServiceContainerRequest_BACKUP_VERIFY {
+		config.Labels = map[string]string{
+			api.LabelServiceID:   req.ServiceId,
+			api.LabelServiceName: spec.Name,
+			api.LabelHook:        api.LabelHookBackupVerify,
+			api.LabelManaged:     "",
+		}
+		hostConfig.Binds = nil
+		hostConfig.Mounts = slices.DeleteFunc(hostConfig.Mounts, func(m mount.Mount) bool {
+			return m.Type != mount.TypeTmpfs
+		})
+		hostConfig.PortBindings = nil
+		hostConfig.RestartPolicy = container.RestartPolicy{
+			Name: container.RestartPolicyDisabled,
+		}
+	}
+
+	endpoint := &network.EndpointSettings{}
+	if len(spec.InternalIPs) > 0 && req.ContainerType == pb.CreateServiceContainerRequest_SERVICE {
+		ip, err := s.claimInternalIP(ctx, spec.InternalIPs)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: ip.String()}
+	}
 	networkConfig := &network.NetworkingConfig{
 		EndpointsConfig: map[string]*network.EndpointSettings{
-			NetworkName: {},
+			NetworkName: endpoint,
 		},
 	}
 
+	// Record the operation so that the container is removed on the next daemon start if the daemon stops or crashes
+	// before the container is fully set up.
+	opID, err := s.beginOperation(ctx, opCreateServiceContainer, containerName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// Finish or roll back the creation even if the client disconnects to not leave a half-created container behind.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), operationFinishTimeout)
+	defer cancel()
+
 	resp, err := s.client.ContainerCreate(ctx, config, hostConfig, networkConfig, nil, containerName)
 	if err != nil {
+		// Keep the operation if the container might have been created despite the error.
+		if _, inspectErr := s.client.ContainerInspect(ctx, containerName); errdefs.IsNotFound(inspectErr) {
+			s.endOperation(opID)
+		}
 		if errdefs.IsNotFound(err) {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	// removeContainer rolls back the creation by removing the container with its anonymous volumes.
+	removeContainer := func() {
+		err := s.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{RemoveVolumes: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			slog.Error("Failed to remove partially created container, it will be removed on the next daemon start.",
+				"id", resp.ID, "err", err)
+			return
+		}
+		s.endOperation(opID)
+	}
+
+	tmplData := api.TemplateData{
+		Env:       envVars,
+		Service:   api.TemplateService{ID: req.ServiceId, Name: spec.Name, Mode: spec.Mode},
+		Container: api.TemplateContainer{Name: containerName, Hostname: hostname},
+	}
+	if s.machineFacts != nil {
+		tmplData.Machine = s.machineFacts()
+	}
 
 	// Inject configs into the created container
-	if err = s.injectConfigs(ctx, resp.ID, spec.Configs, spec.Container.ConfigMounts); err != nil {
+	if err = s.injectConfigs(ctx, resp.ID, spec.Configs, spec.Container.ConfigMounts, tmplData); err != nil {
 		// Remove the container if config injection fails
-		_ = s.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{RemoveVolumes: true})
+		removeContainer()
 		return nil, status.Errorf(codes.Internal, "inject configs: %v", err)
 	}
+	if err = s.injectSecrets(ctx, resp.ID, spec.Secrets, spec.Container.SecretMounts, tmplData); err != nil {
+		removeContainer()
+		return nil, status.Errorf(codes.Internal, "inject secrets: %v", err)
+	}
 
 	respBytes, err := json.Marshal(resp)
 	if err != nil {
+		removeContainer()
 		return nil, status.Errorf(codes.Internal, "marshal response: %v", err)
 	}
 
 	// Store the container spec in the database or remove the container with its anonymous volumes if storing fails.
-	removeContainer := func() {
-		_ = s.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{RemoveVolumes: true})
-	}
-
-	specBytes, err := json.Marshal(spec)
+	specBytes, err := json.Marshal(requestedSpec)
 	if err != nil {
 		removeContainer()
 		return nil, status.Errorf(codes.Internal, "marshal service spec: %v", err)
 	}
 
-	if _, err = s.db.ExecContext(ctx, `INSERT INTO containers (id, service_spec) VALUES ($1, $2)`,
+	// Storing the spec completes the operation so remove it from the journal in the same transaction.
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		removeContainer()
+		return nil, status.Errorf(codes.Internal, "begin machine database transaction: %v", err)
+	}
+	defer tx.Rollback()
+	if _, err = tx.ExecContext(ctx, `INSERT INTO containers (id, service_spec) VALUES ($1, $2)`,
 		resp.ID, string(specBytes)); err != nil {
+		// Release the database lock before removing the container as it also removes the operation.
+		_ = tx.Rollback()
+		removeContainer()
+		return nil, status.Errorf(codes.Internal, "store container in database: %v", err)
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM operations WHERE id = $1`, opID); err != nil {
+		_ = tx.Rollback()
+		removeContainer()
+		return nil, status.Errorf(codes.Internal, "complete operation in database: %v", err)
+	}
+	if err = tx.Commit(); err != nil {
 		removeContainer()
 		return nil, status.Errorf(codes.Internal, "store container in database: %v", err)
 	}
@@ -741,6 +1138,47 @@ func (s *Server) CreateServiceContainer(
 	return &pb.CreateContainerResponse{Response: respBytes}, nil
 }
 
+// claimInternalIP returns the first IP from the list that is in the static IP range of the machine subnet
+// and isn't used by another container in the Docker network.
+func (s *Server) claimInternalIP(ctx context.Context, ips []netip.Addr) (netip.Addr, error) {
+	nw, err := s.client.NetworkInspect(ctx, NetworkName, network.InspectOptions{})
+	if err != nil {
+		return netip.Addr{}, status.Errorf(codes.Internal, "inspect Docker network '%s': %v", NetworkName, err)
+	}
+	if len(nw.IPAM.Config) == 0 {
+		return netip.Addr{}, status.Errorf(codes.Internal, "Docker network '%s' has no subnet", NetworkName)
+	}
+	subnet, err := netip.ParsePrefix(nw.IPAM.Config[0].Subnet)
+	if err != nil {
+		return netip.Addr{}, status.Errorf(codes.Internal, "parse Docker network subnet: %v", err)
+	}
+
+	used := make(map[netip.Addr]struct{}, len(nw.Containers))
+	for _, c := range nw.Containers {
+		if prefix, err := netip.ParsePrefix(c.IPv4Address); err == nil {
+			used[prefix.Addr()] = struct{}{}
+		}
+	}
+
+	var inSubnet []string
+	for _, ip := range ips {
+		if !machinenetwork.IsStaticIP(subnet, ip) {
+			continue
+		}
+		if _, ok := used[ip]; !ok {
+			return ip, nil
+		}
+		inSubnet = append(inSubnet, ip.String())
+	}
+	if len(inSubnet) == 0 {
+		return netip.Addr{}, status.Errorf(codes.FailedPrecondition,
+			"none of the internal IPs is in the static IP range %s of the machine subnet %s",
+			machinenetwork.StaticIPRange(subnet), subnet)
+	}
+	return netip.Addr{}, status.Errorf(codes.FailedPrecondition,
+		"all internal IPs in the machine subnet are already in use: %s", strings.Join(inSubnet, ", "))
+}
+
 func ToDockerMounts(volumes []api.VolumeSpec, mounts []api.VolumeMount) ([]mount.Mount, error) {
 	normalisedVolumes := make([]api.VolumeSpec, len(volumes))
 	for i, v := range volumes {
@@ -794,7 +1232,11 @@ func ToDockerMounts(volumes []api.VolumeSpec, mounts []api.VolumeMount) ([]mount
 
 // injectConfigs writes config content directly into the container.
 // It processes ConfigSpecs and ConfigMounts to mount configuration content into the container filesystem.
-func (s *Server) injectConfigs(ctx context.Context, containerID string, configs []api.ConfigSpec, mounts []api.ConfigMount) error {
+// Templated configs are rendered with tmplData.
+func (s *Server) injectConfigs(
+	ctx context.Context, containerID string, configs []api.ConfigSpec, mounts []api.ConfigMount,
+	tmplData api.TemplateData,
+) error {
 	if len(configs) == 0 || len(mounts) == 0 {
 		return nil
 	}
@@ -839,9 +1281,16 @@ func (s *Server) injectConfigs(ctx context.Context, containerID string, configs
 			return fmt.Errorf("invalid Gid: %w", err)
 		}
 
+		content := config.Content
+		if config.Template {
+			if content, err = api.RenderTemplate(config.Name, content, tmplData); err != nil {
+				return fmt.Errorf("config '%s': %w", config.Name, err)
+			}
+		}
+
 		// Copy the config content directly into the container
 		if err := s.copyContentToContainer(
-			ctx, containerID, config.Content, targetPath, uid, gid, fileMode,
+			ctx, containerID, content, targetPath, uid, gid, fileMode,
 		); err != nil {
 			return fmt.Errorf("copy config file '%s' to container: %w", config.Name, err)
 		}
@@ -855,6 +1304,89 @@ func (s *Server) injectConfigs(ctx context.Context, containerID string, configs
 	return nil
 }
 
+// injectSecrets writes secret content into the container files specified by the secret mounts. Sealed secrets are
+// decrypted and templated secrets are rendered with tmplData.
+func (s *Server) injectSecrets(
+	ctx context.Context, containerID string, secrets []api.SecretSpec, mounts []api.SecretMount,
+	tmplData api.TemplateData,
+) error {
+	if len(secrets) == 0 || len(mounts) == 0 {
+		return nil
+	}
+
+	if err := api.ValidateSecretsAndMounts(secrets, mounts); err != nil {
+		return fmt.Errorf("validate secrets and mounts: %w", err)
+	}
+
+	secretMap := make(map[string]api.SecretSpec)
+	for _, secret := range secrets {
+		secretMap[secret.Name] = secret
+	}
+
+	for _, m := range mounts {
+		secret := secretMap[m.SecretName]
+		content := secret.Content
+		if secret.SealingKeyID != "" {
+			if s.openSealedSecret == nil {
+				return fmt.Errorf("secret '%s' is sealed but sealed secrets are not supported", secret.Name)
+			}
+			var err error
+			if content, err = s.openSealedSecret(ctx, secret.SealingKeyID, secret.Content); err != nil {
+				return fmt.Errorf("open sealed secret '%s': %w", secret.Name, err)
+			}
+		}
+		if secret.ClusterSecret != "" {
+			if s.clusterSecretValue == nil {
+				return fmt.Errorf("secret '%s' references a cluster secret but cluster secrets are not supported",
+					secret.Name)
+			}
+			var err error
+			content, err = s.clusterSecretValue(ctx, secret.ClusterSecret, secret.ClusterSecretVersion)
+			if err != nil {
+				return fmt.Errorf("get cluster secret '%s': %w", secret.ClusterSecret, err)
+			}
+		}
+		if secret.Template {
+			var err error
+			if content, err = api.RenderTemplate(secret.Name, content, tmplData); err != nil {
+				return fmt.Errorf("secret '%s': %w", secret.Name, err)
+			}
+		}
+		targetPath := m.Target()
+
+		// Secrets are owned by root unless specified otherwise.
+		root := uint64(0)
+		uid, err := m.GetNumericUid()
+		if err != nil {
+			return err
+		}
+		if uid == nil {
+			uid = &root
+		}
+		gid, err := m.GetNumericGid()
+		if err != nil {
+			return err
+		}
+		if gid == nil {
+			gid = &root
+		}
+
+		if err = s.copyContentToContainer(
+			ctx, containerID, content, targetPath, uid, gid, m.FileMode(),
+		); err != nil {
+			return fmt.Errorf("copy secret '%s' to container: %w", secret.Name, err)
+		}
+
+		// Never log the secret content.
+		slog.Debug("Injected secret into container",
+			"secret", secret.Name,
+			"container", containerID[:12],
+			"target", targetPath)
+	}
+
+	return nil
+}
+
 // copyContentToContainer copies content directly to a file in the container using Docker's CopyToContainer API.
 // It will create any intermediate directories in the target path that don't exist.
 func (s *Server) copyContentToContainer(ctx context.Context, containerID string, content []byte, targetPath string, uid *uint64, gid *uint64, fileMode os.FileMode) error {
@@ -929,6 +1461,77 @@ func toDockerBindOptions(opts *api.BindOptions) *mount.BindOptions {
 	return dockerOpts
 }
 
+// resolveIpcMode converts the IPC mode from the container spec to the Docker IPC mode. The "service:<name>" mode is
+// resolved to the running container of the named service on this machine.
+func (s *Server) resolveIpcMode(ctx context.Context, mode string) (container.IpcMode, error) {
+	serviceName, ok := strings.CutPrefix(mode, api.IpcModeServicePrefix)
+	if !ok {
+		return container.IpcMode(mode), nil
+	}
+
+	opts := container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("status", "running")),
+	}
+	result, err := s.service.ListServiceContainers(ctx, serviceName, opts)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "list containers of service '%s': %v", serviceName, err)
+	}
+	if len(result.Containers) == 0 {
+		return "", status.Errorf(codes.FailedPrecondition,
+			"IPC mode '%s': no running container of service '%s' found on the machine", mode, serviceName)
+	}
+
+	return container.IpcMode("container:" + result.Containers[0].ID), nil
+}
+
+// resolveUsernsMode returns the user namespace mode for the service container. Privileged containers and containers
+// sharing the host PID namespace can't run with user namespace remapping, so they're opted out automatically when
+// Docker is configured with userns-remap.
+func (s *Server) resolveUsernsMode(ctx context.Context, spec api.ContainerSpec) (container.UsernsMode, error) {
+	if spec.UsernsMode != "" {
+		return container.UsernsMode(spec.UsernsMode), nil
+	}
+
+	clusterRemap := false
+	if s.usernsRemap != nil {
+		enabled, err := s.usernsRemap(ctx)
+		if err != nil {
+			return "", status.Errorf(codes.Internal, "get cluster userns remap option: %v", err)
+		}
+		clusterRemap = enabled
+	}
+	needsHost := spec.Privileged || spec.PidMode == "host"
+	if !clusterRemap && !needsHost {
+		return "", nil
+	}
+
+	remapped, err := s.service.IsUsernsRemapEnabled(ctx)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "%v", err)
+	}
+	if !remapped {
+		if clusterRemap {
+			slog.Warn("User namespace remapping is enabled for the cluster but Docker on this machine " +
+				"isn't configured with userns-remap. The container will run without it.")
+		}
+		return "", nil
+	}
+	if needsHost {
+		return container.UsernsMode(api.UsernsModeHost), nil
+	}
+	return "", nil
+}
+
+// mergeUlimits returns the default ulimits overridden by the service ulimits with the same name.
+func mergeUlimits(defaults, service map[string]api.Ulimit) map[string]api.Ulimit {
+	if len(defaults) == 0 {
+		return service
+	}
+	merged := maps.Clone(defaults)
+	maps.Copy(merged, service)
+	return merged
+}
+
 func toDockerUlimits(ulimits map[string]api.Ulimit) []*units.Ulimit {
 	if len(ulimits) == 0 {
 		return nil
@@ -1107,6 +1710,9 @@ func (s *Server) RemoveServiceContainer(ctx context.Context, req *pb.RemoveConta
 		return nil, err
 	}
 
+	if _, err = s.db.ExecContext(ctx, `DELETE FROM container_restarts WHERE id = $1`, ctrID); err != nil {
+		slog.Error("Failed to remove container restarts from machine database.", "err", err, "id", ctrID)
+	}
 	if _, err = s.db.ExecContext(ctx, `DELETE FROM containers WHERE id = $1`, ctrID); err != nil {
 		slog.Error("Failed to remove container from machine database.", "err", err, "id", ctrID)
 		// Do not return an error because the container has already been removed from the Docker daemon.
@@ -1183,7 +1789,8 @@ func (s *Server) ContainerLogs(
 			// Only send heartbeat if no log entries have been sent since the last heartbeat interval or
 			// if no log entries have been sent at all for at least a heartbeat interval since starting.
 			if now.Sub(lastSent) < logsHeartbeatInterval ||
-				(lastSent.IsZero() && now.Sub(started) < logsHeartbeatInterval) {
+				(lastSent.IsZero() && now.Sub(started) < logsHeartbeatInterval) ||
+				s.faults.DropHeartbeat() {
 				continue
 			}
 
@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeUlimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		defaults map[string]api.Ulimit
+		service  map[string]api.Ulimit
+		want     map[string]api.Ulimit
+	}{
+		{
+			name: "no defaults",
+			service: map[string]api.Ulimit{
+				"nofile": {Soft: 1024, Hard: 2048},
+			},
+			want: map[string]api.Ulimit{
+				"nofile": {Soft: 1024, Hard: 2048},
+			},
+		},
+		{
+			name: "defaults only",
+			defaults: map[string]api.Ulimit{
+				"nofile": {Soft: 65536, Hard: 65536},
+			},
+			want: map[string]api.Ulimit{
+				"nofile": {Soft: 65536, Hard: 65536},
+			},
+		},
+		{
+			name: "service overrides defaults with same name",
+			defaults: map[string]api.Ulimit{
+				"nofile": {Soft: 65536, Hard: 65536},
+				"nproc":  {Soft: 4096, Hard: 4096},
+			},
+			service: map[string]api.Ulimit{
+				"nofile": {Soft: 1024, Hard: 2048},
+				"core":   {Soft: 0, Hard: 0},
+			},
+			want: map[string]api.Ulimit{
+				"nofile": {Soft: 1024, Hard: 2048},
+				"nproc":  {Soft: 4096, Hard: 4096},
+				"core":   {Soft: 0, Hard: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, mergeUlimits(tt.defaults, tt.service))
+		})
+	}
+}
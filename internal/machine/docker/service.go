@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -54,24 +55,60 @@ func (s *Service) InspectServiceContainer(ctx context.Context, nameOrID string)
 	}
 
 	serviceCtr.Container = api.Container{InspectResponse: ctr}
+	if serviceCtr.ServiceSpec, err = s.ContainerServiceSpec(ctx, ctr.ID); err != nil {
+		return serviceCtr, err
+	}
+	if serviceCtr.SupervisedRestarts, err = s.ContainerRestarts(ctx, ctr.ID); err != nil {
+		return serviceCtr, err
+	}
+
+	return serviceCtr, nil
+}
+
+// ContainerServiceSpec retrieves the full ServiceSpec of the container with the given ID from the machine database.
+// Unlike the spec in the cluster store, it includes sensitive data such as environment variables.
+func (s *Service) ContainerServiceSpec(ctx context.Context, id string) (api.ServiceSpec, error) {
+	var spec api.ServiceSpec
 
-	// Retrieve ServiceSpec from the machine database.
 	var specBytes []byte
-	err = s.db.QueryRowContext(ctx, `SELECT service_spec FROM containers WHERE id = $1`, ctr.ID).Scan(&specBytes)
+	err := s.db.QueryRowContext(ctx, `SELECT service_spec FROM containers WHERE id = $1`, id).Scan(&specBytes)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			// If this happens, there is a bug in the code, or someone manually removed the container from the DB,
 			// or created a managed container out of band or by previous uncloud installation.
-			return serviceCtr, fmt.Errorf("service spec not found for container '%s' in machine DB", ctr.ID)
+			return spec, fmt.Errorf("service spec not found for container '%s' in machine DB", id)
 		}
-		return serviceCtr, fmt.Errorf("get service spec for container '%s' from machine DB: %w", ctr.ID, err)
+		return spec, fmt.Errorf("get service spec for container '%s' from machine DB: %w", id, err)
 	}
 
-	if err = json.Unmarshal(specBytes, &serviceCtr.ServiceSpec); err != nil {
-		return serviceCtr, fmt.Errorf("unmarshal service spec for container '%s': %w", ctr.ID, err)
+	if err = json.Unmarshal(specBytes, &spec); err != nil {
+		return spec, fmt.Errorf("unmarshal service spec for container '%s': %w", id, err)
 	}
 
-	return serviceCtr, nil
+	return spec, nil
+}
+
+// ContainerRestarts returns the number of times the machine daemon restarted the container with the given ID after
+// Docker failed to restart it.
+func (s *Service) ContainerRestarts(ctx context.Context, id string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT count FROM container_restarts WHERE id = $1`, id).Scan(&count)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("get restarts of container '%s' from machine DB: %w", id, err)
+	}
+	return count, nil
+}
+
+// RecordContainerRestart increments the number of times the machine daemon restarted the container with
+// the given ID.
+func (s *Service) RecordContainerRestart(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO container_restarts (id, count) VALUES ($1, 1)
+		ON CONFLICT (id) DO UPDATE SET count = count + 1, restarted_at = datetime('subsecond')`, id)
+	if err != nil {
+		return fmt.Errorf("record restart of container '%s' in machine DB: %w", id, err)
+	}
+	return nil
 }
 
 // ListServiceContainersResult holds the result of listing service containers, split into regular
@@ -136,6 +173,17 @@ func (s *Service) IsContainerdImageStoreEnabled(ctx context.Context) (bool, erro
 	return strings.Contains(fmt.Sprintf("%s", info.DriverStatus), "containerd.snapshotter"), nil
 }
 
+// IsUsernsRemapEnabled checks if Docker is configured with user namespace remapping (userns-remap):
+// https://docs.docker.com/engine/security/userns-remap/
+func (s *Service) IsUsernsRemapEnabled(ctx context.Context) (bool, error) {
+	info, err := s.Client.Info(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get Docker info: %w", err)
+	}
+
+	return slices.Contains(info.SecurityOptions, "name=userns"), nil
+}
+
 type Images struct {
 	// Images is a list of images present in the Docker image store (either internal or containerd).
 	Images []image.Summary
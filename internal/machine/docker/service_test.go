@@ -0,0 +1,39 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestService_ContainerRestarts(t *testing.T) {
+	t.Parallel()
+
+	db, err := sqlx.Connect("sqlite", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	// A single connection keeps the in-memory database shared between queries.
+	db.SetMaxOpenConns(1)
+	_, err = db.Exec(`CREATE TABLE container_restarts (id TEXT NOT NULL PRIMARY KEY,
+		count INTEGER NOT NULL DEFAULT 0, restarted_at TIMESTAMP NOT NULL DEFAULT (datetime('subsecond')))`)
+	require.NoError(t, err)
+
+	s := NewService(nil, db)
+	ctx := context.Background()
+
+	count, err := s.ContainerRestarts(ctx, "web")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	require.NoError(t, s.RecordContainerRestart(ctx, "web"))
+	require.NoError(t, s.RecordContainerRestart(ctx, "web"))
+	require.NoError(t, s.RecordContainerRestart(ctx, "db"))
+
+	count, err = s.ContainerRestarts(ctx, "web")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,161 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/snapshot"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateVolumeSnapshot creates an instant snapshot of a local volume stored on a btrfs or ZFS filesystem.
+func (s *Server) CreateVolumeSnapshot(
+	ctx context.Context, req *pb.CreateVolumeSnapshotRequest,
+) (*pb.VolumeSnapshot, error) {
+	vol, err := s.snapshotVolume(ctx, req.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := s.snapshots.Create(ctx, vol.Name, vol.Mountpoint)
+	if err != nil {
+		return nil, snapshotStatusError(err)
+	}
+	return volumeSnapshotToProto(snap), nil
+}
+
+// ListVolumeSnapshots returns the snapshots of a volume or all local volumes on the machine. Volumes stored
+// on filesystems that don't support snapshots have no snapshots.
+func (s *Server) ListVolumeSnapshots(
+	ctx context.Context, req *pb.ListVolumeSnapshotsRequest,
+) (*pb.ListVolumeSnapshotsResponse, error) {
+	var volumes []*volume.Volume
+	if req.Volume != "" {
+		vol, err := s.snapshotVolume(ctx, req.Volume)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, &vol)
+	} else {
+		resp, err := s.client.VolumeList(ctx, volume.ListOptions{
+			Filters: filters.NewArgs(filters.Arg("driver", api.VolumeDriverLocal)),
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		volumes = resp.Volumes
+	}
+
+	var snapshots []*pb.VolumeSnapshot
+	for _, vol := range volumes {
+		volSnapshots, err := s.snapshots.List(ctx, vol.Name, vol.Mountpoint)
+		if err != nil {
+			if errors.Is(err, snapshot.ErrUnsupported) {
+				continue
+			}
+			return nil, snapshotStatusError(err)
+		}
+		for _, snap := range volSnapshots {
+			snapshots = append(snapshots, volumeSnapshotToProto(snap))
+		}
+	}
+
+	return &pb.ListVolumeSnapshotsResponse{
+		Messages: []*pb.MachineVolumeSnapshots{{Snapshots: snapshots}},
+	}, nil
+}
+
+// RestoreVolumeSnapshot replaces the data of a volume with the data from its snapshot. The volume must not be used
+// by running containers as its files are replaced in place.
+func (s *Server) RestoreVolumeSnapshot(ctx context.Context, req *pb.VolumeSnapshotRequest) (*emptypb.Empty, error) {
+	vol, err := s.snapshotVolume(ctx, req.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := s.client.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("volume", vol.Name)),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list containers using volume: %v", err)
+	}
+	if len(containers) > 0 {
+		var names []string
+		for _, c := range containers {
+			names = append(names, strings.TrimPrefix(c.Names[0], "/"))
+		}
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"volume '%s' is used by running containers: %s. Stop them before restoring the snapshot",
+			vol.Name, strings.Join(names, ", "))
+	}
+
+	if err = s.snapshots.Restore(ctx, vol.Name, vol.Mountpoint, req.Id); err != nil {
+		return nil, snapshotStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RemoveVolumeSnapshot removes a snapshot of a volume.
+func (s *Server) RemoveVolumeSnapshot(ctx context.Context, req *pb.VolumeSnapshotRequest) (*emptypb.Empty, error) {
+	vol, err := s.snapshotVolume(ctx, req.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.snapshots.Remove(ctx, vol.Name, vol.Mountpoint, req.Id); err != nil {
+		return nil, snapshotStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// snapshotVolume returns the local volume with the given name that can be snapshotted.
+func (s *Server) snapshotVolume(ctx context.Context, name string) (volume.Volume, error) {
+	if name == "" {
+		return volume.Volume{}, status.Error(codes.InvalidArgument, "volume name is required")
+	}
+
+	vol, err := s.client.VolumeInspect(ctx, name)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return vol, status.Error(codes.NotFound, err.Error())
+		}
+		return vol, status.Error(codes.Internal, err.Error())
+	}
+	if vol.Driver != api.VolumeDriverLocal {
+		return vol, status.Errorf(codes.FailedPrecondition,
+			"volume '%s' uses driver '%s': only volumes with the '%s' driver can be snapshotted",
+			vol.Name, vol.Driver, api.VolumeDriverLocal)
+	}
+	return vol, nil
+}
+
+func snapshotStatusError(err error) error {
+	switch {
+	case errors.Is(err, snapshot.ErrUnsupported):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, snapshot.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("volume snapshot: %v", err))
+	}
+}
+
+func volumeSnapshotToProto(snap api.VolumeSnapshot) *pb.VolumeSnapshot {
+	return &pb.VolumeSnapshot{
+		Id:      snap.ID,
+		Volume:  snap.Volume,
+		Backend: snap.Backend,
+		Created: timestamppb.New(snap.Created),
+	}
+}
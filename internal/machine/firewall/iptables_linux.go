@@ -42,7 +42,26 @@ func ConfigureIptablesChains(machineIP netip.Addr, wgPort int) error {
 		"--dport", strconv.Itoa(constants.UnregistryPort),
 		"-j", "ACCEPT",
 	}
-	for _, rule := range [][]string{acceptUnregistryRule, acceptWireGuardRule} {
+	// Allow cluster machines to connect to the mesh proxy on the machine to reach local mTLS-enabled containers.
+	acceptMeshRule := []string{
+		"-i", network.WireGuardInterfaceName,
+		"-d", machineIP.String(),
+		"-p", "tcp",
+		"--dport", strconv.Itoa(constants.MeshPort),
+		"-j", "ACCEPT",
+	}
+	// Allow local containers to connect to the mesh proxy that their traffic to remote mTLS-enabled containers is
+	// redirected to.
+	acceptMeshRedirectRule := []string{
+		"!", "-i", network.WireGuardInterfaceName,
+		"-d", machineIP.String(),
+		"-p", "tcp",
+		"--dport", strconv.Itoa(constants.MeshRedirectPort),
+		"-j", "ACCEPT",
+	}
+	for _, rule := range [][]string{
+		acceptUnregistryRule, acceptMeshRule, acceptMeshRedirectRule, acceptWireGuardRule,
+	} {
 		if err := ipt4.ProgramRule(iptables.Filter, UncloudInputChain, iptables.Insert, rule); err != nil {
 			return fmt.Errorf("insert iptables rule '%s': %w", strings.Join(rule, " "), err)
 		}
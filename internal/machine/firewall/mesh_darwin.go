@@ -0,0 +1,16 @@
+package firewall
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// SyncMeshRules is a stub for Darwin.
+func SyncMeshRules(subnet netip.Prefix, redirectPort int, remoteIPs, localIPs []netip.Addr) error {
+	return fmt.Errorf("not supported on Darwin")
+}
+
+// CleanupMeshRules is a stub for Darwin.
+func CleanupMeshRules(subnet netip.Prefix) error {
+	return fmt.Errorf("not supported on Darwin")
+}
@@ -0,0 +1,120 @@
+package firewall
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/libnetwork/iptables"
+	"github.com/psviderski/uncloud/internal/machine/network"
+)
+
+const (
+	// MeshNatChain redirects the traffic from local containers to remote mTLS-enabled containers to the local mesh
+	// proxy that wraps it in mTLS.
+	MeshNatChain = "UNCLOUD-MESH"
+	// MeshForwardChain rejects plaintext traffic from other machines to local mTLS-enabled containers. The traffic
+	// must come through the mesh proxy instead.
+	MeshForwardChain = "UNCLOUD-MESH-FORWARD"
+)
+
+// SyncMeshRules replaces the mesh iptables rules with the rules for the given mTLS-enabled container IPs.
+// remoteIPs are the IPs of the containers running on other machines. The TCP traffic to them from the machine subnet
+// is redirected to the mesh proxy listening on redirectPort. localIPs are the IPs of the containers running on this
+// machine that only accept traffic from other machines via the mesh proxy.
+func SyncMeshRules(subnet netip.Prefix, redirectPort int, remoteIPs, localIPs []netip.Addr) error {
+	ipt := iptables.GetIptable(iptables.IPv4)
+
+	for _, c := range []struct {
+		table iptables.Table
+		name  string
+	}{
+		{iptables.Nat, MeshNatChain},
+		{iptables.Filter, MeshForwardChain},
+	} {
+		if _, err := ipt.NewChain(c.name, c.table); err != nil {
+			return fmt.Errorf("create iptables chain '%s': %w", c.name, err)
+		}
+		if err := ipt.RawCombinedOutput("-t", string(c.table), "-F", c.name); err != nil {
+			return fmt.Errorf("flush iptables chain '%s': %w", c.name, err)
+		}
+	}
+
+	for _, ip := range remoteIPs {
+		rule := []string{
+			"--src", subnet.String(),
+			"--dst", ip.String(),
+			"--protocol", "tcp",
+			"-j", "REDIRECT",
+			"--to-ports", strconv.Itoa(redirectPort),
+		}
+		if err := ipt.ProgramRule(iptables.Nat, MeshNatChain, iptables.Append, rule); err != nil {
+			return fmt.Errorf("append iptables rule '%s': %w", strings.Join(rule, " "), err)
+		}
+	}
+	for _, ip := range localIPs {
+		rule := []string{
+			"--in-interface", network.WireGuardInterfaceName,
+			"--dst", ip.String(),
+			"--protocol", "tcp",
+			"-j", "REJECT",
+			"--reject-with", "tcp-reset",
+		}
+		if err := ipt.ProgramRule(iptables.Filter, MeshForwardChain, iptables.Append, rule); err != nil {
+			return fmt.Errorf("append iptables rule '%s': %w", strings.Join(rule, " "), err)
+		}
+	}
+
+	natJumpRule := []string{"--src", subnet.String(), "--protocol", "tcp", "-j", MeshNatChain}
+	if err := ipt.ProgramRule(iptables.Nat, "PREROUTING", iptables.Insert, natJumpRule); err != nil {
+		return fmt.Errorf("insert iptables rule '%s': %w", strings.Join(natJumpRule, " "), err)
+	}
+	// Delete and reinsert the jump rule to ensure it's at the top of the DOCKER-USER chain before the rule accepting
+	// all traffic from the WireGuard network to the Docker bridge.
+	forwardJumpRule := []string{"--in-interface", network.WireGuardInterfaceName, "-j", MeshForwardChain}
+	if err := ipt.ProgramRule(iptables.Filter, DockerUserChain, iptables.Delete, forwardJumpRule); err != nil {
+		return fmt.Errorf("delete iptables rule '%s': %w", strings.Join(forwardJumpRule, " "), err)
+	}
+	if err := ipt.ProgramRule(iptables.Filter, DockerUserChain, iptables.Insert, forwardJumpRule); err != nil {
+		return fmt.Errorf("insert iptables rule '%s': %w", strings.Join(forwardJumpRule, " "), err)
+	}
+
+	return nil
+}
+
+// CleanupMeshRules removes the mesh iptables chains and the jump rules to them created by SyncMeshRules.
+func CleanupMeshRules(subnet netip.Prefix) error {
+	ipt := iptables.GetIptable(iptables.IPv4)
+
+	natJumpRule := []string{"--src", subnet.String(), "--protocol", "tcp", "-j", MeshNatChain}
+	if err := ipt.ProgramRule(iptables.Nat, "PREROUTING", iptables.Delete, natJumpRule); err != nil {
+		return fmt.Errorf("delete iptables rule '%s': %w", strings.Join(natJumpRule, " "), err)
+	}
+	forwardJumpRule := []string{"--in-interface", network.WireGuardInterfaceName, "-j", MeshForwardChain}
+	if err := ipt.ProgramRule(iptables.Filter, DockerUserChain, iptables.Delete, forwardJumpRule); err != nil {
+		return fmt.Errorf("delete iptables rule '%s': %w", strings.Join(forwardJumpRule, " "), err)
+	}
+
+	for _, c := range []struct {
+		table iptables.Table
+		name  string
+	}{
+		{iptables.Nat, MeshNatChain},
+		{iptables.Filter, MeshForwardChain},
+	} {
+		if !ipt.ExistChain(c.name, c.table) {
+			continue
+		}
+		if err := ipt.RawCombinedOutput("-t", string(c.table), "-F", c.name); err != nil {
+			return fmt.Errorf("flush iptables chain '%s': %w", c.name, err)
+		}
+		if err := ipt.RawCombinedOutput("-t", string(c.table), "-X", c.name); err != nil {
+			return fmt.Errorf("delete iptables chain '%s': %w", c.name, err)
+		}
+		slog.Info("Deleted iptables chain.", "chain", c.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+// Package global keeps global services running on every eligible machine as machines join and leave the cluster.
+package global
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client"
+	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// CheckInterval is the default interval for checking if global services are missing on any machines.
+	CheckInterval = 30 * time.Second
+	// retryInterval is the minimum time between deployments of a service after a failed one.
+	retryInterval = 5 * time.Minute
+)
+
+// Machines lists the cluster machines with their membership states.
+type Machines interface {
+	ListMachines(ctx context.Context, _ *emptypb.Empty) (*pb.ListMachinesResponse, error)
+}
+
+// Specs retrieves the full service specs of the containers on the machine. The specs in the cluster store can't be
+// deployed as they don't include sensitive data such as environment variables.
+type Specs interface {
+	ContainerServiceSpec(ctx context.Context, containerID string) (api.ServiceSpec, error)
+}
+
+// DeployFunc deploys a service to the given spec.
+type DeployFunc func(ctx context.Context, spec api.ServiceSpec) error
+
+// Controller deploys global services to the machines that joined the cluster or became eligible to run them
+// after the service was deployed. Each service is handled by only one machine among the machines running the service
+// containers: a control-plane machine if there is one, then the one with the lowest ID. Machines that leave
+// the cluster don't need any action as their containers leave with them.
+type Controller struct {
+	machineID string
+	store     *store.Store
+	machines  Machines
+	specs     Specs
+	deploy    DeployFunc
+	log       *slog.Logger
+	// checkInterval is the interval for checking if global services are missing on any machines.
+	checkInterval time.Duration
+	// failed is the time of the last failed deployment of each service by ID.
+	failed map[string]time.Time
+}
+
+func NewController(
+	machineID string,
+	store *store.Store,
+	machines Machines,
+	specs Specs,
+	deploy DeployFunc,
+	checkInterval time.Duration,
+) *Controller {
+	return &Controller{
+		machineID:     machineID,
+		store:         store,
+		machines:      machines,
+		specs:         specs,
+		deploy:        deploy,
+		log:           slog.With("component", "global-service-controller"),
+		checkInterval: checkInterval,
+		failed:        make(map[string]time.Time),
+	}
+}
+
+func (c *Controller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.reconcile(ctx); err != nil {
+				c.log.Error("Failed to check global services.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Controller) reconcile(ctx context.Context) error {
+	records, err := c.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+	resp, err := c.machines.ListMachines(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+
+	var available []*pb.MachineInfo
+	controlPlane := make(map[string]bool)
+	for _, m := range resp.Machines {
+		if m.State != pb.MachineMember_DOWN {
+			available = append(available, m.Machine)
+		}
+		if api.HasMachineRole(m.Machine, api.MachineRoleControlPlane) {
+			controlPlane[m.Machine.Id] = true
+		}
+	}
+
+	now := time.Now()
+	for _, svc := range coordinatedServices(records, c.machineID, controlPlane) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if failed, ok := c.failed[svc.id]; ok && now.Sub(failed) < retryInterval {
+			continue
+		}
+
+		missing := missingMachines(svc, available)
+		if len(missing) == 0 {
+			continue
+		}
+
+		c.log.Info("Deploying global service to machines without its containers.",
+			"service", svc.spec.Name, "machines", missing)
+		if err = c.deployService(ctx, svc); err != nil {
+			c.failed[svc.id] = now
+			c.log.Error("Failed to deploy global service.", "service", svc.spec.Name, "err", err)
+			continue
+		}
+		delete(c.failed, svc.id)
+		c.log.Info("Global service deployed.", "service", svc.spec.Name)
+	}
+	return nil
+}
+
+// deployService deploys the service with the full spec of its most recently created container on this machine.
+func (c *Controller) deployService(ctx context.Context, svc service) error {
+	spec, err := c.specs.ContainerServiceSpec(ctx, svc.containerID)
+	if err != nil {
+		return fmt.Errorf("get service spec: %w", err)
+	}
+	return c.deploy(ctx, spec)
+}
+
+// service is a global service coordinated by this machine.
+type service struct {
+	id string
+	// spec is the service spec from the cluster store without sensitive data.
+	spec api.ServiceSpec
+	// containerID is the ID of the most recently created service container on this machine.
+	containerID string
+	// machineIDs are the IDs of the machines running the service containers.
+	machineIDs []string
+}
+
+// coordinatedServices returns the global services that should be handled by the machine with the given ID.
+// A service is coordinated by one of the machines running its containers. Machines in the controlPlane set are
+// preferred, then the machine with the lowest ID. The Caddy service is skipped as 'uc machine add' deploys it
+// to new machines along with updating the cluster domain records.
+func coordinatedServices(
+	records []store.ContainerRecord, machineID string, controlPlane map[string]bool,
+) []service {
+	byService := make(map[string][]store.ContainerRecord)
+	for _, r := range records {
+		spec := r.Container.ServiceSpec
+		if spec.Mode != api.ServiceModeGlobal || spec.Name == client.CaddyServiceName {
+			continue
+		}
+		id := r.Container.ServiceID()
+		byService[id] = append(byService[id], r)
+	}
+
+	var services []service
+	for id, rs := range byService {
+		coordinator := slices.MinFunc(rs, func(a, b store.ContainerRecord) int {
+			if controlPlane[a.MachineID] != controlPlane[b.MachineID] {
+				if controlPlane[a.MachineID] {
+					return -1
+				}
+				return 1
+			}
+			return cmp.Compare(a.MachineID, b.MachineID)
+		}).MachineID
+		if coordinator != machineID {
+			continue
+		}
+
+		// Take the spec from the most recently created local container as a deployment may have failed midway.
+		var latest *store.ContainerRecord
+		svc := service{id: id}
+		for i := range rs {
+			if !slices.Contains(svc.machineIDs, rs[i].MachineID) {
+				svc.machineIDs = append(svc.machineIDs, rs[i].MachineID)
+			}
+			if rs[i].MachineID != machineID {
+				continue
+			}
+			if latest == nil || rs[i].Container.CreatedTime().After(latest.Container.CreatedTime()) {
+				latest = &rs[i]
+			}
+		}
+		svc.spec = latest.Container.ServiceSpec
+		svc.containerID = latest.Container.ID
+		services = append(services, svc)
+	}
+
+	slices.SortFunc(services, func(a, b service) int {
+		return cmp.Compare(a.spec.Name, b.spec.Name)
+	})
+	return services
+}
+
+// missingMachines returns the names of the available machines that satisfy the placement constraints
+// of the service but don't run its containers. Services with named volumes are not extended automatically
+// as the volumes must be created on the new machines first.
+func missingMachines(svc service, available []*pb.MachineInfo) []string {
+	state := &scheduler.ClusterState{}
+	for _, m := range available {
+		state.Machines = append(state.Machines, &scheduler.Machine{Info: m})
+	}
+	eligible, err := scheduler.NewServiceScheduler(state, svc.spec).EligibleMachines()
+	if err != nil {
+		return nil
+	}
+
+	var missing []string
+	for _, m := range eligible {
+		if !slices.Contains(svc.machineIDs, m.Info.Id) {
+			missing = append(missing, m.Info.Name)
+		}
+	}
+	return missing
+}
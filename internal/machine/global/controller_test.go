@@ -0,0 +1,171 @@
+package global
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecord(machineID, serviceID string, spec api.ServiceSpec, created time.Time) store.ContainerRecord {
+	return store.ContainerRecord{
+		MachineID: machineID,
+		Container: api.ServiceContainer{
+			Container: api.Container{InspectResponse: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:      machineID + "-" + spec.Name,
+					Created: created.Format(time.RFC3339Nano),
+				},
+				Config: &container.Config{Labels: map[string]string{
+					api.LabelServiceID:   serviceID,
+					api.LabelServiceName: spec.Name,
+				}},
+			}},
+			ServiceSpec: spec,
+		},
+	}
+}
+
+func TestCoordinatedServices(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	exporter := api.ServiceSpec{Name: "exporter", Mode: api.ServiceModeGlobal}
+	exporterNew := api.ServiceSpec{Name: "exporter", Mode: api.ServiceModeGlobal, Replicas: 1}
+	records := []store.ContainerRecord{
+		// exporter runs on machines a and b, a coordinates it.
+		testRecord("b", "exporter-id", exporter, now),
+		testRecord("a", "exporter-id", exporter, now.Add(-time.Hour)),
+		testRecord("a", "exporter-id", exporterNew, now),
+		// shipper runs only on machine b.
+		testRecord("b", "shipper-id", api.ServiceSpec{Name: "shipper", Mode: api.ServiceModeGlobal}, now),
+		// web is a replicated service.
+		testRecord("a", "web-id", api.ServiceSpec{Name: "web", Mode: api.ServiceModeReplicated}, now),
+		// caddy is deployed to new machines by 'uc machine add'.
+		testRecord("a", "caddy-id", api.ServiceSpec{Name: "caddy", Mode: api.ServiceModeGlobal}, now),
+	}
+
+	services := coordinatedServices(records, "a", nil)
+	require.Len(t, services, 1)
+	assert.Equal(t, "exporter-id", services[0].id)
+	assert.Equal(t, exporterNew, services[0].spec, "should use the most recently created local container")
+	assert.ElementsMatch(t, []string{"a", "b"}, services[0].machineIDs)
+	assert.Equal(t, "a-exporter", services[0].containerID)
+
+	services = coordinatedServices(records, "b", nil)
+	require.Len(t, services, 1)
+	assert.Equal(t, "shipper", services[0].spec.Name)
+
+	// Control-plane machine b coordinates all global services it runs.
+	controlPlane := map[string]bool{"b": true}
+	assert.Empty(t, coordinatedServices(records, "a", controlPlane))
+	services = coordinatedServices(records, "b", controlPlane)
+	require.Len(t, services, 2)
+	assert.Equal(t, "exporter", services[0].spec.Name)
+	assert.Equal(t, "shipper", services[1].spec.Name)
+}
+
+func TestMissingMachines(t *testing.T) {
+	t.Parallel()
+
+	available := []*pb.MachineInfo{
+		{Id: "a", Name: "machine-a"},
+		{Id: "b", Name: "machine-b"},
+		{Id: "c", Name: "machine-c"},
+	}
+
+	tests := []struct {
+		name string
+		svc  service
+		want []string
+	}{
+		{
+			name: "new machine",
+			svc: service{
+				spec:       api.ServiceSpec{Name: "exporter", Mode: api.ServiceModeGlobal},
+				machineIDs: []string{"a", "b"},
+			},
+			want: []string{"machine-c"},
+		},
+		{
+			name: "running on all machines",
+			svc: service{
+				spec:       api.ServiceSpec{Name: "exporter", Mode: api.ServiceModeGlobal},
+				machineIDs: []string{"a", "b", "c"},
+			},
+		},
+		{
+			name: "new machine not in placement",
+			svc: service{
+				spec: api.ServiceSpec{
+					Name:      "exporter",
+					Mode:      api.ServiceModeGlobal,
+					Placement: api.Placement{Machines: []string{"machine-a", "machine-b"}},
+				},
+				machineIDs: []string{"a", "b"},
+			},
+		},
+		{
+			name: "named volume",
+			svc: service{
+				spec: api.ServiceSpec{
+					Name: "shipper",
+					Mode: api.ServiceModeGlobal,
+					Container: api.ContainerSpec{
+						VolumeMounts: []api.VolumeMount{{VolumeName: "data", ContainerPath: "/data"}},
+					},
+					Volumes: []api.VolumeSpec{{Name: "data", Type: api.VolumeTypeVolume}},
+				},
+				machineIDs: []string{"a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, missingMachines(tt.svc, available))
+		})
+	}
+}
+
+// fakeSpecs returns the full service specs of containers as stored in the machine database.
+type fakeSpecs map[string]api.ServiceSpec
+
+func (s fakeSpecs) ContainerServiceSpec(_ context.Context, containerID string) (api.ServiceSpec, error) {
+	spec, ok := s[containerID]
+	if !ok {
+		return api.ServiceSpec{}, errors.New("not found")
+	}
+	return spec, nil
+}
+
+func TestControllerDeployService(t *testing.T) {
+	t.Parallel()
+
+	// The spec from the cluster store doesn't include the environment variables.
+	storeSpec := api.ServiceSpec{Name: "exporter", Mode: api.ServiceModeGlobal}
+	fullSpec := storeSpec.Clone()
+	fullSpec.Container.Env = api.EnvVars{"API_TOKEN": "secret"}
+
+	var deployed api.ServiceSpec
+	deploy := func(_ context.Context, spec api.ServiceSpec) error {
+		deployed = spec
+		return nil
+	}
+	ctrl := NewController("a", nil, nil, fakeSpecs{"a-exporter": fullSpec}, deploy, CheckInterval)
+
+	svc := service{id: "exporter-id", spec: storeSpec, containerID: "a-exporter", machineIDs: []string{"a"}}
+	require.NoError(t, ctrl.deployService(context.Background(), svc))
+	assert.Equal(t, fullSpec, deployed, "should deploy the full spec with the environment variables")
+
+	svc.containerID = "unknown"
+	assert.ErrorContains(t, ctrl.deployService(context.Background(), svc), "get service spec")
+}
@@ -0,0 +1,115 @@
+// Package hostupdate updates the OS packages on the machine host and detects if the host needs a reboot to apply
+// the updates.
+package hostupdate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// AptCommand updates the packages on Debian-based hosts keeping the modified config files.
+	AptCommand = "apt-get update && DEBIAN_FRONTEND=noninteractive apt-get -y " +
+		"-o Dpkg::Options::=--force-confdef -o Dpkg::Options::=--force-confold upgrade"
+	// DnfCommand updates the packages on RHEL-based hosts.
+	DnfCommand = "dnf -y upgrade --refresh"
+
+	// unitName is the name of the transient systemd unit the update command runs in.
+	unitName = "uncloud-host-update"
+	// rebootRequiredFile is created by Debian-based hosts when an update requires a reboot.
+	rebootRequiredFile = "/var/run/reboot-required"
+)
+
+// Updater runs the OS package update command on the host.
+type Updater struct {
+	lookPath func(file string) (string, error)
+	// rebootRequiredFile is overridden in tests.
+	rebootRequiredFile string
+}
+
+func NewUpdater() *Updater {
+	return &Updater{
+		lookPath:           exec.LookPath,
+		rebootRequiredFile: rebootRequiredFile,
+	}
+}
+
+// DefaultCommand returns the update command for the package manager available on the host or an empty string
+// if neither apt-get nor dnf is found.
+func (u *Updater) DefaultCommand() string {
+	if _, err := u.lookPath("apt-get"); err == nil {
+		return AptCommand
+	}
+	if _, err := u.lookPath("dnf"); err == nil {
+		return DnfCommand
+	}
+	return ""
+}
+
+// Run runs the shell command and returns the result. The machine daemon runs in a systemd sandbox with read-only
+// system directories so the command runs in a transient systemd unit outside the sandbox if systemd is available.
+func (u *Updater) Run(ctx context.Context, command string) api.HostUpdate {
+	update := api.HostUpdate{
+		Command:   command,
+		StartedAt: time.Now().UTC(),
+	}
+
+	var cmd *exec.Cmd
+	if _, err := u.lookPath("systemd-run"); err == nil {
+		cmd = exec.CommandContext(ctx, "systemd-run", "--unit", unitName, "--wait", "--pipe", "--collect",
+			"--quiet", "--", "sh", "-c", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+	update.FinishedAt = time.Now().UTC()
+	update.Output = tail(out.Bytes(), api.MaxHostUpdateOutput)
+	update.ExitCode = cmd.ProcessState.ExitCode()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			update.Error = fmt.Sprintf("update command failed with exit code %d", exitErr.ExitCode())
+		} else {
+			update.ExitCode = -1
+			update.Error = fmt.Sprintf("run update command: %v", err)
+		}
+	}
+	update.RebootRequired = u.RebootRequired(ctx)
+
+	return update
+}
+
+// RebootRequired returns true if the host needs a reboot to apply the updates, e.g. a new kernel. It's detected
+// with the reboot-required file on Debian-based hosts and the needs-restarting tool on RHEL-based hosts.
+func (u *Updater) RebootRequired(ctx context.Context) bool {
+	if _, err := os.Stat(u.rebootRequiredFile); err == nil {
+		return true
+	}
+	if path, err := u.lookPath("needs-restarting"); err == nil {
+		// needs-restarting -r exits with 1 if a reboot is required.
+		var exitErr *exec.ExitError
+		if err = exec.CommandContext(ctx, path, "-r").Run(); errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// tail returns the last n bytes of the output as a string.
+func tail(out []byte, n int) string {
+	if len(out) <= n {
+		return string(out)
+	}
+	return "..." + string(out[len(out)-n:])
+}
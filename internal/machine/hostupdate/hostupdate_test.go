@@ -0,0 +1,63 @@
+package hostupdate
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// testUpdater returns an updater that runs the commands with sh directly and finds only the given tools.
+func testUpdater(t *testing.T, tools ...string) *Updater {
+	return &Updater{
+		lookPath: func(file string) (string, error) {
+			for _, tool := range tools {
+				if tool == file {
+					return "/usr/bin/" + file, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		},
+		rebootRequiredFile: filepath.Join(t.TempDir(), "reboot-required"),
+	}
+}
+
+func TestUpdater_DefaultCommand(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, AptCommand, testUpdater(t, "apt-get", "dnf").DefaultCommand())
+	assert.Equal(t, DnfCommand, testUpdater(t, "dnf").DefaultCommand())
+	assert.Empty(t, testUpdater(t).DefaultCommand())
+}
+
+func TestUpdater_Run(t *testing.T) {
+	t.Parallel()
+
+	u := testUpdater(t)
+	update := u.Run(context.Background(), "echo upgraded; echo warning >&2")
+	assert.Equal(t, 0, update.ExitCode)
+	assert.Empty(t, update.Error)
+	assert.Equal(t, "upgraded\nwarning\n", update.Output)
+	assert.False(t, update.RebootRequired)
+	assert.False(t, update.FinishedAt.Before(update.StartedAt))
+
+	assert.NoError(t, os.WriteFile(u.rebootRequiredFile, nil, 0o644))
+	update = u.Run(context.Background(), "exit 100")
+	assert.Equal(t, 100, update.ExitCode)
+	assert.Equal(t, "update command failed with exit code 100", update.Error)
+	assert.True(t, update.RebootRequired)
+}
+
+func TestUpdater_Run_TruncatesOutput(t *testing.T) {
+	t.Parallel()
+
+	update := testUpdater(t).Run(context.Background(), "head -c 10000 /dev/zero | tr '\\0' a; echo end")
+	assert.Len(t, update.Output, api.MaxHostUpdateOutput+3)
+	assert.True(t, strings.HasPrefix(update.Output, "..."))
+	assert.True(t, strings.HasSuffix(update.Output, "aend\n"))
+}
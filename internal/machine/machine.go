@@ -5,35 +5,57 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
+	"net/http"
 	"net/netip"
 	"os"
+	"os/exec"
 	"os/user"
 	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/containerd/errdefs"
+	dnetwork "github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/sockets"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/psviderski/uncloud/internal/corrosion"
 	"github.com/psviderski/uncloud/internal/docker"
+	"github.com/psviderski/uncloud/internal/fault"
 	"github.com/psviderski/uncloud/internal/fs"
 	"github.com/psviderski/uncloud/internal/grpcversion"
 	"github.com/psviderski/uncloud/internal/journal"
+	"github.com/psviderski/uncloud/internal/log"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	apiproxy "github.com/psviderski/uncloud/internal/machine/api/proxy"
+	"github.com/psviderski/uncloud/internal/machine/autoupdate"
+	"github.com/psviderski/uncloud/internal/machine/boot"
 	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
 	"github.com/psviderski/uncloud/internal/machine/cluster"
 	"github.com/psviderski/uncloud/internal/machine/constants"
 	"github.com/psviderski/uncloud/internal/machine/corroservice"
 	"github.com/psviderski/uncloud/internal/machine/dns"
 	machinedocker "github.com/psviderski/uncloud/internal/machine/docker"
+	"github.com/psviderski/uncloud/internal/machine/global"
+	"github.com/psviderski/uncloud/internal/machine/hostupdate"
+	"github.com/psviderski/uncloud/internal/machine/mdns"
 	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/offline"
+	"github.com/psviderski/uncloud/internal/machine/preflight"
+	"github.com/psviderski/uncloud/internal/machine/profile"
+	"github.com/psviderski/uncloud/internal/machine/restart"
 	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/internal/machine/throttle"
+	"github.com/psviderski/uncloud/internal/machine/vip"
+	"github.com/psviderski/uncloud/internal/policy"
+	"github.com/psviderski/uncloud/internal/version"
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/unregistry"
 	"github.com/siderolabs/grpc-proxy/proxy"
@@ -49,11 +71,19 @@ import (
 
 const (
 	DefaultMachineSockPath = "/run/uncloud/machine.sock"
-	DefaultUncloudSockPath = "/run/uncloud/uncloud.sock"
+	DefaultUncloudSockPath = constants.DefaultUncloudSockPath
 	DefaultSockGroup       = "uncloud"
 	// DefaultCaddyAdminSockPath is the default path to the Caddy admin socket for validating the generated Caddy
 	// reverse proxy configuration.
 	DefaultCaddyAdminSockPath = "/run/uncloud/caddy/admin.sock"
+
+	// shutdownTimeout is the maximum time to wait for the in-flight API requests to complete when stopping
+	// the machine. It's well below the default systemd stop timeout of 90 seconds.
+	shutdownTimeout = 30 * time.Second
+	// rebootDelay is the delay before rebooting the machine host to let the Reboot call return to the client.
+	rebootDelay = 2 * time.Second
+	// hostUpdateTimeout is the maximum time to run the OS package update command.
+	hostUpdateTimeout = time.Hour
 )
 
 type Config struct {
@@ -80,6 +110,25 @@ type Config struct {
 	CaddyConfigDir string
 	// DNSUpstreams specifies the upstream DNS servers for the embedded internal DNS server.
 	DNSUpstreams []netip.AddrPort
+	// GRPCCompression is the compression for API requests proxied to other machines: none, gzip, or zstd.
+	// All machines must run a version that supports the compression. Default is none.
+	GRPCCompression string
+	// FaultInjection allows injecting faults into the daemon through the machine API for failure testing.
+	// It must never be enabled on production machines.
+	FaultInjection bool
+	// PolicyFile is the path to the admission policy file with the rules that service specs must pass before their
+	// containers are created on the machine. Default is DataDir/policy.yaml. The policy is disabled if the file
+	// doesn't exist.
+	PolicyFile string
+	// Policy overrides the admission policy loaded from PolicyFile. Custom daemons can set it to plug in their own
+	// checks.
+	Policy policy.Policy
+	// MetricsAddr is the address to serve Prometheus metrics on at /metrics, e.g. 127.0.0.1:9100.
+	// Metrics aren't served if empty.
+	MetricsAddr string
+	// Throttle limits the API request rate of each client and the number of heavy operations, such as image pulls,
+	// running concurrently on the machine. The zero value disables the limits.
+	Throttle throttle.Config
 }
 
 // SetDefaults returns a new Config with default values set where not provided.
@@ -104,6 +153,12 @@ func (c *Config) SetDefaults() (*Config, error) {
 		}
 		cfg.DockerClient = cli
 	}
+	if cfg.PolicyFile == "" {
+		cfg.PolicyFile = filepath.Join(cfg.DataDir, "policy.yaml")
+	}
+	if cfg.Policy == nil {
+		cfg.Policy = policy.NewFile(cfg.PolicyFile)
+	}
 	if cfg.CorrosionDir == "" {
 		cfg.CorrosionDir = filepath.Join(cfg.DataDir, "corrosion")
 	}
@@ -189,6 +244,12 @@ type Machine struct {
 	// It proxies requests to the local or remote machine API servers depending on the request targets
 	// and aggregates responses.
 	localProxyServer *grpc.Server
+	// faults are the failures injected into the daemon for testing.
+	faults *fault.Injector
+	// hostUpdater runs the OS package update command on the machine host.
+	hostUpdater *hostupdate.Updater
+	// hostUpdating is true while the host packages are being updated.
+	hostUpdating atomic.Bool
 
 	// mu protects the Machine from concurrent reads and writes.
 	mu sync.RWMutex
@@ -227,11 +288,24 @@ func NewMachine(config *Config) (*Machine, error) {
 		}
 	}
 
+	settings := profile.Get(state.Profile)
+	log.DaemonLevel.Set(settings.LogLevel)
+	if state.Profile != "" {
+		slog.Info("Using machine resource profile.", "profile", state.Profile)
+	}
+
 	corro, err := corrosion.NewAPIClient(config.CorrosionAPIAddr)
 	if err != nil {
 		return nil, fmt.Errorf("create corrosion API client: %w", err)
 	}
-	corroStore := store.New(corro)
+	backend, err := newStoreBackend(state.StoreBackend, config.DataDir, corro)
+	if err != nil {
+		return nil, fmt.Errorf("create cluster store backend: %w", err)
+	}
+	if state.StoreBackend != "" {
+		slog.Info("Using cluster store backend.", "backend", state.StoreBackend)
+	}
+	corroStore := store.New(backend)
 	corroAdmin, err := corrosion.NewAdminClient(config.CorrosionAdminSockPath)
 	if err != nil {
 		return nil, fmt.Errorf("create corrosion admin client: %w", err)
@@ -243,7 +317,7 @@ func NewMachine(config *Config) (*Machine, error) {
 
 	// Init dependencies for a gRPC Docker server that proxies requests to the local Docker daemon.
 	dbFilePath := filepath.Join(config.DataDir, DBFileName)
-	db, err := NewDB(dbFilePath)
+	db, err := NewDB(dbFilePath, settings.DBCacheSizeKiB)
 	if err != nil {
 		return nil, fmt.Errorf("init machine database: %w", err)
 	}
@@ -252,6 +326,7 @@ func NewMachine(config *Config) (*Machine, error) {
 	// Init a local gRPC proxy server that proxies requests to the local or remote machine API servers.
 	mapper := apiproxy.NewCorrosionMapper(corroStore)
 	proxyDirector := apiproxy.NewDirector(config.MachineSockPath, constants.MachineAPIPort, mapper)
+	proxyDirector.SetCompression(config.GRPCCompression)
 	localProxyServer := grpc.NewServer(
 		grpc.ForceServerCodecV2(proxy.Codec()),
 		grpc.UnaryInterceptor(grpcversion.ServerUnaryInterceptor),
@@ -273,6 +348,8 @@ func NewMachine(config *Config) (*Machine, error) {
 		dockerService:    dockerService,
 		localProxyServer: localProxyServer,
 		proxyDirector:    proxyDirector,
+		faults:           fault.NewInjector(config.FaultInjection),
+		hostUpdater:      hostupdate.NewUpdater(),
 	}
 
 	// Machine IP will only be available after the machine is initialised as a cluster member so wrap it in a function.
@@ -286,9 +363,19 @@ func NewMachine(config *Config) (*Machine, error) {
 	m.dockerServer = machinedocker.NewServer(dockerService, db, internalDNSIP, machineID, machinedocker.ServerOptions{
 		NetworkReady:        m.IsNetworkReady,
 		WaitForNetworkReady: m.WaitForNetworkReady,
+		DefaultUlimits:      c.DefaultUlimits,
+		AllowedSysctls:      c.AllowedSysctls,
+		UsernsRemap:         c.UsernsRemap,
+		OpenSealedSecret:    c.OpenSealedSecret,
+		ClusterSecretValue:  c.SecretValue,
+		SecretMaxMode:       c.SecretMaxMode,
+		MachineFacts:        m.templateFacts,
+		Faults:              m.faults,
+		Policy:              config.Policy,
 	})
-	caddyServer := caddyconfig.NewServer(caddyconfig.NewService(config.CaddyConfigDir))
-	m.localMachineServer = newGRPCServer(m, c, m.dockerServer, caddyServer)
+	caddyServer := caddyconfig.NewServer(caddyconfig.NewService(config.CaddyConfigDir, DefaultCaddyAdminSockPath))
+	limiter := throttle.NewLimiter(config.Throttle)
+	m.localMachineServer = newGRPCServer(m, c, m.dockerServer, caddyServer, limiter, m.faults)
 
 	if m.Initialised() {
 		close(m.initialised)
@@ -297,8 +384,19 @@ func NewMachine(config *Config) (*Machine, error) {
 	return m, nil
 }
 
-func newGRPCServer(m pb.MachineServer, c pb.ClusterServer, d pb.DockerServer, caddy pb.CaddyServer) *grpc.Server {
-	s := grpc.NewServer()
+func newGRPCServer(
+	m pb.MachineServer,
+	c pb.ClusterServer,
+	d pb.DockerServer,
+	caddy pb.CaddyServer,
+	limiter *throttle.Limiter,
+	faults *fault.Injector,
+) *grpc.Server {
+	s := grpc.NewServer(
+		// Throttle before injecting faults so that delayed heavy operations hold their slots like slow ones would.
+		grpc.ChainUnaryInterceptor(limiter.UnaryServerInterceptor, faults.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(limiter.StreamServerInterceptor, faults.StreamServerInterceptor),
+	)
 	pb.RegisterMachineServer(s, m)
 	pb.RegisterClusterServer(s, c)
 	pb.RegisterDockerServer(s, d)
@@ -306,11 +404,41 @@ func newGRPCServer(m pb.MachineServer, c pb.ClusterServer, d pb.DockerServer, ca
 	return s
 }
 
+// gracefulStop stops the gRPC server waiting for the in-flight requests to complete. It forcefully closes
+// the remaining connections and requests, such as log streams, after the timeout.
+func gracefulStop(s *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		slog.Warn("Timed out waiting for API requests to complete, stopping server forcefully.",
+			"timeout", timeout)
+		s.Stop()
+		<-done
+	}
+}
+
 // Started returns a channel that is closed when the machine is ready to serve requests on the local API server.
 func (m *Machine) Started() <-chan struct{} {
 	return m.started
 }
 
+// ClusterReady returns a channel that is closed when the machine has joined a cluster and started all cluster
+// components, such as the DNS server and Caddy config controller.
+func (m *Machine) ClusterReady() <-chan struct{} {
+	return m.clusterReady
+}
+
+// Faults returns the failures injected into the daemon for testing.
+func (m *Machine) Faults() *fault.Injector {
+	return m.faults
+}
+
 // Initialised returns true if the machine has been configured as a member of a cluster,
 // either by initialising a new cluster on it or joining an existing one.
 func (m *Machine) Initialised() bool {
@@ -354,6 +482,15 @@ func (m *Machine) IP() netip.Addr {
 	return network.MachineIP(m.state.Network.Subnet)
 }
 
+// templateFacts returns the machine facts available to config and secret templates.
+func (m *Machine) templateFacts() api.TemplateMachine {
+	facts := api.TemplateMachine{ID: m.state.ID, Name: m.state.Name}
+	if ip := m.IP(); ip.IsValid() {
+		facts.IP = ip.String()
+	}
+	return facts
+}
+
 func (m *Machine) Run(ctx context.Context) error {
 	// Create a cancellable context for the Run method to allow stopping the machine gracefully.
 	ctx, m.stop = context.WithCancel(ctx)
@@ -362,6 +499,11 @@ func (m *Machine) Run(ctx context.Context) error {
 	if err := docker.WaitDaemonReady(ctx, m.config.DockerClient); err != nil {
 		return fmt.Errorf("wait for Docker daemon: %w", err)
 	}
+	// Clean up after the operations interrupted when the daemon stopped, e.g. half-created service containers.
+	// It's done before the API server starts to not race with the new deployments.
+	if err := m.dockerServer.ResumeOperations(ctx); err != nil {
+		slog.Error("Failed to resume interrupted operations.", "err", err)
+	}
 
 	// Configure and start the corrosion service on the loopback if the machine is not initialised as a cluster
 	// member. This provides the store required for the machine to initialise a new cluster on it. Once the machine
@@ -406,6 +548,25 @@ func (m *Machine) Run(ctx context.Context) error {
 		}
 		return nil
 	})
+	var metricsServer *http.Server
+	if m.config.MetricsAddr != "" {
+		metricsListener, err := net.Listen("tcp", m.config.MetricsAddr)
+		if err != nil {
+			return fmt.Errorf("listen metrics address %q: %w", m.config.MetricsAddr, err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		metricsServer = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		errGroup.Go(func() error {
+			slog.Info("Starting metrics server.", "addr", m.config.MetricsAddr)
+			if err := metricsServer.Serve(metricsListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("metrics server failed: %w", err)
+			}
+			return nil
+		})
+	}
+
 	// Signal that the machine is ready.
 	close(m.started)
 
@@ -421,6 +582,12 @@ func (m *Machine) Run(ctx context.Context) error {
 		case <-m.initialised:
 			m.cluster.UpdateMachineID(m.state.ID)
 
+			if m.state.ResyncStore {
+				if err := m.discardStore(ctx); err != nil {
+					return fmt.Errorf("discard cluster store: %w", err)
+				}
+			}
+
 			// Ensure the corrosion config is up to date, including a new gossip address if the machine
 			// has just joined a cluster.
 			if err := m.configureCorrosion(); err != nil {
@@ -448,6 +615,8 @@ func (m *Machine) Run(ctx context.Context) error {
 				m.config.CaddyConfigDir,
 				DefaultCaddyAdminSockPath,
 				m.store,
+				m.cluster,
+				m.cluster,
 			)
 			if err != nil {
 				return fmt.Errorf("create caddyconfig controller: %w", err)
@@ -463,6 +632,7 @@ func (m *Machine) Run(ctx context.Context) error {
 			if err != nil {
 				return fmt.Errorf("create embedded DNS server: %w", err)
 			}
+			mdnsResponder := mdns.NewResponder(m.state.ID, m.store)
 
 			var unreg *unregistry.Registry
 			if containerdSock := m.ContainerdSock(); containerdSock != "" {
@@ -503,7 +673,30 @@ func (m *Machine) Run(ctx context.Context) error {
 				caddyconfigCtrl,
 				dnsServer,
 				dnsResolver,
+				dns.NewConfigController(m.state.ID, m.cluster, dnsServer, dns.NewHostResolver(m.IP()), mdnsResponder),
+				mdnsResponder,
 				unreg,
+				offline.NewMonitor(m.state.ID, m.store, m.cluster.MembershipStates),
+				autoupdate.NewController(
+					m.state.ID,
+					m.store,
+					m.cluster,
+					autoupdate.DockerImages{Client: m.dockerService.Client},
+					m.dockerService,
+					autoupdate.LocalDeployer(m.config.UncloudSockPath),
+					autoupdate.CheckInterval,
+				),
+				global.NewController(
+					m.state.ID,
+					m.store,
+					m.cluster,
+					m.dockerService,
+					global.DeployFunc(autoupdate.LocalDeployer(m.config.UncloudSockPath)),
+					global.CheckInterval,
+				),
+				vip.NewController(m.state.ID, m.store, m.cluster, vip.NetlinkAddresses{}, vip.CheckInterval),
+				boot.NewRecovery(m.dockerService.Client),
+				restart.NewSupervisor(m.dockerService.Client, m.dockerService, restart.CheckInterval),
 			)
 			m.mu.Unlock()
 			if err != nil {
@@ -528,17 +721,26 @@ func (m *Machine) Run(ctx context.Context) error {
 
 		<-ctx.Done()
 		slog.Info("Stopping local machine API server.")
-		// TODO: implement timeout for graceful shutdown.
-		m.localMachineServer.GracefulStop()
+		gracefulStop(m.localMachineServer, shutdownTimeout)
 		slog.Info("Local machine API server stopped.")
+		// The operations that couldn't complete in time are resumed on the next start.
+		if n, err := m.dockerServer.PendingOperations(context.Background()); err == nil && n > 0 {
+			slog.Warn("Some operations were interrupted by the shutdown and will be resumed on the next start.",
+				"operations", n)
+		}
 
 		slog.Info("Stopping local API proxy server.")
-		// TODO: implement timeout for graceful shutdown.
-		m.localProxyServer.GracefulStop()
+		gracefulStop(m.localProxyServer, shutdownTimeout)
 		// Close the proxy director to close all backend connections.
 		m.proxyDirector.Close()
 		slog.Info("Local API proxy server stopped.")
 
+		if metricsServer != nil {
+			if err := metricsServer.Close(); err != nil {
+				slog.Error("Failed to stop metrics server.", "err", err)
+			}
+		}
+
 		// Clean up the machine data and resources if the machine shutdown was initiated by a reset.
 		if m.resetting {
 			slog.Info("Cleaning up machine data and resources.")
@@ -588,6 +790,34 @@ func listenUnixSocket(path string) (net.Listener, error) {
 	return sockets.NewUnixSocket(path, gid)
 }
 
+// discardStore stops the corrosion service and removes its database so that the store is re-synced from other
+// machines when the service starts again.
+func (m *Machine) discardStore(ctx context.Context) error {
+	if m.config.CorrosionService.Running() {
+		if err := m.config.CorrosionService.Stop(ctx); err != nil {
+			return fmt.Errorf("stop corrosion service: %w", err)
+		}
+	}
+
+	dbPath := filepath.Join(m.config.CorrosionDir, "store.db")
+	// Remove the SQLite write-ahead log and shared memory files along with the database.
+	for _, path := range []string{dbPath, dbPath + "-wal", dbPath + "-shm"} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove '%s': %w", path, err)
+		}
+	}
+
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.state.ResyncStore = false
+	if err := m.state.Save(); err != nil {
+		return fmt.Errorf("save machine state: %w", err)
+	}
+	slog.Info("Discarded the local cluster store database to re-sync it from other machines.", "path", dbPath)
+
+	return nil
+}
+
 func (m *Machine) configureCorrosion() error {
 	if err := corroservice.MkDataDir(m.config.CorrosionDir, m.config.CorrosionUser); err != nil {
 		return fmt.Errorf("create corrosion data directory: %w", err)
@@ -663,35 +893,29 @@ func (m *Machine) cleanup() error {
 }
 
 // CheckPrerequisites verifies if the machine meets all necessary system requirements to participate in the cluster.
-func (m *Machine) CheckPrerequisites(_ context.Context, _ *emptypb.Empty) (*pb.CheckPrerequisitesResponse, error) {
-	// Check DNS port (UDP) availability.
-	if err := checkDNSPortAvailable(); err != nil {
-		return &pb.CheckPrerequisitesResponse{
-			Satisfied: false,
-			Error:     err.Error(),
-		}, nil
+func (m *Machine) CheckPrerequisites(
+	ctx context.Context, req *pb.CheckPrerequisitesRequest,
+) (*pb.CheckPrerequisitesResponse, error) {
+	wgPort := int(req.WireguardPort)
+	if wgPort == 0 {
+		wgPort = network.DefaultWireGuardPort
 	}
+	checks := preflight.Run(ctx, preflight.Options{
+		Docker:        m.config.DockerClient,
+		WireGuardPort: wgPort,
+		// The ports are in use by the machine itself once it's a cluster member.
+		SkipPortChecks: m.Initialised(),
+	})
 
-	return &pb.CheckPrerequisitesResponse{
+	resp := &pb.CheckPrerequisitesResponse{
 		Satisfied: true,
-	}, nil
-}
-
-// checkDNSPortAvailable verifies that DNS port 53/udp is available for Uncloud's embedded DNS service.
-func checkDNSPortAvailable() error {
-	addr := &net.UDPAddr{
-		IP:   net.IPv4(127, 0, 0, 210), // Use a unique loopback address to avoid conflicts.
-		Port: dns.Port,
+		Checks:    checks,
 	}
-	conn, err := net.ListenUDP("udp", addr)
-	if err != nil {
-		return fmt.Errorf("DNS port %d/udp is already in use by another service: %w. Uncloud needs this port "+
-			"to run the embedded internal DNS service on WireGuard interface 'uncloud'. Please reconfigure "+
-			"any DNS servers (like dnsmasq, systemd-resolved, or named) that might be listening on all network "+
-			"interfaces (0.0.0.0) on the machine and try again", dns.Port, err)
+	if err := preflight.FailedError(checks); err != nil {
+		resp.Satisfied = false
+		resp.Error = err.Error()
 	}
-	conn.Close()
-	return nil
+	return resp, nil
 }
 
 // InitCluster initialises a new cluster on the local machine with the provided network configuration.
@@ -704,6 +928,29 @@ func (m *Machine) InitCluster(ctx context.Context, req *pb.InitClusterRequest) (
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid network: %v", err)
 	}
+	if err = profile.Validate(req.Profile); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = store.ValidateBackend(req.StoreBackend); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	storeBackend := req.StoreBackend
+	if storeBackend == store.BackendCorrosion {
+		storeBackend = ""
+	}
+	if storeBackend != "" {
+		backend, err := newStoreBackend(storeBackend, m.config.DataDir, nil)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "create cluster store backend: %v", err)
+		}
+		if err = m.store.SetBackend(backend); err != nil {
+			if closer, ok := backend.(io.Closer); ok {
+				closer.Close()
+			}
+			return nil, status.Errorf(codes.Internal, "set cluster store backend: %v", err)
+		}
+		slog.Info("Using cluster store backend.", "backend", storeBackend)
+	}
 
 	if err = m.cluster.Init(ctx, clusterNetwork); err != nil {
 		return nil, status.Errorf(codes.Internal, "init cluster: %v", err)
@@ -785,6 +1032,8 @@ func (m *Machine) InitCluster(ctx context.Context, req *pb.InitClusterRequest) (
 		PrivateKey:    m.state.Network.PrivateKey,
 		PublicKey:     m.state.Network.PublicKey,
 	}
+	m.setProfile(req.Profile)
+	m.state.StoreBackend = storeBackend
 	if err = m.state.Save(); err != nil {
 		return nil, status.Errorf(codes.Internal, "save machine state: %v", err)
 	}
@@ -821,6 +1070,9 @@ func (m *Machine) JoinCluster(_ context.Context, req *pb.JoinClusterRequest) (*e
 			codes.InvalidArgument, "public key in the request does not match the public key on the machine",
 		)
 	}
+	if err := profile.Validate(req.Profile); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	// Update the machine state with the provided cluster configuration.
 	subnet, _ := req.Machine.Network.Subnet.ToPrefix()
@@ -842,6 +1094,7 @@ func (m *Machine) JoinCluster(_ context.Context, req *pb.JoinClusterRequest) (*e
 		PublicKey:     m.state.Network.PublicKey,
 	}
 	m.state.MinStoreDBVersion = req.MinStoreDbVersion
+	m.setProfile(req.Profile)
 
 	// Build a peers config from other cluster machines.
 	m.state.Network.Peers = make([]network.PeerConfig, 0, len(req.OtherMachines))
@@ -885,6 +1138,104 @@ func (m *Machine) JoinCluster(_ context.Context, req *pb.JoinClusterRequest) (*e
 	return &emptypb.Empty{}, nil
 }
 
+// setProfile sets the resource profile in the machine state and applies the settings that can be changed at runtime.
+// Other settings are applied when the cluster controller starts or on the next daemon restart.
+func (m *Machine) setProfile(name string) {
+	if name == profile.Default {
+		name = ""
+	}
+	m.state.Profile = name
+	log.DaemonLevel.Set(profile.Get(name).LogLevel)
+}
+
+// SetLogLevel changes the minimum level of the daemon logs until the daemon restarts or the resource profile
+// changes. An empty level resets it to the default of the resource profile.
+func (m *Machine) SetLogLevel(_ context.Context, req *pb.SetLogLevelRequest) (*pb.LogLevelResponse, error) {
+	level := profile.Get(m.state.Profile).LogLevel
+	if req.Level != "" {
+		var err error
+		if level, err = log.ParseLevel(req.Level); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	if prev := log.DaemonLevel.Level(); level != prev {
+		// Log before changing the level so the message isn't filtered out when the level is raised.
+		slog.Info("Changing daemon log level.", "level", log.LevelName(level), "previous", log.LevelName(prev))
+		log.DaemonLevel.Set(level)
+	}
+
+	return &pb.LogLevelResponse{Machines: []*pb.MachineLogLevel{{Level: log.LevelName(level)}}}, nil
+}
+
+// GetLogLevel returns the minimum level of the daemon logs.
+func (m *Machine) GetLogLevel(_ context.Context, _ *emptypb.Empty) (*pb.LogLevelResponse, error) {
+	return &pb.LogLevelResponse{
+		Machines: []*pb.MachineLogLevel{{Level: log.LevelName(log.DaemonLevel.Level())}},
+	}, nil
+}
+
+// InjectFault injects a fault into the daemon for failure testing if the daemon runs with --unsafe-fault-injection.
+func (m *Machine) InjectFault(_ context.Context, req *pb.InjectFaultRequest) (*pb.FaultsResponse, error) {
+	if req.Fault == nil {
+		return nil, status.Error(codes.InvalidArgument, "fault not set")
+	}
+	f := fault.Fault{
+		Kind:  req.Fault.Kind,
+		Match: req.Fault.Match,
+	}
+	if req.Fault.Delay != nil {
+		f.Delay = req.Fault.Delay.AsDuration()
+	}
+	if req.Duration != nil {
+		f.Expires = time.Now().Add(req.Duration.AsDuration())
+	}
+
+	if err := m.faults.Inject(f); err != nil {
+		if errors.Is(err, fault.ErrDisabled) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	slog.Warn("Injected fault.", "kind", f.Kind, "match", f.Match, "delay", f.Delay, "expires", f.Expires)
+
+	return m.faultsResponse(), nil
+}
+
+// ClearFaults removes the faults of the requested kind or all faults injected into the daemon.
+func (m *Machine) ClearFaults(_ context.Context, req *pb.ClearFaultsRequest) (*pb.FaultsResponse, error) {
+	if req.Kind != "" && !slices.Contains(fault.Kinds, req.Kind) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid fault kind '%s', must be one of: %s",
+			req.Kind, strings.Join(fault.Kinds, ", "))
+	}
+	if len(m.faults.List()) > 0 {
+		slog.Info("Clearing injected faults.", "kind", req.Kind)
+	}
+	m.faults.Clear(req.Kind)
+
+	return m.faultsResponse(), nil
+}
+
+// ListFaults returns the active faults injected into the daemon.
+func (m *Machine) ListFaults(_ context.Context, _ *emptypb.Empty) (*pb.FaultsResponse, error) {
+	return m.faultsResponse(), nil
+}
+
+func (m *Machine) faultsResponse() *pb.FaultsResponse {
+	mf := &pb.MachineFaults{Enabled: m.faults.Enabled()}
+	for _, f := range m.faults.List() {
+		pf := &pb.Fault{Kind: f.Kind, Match: f.Match}
+		if f.Delay != 0 {
+			pf.Delay = durationpb.New(f.Delay)
+		}
+		if !f.Expires.IsZero() {
+			pf.Expires = timestamppb.New(f.Expires)
+		}
+		mf.Faults = append(mf.Faults, pf)
+	}
+	return &pb.FaultsResponse{Machines: []*pb.MachineFaults{mf}}
+}
+
 // Token returns the local machine's token that can be used for adding the machine to a cluster.
 func (m *Machine) Token(_ context.Context, _ *emptypb.Empty) (*pb.TokenResponse, error) {
 	if len(m.state.Network.PublicKey) == 0 {
@@ -933,34 +1284,129 @@ func (m *Machine) InspectMachine(ctx context.Context, _ *emptypb.Empty) (*pb.Ins
 		return nil, status.Errorf(codes.Internal, "get database version of the cluster store: %v", err)
 	}
 
-	var rtts map[string]*pb.RTTStats
+	digest, err := m.store.Digest(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get digest of the cluster store: %v", err)
+	}
+
+	var (
+		rtts     map[string]*pb.RTTStats
+		aliveIDs []string
+	)
 	if m.Initialised() {
 		rtts, err = m.getMachineRTTs(ctx)
 		if err != nil {
 			return nil, err
 		}
+		if aliveIDs, err = m.aliveMachineIDs(ctx); err != nil {
+			return nil, err
+		}
 	}
 
-	return &pb.InspectMachineResponse{
-		Machines: []*pb.MachineDetails{
-			{
-				// Metadata is injected by the gRPC proxy.
-				Machine: &pb.MachineInfo{
-					Id:   m.state.ID,
-					Name: m.state.Name,
-					Network: &pb.NetworkConfig{
-						Subnet:       pb.NewIPPrefix(m.state.Network.Subnet),
-						ManagementIp: pb.NewIP(m.state.Network.ManagementIP),
-						PublicKey:    m.state.Network.PublicKey,
-					},
-				},
-				StoreDbVersion: dbVersion,
-				Rtts:           rtts,
+	details := &pb.MachineDetails{
+		// Metadata is injected by the gRPC proxy.
+		Machine: &pb.MachineInfo{
+			Id:   m.state.ID,
+			Name: m.state.Name,
+			Network: &pb.NetworkConfig{
+				Subnet:       pb.NewIPPrefix(m.state.Network.Subnet),
+				ManagementIp: pb.NewIP(m.state.Network.ManagementIP),
+				PublicKey:    m.state.Network.PublicKey,
 			},
 		},
+		StoreDbVersion:  dbVersion,
+		Rtts:            rtts,
+		AliveMachineIds: aliveIDs,
+		StoreDigest:     digest,
+		DaemonVersion:   version.String(),
+	}
+	m.setOfflineStatus(details)
+	m.setBootRecoveryStatus(details)
+
+	return &pb.InspectMachineResponse{
+		Machines: []*pb.MachineDetails{details},
 	}, nil
 }
 
+// setOfflineStatus sets the connectivity status of the machine and the conflicts detected on the last reconnection
+// to the cluster in the machine details.
+func (m *Machine) setOfflineStatus(details *pb.MachineDetails) {
+	m.mu.RLock()
+	clusterCtrl := m.clusterCtrl
+	m.mu.RUnlock()
+	if clusterCtrl == nil {
+		return
+	}
+
+	status := clusterCtrl.offlineMonitor.Status()
+	if !status.OfflineSince.IsZero() {
+		details.OfflineSince = timestamppb.New(status.OfflineSince)
+	}
+	if !status.LastReconnected.IsZero() {
+		details.LastReconnected = timestamppb.New(status.LastReconnected)
+	}
+	for _, c := range status.Conflicts {
+		details.SyncConflicts = append(details.SyncConflicts, &pb.SyncConflict{
+			ServiceName:   c.ServiceName,
+			ContainerId:   c.ContainerID,
+			ContainerName: c.ContainerName,
+			Reason:        c.Reason,
+		})
+	}
+}
+
+// setBootRecoveryStatus sets the progress of starting the service containers that Docker failed to start when
+// the machine booted in the machine details.
+func (m *Machine) setBootRecoveryStatus(details *pb.MachineDetails) {
+	m.mu.RLock()
+	clusterCtrl := m.clusterCtrl
+	m.mu.RUnlock()
+	if clusterCtrl == nil {
+		return
+	}
+
+	status := clusterCtrl.bootRecovery.Status()
+	if status.StartedAt.IsZero() {
+		return
+	}
+	details.BootRecovery = &pb.BootRecovery{
+		StartedAt: timestamppb.New(status.StartedAt),
+	}
+	if !status.FinishedAt.IsZero() {
+		details.BootRecovery.FinishedAt = timestamppb.New(status.FinishedAt)
+	}
+	for _, c := range status.Containers {
+		details.BootRecovery.Containers = append(details.BootRecovery.Containers, &pb.BootRecoveryContainer{
+			ServiceName:   c.ServiceName,
+			ContainerId:   c.ContainerID,
+			ContainerName: c.ContainerName,
+			Stateful:      c.Stateful,
+			Error:         c.Error,
+		})
+	}
+}
+
+// aliveMachineIDs returns the IDs of other machines this machine sees as alive in the cluster membership.
+func (m *Machine) aliveMachineIDs(ctx context.Context) ([]string, error) {
+	resp, err := m.cluster.ListMachines(ctx, &emptypb.Empty{})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			// The cluster is not ready yet.
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, mm := range resp.Machines {
+		if mm.Machine.Id != m.state.ID &&
+			(mm.State == pb.MachineMember_UP || mm.State == pb.MachineMember_SUSPECT) {
+			ids = append(ids, mm.Machine.Id)
+		}
+	}
+	return ids, nil
+}
+
 // getMachineRTTs retrieves round-trip times to other machines in the cluster.
 func (m *Machine) getMachineRTTs(ctx context.Context) (map[string]*pb.RTTStats, error) {
 	rtts, err := m.cluster.MemberRTTs()
@@ -1080,6 +1526,87 @@ func (m *Machine) InspectWireGuardNetwork(
 	}, nil
 }
 
+// InspectNetwork retrieves the machine network configuration including the Docker network, its containers,
+// WireGuard peers, and routes via the WireGuard interface.
+func (m *Machine) InspectNetwork(ctx context.Context, _ *emptypb.Empty) (*pb.InspectNetworkResponse, error) {
+	if !m.Initialised() {
+		return nil, status.Error(codes.FailedPrecondition, "machine is not initialised as a cluster member")
+	}
+
+	wgResp, err := m.InspectWireGuardNetwork(ctx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "inspect WireGuard network: %v", err)
+	}
+
+	routes, err := network.ListWireGuardRoutes()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list WireGuard routes: %v", err)
+	}
+	pbRoutes := make([]*pb.IPPrefix, len(routes))
+	for i, r := range routes {
+		pbRoutes[i] = pb.NewIPPrefix(r)
+	}
+
+	dockerNetwork, err := m.inspectDockerNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.InspectNetworkResponse{
+		Machines: []*pb.MachineNetwork{
+			{
+				// Metadata is injected by the gRPC proxy.
+				Config: &pb.NetworkConfig{
+					Subnet:       pb.NewIPPrefix(m.state.Network.Subnet),
+					ManagementIp: pb.NewIP(m.state.Network.ManagementIP),
+					PublicKey:    m.state.Network.PublicKey,
+				},
+				DockerNetwork:   dockerNetwork,
+				Wireguard:       wgResp,
+				WireguardRoutes: pbRoutes,
+			},
+		},
+	}, nil
+}
+
+// inspectDockerNetwork returns the Docker network managed by Uncloud or nil if it doesn't exist.
+func (m *Machine) inspectDockerNetwork(ctx context.Context) (*pb.DockerNetwork, error) {
+	nw, err := m.config.DockerClient.NetworkInspect(ctx, machinedocker.NetworkName, dnetwork.InspectOptions{})
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Internal, "inspect Docker network '%s': %v", machinedocker.NetworkName, err)
+	}
+
+	dn := &pb.DockerNetwork{
+		Id:     nw.ID,
+		Name:   nw.Name,
+		Driver: nw.Driver,
+	}
+	if len(nw.IPAM.Config) > 0 {
+		if subnet, pErr := netip.ParsePrefix(nw.IPAM.Config[0].Subnet); pErr == nil {
+			dn.Subnet = pb.NewIPPrefix(subnet)
+		}
+		if gateway, pErr := netip.ParseAddr(nw.IPAM.Config[0].Gateway); pErr == nil {
+			dn.Gateway = pb.NewIP(gateway)
+		}
+	}
+
+	for id, ep := range nw.Containers {
+		ctr := &pb.DockerNetworkContainer{Id: id, Name: ep.Name}
+		if prefix, pErr := netip.ParsePrefix(ep.IPv4Address); pErr == nil {
+			ctr.Ip = pb.NewIP(prefix.Addr())
+		}
+		dn.Containers = append(dn.Containers, ctr)
+	}
+	slices.SortFunc(dn.Containers, func(a, b *pb.DockerNetworkContainer) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return dn, nil
+}
+
 // Reset restores the machine to a clean state, scheduling a graceful shutdown and removing all cluster-related
 // configuration and resource. The uncloud daemon will restart the machine if managed by systemd.
 func (m *Machine) Reset(_ context.Context, _ *pb.ResetRequest) (*emptypb.Empty, error) {
@@ -1105,6 +1632,133 @@ func (m *Machine) Reset(_ context.Context, _ *pb.ResetRequest) (*emptypb.Empty,
 	return &emptypb.Empty{}, nil
 }
 
+// Reboot reboots the machine host with systemd after a short delay to let the call return to the client.
+func (m *Machine) Reboot(_ context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if !m.Initialised() {
+		return nil, status.Error(codes.FailedPrecondition, "machine is not initialised as a cluster member")
+	}
+	if _, err := exec.LookPath("systemctl"); err != nil {
+		return nil, status.Error(codes.FailedPrecondition,
+			"systemctl not found, rebooting is only supported on machines managed by systemd")
+	}
+
+	slog.Warn("Rebooting machine.", "delay", rebootDelay)
+	go func() {
+		time.Sleep(rebootDelay)
+		// systemd stops the daemon and Docker gracefully before rebooting.
+		if out, err := exec.Command("systemctl", "reboot").CombinedOutput(); err != nil {
+			slog.Error("Failed to reboot machine.", "err", err, "output", strings.TrimSpace(string(out)))
+		}
+	}()
+
+	return &emptypb.Empty{}, nil
+}
+
+// RunHostUpdate runs the OS package update command on the machine host and records the result in the cluster store.
+// The update isn't interrupted if the client disconnects to not leave the package manager in a broken state.
+func (m *Machine) RunHostUpdate(ctx context.Context, req *pb.RunHostUpdateRequest) (*pb.RunHostUpdateResponse, error) {
+	if !m.Initialised() {
+		return nil, status.Error(codes.FailedPrecondition, "machine is not initialised as a cluster member")
+	}
+	command := req.Command
+	if command == "" {
+		if command = m.hostUpdater.DefaultCommand(); command == "" {
+			return nil, status.Error(codes.FailedPrecondition,
+				"neither apt-get nor dnf found on the machine, specify the update command explicitly")
+		}
+	}
+	if !m.hostUpdating.CompareAndSwap(false, true) {
+		return nil, status.Error(codes.FailedPrecondition, "host packages are already being updated")
+	}
+	defer m.hostUpdating.Store(false)
+
+	slog.Info("Updating host packages.", "command", command)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), hostUpdateTimeout)
+	defer cancel()
+	update := m.hostUpdater.Run(ctx, command)
+	if update.Error != "" {
+		slog.Error("Failed to update host packages.", "err", update.Error, "output", update.Output)
+	} else {
+		slog.Info("Updated host packages.", "reboot_required", update.RebootRequired)
+	}
+
+	if err := m.cluster.AddHostUpdate(ctx, m.state.ID, update); err != nil {
+		slog.Error("Failed to record host update in cluster store.", "err", err)
+	}
+	updateJSON, err := json.Marshal(update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshal host update: %v", err)
+	}
+	return &pb.RunHostUpdateResponse{Update: updateJSON}, nil
+}
+
+// ResyncStore schedules the local cluster store database to be discarded and restarts the machine daemon. The store
+// is re-synced from other machines when the corrosion service starts again with an empty database.
+func (m *Machine) ResyncStore(_ context.Context, req *pb.ResyncStoreRequest) (*emptypb.Empty, error) {
+	if !m.Initialised() {
+		return nil, status.Error(codes.FailedPrecondition, "machine is not initialised as a cluster member")
+	}
+	if !m.store.Backend().Replicated() {
+		return nil, status.Error(codes.FailedPrecondition,
+			"cluster store backend doesn't replicate the state, there is nothing to re-sync it from")
+	}
+
+	m.state.mu.Lock()
+	m.state.ResyncStore = true
+	m.state.MinStoreDBVersion = req.MinStoreDbVersion
+	if err := m.state.Save(); err != nil {
+		m.state.ResyncStore = false
+		m.state.mu.Unlock()
+		return nil, status.Errorf(codes.Internal, "save machine state: %v", err)
+	}
+	m.state.mu.Unlock()
+
+	slog.Warn("Restarting to discard the local cluster store and re-sync it from other machines.",
+		"min_store_db_version", req.MinStoreDbVersion)
+	// Trigger the machine shutdown. The in-flight RPC completes as the API servers are stopped gracefully.
+	m.stop()
+
+	return &emptypb.Empty{}, nil
+}
+
+// ApplySubnet applies the machine subnet from the cluster store if it differs from the local one. The new subnet
+// is saved to the machine state and the machine shutdown is scheduled. The uncloud daemon restarted by systemd then
+// reconfigures the network and moves the containers to the recreated Docker network with the new subnet.
+func (m *Machine) ApplySubnet(ctx context.Context, _ *emptypb.Empty) (*pb.ApplySubnetResponse, error) {
+	if !m.Initialised() {
+		return nil, status.Error(codes.FailedPrecondition, "machine is not initialised as a cluster member")
+	}
+
+	mi, err := m.store.GetMachine(ctx, m.state.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get machine from store: %v", err)
+	}
+	subnet, err := mi.Network.Subnet.ToPrefix()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "invalid machine subnet in store: %v", err)
+	}
+
+	m.state.mu.Lock()
+	oldSubnet := m.state.Network.Subnet
+	if subnet == oldSubnet {
+		m.state.mu.Unlock()
+		return &pb.ApplySubnetResponse{Subnet: pb.NewIPPrefix(subnet)}, nil
+	}
+	m.state.Network.Subnet = subnet
+	if err = m.state.Save(); err != nil {
+		m.state.Network.Subnet = oldSubnet
+		m.state.mu.Unlock()
+		return nil, status.Errorf(codes.Internal, "save machine state: %v", err)
+	}
+	m.state.mu.Unlock()
+
+	slog.Info("Machine subnet changed, restarting to apply it.", "old_subnet", oldSubnet, "subnet", subnet)
+	// Trigger the machine shutdown. The in-flight RPC completes as the API servers are stopped gracefully.
+	m.stop()
+
+	return &pb.ApplySubnetResponse{Restarting: true, Subnet: pb.NewIPPrefix(subnet)}, nil
+}
+
 // InspectService returns detailed information about a service and its containers stored in the cluster store.
 func (m *Machine) InspectService(
 	ctx context.Context, req *pb.InspectServiceRequest,
@@ -1214,7 +1868,8 @@ func (m *Machine) MachineLogs(
 			// Only send heartbeat if no log entries have been sent since the last heartbeat interval or
 			// if no log entries have been sent at all for at least a heartbeat interval since starting.
 			if now.Sub(lastSent) < logsHeartbeatInterval ||
-				(lastSent.IsZero() && now.Sub(started) < logsHeartbeatInterval) {
+				(lastSent.IsZero() && now.Sub(started) < logsHeartbeatInterval) ||
+				m.faults.DropHeartbeat() {
 				continue
 			}
 
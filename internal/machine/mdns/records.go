@@ -0,0 +1,178 @@
+package mdns
+
+import (
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// Domain is the link-local domain resolved using multicast DNS.
+	Domain = "local."
+	// servicesName is the name to browse all advertised service types (RFC 6763, section 9).
+	servicesName = "_services._dns-sd._udp." + Domain
+	// hostTTL is the TTL for records with host names (A and SRV) recommended by RFC 6762, section 10.
+	hostTTL = 120
+	// serviceTTL is the TTL for other records (PTR and TXT) recommended by RFC 6762, section 10.
+	serviceTTL = 4500
+	// cacheFlush is the bit in the record class that marks unique records that replace the cached ones
+	// (RFC 6762, section 10.2).
+	cacheFlush = 1 << 15
+)
+
+// service is a DNS-SD service instance (RFC 6763) advertised for an ingress hostname.
+type service struct {
+	// instance is the service instance name, e.g. jellyfin._http._tcp.local.
+	instance string
+	// typ is the service type, e.g. _http._tcp.local.
+	typ string
+	// host is the target host name, e.g. jellyfin.local.
+	host string
+	port uint16
+}
+
+// Records are the multicast DNS records advertised by the machine.
+type Records struct {
+	// hosts are the canonical host names that resolve to the machine IP on the interface the query is received on.
+	hosts map[string]struct{}
+	// services are sorted by the instance name.
+	services []service
+}
+
+// NewRecords returns the records for the ingress hostnames in the .local domain of the healthy service containers
+// if the Caddy reverse proxy that routes them is running on the machine.
+func NewRecords(machineID string, containers []store.ContainerRecord) *Records {
+	r := &Records{hosts: make(map[string]struct{})}
+
+	caddyRunning := slices.ContainsFunc(containers, func(cr store.ContainerRecord) bool {
+		return cr.MachineID == machineID && cr.Container.ServiceName() == caddyconfig.CaddyServiceName &&
+			cr.Container.Healthy()
+	})
+	if !caddyRunning {
+		return r
+	}
+
+	instances := make(map[string]struct{})
+	for _, cr := range containers {
+		if cr.Container.IsHook() || !cr.Container.Healthy() {
+			continue
+		}
+		ports, err := cr.Container.ServicePorts()
+		if err != nil {
+			continue
+		}
+
+		for _, p := range ports {
+			host := dns.CanonicalName(p.Hostname)
+			if p.Mode != api.PortModeIngress || !dns.IsSubDomain(Domain, host) || dns.CountLabel(host) != 2 {
+				continue
+			}
+
+			svc := service{host: host}
+			switch p.Protocol {
+			case api.ProtocolHTTP:
+				svc.typ, svc.port = "_http._tcp."+Domain, 80
+			case api.ProtocolHTTPS:
+				svc.typ, svc.port = "_https._tcp."+Domain, 443
+			default:
+				continue
+			}
+			// Use the host label as the instance name, e.g. jellyfin for jellyfin.local, which is unique per type.
+			svc.instance = strings.TrimSuffix(host, "."+Domain) + "." + svc.typ
+
+			r.hosts[host] = struct{}{}
+			if _, ok := instances[svc.instance]; !ok {
+				instances[svc.instance] = struct{}{}
+				r.services = append(r.services, svc)
+			}
+		}
+	}
+	slices.SortFunc(r.services, func(a, b service) int {
+		return strings.Compare(a.instance, b.instance)
+	})
+
+	return r
+}
+
+// Empty returns true if there are no records to advertise.
+func (r *Records) Empty() bool {
+	return len(r.hosts) == 0
+}
+
+// Answer returns the answer and additional records for the question. The host names resolve to the IP address.
+func (r *Records) Answer(q dns.Question, ip netip.Addr) (answer, extra []dns.RR) {
+	name := dns.CanonicalName(q.Name)
+	match := func(qtype uint16) bool {
+		return q.Qtype == qtype || q.Qtype == dns.TypeANY
+	}
+
+	if _, ok := r.hosts[name]; ok && match(dns.TypeA) {
+		answer = append(answer, hostA(name, ip))
+	}
+
+	if name == servicesName && match(dns.TypePTR) {
+		var types []string
+		for _, svc := range r.services {
+			if !slices.Contains(types, svc.typ) {
+				types = append(types, svc.typ)
+				answer = append(answer, ptr(servicesName, svc.typ))
+			}
+		}
+	}
+
+	for _, svc := range r.services {
+		switch name {
+		case svc.typ:
+			if match(dns.TypePTR) {
+				answer = append(answer, ptr(svc.typ, svc.instance))
+				extra = append(extra, svc.srv(), svc.txt(), hostA(svc.host, ip))
+			}
+		case svc.instance:
+			if match(dns.TypeSRV) {
+				answer = append(answer, svc.srv())
+				extra = append(extra, hostA(svc.host, ip))
+			}
+			if match(dns.TypeTXT) {
+				answer = append(answer, svc.txt())
+			}
+		}
+	}
+
+	return answer, extra
+}
+
+func hostA(name string, ip netip.Addr) dns.RR {
+	return &dns.A{
+		Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypeA, Class: dns.ClassINET | cacheFlush, Ttl: hostTTL},
+		A:   net.IP(ip.AsSlice()),
+	}
+}
+
+func ptr(name, target string) dns.RR {
+	return &dns.PTR{
+		Hdr: dns.RR_Header{Name: name, Rrtype: dns.TypePTR, Class: dns.ClassINET, Ttl: serviceTTL},
+		Ptr: target,
+	}
+}
+
+func (s service) srv() dns.RR {
+	return &dns.SRV{
+		Hdr:    dns.RR_Header{Name: s.instance, Rrtype: dns.TypeSRV, Class: dns.ClassINET | cacheFlush, Ttl: hostTTL},
+		Port:   s.port,
+		Target: s.host,
+	}
+}
+
+func (s service) txt() dns.RR {
+	return &dns.TXT{
+		Hdr: dns.RR_Header{Name: s.instance, Rrtype: dns.TypeTXT, Class: dns.ClassINET | cacheFlush, Ttl: serviceTTL},
+		// The path key tells clients such as web browsers the path of the web UI.
+		Txt: []string{"path=/"},
+	}
+}
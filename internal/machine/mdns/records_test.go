@@ -0,0 +1,126 @@
+package mdns
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/miekg/dns"
+	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContainerRecord(machineID, serviceName string, ports ...string) store.ContainerRecord {
+	return store.ContainerRecord{
+		MachineID: machineID,
+		Container: api.ServiceContainer{Container: api.Container{InspectResponse: container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{
+				State: &container.State{Running: true},
+			},
+			Config: &container.Config{
+				Labels: map[string]string{
+					api.LabelServiceName:  serviceName,
+					api.LabelServicePorts: strings.Join(ports, ","),
+				},
+			},
+		}}},
+	}
+}
+
+func TestNewRecords(t *testing.T) {
+	t.Parallel()
+
+	containers := []store.ContainerRecord{
+		newContainerRecord("m-1", caddyconfig.CaddyServiceName, "80:80@host", "443:443@host"),
+		newContainerRecord("m-1", "jellyfin", "jellyfin.local:8096/http"),
+		newContainerRecord("m-2", "jellyfin", "jellyfin.local:8096/http"),
+		newContainerRecord("m-2", "ha", "ha.local:8123/https", "ha.example.com:8123/https"),
+		newContainerRecord("m-2", "nested", "app.home.local:8080/http"),
+		newContainerRecord("m-2", "db", "5432:5432@host"),
+	}
+
+	r := NewRecords("m-1", containers)
+	assert.Equal(t, map[string]struct{}{"jellyfin.local.": {}, "ha.local.": {}}, r.hosts)
+	assert.Equal(t, []service{
+		{instance: "ha._https._tcp.local.", typ: "_https._tcp.local.", host: "ha.local.", port: 443},
+		{instance: "jellyfin._http._tcp.local.", typ: "_http._tcp.local.", host: "jellyfin.local.", port: 80},
+	}, r.services)
+
+	// Caddy isn't running on m-2 so it can't route the hostnames.
+	assert.True(t, NewRecords("m-2", containers).Empty())
+}
+
+func TestRecords_Answer(t *testing.T) {
+	t.Parallel()
+
+	r := NewRecords("m-1", []store.ContainerRecord{
+		newContainerRecord("m-1", caddyconfig.CaddyServiceName),
+		newContainerRecord("m-1", "jellyfin", "jellyfin.local:8096/http"),
+	})
+	ip := netip.MustParseAddr("192.168.1.10")
+	question := func(name string, qtype uint16) dns.Question {
+		return dns.Question{Name: name, Qtype: qtype, Qclass: dns.ClassINET}
+	}
+
+	answer, extra := r.Answer(question("Jellyfin.local.", dns.TypeA), ip)
+	require.Len(t, answer, 1)
+	assert.Equal(t, net.IP(ip.AsSlice()), answer[0].(*dns.A).A.To4())
+	assert.Empty(t, extra)
+
+	answer, _ = r.Answer(question("other.local.", dns.TypeA), ip)
+	assert.Empty(t, answer)
+	answer, _ = r.Answer(question("jellyfin.local.", dns.TypeAAAA), ip)
+	assert.Empty(t, answer)
+
+	answer, _ = r.Answer(question(servicesName, dns.TypePTR), ip)
+	require.Len(t, answer, 1)
+	assert.Equal(t, "_http._tcp.local.", answer[0].(*dns.PTR).Ptr)
+
+	answer, extra = r.Answer(question("_http._tcp.local.", dns.TypePTR), ip)
+	require.Len(t, answer, 1)
+	assert.Equal(t, "jellyfin._http._tcp.local.", answer[0].(*dns.PTR).Ptr)
+	require.Len(t, extra, 3)
+	assert.Equal(t, uint16(80), extra[0].(*dns.SRV).Port)
+	assert.Equal(t, "jellyfin.local.", extra[0].(*dns.SRV).Target)
+
+	answer, _ = r.Answer(question("jellyfin._http._tcp.local.", dns.TypeANY), ip)
+	assert.Len(t, answer, 2)
+}
+
+func TestResponder_Answer(t *testing.T) {
+	t.Parallel()
+
+	r := NewResponder("m-1", nil)
+	r.records.Store(NewRecords("m-1", []store.ContainerRecord{
+		newContainerRecord("m-1", caddyconfig.CaddyServiceName),
+		newContainerRecord("m-1", "jellyfin", "jellyfin.local:8096/http"),
+	}))
+	ip := netip.MustParseAddr("192.168.1.10")
+	req := new(dns.Msg).SetQuestion("jellyfin.local.", dns.TypeA)
+
+	// Multicast query.
+	resp, unicast := r.answer(req, &net.UDPAddr{IP: net.ParseIP("192.168.1.20"), Port: Port}, ip)
+	require.NotNil(t, resp)
+	assert.False(t, unicast)
+	assert.Zero(t, resp.Id)
+	assert.Empty(t, resp.Question)
+	assert.Equal(t, uint16(dns.ClassINET|cacheFlush), resp.Answer[0].Header().Class)
+
+	// Legacy unicast query from a conventional resolver.
+	resp, unicast = r.answer(req, &net.UDPAddr{IP: net.ParseIP("192.168.1.20"), Port: 40000}, ip)
+	require.NotNil(t, resp)
+	assert.True(t, unicast)
+	assert.Equal(t, req.Id, resp.Id)
+	assert.Equal(t, req.Question, resp.Question)
+	assert.Equal(t, uint16(dns.ClassINET), resp.Answer[0].Header().Class)
+	assert.EqualValues(t, 10, resp.Answer[0].Header().Ttl)
+
+	resp, _ = r.answer(new(dns.Msg).SetQuestion("other.local.", dns.TypeA),
+		&net.UDPAddr{IP: net.ParseIP("192.168.1.20"), Port: Port}, ip)
+	assert.Nil(t, resp)
+}
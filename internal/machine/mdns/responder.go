@@ -0,0 +1,236 @@
+package mdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/miekg/dns"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/store"
+)
+
+// Port is the multicast DNS port.
+const Port = 5353
+
+// multicastAddr is the IPv4 multicast group and port for multicast DNS.
+var multicastAddr = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: Port}
+
+// ContainerWatcher provides the service containers in the cluster.
+type ContainerWatcher interface {
+	// WatchContainers sends the current list of containers and then the list every time it changes.
+	WatchContainers(ctx context.Context) (<-chan []store.ContainerRecord, error)
+}
+
+// Responder answers multicast DNS queries on the LAN interfaces of the machine for the ingress hostnames
+// in the .local domain and advertises them as DNS-SD services so that devices outside the cluster can find
+// the services without configuring DNS.
+type Responder struct {
+	machineID string
+	watcher   ContainerWatcher
+	records   atomic.Pointer[Records]
+
+	// mu protects conns.
+	mu sync.Mutex
+	// conns are the multicast sockets on the LAN interfaces. It's nil if the responder is disabled.
+	conns []*net.UDPConn
+	log   *slog.Logger
+}
+
+func NewResponder(machineID string, watcher ContainerWatcher) *Responder {
+	r := &Responder{
+		machineID: machineID,
+		watcher:   watcher,
+		log:       slog.With("component", "mdns-responder"),
+	}
+	r.records.Store(&Records{})
+	return r
+}
+
+// Run keeps the records updated with the containers in the cluster until the context is canceled.
+func (r *Responder) Run(ctx context.Context) error {
+	defer r.SetEnabled(false)
+
+	updates, err := r.watcher.WatchContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("subscribe to container changes: %w", err)
+	}
+
+	// The first update is the current list of containers, the following ones are sent after containers change.
+	for containers := range updates {
+		r.records.Store(NewRecords(r.machineID, containers))
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("containers subscription failed")
+}
+
+// SetEnabled starts or stops answering queries on the LAN interfaces. The interfaces are selected when enabled.
+func (r *Responder) SetEnabled(enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !enabled {
+		if r.conns == nil {
+			return nil
+		}
+		for _, conn := range r.conns {
+			conn.Close()
+		}
+		r.conns = nil
+		r.log.Info("Stopped multicast DNS responder.")
+		return nil
+	}
+	if r.conns != nil {
+		return nil
+	}
+
+	ifaces, err := lanInterfaces()
+	if err != nil {
+		return err
+	}
+	if len(ifaces) == 0 {
+		return errors.New("no LAN network interfaces with an IPv4 address that support multicast")
+	}
+
+	conns := make([]*net.UDPConn, 0, len(ifaces))
+	names := make([]string, 0, len(ifaces))
+	for _, li := range ifaces {
+		conn, err := net.ListenMulticastUDP("udp4", &li.iface, multicastAddr)
+		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
+			return fmt.Errorf("listen multicast DNS on interface '%s': %w", li.iface.Name, err)
+		}
+		conns = append(conns, conn)
+		names = append(names, li.iface.Name)
+		go r.serve(conn, li.ip)
+	}
+	r.conns = conns
+	r.log.Info("Started multicast DNS responder.", "interfaces", names)
+
+	return nil
+}
+
+// serve answers the queries received on the connection until it's closed. The host names resolve to the ip address
+// of the interface the connection is listening on.
+func (r *Responder) serve(conn *net.UDPConn, ip netip.Addr) {
+	buf := make([]byte, dns.MaxMsgSize)
+	for {
+		n, src, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				r.log.Error("Failed to read multicast DNS query.", "err", err)
+			}
+			return
+		}
+
+		req := new(dns.Msg)
+		if err = req.Unpack(buf[:n]); err != nil || req.Response || req.Opcode != dns.OpcodeQuery {
+			continue
+		}
+		resp, unicast := r.answer(req, src, ip)
+		if resp == nil {
+			continue
+		}
+
+		msg, err := resp.Pack()
+		if err != nil {
+			r.log.Error("Failed to pack multicast DNS response.", "err", err)
+			continue
+		}
+		dst := multicastAddr
+		if unicast {
+			dst = src
+		}
+		if _, err = conn.WriteToUDP(msg, dst); err != nil {
+			r.log.Debug("Failed to send multicast DNS response.", "dst", dst, "err", err)
+		}
+	}
+}
+
+// answer returns the response to the query or nil if there are no answers, and whether the response should be sent
+// directly to the source instead of the multicast group.
+func (r *Responder) answer(req *dns.Msg, src *net.UDPAddr, ip netip.Addr) (*dns.Msg, bool) {
+	records := r.records.Load()
+	resp := new(dns.Msg)
+	resp.Response = true
+	resp.Authoritative = true
+
+	// Legacy unicast queries from resolvers that don't implement multicast DNS are sent from a port other than 5353.
+	// They expect a conventional DNS response (RFC 6762, section 6.7).
+	legacy := src.Port != Port
+	unicast := legacy
+	for _, q := range req.Question {
+		// The top bit of the class requests a unicast response (RFC 6762, section 5.4).
+		if q.Qclass&cacheFlush != 0 {
+			unicast = true
+		}
+		answer, extra := records.Answer(q, ip)
+		resp.Answer = append(resp.Answer, answer...)
+		resp.Extra = append(resp.Extra, extra...)
+	}
+	if len(resp.Answer) == 0 {
+		return nil, false
+	}
+
+	if legacy {
+		resp.Id = req.Id
+		resp.Question = req.Question
+		for _, rr := range append(resp.Answer, resp.Extra...) {
+			rr.Header().Class &^= cacheFlush
+			// Legacy resolvers may cache the answers so use a short TTL (RFC 6762, section 6.7).
+			rr.Header().Ttl = min(rr.Header().Ttl, 10)
+		}
+	}
+	return resp, unicast
+}
+
+type lanInterface struct {
+	iface net.Interface
+	ip    netip.Addr
+}
+
+// lanInterfaces returns the network interfaces that support multicast with their first IPv4 address, excluding
+// the loopback, the WireGuard mesh, and the Docker interfaces.
+func lanInterfaces() ([]lanInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("list network interfaces: %w", err)
+	}
+
+	var lan []lanInterface
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagMulticast == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		if iface.Name == network.WireGuardInterfaceName || strings.HasPrefix(iface.Name, "docker") ||
+			strings.HasPrefix(iface.Name, "br-") || strings.HasPrefix(iface.Name, "veth") {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, a := range addrs {
+			ipNet, ok := a.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			if ip, ok := netip.AddrFromSlice(ipNet.IP); ok && ip.Unmap().Is4() {
+				lan = append(lan, lanInterface{iface: iface, ip: ip.Unmap()})
+				break
+			}
+		}
+	}
+	return lan, nil
+}
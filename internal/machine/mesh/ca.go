@@ -0,0 +1,207 @@
+package mesh
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// caValidity is the validity period of the cluster mesh CA certificate.
+	caValidity = 10 * 365 * 24 * time.Hour
+	// caBackdate is subtracted from the NotBefore time of the CA certificate so that machines with clocks behind
+	// the machine that generated the CA accept it. It covers the maximum clock tolerance of issued certificates.
+	caBackdate = api.MeshCertValidity
+
+	// identityScheme and identityHost form the SPIFFE-like URI identity embedded in issued certificates,
+	// e.g. spiffe://uncloud/service/web.
+	identityScheme = "spiffe"
+	identityHost   = "uncloud"
+)
+
+// CA is the cluster mesh certificate authority that issues identities for services and machines.
+type CA struct {
+	Cert *x509.Certificate
+	Key  *ecdsa.PrivateKey
+	// CertPEM and KeyPEM are the PEM-encoded certificate and private key of the CA.
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// NewCA generates a new self-signed mesh CA.
+func NewCA() (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate CA key: %w", err)
+	}
+	serial, err := randSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Uncloud"}, CommonName: "Uncloud mesh CA"},
+		NotBefore:             now.Add(-caBackdate),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("create CA certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal CA key: %w", err)
+	}
+
+	return ParseCA(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	)
+}
+
+// ParseCA parses a mesh CA from the PEM-encoded certificate and private key.
+func ParseCA(certPEM, keyPEM []byte) (*CA, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, errors.New("invalid CA certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, errors.New("certificate is not a CA")
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("invalid CA key PEM")
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA key: %w", err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, errors.New("CA key doesn't match the certificate")
+	}
+
+	return &CA{Cert: cert, Key: key, CertPEM: certPEM, KeyPEM: keyPEM}, nil
+}
+
+// Pool returns a certificate pool that contains only the CA certificate.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	return pool
+}
+
+// Issue issues a certificate for the identity that can be used both as a TLS client and server certificate.
+// The validity starts clockTolerance before now to tolerate machines with clocks behind this machine.
+func (ca *CA) Issue(id Identity, clockTolerance time.Duration) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("generate key: %w", err)
+	}
+	serial, err := randSerial()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Uncloud"}, CommonName: id.Name},
+		NotBefore:    now.Add(-clockTolerance),
+		NotAfter:     now.Add(api.MeshCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{id.URI()},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.Cert, &key.PublicKey, ca.Key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("create certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("parse certificate: %w", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der, ca.Cert.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+// Identity is the mesh identity of a service or machine embedded in certificates as a URI SAN.
+type Identity struct {
+	// Kind is either IdentityKindService or IdentityKindMachine.
+	Kind string
+	// Name is the service name or the machine ID.
+	Name string
+}
+
+const (
+	IdentityKindService = "service"
+	IdentityKindMachine = "machine"
+)
+
+// ServiceIdentity returns the mesh identity for the service.
+func ServiceIdentity(name string) Identity {
+	return Identity{Kind: IdentityKindService, Name: name}
+}
+
+// MachineIdentity returns the mesh identity for the machine.
+func MachineIdentity(id string) Identity {
+	return Identity{Kind: IdentityKindMachine, Name: id}
+}
+
+// URI returns the identity URI, e.g. spiffe://uncloud/service/web.
+func (id Identity) URI() *url.URL {
+	return &url.URL{Scheme: identityScheme, Host: identityHost, Path: "/" + id.Kind + "/" + id.Name}
+}
+
+func (id Identity) String() string {
+	return id.URI().String()
+}
+
+// IdentityFromCert extracts the mesh identity from the certificate URI SANs.
+func IdentityFromCert(cert *x509.Certificate) (Identity, error) {
+	for _, u := range cert.URIs {
+		if u.Scheme != identityScheme || u.Host != identityHost {
+			continue
+		}
+		kind, name, ok := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+		if !ok || name == "" || (kind != IdentityKindService && kind != IdentityKindMachine) {
+			continue
+		}
+		return Identity{Kind: kind, Name: name}, nil
+	}
+	return Identity{}, errors.New("certificate has no mesh identity")
+}
+
+func randSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generate serial number: %w", err)
+	}
+	return serial, nil
+}
@@ -0,0 +1,66 @@
+package mesh
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCA_Issue(t *testing.T) {
+	t.Parallel()
+
+	ca, err := NewCA()
+	require.NoError(t, err)
+
+	parsed, err := ParseCA(ca.CertPEM, ca.KeyPEM)
+	require.NoError(t, err)
+	assert.True(t, parsed.Cert.Equal(ca.Cert))
+
+	cert, err := ca.Issue(ServiceIdentity("web"), time.Hour)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), cert.Leaf.NotBefore, time.Minute)
+	assert.WithinDuration(t, time.Now().Add(api.MeshCertValidity), cert.Leaf.NotAfter, time.Minute)
+
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	})
+	require.NoError(t, err)
+
+	// A machine with the clock behind by less than the clock tolerance accepts the certificate.
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{
+		Roots:       ca.Pool(),
+		CurrentTime: time.Now().Add(-50 * time.Minute),
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	require.NoError(t, err)
+
+	id, err := IdentityFromCert(cert.Leaf)
+	require.NoError(t, err)
+	assert.Equal(t, ServiceIdentity("web"), id)
+	assert.Equal(t, "spiffe://uncloud/service/web", id.String())
+
+	otherCA, err := NewCA()
+	require.NoError(t, err)
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{Roots: otherCA.Pool()})
+	assert.Error(t, err, "certificate must not be trusted by another CA")
+}
+
+func TestParseCA_Invalid(t *testing.T) {
+	t.Parallel()
+
+	ca, err := NewCA()
+	require.NoError(t, err)
+	otherCA, err := NewCA()
+	require.NoError(t, err)
+
+	_, err = ParseCA([]byte("invalid"), ca.KeyPEM)
+	assert.ErrorContains(t, err, "invalid CA certificate PEM")
+
+	_, err = ParseCA(ca.CertPEM, otherCA.KeyPEM)
+	assert.ErrorContains(t, err, "doesn't match")
+}
@@ -0,0 +1,297 @@
+package mesh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/constants"
+	"github.com/psviderski/uncloud/internal/machine/firewall"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// CAStoreKey is the cluster store key for the mesh CA.
+	CAStoreKey = "mesh_ca"
+	// ConfigStoreKey is the cluster store key for the JSON of the api.MeshConfig.
+	ConfigStoreKey = "mesh_config"
+	// ResyncInterval is the default interval for resyncing the routes and reloading the mesh CA from the store
+	// even if containers haven't changed.
+	ResyncInterval = time.Minute
+)
+
+// storedCA is the representation of the mesh CA in the cluster store.
+type storedCA struct {
+	Cert string `json:"cert"`
+	Key  string `json:"key"`
+}
+
+// Controller monitors mTLS-enabled containers in the cluster store and configures the mesh proxy and iptables rules
+// to transparently wrap the traffic between services in mTLS.
+type Controller struct {
+	machineID string
+	subnet    netip.Prefix
+	store     *store.Store
+	proxy     *Proxy
+	log       *slog.Logger
+	// resyncInterval is the interval for resyncing the routes even if containers haven't changed.
+	resyncInterval time.Duration
+
+	// rulesSynced indicates whether the iptables rules have been synced for lastRemote and lastLocal IPs.
+	rulesSynced bool
+	lastRemote  []netip.Addr
+	lastLocal   []netip.Addr
+}
+
+func NewController(
+	machineID string, subnet netip.Prefix, store *store.Store, resyncInterval time.Duration,
+) *Controller {
+	return &Controller{
+		machineID:      machineID,
+		subnet:         subnet,
+		store:          store,
+		proxy:          NewProxy(machineID),
+		log:            slog.With("component", "mesh-controller"),
+		resyncInterval: resyncInterval,
+	}
+}
+
+func (c *Controller) Run(ctx context.Context) error {
+	machineIP := network.MachineIP(c.subnet)
+	inboundAddr := net.JoinHostPort(machineIP.String(), strconv.Itoa(constants.MeshPort))
+	inbound, err := net.Listen("tcp", inboundAddr)
+	if err != nil {
+		return fmt.Errorf("listen mesh proxy port: %w", err)
+	}
+	redirectAddr := net.JoinHostPort(machineIP.String(), strconv.Itoa(constants.MeshRedirectPort))
+	redirect, err := net.Listen("tcp", redirectAddr)
+	if err != nil {
+		inbound.Close()
+		return fmt.Errorf("listen mesh proxy redirect port: %w", err)
+	}
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	errGroup.Go(func() error {
+		c.log.Info("Starting mesh proxy.", "addr", inboundAddr)
+		if err := c.proxy.ServeInbound(ctx, inbound); err != nil {
+			return fmt.Errorf("mesh proxy failed: %w", err)
+		}
+		return nil
+	})
+	errGroup.Go(func() error {
+		c.log.Info("Starting mesh redirect proxy.", "addr", redirectAddr)
+		if err := c.proxy.ServeRedirect(ctx, redirect); err != nil {
+			return fmt.Errorf("mesh redirect proxy failed: %w", err)
+		}
+		return nil
+	})
+	errGroup.Go(func() error {
+		return c.watchContainers(ctx)
+	})
+
+	return errGroup.Wait()
+}
+
+func (c *Controller) watchContainers(ctx context.Context) error {
+	updates, err := c.store.WatchContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("subscribe to container changes: %w", err)
+	}
+	c.log.Info("Subscribed to container changes in the cluster to configure mTLS mesh.")
+
+	// Resync periodically with the latest containers to reload the mesh CA and config and restore iptables rules that
+	// may have been changed externally.
+	ticker := time.NewTicker(c.resyncInterval)
+	defer ticker.Stop()
+
+	var containers []store.ContainerRecord
+	for {
+		select {
+		case cs, ok := <-updates:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("containers subscription failed")
+			}
+			containers = cs
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		c.sync(ctx, containers)
+	}
+}
+
+// sync updates the proxy routes and iptables rules for the current mTLS-enabled containers.
+func (c *Controller) sync(ctx context.Context, containers []store.ContainerRecord) {
+	machines, err := c.store.ListMachines(ctx)
+	if err != nil {
+		c.log.Error("Failed to list machines.", "err", err)
+		return
+	}
+	routes := buildRoutes(c.machineID, containers, machines)
+	enabled := len(routes.Local) > 0 || len(routes.Remote) > 0
+
+	// Create the CA lazily when the first mTLS-enabled service is deployed. Reload it on every sync to converge
+	// to the same CA on all machines if multiple machines created it concurrently.
+	if enabled || c.proxy.ca.Load() != nil {
+		config, err := c.loadConfig(ctx)
+		if err != nil {
+			c.log.Error("Failed to load mesh config.", "err", err)
+		} else {
+			c.proxy.SetConfig(config)
+		}
+
+		ca, err := c.loadCA(ctx, enabled)
+		if err != nil {
+			// Still sync the iptables rules so that the traffic fails closed rather than goes unencrypted.
+			c.log.Error("Failed to load mesh CA.", "err", err)
+		} else if ca != nil {
+			c.proxy.SetCA(ca)
+		}
+	}
+	c.proxy.SetRoutes(routes)
+
+	remote, local := sortedKeys(routes.Remote), sortedKeys(routes.Local)
+	if c.rulesSynced && slices.Equal(remote, c.lastRemote) && slices.Equal(local, c.lastLocal) {
+		return
+	}
+	if err = firewall.SyncMeshRules(c.subnet, constants.MeshRedirectPort, remote, local); err != nil {
+		c.log.Error("Failed to sync mesh iptables rules.", "err", err)
+		c.rulesSynced = false
+		return
+	}
+	c.rulesSynced = true
+	c.lastRemote, c.lastLocal = remote, local
+	c.log.Info("Synced mTLS mesh rules.", "remote_containers", len(remote), "local_containers", len(local))
+}
+
+// loadCA loads the mesh CA from the cluster store. If the CA doesn't exist and create is true, it generates a new CA
+// and saves it to the store. It returns nil if the CA doesn't exist and create is false.
+func (c *Controller) loadCA(ctx context.Context, create bool) (*CA, error) {
+	var value string
+	err := c.store.Get(ctx, CAStoreKey, &value)
+	if errors.Is(err, store.ErrKeyNotFound) {
+		if !create {
+			return nil, nil
+		}
+		return c.createCA(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get mesh CA from store: %w", err)
+	}
+
+	var stored storedCA
+	if err = json.Unmarshal([]byte(value), &stored); err != nil {
+		return nil, fmt.Errorf("unmarshal mesh CA: %w", err)
+	}
+	// Keep the current CA if it hasn't changed to preserve the issued certificates cache.
+	if current := c.proxy.ca.Load(); current != nil && string(current.CertPEM) == stored.Cert {
+		return current, nil
+	}
+	return ParseCA([]byte(stored.Cert), []byte(stored.Key))
+}
+
+// loadConfig loads the mesh config from the cluster store. It returns an empty config if it's not configured.
+func (c *Controller) loadConfig(ctx context.Context) (api.MeshConfig, error) {
+	var config api.MeshConfig
+	var configJSON []byte
+	if err := c.store.Get(ctx, ConfigStoreKey, &configJSON); err != nil {
+		if errors.Is(err, store.ErrKeyNotFound) {
+			return config, nil
+		}
+		return config, fmt.Errorf("get mesh config from store: %w", err)
+	}
+
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return config, fmt.Errorf("unmarshal mesh config: %w", err)
+	}
+	return config, nil
+}
+
+func (c *Controller) createCA(ctx context.Context) (*CA, error) {
+	ca, err := NewCA()
+	if err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(storedCA{Cert: string(ca.CertPEM), Key: string(ca.KeyPEM)})
+	if err != nil {
+		return nil, fmt.Errorf("marshal mesh CA: %w", err)
+	}
+	if err = c.store.Put(ctx, CAStoreKey, string(value)); err != nil {
+		return nil, fmt.Errorf("put mesh CA to store: %w", err)
+	}
+	c.log.Info("Generated new mesh CA for mTLS-enabled services.")
+
+	return ca, nil
+}
+
+// buildRoutes builds the proxy routes from the containers and machines in the cluster.
+func buildRoutes(machineID string, containers []store.ContainerRecord, machines []*pb.MachineInfo) *Routes {
+	machineIPs := make(map[string]netip.Addr, len(machines))
+	for _, m := range machines {
+		if m.Network == nil {
+			continue
+		}
+		if subnet, err := m.Network.Subnet.ToPrefix(); err == nil {
+			machineIPs[m.Id] = network.MachineIP(subnet)
+		}
+	}
+
+	routes := &Routes{
+		Local:    make(map[netip.Addr]struct{}),
+		Remote:   make(map[netip.Addr]Peer),
+		Services: make(map[netip.Addr]string),
+	}
+	for _, cr := range containers {
+		ctr := cr.Container
+		ip := ctr.UncloudNetworkIP()
+		if !ip.IsValid() {
+			continue
+		}
+		local := cr.MachineID == machineID
+		if local {
+			routes.Services[ip] = ctr.ServiceName()
+		}
+
+		if !ctr.ServiceSpec.MTLS || ctr.IsHook() || ctr.State == nil || !ctr.State.Running {
+			continue
+		}
+		if local {
+			routes.Local[ip] = struct{}{}
+			continue
+		}
+		if machineIP, ok := machineIPs[cr.MachineID]; ok {
+			routes.Remote[ip] = Peer{
+				MachineID: cr.MachineID,
+				Addr:      netip.AddrPortFrom(machineIP, constants.MeshPort),
+			}
+		}
+	}
+
+	return routes
+}
+
+func sortedKeys[V any](m map[netip.Addr]V) []netip.Addr {
+	keys := make([]netip.Addr, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b netip.Addr) int {
+		return a.Compare(b)
+	})
+	return keys
+}
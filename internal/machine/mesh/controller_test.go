@@ -0,0 +1,64 @@
+package mesh
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/constants"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRoutes(t *testing.T) {
+	t.Parallel()
+
+	record := func(machineID, service, ip string, mtls, running bool) store.ContainerRecord {
+		ctr := api.ServiceContainer{
+			Container: api.Container{InspectResponse: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					State: &container.State{Running: running},
+				},
+				Config: &container.Config{Labels: map[string]string{api.LabelServiceName: service}},
+				NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+					api.DockerNetworkName: {IPAddress: ip},
+				}},
+			}},
+			ServiceSpec: api.ServiceSpec{Name: service, MTLS: mtls},
+		}
+		return store.ContainerRecord{Container: ctr, MachineID: machineID}
+	}
+	machines := []*pb.MachineInfo{
+		{Id: "m1", Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.210.0.0/24"))}},
+		{Id: "m2", Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.210.1.0/24"))}},
+	}
+	containers := []store.ContainerRecord{
+		record("m1", "web", "10.210.0.2", false, true),
+		record("m1", "db", "10.210.0.3", true, true),
+		record("m2", "db", "10.210.1.2", true, true),
+		// Stopped and non-mTLS remote containers aren't routed through the mesh.
+		record("m2", "db", "10.210.1.3", true, false),
+		record("m2", "web", "10.210.1.4", false, true),
+		// Container on an unknown machine.
+		record("m3", "db", "10.210.2.2", true, true),
+	}
+
+	routes := buildRoutes("m1", containers, machines)
+
+	assert.Equal(t, map[netip.Addr]struct{}{
+		netip.MustParseAddr("10.210.0.3"): {},
+	}, routes.Local)
+	assert.Equal(t, map[netip.Addr]Peer{
+		netip.MustParseAddr("10.210.1.2"): {
+			MachineID: "m2",
+			Addr:      netip.AddrPortFrom(netip.MustParseAddr("10.210.1.1"), constants.MeshPort),
+		},
+	}, routes.Remote)
+	assert.Equal(t, map[netip.Addr]string{
+		netip.MustParseAddr("10.210.0.2"): "web",
+		netip.MustParseAddr("10.210.0.3"): "db",
+	}, routes.Services)
+}
@@ -0,0 +1,12 @@
+package mesh
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// originalDst is a stub for Darwin.
+func originalDst(_ net.Conn) (netip.AddrPort, error) {
+	return netip.AddrPort{}, fmt.Errorf("not supported on Darwin")
+}
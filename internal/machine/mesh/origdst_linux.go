@@ -0,0 +1,41 @@
+package mesh
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"golang.org/x/sys/unix"
+)
+
+// originalDst returns the original destination address of the TCP connection redirected by an iptables REDIRECT rule.
+func originalDst(conn net.Conn) (netip.AddrPort, error) {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return netip.AddrPort{}, errors.New("not a TCP connection")
+	}
+	rawConn, err := tcpConn.SyscallConn()
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+
+	var (
+		mreq    *unix.IPv6Mreq
+		sockErr error
+	)
+	// SO_ORIGINAL_DST returns a sockaddr_in struct that fits into IPv6Mreq.
+	if err = rawConn.Control(func(fd uintptr) {
+		mreq, sockErr = unix.GetsockoptIPv6Mreq(int(fd), unix.IPPROTO_IP, unix.SO_ORIGINAL_DST)
+	}); err != nil {
+		return netip.AddrPort{}, err
+	}
+	if sockErr != nil {
+		return netip.AddrPort{}, fmt.Errorf("get SO_ORIGINAL_DST: %w", sockErr)
+	}
+
+	port := binary.BigEndian.Uint16(mreq.Multiaddr[2:4])
+	addr := netip.AddrFrom4([4]byte(mreq.Multiaddr[4:8]))
+	return netip.AddrPortFrom(addr, port), nil
+}
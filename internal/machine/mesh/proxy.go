@@ -0,0 +1,430 @@
+package mesh
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// handshakeTimeout is the maximum time to complete the TLS handshake and exchange the destination header.
+	handshakeTimeout = 10 * time.Second
+	// maxHeaderLen is the maximum length of the destination header sent after the TLS handshake.
+	maxHeaderLen = 64
+	// skewWarnInterval is the minimum interval between clock skew warnings for the same peer identity.
+	skewWarnInterval = 10 * time.Minute
+)
+
+// Routes is a snapshot of the mTLS-enabled containers in the cluster used by the proxy to route connections.
+type Routes struct {
+	// Local is the set of IPs of the mTLS-enabled containers on this machine that accept connections from the mesh.
+	Local map[netip.Addr]struct{}
+	// Remote maps IPs of the mTLS-enabled containers on other machines to the machines running them.
+	Remote map[netip.Addr]Peer
+	// Services maps IPs of the containers on this machine to their service names to select the client identity.
+	Services map[netip.Addr]string
+}
+
+// Peer is a remote machine running a mesh proxy.
+type Peer struct {
+	MachineID string
+	// Addr is the address of the mesh proxy on the machine.
+	Addr netip.AddrPort
+}
+
+// Proxy transparently wraps TCP connections between services in mTLS. Connections from local containers to remote
+// mTLS-enabled containers are redirected to the proxy by iptables. The proxy connects to the proxy on the machine
+// running the destination container using the identity of the source service and sends the original destination
+// after the handshake. The remote proxy verifies the client certificate and forwards the connection to the container.
+type Proxy struct {
+	machineID string
+	ca        atomic.Pointer[CA]
+	routes    atomic.Pointer[Routes]
+	config    atomic.Pointer[api.MeshConfig]
+	dialer    net.Dialer
+	log       *slog.Logger
+
+	mu sync.Mutex
+	// certs caches the issued certificates by identity.
+	certs map[Identity]*tls.Certificate
+	// certsCA is the CA that issued the cached certificates.
+	certsCA *CA
+	// skewWarnings is the last time a clock skew warning was logged for each peer identity.
+	skewWarnings map[Identity]time.Time
+}
+
+func NewProxy(machineID string) *Proxy {
+	p := &Proxy{
+		machineID:    machineID,
+		log:          slog.With("component", "mesh-proxy"),
+		skewWarnings: make(map[Identity]time.Time),
+	}
+	p.routes.Store(&Routes{})
+	p.config.Store(&api.MeshConfig{})
+	return p
+}
+
+// SetCA sets the mesh CA used to issue and verify certificates.
+func (p *Proxy) SetCA(ca *CA) {
+	p.ca.Store(ca)
+}
+
+// SetConfig sets the clock tolerance and renewal windows of the issued certificates. The cached certificates are
+// reissued if the clock tolerance changes.
+func (p *Proxy) SetConfig(config api.MeshConfig) {
+	config = config.WithDefaults()
+	if old := p.config.Swap(&config); old.WithDefaults().ClockTolerance != config.ClockTolerance {
+		p.mu.Lock()
+		p.certs = make(map[Identity]*tls.Certificate)
+		p.mu.Unlock()
+	}
+}
+
+// SetRoutes atomically replaces the routes used by the proxy.
+func (p *Proxy) SetRoutes(routes *Routes) {
+	p.routes.Store(routes)
+}
+
+// ServeInbound accepts mTLS connections from other machines and forwards them to the local mTLS-enabled containers.
+// It blocks until the context is canceled.
+func (p *Proxy) ServeInbound(ctx context.Context, ln net.Listener) error {
+	return serve(ctx, ln, func(conn net.Conn) {
+		p.handleInbound(ctx, conn)
+	})
+}
+
+// ServeRedirect accepts plaintext connections from local containers redirected by iptables and forwards them to
+// the original destination through the mesh. It blocks until the context is canceled.
+func (p *Proxy) ServeRedirect(ctx context.Context, ln net.Listener) error {
+	return serve(ctx, ln, func(conn net.Conn) {
+		p.handleRedirect(ctx, conn)
+	})
+}
+
+func serve(ctx context.Context, ln net.Listener, handle func(net.Conn)) error {
+	stop := context.AfterFunc(ctx, func() {
+		ln.Close()
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handle(conn)
+		}()
+	}
+}
+
+func (p *Proxy) handleInbound(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	log := p.log.With("remote_addr", conn.RemoteAddr())
+
+	ca := p.ca.Load()
+	if ca == nil {
+		log.Warn("Rejected mesh connection: mesh CA is not loaded.")
+		return
+	}
+
+	tlsConn := tls.Server(conn, &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return p.certificate(ca, MachineIdentity(p.machineID))
+		},
+		ClientAuth:       tls.RequireAnyClientCert,
+		VerifyConnection: p.verifyPeer(ca, nil),
+		MinVersion:       tls.VersionTLS13,
+	})
+	if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		log.Debug("Failed to set deadline for mesh connection.", "err", err)
+		return
+	}
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		log.Warn("Mesh TLS handshake failed.", "err", err)
+		return
+	}
+	// Ignore the error as the identity has been checked in VerifyConnection.
+	peerID, _ := IdentityFromCert(tlsConn.ConnectionState().PeerCertificates[0])
+	log = log.With("identity", peerID)
+
+	dst, err := readHeader(tlsConn)
+	if err != nil {
+		log.Warn("Failed to read destination from mesh connection.", "err", err)
+		return
+	}
+	if err = conn.SetDeadline(time.Time{}); err != nil {
+		log.Debug("Failed to reset deadline for mesh connection.", "err", err)
+		return
+	}
+	log = log.With("dst", dst)
+
+	if _, ok := p.routes.Load().Local[dst.Addr()]; !ok {
+		log.Warn("Rejected mesh connection to a container that is not an mTLS-enabled container on this machine.")
+		return
+	}
+
+	upstream, err := p.dialer.DialContext(ctx, "tcp", dst.String())
+	if err != nil {
+		log.Debug("Failed to connect to mTLS-enabled container.", "err", err)
+		return
+	}
+	log.Debug("Forwarding mesh connection to mTLS-enabled container.")
+	pipe(ctx, tlsConn, upstream)
+}
+
+func (p *Proxy) handleRedirect(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	log := p.log.With("remote_addr", conn.RemoteAddr())
+
+	dst, err := originalDst(conn)
+	if err != nil {
+		log.Warn("Failed to get original destination of redirected connection.", "err", err)
+		return
+	}
+	var src netip.Addr
+	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		src = addr.AddrPort().Addr().Unmap()
+	}
+
+	upstream, err := p.dial(ctx, src, dst)
+	if err != nil {
+		log.Warn("Failed to connect to mTLS-enabled container through the mesh.", "dst", dst, "err", err)
+		return
+	}
+	pipe(ctx, conn, upstream)
+}
+
+// dial connects to the mTLS-enabled container at dst through the mesh proxy on the machine running it. The client
+// certificate is issued for the service of the local container with the src IP or for this machine if the source
+// is not a known service container.
+func (p *Proxy) dial(ctx context.Context, src netip.Addr, dst netip.AddrPort) (net.Conn, error) {
+	ca := p.ca.Load()
+	if ca == nil {
+		return nil, errors.New("mesh CA is not loaded")
+	}
+	routes := p.routes.Load()
+	peer, ok := routes.Remote[dst.Addr()]
+	if !ok {
+		return nil, fmt.Errorf("no mTLS-enabled container with IP %s on other machines", dst.Addr())
+	}
+
+	id := MachineIdentity(p.machineID)
+	if name := routes.Services[src]; name != "" {
+		id = ServiceIdentity(name)
+	}
+	cert, err := p.certificate(ca, id)
+	if err != nil {
+		return nil, fmt.Errorf("issue certificate for %s: %w", id, err)
+	}
+
+	conn, err := p.dialer.DialContext(ctx, "tcp", peer.Addr.String())
+	if err != nil {
+		return nil, err
+	}
+	want := MachineIdentity(peer.MachineID)
+	tlsConn := tls.Client(conn, &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		// The server certificate has no DNS names so it's verified against the mesh CA and the expected machine
+		// identity in VerifyConnection instead.
+		InsecureSkipVerify: true,
+		VerifyConnection:   p.verifyPeer(ca, &want),
+		MinVersion:         tls.VersionTLS13,
+	})
+
+	if err = conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err = tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("TLS handshake with %s: %w", peer.Addr, err)
+	}
+	if _, err = io.WriteString(tlsConn, dst.String()+"\n"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("send destination: %w", err)
+	}
+	if err = conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+// certificate returns a cached certificate for the identity issued by the CA or issues a new one if it's missing
+// or about to expire.
+func (p *Proxy) certificate(ca *CA, id Identity) (*tls.Certificate, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	config := p.config.Load().WithDefaults()
+	if p.certsCA != ca {
+		p.certs = make(map[Identity]*tls.Certificate)
+		p.certsCA = ca
+	}
+	if cert, ok := p.certs[id]; ok && time.Until(cert.Leaf.NotAfter) > config.RenewBefore {
+		return cert, nil
+	}
+
+	cert, err := ca.Issue(id, config.ClockTolerance)
+	if err != nil {
+		return nil, err
+	}
+	p.certs[id] = &cert
+	return &cert, nil
+}
+
+// verifyPeer returns a function that verifies the peer certificate is issued by the CA and, if want is not nil,
+// has the wanted identity. It warns when the clock skew with the peer machine that issued the certificate
+// approaches or exceeds the configured windows.
+func (p *Proxy) verifyPeer(ca *CA, want *Identity) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("no peer certificate")
+		}
+		leaf := cs.PeerCertificates[0]
+		intermediates := x509.NewCertPool()
+		for _, cert := range cs.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		config := p.config.Load().WithDefaults()
+		now := time.Now()
+		skew := clockSkew(leaf, now, config)
+		if _, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         ca.Pool(),
+			Intermediates: intermediates,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			var invalidErr x509.CertificateInvalidError
+			if skew != 0 && errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired {
+				// The identity isn't verified but it's only used for logging.
+				id, _ := IdentityFromCert(leaf)
+				p.warnClockSkew(id, skew, true)
+			}
+			return fmt.Errorf("verify peer certificate: %w", err)
+		}
+
+		id, err := IdentityFromCert(leaf)
+		if err != nil {
+			return err
+		}
+		if want != nil && id != *want {
+			return fmt.Errorf("unexpected peer identity %s, expected %s", id, *want)
+		}
+		if skew > config.ClockTolerance/2 || -skew > config.RenewBefore/2 {
+			p.warnClockSkew(id, skew, false)
+		}
+		return nil
+	}
+}
+
+// clockSkew estimates the minimum clock skew between the peer machine that issued the certificate and this machine
+// from the certificate validity. It's positive if the peer clock is ahead and negative if it's behind. The skew
+// can only be detected when the certificate is issued in the future or is older than its renewal time according
+// to this machine's clock. Otherwise, it returns zero.
+func clockSkew(cert *x509.Certificate, now time.Time, config api.MeshConfig) time.Duration {
+	if issued := cert.NotBefore.Add(config.ClockTolerance); issued.After(now) {
+		return issued.Sub(now)
+	}
+	if renew := cert.NotAfter.Add(-config.RenewBefore); now.After(renew) {
+		return renew.Sub(now)
+	}
+	return 0
+}
+
+// warnClockSkew logs a warning about the clock skew with the peer machine at most once per skewWarnInterval
+// for each peer identity.
+func (p *Proxy) warnClockSkew(peer Identity, skew time.Duration, rejected bool) {
+	p.mu.Lock()
+	if last, ok := p.skewWarnings[peer]; ok && time.Since(last) < skewWarnInterval {
+		p.mu.Unlock()
+		return
+	}
+	p.skewWarnings[peer] = time.Now()
+	p.mu.Unlock()
+
+	peerClock := "ahead"
+	if skew < 0 {
+		peerClock, skew = "behind", -skew
+	}
+	msg := "Clock skew with the peer machine is approaching the mesh certificate windows. " +
+		"Synchronize the machine clocks or increase the windows with 'uc cluster mtls set'."
+	if rejected {
+		msg = "Rejected mesh certificate outside its validity period due to clock skew with the peer machine. " +
+			"Synchronize the machine clocks or increase the windows with 'uc cluster mtls set'."
+	}
+	p.log.Warn(msg, "peer", peer, "peer_clock", peerClock, "min_skew", skew.Round(time.Second))
+}
+
+// readHeader reads the destination address line sent by the client proxy. It reads byte by byte to not consume
+// any data following the header.
+func readHeader(r io.Reader) (netip.AddrPort, error) {
+	var buf []byte
+	b := make([]byte, 1)
+	for len(buf) < maxHeaderLen {
+		if _, err := io.ReadFull(r, b); err != nil {
+			return netip.AddrPort{}, err
+		}
+		if b[0] == '\n' {
+			return netip.ParseAddrPort(string(buf))
+		}
+		buf = append(buf, b[0])
+	}
+	return netip.AddrPort{}, errors.New("destination header is too long")
+}
+
+// halfCloser is an interface for connections that support half-close.
+type halfCloser interface {
+	CloseWrite() error
+}
+
+// pipe copies data between the connections in both directions until both directions are done or the context
+// is canceled. Both connections are closed when it returns.
+func pipe(ctx context.Context, a, b net.Conn) {
+	stop := context.AfterFunc(ctx, func() {
+		a.Close()
+		b.Close()
+	})
+	defer stop()
+	defer a.Close()
+	defer b.Close()
+
+	var wg sync.WaitGroup
+	cp := func(dst, src net.Conn) {
+		defer wg.Done()
+		_, _ = io.Copy(dst, src)
+		// Propagate EOF to the other side while allowing it to finish sending its data.
+		if hc, ok := dst.(halfCloser); ok {
+			_ = hc.CloseWrite()
+		} else {
+			dst.Close()
+		}
+	}
+	wg.Add(2)
+	go cp(a, b)
+	go cp(b, a)
+	wg.Wait()
+}
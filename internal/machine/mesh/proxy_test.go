@@ -0,0 +1,209 @@
+package mesh
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startEchoServer starts a TCP server on the loopback that echoes back all received data.
+func startEchoServer(t *testing.T) netip.AddrPort {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).AddrPort()
+}
+
+// startInbound starts the inbound mesh proxy on the loopback and returns its address.
+func startInbound(t *testing.T, ctx context.Context, p *Proxy) netip.AddrPort {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = p.ServeInbound(ctx, ln)
+	}()
+	return ln.Addr().(*net.TCPAddr).AddrPort()
+}
+
+func TestProxy_Dial(t *testing.T) {
+	t.Parallel()
+
+	ca, err := NewCA()
+	require.NoError(t, err)
+	otherCA, err := NewCA()
+	require.NoError(t, err)
+
+	echoAddr := startEchoServer(t)
+	src := netip.MustParseAddr("10.210.0.2")
+
+	tests := []struct {
+		name     string
+		serverCA *CA
+		// local is the set of local mTLS-enabled container IPs on the server.
+		local   map[netip.Addr]struct{}
+		peerID  string
+		wantErr string
+		// wantClosed is true if the server is expected to close the connection after the handshake.
+		wantClosed bool
+	}{
+		{
+			name:     "forwarded",
+			serverCA: ca,
+			local:    map[netip.Addr]struct{}{echoAddr.Addr(): {}},
+			peerID:   "server",
+		},
+		{
+			name:     "untrusted server CA",
+			serverCA: otherCA,
+			local:    map[netip.Addr]struct{}{echoAddr.Addr(): {}},
+			peerID:   "server",
+			wantErr:  "TLS handshake",
+		},
+		{
+			name:     "unexpected server identity",
+			serverCA: ca,
+			local:    map[netip.Addr]struct{}{echoAddr.Addr(): {}},
+			peerID:   "other",
+			wantErr:  "unexpected peer identity",
+		},
+		{
+			name:       "destination not mTLS-enabled",
+			serverCA:   ca,
+			local:      map[netip.Addr]struct{}{},
+			peerID:     "server",
+			wantClosed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			server := NewProxy("server")
+			server.SetCA(tt.serverCA)
+			server.SetRoutes(&Routes{Local: tt.local})
+			serverAddr := startInbound(t, ctx, server)
+
+			client := NewProxy("client")
+			client.SetCA(ca)
+			client.SetRoutes(&Routes{
+				Remote:   map[netip.Addr]Peer{echoAddr.Addr(): {MachineID: tt.peerID, Addr: serverAddr}},
+				Services: map[netip.Addr]string{src: "web"},
+			})
+
+			conn, err := client.dial(ctx, src, echoAddr)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("hello"))
+			require.NoError(t, err)
+			buf := make([]byte, 5)
+			_, err = io.ReadFull(conn, buf)
+			if tt.wantClosed {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(buf))
+		})
+	}
+}
+
+func TestProxy_Dial_NoRoute(t *testing.T) {
+	t.Parallel()
+
+	ca, err := NewCA()
+	require.NoError(t, err)
+	p := NewProxy("client")
+	p.SetCA(ca)
+
+	_, err = p.dial(context.Background(), netip.Addr{}, netip.MustParseAddrPort("10.210.1.2:80"))
+	assert.ErrorContains(t, err, "no mTLS-enabled container")
+}
+
+func TestClockSkew(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := api.MeshConfig{ClockTolerance: 10 * time.Minute, RenewBefore: time.Hour}
+	// issuedAt returns a certificate issued at the time according to the clock of the issuing machine.
+	issuedAt := func(t time.Time) *x509.Certificate {
+		return &x509.Certificate{NotBefore: t.Add(-config.ClockTolerance), NotAfter: t.Add(api.MeshCertValidity)}
+	}
+
+	tests := []struct {
+		name string
+		cert *x509.Certificate
+		want time.Duration
+	}{
+		{name: "in sync", cert: issuedAt(now), want: 0},
+		{name: "due for renewal", cert: issuedAt(now.Add(-23 * time.Hour)), want: 0},
+		{name: "peer ahead", cert: issuedAt(now.Add(7 * time.Minute)), want: 7 * time.Minute},
+		{name: "peer behind", cert: issuedAt(now.Add(-23*time.Hour - 20*time.Minute)), want: -20 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, clockSkew(tt.cert, now, config))
+		})
+	}
+}
+
+func TestProxy_Certificate_Config(t *testing.T) {
+	t.Parallel()
+
+	ca, err := NewCA()
+	require.NoError(t, err)
+	p := NewProxy("m-1")
+	id := ServiceIdentity("web")
+
+	cert, err := p.certificate(ca, id)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-api.DefaultMeshClockTolerance), cert.Leaf.NotBefore, time.Minute)
+	cached, err := p.certificate(ca, id)
+	require.NoError(t, err)
+	assert.Same(t, cert, cached)
+
+	// Changing the clock tolerance reissues the certificates.
+	p.SetConfig(api.MeshConfig{ClockTolerance: time.Hour})
+	cert, err = p.certificate(ca, id)
+	require.NoError(t, err)
+	assert.NotSame(t, cached, cert)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), cert.Leaf.NotBefore, time.Minute)
+
+	// A certificate within the renewal window is reissued.
+	p.SetConfig(api.MeshConfig{ClockTolerance: time.Hour, RenewBefore: 12 * time.Hour})
+	cached, err = p.certificate(ca, id)
+	require.NoError(t, err)
+	assert.Same(t, cert, cached)
+	cert.Leaf.NotAfter = time.Now().Add(11 * time.Hour)
+	renewed, err := p.certificate(ca, id)
+	require.NoError(t, err)
+	assert.NotSame(t, cert, renewed)
+}
@@ -69,6 +69,44 @@ func ListRoutableIPs() ([]netip.Addr, error) {
 	return routable, nil
 }
 
+// InterfaceIPs returns the global unicast IPv4 addresses of the network interface with the given name or its IPv6
+// addresses if it has no IPv4 ones.
+func InterfaceIPs(name string) ([]netip.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("get network interface %q: %w", name, err)
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("list addresses for interface %q: %w", name, err)
+	}
+
+	var ipv4, ipv6 []netip.Addr
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+		ip, ok := netip.AddrFromSlice(ipNet.IP)
+		if !ok {
+			continue
+		}
+		if ip = ip.Unmap(); ip.Is4() {
+			ipv4 = append(ipv4, ip)
+		} else {
+			ipv6 = append(ipv6, ip)
+		}
+	}
+
+	if len(ipv4) > 0 {
+		return ipv4, nil
+	}
+	if len(ipv6) > 0 {
+		return ipv6, nil
+	}
+	return nil, fmt.Errorf("network interface %q has no global unicast IP addresses", name)
+}
+
 func GetPublicIP() (netip.Addr, error) {
 	services := []struct {
 		URL    string
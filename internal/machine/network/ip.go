@@ -6,6 +6,7 @@ import (
 	"net/netip"
 
 	"github.com/psviderski/uncloud/internal/secret"
+	"go4.org/netipx"
 )
 
 // MachineIP returns the IP address of the machine which is the first address in the subnet.
@@ -13,6 +14,24 @@ func MachineIP(subnet netip.Prefix) netip.Addr {
 	return subnet.Masked().Addr().Next()
 }
 
+// DynamicIPRange returns the lower half of the machine subnet from which Docker allocates container IPs dynamically.
+func DynamicIPRange(subnet netip.Prefix) netip.Prefix {
+	subnet = subnet.Masked()
+	return netip.PrefixFrom(subnet.Addr(), subnet.Bits()+1)
+}
+
+// StaticIPRange returns the upper half of the machine subnet reserved for fixed container IPs that services claim
+// with the x-internal-ip extension.
+func StaticIPRange(subnet netip.Prefix) netip.Prefix {
+	subnet = subnet.Masked()
+	return netip.PrefixFrom(netipx.PrefixLastIP(subnet), subnet.Bits()+1).Masked()
+}
+
+// IsStaticIP returns true if the IP is in the static IP range of the machine subnet and isn't its broadcast address.
+func IsStaticIP(subnet netip.Prefix, ip netip.Addr) bool {
+	return StaticIPRange(subnet).Contains(ip) && ip != netipx.PrefixLastIP(subnet.Masked())
+}
+
 // ManagementIP returns the IPv6 address of a peer derived from the first 14 bytes of its public key.
 // This address always starts with fdcc: and is intended for cluster management traffic.
 func ManagementIP(publicKey secret.Secret) netip.Addr {
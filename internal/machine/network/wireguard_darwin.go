@@ -5,6 +5,7 @@ package network
 import (
 	"context"
 	"errors"
+	"net/netip"
 )
 
 type WireGuardNetwork struct{}
@@ -28,3 +29,7 @@ func (n *WireGuardNetwork) WatchEndpoints() <-chan EndpointChangeEvent {
 func (n *WireGuardNetwork) Cleanup() error {
 	return errors.New("not implemented on darwin")
 }
+
+func ListWireGuardRoutes() ([]netip.Prefix, error) {
+	return nil, errors.New("not implemented on darwin")
+}
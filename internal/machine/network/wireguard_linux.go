@@ -271,6 +271,31 @@ func (n *WireGuardNetwork) updatePeerRoutes(machineIP netip.Addr) error {
 	return nil
 }
 
+// ListWireGuardRoutes returns the destinations of the routes via the WireGuard interface.
+func ListWireGuardRoutes() ([]netip.Prefix, error) {
+	link, err := netlink.LinkByName(WireGuardInterfaceName)
+	if err != nil {
+		return nil, fmt.Errorf("get WireGuard link %q: %w", WireGuardInterfaceName, err)
+	}
+	routes, err := netlink.RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("list routes on WireGuard link %q: %w", WireGuardInterfaceName, err)
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(routes))
+	for _, route := range routes {
+		if route.Dst == nil {
+			continue
+		}
+		prefix, pErr := ipNetToPrefix(*route.Dst)
+		if pErr != nil {
+			return nil, fmt.Errorf("parse route destination: %w", pErr)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
 func (n *WireGuardNetwork) Run(ctx context.Context) error {
 	wg, err := wgctrl.New()
 	if err != nil {
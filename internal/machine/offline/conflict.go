@@ -0,0 +1,84 @@
+package offline
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// ReasonServiceRedeployed means the service was redeployed on other machines while the machine was offline
+	// so the local container runs an outdated spec.
+	ReasonServiceRedeployed = "service was redeployed while the machine was offline, the local container " +
+		"runs an outdated spec"
+	// ReasonServiceRemoved means all containers of the service on other machines were removed while the machine
+	// was offline but the local container is still running.
+	ReasonServiceRemoved = "service containers on other machines were removed while the machine was offline"
+)
+
+// Conflict is a local container that diverged from the rest of the cluster while the machine was offline.
+type Conflict struct {
+	ServiceName   string
+	ContainerID   string
+	ContainerName string
+	Reason        string
+}
+
+// DetectConflicts compares the local containers of the machine with the containers on other machines after
+// reconnecting to the cluster. remoteServices is the set of service IDs that had containers on other machines when
+// the machine went offline.
+func DetectConflicts(
+	machineID string, offlineSince time.Time, remoteServices map[string]struct{}, records []store.ContainerRecord,
+) []Conflict {
+	var local []api.ServiceContainer
+	// remote maps service IDs to their containers on other machines.
+	remote := make(map[string][]api.ServiceContainer)
+	for _, r := range records {
+		if r.Container.IsHook() {
+			continue
+		}
+		if r.MachineID == machineID {
+			local = append(local, r.Container)
+		} else {
+			remote[r.Container.ServiceID()] = append(remote[r.Container.ServiceID()], r.Container)
+		}
+	}
+
+	var conflicts []Conflict
+	for _, ctr := range local {
+		reason := ""
+		others := remote[ctr.ServiceID()]
+		if _, ok := remoteServices[ctr.ServiceID()]; ok && len(others) == 0 {
+			reason = ReasonServiceRemoved
+		}
+		for _, o := range others {
+			if o.CreatedTime().After(offlineSince) &&
+				!cmp.Equal(ctr.ServiceSpec, o.ServiceSpec, cmpopts.EquateEmpty()) {
+				reason = ReasonServiceRedeployed
+				break
+			}
+		}
+		if reason == "" {
+			continue
+		}
+
+		conflicts = append(conflicts, Conflict{
+			ServiceName:   ctr.ServiceName(),
+			ContainerID:   ctr.ID,
+			ContainerName: ctr.Name,
+			Reason:        reason,
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].ServiceName == conflicts[j].ServiceName {
+			return conflicts[i].ContainerName < conflicts[j].ContainerName
+		}
+		return conflicts[i].ServiceName < conflicts[j].ServiceName
+	})
+	return conflicts
+}
@@ -0,0 +1,167 @@
+package offline
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/corrosion"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectConflicts(t *testing.T) {
+	t.Parallel()
+
+	offlineSince := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := offlineSince.Add(-time.Hour)
+	after := offlineSince.Add(time.Hour)
+
+	record := func(machineID, id, service, image string, created time.Time) store.ContainerRecord {
+		ctr := api.ServiceContainer{
+			Container: api.Container{InspectResponse: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					ID:      id,
+					Name:    service + "-" + id,
+					Created: created.Format(time.RFC3339Nano),
+				},
+				Config: &container.Config{Labels: map[string]string{
+					api.LabelServiceID:   service + "-id",
+					api.LabelServiceName: service,
+				}},
+			}},
+			ServiceSpec: api.ServiceSpec{
+				Name:      service,
+				Container: api.ContainerSpec{Image: image},
+			},
+		}
+		return store.ContainerRecord{Container: ctr, MachineID: machineID}
+	}
+
+	tests := []struct {
+		name           string
+		remoteServices map[string]struct{}
+		records        []store.ContainerRecord
+		want           []Conflict
+	}{
+		{
+			name:           "no changes",
+			remoteServices: map[string]struct{}{"web-id": {}},
+			records: []store.ContainerRecord{
+				record("m1", "1", "web", "nginx:1", before),
+				record("m2", "2", "web", "nginx:1", before),
+			},
+		},
+		{
+			name:           "service redeployed",
+			remoteServices: map[string]struct{}{"web-id": {}},
+			records: []store.ContainerRecord{
+				record("m1", "1", "web", "nginx:1", before),
+				record("m2", "2", "web", "nginx:2", after),
+			},
+			want: []Conflict{
+				{ServiceName: "web", ContainerID: "1", ContainerName: "web-1", Reason: ReasonServiceRedeployed},
+			},
+		},
+		{
+			name:           "same spec recreated",
+			remoteServices: map[string]struct{}{"web-id": {}},
+			records: []store.ContainerRecord{
+				record("m1", "1", "web", "nginx:1", before),
+				record("m2", "2", "web", "nginx:1", after),
+			},
+		},
+		{
+			name:           "service removed",
+			remoteServices: map[string]struct{}{"web-id": {}, "db-id": {}},
+			records: []store.ContainerRecord{
+				record("m1", "1", "web", "nginx:1", before),
+				record("m1", "3", "db", "postgres:17", before),
+				record("m2", "4", "db", "postgres:17", before),
+			},
+			want: []Conflict{
+				{ServiceName: "web", ContainerID: "1", ContainerName: "web-1", Reason: ReasonServiceRemoved},
+			},
+		},
+		{
+			name: "local-only service",
+			records: []store.ContainerRecord{
+				record("m1", "1", "web", "nginx:1", before),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := DetectConflicts("m1", offlineSince, tt.remoteServices, tt.records)
+			assert.Equal(t, tt.want, conflicts)
+		})
+	}
+}
+
+func TestConnected(t *testing.T) {
+	t.Parallel()
+
+	machine := func(id, ip string) *pb.MachineInfo {
+		return &pb.MachineInfo{Id: id, Network: &pb.NetworkConfig{ManagementIp: pb.NewIP(netip.MustParseAddr(ip))}}
+	}
+	state := func(ip, state string) corrosion.ClusterMembershipState {
+		return corrosion.ClusterMembershipState{Addr: netip.AddrPortFrom(netip.MustParseAddr(ip), 51001), State: state}
+	}
+	machines := []*pb.MachineInfo{
+		machine("m1", "fdcc::1"),
+		machine("m2", "fdcc::2"),
+		machine("m3", "fdcc::3"),
+	}
+
+	tests := []struct {
+		name     string
+		machines []*pb.MachineInfo
+		states   []corrosion.ClusterMembershipState
+		want     bool
+	}{
+		{
+			name:     "single machine",
+			machines: machines[:1],
+			want:     true,
+		},
+		{
+			name:     "peer alive",
+			machines: machines,
+			states: []corrosion.ClusterMembershipState{
+				state("fdcc::2", corrosion.MembershipStateDown),
+				state("fdcc::3", corrosion.MembershipStateAlive),
+			},
+			want: true,
+		},
+		{
+			name:     "peer suspect",
+			machines: machines,
+			states:   []corrosion.ClusterMembershipState{state("fdcc::2", corrosion.MembershipStateSuspect)},
+			want:     true,
+		},
+		{
+			name:     "all peers down",
+			machines: machines,
+			states: []corrosion.ClusterMembershipState{
+				state("fdcc::2", corrosion.MembershipStateDown),
+				state("fdcc::3", corrosion.MembershipStateDown),
+			},
+			want: false,
+		},
+		{
+			name:     "no membership states",
+			machines: machines,
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, connected("m1", tt.machines, tt.states))
+		})
+	}
+}
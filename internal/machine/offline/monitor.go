@@ -0,0 +1,224 @@
+// Package offline tracks whether the machine is connected to the rest of the cluster. When the machine loses
+// connection to all other machines, it keeps running and restarting its local containers while the changes to
+// the local state are queued in the cluster store. The queued changes are synced automatically when the machine
+// reconnects, and conflicts with the changes made in the rest of the cluster in the meantime are reported.
+package offline
+
+import (
+	"context"
+	"log/slog"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/corrosion"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/store"
+)
+
+const (
+	// CheckInterval is the interval for checking the connectivity to other machines in the cluster.
+	CheckInterval = 10 * time.Second
+	// syncTimeout is the maximum time to wait for the cluster store to sync the changes made in the rest
+	// of the cluster after reconnecting before detecting conflicts.
+	syncTimeout = time.Minute
+)
+
+// MembershipFunc returns the cluster membership states of the machines as seen by the local cluster store.
+type MembershipFunc func() ([]corrosion.ClusterMembershipState, error)
+
+// Status is the connectivity status of the machine.
+type Status struct {
+	// OfflineSince is the time the machine lost connection to all other machines. Zero if the machine is online.
+	OfflineSince time.Time
+	// LastReconnected is the time the machine last reconnected to the cluster after being offline.
+	LastReconnected time.Time
+	// Conflicts are the conflicts detected when the machine last reconnected to the cluster.
+	Conflicts []Conflict
+}
+
+// Monitor periodically checks if the machine is connected to at least one other machine in the cluster.
+// It detects the conflicts between the local containers and the changes made in the rest of the cluster
+// while the machine was offline when the connection is restored.
+type Monitor struct {
+	machineID string
+	store     *store.Store
+	members   MembershipFunc
+	log       *slog.Logger
+
+	mu     sync.RWMutex
+	status Status
+	// remoteServices is the set of service IDs that had containers on other machines when the machine went offline.
+	remoteServices map[string]struct{}
+}
+
+func NewMonitor(machineID string, store *store.Store, members MembershipFunc) *Monitor {
+	return &Monitor{
+		machineID: machineID,
+		store:     store,
+		members:   members,
+		log:       slog.With("component", "offline-monitor"),
+	}
+}
+
+// Status returns the current connectivity status of the machine.
+func (m *Monitor) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	status := m.status
+	status.Conflicts = append([]Conflict(nil), m.status.Conflicts...)
+	return status
+}
+
+func (m *Monitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.check(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// check updates the connectivity status and handles the transitions between the online and offline modes.
+func (m *Monitor) check(ctx context.Context) {
+	machines, err := m.store.ListMachines(ctx)
+	if err != nil {
+		m.log.Error("Failed to list machines in the cluster store.", "err", err)
+		return
+	}
+	states, err := m.members()
+	if err != nil {
+		m.log.Error("Failed to get cluster membership states.", "err", err)
+		return
+	}
+
+	m.mu.RLock()
+	offlineSince := m.status.OfflineSince
+	m.mu.RUnlock()
+
+	if connected(m.machineID, machines, states) {
+		if !offlineSince.IsZero() {
+			m.reconnect(ctx, offlineSince)
+		}
+		return
+	}
+	if offlineSince.IsZero() {
+		m.goOffline(ctx)
+	}
+}
+
+// goOffline switches the machine to the offline mode and remembers the services running on other machines to be
+// able to detect the ones removed while the machine was offline.
+func (m *Monitor) goOffline(ctx context.Context) {
+	records, err := m.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		m.log.Error("Failed to list containers in the cluster store.", "err", err)
+		return
+	}
+	remoteServices := make(map[string]struct{})
+	for _, r := range records {
+		if r.MachineID != m.machineID && !r.Container.IsHook() {
+			remoteServices[r.Container.ServiceID()] = struct{}{}
+		}
+	}
+
+	m.mu.Lock()
+	m.status.OfflineSince = time.Now()
+	m.remoteServices = remoteServices
+	m.mu.Unlock()
+
+	m.log.Warn("Lost connection to all other machines in the cluster, continuing in offline mode. " +
+		"Local containers keep running and state changes will be synced when the connection is restored.")
+}
+
+// reconnect waits for the cluster store to sync the changes made while the machine was offline, detects conflicts
+// with the local containers, and switches the machine back to the online mode.
+func (m *Monitor) reconnect(ctx context.Context, offlineSince time.Time) {
+	m.log.Info("Reconnected to the cluster, syncing the changes made while offline.",
+		"offline_duration", time.Since(offlineSince).Round(time.Second))
+	m.waitSync(ctx)
+
+	records, err := m.store.ListContainers(ctx, store.ListOptions{})
+	if err != nil {
+		// Retry on the next check as the machine is still considered offline.
+		m.log.Error("Failed to list containers in the cluster store.", "err", err)
+		return
+	}
+
+	m.mu.Lock()
+	conflicts := DetectConflicts(m.machineID, offlineSince, m.remoteServices, records)
+	m.status = Status{
+		LastReconnected: time.Now(),
+		Conflicts:       conflicts,
+	}
+	m.remoteServices = nil
+	m.mu.Unlock()
+
+	for _, c := range conflicts {
+		m.log.Warn("Detected conflict after reconnecting to the cluster.",
+			"service", c.ServiceName, "container", c.ContainerName, "reason", c.Reason)
+	}
+	m.log.Info("Synced the changes made while offline.", "conflicts", len(conflicts))
+}
+
+// waitSync waits until all known missing changes are synced to the cluster store or the sync timeout is reached.
+func (m *Monitor) waitSync(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		changes, err := m.store.KnownMissingChanges(ctx)
+		if err != nil {
+			m.log.Error("Failed to get known missing changes from the cluster store, skipping sync wait.",
+				"err", err)
+			return
+		}
+		if len(changes) == 0 {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			m.log.Warn("Timed out waiting for the cluster store to sync, conflicts may be incomplete.",
+				"missing_changes", len(changes))
+			return
+		}
+	}
+}
+
+// connected returns true if at least one other machine in the cluster is alive according to the cluster membership
+// states or if there are no other machines in the cluster.
+func connected(machineID string, machines []*pb.MachineInfo, states []corrosion.ClusterMembershipState) bool {
+	alive := make(map[netip.Addr]struct{}, len(states))
+	for _, s := range states {
+		if s.State == corrosion.MembershipStateAlive || s.State == corrosion.MembershipStateSuspect {
+			alive[s.Addr.Addr()] = struct{}{}
+		}
+	}
+
+	others := 0
+	for _, m := range machines {
+		if m.Id == machineID || m.Network == nil {
+			continue
+		}
+		others++
+		addr, err := m.Network.ManagementIp.ToAddr()
+		if err != nil {
+			continue
+		}
+		if _, ok := alive[addr]; ok {
+			return true
+		}
+	}
+	return others == 0
+}
@@ -0,0 +1,168 @@
+// Package preflight checks if the host meets the requirements to run as a cluster machine before it initialises
+// or joins a cluster. Each check reports a status with a message explaining the problem and how to fix it.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/docker/docker/api/types"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/dns"
+)
+
+// MinDockerVersion is the minimum supported Docker Engine version. Older versions are end of life and not tested.
+const MinDockerVersion = "24.0.0"
+
+// DockerClient is the subset of the Docker client used by the checks.
+type DockerClient interface {
+	ServerVersion(ctx context.Context) (types.Version, error)
+}
+
+// Options configures the checks.
+type Options struct {
+	Docker DockerClient
+	// WireGuardPort is the UDP port WireGuard will listen on.
+	WireGuardPort int
+	// SkipPortChecks skips the checks of ports the machine itself listens on once it's a cluster member.
+	SkipPortChecks bool
+}
+
+// Run runs all checks and returns their results in a stable order.
+func Run(ctx context.Context, opts Options) []*pb.PrerequisiteCheck {
+	results := []*pb.PrerequisiteCheck{
+		checkWireGuard(),
+		checkDockerVersion(ctx, opts.Docker),
+		checkCgroupV2(),
+		checkFirewall(),
+		checkTimeSync(),
+	}
+	if !opts.SkipPortChecks {
+		results = append(results,
+			checkDNSPort(),
+			checkWireGuardPort(opts.WireGuardPort),
+			checkIngressPorts(),
+		)
+	}
+	return results
+}
+
+// FailedError returns an error combining the messages of all failed checks or nil if none failed.
+func FailedError(results []*pb.PrerequisiteCheck) error {
+	var errs []error
+	for _, r := range results {
+		if r.Status == pb.PrerequisiteCheck_FAILED {
+			errs = append(errs, fmt.Errorf("%s: %s", r.Name, r.Message))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func ok(id, name, msg string) *pb.PrerequisiteCheck {
+	return &pb.PrerequisiteCheck{Id: id, Name: name, Status: pb.PrerequisiteCheck_OK, Message: msg}
+}
+
+func warning(id, name, msg string) *pb.PrerequisiteCheck {
+	return &pb.PrerequisiteCheck{Id: id, Name: name, Status: pb.PrerequisiteCheck_WARNING, Message: msg}
+}
+
+func failed(id, name, msg string) *pb.PrerequisiteCheck {
+	return &pb.PrerequisiteCheck{Id: id, Name: name, Status: pb.PrerequisiteCheck_FAILED, Message: msg}
+}
+
+func skipped(id, name, msg string) *pb.PrerequisiteCheck {
+	return &pb.PrerequisiteCheck{Id: id, Name: name, Status: pb.PrerequisiteCheck_SKIPPED, Message: msg}
+}
+
+func checkDockerVersion(ctx context.Context, client DockerClient) *pb.PrerequisiteCheck {
+	const id, name = "docker-version", "Docker version"
+	if client == nil {
+		return skipped(id, name, "Docker client is not configured.")
+	}
+
+	v, err := client.ServerVersion(ctx)
+	if err != nil {
+		return failed(id, name, fmt.Sprintf("Failed to get Docker version: %v. Make sure Docker is running.", err))
+	}
+	return compareDockerVersion(v.Version)
+}
+
+func compareDockerVersion(version string) *pb.PrerequisiteCheck {
+	const id, name = "docker-version", "Docker version"
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return warning(id, name, fmt.Sprintf("Unrecognised Docker version '%s'.", version))
+	}
+	if v.LessThan(semver.MustParse(MinDockerVersion)) {
+		return failed(id, name, fmt.Sprintf("Docker %s is not supported. Upgrade Docker to %s or later.",
+			version, MinDockerVersion))
+	}
+	return ok(id, name, "Docker "+version)
+}
+
+func checkFirewall() *pb.PrerequisiteCheck {
+	const id, name = "firewall", "iptables"
+	if path, err := exec.LookPath("iptables"); err == nil {
+		return ok(id, name, path)
+	}
+
+	msg := "The iptables command is not found. Uncloud and Docker need it to configure the firewall."
+	if _, err := exec.LookPath("nft"); err == nil {
+		msg += " Install the iptables-nft package to use iptables on top of nftables."
+	} else {
+		msg += " Install the iptables or iptables-nft package."
+	}
+	return failed(id, name, msg)
+}
+
+func checkDNSPort() *pb.PrerequisiteCheck {
+	const id, name = "dns-port", "DNS port"
+	addr := &net.UDPAddr{
+		IP:   net.IPv4(127, 0, 0, 210), // Use a unique loopback address to avoid conflicts.
+		Port: dns.Port,
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return failed(id, name, fmt.Sprintf("Port %d/udp is already in use by another service: %v. Uncloud "+
+			"needs this port to run the embedded internal DNS service on WireGuard interface 'uncloud'. "+
+			"Reconfigure any DNS servers (like dnsmasq, systemd-resolved, or named) that might be listening on "+
+			"all network interfaces (0.0.0.0) on the machine and try again.", dns.Port, err))
+	}
+	conn.Close()
+	return ok(id, name, fmt.Sprintf("Port %d/udp is available.", dns.Port))
+}
+
+func checkWireGuardPort(port int) *pb.PrerequisiteCheck {
+	const id, name = "wireguard-port", "WireGuard port"
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port})
+	if err != nil {
+		return failed(id, name, fmt.Sprintf("Port %d/udp is already in use by another service: %v. Stop the "+
+			"service or choose another port with --wg-port.", port, err))
+	}
+	conn.Close()
+	return ok(id, name, fmt.Sprintf("Port %d/udp is available.", port))
+}
+
+func checkIngressPorts() *pb.PrerequisiteCheck {
+	const id, name = "ingress-ports", "Ingress ports"
+	var busy []string
+	for _, port := range []int{80, 443} {
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			busy = append(busy, fmt.Sprintf("%d/tcp", port))
+			continue
+		}
+		l.Close()
+	}
+	if len(busy) > 0 {
+		return warning(id, name, fmt.Sprintf("Port(s) %s already in use by another service. Caddy can't "+
+			"serve ingress traffic on this machine until they're freed.", strings.Join(busy, ", ")))
+	}
+	return ok(id, name, "Ports 80/tcp and 443/tcp are available.")
+}
@@ -0,0 +1,22 @@
+package preflight
+
+import (
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+const notSupported = "Not supported on Darwin."
+
+// checkWireGuard is a stub for Darwin.
+func checkWireGuard() *pb.PrerequisiteCheck {
+	return skipped("wireguard", "WireGuard kernel module", notSupported)
+}
+
+// checkCgroupV2 is a stub for Darwin.
+func checkCgroupV2() *pb.PrerequisiteCheck {
+	return skipped("cgroup-v2", "cgroup v2", notSupported)
+}
+
+// checkTimeSync is a stub for Darwin.
+func checkTimeSync() *pb.PrerequisiteCheck {
+	return skipped("time-sync", "Time synchronisation", notSupported)
+}
@@ -0,0 +1,65 @@
+//go:build linux
+
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// checkWireGuard verifies the kernel supports WireGuard by creating and removing a temporary interface.
+func checkWireGuard() *pb.PrerequisiteCheck {
+	const id, name = "wireguard", "WireGuard kernel module"
+
+	// The machine already has a working WireGuard interface, e.g. after a reset that kept it.
+	if link, err := netlink.LinkByName(network.WireGuardInterfaceName); err == nil && link.Type() == "wireguard" {
+		return ok(id, name, "WireGuard is supported.")
+	}
+
+	link := &netlink.GenericLink{
+		LinkAttrs: netlink.LinkAttrs{Name: "uc-preflight"},
+		LinkType:  "wireguard",
+	}
+	if err := netlink.LinkAdd(link); err != nil {
+		if errors.Is(err, unix.EOPNOTSUPP) {
+			return failed(id, name, "The kernel doesn't support WireGuard. Upgrade to Linux 5.6 or later "+
+				"or install the wireguard kernel module.")
+		}
+		return failed(id, name, fmt.Sprintf("Failed to create a WireGuard interface: %v.", err))
+	}
+	_ = netlink.LinkDel(link)
+
+	return ok(id, name, "WireGuard is supported.")
+}
+
+func checkCgroupV2() *pb.PrerequisiteCheck {
+	const id, name = "cgroup-v2", "cgroup v2"
+	if _, err := os.Stat("/sys/fs/cgroup/cgroup.controllers"); err != nil {
+		return warning(id, name, "The system uses cgroup v1 which is deprecated by Docker. Some resource "+
+			"limits may not work. Enable cgroup v2 with the 'systemd.unified_cgroup_hierarchy=1' kernel parameter.")
+	}
+	return ok(id, name, "The unified cgroup v2 hierarchy is mounted.")
+}
+
+// checkTimeSync checks if the kernel reports the system clock as synchronised by an NTP daemon.
+func checkTimeSync() *pb.PrerequisiteCheck {
+	const id, name = "time-sync", "Time synchronisation"
+
+	var tx unix.Timex
+	state, err := unix.Adjtimex(&tx)
+	if err != nil {
+		return skipped(id, name, fmt.Sprintf("Failed to get the clock state: %v.", err))
+	}
+	if state == unix.TIME_ERROR {
+		return warning(id, name, "The system clock is not synchronised. Clock skew between machines "+
+			"can break TLS certificates and the ordering of cluster state changes. Enable NTP, for example, "+
+			"with 'timedatectl set-ntp true'.")
+	}
+	return ok(id, name, "The system clock is synchronised.")
+}
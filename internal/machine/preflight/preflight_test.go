@@ -0,0 +1,78 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDocker struct {
+	version string
+	err     error
+}
+
+func (f fakeDocker) ServerVersion(context.Context) (types.Version, error) {
+	return types.Version{Version: f.version}, f.err
+}
+
+func TestCheckDockerVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		client DockerClient
+		want   pb.PrerequisiteCheck_Status
+	}{
+		{name: "supported", client: fakeDocker{version: "28.2.2"}, want: pb.PrerequisiteCheck_OK},
+		{name: "minimum", client: fakeDocker{version: MinDockerVersion}, want: pb.PrerequisiteCheck_OK},
+		{name: "too old", client: fakeDocker{version: "20.10.24"}, want: pb.PrerequisiteCheck_FAILED},
+		{name: "unrecognised", client: fakeDocker{version: "dev"}, want: pb.PrerequisiteCheck_WARNING},
+		{name: "not running", client: fakeDocker{err: errors.New("connection refused")},
+			want: pb.PrerequisiteCheck_FAILED},
+		{name: "no client", want: pb.PrerequisiteCheck_SKIPPED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := checkDockerVersion(context.Background(), tt.client)
+			assert.Equal(t, "docker-version", r.Id)
+			assert.Equal(t, tt.want, r.Status, r.Message)
+		})
+	}
+}
+
+func TestCheckWireGuardPort(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{})
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+
+	r := checkWireGuardPort(port)
+	assert.Equal(t, pb.PrerequisiteCheck_FAILED, r.Status)
+	assert.Contains(t, r.Message, "--wg-port")
+
+	require.NoError(t, conn.Close())
+	r = checkWireGuardPort(port)
+	assert.Equal(t, pb.PrerequisiteCheck_OK, r.Status, r.Message)
+}
+
+func TestFailedError(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, FailedError([]*pb.PrerequisiteCheck{
+		ok("a", "A", ""), warning("b", "B", "careful"), skipped("c", "C", ""),
+	}))
+
+	err := FailedError([]*pb.PrerequisiteCheck{
+		failed("a", "A", "broken"), ok("b", "B", ""), failed("c", "C", "also broken"),
+	})
+	assert.EqualError(t, err, "A: broken\nC: also broken")
+}
@@ -0,0 +1,60 @@
+// Package profile defines resource profiles that tune the machine daemon for the hardware it runs on.
+package profile
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/docker"
+	"github.com/psviderski/uncloud/internal/machine/mesh"
+)
+
+const (
+	// Default is the profile for regular servers and VMs.
+	Default = "default"
+	// Small is the profile for resource-constrained machines such as Raspberry Pi and other ARM SBCs. It trades
+	// the reaction time of the background reconciliation loops for lower CPU, memory, and disk usage.
+	Small = "small"
+)
+
+// Names is the list of supported profile names.
+var Names = []string{Default, Small}
+
+// Settings are the daemon tunables defined by a profile.
+type Settings struct {
+	// DockerSyncInterval is the regular interval to sync containers to the cluster store.
+	DockerSyncInterval time.Duration
+	// MeshResyncInterval is the regular interval to resync the mTLS mesh routes.
+	MeshResyncInterval time.Duration
+	// DBCacheSizeKiB is the SQLite page cache size of the machine database in KiB. 0 means the SQLite default.
+	DBCacheSizeKiB int
+	// LogLevel is the minimum level of the daemon logs.
+	LogLevel slog.Level
+}
+
+// Validate checks if the profile name is supported. An empty name is equivalent to Default.
+func Validate(name string) error {
+	if name != "" && !slices.Contains(Names, name) {
+		return fmt.Errorf("unsupported profile '%s', supported profiles: %v", name, Names)
+	}
+	return nil
+}
+
+// Get returns the settings for the profile with the given name. Unknown or empty names fall back to Default.
+func Get(name string) Settings {
+	if name == Small {
+		return Settings{
+			DockerSyncInterval: 2 * time.Minute,
+			MeshResyncInterval: 5 * time.Minute,
+			DBCacheSizeKiB:     512,
+			LogLevel:           slog.LevelInfo,
+		}
+	}
+	return Settings{
+		DockerSyncInterval: docker.SyncInterval,
+		MeshResyncInterval: mesh.ResyncInterval,
+		LogLevel:           slog.LevelDebug,
+	}
+}
@@ -0,0 +1,47 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		profile string
+		wantErr string
+	}{
+		{name: "empty", profile: ""},
+		{name: "default", profile: Default},
+		{name: "small", profile: Small},
+		{name: "unsupported", profile: "tiny", wantErr: "unsupported profile 'tiny'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.profile)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Get(""), Get(Default))
+	assert.Equal(t, Get("unknown"), Get(Default))
+
+	small := Get(Small)
+	def := Get(Default)
+	assert.Greater(t, small.DockerSyncInterval, def.DockerSyncInterval)
+	assert.Greater(t, small.MeshResyncInterval, def.MeshResyncInterval)
+	assert.Positive(t, small.DBCacheSizeKiB)
+	assert.Greater(t, small.LogLevel, def.LogLevel)
+}
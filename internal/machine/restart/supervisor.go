@@ -0,0 +1,206 @@
+// Package restart supervises the restarts of the service containers on the machine. Docker restarts the exited
+// containers according to their restart policy but gives up on a container once restarting it fails, for example,
+// because a published port or a volume isn't available. The supervisor keeps restarting such containers with
+// an exponentially increasing delay and counts the restarts so that they're shown along with the Docker ones.
+package restart
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// CheckInterval is the default interval for checking the service containers that need to be restarted.
+	CheckInterval = 5 * time.Second
+	// initialBackoff is the delay before the first restart of a container that Docker failed to restart.
+	initialBackoff = 5 * time.Second
+	// maxBackoff is the maximum delay between the restarts of a container. The delay doubles after each restart.
+	maxBackoff = 5 * time.Minute
+	// resetAfter is how long a restarted container must run before its restart delay is reset.
+	resetAfter = 10 * time.Second
+)
+
+// Docker is the subset of the Docker client used by the supervisor.
+type Docker interface {
+	ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+	ContainerStart(ctx context.Context, containerID string, options container.StartOptions) error
+}
+
+// Restarts reads and records the number of restarts of the containers by the supervisor in the machine database.
+type Restarts interface {
+	ContainerRestarts(ctx context.Context, id string) (int, error)
+	RecordContainerRestart(ctx context.Context, id string) error
+}
+
+// backoff is the restart delay state of a container that the supervisor restarts.
+type backoff struct {
+	// attempts is the number of restart attempts since the delay was last reset.
+	attempts int
+	// next is the earliest time the container can be restarted again.
+	next time.Time
+}
+
+// Supervisor restarts the service containers that Docker failed to restart according to their restart policy.
+type Supervisor struct {
+	docker        Docker
+	restarts      Restarts
+	log           *slog.Logger
+	checkInterval time.Duration
+	// backoffs tracks the restart delays of the containers by container ID.
+	backoffs map[string]*backoff
+}
+
+func NewSupervisor(docker Docker, restarts Restarts, checkInterval time.Duration) *Supervisor {
+	return &Supervisor{
+		docker:        docker,
+		restarts:      restarts,
+		log:           slog.With("component", "restart-supervisor"),
+		checkInterval: checkInterval,
+		backoffs:      make(map[string]*backoff),
+	}
+}
+
+func (s *Supervisor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.reconcile(ctx, time.Now()); err != nil {
+				s.log.Error("Failed to check service containers that need to be restarted.", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reconcile restarts the service containers that Docker failed to restart and whose restart delay has passed.
+func (s *Supervisor) reconcile(ctx context.Context, now time.Time) error {
+	summaries, err := s.docker.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", api.LabelManaged)),
+	})
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	seen := make(map[string]bool, len(summaries))
+	for _, cs := range summaries {
+		seen[cs.ID] = true
+		// Hook containers are one-off and aren't restarted.
+		if _, ok := cs.Labels[api.LabelHook]; ok {
+			continue
+		}
+		// Only running containers with a restart delay need to be inspected to reset the delay.
+		if cs.State == container.StateRunning && s.backoffs[cs.ID] == nil {
+			continue
+		}
+
+		ctr, err := s.docker.ContainerInspect(ctx, cs.ID)
+		if err != nil {
+			s.log.Error("Failed to inspect service container.", "id", cs.ID, "err", err)
+			continue
+		}
+		s.check(ctx, ctr, now)
+	}
+
+	// Forget the restart delays of the removed containers.
+	for id := range s.backoffs {
+		if !seen[id] {
+			delete(s.backoffs, id)
+		}
+	}
+	return nil
+}
+
+// check restarts the container if Docker failed to restart it and its restart delay has passed.
+func (s *Supervisor) check(ctx context.Context, ctr container.InspectResponse, now time.Time) {
+	if ctr.ContainerJSONBase == nil || ctr.State == nil || ctr.HostConfig == nil || ctr.Config == nil {
+		return
+	}
+	if ctr.State.Running {
+		started, err := time.Parse(time.RFC3339Nano, ctr.State.StartedAt)
+		if err == nil && now.Sub(started) >= resetAfter {
+			delete(s.backoffs, ctr.ID)
+		}
+		return
+	}
+
+	supervised, err := s.restarts.ContainerRestarts(ctx, ctr.ID)
+	if err != nil {
+		s.log.Error("Failed to get restarts of service container.", "id", ctr.ID, "err", err)
+		return
+	}
+	if !needsRestart(ctr, supervised) {
+		delete(s.backoffs, ctr.ID)
+		return
+	}
+
+	b := s.backoffs[ctr.ID]
+	if b == nil {
+		b = &backoff{next: now.Add(delay(0))}
+		s.backoffs[ctr.ID] = b
+	}
+	if now.Before(b.next) {
+		return
+	}
+
+	b.attempts++
+	b.next = now.Add(delay(b.attempts))
+	name := strings.TrimPrefix(ctr.Name, "/")
+	if err = s.docker.ContainerStart(ctx, ctr.ID, container.StartOptions{}); err != nil {
+		s.log.Warn("Failed to restart service container, retrying later.",
+			"container", name, "attempt", b.attempts, "retry_in", b.next.Sub(now), "err", err)
+		return
+	}
+	if err = s.restarts.RecordContainerRestart(ctx, ctr.ID); err != nil {
+		s.log.Error("Failed to record restart of service container.", "container", name, "err", err)
+	}
+	s.log.Info("Restarted service container that Docker failed to restart.",
+		"service", ctr.Config.Labels[api.LabelServiceName], "container", name, "attempt", b.attempts)
+}
+
+// delay returns the restart delay after the given number of restart attempts.
+func delay(attempts int) time.Duration {
+	d := initialBackoff
+	for range attempts {
+		if d >= maxBackoff/2 {
+			return maxBackoff
+		}
+		d *= 2
+	}
+	return d
+}
+
+// needsRestart returns true if Docker failed to restart the stopped container despite its restart policy. Containers
+// stopped by the user or exited normally are left to Docker as they don't have a start error. Containers that have
+// never run, e.g. failed to start during a deployment, are left to the deployment. supervised is the number of
+// restarts by the supervisor that count towards the maximum retry count of the 'on-failure' policy.
+func needsRestart(ctr container.InspectResponse, supervised int) bool {
+	if ctr.State.Error == "" || ctr.State.Running || ctr.State.Paused || ctr.State.Restarting {
+		return false
+	}
+	if started, err := time.Parse(time.RFC3339Nano, ctr.State.StartedAt); err != nil || started.IsZero() {
+		return false
+	}
+
+	policy := ctr.HostConfig.RestartPolicy
+	switch policy.Name {
+	case container.RestartPolicyAlways, container.RestartPolicyUnlessStopped:
+		return true
+	case container.RestartPolicyOnFailure:
+		return policy.MaximumRetryCount == 0 || ctr.RestartCount+supervised < policy.MaximumRetryCount
+	default:
+		return false
+	}
+}
@@ -0,0 +1,140 @@
+package restart
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDocker struct {
+	containers map[string]container.InspectResponse
+	// failures is the number of times starting a container fails before it succeeds, by container ID.
+	failures map[string]int
+	started  []string
+}
+
+func (d *fakeDocker) ContainerList(context.Context, container.ListOptions) ([]container.Summary, error) {
+	var summaries []container.Summary
+	for id, ctr := range d.containers {
+		state := container.StateExited
+		if ctr.State.Running {
+			state = container.StateRunning
+		}
+		summaries = append(summaries, container.Summary{ID: id, Labels: ctr.Config.Labels, State: state})
+	}
+	return summaries, nil
+}
+
+func (d *fakeDocker) ContainerInspect(_ context.Context, id string) (container.InspectResponse, error) {
+	return d.containers[id], nil
+}
+
+func (d *fakeDocker) ContainerStart(_ context.Context, id string, _ container.StartOptions) error {
+	if d.failures[id] > 0 {
+		d.failures[id]--
+		return errors.New("address not available")
+	}
+	d.started = append(d.started, id)
+	d.containers[id].State.Running = true
+	d.containers[id].State.Error = ""
+	return nil
+}
+
+type fakeRestarts map[string]int
+
+func (r fakeRestarts) ContainerRestarts(_ context.Context, id string) (int, error) {
+	return r[id], nil
+}
+
+func (r fakeRestarts) RecordContainerRestart(_ context.Context, id string) error {
+	r[id]++
+	return nil
+}
+
+func serviceContainer(id string, policy container.RestartPolicy, startErr string) container.InspectResponse {
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:         id,
+			Name:       "/web-" + id,
+			State:      &container.State{Error: startErr, StartedAt: "2026-10-16T10:00:00Z"},
+			HostConfig: &container.HostConfig{RestartPolicy: policy},
+		},
+		Config: &container.Config{Labels: map[string]string{
+			api.LabelManaged:     "",
+			api.LabelServiceName: "web",
+		}},
+	}
+}
+
+func TestSupervisor_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	unlessStopped := container.RestartPolicy{Name: container.RestartPolicyUnlessStopped}
+	onFailure := container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 3}
+	docker := &fakeDocker{
+		containers: map[string]container.InspectResponse{
+			"failed":    serviceContainer("failed", unlessStopped, "address not available"),
+			"exhausted": serviceContainer("exhausted", onFailure, "address not available"),
+			"retrying":  serviceContainer("retrying", onFailure, "address not available"),
+			"no":        serviceContainer("no", container.RestartPolicy{Name: container.RestartPolicyDisabled}, "err"),
+			// Stopped by the user.
+			"stopped": serviceContainer("stopped", unlessStopped, ""),
+		},
+		failures: map[string]int{"retrying": 1},
+	}
+	exhausted := docker.containers["exhausted"]
+	exhausted.RestartCount = 2
+	docker.containers["exhausted"] = exhausted
+	never := serviceContainer("never", unlessStopped, "address not available")
+	never.State.StartedAt = "0001-01-01T00:00:00Z"
+	docker.containers["never"] = never
+	hook := serviceContainer("hook", unlessStopped, "address not available")
+	hook.Config.Labels[api.LabelHook] = api.LabelHookPreDeploy
+	docker.containers["hook"] = hook
+
+	restarts := fakeRestarts{"exhausted": 1}
+	s := NewSupervisor(docker, restarts, time.Second)
+	ctx := context.Background()
+	now := time.Now()
+
+	// The containers are restarted only after the initial delay.
+	require.NoError(t, s.reconcile(ctx, now))
+	assert.Empty(t, docker.started)
+
+	now = now.Add(initialBackoff)
+	require.NoError(t, s.reconcile(ctx, now))
+	assert.Equal(t, []string{"failed"}, docker.started)
+	assert.Equal(t, fakeRestarts{"exhausted": 1, "failed": 1}, restarts)
+
+	// The failed restart is retried after a doubled delay.
+	require.Contains(t, s.backoffs, "retrying")
+	assert.Equal(t, now.Add(2*initialBackoff), s.backoffs["retrying"].next)
+	require.NoError(t, s.reconcile(ctx, now.Add(initialBackoff)))
+	assert.Equal(t, []string{"failed"}, docker.started)
+	require.NoError(t, s.reconcile(ctx, now.Add(2*initialBackoff)))
+	assert.ElementsMatch(t, []string{"failed", "retrying"}, docker.started)
+	assert.Equal(t, 1, restarts["retrying"])
+
+	// The delay is reset once the restarted container has been running long enough.
+	failed := docker.containers["failed"]
+	failed.State.StartedAt = now.Format(time.RFC3339Nano)
+	docker.containers["failed"] = failed
+	require.NoError(t, s.reconcile(ctx, now.Add(resetAfter)))
+	assert.NotContains(t, s.backoffs, "failed")
+}
+
+func TestDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, initialBackoff, delay(0))
+	assert.Equal(t, 2*initialBackoff, delay(1))
+	assert.Equal(t, 4*initialBackoff, delay(2))
+	assert.Equal(t, maxBackoff, delay(10))
+	assert.Equal(t, maxBackoff, delay(1000))
+}
@@ -0,0 +1,328 @@
+// Package snapshot creates and restores instant snapshots of local Docker volumes stored on btrfs or ZFS
+// filesystems.
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// ErrUnsupported is returned when the filesystem a volume is stored on doesn't support snapshots.
+var ErrUnsupported = errors.New("volume snapshots are only supported for volumes stored on btrfs or ZFS filesystems")
+
+// ErrNotFound is returned when a volume snapshot doesn't exist.
+var ErrNotFound = errors.New("snapshot not found")
+
+// idLayout is the time layout of the snapshot IDs. The IDs are the UTC creation times so they sort chronologically.
+const idLayout = "20060102T150405.000Z"
+
+// btrfsSnapshotsDir is the directory next to the volume data directory where the btrfs snapshots of the volume
+// are stored. Docker removes it together with the volume.
+const btrfsSnapshotsDir = "_snapshots"
+
+// zfsSnapshotPrefix is the prefix of the ZFS snapshot names created for volumes. The full name is
+// <dataset>@uncloud:<volume>:<id>. Volume names can't contain colons so the name is unambiguous.
+const zfsSnapshotPrefix = "uncloud:"
+
+// Manager manages the snapshots of local volumes using the btrfs and zfs command-line tools.
+type Manager struct {
+	// run runs a command and returns its standard output. It allows to mock the commands in tests.
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+	now func() time.Time
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		run: runCommand,
+		now: time.Now,
+	}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Backend returns the snapshot backend for the volume data directory based on its filesystem type.
+// It returns ErrUnsupported if the filesystem doesn't support snapshots.
+func (m *Manager) Backend(ctx context.Context, dir string) (string, error) {
+	out, err := m.run(ctx, "stat", "--file-system", "--format", "%T", dir)
+	if err != nil {
+		return "", fmt.Errorf("detect filesystem type: %w", err)
+	}
+
+	switch fs := strings.TrimSpace(string(out)); fs {
+	case api.VolumeSnapshotBackendBtrfs, api.VolumeSnapshotBackendZFS:
+		return fs, nil
+	default:
+		return "", fmt.Errorf("%w: '%s' is on %s", ErrUnsupported, dir, fs)
+	}
+}
+
+// Create creates a snapshot of the volume with the given data directory.
+func (m *Manager) Create(ctx context.Context, volume, dir string) (api.VolumeSnapshot, error) {
+	backend, err := m.Backend(ctx, dir)
+	if err != nil {
+		return api.VolumeSnapshot{}, err
+	}
+
+	created := m.now().UTC().Truncate(time.Millisecond)
+	snapshot := api.VolumeSnapshot{
+		ID:      created.Format(idLayout),
+		Volume:  volume,
+		Backend: backend,
+		Created: created,
+	}
+
+	if backend == api.VolumeSnapshotBackendBtrfs {
+		err = m.createBtrfs(ctx, dir, snapshot.ID)
+	} else {
+		err = m.createZFS(ctx, volume, dir, snapshot.ID)
+	}
+	if err != nil {
+		return api.VolumeSnapshot{}, err
+	}
+
+	return snapshot, nil
+}
+
+// List returns the snapshots of the volume with the given data directory sorted by creation time. It returns
+// ErrUnsupported if the filesystem the volume is stored on doesn't support snapshots.
+func (m *Manager) List(ctx context.Context, volume, dir string) ([]api.VolumeSnapshot, error) {
+	backend, err := m.Backend(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if backend == api.VolumeSnapshotBackendBtrfs {
+		ids, err = m.listBtrfs(dir)
+	} else {
+		ids, err = m.listZFS(ctx, volume, dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(ids)
+	snapshots := make([]api.VolumeSnapshot, 0, len(ids))
+	for _, id := range ids {
+		created, _ := time.Parse(idLayout, id)
+		snapshots = append(snapshots, api.VolumeSnapshot{
+			ID:      id,
+			Volume:  volume,
+			Backend: backend,
+			Created: created,
+		})
+	}
+	return snapshots, nil
+}
+
+// Restore replaces the contents of the volume data directory with the contents of the snapshot. The volume must not
+// be in use as the files are replaced in place.
+func (m *Manager) Restore(ctx context.Context, volume, dir, id string) error {
+	backend, err := m.Backend(ctx, dir)
+	if err != nil {
+		return err
+	}
+	if err = m.checkExists(ctx, volume, dir, id); err != nil {
+		return err
+	}
+
+	var src, reflink string
+	if backend == api.VolumeSnapshotBackendBtrfs {
+		src = filepath.Join(filepath.Dir(dir), btrfsSnapshotsDir, id)
+		reflink = "--reflink=always"
+	} else {
+		dataset, mountpoint, err := m.zfsDataset(ctx, dir)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(mountpoint, dir)
+		if err != nil {
+			return fmt.Errorf("get volume path relative to ZFS dataset '%s': %w", dataset, err)
+		}
+		// A ZFS snapshot contains the whole dataset so only the volume directory is copied from it. Rolling back
+		// the dataset would also roll back the other volumes stored on it.
+		src = filepath.Join(mountpoint, ".zfs", "snapshot", zfsSnapshotName(volume, id), rel)
+		// ZFS supports block cloning since 2.2 which makes the copy instant if it's enabled for the pool.
+		reflink = "--reflink=auto"
+	}
+
+	if err = clearDir(dir); err != nil {
+		return fmt.Errorf("clear volume data directory: %w", err)
+	}
+	if _, err = m.run(ctx, "cp", "-a", reflink, src+"/.", dir); err != nil {
+		return fmt.Errorf("copy snapshot data to volume: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the snapshot of the volume with the given data directory.
+func (m *Manager) Remove(ctx context.Context, volume, dir, id string) error {
+	backend, err := m.Backend(ctx, dir)
+	if err != nil {
+		return err
+	}
+	if err = m.checkExists(ctx, volume, dir, id); err != nil {
+		return err
+	}
+
+	if backend == api.VolumeSnapshotBackendBtrfs {
+		path := filepath.Join(filepath.Dir(dir), btrfsSnapshotsDir, id)
+		if m.isBtrfsSubvolume(ctx, path) {
+			_, err = m.run(ctx, "btrfs", "subvolume", "delete", path)
+			return err
+		}
+		return os.RemoveAll(path)
+	}
+
+	dataset, _, err := m.zfsDataset(ctx, dir)
+	if err != nil {
+		return err
+	}
+	_, err = m.run(ctx, "zfs", "destroy", dataset+"@"+zfsSnapshotName(volume, id))
+	return err
+}
+
+func (m *Manager) checkExists(ctx context.Context, volume, dir, id string) error {
+	snapshots, err := m.List(ctx, volume, dir)
+	if err != nil {
+		return fmt.Errorf("list snapshots: %w", err)
+	}
+	if !slices.ContainsFunc(snapshots, func(s api.VolumeSnapshot) bool { return s.ID == id }) {
+		return fmt.Errorf("%w: '%s' of volume '%s'", ErrNotFound, id, volume)
+	}
+	return nil
+}
+
+func (m *Manager) createBtrfs(ctx context.Context, dir, id string) error {
+	snapshotsDir := filepath.Join(filepath.Dir(dir), btrfsSnapshotsDir)
+	if err := os.MkdirAll(snapshotsDir, 0o700); err != nil {
+		return fmt.Errorf("create snapshots directory: %w", err)
+	}
+	dst := filepath.Join(snapshotsDir, id)
+
+	if m.isBtrfsSubvolume(ctx, dir) {
+		// Not read-only so that Docker is able to remove it together with the volume.
+		_, err := m.run(ctx, "btrfs", "subvolume", "snapshot", dir, dst)
+		return err
+	}
+
+	// Docker creates volume data directories as regular directories rather than subvolumes. They are cloned with
+	// reflinks instead which share the data blocks with the volume just like a subvolume snapshot.
+	if err := os.Mkdir(dst, 0o700); err != nil {
+		return fmt.Errorf("create snapshot directory: %w", err)
+	}
+	if _, err := m.run(ctx, "cp", "-a", "--reflink=always", dir+"/.", dst); err != nil {
+		_ = os.RemoveAll(dst)
+		return err
+	}
+	return nil
+}
+
+// isBtrfsSubvolume returns true if the path is the root of a btrfs subvolume which always has inode number 256.
+func (m *Manager) isBtrfsSubvolume(ctx context.Context, path string) bool {
+	out, err := m.run(ctx, "stat", "--format", "%i", path)
+	return err == nil && strings.TrimSpace(string(out)) == "256"
+}
+
+func (m *Manager) listBtrfs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(filepath.Dir(dir), btrfsSnapshotsDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read snapshots directory: %w", err)
+	}
+
+	var ids []string
+	for _, e := range entries {
+		if _, err = time.Parse(idLayout, e.Name()); e.IsDir() && err == nil {
+			ids = append(ids, e.Name())
+		}
+	}
+	return ids, nil
+}
+
+func (m *Manager) createZFS(ctx context.Context, volume, dir, id string) error {
+	dataset, _, err := m.zfsDataset(ctx, dir)
+	if err != nil {
+		return err
+	}
+	_, err = m.run(ctx, "zfs", "snapshot", dataset+"@"+zfsSnapshotName(volume, id))
+	return err
+}
+
+func (m *Manager) listZFS(ctx context.Context, volume, dir string) ([]string, error) {
+	dataset, _, err := m.zfsDataset(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	out, err := m.run(ctx, "zfs", "list", "-H", "-t", "snapshot", "-d", "1", "-o", "name", dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := dataset + "@" + zfsSnapshotName(volume, "")
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		id, ok := strings.CutPrefix(line, prefix)
+		if !ok {
+			continue
+		}
+		if _, err = time.Parse(idLayout, id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// zfsDataset returns the name and mountpoint of the ZFS dataset the directory is stored on.
+func (m *Manager) zfsDataset(ctx context.Context, dir string) (string, string, error) {
+	out, err := m.run(ctx, "zfs", "list", "-H", "-o", "name,mountpoint", dir)
+	if err != nil {
+		return "", "", fmt.Errorf("get ZFS dataset: %w", err)
+	}
+	name, mountpoint, ok := strings.Cut(strings.TrimSpace(string(out)), "\t")
+	if !ok || !filepath.IsAbs(mountpoint) {
+		return "", "", fmt.Errorf("unexpected ZFS dataset for '%s': %q", dir, out)
+	}
+	return name, mountpoint, nil
+}
+
+func zfsSnapshotName(volume, id string) string {
+	return zfsSnapshotPrefix + volume + ":" + id
+}
+
+// clearDir removes all the contents of the directory but not the directory itself to preserve its ownership
+// and permissions.
+func clearDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err = os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeManager returns a manager that reports the given filesystem type for all paths and records the commands.
+// The cp commands are executed without the reflink option so that the btrfs backend works on any filesystem.
+func fakeManager(fs string, zfsOutput map[string]string) (*Manager, *[]string) {
+	var calls []string
+	now := time.Date(2026, 10, 15, 12, 30, 45, 123456789, time.UTC)
+
+	m := &Manager{
+		run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			cmd := strings.Join(append([]string{name}, args...), " ")
+			calls = append(calls, cmd)
+
+			switch {
+			case name == "stat" && args[0] == "--file-system":
+				return []byte(fs + "\n"), nil
+			case name == "stat":
+				return []byte("1234\n"), nil
+			case name == "cp":
+				args = slices.DeleteFunc(args, func(a string) bool { return strings.HasPrefix(a, "--reflink") })
+				return exec.CommandContext(ctx, "cp", args...).Output()
+			case name == "zfs":
+				if out, ok := zfsOutput[strings.Join(args, " ")]; ok {
+					return []byte(out), nil
+				}
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unexpected command: %s", cmd)
+		},
+		now: func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		},
+	}
+	return m, &calls
+}
+
+func TestBackend(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	m, _ := fakeManager("btrfs", nil)
+	backend, err := m.Backend(ctx, "/var/lib/docker/volumes/data/_data")
+	require.NoError(t, err)
+	assert.Equal(t, api.VolumeSnapshotBackendBtrfs, backend)
+
+	m, _ = fakeManager("ext2/ext3", nil)
+	_, err = m.Backend(ctx, "/var/lib/docker/volumes/data/_data")
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestBtrfs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dir := filepath.Join(t.TempDir(), "data", "_data")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "db"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db", "file"), []byte("v1"), 0o644))
+
+	m, _ := fakeManager("btrfs", nil)
+
+	snap1, err := m.Create(ctx, "data", dir)
+	require.NoError(t, err)
+	assert.Equal(t, "20261015T123046.123Z", snap1.ID)
+	assert.Equal(t, api.VolumeSnapshotBackendBtrfs, snap1.Backend)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db", "file"), []byte("v2"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new"), []byte("new"), 0o644))
+	snap2, err := m.Create(ctx, "data", dir)
+	require.NoError(t, err)
+
+	snapshots, err := m.List(ctx, "data", dir)
+	require.NoError(t, err)
+	assert.Equal(t, []api.VolumeSnapshot{snap1, snap2}, snapshots)
+
+	require.NoError(t, m.Restore(ctx, "data", dir, snap1.ID))
+	data, err := os.ReadFile(filepath.Join(dir, "db", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, "new"), "files created after the snapshot must be removed")
+
+	require.NoError(t, m.Remove(ctx, "data", dir, snap1.ID))
+	snapshots, err = m.List(ctx, "data", dir)
+	require.NoError(t, err)
+	assert.Equal(t, []api.VolumeSnapshot{snap2}, snapshots)
+
+	assert.ErrorIs(t, m.Restore(ctx, "data", dir, snap1.ID), ErrNotFound)
+	assert.ErrorIs(t, m.Remove(ctx, "data", dir, snap1.ID), ErrNotFound)
+}
+
+func TestZFS(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dir := "/var/lib/docker/volumes/data/_data"
+	m, calls := fakeManager("zfs", map[string]string{
+		"list -H -o name,mountpoint " + dir: "tank/docker\t/var/lib/docker\n",
+		"list -H -t snapshot -d 1 -o name tank/docker": "tank/docker@daily\n" +
+			"tank/docker@uncloud:data:20261015T123046.123Z\n" +
+			"tank/docker@uncloud:data-2:20261015T123047.123Z\n" +
+			"tank/docker@uncloud:data:20261015T123048.123Z\n",
+	})
+
+	snapshot, err := m.Create(ctx, "data", dir)
+	require.NoError(t, err)
+	assert.Equal(t, "20261015T123046.123Z", snapshot.ID)
+	assert.Contains(t, *calls, "zfs snapshot tank/docker@uncloud:data:20261015T123046.123Z")
+
+	snapshots, err := m.List(ctx, "data", dir)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, "20261015T123046.123Z", snapshots[0].ID)
+	assert.Equal(t, "20261015T123048.123Z", snapshots[1].ID)
+	assert.Equal(t, time.Date(2026, 10, 15, 12, 30, 48, 123000000, time.UTC), snapshots[1].Created)
+
+	require.NoError(t, m.Remove(ctx, "data", dir, "20261015T123048.123Z"))
+	assert.Contains(t, *calls, "zfs destroy tank/docker@uncloud:data:20261015T123048.123Z")
+
+	assert.ErrorIs(t, m.Remove(ctx, "data", dir, "20261015T123047.123Z"), ErrNotFound)
+}
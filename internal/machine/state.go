@@ -27,6 +27,15 @@ type State struct {
 	// MinStoreDBVersion is the latest database version of one of the existing cluster machines at the time this machine
 	// joined the cluster. The machine should sync to at least this version before starting any cluster operations.
 	MinStoreDBVersion int64 `json:",omitempty"`
+	// ResyncStore indicates that the local cluster store database should be discarded on the next start to re-sync
+	// it from other machines. MinStoreDBVersion is set along with it to wait for the re-sync to complete.
+	ResyncStore bool `json:",omitempty"`
+	// Profile is the name of the resource profile that tunes the daemon for the machine hardware.
+	// Empty means the default profile.
+	Profile string `json:",omitempty"`
+	// StoreBackend is the name of the cluster store backend selected when the cluster was initialised.
+	// Empty means the default Corrosion backend.
+	StoreBackend string `json:",omitempty"`
 
 	// path is the file path config is read from and saved to.
 	path string
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// BackendCorrosion is the default store backend that replicates the cluster state to all machines using
+	// a distributed Corrosion database.
+	BackendCorrosion = "corrosion"
+	// BackendSQLite is a store backend that keeps the cluster state in a local SQLite database on a single machine.
+	// The state isn't replicated so a cluster using it can't have more than one machine.
+	BackendSQLite = "sqlite"
+)
+
+// Backends is the list of supported store backend names.
+var Backends = []string{BackendCorrosion, BackendSQLite}
+
+// ValidateBackend returns an error if the store backend name is not supported. An empty name means the default
+// Corrosion backend.
+func ValidateBackend(name string) error {
+	switch name {
+	case "", BackendCorrosion, BackendSQLite:
+		return nil
+	default:
+		return fmt.Errorf("unknown store backend '%s', supported backends: %s, %s",
+			name, BackendCorrosion, BackendSQLite)
+	}
+}
+
+// Statement is a write query with its arguments to execute as part of a batch.
+type Statement struct {
+	Query string
+	Args  []any
+}
+
+// Rows is the result of a query to the store backend.
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+// Backend is a database that stores the cluster state. All backends share the same SQL schema defined in schema.sql.
+type Backend interface {
+	// Exec executes a write query and returns the number of affected rows.
+	Exec(ctx context.Context, query string, args ...any) (int64, error)
+	// ExecBatch executes multiple write statements in a single transaction and returns the total number of affected
+	// rows. Either all statements are applied or none of them.
+	ExecBatch(ctx context.Context, statements ...Statement) (int64, error)
+	// Query executes a read query.
+	Query(ctx context.Context, query string, args ...any) (Rows, error)
+	// Subscribe executes the query, passes the resulting rows to scan, and returns a channel that signals when
+	// the query result may have changed. The channel is closed when the context is done or the subscription fails.
+	Subscribe(ctx context.Context, query string, args []any, scan func(Rows) error) (<-chan struct{}, error)
+	// DBVersion returns the current version of the database that increases with every change.
+	DBVersion(ctx context.Context) (int64, error)
+	// KnownMissingChanges returns the changes made on other machines that are known but not yet synced.
+	KnownMissingChanges(ctx context.Context) ([]MissingChange, error)
+	// Name returns the name of the backend, e.g. BackendCorrosion.
+	Name() string
+	// Replicated returns true if the backend replicates the state to other machines in the cluster.
+	Replicated() bool
+}
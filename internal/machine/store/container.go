@@ -52,17 +52,69 @@ type DeleteOptions struct {
 // CreateOrUpdateContainer creates a new container record or updates an existing one in the store database.
 // The container is associated with the given machine ID that indicates which machine the container is running on.
 func (s *Store) CreateOrUpdateContainer(ctx context.Context, ctr api.ServiceContainer, machineID string) error {
+	st, err := upsertContainerStatement(ctr, machineID)
+	if err != nil {
+		return err
+	}
+
+	n, err := s.Backend().Exec(ctx, st.Query, st.Args...)
+	if err != nil {
+		return fmt.Errorf("upsert query: %w", err)
+	}
+	if n > 0 {
+		slog.Debug("Container record updated in store DB.", "id", ctr.ID, "machine_id", machineID)
+	}
+
+	return nil
+}
+
+// SyncContainers creates or updates the records of the given containers running on the machine and deletes
+// the records with deleteIDs in a single transaction. Only the records that have changed are written. It's more
+// efficient than writing the records one by one when many containers change at once, for example, during a deploy.
+func (s *Store) SyncContainers(
+	ctx context.Context, machineID string, containers []api.ServiceContainer, deleteIDs []string,
+) error {
+	statements := make([]Statement, 0, len(containers)+1)
+	if len(deleteIDs) > 0 {
+		statements = append(statements, deleteContainersStatement(deleteIDs))
+	}
+	for _, ctr := range containers {
+		st, err := upsertContainerStatement(ctr, machineID)
+		if err != nil {
+			return err
+		}
+		statements = append(statements, st)
+	}
+	if len(statements) == 0 {
+		return nil
+	}
+
+	n, err := s.Backend().ExecBatch(ctx, statements...)
+	if err != nil {
+		return fmt.Errorf("sync containers transaction: %w", err)
+	}
+	if n > 0 {
+		slog.Debug("Container records synced to store DB.", "machine_id", machineID,
+			"containers", len(containers), "deleted", len(deleteIDs), "changed", n)
+	}
+
+	return nil
+}
+
+// upsertContainerStatement returns a statement that inserts or updates the container record if the container
+// or machine ID has changed.
+func upsertContainerStatement(ctr api.ServiceContainer, machineID string) (Statement, error) {
 	// Stabilise the order of slices that Docker returns non-deterministically, so that byte-level
 	// comparison of the serialised container does not flag spurious changes.
 	normaliseContainerForStore(&ctr)
 
 	cJSON, err := json.Marshal(ctr)
 	if err != nil {
-		return fmt.Errorf("marshal container: %w", err)
+		return Statement{}, fmt.Errorf("marshal container: %w", err)
 	}
 
-	// Insert or update the container record if the container or machine ID has changed.
-	res, err := s.corro.ExecContext(ctx, `
+	return Statement{
+		Query: `
 		INSERT INTO containers (id, container, machine_id, sync_status, updated_at)
 		VALUES (?, ?, ?, ?, datetime('now'))
 		ON CONFLICT (id) DO UPDATE SET container   = excluded.container,
@@ -71,15 +123,8 @@ func (s *Store) CreateOrUpdateContainer(ctx context.Context, ctr api.ServiceCont
 									   updated_at  = excluded.updated_at
 		WHERE containers.container != excluded.container
 		  OR containers.machine_id != excluded.machine_id`,
-		ctr.ID, string(cJSON), machineID, SyncStatusSynced)
-	if err != nil {
-		return fmt.Errorf("upsert query: %w", err)
-	}
-	if res.RowsAffected > 0 {
-		slog.Debug("Container record updated in store DB.", "id", ctr.ID, "machine_id", machineID)
-	}
-
-	return nil
+		Args: []any{ctr.ID, string(cJSON), machineID, SyncStatusSynced},
+	}, nil
 }
 
 // normaliseContainerForStore removes potentially sensitive data and normalises the container fields that Docker may
@@ -89,6 +134,14 @@ func normaliseContainerForStore(ctr *api.ServiceContainer) {
 	// Remove the environment variables to avoid leaking secrets.
 	ctr.Config.Env = nil
 	ctr.ServiceSpec.Container.Env = nil
+	// Remove the plain text content of secrets, e.g. resolved from Vault or SOPS. The ciphertext of sealed secrets
+	// is safe to replicate. Clone the secrets first as they share the backing array with the caller's container.
+	ctr.ServiceSpec.Secrets = slices.Clone(ctr.ServiceSpec.Secrets)
+	for i := range ctr.ServiceSpec.Secrets {
+		if ctr.ServiceSpec.Secrets[i].SealingKeyID == "" {
+			ctr.ServiceSpec.Secrets[i].Content = nil
+		}
+	}
 
 	// Docker returns Mounts in a non-deterministic order so sort them.
 	slices.SortFunc(ctr.Mounts, func(a, b container.MountPoint) int {
@@ -124,7 +177,7 @@ func (s *Store) ListContainers(ctx context.Context, opts ListOptions) ([]Contain
 		return nil, fmt.Errorf("build query: %w", err)
 	}
 
-	rows, err := s.corro.QueryContext(ctx, query, args...)
+	rows, err := s.Backend().Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("select query: %w", err)
 	}
@@ -173,28 +226,34 @@ func (s *Store) ListContainers(ctx context.Context, opts ListOptions) ([]Contain
 
 // DeleteContainers deletes container records from the store database that match the given options.
 func (s *Store) DeleteContainers(ctx context.Context, opts DeleteOptions) error {
-	query := "DELETE FROM containers"
-	var args []any
-
+	st := Statement{Query: "DELETE FROM containers"}
 	if len(opts.IDs) > 0 {
-		query += " WHERE id IN (?" + strings.Repeat(", ?", len(opts.IDs)-1) + ")"
-		args = make([]any, len(opts.IDs))
-		for i, id := range opts.IDs {
-			args[i] = id
-		}
+		st = deleteContainersStatement(opts.IDs)
 	}
 
-	res, err := s.corro.ExecContext(ctx, query, args...)
+	n, err := s.Backend().Exec(ctx, st.Query, st.Args...)
 	if err != nil {
 		return fmt.Errorf("delete query: %w", err)
 	}
-	if res.RowsAffected > 0 {
-		slog.Debug("Container records deleted from store DB.", "ids", opts.IDs, "count", res.RowsAffected)
+	if n > 0 {
+		slog.Debug("Container records deleted from store DB.", "ids", opts.IDs, "count", n)
 	}
 
 	return nil
 }
 
+// deleteContainersStatement returns a statement that deletes the container records with the given IDs.
+func deleteContainersStatement(ids []string) Statement {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return Statement{
+		Query: "DELETE FROM containers WHERE id IN (?" + strings.Repeat(", ?", len(ids)-1) + ")",
+		Args:  args,
+	}
+}
+
 // SubscribeContainers returns a list of containers and a channel that signals changes to the list. The channel doesn't
 // receive any values, it just signals when a container(s) has been added, updated, or deleted in the database.
 func (s *Store) SubscribeContainers(ctx context.Context) ([]ContainerRecord, <-chan struct{}, error) {
@@ -206,37 +265,40 @@ func (s *Store) SubscribeContainers(ctx context.Context) ([]ContainerRecord, <-c
 		return nil, nil, fmt.Errorf("build query: %w", err)
 	}
 
-	sub, err := s.corro.SubscribeContext(ctx, query, args, false)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var containers []ContainerRecord
-	var id, cJSON, updatedAtStr string
 	skipped := 0
 
-	rows := sub.Rows()
-	for rows.Next() {
-		var cr ContainerRecord
-		if err = rows.Scan(&id, &cJSON, &cr.MachineID, &cr.SyncStatus, &updatedAtStr); err != nil {
-			return nil, nil, err
-		}
+	scan := func(rows Rows) error {
+		var id, cJSON, updatedAtStr string
+		for rows.Next() {
+			var cr ContainerRecord
+			if err := rows.Scan(&id, &cJSON, &cr.MachineID, &cr.SyncStatus, &updatedAtStr); err != nil {
+				return err
+			}
 
-		// Skip containers with empty JSON data. This can happen during partial replication
-		// when cr-sqlite has created the row but the container column hasn't been synced yet.
-		if cJSON == "" || cJSON == "{}" {
-			slog.Debug("Skipping container with empty data in the store (partial replication?).", "id", id)
-			skipped++
-			continue
-		}
+			// Skip containers with empty JSON data. This can happen during partial replication
+			// when cr-sqlite has created the row but the container column hasn't been synced yet.
+			if cJSON == "" || cJSON == "{}" {
+				slog.Debug("Skipping container with empty data in the store (partial replication?).", "id", id)
+				skipped++
+				continue
+			}
 
-		if err = json.Unmarshal([]byte(cJSON), &cr.Container); err != nil {
-			return nil, nil, fmt.Errorf("unmarshal container: %w", err)
-		}
-		if cr.UpdatedAt, err = time.Parse(time.DateTime, updatedAtStr); err != nil {
-			return nil, nil, fmt.Errorf("parse updated_at: %w", err)
+			if err := json.Unmarshal([]byte(cJSON), &cr.Container); err != nil {
+				return fmt.Errorf("unmarshal container: %w", err)
+			}
+			var err error
+			if cr.UpdatedAt, err = time.Parse(time.DateTime, updatedAtStr); err != nil {
+				return fmt.Errorf("parse updated_at: %w", err)
+			}
+			containers = append(containers, cr)
 		}
-		containers = append(containers, cr)
+		return rows.Err()
+	}
+
+	changes, err := s.subscribe(ctx, query, args, scan)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if skipped > 0 {
@@ -244,31 +306,5 @@ func (s *Store) SubscribeContainers(ctx context.Context) ([]ContainerRecord, <-c
 			"skipped", skipped, "valid", len(containers))
 	}
 
-	events, err := sub.Changes()
-	if err != nil {
-		return nil, nil, fmt.Errorf("get subscription changes: %w", err)
-	}
-
-	changes := make(chan struct{})
-	go func() {
-		defer close(changes)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case _, ok := <-events:
-				if !ok {
-					// events channel has been closed.
-					if sub.Err() != nil {
-						slog.Error("Containers subscription failed.", "id", sub.ID(), "err", sub.Err())
-					}
-					return
-				}
-				// Just signal that there is a change in the containers list.
-				changes <- struct{}{}
-			}
-		}
-	}()
-
 	return containers, changes, nil
 }
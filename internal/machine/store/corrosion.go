@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+
+	"github.com/psviderski/uncloud/internal/corrosion"
+)
+
+// CorrosionBackend is a store backend that replicates the cluster state to all machines using a distributed
+// Corrosion database.
+type CorrosionBackend struct {
+	corro *corrosion.APIClient
+}
+
+var _ Backend = (*CorrosionBackend)(nil)
+
+func NewCorrosionBackend(corro *corrosion.APIClient) *CorrosionBackend {
+	return &CorrosionBackend{corro: corro}
+}
+
+func (b *CorrosionBackend) Exec(ctx context.Context, query string, args ...any) (int64, error) {
+	res, err := b.corro.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return int64(res.RowsAffected), nil
+}
+
+func (b *CorrosionBackend) ExecBatch(ctx context.Context, statements ...Statement) (int64, error) {
+	corroStatements := make([]corrosion.Statement, len(statements))
+	for i, st := range statements {
+		corroStatements[i] = corrosion.Statement{Query: st.Query, Params: st.Args}
+	}
+
+	resp, err := b.corro.ExecMultiContext(ctx, corroStatements...)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, r := range resp.Results {
+		n += int64(r.RowsAffected)
+	}
+	return n, nil
+}
+
+func (b *CorrosionBackend) Query(ctx context.Context, query string, args ...any) (Rows, error) {
+	rows, err := b.corro.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (b *CorrosionBackend) Subscribe(
+	ctx context.Context, query string, args []any, scan func(Rows) error,
+) (<-chan struct{}, error) {
+	sub, err := b.corro.SubscribeContext(ctx, query, args, false)
+	if err != nil {
+		return nil, err
+	}
+	// The rows must be consumed before the changes become available.
+	if err = scan(sub.Rows()); err != nil {
+		return nil, err
+	}
+
+	events, err := sub.Changes()
+	if err != nil {
+		return nil, fmt.Errorf("get subscription changes: %w", err)
+	}
+
+	changes := make(chan struct{})
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					// events channel has been closed.
+					if sub.Err() != nil {
+						slog.Error("Store subscription failed.", "id", sub.ID(), "query", query, "err", sub.Err())
+					}
+					return
+				}
+				// Just signal that there is a change in the query result.
+				changes <- struct{}{}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// DBVersion returns the current cr-sqlite database version (Lamport timestamp).
+func (b *CorrosionBackend) DBVersion(ctx context.Context) (int64, error) {
+	rows, err := b.corro.QueryContext(ctx, "SELECT crsql_db_version()")
+	if err != nil {
+		return 0, fmt.Errorf("query crsql_db_version(): %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return 0, fmt.Errorf("no result from crsql_db_version()")
+	}
+
+	var version int64
+	if err = rows.Scan(&version); err != nil {
+		return 0, fmt.Errorf("scan db version: %w", err)
+	}
+	return version, nil
+}
+
+// KnownMissingChanges returns a list of currently known missing changes in the Corrosion database.
+func (b *CorrosionBackend) KnownMissingChanges(ctx context.Context) ([]MissingChange, error) {
+	rows, err := b.corro.QueryContext(ctx, "SELECT actor_id, start, end FROM __corro_bookkeeping_gaps")
+	if err != nil {
+		return nil, fmt.Errorf("query missing changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []MissingChange
+	for rows.Next() {
+		var c MissingChange
+		var actorBytes []byte
+		if err = rows.Scan(&actorBytes, &c.StartVersion, &c.EndVersion); err != nil {
+			return nil, fmt.Errorf("scan missing change: %w", err)
+		}
+
+		c.ActorID = hex.EncodeToString(actorBytes)
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+func (b *CorrosionBackend) Name() string {
+	return BackendCorrosion
+}
+
+func (b *CorrosionBackend) Replicated() bool {
+	return true
+}
@@ -0,0 +1,243 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteFileName is the name of the SQLite database file in the machine data directory used by the SQLite backend.
+const SQLiteFileName = "cluster.db"
+
+// versionSchema is the schema of the backend-only table that tracks the database version. The version is incremented
+// in the same transaction as every write that changes the cluster state.
+const versionSchema = `
+CREATE TABLE IF NOT EXISTS __uncloud_db_version
+(
+    id      INTEGER NOT NULL PRIMARY KEY CHECK (id = 1),
+    version INTEGER NOT NULL
+);
+INSERT OR IGNORE INTO __uncloud_db_version (id, version) VALUES (1, 0);
+`
+
+// SQLiteBackend is a store backend that keeps the cluster state in a local SQLite database. It trades replication
+// for the simplicity and durability of a single SQLite file: every write is fsynced before it's acknowledged.
+// The state isn't replicated so a cluster using it can't have more than one machine.
+type SQLiteBackend struct {
+	db *sql.DB
+
+	mu sync.Mutex
+	// watchers are the channels of active subscriptions that are signalled on every change.
+	watchers map[chan struct{}]struct{}
+}
+
+var _ Backend = (*SQLiteBackend)(nil)
+
+// NewSQLiteBackend opens or creates the SQLite database at the given path and applies the store schema.
+func NewSQLiteBackend(path string) (*SQLiteBackend, error) {
+	// Create the database file with 0600 permissions if it doesn't exist.
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("create SQLite database '%s': %w", path, err)
+	}
+	file.Close()
+
+	// - Write-Ahead Logging (WAL) mode for better read/write performance.
+	// - Full synchronous mode to make sure acknowledged writes survive a power loss.
+	// - Busy timeout (5s) to make concurrent writes wait on each other instead of failing immediately.
+	conn := path + "?_pragma=journal_mode=WAL&_pragma=synchronous=FULL&_pragma=busy_timeout=5000"
+	db, err := sql.Open("sqlite", conn)
+	if err != nil {
+		return nil, fmt.Errorf("open SQLite database '%s': %w", path, err)
+	}
+
+	// Make the schema statements idempotent to be able to apply them on every start.
+	schema := strings.NewReplacer(
+		"CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ",
+		"CREATE INDEX ", "CREATE INDEX IF NOT EXISTS ",
+	).Replace(Schema)
+	if _, err = db.Exec(schema + versionSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create schema: %w", err)
+	}
+
+	return &SQLiteBackend{
+		db:       db,
+		watchers: make(map[chan struct{}]struct{}),
+	}, nil
+}
+
+func (b *SQLiteBackend) Close() error {
+	return b.db.Close()
+}
+
+func (b *SQLiteBackend) Exec(ctx context.Context, query string, args ...any) (int64, error) {
+	return b.ExecBatch(ctx, Statement{Query: query, Args: args})
+}
+
+func (b *SQLiteBackend) ExecBatch(ctx context.Context, statements ...Statement) (int64, error) {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var n int64
+	for _, st := range statements {
+		res, err := tx.ExecContext(ctx, st.Query, st.Args...)
+		if err != nil {
+			return 0, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("get affected rows: %w", err)
+		}
+		n += affected
+	}
+	if n > 0 {
+		if _, err = tx.ExecContext(ctx, "UPDATE __uncloud_db_version SET version = version + 1"); err != nil {
+			return 0, fmt.Errorf("increment db version: %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	if n > 0 {
+		b.notify()
+	}
+	return n, nil
+}
+
+func (b *SQLiteBackend) Query(ctx context.Context, query string, args ...any) (Rows, error) {
+	rows, err := b.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows}, nil
+}
+
+// Subscribe executes the query and returns a channel that signals on every change in the database. Unlike
+// the Corrosion backend, it doesn't track which changes affect the query result so the signals may be spurious.
+// Multiple changes made before the subscriber receives the signal are coalesced into one signal.
+func (b *SQLiteBackend) Subscribe(
+	ctx context.Context, query string, args []any, scan func(Rows) error,
+) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+	// Register the watcher before running the query to not miss changes made after the query.
+	b.mu.Lock()
+	b.watchers[changes] = struct{}{}
+	b.mu.Unlock()
+
+	unwatch := func() {
+		b.mu.Lock()
+		delete(b.watchers, changes)
+		close(changes)
+		b.mu.Unlock()
+	}
+
+	rows, err := b.Query(ctx, query, args...)
+	if err != nil {
+		unwatch()
+		return nil, err
+	}
+	err = scan(rows)
+	rows.Close()
+	if err != nil {
+		unwatch()
+		return nil, err
+	}
+	// Drop the signals for the changes that are already included in the query result.
+	select {
+	case <-changes:
+	default:
+	}
+
+	go func() {
+		<-ctx.Done()
+		unwatch()
+	}()
+
+	return changes, nil
+}
+
+// notify signals all subscriptions that the database has changed without blocking.
+func (b *SQLiteBackend) notify() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *SQLiteBackend) DBVersion(ctx context.Context) (int64, error) {
+	var version int64
+	if err := b.db.QueryRowContext(ctx, "SELECT version FROM __uncloud_db_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("query db version: %w", err)
+	}
+	return version, nil
+}
+
+// KnownMissingChanges always returns no changes as the SQLite backend isn't replicated.
+func (b *SQLiteBackend) KnownMissingChanges(context.Context) ([]MissingChange, error) {
+	return nil, nil
+}
+
+func (b *SQLiteBackend) Name() string {
+	return BackendSQLite
+}
+
+func (b *SQLiteBackend) Replicated() bool {
+	return false
+}
+
+// sqliteRows adapts sql.Rows to the Rows interface.
+type sqliteRows struct {
+	*sql.Rows
+}
+
+// Scan scans timestamp columns into string destinations in the same format as the Corrosion backend returns them.
+// The SQLite driver parses the values of TIMESTAMP columns into time.Time that would otherwise be scanned into
+// a string in RFC 3339 format.
+func (r *sqliteRows) Scan(dest ...any) error {
+	wrapped := make([]any, len(dest))
+	for i, d := range dest {
+		if s, ok := d.(*string); ok {
+			wrapped[i] = &timeString{dest: s}
+		} else {
+			wrapped[i] = d
+		}
+	}
+	return r.Rows.Scan(wrapped...)
+}
+
+// timeString is a sql.Scanner that scans a string value and formats a time value with time.DateTime layout.
+type timeString struct {
+	dest *string
+}
+
+func (t *timeString) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t.dest = ""
+	case string:
+		*t.dest = v
+	case []byte:
+		*t.dest = string(v)
+	case time.Time:
+		*t.dest = v.UTC().Format(time.DateTime)
+	default:
+		*t.dest = fmt.Sprint(v)
+	}
+	return nil
+}
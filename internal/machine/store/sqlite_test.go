@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSQLiteStore(t *testing.T) (*Store, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), SQLiteFileName)
+	backend, err := NewSQLiteBackend(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { backend.Close() })
+
+	return New(backend), path
+}
+
+func TestSQLiteBackend_KeyValue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s, _ := newSQLiteStore(t)
+
+	var network string
+	assert.ErrorIs(t, s.Get(ctx, "network", &network), ErrKeyNotFound)
+
+	require.NoError(t, s.Put(ctx, "network", "10.210.0.0/16"))
+	require.NoError(t, s.Get(ctx, "network", &network))
+	assert.Equal(t, "10.210.0.0/16", network)
+
+	require.NoError(t, s.Put(ctx, "data", []byte("value")))
+	var data []byte
+	require.NoError(t, s.Get(ctx, "data", &data))
+	assert.Equal(t, []byte("value"), data)
+
+	require.NoError(t, s.Delete(ctx, "network"))
+	assert.ErrorIs(t, s.Get(ctx, "network", &network), ErrKeyNotFound)
+
+	require.NoError(t, s.Put(ctx, "items/a", []byte("1")))
+	require.NoError(t, s.Put(ctx, "items/b", "2"))
+	require.NoError(t, s.Put(ctx, "items", "not an item"))
+	items, err := s.List(ctx, "items/")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"items/a": []byte("1"), "items/b": []byte("2")}, items)
+}
+
+func TestSQLiteBackend_DBVersion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s, path := newSQLiteStore(t)
+
+	v1, err := s.DBVersion(ctx)
+	require.NoError(t, err)
+	require.NoError(t, s.Put(ctx, "key", "value"))
+	v2, err := s.DBVersion(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, v2, v1)
+
+	// A write that doesn't change anything doesn't increment the version.
+	require.NoError(t, s.Delete(ctx, "missing"))
+	v3, err := s.DBVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, v2, v3)
+
+	// The state and version persist when the database is reopened.
+	backend, err := NewSQLiteBackend(path)
+	require.NoError(t, err)
+	defer backend.Close()
+	reopened := New(backend)
+
+	v4, err := reopened.DBVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, v2, v4)
+	var value string
+	require.NoError(t, reopened.Get(ctx, "key", &value))
+	assert.Equal(t, "value", value)
+}
+
+func TestSQLiteBackend_Machines(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s, _ := newSQLiteStore(t)
+
+	m := &pb.MachineInfo{Id: "m1", Name: "machine-1"}
+	require.NoError(t, s.CreateMachine(ctx, m))
+
+	got, err := s.GetMachine(ctx, "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "machine-1", got.Name)
+
+	m.Name = "renamed"
+	require.NoError(t, s.UpdateMachine(ctx, m))
+	got, err = s.GetMachine(ctx, "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", got.Name)
+
+	require.NoError(t, s.DeleteMachine(ctx, "m1"))
+	_, err = s.GetMachine(ctx, "m1")
+	assert.ErrorIs(t, err, ErrMachineNotFound)
+	assert.ErrorIs(t, s.DeleteMachine(ctx, "m1"), ErrMachineNotFound)
+}
+
+func TestSQLiteBackend_Containers(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _ := newSQLiteStore(t)
+
+	ctr := api.ServiceContainer{
+		Container: api.Container{InspectResponse: container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: "c1", Name: "web-c1"},
+			Config: &container.Config{Labels: map[string]string{
+				api.LabelServiceID:   "web-id",
+				api.LabelServiceName: "web",
+			}},
+		}},
+	}
+
+	records, changes, err := s.SubscribeContainers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	require.NoError(t, s.CreateOrUpdateContainer(ctx, ctr, "m1"))
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a change signal after creating a container")
+	}
+
+	records, err = s.ListContainers(ctx, ListOptions{ServiceIDOrName: ServiceIDOrNameOptions{Name: "web"}})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "c1", records[0].Container.ID)
+	assert.Equal(t, "m1", records[0].MachineID)
+	assert.Equal(t, SyncStatusSynced, records[0].SyncStatus)
+	assert.WithinDuration(t, time.Now(), records[0].UpdatedAt, time.Minute)
+
+	digest, err := s.Digest(ctx)
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteContainers(ctx, DeleteOptions{IDs: []string{"c1"}}))
+	records, err = s.ListContainers(ctx, ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	newDigest, err := s.Digest(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, newDigest)
+
+	cancel()
+	select {
+	case _, ok := <-changes:
+		for ok {
+			_, ok = <-changes
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the changes channel to be closed after cancelling the context")
+	}
+}
+
+func TestStore_SetBackend(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _ := newSQLiteStore(t)
+
+	other, err := NewSQLiteBackend(filepath.Join(t.TempDir(), SQLiteFileName))
+	require.NoError(t, err)
+	t.Cleanup(func() { other.Close() })
+
+	require.NoError(t, s.SetBackend(other))
+	assert.Same(t, other, s.Backend())
+
+	_, _, err = s.SubscribeMachines(ctx)
+	require.NoError(t, err)
+	// Subscribers would keep watching the previous backend.
+	assert.Error(t, s.SetBackend(other))
+}
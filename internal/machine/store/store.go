@@ -2,13 +2,15 @@ package store
 
 import (
 	"context"
+	"crypto/sha256"
 	_ "embed"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"log/slog"
+	"sync"
 
-	"github.com/psviderski/uncloud/internal/corrosion"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -21,17 +23,53 @@ var (
 	ErrMachineNotFound = errors.New("machine not found")
 )
 
-// Store is a cluster store backed by a distributed Corrosion database.
+// Store is a cluster store backed by a pluggable database backend. The default backend is a distributed Corrosion
+// database that replicates the cluster state to all machines.
 type Store struct {
-	corro *corrosion.APIClient
+	mu      sync.RWMutex
+	backend Backend
+	// subscribed is set once a subscription is created. The backend can't be replaced after that as
+	// the subscribers would keep receiving changes from the previous backend.
+	subscribed bool
 }
 
-func New(corro *corrosion.APIClient) *Store {
-	return &Store{corro: corro}
+func New(backend Backend) *Store {
+	return &Store{backend: backend}
+}
+
+// SetBackend replaces the store backend. It's used to switch to the backend selected when initialising a cluster
+// and must be called before any subscriptions to the store changes are created.
+func (s *Store) SetBackend(backend Backend) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscribed {
+		return errors.New("store backend can't be replaced after subscriptions to changes have been created")
+	}
+	s.backend = backend
+	return nil
+}
+
+// subscribe subscribes to the changes of the query result using the current backend. See Backend.Subscribe.
+func (s *Store) subscribe(
+	ctx context.Context, query string, args []any, scan func(Rows) error,
+) (<-chan struct{}, error) {
+	s.mu.Lock()
+	s.subscribed = true
+	backend := s.backend
+	s.mu.Unlock()
+
+	return backend.Subscribe(ctx, query, args, scan)
+}
+
+// Backend returns the current store backend.
+func (s *Store) Backend() Backend {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.backend
 }
 
 func (s *Store) Get(ctx context.Context, key string, value any) error {
-	rows, err := s.corro.QueryContext(ctx, "SELECT value FROM cluster WHERE key = ?", key)
+	rows, err := s.Backend().Query(ctx, "SELECT value FROM cluster WHERE key = ?", key)
 	if err != nil {
 		return err
 	}
@@ -48,61 +86,93 @@ func (s *Store) Get(ctx context.Context, key string, value any) error {
 }
 
 func (s *Store) Put(ctx context.Context, key string, value any) error {
-	_, err := s.corro.ExecContext(ctx, "INSERT OR REPLACE INTO cluster (key, value) VALUES (?, ?)", key, value)
+	_, err := s.Backend().Exec(ctx, "INSERT OR REPLACE INTO cluster (key, value) VALUES (?, ?)", key, value)
 	return err
 }
 
+// List returns the values of all keys that start with the given prefix. Storing related items under separate keys
+// with a common prefix allows different machines to change them concurrently without overwriting each other.
+func (s *Store) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	rows, err := s.Backend().Query(ctx,
+		"SELECT key, value FROM cluster WHERE substr(key, 1, length(?)) = ?", prefix, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string][]byte)
+	for rows.Next() {
+		var (
+			key   string
+			value []byte
+		)
+		if err = rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
+
 func (s *Store) Delete(ctx context.Context, key string) error {
-	_, err := s.corro.ExecContext(ctx, "DELETE FROM cluster WHERE key = ?", key)
+	_, err := s.Backend().Exec(ctx, "DELETE FROM cluster WHERE key = ?", key)
 	return err
 }
 
-// DBVersion returns the current cr-sqlite database version (Lamport timestamp).
+// DBVersion returns the current database version of the store backend. For the Corrosion backend, it's the cr-sqlite
+// database version (Lamport timestamp).
 func (s *Store) DBVersion(ctx context.Context) (int64, error) {
-	rows, err := s.corro.QueryContext(ctx, "SELECT crsql_db_version()")
-	if err != nil {
-		return 0, fmt.Errorf("query crsql_db_version(): %w", err)
-	}
-	defer rows.Close()
+	return s.Backend().DBVersion(ctx)
+}
 
-	if !rows.Next() {
-		return 0, fmt.Errorf("no result from crsql_db_version()")
+// Digest returns a hash of the cluster-wide state in the store: cluster settings, machines, and the set of containers
+// with the machines they belong to. Container states aren't included as they change frequently. Machines with the same
+// digest have converged to the same cluster state.
+func (s *Store) Digest(ctx context.Context) (string, error) {
+	queries := []string{
+		"SELECT key, hex(value) FROM cluster ORDER BY key",
+		"SELECT id, info FROM machines ORDER BY id",
+		"SELECT id, machine_id FROM containers ORDER BY id",
 	}
 
-	var version int64
-	if err = rows.Scan(&version); err != nil {
-		return 0, fmt.Errorf("scan db version: %w", err)
+	h := sha256.New()
+	for _, q := range queries {
+		if err := s.hashRows(ctx, h, q); err != nil {
+			return "", err
+		}
 	}
-	return version, nil
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
-type MissingChange struct {
-	ActorID      string
-	StartVersion int64
-	EndVersion   int64
-}
-
-// KnownMissingChanges returns a list of currently known missing changes in the Corrosion database.
-func (s *Store) KnownMissingChanges(ctx context.Context) ([]MissingChange, error) {
-	rows, err := s.corro.QueryContext(ctx, "SELECT actor_id, start, end FROM __corro_bookkeeping_gaps")
+// hashRows writes the two-column rows returned by the query to the hash.
+func (s *Store) hashRows(ctx context.Context, h hash.Hash, query string) error {
+	rows, err := s.Backend().Query(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("query missing changes: %w", err)
+		return fmt.Errorf("query '%s': %w", query, err)
 	}
 	defer rows.Close()
 
-	var changes []MissingChange
 	for rows.Next() {
-		var c MissingChange
-		var actorBytes []byte
-		if err = rows.Scan(&actorBytes, &c.StartVersion, &c.EndVersion); err != nil {
-			return nil, fmt.Errorf("scan missing change: %w", err)
+		var a, b string
+		if err = rows.Scan(&a, &b); err != nil {
+			return fmt.Errorf("scan row: %w", err)
 		}
-
-		c.ActorID = hex.EncodeToString(actorBytes)
-		changes = append(changes, c)
+		// Separate the values with zero bytes that can't appear in them to make the hash input unambiguous.
+		fmt.Fprintf(h, "%s\x00%s\x00", a, b)
 	}
+	h.Write([]byte{0xff})
+	return rows.Err()
+}
 
-	return changes, nil
+type MissingChange struct {
+	ActorID      string
+	StartVersion int64
+	EndVersion   int64
+}
+
+// KnownMissingChanges returns a list of currently known missing changes in the store backend.
+func (s *Store) KnownMissingChanges(ctx context.Context) ([]MissingChange, error) {
+	return s.Backend().KnownMissingChanges(ctx)
 }
 
 func (s *Store) CreateMachine(ctx context.Context, m *pb.MachineInfo) error {
@@ -110,7 +180,7 @@ func (s *Store) CreateMachine(ctx context.Context, m *pb.MachineInfo) error {
 	if err != nil {
 		return fmt.Errorf("marshal machine info: %w", err)
 	}
-	_, err = s.corro.ExecContext(ctx, "INSERT INTO machines (id, info) VALUES (?, ?)", m.Id, string(mJSON))
+	_, err = s.Backend().Exec(ctx, "INSERT INTO machines (id, info) VALUES (?, ?)", m.Id, string(mJSON))
 	if err != nil {
 		return fmt.Errorf("insert query: %w", err)
 	}
@@ -122,7 +192,7 @@ func (s *Store) GetMachine(ctx context.Context, machineID string) (*pb.MachineIn
 		return nil, fmt.Errorf("machine ID cannot be empty")
 	}
 
-	rows, err := s.corro.QueryContext(ctx, "SELECT info FROM machines WHERE id = ?", machineID)
+	rows, err := s.Backend().Query(ctx, "SELECT info FROM machines WHERE id = ?", machineID)
 	if err != nil {
 		return nil, fmt.Errorf("query machine: %w", err)
 	}
@@ -165,7 +235,7 @@ func (s *Store) GetMachine(ctx context.Context, machineID string) (*pb.MachineIn
 }
 
 func (s *Store) ListMachines(ctx context.Context) ([]*pb.MachineInfo, error) {
-	rows, err := s.corro.QueryContext(ctx, "SELECT id, info FROM machines ORDER BY name")
+	rows, err := s.Backend().Query(ctx, "SELECT id, info FROM machines ORDER BY name")
 	if err != nil {
 		return nil, err
 	}
@@ -222,13 +292,13 @@ func (s *Store) UpdateMachine(ctx context.Context, m *pb.MachineInfo) error {
 		return fmt.Errorf("marshal machine info: %w", err)
 	}
 
-	result, err := s.corro.ExecContext(ctx, "UPDATE machines SET info = ? WHERE id = ?", string(mJSON), m.Id)
+	n, err := s.Backend().Exec(ctx, "UPDATE machines SET info = ? WHERE id = ?", string(mJSON), m.Id)
 	if err != nil {
 		return fmt.Errorf("update machine: %w", err)
 	}
 
 	// Check if machine exists
-	if result.RowsAffected == 0 {
+	if n == 0 {
 		return fmt.Errorf("%w: %s", ErrMachineNotFound, m.Id)
 	}
 
@@ -236,12 +306,12 @@ func (s *Store) UpdateMachine(ctx context.Context, m *pb.MachineInfo) error {
 }
 
 func (s *Store) DeleteMachine(ctx context.Context, id string) error {
-	result, err := s.corro.ExecContext(ctx, "DELETE FROM machines WHERE id = ?", id)
+	n, err := s.Backend().Exec(ctx, "DELETE FROM machines WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("delete machine: %w", err)
 	}
 	// Check if machine was deleted.
-	if result.RowsAffected == 0 {
+	if n == 0 {
 		return fmt.Errorf("%w: %s", ErrMachineNotFound, id)
 	}
 
@@ -251,34 +321,36 @@ func (s *Store) DeleteMachine(ctx context.Context, id string) error {
 // SubscribeMachines returns a list of machines and a channel that signals changes to the list. The channel doesn't
 // receive any values, it just signals when a machine has been added, updated, or deleted in the database.
 func (s *Store) SubscribeMachines(ctx context.Context) ([]*pb.MachineInfo, <-chan struct{}, error) {
-	sub, err := s.corro.SubscribeContext(ctx, "SELECT id, info FROM machines ORDER BY name", nil, false)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	rows := sub.Rows()
 	var machines []*pb.MachineInfo
 	skipped := 0
 
-	for rows.Next() {
-		var id, mJSON string
-		if err = rows.Scan(&id, &mJSON); err != nil {
-			return nil, nil, err
-		}
+	scan := func(rows Rows) error {
+		for rows.Next() {
+			var id, mJSON string
+			if err := rows.Scan(&id, &mJSON); err != nil {
+				return err
+			}
 
-		// Skip machines with empty JSON data. This can happen during partial replication
-		// when cr-sqlite has created the row but the info column hasn't been synced yet.
-		if mJSON == "" || mJSON == "{}" {
-			slog.Debug("Skipping machine with empty data in the store (partial replication?).", "id", id)
-			skipped++
-			continue
-		}
+			// Skip machines with empty JSON data. This can happen during partial replication
+			// when cr-sqlite has created the row but the info column hasn't been synced yet.
+			if mJSON == "" || mJSON == "{}" {
+				slog.Debug("Skipping machine with empty data in the store (partial replication?).", "id", id)
+				skipped++
+				continue
+			}
 
-		var m pb.MachineInfo
-		if err = protojson.Unmarshal([]byte(mJSON), &m); err != nil {
-			return nil, nil, fmt.Errorf("unmarshal machine info: %w", err)
+			var m pb.MachineInfo
+			if err := protojson.Unmarshal([]byte(mJSON), &m); err != nil {
+				return fmt.Errorf("unmarshal machine info: %w", err)
+			}
+			machines = append(machines, &m)
 		}
-		machines = append(machines, &m)
+		return rows.Err()
+	}
+
+	changes, err := s.subscribe(ctx, "SELECT id, info FROM machines ORDER BY name", nil, scan)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if skipped > 0 {
@@ -286,31 +358,5 @@ func (s *Store) SubscribeMachines(ctx context.Context) ([]*pb.MachineInfo, <-cha
 			"skipped", skipped, "valid", len(machines))
 	}
 
-	events, err := sub.Changes()
-	if err != nil {
-		return nil, nil, fmt.Errorf("get subscription changes: %w", err)
-	}
-
-	changes := make(chan struct{})
-	go func() {
-		defer close(changes)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case _, ok := <-events:
-				if !ok {
-					// events channel has been closed.
-					if sub.Err() != nil {
-						slog.Error("Machines subscription failed.", "id", sub.ID(), "err", sub.Err())
-					}
-					return
-				}
-				// Just signal that there is a change in the machines list.
-				changes <- struct{}{}
-			}
-		}
-	}()
-
 	return machines, changes, nil
 }
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WatchDebounceInterval is the time to wait for more changes after a change before listing the records again.
+// A deploy that touches many records produces a burst of changes that are coalesced into a single update.
+const WatchDebounceInterval = 100 * time.Millisecond
+
+// WatchContainers subscribes to container changes and sends the full list of container records to the returned
+// channel: first the current list, then the updated list after every burst of changes. Reconcilers should use it
+// to react to changes instead of polling the store. If the receiver is slower than the changes, intermediate lists
+// are dropped and only the latest one is delivered. The channel is closed when the context is done or
+// the subscription fails.
+func (s *Store) WatchContainers(ctx context.Context) (<-chan []ContainerRecord, error) {
+	containers, changes, err := s.SubscribeContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := func(ctx context.Context) ([]ContainerRecord, error) {
+		return s.ListContainers(ctx, ListOptions{})
+	}
+	return watch(ctx, containers, changes, list), nil
+}
+
+// watch sends the initial value and then the result of list after every burst of changes to the returned channel.
+func watch[T any](
+	ctx context.Context, initial T, changes <-chan struct{}, list func(context.Context) (T, error),
+) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		// pending is the latest value that hasn't been delivered yet.
+		pending, hasPending := initial, true
+		var (
+			debouncer  *time.Timer
+			debounceCh <-chan time.Time
+		)
+		defer func() {
+			if debouncer != nil {
+				debouncer.Stop()
+			}
+		}()
+
+		for {
+			// Only try to send when there is a pending value. A nil channel blocks forever in select.
+			var sendCh chan<- T
+			if hasPending {
+				sendCh = out
+			}
+
+			select {
+			case sendCh <- pending:
+				hasPending = false
+			case _, ok := <-changes:
+				if !ok {
+					return
+				}
+				if debouncer == nil {
+					debouncer = time.NewTimer(WatchDebounceInterval)
+					debounceCh = debouncer.C
+				}
+			case <-debounceCh:
+				debouncer, debounceCh = nil, nil
+				v, err := list(ctx)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					slog.Error("Failed to list records in the store after a change, retrying.", "err", err)
+					debouncer = time.NewTimer(WatchDebounceInterval)
+					debounceCh = debouncer.C
+					continue
+				}
+				pending, hasPending = v, true
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testContainer(id string) api.ServiceContainer {
+	return api.ServiceContainer{
+		Container: api.Container{InspectResponse: container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: id, Name: "web-" + id},
+			Config: &container.Config{Labels: map[string]string{
+				api.LabelServiceID:   "web-id",
+				api.LabelServiceName: "web",
+			}},
+		}},
+	}
+}
+
+func receive(t *testing.T, updates <-chan []ContainerRecord) []ContainerRecord {
+	t.Helper()
+	select {
+	case records, ok := <-updates:
+		require.True(t, ok, "updates channel closed")
+		return records
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for containers update")
+		return nil
+	}
+}
+
+func TestStore_SyncContainers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s, _ := newSQLiteStore(t)
+
+	require.NoError(t, s.SyncContainers(ctx, "m1", []api.ServiceContainer{testContainer("c1"), testContainer("c2")}, nil))
+	v1, err := s.DBVersion(ctx)
+	require.NoError(t, err)
+
+	records, err := s.ListContainers(ctx, ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	// Syncing unchanged containers doesn't write anything.
+	require.NoError(t, s.SyncContainers(ctx, "m1", []api.ServiceContainer{testContainer("c1"), testContainer("c2")}, nil))
+	v2, err := s.DBVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, v1, v2)
+
+	// Deletes and upserts are applied in a single transaction.
+	require.NoError(t, s.SyncContainers(ctx, "m1", []api.ServiceContainer{testContainer("c3")}, []string{"c1", "c2"}))
+	v3, err := s.DBVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, v2+1, v3)
+
+	records, err = s.ListContainers(ctx, ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "c3", records[0].Container.ID)
+}
+
+func TestStore_SyncContainers_RemovesSensitiveData(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	s, _ := newSQLiteStore(t)
+
+	ctr := testContainer("c1")
+	ctr.Config.Env = []string{"DB_PASSWORD=secret"}
+	ctr.ServiceSpec = api.ServiceSpec{
+		Name:      "web",
+		Container: api.ContainerSpec{Env: api.EnvVars{"DB_PASSWORD": "secret"}},
+		Secrets: []api.SecretSpec{
+			{Name: "vault", Content: []byte("plain text")},
+			{Name: "sealed", Content: []byte("ciphertext"), SealingKeyID: "3f1c9a7e5b2d8c40"},
+			{Name: "cluster", ClusterSecret: "api_key", ClusterSecretVersion: 2},
+		},
+	}
+	require.NoError(t, s.SyncContainers(ctx, "m1", []api.ServiceContainer{ctr}, nil))
+
+	records, err := s.ListContainers(ctx, ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	stored := records[0].Container
+	assert.Nil(t, stored.Config.Env)
+	assert.Nil(t, stored.ServiceSpec.Container.Env)
+	assert.Equal(t, []api.SecretSpec{
+		{Name: "vault"},
+		{Name: "sealed", Content: []byte("ciphertext"), SealingKeyID: "3f1c9a7e5b2d8c40"},
+		{Name: "cluster", ClusterSecret: "api_key", ClusterSecretVersion: 2},
+	}, stored.ServiceSpec.Secrets)
+
+	// The caller's container is not modified.
+	assert.Equal(t, []byte("plain text"), ctr.ServiceSpec.Secrets[0].Content)
+}
+
+func TestStore_WatchContainers(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, _ := newSQLiteStore(t)
+
+	require.NoError(t, s.CreateOrUpdateContainer(ctx, testContainer("c0"), "m1"))
+
+	updates, err := s.WatchContainers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, receive(t, updates), 1, "first update should be the current list")
+
+	// A burst of changes is coalesced into updates that converge to the latest list.
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, s.CreateOrUpdateContainer(ctx, testContainer(fmt.Sprintf("c%d", i)), "m1"))
+	}
+	var records []ContainerRecord
+	for len(records) != 11 {
+		records = receive(t, updates)
+	}
+
+	cancel()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the updates channel to close after the context is done")
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Package throttle protects a machine from bursts of API requests, such as many CI jobs deploying to the cluster
+// at the same time. It limits the request rate of each client and the number of heavy operations that run
+// concurrently on the machine. Heavy operations over the limit wait in a queue instead of failing right away.
+package throttle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/api/proxy"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// localClient is the client key for requests from the machine itself or through the local Unix socket,
+	// e.g. from the CLI connected over SSH.
+	localClient = "local"
+	// clientIdleTimeout is how long the rate limiter of a client is kept after its last request.
+	clientIdleTimeout = 10 * time.Minute
+)
+
+// HeavyMethods are the API methods that consume significant CPU, memory, disk, or network resources on the machine.
+// Their concurrency is limited by Config.MaxHeavyOps.
+var HeavyMethods = []string{
+	pb.Docker_PullImage_FullMethodName,
+	pb.Docker_PruneImages_FullMethodName,
+	pb.Docker_CreateServiceContainer_FullMethodName,
+	pb.Docker_CreateVolumeSnapshot_FullMethodName,
+	pb.Docker_RestoreVolumeSnapshot_FullMethodName,
+}
+
+// Config configures the limits. Zero values disable the corresponding limit.
+type Config struct {
+	// RequestRate is the sustained number of API requests per second allowed from each client.
+	RequestRate float64
+	// RequestBurst is the number of API requests a client can send at once above the sustained rate.
+	RequestBurst int
+	// MaxHeavyOps is the maximum number of heavy operations running concurrently on the machine.
+	MaxHeavyOps int
+	// QueueTimeout is the maximum time a heavy operation waits in the queue before it's rejected.
+	// Zero means it waits until the request is canceled.
+	QueueTimeout time.Duration
+}
+
+// DefaultConfig is generous enough to not affect normal use of a cluster and only kicks in on request stampedes.
+var DefaultConfig = Config{
+	RequestRate:  100,
+	RequestBurst: 200,
+	MaxHeavyOps:  4,
+	QueueTimeout: 10 * time.Minute,
+}
+
+func (c Config) Validate() error {
+	if c.RequestRate < 0 {
+		return fmt.Errorf("request rate must not be negative")
+	}
+	if c.RequestRate > 0 && c.RequestBurst < 1 {
+		return fmt.Errorf("request burst must be at least 1 when the request rate is limited")
+	}
+	if c.MaxHeavyOps < 0 {
+		return fmt.Errorf("max heavy operations must not be negative")
+	}
+	if c.QueueTimeout < 0 {
+		return fmt.Errorf("queue timeout must not be negative")
+	}
+	return nil
+}
+
+// Limiter enforces the limits on the API requests served by the machine. Its methods are safe for concurrent use.
+type Limiter struct {
+	config Config
+	// slots holds a token for each running heavy operation. It's nil if the concurrency isn't limited.
+	slots chan struct{}
+	log   *slog.Logger
+
+	mu sync.Mutex
+	// clients are the rate limiters by client key.
+	clients map[string]*client
+	// queued is the number of heavy operations waiting for a slot.
+	queued    int
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+type client struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func NewLimiter(config Config) *Limiter {
+	l := &Limiter{
+		config:  config,
+		log:     slog.With("component", "api-throttle"),
+		clients: make(map[string]*client),
+		now:     time.Now,
+	}
+	if config.MaxHeavyOps > 0 {
+		l.slots = make(chan struct{}, config.MaxHeavyOps)
+	}
+	return l
+}
+
+// UnaryServerInterceptor rejects the requests over the client rate limit and queues the heavy operations over
+// the concurrency limit.
+func (l *Limiter) UnaryServerInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	release, err := l.admit(ctx, info.FullMethod, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects the streaming requests over the client rate limit and queues the heavy operations
+// over the concurrency limit. Image pulls waiting in the queue are notified with a progress message.
+func (l *Limiter) StreamServerInterceptor(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	var notify func(msg string)
+	if info.FullMethod == pb.Docker_PullImage_FullMethodName {
+		notify = func(msg string) {
+			// The progress message is best-effort so the error is ignored. The pull fails anyway if the stream
+			// is broken.
+			_ = sendPullProgress(ss, msg)
+		}
+	}
+
+	release, err := l.admit(ss.Context(), info.FullMethod, notify)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return handler(srv, ss)
+}
+
+// admit checks the rate limit of the client and waits for a slot if the method is a heavy operation. It returns
+// a function to release the slot when the request completes. notify, if not nil, is called with a message for
+// the client when the request is queued.
+func (l *Limiter) admit(ctx context.Context, method string, notify func(msg string)) (func(), error) {
+	client := clientKey(ctx)
+	if !l.allow(client) {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"server busy: too many API requests from client '%s', the limit is %g requests per second "+
+				"with bursts of %d, retry later", client, l.config.RequestRate, l.config.RequestBurst)
+	}
+
+	if l.slots == nil || !slices.Contains(HeavyMethods, method) {
+		return func() {}, nil
+	}
+
+	// Take a free slot without queueing if available.
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+
+	l.mu.Lock()
+	l.queued++
+	position := l.queued
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.queued--
+		l.mu.Unlock()
+	}()
+
+	msg := fmt.Sprintf("Server busy, queued: %d heavy operations are running on the machine, "+
+		"position in queue: %d.", l.config.MaxHeavyOps, position)
+	l.log.Info("Queued API request: machine is busy with heavy operations.",
+		"method", method, "client", client, "position", position)
+	if notify != nil {
+		notify(msg)
+	}
+
+	waitCtx := ctx
+	if l.config.QueueTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, l.config.QueueTimeout)
+		defer cancel()
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-waitCtx.Done():
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"server busy: the request was queued for %s but %d heavy operations (image pulls, container "+
+				"creations, volume snapshots) are still running on the machine, retry later",
+			l.config.QueueTimeout, l.config.MaxHeavyOps)
+	}
+}
+
+func (l *Limiter) release() {
+	<-l.slots
+}
+
+// allow returns true if the client hasn't exceeded its request rate limit.
+func (l *Limiter) allow(key string) bool {
+	if l.config.RequestRate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastPrune) > clientIdleTimeout {
+		for k, c := range l.clients {
+			if now.Sub(c.lastSeen) > clientIdleTimeout {
+				delete(l.clients, k)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{limiter: rate.NewLimiter(rate.Limit(l.config.RequestRate), l.config.RequestBurst)}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+	return c.limiter.AllowN(now, 1)
+}
+
+// clientKey returns the address of the client that sent the request recorded by the machine API proxy
+// or localClient if the request didn't go through the proxy.
+func clientKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if addr := md.Get(proxy.MetadataKeyClientAddr); len(addr) > 0 && addr[0] != "" {
+		return addr[0]
+	}
+	return localClient
+}
+
+// sendPullProgress sends a progress message to the image pull stream that is displayed by the client.
+func sendPullProgress(ss grpc.ServerStream, msg string) error {
+	msgJSON, err := json.Marshal(jsonmessage.JSONMessage{Status: msg})
+	if err != nil {
+		return err
+	}
+	return ss.SendMsg(&pb.JSONMessage{Message: msgJSON})
+}
@@ -0,0 +1,147 @@
+package throttle
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/api/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func clientCtx(addr string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(proxy.MetadataKeyClientAddr, addr))
+}
+
+func TestLimiter_RequestRate(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(Config{RequestRate: 1, RequestBurst: 2})
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: pb.Docker_ListContainers_FullMethodName}
+
+	for range 2 {
+		_, err := l.UnaryServerInterceptor(clientCtx("10.0.0.1"), nil, info, handler)
+		require.NoError(t, err)
+	}
+	_, err := l.UnaryServerInterceptor(clientCtx("10.0.0.1"), nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.ErrorContains(t, err, "server busy: too many API requests from client '10.0.0.1'")
+
+	// Other clients have their own limits.
+	_, err = l.UnaryServerInterceptor(clientCtx("10.0.0.2"), nil, info, handler)
+	require.NoError(t, err)
+	_, err = l.UnaryServerInterceptor(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+
+	// The tokens are refilled over time.
+	now = now.Add(time.Second)
+	_, err = l.UnaryServerInterceptor(clientCtx("10.0.0.1"), nil, info, handler)
+	require.NoError(t, err)
+}
+
+func TestLimiter_HeavyOps(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(Config{MaxHeavyOps: 1, QueueTimeout: 50 * time.Millisecond})
+	heavy := &grpc.UnaryServerInfo{FullMethod: pb.Docker_CreateServiceContainer_FullMethodName}
+	light := &grpc.UnaryServerInfo{FullMethod: pb.Docker_ListContainers_FullMethodName}
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_, _ = l.UnaryServerInterceptor(context.Background(), nil, heavy, func(context.Context, any) (any, error) {
+			close(started)
+			<-done
+			return nil, nil
+		})
+	}()
+	<-started
+
+	// Light requests aren't queued.
+	_, err := l.UnaryServerInterceptor(context.Background(), nil, light, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	// A heavy request is rejected after waiting in the queue for the timeout.
+	_, err = l.UnaryServerInterceptor(context.Background(), nil, heavy, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.ErrorContains(t, err, "server busy: the request was queued for 50ms")
+
+	// A queued request runs once the slot is released.
+	release := make(chan error)
+	go func() {
+		_, err := l.UnaryServerInterceptor(context.Background(), nil, heavy, func(context.Context, any) (any, error) {
+			return nil, nil
+		})
+		release <- err
+	}()
+	close(done)
+	require.NoError(t, <-release)
+}
+
+type recordingStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []any
+}
+
+func (s *recordingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recordingStream) SendMsg(m any) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestLimiter_PullImageQueued(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(Config{MaxHeavyOps: 1})
+	l.slots <- struct{}{}
+	ctx, cancel := context.WithCancel(context.Background())
+	ss := &recordingStream{ctx: ctx}
+	info := &grpc.StreamServerInfo{FullMethod: pb.Docker_PullImage_FullMethodName}
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- l.StreamServerInterceptor(nil, ss, info, func(any, grpc.ServerStream) error { return nil })
+	}()
+	// Release the slot after the pull is queued.
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.queued == 1
+	}, time.Second, time.Millisecond)
+	l.release()
+	require.NoError(t, <-errCh)
+	cancel()
+
+	require.Len(t, ss.sent, 1)
+	var msg jsonmessage.JSONMessage
+	require.NoError(t, json.Unmarshal(ss.sent[0].(*pb.JSONMessage).Message, &msg))
+	assert.Contains(t, msg.Status, "Server busy, queued")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, DefaultConfig.Validate())
+	require.NoError(t, Config{}.Validate())
+	assert.ErrorContains(t, Config{RequestRate: 10}.Validate(), "request burst")
+	assert.ErrorContains(t, Config{MaxHeavyOps: -1}.Validate(), "max heavy operations")
+}
@@ -0,0 +1,17 @@
+package vip
+
+import (
+	"errors"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+type NetlinkAddresses struct{}
+
+func (NetlinkAddresses) Assign(api.IngressVIP) error {
+	return errors.New("not implemented on darwin")
+}
+
+func (NetlinkAddresses) Release(api.IngressVIP) error {
+	return errors.New("not implemented on darwin")
+}
@@ -0,0 +1,120 @@
+package vip
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// NetlinkAddresses assigns the virtual IP to a network interface using netlink and announces it with
+// a gratuitous ARP.
+type NetlinkAddresses struct{}
+
+func (NetlinkAddresses) Assign(vip api.IngressVIP) error {
+	link, err := vipLink(vip)
+	if err != nil {
+		return err
+	}
+	if err = netlink.AddrReplace(link, vipAddr(vip)); err != nil {
+		return fmt.Errorf("add address to interface '%s': %w", link.Attrs().Name, err)
+	}
+	// Update the ARP caches of other hosts on the network so they send traffic to this machine right away.
+	if err = sendGratuitousARP(link, vip.Address.Addr()); err != nil {
+		return fmt.Errorf("send gratuitous ARP on interface '%s': %w", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+func (NetlinkAddresses) Release(vip api.IngressVIP) error {
+	link, err := vipLink(vip)
+	if err != nil {
+		return err
+	}
+	if err = netlink.AddrDel(link, vipAddr(vip)); err != nil && !errors.Is(err, unix.EADDRNOTAVAIL) {
+		return fmt.Errorf("remove address from interface '%s': %w", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+func vipAddr(vip api.IngressVIP) *netlink.Addr {
+	return &netlink.Addr{
+		IPNet: &net.IPNet{
+			IP:   vip.Address.Addr().AsSlice(),
+			Mask: net.CIDRMask(vip.Address.Bits(), 32),
+		},
+	}
+}
+
+// vipLink returns the configured interface or the interface with another address in the virtual IP network.
+func vipLink(vip api.IngressVIP) (netlink.Link, error) {
+	if vip.Interface != "" {
+		link, err := netlink.LinkByName(vip.Interface)
+		if err != nil {
+			return nil, fmt.Errorf("get interface '%s': %w", vip.Interface, err)
+		}
+		return link, nil
+	}
+
+	addrs, err := netlink.AddrList(nil, netlink.FAMILY_V4)
+	if err != nil {
+		return nil, fmt.Errorf("list addresses: %w", err)
+	}
+	network := vip.Address.Masked()
+	for _, a := range addrs {
+		ip, ok := netip.AddrFromSlice(a.IP.To4())
+		if !ok || ip == vip.Address.Addr() || !network.Contains(ip) {
+			continue
+		}
+		link, err := netlink.LinkByIndex(a.LinkIndex)
+		if err != nil {
+			return nil, fmt.Errorf("get interface with index %d: %w", a.LinkIndex, err)
+		}
+		return link, nil
+	}
+	return nil, fmt.Errorf("no interface with an address in network '%s' found, set the interface explicitly",
+		network)
+}
+
+// sendGratuitousARP broadcasts an ARP request for the IP from the interface so other hosts update their ARP caches.
+func sendGratuitousARP(link netlink.Link, ip netip.Addr) error {
+	mac := link.Attrs().HardwareAddr
+	if len(mac) != 6 {
+		// Not an Ethernet interface, nothing to announce.
+		return nil
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM, int(htons(unix.ETH_P_ARP)))
+	if err != nil {
+		return fmt.Errorf("create packet socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	ip4 := ip.As4()
+	packet := make([]byte, 28)
+	binary.BigEndian.PutUint16(packet[0:2], 1)             // Hardware type: Ethernet.
+	binary.BigEndian.PutUint16(packet[2:4], unix.ETH_P_IP) // Protocol type: IPv4.
+	packet[4] = 6                                          // Hardware address length.
+	packet[5] = 4                                          // Protocol address length.
+	binary.BigEndian.PutUint16(packet[6:8], 1)             // Operation: request.
+	copy(packet[8:14], mac)                                // Sender hardware address.
+	copy(packet[14:18], ip4[:])                            // Sender protocol address.
+	copy(packet[24:28], ip4[:])                            // Target protocol address.
+
+	addr := &unix.SockaddrLinklayer{
+		Protocol: htons(unix.ETH_P_ARP),
+		Ifindex:  link.Attrs().Index,
+		Halen:    6,
+		Addr:     [8]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	return unix.Sendto(fd, packet, 0, addr)
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
@@ -0,0 +1,187 @@
+package vip
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/caddyconfig"
+	"github.com/psviderski/uncloud/internal/machine/store"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CheckInterval is the default interval for checking which machine should hold the ingress virtual IP.
+const CheckInterval = 2 * time.Second
+
+// State reads the ingress virtual IP configuration and cluster membership and records the current holder.
+type State interface {
+	IngressVIP(ctx context.Context) (*api.IngressVIP, error)
+	IngressVIPStatus(ctx context.Context) (*api.IngressVIPStatus, error)
+	PutIngressVIPStatus(ctx context.Context, status *api.IngressVIPStatus) error
+	ListMachines(ctx context.Context, _ *emptypb.Empty) (*pb.ListMachinesResponse, error)
+}
+
+// Addresses assigns the virtual IP to and releases it from a network interface on the machine.
+type Addresses interface {
+	// Assign adds the address to the network interface and announces it to the local network.
+	Assign(vip api.IngressVIP) error
+	// Release removes the address from the network interface. It's a no-op if the address isn't assigned.
+	Release(vip api.IngressVIP) error
+}
+
+// Controller floats the ingress virtual IP among ingress machines. Every machine runs the controller and elects
+// the same holder from the replicated cluster state: the current holder while it's up and runs a healthy Caddy
+// container, otherwise the eligible ingress machine with the lowest ID. Only the holder assigns the address.
+type Controller struct {
+	machineID string
+	store     *store.Store
+	state     State
+	addresses Addresses
+	log       *slog.Logger
+	// checkInterval is the interval for checking which machine should hold the address.
+	checkInterval time.Duration
+	// assigned is the virtual IP currently assigned on this machine or nil if none.
+	assigned *api.IngressVIP
+}
+
+func NewController(
+	machineID string, store *store.Store, state State, addresses Addresses, checkInterval time.Duration,
+) *Controller {
+	return &Controller{
+		machineID:     machineID,
+		store:         store,
+		state:         state,
+		addresses:     addresses,
+		log:           slog.With("component", "vip-controller"),
+		checkInterval: checkInterval,
+	}
+}
+
+func (c *Controller) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.reconcile(ctx); err != nil {
+				c.log.Error("Failed to reconcile ingress virtual IP.", "err", err)
+			}
+		case <-ctx.Done():
+			// Release the address on shutdown so another machine can take it over without a conflict.
+			c.release()
+			return nil
+		}
+	}
+}
+
+func (c *Controller) reconcile(ctx context.Context) error {
+	vip, err := c.state.IngressVIP(ctx)
+	if err != nil {
+		return err
+	}
+	if c.assigned != nil && (vip == nil || *vip != *c.assigned) {
+		// The configuration has changed or been removed.
+		c.release()
+	}
+	if vip == nil {
+		return nil
+	}
+
+	resp, err := c.state.ListMachines(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("list machines: %w", err)
+	}
+	records, err := c.store.ListContainers(ctx, store.ListOptions{
+		ServiceIDOrName: store.ServiceIDOrNameOptions{Name: caddyconfig.CaddyServiceName},
+	})
+	if err != nil {
+		return fmt.Errorf("list caddy containers: %w", err)
+	}
+	caddyHealthy := make(map[string]bool)
+	for _, r := range records {
+		if r.Container.Healthy() {
+			caddyHealthy[r.MachineID] = true
+		}
+	}
+
+	current, err := c.state.IngressVIPStatus(ctx)
+	if err != nil {
+		return err
+	}
+	var currentID string
+	if current != nil {
+		currentID = current.MachineID
+	}
+
+	holder := electHolder(resp.Machines, caddyHealthy, currentID)
+	if holder != c.machineID {
+		c.release()
+		return nil
+	}
+
+	if c.assigned == nil {
+		if err = c.addresses.Assign(*vip); err != nil {
+			return fmt.Errorf("assign address '%s': %w", vip.Address, err)
+		}
+		c.assigned = vip
+		c.log.Info("Took over ingress virtual IP.", "address", vip.Address, "previous_holder", currentID)
+	}
+
+	if currentID != c.machineID {
+		var name string
+		if i := slices.IndexFunc(resp.Machines, func(m *pb.MachineMember) bool {
+			return m.Machine.Id == c.machineID
+		}); i != -1 {
+			name = resp.Machines[i].Machine.Name
+		}
+		status := &api.IngressVIPStatus{MachineID: c.machineID, MachineName: name, Since: time.Now().UTC()}
+		if err = c.state.PutIngressVIPStatus(ctx, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) release() {
+	if c.assigned == nil {
+		return
+	}
+	if err := c.addresses.Release(*c.assigned); err != nil {
+		c.log.Error("Failed to release ingress virtual IP.", "address", c.assigned.Address, "err", err)
+		return
+	}
+	c.log.Info("Released ingress virtual IP.", "address", c.assigned.Address)
+	c.assigned = nil
+}
+
+// electHolder returns the ID of the machine that should hold the virtual IP or an empty string if no machine is
+// eligible. A machine is eligible if it's up, runs a healthy Caddy container, and has the ingress role or no machine
+// in the cluster has the role. The current holder keeps the address while it's eligible to avoid needless failovers.
+func electHolder(machines []*pb.MachineMember, caddyHealthy map[string]bool, current string) string {
+	roleAssigned := slices.ContainsFunc(machines, func(m *pb.MachineMember) bool {
+		return api.HasMachineRole(m.Machine, api.MachineRoleIngress)
+	})
+
+	var eligible []string
+	for _, m := range machines {
+		if m.State != pb.MachineMember_UP || !caddyHealthy[m.Machine.Id] {
+			continue
+		}
+		if roleAssigned && !api.HasMachineRole(m.Machine, api.MachineRoleIngress) {
+			continue
+		}
+		eligible = append(eligible, m.Machine.Id)
+	}
+	if len(eligible) == 0 {
+		return ""
+	}
+	if slices.Contains(eligible, current) {
+		return current
+	}
+	return slices.Min(eligible)
+}
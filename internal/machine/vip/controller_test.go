@@ -0,0 +1,77 @@
+package vip
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElectHolder(t *testing.T) {
+	t.Parallel()
+
+	member := func(id string, state pb.MachineMember_MembershipState, roles ...string) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{Id: id, Name: id, Roles: roles}, State: state}
+	}
+
+	tests := []struct {
+		name         string
+		machines     []*pb.MachineMember
+		caddyHealthy map[string]bool
+		current      string
+		want         string
+	}{
+		{
+			name:         "lowest ID without ingress role",
+			machines:     []*pb.MachineMember{member("b", pb.MachineMember_UP), member("a", pb.MachineMember_UP)},
+			caddyHealthy: map[string]bool{"a": true, "b": true},
+			want:         "a",
+		},
+		{
+			name:         "current holder keeps address",
+			machines:     []*pb.MachineMember{member("a", pb.MachineMember_UP), member("b", pb.MachineMember_UP)},
+			caddyHealthy: map[string]bool{"a": true, "b": true},
+			current:      "b",
+			want:         "b",
+		},
+		{
+			name:         "failover when holder is down",
+			machines:     []*pb.MachineMember{member("a", pb.MachineMember_DOWN), member("b", pb.MachineMember_UP)},
+			caddyHealthy: map[string]bool{"a": true, "b": true},
+			current:      "a",
+			want:         "b",
+		},
+		{
+			name:         "failover when holder's caddy is unhealthy",
+			machines:     []*pb.MachineMember{member("a", pb.MachineMember_UP), member("b", pb.MachineMember_UP)},
+			caddyHealthy: map[string]bool{"b": true},
+			current:      "a",
+			want:         "b",
+		},
+		{
+			name: "only ingress machines",
+			machines: []*pb.MachineMember{
+				member("a", pb.MachineMember_UP, api.MachineRoleWorker),
+				member("b", pb.MachineMember_UP, api.MachineRoleIngress),
+				member("c", pb.MachineMember_UP, api.MachineRoleIngress),
+			},
+			caddyHealthy: map[string]bool{"a": true, "b": true, "c": true},
+			current:      "a",
+			want:         "b",
+		},
+		{
+			name:         "no eligible machines",
+			machines:     []*pb.MachineMember{member("a", pb.MachineMember_SUSPECT)},
+			caddyHealthy: map[string]bool{"a": true},
+			want:         "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, electHolder(tt.machines, tt.caddyHealthy, tt.current))
+		})
+	}
+}
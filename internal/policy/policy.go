@@ -0,0 +1,229 @@
+// Package policy implements admission policies that the machine daemon evaluates before creating service containers.
+// A policy can reject a service spec, for example, one that doesn't set resource limits, or mutate it, for example,
+// to set default resource limits. The daemon loads the policy rules from a YAML file on the machine. Custom daemons
+// can implement the Policy interface to plug in their own checks.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/go-units"
+	"github.com/goccy/go-yaml"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const (
+	// LimitCPU is the name of the CPU resource limit in the require_limits rule.
+	LimitCPU = "cpu"
+	// LimitMemory is the name of the memory resource limit in the require_limits rule.
+	LimitMemory = "memory"
+)
+
+// Policy admits, rejects, or mutates service specs before their containers are created.
+type Policy interface {
+	// Admit returns the spec to create the container from, which may be modified, or an error if the spec
+	// is rejected. It must not modify the passed spec in place.
+	Admit(spec api.ServiceSpec) (api.ServiceSpec, error)
+}
+
+// RejectedError is returned when a service spec violates one or more policy rules.
+type RejectedError struct {
+	// Violations are the descriptions of all violated rules.
+	Violations []string
+}
+
+func (e *RejectedError) Error() string {
+	return "rejected by admission policy: " + strings.Join(e.Violations, "; ")
+}
+
+// Rules is a declarative admission policy loaded from a YAML file. The defaults are applied before the checks so
+// that a default limit satisfies a required limit.
+type Rules struct {
+	// DefaultLimits are the resource limits set for services that don't specify them.
+	DefaultLimits DefaultLimits `yaml:"default_limits"`
+	// RequireLimits rejects services that don't set the listed resource limits: cpu, memory.
+	RequireLimits []string `yaml:"require_limits"`
+	// ForbidLatestTag rejects images with the 'latest' tag or without a tag or digest.
+	ForbidLatestTag bool `yaml:"forbid_latest_tag"`
+	// AllowedRegistries rejects images outside the listed registries or repository prefixes, for example,
+	// "ghcr.io/acme". Images without a registry are on "docker.io". Empty allows all images.
+	AllowedRegistries []string `yaml:"allowed_registries"`
+	// ForbidPrivileged rejects services that run privileged containers.
+	ForbidPrivileged bool `yaml:"forbid_privileged"`
+
+	// cpu and memory are the parsed default limits.
+	cpu    int64
+	memory int64
+}
+
+// DefaultLimits are the resource limits in the Compose format, for example, cpu: 0.5 and memory: 512M.
+type DefaultLimits struct {
+	CPU    string `yaml:"cpu"`
+	Memory string `yaml:"memory"`
+}
+
+// Parse parses and validates the policy rules from YAML. Unknown fields are rejected to catch typos that would
+// otherwise silently disable a rule.
+func Parse(data []byte) (*Rules, error) {
+	var r Rules
+	if err := yaml.UnmarshalWithOptions(data, &r, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("parse policy: %w", err)
+	}
+
+	if r.DefaultLimits.CPU != "" {
+		cpus, err := strconv.ParseFloat(r.DefaultLimits.CPU, 64)
+		if err != nil || cpus <= 0 {
+			return nil, fmt.Errorf("invalid default_limits.cpu '%s': must be a positive number of cores",
+				r.DefaultLimits.CPU)
+		}
+		r.cpu = int64(cpus * api.Core)
+	}
+	if r.DefaultLimits.Memory != "" {
+		memory, err := units.RAMInBytes(r.DefaultLimits.Memory)
+		if err != nil || memory <= 0 {
+			return nil, fmt.Errorf("invalid default_limits.memory '%s': must be a positive size such as 512M",
+				r.DefaultLimits.Memory)
+		}
+		r.memory = memory
+	}
+	for _, l := range r.RequireLimits {
+		if l != LimitCPU && l != LimitMemory {
+			return nil, fmt.Errorf("invalid require_limits entry '%s': must be '%s' or '%s'", l, LimitCPU, LimitMemory)
+		}
+	}
+	for i, reg := range r.AllowedRegistries {
+		reg = strings.TrimSuffix(reg, "/")
+		if reg == "" {
+			return nil, errors.New("invalid allowed_registries entry: must not be empty")
+		}
+		r.AllowedRegistries[i] = reg
+	}
+
+	return &r, nil
+}
+
+// Admit applies the default limits to the spec and checks it against all rules. All violations are reported
+// at once.
+func (r *Rules) Admit(spec api.ServiceSpec) (api.ServiceSpec, error) {
+	res := &spec.Container.Resources
+	if r.cpu > 0 && res.CPU == 0 {
+		res.CPU = r.cpu
+	}
+	if r.memory > 0 && res.Memory == 0 {
+		res.Memory = r.memory
+	}
+
+	var violations []string
+	if slices.Contains(r.RequireLimits, LimitCPU) && res.CPU == 0 {
+		violations = append(violations, "require_limits: CPU limit must be set")
+	}
+	if slices.Contains(r.RequireLimits, LimitMemory) && res.Memory == 0 {
+		violations = append(violations, "require_limits: memory limit must be set")
+	}
+	if r.ForbidPrivileged && spec.Container.Privileged {
+		violations = append(violations, "forbid_privileged: privileged containers are not allowed")
+	}
+
+	if r.ForbidLatestTag || len(r.AllowedRegistries) > 0 {
+		named, err := reference.ParseNormalizedNamed(spec.Container.Image)
+		if err != nil {
+			return spec, fmt.Errorf("invalid image reference '%s': %w", spec.Container.Image, err)
+		}
+		if r.ForbidLatestTag && usesLatestTag(named) {
+			violations = append(violations, fmt.Sprintf(
+				"forbid_latest_tag: image '%s' must be pinned to a tag other than 'latest' or a digest",
+				spec.Container.Image))
+		}
+		if len(r.AllowedRegistries) > 0 && !r.registryAllowed(named) {
+			violations = append(violations, fmt.Sprintf(
+				"allowed_registries: image '%s' is not from an allowed registry: %s",
+				spec.Container.Image, strings.Join(r.AllowedRegistries, ", ")))
+		}
+	}
+
+	if len(violations) > 0 {
+		return spec, &RejectedError{Violations: violations}
+	}
+	return spec, nil
+}
+
+func usesLatestTag(named reference.Named) bool {
+	if _, ok := named.(reference.Digested); ok {
+		return false
+	}
+	tagged, ok := named.(reference.Tagged)
+	return !ok || tagged.Tag() == "latest"
+}
+
+func (r *Rules) registryAllowed(named reference.Named) bool {
+	name := named.Name()
+	for _, reg := range r.AllowedRegistries {
+		if name == reg || strings.HasPrefix(name, reg+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// File is a policy that evaluates the rules from a YAML file. The file is reloaded when it changes so that
+// the policy can be updated without restarting the daemon. All specs are admitted unchanged if the file doesn't exist.
+type File struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	rules   *Rules
+}
+
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Admit evaluates the current rules from the file. An invalid policy file rejects all specs rather than silently
+// admitting them.
+func (f *File) Admit(spec api.ServiceSpec) (api.ServiceSpec, error) {
+	rules, err := f.load()
+	if err != nil {
+		return spec, err
+	}
+	if rules == nil {
+		return spec, nil
+	}
+	return rules.Admit(spec)
+}
+
+func (f *File) load() (*Rules, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			f.rules, f.modTime = nil, time.Time{}
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat policy file: %w", err)
+	}
+	if f.rules != nil && info.ModTime().Equal(f.modTime) {
+		return f.rules, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file: %w", err)
+	}
+	rules, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("policy file '%s': %w", f.path, err)
+	}
+	f.rules, f.modTime = rules, info.ModTime()
+	return rules, nil
+}
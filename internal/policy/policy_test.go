@@ -0,0 +1,166 @@
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "empty", data: ""},
+		{
+			name: "all rules",
+			data: `
+default_limits:
+  cpu: 0.5
+  memory: 512M
+require_limits: [cpu, memory]
+forbid_latest_tag: true
+allowed_registries: [ghcr.io/acme/]
+forbid_privileged: true
+`,
+		},
+		{name: "unknown field", data: "forbid_latest_tags: true", wantErr: "unknown field"},
+		{name: "invalid cpu", data: "default_limits: {cpu: half}", wantErr: "invalid default_limits.cpu"},
+		{name: "invalid memory", data: "default_limits: {memory: lots}", wantErr: "invalid default_limits.memory"},
+		{name: "invalid limit", data: "require_limits: [gpu]", wantErr: "invalid require_limits entry 'gpu'"},
+		{name: "empty registry", data: "allowed_registries: ['']", wantErr: "invalid allowed_registries entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse([]byte(tt.data))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRulesAdmit(t *testing.T) {
+	t.Parallel()
+
+	rules, err := Parse([]byte(`
+default_limits:
+  memory: 256M
+require_limits: [cpu, memory]
+forbid_latest_tag: true
+allowed_registries: [ghcr.io/acme, docker.io/library]
+forbid_privileged: true
+`))
+	require.NoError(t, err)
+
+	t.Run("admitted with default limits", func(t *testing.T) {
+		t.Parallel()
+
+		spec := api.ServiceSpec{Name: "web", Container: api.ContainerSpec{
+			Image:     "ghcr.io/acme/web:1.2",
+			Resources: api.ContainerResources{CPU: api.Core},
+		}}
+		admitted, err := rules.Admit(spec)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(256*1024*1024), admitted.Container.Resources.Memory)
+		assert.Equal(t, int64(api.Core), admitted.Container.Resources.CPU)
+		assert.Zero(t, spec.Container.Resources.Memory, "original spec must not be modified")
+	})
+
+	t.Run("explicit limits are kept", func(t *testing.T) {
+		t.Parallel()
+
+		spec := api.ServiceSpec{Name: "web", Container: api.ContainerSpec{
+			Image:     "nginx@sha256:" + "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2",
+			Resources: api.ContainerResources{CPU: api.Core, Memory: 1024},
+		}}
+		admitted, err := rules.Admit(spec)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1024), admitted.Container.Resources.Memory)
+	})
+
+	t.Run("all violations reported", func(t *testing.T) {
+		t.Parallel()
+
+		spec := api.ServiceSpec{Name: "web", Container: api.ContainerSpec{
+			Image:      "quay.io/other/web",
+			Privileged: true,
+		}}
+		_, err := rules.Admit(spec)
+
+		rejected, ok := errors.AsType[*RejectedError](err)
+		require.True(t, ok, "expected RejectedError, got %v", err)
+		assert.Equal(t, []string{
+			"require_limits: CPU limit must be set",
+			"forbid_privileged: privileged containers are not allowed",
+			"forbid_latest_tag: image 'quay.io/other/web' must be pinned to a tag other than 'latest' or a digest",
+			"allowed_registries: image 'quay.io/other/web' is not from an allowed registry: " +
+				"ghcr.io/acme, docker.io/library",
+		}, rejected.Violations)
+	})
+
+	t.Run("latest tag", func(t *testing.T) {
+		t.Parallel()
+
+		spec := api.ServiceSpec{Name: "web", Container: api.ContainerSpec{
+			Image:     "nginx:latest",
+			Resources: api.ContainerResources{CPU: api.Core},
+		}}
+		_, err := rules.Admit(spec)
+		assert.ErrorContains(t, err, "forbid_latest_tag: image 'nginx:latest'")
+	})
+
+	t.Run("registry prefix must match whole path segments", func(t *testing.T) {
+		t.Parallel()
+
+		spec := api.ServiceSpec{Name: "web", Container: api.ContainerSpec{
+			Image:     "ghcr.io/acme-evil/web:1.0",
+			Resources: api.ContainerResources{CPU: api.Core},
+		}}
+		_, err := rules.Admit(spec)
+		assert.ErrorContains(t, err, "allowed_registries")
+	})
+}
+
+func TestFileAdmit(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	f := NewFile(path)
+	spec := api.ServiceSpec{Name: "web", Container: api.ContainerSpec{Image: "nginx"}}
+
+	// A missing file admits all specs.
+	_, err := f.Admit(spec)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("forbid_latest_tag: true"), 0o600))
+	_, err = f.Admit(spec)
+	assert.ErrorContains(t, err, "forbid_latest_tag")
+
+	// The file is reloaded when it changes.
+	require.NoError(t, os.WriteFile(path, []byte("forbid_privileged: true"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Time{}, time.Now().Add(time.Minute)))
+	_, err = f.Admit(spec)
+	require.NoError(t, err)
+
+	// An invalid file rejects all specs.
+	require.NoError(t, os.WriteFile(path, []byte("forbid_privileged: yes please"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Time{}, time.Now().Add(2*time.Minute)))
+	_, err = f.Admit(spec)
+	assert.ErrorContains(t, err, "policy file")
+}
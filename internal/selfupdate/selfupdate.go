@@ -0,0 +1,320 @@
+// Package selfupdate downloads Uncloud CLI releases from GitHub, verifies their checksums, and replaces
+// the running binary with the new one.
+package selfupdate
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+const (
+	// ChannelStable is the channel of regular releases.
+	ChannelStable = "stable"
+	// ChannelBeta is the channel of pre-releases, such as release candidates. It also includes stable releases
+	// if they are newer than the latest pre-release.
+	ChannelBeta = "beta"
+
+	DefaultRepo   = "psviderski/uncloud"
+	DefaultAPIURL = "https://api.github.com"
+
+	// ChecksumsFile is the name of the release asset with SHA-256 checksums of all archives.
+	ChecksumsFile = "checksums.txt"
+	// BinaryName is the name of the CLI binary inside the release archive.
+	BinaryName = "uncloud"
+
+	// nightlyTag is a rolling pre-release that is rebuilt on every commit. It's not part of any channel.
+	nightlyTag = "nightly"
+	// maxBinarySize limits the size of the extracted binary to protect against decompression bombs.
+	maxBinarySize = 500 << 20
+)
+
+// Channels is the list of supported release channels.
+var Channels = []string{ChannelStable, ChannelBeta}
+
+// Release is a published CLI release.
+type Release struct {
+	// Version is the semver version without the 'v' prefix.
+	Version string
+	Tag     string
+	// Assets maps asset names to their download URLs.
+	Assets map[string]string
+}
+
+// ArchiveName returns the name of the release archive with the CLI binary for the given platform.
+func ArchiveName(goos, goarch string) string {
+	if goos == "darwin" {
+		goos = "macos"
+	}
+	return fmt.Sprintf("%s_%s_%s.tar.gz", BinaryName, goos, goarch)
+}
+
+// CurrentArchiveName returns the name of the release archive for the platform the CLI is running on.
+func CurrentArchiveName() string {
+	return ArchiveName(runtime.GOOS, runtime.GOARCH)
+}
+
+// Client fetches releases from GitHub.
+type Client struct {
+	HTTPClient *http.Client
+	// APIURL is the base URL of the GitHub API.
+	APIURL string
+	// Repo is the GitHub repository in the 'owner/name' format.
+	Repo string
+}
+
+// NewClient returns a client for the official Uncloud releases.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		APIURL:     DefaultAPIURL,
+		Repo:       DefaultRepo,
+	}
+}
+
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// LatestRelease returns the latest release in the channel.
+func (c *Client) LatestRelease(ctx context.Context, channel string) (*Release, error) {
+	if !slices.Contains(Channels, channel) {
+		return nil, fmt.Errorf("unsupported channel '%s', supported values: %v", channel, Channels)
+	}
+
+	// The releases are sorted by creation date, newest first. The first page is enough to find the latest
+	// stable release and pre-release.
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=50", c.APIURL, c.Repo)
+	var releases []githubRelease
+	if err := c.getJSON(ctx, url, &releases); err != nil {
+		return nil, fmt.Errorf("list releases: %w", err)
+	}
+
+	var (
+		latest        *Release
+		latestVersion *semver.Version
+	)
+	for _, r := range releases {
+		if r.Draft || r.TagName == nightlyTag || (r.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+		v, err := semver.NewVersion(r.TagName)
+		if err != nil {
+			continue
+		}
+		if latestVersion != nil && !v.GreaterThan(latestVersion) {
+			continue
+		}
+
+		latest = r.toRelease(v)
+		latestVersion = v
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no releases found in the '%s' channel", channel)
+	}
+	return latest, nil
+}
+
+// Release returns the release with the given tag, for example, 'v0.9.0'.
+func (c *Client) Release(ctx context.Context, tag string) (*Release, error) {
+	v, err := semver.NewVersion(tag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release version '%s': %w", tag, err)
+	}
+	if !strings.HasPrefix(tag, "v") {
+		tag = "v" + tag
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/releases/tags/%s", c.APIURL, c.Repo, tag)
+	var r githubRelease
+	if err = c.getJSON(ctx, url, &r); err != nil {
+		return nil, fmt.Errorf("get release %s: %w", tag, err)
+	}
+	return r.toRelease(v), nil
+}
+
+func (r githubRelease) toRelease(v *semver.Version) *Release {
+	assets := make(map[string]string, len(r.Assets))
+	for _, a := range r.Assets {
+		assets[a.Name] = a.BrowserDownloadURL
+	}
+	return &Release{Version: v.String(), Tag: r.TagName, Assets: assets}
+}
+
+// Download downloads the release asset with the given name.
+func (c *Client) Download(ctx context.Context, release *Release, asset string) ([]byte, error) {
+	url, ok := release.Assets[asset]
+	if !ok {
+		return nil, fmt.Errorf("release %s doesn't have asset '%s'", release.Tag, asset)
+	}
+
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("download '%s': %w", asset, err)
+	}
+	return data, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, v any) error {
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode response from '%s': %w", url, err)
+	}
+	return nil
+}
+
+func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code from '%s': %s", url, resp.Status)
+	}
+	return resp, nil
+}
+
+// VerifyChecksum verifies the SHA-256 checksum of the named file using the checksums file in the format
+// produced by sha256sum.
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	var want string
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// sha256sum marks files read in binary mode with a '*' prefix.
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			want = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read checksums: %w", err)
+	}
+	if want == "" {
+		return fmt.Errorf("checksum for '%s' not found", name)
+	}
+
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for '%s': expected %s, got %s", name, want, got)
+	}
+	return nil
+}
+
+// ExtractBinary returns the CLI binary from the gzipped tar release archive.
+func ExtractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("read gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("binary '%s' not found in archive", BinaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read tar archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || filepath.Base(hdr.Name) != BinaryName {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tr, maxBinarySize+1))
+		if err != nil {
+			return nil, fmt.Errorf("read binary from archive: %w", err)
+		}
+		if len(data) > maxBinarySize {
+			return nil, fmt.Errorf("binary in archive is larger than %d bytes", maxBinarySize)
+		}
+		return data, nil
+	}
+}
+
+// IsArchive returns true if the data looks like a gzip archive rather than a binary.
+func IsArchive(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// ExecutablePath returns the resolved path to the running binary. The 'uc' command is usually a symlink
+// to the 'uncloud' binary, so the symlinks are resolved to replace the actual binary.
+func ExecutablePath() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(path)
+}
+
+// Replace atomically replaces the binary at path with the new binary keeping its file mode. The new binary is
+// written to a temporary file in the same directory and renamed over the old one, so a failed update never
+// leaves a partially written binary behind.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err = tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+	if err = os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("set file mode: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("replace binary: %w", err)
+	}
+	return nil
+}
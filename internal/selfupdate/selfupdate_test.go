@@ -0,0 +1,148 @@
+package selfupdate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "uncloud_macos_arm64.tar.gz", ArchiveName("darwin", "arm64"))
+	assert.Equal(t, "uncloud_linux_amd64.tar.gz", ArchiveName("linux", "amd64"))
+}
+
+func TestLatestRelease(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/psviderski/uncloud/releases", r.URL.Path)
+		fmt.Fprint(w, `[
+			{"tag_name": "nightly", "prerelease": true},
+			{"tag_name": "v0.23.0", "draft": true},
+			{"tag_name": "v0.22.0-rc.1", "prerelease": true,
+			 "assets": [{"name": "checksums.txt", "browser_download_url": "https://example.com/rc/checksums.txt"}]},
+			{"tag_name": "v0.21.1",
+			 "assets": [{"name": "checksums.txt", "browser_download_url": "https://example.com/checksums.txt"}]},
+			{"tag_name": "v0.21.0"}
+		]`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Client{HTTPClient: srv.Client(), APIURL: srv.URL, Repo: DefaultRepo}
+
+	r, err := c.LatestRelease(context.Background(), ChannelStable)
+	require.NoError(t, err)
+	assert.Equal(t, "0.21.1", r.Version)
+	assert.Equal(t, "v0.21.1", r.Tag)
+	assert.Equal(t, "https://example.com/checksums.txt", r.Assets[ChecksumsFile])
+
+	r, err = c.LatestRelease(context.Background(), ChannelBeta)
+	require.NoError(t, err)
+	assert.Equal(t, "0.22.0-rc.1", r.Version)
+
+	_, err = c.LatestRelease(context.Background(), "nightly")
+	assert.ErrorContains(t, err, "unsupported channel")
+}
+
+func TestRelease(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/psviderski/uncloud/releases/tags/v0.21.0", r.URL.Path)
+		fmt.Fprint(w, `{"tag_name": "v0.21.0",
+			"assets": [{"name": "checksums.txt", "browser_download_url": "https://example.com/checksums.txt"}]}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Client{HTTPClient: srv.Client(), APIURL: srv.URL, Repo: DefaultRepo}
+
+	r, err := c.Release(context.Background(), "0.21.0")
+	require.NoError(t, err)
+	assert.Equal(t, "0.21.0", r.Version)
+	assert.Equal(t, "v0.21.0", r.Tag)
+	assert.Equal(t, "https://example.com/checksums.txt", r.Assets[ChecksumsFile])
+
+	_, err = c.Release(context.Background(), "latest")
+	assert.ErrorContains(t, err, "invalid release version")
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("archive")
+	sum := sha256.Sum256(data)
+	checksums := []byte(fmt.Sprintf("%s  uncloud_linux_arm64.tar.gz\n%s *uncloud_linux_amd64.tar.gz\n",
+		"0000", hex.EncodeToString(sum[:])))
+
+	assert.NoError(t, VerifyChecksum(checksums, "uncloud_linux_amd64.tar.gz", data))
+	assert.ErrorContains(t, VerifyChecksum(checksums, "uncloud_linux_arm64.tar.gz", data), "checksum mismatch")
+	assert.ErrorContains(t, VerifyChecksum(checksums, "uncloud_macos_arm64.tar.gz", data), "not found")
+}
+
+func tarGz(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestExtractBinary(t *testing.T) {
+	t.Parallel()
+
+	archive := tarGz(t, map[string][]byte{"README.md": []byte("readme"), "uncloud": []byte("binary")})
+	assert.True(t, IsArchive(archive))
+
+	binary, err := ExtractBinary(archive)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("binary"), binary)
+	assert.False(t, IsArchive(binary))
+
+	_, err = ExtractBinary(tarGz(t, map[string][]byte{"uncloudd": []byte("daemon")}))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestReplace(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "uncloud")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o750))
+
+	require.NoError(t, Replace(path, []byte("new")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), data)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o750), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file must be removed")
+}
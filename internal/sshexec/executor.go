@@ -10,6 +10,8 @@ import (
 type Executor interface {
 	Run(ctx context.Context, cmd string) (string, error)
 	Stream(ctx context.Context, cmd string, stdout, stderr io.Writer) error
+	// RunWithInput runs the command with stdin read from the reader and returns its output.
+	RunWithInput(ctx context.Context, cmd string, stdin io.Reader) (string, error)
 	Close() error
 }
 
@@ -20,6 +20,12 @@ func NewRemote(client *ssh.Client) *Remote {
 // Run runs the command on the remote host and returns its output with all leading and trailing
 // white space removed.
 func (r *Remote) Run(ctx context.Context, cmd string) (string, error) {
+	return r.RunWithInput(ctx, cmd, nil)
+}
+
+// RunWithInput runs the command on the remote host with stdin read from the reader and returns its output
+// with all leading and trailing white space removed.
+func (r *Remote) RunWithInput(ctx context.Context, cmd string, stdin io.Reader) (string, error) {
 	session, err := r.client.NewSession()
 	if err != nil {
 		return "", fmt.Errorf("create session: %w", err)
@@ -28,6 +34,7 @@ func (r *Remote) Run(ctx context.Context, cmd string) (string, error) {
 		_ = session.Close()
 	}()
 
+	session.Stdin = stdin
 	// Run the command in a goroutine to be able to cancel it.
 	type result struct {
 		out string
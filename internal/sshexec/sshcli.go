@@ -61,8 +61,12 @@ func (r *SSHCLIRemote) newSSHCommand(ctx context.Context, cmd string) *exec.Cmd
 }
 
 func (r *SSHCLIRemote) Run(ctx context.Context, cmd string) (string, error) {
+	return r.RunWithInput(ctx, cmd, nil)
+}
+
+func (r *SSHCLIRemote) RunWithInput(ctx context.Context, cmd string, stdin io.Reader) (string, error) {
 	var stdout, stderr bytes.Buffer
-	err := r.Stream(ctx, cmd, &stdout, &stderr)
+	err := r.stream(ctx, cmd, stdin, &stdout, &stderr)
 	out := strings.TrimSpace(stdout.String())
 	if err != nil {
 		return out, fmt.Errorf("%w: %s", err, stderr.String())
@@ -71,7 +75,12 @@ func (r *SSHCLIRemote) Run(ctx context.Context, cmd string) (string, error) {
 }
 
 func (r *SSHCLIRemote) Stream(ctx context.Context, cmd string, stdout, stderr io.Writer) error {
+	return r.stream(ctx, cmd, nil, stdout, stderr)
+}
+
+func (r *SSHCLIRemote) stream(ctx context.Context, cmd string, stdin io.Reader, stdout, stderr io.Writer) error {
 	sshCmd := r.newSSHCommand(ctx, cmd)
+	sshCmd.Stdin = stdin
 	sshCmd.Stdout = stdout
 	sshCmd.Stderr = stderr
 
@@ -0,0 +1,125 @@
+// Package systemd generates the systemd unit for the Uncloud machine daemon.
+package systemd
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/log"
+)
+
+const (
+	// DaemonUnitName is the name of the systemd unit that runs the machine daemon.
+	DaemonUnitName = "uncloud.service"
+	// DefaultUnitDir is the directory for unit files installed by the system administrator.
+	DefaultUnitDir = "/etc/systemd/system"
+	// DefaultWatchdog is how long systemd waits for a watchdog ping from the daemon before restarting it.
+	DefaultWatchdog = 30 * time.Second
+	// DefaultRestartDelay is how long systemd waits before restarting the daemon after it exits.
+	DefaultRestartDelay = 2 * time.Second
+)
+
+// UnitOptions configures the generated daemon unit.
+type UnitOptions struct {
+	// BinPath is the path to the uncloudd binary.
+	BinPath string
+	// DataDir is the data directory passed to the daemon. Empty uses the daemon's default.
+	DataDir string
+	// Watchdog is the WatchdogSec value. The daemon pings systemd at half this interval and systemd restarts
+	// the daemon if it stops responding. 0 disables the watchdog.
+	Watchdog time.Duration
+	// RestartDelay is the RestartSec value.
+	RestartDelay time.Duration
+	// LogFormat is the format of the daemon logs in the journal: log.FormatText or log.FormatJSON.
+	// Empty uses the daemon's default text format.
+	LogFormat string
+}
+
+// The unit matches the one created by scripts/install.sh with stricter sandboxing and the watchdog enabled.
+// The daemon needs root to manage WireGuard interfaces, iptables rules, and Docker, so it can't drop privileges
+// or use ProtectSystem=strict. Instead, the options below restrict what a compromised daemon could change.
+var unitTemplate = template.Must(template.New("unit").Parse(`[Unit]
+Description=Uncloud machine daemon
+Documentation=https://uncloud.run/docs
+After=network-online.target docker.service
+Wants=network-online.target
+
+[Service]
+Type=notify
+NotifyAccess=main
+ExecStart={{.ExecStart}}
+TimeoutStartSec=15
+Restart=always
+RestartSec={{.RestartSec}}
+{{- if .WatchdogSec}}
+WatchdogSec={{.WatchdogSec}}
+{{- end}}
+
+# Hardening options.
+NoNewPrivileges=true
+ProtectSystem=full
+ProtectHome=read-only
+ProtectControlGroups=true
+ProtectKernelTunables=true
+ProtectKernelLogs=true
+ProtectClock=true
+PrivateTmp=true
+RestrictAddressFamilies=AF_INET AF_INET6 AF_UNIX AF_NETLINK
+RestrictNamespaces=true
+RestrictRealtime=true
+RestrictSUIDSGID=true
+LockPersonality=true
+SystemCallArchitectures=native
+
+[Install]
+WantedBy=multi-user.target
+`))
+
+// DaemonUnit returns the content of the systemd unit file for the machine daemon.
+func DaemonUnit(opts UnitOptions) (string, error) {
+	if opts.BinPath == "" {
+		return "", fmt.Errorf("path to uncloudd binary must be set")
+	}
+	if opts.Watchdog < 0 || (opts.Watchdog > 0 && opts.Watchdog < 2*time.Second) {
+		return "", fmt.Errorf("watchdog timeout must be 0 to disable it or at least 2s: %s", opts.Watchdog)
+	}
+	if opts.RestartDelay < 0 {
+		return "", fmt.Errorf("restart delay must not be negative: %s", opts.RestartDelay)
+	}
+	if opts.LogFormat != "" && opts.LogFormat != log.FormatText && opts.LogFormat != log.FormatJSON {
+		return "", fmt.Errorf("log format must be '%s' or '%s': %s", log.FormatText, log.FormatJSON, opts.LogFormat)
+	}
+
+	execStart := opts.BinPath
+	if opts.DataDir != "" {
+		execStart += " --data-dir " + opts.DataDir
+	}
+	if opts.LogFormat == log.FormatJSON {
+		execStart += " --log-format " + opts.LogFormat
+	}
+
+	var buf bytes.Buffer
+	err := unitTemplate.Execute(&buf, struct {
+		ExecStart   string
+		RestartSec  string
+		WatchdogSec string
+	}{
+		ExecStart:   execStart,
+		RestartSec:  seconds(opts.RestartDelay),
+		WatchdogSec: seconds(opts.Watchdog),
+	})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// seconds formats the duration as whole seconds for systemd. It returns an empty string for 0.
+func seconds(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", int(d.Round(time.Second).Seconds()))
+}
@@ -0,0 +1,52 @@
+package systemd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemonUnit(t *testing.T) {
+	t.Parallel()
+
+	unit, err := DaemonUnit(UnitOptions{
+		BinPath:      "/usr/local/bin/uncloudd",
+		Watchdog:     DefaultWatchdog,
+		RestartDelay: DefaultRestartDelay,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, unit, "Type=notify\n")
+	assert.Contains(t, unit, "ExecStart=/usr/local/bin/uncloudd\n")
+	assert.Contains(t, unit, "RestartSec=2\n")
+	assert.Contains(t, unit, "WatchdogSec=30\n")
+	assert.Contains(t, unit, "NoNewPrivileges=true\n")
+
+	unit, err = DaemonUnit(UnitOptions{
+		BinPath:      "/opt/uncloudd",
+		DataDir:      "/data/uncloud",
+		RestartDelay: 1500 * time.Millisecond,
+		LogFormat:    "json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, unit, "ExecStart=/opt/uncloudd --data-dir /data/uncloud --log-format json\n")
+	assert.Contains(t, unit, "RestartSec=2\n")
+	assert.NotContains(t, unit, "WatchdogSec")
+}
+
+func TestDaemonUnit_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]UnitOptions{
+		"missing binary":     {},
+		"negative watchdog":  {BinPath: "/usr/local/bin/uncloudd", Watchdog: -time.Second},
+		"too short watchdog": {BinPath: "/usr/local/bin/uncloudd", Watchdog: time.Second},
+		"negative restart":   {BinPath: "/usr/local/bin/uncloudd", RestartDelay: -time.Second},
+		"invalid log format": {BinPath: "/usr/local/bin/uncloudd", LogFormat: "xml"},
+	}
+	for name, opts := range tests {
+		_, err := DaemonUnit(opts)
+		assert.Error(t, err, name)
+	}
+}
@@ -33,6 +33,10 @@ type CreateClusterOptions struct {
 	Machines int
 	// Ports to forward from the cluster machines to the host.
 	PortMap nat.PortMap
+	// FaultInjection runs the machine daemons with --unsafe-fault-injection.
+	FaultInjection bool
+	// Image is the Docker image for the machines. Default is DefaultImage.
+	Image string
 }
 
 func (c *Cluster) PopulateMachineIDs(ctx context.Context) error {
@@ -84,8 +88,10 @@ func (p *Provisioner) CreateCluster(ctx context.Context, name string, opts Creat
 	// Create machines (containers) in the created cluster network.
 	for i := 1; i < opts.Machines+1; i++ {
 		mopts := CreateMachineOptions{
-			Name:    fmt.Sprintf("machine-%d", i),
-			PortMap: opts.PortMap,
+			Name:           fmt.Sprintf("machine-%d", i),
+			Image:          opts.Image,
+			PortMap:        opts.PortMap,
+			FaultInjection: opts.FaultInjection,
 		}
 		m, err := p.CreateMachine(ctx, name, mopts)
 		if err != nil {
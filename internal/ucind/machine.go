@@ -43,6 +43,8 @@ type CreateMachineOptions struct {
 	Image string
 	// Ports to forward from the machine to the host.
 	PortMap nat.PortMap
+	// FaultInjection runs the machine daemon with --unsafe-fault-injection.
+	FaultInjection bool
 }
 
 func (p *Provisioner) CreateMachine(ctx context.Context, clusterName string, opts CreateMachineOptions) (Machine, error) {
@@ -84,6 +86,9 @@ func (p *Provisioner) CreateMachine(ctx context.Context, clusterName string, opt
 			apiPort: struct{}{},
 		},
 	}
+	if opts.FaultInjection {
+		config.Cmd = []string{"uncloudd", "--unsafe-fault-injection"}
+	}
 	hostConfig := &container.HostConfig{
 		NetworkMode: container.NetworkMode(clusterName),
 		PortBindings: nat.PortMap{
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ACMEDNSProviders lists the DNS providers with well-known credential options. Other providers from
+// https://github.com/caddy-dns can be used too as long as the Caddy image includes their module.
+var ACMEDNSProviders = map[string][]string{
+	"cloudflare":   {"api_token"},
+	"digitalocean": {"auth_token"},
+	"duckdns":      {"api_token"},
+	"gandi":        {"bearer_token"},
+	"hetzner":      {"api_token"},
+	"porkbun":      {"api_key", "api_secret_key"},
+	"route53":      {"access_key_id", "secret_access_key", "region"},
+}
+
+// acmeDNSNameRegex matches provider names and credential options. They're used as Caddyfile tokens and file names
+// so only simple identifiers are allowed.
+var acmeDNSNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+
+// ACMEDNS configures Caddy on ingress machines to obtain certificates using the ACME DNS-01 challenge. The challenge
+// doesn't require machines to be reachable on port 80 and allows to issue wildcard certificates.
+type ACMEDNS struct {
+	// Provider is the name of the DNS provider module in Caddy, e.g. cloudflare or route53.
+	Provider string `json:"provider"`
+	// Credentials maps the provider options to sealed secret values. Only the cluster machines can decrypt them.
+	Credentials map[string]string `json:"credentials,omitempty"`
+	// Domains limits the DNS-01 challenge to certificates for these domains and their subdomains. A wildcard domain
+	// such as *.example.com also issues a single wildcard certificate for all its direct subdomains. If empty,
+	// the challenge is used for all HTTPS sites.
+	Domains []string `json:"domains,omitempty"`
+	// Resolvers are the DNS resolvers used to check that the challenge records have propagated.
+	Resolvers []string `json:"resolvers,omitempty"`
+}
+
+func (a *ACMEDNS) Validate() error {
+	if !acmeDNSNameRegex.MatchString(a.Provider) {
+		return fmt.Errorf("invalid provider '%s': must contain only lowercase letters, digits, and underscores",
+			a.Provider)
+	}
+	for opt, value := range a.Credentials {
+		if !acmeDNSNameRegex.MatchString(opt) {
+			return fmt.Errorf("invalid credential option '%s': must contain only lowercase letters, digits, "+
+				"and underscores", opt)
+		}
+		if !IsSealedSecret(value) {
+			return fmt.Errorf("credential '%s' must be a sealed secret", opt)
+		}
+	}
+	for _, d := range a.Domains {
+		if err := validateACMEDNSDomain(d); err != nil {
+			return err
+		}
+	}
+	for _, r := range a.Resolvers {
+		if r == "" || strings.ContainsAny(r, " \t{}") {
+			return fmt.Errorf("invalid resolver '%s'", r)
+		}
+	}
+	return nil
+}
+
+func validateACMEDNSDomain(domain string) error {
+	name := strings.TrimPrefix(domain, "*.")
+	if name == "" || strings.Contains(name, "*") || !strings.Contains(name, ".") {
+		return fmt.Errorf("invalid domain '%s': must be a domain name such as example.com or *.example.com",
+			domain)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("invalid domain '%s'", domain)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("invalid domain '%s': must contain only lowercase letters, digits, and hyphens",
+					domain)
+			}
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the DNS-01 challenge should be used to obtain a certificate for the hostname.
+func (a *ACMEDNS) Matches(hostname string) bool {
+	if len(a.Domains) == 0 {
+		return true
+	}
+	for _, d := range a.Domains {
+		if wildcardMatches(d, hostname) {
+			return true
+		}
+		name := strings.TrimPrefix(d, "*.")
+		if hostname == name || strings.HasSuffix(hostname, "."+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Wildcard returns the configured wildcard domain that covers the hostname, if any.
+func (a *ACMEDNS) Wildcard(hostname string) (string, bool) {
+	for _, d := range a.Domains {
+		if wildcardMatches(d, hostname) {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// Wildcards returns the configured wildcard domains.
+func (a *ACMEDNS) Wildcards() []string {
+	var wildcards []string
+	for _, d := range a.Domains {
+		if strings.HasPrefix(d, "*.") {
+			wildcards = append(wildcards, d)
+		}
+	}
+	return wildcards
+}
+
+// wildcardMatches returns true if the domain is a wildcard such as *.example.com and the hostname is its direct
+// subdomain. A wildcard certificate doesn't cover deeper subdomains or the domain itself.
+func wildcardMatches(domain, hostname string) bool {
+	suffix, ok := strings.CutPrefix(domain, "*")
+	if !ok {
+		return false
+	}
+	label, ok := strings.CutSuffix(hostname, suffix)
+	return ok && label != "" && !strings.Contains(label, ".")
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACMEDNS_Validate(t *testing.T) {
+	t.Parallel()
+
+	sealed := SealedSecretPrefix + "0123456789abcdef/Y2lwaGVydGV4dA"
+	tests := []struct {
+		name    string
+		config  ACMEDNS
+		wantErr string
+	}{
+		{
+			name:   "provider only",
+			config: ACMEDNS{Provider: "cloudflare"},
+		},
+		{
+			name: "full config",
+			config: ACMEDNS{
+				Provider:    "route53",
+				Credentials: map[string]string{"access_key_id": sealed, "secret_access_key": sealed},
+				Domains:     []string{"example.com", "*.apps.example.com"},
+				Resolvers:   []string{"1.1.1.1", "8.8.8.8:53"},
+			},
+		},
+		{
+			name:    "empty provider",
+			config:  ACMEDNS{},
+			wantErr: "invalid provider ''",
+		},
+		{
+			name:    "invalid provider",
+			config:  ACMEDNS{Provider: "cloud flare"},
+			wantErr: "invalid provider 'cloud flare'",
+		},
+		{
+			name:    "invalid credential option",
+			config:  ACMEDNS{Provider: "cloudflare", Credentials: map[string]string{"../token": sealed}},
+			wantErr: "invalid credential option '../token'",
+		},
+		{
+			name:    "plain credential",
+			config:  ACMEDNS{Provider: "cloudflare", Credentials: map[string]string{"api_token": "secret"}},
+			wantErr: "credential 'api_token' must be a sealed secret",
+		},
+		{
+			name:    "top-level domain",
+			config:  ACMEDNS{Provider: "cloudflare", Domains: []string{"com"}},
+			wantErr: "invalid domain 'com'",
+		},
+		{
+			name:    "wildcard in the middle",
+			config:  ACMEDNS{Provider: "cloudflare", Domains: []string{"app.*.example.com"}},
+			wantErr: "invalid domain 'app.*.example.com'",
+		},
+		{
+			name:    "uppercase domain",
+			config:  ACMEDNS{Provider: "cloudflare", Domains: []string{"Example.com"}},
+			wantErr: "invalid domain 'Example.com'",
+		},
+		{
+			name:    "invalid resolver",
+			config:  ACMEDNS{Provider: "cloudflare", Resolvers: []string{"1.1.1.1 {"}},
+			wantErr: "invalid resolver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.config.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACMEDNS_Matches(t *testing.T) {
+	t.Parallel()
+
+	all := ACMEDNS{Provider: "cloudflare"}
+	limited := ACMEDNS{Provider: "cloudflare", Domains: []string{"example.com", "*.apps.test.io"}}
+
+	tests := []struct {
+		name         string
+		config       ACMEDNS
+		hostname     string
+		want         bool
+		wantWildcard string
+	}{
+		{name: "no domains match any hostname", config: all, hostname: "app.other.org", want: true},
+		{name: "domain itself", config: limited, hostname: "example.com", want: true},
+		{name: "subdomain", config: limited, hostname: "a.b.example.com", want: true},
+		{name: "suffix of another domain", config: limited, hostname: "notexample.com", want: false},
+		{
+			name: "wildcard subdomain", config: limited, hostname: "web.apps.test.io", want: true,
+			wantWildcard: "*.apps.test.io",
+		},
+		{name: "wildcard base domain", config: limited, hostname: "apps.test.io", want: true},
+		{name: "deeper than wildcard", config: limited, hostname: "a.web.apps.test.io", want: true},
+		{name: "other domain", config: limited, hostname: "test.io", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.config.Matches(tt.hostname))
+			wildcard, ok := tt.config.Wildcard(tt.hostname)
+			assert.Equal(t, tt.wantWildcard, wildcard)
+			assert.Equal(t, tt.wantWildcard != "", ok)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+)
+
+const (
+	// DefaultAutoUpdateInterval is the interval for checking the registry for a new service image digest
+	// when auto-update is enabled without an explicit interval.
+	DefaultAutoUpdateInterval = time.Hour
+	// MinAutoUpdateInterval is the shortest allowed interval to avoid hitting registry rate limits.
+	MinAutoUpdateInterval = time.Minute
+	// MaxAutoUpdateEvents is the number of the most recent auto-update events kept for each service.
+	MaxAutoUpdateEvents = 20
+
+	AutoUpdateEventUpdated = "updated"
+	AutoUpdateEventFailed  = "failed"
+)
+
+// AutoUpdateSpec configures automatic updates of a service when its image tag in the registry is moved
+// to a new image digest.
+type AutoUpdateSpec struct {
+	// Interval is how often to check the registry for a new image digest.
+	Interval time.Duration
+}
+
+func (a *AutoUpdateSpec) Validate() error {
+	if a.Interval < MinAutoUpdateInterval {
+		return fmt.Errorf("auto-update interval must be at least %s, got %s", MinAutoUpdateInterval, a.Interval)
+	}
+	return nil
+}
+
+func (a *AutoUpdateSpec) Equals(other *AutoUpdateSpec) bool {
+	if a == nil || other == nil {
+		return a == other
+	}
+	return *a == *other
+}
+
+// AutoUpdateImageTag returns the image reference without the digest that should be checked for updates.
+// The image of an auto-updated service is pinned to the digest as 'name:tag@digest' after the first update.
+func AutoUpdateImageTag(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid image '%s': %w", image, err)
+	}
+	tag := "latest"
+	if t, ok := named.(reference.Tagged); ok {
+		tag = t.Tag()
+	} else if _, ok = named.(reference.Digested); ok {
+		return "", fmt.Errorf("image '%s' is pinned to a digest without a tag, auto-update requires a tag", image)
+	}
+
+	tagged, err := reference.WithTag(reference.TrimNamed(named), tag)
+	if err != nil {
+		return "", fmt.Errorf("invalid image '%s': %w", image, err)
+	}
+	return reference.FamiliarString(tagged), nil
+}
+
+// HasRepoDigest returns true if the digest matches one of the repo digests of an image, e.g. as reported
+// by the image inspect.
+func HasRepoDigest(repoDigests []string, digest string) bool {
+	for _, rd := range repoDigests {
+		if strings.HasSuffix(rd, "@"+digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// AutoUpdateStatus is the state of automatic updates of a service maintained by the machine that checks
+// the registry for its image updates.
+type AutoUpdateStatus struct {
+	ServiceID   string `json:"service_id"`
+	ServiceName string `json:"service_name"`
+	// Image is the image tag checked for updates.
+	Image string `json:"image,omitempty"`
+	// Digest is the image digest the tag pointed to during the last successful check.
+	Digest string `json:"digest,omitempty"`
+	// LastCheck is the time of the last check, successful or not.
+	LastCheck time.Time `json:"last_check,omitzero"`
+	// LastUpdate is the time of the last successful update.
+	LastUpdate time.Time `json:"last_update,omitzero"`
+	// Error is the error of the last check or update if it failed.
+	Error string `json:"error,omitempty"`
+	// Events are the most recent auto-update events, oldest first.
+	Events []AutoUpdateEvent `json:"events,omitempty"`
+}
+
+type AutoUpdateEvent struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+}
+
+// AddEvent appends an event keeping only the MaxAutoUpdateEvents most recent ones.
+func (s *AutoUpdateStatus) AddEvent(e AutoUpdateEvent) {
+	s.Events = append(s.Events, e)
+	if len(s.Events) > MaxAutoUpdateEvents {
+		s.Events = s.Events[len(s.Events)-MaxAutoUpdateEvents:]
+	}
+}
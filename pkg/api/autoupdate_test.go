@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoUpdateImageTag(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	tests := []struct {
+		image   string
+		want    string
+		wantErr string
+	}{
+		{image: "nginx", want: "nginx:latest"},
+		{image: "nginx:1.27", want: "nginx:1.27"},
+		{image: "nginx:1.27@" + digest, want: "nginx:1.27"},
+		{image: "ghcr.io/org/app:v2", want: "ghcr.io/org/app:v2"},
+		{image: "registry:5000/app:v2@" + digest, want: "registry:5000/app:v2"},
+		{image: "nginx@" + digest, wantErr: "auto-update requires a tag"},
+		{image: "Invalid:Image", wantErr: "invalid image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := AutoUpdateImageTag(tt.image)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAutoUpdateStatus_AddEvent(t *testing.T) {
+	t.Parallel()
+
+	var s AutoUpdateStatus
+	for i := range MaxAutoUpdateEvents + 5 {
+		s.AddEvent(AutoUpdateEvent{Time: time.Unix(int64(i), 0), Type: AutoUpdateEventUpdated})
+	}
+
+	require.Len(t, s.Events, MaxAutoUpdateEvents)
+	assert.Equal(t, time.Unix(5, 0), s.Events[0].Time, "oldest events should be dropped")
+	assert.Equal(t, time.Unix(MaxAutoUpdateEvents+4, 0), s.Events[MaxAutoUpdateEvents-1].Time)
+}
@@ -7,15 +7,20 @@ type CaddySpec struct {
 	// Config contains the Caddy config (Caddyfile) content. It must not conflict with the Caddy configs
 	// of other services.
 	Config string
+	// Directives contains raw Caddyfile directives that are injected into the sites generated for the ingress
+	// ports of the service, e.g. to set headers or enable compression. They're applied to every HTTP and HTTPS
+	// hostname of the service.
+	Directives string `json:",omitempty"`
 }
 
 func (c *CaddySpec) Equals(other *CaddySpec) bool {
 	if c == nil {
-		return other == nil || strings.TrimSpace(other.Config) == ""
+		c = &CaddySpec{}
 	}
 	if other == nil {
-		return strings.TrimSpace(c.Config) == ""
+		other = &CaddySpec{}
 	}
 
-	return strings.TrimSpace(c.Config) == strings.TrimSpace(other.Config)
+	return strings.TrimSpace(c.Config) == strings.TrimSpace(other.Config) &&
+		strings.TrimSpace(c.Directives) == strings.TrimSpace(other.Directives)
 }
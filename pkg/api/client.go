@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"os"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/volume"
@@ -39,6 +40,11 @@ type DNSClient interface {
 	GetDomain(ctx context.Context) (string, error)
 }
 
+// EnvClient provides access to the environment variable overrides for Compose services stored in the cluster.
+type EnvClient interface {
+	ServiceEnv(ctx context.Context, project, service string) (map[string]string, error)
+}
+
 type ImageClient interface {
 	InspectImage(ctx context.Context, id string) ([]MachineImage, error)
 	InspectRemoteImage(ctx context.Context, id string) ([]MachineRemoteImage, error)
@@ -58,6 +64,30 @@ type MachineClient interface {
 	RenameMachine(ctx context.Context, nameOrID, newName string) (*pb.MachineInfo, error)
 }
 
+// PolicyClient provides access to the cluster policies that restrict what services can do.
+type PolicyClient interface {
+	ListAllowedSysctls(ctx context.Context) ([]string, error)
+	SecretMaxMode(ctx context.Context) (os.FileMode, error)
+}
+
+// ProjectClient provides access to the metadata of Compose projects stored in the cluster.
+type ProjectClient interface {
+	UpdateProject(ctx context.Context, name string, update ProjectUpdate) (Project, error)
+	ListProjects(ctx context.Context) ([]Project, error)
+}
+
+// ReservationClient reserves the published ports and ingress hostnames for Compose projects in the cluster.
+type ReservationClient interface {
+	ReservePublished(
+		ctx context.Context, project string, ports, hostnames []string, opts ReserveOptions,
+	) ([]PublishedReservation, error)
+}
+
+// SecretClient provides access to the secrets stored in the cluster.
+type SecretClient interface {
+	InspectSecret(ctx context.Context, name string) (ClusterSecret, error)
+}
+
 type ServiceClient interface {
 	RunService(ctx context.Context, spec ServiceSpec) (RunServiceResponse, error)
 	InspectService(ctx context.Context, id string) (Service, error)
@@ -70,4 +100,8 @@ type VolumeClient interface {
 	CreateVolume(ctx context.Context, machineNameOrID string, opts volume.CreateOptions) (MachineVolume, error)
 	ListVolumes(ctx context.Context, filter *VolumeFilter) ([]MachineVolume, error)
 	RemoveVolume(ctx context.Context, machineNameOrID, volumeName string, force bool) error
+	CreateVolumeSnapshot(ctx context.Context, machineNameOrID, volumeName string) (MachineVolumeSnapshot, error)
+	ListVolumeSnapshots(ctx context.Context, volumeName string, machines []string) ([]MachineVolumeSnapshot, error)
+	RestoreVolumeSnapshot(ctx context.Context, machineNameOrID, volumeName, id string) error
+	RemoveVolumeSnapshot(ctx context.Context, machineNameOrID, volumeName, id string) error
 }
@@ -16,6 +16,8 @@ type ConfigSpec struct {
 
 	// Content of the config when specified inline
 	Content []byte `json:",omitempty"`
+	// Template indicates the content is a Go template rendered with TemplateData when mounting the config.
+	Template bool `json:",omitempty"`
 
 	// Note: NOT IMPLEMENTED
 	// External indicates this config already exists and should not be created
@@ -32,13 +34,19 @@ func (c *ConfigSpec) Validate() error {
 	if c.Name == "" {
 		return fmt.Errorf("config name is required")
 	}
+	if c.Template {
+		if err := ParseTemplate(c.Name, c.Content); err != nil {
+			return fmt.Errorf("config '%s': %w", c.Name, err)
+		}
+	}
 	return nil
 }
 
 // Equals compares two ConfigSpec instances
 func (c *ConfigSpec) Equals(other ConfigSpec) bool {
 	return c.Name == other.Name &&
-		bytes.Equal(c.Content, other.Content)
+		bytes.Equal(c.Content, other.Content) &&
+		c.Template == other.Template
 }
 
 // ConfigMount defines how a config is mounted into a container
@@ -83,20 +91,22 @@ func (c *ConfigMount) GetNumericGid() (*uint64, error) {
 	return &gid, nil
 }
 
+// Validate returns ValidationErrors with the paths of the invalid fields using the Compose keys of a config mount.
 func (c *ConfigMount) Validate() error {
+	var errs ValidationErrors
 	if c.ConfigName == "" {
-		return fmt.Errorf("config mount source is required")
+		errs.Addf("source", "config mount source is required")
 	}
 	if _, err := c.GetNumericUid(); err != nil {
-		return err
+		errs.Add("uid", err)
 	}
 	if _, err := c.GetNumericGid(); err != nil {
-		return err
+		errs.Add("gid", err)
 	}
 	if c.ContainerPath != "" && !filepath.IsAbs(c.ContainerPath) {
-		return fmt.Errorf("container path must be absolute")
+		errs.Addf("target", "container path must be absolute")
 	}
-	return nil
+	return errs.Err()
 }
 
 // Compare compares this ConfigMount with another.
@@ -171,26 +181,29 @@ func sortConfigMounts(mounts []ConfigMount) {
 
 // ValidateConfigsAndMounts takes config specs and config mounts and validates that all mounts refer to existing specs
 func ValidateConfigsAndMounts(configs []ConfigSpec, mounts []ConfigMount) error {
+	var errs ValidationErrors
 	configMap := make(map[string]struct{})
 	for _, cfg := range configs {
 		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("invalid config: %w", err)
+			errs.Add(namePath("configs", cfg.Name), fmt.Errorf("invalid config: %w", err))
 		}
 		if _, ok := configMap[cfg.Name]; ok {
-			return fmt.Errorf("duplicate config name: '%s'", cfg.Name)
+			errs.Addf(namePath("configs", cfg.Name), "duplicate config name: '%s'", cfg.Name)
 		}
 
 		configMap[cfg.Name] = struct{}{}
 	}
 
-	for _, mount := range mounts {
+	for i, mount := range mounts {
+		path := indexPath("configs", i)
 		if err := mount.Validate(); err != nil {
-			return fmt.Errorf("invalid config mount: %w", err)
+			errs.Add(path, err)
 		}
-		if _, exists := configMap[mount.ConfigName]; !exists {
-			return fmt.Errorf("config mount source '%s' does not refer to any defined config", mount.ConfigName)
+		if _, exists := configMap[mount.ConfigName]; !exists && mount.ConfigName != "" {
+			errs.Addf(JoinFieldPath(path, "source"),
+				"config mount source '%s' does not refer to any defined config", mount.ConfigName)
 		}
 	}
 
-	return nil
+	return errs.Err()
 }
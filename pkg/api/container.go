@@ -24,10 +24,15 @@ const (
 	LabelServiceName  = "uncloud.service.name"
 	LabelServiceMode  = "uncloud.service.mode"
 	LabelServicePorts = "uncloud.service.ports"
+	// LabelServiceDependsOn is a comma-separated list of services the service of the container depends on.
+	LabelServiceDependsOn = "uncloud.service.depends-on"
 	// LabelHook marks a container as a deployment hook. The value indicates the hook type (e.g. LabelHookPreDeploy).
 	LabelHook = "uncloud.service.hook"
 	// LabelHookPreDeploy indicates that the container is a pre-deploy hook that runs before deploying the service.
 	LabelHookPreDeploy = "pre-deploy"
+	// LabelHookBackupVerify indicates that the container is a throwaway service container that a backup is restored
+	// into to verify it.
+	LabelHookBackupVerify = "backup-verify"
 )
 
 type Container struct {
@@ -174,6 +179,14 @@ type CreateContainerResponse struct {
 type ServiceContainer struct {
 	Container
 	ServiceSpec ServiceSpec
+	// SupervisedRestarts is the number of times the machine daemon restarted the container after Docker failed
+	// to restart it according to its restart policy. See Restarts for the total number of restarts.
+	SupervisedRestarts int `json:",omitempty"`
+}
+
+// Restarts returns the total number of times the container was restarted by Docker and the machine daemon.
+func (c *ServiceContainer) Restarts() int {
+	return c.RestartCount + c.SupervisedRestarts
 }
 
 // ShortID returns the truncated ID of the container (12 characters).
@@ -240,8 +253,9 @@ func (c *ServiceContainer) ConflictingServicePorts(ports []PortSpec) ([]PortSpec
 		}
 
 		// Two host ports conflict if they have the same published port number and protocol, and either:
-		//   * At least one host IP is not set (meaning it uses all interfaces)
-		//   * Both host IPs are identical
+		//   * At least one host IP or interface is not set (meaning it uses all interfaces)
+		//   * Both host IPs or interfaces are identical
+		//   * One is bound to a host IP and the other to an interface that may have this IP
 		for _, svcPort := range svcPorts {
 			if svcPort.Mode != PortModeHost ||
 				svcPort.PublishedPort != p.PublishedPort ||
@@ -249,7 +263,7 @@ func (c *ServiceContainer) ConflictingServicePorts(ports []PortSpec) ([]PortSpec
 				continue
 			}
 
-			if !svcPort.HostIP.IsValid() || !p.HostIP.IsValid() || svcPort.HostIP.Compare(p.HostIP) == 0 {
+			if hostBindingsOverlap(svcPort, p) {
 				conflicting = append(conflicting, p)
 			}
 		}
@@ -258,6 +272,22 @@ func (c *ServiceContainer) ConflictingServicePorts(ports []PortSpec) ([]PortSpec
 	return conflicting, nil
 }
 
+func hostBindingsOverlap(a, b PortSpec) bool {
+	aAll := !a.HostIP.IsValid() && a.HostInterface == ""
+	bAll := !b.HostIP.IsValid() && b.HostInterface == ""
+	if aAll || bAll {
+		return true
+	}
+	if a.HostInterface != "" && b.HostInterface != "" {
+		return a.HostInterface == b.HostInterface
+	}
+	if a.HostIP.IsValid() && b.HostIP.IsValid() {
+		return a.HostIP.Compare(b.HostIP) == 0
+	}
+	// The IPs of the interface are only known on the machine.
+	return true
+}
+
 // UnmarshalJSON implements custom unmarshalling for ServiceContainer to override the custom unmarshaler
 // of the embedded Container field.
 func (c *ServiceContainer) UnmarshalJSON(data []byte) error {
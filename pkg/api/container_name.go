@@ -0,0 +1,113 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContainerNamePlaceholderService is replaced with the service name in a container name template.
+	ContainerNamePlaceholderService = "{service}"
+	// ContainerNamePlaceholderOrdinal is replaced with the lowest positive number that isn't used by other containers
+	// of the service in a container name template.
+	ContainerNamePlaceholderOrdinal = "{ordinal}"
+	// ContainerNamePlaceholderRandom is replaced with a random alphanumeric suffix in a container name template.
+	ContainerNamePlaceholderRandom = "{random}"
+
+	// DefaultContainerNameTemplate is the template used to generate service container names if the service
+	// doesn't specify one.
+	DefaultContainerNameTemplate = ContainerNamePlaceholderService + "-" + ContainerNamePlaceholderRandom
+)
+
+var (
+	containerNamePlaceholderRegexp = regexp.MustCompile(`\{[^{}]*}`)
+	// dockerContainerNameRegexp is the same pattern Docker uses to validate container names.
+	dockerContainerNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+)
+
+// ValidateContainerNameTemplate checks that the container name template only uses supported placeholders, always
+// generates unique names for replicas, and produces valid Docker container names.
+func ValidateContainerNameTemplate(tmpl string) error {
+	for _, p := range containerNamePlaceholderRegexp.FindAllString(tmpl, -1) {
+		switch p {
+		case ContainerNamePlaceholderService, ContainerNamePlaceholderOrdinal, ContainerNamePlaceholderRandom:
+		default:
+			return fmt.Errorf("unsupported placeholder '%s', supported placeholders are %s, %s, %s", p,
+				ContainerNamePlaceholderService, ContainerNamePlaceholderOrdinal, ContainerNamePlaceholderRandom)
+		}
+	}
+	if !strings.Contains(tmpl, ContainerNamePlaceholderOrdinal) &&
+		!strings.Contains(tmpl, ContainerNamePlaceholderRandom) {
+		return fmt.Errorf("template must contain %s or %s to generate unique names for service containers",
+			ContainerNamePlaceholderOrdinal, ContainerNamePlaceholderRandom)
+	}
+
+	name := RenderContainerName(tmpl, "service", 1, "abcd")
+	if !dockerContainerNameRegexp.MatchString(name) {
+		return fmt.Errorf("template generates invalid container name '%s', only [a-zA-Z0-9][a-zA-Z0-9_.-] "+
+			"characters are allowed", name)
+	}
+
+	return nil
+}
+
+// RenderContainerName generates a container name from the template by replacing the placeholders with the given
+// service name, ordinal, and random suffix.
+func RenderContainerName(tmpl, serviceName string, ordinal int, random string) string {
+	return strings.NewReplacer(
+		ContainerNamePlaceholderService, serviceName,
+		ContainerNamePlaceholderOrdinal, strconv.Itoa(ordinal),
+		ContainerNamePlaceholderRandom, random,
+	).Replace(tmpl)
+}
+
+// NextContainerNameOrdinal returns the lowest positive ordinal that isn't used by any of the existing container
+// names generated from the template for the service.
+func NextContainerNameOrdinal(tmpl, serviceName string, existingNames []string) int {
+	// Build a regexp that matches the names generated from the template and captures the ordinal.
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range containerNamePlaceholderRegexp.FindAllStringIndex(tmpl, -1) {
+		pattern.WriteString(regexp.QuoteMeta(tmpl[last:loc[0]]))
+		switch tmpl[loc[0]:loc[1]] {
+		case ContainerNamePlaceholderService:
+			pattern.WriteString(regexp.QuoteMeta(serviceName))
+		case ContainerNamePlaceholderOrdinal:
+			pattern.WriteString(`(\d+)`)
+		case ContainerNamePlaceholderRandom:
+			pattern.WriteString(`[a-zA-Z0-9]+`)
+		default:
+			pattern.WriteString(regexp.QuoteMeta(tmpl[loc[0]:loc[1]]))
+		}
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(tmpl[last:]))
+	pattern.WriteString("$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return 1
+	}
+
+	var used []int
+	for _, name := range existingNames {
+		m := re.FindStringSubmatch(name)
+		if len(m) < 2 {
+			continue
+		}
+		// All ordinal placeholders in the template render the same number, so check only the first one.
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			used = append(used, n)
+		}
+	}
+
+	ordinal := 1
+	for slices.Contains(used, ordinal) {
+		ordinal++
+	}
+	return ordinal
+}
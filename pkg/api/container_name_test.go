@@ -0,0 +1,91 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateContainerNameTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantErr string
+	}{
+		{name: "default", tmpl: DefaultContainerNameTemplate},
+		{name: "ordinal", tmpl: "myapp-{service}-{ordinal}"},
+		{name: "ordinal and random", tmpl: "{service}_{ordinal}.{random}"},
+		{name: "no unique placeholder", tmpl: "myapp-{service}", wantErr: "must contain {ordinal} or {random}"},
+		{name: "unsupported placeholder", tmpl: "{machine}-{ordinal}", wantErr: "unsupported placeholder '{machine}'"},
+		{name: "invalid characters", tmpl: "my app-{ordinal}", wantErr: "invalid container name 'my app-1'"},
+		{name: "invalid first character", tmpl: "-{service}-{ordinal}", wantErr: "invalid container name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateContainerNameTemplate(tt.tmpl)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderContainerName(t *testing.T) {
+	assert.Equal(t, "web-x1y2", RenderContainerName(DefaultContainerNameTemplate, "web", 0, "x1y2"))
+	assert.Equal(t, "myapp-web-3", RenderContainerName("myapp-{service}-{ordinal}", "web", 3, "x1y2"))
+}
+
+func TestNextContainerNameOrdinal(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		existing []string
+		want     int
+	}{
+		{
+			name: "no containers",
+			tmpl: "myapp-{service}-{ordinal}",
+			want: 1,
+		},
+		{
+			name:     "sequential ordinals",
+			tmpl:     "myapp-{service}-{ordinal}",
+			existing: []string{"myapp-web-1", "myapp-web-2"},
+			want:     3,
+		},
+		{
+			name:     "gap in ordinals",
+			tmpl:     "myapp-{service}-{ordinal}",
+			existing: []string{"myapp-web-3", "myapp-web-1"},
+			want:     2,
+		},
+		{
+			name:     "ignore names not matching template",
+			tmpl:     "myapp-{service}-{ordinal}",
+			existing: []string{"web-abcd", "myapp-web-db-1", "other-web-1"},
+			want:     1,
+		},
+		{
+			name:     "ordinal with random suffix",
+			tmpl:     "{service}.{ordinal}.{random}",
+			existing: []string{"web.1.x1y2", "web.2.a3b4"},
+			want:     3,
+		},
+		{
+			name:     "template special characters are escaped",
+			tmpl:     "a.{service}-{ordinal}",
+			existing: []string{"a.web-1", "abweb-2"},
+			want:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NextContainerNameOrdinal(tt.tmpl, "web", tt.existing))
+		})
+	}
+}
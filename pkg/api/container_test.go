@@ -196,6 +196,26 @@ func TestContainer_ConflictingServicePorts(t *testing.T) {
 			want:    nil,
 			wantErr: false,
 		},
+		{
+			name:           "host mode ports with same published port but different host interfaces don't conflict",
+			containerPorts: "%eth0:8080:80/tcp@host",
+			checkPorts: []PortSpec{
+				{Mode: PortModeHost, HostInterface: "eth1", PublishedPort: 8080, ContainerPort: 80, Protocol: ProtocolTCP},
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:           "host mode ports with host IP and host interface conflict",
+			containerPorts: "10.0.0.1:8080:80/tcp@host",
+			checkPorts: []PortSpec{
+				{Mode: PortModeHost, HostInterface: "eth1", PublishedPort: 8080, ContainerPort: 80, Protocol: ProtocolTCP},
+			},
+			want: []PortSpec{
+				{Mode: PortModeHost, HostInterface: "eth1", PublishedPort: 8080, ContainerPort: 80, Protocol: ProtocolTCP},
+			},
+			wantErr: false,
+		},
 		{
 			name:           "host mode ports with same published port, protocol, and host IP conflict",
 			containerPorts: "127.0.0.1:8080:80/tcp@host",
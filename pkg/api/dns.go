@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// internalDNSZone is the cluster internal domain resolved by the embedded DNS server for service discovery.
+const internalDNSZone = "internal"
+
+// DNSConfig configures the embedded DNS server on machines: how it forwards queries for names outside the cluster
+// internal domain, which queries it logs, and whether the machines resolve internal names using it and advertise
+// services on their LAN using multicast DNS. The cluster-wide settings apply to all machines and can be overridden
+// per machine.
+type DNSConfig struct {
+	DNSSettings
+	// Machines maps machine IDs to the settings that override the cluster-wide ones on the machine.
+	Machines map[string]DNSSettings `json:"machines,omitempty"`
+}
+
+// DNSSettings configures forwarding of external DNS queries and query logging.
+type DNSSettings struct {
+	// Upstreams are the DNS servers in IP[:PORT] format to forward queries to. If empty, the upstreams are inherited
+	// from the cluster-wide settings or the machine's /etc/resolv.conf.
+	Upstreams []string `json:"upstreams,omitempty"`
+	// Zones maps DNS zones, such as corp.example.com, to the DNS servers in IP[:PORT] format to forward queries for
+	// the zone and its subdomains to instead of the upstreams (conditional forwarding).
+	Zones map[string][]string `json:"zones,omitempty"`
+	// NegativeTTL is the maximum duration to cache negative answers (NXDOMAIN or no records) from the DNS servers.
+	// Zero disables negative caching. If nil, it's inherited from the cluster-wide settings.
+	NegativeTTL *time.Duration `json:"negative_ttl,omitempty"`
+	// QueryLogSampleRate is the fraction of DNS queries from 0 to 1 to log with their answers, e.g. 0.1 logs
+	// every tenth query on average. Zero disables query logging. If nil, it's inherited from the cluster-wide settings.
+	QueryLogSampleRate *float64 `json:"query_log_sample_rate,omitempty"`
+	// HostDNS registers the embedded DNS server in the machine's systemd-resolved as the DNS server for the internal
+	// domain so that processes on the machine outside containers can resolve service names. If nil, it's inherited
+	// from the cluster-wide settings.
+	HostDNS *bool `json:"host_dns,omitempty"`
+	// MDNS makes the machine answer multicast DNS queries on its LAN for the ingress hostnames in the .local domain
+	// and advertise them as DNS-SD services so that devices outside the cluster can find the services. If nil, it's
+	// inherited from the cluster-wide settings.
+	MDNS *bool `json:"mdns,omitempty"`
+}
+
+// IsZero returns true if no settings are configured.
+func (f *DNSSettings) IsZero() bool {
+	return len(f.Upstreams) == 0 && len(f.Zones) == 0 && f.NegativeTTL == nil && f.QueryLogSampleRate == nil &&
+		f.HostDNS == nil && f.MDNS == nil
+}
+
+func (f *DNSSettings) Validate() error {
+	for _, u := range f.Upstreams {
+		if _, err := ParseDNSServer(u); err != nil {
+			return err
+		}
+	}
+	for zone, servers := range f.Zones {
+		if err := validateDNSZone(zone); err != nil {
+			return err
+		}
+		if len(servers) == 0 {
+			return fmt.Errorf("no DNS servers specified for zone '%s'", zone)
+		}
+		for _, s := range servers {
+			if _, err := ParseDNSServer(s); err != nil {
+				return fmt.Errorf("zone '%s': %w", zone, err)
+			}
+		}
+	}
+	if f.NegativeTTL != nil && *f.NegativeTTL < 0 {
+		return fmt.Errorf("negative TTL must not be negative")
+	}
+	if f.QueryLogSampleRate != nil && (*f.QueryLogSampleRate < 0 || *f.QueryLogSampleRate > 1) {
+		return fmt.Errorf("query log sample rate must be between 0 and 1")
+	}
+	return nil
+}
+
+func (c *DNSConfig) Validate() error {
+	if err := c.DNSSettings.Validate(); err != nil {
+		return err
+	}
+	for id, f := range c.Machines {
+		if err := f.Validate(); err != nil {
+			return fmt.Errorf("machine '%s': %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ForMachine returns the effective settings for the machine. The machine settings override the cluster-wide ones
+// except the zones that are merged with the machine zones taking precedence.
+func (c *DNSConfig) ForMachine(machineID string) DNSSettings {
+	f := DNSSettings{
+		Upstreams:          c.Upstreams,
+		Zones:              maps.Clone(c.Zones),
+		NegativeTTL:        c.NegativeTTL,
+		QueryLogSampleRate: c.QueryLogSampleRate,
+		HostDNS:            c.HostDNS,
+		MDNS:               c.MDNS,
+	}
+
+	m, ok := c.Machines[machineID]
+	if !ok {
+		return f
+	}
+	if len(m.Upstreams) > 0 {
+		f.Upstreams = m.Upstreams
+	}
+	if len(m.Zones) > 0 {
+		if f.Zones == nil {
+			f.Zones = make(map[string][]string, len(m.Zones))
+		}
+		maps.Copy(f.Zones, m.Zones)
+	}
+	if m.NegativeTTL != nil {
+		f.NegativeTTL = m.NegativeTTL
+	}
+	if m.QueryLogSampleRate != nil {
+		f.QueryLogSampleRate = m.QueryLogSampleRate
+	}
+	if m.HostDNS != nil {
+		f.HostDNS = m.HostDNS
+	}
+	if m.MDNS != nil {
+		f.MDNS = m.MDNS
+	}
+	return f
+}
+
+// ParseDNSServer parses a DNS server address in IP[:PORT] format. The port defaults to 53.
+func ParseDNSServer(s string) (netip.AddrPort, error) {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return netip.AddrPortFrom(addr, 53), nil
+	}
+	addrPort, err := netip.ParseAddrPort(s)
+	if err != nil {
+		return netip.AddrPort{}, fmt.Errorf("invalid DNS server '%s': must be an IP address with an optional port", s)
+	}
+	return addrPort, nil
+}
+
+// NormaliseDNSZone returns the zone in lowercase without the trailing dot.
+func NormaliseDNSZone(zone string) string {
+	return strings.TrimSuffix(strings.ToLower(zone), ".")
+}
+
+func validateDNSZone(zone string) error {
+	if zone == "" || zone != NormaliseDNSZone(zone) {
+		return fmt.Errorf("invalid zone '%s': must be a lowercase domain name without the trailing dot", zone)
+	}
+	if zone == internalDNSZone || strings.HasSuffix(zone, "."+internalDNSZone) {
+		return fmt.Errorf("invalid zone '%s': the '%s' domain is resolved by the cluster DNS and can't be forwarded",
+			zone, internalDNSZone)
+	}
+	for _, label := range strings.Split(zone, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("invalid zone '%s'", zone)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSSettings_Validate(t *testing.T) {
+	t.Parallel()
+
+	negative := -time.Second
+	rate := 1.5
+	tests := []struct {
+		name    string
+		f       DNSSettings
+		wantErr string
+	}{
+		{name: "empty"},
+		{
+			name: "valid",
+			f: DNSSettings{
+				Upstreams: []string{"9.9.9.9", "10.0.0.53:5353", "[fd00::53]:53"},
+				Zones:     map[string][]string{"corp.example.com": {"10.0.0.53"}},
+			},
+		},
+		{name: "invalid upstream", f: DNSSettings{Upstreams: []string{"dns.google"}}, wantErr: "invalid DNS server"},
+		{
+			name:    "internal zone",
+			f:       DNSSettings{Zones: map[string][]string{"db.internal": {"10.0.0.53"}}},
+			wantErr: "can't be forwarded",
+		},
+		{
+			name:    "zone with trailing dot",
+			f:       DNSSettings{Zones: map[string][]string{"corp.example.com.": {"10.0.0.53"}}},
+			wantErr: "invalid zone",
+		},
+		{
+			name:    "zone without servers",
+			f:       DNSSettings{Zones: map[string][]string{"corp.example.com": nil}},
+			wantErr: "no DNS servers specified",
+		},
+		{name: "negative TTL", f: DNSSettings{NegativeTTL: &negative}, wantErr: "must not be negative"},
+		{name: "query log sample rate", f: DNSSettings{QueryLogSampleRate: &rate}, wantErr: "between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.f.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDNSConfig_ForMachine(t *testing.T) {
+	t.Parallel()
+
+	clusterTTL := 30 * time.Second
+	machineTTL := time.Duration(0)
+	machineRate := 0.5
+	hostDNS := true
+	config := DNSConfig{
+		DNSSettings: DNSSettings{
+			Upstreams: []string{"9.9.9.9"},
+			Zones: map[string][]string{
+				"corp.example.com": {"10.0.0.53"},
+				"lab.example.com":  {"10.0.1.53"},
+			},
+			NegativeTTL: &clusterTTL,
+			HostDNS:     &hostDNS,
+		},
+		Machines: map[string]DNSSettings{
+			"m-1": {
+				Upstreams:          []string{"192.168.1.1"},
+				Zones:              map[string][]string{"lab.example.com": {"192.168.1.53"}},
+				NegativeTTL:        &machineTTL,
+				QueryLogSampleRate: &machineRate,
+			},
+			"m-2": {
+				Zones: map[string][]string{"home.arpa": {"192.168.2.1"}},
+			},
+		},
+	}
+
+	assert.Equal(t, config.DNSSettings, config.ForMachine("m-0"))
+	assert.Equal(t, DNSSettings{
+		Upstreams: []string{"192.168.1.1"},
+		Zones: map[string][]string{
+			"corp.example.com": {"10.0.0.53"},
+			"lab.example.com":  {"192.168.1.53"},
+		},
+		NegativeTTL:        &machineTTL,
+		QueryLogSampleRate: &machineRate,
+		HostDNS:            &hostDNS,
+	}, config.ForMachine("m-1"))
+	assert.Equal(t, DNSSettings{
+		Upstreams: []string{"9.9.9.9"},
+		Zones: map[string][]string{
+			"corp.example.com": {"10.0.0.53"},
+			"lab.example.com":  {"10.0.1.53"},
+			"home.arpa":        {"192.168.2.1"},
+		},
+		NegativeTTL: &clusterTTL,
+		HostDNS:     &hostDNS,
+	}, config.ForMachine("m-2"))
+
+	// The cluster-wide zones must not be modified by merging.
+	assert.Len(t, config.Zones, 2)
+}
+
+func TestParseDNSServer(t *testing.T) {
+	t.Parallel()
+
+	addr, err := ParseDNSServer("10.0.0.53")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddrPort("10.0.0.53:53"), addr)
+
+	addr, err = ParseDNSServer("10.0.0.53:5353")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddrPort("10.0.0.53:5353"), addr)
+
+	addr, err = ParseDNSServer("fd00::53")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddrPort("[fd00::53]:53"), addr)
+
+	_, err = ParseDNSServer("10.0.0.53:")
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package api
+
+import "time"
+
+const (
+	// MaxHostUpdates is the number of the most recent host package updates kept in the history of each machine.
+	MaxHostUpdates = 20
+	// MaxHostUpdateOutput is the maximum size of the tail of the update command output kept for each update.
+	MaxHostUpdateOutput = 4096
+)
+
+// HostUpdate is a run of the OS package update command on a machine host.
+type HostUpdate struct {
+	// Command is the shell command that updated the host packages.
+	Command    string    `json:"command"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitzero"`
+	// ExitCode is the exit code of the command. -1 if the command couldn't be run or was killed.
+	ExitCode int `json:"exit_code"`
+	// Output is the tail of the combined stdout and stderr of the command.
+	Output string `json:"output,omitempty"`
+	// RebootRequired is true if the host reported that it needs a reboot to apply the updates, e.g. a new kernel.
+	RebootRequired bool `json:"reboot_required,omitempty"`
+	// Error is the reason the command failed. Empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// HostUpdateHistory is the history of host package updates on a machine.
+type HostUpdateHistory struct {
+	MachineID string `json:"machine_id"`
+	// Updates are the most recent updates, oldest first.
+	Updates []HostUpdate `json:"updates,omitempty"`
+}
+
+// Add appends an update keeping only the MaxHostUpdates most recent ones.
+func (h *HostUpdateHistory) Add(u HostUpdate) {
+	h.Updates = append(h.Updates, u)
+	if len(h.Updates) > MaxHostUpdates {
+		h.Updates = h.Updates[len(h.Updates)-MaxHostUpdates:]
+	}
+}
@@ -1,10 +1,15 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+
 	"github.com/distribution/reference"
 	"github.com/docker/docker/api/types/image"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/grpc/codes"
 )
 
 type MachineImage struct {
@@ -46,3 +51,134 @@ type RemoteImage struct {
 	IndexManifest *v1.Index
 	ImageManifest *v1.Manifest
 }
+
+// ImageOperationStatus is the outcome of an image operation (remove, prune, pull) on a particular machine.
+type ImageOperationStatus string
+
+const (
+	ImageOperationSucceeded ImageOperationStatus = "succeeded"
+	// ImageOperationNotFound means the image doesn't exist on the machine or in the registry when pulling.
+	ImageOperationNotFound ImageOperationStatus = "not-found"
+	// ImageOperationInUse means the image is used by a container on the machine and can't be removed.
+	ImageOperationInUse ImageOperationStatus = "in-use"
+	// ImageOperationPermissionDenied means the machine isn't allowed to perform the operation, for example,
+	// it lacks credentials to pull the image from a private registry.
+	ImageOperationPermissionDenied ImageOperationStatus = "permission-denied"
+	// ImageOperationFailed is any other failure, including the machine being unreachable.
+	ImageOperationFailed ImageOperationStatus = "failed"
+)
+
+// ImageOperationStatusFromCode maps a gRPC status code returned by a machine to an image operation status.
+func ImageOperationStatusFromCode(code codes.Code) ImageOperationStatus {
+	switch code {
+	case codes.OK:
+		return ImageOperationSucceeded
+	case codes.NotFound:
+		return ImageOperationNotFound
+	case codes.FailedPrecondition:
+		return ImageOperationInUse
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return ImageOperationPermissionDenied
+	default:
+		return ImageOperationFailed
+	}
+}
+
+// MachineImageResult is the result of an image operation on a particular machine.
+type MachineImageResult struct {
+	MachineID   string
+	MachineName string
+	Status      ImageOperationStatus
+	// Err is the error reported by the machine if the operation didn't succeed.
+	Err error
+	// Deleted is a list of image references and IDs untagged or deleted by a remove or prune operation.
+	Deleted []image.DeleteResponse
+	// SpaceReclaimed is the disk space in bytes freed by a prune operation.
+	SpaceReclaimed uint64
+}
+
+// NewMachineImageResult creates a result for the machine identified by the response metadata. The status and error
+// are derived from the error the machine reported in the metadata, if any.
+func NewMachineImageResult(md *pb.Metadata) MachineImageResult {
+	r := MachineImageResult{Status: ImageOperationSucceeded}
+	if md == nil {
+		return r
+	}
+
+	r.MachineID = md.MachineId
+	r.MachineName = md.MachineName
+	if md.Error == "" {
+		return r
+	}
+
+	r.Err = errors.New(md.Error)
+	code := codes.Unknown
+	if md.Status != nil {
+		code = codes.Code(md.Status.Code)
+		if md.Status.Message != "" {
+			r.Err = errors.New(md.Status.Message)
+		}
+	}
+	r.Status = ImageOperationStatusFromCode(code)
+	// Don't report a failure as a success if the machine returned an error without a status code.
+	if r.Status == ImageOperationSucceeded {
+		r.Status = ImageOperationFailed
+	}
+
+	return r
+}
+
+// Succeeded returns true if the operation succeeded on the machine.
+func (r MachineImageResult) Succeeded() bool {
+	return r.Status == ImageOperationSucceeded
+}
+
+// NormalizeImageReference parses an image reference and returns it in the familiar form, for example, "nginx:latest"
+// for "docker.io/library/nginx:latest". It doesn't add the default tag if the reference doesn't have one.
+func NormalizeImageReference(ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference '%s': %w", ref, err)
+	}
+	return reference.FamiliarString(named), nil
+}
+
+// ImageMatchesPinned returns true if an image with the given repo tags and digests matches any of the pinned image
+// references. A pinned reference with a tag or digest matches only that tag or digest. A pinned reference without
+// them matches all tags and digests of the repository.
+func ImageMatchesPinned(pinned, repoTags, repoDigests []string) bool {
+	var imageRefs []reference.Named
+	for _, r := range append(slices.Clone(repoTags), repoDigests...) {
+		if named, err := reference.ParseNormalizedNamed(r); err == nil {
+			imageRefs = append(imageRefs, named)
+		}
+	}
+
+	for _, p := range pinned {
+		pin, err := reference.ParseNormalizedNamed(p)
+		if err != nil {
+			continue
+		}
+
+		for _, ref := range imageRefs {
+			if pin.Name() != ref.Name() {
+				continue
+			}
+
+			switch pinRef := pin.(type) {
+			case reference.Canonical:
+				if canonical, ok := ref.(reference.Canonical); ok && canonical.Digest() == pinRef.Digest() {
+					return true
+				}
+			case reference.Tagged:
+				if tagged, ok := ref.(reference.Tagged); ok && tagged.Tag() == pinRef.Tag() {
+					return true
+				}
+			default:
+				return true
+			}
+		}
+	}
+
+	return false
+}
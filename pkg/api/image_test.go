@@ -0,0 +1,186 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewMachineImageResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		md         *pb.Metadata
+		wantStatus ImageOperationStatus
+		wantErr    string
+	}{
+		{
+			name:       "nil metadata",
+			md:         nil,
+			wantStatus: ImageOperationSucceeded,
+		},
+		{
+			name:       "succeeded",
+			md:         &pb.Metadata{MachineId: "id1", MachineName: "machine1"},
+			wantStatus: ImageOperationSucceeded,
+		},
+		{
+			name: "not found",
+			md: &pb.Metadata{
+				MachineName: "machine1",
+				Error:       "rpc error: code = NotFound desc = No such image: nginx",
+				Status:      &status.Status{Code: int32(codes.NotFound), Message: "No such image: nginx"},
+			},
+			wantStatus: ImageOperationNotFound,
+			wantErr:    "No such image: nginx",
+		},
+		{
+			name: "in use",
+			md: &pb.Metadata{
+				MachineName: "machine1",
+				Error:       "conflict",
+				Status:      &status.Status{Code: int32(codes.FailedPrecondition), Message: "image is being used"},
+			},
+			wantStatus: ImageOperationInUse,
+			wantErr:    "image is being used",
+		},
+		{
+			name: "permission denied",
+			md: &pb.Metadata{
+				MachineName: "machine1",
+				Error:       "denied",
+				Status:      &status.Status{Code: int32(codes.PermissionDenied), Message: "denied"},
+			},
+			wantStatus: ImageOperationPermissionDenied,
+			wantErr:    "denied",
+		},
+		{
+			name: "unavailable machine",
+			md: &pb.Metadata{
+				MachineName: "machine1",
+				Error:       "connection refused",
+				Status:      &status.Status{Code: int32(codes.Unavailable), Message: "connection refused"},
+			},
+			wantStatus: ImageOperationFailed,
+			wantErr:    "connection refused",
+		},
+		{
+			name:       "error without status",
+			md:         &pb.Metadata{MachineName: "machine1", Error: "boom"},
+			wantStatus: ImageOperationFailed,
+			wantErr:    "boom",
+		},
+		{
+			name: "error with OK status",
+			md: &pb.Metadata{
+				MachineName: "machine1",
+				Error:       "boom",
+				Status:      &status.Status{Code: int32(codes.OK)},
+			},
+			wantStatus: ImageOperationFailed,
+			wantErr:    "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewMachineImageResult(tt.md)
+			assert.Equal(t, tt.wantStatus, r.Status)
+			if tt.md != nil {
+				assert.Equal(t, tt.md.MachineName, r.MachineName)
+			}
+			if tt.wantErr == "" {
+				assert.NoError(t, r.Err)
+				assert.True(t, r.Succeeded())
+			} else {
+				assert.EqualError(t, r.Err, tt.wantErr)
+				assert.False(t, r.Succeeded())
+			}
+		})
+	}
+}
+
+func TestImageMatchesPinned(t *testing.T) {
+	t.Parallel()
+
+	const digest = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	tests := []struct {
+		name        string
+		pinned      []string
+		repoTags    []string
+		repoDigests []string
+		want        bool
+	}{
+		{
+			name:     "no pins",
+			repoTags: []string{"nginx:latest"},
+		},
+		{
+			name:     "exact tag",
+			pinned:   []string{"postgres:17"},
+			repoTags: []string{"postgres:17"},
+			want:     true,
+		},
+		{
+			name:     "normalised tag",
+			pinned:   []string{"docker.io/library/postgres:17"},
+			repoTags: []string{"postgres:17"},
+			want:     true,
+		},
+		{
+			name:     "different tag",
+			pinned:   []string{"postgres:17"},
+			repoTags: []string{"postgres:16"},
+		},
+		{
+			name:     "repository matches any tag",
+			pinned:   []string{"ghcr.io/org/base"},
+			repoTags: []string{"ghcr.io/org/base:1.2.3"},
+			want:     true,
+		},
+		{
+			name:        "repository matches untagged image with digest",
+			pinned:      []string{"ghcr.io/org/base"},
+			repoDigests: []string{"ghcr.io/org/base@" + digest},
+			want:        true,
+		},
+		{
+			name:     "different repository",
+			pinned:   []string{"ghcr.io/org/base"},
+			repoTags: []string{"ghcr.io/org/app:1.0"},
+		},
+		{
+			name:        "digest",
+			pinned:      []string{"postgres@" + digest},
+			repoTags:    []string{"postgres:17"},
+			repoDigests: []string{"postgres@" + digest},
+			want:        true,
+		},
+		{
+			name:     "digest doesn't match tag",
+			pinned:   []string{"postgres@" + digest},
+			repoTags: []string{"postgres:17"},
+		},
+		{
+			name:     "one of multiple tags",
+			pinned:   []string{"invalid:ref:", "app:stable"},
+			repoTags: []string{"app:1.0", "app:stable"},
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, ImageMatchesPinned(tt.pinned, tt.repoTags, tt.repoDigests))
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitWindows maps the supported rate limit units to their time windows.
+var rateLimitWindows = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+const (
+	// IngressBackendHTTP proxies requests to service containers over HTTP/1.1. It's the default.
+	IngressBackendHTTP = "http"
+	// IngressBackendH2C proxies requests to service containers over cleartext HTTP/2 (h2c) as required by gRPC.
+	IngressBackendH2C = "h2c"
+
+	// DefaultRequestIDHeader is the request header that carries the request ID if request IDs are enabled without
+	// specifying a header name.
+	DefaultRequestIDHeader = "X-Request-ID"
+)
+
+// headerNameRegexp matches valid HTTP header names.
+var headerNameRegexp = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+
+// IngressPolicy configures how Caddy proxies requests to the ingress routes (hostnames) of a service and protects
+// them from abusive traffic.
+type IngressPolicy struct {
+	// RateLimit limits the number of requests from each client IP. Requires the Caddy image to include
+	// the rate_limit module.
+	RateLimit *RateLimit `json:",omitempty"`
+	// MaxConnections limits the number of concurrent connections from Caddy to each service container.
+	// 0 means unlimited.
+	MaxConnections uint `json:",omitempty"`
+	// Allow is a list of client IP ranges that are allowed to access the routes. If empty, all clients are allowed
+	// unless denied.
+	Allow []netip.Prefix `json:",omitempty"`
+	// Deny is a list of client IP ranges that are denied access to the routes. Deny takes precedence over Allow.
+	Deny []netip.Prefix `json:",omitempty"`
+	// BackendProtocol is the protocol Caddy uses to connect to service containers: IngressBackendHTTP (default)
+	// or IngressBackendH2C.
+	BackendProtocol string `json:",omitempty"`
+	// StreamTimeout is the maximum duration of streaming connections such as WebSockets. 0 means no limit.
+	StreamTimeout time.Duration `json:",omitempty"`
+	// StreamCloseDelay is how long streaming connections such as WebSockets are kept open after Caddy reloads
+	// its config. 0 means they're closed immediately on reload.
+	StreamCloseDelay time.Duration `json:",omitempty"`
+	// MaxBodySize is the maximum size of request bodies in bytes. 0 means unlimited.
+	MaxBodySize int64 `json:",omitempty"`
+	// RequestIDHeader is the request header that carries a unique ID of each request. If set, Caddy generates an ID
+	// for requests without the header, passes it to service containers, returns it in the response, and adds it to
+	// the access log as request_id. Empty means request IDs are disabled.
+	RequestIDHeader string `json:",omitempty"`
+	// TrustedProxies is a list of IP ranges of proxies in front of Caddy, such as a CDN or load balancer. Caddy passes
+	// the X-Forwarded-For, X-Forwarded-Proto, and X-Forwarded-Host headers received from them to service containers.
+	// The headers received from other clients are replaced.
+	TrustedProxies []netip.Prefix `json:",omitempty"`
+}
+
+// RateLimit is the maximum number of requests within a sliding time window.
+type RateLimit struct {
+	Requests uint
+	Window   time.Duration
+}
+
+// ParseRateLimit parses a rate limit in the format '<requests>/<unit>' where unit is s, m, or h, e.g. '100/s'.
+func ParseRateLimit(s string) (RateLimit, error) {
+	num, unit, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit '%s': expected format '<requests>/<unit>', e.g. '100/s'",
+			s)
+	}
+	requests, err := strconv.ParseUint(strings.TrimSpace(num), 10, 32)
+	if err != nil || requests == 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit '%s': number of requests must be a positive integer", s)
+	}
+	window, ok := rateLimitWindows[strings.TrimSpace(unit)]
+	if !ok {
+		return RateLimit{}, fmt.Errorf("invalid rate limit '%s': unit must be one of: s, m, h", s)
+	}
+	return RateLimit{Requests: uint(requests), Window: window}, nil
+}
+
+func (r RateLimit) String() string {
+	for unit, window := range rateLimitWindows {
+		if window == r.Window {
+			return fmt.Sprintf("%d/%s", r.Requests, unit)
+		}
+	}
+	return fmt.Sprintf("%d/%s", r.Requests, r.Window)
+}
+
+// ParseIPPrefix parses an IP address range in CIDR notation or a single IP address that is converted to a prefix
+// with the full length.
+func ParseIPPrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid IP range '%s': %w", s, err)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address '%s': %w", s, err)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func (p *IngressPolicy) Validate() error {
+	if p.RateLimit != nil && (p.RateLimit.Requests == 0 || p.RateLimit.Window <= 0) {
+		return fmt.Errorf("invalid rate limit: number of requests and time window must be positive")
+	}
+	for _, prefix := range slices.Concat(p.Allow, p.Deny) {
+		if !prefix.IsValid() {
+			return fmt.Errorf("invalid IP range in allow or deny list")
+		}
+	}
+	for _, prefix := range p.TrustedProxies {
+		if !prefix.IsValid() {
+			return fmt.Errorf("invalid IP range in trusted proxies")
+		}
+	}
+	if p.RequestIDHeader != "" && !headerNameRegexp.MatchString(p.RequestIDHeader) {
+		return fmt.Errorf("invalid request ID header name '%s'", p.RequestIDHeader)
+	}
+	switch p.BackendProtocol {
+	case "", IngressBackendHTTP, IngressBackendH2C:
+	default:
+		return fmt.Errorf("invalid backend protocol '%s': must be one of: %s, %s",
+			p.BackendProtocol, IngressBackendHTTP, IngressBackendH2C)
+	}
+	if p.StreamTimeout < 0 || p.StreamCloseDelay < 0 {
+		return fmt.Errorf("stream timeout and close delay must not be negative")
+	}
+	if p.MaxBodySize < 0 {
+		return fmt.Errorf("max body size must not be negative")
+	}
+	return nil
+}
+
+// Equals returns true if the policies are equivalent. A nil policy is equal to an empty one.
+func (p *IngressPolicy) Equals(other *IngressPolicy) bool {
+	if p == nil {
+		p = &IngressPolicy{}
+	}
+	if other == nil {
+		other = &IngressPolicy{}
+	}
+	rateLimitEqual := p.RateLimit == nil && other.RateLimit == nil ||
+		p.RateLimit != nil && other.RateLimit != nil && *p.RateLimit == *other.RateLimit
+	return rateLimitEqual &&
+		p.MaxConnections == other.MaxConnections &&
+		slices.Equal(p.Allow, other.Allow) &&
+		slices.Equal(p.Deny, other.Deny) &&
+		p.backendProtocol() == other.backendProtocol() &&
+		p.StreamTimeout == other.StreamTimeout &&
+		p.StreamCloseDelay == other.StreamCloseDelay &&
+		p.MaxBodySize == other.MaxBodySize &&
+		p.RequestIDHeader == other.RequestIDHeader &&
+		slices.Equal(p.TrustedProxies, other.TrustedProxies)
+}
+
+// backendProtocol returns the backend protocol with the default applied.
+func (p *IngressPolicy) backendProtocol() string {
+	if p.BackendProtocol == "" {
+		return IngressBackendHTTP
+	}
+	return p.BackendProtocol
+}
+
+// H2C returns true if Caddy should connect to service containers over cleartext HTTP/2.
+func (p *IngressPolicy) H2C() bool {
+	return p != nil && p.BackendProtocol == IngressBackendH2C
+}
+
+func (p *IngressPolicy) Clone() *IngressPolicy {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	if p.RateLimit != nil {
+		rateLimit := *p.RateLimit
+		clone.RateLimit = &rateLimit
+	}
+	clone.Allow = slices.Clone(p.Allow)
+	clone.Deny = slices.Clone(p.Deny)
+	clone.TrustedProxies = slices.Clone(p.TrustedProxies)
+	return &clone
+}
@@ -0,0 +1,37 @@
+package api
+
+import "time"
+
+const (
+	// MaxIngressEvents is the number of the most recent ingress events kept for each machine.
+	MaxIngressEvents = 50
+
+	// IngressEventLoaded means a new ingress configuration was loaded into Caddy.
+	IngressEventLoaded = "loaded"
+	// IngressEventFailed means Caddy failed to load a new ingress configuration and kept serving the previous one.
+	IngressEventFailed = "failed"
+	// IngressEventReverted means a new ingress configuration was loaded but Caddy stopped serving requests with it,
+	// so the previous configuration was restored.
+	IngressEventReverted = "reverted"
+)
+
+// IngressEvents are the most recent changes of the ingress configuration on a machine.
+type IngressEvents struct {
+	MachineID string `json:"machine_id"`
+	// Events are the most recent ingress events, oldest first.
+	Events []IngressEvent `json:"events,omitempty"`
+}
+
+type IngressEvent struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+}
+
+// AddEvent appends an event keeping only the MaxIngressEvents most recent ones.
+func (e *IngressEvents) AddEvent(event IngressEvent) {
+	e.Events = append(e.Events, event)
+	if len(e.Events) > MaxIngressEvents {
+		e.Events = e.Events[len(e.Events)-MaxIngressEvents:]
+	}
+}
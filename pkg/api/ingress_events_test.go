@@ -0,0 +1,22 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngressEvents_AddEvent(t *testing.T) {
+	t.Parallel()
+
+	var e IngressEvents
+	for i := range MaxIngressEvents + 3 {
+		e.AddEvent(IngressEvent{Time: time.Unix(int64(i), 0), Type: IngressEventLoaded})
+	}
+
+	require.Len(t, e.Events, MaxIngressEvents)
+	assert.Equal(t, time.Unix(3, 0), e.Events[0].Time, "oldest events should be dropped")
+	assert.Equal(t, time.Unix(MaxIngressEvents+2, 0), e.Events[MaxIngressEvents-1].Time)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+)
+
+// IngressRouteIssue describes an ingress route (Caddy site) that is defined by more than one service.
+type IngressRouteIssue struct {
+	// Route is the site address, e.g. https://app.example.com or https://example.com/api/*.
+	Route string
+	// Services are the names of the services that define the route sorted alphabetically.
+	Services []string
+	// Conflict is true if the route definitions conflict so that Caddy skips or ignores some of them.
+	// Otherwise, the issue is a warning about a route that is likely shared by mistake.
+	Conflict bool
+	Message  string
+}
+
+// routeSource is a definition of an ingress route by a service.
+type routeSource struct {
+	service string
+	// caddy is true if the route is defined in the custom Caddy config (x-caddy) rather than generated
+	// from the ingress ports.
+	caddy bool
+	spec  *ServiceSpec
+}
+
+// IngressRouteIssues checks the ingress routes of the given services for conflicts. Routes are generated from
+// the HTTP and HTTPS ingress ports and the site addresses in custom Caddy configs. The returned issues are sorted
+// by route.
+func IngressRouteIssues(specs []ServiceSpec) []IngressRouteIssue {
+	routes := make(map[string][]routeSource)
+	for i := range specs {
+		spec := &specs[i]
+		for _, port := range spec.Ports {
+			if port.Mode != "" && port.Mode != PortModeIngress {
+				continue
+			}
+			if port.Protocol != ProtocolHTTP && port.Protocol != ProtocolHTTPS {
+				continue
+			}
+			route := port.Protocol + "://" + strings.ToLower(port.Hostname)
+			if !slices.ContainsFunc(routes[route], func(s routeSource) bool { return s.service == spec.Name }) {
+				routes[route] = append(routes[route], routeSource{service: spec.Name, spec: spec})
+			}
+		}
+		for _, addr := range caddySiteAddresses(spec.CaddyConfig()) {
+			route := normalizeSiteAddress(addr)
+			if !slices.ContainsFunc(routes[route], func(s routeSource) bool { return s.service == spec.Name }) {
+				routes[route] = append(routes[route], routeSource{service: spec.Name, caddy: true, spec: spec})
+			}
+		}
+	}
+
+	var issues []IngressRouteIssue
+	for _, route := range slices.Sorted(maps.Keys(routes)) {
+		sources := routes[route]
+		if len(sources) < 2 {
+			continue
+		}
+		slices.SortFunc(sources, func(a, b routeSource) int { return strings.Compare(a.service, b.service) })
+
+		issue := IngressRouteIssue{Route: route}
+		custom := false
+		sameSettings := true
+		for _, s := range sources {
+			issue.Services = append(issue.Services, s.service)
+			custom = custom || s.caddy
+			sameSettings = sameSettings && s.spec.Ingress.Equals(sources[0].spec.Ingress) &&
+				s.spec.CaddyDirectives() == sources[0].spec.CaddyDirectives()
+		}
+
+		switch {
+		case custom:
+			issue.Conflict = true
+			issue.Message = "the route is defined in the Caddy config (x-caddy) of one service and by another " +
+				"service, Caddy config of only one of them is used"
+		case !sameSettings:
+			issue.Conflict = true
+			issue.Message = fmt.Sprintf("the services publish the same hostname with different ingress policies "+
+				"or Caddy directives, only the settings of service '%s' are used", sources[0].service)
+		default:
+			issue.Message = "requests are load balanced across the containers of all the services"
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// caddySiteAddresses returns the site addresses of the site blocks in the Caddyfile config. Snippets, named routes,
+// and the global options block are skipped. It's a best-effort scanner for detecting conflicts and doesn't validate
+// the config.
+func caddySiteAddresses(config string) []string {
+	var addresses, keys []string
+	depth := 0
+	for _, line := range strings.Split(config, "\n") {
+		for _, token := range caddyfileLineTokens(line) {
+			switch token {
+			case "{":
+				if depth == 0 {
+					for _, k := range keys {
+						if !strings.HasPrefix(k, "(") && !strings.HasPrefix(k, "&(") {
+							addresses = append(addresses, k)
+						}
+					}
+					keys = nil
+				}
+				depth++
+			case "}":
+				depth = max(depth-1, 0)
+			default:
+				if depth == 0 {
+					for _, k := range strings.Split(token, ",") {
+						if k != "" {
+							keys = append(keys, k)
+						}
+					}
+				}
+			}
+		}
+		// Site addresses not followed by a brace on the same line start a site block without braces. It must be
+		// the only site block in the config, and the following lines are its directives. A trailing comma continues
+		// the addresses on the next line.
+		if depth == 0 && len(keys) > 0 && !strings.HasSuffix(strings.TrimSpace(line), ",") {
+			if keys[0] == "import" {
+				// Top-level import of a snippet or file.
+				keys = nil
+				continue
+			}
+			return append(addresses, keys...)
+		}
+	}
+	return addresses
+}
+
+// caddyfileLineTokens splits a Caddyfile line into tokens ignoring comments. Quoted tokens are not supported but
+// they're not expected in site addresses.
+func caddyfileLineTokens(line string) []string {
+	var tokens []string
+	for _, field := range strings.Fields(line) {
+		if strings.HasPrefix(field, "#") {
+			break
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// normalizeSiteAddress converts a Caddy site address to the scheme://host[:port][/path] form. Addresses without
+// a scheme use https unless the port is 80. The default ports are omitted.
+func normalizeSiteAddress(addr string) string {
+	scheme := ""
+	if s, rest, ok := strings.Cut(addr, "://"); ok {
+		scheme, addr = strings.ToLower(s), rest
+	}
+	hostPort, path := addr, ""
+	if i := strings.Index(addr, "/"); i >= 0 {
+		hostPort, path = addr[:i], addr[i:]
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		host, port = hostPort, ""
+	}
+	if scheme == "" {
+		scheme = "https"
+		if port == "80" {
+			scheme = "http"
+		}
+	}
+	if scheme == "http" && port == "80" || scheme == "https" && port == "443" {
+		port = ""
+	}
+
+	route := scheme + "://" + strings.ToLower(host)
+	if port != "" {
+		route += ":" + port
+	}
+	return route + path
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngressRouteIssues(t *testing.T) {
+	t.Parallel()
+
+	httpsPort := func(hostname string) PortSpec {
+		return PortSpec{Hostname: hostname, ContainerPort: 8080, Protocol: ProtocolHTTPS, Mode: PortModeIngress}
+	}
+
+	tests := []struct {
+		name  string
+		specs []ServiceSpec
+		want  []IngressRouteIssue
+	}{
+		{
+			name: "no shared routes",
+			specs: []ServiceSpec{
+				{Name: "web", Ports: []PortSpec{httpsPort("app.example.com")}},
+				{Name: "api", Ports: []PortSpec{httpsPort("api.example.com")}},
+				{Name: "docs", Caddy: &CaddySpec{Config: "docs.example.com {\n\treverse_proxy {{upstreams}}\n}"}},
+			},
+		},
+		{
+			name: "same hostname with same settings",
+			specs: []ServiceSpec{
+				{Name: "web-blue", Ports: []PortSpec{httpsPort("app.example.com")}},
+				{Name: "web-green", Ports: []PortSpec{httpsPort("App.example.com")}},
+			},
+			want: []IngressRouteIssue{{
+				Route:    "https://app.example.com",
+				Services: []string{"web-blue", "web-green"},
+				Message:  "requests are load balanced across the containers of all the services",
+			}},
+		},
+		{
+			name: "same hostname with different policies",
+			specs: []ServiceSpec{
+				{Name: "web", Ports: []PortSpec{httpsPort("app.example.com")}},
+				{
+					Name:    "admin",
+					Ports:   []PortSpec{httpsPort("app.example.com")},
+					Ingress: &IngressPolicy{MaxConnections: 10},
+				},
+			},
+			want: []IngressRouteIssue{{
+				Route:    "https://app.example.com",
+				Services: []string{"admin", "web"},
+				Conflict: true,
+				Message: "the services publish the same hostname with different ingress policies or Caddy " +
+					"directives, only the settings of service 'admin' are used",
+			}},
+		},
+		{
+			name: "Caddy config site conflicts with port",
+			specs: []ServiceSpec{
+				{Name: "web", Ports: []PortSpec{httpsPort("app.example.com")}},
+				{Name: "proxy", Caddy: &CaddySpec{Config: "app.example.com:443 {\n\trespond 200\n}"}},
+			},
+			want: []IngressRouteIssue{{
+				Route:    "https://app.example.com",
+				Services: []string{"proxy", "web"},
+				Conflict: true,
+				Message: "the route is defined in the Caddy config (x-caddy) of one service and by another " +
+					"service, Caddy config of only one of them is used",
+			}},
+		},
+		{
+			name: "different paths and schemes don't conflict",
+			specs: []ServiceSpec{
+				{Name: "web", Ports: []PortSpec{httpsPort("example.com")}},
+				{Name: "api", Caddy: &CaddySpec{Config: "example.com/api/* {\n\trespond 200\n}"}},
+				{Name: "legacy", Caddy: &CaddySpec{Config: "http://example.com {\n\trespond 200\n}"}},
+			},
+		},
+		{
+			name: "same path in Caddy configs",
+			specs: []ServiceSpec{
+				{Name: "api", Caddy: &CaddySpec{Config: "example.com/api/* {\n\trespond 200\n}"}},
+				{Name: "api-v2", Caddy: &CaddySpec{Config: "https://example.com/api/* {\n\trespond 200\n}"}},
+			},
+			want: []IngressRouteIssue{{
+				Route:    "https://example.com/api/*",
+				Services: []string{"api", "api-v2"},
+				Conflict: true,
+				Message: "the route is defined in the Caddy config (x-caddy) of one service and by another " +
+					"service, Caddy config of only one of them is used",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, IngressRouteIssues(tt.specs))
+		})
+	}
+}
+
+func TestCaddySiteAddresses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "global options, snippets, and sites",
+			config: `{
+	email admin@example.com
+}
+
+(common) {
+	header -Server
+}
+
+# Main site.
+example.com, www.example.com {
+	import common
+	handle /api/* {
+		reverse_proxy {{upstreams "api"}}
+	}
+}
+
+http://legacy.example.com:8080,
+	https://old.example.com { # Old domains.
+	redir https://example.com{uri}
+}
+`,
+			want: []string{"example.com", "www.example.com", "http://legacy.example.com:8080", "https://old.example.com"},
+		},
+		{
+			name:   "site without braces",
+			config: "import common\nexample.com\nreverse_proxy {{upstreams}}\n",
+			want:   []string{"example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, caddySiteAddresses(tt.config))
+		})
+	}
+}
+
+func TestNormalizeSiteAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://example.com", normalizeSiteAddress("Example.com"))
+	assert.Equal(t, "https://example.com", normalizeSiteAddress("example.com:443"))
+	assert.Equal(t, "http://example.com", normalizeSiteAddress("example.com:80"))
+	assert.Equal(t, "http://example.com:8080/api/*", normalizeSiteAddress("HTTP://example.com:8080/api/*"))
+	assert.Equal(t, "https://:8443", normalizeSiteAddress(":8443"))
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input      string
+		want       RateLimit
+		wantString string
+		wantErr    string
+	}{
+		{input: "100/s", want: RateLimit{Requests: 100, Window: time.Second}, wantString: "100/s"},
+		{input: " 600 / m ", want: RateLimit{Requests: 600, Window: time.Minute}, wantString: "600/m"},
+		{input: "1000/h", want: RateLimit{Requests: 1000, Window: time.Hour}, wantString: "1000/h"},
+		{input: "100", wantErr: "expected format"},
+		{input: "0/s", wantErr: "must be a positive integer"},
+		{input: "-1/s", wantErr: "must be a positive integer"},
+		{input: "10/d", wantErr: "unit must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRateLimit(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantString, got.String())
+		})
+	}
+}
+
+func TestParseIPPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    netip.Prefix
+		wantErr bool
+	}{
+		{input: "10.0.0.0/8", want: netip.MustParsePrefix("10.0.0.0/8")},
+		{input: "10.1.2.3/8", want: netip.MustParsePrefix("10.0.0.0/8")},
+		{input: "203.0.113.7", want: netip.MustParsePrefix("203.0.113.7/32")},
+		{input: "2001:db8::1", want: netip.MustParsePrefix("2001:db8::1/128")},
+		{input: "10.0.0.0/33", wantErr: true},
+		{input: "example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseIPPrefix(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServiceSpec_Validate_Ingress(t *testing.T) {
+	t.Parallel()
+
+	ingressPort := PortSpec{
+		Hostname: "app.example.com", ContainerPort: 8080, Protocol: ProtocolHTTPS, Mode: PortModeIngress,
+	}
+	hostPort := PortSpec{PublishedPort: 8080, ContainerPort: 8080, Protocol: ProtocolTCP, Mode: PortModeHost}
+
+	tests := []struct {
+		name    string
+		ports   []PortSpec
+		policy  *IngressPolicy
+		wantErr string
+	}{
+		{
+			name:  "valid policy",
+			ports: []PortSpec{ingressPort},
+			policy: &IngressPolicy{
+				RateLimit:      &RateLimit{Requests: 10, Window: time.Second},
+				MaxConnections: 100,
+				Allow:          []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			},
+		},
+		{
+			name:    "no ingress ports",
+			ports:   []PortSpec{hostPort},
+			policy:  &IngressPolicy{MaxConnections: 100},
+			wantErr: "requires at least one HTTP or HTTPS ingress port",
+		},
+		{
+			name:    "invalid rate limit",
+			ports:   []PortSpec{ingressPort},
+			policy:  &IngressPolicy{RateLimit: &RateLimit{Requests: 10}},
+			wantErr: "invalid rate limit",
+		},
+		{
+			name:    "invalid IP range",
+			ports:   []PortSpec{ingressPort},
+			policy:  &IngressPolicy{Deny: []netip.Prefix{{}}},
+			wantErr: "invalid IP range",
+		},
+		{
+			name:  "valid proxying options",
+			ports: []PortSpec{ingressPort},
+			policy: &IngressPolicy{
+				BackendProtocol:  IngressBackendH2C,
+				StreamTimeout:    time.Hour,
+				StreamCloseDelay: 5 * time.Minute,
+				MaxBodySize:      10 << 20,
+			},
+		},
+		{
+			name:    "invalid backend protocol",
+			ports:   []PortSpec{ingressPort},
+			policy:  &IngressPolicy{BackendProtocol: "grpc"},
+			wantErr: "invalid backend protocol 'grpc'",
+		},
+		{
+			name:    "negative stream timeout",
+			ports:   []PortSpec{ingressPort},
+			policy:  &IngressPolicy{StreamTimeout: -time.Second},
+			wantErr: "must not be negative",
+		},
+		{
+			name:  "valid request tracing options",
+			ports: []PortSpec{ingressPort},
+			policy: &IngressPolicy{
+				RequestIDHeader: "X-Correlation-ID",
+				TrustedProxies:  []netip.Prefix{netip.MustParsePrefix("173.245.48.0/20")},
+			},
+		},
+		{
+			name:    "invalid request ID header",
+			ports:   []PortSpec{ingressPort},
+			policy:  &IngressPolicy{RequestIDHeader: "X Request ID"},
+			wantErr: "invalid request ID header name 'X Request ID'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := ServiceSpec{
+				Name:      "app",
+				Container: ContainerSpec{Image: "app:latest"},
+				Ports:     tt.ports,
+				Ingress:   tt.policy,
+			}
+			err := spec.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIngressPolicy_Equals(t *testing.T) {
+	t.Parallel()
+
+	policy := &IngressPolicy{
+		RateLimit: &RateLimit{Requests: 10, Window: time.Second},
+		Deny:      []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")},
+	}
+
+	assert.True(t, (*IngressPolicy)(nil).Equals(&IngressPolicy{}))
+	assert.True(t, policy.Equals(policy.Clone()))
+	assert.False(t, policy.Equals(nil))
+
+	changed := policy.Clone()
+	changed.RateLimit.Requests = 20
+	assert.False(t, policy.Equals(changed))
+	assert.Equal(t, uint(10), policy.RateLimit.Requests, "clone must not share the rate limit")
+
+	changed = policy.Clone()
+	changed.MaxBodySize = 1024
+	assert.False(t, policy.Equals(changed))
+
+	changed = policy.Clone()
+	changed.RequestIDHeader = DefaultRequestIDHeader
+	assert.False(t, policy.Equals(changed))
+
+	changed = policy.Clone()
+	changed.TrustedProxies = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	assert.False(t, policy.Equals(changed))
+
+	assert.True(t, (&IngressPolicy{BackendProtocol: IngressBackendHTTP}).Equals(nil),
+		"explicit default backend protocol must equal unset")
+	assert.False(t, (&IngressPolicy{BackendProtocol: IngressBackendH2C}).Equals(nil))
+}
@@ -1,6 +1,60 @@
 package api
 
-import "github.com/psviderski/uncloud/internal/machine/api/pb"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// Machine roles designate machines for specific jobs in the cluster. A role only takes effect when at least one
+// machine in the cluster has it. Otherwise, any machine can do the job.
+const (
+	// MachineRoleBuilder designates a machine to run image builds and serve built images to other machines.
+	MachineRoleBuilder = "builder"
+	// MachineRoleControlPlane designates a machine to coordinate cluster-wide tasks such as image auto-updates.
+	MachineRoleControlPlane = "control-plane"
+	// MachineRoleIngress designates a machine to run the Caddy reverse proxy and serve ingress traffic.
+	MachineRoleIngress = "ingress"
+	// MachineRoleWorker designates a machine to run containers of replicated services without explicit placement.
+	MachineRoleWorker = "worker"
+)
+
+// MachineRoles lists all supported machine roles.
+var MachineRoles = []string{MachineRoleBuilder, MachineRoleControlPlane, MachineRoleIngress, MachineRoleWorker}
+
+// ValidateMachineRoles checks that all roles are supported and not duplicated.
+func ValidateMachineRoles(roles []string) error {
+	seen := make(map[string]struct{}, len(roles))
+	for _, r := range roles {
+		if !slices.Contains(MachineRoles, r) {
+			return fmt.Errorf("unknown machine role '%s', supported roles: %s", r, strings.Join(MachineRoles, ", "))
+		}
+		if _, ok := seen[r]; ok {
+			return fmt.Errorf("duplicate machine role '%s'", r)
+		}
+		seen[r] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateMachineZone checks that the zone is empty or a valid zone name such as 'eu-central' or 'fsn1'.
+func ValidateMachineZone(zone string) error {
+	if zone == "" {
+		return nil
+	}
+	if len(zone) > 63 || !dnsLabelRegexp.MatchString(zone) {
+		return fmt.Errorf("invalid zone '%s': must be 1-63 characters, lowercase letters, numbers, and dashes only; "+
+			"must start and end with a letter or number", zone)
+	}
+	return nil
+}
+
+// HasMachineRole returns true if the machine has the given role.
+func HasMachineRole(m *pb.MachineInfo, role string) bool {
+	return m != nil && slices.Contains(m.Roles, role)
+}
 
 // MachineFilter defines criteria to filter machines in ListMachines.
 type MachineFilter struct {
@@ -21,3 +75,20 @@ func (m MachineMembersList) FindByNameOrID(nameOrID string) *pb.MachineMember {
 
 	return nil
 }
+
+// WithRolePreferred returns a copy of the list where machines with the given role come first. The relative order
+// of machines is preserved otherwise.
+func (m MachineMembersList) WithRolePreferred(role string) MachineMembersList {
+	sorted := slices.Clone(m)
+	slices.SortStableFunc(sorted, func(a, b *pb.MachineMember) int {
+		aHas, bHas := HasMachineRole(a.Machine, role), HasMachineRole(b.Machine, role)
+		switch {
+		case aHas && !bHas:
+			return -1
+		case !aHas && bHas:
+			return 1
+		}
+		return 0
+	})
+	return sorted
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMachineRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   []string
+		wantErr string
+	}{
+		{name: "no roles"},
+		{name: "builder", roles: []string{MachineRoleBuilder}},
+		{name: "unknown", roles: []string{"gpu"}, wantErr: "unknown machine role 'gpu'"},
+		{
+			name:    "duplicate",
+			roles:   []string{MachineRoleBuilder, MachineRoleBuilder},
+			wantErr: "duplicate machine role 'builder'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMachineRoles(tt.roles)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMachineMembersList_WithRolePreferred(t *testing.T) {
+	member := func(name string, roles ...string) *pb.MachineMember {
+		return &pb.MachineMember{Machine: &pb.MachineInfo{Name: name, Roles: roles}}
+	}
+	names := func(l MachineMembersList) []string {
+		var res []string
+		for _, m := range l {
+			res = append(res, m.Machine.Name)
+		}
+		return res
+	}
+
+	list := MachineMembersList{
+		member("a"),
+		member("b", MachineRoleBuilder),
+		member("c"),
+		member("d", MachineRoleBuilder),
+	}
+
+	assert.Equal(t, []string{"b", "d", "a", "c"}, names(list.WithRolePreferred(MachineRoleBuilder)))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names(list), "original list must not be modified")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names(list.WithRolePreferred("other")))
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MeshCertValidity is the validity period of the certificates issued by the mesh CA for mTLS-enabled services.
+	MeshCertValidity = 24 * time.Hour
+	// DefaultMeshClockTolerance is the default clock difference between machines tolerated by mesh certificates.
+	DefaultMeshClockTolerance = 5 * time.Minute
+	// DefaultMeshRenewBefore is the default time before expiration when a mesh certificate is reissued.
+	DefaultMeshRenewBefore = time.Hour
+)
+
+// MeshConfig configures the certificates issued by the mesh CA for mTLS-enabled services. The certificates are
+// issued by each machine using its own clock, so the windows protect the mTLS connections from clock drift between
+// machines, e.g. on devices without a real-time clock that boot with a stale time.
+type MeshConfig struct {
+	// ClockTolerance is how far back the validity of issued certificates starts before the time they're issued.
+	// Machines with clocks behind the issuing machine by up to this value accept the certificates. Zero means
+	// DefaultMeshClockTolerance.
+	ClockTolerance time.Duration `json:"clock_tolerance,omitempty"`
+	// RenewBefore is the time before expiration when a certificate is reissued. Machines with clocks ahead of
+	// the issuing machine by up to this value accept the certificates. Zero means DefaultMeshRenewBefore.
+	RenewBefore time.Duration `json:"renew_before,omitempty"`
+}
+
+func (c MeshConfig) Validate() error {
+	// Keep at least half of the validity period for using a certificate before it's renewed.
+	maxWindow := MeshCertValidity / 2
+	if c.ClockTolerance < 0 || c.ClockTolerance > maxWindow {
+		return fmt.Errorf("clock tolerance must be between 0 and %s", maxWindow)
+	}
+	if c.RenewBefore < 0 || c.RenewBefore > maxWindow {
+		return fmt.Errorf("renew before must be between 0 and %s", maxWindow)
+	}
+	return nil
+}
+
+// WithDefaults returns the configuration with the unset windows replaced by their defaults.
+func (c MeshConfig) WithDefaults() MeshConfig {
+	if c.ClockTolerance == 0 {
+		c.ClockTolerance = DefaultMeshClockTolerance
+	}
+	if c.RenewBefore == 0 {
+		c.RenewBefore = DefaultMeshRenewBefore
+	}
+	return c
+}
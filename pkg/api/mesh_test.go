@@ -0,0 +1,46 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeshConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		c       MeshConfig
+		wantErr string
+	}{
+		{name: "empty"},
+		{name: "valid", c: MeshConfig{ClockTolerance: time.Hour, RenewBefore: 6 * time.Hour}},
+		{name: "negative clock tolerance", c: MeshConfig{ClockTolerance: -time.Minute}, wantErr: "clock tolerance"},
+		{name: "clock tolerance too large", c: MeshConfig{ClockTolerance: 13 * time.Hour}, wantErr: "between 0 and 12h"},
+		{name: "renew before too large", c: MeshConfig{RenewBefore: 24 * time.Hour}, wantErr: "renew before"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.c.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMeshConfig_WithDefaults(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MeshConfig{ClockTolerance: DefaultMeshClockTolerance, RenewBefore: DefaultMeshRenewBefore},
+		MeshConfig{}.WithDefaults())
+	assert.Equal(t, MeshConfig{ClockTolerance: time.Hour, RenewBefore: DefaultMeshRenewBefore},
+		MeshConfig{ClockTolerance: time.Hour}.WithDefaults())
+}
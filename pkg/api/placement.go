@@ -1,8 +1,104 @@
 package api
 
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
 // Placement defines the placement constraints for service containers.
 type Placement struct {
 	// Machines is a list of machine names or IDs where service containers are allowed to be deployed.
 	// If empty, containers can be deployed to any available machine in the cluster.
 	Machines []string `json:",omitempty"`
+	// ExcludeMachines is a list of machine names or IDs where service containers must not be deployed.
+	ExcludeMachines []string `json:",omitempty"`
+	// MachineReplicas is the number of containers to run on each machine by machine name or ID. The total must match
+	// the number of service replicas. It can't be combined with the other placement constraints.
+	MachineReplicas map[string]uint `json:",omitempty"`
+	// MachineCount limits the containers to any MachineCount of the allowed machines. Zero means no limit.
+	MachineCount uint `json:",omitempty"`
+	// SpreadZones distributes the containers evenly across the zones of the allowed machines so that losing
+	// a zone doesn't take out all containers. Machines without a zone are considered to be in the same zone.
+	SpreadZones bool `json:",omitempty"`
+}
+
+// AllowedMachines returns the names or IDs of the machines where containers are allowed to be deployed including
+// the machines with replica counts, sorted. An empty result means any machine except the excluded ones.
+func (p Placement) AllowedMachines() []string {
+	machines := slices.Concat(p.Machines, slices.Collect(maps.Keys(p.MachineReplicas)))
+	slices.Sort(machines)
+	return slices.Compact(machines)
+}
+
+// HasMachines returns true if the placement explicitly lists the machines where containers can be deployed.
+func (p Placement) HasMachines() bool {
+	return len(p.Machines) > 0 || len(p.MachineReplicas) > 0
+}
+
+// MachineReplicasTotal returns the total number of containers across all machines in MachineReplicas.
+func (p Placement) MachineReplicasTotal() uint {
+	var total uint
+	for _, n := range p.MachineReplicas {
+		total += n
+	}
+	return total
+}
+
+func (p Placement) Clone() Placement {
+	return Placement{
+		Machines:        slices.Clone(p.Machines),
+		ExcludeMachines: slices.Clone(p.ExcludeMachines),
+		MachineReplicas: maps.Clone(p.MachineReplicas),
+		MachineCount:    p.MachineCount,
+		SpreadZones:     p.SpreadZones,
+	}
+}
+
+// Validate checks that the placement constraints don't contradict each other and can be satisfied by a service
+// with the given mode and number of replicas.
+func (p Placement) Validate(mode string, replicas uint) error {
+	for _, m := range p.ExcludeMachines {
+		if slices.Contains(p.AllowedMachines(), m) {
+			return fmt.Errorf("machine '%s' is both allowed and excluded", m)
+		}
+	}
+
+	if len(p.MachineReplicas) > 0 {
+		if mode == ServiceModeGlobal {
+			return errors.New("replica counts per machine are not supported in global mode")
+		}
+		if len(p.Machines) > 0 || len(p.ExcludeMachines) > 0 || p.MachineCount > 0 || p.SpreadZones {
+			return errors.New("replica counts per machine can't be combined with other machine constraints")
+		}
+		for _, m := range slices.Sorted(maps.Keys(p.MachineReplicas)) {
+			if p.MachineReplicas[m] == 0 {
+				return fmt.Errorf("replica count for machine '%s' must be greater than 0", m)
+			}
+		}
+		if replicas == 0 {
+			// The default number of replicas for a replicated service.
+			replicas = 1
+		}
+		if total := p.MachineReplicasTotal(); replicas != total {
+			return fmt.Errorf("replica counts per machine add up to %d but the service has %d replicas",
+				total, replicas)
+		}
+	}
+
+	if p.MachineCount > 0 {
+		if mode == ServiceModeGlobal {
+			return errors.New("a number of machines to choose from is not supported in global mode")
+		}
+		if len(p.Machines) > 0 && int(p.MachineCount) > len(p.Machines) {
+			return fmt.Errorf("can't choose any %d of %d machines", p.MachineCount, len(p.Machines))
+		}
+	}
+
+	if p.SpreadZones && mode == ServiceModeGlobal {
+		return errors.New("spreading across zones is not supported in global mode")
+	}
+
+	return nil
 }
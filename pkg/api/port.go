@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"net/netip"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -18,11 +19,22 @@ const (
 	ProtocolUDP   = "udp"
 )
 
+// portNameRegexp matches valid port names: up to 15 lowercase letters, numbers, and dashes, starting with a letter
+// and ending with a letter or number.
+var portNameRegexp = regexp.MustCompile(`^[a-z]([a-z0-9-]{0,13}[a-z0-9])?$`)
+
 type PortSpec struct {
+	// Name is an optional name of the port. Caddy templates and container health checks can reference the port
+	// by its name instead of the number. Ports with the same name must have the same ContainerPort.
+	Name string `json:",omitempty"`
 	// Hostname specifies the DNS name that will route to this service. Only valid in ingress mode.
 	Hostname string
 	// HostIP is the host IP to bind the PublishedPort to. Only valid in host mode.
 	HostIP netip.Addr
+	// HostInterface is the name of the machine network interface to bind the PublishedPort to, for example, eth1.
+	// The port is bound to the IPv4 addresses of the interface on each machine or its IPv6 addresses if it has
+	// no IPv4 ones. Only valid in host mode and can't be combined with HostIP.
+	HostInterface string `json:",omitempty"`
 	// PublishedPort is the port number exposed outside the container.
 	// In ingress mode, this is the load balancer port. In host mode, this is the port bound on the host.
 	PublishedPort uint16
@@ -38,6 +50,10 @@ func (p *PortSpec) Validate() error {
 	if p.ContainerPort == 0 {
 		return fmt.Errorf("container port must be non-zero")
 	}
+	if p.Name != "" && !portNameRegexp.MatchString(p.Name) {
+		return fmt.Errorf("invalid port name '%s': must be 1-15 lowercase letters, numbers, and dashes, "+
+			"start with a letter, and end with a letter or number", p.Name)
+	}
 
 	switch p.Protocol {
 	case "":
@@ -55,6 +71,9 @@ func (p *PortSpec) Validate() error {
 		if p.HostIP.IsValid() {
 			return fmt.Errorf("host IP cannot be specified in %s mode", PortModeIngress)
 		}
+		if p.HostInterface != "" {
+			return fmt.Errorf("host interface cannot be specified in %s mode", PortModeIngress)
+		}
 		if p.Hostname != "" {
 			if p.Protocol != ProtocolHTTP && p.Protocol != ProtocolHTTPS {
 				return fmt.Errorf("hostname is only valid with '%s' or '%s' protocols", ProtocolHTTP, ProtocolHTTPS)
@@ -74,6 +93,14 @@ func (p *PortSpec) Validate() error {
 		if p.Hostname != "" {
 			return fmt.Errorf("hostname cannot be specified in %s mode", PortModeHost)
 		}
+		if p.HostInterface != "" {
+			if p.HostIP.IsValid() {
+				return fmt.Errorf("host IP and host interface cannot be specified simultaneously")
+			}
+			if err := validateInterfaceName(p.HostInterface); err != nil {
+				return fmt.Errorf("invalid host interface '%s': %w", p.HostInterface, err)
+			}
+		}
 	default:
 		return fmt.Errorf("invalid mode: '%s'", p.Mode)
 	}
@@ -83,13 +110,17 @@ func (p *PortSpec) Validate() error {
 
 // String returns the port specification in the -p/--publish flag format.
 // Format:
-// [hostname:][load_balancer_port:]container_port/protocol for ingress mode (default) or
-// [host_ip:]:host_port:container_port/protocol@host for host mode.
+// [name=][hostname:][load_balancer_port:]container_port/protocol for ingress mode (default) or
+// [name=][host_ip|%host_interface:]:host_port:container_port/protocol@host for host mode.
 func (p *PortSpec) String() (string, error) {
 	if err := p.Validate(); err != nil {
 		return "", err
 	}
 
+	name := ""
+	if p.Name != "" {
+		name = p.Name + "="
+	}
 	var parts []string
 
 	switch p.Mode {
@@ -102,9 +133,11 @@ func (p *PortSpec) String() (string, error) {
 		}
 		parts = append(parts, fmt.Sprint(p.ContainerPort))
 
-		return fmt.Sprintf("%s/%s", strings.Join(parts, ":"), p.Protocol), nil
-	case PortModeHost: // [host_ip:]:host_port:container_port/protocol@host
-		if p.HostIP.IsValid() {
+		return fmt.Sprintf("%s%s/%s", name, strings.Join(parts, ":"), p.Protocol), nil
+	case PortModeHost: // [host_ip|%host_interface:]:host_port:container_port/protocol@host
+		if p.HostInterface != "" {
+			parts = append(parts, "%"+p.HostInterface)
+		} else if p.HostIP.IsValid() {
 			if p.HostIP.Is6() {
 				parts = append(parts, fmt.Sprintf("[%s]", p.HostIP))
 			} else {
@@ -114,19 +147,31 @@ func (p *PortSpec) String() (string, error) {
 		parts = append(parts, fmt.Sprint(p.PublishedPort))
 		parts = append(parts, fmt.Sprint(p.ContainerPort))
 
-		return fmt.Sprintf("%s/%s@host", strings.Join(parts, ":"), p.Protocol), nil
+		return fmt.Sprintf("%s%s/%s@host", name, strings.Join(parts, ":"), p.Protocol), nil
 	default:
 		return "", fmt.Errorf("not implemented for mode: '%s'", p.Mode)
 	}
 }
 
+// PortEnvVar returns the name of the environment variable with the container port number of the named port that is
+// injected into service containers, for example, UNCLOUD_PORT_GRPC_WEB for the port named 'grpc-web'.
+func PortEnvVar(name string) string {
+	return "UNCLOUD_PORT_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
 func ParsePortSpec(port string) (PortSpec, error) {
 	spec := PortSpec{
 		Protocol: ProtocolTCP,     // Default protocol.
 		Mode:     PortModeIngress, // Default mode.
 	}
 
-	// Split off mode first.
+	// Split off the optional name first.
+	if name, rest, ok := strings.Cut(port, "="); ok {
+		spec.Name = name
+		port = rest
+	}
+
+	// Split off mode.
 	parts := strings.Split(port, "@")
 	if len(parts) > 2 {
 		return spec, fmt.Errorf("too many '@' symbols")
@@ -196,22 +241,29 @@ func ParsePortSpec(port string) (PortSpec, error) {
 		}
 
 		if spec.Mode == PortModeHost {
-			// In host mode, the first part must be IP.
-			ip := parts[0]
-			// Strip brackets from IPv6 address if present.
-			if strings.Contains(ip, ":") {
-				if !strings.HasPrefix(ip, "[") {
-					return spec, fmt.Errorf(
-						"invalid host IP '%s': IPv6 address must be enclosed in square brackets", ip)
+			// In host mode, the first part must be IP or interface name prefixed with '%'.
+			if iface, ok := strings.CutPrefix(parts[0], "%"); ok {
+				if err = validateInterfaceName(iface); err != nil {
+					return spec, fmt.Errorf("invalid host interface '%s': %w", iface, err)
 				}
-				if !strings.HasSuffix(ip, "]") {
-					return spec, fmt.Errorf("invalid host IP '%s': missing closing bracket", ip)
+				spec.HostInterface = iface
+			} else {
+				ip := parts[0]
+				// Strip brackets from IPv6 address if present.
+				if strings.Contains(ip, ":") {
+					if !strings.HasPrefix(ip, "[") {
+						return spec, fmt.Errorf(
+							"invalid host IP '%s': IPv6 address must be enclosed in square brackets", ip)
+					}
+					if !strings.HasSuffix(ip, "]") {
+						return spec, fmt.Errorf("invalid host IP '%s': missing closing bracket", ip)
+					}
+					ip = ip[1 : len(ip)-1]
 				}
-				ip = ip[1 : len(ip)-1]
-			}
 
-			if spec.HostIP, err = netip.ParseAddr(ip); err != nil {
-				return spec, fmt.Errorf("invalid host IP '%s': %w", parts[0], err)
+				if spec.HostIP, err = netip.ParseAddr(ip); err != nil {
+					return spec, fmt.Errorf("invalid host IP '%s': %w", parts[0], err)
+				}
 			}
 		} else {
 			// Hostname may be empty.
@@ -253,6 +305,21 @@ func parsePort(s string) (uint16, error) {
 	return uint16(port), nil
 }
 
+// validateInterfaceName checks that the name is a valid Linux network interface name.
+func validateInterfaceName(name string) error {
+	if name == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	// IFNAMSIZ is 16 bytes including the null terminator.
+	if len(name) > 15 {
+		return fmt.Errorf("must be at most 15 characters long")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, "/:,@% \t\n") {
+		return fmt.Errorf("must not be '.' or '..' and must not contain whitespace or '/:,@%%' characters")
+	}
+	return nil
+}
+
 func validateHostname(hostname string) error {
 	if hostname == "" {
 		return fmt.Errorf("must not be empty")
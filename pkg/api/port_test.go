@@ -191,6 +191,28 @@ func TestPortSpec_Validate(t *testing.T) {
 			},
 			wantErr: "host IP cannot be specified in ingress mode",
 		},
+		{
+			name: "host interface in ingress mode",
+			spec: PortSpec{
+				HostInterface: "eth1",
+				ContainerPort: 8080,
+				Protocol:      ProtocolTCP,
+				Mode:          PortModeIngress,
+			},
+			wantErr: "host interface cannot be specified in ingress mode",
+		},
+		{
+			name: "host IP and interface in host mode",
+			spec: PortSpec{
+				HostIP:        netip.MustParseAddr("10.0.0.1"),
+				HostInterface: "eth1",
+				PublishedPort: 80,
+				ContainerPort: 8080,
+				Protocol:      ProtocolTCP,
+				Mode:          PortModeHost,
+			},
+			wantErr: "host IP and host interface cannot be specified simultaneously",
+		},
 		{
 			name: "zero published port in host mode",
 			spec: PortSpec{
@@ -358,6 +380,28 @@ func TestPortSpec_String(t *testing.T) {
 			},
 			expected: "127.0.0.1:80:8080/tcp@host",
 		},
+		{
+			name: "host mode with interface",
+			spec: PortSpec{
+				HostInterface: "eth1",
+				PublishedPort: 5432,
+				ContainerPort: 5432,
+				Protocol:      ProtocolTCP,
+				Mode:          PortModeHost,
+			},
+			expected: "%eth1:5432:5432/tcp@host",
+		},
+		{
+			name: "named host mode port",
+			spec: PortSpec{
+				Name:          "postgres",
+				PublishedPort: 5432,
+				ContainerPort: 5432,
+				Protocol:      ProtocolTCP,
+				Mode:          PortModeHost,
+			},
+			expected: "postgres=5432:5432/tcp@host",
+		},
 		{
 			name: "host mode with IPv4 udp",
 			spec: PortSpec{
@@ -553,6 +597,28 @@ func TestParsePortSpec(t *testing.T) {
 				Mode:          PortModeHost,
 			},
 		},
+		{
+			name: "named port",
+			port: "grpc=api.example.com:9000/https",
+			expected: PortSpec{
+				Name:          "grpc",
+				Hostname:      "api.example.com",
+				ContainerPort: 9000,
+				Protocol:      ProtocolHTTPS,
+				Mode:          PortModeIngress,
+			},
+		},
+		{
+			name: "host mode with interface",
+			port: "%eth1:5432:5432@host",
+			expected: PortSpec{
+				HostInterface: "eth1",
+				PublishedPort: 5432,
+				ContainerPort: 5432,
+				Protocol:      ProtocolTCP,
+				Mode:          PortModeHost,
+			},
+		},
 
 		// Error cases.
 		{
@@ -641,6 +707,21 @@ func TestParsePortSpec(t *testing.T) {
 			port:    "[:::1]:80:8080@host",
 			wantErr: "invalid host IP",
 		},
+		{
+			name:    "invalid port name",
+			port:    "HTTP=8080/http",
+			wantErr: "invalid port name 'HTTP'",
+		},
+		{
+			name:    "empty host interface",
+			port:    "%:80:8080@host",
+			wantErr: "invalid host interface '': must not be empty",
+		},
+		{
+			name:    "too long host interface",
+			port:    "%very-long-interface:80:8080@host",
+			wantErr: "invalid host interface 'very-long-interface': must be at most 15 characters long",
+		},
 		{
 			name:    "missing closing bracket in IPv6",
 			port:    "[::1:80:8080@host",
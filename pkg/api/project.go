@@ -0,0 +1,34 @@
+package api
+
+import (
+	"slices"
+)
+
+// Project is the metadata of a Compose project deployed to the cluster.
+type Project struct {
+	Name string
+	// Owner is a free-form team or person responsible for the project.
+	Owner string
+	// Protected projects require --force to remove or stop their services.
+	Protected bool
+	// Services are the names of the services deployed from the project.
+	Services []string
+}
+
+// ProjectUpdate is a change to the metadata of a Compose project. The nil fields keep their current values.
+type ProjectUpdate struct {
+	Owner     *string
+	Protected *bool
+	// Services are added to the services deployed from the project.
+	Services []string
+}
+
+// ServiceProject returns the project the service with the given name was deployed from.
+func ServiceProject(projects []Project, service string) (Project, bool) {
+	for _, p := range projects {
+		if slices.Contains(p.Services, service) {
+			return p, true
+		}
+	}
+	return Project{}, false
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceProject(t *testing.T) {
+	t.Parallel()
+
+	projects := []Project{
+		{Name: "blog", Services: []string{"ghost"}},
+		{Name: "shop", Owner: "platform", Protected: true, Services: []string{"db", "web"}},
+	}
+
+	p, ok := ServiceProject(projects, "web")
+	assert.True(t, ok)
+	assert.Equal(t, "shop", p.Name)
+
+	_, ok = ServiceProject(projects, "worker")
+	assert.False(t, ok)
+}
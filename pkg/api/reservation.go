@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PublishedReservation is a published port or ingress hostname reserved by a Compose project in the cluster so that
+// services from other projects can't claim it.
+type PublishedReservation struct {
+	Project string
+	// Port is the reserved port in the [host_ip:]published_port/protocol[@host] format. Either Port or Hostname is set.
+	Port     string
+	Hostname string
+}
+
+type ReserveOptions struct {
+	// DryRun only checks that no other project has reserved the ports and hostnames without reserving them.
+	DryRun bool
+	// ReleaseOthers releases the project's reservations that aren't in the request. It should only be set when
+	// deploying all services of the project.
+	ReleaseOthers bool
+}
+
+// ReservationPort returns the identifier of the published port to reserve it in the cluster in the
+// [host_ip|%host_interface:]published_port/protocol[@host] format. It returns an empty string for HTTP(S) ingress ports because
+// the ingress shares them among all services and routes the requests by hostname.
+func (p *PortSpec) ReservationPort() string {
+	switch p.Mode {
+	case "", PortModeIngress:
+		if p.Protocol == ProtocolHTTP || p.Protocol == ProtocolHTTPS {
+			return ""
+		}
+		port := p.PublishedPort
+		if port == 0 {
+			port = p.ContainerPort
+		}
+		return fmt.Sprintf("%d/%s", port, p.Protocol)
+	case PortModeHost:
+		if p.HostInterface != "" {
+			return fmt.Sprintf("%%%s:%d/%s@host", p.HostInterface, p.PublishedPort, p.Protocol)
+		}
+		if p.HostIP.IsValid() {
+			if p.HostIP.Is6() {
+				return fmt.Sprintf("[%s]:%d/%s@host", p.HostIP, p.PublishedPort, p.Protocol)
+			}
+			return fmt.Sprintf("%s:%d/%s@host", p.HostIP, p.PublishedPort, p.Protocol)
+		}
+		return fmt.Sprintf("%d/%s@host", p.PublishedPort, p.Protocol)
+	default:
+		return ""
+	}
+}
+
+// PublishedResources returns the sorted published ports and ingress hostnames of the services that must be reserved
+// for their project. See PortSpec.ReservationPort for the port format.
+func PublishedResources(specs []ServiceSpec) (ports, hostnames []string) {
+	for _, spec := range specs {
+		for _, p := range spec.Ports {
+			if port := p.ReservationPort(); port != "" && !slices.Contains(ports, port) {
+				ports = append(ports, port)
+			}
+			if p.Hostname != "" && !slices.Contains(hostnames, p.Hostname) {
+				hostnames = append(hostnames, p.Hostname)
+			}
+		}
+	}
+	slices.Sort(ports)
+	slices.Sort(hostnames)
+	return ports, hostnames
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortSpecReservationPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		port PortSpec
+		want string
+	}{
+		{
+			name: "http ingress is shared",
+			port: PortSpec{Hostname: "app.example.com", ContainerPort: 80, Protocol: ProtocolHTTP, Mode: PortModeIngress},
+			want: "",
+		},
+		{
+			name: "tcp ingress",
+			port: PortSpec{PublishedPort: 2222, ContainerPort: 22, Protocol: ProtocolTCP, Mode: PortModeIngress},
+			want: "2222/tcp",
+		},
+		{
+			name: "tcp ingress without published port",
+			port: PortSpec{ContainerPort: 6379, Protocol: ProtocolTCP, Mode: PortModeIngress},
+			want: "6379/tcp",
+		},
+		{
+			name: "host",
+			port: PortSpec{PublishedPort: 53, ContainerPort: 53, Protocol: ProtocolUDP, Mode: PortModeHost},
+			want: "53/udp@host",
+		},
+		{
+			name: "host with interface",
+			port: PortSpec{
+				HostInterface: "eth1", PublishedPort: 5432, ContainerPort: 5432, Protocol: ProtocolTCP, Mode: PortModeHost,
+			},
+			want: "%eth1:5432/tcp@host",
+		},
+		{
+			name: "host with IPv6",
+			port: PortSpec{
+				HostIP:        netip.MustParseAddr("::1"),
+				PublishedPort: 8080,
+				ContainerPort: 80,
+				Protocol:      ProtocolTCP,
+				Mode:          PortModeHost,
+			},
+			want: "[::1]:8080/tcp@host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.port.ReservationPort())
+		})
+	}
+}
+
+func TestPublishedResources(t *testing.T) {
+	t.Parallel()
+
+	specs := []ServiceSpec{
+		{Ports: []PortSpec{
+			{Hostname: "b.example.com", ContainerPort: 80, Protocol: ProtocolHTTP, Mode: PortModeIngress},
+			{Hostname: "a.example.com", ContainerPort: 80, Protocol: ProtocolHTTP, Mode: PortModeIngress},
+			{PublishedPort: 9000, ContainerPort: 9000, Protocol: ProtocolTCP, Mode: PortModeHost},
+		}},
+		{Ports: []PortSpec{
+			{Hostname: "a.example.com", ContainerPort: 443, Protocol: ProtocolHTTPS, Mode: PortModeIngress},
+			{PublishedPort: 9000, ContainerPort: 9000, Protocol: ProtocolTCP, Mode: PortModeHost},
+		}},
+	}
+
+	ports, hostnames := PublishedResources(specs)
+	assert.Equal(t, []string{"9000/tcp@host"}, ports)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, hostnames)
+}
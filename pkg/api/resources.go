@@ -25,6 +25,11 @@ type ContainerResources struct {
 	SharedMemory int64
 	// Ulimits defines the resource limits for the container.
 	Ulimits map[string]Ulimit
+	// PidsLimit is the maximum number of processes the container can run. 0 or -1 means unlimited.
+	PidsLimit int64
+	// EgressBandwidth is the maximum rate (in bytes per second) of the traffic the container can send. It's enforced
+	// with a tc policer on the host side of the container's veth interface. 0 means unlimited.
+	EgressBandwidth int64 `json:",omitempty"`
 }
 
 // DeviceMapping represents a device mapping between host and container.
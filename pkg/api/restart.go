@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+const (
+	// RestartPolicyNo never restarts the service containers when they exit.
+	RestartPolicyNo = "no"
+	// RestartPolicyAlways always restarts the service containers when they exit, including the containers stopped
+	// with 'uc stop' once the Docker daemon or machine restarts.
+	RestartPolicyAlways = "always"
+	// RestartPolicyOnFailure restarts the service containers only when they exit with a non-zero code. An optional
+	// maximum number of restart attempts can be specified as 'on-failure:N'.
+	RestartPolicyOnFailure = "on-failure"
+	// RestartPolicyUnlessStopped restarts the service containers when they exit unless they were explicitly stopped.
+	// This is the default policy.
+	RestartPolicyUnlessStopped = "unless-stopped"
+)
+
+// ParseRestartPolicy parses a restart policy in the Compose format: 'no', 'always', 'on-failure[:max-retries]',
+// or 'unless-stopped' into the Docker restart policy. An empty policy is RestartPolicyUnlessStopped.
+// The Docker daemon on the machine restarts the containers with an exponentially increasing delay starting
+// at 100ms and doubling up to 1m until a container runs for at least 10 seconds. The machine daemon supervises
+// the containers and restarts the ones Docker failed to restart with its own exponential backoff.
+func ParseRestartPolicy(policy string) (container.RestartPolicy, error) {
+	name, maxRetries, hasMax := strings.Cut(policy, ":")
+	switch name {
+	case "":
+		if hasMax {
+			break
+		}
+		return container.RestartPolicy{Name: container.RestartPolicyUnlessStopped}, nil
+	case RestartPolicyNo:
+		if hasMax {
+			break
+		}
+		return container.RestartPolicy{Name: container.RestartPolicyDisabled}, nil
+	case RestartPolicyAlways, RestartPolicyUnlessStopped:
+		if hasMax {
+			return container.RestartPolicy{}, fmt.Errorf(
+				"invalid restart policy '%s': maximum retry count can only be used with '%s'",
+				policy, RestartPolicyOnFailure)
+		}
+		return container.RestartPolicy{Name: container.RestartPolicyMode(name)}, nil
+	case RestartPolicyOnFailure:
+		rp := container.RestartPolicy{Name: container.RestartPolicyOnFailure}
+		if hasMax {
+			n, err := strconv.Atoi(maxRetries)
+			if err != nil || n < 0 {
+				return container.RestartPolicy{}, fmt.Errorf(
+					"invalid restart policy '%s': maximum retry count must be a non-negative integer", policy)
+			}
+			rp.MaximumRetryCount = n
+		}
+		return rp, nil
+	}
+
+	return container.RestartPolicy{}, fmt.Errorf("invalid restart policy '%s': must be one of: %s, %s, %s[:N], %s",
+		policy, RestartPolicyNo, RestartPolicyAlways, RestartPolicyOnFailure, RestartPolicyUnlessStopped)
+}
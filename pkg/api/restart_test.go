@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy  string
+		want    container.RestartPolicy
+		wantErr string
+	}{
+		{policy: "", want: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped}},
+		{policy: "no", want: container.RestartPolicy{Name: container.RestartPolicyDisabled}},
+		{policy: "always", want: container.RestartPolicy{Name: container.RestartPolicyAlways}},
+		{policy: "unless-stopped", want: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped}},
+		{policy: "on-failure", want: container.RestartPolicy{Name: container.RestartPolicyOnFailure}},
+		{
+			policy: "on-failure:3",
+			want:   container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 3},
+		},
+		{policy: "on-failure:-1", wantErr: "must be a non-negative integer"},
+		{policy: "on-failure:many", wantErr: "must be a non-negative integer"},
+		{policy: "always:3", wantErr: "can only be used with 'on-failure'"},
+		{policy: "no:3", wantErr: "must be one of"},
+		{policy: ":3", wantErr: "must be one of"},
+		{policy: "never", wantErr: "must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := ParseRestartPolicy(tt.policy)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// SealedSecretPrefix is the prefix of sealed secret values. A sealed secret has the format
+// 'sealed://<key ID>/<base64url-encoded ciphertext>' and can be used as the name of an external Compose secret.
+const SealedSecretPrefix = "sealed://"
+
+// SealingKeyID returns the ID of the sealing key pair derived from its public key.
+func SealingKeyID(publicKey *[32]byte) string {
+	sum := sha256.Sum256(publicKey[:])
+	return hex.EncodeToString(sum[:8])
+}
+
+// SealSecret encrypts the value with the cluster sealing public key so that only the cluster machines can decrypt it.
+// It returns the sealed value in the 'sealed://<key ID>/<ciphertext>' format.
+func SealSecret(value []byte, keyID string, publicKey *[32]byte) (string, error) {
+	ciphertext, err := SealSecretContent(value, publicKey)
+	if err != nil {
+		return "", err
+	}
+	return SealedSecretPrefix + keyID + "/" + base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// SealSecretContent encrypts the value with the cluster sealing public key and returns the raw ciphertext.
+func SealSecretContent(value []byte, publicKey *[32]byte) ([]byte, error) {
+	ciphertext, err := box.SealAnonymous(nil, value, publicKey, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt secret: %w", err)
+	}
+	return ciphertext, nil
+}
+
+// IsSealedSecret returns true if the value is a sealed secret.
+func IsSealedSecret(value string) bool {
+	return strings.HasPrefix(value, SealedSecretPrefix)
+}
+
+// ParseSealedSecret parses a sealed secret value and returns the ID of the key pair it was sealed with
+// and the ciphertext.
+func ParseSealedSecret(value string) (string, []byte, error) {
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(value, SealedSecretPrefix), "/")
+	if !IsSealedSecret(value) || !ok || keyID == "" || encoded == "" {
+		return "", nil, fmt.Errorf("invalid sealed secret: expected format '%s<key ID>/<ciphertext>'",
+			SealedSecretPrefix)
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid sealed secret: decode ciphertext: %w", err)
+	}
+	return keyID, ciphertext, nil
+}
+
+// OpenSealedSecret decrypts the ciphertext of a sealed secret with the cluster sealing key pair.
+func OpenSealedSecret(ciphertext []byte, publicKey, privateKey *[32]byte) ([]byte, error) {
+	value, ok := box.OpenAnonymous(nil, ciphertext, publicKey, privateKey)
+	if !ok {
+		return nil, fmt.Errorf("decrypt sealed secret: invalid ciphertext or key")
+	}
+	return value, nil
+}
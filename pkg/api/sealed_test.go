@@ -0,0 +1,59 @@
+package api
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestSealSecret(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyID := SealingKeyID(publicKey)
+
+	sealed, err := SealSecret([]byte("s3cret"), keyID, publicKey)
+	require.NoError(t, err)
+	assert.True(t, IsSealedSecret(sealed))
+	assert.True(t, strings.HasPrefix(sealed, "sealed://"+keyID+"/"))
+	assert.NotContains(t, sealed, "s3cret")
+
+	parsedKeyID, ciphertext, err := ParseSealedSecret(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, keyID, parsedKeyID)
+
+	value, err := OpenSealedSecret(ciphertext, publicKey, privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", string(value))
+
+	otherPublicKey, otherPrivateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = OpenSealedSecret(ciphertext, otherPublicKey, otherPrivateKey)
+	assert.ErrorContains(t, err, "invalid ciphertext or key")
+}
+
+func TestParseSealedSecret_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"vault://secret/app#password",
+		"sealed://",
+		"sealed://abc",
+		"sealed:///Zm9v",
+		"sealed://abc/not base64!",
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := ParseSealedSecret(value)
+			assert.ErrorContains(t, err, "invalid sealed secret")
+		})
+	}
+}
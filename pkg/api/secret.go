@@ -0,0 +1,280 @@
+// Implementation of Secret feature from the Compose spec
+package api
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSecretsDir is the directory in the container where secrets are mounted by default.
+	DefaultSecretsDir = "/run/secrets"
+	// DefaultSecretFileMode is the default file mode of mounted secrets. It's stricter than the Compose spec default
+	// 0444 so that only the owner, root by default, can read the secret.
+	DefaultSecretFileMode os.FileMode = 0o400
+)
+
+// SecretSpec defines a secret that can be mounted into containers.
+type SecretSpec struct {
+	Name string
+	// Content of the secret. Secrets from external providers are resolved to their content client-side before
+	// the spec is sent to the cluster. If SealingKeyID is set, Content is the ciphertext of a sealed secret
+	// that machines decrypt when mounting it into containers.
+	Content []byte `json:",omitempty"`
+	// SealingKeyID is the ID of the cluster sealing key pair the secret was sealed with. Empty for plain secrets.
+	SealingKeyID string `json:",omitempty"`
+	// Template indicates the content is a Go template rendered with TemplateData when mounting the secret.
+	Template bool `json:",omitempty"`
+	// ClusterSecret is the name of a secret stored in the cluster with 'uc secret create' that is mounted instead
+	// of Content. Machines read and decrypt it from the cluster store when mounting the secret.
+	ClusterSecret string `json:",omitempty"`
+	// ClusterSecretVersion is the version of the cluster secret at deploy time. It changes the spec when the secret
+	// is updated so that redeploying the service recreates its containers with the new value.
+	ClusterSecretVersion int64 `json:",omitempty"`
+}
+
+func (s *SecretSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("secret name is required")
+	}
+	if s.ClusterSecret != "" {
+		if len(s.Content) > 0 || s.SealingKeyID != "" {
+			return fmt.Errorf("secret '%s': content can't be set for a cluster secret", s.Name)
+		}
+		if err := ValidateClusterSecretName(s.ClusterSecret); err != nil {
+			return fmt.Errorf("secret '%s': %w", s.Name, err)
+		}
+	}
+	// Sealed and cluster secret templates can only be checked after they're decrypted on the machine.
+	if s.Template && s.SealingKeyID == "" && s.ClusterSecret == "" {
+		if err := ParseTemplate(s.Name, s.Content); err != nil {
+			return fmt.Errorf("secret '%s': %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// Equals compares two SecretSpec instances.
+func (s *SecretSpec) Equals(other SecretSpec) bool {
+	return s.Name == other.Name &&
+		bytes.Equal(s.Content, other.Content) &&
+		s.SealingKeyID == other.SealingKeyID &&
+		s.Template == other.Template &&
+		s.ClusterSecret == other.ClusterSecret &&
+		s.ClusterSecretVersion == other.ClusterSecretVersion
+}
+
+// ClusterSecret describes a secret stored encrypted in the cluster. Its value is never returned to clients.
+type ClusterSecret struct {
+	Name string
+	// Version is incremented each time the secret value is updated.
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var clusterSecretNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ValidateClusterSecretName checks that the name of a cluster secret consists of letters, digits, and '_', '.',
+// or '-' characters and starts with a letter or digit so that it can't be confused with a sealed secret or
+// a reference to an external secret provider.
+func ValidateClusterSecretName(name string) error {
+	if len(name) > 64 {
+		return fmt.Errorf("invalid secret name '%s': must be at most 64 characters long", name)
+	}
+	if !clusterSecretNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid secret name '%s': must start with a letter or digit and contain only letters, "+
+			"digits, '_', '.', or '-'", name)
+	}
+	return nil
+}
+
+// SecretMount defines how a secret is mounted into a container.
+type SecretMount struct {
+	// SecretName references a secret defined in ServiceSpec.Secrets by its Name field.
+	SecretName string
+	// ContainerPath is the path where the secret is mounted in the container. A relative path is relative to
+	// DefaultSecretsDir. Defaults to DefaultSecretsDir/<SecretName> if empty.
+	ContainerPath string `json:",omitempty"`
+	// Uid for the mounted secret file. Defaults to root (0) if empty.
+	Uid string `json:",omitempty"`
+	// Gid for the mounted secret file. Defaults to root (0) if empty.
+	Gid string `json:",omitempty"`
+	// Mode (file permissions) for the mounted secret file. Defaults to DefaultSecretFileMode if nil.
+	Mode *os.FileMode `json:",omitempty"`
+}
+
+// Target returns the absolute path where the secret is mounted in the container.
+func (m *SecretMount) Target() string {
+	if m.ContainerPath == "" {
+		return path.Join(DefaultSecretsDir, m.SecretName)
+	}
+	if path.IsAbs(m.ContainerPath) {
+		return m.ContainerPath
+	}
+	return path.Join(DefaultSecretsDir, m.ContainerPath)
+}
+
+// FileMode returns the file mode of the mounted secret file.
+func (m *SecretMount) FileMode() os.FileMode {
+	if m.Mode != nil {
+		return *m.Mode
+	}
+	return DefaultSecretFileMode
+}
+
+func (m *SecretMount) GetNumericUid() (*uint64, error) {
+	return parseNumericID("Uid", m.Uid)
+}
+
+func (m *SecretMount) GetNumericGid() (*uint64, error) {
+	return parseNumericID("Gid", m.Gid)
+}
+
+func parseNumericID(kind, id string) (*uint64, error) {
+	if id == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s '%s': %w", kind, id, err)
+	}
+	return &v, nil
+}
+
+// Validate returns ValidationErrors with the paths of the invalid fields using the Compose keys of a secret mount.
+func (m *SecretMount) Validate() error {
+	var errs ValidationErrors
+	if m.SecretName == "" {
+		errs.Addf("source", "secret mount source is required")
+	}
+	if _, err := m.GetNumericUid(); err != nil {
+		errs.Add("uid", err)
+	}
+	if _, err := m.GetNumericGid(); err != nil {
+		errs.Add("gid", err)
+	}
+	if m.ContainerPath != "" && filepath.Clean(m.ContainerPath) == "." {
+		errs.Addf("target", "invalid secret mount path '%s'", m.ContainerPath)
+	}
+	if m.Mode != nil {
+		if *m.Mode&^os.ModePerm != 0 {
+			errs.Addf("mode", "invalid secret mode %04o: only permission bits are allowed", uint32(*m.Mode))
+		} else if *m.Mode&0o002 != 0 {
+			errs.Addf("mode", "invalid secret mode %04o: secret files must not be world-writable",
+				uint32(*m.Mode))
+		}
+	}
+	return errs.Err()
+}
+
+// Compare compares this SecretMount with another and returns -1, 0, or +1 like cmp.Compare.
+func (m *SecretMount) Compare(other *SecretMount) int {
+	if c := cmp.Compare(m.SecretName, other.SecretName); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(m.ContainerPath, other.ContainerPath); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(m.Uid, other.Uid); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(m.Gid, other.Gid); c != 0 {
+		return c
+	}
+	switch {
+	case m.Mode == nil && other.Mode == nil:
+		return 0
+	case m.Mode == nil:
+		return -1
+	case other.Mode == nil:
+		return 1
+	}
+	return cmp.Compare(*m.Mode, *other.Mode)
+}
+
+func (m *SecretMount) Clone() SecretMount {
+	clone := *m
+	if m.Mode != nil {
+		mode := *m.Mode
+		clone.Mode = &mode
+	}
+	return clone
+}
+
+func sortSecretMounts(mounts []SecretMount) {
+	slices.SortFunc(mounts, func(a, b SecretMount) int {
+		return a.Compare(&b)
+	})
+}
+
+// CheckSecretModesAllowed checks that the modes of the secret mounts don't grant more permissions than maxMode
+// allows. A zero maxMode means no limit.
+func CheckSecretModesAllowed(mounts []SecretMount, maxMode os.FileMode) error {
+	if maxMode == 0 {
+		return nil
+	}
+
+	var denied []string
+	for _, m := range mounts {
+		if mode := m.FileMode(); mode&^maxMode != 0 {
+			denied = append(denied, fmt.Sprintf("%s (%04o)", m.SecretName, uint32(mode)))
+		}
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("secret modes exceed the cluster maximum %04o: %s. Use a stricter mode for them",
+			uint32(maxMode), strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+// SecretUsage describes a secret mount in a service container.
+type SecretUsage struct {
+	ServiceID   string
+	ServiceName string
+	MachineID   string
+	MachineName string
+	ContainerID string
+	Mount       SecretMount
+	// Sealed is true if the secret is sealed with the cluster sealing key pair.
+	Sealed bool
+}
+
+// ValidateSecretsAndMounts validates secret specs and secret mounts and checks that all mounts refer to
+// existing specs. It reports all invalid secrets and mounts as ValidationErrors. The secret specs are referenced
+// by name like the top-level Compose secrets (secrets.<name>) and the mounts by index (secrets[i]).
+func ValidateSecretsAndMounts(secrets []SecretSpec, mounts []SecretMount) error {
+	var errs ValidationErrors
+	names := make(map[string]struct{}, len(secrets))
+	for _, s := range secrets {
+		if err := s.Validate(); err != nil {
+			errs.Add(namePath("secrets", s.Name), fmt.Errorf("invalid secret: %w", err))
+		}
+		if _, ok := names[s.Name]; ok {
+			errs.Addf(namePath("secrets", s.Name), "duplicate secret name: '%s'", s.Name)
+		}
+		names[s.Name] = struct{}{}
+	}
+
+	for i, m := range mounts {
+		path := indexPath("secrets", i)
+		if err := m.Validate(); err != nil {
+			errs.Add(path, err)
+		}
+		if _, ok := names[m.SecretName]; !ok && m.SecretName != "" {
+			errs.Addf(JoinFieldPath(path, "source"),
+				"secret mount source '%s' does not refer to any defined secret", m.SecretName)
+		}
+	}
+
+	return errs.Err()
+}
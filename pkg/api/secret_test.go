@@ -0,0 +1,125 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fileMode(m os.FileMode) *os.FileMode {
+	return &m
+}
+
+func TestValidateSecretsAndMounts(t *testing.T) {
+	t.Parallel()
+
+	secrets := []SecretSpec{{Name: "db_password", Content: []byte("s3cret")}}
+
+	tests := []struct {
+		name    string
+		secrets []SecretSpec
+		mounts  []SecretMount
+		wantErr string
+	}{
+		{
+			name:    "valid mounts",
+			secrets: secrets,
+			mounts: []SecretMount{
+				{SecretName: "db_password"},
+				{SecretName: "db_password", ContainerPath: "/etc/db/password", Uid: "999", Gid: "999",
+					Mode: fileMode(0o440)},
+			},
+		},
+		{
+			name:    "duplicate secret",
+			secrets: append(secrets, SecretSpec{Name: "db_password"}),
+			wantErr: "duplicate secret name",
+		},
+		{
+			name:    "unknown secret",
+			secrets: secrets,
+			mounts:  []SecretMount{{SecretName: "api_key"}},
+			wantErr: "does not refer to any defined secret",
+		},
+		{
+			name:    "invalid uid",
+			secrets: secrets,
+			mounts:  []SecretMount{{SecretName: "db_password", Uid: "root"}},
+			wantErr: "invalid Uid 'root'",
+		},
+		{
+			name:    "world-writable mode",
+			secrets: secrets,
+			mounts:  []SecretMount{{SecretName: "db_password", Mode: fileMode(0o666)}},
+			wantErr: "must not be world-writable",
+		},
+		{
+			name:    "cluster secret",
+			secrets: []SecretSpec{{Name: "db_password", ClusterSecret: "prod.db-password", ClusterSecretVersion: 1}},
+			mounts:  []SecretMount{{SecretName: "db_password"}},
+		},
+		{
+			name:    "cluster secret with content",
+			secrets: []SecretSpec{{Name: "db_password", ClusterSecret: "db_password", Content: []byte("s3cret")}},
+			wantErr: "content can't be set for a cluster secret",
+		},
+		{
+			name:    "invalid cluster secret name",
+			secrets: []SecretSpec{{Name: "db_password", ClusterSecret: "vault://secret/db"}},
+			wantErr: "invalid secret name 'vault://secret/db'",
+		},
+		{
+			name:    "setuid mode",
+			secrets: secrets,
+			mounts:  []SecretMount{{SecretName: "db_password", Mode: fileMode(os.ModeSetuid | 0o400)}},
+			wantErr: "only permission bits are allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateSecretsAndMounts(tt.secrets, tt.mounts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSecretMount_Defaults(t *testing.T) {
+	t.Parallel()
+
+	m := SecretMount{SecretName: "db_password"}
+	assert.Equal(t, "/run/secrets/db_password", m.Target())
+	assert.Equal(t, os.FileMode(0o400), m.FileMode())
+
+	m = SecretMount{SecretName: "db_password", ContainerPath: "db/password", Mode: fileMode(0o440)}
+	assert.Equal(t, "/run/secrets/db/password", m.Target())
+	assert.Equal(t, os.FileMode(0o440), m.FileMode())
+}
+
+func TestCheckSecretModesAllowed(t *testing.T) {
+	t.Parallel()
+
+	mounts := []SecretMount{
+		{SecretName: "default"},
+		{SecretName: "group", Mode: fileMode(0o440)},
+		{SecretName: "others", Mode: fileMode(0o444)},
+	}
+
+	require.NoError(t, CheckSecretModesAllowed(mounts, 0))
+	require.NoError(t, CheckSecretModesAllowed(mounts, 0o644))
+
+	err := CheckSecretModesAllowed(mounts, 0o440)
+	assert.EqualError(t, err, "secret modes exceed the cluster maximum 0440: others (0444). "+
+		"Use a stricter mode for them")
+
+	err = CheckSecretModesAllowed(mounts, 0o400)
+	assert.ErrorContains(t, err, "group (0440), others (0444)")
+}
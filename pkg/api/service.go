@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"math"
+	"net/netip"
 	"regexp"
 	"slices"
 	"strings"
@@ -13,6 +15,7 @@ import (
 	"github.com/distribution/reference"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/moby/sys/signal"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
 )
 
@@ -38,6 +41,22 @@ const (
 	// deployed to machines where the image is already available.
 	// TODO: see the TODO above for PullPolicyMissing. Pull from other machines in the cluster if available.
 	PullPolicyNever = "never"
+
+	// IpcModeNone is the IPC mode with a private IPC namespace and /dev/shm not mounted.
+	IpcModeNone = "none"
+	// IpcModePrivate is the IPC mode with a private IPC namespace that can't be shared with other containers.
+	IpcModePrivate = "private"
+	// IpcModeShareable is the IPC mode with a private IPC namespace that can be shared with other containers.
+	IpcModeShareable = "shareable"
+	// IpcModeHost is the IPC mode that uses the host's IPC namespace.
+	IpcModeHost = "host"
+	// IpcModeServicePrefix is the prefix of the IPC mode that joins the IPC namespace of a container of another
+	// service running on the same machine, e.g. "service:db". The other service should use IpcModeShareable.
+	IpcModeServicePrefix = "service:"
+
+	// UsernsModeHost is the user namespace mode that uses the host's user namespace. It opts the container out
+	// of user namespace remapping configured in the Docker daemon.
+	UsernsModeHost = "host"
 )
 
 var (
@@ -52,6 +71,12 @@ func ValidateServiceID(id string) bool {
 // ServiceSpec defines the desired state of a service.
 // ATTENTION: after changing this struct, verify if deploy.EvalContainerSpecChange needs to be updated.
 type ServiceSpec struct {
+	// AutoUpdate enables automatic rolling updates of the service when its image tag in the registry is moved
+	// to a new image digest.
+	AutoUpdate *AutoUpdateSpec `json:",omitempty"`
+	// Backup is an optional hook that writes a logical backup of the service data to its standard output when
+	// run in a service container, for example, with 'uc backup'.
+	Backup *BackupHook `json:",omitempty"`
 	// Caddy is the optional Caddy reverse proxy configuration for the service.
 	// Caddy and Ports cannot be specified simultaneously.
 	Caddy *CaddySpec `json:",omitempty"`
@@ -59,6 +84,22 @@ type ServiceSpec struct {
 	Configs []ConfigSpec
 	// Container defines the desired state of each container in the service.
 	Container ContainerSpec
+	// ContainerNameTemplate is the template for generating names of new service containers.
+	// See ContainerNamePlaceholder* constants for supported placeholders. DefaultContainerNameTemplate is used if empty.
+	ContainerNameTemplate string `json:",omitempty"`
+	// DependsOn is a list of services the service depends on. The containers of the dependencies are started first
+	// when a machine recovers the containers that Docker failed to start on boot.
+	DependsOn []string `json:",omitempty"`
+	// Ingress is the optional policy that protects the ingress routes of the service from abusive traffic.
+	// It requires HTTP or HTTPS ingress ports.
+	Ingress *IngressPolicy `json:",omitempty"`
+	// InternalIPs is a list of fixed IPs claimed by the service containers. Each container claims a free IP from
+	// the list that is in the static IP range of its machine subnet. The containers are placed only on machines
+	// whose subnets contain the IPs. Useful for legacy clients configured by IP rather than DNS.
+	InternalIPs []netip.Addr `json:",omitempty"`
+	// MTLS enables transparent mTLS for the TCP traffic from containers on other machines to the service containers.
+	// The traffic is wrapped in mTLS by the mesh proxies on the machines using identities issued by the cluster CA.
+	MTLS bool `json:",omitempty"`
 	// Mode is the replication mode of the service. Default is ServiceModeReplicated if empty.
 	Mode string
 	Name string
@@ -72,6 +113,11 @@ type ServiceSpec struct {
 	PreDeploy *PreDeployHook `json:",omitempty"`
 	// Replicas is the number of containers to run for the service. Only valid for a replicated service.
 	Replicas uint `json:",omitempty"`
+	// RestartPolicy defines when the service containers are restarted after they exit: 'no', 'always',
+	// 'on-failure[:max-retries]', or 'unless-stopped'. Default is RestartPolicyUnlessStopped if empty.
+	RestartPolicy string `json:",omitempty"`
+	// Secrets is a list of secrets that can be mounted into the container.
+	Secrets []SecretSpec `json:",omitempty"`
 	// UpdateConfig configures how the service is updated during a deployment.
 	UpdateConfig UpdateConfig
 	// Volumes is list of data volumes that can be mounted into the container.
@@ -86,6 +132,15 @@ func (s *ServiceSpec) CaddyConfig() string {
 	return strings.TrimSpace(s.Caddy.Config)
 }
 
+// CaddyDirectives returns the Caddyfile directives injected into the sites generated for the ingress ports
+// of the service or an empty string if they're not defined.
+func (s *ServiceSpec) CaddyDirectives() string {
+	if s.Caddy == nil {
+		return ""
+	}
+	return strings.TrimSpace(s.Caddy.Directives)
+}
+
 func (s *ServiceSpec) Volume(name string) (VolumeSpec, bool) {
 	for _, v := range s.Volumes {
 		if v.Name == name {
@@ -104,6 +159,15 @@ func (s *ServiceSpec) Config(name string) (ConfigSpec, bool) {
 	return ConfigSpec{}, false
 }
 
+func (s *ServiceSpec) Secret(name string) (SecretSpec, bool) {
+	for _, sec := range s.Secrets {
+		if sec.Name == name {
+			return sec, true
+		}
+	}
+	return SecretSpec{}, false
+}
+
 // MountedDockerVolumes returns the list of volumes of VolumeTypeVolume type that are mounted into the container.
 func (s *ServiceSpec) MountedDockerVolumes() []VolumeSpec {
 	volumes := make(map[string]VolumeSpec)
@@ -116,6 +180,29 @@ func (s *ServiceSpec) MountedDockerVolumes() []VolumeSpec {
 	return slices.Collect(maps.Values(volumes))
 }
 
+// NamedPorts returns the container port numbers of the named ports by name.
+func (s *ServiceSpec) NamedPorts() map[string]uint16 {
+	ports := make(map[string]uint16)
+	for _, p := range s.Ports {
+		if p.Name != "" {
+			ports[p.Name] = p.ContainerPort
+		}
+	}
+	return ports
+}
+
+// HostPorts returns the ports published in host mode in the [host_ip:]published_port/protocol@host format.
+// Only one container on a machine can publish each of them.
+func (s *ServiceSpec) HostPorts() []string {
+	var ports []string
+	for _, p := range s.Ports {
+		if p.Mode == PortModeHost {
+			ports = append(ports, p.ReservationPort())
+		}
+	}
+	return ports
+}
+
 func (s *ServiceSpec) SetDefaults() ServiceSpec {
 	spec := s.Clone()
 
@@ -135,34 +222,58 @@ func (s *ServiceSpec) SetDefaults() ServiceSpec {
 	return spec
 }
 
+// Validate checks the whole spec and returns ValidationErrors with all invalid fields. The paths of the fields
+// use the Compose service keys, for example, secrets[0].uid, so that they can be prefixed with services.<name>
+// to point at the invalid field in a Compose file.
 func (s *ServiceSpec) Validate() error {
-	if err := s.Container.Validate(); err != nil {
-		return err
-	}
+	var errs ValidationErrors
+	errs.Add("", s.Container.Validate())
 
 	switch s.Mode {
 	case "", ServiceModeGlobal, ServiceModeReplicated:
 	default:
-		return fmt.Errorf("invalid mode: %q", s.Mode)
+		errs.Addf("deploy.mode", "invalid mode: %q", s.Mode)
 	}
 
 	if s.Name != "" {
 		if len(s.Name) > 63 {
-			return fmt.Errorf("service name too long (max 63 characters): %q", s.Name)
-		}
-		if !dnsLabelRegexp.MatchString(s.Name) {
-			return fmt.Errorf("invalid service name: %q. must be 1-63 characters, lowercase letters, numbers, "+
+			errs.Addf("name", "service name too long (max 63 characters): %q", s.Name)
+		} else if !dnsLabelRegexp.MatchString(s.Name) {
+			errs.Addf("name", "invalid service name: %q. must be 1-63 characters, lowercase letters, numbers, "+
 				"and dashes only; must start and end with a letter or number", s.Name)
 		}
 	}
 
-	for _, p := range s.Ports {
+	if _, err := ParseRestartPolicy(s.RestartPolicy); err != nil {
+		errs.Add("restart", err)
+	}
+
+	if s.ContainerNameTemplate != "" {
+		if err := ValidateContainerNameTemplate(s.ContainerNameTemplate); err != nil {
+			errs.Addf("x-container_name", "invalid container name template '%s': %w", s.ContainerNameTemplate, err)
+		}
+	}
+
+	for i, p := range s.Ports {
 		if (p.Mode == "" || p.Mode == PortModeIngress) &&
 			p.Protocol != ProtocolHTTP && p.Protocol != ProtocolHTTPS {
-			return fmt.Errorf("unsupported protocol for ingress port %d: %s", p.ContainerPort, p.Protocol)
+			errs.Addf(indexPath("ports", i), "unsupported protocol for ingress port %d: %s",
+				p.ContainerPort, p.Protocol)
 		}
 	}
 
+	portNames := make(map[string]uint16)
+	for i, p := range s.Ports {
+		if p.Name == "" {
+			continue
+		}
+		if port, ok := portNames[p.Name]; ok && port != p.ContainerPort {
+			errs.Addf(indexPath("ports", i), "port name '%s' is used for different container ports %d and %d",
+				p.Name, port, p.ContainerPort)
+		}
+		portNames[p.Name] = p.ContainerPort
+	}
+
 	// TODO: validate there is no conflict between ports.
 
 	// Validate that Caddy and Ports are not used together, unless all ports are host mode.
@@ -176,62 +287,141 @@ func (s *ServiceSpec) Validate() error {
 			}
 		}
 		if hasIngressPort {
-			return fmt.Errorf("ingress ports and Caddy configuration cannot be specified simultaneously: " +
-				"Caddy config is auto-generated from ingress ports, use only one of them. " +
+			errs.Addf("x-caddy", "ingress ports and Caddy configuration cannot be specified simultaneously: "+
+				"Caddy config is auto-generated from ingress ports, use only one of them. "+
 				"Host mode ports can be used with Caddy config")
 		}
 	}
 
+	if s.CaddyDirectives() != "" && !slices.ContainsFunc(s.Ports, func(p PortSpec) bool {
+		return p.Mode == "" || p.Mode == PortModeIngress
+	}) {
+		errs.Addf("x-caddy", "injected Caddy directives require at least one HTTP or HTTPS ingress port")
+	}
+
+	if s.Ingress != nil {
+		if err := s.Ingress.Validate(); err != nil {
+			errs.Addf("x-ingress", "invalid ingress policy: %w", err)
+		}
+		if !slices.ContainsFunc(s.Ports, func(p PortSpec) bool {
+			return p.Mode == "" || p.Mode == PortModeIngress
+		}) {
+			errs.Addf("x-ingress", "ingress policy requires at least one HTTP or HTTPS ingress port")
+		}
+	}
+
 	// Validate volumes
 	volumeNames := make(map[string]struct{})
 	for _, v := range s.Volumes {
 		if err := v.Validate(); err != nil {
-			return fmt.Errorf("invalid volume: %w", err)
+			errs.Addf(namePath("volumes", v.Name), "invalid volume: %w", err)
 		}
 		if _, ok := volumeNames[v.Name]; ok {
-			return fmt.Errorf("duplicate volume name: '%s'", v.Name)
+			errs.Addf(namePath("volumes", v.Name), "duplicate volume name: '%s'", v.Name)
 		}
 		volumeNames[v.Name] = struct{}{}
 	}
 
-	for _, m := range s.Container.VolumeMounts {
+	for i, m := range s.Container.VolumeMounts {
+		if m.VolumeName == "" {
+			// Already reported by the mount validation.
+			continue
+		}
 		if !slices.ContainsFunc(s.Volumes, func(v VolumeSpec) bool {
 			return v.Name == m.VolumeName
 		}) {
-			return fmt.Errorf("volume mount references a volume that doesn't exist in the service spec: '%s'",
-				m.VolumeName)
+			errs.Addf(indexPath("volumes", i),
+				"volume mount references a volume that doesn't exist in the service spec: '%s'", m.VolumeName)
 		}
 	}
 
-	// Validate configs
-	if err := ValidateConfigsAndMounts(s.Configs, s.Container.ConfigMounts); err != nil {
-		return fmt.Errorf("validate service configs and mounts: %w", err)
-	}
+	errs.Add("", ValidateConfigsAndMounts(s.Configs, s.Container.ConfigMounts))
+	errs.Add("", ValidateSecretsAndMounts(s.Secrets, s.Container.SecretMounts))
 
 	if s.PreDeploy != nil {
-		if err := s.PreDeploy.Validate(); err != nil {
-			return err
+		errs.Add("x-pre_deploy", s.PreDeploy.Validate())
+	}
+	if s.Backup != nil {
+		errs.Add("x-backup", s.Backup.Validate())
+	}
+
+	if s.AutoUpdate != nil {
+		errs.Add("x-auto-update", s.AutoUpdate.Validate())
+		if _, err := AutoUpdateImageTag(s.Container.Image); err != nil {
+			errs.Add("image", err)
+		}
+	}
+
+	errs.Add("x-internal-ip", s.validateInternalIPs())
+	errs.Add("x-machines", s.Placement.Validate(s.Mode, s.Replicas))
+	if hostPorts := s.HostPorts(); len(hostPorts) > 0 {
+		for _, m := range slices.Sorted(maps.Keys(s.Placement.MachineReplicas)) {
+			if s.Placement.MachineReplicas[m] > 1 {
+				errs.Addf("x-machines", "can't run %d replicas on machine '%s': the service publishes host "+
+					"port %s that only one container on a machine can publish",
+					s.Placement.MachineReplicas[m], m, hostPorts[0])
+				break
+			}
 		}
 	}
 
+	return errs.Err()
+}
+
+func (s *ServiceSpec) validateInternalIPs() error {
+	if len(s.InternalIPs) == 0 {
+		return nil
+	}
+
+	seen := make(map[netip.Addr]struct{}, len(s.InternalIPs))
+	for _, ip := range s.InternalIPs {
+		if !ip.Is4() {
+			return fmt.Errorf("invalid internal IP '%s': must be an IPv4 address", ip)
+		}
+		if _, ok := seen[ip]; ok {
+			return fmt.Errorf("duplicate internal IP: '%s'", ip)
+		}
+		seen[ip] = struct{}{}
+	}
+	if (s.Mode == "" || s.Mode == ServiceModeReplicated) && int(s.Replicas) > len(s.InternalIPs) {
+		return fmt.Errorf("not enough internal IPs for %d replicas: each replica must claim one of %d IPs",
+			s.Replicas, len(s.InternalIPs))
+	}
+
 	return nil
 }
 
 func (s *ServiceSpec) Clone() ServiceSpec {
 	spec := *s
 
+	if s.AutoUpdate != nil {
+		autoUpdate := *s.AutoUpdate
+		spec.AutoUpdate = &autoUpdate
+	}
 	if s.Caddy != nil {
 		caddyCopy := *s.Caddy
 		spec.Caddy = &caddyCopy
 	}
 	spec.Container = s.Container.Clone()
+	spec.DependsOn = slices.Clone(s.DependsOn)
+	spec.Ingress = s.Ingress.Clone()
 	spec.PreDeploy = s.PreDeploy.Clone()
+	spec.Backup = s.Backup.Clone()
 
+	spec.InternalIPs = slices.Clone(s.InternalIPs)
+	spec.Placement = s.Placement.Clone()
 	if s.Ports != nil {
 		spec.Ports = make([]PortSpec, len(s.Ports))
 		copy(spec.Ports, s.Ports)
 	}
 
+	if s.Secrets != nil {
+		spec.Secrets = make([]SecretSpec, len(s.Secrets))
+		for i, sec := range s.Secrets {
+			spec.Secrets[i] = sec
+			spec.Secrets[i].Content = slices.Clone(sec.Content)
+		}
+	}
 	if s.Volumes != nil {
 		spec.Volumes = make([]VolumeSpec, len(s.Volumes))
 		for i, v := range s.Volumes {
@@ -245,6 +435,9 @@ func (s *ServiceSpec) Clone() ServiceSpec {
 // ContainerSpec defines the desired state of a container in a service.
 // ATTENTION: after changing this struct, verify if deploy.EvalContainerSpecChange needs to be updated.
 type ContainerSpec struct {
+	// Annotations are arbitrary key-value metadata attached to the container and passed to the OCI runtime.
+	// External tools can read them from the container's HostConfig.Annotations in the Docker API.
+	Annotations map[string]string `json:",omitempty"`
 	// Specifies which additional capabilities should be added for the container.
 	CapAdd []string
 	// Specifies which capabilities should be dropped from the container.
@@ -253,16 +446,31 @@ type ContainerSpec struct {
 	Command []string
 	// Entrypoint overrides the default ENTRYPOINT of the image.
 	Entrypoint []string
+	// Domainname is the domain name of the container. It doesn't affect the cluster internal DNS.
+	Domainname string `json:",omitempty"`
 	// Env defines the environment variables to set inside the container.
 	Env EnvVars
+	// ExtraHosts is a list of additional hostname mappings to add to the container's /etc/hosts file.
+	// Format: "hostname:IP". The special "host-gateway" IP resolves to the machine's gateway IP.
+	ExtraHosts []string `json:",omitempty"`
 	// Healthcheck defines the health check configuration for the container or overrides the health check options
 	// defined in the image. If nil, the image's default health check is used.
 	Healthcheck *HealthcheckSpec `json:",omitempty"`
-	Image       string
+	// Hostname overrides the hostname of the container which defaults to the container name.
+	// It doesn't affect the cluster internal DNS that resolves service names.
+	Hostname string `json:",omitempty"`
+	Image    string
 	// Run a custom init inside the container. If nil, use the daemon's configured settings.
 	Init *bool
+	// IpcMode sets the IPC namespace mode for the container. Supported values are "" (Docker daemon default),
+	// "none", "private", "shareable", "host", and "service:<name>" to join the IPC namespace of a container of
+	// another service running on the same machine.
+	IpcMode string `json:",omitempty"`
 	// LogDriver overrides the default logging driver for the container. Each Docker daemon can have its own default.
 	LogDriver *LogDriver
+	// OomScoreAdj tunes the host's OOM killer preferences for the container. Valid range is [-1000, 1000].
+	// A lower value makes the container less likely to be killed when the machine runs out of memory.
+	OomScoreAdj int `json:",omitempty"`
 	// PidMode sets the PID namespace mode for the container. Currently only "" or "host" is supported.
 	PidMode string
 	// Privileged gives extended privileges to the container. This is a security risk and should be used with caution.
@@ -272,16 +480,27 @@ type ContainerSpec struct {
 	PullPolicy string
 	// Resource allocation for the container.
 	Resources ContainerResources
-	// StopGracePeriod is how long to wait after SIGTERM before sending SIGKILL when stopping the container.
+	// SecretMounts specifies how secrets are mounted into the container filesystem.
+	// Each mount references a secret defined in ServiceSpec.Secrets.
+	SecretMounts []SecretMount `json:",omitempty"`
+	// StopGracePeriod is how long to wait after the stop signal before sending SIGKILL when stopping the container.
 	// Default is 10 seconds if not specified.
 	StopGracePeriod *time.Duration `json:",omitempty"`
+	// StopSignal is the signal sent to the container to stop it, e.g. SIGINT or SIGQUIT. Default is the STOPSIGNAL
+	// of the image or SIGTERM if not specified.
+	StopSignal string `json:",omitempty"`
 	// Namespaced kernel parameters to be set in the container.
 	Sysctls map[string]string
 	// User overrides the default user of the image used to run the container. Format: user|UID[:group|GID].
 	User string
+	// UsernsMode sets the user namespace mode for the container. Supported values are "" (Docker daemon default)
+	// and "host" to opt out of user namespace remapping when it's enabled in the Docker daemon.
+	UsernsMode string `json:",omitempty"`
 	// VolumeMounts specifies how volumes are mounted into the container filesystem.
 	// Each mount references a volume defined in ServiceSpec.Volumes.
 	VolumeMounts []VolumeMount
+	// WorkingDir overrides the default working directory of the image for running the command in the container.
+	WorkingDir string `json:",omitempty"`
 	// ConfigMounts specifies how configs are mounted into the container filesystem.
 	// Each mount references a config defined in ServiceSpec.Configs.
 	ConfigMounts []ConfigMount
@@ -306,20 +525,82 @@ func (s *ContainerSpec) SetDefaults() ContainerSpec {
 	return spec
 }
 
+// Validate returns ValidationErrors with the paths of all invalid fields using the Compose service keys.
 func (s *ContainerSpec) Validate() error {
+	var errs ValidationErrors
 	if _, err := reference.ParseDockerRef(s.Image); err != nil {
-		return fmt.Errorf("invalid image '%s': %w", s.Image, err)
+		errs.Addf("image", "invalid image '%s': %w", s.Image, err)
 	}
 
-	for _, m := range s.VolumeMounts {
+	for i, h := range s.ExtraHosts {
+		errs.Add(indexPath("extra_hosts", i), validateExtraHost(h))
+	}
+	errs.Add("ipc", validateIpcMode(s.IpcMode))
+	if s.UsernsMode != "" && s.UsernsMode != UsernsModeHost {
+		errs.Addf("userns_mode", "invalid user namespace mode '%s': only '%s' is supported",
+			s.UsernsMode, UsernsModeHost)
+	}
+	if s.OomScoreAdj < -1000 || s.OomScoreAdj > 1000 {
+		errs.Addf("oom_score_adj", "invalid OOM score adjustment %d: must be in range [-1000, 1000]",
+			s.OomScoreAdj)
+	}
+	if s.StopGracePeriod != nil && *s.StopGracePeriod < 0 {
+		errs.Addf("stop_grace_period", "invalid stop grace period '%s': must not be negative", *s.StopGracePeriod)
+	}
+	if s.StopSignal != "" {
+		if _, err := signal.ParseSignal(s.StopSignal); err != nil {
+			errs.Addf("stop_signal", "invalid stop signal '%s': must be a signal name like SIGINT or number",
+				s.StopSignal)
+		}
+	}
+	if s.Resources.PidsLimit < -1 {
+		errs.Addf("pids_limit", "invalid PIDs limit %d: must be -1 (unlimited) or greater", s.Resources.PidsLimit)
+	}
+	// The tc policer rate is a 32-bit number of bytes per second.
+	if s.Resources.EgressBandwidth < 0 || s.Resources.EgressBandwidth > math.MaxUint32 {
+		errs.Addf("x-bandwidth.egress", "invalid egress bandwidth %d: must be in range [0, %d] bytes per second",
+			s.Resources.EgressBandwidth, uint32(math.MaxUint32))
+	}
+
+	for i, m := range s.VolumeMounts {
 		if err := m.Validate(); err != nil {
-			return fmt.Errorf("invalid volume mount: %w", err)
+			errs.Addf(indexPath("volumes", i), "invalid volume mount: %w", err)
 		}
 	}
 
+	return errs.Err()
+}
+
+func validateExtraHost(h string) error {
+	// IPv6 addresses contain colons so the hostname is separated by the first colon.
+	host, ip, ok := strings.Cut(h, ":")
+	if !ok || host == "" || ip == "" {
+		return fmt.Errorf("invalid extra host '%s': expected format 'hostname:IP'", h)
+	}
+	if ip == "host-gateway" {
+		return nil
+	}
+	if _, err := netip.ParseAddr(strings.Trim(ip, "[]")); err != nil {
+		return fmt.Errorf("invalid extra host '%s': invalid IP address '%s'", h, ip)
+	}
 	return nil
 }
 
+func validateIpcMode(mode string) error {
+	switch mode {
+	case "", IpcModeNone, IpcModePrivate, IpcModeShareable, IpcModeHost:
+		return nil
+	}
+	if name, ok := strings.CutPrefix(mode, IpcModeServicePrefix); ok {
+		if name == "" {
+			return fmt.Errorf("invalid IPC mode '%s': service name must not be empty", mode)
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid IPC mode '%s': supported values are '%s', '%s', '%s', '%s', and '%s<name>'",
+		mode, IpcModeNone, IpcModePrivate, IpcModeShareable, IpcModeHost, IpcModeServicePrefix)
+}
+
 func (s *ContainerSpec) Equals(spec ContainerSpec) bool {
 	orig := s.SetDefaults()
 	spec = spec.SetDefaults()
@@ -328,6 +609,10 @@ func (s *ContainerSpec) Equals(spec ContainerSpec) bool {
 	slices.Sort(orig.Volumes)
 	slices.Sort(spec.Volumes)
 
+	// Extra hosts
+	slices.Sort(orig.ExtraHosts)
+	slices.Sort(spec.ExtraHosts)
+
 	// Volume mounts
 	sortVolumeMounts(orig.VolumeMounts)
 	sortVolumeMounts(spec.VolumeMounts)
@@ -336,12 +621,19 @@ func (s *ContainerSpec) Equals(spec ContainerSpec) bool {
 	sortConfigMounts(orig.ConfigMounts)
 	sortConfigMounts(spec.ConfigMounts)
 
+	// Secret mounts
+	sortSecretMounts(orig.SecretMounts)
+	sortSecretMounts(spec.SecretMounts)
+
 	return cmp.Equal(orig, spec, cmpopts.EquateEmpty())
 }
 
 func (s *ContainerSpec) Clone() ContainerSpec {
 	spec := *s
 
+	if s.Annotations != nil {
+		spec.Annotations = maps.Clone(s.Annotations)
+	}
 	if s.CapAdd != nil {
 		spec.CapAdd = make([]string, len(s.CapAdd))
 		copy(spec.CapAdd, s.CapAdd)
@@ -362,6 +654,9 @@ func (s *ContainerSpec) Clone() ContainerSpec {
 		spec.Env = make(EnvVars, len(s.Env))
 		maps.Copy(spec.Env, s.Env)
 	}
+	if s.ExtraHosts != nil {
+		spec.ExtraHosts = slices.Clone(s.ExtraHosts)
+	}
 	if s.Healthcheck != nil {
 		hc := *s.Healthcheck
 		hc.Test = slices.Clone(s.Healthcheck.Test)
@@ -388,6 +683,12 @@ func (s *ContainerSpec) Clone() ContainerSpec {
 			spec.ConfigMounts[i] = cm.Clone()
 		}
 	}
+	if s.SecretMounts != nil {
+		spec.SecretMounts = make([]SecretMount, len(s.SecretMounts))
+		for i, sm := range s.SecretMounts {
+			spec.SecretMounts[i] = sm.Clone()
+		}
+	}
 	if s.Sysctls != nil {
 		spec.Sysctls = make(map[string]string, len(s.Sysctls))
 		maps.Copy(spec.Sysctls, s.Sysctls)
@@ -486,6 +787,54 @@ func (h *PreDeployHook) Equals(other *PreDeployHook) bool {
 	return cmp.Equal(h, other, cmpopts.EquateEmpty())
 }
 
+// BackupHook defines a command that writes a logical backup of the service data, such as a database dump, to its
+// standard output. Unlike a raw copy of the volume files, it's consistent as the command runs in a running service
+// container and uses the tools of the service, e.g. pg_dump.
+type BackupHook struct {
+	// Command to execute in the container.
+	Command []string
+	// Timeout is the maximum duration to wait for the command to complete. If nil, DefaultBackupTimeout is used.
+	Timeout *time.Duration `json:",omitempty"`
+	// User to run the command as. If empty, the service user is used. Format: user|UID[:group|GID].
+	User string `json:",omitempty"`
+	// RestoreCommand is the optional command that restores a backup written by Command from its standard input.
+	// It's used to verify that backups are restorable in a throwaway service container.
+	RestoreCommand []string `json:",omitempty"`
+}
+
+// DefaultBackupTimeout is the maximum duration of a backup command if the backup hook doesn't specify it.
+const DefaultBackupTimeout = time.Hour
+
+func (h *BackupHook) Validate() error {
+	if len(h.Command) == 0 {
+		return fmt.Errorf("backup hook command is required")
+	}
+	if h.Timeout != nil && *h.Timeout <= 0 {
+		return fmt.Errorf("backup hook timeout must be positive")
+	}
+	return nil
+}
+
+func (h *BackupHook) Clone() *BackupHook {
+	if h == nil {
+		return nil
+	}
+
+	hook := *h
+	hook.Command = slices.Clone(h.Command)
+	hook.RestoreCommand = slices.Clone(h.RestoreCommand)
+	if h.Timeout != nil {
+		timeout := *h.Timeout
+		hook.Timeout = &timeout
+	}
+
+	return &hook
+}
+
+func (h *BackupHook) Equals(other *BackupHook) bool {
+	return cmp.Equal(h, other, cmpopts.EquateEmpty())
+}
+
 // UpdateConfig configures how a service is updated during a deployment.
 type UpdateConfig struct {
 	// Order specifies the order of operations during an update.
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"net/netip"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/assert"
@@ -187,6 +189,40 @@ func TestServiceSpec_Validate_CaddyAndPorts(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "valid with Caddy directives and ingress Ports",
+			spec: ServiceSpec{
+				Name: "test",
+				Container: ContainerSpec{
+					Image: "nginx:latest",
+				},
+				Caddy: &CaddySpec{
+					Directives: "encode gzip",
+				},
+				Ports: []PortSpec{
+					{
+						Hostname:      "example.com",
+						ContainerPort: 80,
+						Protocol:      ProtocolHTTPS,
+						Mode:          PortModeIngress,
+					},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid with Caddy directives and no ingress Ports",
+			spec: ServiceSpec{
+				Name: "test",
+				Container: ContainerSpec{
+					Image: "nginx:latest",
+				},
+				Caddy: &CaddySpec{
+					Directives: "encode gzip",
+				},
+			},
+			wantErr: "injected Caddy directives require at least one HTTP or HTTPS ingress port",
+		},
 	}
 
 	for _, tt := range tests {
@@ -201,6 +237,215 @@ func TestServiceSpec_Validate_CaddyAndPorts(t *testing.T) {
 	}
 }
 
+func TestContainerSpec_Validate_OomScoreAdjAndPidsLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ContainerSpec
+		wantErr string
+	}{
+		{
+			name: "valid OOM score adjustment and PIDs limit",
+			spec: ContainerSpec{
+				Image:       "postgres",
+				OomScoreAdj: -500,
+				Resources:   ContainerResources{PidsLimit: 100},
+			},
+		},
+		{
+			name: "valid unlimited PIDs",
+			spec: ContainerSpec{
+				Image:     "postgres",
+				Resources: ContainerResources{PidsLimit: -1},
+			},
+		},
+		{
+			name: "OOM score adjustment too low",
+			spec: ContainerSpec{
+				Image:       "postgres",
+				OomScoreAdj: -1001,
+			},
+			wantErr: "invalid OOM score adjustment",
+		},
+		{
+			name: "OOM score adjustment too high",
+			spec: ContainerSpec{
+				Image:       "postgres",
+				OomScoreAdj: 1001,
+			},
+			wantErr: "invalid OOM score adjustment",
+		},
+		{
+			name: "invalid PIDs limit",
+			spec: ContainerSpec{
+				Image:     "postgres",
+				Resources: ContainerResources{PidsLimit: -2},
+			},
+			wantErr: "invalid PIDs limit",
+		},
+		{
+			name: "negative egress bandwidth",
+			spec: ContainerSpec{
+				Image:     "postgres",
+				Resources: ContainerResources{EgressBandwidth: -1},
+			},
+			wantErr: "invalid egress bandwidth",
+		},
+		{
+			name: "egress bandwidth too high",
+			spec: ContainerSpec{
+				Image:     "postgres",
+				Resources: ContainerResources{EgressBandwidth: 1 << 32},
+			},
+			wantErr: "invalid egress bandwidth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainerSpec_Validate_Stop(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ContainerSpec
+		wantErr string
+	}{
+		{
+			name: "valid stop signal name and grace period",
+			spec: ContainerSpec{Image: "postgres", StopSignal: "SIGINT", StopGracePeriod: new(time.Minute)},
+		},
+		{
+			name: "valid stop signal without SIG prefix",
+			spec: ContainerSpec{Image: "nginx", StopSignal: "QUIT"},
+		},
+		{
+			name: "valid stop signal number",
+			spec: ContainerSpec{Image: "nginx", StopSignal: "15"},
+		},
+		{
+			name:    "invalid stop signal",
+			spec:    ContainerSpec{Image: "nginx", StopSignal: "SIGFOO"},
+			wantErr: "invalid stop signal 'SIGFOO'",
+		},
+		{
+			name:    "negative grace period",
+			spec:    ContainerSpec{Image: "nginx", StopGracePeriod: new(-time.Second)},
+			wantErr: "invalid stop grace period",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainerSpec_Validate_IpcMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr string
+	}{
+		{name: "default", mode: ""},
+		{name: "none", mode: "none"},
+		{name: "private", mode: "private"},
+		{name: "shareable", mode: "shareable"},
+		{name: "host", mode: "host"},
+		{name: "service", mode: "service:db"},
+		{name: "service without name", mode: "service:", wantErr: "service name must not be empty"},
+		{name: "container", mode: "container:abc", wantErr: "invalid IPC mode"},
+		{name: "unknown", mode: "shared", wantErr: "invalid IPC mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ContainerSpec{Image: "postgres", IpcMode: tt.mode}
+			err := spec.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainerSpec_Validate_UsernsMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr string
+	}{
+		{name: "default", mode: ""},
+		{name: "host", mode: "host"},
+		{name: "private", mode: "private", wantErr: "invalid user namespace mode"},
+		{name: "auto", mode: "auto", wantErr: "invalid user namespace mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ContainerSpec{Image: "postgres", UsernsMode: tt.mode}
+			err := spec.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainerSpec_Validate_ExtraHosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		wantErr string
+	}{
+		{name: "IPv4", host: "db.example.com:10.0.0.5"},
+		{name: "IPv6", host: "db.example.com:2001:db8::1"},
+		{name: "bracketed IPv6", host: "db.example.com:[2001:db8::1]"},
+		{name: "host gateway", host: "host.docker.internal:host-gateway"},
+		{name: "missing IP", host: "db.example.com", wantErr: "expected format 'hostname:IP'"},
+		{name: "empty hostname", host: ":10.0.0.5", wantErr: "expected format 'hostname:IP'"},
+		{name: "invalid IP", host: "db.example.com:not-an-ip", wantErr: "invalid IP address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ContainerSpec{Image: "nginx", ExtraHosts: []string{tt.host}}
+			err := spec.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainerSpec_Equals_ExtraHostsOrder(t *testing.T) {
+	a := ContainerSpec{Image: "nginx", ExtraHosts: []string{"a:10.0.0.1", "b:10.0.0.2"}}
+	b := ContainerSpec{Image: "nginx", ExtraHosts: []string{"b:10.0.0.2", "a:10.0.0.1"}}
+	assert.True(t, a.Equals(b))
+
+	b.ExtraHosts = []string{"a:10.0.0.1"}
+	assert.False(t, a.Equals(b))
+}
+
 func TestContainerSpec_Clone(t *testing.T) {
 	mode := os.FileMode(0o644)
 	original := ContainerSpec{
@@ -299,3 +544,190 @@ func TestContainerSpec_Clone(t *testing.T) {
 	assert.Equal(t, os.FileMode(0o644), *cloned.ConfigMounts[0].Mode, "Mode should be deep copied")
 	assert.Equal(t, "1", cloned.Sysctls["net.ipv4.ip_forward"])
 }
+
+func TestServiceSpec_Validate_PortNames(t *testing.T) {
+	t.Parallel()
+
+	port := func(name, hostname string, containerPort uint16) PortSpec {
+		return PortSpec{
+			Name: name, Hostname: hostname, ContainerPort: containerPort, Protocol: ProtocolHTTPS, Mode: PortModeIngress,
+		}
+	}
+
+	spec := ServiceSpec{
+		Container: ContainerSpec{Image: "nginx"},
+		Ports: []PortSpec{
+			port("http", "app.example.com", 8080),
+			port("http", "www.example.com", 8080),
+			port("admin", "admin.example.com", 9000),
+		},
+	}
+	require.NoError(t, spec.Validate())
+	assert.Equal(t, map[string]uint16{"http": 8080, "admin": 9000}, spec.NamedPorts())
+
+	spec.Ports = append(spec.Ports, port("http", "api.example.com", 9090))
+	assert.EqualError(t, spec.Validate(),
+		"ports[3]: port name 'http' is used for different container ports 8080 and 9090")
+}
+
+func TestServiceSpec_Validate_InternalIPs(t *testing.T) {
+	t.Parallel()
+
+	ip1 := netip.MustParseAddr("10.210.0.200")
+	ip2 := netip.MustParseAddr("10.210.1.200")
+	tests := []struct {
+		name    string
+		spec    ServiceSpec
+		wantErr string
+	}{
+		{
+			name: "replica per IP",
+			spec: ServiceSpec{Replicas: 2, InternalIPs: []netip.Addr{ip1, ip2}},
+		},
+		{
+			name: "global mode",
+			spec: ServiceSpec{Mode: ServiceModeGlobal, InternalIPs: []netip.Addr{ip1}},
+		},
+		{
+			name:    "more replicas than IPs",
+			spec:    ServiceSpec{Replicas: 2, InternalIPs: []netip.Addr{ip1}},
+			wantErr: "x-internal-ip: not enough internal IPs for 2 replicas: each replica must claim one of 1 IPs",
+		},
+		{
+			name:    "duplicate IP",
+			spec:    ServiceSpec{Replicas: 1, InternalIPs: []netip.Addr{ip1, ip1}},
+			wantErr: "x-internal-ip: duplicate internal IP: '10.210.0.200'",
+		},
+		{
+			name:    "IPv6",
+			spec:    ServiceSpec{Replicas: 1, InternalIPs: []netip.Addr{netip.MustParseAddr("fd00::1")}},
+			wantErr: "x-internal-ip: invalid internal IP 'fd00::1': must be an IPv4 address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.spec.Container = ContainerSpec{Image: "nginx"}
+			err := tt.spec.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServiceSpec_Validate_Placement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    ServiceSpec
+		wantErr string
+	}{
+		{
+			name: "exclusion",
+			spec: ServiceSpec{Placement: Placement{Machines: []string{"m1"}, ExcludeMachines: []string{"m2"}}},
+		},
+		{
+			name: "replica counts",
+			spec: ServiceSpec{Replicas: 3, Placement: Placement{MachineReplicas: map[string]uint{"m1": 2, "m2": 1}}},
+		},
+		{
+			name: "replica counts with default replicas",
+			spec: ServiceSpec{Placement: Placement{MachineReplicas: map[string]uint{"m1": 1}}},
+		},
+		{
+			name: "any of machines",
+			spec: ServiceSpec{Placement: Placement{Machines: []string{"m1", "m2", "m3"}, MachineCount: 2}},
+		},
+		{
+			name:    "allowed and excluded",
+			spec:    ServiceSpec{Placement: Placement{Machines: []string{"m1"}, ExcludeMachines: []string{"m1"}}},
+			wantErr: "x-machines: machine 'm1' is both allowed and excluded",
+		},
+		{
+			name:    "replica counts don't match replicas",
+			spec:    ServiceSpec{Replicas: 2, Placement: Placement{MachineReplicas: map[string]uint{"m1": 2, "m2": 1}}},
+			wantErr: "x-machines: replica counts per machine add up to 3 but the service has 2 replicas",
+		},
+		{
+			name:    "zero replica count",
+			spec:    ServiceSpec{Replicas: 1, Placement: Placement{MachineReplicas: map[string]uint{"m1": 1, "m2": 0}}},
+			wantErr: "x-machines: replica count for machine 'm2' must be greater than 0",
+		},
+		{
+			name: "replica counts in global mode",
+			spec: ServiceSpec{
+				Mode:      ServiceModeGlobal,
+				Placement: Placement{MachineReplicas: map[string]uint{"m1": 1}},
+			},
+			wantErr: "x-machines: replica counts per machine are not supported in global mode",
+		},
+		{
+			name: "replica counts with exclusion",
+			spec: ServiceSpec{Placement: Placement{
+				MachineReplicas: map[string]uint{"m1": 1},
+				ExcludeMachines: []string{"m2"},
+			}},
+			wantErr: "x-machines: replica counts per machine can't be combined with other machine constraints",
+		},
+		{
+			name: "spread zones in global mode",
+			spec: ServiceSpec{
+				Mode:      ServiceModeGlobal,
+				Placement: Placement{SpreadZones: true},
+			},
+			wantErr: "x-machines: spreading across zones is not supported in global mode",
+		},
+		{
+			name:    "more machines than listed",
+			spec:    ServiceSpec{Placement: Placement{Machines: []string{"m1", "m2"}, MachineCount: 3}},
+			wantErr: "x-machines: can't choose any 3 of 2 machines",
+		},
+		{
+			name: "replica counts with host port",
+			spec: ServiceSpec{
+				Replicas:  3,
+				Placement: Placement{MachineReplicas: map[string]uint{"m1": 2, "m2": 1}},
+				Ports: []PortSpec{{
+					PublishedPort: 53, ContainerPort: 53, Protocol: ProtocolUDP, Mode: PortModeHost,
+				}},
+			},
+			wantErr: "x-machines: can't run 2 replicas on machine 'm1': the service publishes host port " +
+				"53/udp@host that only one container on a machine can publish",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.spec.Container = ContainerSpec{Image: "nginx"}
+			err := tt.spec.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServiceSpec_Clone_Secrets(t *testing.T) {
+	original := ServiceSpec{
+		Name: "web",
+		Secrets: []SecretSpec{
+			{Name: "db_password", Content: []byte("ciphertext"), SealingKeyID: "3f1c9a7e5b2d8c40", Template: true},
+		},
+	}
+
+	cloned := original.Clone()
+	assert.Equal(t, original.Secrets, cloned.Secrets)
+
+	original.Secrets[0].Content[0] = 'C'
+	assert.Equal(t, []byte("ciphertext"), cloned.Secrets[0].Content)
+}
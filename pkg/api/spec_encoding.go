@@ -0,0 +1,290 @@
+package api
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ServiceSpecVersion is the version of the service spec encoding written by this version of Uncloud. Service specs
+// are stored in the cluster store and machine databases, so a spec written by an older daemon must stay readable
+// by newer ones. Adding an optional field doesn't require a new version as missing fields are decoded as zero values.
+// New fields must have the omitempty JSON option so that the specs that don't use them are still accepted by older
+// daemons which reject specs with unknown fields, see UnknownServiceSpecFields. Bump the version only when
+// the meaning of an existing field changes and register a migration in serviceSpecMigrations to convert older specs.
+const ServiceSpecVersion = 1
+
+// serviceSpecVersionKey is the key of the version marker in the encoded service spec. Specs written before the marker
+// was introduced don't have it and are treated as version 0.
+const serviceSpecVersionKey = "SpecVersion"
+
+// serviceSpecMigrations convert the decoded JSON object of a spec with the version of the map key to the next version.
+// Versions that don't change the encoding have no migration. Version 0 is the encoding without the version marker
+// which only differs from version 1 in the key order and null values.
+var serviceSpecMigrations = map[int]func(obj map[string]any) error{}
+
+// serviceSpecJSON has the same fields as ServiceSpec but uses the default JSON encoding.
+type serviceSpecJSON ServiceSpec
+
+// MarshalJSON encodes the service spec in the canonical form so that equal specs are always encoded to the same bytes.
+// Object keys are sorted, null values and nil slices and maps are omitted, and the encoding version is recorded with
+// the SpecVersion key.
+func (s ServiceSpec) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(serviceSpecJSON(s))
+	if err != nil {
+		return nil, err
+	}
+	obj, err := decodeJSONObject(data)
+	if err != nil {
+		return nil, err
+	}
+	obj[serviceSpecVersionKey] = ServiceSpecVersion
+	// Maps are encoded with sorted keys which makes the encoding independent of the field order in the structs.
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON decodes the service spec written by this or an older version of Uncloud, migrating it to the current
+// version if needed. It fails if the spec was written by a newer version with an incompatible encoding.
+func (s *ServiceSpec) UnmarshalJSON(data []byte) error {
+	var marker struct {
+		SpecVersion int
+	}
+	if err := json.Unmarshal(data, &marker); err != nil {
+		return err
+	}
+	if marker.SpecVersion > ServiceSpecVersion {
+		return fmt.Errorf("service spec version %d is newer than the supported version %d, upgrade Uncloud "+
+			"to read it", marker.SpecVersion, ServiceSpecVersion)
+	}
+
+	if needsServiceSpecMigration(marker.SpecVersion) {
+		obj, err := decodeJSONObject(data)
+		if err != nil {
+			return err
+		}
+		for v := marker.SpecVersion; v < ServiceSpecVersion; v++ {
+			if migrate, ok := serviceSpecMigrations[v]; ok {
+				if err = migrate(obj); err != nil {
+					return fmt.Errorf("migrate service spec from version %d: %w", v, err)
+				}
+			}
+		}
+		if data, err = json.Marshal(obj); err != nil {
+			return err
+		}
+	}
+
+	// The version marker is ignored as serviceSpecJSON has no field for it.
+	return json.Unmarshal(data, (*serviceSpecJSON)(s))
+}
+
+func needsServiceSpecMigration(version int) bool {
+	for v := version; v < ServiceSpecVersion; v++ {
+		if _, ok := serviceSpecMigrations[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrateServiceSpecJSON upgrades the encoded service spec written by this or an older version of Uncloud
+// to the canonical encoding of the current version. It returns the upgraded spec and true if it differs from data.
+// Fields unknown to this version are dropped, so check the spec with UnknownServiceSpecFields first.
+func MigrateServiceSpecJSON(data []byte) ([]byte, bool, error) {
+	var spec ServiceSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, false, err
+	}
+	migrated, err := json.Marshal(spec)
+	if err != nil {
+		return nil, false, err
+	}
+	return migrated, !bytes.Equal(data, migrated), nil
+}
+
+// UnknownServiceSpecFields returns the sorted paths of the fields with non-zero values in the encoded service spec
+// that this version of ServiceSpec doesn't have, for example, Container.StopTimeout. Such a spec was written by
+// a newer version of Uncloud and decoding it would silently drop the fields.
+func UnknownServiceSpecFields(data []byte) ([]string, error) {
+	obj, err := decodeJSONObject(data)
+	if err != nil {
+		return nil, err
+	}
+	delete(obj, serviceSpecVersionKey)
+
+	var unknown []string
+	collectUnknownFields(obj, reflect.TypeFor[serviceSpecJSON](), "", &unknown)
+	slices.Sort(unknown)
+	return unknown, nil
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// collectUnknownFields appends the paths of the fields in the decoded JSON value v that the type t doesn't have.
+func collectUnknownFields(v any, t reflect.Type, path string, unknown *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Types with custom decoding may accept any keys.
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for k, item := range obj {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			ft, ok := lookupJSONField(fields, k)
+			if !ok {
+				if !isZeroJSON(item) {
+					*unknown = append(*unknown, fieldPath)
+				}
+				continue
+			}
+			collectUnknownFields(item, ft, fieldPath, unknown)
+		}
+	case reflect.Map:
+		if obj, ok := v.(map[string]any); ok {
+			for k, item := range obj {
+				collectUnknownFields(item, t.Elem(), path+"."+k, unknown)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if arr, ok := v.([]any); ok {
+			for i, item := range arr {
+				collectUnknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+			}
+		}
+	}
+}
+
+// jsonFields returns the types of the struct fields by their JSON keys including the promoted fields of embedded
+// structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	var embedded []reflect.Type
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	// Fields of the outer struct take precedence over the promoted ones.
+	for _, et := range embedded {
+		for name, ft := range jsonFields(et) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = ft
+			}
+		}
+	}
+	return fields
+}
+
+// lookupJSONField finds the field for the JSON key preferring an exact match but accepting a case-insensitive one
+// like encoding/json does.
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if ft, ok := fields[key]; ok {
+		return ft, true
+	}
+	for name, ft := range fields {
+		if strings.EqualFold(name, key) {
+			return ft, true
+		}
+	}
+	return nil, false
+}
+
+func isZeroJSON(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !val
+	case string:
+		return val == ""
+	case json.Number:
+		f, err := val.Float64()
+		return err == nil && f == 0
+	case []any:
+		return len(val) == 0
+	case map[string]any:
+		return len(val) == 0
+	}
+	return false
+}
+
+// ServiceSpecYAML encodes the service spec in the canonical form as YAML. It has the same keys and values as
+// the JSON encoding.
+func ServiceSpecYAML(s ServiceSpec) ([]byte, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(data)
+}
+
+// decodeJSONObject decodes a JSON object omitting the null values in it and nested objects. Numbers are kept as is
+// to avoid losing precision of large integers.
+func decodeJSONObject(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	omitNulls(obj)
+	return obj, nil
+}
+
+func omitNulls(v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			if item == nil {
+				delete(val, k)
+				continue
+			}
+			omitNulls(item)
+		}
+	case []any:
+		for _, item := range val {
+			omitNulls(item)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "Update the golden files in testdata.")
+
+// TestServiceSpecEncodingGolden verifies that the specs written by older versions are still decoded and encoded
+// to the same canonical form. Never change the v0-*.json files as they are specs written by older daemons. When
+// the encoding changes intentionally, add new files for the new version and regenerate the expected output with:
+//
+//	go test ./pkg/api -run TestServiceSpecEncodingGolden -update
+func TestServiceSpecEncodingGolden(t *testing.T) {
+	for _, name := range []string{"minimal", "full", "caddy"} {
+		t.Run(name, func(t *testing.T) {
+			legacy, err := os.ReadFile(filepath.Join("testdata", "service_spec", "v0-"+name+".json"))
+			require.NoError(t, err)
+
+			var spec ServiceSpec
+			require.NoError(t, json.Unmarshal(legacy, &spec))
+			data, err := json.Marshal(spec)
+			require.NoError(t, err)
+			var indented bytes.Buffer
+			require.NoError(t, json.Indent(&indented, data, "", "  "))
+			indented.WriteByte('\n')
+
+			goldenPath := filepath.Join("testdata", "service_spec", "v1-"+name+".json")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(goldenPath, indented.Bytes(), 0o644))
+			}
+			golden, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), indented.String())
+
+			// The canonical form must decode to the same spec.
+			var decoded ServiceSpec
+			require.NoError(t, json.Unmarshal(golden, &decoded))
+			assert.Equal(t, spec, decoded)
+		})
+	}
+}
+
+func TestServiceSpecMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	spec := ServiceSpec{
+		Name: "web",
+		Container: ContainerSpec{
+			Image: "nginx:1.27",
+			Env:   EnvVars{"B": "2", "A": "1"},
+		},
+	}
+
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Container": {
+			"Env": {"A": "1", "B": "2"},
+			"Image": "nginx:1.27",
+			"PidMode": "",
+			"Privileged": false,
+			"PullPolicy": "",
+			"Resources": {"CPU": 0, "Memory": 0, "MemoryReservation": 0, "PidsLimit": 0, "SharedMemory": 0},
+			"User": ""
+		},
+		"Mode": "",
+		"Name": "web",
+		"Placement": {},
+		"SpecVersion": 1,
+		"UpdateConfig": {}
+	}`, string(data))
+
+	// Pointers and values are encoded the same way.
+	ptrData, err := json.Marshal(&spec)
+	require.NoError(t, err)
+	assert.Equal(t, data, ptrData)
+
+	// Encoding is deterministic.
+	again, err := json.Marshal(spec.Clone())
+	require.NoError(t, err)
+	assert.Equal(t, data, again)
+}
+
+func TestServiceSpecUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("newer version", func(t *testing.T) {
+		t.Parallel()
+		var spec ServiceSpec
+		err := json.Unmarshal([]byte(`{"Name": "web", "SpecVersion": 2}`), &spec)
+		assert.ErrorContains(t, err, "service spec version 2 is newer than the supported version 1")
+	})
+
+	t.Run("unknown fields from compatible versions are ignored", func(t *testing.T) {
+		t.Parallel()
+		var spec ServiceSpec
+		err := json.Unmarshal([]byte(`{"Name": "web", "SpecVersion": 1, "NewField": true}`), &spec)
+		require.NoError(t, err)
+		assert.Equal(t, "web", spec.Name)
+	})
+
+	t.Run("nested in other types", func(t *testing.T) {
+		t.Parallel()
+		var v struct {
+			Spec  ServiceSpec
+			Specs []ServiceSpec
+		}
+		err := json.Unmarshal([]byte(`{"Spec": {"Name": "web", "Replicas": 2}, "Specs": [{"Name": "db"}]}`), &v)
+		require.NoError(t, err)
+		assert.Equal(t, "web", v.Spec.Name)
+		assert.Equal(t, uint(2), v.Spec.Replicas)
+		assert.Equal(t, "db", v.Specs[0].Name)
+	})
+}
+
+func TestServiceSpecMigrations(t *testing.T) {
+	// Not parallel as it replaces the global migrations.
+	orig := serviceSpecMigrations
+	t.Cleanup(func() { serviceSpecMigrations = orig })
+	serviceSpecMigrations = map[int]func(obj map[string]any) error{
+		0: func(obj map[string]any) error {
+			if name, ok := obj["OldName"]; ok {
+				obj["Name"] = name
+				delete(obj, "OldName")
+			}
+			return nil
+		},
+	}
+
+	var spec ServiceSpec
+	require.NoError(t, json.Unmarshal([]byte(`{"OldName": "web"}`), &spec))
+	assert.Equal(t, "web", spec.Name)
+
+	// Specs with the current version are not migrated.
+	spec = ServiceSpec{}
+	require.NoError(t, json.Unmarshal([]byte(`{"OldName": "web", "SpecVersion": 1}`), &spec))
+	assert.Empty(t, spec.Name)
+}
+
+func TestServiceSpecYAML(t *testing.T) {
+	t.Parallel()
+
+	data, err := ServiceSpecYAML(ServiceSpec{
+		Name:      "web",
+		Replicas:  2,
+		Container: ContainerSpec{Image: "nginx:1.27"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `Container:
+  Image: nginx:1.27
+  PidMode: ""
+  Privileged: false
+  PullPolicy: ""
+  Resources:
+    CPU: 0
+    Memory: 0
+    MemoryReservation: 0
+    PidsLimit: 0
+    SharedMemory: 0
+  User: ""
+Mode: ""
+Name: web
+Placement: {}
+Replicas: 2
+SpecVersion: 1
+UpdateConfig: {}
+`, string(data))
+}
+
+func TestMigrateServiceSpecJSON(t *testing.T) {
+	t.Parallel()
+
+	legacy, err := os.ReadFile(filepath.Join("testdata", "service_spec", "v0-minimal.json"))
+	require.NoError(t, err)
+
+	migrated, changed, err := MigrateServiceSpecJSON(legacy)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Contains(t, string(migrated), `"SpecVersion":1`)
+
+	// Migrating the current version is a no-op.
+	again, changed, err := MigrateServiceSpecJSON(migrated)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, migrated, again)
+}
+
+func TestUnknownServiceSpecFields(t *testing.T) {
+	t.Parallel()
+
+	full, err := os.ReadFile(filepath.Join("testdata", "service_spec", "v1-full.json"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "full spec",
+			data: string(full),
+		},
+		{
+			name: "legacy spec",
+			data: `{"Name": "web", "Configs": null, "Container": {"Image": "nginx", "Init": null}}`,
+		},
+		{
+			name: "case-insensitive keys",
+			data: `{"name": "web", "container": {"image": "nginx"}}`,
+		},
+		{
+			name: "unknown fields",
+			data: `{
+				"Name": "web",
+				"Schedule": "@daily",
+				"Container": {"Image": "nginx", "ReadonlyRootfs": true},
+				"Ports": [{"ContainerPort": 80, "AppProtocol": "h2c"}],
+				"SpecVersion": 2
+			}`,
+			want: []string{"Container.ReadonlyRootfs", "Ports[0].AppProtocol", "Schedule"},
+		},
+		{
+			name: "unknown fields with zero values",
+			data: `{"Name": "web", "Schedule": "", "Priority": 0, "Tags": [], "Extra": {}, "Debug": false}`,
+		},
+		{
+			name: "map values",
+			data: `{"Name": "web", "Container": {"Env": {"FOO": "bar"}, "Resources": {"Ulimits": {"nofile": {"Soft": 1, "Burst": 2}}}}}`,
+			want: []string{"Container.Resources.Ulimits.nofile.Burst"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := UnknownServiceSpecFields([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
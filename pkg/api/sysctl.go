@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// sysctlNameRegexp matches a kernel parameter name such as 'net.ipv4.ip_forward'.
+var sysctlNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*$`)
+
+// ValidateSysctlPattern checks that the pattern is a valid entry of the cluster sysctl allow-list. A pattern is
+// either an exact sysctl name, for example, 'net.ipv4.ip_forward', or a prefix followed by '.*' that matches all
+// sysctls under it, for example, 'net.ipv4.*'.
+func ValidateSysctlPattern(pattern string) error {
+	name := strings.TrimSuffix(pattern, ".*")
+	if !sysctlNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid sysctl pattern '%s': expected a sysctl name like 'net.ipv4.ip_forward' "+
+			"or a prefix with a wildcard like 'net.ipv4.*'", pattern)
+	}
+	return nil
+}
+
+// SysctlAllowed reports whether the sysctl name matches any of the allowed patterns. Names written with '/' as
+// a separator, for example, 'net/ipv4/ip_forward', are treated the same as names with '.'.
+func SysctlAllowed(name string, allowed []string) bool {
+	name = strings.ReplaceAll(name, "/", ".")
+	for _, pattern := range allowed {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckSysctlsAllowed returns an error listing the sysctls that don't match the cluster allow-list. An empty
+// allow-list means there is no policy and all sysctls are allowed.
+func CheckSysctlsAllowed(sysctls map[string]string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	var disallowed []string
+	for _, name := range slices.Sorted(maps.Keys(sysctls)) {
+		if !SysctlAllowed(name, allowed) {
+			disallowed = append(disallowed, name)
+		}
+	}
+	if len(disallowed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("sysctls not allowed by the cluster policy: %s (allowed: %s). "+
+		"Ask a cluster operator to allow them with 'uc cluster sysctls allow'",
+		strings.Join(disallowed, ", "), strings.Join(allowed, ", "))
+}
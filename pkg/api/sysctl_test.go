@@ -0,0 +1,106 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSysctlPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{pattern: "net.ipv4.ip_forward"},
+		{pattern: "net.ipv4.*"},
+		{pattern: "kernel.shm_rmid_forced"},
+		{pattern: "net.ipv4.conf.eth-0.forwarding"},
+		{pattern: "", wantErr: true},
+		{pattern: "*", wantErr: true},
+		{pattern: ".*", wantErr: true},
+		{pattern: "net.*.ip_forward", wantErr: true},
+		{pattern: "net.ipv4*", wantErr: true},
+		{pattern: "net..ipv4", wantErr: true},
+		{pattern: "net/ipv4/ip_forward", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateSysctlPattern(tt.pattern)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckSysctlsAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		sysctls map[string]string
+		allowed []string
+		wantErr string
+	}{
+		{
+			name:    "no policy",
+			sysctls: map[string]string{"kernel.msgmax": "65536"},
+		},
+		{
+			name:    "no sysctls",
+			allowed: []string{"net.ipv4.ip_forward"},
+		},
+		{
+			name:    "exact match",
+			sysctls: map[string]string{"net.ipv4.ip_forward": "1"},
+			allowed: []string{"net.ipv4.ip_forward"},
+		},
+		{
+			name:    "wildcard match",
+			sysctls: map[string]string{"net.ipv4.ip_forward": "1", "net.ipv4.tcp_syncookies": "1"},
+			allowed: []string{"net.ipv4.*"},
+		},
+		{
+			name:    "slash separator",
+			sysctls: map[string]string{"net/ipv4/ip_forward": "1"},
+			allowed: []string{"net.ipv4.ip_forward"},
+		},
+		{
+			name:    "wildcard doesn't match partial segment",
+			sysctls: map[string]string{"net.ipv4x.ip_forward": "1"},
+			allowed: []string{"net.ipv4.*"},
+			wantErr: "sysctls not allowed by the cluster policy: net.ipv4x.ip_forward",
+		},
+		{
+			name: "disallowed",
+			sysctls: map[string]string{
+				"net.ipv4.ip_forward": "1",
+				"kernel.shmmax":       "1",
+				"kernel.msgmax":       "1",
+			},
+			allowed: []string{"net.ipv4.ip_forward"},
+			wantErr: "sysctls not allowed by the cluster policy: kernel.msgmax, kernel.shmmax " +
+				"(allowed: net.ipv4.ip_forward)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckSysctlsAllowed(tt.sysctls, tt.allowed)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
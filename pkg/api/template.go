@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// TemplateDriverGolang is the Compose template driver for configs and secrets rendered as Go templates.
+const TemplateDriverGolang = "golang"
+
+// TemplateData is the data available to config and secret templates. They're rendered on the machine when
+// mounting them into a container.
+type TemplateData struct {
+	// Env is the environment variables of the container.
+	Env map[string]string
+	// Machine is the machine the container runs on.
+	Machine TemplateMachine
+	// Service is the service the container belongs to.
+	Service TemplateService
+	// Container is the container the template is mounted into.
+	Container TemplateContainer
+}
+
+type TemplateMachine struct {
+	ID   string
+	Name string
+	// IP is the machine IP address in the cluster network.
+	IP string
+}
+
+type TemplateService struct {
+	ID   string
+	Name string
+	Mode string
+}
+
+type TemplateContainer struct {
+	Name     string
+	Hostname string
+}
+
+// ParseTemplate checks that the content is a valid Go template.
+func ParseTemplate(name string, content []byte) error {
+	_, err := newTemplate(name, content)
+	return err
+}
+
+// RenderTemplate renders the content as a Go template with the given data. Referencing a missing environment
+// variable with {{ .Env.NAME }} fails, use {{ index .Env "NAME" }} to get an empty string instead.
+func RenderTemplate(name string, content []byte, data TemplateData) ([]byte, error) {
+	tmpl, err := newTemplate(name, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func newTemplate(name string, content []byte) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	data := TemplateData{
+		Env:       map[string]string{"PORT": "8080"},
+		Machine:   TemplateMachine{ID: "m1", Name: "machine-1", IP: "10.210.0.1"},
+		Service:   TemplateService{ID: "s1", Name: "web", Mode: ServiceModeReplicated},
+		Container: TemplateContainer{Name: "web-abcd", Hostname: "web-abcd"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "all data",
+			content: "{{.Service.Name}} {{.Container.Name}} on {{.Machine.Name}} ({{.Machine.IP}}):{{.Env.PORT}}",
+			want:    "web web-abcd on machine-1 (10.210.0.1):8080",
+		},
+		{
+			name:    "optional env with index",
+			content: `debug={{index .Env "DEBUG"}}`,
+			want:    "debug=",
+		},
+		{
+			name:    "missing env",
+			content: "{{.Env.DEBUG}}",
+			wantErr: `map has no entry for key "DEBUG"`,
+		},
+		{
+			name:    "syntax error",
+			content: "{{.Env.PORT",
+			wantErr: "parse template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RenderTemplate("config", []byte(tt.content), data)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestConfigSpec_Validate_Template(t *testing.T) {
+	t.Parallel()
+
+	valid := ConfigSpec{Name: "nginx", Content: []byte("listen {{.Env.PORT}};"), Template: true}
+	require.NoError(t, valid.Validate())
+
+	invalid := ConfigSpec{Name: "nginx", Content: []byte("listen {{.Env.PORT;"), Template: true}
+	assert.ErrorContains(t, invalid.Validate(), "config 'nginx': parse template")
+
+	// Content isn't parsed as a template unless Template is set.
+	plain := ConfigSpec{Name: "nginx", Content: []byte("listen {{.Env.PORT;")}
+	require.NoError(t, plain.Validate())
+}
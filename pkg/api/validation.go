@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is a validation error of a field in a spec.
+type FieldError struct {
+	// Path is the path to the invalid field using the Compose file keys where possible, for example,
+	// services.web.secrets[0].uid. Empty if the error isn't specific to a field.
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is a list of all validation errors found in a spec. Validation doesn't stop at the first error
+// so that all invalid fields can be fixed at once.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d validation errors:", len(e))
+	for _, fe := range e {
+		b.WriteString("\n  - ")
+		// Indent the continuation lines of multi-line errors to keep them in the list item.
+		b.WriteString(strings.ReplaceAll(fe.Error(), "\n", "\n    "))
+	}
+	return b.String()
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fe := range e {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// Add appends the error of the field at the path. The paths of nested ValidationErrors and FieldError are prefixed
+// with the path. A nil error is ignored.
+func (e *ValidationErrors) Add(path string, err error) {
+	switch v := err.(type) {
+	case nil:
+		return
+	case ValidationErrors:
+		for _, fe := range v {
+			*e = append(*e, &FieldError{Path: JoinFieldPath(path, fe.Path), Err: fe.Err})
+		}
+	case *FieldError:
+		*e = append(*e, &FieldError{Path: JoinFieldPath(path, v.Path), Err: v.Err})
+	default:
+		*e = append(*e, &FieldError{Path: path, Err: err})
+	}
+}
+
+// Addf appends the formatted error of the field at the path.
+func (e *ValidationErrors) Addf(path, format string, args ...any) {
+	e.Add(path, fmt.Errorf(format, args...))
+}
+
+// Err returns the validation errors as an error or nil if there are none.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// JoinFieldPath joins the field path to the prefix path, for example, services.web and secrets[0].
+func JoinFieldPath(prefix, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	case strings.HasPrefix(path, "["):
+		return prefix + path
+	default:
+		return prefix + "." + path
+	}
+}
+
+// namePath returns the path of the map item or named definition, for example, secrets.db_password.
+func namePath(path, name string) string {
+	if name == "" {
+		return path
+	}
+	return path + "." + name
+}
+
+// indexPath returns the path of the slice item at the index, for example, secrets[0].
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
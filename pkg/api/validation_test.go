@@ -0,0 +1,96 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrors_Add(t *testing.T) {
+	t.Parallel()
+
+	var nested ValidationErrors
+	nested.Addf("uid", "invalid uid")
+	nested.Add("[1]", errors.New("invalid item"))
+
+	var errs ValidationErrors
+	errs.Add("image", nil)
+	errs.Add("secrets[0]", nested)
+	errs.Add("x-ingress", &FieldError{Path: "allow", Err: errors.New("invalid CIDR")})
+	errs.Addf("", "plain error")
+
+	require.Len(t, errs, 4)
+	assert.Equal(t, "secrets[0].uid", errs[0].Path)
+	assert.Equal(t, "secrets[0][1]", errs[1].Path)
+	assert.Equal(t, "x-ingress.allow", errs[2].Path)
+	assert.Equal(t, "", errs[3].Path)
+	assert.EqualError(t, errs, "4 validation errors:\n"+
+		"  - secrets[0].uid: invalid uid\n"+
+		"  - secrets[0][1]: invalid item\n"+
+		"  - x-ingress.allow: invalid CIDR\n"+
+		"  - plain error")
+}
+
+func TestValidationErrors_Err(t *testing.T) {
+	t.Parallel()
+
+	var errs ValidationErrors
+	assert.NoError(t, errs.Err())
+
+	errs.Addf("name", "invalid name")
+	err := errs.Err()
+	assert.EqualError(t, err, "name: invalid name")
+
+	var ve ValidationErrors
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, ve, 1)
+}
+
+func TestServiceSpec_Validate_Aggregated(t *testing.T) {
+	t.Parallel()
+
+	spec := ServiceSpec{
+		Name: "Web",
+		Mode: "unknown",
+		Container: ContainerSpec{
+			Image:      "nginx:",
+			ExtraHosts: []string{"host:1.2.3.4", "invalid"},
+			SecretMounts: []SecretMount{
+				{SecretName: "db_password", Uid: "root"},
+				{SecretName: "missing"},
+			},
+			ConfigMounts: []ConfigMount{
+				{ConfigName: "app", ContainerPath: "relative"},
+			},
+		},
+		Configs: []ConfigSpec{{Name: "app"}},
+		Secrets: []SecretSpec{{Name: "db_password", Content: []byte("secret")}},
+	}
+
+	err := spec.Validate()
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+
+	paths := make([]string, len(errs))
+	for i, fe := range errs {
+		paths[i] = fe.Path
+	}
+	assert.Equal(t, []string{
+		"image",
+		"extra_hosts[1]",
+		"deploy.mode",
+		"name",
+		"configs[0].target",
+		"secrets[0].uid",
+		"secrets[1].source",
+	}, paths, strconv.Quote(err.Error()))
+
+	var prefixed ValidationErrors
+	prefixed.Add("services.web", err)
+	assert.Equal(t, "services.web.secrets[0].uid", prefixed[5].Path)
+	assert.ErrorContains(t, prefixed, "services.web.secrets[1].source: secret mount source 'missing' does not "+
+		"refer to any defined secret")
+}
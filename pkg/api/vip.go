@@ -0,0 +1,44 @@
+package api
+
+import (
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+// IngressVIP is a virtual IP address that floats among ingress machines. One healthy ingress machine holds
+// the address at a time and another one takes it over if the holder goes down.
+type IngressVIP struct {
+	// Address is the virtual IPv4 address with the prefix length of the local network it belongs to,
+	// e.g. 192.168.1.100/24.
+	Address netip.Prefix `json:"address"`
+	// Interface is the network interface on the machines to assign the address to. If empty, the interface with
+	// an address in the same network is used.
+	Interface string `json:"interface,omitempty"`
+}
+
+func (v *IngressVIP) Validate() error {
+	if !v.Address.IsValid() {
+		return fmt.Errorf("invalid address: must be an IP address with a prefix length, e.g. 192.168.1.100/24")
+	}
+	if !v.Address.Addr().Is4() {
+		return fmt.Errorf("invalid address '%s': only IPv4 addresses are supported", v.Address)
+	}
+	if v.Address.Bits() < 8 || v.Address.Bits() > 32 {
+		return fmt.Errorf("invalid address '%s': prefix length must be between 8 and 32", v.Address)
+	}
+	if v.Address.Bits() < 31 && v.Address.Addr() == v.Address.Masked().Addr() {
+		return fmt.Errorf("invalid address '%s': must not be the network address", v.Address)
+	}
+	return nil
+}
+
+// IngressVIPStatus is the current state of the ingress virtual IP.
+type IngressVIPStatus struct {
+	// MachineID is the ID of the machine that holds the address.
+	MachineID string `json:"machine_id"`
+	// MachineName is the name of the machine that holds the address.
+	MachineName string `json:"machine_name"`
+	// Since is the time when the machine took over the address.
+	Since time.Time `json:"since"`
+}
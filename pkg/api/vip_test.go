@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngressVIP_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		wantErr string
+	}{
+		{name: "valid", address: "192.168.1.100/24"},
+		{name: "host prefix", address: "203.0.113.10/32"},
+		{name: "ipv6", address: "fd00::10/64", wantErr: "only IPv4 addresses are supported"},
+		{name: "network address", address: "192.168.1.0/24", wantErr: "must not be the network address"},
+		{name: "short prefix", address: "10.0.0.1/4", wantErr: "prefix length must be between 8 and 32"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vip := IngressVIP{Address: netip.MustParsePrefix(tt.address)}
+			err := vip.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	assert.ErrorContains(t, (&IngressVIP{}).Validate(), "invalid address")
+}
@@ -7,6 +7,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/volume"
@@ -244,6 +245,8 @@ type MachineVolume struct {
 
 // VolumeFilter defines criteria to filter volumes in ListVolumes.
 type VolumeFilter struct {
+	// Dangling filters volumes to those not referenced by any container, e.g. left behind by removed services.
+	Dangling bool
 	// Driver filters volumes by storage driver name.
 	Driver string
 	// Labels filters volumes by label key-value pairs. Volumes must match all labels.
@@ -285,3 +288,30 @@ func (v *MachineVolume) MatchesFilter(filter *VolumeFilter) bool {
 
 	return true
 }
+
+const (
+	// VolumeSnapshotBackendBtrfs is the snapshot backend for volumes stored on a btrfs filesystem.
+	VolumeSnapshotBackendBtrfs = "btrfs"
+	// VolumeSnapshotBackendZFS is the snapshot backend for volumes stored on a ZFS filesystem.
+	VolumeSnapshotBackendZFS = "zfs"
+)
+
+// VolumeSnapshot is an instant point-in-time snapshot of a local volume created with the snapshot capabilities
+// of the filesystem the volume is stored on.
+type VolumeSnapshot struct {
+	// ID is unique among the snapshots of the volume. IDs are derived from the creation time so they sort
+	// chronologically.
+	ID string
+	// Volume is the name of the snapshotted volume.
+	Volume string
+	// Backend is the filesystem used to create the snapshot: btrfs or zfs.
+	Backend string
+	Created time.Time
+}
+
+// MachineVolumeSnapshot represents a volume snapshot on a specific machine.
+type MachineVolumeSnapshot struct {
+	MachineID   string
+	MachineName string
+	Snapshot    VolumeSnapshot
+}
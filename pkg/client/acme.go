@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetACMEDNS configures Caddy on ingress machines to obtain certificates using the ACME DNS-01 challenge.
+// Plain credential values are sealed with the cluster public key before they're sent to the cluster.
+func (cli *Client) SetACMEDNS(ctx context.Context, config api.ACMEDNS) error {
+	creds := maps.Clone(config.Credentials)
+	for opt, value := range creds {
+		if api.IsSealedSecret(value) {
+			continue
+		}
+		sealed, err := cli.SealSecret(ctx, []byte(value))
+		if err != nil {
+			return fmt.Errorf("seal credential '%s': %w", opt, err)
+		}
+		creds[opt] = sealed
+	}
+	config.Credentials = creds
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal ACME DNS config: %w", err)
+	}
+	_, err = cli.ClusterClient.SetACMEDNS(ctx, &pb.ACMEDNS{Config: configJSON})
+	return err
+}
+
+// RemoveACMEDNS removes the ACME DNS-01 challenge configuration so that Caddy uses the default challenges.
+func (cli *Client) RemoveACMEDNS(ctx context.Context) error {
+	_, err := cli.ClusterClient.SetACMEDNS(ctx, &pb.ACMEDNS{})
+	return err
+}
+
+// ACMEDNS returns the ACME DNS-01 challenge configuration or nil if it's not configured. The credentials are sealed.
+func (cli *Client) ACMEDNS(ctx context.Context) (*api.ACMEDNS, error) {
+	resp, err := cli.ClusterClient.GetACMEDNS(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Config) == 0 {
+		return nil, nil
+	}
+
+	var config api.ACMEDNS
+	if err = json.Unmarshal(resp.Config, &config); err != nil {
+		return nil, fmt.Errorf("unmarshal ACME DNS config: %w", err)
+	}
+	return &config, nil
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetAutoUpdatePaused pauses or resumes automatic image updates for the service with the given name or for all
+// services if the name is empty. It returns the updated paused state.
+func (cli *Client) SetAutoUpdatePaused(ctx context.Context, service string, paused bool) (*pb.AutoUpdatePaused, error) {
+	return cli.ClusterClient.SetAutoUpdatePaused(ctx, &pb.SetAutoUpdatePausedRequest{
+		Service: service,
+		Paused:  paused,
+	})
+}
+
+// AutoUpdatePaused returns the services with paused automatic image updates.
+func (cli *Client) AutoUpdatePaused(ctx context.Context) (*pb.AutoUpdatePaused, error) {
+	return cli.ClusterClient.GetAutoUpdatePaused(ctx, &emptypb.Empty{})
+}
+
+// AutoUpdateStatus returns the state of automatic image updates for the service with the given ID or nil
+// if the service has never been checked for updates.
+func (cli *Client) AutoUpdateStatus(ctx context.Context, serviceID string) (*api.AutoUpdateStatus, error) {
+	resp, err := cli.ClusterClient.GetAutoUpdateStatus(ctx, &pb.GetAutoUpdateStatusRequest{ServiceId: serviceID})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Status) == 0 {
+		return nil, nil
+	}
+
+	var status api.AutoUpdateStatus
+	if err = json.Unmarshal(resp.Status, &status); err != nil {
+		return nil, fmt.Errorf("unmarshal auto-update status: %w", err)
+	}
+	return &status, nil
+}
@@ -1,8 +1,11 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 
 	"github.com/Masterminds/semver"
 	"github.com/distribution/reference"
@@ -107,6 +110,43 @@ func (cli *Client) NewCaddyDeployment(image, config string, placement api.Placem
 	return cli.NewDeployment(spec, nil), nil
 }
 
+// IngressPlacement returns the placement for the Caddy service that limits it to machines with the ingress role.
+// It returns an empty placement if no machine has the ingress role, which means Caddy runs on all machines.
+func (cli *Client) IngressPlacement(ctx context.Context) (api.Placement, error) {
+	machines, err := cli.ListMachines(ctx, nil)
+	if err != nil {
+		return api.Placement{}, fmt.Errorf("list machines: %w", err)
+	}
+
+	var placement api.Placement
+	for _, m := range machines {
+		if api.HasMachineRole(m.Machine, api.MachineRoleIngress) {
+			placement.Machines = append(placement.Machines, m.Machine.Name)
+		}
+	}
+	return placement, nil
+}
+
+// IngressMachineIDs returns the IDs of machines that run a Caddy container and serve ingress traffic.
+// It returns an empty list if the Caddy service is not deployed.
+func (cli *Client) IngressMachineIDs(ctx context.Context) ([]string, error) {
+	svc, err := cli.InspectService(ctx, CaddyServiceName)
+	if err != nil {
+		if errors.Is(err, api.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("inspect service '%s': %w", CaddyServiceName, err)
+	}
+
+	var ids []string
+	for _, c := range svc.Containers {
+		if c.Container.State.Running && !slices.Contains(ids, c.MachineID) {
+			ids = append(ids, c.MachineID)
+		}
+	}
+	return ids, nil
+}
+
 // LatestCaddyImage returns the latest image of the official Caddy Docker image on Docker Hub.
 // The latest image is determined by the latest version tag 2.x.x.
 func LatestCaddyImage() (reference.NamedTagged, error) {
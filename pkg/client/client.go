@@ -19,6 +19,8 @@ import (
 type Client struct {
 	connector Connector
 	conn      *grpc.ClientConn
+	// machines caches the cluster machines if enabled with WithMachinesCache.
+	machines *machinesCache
 
 	// TODO: refactor to not embed MachineClient and instead expose only required methods.
 	//  Methods such as Reset or Inspect are ambiguous in the context of a machine+cluster client.
@@ -43,20 +45,40 @@ type Connector interface {
 
 // New creates a new client for the machine API. The connector is used to establish the connection
 // either locally or remotely. The client is responsible for closing the connector.
-func New(ctx context.Context, connector Connector) (*Client, error) {
+// Options configure the connect timeout, RPC timeout, and retry policy for RPCs.
+func New(ctx context.Context, connector Connector, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.connectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.connectTimeout)
+		defer cancel()
+	}
+
 	c := &Client{
 		connector: connector,
+		machines:  o.machines,
 	}
 	var err error
 	c.conn, err = connector.Connect(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("connect to machine: %w", err)
 	}
+	if o.connectTimeout > 0 {
+		if err = waitReady(ctx, c.conn); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("connect to machine within %s: %w", o.connectTimeout, err)
+		}
+	}
 
-	c.MachineClient = pb.NewMachineClient(c.conn)
-	c.ClusterClient = pb.NewClusterClient(c.conn)
-	c.Caddy = pb.NewCaddyClient(c.conn)
-	c.Docker = docker.NewClient(c.conn)
+	conn := &rpcConn{ClientConnInterface: c.conn, opts: o}
+	c.MachineClient = pb.NewMachineClient(conn)
+	c.ClusterClient = pb.NewClusterClient(conn)
+	c.Caddy = pb.NewCaddyClient(conn)
+	c.Docker = docker.NewClient(conn)
 
 	return c, nil
 }
@@ -0,0 +1,56 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const AutoUpdateExtensionKey = "x-auto-update"
+
+// AutoUpdate represents the parsed x-auto-update extension data that enables automatic image updates.
+type AutoUpdate struct {
+	Enabled bool
+	// Interval is how often to check the registry for a new image digest.
+	Interval time.Duration
+}
+
+// DecodeMapstructure implements custom decoding for the boolean and duration forms.
+func (a *AutoUpdate) DecodeMapstructure(value any) error {
+	switch v := value.(type) {
+	case *AutoUpdate:
+		*a = *v
+		return nil
+	case AutoUpdate:
+		*a = v
+		return nil
+	case bool:
+		// Enable auto-update with the default interval: x-auto-update: true
+		*a = AutoUpdate{Enabled: v}
+		if v {
+			a.Interval = api.DefaultAutoUpdateInterval
+		}
+		return nil
+	case string:
+		// Support boolean values that may come from variable interpolation: x-auto-update: ${AUTO_UPDATE}
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			return a.DecodeMapstructure(enabled)
+		}
+		// Enable auto-update with the interval: x-auto-update: 15m
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value '%s': must be a boolean or a duration like '15m'",
+				AutoUpdateExtensionKey, v)
+		}
+		if interval < api.MinAutoUpdateInterval {
+			return fmt.Errorf("invalid %s value '%s': interval must be at least %s",
+				AutoUpdateExtensionKey, v, api.MinAutoUpdateInterval)
+		}
+		*a = AutoUpdate{Enabled: true, Interval: interval}
+		return nil
+	default:
+		return fmt.Errorf("%s must be a boolean or a duration string, got %T", AutoUpdateExtensionKey, value)
+	}
+}
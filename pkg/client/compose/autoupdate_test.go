@@ -0,0 +1,97 @@
+package compose
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceSpecFromCompose_XAutoUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		composeYAML string
+		want        *api.AutoUpdateSpec
+		wantErr     string
+	}{
+		{
+			name: "not set",
+			composeYAML: `
+services:
+  test:
+    image: myapp:1.2
+`,
+		},
+		{
+			name: "true",
+			composeYAML: `
+services:
+  test:
+    image: myapp:1.2
+    x-auto-update: true
+`,
+			want: &api.AutoUpdateSpec{Interval: api.DefaultAutoUpdateInterval},
+		},
+		{
+			name: "false",
+			composeYAML: `
+services:
+  test:
+    image: myapp:1.2
+    x-auto-update: false
+`,
+		},
+		{
+			name: "interval",
+			composeYAML: `
+services:
+  test:
+    image: myapp:1.2
+    x-auto-update: 15m
+`,
+			want: &api.AutoUpdateSpec{Interval: 15 * time.Minute},
+		},
+		{
+			name: "interval too short",
+			composeYAML: `
+services:
+  test:
+    image: myapp:1.2
+    x-auto-update: 10s
+`,
+			wantErr: "interval must be at least 1m0s",
+		},
+		{
+			name: "invalid",
+			composeYAML: `
+services:
+  test:
+    image: myapp:1.2
+    x-auto-update: often
+`,
+			wantErr: "invalid x-auto-update value 'often'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.AutoUpdate)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package compose
+
+import (
+	"fmt"
+
+	"github.com/compose-spec/compose-go/v2/types"
+)
+
+const BackupHookExtensionKey = "x-backup"
+
+// BackupHook represents the parsed x-backup extension config.
+type BackupHook struct {
+	Exec    types.ShellCommand `yaml:"exec" json:"exec"`
+	Timeout *types.Duration    `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	User    string             `yaml:"user,omitempty" json:"user,omitempty"`
+	Restore types.ShellCommand `yaml:"restore,omitempty" json:"restore,omitempty"`
+}
+
+// Validate checks that the backup hook configuration is valid.
+func (b *BackupHook) Validate() error {
+	if len(b.Exec) == 0 {
+		return fmt.Errorf("missing required attribute 'exec' in %s extension", BackupHookExtensionKey)
+	}
+	if b.Timeout != nil && *b.Timeout <= 0 {
+		return fmt.Errorf("attribute 'timeout' in %s extension must be positive", BackupHookExtensionKey)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package compose
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceSpecFromCompose_XBackup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		composeYAML string
+		want        *api.BackupHook
+		wantErr     string
+	}{
+		{
+			name: "not set",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+`,
+		},
+		{
+			name: "exec as string",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+    x-backup:
+      exec: pg_dump -U postgres -Fc app
+`,
+			want: &api.BackupHook{Command: []string{"pg_dump", "-U", "postgres", "-Fc", "app"}},
+		},
+		{
+			name: "all attributes",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+    x-backup:
+      exec: ["sh", "-c", "pg_dumpall -U $$POSTGRES_USER"]
+      timeout: 2h
+      user: postgres
+      restore: psql -U postgres
+`,
+			want: &api.BackupHook{
+				Command:        []string{"sh", "-c", "pg_dumpall -U $POSTGRES_USER"},
+				Timeout:        new(2 * time.Hour),
+				User:           "postgres",
+				RestoreCommand: []string{"psql", "-U", "postgres"},
+			},
+		},
+		{
+			name: "missing exec",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+    x-backup:
+      user: postgres
+`,
+			wantErr: "service 'db': missing required attribute 'exec' in x-backup extension",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "db")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Backup)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const BandwidthExtensionKey = "x-bandwidth"
+
+// bandwidthUnits maps the supported bandwidth units to the number of bits per second they represent.
+var bandwidthUnits = map[string]float64{
+	"bit":  1,
+	"kbit": 1e3,
+	"mbit": 1e6,
+	"gbit": 1e9,
+	"bps":  1,
+	"kbps": 1e3,
+	"mbps": 1e6,
+	"gbps": 1e9,
+}
+
+// Bandwidth represents the parsed x-bandwidth extension data with the bandwidth limits for the service containers.
+type Bandwidth struct {
+	// Egress is the maximum rate (in bytes per second) of the traffic a container can send. 0 means unlimited.
+	Egress int64
+}
+
+// DecodeMapstructure implements custom decoding for the string and map forms.
+func (b *Bandwidth) DecodeMapstructure(value any) error {
+	switch v := value.(type) {
+	case *Bandwidth:
+		*b = *v
+		return nil
+	case Bandwidth:
+		*b = v
+		return nil
+	case string:
+		// Support a single rate that limits egress traffic: x-bandwidth: 10mbit
+		egress, err := ParseBandwidth(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", BandwidthExtensionKey, err)
+		}
+		*b = Bandwidth{Egress: egress}
+		return nil
+	case map[string]any:
+		// Support a map with limits per direction: x-bandwidth: {egress: 10mbit}
+		var res Bandwidth
+		for k, raw := range v {
+			str, ok := raw.(string)
+			if !ok {
+				return fmt.Errorf("%s.%s must be a string, got %T", BandwidthExtensionKey, k, raw)
+			}
+			switch k {
+			case "egress":
+				egress, err := ParseBandwidth(str)
+				if err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", BandwidthExtensionKey, k, err)
+				}
+				res.Egress = egress
+			default:
+				return fmt.Errorf("unsupported %s attribute '%s', only 'egress' is supported",
+					BandwidthExtensionKey, k)
+			}
+		}
+		*b = res
+		return nil
+	default:
+		return fmt.Errorf("%s must be a string or map, got %T", BandwidthExtensionKey, value)
+	}
+}
+
+// ParseBandwidth parses a bandwidth rate in bits per second with a unit, e.g. '500kbit', '10mbit', or '1Gbps',
+// and returns it in bytes per second.
+func ParseBandwidth(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i <= 0 {
+		return 0, fmt.Errorf("'%s' must be a number followed by a unit, for example '10mbit'", s)
+	}
+
+	num, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number in '%s': %w", s, err)
+	}
+	multiplier, ok := bandwidthUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("unsupported unit in '%s', supported units: bit, kbit, mbit, gbit, bps, kbps, "+
+			"mbps, gbps", s)
+	}
+
+	bytes := int64(num * multiplier / 8)
+	if bytes <= 0 {
+		return 0, fmt.Errorf("'%s' must be at least 8bit", s)
+	}
+	return bytes, nil
+}
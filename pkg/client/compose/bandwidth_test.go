@@ -0,0 +1,123 @@
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr string
+	}{
+		{value: "8bit", want: 1},
+		{value: "500kbit", want: 62500},
+		{value: "10mbit", want: 1250000},
+		{value: "1.5Mbps", want: 187500},
+		{value: " 1 gbit ", want: 125000000},
+		{value: "10", wantErr: "must be a number followed by a unit"},
+		{value: "10MB", wantErr: "unsupported unit"},
+		{value: "mbit", wantErr: "must be a number followed by a unit"},
+		{value: "1bit", wantErr: "must be at least 8bit"},
+		{value: "1.2.3mbit", wantErr: "invalid number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseBandwidth(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_XBandwidth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		composeYAML string
+		want        int64
+		wantErr     string
+	}{
+		{
+			name: "not set",
+			composeYAML: `
+services:
+  test:
+    image: restic/restic
+`,
+		},
+		{
+			name: "string",
+			composeYAML: `
+services:
+  test:
+    image: restic/restic
+    x-bandwidth: 20mbit
+`,
+			want: 2500000,
+		},
+		{
+			name: "map",
+			composeYAML: `
+services:
+  test:
+    image: restic/restic
+    x-bandwidth:
+      egress: 8mbit
+`,
+			want: 1000000,
+		},
+		{
+			name: "unsupported attribute",
+			composeYAML: `
+services:
+  test:
+    image: restic/restic
+    x-bandwidth:
+      ingress: 8mbit
+`,
+			wantErr: "unsupported x-bandwidth attribute 'ingress'",
+		},
+		{
+			name: "invalid rate",
+			composeYAML: `
+services:
+  test:
+    image: restic/restic
+    x-bandwidth: fast
+`,
+			wantErr: "invalid x-bandwidth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Container.Resources.EgressBandwidth)
+		})
+	}
+}
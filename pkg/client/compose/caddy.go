@@ -14,6 +14,8 @@ const CaddyExtensionKey = "x-caddy"
 
 type Caddy struct {
 	Config string `yaml:"config" json:"config"`
+	// Directives are injected into the sites generated from the ingress ports in x-ports.
+	Directives string `yaml:"directives" json:"directives"`
 }
 
 // DecodeMapstructure decodes x-caddy extension from either a string or an object.
@@ -87,6 +89,7 @@ func transformServicesCaddyExtension(project *types.Project) (*types.Project, er
 		}
 
 		caddy.Config = strings.TrimSpace(caddy.Config)
+		caddy.Directives = strings.TrimSpace(caddy.Directives)
 		service.Extensions[CaddyExtensionKey] = caddy
 
 		return service, nil
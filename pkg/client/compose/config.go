@@ -34,9 +34,14 @@ func configSpecsFromCompose(
 
 		spec, exists = configSpecsMap[serviceConfig.Source]
 		if !exists {
+			template, err := templateFromDriver(projectConfig.TemplateDriver)
+			if err != nil {
+				return nil, nil, fmt.Errorf("config '%s': %w", serviceConfig.Source, err)
+			}
 			spec = api.ConfigSpec{
-				Name:    serviceConfig.Source,
-				Content: []byte(projectConfig.Content),
+				Name:     serviceConfig.Source,
+				Content:  []byte(projectConfig.Content),
+				Template: template,
 			}
 
 			// If File is specified, read the file contents
@@ -86,3 +91,16 @@ func configSpecsFromCompose(
 
 	return configSpecs, configMounts, nil
 }
+
+// templateFromDriver returns whether the config or secret content is a template based on its template driver.
+func templateFromDriver(driver string) (bool, error) {
+	switch driver {
+	case "":
+		return false, nil
+	case api.TemplateDriverGolang:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported template driver '%s', only '%s' is supported",
+			driver, api.TemplateDriverGolang)
+	}
+}
@@ -173,6 +173,50 @@ func TestConfigSpecsFromCompose(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "templated config",
+			configs: types.Configs{
+				"prometheus": types.ConfigObjConfig{
+					Content:        "external_labels:\n  machine: {{.Machine.Name}}",
+					TemplateDriver: "golang",
+				},
+			},
+			serviceConfigs: []types.ServiceConfigObjConfig{
+				{
+					Source: "prometheus",
+					Target: "/etc/prometheus/prometheus.yml",
+				},
+			},
+			expectedSpecs: []api.ConfigSpec{
+				{
+					Name:     "prometheus",
+					Content:  []byte("external_labels:\n  machine: {{.Machine.Name}}"),
+					Template: true,
+				},
+			},
+			expectedMounts: []api.ConfigMount{
+				{
+					ConfigName:    "prometheus",
+					ContainerPath: "/etc/prometheus/prometheus.yml",
+				},
+			},
+		},
+		{
+			name: "unsupported template driver error",
+			configs: types.Configs{
+				"prometheus": types.ConfigObjConfig{
+					Content:        "{{ .Machine.Name }}",
+					TemplateDriver: "jinja2",
+				},
+			},
+			serviceConfigs: []types.ServiceConfigObjConfig{
+				{
+					Source: "prometheus",
+					Target: "/etc/prometheus/prometheus.yml",
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "config not found error",
 			configs: types.Configs{
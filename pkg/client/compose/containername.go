@@ -0,0 +1,29 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/types"
+)
+
+// ContainerNameExtensionKey is the top-level Compose extension key for specifying the naming template for service
+// containers in the project.
+const ContainerNameExtensionKey = "x-container_name"
+
+// ContainerNamePlaceholderProject is replaced with the project name in the x-container_name template.
+const ContainerNamePlaceholderProject = "{project}"
+
+// ContainerNameTemplate extracts the x-container_name template from the project's top-level extensions and replaces
+// the project placeholder. Returns an empty string if x-container_name is not set.
+func ContainerNameTemplate(project *types.Project) (string, error) {
+	v, ok := project.Extensions[ContainerNameExtensionKey]
+	if !ok {
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid %s value: must be a string", ContainerNameExtensionKey)
+	}
+	return strings.ReplaceAll(s, ContainerNamePlaceholderProject, project.Name), nil
+}
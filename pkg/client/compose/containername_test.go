@@ -0,0 +1,69 @@
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerNameTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no x-container_name",
+			content: `
+services:
+  web:
+    image: nginx
+`,
+			want: "",
+		},
+		{
+			name: "x-container_name with project placeholder",
+			content: `
+name: myapp
+x-container_name: "{project}-{service}-{ordinal}"
+services:
+  web:
+    image: nginx
+`,
+			want: "myapp-{service}-{ordinal}",
+		},
+		{
+			name: "x-container_name not a string",
+			content: `
+x-container_name:
+  template: "{service}-{ordinal}"
+services:
+  web:
+    image: nginx
+`,
+			wantErr: "must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := LoadProjectFromContent(context.Background(), tt.content)
+			require.NoError(t, err)
+
+			got, err := ContainerNameTemplate(project)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			spec, err := ServiceSpecFromCompose(project, "web")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.ContainerNameTemplate)
+		})
+	}
+}
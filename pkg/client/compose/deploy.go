@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -15,20 +16,28 @@ import (
 	"github.com/psviderski/uncloud/pkg/client/deploy"
 	"github.com/psviderski/uncloud/pkg/client/deploy/operation"
 	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+	"github.com/psviderski/uncloud/pkg/client/secrets"
 )
 
 type Client interface {
 	api.DNSClient
+	api.EnvClient
+	api.ProjectClient
+	api.ReservationClient
+	api.SecretClient
 	deploy.Client
 }
 
 type Deployment struct {
-	Client       Client
-	Project      *types.Project
-	SpecResolver *deploy.ServiceSpecResolver
-	Strategy     deploy.Strategy
-	state        *scheduler.ClusterState
-	plan         *Plan
+	Client  Client
+	Project *types.Project
+	// SecretResolver resolves the project secrets that reference external secret providers. If nil, the default
+	// resolver with the built-in providers is used.
+	SecretResolver *secrets.Resolver
+	SpecResolver   *deploy.ServiceSpecResolver
+	Strategy       deploy.Strategy
+	state          *scheduler.ClusterState
+	plan           *Plan
 }
 
 func NewDeployment(ctx context.Context, cli Client, project *types.Project) (*Deployment, error) {
@@ -65,6 +74,20 @@ func (d *Deployment) Plan(ctx context.Context) (Plan, error) {
 	}
 	var plan Plan
 
+	resolver := d.SecretResolver
+	if resolver == nil {
+		resolver = secrets.NewDefaultResolver(d.Project.WorkingDir)
+	}
+	if err := ResolveSecrets(ctx, d.Project, resolver); err != nil {
+		return plan, fmt.Errorf("resolve secrets: %w", err)
+	}
+	if err := ResolveClusterSecrets(ctx, d.Project, d.Client); err != nil {
+		return plan, fmt.Errorf("resolve cluster secrets: %w", err)
+	}
+	if err := ApplyEnvOverrides(ctx, d.Project, d.Client); err != nil {
+		return plan, fmt.Errorf("apply environment overrides: %w", err)
+	}
+
 	// Generate service specs for all services in the project.
 	var serviceSpecs []api.ServiceSpec
 	var mu sync.Mutex
@@ -83,6 +106,17 @@ func (d *Deployment) Plan(ctx context.Context) (Plan, error) {
 	if err != nil {
 		return plan, err
 	}
+	if err = validateServiceSpecs(serviceSpecs); err != nil {
+		return plan, err
+	}
+	reserve, err := d.checkReservations(ctx, serviceSpecs)
+	if err != nil {
+		return plan, err
+	}
+	if reserve != nil {
+		plan.prepare = append(plan.prepare, reserve)
+	}
+	plan.prepare = append(plan.prepare, d.recordProjectServices(serviceSpecs))
 
 	// Check external volumes and plan the creation of missing volumes before deploying services.
 	// Updates the cluster state (d.state) with the scheduled volumes.
@@ -92,8 +126,9 @@ func (d *Deployment) Plan(ctx context.Context) (Plan, error) {
 	}
 	plan.Volumes = volumeOps
 
+	// The service specs are in dependency order so the dependencies are deployed before the services that depend
+	// on them.
 	for _, spec := range serviceSpecs {
-		// TODO: properly handle depends_on conditions in the service deployment plan as the first operation.
 		// Pass the updated cluster state with the scheduled volumes to the deployment.
 		deployment := deploy.NewDeploymentWithClusterState(d.Client, spec, d.Strategy, d.state)
 		servicePlan, err := deployment.Plan(ctx)
@@ -103,6 +138,7 @@ func (d *Deployment) Plan(ctx context.Context) (Plan, error) {
 
 		// Skip no-op (up-to-date) service plans.
 		if len(servicePlan.Operations) > 0 {
+			servicePlan.Operations = append(d.dependencyOperations(spec.Name), servicePlan.Operations...)
 			plan.Services = append(plan.Services, &servicePlan)
 		}
 	}
@@ -111,6 +147,26 @@ func (d *Deployment) Plan(ctx context.Context) (Plan, error) {
 	return plan, nil
 }
 
+// dependencyOperations returns the operations that wait for the depends_on services of the service to start
+// or become healthy according to their conditions before deploying the service.
+func (d *Deployment) dependencyOperations(name string) []operation.Operation {
+	service, ok := d.Project.Services[name]
+	if !ok {
+		return nil
+	}
+
+	var ops []operation.Operation
+	for _, dep := range slices.Sorted(maps.Keys(service.DependsOn)) {
+		ops = append(ops, &operation.WaitDependencyOperation{
+			ServiceName: name,
+			Dependency:  dep,
+			Healthy:     service.DependsOn[dep].Condition == types.ServiceConditionHealthy,
+			Required:    service.DependsOn[dep].Required,
+		})
+	}
+	return ops
+}
+
 // ServiceSpec returns the service specification for the given compose service that is ready for deployment.
 func (d *Deployment) ServiceSpec(name string) (api.ServiceSpec, error) {
 	spec, err := ServiceSpecFromCompose(d.Project, name)
@@ -121,6 +177,24 @@ func (d *Deployment) ServiceSpec(name string) (api.ServiceSpec, error) {
 	return spec, nil
 }
 
+// validateServiceSpecs validates the specs of all services in the project before planning any of them so that
+// all invalid fields are reported at once. The field paths are prefixed with services.<name> to match the Compose file.
+func validateServiceSpecs(specs []api.ServiceSpec) error {
+	// Specs are generated concurrently so sort them to report the errors in a stable order.
+	sorted := slices.SortedFunc(slices.Values(specs), func(a, b api.ServiceSpec) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var errs api.ValidationErrors
+	for _, spec := range sorted {
+		errs.Add("services."+spec.Name, spec.Validate())
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("invalid service spec: %w", err)
+	}
+	return nil
+}
+
 // PlanVolumes checks if the external volumes exist and plans the creation of missing volumes.
 func (d *Deployment) planVolumes(serviceSpecs []api.ServiceSpec) ([]*operation.CreateVolumeOperation, error) {
 	if len(d.Project.Volumes) == 0 {
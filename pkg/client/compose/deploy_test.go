@@ -0,0 +1,28 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/client/deploy/operation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentDependencyOperations(t *testing.T) {
+	t.Parallel()
+
+	d := &Deployment{Project: &types.Project{Services: types.Services{
+		"db":    {Name: "db"},
+		"cache": {Name: "cache"},
+		"web": {Name: "web", DependsOn: types.DependsOnConfig{
+			"db":    {Condition: types.ServiceConditionHealthy, Required: true},
+			"cache": {Condition: types.ServiceConditionStarted},
+		}},
+	}}}
+
+	assert.Empty(t, d.dependencyOperations("db"))
+	assert.Equal(t, []operation.Operation{
+		&operation.WaitDependencyOperation{ServiceName: "web", Dependency: "cache"},
+		&operation.WaitDependencyOperation{ServiceName: "web", Dependency: "db", Healthy: true, Required: true},
+	}, d.dependencyOperations("web"))
+}
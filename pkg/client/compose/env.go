@@ -0,0 +1,39 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApplyEnvOverrides merges the environment variable overrides stored in the cluster for the project services
+// into their environment from the Compose file. The overrides take precedence.
+func ApplyEnvOverrides(ctx context.Context, project *types.Project, cli api.EnvClient) error {
+	for name, service := range project.Services {
+		env, err := cli.ServiceEnv(ctx, project.Name, name)
+		if err != nil {
+			// Older machines don't support environment overrides so there is nothing to apply.
+			if status.Code(err) == codes.Unimplemented {
+				return nil
+			}
+			return fmt.Errorf("get environment overrides for service '%s': %w", name, err)
+		}
+		if len(env) == 0 {
+			continue
+		}
+
+		if service.Environment == nil {
+			service.Environment = make(types.MappingWithEquals, len(env))
+		}
+		for k, v := range env {
+			service.Environment[k] = &v
+		}
+		project.Services[name] = service
+	}
+
+	return nil
+}
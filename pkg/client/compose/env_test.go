@@ -0,0 +1,114 @@
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeEnvClient struct {
+	env map[string]map[string]string
+	err error
+}
+
+func (c *fakeEnvClient) ServiceEnv(_ context.Context, project, service string) (map[string]string, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.env[project+"/"+service], nil
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Parallel()
+
+	newProject := func() *types.Project {
+		return &types.Project{
+			Name: "myapp",
+			Services: types.Services{
+				"web": {
+					Name: "web",
+					Environment: types.MappingWithEquals{
+						"LOG_LEVEL": ptr("info"),
+						"PORT":      ptr("8000"),
+					},
+				},
+				"worker": {
+					Name: "worker",
+				},
+				"db": {
+					Name:        "db",
+					Environment: types.MappingWithEquals{"PGDATA": ptr("/data")},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		client  *fakeEnvClient
+		want    map[string]types.MappingWithEquals
+		wantErr string
+	}{
+		{
+			name: "overrides merged",
+			client: &fakeEnvClient{env: map[string]map[string]string{
+				"myapp/web":    {"LOG_LEVEL": "debug", "FEATURE_X": "true"},
+				"myapp/worker": {"WORKERS": "4"},
+				"other/db":     {"PGDATA": "/other"},
+			}},
+			want: map[string]types.MappingWithEquals{
+				"web": {
+					"LOG_LEVEL": ptr("debug"),
+					"PORT":      ptr("8000"),
+					"FEATURE_X": ptr("true"),
+				},
+				"worker": {"WORKERS": ptr("4")},
+				"db":     {"PGDATA": ptr("/data")},
+			},
+		},
+		{
+			name:   "unimplemented ignored",
+			client: &fakeEnvClient{err: status.Error(codes.Unimplemented, "unknown method")},
+			want: map[string]types.MappingWithEquals{
+				"web": {
+					"LOG_LEVEL": ptr("info"),
+					"PORT":      ptr("8000"),
+				},
+				"worker": nil,
+				"db":     {"PGDATA": ptr("/data")},
+			},
+		},
+		{
+			name:    "error",
+			client:  &fakeEnvClient{err: status.Error(codes.Unavailable, "cluster unavailable")},
+			wantErr: "cluster unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project := newProject()
+			err := ApplyEnvOverrides(context.Background(), project, tt.client)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			for name, want := range tt.want {
+				assert.Equal(t, want, project.Services[name].Environment, name)
+			}
+		})
+	}
+}
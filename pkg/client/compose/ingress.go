@@ -0,0 +1,201 @@
+package compose
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+const IngressExtensionKey = "x-ingress"
+
+// Ingress represents the parsed x-ingress extension data with the policy that configures how the ingress routes
+// of the service are proxied and protected.
+type Ingress api.IngressPolicy
+
+// DecodeMapstructure implements custom decoding for the map form.
+func (in *Ingress) DecodeMapstructure(value any) error {
+	switch v := value.(type) {
+	case *Ingress:
+		*in = *v
+		return nil
+	case Ingress:
+		*in = v
+		return nil
+	case map[string]any:
+		var res Ingress
+		for k, raw := range v {
+			var err error
+			switch k {
+			case "rate_limit":
+				str, ok := raw.(string)
+				if !ok {
+					return fmt.Errorf("%s.%s must be a string, got %T", IngressExtensionKey, k, raw)
+				}
+				rateLimit, err := api.ParseRateLimit(str)
+				if err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+				res.RateLimit = &rateLimit
+			case "max_connections":
+				if res.MaxConnections, err = decodeMaxConnections(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			case "allow":
+				if res.Allow, err = decodeIPPrefixes(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			case "deny":
+				if res.Deny, err = decodeIPPrefixes(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			case "backend_protocol":
+				str, ok := raw.(string)
+				if !ok {
+					return fmt.Errorf("%s.%s must be a string, got %T", IngressExtensionKey, k, raw)
+				}
+				if str != api.IngressBackendHTTP && str != api.IngressBackendH2C {
+					return fmt.Errorf("invalid %s.%s '%s': must be one of: %s, %s", IngressExtensionKey, k, str,
+						api.IngressBackendHTTP, api.IngressBackendH2C)
+				}
+				res.BackendProtocol = str
+			case "stream_timeout":
+				if res.StreamTimeout, err = decodeDuration(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			case "stream_close_delay":
+				if res.StreamCloseDelay, err = decodeDuration(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			case "max_body_size":
+				if res.MaxBodySize, err = decodeBodySize(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			case "request_id":
+				if res.RequestIDHeader, err = decodeRequestID(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			case "trusted_proxies":
+				if res.TrustedProxies, err = decodeIPPrefixes(raw); err != nil {
+					return fmt.Errorf("invalid %s.%s: %w", IngressExtensionKey, k, err)
+				}
+			default:
+				return fmt.Errorf("unsupported %s attribute '%s', supported attributes: rate_limit, "+
+					"max_connections, allow, deny, backend_protocol, stream_timeout, stream_close_delay, "+
+					"max_body_size, request_id, trusted_proxies", IngressExtensionKey, k)
+			}
+		}
+		*in = res
+		return nil
+	default:
+		return fmt.Errorf("%s must be a map, got %T", IngressExtensionKey, value)
+	}
+}
+
+func decodeMaxConnections(value any) (uint, error) {
+	switch v := value.(type) {
+	case int:
+		if v <= 0 {
+			return 0, fmt.Errorf("must be a positive integer, got %d", v)
+		}
+		return uint(v), nil
+	case string:
+		// Support string values that may come from variable interpolation: max_connections: ${MAX_CONNS}
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("must be a positive integer, got '%s'", v)
+		}
+		return uint(n), nil
+	default:
+		return 0, fmt.Errorf("must be a positive integer, got %T", value)
+	}
+}
+
+// decodeDuration decodes a positive duration string such as '30s' or '1h'.
+func decodeDuration(value any) (time.Duration, error) {
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("must be a duration string, e.g. '1h', got %T", value)
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive, got '%s'", str)
+	}
+	return d, nil
+}
+
+// decodeBodySize decodes a size in bytes or a human-readable size string such as '10m' or '1g'.
+func decodeBodySize(value any) (int64, error) {
+	var size int64
+	switch v := value.(type) {
+	case int:
+		size = int64(v)
+	case string:
+		var err error
+		if size, err = units.RAMInBytes(v); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("must be a size in bytes or a string such as '10m', got %T", value)
+	}
+	if size <= 0 {
+		return 0, fmt.Errorf("must be positive, got %v", value)
+	}
+	return size, nil
+}
+
+// decodeRequestID decodes a boolean that enables request IDs in the default header or the name of the header.
+func decodeRequestID(value any) (string, error) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return api.DefaultRequestIDHeader, nil
+		}
+		return "", nil
+	case string:
+		// Support boolean strings that may come from variable interpolation: request_id: ${REQUEST_ID}
+		if b, err := strconv.ParseBool(v); err == nil {
+			return decodeRequestID(b)
+		}
+		policy := api.IngressPolicy{RequestIDHeader: v}
+		if err := policy.Validate(); err != nil {
+			return "", err
+		}
+		return v, nil
+	default:
+		return "", fmt.Errorf("must be a boolean or a header name, got %T", value)
+	}
+}
+
+// decodeIPPrefixes decodes a single IP range or a list of IP ranges. Single IP addresses are also allowed.
+func decodeIPPrefixes(value any) ([]netip.Prefix, error) {
+	var items []any
+	switch v := value.(type) {
+	case string:
+		items = []any{v}
+	case []any:
+		items = v
+	default:
+		return nil, fmt.Errorf("must be a string or list of strings, got %T", value)
+	}
+
+	prefixes := make([]netip.Prefix, 0, len(items))
+	for i, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("item %d must be a string, got %T", i, item)
+		}
+		prefix, err := api.ParseIPPrefix(str)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
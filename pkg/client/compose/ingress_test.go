@@ -0,0 +1,231 @@
+package compose
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceSpecFromCompose_XIngress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		composeYAML string
+		want        *api.IngressPolicy
+		wantErr     string
+	}{
+		{
+			name: "not set",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+`,
+		},
+		{
+			name: "full policy",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      rate_limit: 100/s
+      max_connections: 50
+      allow:
+        - 10.0.0.0/8
+        - 203.0.113.7
+      deny: 10.1.0.0/16
+`,
+			want: &api.IngressPolicy{
+				RateLimit:      &api.RateLimit{Requests: 100, Window: time.Second},
+				MaxConnections: 50,
+				Allow: []netip.Prefix{
+					netip.MustParsePrefix("10.0.0.0/8"),
+					netip.MustParsePrefix("203.0.113.7/32"),
+				},
+				Deny: []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")},
+			},
+		},
+		{
+			name: "proxying options",
+			composeYAML: `
+services:
+  test:
+    image: grpc-app
+    x-ports:
+      - grpc.example.com:50051/https
+    x-ingress:
+      backend_protocol: h2c
+      stream_timeout: 1h
+      stream_close_delay: 5m
+      max_body_size: 10m
+`,
+			want: &api.IngressPolicy{
+				BackendProtocol:  api.IngressBackendH2C,
+				StreamTimeout:    time.Hour,
+				StreamCloseDelay: 5 * time.Minute,
+				MaxBodySize:      10 << 20,
+			},
+		},
+		{
+			name: "max body size in bytes",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      max_body_size: 1048576
+`,
+			want: &api.IngressPolicy{MaxBodySize: 1 << 20},
+		},
+		{
+			name: "request tracing options",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      request_id: true
+      trusted_proxies:
+        - 173.245.48.0/20
+        - 2400:cb00::/32
+`,
+			want: &api.IngressPolicy{
+				RequestIDHeader: api.DefaultRequestIDHeader,
+				TrustedProxies: []netip.Prefix{
+					netip.MustParsePrefix("173.245.48.0/20"),
+					netip.MustParsePrefix("2400:cb00::/32"),
+				},
+			},
+		},
+		{
+			name: "custom request ID header",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      request_id: X-Correlation-ID
+`,
+			want: &api.IngressPolicy{RequestIDHeader: "X-Correlation-ID"},
+		},
+		{
+			name: "invalid request ID header",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      request_id: X Correlation ID
+`,
+			wantErr: "invalid x-ingress.request_id: invalid request ID header name",
+		},
+		{
+			name: "invalid backend protocol",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      backend_protocol: grpc
+`,
+			wantErr: "invalid x-ingress.backend_protocol 'grpc'",
+		},
+		{
+			name: "invalid stream timeout",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      stream_timeout: forever
+`,
+			wantErr: "invalid x-ingress.stream_timeout",
+		},
+		{
+			name: "interpolated max connections",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ports:
+      - app.example.com:80/https
+    x-ingress:
+      max_connections: ${MAX_CONNS:-20}
+`,
+			want: &api.IngressPolicy{MaxConnections: 20},
+		},
+		{
+			name: "unsupported attribute",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ingress:
+      waf: true
+`,
+			wantErr: "unsupported x-ingress attribute 'waf'",
+		},
+		{
+			name: "invalid rate limit",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ingress:
+      rate_limit: fast
+`,
+			wantErr: "invalid x-ingress.rate_limit",
+		},
+		{
+			name: "invalid IP range",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-ingress:
+      deny: [10.0.0.0/40]
+`,
+			wantErr: "invalid x-ingress.deny",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Ingress)
+		})
+	}
+}
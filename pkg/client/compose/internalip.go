@@ -0,0 +1,66 @@
+package compose
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+const InternalIPExtensionKey = "x-internal-ip"
+
+// InternalIPSource represents the parsed x-internal-ip extension data as a list of fixed IPs claimed by
+// the service containers.
+type InternalIPSource []netip.Addr
+
+// DecodeMapstructure implements custom decoding for multiple input types.
+func (s *InternalIPSource) DecodeMapstructure(value any) error {
+	switch v := value.(type) {
+	case *InternalIPSource:
+		*s = *v
+		return nil
+	case InternalIPSource:
+		*s = v
+		return nil
+	case string:
+		// Support single IP or comma-separated IPs: x-internal-ip: 10.210.0.200 or "10.210.0.200,10.210.1.200"
+		ips, err := parseInternalIPs(strings.Split(v, ","))
+		if err != nil {
+			return err
+		}
+		*s = ips
+		return nil
+	case []any:
+		// Support a list of IPs: x-internal-ip: ["10.210.0.200", "10.210.1.200"]
+		values := make([]string, len(v))
+		for i, ip := range v {
+			str, ok := ip.(string)
+			if !ok {
+				return fmt.Errorf("%s[%d] is not a string, got %T", InternalIPExtensionKey, i, ip)
+			}
+			values[i] = str
+		}
+		ips, err := parseInternalIPs(values)
+		if err != nil {
+			return err
+		}
+		*s = ips
+		return nil
+	default:
+		return fmt.Errorf("%s must be a string or list of strings, got %T", InternalIPExtensionKey, value)
+	}
+}
+
+func parseInternalIPs(values []string) ([]netip.Addr, error) {
+	ips := make([]netip.Addr, 0, len(values))
+	for _, v := range values {
+		ip, err := netip.ParseAddr(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s': %w", InternalIPExtensionKey, v, err)
+		}
+		if !ip.Is4() {
+			return nil, fmt.Errorf("invalid %s '%s': must be an IPv4 address", InternalIPExtensionKey, v)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
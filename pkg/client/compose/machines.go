@@ -1,14 +1,24 @@
 package compose
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"strings"
+
+	"github.com/psviderski/uncloud/pkg/api"
 )
 
 const MachinesExtensionKey = "x-machines"
 
-// MachinesSource represents the parsed x-machines extension data as slice of strings
-type MachinesSource []string
+// MachinesSource represents the parsed x-machines extension data. It supports:
+//   - a machine name or a comma-separated list of names: "machine-1,machine-2"
+//   - a list of names where names prefixed with '!' are excluded: ["machine-1", "!machine-3"]
+//   - a map of replica counts per machine: {machine-1: 2, machine-2: 1}
+//   - any N of the listed machines or any N machines if the list is omitted: {any: 2, of: [machine-1, machine-2]}
+type MachinesSource api.Placement
 
 // DecodeMapstructure implements custom decoding for multiple input types
 func (m *MachinesSource) DecodeMapstructure(value any) error {
@@ -24,43 +34,52 @@ func (m *MachinesSource) DecodeMapstructure(value any) error {
 	case string:
 		// Support single string value or comma-separated values
 		// x-machines: my-machine or x-machines: "machine-1,machine-2"
-		machines, err := parseMachineNames(v)
+		placement, err := parseMachineNames(v)
 		if err != nil {
 			return err
 		}
-		*m = MachinesSource(machines)
+		*m = MachinesSource(placement)
 		return nil
 	case []string:
 		// Support string array: x-machines: ["machine-1", "machine-2"]
-		machines, err := validateMachineNames(v)
+		placement, err := validateMachineNames(v)
 		if err != nil {
 			return err
 		}
-		*m = MachinesSource(machines)
+		*m = MachinesSource(placement)
 		return nil
 	case []any:
 		// Support interface array that may come from YAML parsing
-		machineNames := make([]string, 0, len(v))
-		for i, machine := range v {
-			str, ok := machine.(string)
-			if !ok {
-				return fmt.Errorf("x-machines[%d] is not a string, got %T", i, machine)
-			}
-			machineNames = append(machineNames, str)
+		machineNames, err := anyToStrings(v)
+		if err != nil {
+			return err
+		}
+		placement, err := validateMachineNames(machineNames)
+		if err != nil {
+			return err
 		}
-		machines, err := validateMachineNames(machineNames)
+		*m = MachinesSource(placement)
+		return nil
+	case map[string]any:
+		placement, err := parseMachinesMap(v)
 		if err != nil {
 			return err
 		}
-		*m = MachinesSource(machines)
+		*m = MachinesSource(placement)
 		return nil
 	default:
-		return fmt.Errorf("x-machines must be a string or list of strings, got %T", value)
+		return fmt.Errorf("x-machines must be a string, list of strings, or map, got %T", value)
 	}
 }
 
+// PushMachines returns the names or IDs of the machines that may run the service containers and need its image.
+// An empty result means all machines.
+func (m MachinesSource) PushMachines() []string {
+	return api.Placement(m).AllowedMachines()
+}
+
 // parseMachineNames parses a single string that may contain comma-separated machine names
-func parseMachineNames(machinesStr string) ([]string, error) {
+func parseMachineNames(machinesStr string) (api.Placement, error) {
 	// Split by comma and process each machine name, works for both single and multiple values
 	parts := strings.Split(machinesStr, ",")
 	machines := make([]string, 0, len(parts))
@@ -70,14 +89,106 @@ func parseMachineNames(machinesStr string) ([]string, error) {
 	return validateMachineNames(machines)
 }
 
-// validateMachineNames validates machine names to ensure they are not empty and contain valid characters.
-func validateMachineNames(machines []string) ([]string, error) {
+// validateMachineNames validates machine names to ensure they are not empty and splits them into allowed
+// and excluded machines. Excluded machine names are prefixed with '!'.
+func validateMachineNames(machines []string) (api.Placement, error) {
+	placement := api.Placement{Machines: make([]string, 0, len(machines))}
 	for i, machine := range machines {
 		machine = strings.TrimSpace(machine)
+		excluded := strings.HasPrefix(machine, "!")
+		if excluded {
+			machine = strings.TrimSpace(strings.TrimPrefix(machine, "!"))
+		}
 		if machine == "" {
-			return nil, fmt.Errorf("x-machines[%d] cannot be empty", i)
+			return api.Placement{}, fmt.Errorf("x-machines[%d] cannot be empty", i)
+		}
+
+		if excluded {
+			placement.ExcludeMachines = append(placement.ExcludeMachines, machine)
+		} else {
+			placement.Machines = append(placement.Machines, machine)
 		}
-		machines[i] = machine
 	}
-	return machines, nil
+	return placement, nil
+}
+
+// parseMachinesMap parses either the 'any N of' form {any: N, of: [...]} or replica counts per machine
+// {machine-1: 2, machine-2: 1}. A map with only the 'any' and optional 'of' keys is always the 'any N of' form.
+func parseMachinesMap(m map[string]any) (api.Placement, error) {
+	_, hasAny := m["any"]
+	_, hasOf := m["of"]
+	if hasAny && (len(m) == 1 || len(m) == 2 && hasOf) {
+		count, err := anyToUint(m["any"])
+		if err != nil || count == 0 {
+			return api.Placement{}, fmt.Errorf("x-machines.any must be a positive integer, got '%v'", m["any"])
+		}
+
+		placement := api.Placement{}
+		if hasOf {
+			switch of := m["of"].(type) {
+			case string:
+				placement, err = parseMachineNames(of)
+			case []any:
+				var names []string
+				if names, err = anyToStrings(of); err == nil {
+					placement, err = validateMachineNames(names)
+				}
+			default:
+				err = fmt.Errorf("x-machines.of must be a string or list of strings, got %T", of)
+			}
+			if err != nil {
+				return api.Placement{}, err
+			}
+		}
+		placement.MachineCount = count
+		return placement, nil
+	}
+
+	placement := api.Placement{MachineReplicas: make(map[string]uint, len(m))}
+	for _, machine := range slices.Sorted(maps.Keys(m)) {
+		name := strings.TrimSpace(machine)
+		if name == "" {
+			return api.Placement{}, errors.New("x-machines machine name cannot be empty")
+		}
+		count, err := anyToUint(m[machine])
+		if err != nil {
+			return api.Placement{}, fmt.Errorf("x-machines.%s must be a number of replicas: %w", machine, err)
+		}
+		placement.MachineReplicas[name] = count
+	}
+	return placement, nil
+}
+
+func anyToStrings(values []any) ([]string, error) {
+	strs := make([]string, 0, len(values))
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("x-machines[%d] is not a string, got %T", i, v)
+		}
+		strs = append(strs, str)
+	}
+	return strs, nil
+}
+
+func anyToUint(v any) (uint, error) {
+	switch n := v.(type) {
+	case int:
+		if n >= 0 {
+			return uint(n), nil
+		}
+	case int64:
+		if n >= 0 {
+			return uint(n), nil
+		}
+	case uint64:
+		return uint(n), nil
+	case float64:
+		if n >= 0 && n == math.Trunc(n) {
+			return uint(n), nil
+		}
+	default:
+		return 0, fmt.Errorf("expected a non-negative integer, got %T", v)
+	}
+	return 0, fmt.Errorf("expected a non-negative integer, got %v", v)
 }
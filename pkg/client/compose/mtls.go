@@ -0,0 +1,36 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const MTLSExtensionKey = "x-mtls"
+
+// MTLS represents the parsed x-mtls extension data that enables transparent mTLS for the service traffic.
+type MTLS bool
+
+// DecodeMapstructure implements custom decoding for boolean and string values.
+func (m *MTLS) DecodeMapstructure(value any) error {
+	switch v := value.(type) {
+	case *MTLS:
+		*m = *v
+		return nil
+	case MTLS:
+		*m = v
+		return nil
+	case bool:
+		*m = MTLS(v)
+		return nil
+	case string:
+		// Support string values that may come from variable interpolation: x-mtls: ${MTLS_ENABLED}
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s value '%s': must be a boolean", MTLSExtensionKey, v)
+		}
+		*m = MTLS(enabled)
+		return nil
+	default:
+		return fmt.Errorf("%s must be a boolean, got %T", MTLSExtensionKey, value)
+	}
+}
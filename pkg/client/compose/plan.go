@@ -3,6 +3,7 @@ package compose
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -17,6 +18,9 @@ import (
 type Plan struct {
 	Volumes  []*operation.CreateVolumeOperation
 	Services []*deploy.ServicePlan
+	// prepare are the steps that record the project in the cluster before deploying the services, such as reserving
+	// its published ports and hostnames.
+	prepare []func(ctx context.Context) error
 }
 
 // IsEmpty returns true if the plan has no volume or service operations.
@@ -115,7 +119,44 @@ func (p *Plan) formatSummary() string {
 }
 
 // Execute runs all volume operations followed by all service operations.
+// ImagePulls returns the unique images that the plan runs in new containers on each machine. Images are ordered
+// by service and then by the order of the service operations.
+func (p *Plan) ImagePulls() []deploy.ImagePull {
+	var pulls []deploy.ImagePull
+	for _, sp := range p.Services {
+		for _, pull := range sp.ImagePulls() {
+			if !slices.Contains(pulls, pull) {
+				pulls = append(pulls, pull)
+			}
+		}
+	}
+	return pulls
+}
+
+// UpdatedServiceVolumes returns the sorted names of the named Docker volumes mounted by the existing services
+// the plan updates. The new containers of the services may change their data, e.g. by running migrations.
+func (p *Plan) UpdatedServiceVolumes() []string {
+	var names []string
+	for _, sp := range p.Services {
+		if sp.IsNewService {
+			continue
+		}
+		for _, v := range sp.Spec.MountedDockerVolumes() {
+			if name := v.DockerVolumeName(); !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 func (p *Plan) Execute(ctx context.Context, cli operation.Client) error {
+	for _, prepare := range p.prepare {
+		if err := prepare(ctx); err != nil {
+			return err
+		}
+	}
 	for _, op := range p.Volumes {
 		if err := op.Execute(ctx, cli); err != nil {
 			return err
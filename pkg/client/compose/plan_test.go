@@ -0,0 +1,40 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanUpdatedServiceVolumes(t *testing.T) {
+	t.Parallel()
+
+	spec := func(name string, volumes ...api.VolumeSpec) api.ServiceSpec {
+		s := api.ServiceSpec{Name: name, Volumes: volumes}
+		for _, v := range volumes {
+			s.Container.VolumeMounts = append(s.Container.VolumeMounts,
+				api.VolumeMount{VolumeName: v.Name, ContainerPath: "/" + v.Name})
+		}
+		return s
+	}
+
+	plan := Plan{Services: []*deploy.ServicePlan{
+		{ServiceName: "db", Spec: spec("db",
+			api.VolumeSpec{Name: "data", Type: api.VolumeTypeVolume, VolumeOptions: &api.VolumeOptions{Name: "db-data"}},
+			api.VolumeSpec{Name: "conf", Type: api.VolumeTypeBind, BindOptions: &api.BindOptions{HostPath: "/etc/db"}},
+		)},
+		{ServiceName: "web", Spec: spec("web",
+			api.VolumeSpec{Name: "uploads", Type: api.VolumeTypeVolume},
+			api.VolumeSpec{Name: "db-data", Type: api.VolumeTypeVolume},
+		)},
+		// New services have no data to snapshot yet.
+		{ServiceName: "cache", IsNewService: true, Spec: spec("cache",
+			api.VolumeSpec{Name: "cache", Type: api.VolumeTypeVolume},
+		)},
+	}}
+
+	assert.Equal(t, []string{"db-data", "uploads"}, plan.UpdatedServiceVolumes())
+	assert.Empty(t, (&Plan{}).UpdatedServiceVolumes())
+}
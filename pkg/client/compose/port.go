@@ -81,6 +81,7 @@ func transformPortsExtension(ports PortsSource) ([]api.PortSpec, error) {
 // convertServicePortConfigToPortSpec converts types.ServicePortConfig directly to api.PortSpec
 func convertServicePortConfigToPortSpec(port types.ServicePortConfig) (api.PortSpec, error) {
 	spec := api.PortSpec{
+		Name:          port.Name,
 		ContainerPort: uint16(port.Target),
 		Protocol:      port.Protocol,
 		Mode:          port.Mode,
@@ -109,8 +110,10 @@ func convertServicePortConfigToPortSpec(port types.ServicePortConfig) (api.PortS
 		spec.PublishedPort = uint16(publishedPort)
 	}
 
-	// Set host IP if specified
-	if port.HostIP != "" {
+	// Set host IP or interface name prefixed with '%' if specified
+	if iface, ok := strings.CutPrefix(port.HostIP, "%"); ok {
+		spec.HostInterface = iface
+	} else if port.HostIP != "" {
 		hostIP, err := netip.ParseAddr(port.HostIP)
 		if err != nil {
 			return spec, fmt.Errorf("invalid host IP %q: %w", port.HostIP, err)
@@ -169,6 +169,23 @@ func TestConvertServicePortConfigToPortSpec(t *testing.T) {
 				HostIP:        mustParseAddr("::1"),
 			},
 		},
+		{
+			name: "host interface",
+			port: types.ServicePortConfig{
+				Target:    5432,
+				Published: "5432",
+				Protocol:  "tcp",
+				Mode:      "host",
+				HostIP:    "%eth1",
+			},
+			expected: api.PortSpec{
+				ContainerPort: 5432,
+				PublishedPort: 5432,
+				Protocol:      "tcp",
+				Mode:          "host",
+				HostInterface: "eth1",
+			},
+		},
 		{
 			name: "HTTP protocol",
 			port: types.ServicePortConfig{
@@ -36,8 +36,14 @@ func LoadProject(ctx context.Context, paths []string, opts ...composecli.Project
 		composecli.WithConfigFileEnv,
 		// If none was selected, get default Compose file names from current or parent folders.
 		composecli.WithDefaultConfigPath,
+		composecli.WithExtension(AutoUpdateExtensionKey, AutoUpdate{}),
+		composecli.WithExtension(BackupHookExtensionKey, BackupHook{}),
+		composecli.WithExtension(BandwidthExtensionKey, Bandwidth{}),
 		composecli.WithExtension(CaddyExtensionKey, Caddy{}),
+		composecli.WithExtension(IngressExtensionKey, Ingress{}),
+		composecli.WithExtension(InternalIPExtensionKey, InternalIPSource{}),
 		composecli.WithExtension(MachinesExtensionKey, MachinesSource{}),
+		composecli.WithExtension(MTLSExtensionKey, MTLS(false)),
 		composecli.WithExtension(PortsExtensionKey, PortsSource{}),
 		composecli.WithExtension(PreDeployHookExtensionKey, PreDeployHook{}),
 	}
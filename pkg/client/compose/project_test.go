@@ -268,15 +268,9 @@ networks:
       - db
   db:
     image: postgres:latest
-    secrets:
-      - db_password
-
-secrets:
-  db_password:
-    file: ./secret.txt
 `,
-			warnCount:    3,
-			warnContains: []string{"dns", "links", "secrets"},
+			warnCount:    2,
+			warnContains: []string{"dns", "links"},
 		},
 		{
 			name: "supported networks",
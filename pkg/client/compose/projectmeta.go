@@ -0,0 +1,28 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordProjectServices returns a function that records the services as deployed from the project so that
+// the project metadata such as protection applies to them.
+func (d *Deployment) recordProjectServices(specs []api.ServiceSpec) func(ctx context.Context) error {
+	services := make([]string, len(specs))
+	for i, s := range specs {
+		services[i] = s.Name
+	}
+
+	return func(ctx context.Context) error {
+		_, err := d.Client.UpdateProject(ctx, d.Project.Name, api.ProjectUpdate{Services: services})
+		// Older machines don't support project metadata so there is nothing to record.
+		if err != nil && status.Code(err) != codes.Unimplemented {
+			return fmt.Errorf("record project services: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeProjectClient implements only the project methods of Client.
+type fakeProjectClient struct {
+	Client
+	updates map[string]api.ProjectUpdate
+	err     error
+}
+
+func (c *fakeProjectClient) UpdateProject(_ context.Context, name string, update api.ProjectUpdate) (api.Project, error) {
+	if c.err != nil {
+		return api.Project{}, c.err
+	}
+	c.updates[name] = update
+	return api.Project{Name: name, Services: update.Services}, nil
+}
+
+func TestRecordProjectServices(t *testing.T) {
+	t.Parallel()
+
+	specs := []api.ServiceSpec{{Name: "web"}, {Name: "db"}}
+
+	cli := &fakeProjectClient{updates: make(map[string]api.ProjectUpdate)}
+	d := &Deployment{Client: cli, Project: &types.Project{Name: "shop"}}
+	require.NoError(t, d.recordProjectServices(specs)(context.Background()))
+	assert.Equal(t, map[string]api.ProjectUpdate{"shop": {Services: []string{"web", "db"}}}, cli.updates)
+
+	cli.err = status.Error(codes.Unimplemented, "unknown method")
+	assert.NoError(t, d.recordProjectServices(specs)(context.Background()), "older machines must be ignored")
+
+	cli.err = status.Error(codes.Unavailable, "machine is not ready")
+	assert.ErrorContains(t, d.recordProjectServices(specs)(context.Background()), "record project services")
+}
@@ -0,0 +1,43 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkReservations checks that no other Compose project in the cluster has reserved the published ports and ingress
+// hostnames of the services. It returns a function that reserves them for the project when the plan is executed.
+func (d *Deployment) checkReservations(
+	ctx context.Context, specs []api.ServiceSpec,
+) (func(ctx context.Context) error, error) {
+	ports, hostnames := api.PublishedResources(specs)
+	opts := api.ReserveOptions{
+		DryRun: true,
+		// Deploying only some of the services or profiles must keep the reservations of the others.
+		ReleaseOthers: len(d.Project.DisabledServices) == 0,
+	}
+
+	if _, err := d.Client.ReservePublished(ctx, d.Project.Name, ports, hostnames, opts); err != nil {
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			// Older machines don't support reservations so there is nothing to check.
+			return nil, nil
+		case codes.AlreadyExists:
+			return nil, fmt.Errorf("published ports or hostnames conflict with another project: %s",
+				status.Convert(err).Message())
+		}
+		return nil, fmt.Errorf("check published port and hostname reservations: %w", err)
+	}
+
+	return func(ctx context.Context) error {
+		opts.DryRun = false
+		if _, err := d.Client.ReservePublished(ctx, d.Project.Name, ports, hostnames, opts); err != nil {
+			return fmt.Errorf("reserve published ports and hostnames: %w", err)
+		}
+		return nil
+	}, nil
+}
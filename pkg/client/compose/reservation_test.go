@@ -0,0 +1,118 @@
+package compose
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type reserveCall struct {
+	project   string
+	ports     []string
+	hostnames []string
+	opts      api.ReserveOptions
+}
+
+// fakeReservationClient implements only the reservation methods of Client.
+type fakeReservationClient struct {
+	Client
+	calls []reserveCall
+	err   error
+}
+
+func (c *fakeReservationClient) ReservePublished(
+	_ context.Context, project string, ports, hostnames []string, opts api.ReserveOptions,
+) ([]api.PublishedReservation, error) {
+	c.calls = append(c.calls, reserveCall{project: project, ports: ports, hostnames: hostnames, opts: opts})
+	return nil, c.err
+}
+
+func TestCheckReservations(t *testing.T) {
+	t.Parallel()
+
+	specs := []api.ServiceSpec{
+		{
+			Name: "web",
+			Ports: []api.PortSpec{
+				{Hostname: "app.example.com", ContainerPort: 8000, Protocol: api.ProtocolHTTPS, Mode: api.PortModeIngress},
+				{PublishedPort: 2222, ContainerPort: 22, Protocol: api.ProtocolTCP, Mode: api.PortModeIngress},
+			},
+		},
+		{
+			Name: "db",
+			Ports: []api.PortSpec{{
+				HostIP:        netip.MustParseAddr("127.0.0.1"),
+				PublishedPort: 5432,
+				ContainerPort: 5432,
+				Protocol:      api.ProtocolTCP,
+				Mode:          api.PortModeHost,
+			}},
+		},
+	}
+
+	t.Run("reserved on execute", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &fakeReservationClient{}
+		d := &Deployment{Client: cli, Project: &types.Project{Name: "shop"}}
+
+		reserve, err := d.checkReservations(context.Background(), specs)
+		require.NoError(t, err)
+		require.Len(t, cli.calls, 1)
+		assert.Equal(t, reserveCall{
+			project:   "shop",
+			ports:     []string{"127.0.0.1:5432/tcp@host", "2222/tcp"},
+			hostnames: []string{"app.example.com"},
+			opts:      api.ReserveOptions{DryRun: true, ReleaseOthers: true},
+		}, cli.calls[0])
+
+		require.NoError(t, reserve(context.Background()))
+		require.Len(t, cli.calls, 2)
+		assert.Equal(t, api.ReserveOptions{ReleaseOthers: true}, cli.calls[1].opts)
+	})
+
+	t.Run("partial deploy keeps other reservations", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &fakeReservationClient{}
+		d := &Deployment{Client: cli, Project: &types.Project{
+			Name:             "shop",
+			DisabledServices: types.Services{"worker": {Name: "worker"}},
+		}}
+
+		_, err := d.checkReservations(context.Background(), specs)
+		require.NoError(t, err)
+		assert.False(t, cli.calls[0].opts.ReleaseOthers)
+	})
+
+	t.Run("conflict names the owning project", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &fakeReservationClient{
+			err: status.Error(codes.AlreadyExists, "hostname app.example.com is reserved by project 'blog'"),
+		}
+		d := &Deployment{Client: cli, Project: &types.Project{Name: "shop"}}
+
+		_, err := d.checkReservations(context.Background(), specs)
+		assert.EqualError(t, err, "published ports or hostnames conflict with another project: "+
+			"hostname app.example.com is reserved by project 'blog'")
+	})
+
+	t.Run("unsupported by machine", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &fakeReservationClient{err: status.Error(codes.Unimplemented, "unknown method")}
+		d := &Deployment{Client: cli, Project: &types.Project{Name: "shop"}}
+
+		reserve, err := d.checkReservations(context.Background(), specs)
+		require.NoError(t, err)
+		assert.Nil(t, reserve)
+	})
+}
@@ -0,0 +1,182 @@
+package compose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/secrets"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResolveSecrets resolves the external project secrets that reference an external secret provider in their name,
+// for example, 'vault://secret/app#password', and replaces them with inline secrets with the resolved content.
+// It should be called at deploy time before converting the services to service specs. Sealed secrets are left
+// as is because only the cluster machines can decrypt them.
+func ResolveSecrets(ctx context.Context, project *types.Project, resolver *secrets.Resolver) error {
+	for name, secret := range project.Secrets {
+		if !bool(secret.External) || api.IsSealedSecret(secret.Name) {
+			continue
+		}
+		ref, ok, err := secrets.ParseReference(secret.Name)
+		if err != nil {
+			return fmt.Errorf("secret '%s': %w", name, err)
+		}
+		if !ok {
+			continue
+		}
+
+		content, err := resolver.Resolve(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("secret '%s': %w", name, err)
+		}
+		project.Secrets[name] = types.SecretConfig{
+			Name:           name,
+			Content:        string(content),
+			TemplateDriver: secret.TemplateDriver,
+		}
+	}
+
+	return nil
+}
+
+// clusterSecretVersionExtensionKey is the extension of a project secret that records the version of the cluster
+// secret it references. It's set by ResolveClusterSecrets.
+const clusterSecretVersionExtensionKey = "x-uncloud-cluster-secret-version"
+
+// ResolveClusterSecrets checks that the cluster secrets referenced by the external project secrets exist and records
+// their current versions so that updating a cluster secret changes the specs of the services that mount it.
+// An external secret references a cluster secret if its name is neither a sealed secret nor a reference to
+// an external secret provider. It should be called at deploy time after ResolveSecrets.
+func ResolveClusterSecrets(ctx context.Context, project *types.Project, cli api.SecretClient) error {
+	for name, secret := range project.Secrets {
+		if !isClusterSecret(secret) {
+			continue
+		}
+
+		info, err := cli.InspectSecret(ctx, secret.Name)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				return fmt.Errorf("secret '%s': cluster secret '%s' not found, create it with "+
+					"'uc secret create %s'", name, secret.Name, secret.Name)
+			}
+			if status.Code(err) == codes.Unimplemented {
+				return fmt.Errorf("secret '%s': the connected machine doesn't support cluster secrets, "+
+					"upgrade it to the latest version", name)
+			}
+			return fmt.Errorf("secret '%s': inspect cluster secret '%s': %w", name, secret.Name, err)
+		}
+		if secret.Extensions == nil {
+			secret.Extensions = types.Extensions{}
+		}
+		secret.Extensions[clusterSecretVersionExtensionKey] = info.Version
+		project.Secrets[name] = secret
+	}
+
+	return nil
+}
+
+// isClusterSecret returns true if the project secret references a secret stored in the cluster.
+func isClusterSecret(secret types.SecretConfig) bool {
+	if !bool(secret.External) || api.IsSealedSecret(secret.Name) {
+		return false
+	}
+	_, isRef, _ := secrets.ParseReference(secret.Name)
+	return !isRef
+}
+
+func secretSpecsFromCompose(
+	projectSecrets types.Secrets, serviceSecrets []types.ServiceSecretConfig, workingDir string,
+) ([]api.SecretSpec, []api.SecretMount, error) {
+	var specs []api.SecretSpec
+	var mounts []api.SecretMount
+
+	for _, serviceSecret := range serviceSecrets {
+		projectSecret, ok := projectSecrets[serviceSecret.Source]
+		if !ok {
+			return nil, nil, fmt.Errorf("secret '%s' not found in project secrets", serviceSecret.Source)
+		}
+
+		if _, exists := (&api.ServiceSpec{Secrets: specs}).Secret(serviceSecret.Source); !exists {
+			spec, err := secretSpecFromCompose(serviceSecret.Source, projectSecret, workingDir)
+			if err != nil {
+				return nil, nil, err
+			}
+			specs = append(specs, spec)
+		}
+
+		mount := api.SecretMount{
+			SecretName:    serviceSecret.Source,
+			ContainerPath: serviceSecret.Target,
+			Uid:           serviceSecret.UID,
+			Gid:           serviceSecret.GID,
+		}
+		if serviceSecret.Mode != nil {
+			mode := os.FileMode(*serviceSecret.Mode)
+			mount.Mode = &mode
+		}
+		mounts = append(mounts, mount)
+	}
+
+	return specs, mounts, nil
+}
+
+func secretSpecFromCompose(name string, secret types.SecretConfig, workingDir string) (api.SecretSpec, error) {
+	spec := api.SecretSpec{Name: name}
+
+	template, err := templateFromDriver(secret.TemplateDriver)
+	if err != nil {
+		return spec, fmt.Errorf("secret '%s': %w", name, err)
+	}
+	spec.Template = template
+
+	switch {
+	case bool(secret.External):
+		if api.IsSealedSecret(secret.Name) {
+			keyID, ciphertext, err := api.ParseSealedSecret(secret.Name)
+			if err != nil {
+				return spec, fmt.Errorf("secret '%s': %w", name, err)
+			}
+			spec.Content = ciphertext
+			spec.SealingKeyID = keyID
+			return spec, nil
+		}
+		if !isClusterSecret(secret) {
+			return spec, fmt.Errorf("secret '%s' references an external provider '%s' and must be resolved "+
+				"at deploy time", name, secret.Name)
+		}
+		if err = api.ValidateClusterSecretName(secret.Name); err != nil {
+			return spec, fmt.Errorf("secret '%s': %w", name, err)
+		}
+		spec.ClusterSecret = secret.Name
+		if version, ok := secret.Extensions[clusterSecretVersionExtensionKey].(int64); ok {
+			spec.ClusterSecretVersion = version
+		}
+	case secret.File != "":
+		path := secret.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workingDir, path)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return spec, fmt.Errorf("read secret '%s' from file '%s': %w", name, secret.File, err)
+		}
+		spec.Content = content
+	case secret.Environment != "":
+		// The loader resolves the environment variable into the secret extensions.
+		value, ok := secret.Extensions[types.SecretConfigXValue].(string)
+		if !ok {
+			return spec, fmt.Errorf("secret '%s': environment variable '%s' is not set", name, secret.Environment)
+		}
+		spec.Content = []byte(value)
+	default:
+		spec.Content = []byte(secret.Content)
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,347 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretSpecsFromCompose(t *testing.T) {
+	tests := []struct {
+		name           string
+		secrets        types.Secrets
+		serviceSecrets []types.ServiceSecretConfig
+		expectedSpecs  []api.SecretSpec
+		expectedMounts []api.SecretMount
+		expectError    string
+	}{
+		{
+			name: "file secret with default target",
+			secrets: types.Secrets{
+				"db_password": types.SecretConfig{
+					File: "testdata/config1.txt",
+				},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "db_password"},
+			},
+			expectedSpecs: []api.SecretSpec{
+				{
+					Name:    "db_password",
+					Content: []byte("test config content\n"),
+				},
+			},
+			expectedMounts: []api.SecretMount{
+				{SecretName: "db_password"},
+			},
+		},
+		{
+			name: "environment secret with target, owner, and mode",
+			secrets: types.Secrets{
+				"api_key": types.SecretConfig{
+					Environment: "API_KEY",
+					Extensions:  types.Extensions{types.SecretConfigXValue: "s3cret"},
+				},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{
+					Source: "api_key",
+					Target: "/etc/app/api_key",
+					UID:    "1000",
+					GID:    "1000",
+					Mode:   func() *types.FileMode { m := types.FileMode(0o400); return &m }(),
+				},
+			},
+			expectedSpecs: []api.SecretSpec{
+				{
+					Name:    "api_key",
+					Content: []byte("s3cret"),
+				},
+			},
+			expectedMounts: []api.SecretMount{
+				{
+					SecretName:    "api_key",
+					ContainerPath: "/etc/app/api_key",
+					Uid:           "1000",
+					Gid:           "1000",
+					Mode:          func() *os.FileMode { m := os.FileMode(0o400); return &m }(),
+				},
+			},
+		},
+		{
+			name: "same secret mounted twice",
+			secrets: types.Secrets{
+				"token": types.SecretConfig{Content: "abc"},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "token"},
+				{Source: "token", Target: "token_copy"},
+			},
+			expectedSpecs: []api.SecretSpec{
+				{Name: "token", Content: []byte("abc")},
+			},
+			expectedMounts: []api.SecretMount{
+				{SecretName: "token"},
+				{SecretName: "token", ContainerPath: "token_copy"},
+			},
+		},
+		{
+			name: "environment variable not set",
+			secrets: types.Secrets{
+				"api_key": types.SecretConfig{Environment: "API_KEY"},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "api_key"},
+			},
+			expectError: "environment variable 'API_KEY' is not set",
+		},
+		{
+			name:    "secret not found",
+			secrets: types.Secrets{},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "missing"},
+			},
+			expectError: "secret 'missing' not found",
+		},
+		{
+			name: "unresolved external provider secret",
+			secrets: types.Secrets{
+				"db_password": types.SecretConfig{External: true, Name: "vault://secret/db#password"},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "db_password"},
+			},
+			expectError: "must be resolved at deploy time",
+		},
+		{
+			name: "sealed secret",
+			secrets: types.Secrets{
+				"db_password": types.SecretConfig{External: true, Name: "sealed://0123456789abcdef/Y2lwaGVydGV4dA"},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "db_password"},
+			},
+			expectedSpecs: []api.SecretSpec{
+				{
+					Name:         "db_password",
+					Content:      []byte("ciphertext"),
+					SealingKeyID: "0123456789abcdef",
+				},
+			},
+			expectedMounts: []api.SecretMount{
+				{SecretName: "db_password"},
+			},
+		},
+		{
+			name: "cluster secret",
+			secrets: types.Secrets{
+				"db_password": types.SecretConfig{
+					External:   true,
+					Name:       "prod_db_password",
+					Extensions: types.Extensions{clusterSecretVersionExtensionKey: int64(3)},
+				},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "db_password"},
+			},
+			expectedSpecs: []api.SecretSpec{
+				{
+					Name:                 "db_password",
+					ClusterSecret:        "prod_db_password",
+					ClusterSecretVersion: 3,
+				},
+			},
+			expectedMounts: []api.SecretMount{
+				{SecretName: "db_password"},
+			},
+		},
+		{
+			name: "invalid cluster secret name",
+			secrets: types.Secrets{
+				"db_password": types.SecretConfig{External: true, Name: "db password"},
+			},
+			serviceSecrets: []types.ServiceSecretConfig{
+				{Source: "db_password"},
+			},
+			expectError: "invalid secret name 'db password'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, mounts, err := secretSpecsFromCompose(tt.secrets, tt.serviceSecrets, ".")
+
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSpecs, specs)
+			assert.Equal(t, tt.expectedMounts, mounts)
+		})
+	}
+}
+
+type fakeSecretProvider map[string]string
+
+func (p fakeSecretProvider) Resolve(_ context.Context, ref secrets.Reference) ([]byte, error) {
+	v, ok := p[ref.String()]
+	if !ok {
+		return nil, fmt.Errorf("secret not found")
+	}
+	return []byte(v), nil
+}
+
+func TestResolveSecrets(t *testing.T) {
+	ctx := context.Background()
+	resolver := secrets.NewResolver(map[string]secrets.Provider{
+		"vault": fakeSecretProvider{"vault://secret/db#password": "s3cret"},
+	})
+
+	t.Run("resolves provider references", func(t *testing.T) {
+		project, err := LoadProjectFromContent(ctx, `
+services:
+  db:
+    image: postgres
+    secrets:
+      - source: db_password
+        target: postgres_password
+secrets:
+  db_password:
+    external: true
+    name: vault://secret/db#password
+`)
+		require.NoError(t, err)
+
+		require.NoError(t, ResolveSecrets(ctx, project, resolver))
+
+		spec, err := ServiceSpecFromCompose(project, "db")
+		require.NoError(t, err)
+		assert.Equal(t, []api.SecretSpec{{Name: "db_password", Content: []byte("s3cret")}}, spec.Secrets)
+		assert.Equal(t, []api.SecretMount{
+			{SecretName: "db_password", ContainerPath: "postgres_password"},
+		}, spec.Container.SecretMounts)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		project, err := LoadProjectFromContent(ctx, `
+services:
+  db:
+    image: postgres
+    secrets:
+      - db_password
+secrets:
+  db_password:
+    external: true
+    name: vault://secret/missing#password
+`)
+		require.NoError(t, err)
+
+		err = ResolveSecrets(ctx, project, resolver)
+		assert.ErrorContains(t, err, "secret 'db_password': resolve secret 'vault://secret/missing#password'")
+	})
+
+	t.Run("keeps sealed secrets", func(t *testing.T) {
+		project, err := LoadProjectFromContent(ctx, `
+services:
+  db:
+    image: postgres
+    secrets:
+      - db_password
+secrets:
+  db_password:
+    external: true
+    name: sealed://0123456789abcdef/Y2lwaGVydGV4dA
+`)
+		require.NoError(t, err)
+
+		require.NoError(t, ResolveSecrets(ctx, project, resolver))
+		assert.True(t, bool(project.Secrets["db_password"].External))
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		project, err := LoadProjectFromContent(ctx, `
+services:
+  db:
+    image: postgres
+    secrets:
+      - db_password
+secrets:
+  db_password:
+    external: true
+    name: gcp-sm://db
+`)
+		require.NoError(t, err)
+
+		err = ResolveSecrets(ctx, project, resolver)
+		assert.ErrorContains(t, err, "unsupported secret provider 'gcp-sm'")
+	})
+}
+
+type fakeSecretClient map[string]api.ClusterSecret
+
+func (c fakeSecretClient) InspectSecret(_ context.Context, name string) (api.ClusterSecret, error) {
+	s, ok := c[name]
+	if !ok {
+		return api.ClusterSecret{}, api.ErrNotFound
+	}
+	return s, nil
+}
+
+func TestResolveClusterSecrets(t *testing.T) {
+	ctx := context.Background()
+	cli := fakeSecretClient{"db_password": {Name: "db_password", Version: 2}}
+
+	t.Run("records cluster secret versions", func(t *testing.T) {
+		project, err := LoadProjectFromContent(ctx, `
+services:
+  db:
+    image: postgres
+    secrets:
+      - db_password
+      - api_key
+secrets:
+  db_password:
+    external: true
+  api_key:
+    external: true
+    name: sealed://0123456789abcdef/Y2lwaGVydGV4dA
+`)
+		require.NoError(t, err)
+
+		require.NoError(t, ResolveClusterSecrets(ctx, project, cli))
+
+		spec, err := ServiceSpecFromCompose(project, "db")
+		require.NoError(t, err)
+		assert.Equal(t, []api.SecretSpec{
+			{Name: "db_password", ClusterSecret: "db_password", ClusterSecretVersion: 2},
+			{Name: "api_key", Content: []byte("ciphertext"), SealingKeyID: "0123456789abcdef"},
+		}, spec.Secrets)
+	})
+
+	t.Run("cluster secret not found", func(t *testing.T) {
+		project, err := LoadProjectFromContent(ctx, `
+services:
+  db:
+    image: postgres
+    secrets:
+      - db_password
+secrets:
+  db_password:
+    external: true
+    name: missing
+`)
+		require.NoError(t, err)
+
+		err = ResolveClusterSecrets(ctx, project, cli)
+		assert.ErrorContains(t, err, "cluster secret 'missing' not found, create it with 'uc secret create missing'")
+	})
+}
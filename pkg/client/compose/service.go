@@ -3,6 +3,7 @@ package compose
 import (
 	"fmt"
 	"maps"
+	"net/netip"
 	"os"
 	"slices"
 	"time"
@@ -43,39 +44,76 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 		env[k] = *v
 	}
 
+	var extraHosts []string
+	if len(service.ExtraHosts) > 0 {
+		extraHosts = service.ExtraHosts.AsList(":")
+		slices.Sort(extraHosts)
+	}
+
 	spec := api.ServiceSpec{
 		Container: api.ContainerSpec{
+			Annotations: service.Annotations,
 			CapAdd:      service.CapAdd,
 			CapDrop:     service.CapDrop,
 			Command:     service.Command,
+			Domainname:  service.DomainName,
 			Entrypoint:  service.Entrypoint,
 			Env:         env,
+			ExtraHosts:  extraHosts,
 			Healthcheck: healthcheckFromCompose(service.HealthCheck),
+			Hostname:    service.Hostname,
 			Image:       service.Image,
 			Init:        service.Init,
+			IpcMode:     service.Ipc,
+			OomScoreAdj: int(service.OomScoreAdj),
 			PidMode:     service.Pid,
 			Privileged:  service.Privileged,
 			PullPolicy:  pullPolicy,
 			Resources:   resourcesFromCompose(service),
 			Sysctls:     service.Sysctls,
 			User:        service.User,
+			UsernsMode:  service.UserNSMode,
+			WorkingDir:  service.WorkingDir,
 		},
-		Name: serviceName,
-		Mode: api.ServiceModeReplicated,
+		Name:          serviceName,
+		Mode:          api.ServiceModeReplicated,
+		RestartPolicy: service.Restart,
 	}
 
 	// Map x-caddy extension to spec.Caddy if specified.
-	if caddy, ok := service.Extensions[CaddyExtensionKey].(Caddy); ok && caddy.Config != "" {
+	if caddy, ok := service.Extensions[CaddyExtensionKey].(Caddy); ok && (caddy.Config != "" || caddy.Directives != "") {
 		spec.Caddy = &api.CaddySpec{
-			Config: caddy.Config,
+			Config:     caddy.Config,
+			Directives: caddy.Directives,
 		}
 	}
 	if ports, ok := service.Extensions[PortsExtensionKey].([]api.PortSpec); ok {
 		spec.Ports = ports
 	}
+	if ingress, ok := service.Extensions[IngressExtensionKey].(Ingress); ok {
+		policy := api.IngressPolicy(ingress)
+		spec.Ingress = &policy
+	}
+
+	if spec.ContainerNameTemplate, err = ContainerNameTemplate(project); err != nil {
+		return spec, err
+	}
+	if len(service.DependsOn) > 0 {
+		spec.DependsOn = slices.Sorted(maps.Keys(service.DependsOn))
+	}
 
 	if machines, ok := service.Extensions[MachinesExtensionKey].(MachinesSource); ok {
-		spec.Placement.Machines = machines
+		spec.Placement = api.Placement(machines)
+		// Run the listed number of replicas on each machine unless the number is set explicitly.
+		if len(spec.Placement.MachineReplicas) > 0 {
+			spec.Replicas = spec.Placement.MachineReplicasTotal()
+		}
+	}
+	if mtls, ok := service.Extensions[MTLSExtensionKey].(MTLS); ok {
+		spec.MTLS = bool(mtls)
+	}
+	if autoUpdate, ok := service.Extensions[AutoUpdateExtensionKey].(AutoUpdate); ok && autoUpdate.Enabled {
+		spec.AutoUpdate = &api.AutoUpdateSpec{Interval: autoUpdate.Interval}
 	}
 
 	// Map LogDriver if specified
@@ -90,6 +128,13 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 		d := time.Duration(*service.StopGracePeriod)
 		spec.Container.StopGracePeriod = &d
 	}
+	spec.Container.StopSignal = service.StopSignal
+
+	if ips, ok := service.Extensions[InternalIPExtensionKey].(InternalIPSource); ok {
+		spec.InternalIPs = ips
+		// Each replica claims one of the internal IPs. Run a replica per IP unless the number is set explicitly.
+		spec.Replicas = uint(len(ips))
+	}
 
 	if service.Scale != nil {
 		spec.Replicas = uint(*service.Scale)
@@ -107,6 +152,15 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 			return spec, fmt.Errorf("unsupported deploy mode: '%s'", service.Deploy.Mode)
 		}
 
+		for _, pref := range service.Deploy.Placement.Preferences {
+			// Also accept the Docker Swarm style node label for compatibility.
+			if pref.Spread != "zone" && pref.Spread != "node.labels.zone" {
+				return spec, fmt.Errorf("unsupported deploy.placement.preferences spread: '%s', "+
+					"only 'zone' is supported", pref.Spread)
+			}
+			spec.Placement.SpreadZones = true
+		}
+
 		if cfg := service.Deploy.UpdateConfig; cfg != nil {
 			switch cfg.Order {
 			case "":
@@ -142,6 +196,13 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 	spec.Configs = configSpecs
 	spec.Container.ConfigMounts = configMounts
 
+	secretSpecs, secretMounts, err := secretSpecsFromCompose(project.Secrets, service.Secrets, project.WorkingDir)
+	if err != nil {
+		return spec, err
+	}
+	spec.Secrets = secretSpecs
+	spec.Container.SecretMounts = secretMounts
+
 	if h, ok := service.Extensions[PreDeployHookExtensionKey].(PreDeployHook); ok {
 		hook := &api.PreDeployHook{
 			Command:    h.Command,
@@ -163,6 +224,19 @@ func ServiceSpecFromCompose(project *types.Project, serviceName string) (api.Ser
 		spec.PreDeploy = hook
 	}
 
+	if b, ok := service.Extensions[BackupHookExtensionKey].(BackupHook); ok {
+		hook := &api.BackupHook{
+			Command:        b.Exec,
+			User:           b.User,
+			RestoreCommand: b.Restore,
+		}
+		if b.Timeout != nil {
+			d := time.Duration(*b.Timeout)
+			hook.Timeout = &d
+		}
+		spec.Backup = hook
+	}
+
 	return spec, nil
 }
 
@@ -201,6 +275,10 @@ func resourcesFromCompose(service types.ServiceConfig) api.ContainerResources {
 		MemoryReservation: int64(service.MemReservation),
 		SharedMemory:      int64(service.ShmSize),
 		Ulimits:           ulimitsFromCompose(service.Ulimits),
+		PidsLimit:         service.PidsLimit,
+	}
+	if bandwidth, ok := service.Extensions[BandwidthExtensionKey].(Bandwidth); ok {
+		resources.EgressBandwidth = bandwidth.Egress
 	}
 
 	// Convert device mappings, separating CDI devices from regular device mappings.
@@ -238,6 +316,9 @@ func resourcesFromCompose(service types.ServiceConfig) api.ContainerResources {
 			if service.Deploy.Resources.Limits.MemoryBytes > 0 {
 				resources.Memory = int64(service.Deploy.Resources.Limits.MemoryBytes)
 			}
+			if service.Deploy.Resources.Limits.Pids != 0 {
+				resources.PidsLimit = service.Deploy.Resources.Limits.Pids
+			}
 		}
 		if service.Deploy.Resources.Reservations != nil {
 			if service.Deploy.Resources.Reservations.MemoryBytes > 0 {
@@ -377,12 +458,14 @@ func tmpfsVolumeSpecFromCompose(serviceVolume types.ServiceVolumeConfig) api.Vol
 	// compose-go parser deduplicates volumes by the target path so it's safe to use it as the unique name.
 	name := "tmpfs-" + digest.SHA256.FromString(serviceVolume.Target).Encoded()
 	spec := api.VolumeSpec{
-		Name: name,
-		Type: api.VolumeTypeTmpfs,
-		TmpfsOptions: &mount.TmpfsOptions{
-			SizeBytes: int64(serviceVolume.Tmpfs.Size),
-			Mode:      os.FileMode(serviceVolume.Tmpfs.Mode),
-		},
+		Name:         name,
+		Type:         api.VolumeTypeTmpfs,
+		TmpfsOptions: &mount.TmpfsOptions{},
+	}
+	// The tmpfs options are not set if the long syntax volume doesn't specify them.
+	if serviceVolume.Tmpfs != nil {
+		spec.TmpfsOptions.SizeBytes = int64(serviceVolume.Tmpfs.Size)
+		spec.TmpfsOptions.Mode = os.FileMode(serviceVolume.Tmpfs.Mode)
 	}
 
 	return spec
@@ -417,6 +500,8 @@ func ulimitsFromCompose(ulimits map[string]*types.UlimitsConfig) map[string]api.
 
 // validateServicesExtensions validates extension combinations across all services in the project.
 func validateServicesExtensions(project *types.Project) error {
+	// Internal IPs claimed by services mapped to the service names.
+	claimedIPs := make(map[netip.Addr]string)
 	for _, service := range project.Services {
 		// Check for x-caddy and x-ports conflict, unless all ports are host mode.
 		hasCaddy := false
@@ -436,7 +521,8 @@ func validateServicesExtensions(project *types.Project) error {
 			if hasIngressPort {
 				return fmt.Errorf("service '%s': ingress ports in 'x-ports' and 'x-caddy' cannot be specified "+
 					"simultaneously: Caddy config is auto-generated from ingress ports, use only one of them. "+
-					"Host mode ports in 'x-caddy' can be used with 'x-caddy'", service.Name)
+					"Host mode ports in 'x-caddy' can be used with 'x-caddy'. Use 'x-caddy.directives' to customize "+
+					"the config generated from ingress ports", service.Name)
 			}
 		}
 
@@ -445,6 +531,21 @@ func validateServicesExtensions(project *types.Project) error {
 				return fmt.Errorf("service '%s': %w", service.Name, err)
 			}
 		}
+		if hook, ok := service.Extensions[BackupHookExtensionKey].(BackupHook); ok {
+			if err := hook.Validate(); err != nil {
+				return fmt.Errorf("service '%s': %w", service.Name, err)
+			}
+		}
+
+		if ips, ok := service.Extensions[InternalIPExtensionKey].(InternalIPSource); ok {
+			for _, ip := range ips {
+				if other, ok := claimedIPs[ip]; ok {
+					return fmt.Errorf("service '%s': internal IP '%s' in '%s' is already claimed by service '%s'",
+						service.Name, ip, InternalIPExtensionKey, other)
+				}
+				claimedIPs[ip] = service.Name
+			}
+		}
 	}
 
 	return nil
@@ -460,6 +561,11 @@ func validateServicesFeatures(project *types.Project) []error {
 	// TODO: check other commonly used but unsupported features.
 	var errs []error
 	for _, service := range project.Services {
+		if service.ContainerName != "" {
+			errs = append(errs, fmt.Errorf("service '%s': unsupported feature 'container_name', "+
+				"use the top-level '%s' extension to customize container names: %s", service.Name,
+				ContainerNameExtensionKey, "https://uncloud.run/docs/compose-file-reference/extensions#x-container_name"))
+		}
 		if service.SecurityOpt != nil {
 			errs = append(errs, err(service.Name, "security_opt"))
 		}
@@ -481,9 +587,6 @@ func validateServicesFeatures(project *types.Project) []error {
 		if service.MemSwapLimit > 0 {
 			errs = append(errs, err(service.Name, "memswap_limit"))
 		}
-		if service.Secrets != nil {
-			errs = append(errs, err(service.Name, "secrets"))
-		}
 		if service.StorageOpt != nil {
 			errs = append(errs, err(service.Name, "storage_opt"))
 		}
@@ -101,6 +101,10 @@ func TestServiceSpecFromCompose(t *testing.T) {
 					Name: "test",
 					Mode: api.ServiceModeReplicated,
 					Container: api.ContainerSpec{
+						Annotations: map[string]string{
+							"com.example.backup": "daily",
+							"com.example.team":   "platform",
+						},
 						CapAdd:     []string{"NET_ADMIN"},
 						CapDrop:    []string{"ALL"},
 						Command:    []string{"nginx", "updated", "command"},
@@ -148,10 +152,12 @@ func TestServiceSpecFromCompose(t *testing.T) {
 							},
 						},
 						StopGracePeriod: new(30 * time.Second),
+						StopSignal:      "SIGQUIT",
 						Sysctls: map[string]string{
 							"net.ipv4.ip_forward": "1",
 						},
-						User: "nginx:nginx",
+						User:       "nginx:nginx",
+						UsernsMode: api.UsernsModeHost,
 						VolumeMounts: []api.VolumeMount{
 							{
 								VolumeName:    "bind-bb6aed1683cea1e0a1ae5cd227aacd0734f2f87f7a78fcf1baeff978ce300b90",
@@ -406,6 +412,23 @@ services:
 }`,
 			},
 		},
+		{
+			name: "x-caddy with directives",
+			composeYAML: `
+services:
+  web:
+    image: nginx
+    x-ports:
+      - example.com:80/https
+    x-caddy:
+      directives: |
+        encode gzip
+        header -Server
+`,
+			want: &api.CaddySpec{
+				Directives: "encode gzip\nheader -Server",
+			},
+		},
 		{
 			name: "x-caddy with path to Caddyfile",
 			composeYAML: `
@@ -861,6 +884,276 @@ services:
 	}
 }
 
+func TestServiceSpecFromCompose_OomScoreAdjAndPidsLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		composeYAML     string
+		wantOomScoreAdj int
+		wantPidsLimit   int64
+	}{
+		{
+			name: "not set",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+`,
+		},
+		{
+			name: "oom_score_adj and pids_limit",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+    oom_score_adj: -500
+    pids_limit: 200
+`,
+			wantOomScoreAdj: -500,
+			wantPidsLimit:   200,
+		},
+		{
+			name: "deploy resources pids limit",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+    deploy:
+      resources:
+        limits:
+          pids: 300
+`,
+			wantPidsLimit: 300,
+		},
+		{
+			name: "unlimited pids",
+			composeYAML: `
+services:
+  db:
+    image: postgres
+    pids_limit: -1
+`,
+			wantPidsLimit: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "db")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantOomScoreAdj, spec.Container.OomScoreAdj)
+			assert.Equal(t, tt.wantPidsLimit, spec.Container.Resources.PidsLimit)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_Restart(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		restart string
+		want    string
+	}{
+		{name: "not set", want: ""},
+		{name: "no", restart: "no", want: "no"},
+		{name: "always", restart: "always", want: "always"},
+		{name: "on-failure with max retries", restart: `"on-failure:5"`, want: "on-failure:5"},
+		{name: "unless-stopped", restart: "unless-stopped", want: "unless-stopped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			composeYAML := `
+services:
+  db:
+    image: postgres
+`
+			if tt.restart != "" {
+				composeYAML += "    restart: " + tt.restart + "\n"
+			}
+			project, err := LoadProjectFromContent(context.Background(), composeYAML)
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "db")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, spec.RestartPolicy)
+			assert.NoError(t, spec.Validate())
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_IpcAndShmSize(t *testing.T) {
+	t.Parallel()
+
+	project, err := LoadProjectFromContent(context.Background(), `
+services:
+  db:
+    image: postgres
+    ipc: shareable
+    shm_size: 256m
+  chrome:
+    image: chromedp/headless-shell
+    ipc: service:db
+`)
+	require.NoError(t, err)
+
+	db, err := ServiceSpecFromCompose(project, "db")
+	require.NoError(t, err)
+	assert.Equal(t, api.IpcModeShareable, db.Container.IpcMode)
+	assert.Equal(t, int64(256*1024*1024), db.Container.Resources.SharedMemory)
+
+	chrome, err := ServiceSpecFromCompose(project, "chrome")
+	require.NoError(t, err)
+	assert.Equal(t, "service:db", chrome.Container.IpcMode)
+	// The service that shares the IPC namespace is implicitly deployed after the service it depends on.
+	assert.Contains(t, project.Services["chrome"].DependsOn, "db")
+}
+
+func TestServiceSpecFromCompose_TmpfsWithoutOptions(t *testing.T) {
+	t.Parallel()
+
+	project, err := LoadProjectFromContent(context.Background(), `
+services:
+  app:
+    image: nginx
+    volumes:
+      - type: tmpfs
+        target: /cache
+`)
+	require.NoError(t, err)
+
+	spec, err := ServiceSpecFromCompose(project, "app")
+	require.NoError(t, err)
+	require.Len(t, spec.Volumes, 1)
+	assert.Equal(t, api.VolumeTypeTmpfs, spec.Volumes[0].Type)
+	assert.Equal(t, &mount.TmpfsOptions{}, spec.Volumes[0].TmpfsOptions)
+	require.NoError(t, spec.Validate())
+}
+
+func TestServiceSpecFromCompose_DependsOn(t *testing.T) {
+	t.Parallel()
+
+	project, err := LoadProjectFromContent(context.Background(), `
+services:
+  web:
+    image: nginx
+    depends_on:
+      - db
+      - cache
+  db:
+    image: postgres
+  cache:
+    image: redis
+`)
+	require.NoError(t, err)
+
+	spec, err := ServiceSpecFromCompose(project, "web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cache", "db"}, spec.DependsOn)
+
+	spec, err = ServiceSpecFromCompose(project, "db")
+	require.NoError(t, err)
+	assert.Nil(t, spec.DependsOn)
+}
+
+func TestServiceSpecFromCompose_ExtraHosts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		composeYAML string
+		expected    []string
+	}{
+		{
+			name: "list syntax",
+			composeYAML: `
+services:
+  app:
+    image: nginx
+    extra_hosts:
+      - "somehost:162.242.195.82"
+      - "otherhost=50.31.209.229"
+      - "host.docker.internal:host-gateway"
+`,
+			expected: []string{
+				"host.docker.internal:host-gateway",
+				"otherhost:50.31.209.229",
+				"somehost:162.242.195.82",
+			},
+		},
+		{
+			name: "map syntax with IPv6",
+			composeYAML: `
+services:
+  app:
+    image: nginx
+    extra_hosts:
+      myhostv6: "::1"
+`,
+			expected: []string{"myhostv6:::1"},
+		},
+		{
+			name: "not set",
+			composeYAML: `
+services:
+  app:
+    image: nginx
+`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "app")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, spec.Container.ExtraHosts)
+			require.NoError(t, spec.Validate())
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_WorkingDirHostnameDomainname(t *testing.T) {
+	t.Parallel()
+
+	project, err := LoadProjectFromContent(context.Background(), `
+services:
+  app:
+    image: nginx
+    working_dir: /app
+    hostname: web
+    domainname: example.com
+  default:
+    image: nginx
+`)
+	require.NoError(t, err)
+
+	spec, err := ServiceSpecFromCompose(project, "app")
+	require.NoError(t, err)
+	assert.Equal(t, "/app", spec.Container.WorkingDir)
+	assert.Equal(t, "web", spec.Container.Hostname)
+	assert.Equal(t, "example.com", spec.Container.Domainname)
+
+	spec, err = ServiceSpecFromCompose(project, "default")
+	require.NoError(t, err)
+	assert.Empty(t, spec.Container.WorkingDir)
+	assert.Empty(t, spec.Container.Hostname)
+	assert.Empty(t, spec.Container.Domainname)
+}
+
 func TestServiceSpecFromCompose_UpdateConfig(t *testing.T) {
 	t.Parallel()
 
@@ -1150,6 +1443,93 @@ services:
   test:
     image: nginx
     x-machines: "machine-1,,machine-2"
+`,
+			expectError: true,
+		},
+		{
+			name: "x-machines with exclusion",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-machines: ["machine-1", "machine-2", "!machine-3"]
+`,
+			expected: api.Placement{
+				Machines:        []string{"machine-1", "machine-2"},
+				ExcludeMachines: []string{"machine-3"},
+			},
+		},
+		{
+			name: "x-machines with only exclusion",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-machines: "!machine-3"
+`,
+			expected: api.Placement{
+				Machines:        []string{},
+				ExcludeMachines: []string{"machine-3"},
+			},
+		},
+		{
+			name: "x-machines with replica counts",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-machines:
+      machine-1: 2
+      machine-2: 1
+`,
+			expected: api.Placement{
+				MachineReplicas: map[string]uint{"machine-1": 2, "machine-2": 1},
+			},
+		},
+		{
+			name: "x-machines with any of machines",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-machines:
+      any: 2
+      of: [machine-1, machine-2, machine-3]
+`,
+			expected: api.Placement{
+				Machines:     []string{"machine-1", "machine-2", "machine-3"},
+				MachineCount: 2,
+			},
+		},
+		{
+			name: "x-machines with any machines",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-machines:
+      any: 2
+`,
+			expected: api.Placement{MachineCount: 2},
+		},
+		{
+			name: "x-machines with invalid replica count",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-machines:
+      machine-1: two
+`,
+			expectError: true,
+		},
+		{
+			name: "x-machines with empty excluded machine",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-machines: ["machine-1", "!"]
 `,
 			expectError: true,
 		},
@@ -1184,13 +1564,223 @@ services:
 
 			if len(tt.expected.Machines) == 0 && len(spec.Placement.Machines) == 0 {
 				// Both are empty, consider them equal
-				return
+				tt.expected.Machines = spec.Placement.Machines
 			}
 			assert.Equal(t, tt.expected, spec.Placement)
 		})
 	}
 }
 
+func TestServiceSpecFromCompose_SpreadZones(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spread  string
+		want    bool
+		wantErr string
+	}{
+		{name: "zone", spread: "zone", want: true},
+		{name: "swarm node label", spread: "node.labels.zone", want: true},
+		{
+			name:    "unsupported",
+			spread:  "node.labels.rack",
+			wantErr: "unsupported deploy.placement.preferences spread: 'node.labels.rack', only 'zone' is supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := LoadProjectFromContent(context.Background(), `
+services:
+  test:
+    image: nginx
+    deploy:
+      placement:
+        preferences:
+          - spread: `+tt.spread+`
+`)
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Placement.SpreadZones)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_XInternalIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		composeYAML  string
+		wantIPs      []netip.Addr
+		wantReplicas uint
+		wantErr      string
+	}{
+		{
+			name: "single IP",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-internal-ip: 10.210.0.200
+`,
+			wantIPs:      []netip.Addr{netip.MustParseAddr("10.210.0.200")},
+			wantReplicas: 1,
+		},
+		{
+			name: "list of IPs runs replica per IP",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-internal-ip: ["10.210.0.200", "10.210.1.200"]
+`,
+			wantIPs:      []netip.Addr{netip.MustParseAddr("10.210.0.200"), netip.MustParseAddr("10.210.1.200")},
+			wantReplicas: 2,
+		},
+		{
+			name: "comma-separated IPs with explicit replicas",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-internal-ip: "10.210.0.200, 10.210.1.200"
+    deploy:
+      replicas: 1
+`,
+			wantIPs:      []netip.Addr{netip.MustParseAddr("10.210.0.200"), netip.MustParseAddr("10.210.1.200")},
+			wantReplicas: 1,
+		},
+		{
+			name: "invalid IP",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-internal-ip: 10.210.0.300
+`,
+			wantErr: "invalid x-internal-ip '10.210.0.300'",
+		},
+		{
+			name: "IPv6 not supported",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-internal-ip: fd00::1
+`,
+			wantErr: "must be an IPv4 address",
+		},
+		{
+			name: "IP claimed by multiple services",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-internal-ip: 10.210.0.200
+  other:
+    image: nginx
+    x-internal-ip: 10.210.0.200
+`,
+			wantErr: "is already claimed by service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIPs, spec.InternalIPs)
+			assert.Equal(t, tt.wantReplicas, spec.Replicas)
+		})
+	}
+}
+
+func TestServiceSpecFromCompose_XMTLS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		composeYAML string
+		want        bool
+		wantErr     string
+	}{
+		{
+			name: "not set",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+`,
+		},
+		{
+			name: "enabled",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-mtls: true
+`,
+			want: true,
+		},
+		{
+			name: "string value",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-mtls: "true"
+`,
+			want: true,
+		},
+		{
+			name: "invalid value",
+			composeYAML: `
+services:
+  test:
+    image: nginx
+    x-mtls: sometimes
+`,
+			wantErr: "invalid x-mtls value 'sometimes'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := LoadProjectFromContent(context.Background(), tt.composeYAML)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			spec, err := ServiceSpecFromCompose(project, "test")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.MTLS)
+		})
+	}
+}
+
 func TestServiceSpecFromCompose_Devices(t *testing.T) {
 	t.Parallel()
 
@@ -17,6 +17,7 @@ import (
 	machinedocker "github.com/psviderski/uncloud/internal/machine/docker"
 	"github.com/psviderski/uncloud/internal/secret"
 	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/timing"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -40,6 +41,15 @@ func (cli *Client) CreatePreDeployHookContainer(
 		ctx, serviceID, spec, machineID, pb.CreateServiceContainerRequest_PRE_DEPLOY)
 }
 
+// CreateBackupVerifyContainer creates a throwaway container for the given service on the specified machine to restore
+// a backup into. The container doesn't use the service volumes and published ports.
+func (cli *Client) CreateBackupVerifyContainer(
+	ctx context.Context, serviceID string, spec api.ServiceSpec, machineID string,
+) (api.CreateContainerResponse, error) {
+	return cli.createServiceContainerWithPull(
+		ctx, serviceID, spec, machineID, pb.CreateServiceContainerRequest_BACKUP_VERIFY)
+}
+
 // createServiceContainerWithPull creates a regular or deployment hook container for the service
 // on the specified machine, pulling the image if needed.
 func (cli *Client) createServiceContainerWithPull(
@@ -67,9 +77,16 @@ func (cli *Client) createServiceContainerWithPull(
 		return resp, fmt.Errorf("generate random suffix: %w", err)
 	}
 
-	containerName := fmt.Sprintf("%s-%s", spec.Name, suffix)
-	if containerType == pb.CreateServiceContainerRequest_PRE_DEPLOY {
+	var containerName string
+	switch containerType {
+	case pb.CreateServiceContainerRequest_PRE_DEPLOY:
 		containerName = fmt.Sprintf("%s-%s-%s", spec.Name, api.LabelHookPreDeploy, suffix)
+	case pb.CreateServiceContainerRequest_BACKUP_VERIFY:
+		containerName = fmt.Sprintf("%s-%s-%s", spec.Name, api.LabelHookBackupVerify, suffix)
+	default:
+		if containerName, err = cli.serviceContainerName(ctx, serviceID, spec, suffix); err != nil {
+			return resp, err
+		}
 	}
 	resp.Name = containerName
 
@@ -97,7 +114,9 @@ func (cli *Client) createServiceContainerWithPull(
 		ContainerType: containerType,
 	}
 
+	stopTiming := timing.Start(ctx, machine.Machine.Name, timing.PhaseCreate)
 	grpcResp, err := cli.Docker.GRPCClient.CreateServiceContainer(ctx, req)
+	stopTiming()
 	if err != nil {
 		switch spec.Container.PullPolicy {
 		case api.PullPolicyAlways, api.PullPolicyNever:
@@ -116,7 +135,10 @@ func (cli *Client) createServiceContainerWithPull(
 		if err = cli.pullImageWithProgress(ctx, spec.Container.Image, machine.Machine.Name, eventID); err != nil {
 			return resp, err
 		}
-		if grpcResp, err = cli.Docker.GRPCClient.CreateServiceContainer(ctx, req); err != nil {
+		stopTiming = timing.Start(ctx, machine.Machine.Name, timing.PhaseCreate)
+		grpcResp, err = cli.Docker.GRPCClient.CreateServiceContainer(ctx, req)
+		stopTiming()
+		if err != nil {
 			return resp, err
 		}
 	}
@@ -129,7 +151,49 @@ func (cli *Client) createServiceContainerWithPull(
 	return resp, nil
 }
 
+// serviceContainerName generates a name for a new service container from the container name template of the service.
+func (cli *Client) serviceContainerName(
+	ctx context.Context, serviceID string, spec api.ServiceSpec, random string,
+) (string, error) {
+	tmpl := spec.ContainerNameTemplate
+	if tmpl == "" {
+		tmpl = api.DefaultContainerNameTemplate
+	}
+
+	var ordinal int
+	if strings.Contains(tmpl, api.ContainerNamePlaceholderOrdinal) {
+		svc, err := cli.InspectService(ctx, serviceID)
+		if err != nil && !errors.Is(err, api.ErrNotFound) {
+			return "", fmt.Errorf("inspect service '%s' to generate container name: %w", spec.Name, err)
+		}
+
+		names := make([]string, 0, len(svc.Containers))
+		for _, mc := range svc.Containers {
+			names = append(names, strings.TrimPrefix(mc.Container.Name, "/"))
+		}
+		ordinal = api.NextContainerNameOrdinal(tmpl, spec.Name, names)
+	}
+
+	return api.RenderContainerName(tmpl, spec.Name, ordinal, random), nil
+}
+
+// statusMessageError is a gRPC status error that only prints the status message. It keeps the error message concise
+// while still allowing to retrieve the status code with status.Code.
+type statusMessageError struct {
+	s *status.Status
+}
+
+func (e statusMessageError) Error() string {
+	return e.s.Message()
+}
+
+func (e statusMessageError) GRPCStatus() *status.Status {
+	return e.s
+}
+
 func (cli *Client) pullImageWithProgress(ctx context.Context, image, machineName, parentEventID string) error {
+	defer timing.Start(ctx, machineName, timing.PhasePull)()
+
 	pw := progress.ContextWriter(ctx)
 	eventID := cliprogress.ImageEventID(image, machineName)
 	pw.Event(progress.Event{
@@ -156,7 +220,7 @@ func (cli *Client) pullImageWithProgress(ctx context.Context, image, machineName
 			Status:     progress.Error,
 			StatusText: statusErr.Message(),
 		})
-		return fmt.Errorf("pull image: %w", errors.New(statusErr.Message()))
+		return fmt.Errorf("pull image: %w", statusMessageError{statusErr})
 	}
 
 	// Wait for pull to complete by reading all progress messages and converting them to events.
@@ -177,7 +241,17 @@ func (cli *Client) pullImageWithProgress(ctx context.Context, image, machineName
 				Status:     progress.Error,
 				StatusText: statusErr.Message(),
 			})
-			return fmt.Errorf("pull image: %w", errors.New(statusErr.Message()))
+			return fmt.Errorf("pull image: %w", statusMessageError{statusErr})
+		}
+
+		if machinedocker.IsPullRetryMessage(msg.Message) {
+			pw.Event(progress.Event{
+				ID:         eventID,
+				ParentID:   parentEventID,
+				Status:     progress.Warning,
+				StatusText: msg.Message.Status,
+			})
+			continue
 		}
 
 		// TODO: add like in compose: --quiet-pull Pull without printing progress information
@@ -263,6 +337,7 @@ func (cli *Client) InspectContainer(
 type containerOperationContext struct {
 	ctx         context.Context
 	containerID string
+	machineName string
 	eventID     string
 }
 
@@ -279,6 +354,7 @@ func (cli *Client) resolveContainerOperation(
 	return containerOperationContext{
 		ctx:         cli.ProxySingleMachineContext(ctx, ctr.MachineID),
 		containerID: ctr.Container.ID,
+		machineName: ctr.MachineName,
 		eventID:     eventID,
 	}, nil
 }
@@ -290,6 +366,8 @@ func (cli *Client) StartContainer(ctx context.Context, serviceNameOrID, containe
 		return err
 	}
 
+	defer timing.Start(ctx, op.machineName, timing.PhaseStart)()
+
 	pw := progress.ContextWriter(op.ctx)
 	pw.Event(progress.StartingEvent(op.eventID))
 	if err = cli.Docker.StartContainer(op.ctx, op.containerID, container.StartOptions{}); err != nil {
@@ -411,6 +489,8 @@ func (cli *Client) WaitContainerHealthy(
 		return fmt.Errorf("inspect machine '%s': %w", mc.MachineID, err)
 	}
 
+	defer timing.Start(ctx, machine.Machine.Name, timing.PhaseHealthWait)()
+
 	pw := progress.ContextWriter(ctx)
 	eventID := cliprogress.ContainerEventID(ctx, mc.Container.ServiceSpec.Name, mc.Container.ID, machine.Machine.Name)
 
@@ -1,7 +1,9 @@
 package deploy
 
 import (
+	"net/netip"
 	"reflect"
+	"slices"
 	"sort"
 
 	"github.com/google/go-cmp/cmp"
@@ -55,6 +57,31 @@ func EvalContainerSpecChange(current api.ServiceSpec, new api.ServiceSpec) Conta
 		return ContainerNeedsRecreate
 	}
 
+	if !slices.Equal(sortedAddrs(current.InternalIPs), sortedAddrs(new.InternalIPs)) {
+		return ContainerNeedsRecreate
+	}
+
+	// TODO: this could be just an in-place spec update when available as the mesh is configured from the spec.
+	if current.MTLS != new.MTLS {
+		return ContainerNeedsRecreate
+	}
+	// TODO: this could be just an in-place spec update when available as auto-update is configured from the spec.
+	if !current.AutoUpdate.Equals(new.AutoUpdate) {
+		return ContainerNeedsRecreate
+	}
+	// TODO: this could be just an in-place spec update when available as the backup hook is only read from the spec.
+	if !current.Backup.Equals(new.Backup) {
+		return ContainerNeedsRecreate
+	}
+	// TODO: this could be a container update as Docker allows changing the restart policy of a running container.
+	if !restartPoliciesEqual(current.RestartPolicy, new.RestartPolicy) {
+		return ContainerNeedsRecreate
+	}
+	// Containers are recreated to get names generated from the new template.
+	if containerNameTemplate(current) != containerNameTemplate(new) {
+		return ContainerNeedsRecreate
+	}
+
 	// Compare volumes.
 	if len(current.Volumes) != len(new.Volumes) {
 		return ContainerNeedsRecreate
@@ -83,6 +110,18 @@ func EvalContainerSpecChange(current api.ServiceSpec, new api.ServiceSpec) Conta
 		}
 	}
 
+	// Compare secrets.
+	if len(current.Secrets) != len(new.Secrets) {
+		return ContainerNeedsRecreate
+	}
+	sortSecrets(current.Secrets)
+	sortSecrets(new.Secrets)
+	for i := range current.Secrets {
+		if !current.Secrets[i].Equals(new.Secrets[i]) {
+			return ContainerNeedsRecreate
+		}
+	}
+
 	// Device reservations and mappings are immutable, so we'll need to recreate if any have changed
 	if !reflect.DeepEqual(current.Container.Resources.DeviceReservations, newResources.DeviceReservations) {
 		return ContainerNeedsRecreate
@@ -99,6 +138,10 @@ func EvalContainerSpecChange(current api.ServiceSpec, new api.ServiceSpec) Conta
 	if !current.Caddy.Equals(new.Caddy) {
 		return ContainerNeedsRecreate
 	}
+	// TODO: this could be just an in-place spec update when available as Caddy is configured from the spec.
+	if !current.Ingress.Equals(new.Ingress) {
+		return ContainerNeedsRecreate
+	}
 
 	// Remaining resources are mutable.
 	if !reflect.DeepEqual(current.Container.Resources, newResources) {
@@ -119,3 +162,34 @@ func sortConfigs(configs []api.ConfigSpec) {
 		return configs[i].Name < configs[j].Name
 	})
 }
+
+func sortSecrets(secrets []api.SecretSpec) {
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+}
+
+// restartPoliciesEqual returns true if the restart policies result in the same Docker restart policy, e.g. an empty
+// policy is equal to 'unless-stopped'.
+func restartPoliciesEqual(a, b string) bool {
+	aPolicy, aErr := api.ParseRestartPolicy(a)
+	bPolicy, bErr := api.ParseRestartPolicy(b)
+	if aErr != nil || bErr != nil {
+		return a == b
+	}
+	return aPolicy == bPolicy
+}
+
+// containerNameTemplate returns the template used to generate names of the service containers.
+func containerNameTemplate(spec api.ServiceSpec) string {
+	if spec.ContainerNameTemplate == "" {
+		return api.DefaultContainerNameTemplate
+	}
+	return spec.ContainerNameTemplate
+}
+
+func sortedAddrs(addrs []netip.Addr) []netip.Addr {
+	addrs = slices.Clone(addrs)
+	slices.SortFunc(addrs, func(a, b netip.Addr) int { return a.Compare(b) })
+	return addrs
+}
@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/mount"
@@ -403,6 +404,28 @@ func TestEvalContainerSpecChange_ContainerSysctls(t *testing.T) {
 	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(newSpec, currentSpec))
 }
 
+func TestEvalContainerSpecChange_ContainerAnnotations(t *testing.T) {
+	t.Parallel()
+
+	currentSpec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Image: "nginx:latest",
+		},
+	}
+	newSpec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Annotations: map[string]string{
+				"com.example.backup": "daily",
+			},
+			Image: "nginx:latest",
+		},
+	}
+
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(currentSpec, newSpec))
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(newSpec, currentSpec))
+	assert.Equal(t, ContainerUpToDate, EvalContainerSpecChange(newSpec, newSpec))
+}
+
 func TestEvalContainerSpecChange_PullPolicy(t *testing.T) {
 	t.Parallel()
 
@@ -2046,3 +2069,123 @@ func TestEvalContainerSpecChange_Mixed(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalContainerSpecChange_Ingress(t *testing.T) {
+	t.Parallel()
+
+	currentSpec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Image: "nginx:latest",
+		},
+	}
+	emptySpec := currentSpec
+	emptySpec.Ingress = &api.IngressPolicy{}
+	newSpec := currentSpec
+	newSpec.Ingress = &api.IngressPolicy{
+		RateLimit: &api.RateLimit{Requests: 100, Window: time.Second},
+	}
+
+	assert.Equal(t, ContainerUpToDate, EvalContainerSpecChange(currentSpec, emptySpec))
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(currentSpec, newSpec))
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(newSpec, currentSpec))
+}
+
+func TestEvalContainerSpecChange_CaddyDirectives(t *testing.T) {
+	t.Parallel()
+
+	currentSpec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Image: "nginx:latest",
+		},
+	}
+	emptySpec := currentSpec
+	emptySpec.Caddy = &api.CaddySpec{Directives: "  "}
+	newSpec := currentSpec
+	newSpec.Caddy = &api.CaddySpec{Directives: "encode gzip"}
+
+	assert.Equal(t, ContainerUpToDate, EvalContainerSpecChange(currentSpec, emptySpec))
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(currentSpec, newSpec))
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(newSpec, currentSpec))
+}
+
+func TestEvalContainerSpecChange_Backup(t *testing.T) {
+	t.Parallel()
+
+	currentSpec := api.ServiceSpec{
+		Container: api.ContainerSpec{
+			Image: "postgres:17",
+		},
+		Backup: &api.BackupHook{Command: []string{"pg_dump", "-U", "postgres", "app"}},
+	}
+	sameSpec := currentSpec.Clone()
+	newSpec := currentSpec.Clone()
+	newSpec.Backup.Command = []string{"pg_dumpall", "-U", "postgres"}
+	noBackupSpec := currentSpec.Clone()
+	noBackupSpec.Backup = nil
+
+	assert.Equal(t, ContainerUpToDate, EvalContainerSpecChange(currentSpec, sameSpec))
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(currentSpec, newSpec))
+	assert.Equal(t, ContainerNeedsRecreate, EvalContainerSpecChange(currentSpec, noBackupSpec))
+}
+
+func TestEvalContainerSpecChange_RestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current string
+		new     string
+		want    ContainerSpecStatus
+	}{
+		{name: "same", current: "on-failure:3", new: "on-failure:3", want: ContainerUpToDate},
+		{name: "default is unless-stopped", current: "", new: "unless-stopped", want: ContainerUpToDate},
+		{name: "changed", current: "unless-stopped", new: "always", want: ContainerNeedsRecreate},
+		{name: "set from default", current: "", new: "no", want: ContainerNeedsRecreate},
+		{name: "max retries changed", current: "on-failure:3", new: "on-failure:5", want: ContainerNeedsRecreate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			currentSpec := api.ServiceSpec{
+				Container:     api.ContainerSpec{Image: "nginx:latest"},
+				RestartPolicy: tt.current,
+			}
+			newSpec := currentSpec.Clone()
+			newSpec.RestartPolicy = tt.new
+
+			assert.Equal(t, tt.want, EvalContainerSpecChange(currentSpec, newSpec))
+		})
+	}
+}
+
+func TestEvalContainerSpecChange_ContainerNameTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current string
+		new     string
+		want    ContainerSpecStatus
+	}{
+		{name: "same", current: "{service}-{ordinal}", new: "{service}-{ordinal}", want: ContainerUpToDate},
+		{name: "empty is default", current: "", new: api.DefaultContainerNameTemplate, want: ContainerUpToDate},
+		{name: "changed", current: "", new: "web-{ordinal}", want: ContainerNeedsRecreate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			currentSpec := api.ServiceSpec{
+				Container:             api.ContainerSpec{Image: "nginx:latest"},
+				ContainerNameTemplate: tt.current,
+			}
+			newSpec := currentSpec.Clone()
+			newSpec.ContainerNameTemplate = tt.new
+
+			assert.Equal(t, tt.want, EvalContainerSpecChange(currentSpec, newSpec))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -14,6 +15,8 @@ import (
 	"github.com/psviderski/uncloud/pkg/api"
 	"github.com/psviderski/uncloud/pkg/client/deploy/operation"
 	"github.com/psviderski/uncloud/pkg/client/deploy/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Client interface {
@@ -21,6 +24,7 @@ type Client interface {
 	api.DNSClient
 	api.ImageClient
 	api.MachineClient
+	api.PolicyClient
 	api.ServiceClient
 	api.VolumeClient
 }
@@ -44,9 +48,50 @@ type ServicePlan struct {
 	IsNewService bool
 	// Spec is the desired service spec being deployed.
 	Spec api.ServiceSpec
+	// Warnings are potential problems with the planned placement of the service containers, e.g. all replicas
+	// running in a single zone.
+	Warnings []string
 	operation.SequenceOperation
 }
 
+// ImagePull is an image that a deployment runs on a machine.
+type ImagePull struct {
+	Image       string
+	MachineID   string
+	MachineName string
+	// PullPolicy is the pull policy of the service that runs the image.
+	PullPolicy string
+}
+
+// ImagePulls returns the unique images that the plan runs in new containers on each machine in the order of
+// the operations.
+func (sp *ServicePlan) ImagePulls() []ImagePull {
+	var pulls []ImagePull
+	for _, op := range sp.Operations {
+		var spec api.ServiceSpec
+		var machineID, machineName string
+		switch o := op.(type) {
+		case *operation.RunContainerOperation:
+			spec, machineID, machineName = o.Spec, o.MachineID, o.MachineName
+		case *operation.ReplaceContainerOperation:
+			spec, machineID, machineName = o.Spec, o.MachineID, o.MachineName
+		default:
+			continue
+		}
+
+		pull := ImagePull{
+			Image:       spec.Container.Image,
+			MachineID:   machineID,
+			MachineName: machineName,
+			PullPolicy:  spec.Container.PullPolicy,
+		}
+		if !slices.Contains(pulls, pull) {
+			pulls = append(pulls, pull)
+		}
+	}
+	return pulls
+}
+
 // Format renders the service plan as a styled block with a spec diff and nested container operations.
 func (sp *ServicePlan) Format() string {
 	// Determine service-level operation type and extract the old spec from container operations.
@@ -167,6 +212,10 @@ func (sp *ServicePlan) Format() string {
 		out.WriteString(connector + " " + op.Format())
 		out.WriteString("\n")
 	}
+	for _, w := range sp.Warnings {
+		out.WriteString("  " + tui.BoldYellow.Render("WARNING: "+w))
+		out.WriteString("\n")
+	}
 
 	return out.String()
 }
@@ -336,6 +385,12 @@ func (d *Deployment) Validate(ctx context.Context) error {
 	if err := d.Spec.Validate(); err != nil {
 		return fmt.Errorf("invalid service spec: %w", err)
 	}
+	if err := d.validateSysctls(ctx); err != nil {
+		return err
+	}
+	if err := d.validateSecretModes(ctx); err != nil {
+		return err
+	}
 
 	if d.Service == nil && d.Spec.Name != "" {
 		svc, err := d.cli.InspectService(ctx, d.Spec.Name)
@@ -367,6 +422,48 @@ func (d *Deployment) Validate(ctx context.Context) error {
 	return nil
 }
 
+// validateSecretModes checks the secret modes requested by the service against the cluster maximum before any
+// containers are changed. Machines enforce the maximum as well.
+func (d *Deployment) validateSecretModes(ctx context.Context) error {
+	if len(d.Spec.Container.SecretMounts) == 0 {
+		return nil
+	}
+
+	maxMode, err := d.cli.SecretMaxMode(ctx)
+	if err != nil {
+		// Older machines don't support the secret mode policy so there is nothing to check.
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return fmt.Errorf("get secret max mode: %w", err)
+	}
+	if err = api.CheckSecretModesAllowed(d.Spec.Container.SecretMounts, maxMode); err != nil {
+		return fmt.Errorf("invalid service spec: %w", err)
+	}
+	return nil
+}
+
+// validateSysctls checks the sysctls requested by the service against the cluster allow-list before any containers
+// are changed. Machines enforce the allow-list as well, this check just fails the deployment early with a clear error.
+func (d *Deployment) validateSysctls(ctx context.Context) error {
+	if len(d.Spec.Container.Sysctls) == 0 {
+		return nil
+	}
+
+	allowed, err := d.cli.ListAllowedSysctls(ctx)
+	if err != nil {
+		// Older machines don't support the sysctl policy so there is nothing to check.
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+		return fmt.Errorf("list allowed sysctls: %w", err)
+	}
+	if err = api.CheckSysctlsAllowed(d.Spec.Container.Sysctls, allowed); err != nil {
+		return fmt.Errorf("invalid service spec: %w", err)
+	}
+	return nil
+}
+
 // Run executes the deployment plan and returns the ID of the created or updated service.
 // It will create a new plan if one hasn't been created yet. The deployment will either create a new service or update
 // the existing one to match the desired specification.
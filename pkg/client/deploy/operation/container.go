@@ -281,7 +281,8 @@ func (o *ReplaceContainerOperation) String() string {
 		o.MachineID, o.ServiceID, o.OldContainer.ID, o.Order)
 }
 
-// stopOptions converts a stop grace period duration to Docker container stop options.
+// stopOptions converts a stop grace period duration to Docker container stop options. The stop signal is not set
+// so that Docker sends the one configured for the container when it was created from its service spec.
 func stopOptions(gracePeriod *time.Duration) container.StopOptions {
 	if gracePeriod == nil {
 		return container.StopOptions{}
@@ -0,0 +1,113 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/compose/v2/pkg/progress"
+	cliprogress "github.com/psviderski/uncloud/internal/cli/progress"
+	"github.com/psviderski/uncloud/internal/cli/tui"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// DefaultDependencyTimeout is the maximum duration to wait for a dependency service to start or become healthy.
+const DefaultDependencyTimeout = 5 * time.Minute
+
+// WaitDependencyOperation waits for a service that another service depends on to have a running container,
+// or a healthy one if Healthy is set, before the dependent service containers are started.
+type WaitDependencyOperation struct {
+	// ServiceName is the name of the dependent service.
+	ServiceName string
+	// Dependency is the name of the service to wait for.
+	Dependency string
+	// Healthy waits for a container of the dependency to become healthy rather than just running.
+	Healthy bool
+	// Required fails the operation if the dependency service isn't deployed. Otherwise, it's skipped.
+	Required bool
+	// Timeout is the maximum duration to wait. Zero means DefaultDependencyTimeout.
+	Timeout time.Duration
+}
+
+func (o *WaitDependencyOperation) Execute(ctx context.Context, cli Client) error {
+	pw := progress.ContextWriter(ctx)
+	eventID := cliprogress.DependencyEventID(o.Dependency)
+	pw.Event(progress.Waiting(eventID))
+
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = DefaultDependencyTimeout
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		svc, err := cli.InspectService(timeoutCtx, o.Dependency)
+		if err != nil && !errors.Is(err, api.ErrNotFound) && timeoutCtx.Err() == nil {
+			return fmt.Errorf("inspect service '%s': %w", o.Dependency, err)
+		}
+		if errors.Is(err, api.ErrNotFound) {
+			if !o.Required {
+				pw.Event(progress.NewEvent(eventID, progress.Done, "Skipped (not deployed)"))
+				return nil
+			}
+			pw.Event(progress.ErrorEvent(eventID))
+			return fmt.Errorf("service '%s' depends on service '%s' that is not deployed",
+				o.ServiceName, o.Dependency)
+		}
+		if err == nil && o.satisfied(svc) {
+			if o.Healthy {
+				pw.Event(progress.NewEvent(eventID, progress.Done, "Healthy"))
+			} else {
+				pw.Event(progress.RunningEvent(eventID))
+			}
+			return nil
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			if ctx.Err() != nil {
+				pw.Event(progress.NewEvent(eventID, progress.Error, "Cancelled"))
+				return ctx.Err()
+			}
+			pw.Event(progress.NewEvent(eventID, progress.Error, fmt.Sprintf("Timeout (%s)", timeout)))
+			return fmt.Errorf("service '%s' depends on service '%s' that didn't %s within %s",
+				o.ServiceName, o.Dependency, o.condition(), timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// satisfied returns true if any container of the dependency service is running, and healthy if required.
+func (o *WaitDependencyOperation) satisfied(svc api.Service) bool {
+	for _, mc := range svc.Containers {
+		ctr := mc.Container.Container
+		if o.Healthy && ctr.Healthy() || !o.Healthy && ctr.State.Running && !ctr.State.Restarting {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *WaitDependencyOperation) condition() string {
+	if o.Healthy {
+		return "become healthy"
+	}
+	return "start"
+}
+
+func (o *WaitDependencyOperation) Format() string {
+	return tui.Faint.Render("⧗") + "   " +
+		tui.Faint.Render("wait for") + " " +
+		o.Dependency + " " +
+		tui.Faint.Render("to "+o.condition())
+}
+
+func (o *WaitDependencyOperation) String() string {
+	return fmt.Sprintf("WaitDependencyOperation[service=%s dependency=%s healthy=%t]",
+		o.ServiceName, o.Dependency, o.Healthy)
+}
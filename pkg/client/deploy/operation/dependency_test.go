@@ -0,0 +1,143 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServiceClient returns the same service or error on every InspectService call.
+type fakeServiceClient struct {
+	Client
+	svc   api.Service
+	err   error
+	calls int
+}
+
+func (c *fakeServiceClient) InspectService(context.Context, string) (api.Service, error) {
+	c.calls++
+	return c.svc, c.err
+}
+
+func serviceWithStates(states ...container.State) api.Service {
+	svc := api.Service{Name: "db"}
+	for _, state := range states {
+		svc.Containers = append(svc.Containers, api.MachineServiceContainer{
+			Container: api.ServiceContainer{Container: api.Container{InspectResponse: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{State: &state},
+			}}},
+		})
+	}
+	return svc
+}
+
+func TestWaitDependencyOperation_Execute(t *testing.T) {
+	t.Parallel()
+
+	running := container.State{Running: true}
+	healthy := container.State{Running: true, Health: &container.Health{Status: container.Healthy}}
+	starting := container.State{Running: true, Health: &container.Health{Status: container.Starting}}
+	restarting := container.State{Running: true, Restarting: true}
+	exited := container.State{Status: container.StateExited}
+
+	tests := []struct {
+		name     string
+		healthy  bool
+		required bool
+		svc      api.Service
+		err      error
+		wantErr  string
+	}{
+		{
+			name: "running",
+			svc:  serviceWithStates(exited, running),
+		},
+		{
+			name:    "healthy",
+			healthy: true,
+			svc:     serviceWithStates(starting, healthy),
+		},
+		{
+			name:    "running without health check is healthy",
+			healthy: true,
+			svc:     serviceWithStates(running),
+		},
+		{
+			name:    "health check starting",
+			healthy: true,
+			svc:     serviceWithStates(starting),
+			wantErr: "service 'web' depends on service 'db' that didn't become healthy within 20ms",
+		},
+		{
+			name:    "restarting",
+			svc:     serviceWithStates(restarting, exited),
+			wantErr: "service 'web' depends on service 'db' that didn't start within 20ms",
+		},
+		{
+			name:    "restarting is not healthy",
+			healthy: true,
+			svc:     serviceWithStates(restarting),
+			wantErr: "service 'web' depends on service 'db' that didn't become healthy within 20ms",
+		},
+		{
+			name:    "no containers",
+			svc:     serviceWithStates(),
+			wantErr: "service 'web' depends on service 'db' that didn't start within 20ms",
+		},
+		{
+			name: "not deployed",
+			err:  api.ErrNotFound,
+		},
+		{
+			name:     "required not deployed",
+			required: true,
+			err:      api.ErrNotFound,
+			wantErr:  "service 'web' depends on service 'db' that is not deployed",
+		},
+		{
+			name:    "inspect error",
+			err:     errors.New("connection refused"),
+			wantErr: "inspect service 'db': connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cli := &fakeServiceClient{svc: tt.svc, err: tt.err}
+			op := &WaitDependencyOperation{
+				ServiceName: "web",
+				Dependency:  "db",
+				Healthy:     tt.healthy,
+				Required:    tt.required,
+				Timeout:     20 * time.Millisecond,
+			}
+
+			err := op.Execute(context.Background(), cli)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, cli.calls)
+		})
+	}
+}
+
+func TestWaitDependencyOperation_Execute_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	op := &WaitDependencyOperation{ServiceName: "web", Dependency: "db", Timeout: time.Minute}
+	err := op.Execute(ctx, &fakeServiceClient{svc: serviceWithStates()})
+	assert.ErrorIs(t, err, context.Canceled)
+}
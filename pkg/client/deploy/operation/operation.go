@@ -19,5 +19,6 @@ type Operation interface {
 // Client defines the interface required to execute deployment operations.
 type Client interface {
 	api.ContainerClient
+	api.ServiceClient
 	api.VolumeClient
 }
@@ -1,12 +1,15 @@
 package scheduler
 
 import (
+	"net/netip"
 	"reflect"
 	"slices"
 	"strings"
 
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/network"
 	"github.com/psviderski/uncloud/pkg/api"
 )
 
@@ -26,9 +29,16 @@ func constraintsFromSpec(spec api.ServiceSpec) []Constraint {
 	// TODO: add placement constraint based on the supported platforms of the image.
 	// TODO: add placement constraint to limit machines with the image if pull policy is never.
 
-	if len(spec.Placement.Machines) > 0 {
+	if machines := spec.Placement.AllowedMachines(); len(machines) > 0 || len(spec.Placement.ExcludeMachines) > 0 {
 		constraints = append(constraints, &PlacementConstraint{
-			Machines: spec.Placement.Machines,
+			Machines:        machines,
+			ExcludeMachines: spec.Placement.ExcludeMachines,
+		})
+	}
+
+	if len(spec.InternalIPs) > 0 {
+		constraints = append(constraints, &InternalIPsConstraint{
+			IPs: spec.InternalIPs,
 		})
 	}
 
@@ -52,20 +62,81 @@ type PlacementConstraint struct {
 	// Machines is a list of machine names or IDs where service containers are allowed to be deployed.
 	// If empty, containers can be deployed to any available machine in the cluster.
 	Machines []string
+	// ExcludeMachines is a list of machine names or IDs where service containers must not be deployed.
+	ExcludeMachines []string
 }
 
 func (c *PlacementConstraint) Evaluate(machine *Machine) bool {
-	for _, nameOrID := range c.Machines {
-		if machine.Info.Id == nameOrID || machine.Info.Name == nameOrID {
-			return true
-		}
+	matches := func(nameOrID string) bool {
+		return machine.Info.Id == nameOrID || machine.Info.Name == nameOrID
 	}
-	return false
+	if slices.ContainsFunc(c.ExcludeMachines, matches) {
+		return false
+	}
+	return len(c.Machines) == 0 || slices.ContainsFunc(c.Machines, matches)
 }
 
 func (c *PlacementConstraint) Description() string {
 	slices.Sort(c.Machines)
-	return "Placement constraint by machines: " + strings.Join(c.Machines, ", ")
+	machines := strings.Join(c.Machines, ", ")
+	if len(c.Machines) == 0 {
+		machines = "any"
+	}
+	if len(c.ExcludeMachines) > 0 {
+		machines += " except " + strings.Join(slices.Sorted(slices.Values(c.ExcludeMachines)), ", ")
+	}
+	return "Placement constraint by machines: " + machines
+}
+
+// RoleConstraint restricts container placement to machines with the given role.
+type RoleConstraint struct {
+	Role string
+}
+
+func (c *RoleConstraint) Evaluate(machine *Machine) bool {
+	return api.HasMachineRole(machine.Info, c.Role)
+}
+
+func (c *RoleConstraint) Description() string {
+	return "Machine role: " + c.Role
+}
+
+// InternalIPsConstraint restricts container placement to machines whose subnets contain any of the fixed IPs
+// claimed by the service containers.
+type InternalIPsConstraint struct {
+	IPs []netip.Addr
+}
+
+func (c *InternalIPsConstraint) Evaluate(machine *Machine) bool {
+	return len(MachineInternalIPs(machine.Info, c.IPs)) > 0
+}
+
+func (c *InternalIPsConstraint) Description() string {
+	ips := make([]string, len(c.IPs))
+	for i, ip := range c.IPs {
+		ips[i] = ip.String()
+	}
+	slices.Sort(ips)
+	return "Internal IPs: " + strings.Join(ips, ", ")
+}
+
+// MachineInternalIPs returns the IPs that are in the static IP range of the machine subnet.
+func MachineInternalIPs(machine *pb.MachineInfo, ips []netip.Addr) []netip.Addr {
+	if machine.Network == nil {
+		return nil
+	}
+	subnet, err := machine.Network.Subnet.ToPrefix()
+	if err != nil {
+		return nil
+	}
+
+	var matched []netip.Addr
+	for _, ip := range ips {
+		if network.IsStaticIP(subnet, ip) {
+			matched = append(matched, ip)
+		}
+	}
+	return matched
 }
 
 // VolumesConstraint restricts container placement to machines that have the required named Docker volumes.
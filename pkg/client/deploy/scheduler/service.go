@@ -16,6 +16,11 @@ type ServiceScheduler struct {
 // NewServiceScheduler creates a new ServiceScheduler with the given cluster state and service specification.
 func NewServiceScheduler(state *ClusterState, spec api.ServiceSpec) *ServiceScheduler {
 	constraints := constraintsFromSpec(spec)
+	// Replicated services without explicit placement run only on worker machines if the cluster has any.
+	if spec.Mode != api.ServiceModeGlobal && !spec.Placement.HasMachines() &&
+		state.HasMachineRole(api.MachineRoleWorker) {
+		constraints = append(constraints, &RoleConstraint{Role: api.MachineRoleWorker})
+	}
 
 	return &ServiceScheduler{
 		state:       state,
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceScheduler_EligibleMachines_WorkerRole(t *testing.T) {
+	t.Parallel()
+
+	machine := func(id string, roles ...string) *Machine {
+		return &Machine{Info: &pb.MachineInfo{Id: id, Name: id, Roles: roles}}
+	}
+	ids := func(machines []*Machine) []string {
+		var res []string
+		for _, m := range machines {
+			res = append(res, m.Info.Id)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name     string
+		machines []*Machine
+		spec     api.ServiceSpec
+		want     []string
+	}{
+		{
+			name:     "no worker machines",
+			machines: []*Machine{machine("m1"), machine("m2", api.MachineRoleControlPlane)},
+			spec:     api.ServiceSpec{Mode: api.ServiceModeReplicated},
+			want:     []string{"m1", "m2"},
+		},
+		{
+			name: "replicated service runs on workers only",
+			machines: []*Machine{
+				machine("m1", api.MachineRoleControlPlane),
+				machine("m2", api.MachineRoleWorker),
+				machine("m3", api.MachineRoleIngress, api.MachineRoleWorker),
+			},
+			spec: api.ServiceSpec{Mode: api.ServiceModeReplicated},
+			want: []string{"m2", "m3"},
+		},
+		{
+			name:     "global service runs on all machines",
+			machines: []*Machine{machine("m1", api.MachineRoleControlPlane), machine("m2", api.MachineRoleWorker)},
+			spec:     api.ServiceSpec{Mode: api.ServiceModeGlobal},
+			want:     []string{"m1", "m2"},
+		},
+		{
+			name:     "explicit placement overrides worker role",
+			machines: []*Machine{machine("m1", api.MachineRoleControlPlane), machine("m2", api.MachineRoleWorker)},
+			spec: api.ServiceSpec{
+				Mode:      api.ServiceModeReplicated,
+				Placement: api.Placement{Machines: []string{"m1"}},
+			},
+			want: []string{"m1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewServiceScheduler(&ClusterState{Machines: tt.machines}, tt.spec)
+			machines, err := s.EligibleMachines()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(machines))
+		})
+	}
+}
@@ -14,6 +14,16 @@ type ClusterState struct {
 	Machines []*Machine
 }
 
+// HasMachineRole returns true if at least one machine in the cluster has the given role.
+func (s *ClusterState) HasMachineRole(role string) bool {
+	for _, m := range s.Machines {
+		if api.HasMachineRole(m.Info, role) {
+			return true
+		}
+	}
+	return false
+}
+
 type Machine struct {
 	Info             *pb.MachineInfo
 	Volumes          []volume.Volume
@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"fmt"
+	"maps"
 	"math/rand/v2"
 	"slices"
 
@@ -31,6 +32,9 @@ type RollingStrategy struct {
 	ForceRecreate bool
 	// SkipHealthMonitor skips the monitoring period and health checks for faster emergency deployments.
 	SkipHealthMonitor bool
+	// LastMachineIDs are the IDs of machines whose containers are updated after the containers on all other
+	// machines, for example, the machines that serve live ingress traffic.
+	LastMachineIDs []string
 
 	// state is the current and planned state of the cluster used for scheduling decisions.
 	state *scheduler.ClusterState
@@ -145,6 +149,70 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 		})
 	}
 
+	if spec.Placement.SpreadZones {
+		// Alternate zones so that choosing any N machines below picks machines from as many zones as possible.
+		matchedMachines = interleaveZones(matchedMachines)
+	}
+	if spec.Placement.MachineCount > 0 {
+		// Keep only the machines preferred by the sort order above, i.e. the ones that already run containers.
+		if len(matchedMachines) < int(spec.Placement.MachineCount) {
+			return plan, fmt.Errorf("only %d machines available that satisfy all constraints, "+
+				"x-machines requires any %d", len(matchedMachines), spec.Placement.MachineCount)
+		}
+		matchedMachines = matchedMachines[:spec.Placement.MachineCount]
+	}
+
+	// Services with replica counts per machine run the exact number of containers on each listed machine.
+	// Interleave machines once per replica so that the round-robin below rolls out the containers across
+	// the machines rather than one machine after another.
+	if len(spec.Placement.MachineReplicas) > 0 {
+		if matchedMachines, err = machineReplicaSlots(matchedMachines, spec.Placement.MachineReplicas); err != nil {
+			return plan, err
+		}
+	}
+
+	// Balance the containers between zones first and then between machines in each zone. Internal IPs pin
+	// the containers to machines so the zones are ignored for services with internal IPs.
+	if spec.Placement.SpreadZones && len(spec.InternalIPs) == 0 {
+		var zones []string
+		matchedMachines, zones = spreadZones(matchedMachines, int(spec.Replicas))
+		if len(zones) == 1 {
+			zone := fmt.Sprintf("zone '%s'", zones[0])
+			if zones[0] == "" {
+				zone = "machines without a zone"
+			}
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("All replicas run in %s. Losing it takes out "+
+				"all replicas. Add machines in other zones or increase the number of replicas.", zone))
+		}
+	}
+
+	// Services with internal IPs can run as many containers on a machine as there are IPs in its subnet.
+	// Interleave machines once per IP so that the round-robin below spreads the containers and doesn't exceed
+	// this number.
+	if len(spec.InternalIPs) > 0 {
+		var slots []*pb.MachineInfo
+		for i := 0; i < len(spec.InternalIPs); i++ {
+			for _, m := range matchedMachines {
+				if len(scheduler.MachineInternalIPs(m, spec.InternalIPs)) > i {
+					slots = append(slots, m)
+				}
+			}
+		}
+		if len(slots) < int(spec.Replicas) {
+			return plan, fmt.Errorf("only %d of the internal IPs belong to available machines, %d replicas requested",
+				len(slots), spec.Replicas)
+		}
+		matchedMachines = slots
+	}
+
+	// A host port can only be published by one container on a machine so the containers of services with host ports
+	// can't share machines. Reject the placement instead of failing to start the extra containers.
+	if hostPorts := spec.HostPorts(); len(hostPorts) > 0 {
+		if err = checkOneReplicaPerMachine(matchedMachines, int(spec.Replicas), hostPorts[0]); err != nil {
+			return plan, err
+		}
+	}
+
 	// Spread the containers across the available machines evenly using a simple round-robin approach, starting with
 	// machines that already have containers and prioritising machines with containers that match the desired spec.
 	for i := 0; i < int(spec.Replicas); i++ {
@@ -199,6 +267,7 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 		}
 	}
 
+	moveMachinesLast(plan.Operations, s.LastMachineIDs)
 	if ops := s.preDeployOperations(svc, plan); len(ops) > 0 {
 		plan.Operations = append(ops, plan.Operations...)
 	}
@@ -206,6 +275,101 @@ func (s *RollingStrategy) planReplicated(svc *api.Service, spec api.ServiceSpec)
 	return plan, nil
 }
 
+// checkOneReplicaPerMachine returns an error if the round-robin over the machine slots places more than one
+// of the given number of replicas on the same machine.
+func checkOneReplicaPerMachine(slots []*pb.MachineInfo, replicas int, hostPort string) error {
+	seen := make(map[string]struct{}, replicas)
+	for i := 0; i < replicas && len(slots) > 0; i++ {
+		m := slots[i%len(slots)]
+		if _, ok := seen[m.Id]; !ok {
+			seen[m.Id] = struct{}{}
+			continue
+		}
+
+		machines := make(map[string]struct{}, len(slots))
+		for _, m := range slots {
+			machines[m.Id] = struct{}{}
+		}
+		return fmt.Errorf("can't run %d replicas on %d available machines: the service publishes host port %s "+
+			"that only one container on a machine can publish. Reduce the number of replicas, add machines that "+
+			"satisfy all constraints, or publish the port in ingress mode", replicas, len(machines), hostPort)
+	}
+	return nil
+}
+
+// machineReplicaSlots returns the machines repeated as many times as the number of replicas to run on each machine,
+// interleaved. It returns an error if a machine with replicas isn't among the available machines.
+func machineReplicaSlots(machines []*pb.MachineInfo, replicas map[string]uint) ([]*pb.MachineInfo, error) {
+	counts := make(map[string]uint, len(replicas))
+	var maxCount uint
+	for _, nameOrID := range slices.Sorted(maps.Keys(replicas)) {
+		i := slices.IndexFunc(machines, func(m *pb.MachineInfo) bool {
+			return m.Id == nameOrID || m.Name == nameOrID
+		})
+		if i == -1 {
+			return nil, fmt.Errorf("machine '%s' from x-machines is not available or doesn't satisfy all constraints",
+				nameOrID)
+		}
+		counts[machines[i].Id] += replicas[nameOrID]
+		maxCount = max(maxCount, counts[machines[i].Id])
+	}
+
+	var slots []*pb.MachineInfo
+	for i := uint(0); i < maxCount; i++ {
+		for _, m := range machines {
+			if counts[m.Id] > i {
+				slots = append(slots, m)
+			}
+		}
+	}
+	return slots, nil
+}
+
+// groupZones groups the machines by zone keeping their order. It returns the zones in the order of their first
+// machine. Machines without a zone are grouped under the empty zone.
+func groupZones(machines []*pb.MachineInfo) ([]string, map[string][]*pb.MachineInfo) {
+	var zones []string
+	byZone := make(map[string][]*pb.MachineInfo)
+	for _, m := range machines {
+		if _, ok := byZone[m.Zone]; !ok {
+			zones = append(zones, m.Zone)
+		}
+		byZone[m.Zone] = append(byZone[m.Zone], m)
+	}
+	return zones, byZone
+}
+
+// interleaveZones reorders the machines to take one machine from each zone in turn keeping the order of machines
+// within each zone.
+func interleaveZones(machines []*pb.MachineInfo) []*pb.MachineInfo {
+	zones, byZone := groupZones(machines)
+	interleaved := make([]*pb.MachineInfo, 0, len(machines))
+	for i := 0; len(interleaved) < len(machines); i++ {
+		for _, z := range zones {
+			if i < len(byZone[z]) {
+				interleaved = append(interleaved, byZone[z][i])
+			}
+		}
+	}
+	return interleaved
+}
+
+// spreadZones returns a machine for each of n replicas so that the replicas are balanced between the zones first
+// and then between the machines in each zone. It also returns the zones the replicas run in.
+func spreadZones(machines []*pb.MachineInfo, n int) ([]*pb.MachineInfo, []string) {
+	zones, byZone := groupZones(machines)
+	if len(zones) == 0 {
+		return machines, nil
+	}
+
+	slots := make([]*pb.MachineInfo, n)
+	for i := range slots {
+		zoneMachines := byZone[zones[i%len(zones)]]
+		slots[i] = zoneMachines[(i/len(zones))%len(zoneMachines)]
+	}
+	return slots, zones[:min(n, len(zones))]
+}
+
 // planGlobal creates a plan for a global service deployment, ensuring one container runs on each available machine.
 // For machines with an existing container, it attempts to start a new container before removing the old one if
 // possible. If the new container would have port conflicts with the existing one, the old container is removed first.
@@ -269,6 +433,7 @@ func (s *RollingStrategy) planGlobal(svc *api.Service, spec api.ServiceSpec) (Se
 		}
 	}
 
+	moveMachinesLast(plan.Operations, s.LastMachineIDs)
 	if ops := s.preDeployOperations(svc, plan); len(ops) > 0 {
 		plan.Operations = append(ops, plan.Operations...)
 	}
@@ -276,6 +441,43 @@ func (s *RollingStrategy) planGlobal(svc *api.Service, spec api.ServiceSpec) (Se
 	return plan, nil
 }
 
+// moveMachinesLast reorders the container operations in place so that the operations on the given machines run
+// after the operations on all other machines. The order of operations within each group is preserved.
+func moveMachinesLast(ops []operation.Operation, machineIDs []string) {
+	if len(machineIDs) == 0 {
+		return
+	}
+	isLast := func(op operation.Operation) bool {
+		return slices.Contains(machineIDs, operationMachineID(op))
+	}
+	slices.SortStableFunc(ops, func(a, b operation.Operation) int {
+		switch {
+		case isLast(a) == isLast(b):
+			return 0
+		case isLast(a):
+			return 1
+		default:
+			return -1
+		}
+	})
+}
+
+// operationMachineID returns the ID of the machine the container operation runs on or an empty string.
+func operationMachineID(op operation.Operation) string {
+	switch o := op.(type) {
+	case *operation.RunContainerOperation:
+		return o.MachineID
+	case *operation.ReplaceContainerOperation:
+		return o.MachineID
+	case *operation.RemoveContainerOperation:
+		return o.MachineID
+	case *operation.StopContainerOperation:
+		return o.MachineID
+	default:
+		return ""
+	}
+}
+
 // reconcileGlobalContainer returns a sequence of operations to reconcile containers on a machine for a global service.
 // It ensures exactly one container with the desired spec is running on the machine by creating a new container and
 // removing old ones. If there is a host port conflict, it stops the old container before starting a new one.
@@ -419,6 +621,7 @@ func reconcileGlobalContainer(
 // and current container state. The order can be explicitly set in UpdateConfig, or automatically determined:
 // - If the user explicitly set order, respect it
 // - Services with port conflicts require stop-first (ports must be freed first)
+// - Services with internal IPs require stop-first (IPs must be released first)
 // - Single-replica services with data volumes default to stop-first (prevents data corruption)
 // - Multi-replica services use start-first (concurrent access already happening)
 // - All other services default to start-first (minimizes downtime)
@@ -434,6 +637,11 @@ func determineUpdateOrder(oldContainer api.ServiceContainer, spec api.ServiceSpe
 		return api.UpdateOrderStopFirst
 	}
 
+	// The new container can't claim the internal IP until the old one releases it.
+	if len(spec.InternalIPs) > 0 {
+		return api.UpdateOrderStopFirst
+	}
+
 	// Single-replica services with data volumes default to stop-first to prevent data corruption.
 	// Multi-replica services already have concurrent access, so start-first is safe.
 	if spec.Replicas <= 1 && len(spec.MountedDockerVolumes()) > 0 {
@@ -479,6 +687,10 @@ func (s *RollingStrategy) preDeployOperations(svc *api.Service, plan ServicePlan
 	var oldContainerIDs []string
 	if svc != nil {
 		for _, c := range svc.HookContainers {
+			// Backup verification containers are removed by the command that created them.
+			if c.Container.Config != nil && c.Container.Config.Labels[api.LabelHook] == api.LabelHookBackupVerify {
+				continue
+			}
 			oldContainerIDs = append(oldContainerIDs, c.Container.ID)
 			if c.Container.State.Running {
 				hookMachineName, _ := s.state.MachineName(c.MachineID)
@@ -1,6 +1,8 @@
 package deploy
 
 import (
+	"net/netip"
+	"slices"
 	"testing"
 
 	"github.com/docker/docker/api/types/container"
@@ -245,6 +247,21 @@ func TestDetermineUpdateOrder(t *testing.T) {
 			},
 			expected: api.UpdateOrderStopFirst,
 		},
+		{
+			name: "internal IPs default to stop-first",
+			oldContainer: api.ServiceContainer{
+				Container: api.Container{
+					InspectResponse: container.InspectResponse{
+						Config: &container.Config{Labels: map[string]string{}},
+					},
+				},
+			},
+			spec: api.ServiceSpec{
+				Replicas:    2,
+				InternalIPs: []netip.Addr{netip.MustParseAddr("10.210.0.200")},
+			},
+			expected: api.UpdateOrderStopFirst,
+		},
 	}
 
 	for _, tt := range tests {
@@ -441,6 +458,8 @@ func TestPreDeployOperations(t *testing.T) {
 
 	runningHook1 := newServiceContainer("running-hook-1", container.State{Running: true, Status: "running"})
 	runningHook2 := newServiceContainer("running-hook-2", container.State{Running: true, Status: "running"})
+	backupVerify := newServiceContainer("backup-verify", container.State{Running: true, Status: "running"})
+	backupVerify.Config = &container.Config{Labels: map[string]string{api.LabelHook: api.LabelHookBackupVerify}}
 
 	tests := []struct {
 		name     string
@@ -638,6 +657,31 @@ func TestPreDeployOperations(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "backup verification containers are ignored",
+			plan: ServicePlan{
+				ServiceID:   "svc-1",
+				ServiceName: "app",
+				Spec:        api.ServiceSpec{PreDeploy: hook},
+				SequenceOperation: operation.SequenceOperation{
+					Operations: []operation.Operation{
+						&operation.RunContainerOperation{MachineID: "m-1", MachineName: "machine-1"},
+					},
+				},
+			},
+			svc: &api.Service{
+				HookContainers: []api.MachineServiceContainer{
+					{MachineID: "m-1", Container: backupVerify},
+				},
+			},
+			expected: []operation.Operation{
+				&operation.RunPreDeployOperation{
+					ServiceID:   "svc-1",
+					MachineID:   "m-1",
+					MachineName: "machine-1",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -678,3 +722,371 @@ func assertOperationsEqual(t *testing.T, expected, actual []operation.Operation)
 		t.Errorf("operations mismatch (-expected +actual):\n%s", diff)
 	}
 }
+
+func TestRollingStrategy_PlanReplicated_InternalIPs(t *testing.T) {
+	t.Parallel()
+
+	machine := func(id, subnet string) *scheduler.Machine {
+		return &scheduler.Machine{Info: &pb.MachineInfo{
+			Id:      id,
+			Name:    "machine-" + id,
+			Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix(subnet))},
+		}}
+	}
+	state := &scheduler.ClusterState{Machines: []*scheduler.Machine{
+		machine("1", "10.210.0.0/24"),
+		machine("2", "10.210.1.0/24"),
+		machine("3", "10.210.2.0/24"),
+	}}
+
+	tests := []struct {
+		name         string
+		ips          []string
+		replicas     uint
+		wantMachines []string
+		wantErr      string
+	}{
+		{
+			name:         "one replica per machine",
+			ips:          []string{"10.210.0.200", "10.210.2.200"},
+			replicas:     2,
+			wantMachines: []string{"1", "3"},
+		},
+		{
+			name:         "multiple replicas on one machine",
+			ips:          []string{"10.210.1.200", "10.210.1.201"},
+			replicas:     2,
+			wantMachines: []string{"2", "2"},
+		},
+		{
+			name:     "IP in dynamic range",
+			ips:      []string{"10.210.0.200", "10.210.1.20"},
+			replicas: 2,
+			wantErr:  "only 1 of the internal IPs belong to available machines, 2 replicas requested",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := api.ServiceSpec{
+				Name:      "test",
+				Mode:      api.ServiceModeReplicated,
+				Replicas:  tt.replicas,
+				Container: api.ContainerSpec{Image: "nginx"},
+			}
+			for _, ip := range tt.ips {
+				spec.InternalIPs = append(spec.InternalIPs, netip.MustParseAddr(ip))
+			}
+
+			strategy := &RollingStrategy{}
+			plan, err := strategy.Plan(state, nil, spec)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var machines []string
+			for _, op := range plan.Operations {
+				machines = append(machines, op.(*operation.RunContainerOperation).MachineID)
+			}
+			assert.ElementsMatch(t, tt.wantMachines, machines)
+		})
+	}
+}
+
+func TestRollingStrategy_PlanReplicated_Placement(t *testing.T) {
+	t.Parallel()
+
+	state := &scheduler.ClusterState{}
+	for _, id := range []string{"1", "2", "3", "4"} {
+		state.Machines = append(state.Machines, &scheduler.Machine{
+			Info: &pb.MachineInfo{Id: id, Name: "machine-" + id},
+		})
+	}
+
+	tests := []struct {
+		name         string
+		placement    api.Placement
+		replicas     uint
+		wantMachines []string
+		// wantCount is the number of distinct machines if the exact machines are chosen randomly.
+		wantCount int
+		wantErr   string
+	}{
+		{
+			name:         "exclusion",
+			placement:    api.Placement{ExcludeMachines: []string{"machine-2", "4"}},
+			replicas:     4,
+			wantMachines: []string{"1", "1", "3", "3"},
+		},
+		{
+			name:         "replica counts",
+			placement:    api.Placement{MachineReplicas: map[string]uint{"machine-1": 2, "3": 1}},
+			replicas:     3,
+			wantMachines: []string{"1", "1", "3"},
+		},
+		{
+			name:      "any of machines",
+			placement: api.Placement{Machines: []string{"machine-1", "machine-2", "machine-3"}, MachineCount: 2},
+			replicas:  4,
+			wantCount: 2,
+		},
+		{
+			name:      "replica counts on unknown machine",
+			placement: api.Placement{MachineReplicas: map[string]uint{"machine-1": 1, "machine-5": 1}},
+			replicas:  2,
+			wantErr:   "machine 'machine-5' from x-machines is not available or doesn't satisfy all constraints",
+		},
+		{
+			name:      "any of too few machines",
+			placement: api.Placement{ExcludeMachines: []string{"machine-1", "machine-2", "machine-3"}, MachineCount: 2},
+			replicas:  2,
+			wantErr:   "only 1 machines available that satisfy all constraints, x-machines requires any 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := api.ServiceSpec{
+				Name:      "test",
+				Mode:      api.ServiceModeReplicated,
+				Replicas:  tt.replicas,
+				Placement: tt.placement,
+				Container: api.ContainerSpec{Image: "nginx"},
+			}
+
+			strategy := &RollingStrategy{}
+			plan, err := strategy.Plan(state, nil, spec)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var machines []string
+			for _, op := range plan.Operations {
+				machines = append(machines, op.(*operation.RunContainerOperation).MachineID)
+			}
+			if tt.wantCount > 0 {
+				assert.Len(t, machines, int(tt.replicas))
+				slices.Sort(machines)
+				assert.Len(t, slices.Compact(machines), tt.wantCount)
+				return
+			}
+			assert.ElementsMatch(t, tt.wantMachines, machines)
+		})
+	}
+}
+
+func TestRollingStrategy_PlanReplicated_HostPorts(t *testing.T) {
+	t.Parallel()
+
+	state := &scheduler.ClusterState{}
+	for _, id := range []string{"1", "2", "3"} {
+		state.Machines = append(state.Machines, &scheduler.Machine{
+			Info: &pb.MachineInfo{Id: id, Name: "machine-" + id},
+		})
+	}
+
+	tests := []struct {
+		name      string
+		placement api.Placement
+		replicas  uint
+		wantErr   string
+	}{
+		{
+			name:     "one replica per machine",
+			replicas: 3,
+		},
+		{
+			name:     "more replicas than machines",
+			replicas: 4,
+			wantErr: "can't run 4 replicas on 3 available machines: the service publishes host port 8080/tcp@host " +
+				"that only one container on a machine can publish. Reduce the number of replicas, add machines that " +
+				"satisfy all constraints, or publish the port in ingress mode",
+		},
+		{
+			name:      "more replicas than allowed machines",
+			placement: api.Placement{Machines: []string{"machine-1", "machine-2"}},
+			replicas:  3,
+			wantErr: "can't run 3 replicas on 2 available machines: the service publishes host port 8080/tcp@host " +
+				"that only one container on a machine can publish. Reduce the number of replicas, add machines that " +
+				"satisfy all constraints, or publish the port in ingress mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := api.ServiceSpec{
+				Name:      "test",
+				Mode:      api.ServiceModeReplicated,
+				Replicas:  tt.replicas,
+				Placement: tt.placement,
+				Container: api.ContainerSpec{Image: "nginx"},
+				Ports: []api.PortSpec{{
+					PublishedPort: 8080,
+					ContainerPort: 80,
+					Protocol:      api.ProtocolTCP,
+					Mode:          api.PortModeHost,
+				}},
+			}
+
+			strategy := &RollingStrategy{}
+			plan, err := strategy.Plan(state, nil, spec)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			var machines []string
+			for _, op := range plan.Operations {
+				machines = append(machines, op.(*operation.RunContainerOperation).MachineID)
+			}
+			slices.Sort(machines)
+			assert.Len(t, slices.Compact(machines), int(tt.replicas))
+		})
+	}
+}
+
+func TestRollingStrategy_PlanReplicated_SpreadZones(t *testing.T) {
+	t.Parallel()
+
+	machine := func(id, zone string) *scheduler.Machine {
+		return &scheduler.Machine{Info: &pb.MachineInfo{Id: id, Name: "machine-" + id, Zone: zone}}
+	}
+	state := &scheduler.ClusterState{Machines: []*scheduler.Machine{
+		machine("1", "fsn1"),
+		machine("2", "fsn1"),
+		machine("3", "fsn1"),
+		machine("4", "hel1"),
+	}}
+
+	tests := []struct {
+		name      string
+		placement api.Placement
+		replicas  uint
+		// wantZones is the number of replicas in each zone if the zones can be determined.
+		wantZones map[string]int
+		// wantZoneCount is the number of zones with replicas if the zones are chosen randomly.
+		wantZoneCount int
+		wantWarning   bool
+	}{
+		{
+			name:      "balanced across zones",
+			placement: api.Placement{SpreadZones: true},
+			replicas:  4,
+			wantZones: map[string]int{"fsn1": 2, "hel1": 2},
+		},
+		{
+			name:          "odd number of replicas",
+			placement:     api.Placement{SpreadZones: true},
+			replicas:      3,
+			wantZoneCount: 2,
+		},
+		{
+			name:      "any 2 machines in different zones",
+			placement: api.Placement{SpreadZones: true, MachineCount: 2},
+			replicas:  2,
+			wantZones: map[string]int{"fsn1": 1, "hel1": 1},
+		},
+		{
+			name:        "single zone",
+			placement:   api.Placement{SpreadZones: true, ExcludeMachines: []string{"machine-4"}},
+			replicas:    3,
+			wantZones:   map[string]int{"fsn1": 3},
+			wantWarning: true,
+		},
+		{
+			name:          "single replica",
+			placement:     api.Placement{SpreadZones: true},
+			replicas:      1,
+			wantZoneCount: 1,
+			wantWarning:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := api.ServiceSpec{
+				Name:      "test",
+				Mode:      api.ServiceModeReplicated,
+				Replicas:  tt.replicas,
+				Placement: tt.placement,
+				Container: api.ContainerSpec{Image: "nginx"},
+			}
+
+			strategy := &RollingStrategy{}
+			plan, err := strategy.Plan(state, nil, spec)
+			assert.NoError(t, err)
+			assert.Len(t, plan.Operations, int(tt.replicas))
+
+			zones := make(map[string]int)
+			for _, op := range plan.Operations {
+				m, _ := state.Machine(op.(*operation.RunContainerOperation).MachineID)
+				zones[m.Info.Zone]++
+			}
+			if tt.wantZones != nil {
+				assert.Equal(t, tt.wantZones, zones)
+			} else {
+				assert.Len(t, zones, tt.wantZoneCount)
+			}
+			if tt.wantWarning {
+				assert.Len(t, plan.Warnings, 1)
+			} else {
+				assert.Empty(t, plan.Warnings)
+			}
+		})
+	}
+}
+
+func TestRollingStrategy_Plan_LastMachines(t *testing.T) {
+	t.Parallel()
+
+	state := &scheduler.ClusterState{}
+	for _, id := range []string{"1", "2", "3", "4"} {
+		state.Machines = append(state.Machines, &scheduler.Machine{Info: &pb.MachineInfo{
+			Id:      id,
+			Name:    "machine-" + id,
+			Network: &pb.NetworkConfig{Subnet: pb.NewIPPrefix(netip.MustParsePrefix("10.210." + id + ".0/24"))},
+		}})
+	}
+
+	for _, mode := range []string{api.ServiceModeReplicated, api.ServiceModeGlobal} {
+		t.Run(mode, func(t *testing.T) {
+			t.Parallel()
+
+			spec := api.ServiceSpec{
+				Name:      "test",
+				Mode:      mode,
+				Replicas:  4,
+				Container: api.ContainerSpec{Image: "nginx"},
+			}
+			strategy := &RollingStrategy{LastMachineIDs: []string{"1", "3"}}
+			plan, err := strategy.Plan(state, nil, spec)
+			assert.NoError(t, err)
+
+			var machines []string
+			for _, op := range plan.Operations {
+				machines = append(machines, op.(*operation.RunContainerOperation).MachineID)
+			}
+			assert.Len(t, machines, 4)
+			assert.ElementsMatch(t, []string{"2", "4"}, machines[:2])
+			assert.ElementsMatch(t, []string{"1", "3"}, machines[2:])
+
+			pulls := plan.ImagePulls()
+			assert.Len(t, pulls, 4)
+			assert.Equal(t, "nginx", pulls[0].Image)
+			assert.Equal(t, machines[0], pulls[0].MachineID)
+		})
+	}
+}
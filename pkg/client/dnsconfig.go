@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetDNSConfig sets the configuration of the embedded DNS servers on machines. An empty configuration
+// removes it so that machines forward queries to the nameservers from their /etc/resolv.conf.
+func (cli *Client) SetDNSConfig(ctx context.Context, config api.DNSConfig) error {
+	for id, f := range config.Machines {
+		if f.IsZero() {
+			delete(config.Machines, id)
+		}
+	}
+	if config.IsZero() && len(config.Machines) == 0 {
+		_, err := cli.ClusterClient.SetDNSConfig(ctx, &pb.DNSConfig{})
+		return err
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal DNS config: %w", err)
+	}
+	_, err = cli.ClusterClient.SetDNSConfig(ctx, &pb.DNSConfig{Config: configJSON})
+	return err
+}
+
+// DNSConfig returns the configuration of the embedded DNS servers. It returns an empty configuration
+// if it's not configured.
+func (cli *Client) DNSConfig(ctx context.Context) (api.DNSConfig, error) {
+	var config api.DNSConfig
+
+	resp, err := cli.ClusterClient.GetDNSConfig(ctx, &emptypb.Empty{})
+	if err != nil {
+		return config, err
+	}
+	if len(resp.Config) == 0 {
+		return config, nil
+	}
+
+	if err = json.Unmarshal(resp.Config, &config); err != nil {
+		return config, fmt.Errorf("unmarshal DNS config: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// SetServiceEnv sets environment variable overrides for a Compose service that are merged into the service
+// environment on deploy. It returns all overrides for the service.
+func (cli *Client) SetServiceEnv(
+	ctx context.Context, project, service string, env map[string]string,
+) (map[string]string, error) {
+	resp, err := cli.ClusterClient.SetServiceEnv(ctx, &pb.SetServiceEnvRequest{
+		Project: project,
+		Service: service,
+		Env:     env,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Env, nil
+}
+
+// UnsetServiceEnv removes environment variable overrides for a Compose service. It returns the remaining overrides.
+func (cli *Client) UnsetServiceEnv(ctx context.Context, project, service string, keys ...string) (map[string]string, error) {
+	resp, err := cli.ClusterClient.UnsetServiceEnv(ctx, &pb.UnsetServiceEnvRequest{
+		Project: project,
+		Service: service,
+		Keys:    keys,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Env, nil
+}
+
+// ServiceEnv returns environment variable overrides for a Compose service.
+func (cli *Client) ServiceEnv(ctx context.Context, project, service string) (map[string]string, error) {
+	resp, err := cli.ClusterClient.GetServiceEnv(ctx, &pb.GetServiceEnvRequest{
+		Project: project,
+		Service: service,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Env, nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ClusterEvent signals that the machines or containers in the cluster have changed.
+type ClusterEvent struct {
+	Type pb.ClusterEvent_Type
+	// Err is set if the event stream failed. No more events are sent after it.
+	Err error
+}
+
+// WatchEvents streams an event each time the machines or containers in the cluster change as seen by the connected
+// machine. The channel is closed when the context is cancelled or after an event with an error.
+// The machines cache is invalidated on each machines change event.
+func (cli *Client) WatchEvents(ctx context.Context) (<-chan ClusterEvent, error) {
+	stream, err := cli.ClusterClient.WatchEvents(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ClusterEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) || ctx.Err() != nil || status.Code(err) == codes.Canceled {
+					return
+				}
+				select {
+				case ch <- ClusterEvent{Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			if event.Type == pb.ClusterEvent_MACHINES {
+				cli.machines.invalidate()
+			}
+			select {
+			case ch <- ClusterEvent{Type: event.Type}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
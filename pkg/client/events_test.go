@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type mockClusterClient struct {
+	pb.ClusterClient
+	stream *mockEventStream
+}
+
+func (m *mockClusterClient) WatchEvents(
+	context.Context, *emptypb.Empty, ...grpc.CallOption,
+) (grpc.ServerStreamingClient[pb.ClusterEvent], error) {
+	return m.stream, nil
+}
+
+type mockEventStream struct {
+	grpc.ClientStream
+	events []*pb.ClusterEvent
+	err    error
+}
+
+func (s *mockEventStream) Recv() (*pb.ClusterEvent, error) {
+	if len(s.events) == 0 {
+		return nil, s.err
+	}
+	e := s.events[0]
+	s.events = s.events[1:]
+	return e, nil
+}
+
+func TestWatchEvents(t *testing.T) {
+	t.Parallel()
+
+	events := []*pb.ClusterEvent{{Type: pb.ClusterEvent_CONTAINERS}, {Type: pb.ClusterEvent_MACHINES}}
+
+	tests := []struct {
+		name    string
+		err     error
+		want    []pb.ClusterEvent_Type
+		wantErr codes.Code
+	}{
+		{
+			name: "stream ends",
+			err:  io.EOF,
+			want: []pb.ClusterEvent_Type{pb.ClusterEvent_CONTAINERS, pb.ClusterEvent_MACHINES},
+		},
+		{
+			name:    "stream fails",
+			err:     status.Error(codes.Unavailable, "machine is not ready"),
+			want:    []pb.ClusterEvent_Type{pb.ClusterEvent_CONTAINERS, pb.ClusterEvent_MACHINES},
+			wantErr: codes.Unavailable,
+		},
+		{
+			name: "stream cancelled",
+			err:  status.Error(codes.Canceled, "context canceled"),
+			want: []pb.ClusterEvent_Type{pb.ClusterEvent_CONTAINERS, pb.ClusterEvent_MACHINES},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cli := &Client{ClusterClient: &mockClusterClient{
+				stream: &mockEventStream{events: append([]*pb.ClusterEvent(nil), events...), err: tt.err},
+			}}
+			ch, err := cli.WatchEvents(context.Background())
+			require.NoError(t, err)
+
+			var got []pb.ClusterEvent_Type
+			var gotErr error
+			for e := range ch {
+				if e.Err != nil {
+					gotErr = e.Err
+					continue
+				}
+				got = append(got, e.Type)
+			}
+
+			assert.Equal(t, tt.want, got)
+			if tt.wantErr != codes.OK {
+				assert.Equal(t, tt.wantErr, status.Code(gotErr))
+			} else {
+				assert.NoError(t, gotErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// RunHostUpdate runs the OS package update command on the host of the machine with the given name or ID. The default
+// command for the package manager on the host is used if the command is empty. The update may take several minutes
+// so the context should have a long enough deadline.
+func (cli *Client) RunHostUpdate(ctx context.Context, machineNameOrID, command string) (api.HostUpdate, error) {
+	var update api.HostUpdate
+	ctx = cli.ProxySingleMachineContext(ctx, machineNameOrID)
+	resp, err := cli.MachineClient.RunHostUpdate(ctx, &pb.RunHostUpdateRequest{Command: command})
+	if err != nil {
+		return update, err
+	}
+	if err = json.Unmarshal(resp.Update, &update); err != nil {
+		return update, fmt.Errorf("unmarshal host update: %w", err)
+	}
+	return update, nil
+}
+
+// HostUpdates returns the history of OS package updates on the host of the machine with the given ID.
+func (cli *Client) HostUpdates(ctx context.Context, machineID string) (api.HostUpdateHistory, error) {
+	history := api.HostUpdateHistory{MachineID: machineID}
+	resp, err := cli.ClusterClient.GetHostUpdates(ctx, &pb.GetHostUpdatesRequest{MachineId: machineID})
+	if err != nil {
+		return history, err
+	}
+	if len(resp.History) == 0 {
+		return history, nil
+	}
+
+	if err = json.Unmarshal(resp.History, &history); err != nil {
+		return history, fmt.Errorf("unmarshal host updates: %w", err)
+	}
+	return history, nil
+}
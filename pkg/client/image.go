@@ -1,10 +1,12 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"os"
@@ -17,6 +19,7 @@ import (
 
 	"charm.land/lipgloss/v2"
 	"github.com/containerd/errdefs"
+	"github.com/docker/cli/cli/connhelper"
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
@@ -25,6 +28,7 @@ import (
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/go-connections/nat"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	cliprogress "github.com/psviderski/uncloud/internal/cli/progress"
 	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/internal/docker"
 	"github.com/psviderski/uncloud/internal/machine/api/pb"
@@ -33,7 +37,11 @@ import (
 	"github.com/psviderski/uncloud/internal/proxy"
 	"github.com/psviderski/uncloud/internal/secret"
 	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/psviderski/uncloud/pkg/client/deploy"
+	"github.com/psviderski/uncloud/pkg/client/timing"
 	netproxy "golang.org/x/net/proxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // This is the container image used to run socat proxy containers.
@@ -51,6 +59,58 @@ func (cli *Client) InspectImage(ctx context.Context, id string) ([]api.MachineIm
 	return images, err
 }
 
+// InspectImages returns the image information for each of the given image IDs on the machines the context is proxied
+// to using one request per machine. The returned slice is aligned with ids. An entry is nil if the image isn't found
+// on any machine. Machines that don't support batch requests are inspected one image at a time.
+func (cli *Client) InspectImages(ctx context.Context, ids []string) ([][]api.MachineImage, error) {
+	images, unsupported, err := cli.Docker.InspectImages(ctx, ids)
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// The only machine the request was sent to doesn't support it.
+		images = make([][]api.MachineImage, len(ids))
+		if err = cli.inspectImagesOneByOne(ctx, ids, images); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case len(unsupported) > 0:
+		if err = cli.inspectImagesOneByOne(cli.ProxyMachinesContext(ctx, unsupported), ids, images); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, machineImages := range images {
+		notFound := true
+		for _, mi := range machineImages {
+			if mi.Metadata.GetStatus() == nil || codes.Code(mi.Metadata.Status.Code) != codes.NotFound {
+				notFound = false
+				break
+			}
+		}
+		if notFound {
+			images[i] = nil
+		}
+	}
+
+	return images, nil
+}
+
+// inspectImagesOneByOne inspects the images with separate requests for older machines that don't support
+// the InspectImages method and appends the results to images.
+func (cli *Client) inspectImagesOneByOne(ctx context.Context, ids []string, images [][]api.MachineImage) error {
+	for i, id := range ids {
+		machineImages, err := cli.InspectImage(ctx, id)
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("inspect image '%s': %w", id, err)
+		}
+		images[i] = append(images[i], machineImages...)
+	}
+	return nil
+}
+
 func (cli *Client) InspectRemoteImage(ctx context.Context, id string) ([]api.MachineRemoteImage, error) {
 	return cli.Docker.InspectRemoteImage(ctx, id)
 }
@@ -113,6 +173,238 @@ func (cli *Client) ListImages(ctx context.Context, filter api.ImageFilter) ([]ap
 	return machineImages, nil
 }
 
+type RemoveImageOptions struct {
+	// Machines is a list of machine names or IDs to remove the image from. If empty, removes from all machines.
+	Machines []string
+	// Force removes the image even if it's used by stopped containers or has other tags.
+	Force bool
+}
+
+// RemoveImage removes the image from the specified machines or all machines if none specified and returns
+// the result for each machine. Failures on individual machines are reported in the results rather than as an error.
+func (cli *Client) RemoveImage(
+	ctx context.Context, img string, opts RemoveImageOptions,
+) ([]api.MachineImageResult, error) {
+	rmCtx := cli.ProxyMachinesContext(ctx, opts.Machines)
+	return cli.Docker.RemoveImage(rmCtx, img, image.RemoveOptions{
+		Force:         opts.Force,
+		PruneChildren: true,
+	})
+}
+
+// RemoveImages removes the images from the specified machines or all machines if none specified using one request
+// per machine and returns the results for each image and machine. The returned slice is aligned with imgs. Failures
+// on individual machines are reported in the results rather than as an error. Machines that don't support batch
+// requests are sent a separate request for each image.
+func (cli *Client) RemoveImages(
+	ctx context.Context, imgs []string, opts RemoveImageOptions,
+) ([][]api.MachineImageResult, error) {
+	rmOpts := image.RemoveOptions{
+		Force:         opts.Force,
+		PruneChildren: true,
+	}
+	results, unsupported, err := cli.Docker.RemoveImages(cli.ProxyMachinesContext(ctx, opts.Machines), imgs, rmOpts)
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// The only machine the request was sent to doesn't support it.
+		results = make([][]api.MachineImageResult, len(imgs))
+		if err = cli.removeImagesOneByOne(ctx, imgs, opts.Machines, rmOpts, results); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case len(unsupported) > 0:
+		if err = cli.removeImagesOneByOne(ctx, imgs, unsupported, rmOpts, results); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// removeImagesOneByOne removes the images with separate requests from older machines that don't support
+// the RemoveImages method and appends the results to results. Machines are all machines if empty.
+func (cli *Client) removeImagesOneByOne(
+	ctx context.Context, imgs, machines []string, opts image.RemoveOptions, results [][]api.MachineImageResult,
+) error {
+	rmCtx := cli.ProxyMachinesContext(ctx, machines)
+	for i, img := range imgs {
+		machineResults, err := cli.Docker.RemoveImage(rmCtx, img, opts)
+		if err != nil {
+			return fmt.Errorf("remove image '%s': %w", img, err)
+		}
+		results[i] = append(results[i], machineResults...)
+	}
+	return nil
+}
+
+type PruneImagesOptions struct {
+	// Machines is a list of machine names or IDs to prune images on. If empty, prunes images on all machines.
+	Machines []string
+	// All removes all images not used by any container, not just dangling ones.
+	All bool
+}
+
+// PruneImages removes unused images on the specified machines or all machines if none specified and returns
+// the result for each machine. Pinned images are never removed. Failures on individual machines are reported
+// in the results rather than as an error.
+func (cli *Client) PruneImages(ctx context.Context, opts PruneImagesOptions) ([]api.MachineImageResult, error) {
+	pinned, err := cli.ListPinnedImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list pinned images: %w", err)
+	}
+
+	pruneCtx := cli.ProxyMachinesContext(ctx, opts.Machines)
+	return cli.Docker.PruneImages(pruneCtx, filters.NewArgs(
+		filters.Arg("dangling", strconv.FormatBool(!opts.All)),
+	), pinned)
+}
+
+// PinImage adds the image references to the cluster-wide list of pinned images that are protected from pruning.
+// A reference without a tag or digest pins all tags of the repository. It returns the updated list.
+func (cli *Client) PinImage(ctx context.Context, images ...string) ([]string, error) {
+	resp, err := cli.ClusterClient.PinImage(ctx, &pb.PinImageRequest{Images: images})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Images, nil
+}
+
+// UnpinImage removes the image references from the cluster-wide list of pinned images. It returns the updated list.
+func (cli *Client) UnpinImage(ctx context.Context, images ...string) ([]string, error) {
+	resp, err := cli.ClusterClient.UnpinImage(ctx, &pb.PinImageRequest{Images: images})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Images, nil
+}
+
+// ListPinnedImages returns the sorted cluster-wide list of pinned image references.
+func (cli *Client) ListPinnedImages(ctx context.Context) ([]string, error) {
+	resp, err := cli.ClusterClient.ListPinnedImages(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Images, nil
+}
+
+type PullImageOptions struct {
+	// Machines is a list of machine names or IDs to pull the image on. If empty, pulls on all machines.
+	Machines []string
+}
+
+// PullImage pulls the image from a registry on the specified machines or all machines if none specified
+// concurrently and returns the result for each machine. Failures on individual machines are reported
+// in the results rather than as an error.
+func (cli *Client) PullImage(
+	ctx context.Context, img string, opts PullImageOptions,
+) ([]api.MachineImageResult, error) {
+	var filter *api.MachineFilter
+	if len(opts.Machines) > 0 {
+		filter = &api.MachineFilter{NamesOrIDs: opts.Machines}
+	}
+	machines, err := cli.ListMachines(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+
+	results := make([]api.MachineImageResult, len(machines))
+	var wg sync.WaitGroup
+	for i, mm := range machines {
+		wg.Go(func() {
+			results[i] = api.MachineImageResult{
+				MachineID:   mm.Machine.Id,
+				MachineName: mm.Machine.Name,
+				Status:      api.ImageOperationSucceeded,
+			}
+
+			pullCtx := cli.ProxySingleMachineContext(ctx, mm.Machine.Id)
+			if err := cli.pullImageWithProgress(pullCtx, img, mm.Machine.Name, ""); err != nil {
+				results[i].Status = api.ImageOperationStatusFromCode(status.Code(err))
+				if results[i].Succeeded() {
+					results[i].Status = api.ImageOperationFailed
+				}
+				results[i].Err = err
+			}
+		})
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// PrePullImages pulls the images on the machines before a deployment starts its containers, running at most
+// maxConcurrent pulls at a time across the cluster. It avoids saturating a shared network uplink when many machines
+// pull large images at once. Pulls are started in the given order. 0 or less means no limit.
+// The pull policy of each image is respected: images with the "missing" policy are only pulled if they are not
+// available on the machine, and images with the "never" policy are skipped.
+func (cli *Client) PrePullImages(ctx context.Context, pulls []deploy.ImagePull, maxConcurrent int) error {
+	// Check concurrently which images need to be pulled according to their pull policy.
+	needed := make([]bool, len(pulls))
+	errs := make([]error, len(pulls))
+	var wg sync.WaitGroup
+	for i, p := range pulls {
+		switch p.PullPolicy {
+		case api.PullPolicyNever:
+			continue
+		case api.PullPolicyAlways:
+			needed[i] = true
+			continue
+		}
+		wg.Go(func() {
+			_, err := cli.InspectImage(cli.ProxySingleMachineContext(ctx, p.MachineID), p.Image)
+			if errors.Is(err, api.ErrNotFound) {
+				needed[i] = true
+			} else if err != nil {
+				errs[i] = fmt.Errorf("inspect image '%s' on machine '%s': %w", p.Image, p.MachineName, err)
+			}
+		})
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	pw := progress.ContextWriter(ctx)
+	var queued []deploy.ImagePull
+	for i, p := range pulls {
+		if !needed[i] {
+			continue
+		}
+		queued = append(queued, p)
+		pw.Event(progress.Event{
+			ID:         cliprogress.ImageEventID(p.Image, p.MachineName),
+			Status:     progress.Working,
+			StatusText: "Waiting",
+		})
+	}
+
+	if maxConcurrent <= 0 {
+		maxConcurrent = len(queued)
+	}
+	sem := make(chan struct{}, max(1, maxConcurrent))
+	errs = make([]error, len(queued))
+	for i, p := range queued {
+		// Acquire a slot before starting the goroutine to start the pulls in order.
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			pullCtx := cli.ProxySingleMachineContext(ctx, p.MachineID)
+			if err := cli.pullImageWithProgress(pullCtx, p.Image, p.MachineName, ""); err != nil {
+				errs[i] = fmt.Errorf("pull image '%s' on machine '%s': %w", p.Image, p.MachineName, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
 type PushImageOptions struct {
 	// AllMachines pushes the image to all machines in the cluster. Takes precedence over Machines field.
 	AllMachines bool
@@ -122,25 +414,51 @@ type PushImageOptions struct {
 	// Platform to push for a multi-platform image. Local Docker must use containerd image store
 	// to support multi-platform images.
 	Platform *ocispec.Platform
+	// Builder is the machine to push the image from instead of the local Docker, for example, because the image
+	// was built on it. The image is pushed from the Docker on the builder directly to the target machines over
+	// the cluster network. The builder itself is skipped as the image is already in its Docker.
+	Builder *pb.MachineInfo
+	// BuilderDockerHost is the Docker host URL of the Docker daemon on the Builder machine,
+	// e.g. ssh://user@host. Required if Builder is set.
+	BuilderDockerHost string
+	// Stream uploads the whole image as a tarball over the cluster connection and loads it into Docker on the target
+	// machines instead of pushing only the missing layers to their unregistry. It doesn't require the containerd
+	// image store on the machines or a proxy container in the local Docker.
+	Stream bool
 }
 
 // PushImage pushes a local Docker image to the specified machines. If no machines are specified,
-// it pushes to the machine the client is connected to.
+// it pushes to the machine the client is connected to. If a builder is specified, the image is pushed
+// from the Docker on the builder machine instead.
 func (cli *Client) PushImage(ctx context.Context, image string, opts PushImageOptions) error {
-	dockerCliWrapped, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv,
-		dockerclient.WithAPIVersionNegotiation())
+	dockerOpts := []dockerclient.Opt{dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation()}
+	where := "locally"
+	if opts.Builder != nil {
+		helper, err := connhelper.GetConnectionHelper(opts.BuilderDockerHost)
+		if err != nil {
+			return fmt.Errorf("parse Docker host '%s' of builder: %w", opts.BuilderDockerHost, err)
+		}
+		if helper == nil {
+			return fmt.Errorf("unsupported Docker host '%s' of builder: must be an ssh:// URL", opts.BuilderDockerHost)
+		}
+		dockerOpts = append(dockerOpts,
+			dockerclient.WithHost(helper.Host), dockerclient.WithDialContext(helper.Dialer))
+		where = fmt.Sprintf("on builder machine '%s'", opts.Builder.Name)
+	}
+
+	dockerCliWrapped, err := dockerclient.NewClientWithOpts(dockerOpts...)
 	if err != nil {
 		return fmt.Errorf("create Docker client: %w", err)
 	}
 	dockerCli := &docker.Client{Client: dockerCliWrapped}
 	defer dockerCli.Close()
 
-	// Check if Docker image exists locally.
+	// Check if Docker image exists locally or on the builder.
 	if _, err = dockerCli.ImageInspect(ctx, image); err != nil {
 		if errdefs.IsNotFound(err) {
-			return fmt.Errorf("image '%s' not found locally", image)
+			return fmt.Errorf("image '%s' not found %s", image, where)
 		}
-		return fmt.Errorf("inspect image '%s' locally: %w", image, err)
+		return fmt.Errorf("inspect image '%s' %s: %w", image, where, err)
 	}
 
 	// Get the machine info for the specified machines or the connected machine if none are specified.
@@ -181,6 +499,14 @@ func (cli *Client) PushImage(ctx context.Context, image string, opts PushImageOp
 		machines = append(machines, mm.Machine)
 	}
 
+	var tarball *imageTarball
+	if opts.Stream {
+		if tarball, err = saveImage(ctx, dockerCli, image, opts.Platform); err != nil {
+			return err
+		}
+		defer tarball.Remove()
+	}
+
 	// Push image to all specified machines.
 	var wg sync.WaitGroup
 	errCh := make(chan error, len(machines))
@@ -188,8 +514,19 @@ func (cli *Client) PushImage(ctx context.Context, image string, opts PushImageOp
 	// TODO: detect the target machine platform and figure out how to handle scenarios when local and target
 	//  platforms differ.
 	for _, m := range machines {
+		if opts.Builder != nil && m.Id == opts.Builder.Id {
+			continue
+		}
 		wg.Go(func() {
-			if err := cli.pushImageToMachine(ctx, dockerCli, image, m, opts.Platform); err != nil {
+			var err error
+			if tarball != nil {
+				err = cli.loadImageToMachine(ctx, tarball, image, m)
+			} else if opts.Builder != nil {
+				err = cli.pushImageFromBuilder(ctx, dockerCli, image, m, opts.Platform)
+			} else {
+				err = cli.pushImageToMachine(ctx, dockerCli, image, m, opts.Platform)
+			}
+			if err != nil {
 				errCh <- fmt.Errorf("push image to machine '%s': %w", m.Name, err)
 			}
 		})
@@ -214,27 +551,14 @@ func (cli *Client) pushImageToMachine(
 	machine *pb.MachineInfo,
 	platform *ocispec.Platform,
 ) error {
+	defer timing.Start(ctx, machine.Name, timing.PhasePush)()
+
 	pw := progress.ContextWriter(ctx)
 	boldStyle := lipgloss.NewStyle().Bold(true)
 	pushEventID := fmt.Sprintf("Pushing %s to %s", boldStyle.Render(imageName), boldStyle.Render(machine.Name))
 
-	// Check the Docker image store type on the target machine.
-	images, err := cli.ListImages(ctx, api.ImageFilter{
-		Machines: []string{machine.Id},
-		Name:     "%invalid-name-to-only-check-store-type%",
-	})
-	if err != nil {
-		return fmt.Errorf("check Docker image store type on machine '%s': %w", machine.Name, err)
-	}
-
-	// Only support Docker with containerd image store enabled to avoid the confusion of pushing images to containerd
-	// and then not being able to see and use them in Docker.
-	if !images[0].ContainerdStore {
-		pw.Event(progress.NewEvent(pushEventID, progress.Error, "containerd image store required"))
-		return fmt.Errorf("docker on machine '%s' is not using containerd image store, "+
-			"which is required for pushing images. Follow the instructions to enable it: "+
-			"https://docs.docker.com/engine/storage/containerd/, and then restart the uncloud daemon "+
-			"via 'systemctl restart uncloud'", machine.Name)
+	if err := cli.checkContainerdStore(ctx, machine, pushEventID); err != nil {
+		return err
 	}
 
 	machineSubnet, _ := machine.Network.Subnet.ToPrefix()
@@ -369,7 +693,236 @@ func (cli *Client) pushImageToMachine(
 		return fmt.Errorf("tag image for push: %w", err)
 	}
 
-	// Push the image through the proxy.
+	if err = pushTaggedImage(ctx, dockerCli, pushImageTag, machine.Name, platform, pushEventID); err != nil {
+		// Include the proxy error (if any) to expose the root cause behind a generic push failure.
+		select {
+		case proxyErr := <-proxyErrCh:
+			return fmt.Errorf("push image: %w", errors.Join(err, proxyErr))
+		default:
+		}
+
+		return fmt.Errorf("push image: %w", err)
+	}
+
+	return nil
+}
+
+// pushImageFromBuilder pushes an image from the Docker on a builder machine to a specific machine. A socat container
+// on the builder forwards a port on its localhost to the unregistry on the target machine over the cluster network.
+func (cli *Client) pushImageFromBuilder(
+	ctx context.Context,
+	dockerCli *docker.Client,
+	imageName string,
+	machine *pb.MachineInfo,
+	platform *ocispec.Platform,
+) error {
+	defer timing.Start(ctx, machine.Name, timing.PhasePush)()
+
+	pw := progress.ContextWriter(ctx)
+	boldStyle := lipgloss.NewStyle().Bold(true)
+	pushEventID := fmt.Sprintf("Pushing %s to %s", boldStyle.Render(imageName), boldStyle.Render(machine.Name))
+
+	if err := cli.checkContainerdStore(ctx, machine, pushEventID); err != nil {
+		return err
+	}
+
+	machineSubnet, _ := machine.Network.Subnet.ToPrefix()
+	machineIP := network.MachineIP(machineSubnet)
+	unregistryAddr := net.JoinHostPort(machineIP.String(), strconv.Itoa(constants.UnregistryPort))
+
+	proxyEventID := fmt.Sprintf("Proxy to unregistry on %s", boldStyle.Render(machine.Name))
+	pw.Event(progress.Event{
+		ID:         proxyEventID,
+		Status:     progress.Working,
+		StatusText: "Starting",
+		Text:       "(starting socat proxy container on builder)",
+	})
+
+	proxyCtrID, proxyPort, err := runBuilderProxyContainer(ctx, dockerCli, unregistryAddr)
+	if err != nil {
+		pw.Event(progress.NewEvent(proxyEventID, progress.Error, err.Error()))
+		return fmt.Errorf("run socat container on builder to proxy unregistry: %w", err)
+	}
+	defer dockerCli.ContainerRemove(ctx, proxyCtrID, container.RemoveOptions{Force: true})
+
+	pw.Event(progress.Event{
+		ID:         proxyEventID,
+		Status:     progress.Done,
+		StatusText: "Started",
+		Text:       fmt.Sprintf("(builder localhost:%d → %s)", proxyPort, unregistryAddr),
+	})
+
+	// Tag the image for pushing through the proxy.
+	pushImageTag := fmt.Sprintf("127.0.0.1:%d/%s", proxyPort, imageName)
+	if err = dockerCli.ImageTag(ctx, imageName, pushImageTag); err != nil {
+		return fmt.Errorf("tag image for push: %w", err)
+	}
+	defer dockerCli.ImageRemove(ctx, pushImageTag, image.RemoveOptions{})
+
+	if err = pushTaggedImage(ctx, dockerCli, pushImageTag, machine.Name, platform, pushEventID); err != nil {
+		return fmt.Errorf("push image: %w", err)
+	}
+
+	return nil
+}
+
+// imageTarball is an image saved in the 'docker save' format to a temporary file.
+type imageTarball struct {
+	path string
+	size int64
+}
+
+// Remove deletes the temporary file.
+func (t *imageTarball) Remove() {
+	os.Remove(t.path)
+}
+
+// saveImage saves the image from Docker to a temporary file once so that it can be streamed to multiple machines
+// concurrently.
+func saveImage(
+	ctx context.Context, dockerCli *docker.Client, imageName string, platform *ocispec.Platform,
+) (*imageTarball, error) {
+	var saveOpts []dockerclient.ImageSaveOption
+	if platform != nil {
+		saveOpts = append(saveOpts, dockerclient.ImageSaveWithPlatforms(*platform))
+	}
+	rc, err := dockerCli.ImageSave(ctx, []string{imageName}, saveOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("save image '%s': %w", imageName, err)
+	}
+	defer rc.Close()
+
+	f, err := os.CreateTemp("", "uncloud-image-*.tar")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary file for image: %w", err)
+	}
+	defer f.Close()
+
+	size, err := io.Copy(f, rc)
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, fmt.Errorf("save image '%s': %w", imageName, err)
+	}
+
+	return &imageTarball{path: f.Name(), size: size}, nil
+}
+
+// loadImageToMachine streams the image tarball to a specific machine over the cluster connection and loads it into
+// Docker on the machine.
+func (cli *Client) loadImageToMachine(
+	ctx context.Context, tarball *imageTarball, imageName string, machine *pb.MachineInfo,
+) error {
+	defer timing.Start(ctx, machine.Name, timing.PhasePush)()
+
+	pw := progress.ContextWriter(ctx)
+	boldStyle := lipgloss.NewStyle().Bold(true)
+	eventID := fmt.Sprintf("Streaming %s to %s", boldStyle.Render(imageName), boldStyle.Render(machine.Name))
+	pw.Event(progress.NewEvent(eventID, progress.Working, "Uploading"))
+
+	f, err := os.Open(tarball.path)
+	if err != nil {
+		pw.Event(progress.NewEvent(eventID, progress.Error, err.Error()))
+		return fmt.Errorf("open image tarball: %w", err)
+	}
+	defer f.Close()
+
+	r := &progressReader{r: f, onRead: func(read int64) {
+		pw.Event(progress.Event{
+			ID:         eventID,
+			Status:     progress.Working,
+			StatusText: "Uploading",
+			Current:    read,
+			Total:      tarball.size,
+			Percent:    int(read * 100 / max(tarball.size, 1)),
+		})
+	}}
+
+	loadCh, err := cli.Docker.LoadImage(cli.ProxySingleMachineContext(ctx, machine.Id), r)
+	if err != nil {
+		pw.Event(progress.NewEvent(eventID, progress.Error, err.Error()))
+		return fmt.Errorf("load image: %w", err)
+	}
+
+	for msg := range loadCh {
+		err = msg.Err
+		if err == nil && msg.Message.Error != nil {
+			err = errors.New(msg.Message.Error.Message)
+		}
+		if err != nil {
+			statusErr := status.Convert(err)
+			pw.Event(progress.NewEvent(eventID, progress.Error, statusErr.Message()))
+			return fmt.Errorf("load image: %w", statusMessageError{statusErr})
+		}
+
+		if e := toPushProgressEvent(msg.Message); e != nil {
+			e.ID = fmt.Sprintf("Layer %s on %s:", e.ID, boldStyle.Render(machine.Name))
+			e.ParentID = eventID
+			// Docker doesn't report a final status for each loaded layer.
+			if e.Total > 0 && e.Current >= e.Total {
+				e.Status = progress.Done
+			}
+			pw.Event(*e)
+		}
+	}
+	pw.Event(progress.NewEvent(eventID, progress.Done, "Loaded"))
+
+	return nil
+}
+
+// progressReader calls onRead with the total number of bytes read after each read.
+type progressReader struct {
+	r      io.Reader
+	read   int64
+	onRead func(read int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.onRead(r.read)
+	}
+	return n, err
+}
+
+// checkContainerdStore checks that Docker on the machine uses the containerd image store which is required
+// for pushing images to it.
+func (cli *Client) checkContainerdStore(ctx context.Context, machine *pb.MachineInfo, pushEventID string) error {
+	images, err := cli.ListImages(ctx, api.ImageFilter{
+		Machines: []string{machine.Id},
+		Name:     "%invalid-name-to-only-check-store-type%",
+	})
+	if err != nil {
+		return fmt.Errorf("check Docker image store type on machine '%s': %w", machine.Name, err)
+	}
+
+	// Only support Docker with containerd image store enabled to avoid the confusion of pushing images to containerd
+	// and then not being able to see and use them in Docker.
+	if !images[0].ContainerdStore {
+		pw := progress.ContextWriter(ctx)
+		pw.Event(progress.NewEvent(pushEventID, progress.Error, "containerd image store required"))
+		return fmt.Errorf("docker on machine '%s' is not using containerd image store, "+
+			"which is required for pushing images. Follow the instructions to enable it: "+
+			"https://docs.docker.com/engine/storage/containerd/, and then restart the uncloud daemon "+
+			"via 'systemctl restart uncloud'. Alternatively, stream the whole image with 'uc image push --stream'",
+			machine.Name)
+	}
+
+	return nil
+}
+
+// pushTaggedImage pushes the image tagged with a proxy address to the unregistry and converts the push progress
+// messages to events for the layers pushed to the machine.
+func pushTaggedImage(
+	ctx context.Context,
+	dockerCli *docker.Client,
+	pushImageTag string,
+	machineName string,
+	platform *ocispec.Platform,
+	pushEventID string,
+) error {
+	pw := progress.ContextWriter(ctx)
+	boldStyle := lipgloss.NewStyle().Bold(true)
 	pw.Event(progress.NewEvent(pushEventID, progress.Working, "Pushing"))
 
 	pushCh, err := dockerCli.PushImage(ctx, pushImageTag, image.PushOptions{
@@ -377,7 +930,7 @@ func (cli *Client) pushImageToMachine(
 	})
 	if err != nil {
 		pw.Event(progress.NewEvent(pushEventID, progress.Error, err.Error()))
-		return fmt.Errorf("push image: %w", err)
+		return err
 	}
 
 	// Wait for push to complete by reading all progress messages and converting them to events.
@@ -385,19 +938,12 @@ func (cli *Client) pushImageToMachine(
 	for msg := range pushCh {
 		if msg.Err != nil {
 			pw.Event(progress.NewEvent(pushEventID, progress.Error, msg.Err.Error()))
-			// Include the proxy error (if any) to expose the root cause behind a generic push failure.
-			select {
-			case proxyErr := <-proxyErrCh:
-				return fmt.Errorf("push image: %w", errors.Join(msg.Err, proxyErr))
-			default:
-			}
-
-			return fmt.Errorf("push image: %w", msg.Err)
+			return msg.Err
 		}
 
 		// TODO: support quite mode like in compose: --quiet Push without printing progress information
 		if e := toPushProgressEvent(msg.Message); e != nil {
-			e.ID = fmt.Sprintf("Layer %s on %s:", e.ID, boldStyle.Render(machine.Name))
+			e.ID = fmt.Sprintf("Layer %s on %s:", e.ID, boldStyle.Render(machineName))
 			e.ParentID = pushEventID
 			pw.Event(*e)
 		}
@@ -623,6 +1169,108 @@ func runSocatProxyContainer(ctx context.Context, dockerCli *docker.Client, socat
 	return resp.ID, hostPort, nil
 }
 
+// runBuilderProxyContainer creates a socat container on a builder machine that listens on TCP port 5000 and forwards
+// to the unregistry address on another machine over the cluster network. The container port is bound to a random
+// port on the builder's localhost as a local port can't be reserved on a remote machine.
+// Returns the container ID and the port on the builder's localhost that 'docker push' should target.
+func runBuilderProxyContainer(
+	ctx context.Context, dockerCli *docker.Client, unregistryAddr string,
+) (string, int, error) {
+	suffix, err := secret.RandomAlphaNumeric(4)
+	if err != nil {
+		return "", 0, fmt.Errorf("generate random suffix: %w", err)
+	}
+	containerName := fmt.Sprintf("uncloud-push-proxy-%s", suffix)
+
+	containerPort := nat.Port("5000/tcp")
+	config := &container.Config{
+		Image: socatImage,
+		// Reset the default entrypoint "socat".
+		Entrypoint: []string{},
+		Cmd: []string{
+			"timeout", "1800", // Auto-terminate socat after 30 minutes.
+			// Log the listening address to detect when socat is ready to accept connections.
+			"socat", "-d", "-d",
+			"TCP-LISTEN:5000,fork,reuseaddr",
+			"TCP-CONNECT:" + unregistryAddr,
+		},
+		ExposedPorts: nat.PortSet{
+			containerPort: {},
+		},
+		Labels: map[string]string{
+			api.LabelManaged: "",
+		},
+	}
+	hostConfig := &container.HostConfig{
+		AutoRemove: true,
+		PortBindings: nat.PortMap{
+			containerPort: []nat.PortBinding{{HostIP: "127.0.0.1"}},
+		},
+	}
+
+	resp, err := dockerCli.CreateContainerWithImagePull(ctx, containerName, config, hostConfig)
+	if err != nil {
+		return "", 0, fmt.Errorf("create socat proxy container: %w", err)
+	}
+
+	cleanup := func() {
+		dockerCli.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true})
+	}
+
+	if err = dockerCli.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		cleanup()
+		return "", 0, fmt.Errorf("start socat proxy container %s: %w", resp.ID, err)
+	}
+
+	bindings, err := dockerCli.WaitPortPublished(ctx, resp.ID, containerPort)
+	if err != nil {
+		cleanup()
+		return "", 0, fmt.Errorf("wait for socat proxy container %s port to be published: %w", resp.ID, err)
+	}
+	hostPort, err := strconv.Atoi(bindings[0].HostPort)
+	if err != nil {
+		cleanup()
+		return "", 0, fmt.Errorf("invalid published port '%s' of socat proxy container %s: %w",
+			bindings[0].HostPort, resp.ID, err)
+	}
+
+	if err = waitForSocatListening(ctx, dockerCli, resp.ID, 10*time.Second); err != nil {
+		cleanup()
+		return "", 0, fmt.Errorf("socat proxy container %s: %w", resp.ID, err)
+	}
+
+	return resp.ID, hostPort, nil
+}
+
+// waitForSocatListening polls the logs of a socat container started with '-d -d' until socat reports that it's
+// listening for connections or timeout is reached. It's used when the container port can't be dialled directly.
+func waitForSocatListening(
+	ctx context.Context, dockerCli *docker.Client, containerID string, timeout time.Duration,
+) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		logs, err := dockerCli.ContainerLogs(ctx, containerID, container.LogsOptions{ShowStderr: true})
+		if err != nil {
+			return fmt.Errorf("get container logs: %w", err)
+		}
+		out, err := io.ReadAll(logs)
+		logs.Close()
+		if err != nil {
+			return fmt.Errorf("read container logs: %w", err)
+		}
+		if bytes.Contains(out, []byte("listening on")) {
+			return nil
+		}
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return fmt.Errorf("socat did not start listening within %s", timeout)
+}
+
 // waitForTCPPort polls addr until a TCP connection succeeds or timeout is reached.
 func waitForTCPPort(addr string, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
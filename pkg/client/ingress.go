@@ -0,0 +1,169 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// IngressMachine describes how a machine participates in serving ingress traffic.
+type IngressMachine struct {
+	Machine *pb.MachineInfo
+	State   pb.MachineMember_MembershipState
+	// Designated is true if the machine has the ingress role or no machine in the cluster has it.
+	Designated bool
+	// CaddyRunning is true if a Caddy container is running on the machine.
+	CaddyRunning bool
+	// CaddyHealthy is true if the running Caddy container passes its health check or doesn't have one.
+	CaddyHealthy bool
+	// Reachable reports whether Caddy responds on the public IP of the machine. It's nil if not checked.
+	Reachable *bool
+	// ReachableError is the reason Caddy is not reachable on the public IP.
+	ReachableError string
+}
+
+// Serving returns true if the machine is up and runs a healthy Caddy container that is reachable on its public IP
+// when the reachability was checked.
+func (m IngressMachine) Serving() bool {
+	if m.State != pb.MachineMember_UP || !m.CaddyRunning || !m.CaddyHealthy {
+		return false
+	}
+	return m.Reachable == nil || *m.Reachable
+}
+
+// IngressStatusOptions configures IngressStatus.
+type IngressStatusOptions struct {
+	// CheckReachable sends HTTP requests to the public IPs of the machines running Caddy to verify they're
+	// reachable from the client.
+	CheckReachable bool
+}
+
+// IngressStatus returns the ingress status of all machines in the cluster sorted by machine name.
+func (cli *Client) IngressStatus(ctx context.Context, opts IngressStatusOptions) ([]IngressMachine, error) {
+	machines, err := cli.ListMachines(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+
+	var containers []api.MachineServiceContainer
+	svc, err := cli.InspectService(ctx, CaddyServiceName)
+	if err != nil {
+		if !errors.Is(err, api.ErrNotFound) {
+			return nil, fmt.Errorf("inspect service '%s': %w", CaddyServiceName, err)
+		}
+	} else {
+		containers = svc.Containers
+	}
+
+	status := ingressStatus(machines, containers)
+	if !opts.CheckReachable {
+		return status, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := range status {
+		m := &status[i]
+		if !m.CaddyRunning || m.Machine.PublicIp == nil {
+			continue
+		}
+		wg.Go(func() {
+			reachable := true
+			if err := verifyCaddyReachable(ctx, m.Machine); err != nil {
+				reachable = false
+				m.ReachableError = err.Error()
+			}
+			m.Reachable = &reachable
+		})
+	}
+	wg.Wait()
+
+	return status, nil
+}
+
+// ingressStatus combines the machines and Caddy containers into the ingress status of each machine.
+func ingressStatus(machines api.MachineMembersList, caddyContainers []api.MachineServiceContainer) []IngressMachine {
+	roleAssigned := slices.ContainsFunc(machines, func(m *pb.MachineMember) bool {
+		return api.HasMachineRole(m.Machine, api.MachineRoleIngress)
+	})
+
+	status := make([]IngressMachine, 0, len(machines))
+	for _, m := range machines {
+		im := IngressMachine{
+			Machine:    m.Machine,
+			State:      m.State,
+			Designated: !roleAssigned || api.HasMachineRole(m.Machine, api.MachineRoleIngress),
+		}
+		for _, c := range caddyContainers {
+			if c.MachineID != m.Machine.Id || !c.Container.State.Running {
+				continue
+			}
+			im.CaddyRunning = true
+			im.CaddyHealthy = im.CaddyHealthy || c.Container.Healthy()
+		}
+		status = append(status, im)
+	}
+
+	slices.SortFunc(status, func(a, b IngressMachine) int {
+		return cmp.Compare(a.Machine.Name, b.Machine.Name)
+	})
+	return status
+}
+
+// IngressRouteIssues checks the ingress routes of the given service specs for conflicts with each other and with
+// the routes of the other services running in the cluster. The specs replace the running services with the same
+// names as if they were deployed.
+func (cli *Client) IngressRouteIssues(ctx context.Context, specs []api.ServiceSpec) ([]api.IngressRouteIssue, error) {
+	services, err := cli.ListServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list services: %w", err)
+	}
+
+	allSpecs := slices.Clone(specs)
+	for _, svc := range services {
+		if slices.ContainsFunc(specs, func(s api.ServiceSpec) bool { return s.Name == svc.Name }) {
+			continue
+		}
+		if len(svc.Containers) == 0 {
+			continue
+		}
+
+		// The most recent container defines the current spec of the service.
+		latest := slices.MaxFunc(svc.Containers, func(a, b api.MachineServiceContainer) int {
+			return a.Container.CreatedTime().Compare(b.Container.CreatedTime())
+		}).Container
+		spec := latest.ServiceSpec
+		spec.Name = svc.Name
+		// Ports are stored in a container label and may be missing in the spec of older containers.
+		if spec.Ports, err = latest.ServicePorts(); err != nil {
+			return nil, fmt.Errorf("parse ports of service '%s': %w", svc.Name, err)
+		}
+		allSpecs = append(allSpecs, spec)
+	}
+
+	return api.IngressRouteIssues(allSpecs), nil
+}
+
+// IngressEvents returns the most recent changes of the ingress configuration on the machine with the given ID
+// or nil if there are none.
+func (cli *Client) IngressEvents(ctx context.Context, machineID string) (*api.IngressEvents, error) {
+	resp, err := cli.ClusterClient.GetIngressEvents(ctx, &pb.GetIngressEventsRequest{MachineId: machineID})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Events) == 0 {
+		return nil, nil
+	}
+
+	var events api.IngressEvents
+	if err = json.Unmarshal(resp.Events, &events); err != nil {
+		return nil, fmt.Errorf("unmarshal ingress events: %w", err)
+	}
+	return &events, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngressStatus(t *testing.T) {
+	t.Parallel()
+
+	member := func(name string, state pb.MachineMember_MembershipState, roles ...string) *pb.MachineMember {
+		return &pb.MachineMember{
+			Machine: &pb.MachineInfo{Id: name + "-id", Name: name, Roles: roles},
+			State:   state,
+		}
+	}
+	caddy := func(machine string, state *container.State) api.MachineServiceContainer {
+		return api.MachineServiceContainer{
+			MachineID: machine + "-id",
+			Container: api.ServiceContainer{Container: api.Container{
+				InspectResponse: container.InspectResponse{
+					ContainerJSONBase: &container.ContainerJSONBase{State: state},
+				},
+			}},
+		}
+	}
+
+	t.Run("no ingress role", func(t *testing.T) {
+		t.Parallel()
+
+		machines := api.MachineMembersList{
+			member("b", pb.MachineMember_UP),
+			member("a", pb.MachineMember_UP),
+		}
+		status := ingressStatus(machines, []api.MachineServiceContainer{
+			caddy("a", &container.State{Running: true}),
+		})
+
+		require.Len(t, status, 2)
+		assert.Equal(t, "a", status[0].Machine.Name)
+		assert.True(t, status[0].Designated)
+		assert.True(t, status[0].Serving())
+		assert.Equal(t, "b", status[1].Machine.Name)
+		assert.True(t, status[1].Designated, "all machines are designated if none has the ingress role")
+		assert.False(t, status[1].Serving())
+	})
+
+	t.Run("ingress role", func(t *testing.T) {
+		t.Parallel()
+
+		machines := api.MachineMembersList{
+			member("a", pb.MachineMember_UP, api.MachineRoleIngress),
+			member("b", pb.MachineMember_DOWN, api.MachineRoleIngress),
+			member("c", pb.MachineMember_UP, api.MachineRoleWorker),
+			member("d", pb.MachineMember_UP, api.MachineRoleIngress),
+		}
+		status := ingressStatus(machines, []api.MachineServiceContainer{
+			caddy("a", &container.State{Running: true}),
+			caddy("b", &container.State{Running: true}),
+			caddy("d", &container.State{
+				Running: true,
+				Health:  &container.Health{Status: container.Unhealthy},
+			}),
+		})
+
+		require.Len(t, status, 4)
+		assert.True(t, status[0].Serving())
+		assert.False(t, status[1].Serving(), "down machine must not be serving")
+		assert.False(t, status[2].Designated)
+		assert.True(t, status[3].CaddyRunning)
+		assert.False(t, status[3].CaddyHealthy)
+		assert.False(t, status[3].Serving(), "unhealthy Caddy must not be serving")
+
+		unreachable := false
+		status[0].Reachable = &unreachable
+		assert.False(t, status[0].Serving(), "unreachable machine must not be serving")
+	})
+}
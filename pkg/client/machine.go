@@ -26,17 +26,26 @@ func (cli *Client) InspectMachine(ctx context.Context, nameOrID string) (*pb.Mac
 			return m, nil
 		}
 	}
+	// The machine might have been added or renamed after the machines were cached.
+	if cli.machines != nil && !isFreshMachines(ctx) {
+		return cli.InspectMachine(WithFreshMachines(ctx), nameOrID)
+	}
 
 	return nil, api.ErrNotFound
 }
 
 // ListMachines returns a list of all machines registered in the cluster that match the filter.
+// The machines may be served from the cache if enabled with WithMachinesCache unless the filter selects only
+// available machines or the context is created with WithFreshMachines.
 func (cli *Client) ListMachines(ctx context.Context, filter *api.MachineFilter) (api.MachineMembersList, error) {
-	resp, err := cli.ClusterClient.ListMachines(ctx, &emptypb.Empty{})
+	// The machine states in the cache may be outdated, so available machines are always listed from the cluster.
+	if filter != nil && filter.Available {
+		ctx = WithFreshMachines(ctx)
+	}
+	machines, cached, err := cli.listMachines(ctx)
 	if err != nil {
 		return nil, err
 	}
-	machines := api.MachineMembersList(resp.Machines)
 
 	if filter == nil {
 		return machines, nil
@@ -57,6 +66,9 @@ func (cli *Client) ListMachines(ctx context.Context, filter *api.MachineFilter)
 		machines = matched
 
 		if len(notFound) > 0 {
+			if cached {
+				return cli.ListMachines(WithFreshMachines(ctx), filter)
+			}
 			return nil, fmt.Errorf("machines not found: %s", strings.Join(notFound, ", "))
 		}
 	}
@@ -74,6 +86,25 @@ func (cli *Client) ListMachines(ctx context.Context, filter *api.MachineFilter)
 	return machines, nil
 }
 
+// listMachines lists all machines in the cluster or returns the cached ones. cached is true if the machines were
+// served from the cache.
+func (cli *Client) listMachines(ctx context.Context) (machines api.MachineMembersList, cached bool, err error) {
+	if cli.machines != nil && !isFreshMachines(ctx) {
+		if machines, ok := cli.machines.get(); ok {
+			return machines, true, nil
+		}
+	}
+
+	resp, err := cli.ClusterClient.ListMachines(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, false, err
+	}
+	if cli.machines != nil {
+		cli.machines.set(resp)
+	}
+	return resp.Machines, false, nil
+}
+
 // UpdateMachine updates machine configuration in the cluster.
 func (cli *Client) UpdateMachine(ctx context.Context, req *pb.UpdateMachineRequest) (*pb.MachineInfo, error) {
 	resp, err := cli.ClusterClient.UpdateMachine(ctx, req)
@@ -103,6 +134,20 @@ func (cli *Client) RenameMachine(ctx context.Context, nameOrID, newName string)
 	return cli.UpdateMachine(ctx, req)
 }
 
+// SetMachineRoles replaces the roles of the machine with the given name or ID. An empty list removes all roles.
+func (cli *Client) SetMachineRoles(ctx context.Context, nameOrID string, roles []string) (*pb.MachineInfo, error) {
+	machine, err := cli.InspectMachine(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.UpdateMachineRequest{
+		MachineId: machine.Machine.Id,
+		Roles:     &pb.MachineRoles{Roles: roles},
+	}
+	return cli.UpdateMachine(ctx, req)
+}
+
 // WaitMachineReady waits for the machine API on the connected machine to respond.
 func (cli *Client) WaitMachineReady(ctx context.Context, timeout time.Duration) error {
 	boff := backoff.WithContext(backoff.NewExponentialBackOff(
@@ -132,7 +177,7 @@ func (cli *Client) WaitClusterReady(ctx context.Context, timeout time.Duration)
 	), ctx)
 
 	listMachines := func() error {
-		_, err := cli.ListMachines(ctx, nil)
+		_, err := cli.ListMachines(WithFreshMachines(ctx), nil)
 		if err != nil {
 			if s, ok := status.FromError(err); ok &&
 				// TODO: remove FailedPrecondition after releading 0.17.
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// machineMutatingMethods are the full names of RPC methods that change the cluster machines and invalidate
+// the machines cache.
+var machineMutatingMethods = map[string]struct{}{
+	pb.Cluster_AddMachine_FullMethodName:              {},
+	pb.Cluster_UpdateMachine_FullMethodName:           {},
+	pb.Cluster_RemoveMachine_FullMethodName:           {},
+	pb.Cluster_ReallocateMachineSubnet_FullMethodName: {},
+}
+
+// machinesCache caches the cluster machines returned by the ListMachines RPC for a short time so that resolving
+// machine names or IDs doesn't list all machines over the network on every call.
+type machinesCache struct {
+	ttl time.Duration
+	// path is the file the cached machines are persisted to so that they're shared between clients, for example,
+	// consecutive CLI invocations. The machines are cached only in memory if empty.
+	path string
+
+	mu        sync.Mutex
+	resp      *pb.ListMachinesResponse
+	fetchedAt time.Time
+}
+
+// get returns a copy of the cached machines if they were fetched less than ttl ago.
+func (c *machinesCache) get() ([]*pb.MachineMember, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.resp == nil || time.Since(c.fetchedAt) >= c.ttl {
+		c.resp = c.load()
+	}
+	if c.resp == nil {
+		return nil, false
+	}
+	return proto.Clone(c.resp).(*pb.ListMachinesResponse).Machines, true
+}
+
+// load reads the cached machines from the file if it was written less than ttl ago.
+func (c *machinesCache) load() *pb.ListMachinesResponse {
+	if c.path == "" {
+		return nil
+	}
+	info, err := os.Stat(c.path)
+	if err != nil || time.Since(info.ModTime()) >= c.ttl {
+		return nil
+	}
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil
+	}
+
+	resp := &pb.ListMachinesResponse{}
+	if err = protojson.Unmarshal(data, resp); err != nil {
+		slog.Debug("Failed to parse machines cache file.", "path", c.path, "err", err)
+		return nil
+	}
+	c.fetchedAt = info.ModTime()
+	return resp
+}
+
+func (c *machinesCache) set(resp *pb.ListMachinesResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resp = proto.Clone(resp).(*pb.ListMachinesResponse)
+	c.fetchedAt = time.Now()
+	if c.path == "" {
+		return
+	}
+	if err := c.save(); err != nil {
+		slog.Debug("Failed to write machines cache file.", "path", c.path, "err", err)
+	}
+}
+
+// save atomically writes the cached machines to the file so that concurrent clients never read a partial file.
+func (c *machinesCache) save() error {
+	data, err := protojson.Marshal(c.resp)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path)
+}
+
+// invalidate drops the cached machines. It's safe to call on a nil cache.
+func (c *machinesCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resp = nil
+	if c.path != "" {
+		if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+			slog.Debug("Failed to remove machines cache file.", "path", c.path, "err", err)
+		}
+	}
+}
+
+type freshMachinesKey struct{}
+
+// WithFreshMachines returns a context that makes ListMachines and InspectMachine bypass the machines cache and
+// list the machines from the cluster. Use it when the up-to-date machine state matters, for example, when waiting
+// for a machine to come back up.
+func WithFreshMachines(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshMachinesKey{}, true)
+}
+
+func isFreshMachines(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshMachinesKey{}).(bool)
+	return fresh
+}
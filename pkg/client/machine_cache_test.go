@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type listMachinesClient struct {
+	pb.ClusterClient
+	machines []*pb.MachineMember
+	calls    int
+}
+
+func (m *listMachinesClient) ListMachines(
+	context.Context, *emptypb.Empty, ...grpc.CallOption,
+) (*pb.ListMachinesResponse, error) {
+	m.calls++
+	return &pb.ListMachinesResponse{Machines: m.machines}, nil
+}
+
+func member(id, name string) *pb.MachineMember {
+	return &pb.MachineMember{Machine: &pb.MachineInfo{Id: id, Name: name}, State: pb.MachineMember_UP}
+}
+
+func TestListMachines_Cache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	mock := &listMachinesClient{machines: []*pb.MachineMember{member("id1", "machine-1")}}
+	cli := &Client{ClusterClient: mock, machines: &machinesCache{ttl: time.Minute}}
+
+	_, err := cli.ListMachines(ctx, nil)
+	require.NoError(t, err)
+	m, err := cli.InspectMachine(ctx, "machine-1")
+	require.NoError(t, err)
+	assert.Equal(t, "id1", m.Machine.Id)
+	assert.Equal(t, 1, mock.calls, "machines should be served from the cache")
+
+	_, err = cli.ListMachines(WithFreshMachines(ctx), nil)
+	require.NoError(t, err)
+	_, err = cli.ListMachines(ctx, &api.MachineFilter{Available: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, mock.calls, "fresh and available machines should bypass the cache")
+
+	// A machine added after the machines were cached is looked up in the cluster.
+	mock.machines = append(mock.machines, member("id2", "machine-2"))
+	machines, err := cli.ListMachines(ctx, &api.MachineFilter{NamesOrIDs: []string{"machine-2"}})
+	require.NoError(t, err)
+	require.Len(t, machines, 1)
+	assert.Equal(t, "id2", machines[0].Machine.Id)
+	assert.Equal(t, 4, mock.calls)
+
+	_, err = cli.InspectMachine(ctx, "unknown")
+	assert.ErrorIs(t, err, api.ErrNotFound)
+	assert.Equal(t, 5, mock.calls)
+
+	cli.machines.fetchedAt = time.Now().Add(-time.Minute)
+	_, err = cli.ListMachines(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 6, mock.calls, "expired machines should be listed again")
+}
+
+func TestMachinesCache_File(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "machines", "cluster.json")
+	resp := &pb.ListMachinesResponse{Machines: []*pb.MachineMember{member("id1", "machine-1")}}
+
+	(&machinesCache{ttl: time.Minute, path: path}).set(resp)
+
+	// Another client, for example, the next CLI invocation, reads the machines from the file.
+	other := &machinesCache{ttl: time.Minute, path: path}
+	machines, ok := other.get()
+	require.True(t, ok)
+	require.Len(t, machines, 1)
+	assert.Equal(t, "machine-1", machines[0].Machine.Name)
+
+	expired := &machinesCache{ttl: time.Nanosecond, path: path}
+	time.Sleep(time.Millisecond)
+	_, ok = expired.get()
+	assert.False(t, ok)
+
+	other.invalidate()
+	_, ok = other.get()
+	assert.False(t, ok)
+	_, ok = (&machinesCache{ttl: time.Minute, path: path}).get()
+	assert.False(t, ok, "invalidated cache file should be removed")
+}
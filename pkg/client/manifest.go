@@ -0,0 +1,235 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/internal/machine/constants"
+	"github.com/psviderski/uncloud/internal/machine/network"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// PlatformImage is a single-platform image present on a machine in the cluster.
+type PlatformImage struct {
+	Platform    v1.Platform
+	MachineID   string
+	MachineName string
+	// Digest is the digest of the image manifest.
+	Digest string
+	image  v1.Image
+}
+
+// ImagePlatforms finds the platform-specific variants of the image on the specified machines or all machines
+// if none are specified. It reads the images through the embedded registry (unregistry) on each machine so Docker
+// on the machines must use the containerd image store. If the same platform is present on several machines,
+// the one on a machine with the builder role is preferred, then the one on the machine with the lexicographically
+// smallest name.
+func (cli *Client) ImagePlatforms(ctx context.Context, image string, machines []string) ([]PlatformImage, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image '%s': %w", image, err)
+	}
+	named = reference.TagNameOnly(named)
+
+	machineImages, err := cli.ListImages(ctx, api.ImageFilter{Machines: machines, Name: image})
+	if err != nil {
+		return nil, fmt.Errorf("list images: %w", err)
+	}
+	var machineIDs []string
+	for _, mi := range machineImages {
+		if len(mi.Images) > 0 && mi.ContainerdStore {
+			machineIDs = append(machineIDs, mi.Metadata.MachineId)
+		}
+	}
+	if len(machineIDs) == 0 {
+		return nil, fmt.Errorf("image '%s' not found on any machine with the containerd image store", image)
+	}
+
+	members, err := cli.ListMachines(ctx, &api.MachineFilter{NamesOrIDs: machineIDs})
+	if err != nil {
+		return nil, fmt.Errorf("list machines: %w", err)
+	}
+	slices.SortFunc(members, func(a, b *pb.MachineMember) int {
+		return cmp.Compare(a.Machine.Name, b.Machine.Name)
+	})
+	// Prefer images on builder machines as they are expected to have the freshest builds.
+	members = members.WithRolePreferred(api.MachineRoleBuilder)
+
+	transport, err := cli.unregistryTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []PlatformImage
+	for _, m := range members {
+		subnet, _ := m.Machine.Network.Subnet.ToPrefix()
+		addr := net.JoinHostPort(network.MachineIP(subnet).String(), strconv.Itoa(constants.UnregistryPort))
+		images, err := unregistryPlatformImages(ctx, addr, named, transport)
+		if err != nil {
+			return nil, fmt.Errorf("read image '%s' on machine '%s': %w", image, m.Machine.Name, err)
+		}
+
+		for _, img := range images {
+			if slices.ContainsFunc(result, func(r PlatformImage) bool {
+				return r.Platform.Equals(img.Platform)
+			}) {
+				continue
+			}
+			img.MachineID = m.Machine.Id
+			img.MachineName = m.Machine.Name
+			result = append(result, img)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b PlatformImage) int {
+		return cmp.Compare(a.Platform.String(), b.Platform.String())
+	})
+	return result, nil
+}
+
+// PushImageManifest pushes the platform-specific variants of the image found on the cluster machines to the target
+// registry and creates a multi-platform manifest list (OCI image index) tagged as the target that references them.
+// It returns the digest of the pushed manifest list.
+func (cli *Client) PushImageManifest(ctx context.Context, target string, images []PlatformImage) (string, error) {
+	if len(images) == 0 {
+		return "", errors.New("no platform images to push")
+	}
+	targetRef, err := name.ParseReference(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid target image '%s': %w", target, err)
+	}
+
+	var index v1.ImageIndex = mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+	for _, img := range images {
+		p := img.Platform
+		index = mutate.AppendManifests(index, mutate.IndexAddendum{
+			Add:        img.image,
+			Descriptor: v1.Descriptor{Platform: &p},
+		})
+	}
+
+	pw := progress.ContextWriter(ctx)
+	eventID := fmt.Sprintf("Pushing manifest list %s", targetRef.Name())
+	pw.Event(progress.NewEvent(eventID, progress.Working, "Uploading"))
+
+	// WriteIndex uploads the platform images (their blobs are streamed from the machines) and then the index.
+	if err = remote.WriteIndex(targetRef, index,
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	); err != nil {
+		pw.Event(progress.ErrorMessageEvent(eventID, err.Error()))
+		return "", fmt.Errorf("push manifest list '%s': %w", target, err)
+	}
+
+	digest, err := index.Digest()
+	if err != nil {
+		return "", fmt.Errorf("get manifest list digest: %w", err)
+	}
+	pw.Event(progress.NewEvent(eventID, progress.Done, "Pushed"))
+
+	return digest.String(), nil
+}
+
+// unregistryTransport returns an HTTP transport that connects to the unregistry on the machines through
+// the cluster connection.
+func (cli *Client) unregistryTransport() (http.RoundTripper, error) {
+	dialer, err := cli.connector.Dialer()
+	if err != nil {
+		return nil, fmt.Errorf("get proxy dialer: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport, nil
+}
+
+// unregistryPlatformImages returns the single-platform images for the reference stored on the machine with
+// the unregistry listening on addr. Platforms of a multi-platform image whose content isn't available on the machine
+// are skipped, as well as attestation manifests.
+func unregistryPlatformImages(
+	ctx context.Context, addr string, named reference.Named, transport http.RoundTripper,
+) ([]PlatformImage, error) {
+	ref, err := name.ParseReference(addr+"/"+reference.FamiliarName(named)+":"+named.(reference.Tagged).Tag(),
+		name.Insecure)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := remote.Get(ref, remote.WithContext(ctx), remote.WithTransport(transport))
+	if err != nil {
+		return nil, err
+	}
+
+	if !desc.MediaType.IsIndex() {
+		img, err := desc.Image()
+		if err != nil {
+			return nil, err
+		}
+		pi, err := platformImage(img)
+		if err != nil {
+			return nil, err
+		}
+		return []PlatformImage{pi}, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var images []PlatformImage
+	for _, m := range manifest.Manifests {
+		if m.Platform == nil || m.Platform.OS == "unknown" || !m.MediaType.IsImage() {
+			continue
+		}
+		img, err := index.Image(m.Digest)
+		if err != nil {
+			continue
+		}
+		// The config blob is only available if the platform content has been pulled to the machine.
+		pi, err := platformImage(img)
+		if err != nil {
+			continue
+		}
+		images = append(images, pi)
+	}
+	return images, nil
+}
+
+func platformImage(img v1.Image) (PlatformImage, error) {
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return PlatformImage{}, fmt.Errorf("get image config: %w", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return PlatformImage{}, fmt.Errorf("get image digest: %w", err)
+	}
+
+	p := v1.Platform{OS: cfg.OS, Architecture: cfg.Architecture, Variant: cfg.Variant, OSVersion: cfg.OSVersion}
+	// Normalise the platform, e.g. 'arm64/v8' to 'arm64', to match the same platform built on different machines.
+	normalised := platforms.Normalize(platforms.Platform{OS: p.OS, Architecture: p.Architecture, Variant: p.Variant})
+	p.Architecture, p.Variant = normalised.Architecture, normalised.Variant
+
+	return PlatformImage{Platform: p, Digest: digest.String(), image: img}, nil
+}
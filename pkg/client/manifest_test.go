@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/distribution/reference"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPlatformImage(t *testing.T, os, arch, variant string) v1.Image {
+	t.Helper()
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	cfg, err := img.ConfigFile()
+	require.NoError(t, err)
+	cfg.OS, cfg.Architecture, cfg.Variant = os, arch, variant
+	img, err = mutate.ConfigFile(img, cfg)
+	require.NoError(t, err)
+	return img
+}
+
+func TestUnregistryPlatformImages(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	amd64 := testPlatformImage(t, "linux", "amd64", "")
+	arm64 := testPlatformImage(t, "linux", "arm64", "v8")
+	attestation := testPlatformImage(t, "unknown", "unknown", "")
+
+	// A single-platform image.
+	ref, err := name.ParseReference(addr+"/myapp:single", name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, arm64))
+
+	// A multi-platform image with an attestation manifest.
+	var index v1.ImageIndex = mutate.IndexMediaType(empty.Index, types.OCIImageIndex)
+	index = mutate.AppendManifests(index,
+		mutate.IndexAddendum{Add: amd64, Descriptor: v1.Descriptor{
+			Platform: &v1.Platform{OS: "linux", Architecture: "amd64"},
+		}},
+		mutate.IndexAddendum{Add: attestation, Descriptor: v1.Descriptor{
+			Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"},
+		}},
+	)
+	ref, err = name.ParseReference(addr+"/ghcr.io/org/myapp:multi", name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.WriteIndex(ref, index))
+
+	t.Run("single platform", func(t *testing.T) {
+		t.Parallel()
+
+		named, err := reference.ParseNormalizedNamed("myapp:single")
+		require.NoError(t, err)
+		images, err := unregistryPlatformImages(context.Background(), addr, named, http.DefaultTransport)
+		require.NoError(t, err)
+
+		require.Len(t, images, 1)
+		assert.Equal(t, "linux/arm64", images[0].Platform.String(), "platform should be normalised")
+		wantDigest, err := arm64.Digest()
+		require.NoError(t, err)
+		assert.Equal(t, wantDigest.String(), images[0].Digest)
+	})
+
+	t.Run("multi-platform skips attestations", func(t *testing.T) {
+		t.Parallel()
+
+		named, err := reference.ParseNormalizedNamed("ghcr.io/org/myapp:multi")
+		require.NoError(t, err)
+		images, err := unregistryPlatformImages(context.Background(), addr, named, http.DefaultTransport)
+		require.NoError(t, err)
+
+		require.Len(t, images, 1)
+		assert.Equal(t, "linux/amd64", images[0].Platform.String())
+	})
+}
+
+func TestPushImageManifest(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	addr := strings.TrimPrefix(srv.URL, "http://")
+
+	var images []PlatformImage
+	for _, arch := range []string{"amd64", "arm64"} {
+		pi, err := platformImage(testPlatformImage(t, "linux", arch, ""))
+		require.NoError(t, err)
+		images = append(images, pi)
+	}
+
+	cli := &Client{}
+	digest, err := cli.PushImageManifest(context.Background(), addr+"/myapp:1.0", images)
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(addr+"/myapp:1.0", name.Insecure)
+	require.NoError(t, err)
+	index, err := remote.Index(ref)
+	require.NoError(t, err)
+	indexDigest, err := index.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, digest, indexDigest.String())
+
+	manifest, err := index.IndexManifest()
+	require.NoError(t, err)
+	require.Len(t, manifest.Manifests, 2)
+	for i, m := range manifest.Manifests {
+		assert.Equal(t, images[i].Digest, m.Digest.String())
+		assert.Equal(t, images[i].Platform.String(), m.Platform.String())
+	}
+}
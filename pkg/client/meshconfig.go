@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetMeshConfig sets the configuration of the certificates issued for mTLS-enabled services. An empty configuration
+// removes it so that machines use the default windows.
+func (cli *Client) SetMeshConfig(ctx context.Context, config api.MeshConfig) error {
+	if config == (api.MeshConfig{}) {
+		_, err := cli.ClusterClient.SetMeshConfig(ctx, &pb.MeshConfig{})
+		return err
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal mesh config: %w", err)
+	}
+	_, err = cli.ClusterClient.SetMeshConfig(ctx, &pb.MeshConfig{Config: configJSON})
+	return err
+}
+
+// MeshConfig returns the configuration of the certificates issued for mTLS-enabled services. It returns an empty
+// configuration if it's not configured.
+func (cli *Client) MeshConfig(ctx context.Context) (api.MeshConfig, error) {
+	var config api.MeshConfig
+
+	resp, err := cli.ClusterClient.GetMeshConfig(ctx, &emptypb.Empty{})
+	if err != nil {
+		return config, err
+	}
+	if len(resp.Config) == 0 {
+		return config, nil
+	}
+
+	if err = json.Unmarshal(resp.Config, &config); err != nil {
+		return config, fmt.Errorf("unmarshal mesh config: %w", err)
+	}
+	return config, nil
+}
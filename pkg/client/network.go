@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// GetClusterNetwork returns the cluster network from which machine subnets are allocated.
+func (cli *Client) GetClusterNetwork(ctx context.Context) (netip.Prefix, error) {
+	resp, err := cli.ClusterClient.GetNetwork(ctx, nil)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return resp.Network.ToPrefix()
+}
+
+// SetClusterNetwork changes the cluster network. Machine subnets outside the new network must be reallocated
+// with ReallocateMachineSubnet and applied on the machines afterwards.
+func (cli *Client) SetClusterNetwork(ctx context.Context, network netip.Prefix) (netip.Prefix, error) {
+	resp, err := cli.ClusterClient.SetNetwork(ctx, &pb.ClusterNetwork{Network: pb.NewIPPrefix(network)})
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return resp.Network.ToPrefix()
+}
+
+// ReallocateMachineSubnet allocates a new subnet from the cluster network for the machine if its current subnet
+// is outside the network. It returns the updated machine info.
+func (cli *Client) ReallocateMachineSubnet(ctx context.Context, machineID string) (*pb.MachineInfo, error) {
+	resp, err := cli.ClusterClient.ReallocateMachineSubnet(ctx, &pb.ReallocateMachineSubnetRequest{
+		MachineId: machineID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Machine, nil
+}
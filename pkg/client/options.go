@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/psviderski/uncloud/internal/grpccompress"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// Option configures the client.
+type Option func(*options)
+
+type options struct {
+	connectTimeout time.Duration
+	rpcTimeout     time.Duration
+	retry          RetryPolicy
+	// callOptions are added to all RPCs, for example, to enable compression.
+	callOptions []grpc.CallOption
+	// machines caches the ListMachines results if not nil.
+	machines *machinesCache
+}
+
+// RetryPolicy configures retries of idempotent RPCs that failed because the machine was unavailable or didn't
+// respond in time. Only unary RPCs that read state, such as Inspect* and List*, are retried.
+// The gRPC connection retries all RPCs that fail before reaching the machine on its own, so this policy mostly
+// helps to ride out longer network outages, for example, on flaky WAN links between machines.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one. 1 or less disables retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. The delay doubles after each attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used when no other policy is configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     10 * time.Second,
+}
+
+func defaultOptions() options {
+	return options{retry: DefaultRetryPolicy}
+}
+
+// WithConnectTimeout sets the maximum time to establish the connection to the machine. If set, New waits until
+// the connection is ready instead of connecting lazily on the first RPC. 0 disables the timeout.
+func WithConnectTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.connectTimeout = d
+	}
+}
+
+// WithRPCTimeout sets the timeout for each attempt of unary RPCs whose context has no deadline. Streaming RPCs
+// such as logs or image pulls are not limited. 0 disables the timeout.
+func WithRPCTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.rpcTimeout = d
+	}
+}
+
+// WithRetryPolicy sets the retry policy for idempotent RPCs.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithCompression compresses the RPC requests and responses with the named compressor, one of grpccompress.Names.
+// It reduces the bandwidth for streaming logs and other large responses on slow links at the cost of CPU.
+// The machine must support the compressor. Machines running an older version respond with the Unimplemented error.
+func WithCompression(name string) Option {
+	return func(o *options) {
+		o.callOptions = append(o.callOptions, grpccompress.CallOptions(name)...)
+	}
+}
+
+// WithMachinesCache caches the machines listed by ListMachines and InspectMachine for ttl so that commands resolving
+// machine names don't list all machines on every call, which is slow on high-latency links. If path is not empty,
+// the machines are also cached in the file at path to share them between clients, for example, consecutive CLI
+// invocations. The cache is invalidated when the client changes the machines or receives a machines change event.
+// Machines that aren't found in the cache are looked up in the cluster. 0 ttl disables the cache.
+func WithMachinesCache(path string, ttl time.Duration) Option {
+	return func(o *options) {
+		o.machines = nil
+		if ttl > 0 {
+			o.machines = &machinesCache{ttl: ttl, path: path}
+		}
+	}
+}
+
+// idempotentMethodPrefixes are the prefixes of RPC method names that only read state and are safe to retry.
+var idempotentMethodPrefixes = []string{"Check", "Get", "Inspect", "List"}
+
+// isIdempotent returns true if the full RPC method name, e.g. "/api.Machine/InspectMachine", only reads state.
+func isIdempotent(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, p := range idempotentMethodPrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// rpcConn wraps a gRPC client connection to apply the call options to all RPCs and the RPC timeout and retry
+// policy to unary RPCs.
+type rpcConn struct {
+	grpc.ClientConnInterface
+	opts options
+}
+
+func (c *rpcConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	attempts := 1
+	if isIdempotent(method) {
+		attempts = max(1, c.opts.retry.MaxAttempts)
+	}
+
+	backoff := c.opts.retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := c.invoke(ctx, method, args, reply, slices.Concat(c.opts.callOptions, opts)...)
+		if _, ok := machineMutatingMethods[method]; ok {
+			// Invalidate even if the call failed as the change might have been applied before the error.
+			c.opts.machines.invalidate()
+		}
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !isRetryable(err) {
+			return err
+		}
+
+		slog.Debug("Retrying RPC.", "method", method, "attempt", attempt, "backoff", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff = min(backoff*2, max(c.opts.retry.MaxBackoff, c.opts.retry.InitialBackoff))
+	}
+}
+
+func (c *rpcConn) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return c.ClientConnInterface.NewStream(ctx, desc, method, slices.Concat(c.opts.callOptions, opts)...)
+}
+
+func (c *rpcConn) invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	// Respect the deadline set by the caller as some RPCs are expected to take longer than the default timeout.
+	if _, ok := ctx.Deadline(); !ok && c.opts.rpcTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.rpcTimeout)
+		defer cancel()
+	}
+	return c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	// The machine returns ResourceExhausted when it's busy and rejects the request before running it.
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitReady waits until the connection is ready or the context is done.
+func waitReady(ctx context.Context, conn *grpc.ClientConn) error {
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("connection is not ready (%s): %w", strings.ToLower(state.String()), ctx.Err())
+		}
+	}
+}
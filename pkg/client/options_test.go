@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeConn returns the errors in order for each Invoke call and records whether the call context had a deadline.
+type fakeConn struct {
+	grpc.ClientConnInterface
+	errs      []error
+	calls     int
+	deadlines []bool
+}
+
+func (c *fakeConn) Invoke(ctx context.Context, _ string, _, _ any, _ ...grpc.CallOption) error {
+	_, ok := ctx.Deadline()
+	c.deadlines = append(c.deadlines, ok)
+	c.calls++
+	if len(c.errs) == 0 {
+		return nil
+	}
+	err := c.errs[0]
+	c.errs = c.errs[1:]
+	return err
+}
+
+func TestIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"/api.Machine/InspectMachine":     true,
+		"/api.Cluster/ListMachines":       true,
+		"/api.Caddy/GetConfig":            true,
+		"/api.Machine/CheckPrerequisites": true,
+		"/api.Docker/CreateContainer":     false,
+		"/api.Cluster/RemoveMachine":      false,
+		"/api.Machine/Reset":              false,
+		"/api.Machine/Reboot":             false,
+	}
+	for method, want := range tests {
+		assert.Equal(t, want, isIdempotent(method), method)
+	}
+}
+
+func TestRPCConn_Invoke(t *testing.T) {
+	t.Parallel()
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	retry := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	tests := map[string]struct {
+		method    string
+		errs      []error
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		"success": {
+			method:    "/api.Machine/InspectMachine",
+			wantCalls: 1,
+			wantCode:  codes.OK,
+		},
+		"retry idempotent until success": {
+			method:    "/api.Machine/InspectMachine",
+			errs:      []error{unavailable, unavailable},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		"retry idempotent until max attempts": {
+			method:    "/api.Cluster/ListMachines",
+			errs:      []error{unavailable, unavailable, unavailable, unavailable},
+			wantCalls: 3,
+			wantCode:  codes.Unavailable,
+		},
+		"retry deadline exceeded": {
+			method:    "/api.Cluster/ListMachines",
+			errs:      []error{status.Error(codes.DeadlineExceeded, "timeout")},
+			wantCalls: 2,
+			wantCode:  codes.OK,
+		},
+		"retry server busy": {
+			method:    "/api.Cluster/ListMachines",
+			errs:      []error{status.Error(codes.ResourceExhausted, "server busy")},
+			wantCalls: 2,
+			wantCode:  codes.OK,
+		},
+		"don't retry non-idempotent": {
+			method:    "/api.Docker/CreateContainer",
+			errs:      []error{unavailable},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+		"don't retry non-retryable code": {
+			method:    "/api.Machine/InspectMachine",
+			errs:      []error{status.Error(codes.NotFound, "not found")},
+			wantCalls: 1,
+			wantCode:  codes.NotFound,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeConn{errs: tt.errs}
+			conn := &rpcConn{ClientConnInterface: fake, opts: options{retry: retry}}
+
+			err := conn.Invoke(context.Background(), tt.method, nil, nil)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCalls, fake.calls)
+		})
+	}
+}
+
+func TestRPCConn_InvokeTimeout(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeConn{}
+	conn := &rpcConn{ClientConnInterface: fake, opts: options{rpcTimeout: time.Minute}}
+	assert.NoError(t, conn.Invoke(context.Background(), "/api.Machine/InspectMachine", nil, nil))
+
+	// The deadline set by the caller is kept as is.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	assert.NoError(t, conn.Invoke(ctx, "/api.Machine/InspectMachine", nil, nil))
+
+	conn.opts.rpcTimeout = 0
+	assert.NoError(t, conn.Invoke(context.Background(), "/api.Machine/InspectMachine", nil, nil))
+
+	assert.Equal(t, []bool{true, true, false}, fake.deadlines)
+}
+
+func TestRPCConn_InvokeCancelled(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeConn{errs: []error{status.Error(codes.Unavailable, "connection refused")}}
+	conn := &rpcConn{ClientConnInterface: fake, opts: options{retry: RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Hour,
+	}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := conn.Invoke(ctx, "/api.Machine/InspectMachine", nil, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, fake.calls)
+}
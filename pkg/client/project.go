@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// UpdateProject updates the metadata of a Compose project and records the services deployed from it.
+func (cli *Client) UpdateProject(ctx context.Context, name string, update api.ProjectUpdate) (api.Project, error) {
+	resp, err := cli.ClusterClient.UpdateProject(ctx, &pb.UpdateProjectRequest{
+		Name:      name,
+		Owner:     update.Owner,
+		Protected: update.Protected,
+		Services:  update.Services,
+	})
+	if err != nil {
+		return api.Project{}, err
+	}
+	return projectFromProto(resp), nil
+}
+
+// ListProjects returns the metadata of all Compose projects deployed to the cluster.
+func (cli *Client) ListProjects(ctx context.Context) ([]api.Project, error) {
+	resp, err := cli.ClusterClient.ListProjects(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]api.Project, len(resp.Projects))
+	for i, p := range resp.Projects {
+		projects[i] = projectFromProto(p)
+	}
+	return projects, nil
+}
+
+func projectFromProto(p *pb.Project) api.Project {
+	return api.Project{
+		Name:      p.Name,
+		Owner:     p.Owner,
+		Protected: p.Protected,
+		Services:  p.Services,
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ReservePublished reserves the published ports and ingress hostnames for a Compose project so that other projects
+// can't claim them. It returns the project's reservations.
+func (cli *Client) ReservePublished(
+	ctx context.Context, project string, ports, hostnames []string, opts api.ReserveOptions,
+) ([]api.PublishedReservation, error) {
+	resp, err := cli.ClusterClient.ReservePublished(ctx, &pb.ReservePublishedRequest{
+		Project:       project,
+		Ports:         ports,
+		Hostnames:     hostnames,
+		DryRun:        opts.DryRun,
+		ReleaseOthers: opts.ReleaseOthers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return publishedReservationsFromProto(resp), nil
+}
+
+// ReleasePublished releases all published ports and ingress hostnames reserved by a Compose project. It returns
+// the released reservations.
+func (cli *Client) ReleasePublished(ctx context.Context, project string) ([]api.PublishedReservation, error) {
+	resp, err := cli.ClusterClient.ReleasePublished(ctx, &pb.ReleasePublishedRequest{Project: project})
+	if err != nil {
+		return nil, err
+	}
+	return publishedReservationsFromProto(resp), nil
+}
+
+// ListPublishedReservations returns the published ports and ingress hostnames reserved by all Compose projects.
+func (cli *Client) ListPublishedReservations(ctx context.Context) ([]api.PublishedReservation, error) {
+	resp, err := cli.ClusterClient.ListPublishedReservations(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return publishedReservationsFromProto(resp), nil
+}
+
+func publishedReservationsFromProto(resp *pb.PublishedReservations) []api.PublishedReservation {
+	reservations := make([]api.PublishedReservation, len(resp.Reservations))
+	for i, r := range resp.Reservations {
+		reservations[i] = api.PublishedReservation{
+			Project:  r.Project,
+			Port:     r.Port,
+			Hostname: r.Hostname,
+		}
+	}
+	return reservations
+}
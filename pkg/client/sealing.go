@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// SealSecret encrypts the value with the cluster public key so that only the cluster machines can decrypt it.
+// It returns the sealed value that can be used as the name of an external secret in a Compose file.
+func (cli *Client) SealSecret(ctx context.Context, value []byte) (string, error) {
+	key, err := cli.ClusterClient.GetSealingKey(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("get cluster sealing key: %w", err)
+	}
+	if len(key.PublicKey) != 32 {
+		return "", fmt.Errorf("invalid cluster sealing key length: %d", len(key.PublicKey))
+	}
+
+	publicKey := (*[32]byte)(key.PublicKey)
+	return api.SealSecret(value, key.Id, publicKey)
+}
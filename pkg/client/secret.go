@@ -0,0 +1,162 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"slices"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SecretUsage returns the service containers across the cluster that mount the secret with the given name
+// and how it's mounted in each of them.
+func (cli *Client) SecretUsage(ctx context.Context, name string) ([]api.SecretUsage, error) {
+	services, err := cli.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return secretUsage(services, name), nil
+}
+
+// secretUsage finds the mounts of the secret with the given name in the containers of the services.
+func secretUsage(services []api.Service, name string) []api.SecretUsage {
+	var usage []api.SecretUsage
+	for _, svc := range services {
+		for _, mc := range slices.Concat(svc.Containers, svc.HookContainers) {
+			spec := mc.Container.ServiceSpec
+			secret, ok := spec.Secret(name)
+			if !ok {
+				continue
+			}
+
+			for _, m := range spec.Container.SecretMounts {
+				if m.SecretName != name {
+					continue
+				}
+				usage = append(usage, api.SecretUsage{
+					ServiceID:   svc.ID,
+					ServiceName: svc.Name,
+					MachineID:   mc.MachineID,
+					MachineName: mc.MachineName,
+					ContainerID: mc.Container.ID,
+					Mount:       m,
+					Sealed:      secret.SealingKeyID != "",
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(usage, func(a, b api.SecretUsage) int {
+		return cmp.Or(
+			cmp.Compare(a.ServiceName, b.ServiceName),
+			cmp.Compare(a.MachineName, b.MachineName),
+			cmp.Compare(a.ContainerID, b.ContainerID),
+			cmp.Compare(a.Mount.Target(), b.Mount.Target()),
+		)
+	})
+	return usage
+}
+
+// SetSecretMaxMode sets the most permissive file mode allowed for secrets mounted into service containers.
+// Zero mode removes the limit.
+func (cli *Client) SetSecretMaxMode(ctx context.Context, mode os.FileMode) error {
+	_, err := cli.ClusterClient.SetSecretMaxMode(ctx, &pb.SecretMaxMode{Mode: uint32(mode)})
+	return err
+}
+
+// SecretMaxMode returns the most permissive file mode allowed for secrets mounted into service containers.
+// Zero means no limit.
+func (cli *Client) SecretMaxMode(ctx context.Context) (os.FileMode, error) {
+	resp, err := cli.ClusterClient.GetSecretMaxMode(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return os.FileMode(resp.Mode), nil
+}
+
+// CreateSecret stores a secret in the cluster encrypted with the cluster sealing key so that services can mount it
+// by name. If update is true, the value of an existing secret is replaced, otherwise it fails if the secret exists.
+func (cli *Client) CreateSecret(
+	ctx context.Context, name string, value []byte, update bool,
+) (api.ClusterSecret, error) {
+	resp, err := cli.ClusterClient.CreateSecret(ctx, &pb.CreateSecretRequest{
+		Name:   name,
+		Value:  value,
+		Update: update,
+	})
+	if err != nil {
+		return api.ClusterSecret{}, err
+	}
+	return clusterSecretFromProto(resp), nil
+}
+
+// ListSecrets returns the secrets stored in the cluster sorted by name. Their values are never returned.
+func (cli *Client) ListSecrets(ctx context.Context) ([]api.ClusterSecret, error) {
+	resp, err := cli.ClusterClient.ListSecrets(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]api.ClusterSecret, 0, len(resp.Secrets))
+	for _, s := range resp.Secrets {
+		secrets = append(secrets, clusterSecretFromProto(s))
+	}
+	return secrets, nil
+}
+
+// InspectSecret returns the secret stored in the cluster with the given name or api.ErrNotFound if it doesn't exist.
+func (cli *Client) InspectSecret(ctx context.Context, name string) (api.ClusterSecret, error) {
+	resp, err := cli.ClusterClient.InspectSecret(ctx, &pb.InspectSecretRequest{Name: name})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return api.ClusterSecret{}, api.ErrNotFound
+		}
+		return api.ClusterSecret{}, err
+	}
+	return clusterSecretFromProto(resp), nil
+}
+
+// RemoveSecret removes the secret stored in the cluster. It returns api.ErrNotFound if the secret doesn't exist.
+func (cli *Client) RemoveSecret(ctx context.Context, name string) error {
+	_, err := cli.ClusterClient.RemoveSecret(ctx, &pb.RemoveSecretRequest{Name: name})
+	if status.Code(err) == codes.NotFound {
+		return api.ErrNotFound
+	}
+	return err
+}
+
+// ClusterSecretUsage returns the names of the services whose specs reference the cluster secret with the given name.
+func (cli *Client) ClusterSecretUsage(ctx context.Context, name string) ([]string, error) {
+	services, err := cli.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, svc := range services {
+		for _, mc := range slices.Concat(svc.Containers, svc.HookContainers) {
+			if slices.ContainsFunc(mc.Container.ServiceSpec.Secrets, func(s api.SecretSpec) bool {
+				return s.ClusterSecret == name
+			}) {
+				names = append(names, svc.Name)
+				break
+			}
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func clusterSecretFromProto(s *pb.ClusterSecret) api.ClusterSecret {
+	return api.ClusterSecret{
+		Name:      s.Name,
+		Version:   s.Version,
+		CreatedAt: s.CreatedAt.AsTime(),
+		UpdatedAt: s.UpdatedAt.AsTime(),
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/psviderski/uncloud/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretUsage(t *testing.T) {
+	t.Parallel()
+
+	mode := os.FileMode(0o400)
+	newContainer := func(id string, spec api.ServiceSpec) api.ServiceContainer {
+		return api.ServiceContainer{
+			Container: api.Container{InspectResponse: container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{ID: id},
+			}},
+			ServiceSpec: spec,
+		}
+	}
+	dbSpec := api.ServiceSpec{
+		Name:    "db",
+		Secrets: []api.SecretSpec{{Name: "db_password", SealingKeyID: "0123456789abcdef"}},
+		Container: api.ContainerSpec{
+			SecretMounts: []api.SecretMount{
+				{SecretName: "db_password", ContainerPath: "/etc/db/password", Mode: &mode},
+			},
+		},
+	}
+	webSpec := api.ServiceSpec{
+		Name:    "web",
+		Secrets: []api.SecretSpec{{Name: "db_password"}, {Name: "api_key"}},
+		Container: api.ContainerSpec{
+			SecretMounts: []api.SecretMount{
+				{SecretName: "api_key"},
+				{SecretName: "db_password"},
+			},
+		},
+	}
+	cacheSpec := api.ServiceSpec{Name: "cache"}
+	services := []api.Service{
+		{
+			ID:   "web-id",
+			Name: "web",
+			Containers: []api.MachineServiceContainer{
+				{MachineID: "m2", MachineName: "machine-2", Container: newContainer("c3", webSpec)},
+				{MachineID: "m1", MachineName: "machine-1", Container: newContainer("c2", webSpec)},
+			},
+		},
+		{
+			ID:   "db-id",
+			Name: "db",
+			Containers: []api.MachineServiceContainer{
+				{MachineID: "m1", MachineName: "machine-1", Container: newContainer("c1", dbSpec)},
+			},
+		},
+		{
+			ID:   "cache-id",
+			Name: "cache",
+			Containers: []api.MachineServiceContainer{
+				{MachineID: "m1", MachineName: "machine-1", Container: newContainer("c4", cacheSpec)},
+			},
+		},
+	}
+
+	usage := secretUsage(services, "db_password")
+	assert.Equal(t, []api.SecretUsage{
+		{
+			ServiceID:   "db-id",
+			ServiceName: "db",
+			MachineID:   "m1",
+			MachineName: "machine-1",
+			ContainerID: "c1",
+			Mount:       api.SecretMount{SecretName: "db_password", ContainerPath: "/etc/db/password", Mode: &mode},
+			Sealed:      true,
+		},
+		{
+			ServiceID:   "web-id",
+			ServiceName: "web",
+			MachineID:   "m1",
+			MachineName: "machine-1",
+			ContainerID: "c2",
+			Mount:       api.SecretMount{SecretName: "db_password"},
+		},
+		{
+			ServiceID:   "web-id",
+			ServiceName: "web",
+			MachineID:   "m2",
+			MachineName: "machine-2",
+			ContainerID: "c3",
+			Mount:       api.SecretMount{SecretName: "db_password"},
+		},
+	}, usage)
+
+	assert.Empty(t, secretUsage(services, "unknown"))
+}
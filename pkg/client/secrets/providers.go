@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// VaultProvider resolves 'vault://PATH#FIELD' references to a field of a secret in HashiCorp Vault using
+// 'vault kv get'. It works with both KV v1 and v2 secrets engines. The Vault address and token are taken from
+// the vault CLI configuration, for example, VAULT_ADDR and VAULT_TOKEN environment variables.
+type VaultProvider struct {
+	run runFunc
+}
+
+func (p *VaultProvider) Resolve(ctx context.Context, ref Reference) ([]byte, error) {
+	if ref.Key == "" {
+		return nil, errors.New("field is required, use vault://PATH#FIELD")
+	}
+	return run(ctx, p.run, "vault", "kv", "get", "-field="+ref.Key, ref.Path)
+}
+
+// SOPSProvider resolves 'sops://FILE[#KEY]' references to a decrypted SOPS file or a single value from it using
+// 'sops --decrypt'. KEY is a dot-separated path to a value in a structured file, for example, 'db.password'.
+// Relative file paths are resolved relative to WorkingDir.
+type SOPSProvider struct {
+	WorkingDir string
+	run        runFunc
+}
+
+func (p *SOPSProvider) Resolve(ctx context.Context, ref Reference) ([]byte, error) {
+	path := ref.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.WorkingDir, path)
+	}
+
+	args := []string{"--decrypt"}
+	if ref.Key != "" {
+		args = append(args, "--extract", sopsExtractPath(ref.Key))
+	}
+	args = append(args, path)
+
+	return run(ctx, p.run, "sops", args...)
+}
+
+// sopsExtractPath converts a dot-separated key path like 'db.password' to the SOPS extract format
+// '["db"]["password"]'.
+func sopsExtractPath(key string) string {
+	var b strings.Builder
+	for _, k := range strings.Split(key, ".") {
+		b.WriteString("[" + strconv.Quote(k) + "]")
+	}
+	return b.String()
+}
+
+// AWSSecretsManagerProvider resolves 'aws-sm://NAME[#KEY]' references to a secret in AWS Secrets Manager using
+// 'aws secretsmanager get-secret-value'. NAME is the secret name or ARN. If KEY is specified, the secret value
+// must be a JSON object and the value of the key is returned. The AWS credentials and region are taken from
+// the aws CLI configuration.
+type AWSSecretsManagerProvider struct {
+	run runFunc
+}
+
+func (p *AWSSecretsManagerProvider) Resolve(ctx context.Context, ref Reference) ([]byte, error) {
+	out, err := run(ctx, p.run, "aws", "secretsmanager", "get-secret-value",
+		"--secret-id", ref.Path, "--query", "SecretString", "--output", "text")
+	if err != nil {
+		return nil, err
+	}
+	// The text output ends with a newline that isn't part of the secret.
+	value := bytes.TrimSuffix(out, []byte("\n"))
+	if ref.Key == "" {
+		return value, nil
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(value, &fields); err != nil {
+		return nil, fmt.Errorf("secret value is not a JSON object to select key '%s' from: %w", ref.Key, err)
+	}
+	v, ok := fields[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("key '%s' not found in secret", ref.Key)
+	}
+	if s, ok := v.(string); ok {
+		return []byte(s), nil
+	}
+	return json.Marshal(v)
+}
+
+func run(ctx context.Context, fn runFunc, name string, args ...string) ([]byte, error) {
+	if fn == nil {
+		fn = runCommand
+	}
+	return fn(ctx, name, args...)
+}
@@ -0,0 +1,128 @@
+// Package secrets resolves secret references to external secret managers such as HashiCorp Vault, SOPS encrypted
+// files, and AWS Secrets Manager. The references are resolved client-side at deploy time using the CLI tools and
+// credentials available on the local machine.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+const (
+	SchemeVault                = "vault"
+	SchemeSOPS                 = "sops"
+	SchemeAWSSecretsManager    = "aws-sm"
+	schemeSeparator            = "://"
+	referenceFragmentSeparator = "#"
+)
+
+// Reference is a parsed reference to a secret in an external provider in the format SCHEME://PATH[#KEY].
+type Reference struct {
+	// Scheme identifies the provider, for example, 'vault'.
+	Scheme string
+	// Path identifies the secret within the provider.
+	Path string
+	// Key selects a single value from a secret that contains multiple key-value pairs. It's optional.
+	Key string
+}
+
+func (r Reference) String() string {
+	s := r.Scheme + schemeSeparator + r.Path
+	if r.Key != "" {
+		s += referenceFragmentSeparator + r.Key
+	}
+	return s
+}
+
+// ParseReference parses a secret reference in the format SCHEME://PATH[#KEY]. It returns false if the string
+// is not a reference.
+func ParseReference(s string) (Reference, bool, error) {
+	scheme, rest, ok := strings.Cut(s, schemeSeparator)
+	if !ok || scheme == "" {
+		return Reference{}, false, nil
+	}
+
+	ref := Reference{Scheme: scheme}
+	ref.Path, ref.Key, _ = strings.Cut(rest, referenceFragmentSeparator)
+	if ref.Path == "" {
+		return ref, true, fmt.Errorf("invalid secret reference '%s': path is required", s)
+	}
+	return ref, true, nil
+}
+
+// Provider resolves secret references to their values in an external secret manager.
+type Provider interface {
+	Resolve(ctx context.Context, ref Reference) ([]byte, error)
+}
+
+// Resolver resolves secret references using the provider registered for the reference scheme.
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver creates a resolver with the given providers by scheme.
+func NewResolver(providers map[string]Provider) *Resolver {
+	return &Resolver{providers: providers}
+}
+
+// NewDefaultResolver creates a resolver with the built-in Vault, SOPS, and AWS Secrets Manager providers.
+// Relative paths to SOPS encrypted files are resolved relative to workingDir.
+func NewDefaultResolver(workingDir string) *Resolver {
+	return NewResolver(map[string]Provider{
+		SchemeVault:             &VaultProvider{},
+		SchemeSOPS:              &SOPSProvider{WorkingDir: workingDir},
+		SchemeAWSSecretsManager: &AWSSecretsManagerProvider{},
+	})
+}
+
+// Register adds or replaces the provider for the scheme.
+func (r *Resolver) Register(scheme string, p Provider) {
+	if r.providers == nil {
+		r.providers = make(map[string]Provider)
+	}
+	r.providers[scheme] = p
+}
+
+// Resolve returns the value of the secret the reference points to.
+func (r *Resolver) Resolve(ctx context.Context, ref Reference) ([]byte, error) {
+	p, ok := r.providers[ref.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("unsupported secret provider '%s', supported providers: %s",
+			ref.Scheme, strings.Join(slices.Sorted(maps.Keys(r.providers)), ", "))
+	}
+
+	value, err := p.Resolve(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("resolve secret '%s': %w", ref, err)
+	}
+	return value, nil
+}
+
+// runFunc runs a command and returns its standard output.
+type runFunc func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// runCommand runs a command and returns its standard output. The error includes the standard error output
+// if the command fails.
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("'%s' command not found, install it to resolve the secret", name)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
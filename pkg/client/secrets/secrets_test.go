@@ -0,0 +1,172 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    Reference
+		isRef   bool
+		wantErr string
+	}{
+		{in: "db_password"},
+		{in: "/path/to/file"},
+		{
+			in:    "vault://secret/app#password",
+			want:  Reference{Scheme: "vault", Path: "secret/app", Key: "password"},
+			isRef: true,
+		},
+		{
+			in:    "sops://./secrets.enc.yaml#db.password",
+			want:  Reference{Scheme: "sops", Path: "./secrets.enc.yaml", Key: "db.password"},
+			isRef: true,
+		},
+		{
+			in:    "aws-sm://prod/app",
+			want:  Reference{Scheme: "aws-sm", Path: "prod/app"},
+			isRef: true,
+		},
+		{in: "vault://#password", isRef: true, wantErr: "path is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			ref, isRef, err := ParseReference(tt.in)
+			assert.Equal(t, tt.isRef, isRef)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ref)
+			if isRef {
+				assert.Equal(t, tt.in, ref.String())
+			}
+		})
+	}
+}
+
+// fakeRun returns a runFunc that records the command and returns the given output.
+func fakeRun(cmd *string, out string) runFunc {
+	return func(_ context.Context, name string, args ...string) ([]byte, error) {
+		*cmd = strings.Join(append([]string{name}, args...), " ")
+		return []byte(out), nil
+	}
+}
+
+func TestProviders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider func(run runFunc) Provider
+		ref      string
+		out      string
+		wantCmd  string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "vault field",
+			provider: func(run runFunc) Provider { return &VaultProvider{run: run} },
+			ref:      "vault://secret/app#password",
+			out:      "s3cret",
+			wantCmd:  "vault kv get -field=password secret/app",
+			want:     "s3cret",
+		},
+		{
+			name:     "vault without field",
+			provider: func(run runFunc) Provider { return &VaultProvider{run: run} },
+			ref:      "vault://secret/app",
+			wantErr:  "field is required",
+		},
+		{
+			name:     "sops file",
+			provider: func(run runFunc) Provider { return &SOPSProvider{WorkingDir: "/project", run: run} },
+			ref:      "sops://secrets/app.env",
+			out:      "A=1\n",
+			wantCmd:  "sops --decrypt /project/secrets/app.env",
+			want:     "A=1\n",
+		},
+		{
+			name:     "sops key",
+			provider: func(run runFunc) Provider { return &SOPSProvider{WorkingDir: "/project", run: run} },
+			ref:      "sops:///etc/app.enc.yaml#db.password",
+			out:      "s3cret",
+			wantCmd:  `sops --decrypt --extract ["db"]["password"] /etc/app.enc.yaml`,
+			want:     "s3cret",
+		},
+		{
+			name:     "aws secret string",
+			provider: func(run runFunc) Provider { return &AWSSecretsManagerProvider{run: run} },
+			ref:      "aws-sm://prod/app",
+			out:      "s3cret\n",
+			wantCmd: "aws secretsmanager get-secret-value --secret-id prod/app " +
+				"--query SecretString --output text",
+			want: "s3cret",
+		},
+		{
+			name:     "aws json key",
+			provider: func(run runFunc) Provider { return &AWSSecretsManagerProvider{run: run} },
+			ref:      "aws-sm://prod/app#password",
+			out:      `{"username":"app","password":"s3cret","port":5432}` + "\n",
+			want:     "s3cret",
+		},
+		{
+			name:     "aws json non-string key",
+			provider: func(run runFunc) Provider { return &AWSSecretsManagerProvider{run: run} },
+			ref:      "aws-sm://prod/app#port",
+			out:      `{"port":5432}`,
+			want:     "5432",
+		},
+		{
+			name:     "aws missing key",
+			provider: func(run runFunc) Provider { return &AWSSecretsManagerProvider{run: run} },
+			ref:      "aws-sm://prod/app#token",
+			out:      `{"port":5432}`,
+			wantErr:  "key 'token' not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ref, _, err := ParseReference(tt.ref)
+			require.NoError(t, err)
+
+			var cmd string
+			value, err := tt.provider(fakeRun(&cmd, tt.out)).Resolve(context.Background(), ref)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(value))
+			if tt.wantCmd != "" {
+				assert.Equal(t, tt.wantCmd, cmd)
+			}
+		})
+	}
+}
+
+func TestResolver_UnsupportedProvider(t *testing.T) {
+	t.Parallel()
+
+	ref, _, err := ParseReference("gcp-sm://app")
+	require.NoError(t, err)
+
+	_, err = NewDefaultResolver("").Resolve(context.Background(), ref)
+	assert.ErrorContains(t, err, "unsupported secret provider 'gcp-sm', supported providers: aws-sm, sops, vault")
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// AllowSysctls adds patterns to the cluster sysctl allow-list. It returns the updated allow-list.
+func (cli *Client) AllowSysctls(ctx context.Context, patterns ...string) ([]string, error) {
+	resp, err := cli.ClusterClient.AllowSysctls(ctx, &pb.SysctlsRequest{Patterns: patterns})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Patterns, nil
+}
+
+// DisallowSysctls removes patterns from the cluster sysctl allow-list. It returns the updated allow-list.
+func (cli *Client) DisallowSysctls(ctx context.Context, patterns ...string) ([]string, error) {
+	resp, err := cli.ClusterClient.DisallowSysctls(ctx, &pb.SysctlsRequest{Patterns: patterns})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Patterns, nil
+}
+
+// ListAllowedSysctls returns the cluster sysctl allow-list. An empty list means all sysctls are allowed.
+func (cli *Client) ListAllowedSysctls(ctx context.Context) ([]string, error) {
+	resp, err := cli.ClusterClient.ListAllowedSysctls(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Patterns, nil
+}
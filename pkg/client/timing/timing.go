@@ -0,0 +1,117 @@
+// Package timing records how long client operations take on each machine to report where time was spent, for
+// example, during a deployment. Recording is enabled by attaching a Recorder to the context. Timings are only kept
+// in memory and never sent anywhere.
+package timing
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Phases of client operations.
+const (
+	PhaseConnect    = "connect"
+	PhasePlan       = "plan"
+	PhasePull       = "pull image"
+	PhasePush       = "push image"
+	PhaseCreate     = "create container"
+	PhaseStart      = "start container"
+	PhaseHealthWait = "wait healthy"
+	PhaseDeploy     = "deploy"
+)
+
+// Span is a recorded duration of a phase on a machine. Machine is empty for cluster-wide phases.
+type Span struct {
+	Machine  string
+	Phase    string
+	Duration time.Duration
+}
+
+// Summary is the aggregated duration of all spans of a phase on a machine.
+type Summary struct {
+	Machine string
+	Phase   string
+	Count   int
+	Total   time.Duration
+	Max     time.Duration
+}
+
+// Recorder collects spans from concurrent operations.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record adds a span with the given duration.
+func (r *Recorder) Record(machine, phase string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, Span{Machine: machine, Phase: phase, Duration: d})
+}
+
+// Spans returns a copy of the recorded spans in the order they finished.
+func (r *Recorder) Spans() []Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.spans)
+}
+
+// Summaries returns the spans aggregated by machine and phase. Cluster-wide phases come first, then machines sorted
+// by name. Phases of each machine are in the order they first finished.
+func (r *Recorder) Summaries() []Summary {
+	spans := r.Spans()
+
+	var summaries []Summary
+	index := make(map[[2]string]int)
+	for _, s := range spans {
+		key := [2]string{s.Machine, s.Phase}
+		i, ok := index[key]
+		if !ok {
+			i = len(summaries)
+			index[key] = i
+			summaries = append(summaries, Summary{Machine: s.Machine, Phase: s.Phase})
+		}
+		summaries[i].Count++
+		summaries[i].Total += s.Duration
+		summaries[i].Max = max(summaries[i].Max, s.Duration)
+	}
+
+	slices.SortStableFunc(summaries, func(a, b Summary) int {
+		return cmp.Compare(a.Machine, b.Machine)
+	})
+	return summaries
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context that records the timings of operations to the recorder.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// RecorderFromContext returns the recorder attached to the context or nil if timings are not recorded.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Start starts timing a phase on the machine and returns a function that records the span when called.
+// It does nothing if the context has no recorder.
+func Start(ctx context.Context, machine, phase string) func() {
+	r := RecorderFromContext(ctx)
+	if r == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		r.Record(machine, phase, time.Since(start))
+	}
+}
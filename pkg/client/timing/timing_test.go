@@ -0,0 +1,46 @@
+package timing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder_Summaries(t *testing.T) {
+	t.Parallel()
+
+	r := NewRecorder()
+	r.Record("machine-2", PhasePull, 3*time.Second)
+	r.Record("", PhaseConnect, time.Second)
+	r.Record("machine-1", PhaseCreate, 2*time.Second)
+	r.Record("machine-2", PhaseCreate, time.Second)
+	r.Record("machine-2", PhasePull, 5*time.Second)
+
+	assert.Equal(t, []Summary{
+		{Machine: "", Phase: PhaseConnect, Count: 1, Total: time.Second, Max: time.Second},
+		{Machine: "machine-1", Phase: PhaseCreate, Count: 1, Total: 2 * time.Second, Max: 2 * time.Second},
+		{Machine: "machine-2", Phase: PhasePull, Count: 2, Total: 8 * time.Second, Max: 5 * time.Second},
+		{Machine: "machine-2", Phase: PhaseCreate, Count: 1, Total: time.Second, Max: time.Second},
+	}, r.Summaries())
+}
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+
+	// No recorder in the context.
+	Start(context.Background(), "machine-1", PhasePull)()
+
+	r := NewRecorder()
+	ctx := WithRecorder(context.Background(), r)
+	assert.Same(t, r, RecorderFromContext(ctx))
+
+	stop := Start(ctx, "machine-1", PhasePull)
+	stop()
+	spans := r.Spans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "machine-1", spans[0].Machine)
+		assert.Equal(t, PhasePull, spans[0].Phase)
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+)
+
+// SetDefaultUlimits adds or replaces cluster-wide default ulimits applied to every service container created
+// afterwards. It returns the updated defaults.
+func (cli *Client) SetDefaultUlimits(ctx context.Context, ulimits map[string]api.Ulimit) (map[string]api.Ulimit, error) {
+	req := &pb.DefaultUlimits{Ulimits: make(map[string]*pb.Ulimit, len(ulimits))}
+	for name, u := range ulimits {
+		req.Ulimits[name] = &pb.Ulimit{Soft: u.Soft, Hard: u.Hard}
+	}
+	resp, err := cli.ClusterClient.SetDefaultUlimits(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return ulimitsFromProto(resp), nil
+}
+
+// RemoveDefaultUlimits removes cluster-wide default ulimits by name. It returns the updated defaults.
+func (cli *Client) RemoveDefaultUlimits(ctx context.Context, names ...string) (map[string]api.Ulimit, error) {
+	resp, err := cli.ClusterClient.RemoveDefaultUlimits(ctx, &pb.RemoveDefaultUlimitsRequest{Names: names})
+	if err != nil {
+		return nil, err
+	}
+	return ulimitsFromProto(resp), nil
+}
+
+// ListDefaultUlimits returns the cluster-wide default ulimits.
+func (cli *Client) ListDefaultUlimits(ctx context.Context) (map[string]api.Ulimit, error) {
+	resp, err := cli.ClusterClient.ListDefaultUlimits(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ulimitsFromProto(resp), nil
+}
+
+func ulimitsFromProto(resp *pb.DefaultUlimits) map[string]api.Ulimit {
+	ulimits := make(map[string]api.Ulimit, len(resp.Ulimits))
+	for name, u := range resp.Ulimits {
+		ulimits[name] = api.Ulimit{Soft: u.Soft, Hard: u.Hard}
+	}
+	return ulimits
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"context"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+)
+
+// SetUsernsRemap enables or disables user namespace remapping for the cluster.
+func (cli *Client) SetUsernsRemap(ctx context.Context, enabled bool) error {
+	_, err := cli.ClusterClient.SetUsernsRemap(ctx, &pb.UsernsRemap{Enabled: enabled})
+	return err
+}
+
+// UsernsRemap returns whether user namespace remapping is enabled for the cluster.
+func (cli *Client) UsernsRemap(ctx context.Context) (bool, error) {
+	resp, err := cli.ClusterClient.GetUsernsRemap(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Enabled, nil
+}
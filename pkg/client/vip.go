@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/psviderski/uncloud/internal/machine/api/pb"
+	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetIngressVIP sets the virtual IP address that floats among ingress machines.
+func (cli *Client) SetIngressVIP(ctx context.Context, vip api.IngressVIP) error {
+	if err := vip.Validate(); err != nil {
+		return err
+	}
+	config, err := json.Marshal(vip)
+	if err != nil {
+		return fmt.Errorf("marshal ingress VIP: %w", err)
+	}
+	_, err = cli.ClusterClient.SetIngressVIP(ctx, &pb.IngressVIP{Config: config})
+	return err
+}
+
+// RemoveIngressVIP removes the ingress virtual IP. The holder machine releases the address.
+func (cli *Client) RemoveIngressVIP(ctx context.Context) error {
+	_, err := cli.ClusterClient.SetIngressVIP(ctx, &pb.IngressVIP{})
+	return err
+}
+
+// IngressVIP returns the ingress virtual IP configuration and the machine that holds the address. The configuration
+// is nil if the virtual IP is not configured. The status is nil if no machine has taken the address yet.
+func (cli *Client) IngressVIP(ctx context.Context) (*api.IngressVIP, *api.IngressVIPStatus, error) {
+	resp, err := cli.ClusterClient.GetIngressVIP(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Config) == 0 {
+		return nil, nil, nil
+	}
+
+	var vip api.IngressVIP
+	if err = json.Unmarshal(resp.Config, &vip); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal ingress VIP: %w", err)
+	}
+	if len(resp.Status) == 0 {
+		return &vip, nil, nil
+	}
+	var status api.IngressVIPStatus
+	if err = json.Unmarshal(resp.Status, &status); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal ingress VIP status: %w", err)
+	}
+	return &vip, &status, nil
+}
@@ -6,10 +6,12 @@ import (
 
 	"github.com/containerd/errdefs"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/volume"
 	cliprogress "github.com/psviderski/uncloud/internal/cli/progress"
 	"github.com/psviderski/uncloud/internal/cli/tui"
 	"github.com/psviderski/uncloud/pkg/api"
+	"google.golang.org/grpc/codes"
 )
 
 // CreateVolume creates a new volume on the specified machine.
@@ -52,12 +54,17 @@ func (cli *Client) CreateVolume(
 func (cli *Client) ListVolumes(ctx context.Context, filter *api.VolumeFilter) ([]api.MachineVolume, error) {
 	// Broadcast the volume list request to the specified machines in the filter or all machines if filter is nil.
 	var proxyMachines []string
+	var opts volume.ListOptions
 	if filter != nil {
 		proxyMachines = filter.Machines
+		// Only Docker knows which volumes are referenced by containers so the dangling filter is applied by Docker.
+		if filter.Dangling {
+			opts.Filters = filters.NewArgs(filters.Arg("dangling", "true"))
+		}
 	}
 
 	listCtx := cli.ProxyMachinesContext(ctx, proxyMachines)
-	machineVolumes, err := cli.Docker.ListVolumes(listCtx, volume.ListOptions{})
+	machineVolumes, err := cli.Docker.ListVolumes(listCtx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -123,3 +130,110 @@ func (cli *Client) RemoveVolume(ctx context.Context, machineNameOrID, volumeName
 
 	return nil
 }
+
+// CreateVolumeSnapshot creates an instant snapshot of a local volume on the specified machine. The volume must be
+// stored on a btrfs or ZFS filesystem.
+func (cli *Client) CreateVolumeSnapshot(
+	ctx context.Context, machineNameOrID, volumeName string,
+) (api.MachineVolumeSnapshot, error) {
+	machine, err := cli.InspectMachine(ctx, machineNameOrID)
+	if err != nil {
+		return api.MachineVolumeSnapshot{}, fmt.Errorf("inspect machine '%s': %w", machineNameOrID, err)
+	}
+	// Proxy Docker gRPC requests to the selected machine.
+	ctx = cli.ProxySingleMachineContext(ctx, machine.Machine.Id)
+
+	pw := progress.ContextWriter(ctx)
+	eventID := cliprogress.VolumeEventID(volumeName, machine.Machine.Name)
+	pw.Event(progress.NewEvent(eventID, progress.Working, "Snapshotting"))
+
+	snapshot, err := cli.Docker.CreateVolumeSnapshot(ctx, volumeName)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return api.MachineVolumeSnapshot{}, api.ErrNotFound
+		}
+		return api.MachineVolumeSnapshot{}, err
+	}
+	pw.Event(progress.NewEvent(eventID, progress.Done, "Snapshotted "+snapshot.ID))
+
+	return api.MachineVolumeSnapshot{
+		MachineID:   machine.Machine.Id,
+		MachineName: machine.Machine.Name,
+		Snapshot:    snapshot,
+	}, nil
+}
+
+// ListVolumeSnapshots returns the snapshots of the volume or all volumes if the name is empty on the specified
+// machines or all machines if none are specified.
+func (cli *Client) ListVolumeSnapshots(
+	ctx context.Context, volumeName string, machines []string,
+) ([]api.MachineVolumeSnapshot, error) {
+	listCtx := cli.ProxyMachinesContext(ctx, machines)
+	machineSnapshots, err := cli.Docker.ListVolumeSnapshots(listCtx, volumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []api.MachineVolumeSnapshot
+	for _, ms := range machineSnapshots {
+		if ms.Metadata == nil {
+			tui.PrintWarning("metadata is missing in response from unknown server")
+			continue
+		}
+
+		if ms.Metadata.Error != "" {
+			// The volume doesn't have to exist on every machine.
+			if ms.Metadata.Status != nil && codes.Code(ms.Metadata.Status.Code) == codes.NotFound {
+				continue
+			}
+			tui.PrintWarning(fmt.Sprintf("failed to list volume snapshots on machine '%s': %s",
+				ms.Metadata.MachineName, ms.Metadata.Error))
+			continue
+		}
+
+		for _, s := range ms.Snapshots {
+			snapshots = append(snapshots, api.MachineVolumeSnapshot{
+				MachineID:   ms.Metadata.MachineId,
+				MachineName: ms.Metadata.MachineName,
+				Snapshot:    s,
+			})
+		}
+	}
+
+	return snapshots, nil
+}
+
+// RestoreVolumeSnapshot replaces the data of the volume on the specified machine with the data from its snapshot.
+// The volume must not be used by running containers.
+func (cli *Client) RestoreVolumeSnapshot(ctx context.Context, machineNameOrID, volumeName, id string) error {
+	machine, err := cli.InspectMachine(ctx, machineNameOrID)
+	if err != nil {
+		return fmt.Errorf("inspect machine '%s': %w", machineNameOrID, err)
+	}
+	ctx = cli.ProxySingleMachineContext(ctx, machine.Machine.Id)
+
+	if err = cli.Docker.RestoreVolumeSnapshot(ctx, volumeName, id); err != nil {
+		if errdefs.IsNotFound(err) {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveVolumeSnapshot removes the snapshot of the volume on the specified machine.
+func (cli *Client) RemoveVolumeSnapshot(ctx context.Context, machineNameOrID, volumeName, id string) error {
+	machine, err := cli.InspectMachine(ctx, machineNameOrID)
+	if err != nil {
+		return fmt.Errorf("inspect machine '%s': %w", machineNameOrID, err)
+	}
+	ctx = cli.ProxySingleMachineContext(ctx, machine.Machine.Id)
+
+	if err = cli.Docker.RemoveVolumeSnapshot(ctx, volumeName, id); err != nil {
+		if errdefs.IsNotFound(err) {
+			return api.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Package cluster runs a real Uncloud cluster in Docker for integration tests of code that uses the Go SDK.
+//
+// Each machine is a Docker-in-Docker container running the uncloudd daemon and its own Docker daemon. All machines
+// in a cluster are connected to a dedicated Docker network that acts as their local network, so they set up the
+// WireGuard mesh and sync the cluster store as real machines would. Only Docker is required on the host. Running
+// daemons in the test process isn't supported because they need root privileges to manage WireGuard, iptables,
+// and Docker.
+//
+// Create a cluster in a test and use its clients:
+//
+//	func TestDeploy(t *testing.T) {
+//		c := cluster.New(t, cluster.Options{Machines: 2})
+//		cli := c.Client()
+//		// Deploy services with cli and assert the results.
+//	}
+//
+// The cluster is removed when the test finishes. The test is skipped if Docker isn't available.
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/netip"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/psviderski/uncloud/internal/ucind"
+	"github.com/psviderski/uncloud/pkg/client"
+)
+
+const (
+	// DefaultReadyTimeout is how long New waits for the cluster machines to be up and synced by default.
+	DefaultReadyTimeout = 90 * time.Second
+	// ImageEnv is the environment variable to override the default Docker image for the machines, for example,
+	// to test a locally built image with unreleased daemon changes.
+	ImageEnv = "UNCLOUD_TEST_IMAGE"
+)
+
+// Options configures a test cluster.
+type Options struct {
+	// Machines is the number of machines in the cluster. Default is 1.
+	Machines int
+	// Image is the Docker image for the machines. Default is the image from the UNCLOUD_TEST_IMAGE environment
+	// variable or the latest published ucind image.
+	Image string
+	// FaultInjection runs the machine daemons with --unsafe-fault-injection to allow injecting failures such as
+	// delayed API requests or failed image pulls.
+	FaultInjection bool
+	// ReadyTimeout is how long to wait for the cluster machines to be up and synced. Default is DefaultReadyTimeout.
+	ReadyTimeout time.Duration
+}
+
+// Cluster is a running test cluster.
+type Cluster struct {
+	// Name is the name of the cluster and its Docker network.
+	Name     string
+	Machines []*Machine
+}
+
+// Machine is a machine in the test cluster.
+type Machine struct {
+	ID   string
+	Name string
+	// ContainerName is the name of the Docker container running the machine.
+	ContainerName string
+	// APIAddress is the address of the machine API published on the host.
+	APIAddress netip.AddrPort
+
+	t      testing.TB
+	m      ucind.Machine
+	mu     sync.Mutex
+	client *client.Client
+}
+
+// New creates a cluster and waits until all its machines are up and synced. The cluster is removed when the test
+// and all its subtests finish. New skips the test if Docker isn't available and fails it if the cluster can't be
+// created.
+func New(t testing.TB, opts Options) *Cluster {
+	t.Helper()
+
+	if opts.Machines == 0 {
+		opts.Machines = 1
+	}
+	if opts.Image == "" {
+		opts.Image = os.Getenv(ImageEnv)
+	}
+	if opts.ReadyTimeout == 0 {
+		opts.ReadyTimeout = DefaultReadyTimeout
+	}
+
+	ctx := context.Background()
+	dockerCli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
+	if err != nil {
+		t.Skipf("Docker is not available: %v", err)
+	}
+	if _, err = dockerCli.Ping(ctx); err != nil {
+		dockerCli.Close()
+		t.Skipf("Docker is not available: %v", err)
+	}
+
+	p := ucind.NewProvisioner(dockerCli, nil)
+	name := clusterName(t.Name())
+	// Register the cleanup before creating the cluster to remove a partially created cluster on failure.
+	t.Cleanup(func() {
+		if err := p.RemoveCluster(context.Background(), name); err != nil {
+			t.Errorf("remove test cluster '%s': %v", name, err)
+		}
+		dockerCli.Close()
+	})
+
+	uc, err := p.CreateCluster(ctx, name, ucind.CreateClusterOptions{
+		Machines:       opts.Machines,
+		Image:          opts.Image,
+		FaultInjection: opts.FaultInjection,
+	})
+	if err != nil {
+		t.Fatalf("create test cluster '%s': %v", name, err)
+	}
+	if err = p.WaitClusterReady(ctx, uc, opts.ReadyTimeout); err != nil {
+		t.Fatalf("wait for test cluster '%s' to be ready: %v", name, err)
+	}
+
+	c := &Cluster{Name: name}
+	for _, m := range uc.Machines {
+		c.Machines = append(c.Machines, &Machine{
+			ID:            m.ID,
+			Name:          m.Name,
+			ContainerName: m.ContainerName,
+			APIAddress:    m.APIAddress,
+			t:             t,
+			m:             m,
+		})
+	}
+	// Cleanups run in the reverse order so the clients are closed before the cluster is removed.
+	t.Cleanup(func() {
+		for _, m := range c.Machines {
+			m.close()
+		}
+	})
+
+	return c
+}
+
+// Client returns a client connected to the first machine. Any machine can serve cluster requests.
+func (c *Cluster) Client() *client.Client {
+	return c.Machines[0].Client()
+}
+
+// Client returns a client connected to the machine API. The client is created on the first call and closed when
+// the test finishes.
+func (m *Machine) Client() *client.Client {
+	m.t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.client == nil {
+		cli, err := m.m.Connect(context.Background())
+		if err != nil {
+			m.t.Fatalf("connect to machine '%s': %v", m.Name, err)
+		}
+		m.client = cli
+	}
+	return m.client
+}
+
+func (m *Machine) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.client != nil {
+		m.client.Close()
+		m.client = nil
+	}
+}
+
+var invalidNameCharsRegexp = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// clusterName returns a unique cluster name derived from the test name that is valid for Docker networks
+// and containers.
+func clusterName(testName string) string {
+	name := invalidNameCharsRegexp.ReplaceAllString(strings.ToLower(testName), "-")
+	name = strings.Trim(name, "-.")
+	// Keep the container names that include the machine names reasonably short.
+	if len(name) > 40 {
+		name = strings.TrimRight(name[:40], "-.")
+	}
+
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return "uctest-" + name + "-" + hex.EncodeToString(suffix)
+}
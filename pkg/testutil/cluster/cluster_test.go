@@ -0,0 +1,49 @@
+package cluster
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		testName string
+		want     string
+	}{
+		{
+			name:     "simple",
+			testName: "TestDeploy",
+			want:     "uctest-testdeploy",
+		},
+		{
+			name:     "subtest",
+			testName: "TestDeploy/two replicas: web",
+			want:     "uctest-testdeploy-two-replicas-web",
+		},
+		{
+			name:     "long",
+			testName: "TestDeployServiceWithManyReplicasAcrossAllMachines/subtest",
+			want:     "uctest-testdeployservicewithmanyreplicasacrossa",
+		},
+	}
+
+	suffixRegexp := regexp.MustCompile(`^(.+)-[0-9a-f]{6}$`)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := clusterName(tt.testName)
+			m := suffixRegexp.FindStringSubmatch(got)
+			if assert.NotNil(t, m, "missing random suffix: %s", got) {
+				assert.Equal(t, tt.want, m[1])
+			}
+		})
+	}
+
+	assert.NotEqual(t, clusterName("TestDeploy"), clusterName("TestDeploy"))
+}
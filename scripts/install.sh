@@ -17,6 +17,16 @@ UNCLOUD_USER="uncloud"
 # Add the specified Linux user to group $UNCLOUD_USER to allow the user to run uncloud commands without sudo.
 UNCLOUD_GROUP_ADD_USER=${UNCLOUD_GROUP_ADD_USER:-}
 UNCLOUD_DATA_DIR=${UNCLOUD_DATA_DIR:-/var/lib/uncloud}
+# Resource profile of the machine. The 'small' profile tunes Docker for resource-constrained machines like Raspberry Pi.
+UNCLOUD_PROFILE=${UNCLOUD_PROFILE:-default}
+
+# Directory with an extracted air-gapped installation bundle created with 'uc bundle create'. If set, the binaries
+# and images are installed from the bundle instead of being downloaded from the internet.
+UNCLOUD_BUNDLE_DIR=${UNCLOUD_BUNDLE_DIR:-}
+
+# Configure Docker with user namespace remapping (userns-remap) so root in containers is an unprivileged user
+# on the machine. It's only applied when Docker is installed by this script.
+UNCLOUD_USERNS_REMAP=${UNCLOUD_USERNS_REMAP:-false}
 
 CORROSION_GITHUB_URL="https://github.com/psviderski/corrosion"
 CORROSION_VERSION=${CORROSION_VERSION:-v0.2.2}
@@ -31,6 +41,26 @@ DOCKER_DAEMON_CONFIG='{
   },
   "live-restore": true
 }'
+if [ "${UNCLOUD_PROFILE}" == "small" ]; then
+    # Rotate container logs early to save disk space and reduce writes to SD cards.
+    DOCKER_DAEMON_CONFIG='{
+  "features": {
+    "containerd-snapshotter": true
+  },
+  "live-restore": true,
+  "log-opts": {
+    "max-size": "10m",
+    "max-file": "2"
+  }
+}'
+fi
+if [ "${UNCLOUD_USERNS_REMAP}" == "true" ]; then
+    # Append the userns-remap option to the JSON config by replacing its closing brace.
+    DOCKER_DAEMON_CONFIG="${DOCKER_DAEMON_CONFIG%$'\n'\}},
+  \"userns-remap\": \"default\"
+}"
+fi
+USERNS_REMAP_ENABLED=false
 
 log() {
     echo -e "\033[1;32m$1\033[0m"
@@ -67,6 +97,35 @@ Uncloud supports only systemd-based Linux systems for now."
   fi
 }
 
+verify_bundle() {
+    if [ -z "${UNCLOUD_BUNDLE_DIR}" ]; then
+        return
+    fi
+
+    local file
+    for file in bundle.json uncloudd.tar.gz corrosion.tar.gz uninstall.sh images.tar; do
+        if [ ! -f "${UNCLOUD_BUNDLE_DIR}/${file}" ]; then
+            error "File '${file}' not found in bundle directory ${UNCLOUD_BUNDLE_DIR}. \
+Make sure it contains the extracted bundle created with 'uc bundle create'."
+        fi
+    done
+
+    local file_arch
+    case $(uname -m) in
+        x86_64)
+            file_arch="amd64"
+            ;;
+        aarch64)
+            file_arch="arm64"
+            ;;
+    esac
+    if ! grep -q "\"arch\": \"${file_arch}\"" "${UNCLOUD_BUNDLE_DIR}/bundle.json"; then
+        error "The bundle in ${UNCLOUD_BUNDLE_DIR} is not built for the machine architecture (${file_arch}). \
+Create a bundle with 'uc bundle create --arch ${file_arch}'."
+    fi
+    log "✓ Installing from bundle: ${UNCLOUD_BUNDLE_DIR}"
+}
+
 install_docker() {
     if command_exists dockerd; then
         log "✓ Docker is already installed."
@@ -84,10 +143,21 @@ install_docker() {
         if [[ "$driver_status" == *"io.containerd.snapshotter"* ]]; then
             CONTAINERD_IMAGE_STORE_ENABLED="true"
         fi
+        # Check if the installed Docker is configured with user namespace remapping.
+        local security_options
+        security_options=$(docker info -f '{{ .SecurityOptions }}' 2>/dev/null)
+        if [[ "$security_options" == *"name=userns"* ]]; then
+            USERNS_REMAP_ENABLED="true"
+        fi
 
         return
     fi
 
+    if [ -n "${UNCLOUD_BUNDLE_DIR}" ]; then
+        error "Docker is not installed. It can't be installed from a bundle without internet access. \
+Install Docker on the machine using the packages for your Linux distribution and try again."
+    fi
+
     log "⏳ Installing Docker..."
     curl -fsSL https://get.docker.com | sh
 
@@ -109,6 +179,18 @@ install_docker() {
     log "✓ Docker installed and configured successfully."
 }
 
+load_bundle_images() {
+    if [ -z "${UNCLOUD_BUNDLE_DIR}" ] || [[ "${INSTALL_ONLY}" == "true" ]]; then
+        return
+    fi
+
+    log "⏳ Loading images from bundle into Docker..."
+    if ! docker load -i "${UNCLOUD_BUNDLE_DIR}/images.tar"; then
+        error "Failed to load images from bundle."
+    fi
+    log "✓ Images loaded."
+}
+
 create_uncloud_user_and_group() {
     if id "${UNCLOUD_USER}" &> /dev/null; then
         log "✓ Linux user '${UNCLOUD_USER}' already exists."
@@ -176,9 +258,19 @@ install_uncloud_binaries() {
     local uncloudd_download_path="${tmp_dir}/uncloudd.tar.gz"
     local uninstall_download_path="${tmp_dir}/uninstall.sh"
 
-    log "⏳ Downloading uncloudd binary: ${uncloudd_url}"
-    if ! curl -fsSL -o "${uncloudd_download_path}" "${uncloudd_url}"; then
-        error "Failed to download uncloudd binary."
+    if [ -n "${UNCLOUD_BUNDLE_DIR}" ]; then
+        cp "${UNCLOUD_BUNDLE_DIR}/uncloudd.tar.gz" "${uncloudd_download_path}"
+        cp "${UNCLOUD_BUNDLE_DIR}/uninstall.sh" "${uninstall_download_path}"
+    else
+        log "⏳ Downloading uncloudd binary: ${uncloudd_url}"
+        if ! curl -fsSL -o "${uncloudd_download_path}" "${uncloudd_url}"; then
+            error "Failed to download uncloudd binary."
+        fi
+
+        log "⏳ Downloading uninstall script: ${uninstall_url}"
+        if ! curl -fsSL -o "${uninstall_download_path}" "${uninstall_url}"; then
+            error "Failed to download uninstall script."
+        fi
     fi
     tar -xf "${uncloudd_download_path}" --directory "${tmp_dir}"
     if ! install "${tmp_dir}/uncloudd" "${uncloudd_install_path}"; then
@@ -186,10 +278,6 @@ install_uncloud_binaries() {
     fi
     log "✓ uncloudd binary installed: ${uncloudd_install_path}"
 
-    log "⏳ Downloading uninstall script: ${uninstall_url}"
-    if ! curl -fsSL -o "${uninstall_download_path}" "${uninstall_url}"; then
-        error "Failed to download uninstall script."
-    fi
     local uninstall_install_path="${INSTALL_BIN_DIR}/uncloud-uninstall"
     if ! install "${uninstall_download_path}" "${uninstall_install_path}"; then
         error "Failed to install uninstall.sh script to ${uninstall_install_path}"
@@ -263,9 +351,13 @@ install_corrosion() {
     fi
     local corrosion_download_path="${tmp_dir}/corrosion.tar.gz"
 
-    log "⏳ Downloading uncloud-corrosion binary: ${corrosion_url}"
-    if ! curl -fsSL -o "${corrosion_download_path}" "${corrosion_url}"; then
-        error "Failed to download uncloud-corrosion binary."
+    if [ -n "${UNCLOUD_BUNDLE_DIR}" ]; then
+        cp "${UNCLOUD_BUNDLE_DIR}/corrosion.tar.gz" "${corrosion_download_path}"
+    else
+        log "⏳ Downloading uncloud-corrosion binary: ${corrosion_url}"
+        if ! curl -fsSL -o "${corrosion_download_path}" "${corrosion_url}"; then
+            error "Failed to download uncloud-corrosion binary."
+        fi
     fi
     tar -xf "${corrosion_download_path}" -C "${tmp_dir}"
     if ! install "${tmp_dir}/corrosion" "${corrosion_install_path}"; then
@@ -324,7 +416,9 @@ if [ "$EUID" -ne 0 ]; then
 fi
 
 verify_system
+verify_bundle
 install_docker
+load_bundle_images
 create_uncloud_user_and_group
 install_uncloud_binaries
 install_uncloud_systemd
@@ -362,4 +456,27 @@ if [ "$DOCKER_ALREADY_INSTALLED" = "true" ] && [ "$CONTAINERD_IMAGE_STORE_ENABLE
     echo ""
 fi
 
+# Show warning if user namespace remapping was requested but the already installed Docker isn't configured with it.
+if [ "$DOCKER_ALREADY_INSTALLED" = "true" ] && [ "$UNCLOUD_USERNS_REMAP" = "true" ] \
+    && [ "$USERNS_REMAP_ENABLED" = "false" ]; then
+    echo ""
+    warning "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+    warning "⚠️  IMPORTANT: User namespace remapping configuration"
+    warning "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+    echo ""
+    warning "User namespace remapping is enabled for the cluster but Docker was already installed"
+    warning "on the machine without it. Containers on this machine run without remapping."
+    echo ""
+    warning "See https://docs.docker.com/engine/security/userns-remap/ for more details."
+    echo ""
+    warning "To enable it, add the following option to ${DOCKER_DAEMON_CONFIG_FILE} and restart Docker:"
+    echo ""
+    echo '  "userns-remap": "default"'
+    echo ""
+    warning "WARNING: Docker stores images and containers separately for the remapped user so you"
+    warning "temporarily lose the existing ones until you turn the option off."
+    warning "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+    echo ""
+fi
+
 log "✓ Uncloud installed on the machine successfully! 🎉"
@@ -6,3 +6,6 @@ import _ "embed"
 
 //go:embed install.sh
 var InstallScript string
+
+//go:embed uninstall.sh
+var UninstallScript string